@@ -0,0 +1,264 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rai/clean-modularmonolith-go/internal/bootstrap/seed"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/app"
+	"github.com/rai/clean-modularmonolith-go/modules/orders"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/projection"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/users"
+)
+
+// userCreate creates a user and prints its ID, e.g. for seeding in CI.
+func userCreate(ctx context.Context, a *app.App, args []string) error {
+	fs := flag.NewFlagSet("user create", flag.ContinueOnError)
+	email := fs.String("email", "", "email address (required)")
+	firstName := fs.String("first-name", "", "first name")
+	lastName := fs.String("last-name", "", "last name")
+	id := fs.String("id", "", "user ID (default: a new one)")
+	if _, err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	m, err := app.Lookup[users.Module](a, "users")
+	if err != nil {
+		return err
+	}
+	userID, err := m.CreateUser(ctx, *id, *email, *firstName, *lastName)
+	if err != nil {
+		return err
+	}
+	fmt.Println(userID)
+	return nil
+}
+
+// userDelete soft-deletes a user; other modules react to UserDeleted as usual.
+func userDelete(ctx context.Context, a *app.App, args []string) error {
+	fs := flag.NewFlagSet("user delete", flag.ContinueOnError)
+	rest, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	m, err := app.Lookup[users.Module](a, "users")
+	if err != nil {
+		return err
+	}
+	if err := m.DeleteUser(ctx, rest[0]); err != nil {
+		return err
+	}
+	fmt.Printf("user %s deleted\n", rest[0])
+	return nil
+}
+
+// userRestore reactivates a soft-deleted user.
+func userRestore(ctx context.Context, a *app.App, args []string) error {
+	fs := flag.NewFlagSet("user restore", flag.ContinueOnError)
+	rest, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	m, err := app.Lookup[users.Module](a, "users")
+	if err != nil {
+		return err
+	}
+	if err := m.RestoreUser(ctx, rest[0]); err != nil {
+		return err
+	}
+	fmt.Printf("user %s restored\n", rest[0])
+	return nil
+}
+
+// userRepairStatus sets the status of a user whose stored status is corrupt,
+// as named by the corrupt row error reading the user fails with.
+func userRepairStatus(ctx context.Context, a *app.App, args []string) error {
+	fs := flag.NewFlagSet("user repair-status", flag.ContinueOnError)
+	rest, err := parseFlags(fs, args, 2)
+	if err != nil {
+		return err
+	}
+
+	m, err := app.Lookup[users.Module](a, "users")
+	if err != nil {
+		return err
+	}
+	if err := m.RepairUserStatus(ctx, rest[0], rest[1]); err != nil {
+		return err
+	}
+	fmt.Printf("user %s repaired to status %s\n", rest[0], rest[1])
+	return nil
+}
+
+// userImport creates the users of a CSV file with an email, first_name and
+// last_name header, -batch rows at a time, printing the ID of each user
+// created and, on stderr, the line of each user that was not.
+func userImport(ctx context.Context, a *app.App, args []string) error {
+	fs := flag.NewFlagSet("user import", flag.ContinueOnError)
+	batch := fs.Int("batch", 500, "users imported per batch write")
+	rest, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	if *batch <= 0 {
+		return fmt.Errorf("-batch must be positive, got %d", *batch)
+	}
+	f, err := os.Open(rest[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	header, err := r.Read()
+	if err != nil {
+		return fmt.Errorf("reading the header of %s: %w", rest[0], err)
+	}
+	if !slices.Equal(header, []string{"email", "first_name", "last_name"}) {
+		return fmt.Errorf("%s: header is %q, want email,first_name,last_name", rest[0], strings.Join(header, ","))
+	}
+
+	m, err := app.Lookup[users.Module](a, "users")
+	if err != nil {
+		return err
+	}
+	imported, failed := 0, 0
+	importBatch := func(rows []users.ImportUser, lines []int) error {
+		userIDs, failures, err := m.ImportUsers(ctx, rows)
+		for _, id := range userIDs {
+			fmt.Println(id)
+		}
+		for _, fail := range failures {
+			fmt.Fprintf(os.Stderr, "%s:%d: %s: %v\n", rest[0], lines[fail.Index], fail.Email, fail.Err)
+		}
+		imported, failed = imported+len(userIDs), failed+len(failures)
+		return err
+	}
+
+	var rows []users.ImportUser
+	var lines []int
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := r.FieldPos(0)
+		rows = append(rows, users.ImportUser{Email: record[0], FirstName: record[1], LastName: record[2]})
+		lines = append(lines, line)
+		if len(rows) == *batch {
+			if err := importBatch(rows, lines); err != nil {
+				return err
+			}
+			rows, lines = rows[:0], lines[:0]
+		}
+	}
+	if len(rows) > 0 {
+		if err := importBatch(rows, lines); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "%d user(s) imported, %d failed\n", imported, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d user(s) not imported", failed)
+	}
+	return nil
+}
+
+// orderCancel cancels an order as an admin, attributed to -actor.
+func orderCancel(ctx context.Context, a *app.App, args []string) error {
+	fs := flag.NewFlagSet("order cancel", flag.ContinueOnError)
+	reason := fs.String("reason", "", "cancellation reason")
+	actorName := fs.String("actor", "", "admin recorded as cancelling the order (default cli:$USER)")
+	rest, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	m, err := app.Lookup[orders.Module](a, "orders")
+	if err != nil {
+		return err
+	}
+	if err := m.CancelOrder(ctx, rest[0], *reason, actor(*actorName)); err != nil {
+		return err
+	}
+	fmt.Printf("order %s cancelled\n", rest[0])
+	return nil
+}
+
+// projectionRebuild rebuilds a projection, e.g. "orders.OrderSummary", in
+// the module that owns it. Projections are kept per tenant, so only the
+// rows of -tenant are rebuilt.
+func projectionRebuild(ctx context.Context, a *app.App, args []string) error {
+	fs := flag.NewFlagSet("projection rebuild", flag.ContinueOnError)
+	restart := fs.Bool("restart", false, "start over instead of resuming an interrupted rebuild")
+	tenant := fs.String("tenant", "", "tenant whose projection is rebuilt (default: the default tenant)")
+	rest, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	v := requestcontext.FromContext(ctx)
+	v.TenantID = *tenant
+	ctx = requestcontext.With(ctx, v)
+
+	m, err := app.Lookup[projection.Rebuilder](a, projection.Module(rest[0]))
+	if err != nil {
+		return err
+	}
+	result, err := m.RebuildProjection(ctx, projection.RebuildCommand{Projection: rest[0], Restart: *restart})
+	if err != nil {
+		return err
+	}
+	if result.Resumed {
+		fmt.Printf("projection %s rebuilt (resumed) in %d batches\n", rest[0], result.Batches)
+	} else {
+		fmt.Printf("projection %s rebuilt in %d batches\n", rest[0], result.Batches)
+	}
+	return nil
+}
+
+// seedFixtures loads a fixtures file and prints the ID of every seeded entity.
+func seedFixtures(ctx context.Context, a *app.App, args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	rest, err := parseFlags(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	fixtures, err := seed.Load(rest[0])
+	if err != nil {
+		return err
+	}
+
+	usersModule, err := app.Lookup[users.Module](a, "users")
+	if err != nil {
+		return err
+	}
+	ordersModule, err := app.Lookup[orders.Module](a, "orders")
+	if err != nil {
+		return err
+	}
+	if err := seed.NewSeeder(usersModule, ordersModule, slog.Default()).Seed(ctx, fixtures); err != nil {
+		return err
+	}
+
+	for _, u := range fixtures.Users {
+		fmt.Printf("user\t%s\t%s\n", u.Key, seed.UserID(u.Key))
+	}
+	for _, o := range fixtures.Orders {
+		fmt.Printf("order\t%s\t%s\n", o.Key, seed.OrderID(o.Key))
+	}
+	return nil
+}
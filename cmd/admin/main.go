@@ -0,0 +1,145 @@
+// Package main is the admin CLI for operational tasks. It builds the same
+// modules as cmd/server and runs their commands directly, without HTTP, so
+// the audit log, events and their handlers behave as for an API call:
+//
+//	admin user create -email ada@example.com -first-name Ada -last-name Lovelace
+//	admin user delete <user-id>
+//	admin user repair-status <user-id> <status>
+//	admin user import [-batch n] users.csv
+//	admin order cancel [-reason text] [-actor name] <order-id>
+//	admin projection rebuild [-restart] [-tenant id] orders.OrderSummary
+//	admin seed fixtures/demo.yaml
+//
+// Logs go to stdout like the server's and default to LOG_LEVEL=warn.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/rai/clean-modularmonolith-go/internal/bootstrap"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/app"
+)
+
+// errUsage reports a malformed command line; the usage has been printed.
+var errUsage = errors.New("invalid arguments")
+
+// subcommand is one "<group> <action>" (or single word) of the CLI. A subcommand without run
+// is not supported by this deployment, for the reason given in usage.
+type subcommand struct {
+	usage string
+	run   func(ctx context.Context, a *app.App, args []string) error
+}
+
+var subcommands = map[string]subcommand{
+	"user create":        {"-email <email> [-first-name <name>] [-last-name <name>] [-id <user-id>]", userCreate},
+	"user delete":        {"<user-id>", userDelete},
+	"user restore":       {"<user-id>", userRestore},
+	"user repair-status": {"<user-id> <active|inactive|deleted>", userRepairStatus},
+	"user import":        {"[-batch <n>] <users.csv>", userImport},
+	"order cancel":       {"[-reason <text>] [-actor <name>] <order-id>", orderCancel},
+	"order backfill":     {"(not supported: orders are only created by their users, there is nothing to import them from)", nil},
+	"projection rebuild": {"[-restart] [-tenant id] <projection>", projectionRebuild},
+	"events replay":      {"(not supported: the audit log keeps events for reading, not replaying)", nil},
+	"outbox flush":       {"(not supported: post-commit events are dispatched in-process, without an outbox)", nil},
+	"seed":               {"<fixtures.yaml|fixtures.json>", seedFixtures},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	name, args := os.Args[1], os.Args[2:]
+	if _, ok := subcommands[name]; !ok && len(args) > 0 {
+		name, args = name+" "+args[0], args[1:]
+	}
+	cmd, ok := subcommands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", name)
+		usage()
+		os.Exit(2)
+	}
+	if cmd.run == nil {
+		fmt.Fprintf(os.Stderr, "%s %s\n", name, cmd.usage)
+		os.Exit(1)
+	}
+
+	// Cancelled on SIGINT/SIGTERM; the command's transaction is rolled back
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	if os.Getenv("LOG_LEVEL") == "" {
+		os.Setenv("LOG_LEVEL", "warn")
+	}
+	platform, err := bootstrap.Setup(ctx, "clean-modularmonolith-admin")
+	if err != nil {
+		slog.Error("failed to set up platform", slog.Any("error", err))
+		os.Exit(1)
+	}
+	application, err := platform.Builder().Build()
+	if err != nil {
+		platform.Logger.Error("failed to build application", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	err = cmd.run(ctx, application, args)
+
+	// Wait for the event handlers the command triggered, then release the platform
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), app.ShutdownTimeout)
+	defer cancel()
+	if serr := application.Shutdown(shutdownCtx); serr != nil {
+		platform.Logger.Error("shutdown incomplete", slog.Any("error", serr))
+	}
+
+	switch {
+	case errors.Is(err, errUsage):
+		os.Exit(2)
+	case err != nil:
+		fmt.Fprintf(os.Stderr, "%s: %v\n", name, err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: admin <command> [arguments]")
+	fmt.Fprintln(os.Stderr, "\ncommands:")
+	names := make([]string, 0, len(subcommands))
+	for name := range subcommands {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %s %s\n", name, subcommands[name].usage)
+	}
+}
+
+// parseFlags parses args with fs and returns the n positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string, n int) ([]string, error) {
+	fs.SetOutput(os.Stderr)
+	if err := fs.Parse(args); err != nil {
+		return nil, errUsage
+	}
+	if fs.NArg() != n {
+		fmt.Fprintf(os.Stderr, "expected %d argument(s), got %d\n", n, fs.NArg())
+		fs.Usage()
+		return nil, errUsage
+	}
+	return fs.Args(), nil
+}
+
+// actor names the operator in the audit trail: -actor, or the login name.
+func actor(flagValue string) string {
+	if flagValue = strings.TrimSpace(flagValue); flagValue != "" {
+		return flagValue
+	}
+	return "cli:" + bootstrap.Getenv("USER", "unknown")
+}
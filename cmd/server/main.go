@@ -1,195 +1,61 @@
-// Package main is the entry point for the modular monolith application.
-// It wires together all modules and starts the HTTP server.
+// Package main is the entry point of the API server. It sets up the
+// platform, the modules and the platform endpoints (see internal/bootstrap)
+// and serves HTTP. The modules' background jobs run here too unless
+// BACKGROUND_JOBS=false leaves them to cmd/worker.
 package main
 
 import (
 	"context"
 	"log/slog"
-	"net/http"
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
-	"time"
 
-	"github.com/rai/clean-modularmonolith-go/internal/platform/elasticsearch"
-	"github.com/rai/clean-modularmonolith-go/internal/platform/eventbus"
+	"github.com/rai/clean-modularmonolith-go/internal/bootstrap"
 	"github.com/rai/clean-modularmonolith-go/internal/platform/httpserver"
-	"github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
-	"github.com/rai/clean-modularmonolith-go/modules/notifications"
-	"github.com/rai/clean-modularmonolith-go/modules/orders"
-	orderspersistence "github.com/rai/clean-modularmonolith-go/modules/orders/infrastructure/persistence"
-	"github.com/rai/clean-modularmonolith-go/modules/users"
-	userspersistence "github.com/rai/clean-modularmonolith-go/modules/users/infrastructure/persistence"
 )
 
 func main() {
-	ctx := context.Background()
+	// Cancelled on SIGINT/SIGTERM, which starts the graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	// Initialize logger
-	slogOptions := &slog.HandlerOptions{
-		Level: slog.LevelDebug,
-	}
-	slogJsonHandler := slog.NewJSONHandler(os.Stdout, slogOptions)
-	logger := slog.New(slogJsonHandler)
-	slog.SetDefault(logger)
-
-	logger.Info("starting modular monolith application")
-
-	// Initialize Spanner client
-	spannerCfg := spanner.Config{
-		ProjectID:  getEnv("SPANNER_PROJECT_ID", "local-project"),
-		InstanceID: getEnv("SPANNER_INSTANCE_ID", "local-instance"),
-		DatabaseID: getEnv("SPANNER_DATABASE_ID", "app-db"),
-	}
-	spannerClient, err := spanner.NewClient(ctx, spannerCfg)
+	platform, err := bootstrap.Setup(ctx, "clean-modularmonolith")
 	if err != nil {
-		logger.Error("failed to create spanner client", slog.Any("error", err))
+		slog.Error("failed to set up platform", slog.Any("error", err))
 		os.Exit(1)
 	}
-	defer spannerClient.Close()
-
-	// Initialize transaction scopes
-	txScope := spanner.NewReadWriteTransactionScope(spannerClient, logger)
-	roTxScope := spanner.NewReadOnlyTransactionScope(spannerClient, logger)
-
-	// Initialize event bus (for inter-module communication)
-	// Implements both events.Publisher and events.Subscriber
-	eventBus := eventbus.NewEventBus(logger)
-
-	// Initialize repositories
-	usersRepo := userspersistence.NewSpannerRepository(spannerClient, logger)
-	ordersRepo := orderspersistence.NewSpannerRepository(spannerClient, logger)
+	logger := platform.Logger
+	logger.Info("starting modular monolith application")
 
-	// Initialize Elasticsearch client
-	esClient, err := newElasticsearchClient(logger)
+	application, handler, err := platform.API()
 	if err != nil {
-		logger.Error("failed to create elasticsearch client", slog.Any("error", err))
+		logger.Error("failed to build application", slog.Any("error", err))
 		os.Exit(1)
 	}
 
-	// Initialize modules
-	// Each module subscribes to events it cares about internally
-	usersCfg := users.Config{
-		Repository:                usersRepo,
-		ReadWriteTransactionScope: txScope,
-		ReadOnlyTransactionScope:  roTxScope,
-		Publisher:                 eventBus,
-		PostCommitPublisher:       eventBus,
-		Subscriber:                eventBus,
-		PostCommitSubscriber:      eventBus,
-		ESClient:                  esClient,
-		Logger:                    logger,
-	}
-	usersModule, usersCleanup := users.New(usersCfg)
-	if usersCleanup != nil {
-		defer usersCleanup()
-	}
-
-	ordersCfg := orders.Config{
-		Repository:          ordersRepo,
-		TransactionScope:    txScope,
-		Publisher:           eventBus,
-		PostCommitPublisher: eventBus,
-		Subscriber:          eventBus,
-		Logger:              logger,
-	}
-	ordersModule := orders.New(ordersCfg)
-
-	// Notifications module subscribes to events but runs outside transactions
-	// (external side effects like email should not be in DB transactions)
-	notificationCfg := notifications.Config{
-		PostCommitEventSubscriber: eventBus,
-		Logger:                    logger,
-	}
-	_, notificationsCleanup := notifications.New(notificationCfg)
-	defer notificationsCleanup()
-
-	// Log all event subscriptions after module initialization
-	eventBus.LogSubscriptions()
-
-	// Build HTTP router
-	router := buildRouter(usersModule, ordersModule)
-
-	// Apply middleware
-	handler := httpserver.Middleware(router, httpserver.Recovery(logger), httpserver.Logging(logger), httpserver.CORS([]string{"*"}))
-
-	// Create and start server
 	cfg := httpserver.DefaultConfig()
-	server := httpserver.New(cfg, handler, logger)
-
-	// Graceful shutdown
-	go func() {
-		if err := server.Start(); err != nil {
-			logger.Error("server error", slog.Any("error", err))
-			os.Exit(1)
-		}
-	}()
+	servers := []*httpserver.Server{httpserver.New(cfg, handler, logger)}
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	logger.Info("shutting down server...")
-
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	if err := server.Shutdown(ctx); err != nil {
-		logger.Error("server shutdown error", slog.Any("error", err))
+	// Debug server exposes pprof and runtime stats on a separate, private listener
+	debugServer, err := bootstrap.DebugServer(logger)
+	if err != nil {
+		logger.Error("invalid debug server configuration", slog.Any("error", err))
+		os.Exit(1)
 	}
-
-	logger.Info("server stopped")
-}
-
-// buildRouter creates the main HTTP router with all module handlers.
-func buildRouter(usersModule users.Module, ordersModule orders.Module) http.Handler {
-	mux := http.NewServeMux()
-
-	// Health check endpoint
-	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"status":"ok"}`))
-	})
-
-	// API version prefix
-	mux.HandleFunc("GET /api/v1/", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"version":"1.0.0"}`))
-	})
-
-	// Each module registers its own routes (same pattern as event subscriptions)
-	usersModule.RegisterRoutes(mux)
-	ordersModule.RegisterRoutes(mux)
-
-	return mux
-}
-
-// newElasticsearchClient creates an Elasticsearch client from environment config.
-func newElasticsearchClient(logger *slog.Logger) (elasticsearch.Client, error) {
-	addrs := getEnv("ELASTICSEARCH_ADDRESSES", "http://localhost:9200")
-
-	cfg := elasticsearch.Config{
-		Addresses: strings.Split(addrs, ","),
-		Username:  getEnv("ELASTICSEARCH_USERNAME", ""),
-		Password:  getEnv("ELASTICSEARCH_PASSWORD", ""),
-		APIKey:    getEnv("ELASTICSEARCH_API_KEY", ""),
+	if debugServer != nil {
+		servers = append(servers, debugServer)
 	}
 
-	client, err := elasticsearch.NewElasticsearchClient(cfg)
-	if err != nil {
-		return nil, err
+	// Serve until interrupted, then shut down gracefully. With a worker
+	// deployed, the background jobs run there only.
+	run := application.Run
+	if bootstrap.Getenv("BACKGROUND_JOBS", "true") == "false" {
+		logger.Info("background jobs disabled, leaving them to the worker")
+		run = application.Serve
 	}
-
-	logger.Info("elasticsearch client initialized", slog.String("addresses", addrs))
-	return client, nil
-}
-
-// getEnv returns the value of an environment variable or a default value.
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+	if err := run(ctx, servers...); err != nil {
+		logger.Error("server error", slog.Any("error", err))
+		os.Exit(1)
 	}
-	return defaultValue
 }
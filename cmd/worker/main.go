@@ -0,0 +1,69 @@
+// Package main is the entry point of the background worker. It runs the same
+// modules as cmd/server but serves no API: it starts the modules' background
+// jobs (draft expiry, notification and webhook retries) and handles the
+// events they publish, so that it can be scaled independently of the API
+// servers, which then run with BACKGROUND_JOBS=false.
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/rai/clean-modularmonolith-go/internal/bootstrap"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/httpserver"
+)
+
+func main() {
+	// Cancelled on SIGINT/SIGTERM, which starts the graceful shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	platform, err := bootstrap.Setup(ctx, "clean-modularmonolith-worker")
+	if err != nil {
+		slog.Error("failed to set up platform", slog.Any("error", err))
+		os.Exit(1)
+	}
+	logger := platform.Logger
+	logger.Info("starting modular monolith worker")
+
+	application, err := platform.Builder().Build()
+	if err != nil {
+		logger.Error("failed to build application", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	// Only probes and metrics are served, on WORKER_PORT (default 8081)
+	port, err := strconv.Atoi(bootstrap.Getenv("WORKER_PORT", "8081"))
+	if err != nil {
+		logger.Error("invalid worker port", slog.Any("error", err))
+		os.Exit(1)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("GET /health", application.HealthHandler())
+	mux.Handle("GET /metrics", platform.Metrics.Handler())
+
+	cfg := httpserver.DefaultConfig()
+	cfg.Port = port
+	servers := []*httpserver.Server{httpserver.New(cfg, mux, logger)}
+
+	// Debug server exposes pprof and runtime stats on a separate, private listener
+	debugServer, err := bootstrap.DebugServer(logger)
+	if err != nil {
+		logger.Error("invalid debug server configuration", slog.Any("error", err))
+		os.Exit(1)
+	}
+	if debugServer != nil {
+		servers = append(servers, debugServer)
+	}
+
+	// Run the background jobs until interrupted, then shut down gracefully
+	if err := application.Run(ctx, servers...); err != nil {
+		logger.Error("worker error", slog.Any("error", err))
+		os.Exit(1)
+	}
+}
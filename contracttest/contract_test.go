@@ -0,0 +1,67 @@
+package contracttest
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+const (
+	userID  = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	orderID = "a3bb189e-8bf9-3888-9912-ace4e6543002"
+)
+
+// verify checks that sent, built by the publisher with every field the
+// consumer reads set, has the event type the consumer subscribes to, carries
+// the fields under the names the consumer expects, and survives a round trip
+// through the serialized envelope, and its CloudEvents form, unchanged.
+func verify[E events.Event](t *testing.T, sent E, eventType events.EventType, fields ...string) {
+	t.Helper()
+
+	if sent.EventType() != eventType {
+		t.Errorf("event type = %s, consumer subscribes to %s", sent.EventType(), eventType)
+	}
+
+	data, err := events.Marshal(sent)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var env struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &env); err != nil {
+		t.Fatalf("decoding envelope: %v", err)
+	}
+	for _, field := range fields {
+		if v, ok := env.Data[field]; !ok || string(v) == "null" {
+			t.Errorf("data has no field %q, which the consumer reads; data = %s", field, data)
+		}
+	}
+
+	received := new(E)
+	target, ok := any(received).(events.Event)
+	if !ok {
+		t.Fatalf("%T is not an event", received)
+	}
+	if err := events.Unmarshal(data, target); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(*received, sent) {
+		t.Errorf("round trip = %+v, want %+v", *received, sent)
+	}
+
+	data, err = events.MarshalCloudEvent(sent)
+	if err != nil {
+		t.Fatalf("MarshalCloudEvent() error = %v", err)
+	}
+	received = new(E)
+	if err := events.UnmarshalCloudEvent(data, any(received).(events.Event)); err != nil {
+		t.Fatalf("UnmarshalCloudEvent() error = %v", err)
+	}
+	if !reflect.DeepEqual(*received, sent) {
+		t.Errorf("CloudEvents round trip = %+v, want %+v", *received, sent)
+	}
+}
@@ -0,0 +1,10 @@
+// Package contracttest holds the consumer-driven contract tests for the
+// events modules exchange. Each consuming module states, in its own test
+// file, the event types it subscribes to and the fields it reads; the tests
+// check them against the publishers' event structs — at compile time through
+// the struct fields, and at test time through a JSON round trip of the
+// serialized envelope (events.Marshal), so that renaming a field or its JSON
+// tag fails here rather than in a consumer.
+//
+// Add a contract when a module starts consuming another module's event.
+package contracttest
@@ -0,0 +1,3 @@
+module github.com/rai/clean-modularmonolith-go/contracttest
+
+go 1.26.0
@@ -0,0 +1,26 @@
+package contracttest
+
+import (
+	"testing"
+
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// The integrations module books confirmed orders in the ERP
+// (OrderConfirmedHandler), translating the whole order into a sales order.
+
+func TestIntegrations_OrderConfirmed(t *testing.T) {
+	verify(t, orderevents.OrderConfirmedEvent{
+		BaseEvent:      events.NewBaseEvent(orderevents.OrderConfirmedEventType),
+		OrderID:        orderID,
+		UserID:         userID,
+		TotalAmount:    990,
+		DiscountAmount: 100,
+		TaxAmount:      90,
+		Currency:       "USD",
+		Lines: []orderevents.ConfirmedLine{
+			{ProductID: "sku-1", ProductName: "Mug", Quantity: 2, UnitPrice: 500},
+		},
+	}, "orders.OrderConfirmed", "order_id", "user_id", "total_amount", "discount_amount", "tax_amount", "currency", "lines", "shipping_address")
+}
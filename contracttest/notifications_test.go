@@ -0,0 +1,65 @@
+package contracttest
+
+import (
+	"testing"
+
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+)
+
+// The notifications module builds its messages from the events in
+// NotificationMappings and keeps recipient preferences from
+// UserPreferencesUpdated.
+
+func TestNotifications_UserCreated(t *testing.T) {
+	verify(t, userevents.UserCreatedEvent{
+		BaseEvent: events.NewBaseEvent(userevents.UserCreatedEventType),
+		UserID:    userID,
+		FirstName: "Alice",
+	}, "users.UserCreated", "user_id", "first_name")
+}
+
+func TestNotifications_EmailChangeRequested(t *testing.T) {
+	verify(t, userevents.EmailChangeRequestedEvent{
+		BaseEvent: events.NewBaseEvent(userevents.EmailChangeRequestedEventType),
+		UserID:    userID,
+		NewEmail:  "alice@example.org",
+		Token:     "a.b.c",
+	}, "users.EmailChangeRequested", "user_id", "new_email", "token")
+}
+
+func TestNotifications_PasswordResetRequested(t *testing.T) {
+	verify(t, userevents.PasswordResetRequestedEvent{
+		BaseEvent: events.NewBaseEvent(userevents.PasswordResetRequestedEventType),
+		UserID:    userID,
+		Token:     "a.b.c",
+	}, "users.PasswordResetRequested", "user_id", "token")
+}
+
+func TestNotifications_UserPreferencesUpdated(t *testing.T) {
+	verify(t, userevents.UserPreferencesUpdatedEvent{
+		BaseEvent:     events.NewBaseEvent(userevents.UserPreferencesUpdatedEventType),
+		UserID:        userID,
+		MutedChannels: []string{"sms"},
+	}, "users.UserPreferencesUpdated", "user_id", "muted_channels")
+}
+
+func TestNotifications_OrderSubmitted(t *testing.T) {
+	verify(t, orderevents.OrderSubmittedEvent{
+		BaseEvent:   events.NewBaseEvent(orderevents.OrderSubmittedEventType),
+		OrderID:     orderID,
+		UserID:      userID,
+		TotalAmount: 2500,
+		Currency:    "EUR",
+	}, "orders.OrderSubmitted", "OrderID", "UserID", "TotalAmount", "Currency")
+}
+
+func TestNotifications_OrderCancelled(t *testing.T) {
+	verify(t, orderevents.OrderCancelledEvent{
+		BaseEvent: events.NewBaseEvent(orderevents.OrderCancelledEventType),
+		OrderID:   orderID,
+		UserID:    userID,
+		Reason:    "changed my mind",
+	}, "orders.OrderCancelled", "order_id", "user_id", "reason")
+}
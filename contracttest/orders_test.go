@@ -0,0 +1,41 @@
+package contracttest
+
+import (
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+)
+
+// The orders module cancels the orders of deleted users (UserDeletedHandler),
+// reopens them when the user is restored (UserRestoredHandler) and copies user emails into its order summaries (OrderSummaryProjection).
+
+func TestOrders_UserDeleted(t *testing.T) {
+	verify(t, userevents.UserDeletedEvent{
+		BaseEvent: events.NewBaseEvent(userevents.UserDeletedEventType),
+		UserID:    userID,
+	}, "users.UserDeleted", "UserID")
+}
+
+func TestOrders_UserRestored(t *testing.T) {
+	verify(t, userevents.UserRestoredEvent{
+		BaseEvent: events.NewBaseEvent(userevents.UserRestoredEventType),
+		UserID:    userID,
+	}, "users.UserRestored", "user_id")
+}
+
+func TestOrders_UserCreated(t *testing.T) {
+	verify(t, userevents.UserCreatedEvent{
+		BaseEvent: events.NewBaseEvent(userevents.UserCreatedEventType),
+		UserID:    userID,
+		Email:     "alice@example.com",
+	}, "users.UserCreated", "user_id", "email")
+}
+
+func TestOrders_UserUpdated(t *testing.T) {
+	verify(t, userevents.UserUpdatedEvent{
+		BaseEvent: events.NewBaseEvent(userevents.UserUpdatedEventType),
+		UserID:    userID,
+		Email:     "alice@example.org",
+	}, "users.UserUpdated", "user_id", "email")
+}
@@ -0,0 +1,92 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+// call sends body as JSON to the application and decodes the response into
+// out, if not nil. It fails the test unless the response status is want.
+func call(t *testing.T, method, path string, body any, want int, out any) {
+	t.Helper()
+	do(t, method, path, nil, body, want, out)
+}
+
+// callAdmin is call with the admin token.
+func callAdmin(t *testing.T, method, path string, body any, want int, out any) {
+	t.Helper()
+	do(t, method, path, http.Header{security.AdminTokenHeader: {os.Getenv("ADMIN_TOKEN")}}, body, want, out)
+}
+
+func do(t *testing.T, method, path string, header http.Header, body any, want int, out any) {
+	t.Helper()
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("%s %s: encoding request: %v", method, path, err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(t.Context(), method, server.URL+path, reqBody)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	resp, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("%s %s: reading response: %v", method, path, err)
+	}
+
+	if resp.StatusCode != want {
+		t.Fatalf("%s %s = %d %s, want %d", method, path, resp.StatusCode, data, want)
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			t.Fatalf("%s %s: decoding %s: %v", method, path, data, err)
+		}
+	}
+}
+
+// eventually polls cond until it holds, failing the test after timeout.
+// Post-commit event handlers run asynchronously, so their effects are
+// asserted this way.
+func eventually(t *testing.T, timeout time.Duration, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out after %s waiting for %s", timeout, what)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// unique returns prefix with a random suffix, so that runs against the same
+// database do not collide.
+func unique(prefix string) string {
+	b := make([]byte, 6)
+	rand.Read(b)
+	return prefix + "-" + hex.EncodeToString(b)
+}
@@ -0,0 +1,10 @@
+// Package e2e holds the end-to-end tests: black-box HTTP scenarios against
+// the full application, booted in-process on a random port with the same
+// wiring as cmd/server. They need the Spanner emulator with the schema
+// loaded (make up) and run only with the e2e build tag:
+//
+//	SPANNER_EMULATOR_HOST=localhost:9010 go test -tags e2e ./e2e/...
+//
+// or make test-e2e. Every run creates its own users and products, so the
+// database does not need to be reset between runs.
+package e2e
@@ -0,0 +1,3 @@
+module github.com/rai/clean-modularmonolith-go/e2e
+
+go 1.26.0
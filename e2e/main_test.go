@@ -0,0 +1,64 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/internal/bootstrap"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/app"
+)
+
+// adminToken is set for the application unless ADMIN_TOKEN already is.
+const adminToken = "e2e-admin-token"
+
+// server is the application under test, shared by every scenario.
+var server *httptest.Server
+
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	if os.Getenv("SPANNER_EMULATOR_HOST") == "" {
+		fmt.Fprintln(os.Stderr, "e2e: SPANNER_EMULATOR_HOST is not set; start the emulator with make up")
+		return 1
+	}
+	if os.Getenv("ADMIN_TOKEN") == "" {
+		os.Setenv("ADMIN_TOKEN", adminToken)
+	}
+	if os.Getenv("LOG_LEVEL") == "" {
+		os.Setenv("LOG_LEVEL", "warn")
+	}
+
+	ctx := context.Background()
+	platform, err := bootstrap.Setup(ctx, "clean-modularmonolith-e2e")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "e2e: setting up platform:", err)
+		return 1
+	}
+	application, handler, err := platform.API()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "e2e: building application:", err)
+		return 1
+	}
+	if err := application.Start(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "e2e: starting application:", err)
+		return 1
+	}
+
+	server = httptest.NewServer(handler)
+	code := m.Run()
+	server.Close()
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, app.ShutdownTimeout)
+	defer cancel()
+	if err := application.Shutdown(shutdownCtx); err != nil {
+		fmt.Fprintln(os.Stderr, "e2e: shutting down:", err)
+	}
+	return code
+}
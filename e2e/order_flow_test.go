@@ -0,0 +1,80 @@
+//go:build e2e
+
+package e2e
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+type idResponse struct {
+	ID string `json:"id"`
+}
+
+type orderResponse struct {
+	Status string `json:"status"`
+	Total  struct {
+		Amount int64 `json:"amount"`
+	} `json:"total"`
+}
+
+type stockResponse struct {
+	Reserved int `json:"reserved"`
+}
+
+type notificationsResponse struct {
+	Notifications []struct {
+		Template string            `json:"template"`
+		Payload  map[string]string `json:"payload"`
+	} `json:"notifications"`
+}
+
+// TestOrderFlow walks a customer through ordering: create a user, create an
+// order, add items, submit it, then checks the reactions of the other
+// modules: the stock is reserved and the order confirmation is sent.
+func TestOrderFlow(t *testing.T) {
+	productID := unique("e2e-book")
+	callAdmin(t, http.MethodPut, "/inventory/"+productID, map[string]any{"on_hand": 10}, http.StatusOK, nil)
+
+	var user idResponse
+	call(t, http.MethodPost, "/users", map[string]any{
+		"email":      unique("e2e") + "@example.com",
+		"first_name": "Alice",
+		"last_name":  "Liddell",
+	}, http.StatusCreated, &user)
+
+	var order idResponse
+	call(t, http.MethodPost, "/orders", map[string]any{"user_id": user.ID, "currency": "USD"}, http.StatusCreated, &order)
+	call(t, http.MethodPost, "/orders/"+order.ID+"/items", map[string]any{
+		"product_id":   productID,
+		"product_name": "Through the Looking-Glass",
+		"quantity":     2,
+		"unit_price":   1250,
+		"currency":     "USD",
+	}, http.StatusNoContent, nil)
+	call(t, http.MethodPost, "/orders/"+order.ID+"/submit", nil, http.StatusNoContent, nil)
+
+	var submitted orderResponse
+	call(t, http.MethodGet, "/orders/"+order.ID, nil, http.StatusOK, &submitted)
+	if submitted.Status == "draft" || submitted.Status == "cancelled" || submitted.Total.Amount < 2500 {
+		t.Errorf("submitted order = %+v, want it pending with a total of at least 2500", submitted)
+	}
+
+	eventually(t, 10*time.Second, "the stock reservation", func() bool {
+		var stock stockResponse
+		call(t, http.MethodGet, "/inventory/"+productID, nil, http.StatusOK, &stock)
+		return stock.Reserved == 2
+	})
+
+	eventually(t, 10*time.Second, "the order confirmation", func() bool {
+		var list notificationsResponse
+		call(t, http.MethodGet, "/users/"+user.ID+"/notifications", nil, http.StatusOK, &list)
+		for _, n := range list.Notifications {
+			if n.Template == "order_confirmation" && n.Payload["order_id"] == order.ID {
+				return true
+			}
+		}
+		return false
+	})
+}
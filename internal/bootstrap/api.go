@@ -0,0 +1,70 @@
+package bootstrap
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/internal/platform/app"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/httpserver"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/runtimeconfig"
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+// API builds the application with the platform endpoints and returns it with
+// its HTTP handler, middleware included: what cmd/server serves, and what
+// the end-to-end tests boot.
+func (p *Platform) API() (*app.App, http.Handler, error) {
+	// Per-client rate limit, reloadable with the runtime config
+	rateLimiter := httpserver.NewRateLimiter(0, 0)
+	p.RuntimeConfig.Subscribe(func(ctx context.Context, cfg runtimeconfig.Config) {
+		rateLimiter.SetLimit(cfg.RateLimit.RequestsPerSecond, cfg.RateLimit.Burst)
+	})
+
+	platformGuard := security.AdminGuard{Module: "platform", Token: p.AdminToken, Sink: p.SecuritySink}
+	logLevelHandler := platformGuard.Handler(p.LogLevels.HTTPHandler())
+	runtimeConfigHandler := platformGuard.Handler(p.RuntimeConfig.HTTPHandler())
+
+	// Platform endpoints
+	builder := p.Builder().
+		Handle("GET /metrics", p.Metrics.Handler()).
+		// Runtime log level control; reloading the runtime config resets it
+		Handle("GET /admin/loglevel", logLevelHandler).
+		Handle("PUT /admin/loglevel", logLevelHandler).
+		// Event bus subscriptions and dispatch statistics
+		Handle("GET /admin/eventbus", platformGuard.Handler(p.EventBus.IntrospectionHandler())).
+		// Hot-reloadable runtime configuration
+		Handle("GET /admin/config", runtimeConfigHandler).
+		Handle("POST /admin/config/reload", runtimeConfigHandler).
+		// API version prefix
+		Handle("GET /api/v1/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"version":"1.0.0"}`))
+		}))
+
+	// Swagger UI for the OpenAPI specification served at /openapi.json; it
+	// loads from a CDN, so it is for development only
+	if Getenv("OPENAPI_UI", "false") == "true" {
+		builder.Handle("GET /docs", openapi.UIHandler("/openapi.json"))
+	}
+
+	application, err := builder.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keys, err := app.Lookup[apikeys.Module](application, "apikeys")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Only the admin token may choose the tenant of a request with
+	// X-Tenant-ID; API keys carry their own
+	isAdmin := func(r *http.Request) bool { return platformGuard.Actor(r) == security.AdminActor }
+
+	// Apply middleware; API keys are authenticated after the rate limit, so
+	// that guessing them is rate limited too
+	handler := httpserver.Middleware(application.Handler(), httpserver.Tracing(), httpserver.Metrics(p.Metrics), httpserver.Recovery(p.Logger, p.ErrorReporter), httpserver.RequestContext(platformGuard.Actor, isAdmin), httpserver.Logging(p.Logger), httpserver.RateLimit(rateLimiter), keys.Middleware(), httpserver.CORS([]string{"*"}))
+	return application, handler, nil
+}
@@ -0,0 +1,482 @@
+// Package bootstrap is the composition root shared by the binaries in cmd:
+// it sets up the platform from the environment and registers every module,
+// so that the API server and the background worker run the same wiring and
+// differ only in what they serve and start.
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/internal/platform/app"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/elasticsearch"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/eventbus"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/featureflags"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/httpserver"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/logging"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/metrics"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/runtimeconfig"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/telemetry"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/chaos"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/fault"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// Platform is the infrastructure of one process: what the modules get
+// (app.Platform) and what the binaries need to serve and manage it.
+type Platform struct {
+	app.Platform
+
+	LogLevels     *logging.Levels
+	RuntimeConfig *runtimeconfig.Store
+	ErrorReporter fault.Reporter
+
+	elasticsearch   *elasticsearch.ElasticsearchClient
+	txScopes        []*spanner.ReadWriteTransactionScope // shared database first
+	shutdownTracing func(ctx context.Context) error
+}
+
+// Setup initializes the platform from the environment. serviceName is the
+// default OTEL_SERVICE_NAME, so that each binary's spans can be told apart.
+func Setup(ctx context.Context, serviceName string) (*Platform, error) {
+	// Initialize logger; levels can be changed at runtime via /admin/loglevel
+	logLevels, err := parseLogLevels()
+	if err != nil {
+		return nil, fmt.Errorf("invalid log level configuration: %w", err)
+	}
+	slogOptions := &slog.HandlerOptions{
+		Level: slog.LevelDebug, // the floor; logLevels does the filtering
+	}
+	slogJsonHandler := slog.NewJSONHandler(os.Stdout, slogOptions)
+	logger := slog.New(logLevels.Handler(slogJsonHandler))
+	slog.SetDefault(logger)
+
+	// Initialize tracing before any client is created so they pick up the provider
+	tracingCfg, err := parseTracingConfig(serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tracing configuration: %w", err)
+	}
+	shutdownTracing, err := telemetry.Setup(ctx, tracingCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+
+	// Spanner connection settings; the clients are created with the transaction scopes
+	spannerCfg := spanner.Config{
+		ProjectID:  Getenv("SPANNER_PROJECT_ID", "local-project"),
+		InstanceID: Getenv("SPANNER_INSTANCE_ID", "local-instance"),
+		DatabaseID: Getenv("SPANNER_DATABASE_ID", "app-db"),
+
+		EnableEndToEndTracing: Getenv("SPANNER_END_TO_END_TRACING", "false") == "true",
+	}
+
+	// Initialize Prometheus metrics, served on /metrics
+	sloCfg, err := parseSLOConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid SLO configuration: %w", err)
+	}
+	metricsRegistry := metrics.NewRegistry(metrics.WithSLO(sloCfg))
+
+	// Warn about transactions and event handlers that run too long; zero disables
+	slowTransaction, err := time.ParseDuration(Getenv("SLOW_TRANSACTION_THRESHOLD", "5s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid slow transaction threshold: %w", err)
+	}
+	slowEventHandler, err := time.ParseDuration(Getenv("SLOW_EVENT_HANDLER_THRESHOLD", "2s"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid slow event handler threshold: %w", err)
+	}
+
+	// Commit priority of transactions that do not choose one (low, medium or
+	// high); unset leaves it to Spanner
+	txPriority, err := transaction.ParsePriority(Getenv("SPANNER_TRANSACTION_PRIORITY", ""))
+	if err != nil {
+		return nil, err
+	}
+
+	// Fault injection for development, see parseChaosConfig
+	chaosCfg, err := parseChaosConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid chaos configuration: %w", err)
+	}
+	var injector *chaos.Injector
+	if chaosCfg.Enabled() {
+		logger.Warn("chaos: injecting faults", slog.Duration("latency", chaosCfg.Latency), slog.Float64("error_rate", chaosCfg.ErrorRate),
+			slog.Float64("abort_rate", chaosCfg.AbortRate), slog.Float64("duplicate_rate", chaosCfg.DuplicateRate))
+		injector = chaos.New(chaosCfg)
+	}
+
+	// Initialize the Spanner clients and their transaction scopes
+	openDatabase := func(cfg spanner.Config) (app.Database, *spanner.ReadWriteTransactionScope, error) {
+		client, err := spanner.NewClient(ctx, cfg)
+		if err != nil {
+			return app.Database{}, nil, err
+		}
+		txScope := spanner.NewReadWriteTransactionScope(client, logger, spanner.WithMetrics(metricsRegistry), spanner.WithSlowTransactionThreshold(slowTransaction), spanner.WithDefaultPriority(txPriority))
+		var rwTxScope, roTxScope transaction.Scope = txScope, spanner.NewReadOnlyTransactionScope(client, logger)
+		if injector != nil {
+			rwTxScope, roTxScope = chaos.Scope(rwTxScope, injector), chaos.Scope(roTxScope, injector)
+		}
+		return app.Database{Spanner: client, TxScope: rwTxScope, ReadOnlyTxScope: roTxScope}, txScope, nil
+	}
+	db, txScope, err := openDatabase(spannerCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spanner client: %w", err)
+	}
+	txScopes := []*spanner.ReadWriteTransactionScope{txScope}
+
+	// Modules listed in SPANNER_MODULE_DATABASES keep their tables in a
+	// database of their own, on the same instance
+	moduleDatabaseIDs, err := parseModuleDatabases()
+	if err != nil {
+		return nil, fmt.Errorf("invalid module database configuration: %w", err)
+	}
+	databases := make(map[string]app.Database, len(moduleDatabaseIDs))
+	for module, databaseID := range moduleDatabaseIDs {
+		moduleCfg := spannerCfg
+		moduleCfg.DatabaseID = databaseID
+		moduleDB, moduleTxScope, err := openDatabase(moduleCfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create spanner client for module %s: %w", module, err)
+		}
+		databases[module] = moduleDB
+		txScopes = append(txScopes, moduleTxScope)
+		logger.Info("module database configured", slog.String("module", module), slog.String("database", databaseID))
+	}
+
+	// Unexpected failures (panics, unclassified errors) are reported here;
+	// implement fault.Reporter to send them to an error tracking service instead
+	errorReporter := fault.LogReporter(logger.With("log", "errors"))
+
+	// Initialize event bus (for inter-module communication)
+	// Implements both events.Publisher and events.Subscriber
+	// Its per-event debug logs are sampled, see EVENTBUS_LOG_SAMPLE_EVERY
+	eventBusLogSampling, err := strconv.Atoi(Getenv("EVENTBUS_LOG_SAMPLE_EVERY", "100"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid event bus log sampling configuration: %w", err)
+	}
+	// Cycles of events causing each other are always refused; this bounds
+	// acyclic causation chains (0 for no bound)
+	eventBusMaxCausationDepth, err := strconv.Atoi(Getenv("EVENTBUS_MAX_CAUSATION_DEPTH", "64"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid event bus causation depth configuration: %w", err)
+	}
+	eventBusOpts := []eventbus.Option{eventbus.WithMetrics(metricsRegistry), eventbus.WithLogSampling(eventBusLogSampling), eventbus.WithSlowHandlerThreshold(slowEventHandler), eventbus.WithErrorReporter(errorReporter), eventbus.WithMaxCausationDepth(eventBusMaxCausationDepth)}
+	if injector != nil {
+		eventBusOpts = append(eventBusOpts, eventbus.WithHandlerDecorator(func(h events.Handler) events.Handler { return chaos.Handler(h, injector) }))
+	}
+	eventBus := eventbus.NewEventBus(logger.With(logging.ModuleKey, "eventbus"), eventBusOpts...)
+
+	// Reloadable configuration: the environment overlaid with RUNTIME_CONFIG_FILE,
+	// re-read on SIGHUP or POST /admin/config/reload. Modules are told about
+	// changes through runtimeconfig.ConfigReloadedEvent.
+	runtimeBase, err := parseRuntimeConfig()
+	if err != nil {
+		return nil, fmt.Errorf("invalid runtime configuration: %w", err)
+	}
+	runtimeCfg, err := runtimeconfig.NewStore(runtimeBase, Getenv("RUNTIME_CONFIG_FILE", ""), eventBus, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load runtime configuration: %w", err)
+	}
+	runtimeCfg.Subscribe(func(ctx context.Context, cfg runtimeconfig.Config) {
+		applyLogLevels(logLevels, cfg)
+	})
+	runtimeCfg.ReloadOnSignal(ctx)
+
+	// Feature flags follow the runtime configuration (FEATURE_FLAGS, reloadable).
+	featureFlags := featureflags.New(featureflags.RuntimeConfig(runtimeCfg))
+
+	// Initialize Elasticsearch client
+	esClient, err := newElasticsearchClient(logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create elasticsearch client: %w", err)
+	}
+
+	// Authentication and authorization decisions are logged under their own
+	// logger; SECURITY_AUDIT_EVENTS also publishes them into the audit log
+	securitySink := security.LogSink(logger.With("log", "security"))
+	if Getenv("SECURITY_AUDIT_EVENTS", "false") == "true" {
+		securitySink = security.Sinks(securitySink, security.EventSink(eventBus))
+	}
+
+	return &Platform{
+		Platform: app.Platform{
+			Logger:          logger,
+			Spanner:         db.Spanner,
+			TxScope:         db.TxScope,
+			ReadOnlyTxScope: db.ReadOnlyTxScope,
+			EventBus:        eventBus,
+			Metrics:         metricsRegistry,
+			SecuritySink:    securitySink,
+			Features:        featureFlags,
+			AdminToken:      Getenv("ADMIN_TOKEN", ""),
+			Databases:       databases,
+		},
+		LogLevels:       logLevels,
+		RuntimeConfig:   runtimeCfg,
+		ErrorReporter:   errorReporter,
+		elasticsearch:   esClient,
+		txScopes:        txScopes,
+		shutdownTracing: shutdownTracing,
+	}, nil
+}
+
+// Builder returns an application builder with every module registered, the
+// platform health checks and the platform's shutdown phases. The binaries
+// add their own endpoints.
+func (p *Platform) Builder() *app.Builder {
+	// Modules are built, started and routed in registration order (see
+	// modules.go); a module can only depend on modules registered before it.
+	b := app.NewBuilder(p.Platform).
+		Register(auditModule()).
+		Register(usersModule(p.elasticsearch)).
+		Register(promotionsModule()).
+		Register(ordersModule()).
+		Register(inventoryModule()).
+		Register(paymentsModule()).
+		Register(reviewsModule()).
+		Register(analyticsModule()).
+		Register(notificationsModule()).
+		Register(webhooksModule()).
+		Register(integrationsModule()).
+		Register(apikeysModule()).
+		Register(adminModule())
+	// The read-only GraphQL gateway is optional
+	if Getenv("GRAPHQL_ENABLED", "false") == "true" {
+		b.Register(graphqlModule())
+	}
+	b.HealthCheck("spanner", func(ctx context.Context) error { return spanner.Ping(ctx, p.Spanner) })
+	for _, module := range slices.Sorted(maps.Keys(p.Databases)) {
+		client := p.Databases[module].Spanner
+		b.HealthCheck("spanner."+module, func(ctx context.Context) error { return spanner.Ping(ctx, client) })
+	}
+	return b.
+		OnShutdown("transactions", func(ctx context.Context) error {
+			var errs []error
+			for _, s := range p.txScopes {
+				errs = append(errs, s.Drain(ctx))
+			}
+			return errors.Join(errs...)
+		}).
+		OnShutdown("spanner", func(ctx context.Context) error {
+			p.Spanner.Close()
+			for _, db := range p.Databases {
+				db.Spanner.Close()
+			}
+			return nil
+		}).
+		OnShutdown("tracing", p.shutdownTracing)
+}
+
+// DebugServer creates the admin listener for pprof, expvar and runtime
+// stats. It is disabled (nil) unless DEBUG_PORT is set, and binds to the
+// loopback interface unless DEBUG_HOST says otherwise: the endpoints are
+// unauthenticated and must never be exposed publicly.
+func DebugServer(logger *slog.Logger) (*httpserver.Server, error) {
+	port, err := strconv.Atoi(Getenv("DEBUG_PORT", "0"))
+	if err != nil {
+		return nil, err
+	}
+	if port == 0 {
+		return nil, nil
+	}
+
+	cfg := httpserver.DebugConfig(Getenv("DEBUG_HOST", "127.0.0.1"), port)
+	return httpserver.New(cfg, httpserver.DebugHandler(), logger.With("listener", "debug")), nil
+}
+
+// newElasticsearchClient creates an Elasticsearch client from environment config.
+func newElasticsearchClient(logger *slog.Logger) (*elasticsearch.ElasticsearchClient, error) {
+	addrs := Getenv("ELASTICSEARCH_ADDRESSES", "http://localhost:9200")
+
+	cfg := elasticsearch.Config{
+		Addresses: strings.Split(addrs, ","),
+		Username:  Getenv("ELASTICSEARCH_USERNAME", ""),
+		Password:  Getenv("ELASTICSEARCH_PASSWORD", ""),
+		APIKey:    Getenv("ELASTICSEARCH_API_KEY", ""),
+	}
+
+	client, err := elasticsearch.NewElasticsearchClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("elasticsearch client initialized", slog.String("addresses", addrs))
+	return client, nil
+}
+
+// parseTracingConfig reads the tracing settings. Spans are exported over
+// OTLP/HTTP when an OTLP endpoint is configured; the exporter itself reads
+// the standard OTEL_EXPORTER_OTLP_* variables.
+func parseTracingConfig(serviceName string) (telemetry.Config, error) {
+	sampleRatio, err := strconv.ParseFloat(Getenv("OTEL_TRACES_SAMPLER_ARG", "1"), 64)
+	if err != nil {
+		return telemetry.Config{}, err
+	}
+	return telemetry.Config{
+		Enabled:        Getenv("OTEL_EXPORTER_OTLP_ENDPOINT", Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")) != "",
+		ServiceName:    Getenv("OTEL_SERVICE_NAME", serviceName),
+		ServiceVersion: Getenv("SERVICE_VERSION", "dev"),
+		SampleRatio:    sampleRatio,
+	}, nil
+}
+
+// parseSLOConfig reads the modules whose API is an SLI (SLO_API_MODULES,
+// default users and orders) and the SLI latency buckets in seconds
+// (SLO_LATENCY_BUCKETS, e.g. "0.1,0.3,1").
+func parseSLOConfig() (metrics.SLOConfig, error) {
+	var cfg metrics.SLOConfig
+	for module := range strings.SplitSeq(Getenv("SLO_API_MODULES", "users,orders"), ",") {
+		if module = strings.TrimSpace(module); module != "" {
+			cfg.APIModules = append(cfg.APIModules, module)
+		}
+	}
+	for bucket := range strings.SplitSeq(Getenv("SLO_LATENCY_BUCKETS", ""), ",") {
+		if bucket = strings.TrimSpace(bucket); bucket == "" {
+			continue
+		}
+		seconds, err := strconv.ParseFloat(bucket, 64)
+		if err != nil {
+			return metrics.SLOConfig{}, err
+		}
+		cfg.LatencyBuckets = append(cfg.LatencyBuckets, seconds)
+	}
+	return cfg, nil
+}
+
+// parseChaosConfig reads the fault injection settings: CHAOS_LATENCY (the
+// maximum random delay of each transaction, Spanner call and event handler),
+// CHAOS_ERROR_RATE, CHAOS_ABORT_RATE, CHAOS_DUPLICATE_RATE (probabilities
+// from 0 to 1) and CHAOS_SEED (zero for a random one). Latency and rates
+// default to zero, which disables injection.
+// Faults are only allowed against the Spanner emulator.
+func parseChaosConfig() (chaos.Config, error) {
+	var cfg chaos.Config
+	var err error
+	if cfg.Latency, err = time.ParseDuration(Getenv("CHAOS_LATENCY", "0s")); err != nil {
+		return chaos.Config{}, err
+	}
+	rates := []struct {
+		key  string
+		rate *float64
+	}{
+		{"CHAOS_ERROR_RATE", &cfg.ErrorRate},
+		{"CHAOS_ABORT_RATE", &cfg.AbortRate},
+		{"CHAOS_DUPLICATE_RATE", &cfg.DuplicateRate},
+	}
+	for _, r := range rates {
+		if *r.rate, err = strconv.ParseFloat(Getenv(r.key, "0"), 64); err != nil {
+			return chaos.Config{}, err
+		}
+		if *r.rate < 0 || *r.rate > 1 {
+			return chaos.Config{}, fmt.Errorf("%s must be between 0 and 1", r.key)
+		}
+	}
+	if cfg.Seed, err = strconv.ParseUint(Getenv("CHAOS_SEED", "0"), 10, 64); err != nil {
+		return chaos.Config{}, err
+	}
+	if cfg.Enabled() && os.Getenv("SPANNER_EMULATOR_HOST") == "" {
+		return chaos.Config{}, errors.New("fault injection requires SPANNER_EMULATOR_HOST")
+	}
+	return cfg, nil
+}
+
+// parseModuleDatabases reads the modules with a database of their own from
+// SPANNER_MODULE_DATABASES, e.g. "orders=orders-db,payments=payments-db".
+// Their tables must be created in that database rather than the shared one.
+func parseModuleDatabases() (map[string]string, error) {
+	databases := map[string]string{}
+	for entry := range strings.SplitSeq(Getenv("SPANNER_MODULE_DATABASES", ""), ",") {
+		if entry = strings.TrimSpace(entry); entry == "" {
+			continue
+		}
+		module, databaseID, ok := strings.Cut(entry, "=")
+		module, databaseID = strings.TrimSpace(module), strings.TrimSpace(databaseID)
+		if !ok || module == "" || databaseID == "" {
+			return nil, fmt.Errorf("%q is not module=database", entry)
+		}
+		if _, dup := databases[module]; dup {
+			return nil, fmt.Errorf("module %s has two databases", module)
+		}
+		databases[module] = databaseID
+	}
+	return databases, nil
+}
+
+// parseLogLevels reads the global LOG_LEVEL (default debug) and the
+// module-scoped overrides in LOG_LEVELS, e.g. "orders=info,eventbus=warn".
+func parseLogLevels() (*logging.Levels, error) {
+	level, err := logging.ParseLevel(Getenv("LOG_LEVEL", "debug"))
+	if err != nil {
+		return nil, err
+	}
+	modules, err := logging.ParseModuleLevels(Getenv("LOG_LEVELS", ""))
+	if err != nil {
+		return nil, err
+	}
+	levels := logging.NewLevels(level)
+	for module, lvl := range modules {
+		levels.Set(module, lvl)
+	}
+	return levels, nil
+}
+
+// parseRuntimeConfig reads the environment's values of the reloadable
+// settings: LOG_LEVEL and LOG_LEVELS (see parseLogLevels), RATE_LIMIT_RPS and
+// RATE_LIMIT_BURST (per client; zero disables), and FEATURE_FLAGS, a
+// comma-separated list of enabled flags.
+func parseRuntimeConfig() (runtimeconfig.Config, error) {
+	cfg := runtimeconfig.Config{
+		LogLevel:  Getenv("LOG_LEVEL", "debug"),
+		LogLevels: make(map[string]string),
+		Features:  make(map[string]bool),
+	}
+	modules, err := logging.ParseModuleLevels(Getenv("LOG_LEVELS", ""))
+	if err != nil {
+		return runtimeconfig.Config{}, err
+	}
+	for module, level := range modules {
+		cfg.LogLevels[module] = level.String()
+	}
+	if cfg.RateLimit.RequestsPerSecond, err = strconv.ParseFloat(Getenv("RATE_LIMIT_RPS", "0"), 64); err != nil {
+		return runtimeconfig.Config{}, err
+	}
+	if cfg.RateLimit.Burst, err = strconv.Atoi(Getenv("RATE_LIMIT_BURST", "0")); err != nil {
+		return runtimeconfig.Config{}, err
+	}
+	for flag := range strings.SplitSeq(Getenv("FEATURE_FLAGS", ""), ",") {
+		if flag = strings.TrimSpace(flag); flag != "" {
+			cfg.Features[flag] = true
+		}
+	}
+	return cfg, nil
+}
+
+// applyLogLevels replaces logLevels with the levels of a validated runtime config.
+func applyLogLevels(logLevels *logging.Levels, cfg runtimeconfig.Config) {
+	level, _ := logging.ParseLevel(cfg.LogLevel)
+	modules := make(map[string]slog.Level, len(cfg.LogLevels))
+	for module, name := range cfg.LogLevels {
+		modules[module], _ = logging.ParseLevel(name)
+	}
+	logLevels.Replace(level, modules)
+}
+
+// Getenv returns the value of an environment variable or a default value.
+func Getenv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
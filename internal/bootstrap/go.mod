@@ -0,0 +1,8 @@
+module github.com/rai/clean-modularmonolith-go/internal/bootstrap
+
+go 1.26.0
+
+require (
+	github.com/google/uuid v1.6.0
+	gopkg.in/yaml.v3 v3.0.1
+)
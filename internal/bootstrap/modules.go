@@ -0,0 +1,587 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/internal/platform/app"
+	platformcache "github.com/rai/clean-modularmonolith-go/internal/platform/cache"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/elasticsearch"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/metrics"
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/admin"
+	admindomain "github.com/rai/clean-modularmonolith-go/modules/admin/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/analytics"
+	analyticspersistence "github.com/rai/clean-modularmonolith-go/modules/analytics/infrastructure/persistence"
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys"
+	apikeyspersistence "github.com/rai/clean-modularmonolith-go/modules/apikeys/infrastructure/persistence"
+	"github.com/rai/clean-modularmonolith-go/modules/audit"
+	auditpersistence "github.com/rai/clean-modularmonolith-go/modules/audit/infrastructure/persistence"
+	"github.com/rai/clean-modularmonolith-go/modules/graphql"
+	graphqldomain "github.com/rai/clean-modularmonolith-go/modules/graphql/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/integrations"
+	integrationsdomain "github.com/rai/clean-modularmonolith-go/modules/integrations/domain"
+	integrationserp "github.com/rai/clean-modularmonolith-go/modules/integrations/infrastructure/erp"
+	integrationspersistence "github.com/rai/clean-modularmonolith-go/modules/integrations/infrastructure/persistence"
+	"github.com/rai/clean-modularmonolith-go/modules/inventory"
+	inventorypersistence "github.com/rai/clean-modularmonolith-go/modules/inventory/infrastructure/persistence"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications"
+	notificationsdomain "github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	notificationschannels "github.com/rai/clean-modularmonolith-go/modules/notifications/infrastructure/channels"
+	notificationspersistence "github.com/rai/clean-modularmonolith-go/modules/notifications/infrastructure/persistence"
+	"github.com/rai/clean-modularmonolith-go/modules/orders"
+	ordersdomain "github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	orderspersistence "github.com/rai/clean-modularmonolith-go/modules/orders/infrastructure/persistence"
+	"github.com/rai/clean-modularmonolith-go/modules/payments"
+	paymentsdomain "github.com/rai/clean-modularmonolith-go/modules/payments/domain"
+	paymentspersistence "github.com/rai/clean-modularmonolith-go/modules/payments/infrastructure/persistence"
+	"github.com/rai/clean-modularmonolith-go/modules/promotions"
+	promotionspersistence "github.com/rai/clean-modularmonolith-go/modules/promotions/infrastructure/persistence"
+	"github.com/rai/clean-modularmonolith-go/modules/reviews"
+	reviewspersistence "github.com/rai/clean-modularmonolith-go/modules/reviews/infrastructure/persistence"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/cache"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/fault"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/quota"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
+	"github.com/rai/clean-modularmonolith-go/modules/users"
+	usersdomain "github.com/rai/clean-modularmonolith-go/modules/users/domain"
+	userspersistence "github.com/rai/clean-modularmonolith-go/modules/users/infrastructure/persistence"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks"
+	webhookspersistence "github.com/rai/clean-modularmonolith-go/modules/webhooks/infrastructure/persistence"
+)
+
+// Module registrations, one per module: its name (and config section),
+// repositories, configuration and the modules it depends on. Each module
+// subscribes to the events it cares about internally.
+
+// auditModule records commands and events. It is registered first: the
+// modules after it report their commands to it.
+func auditModule() app.Module {
+	return app.Module{Name: "audit", New: func(c *app.Context) (any, error) {
+		return audit.New(audit.Config{
+			Repository:           auditpersistence.NewSpannerAuditLogRepository(c.Spanner, c.Logger),
+			TransactionScope:     c.TxScope,
+			PostCommitSubscriber: c.EventBus,
+			Logger:               c.Logger,
+			AdminToken:           c.AdminToken,
+			SecuritySink:         c.SecuritySink,
+		}), nil
+	}}
+}
+
+func usersModule(esClient *elasticsearch.ElasticsearchClient) app.Module {
+	return app.Module{
+		Name: "users",
+		New: func(c *app.Context) (any, error) {
+			strictness, err := usersdomain.ParseEmailStrictness(c.Config.String("EMAIL_STRICTNESS", "standard"))
+			c.Config.Check("EMAIL_STRICTNESS", err)
+
+			return users.New(users.Config{
+				Repository:                userspersistence.NewSpannerRepository(c.Spanner, c.Logger),
+				ReadWriteTransactionScope: c.TxScope,
+				ReadOnlyTransactionScope:  c.ReadOnlyTxScope,
+				Publisher:                 c.EventBus,
+				PostCommitPublisher:       c.EventBus,
+				Subscriber:                c.EventBus,
+				PostCommitSubscriber:      c.EventBus,
+				ESClient:                  esClient,
+				Logger:                    c.Logger,
+				EmailPolicy: usersdomain.EmailPolicy{
+					Strictness:        strictness,
+					NormalizePlusTags: c.Config.Bool("EMAIL_NORMALIZE_PLUS_TAGS", false),
+				},
+				RestoreGracePeriod: c.Config.Duration("RESTORE_GRACE_PERIOD", 30*24*time.Hour),
+				Tokens:             tokenSigner(c),
+				EmailChangeTTL:     c.Config.Duration("EMAIL_CHANGE_TTL", 24*time.Hour),
+				PasswordResetTTL:   c.Config.Duration("PASSWORD_RESET_TTL", time.Hour),
+				AdminToken:         c.AdminToken,
+				SecuritySink:       c.SecuritySink,
+				CommandBus:         c.CommandBus(),
+				Cache:              queryCache(c),
+				CacheTTL:           c.Config.Duration("CACHE_TTL", time.Minute),
+			}), nil
+		},
+		HealthChecks: []app.HealthCheck{{Name: "elasticsearch", Check: esClient.Ping}},
+	}
+}
+
+// promotionsModule is evaluated by orders at submit and records redemptions pre-commit.
+func promotionsModule() app.Module {
+	return app.Module{Name: "promotions", New: func(c *app.Context) (any, error) {
+		return promotions.New(promotions.Config{
+			Repository:          promotionspersistence.NewSpannerPromotionRepository(c.Spanner, c.Logger),
+			TransactionScope:    c.TxScope,
+			Publisher:           c.EventBus,
+			PostCommitPublisher: c.EventBus,
+			Subscriber:          c.EventBus,
+			Logger:              c.Logger,
+			AdminToken:          c.AdminToken,
+			SecuritySink:        c.SecuritySink,
+			CommandBus:          c.CommandBus(),
+		}), nil
+	}}
+}
+
+func ordersModule() app.Module {
+	return app.Module{Name: "orders", New: func(c *app.Context) (any, error) {
+		usersModule, err := app.Get[users.Module](c, "users")
+		if err != nil {
+			return nil, err
+		}
+		promotionsModule, err := app.Get[promotions.Module](c, "promotions")
+		if err != nil {
+			return nil, err
+		}
+
+		repository, err := orderRepository(c)
+		if err != nil {
+			return nil, err
+		}
+		return orders.New(orders.Config{
+			Repository:         repository,
+			DiscountRepository: orderspersistence.NewSpannerDiscountCodeRepository(c.Spanner, c.Logger),
+			HistoryRepository:  orderspersistence.NewSpannerStatusHistoryRepository(c.Spanner, c.Logger),
+			SummaryRepository:  orderspersistence.NewSpannerOrderSummaryRepository(c.Spanner, c.Logger),
+			Checkpoints:        platformspanner.NewCheckpointStore(c.Spanner, c.Logger),
+			Inbox:              platformspanner.NewInboxStore(c.Spanner, c.Logger, "OrdersInbox"),
+			Users:              usersModule,
+			Promotions:         promotionEngine(promotionsModule),
+			TaxCalculator: ordersdomain.FlatRateTaxCalculator{
+				Name:    c.Config.String("TAX_NAME", "Sales tax"),
+				RateBps: c.Config.Int64("TAX_RATE_BPS", 0),
+			},
+			TransactionScope:         c.TxScope,
+			ReadOnlyTransactionScope: c.ReadOnlyTxScope,
+			Publisher:                c.EventBus,
+			PostCommitPublisher:      c.EventBus,
+			Subscriber:               c.EventBus,
+			PostCommitSubscriber:     c.EventBus,
+			Logger:                   c.Logger,
+			// Zero disables a limit
+			Limits: ordersdomain.OrderLimits{
+				MaxDistinctItems: c.Config.Int("MAX_ITEMS", 100),
+				MaxLineQuantity:  c.Config.Int("MAX_LINE_QUANTITY", 1000),
+				MaxTotal:         c.Config.Int64("MAX_TOTAL", 0),
+			},
+			Quotas: ordersdomain.OrderQuotas{
+				OrdersPerTenantPerDay: c.Config.Int64("QUOTA_PER_TENANT_PER_DAY", 0),
+				DraftsPerUser:         c.Config.Int64("QUOTA_DRAFTS_PER_USER", 0),
+			},
+			QuotaService: quota.NewService(platformspanner.NewQuotaStore(c.Spanner, c.Logger)),
+			DraftExpiry: orders.DraftExpiryConfig{
+				TTL:      c.Config.Duration("DRAFT_TTL", 0),
+				Interval: c.Config.Duration("DRAFT_EXPIRY_INTERVAL", 5*time.Minute),
+			},
+			Tokens:       tokenSigner(c),
+			ClaimTTL:     c.Config.Duration("CLAIM_TTL", 7*24*time.Hour),
+			AdminToken:   c.AdminToken,
+			SecuritySink: c.SecuritySink,
+			Features:     c.Features,
+			CommandBus:   c.CommandBus(),
+			Cache:        queryCache(c),
+			CacheTTL:     c.Config.Duration("CACHE_TTL", time.Minute),
+		}), nil
+	}}
+}
+
+// tokenSigner returns the signer of a module's action tokens, from its
+// TOKEN_SECRET. Without one it returns nil, which disables the module's token
+// flows (501).
+func tokenSigner(c *app.Context) *tokens.Signer {
+	secret := c.Config.String("TOKEN_SECRET", "")
+	if secret == "" {
+		return nil
+	}
+	signer, err := tokens.NewSigner([]byte(secret))
+	c.Config.Check("TOKEN_SECRET", err)
+	return signer
+}
+
+// orderRepository returns the order repository selected by PERSISTENCE:
+// "state" (the default) stores orders in the Orders tables, "events" as
+// event streams snapshotted every SNAPSHOT_EVERY events (see
+// orderspersistence.EventSourcedRepository).
+func orderRepository(c *app.Context) (ordersdomain.OrderRepository, error) {
+	switch p := c.Config.String("PERSISTENCE", "state"); p {
+	case "state":
+		return orderspersistence.NewSpannerRepository(c.Spanner, c.Logger), nil
+	case "events":
+		snapshotEvery := c.Config.Int("SNAPSHOT_EVERY", orderspersistence.DefaultSnapshotEvery)
+		return orderspersistence.NewEventSourcedRepository(c.Spanner, c.Logger, snapshotEvery), nil
+	default:
+		return nil, fmt.Errorf("unknown ORDERS_PERSISTENCE %q: want state or events", p)
+	}
+}
+
+// queryCache returns the module's in-process read model cache holding
+// CACHE_SIZE entries, or nil (no caching) when CACHE_SIZE is 0, the default:
+// an instance evicts only the entries of the events it handled itself, so
+// caching suits single-instance deployments until a shared store is wired.
+func queryCache(c *app.Context) cache.Store {
+	size := c.Config.Int("CACHE_SIZE", 0)
+	if size <= 0 {
+		return nil
+	}
+	return platformcache.NewLRU(size)
+}
+
+// inventoryModule reserves stock for submitted orders (saga step after commit).
+func inventoryModule() app.Module {
+	return app.Module{Name: "inventory", New: func(c *app.Context) (any, error) {
+		return inventory.New(inventory.Config{
+			StockRepository:       inventorypersistence.NewSpannerStockRepository(c.Spanner, c.Logger),
+			ReservationRepository: inventorypersistence.NewSpannerReservationRepository(c.Spanner, c.Logger),
+			TransactionScope:      c.TxScope,
+			Publisher:             c.EventBus,
+			PostCommitPublisher:   c.EventBus,
+			PostCommitSubscriber:  c.EventBus,
+			Logger:                c.Logger,
+			AdminToken:            c.AdminToken,
+			SecuritySink:          c.SecuritySink,
+			CommandBus:            c.CommandBus(),
+		}), nil
+	}}
+}
+
+// paymentsModule charges submitted orders; captures confirm them via events.
+func paymentsModule() app.Module {
+	return app.Module{Name: "payments", New: func(c *app.Context) (any, error) {
+		ordersModule, err := app.Get[orders.Module](c, "orders")
+		if err != nil {
+			return nil, err
+		}
+
+		return payments.New(payments.Config{
+			Repository: paymentspersistence.NewSpannerPaymentRepository(c.Spanner, c.Logger),
+			Orders: paymentsdomain.OrderDirectoryFunc(func(ctx context.Context, orderID string) (int64, string, bool, error) {
+				return ordersModule.AmountDue(ctx, orderID)
+			}),
+			TransactionScope:    c.TxScope,
+			Publisher:           c.EventBus,
+			PostCommitPublisher: c.EventBus,
+			Logger:              c.Logger,
+			CommandBus:          c.CommandBus(),
+		}), nil
+	}}
+}
+
+// reviewsModule learns eligible purchases from completed orders.
+func reviewsModule() app.Module {
+	return app.Module{Name: "reviews", New: func(c *app.Context) (any, error) {
+		return reviews.New(reviews.Config{
+			ReviewRepository:        reviewspersistence.NewSpannerReviewRepository(c.Spanner, c.Logger),
+			PurchaseRepository:      reviewspersistence.NewSpannerPurchaseRepository(c.Spanner, c.Logger),
+			ProductRatingRepository: reviewspersistence.NewSpannerProductRatingRepository(c.Spanner, c.Logger),
+			TransactionScope:        c.TxScope,
+			Publisher:               c.EventBus,
+			PostCommitPublisher:     c.EventBus,
+			Subscriber:              c.EventBus,
+			PostCommitSubscriber:    c.EventBus,
+			Logger:                  c.Logger,
+			AdminToken:              c.AdminToken,
+			SecuritySink:            c.SecuritySink,
+			CommandBus:              c.CommandBus(),
+		}), nil
+	}}
+}
+
+// analyticsModule projects events into reporting read models after commit.
+func analyticsModule() app.Module {
+	return app.Module{Name: "analytics", New: func(c *app.Context) (any, error) {
+		return analytics.New(analytics.Config{
+			MetricsRepository:    analyticspersistence.NewSpannerMetricsRepository(c.Spanner, c.Logger),
+			InboxRepository:      analyticspersistence.NewSpannerInboxRepository(c.Spanner, c.Logger),
+			TransactionScope:     c.TxScope,
+			PostCommitSubscriber: c.EventBus,
+			Logger:               c.Logger,
+			AdminToken:           c.AdminToken,
+			SecuritySink:         c.SecuritySink,
+		}), nil
+	}}
+}
+
+// notificationsModule subscribes to events but runs outside transactions
+// (external side effects like email should not be in DB transactions).
+func notificationsModule() app.Module {
+	return app.Module{Name: "notifications", New: func(c *app.Context) (any, error) {
+		usersModule, err := app.Get[users.Module](c, "users")
+		if err != nil {
+			return nil, err
+		}
+		emailChannel, err := newEmailChannel(c.Config, c.Logger)
+		if err != nil {
+			return nil, err
+		}
+
+		return notifications.New(notifications.Config{
+			Repository:                notificationspersistence.NewSpannerRepository(c.Spanner, c.Logger),
+			PreferencesRepository:     notificationspersistence.NewSpannerPreferencesRepository(c.Spanner, c.Logger),
+			TransactionScope:          c.TxScope,
+			EventSubscriber:           c.EventBus,
+			PostCommitEventSubscriber: c.EventBus,
+			Logger:                    c.Logger,
+			Channels:                  []notificationsdomain.Channel{measuredChannel{Channel: emailChannel, metrics: c.Metrics}},
+			Retry: notifications.RetryConfig{
+				MaxAttempts: c.Config.Int("RETRY_MAX_ATTEMPTS", 6),
+				BaseDelay:   c.Config.Duration("RETRY_BASE_DELAY", 30*time.Second),
+				MaxDelay:    c.Config.Duration("RETRY_MAX_DELAY", 15*time.Minute),
+				Interval:    c.Config.Duration("RETRY_INTERVAL", 30*time.Second),
+			},
+			Users: usersModule,
+		}), nil
+	}}
+}
+
+// webhooksModule forwards events to external consumers after commit.
+func webhooksModule() app.Module {
+	return app.Module{Name: "webhooks", New: func(c *app.Context) (any, error) {
+		return webhooks.New(webhooks.Config{
+			SubscriptionRepository:    webhookspersistence.NewSpannerSubscriptionRepository(c.Spanner, c.Logger),
+			DeliveryRepository:        webhookspersistence.NewSpannerDeliveryRepository(c.Spanner, c.Logger),
+			TransactionScope:          c.TxScope,
+			PostCommitEventSubscriber: c.EventBus,
+			Logger:                    c.Logger,
+			AdminToken:                c.AdminToken,
+			SecuritySink:              c.SecuritySink,
+			CommandBus:                c.CommandBus(),
+			MetricsRegisterer:         c.Metrics.Registerer(),
+		}), nil
+	}}
+}
+
+// integrationsModule books confirmed orders in the ERP after commit. Without
+// ERP_URL it books them in the stub ERP.
+func integrationsModule() app.Module {
+	return app.Module{Name: "integrations", New: func(c *app.Context) (any, error) {
+		var erp integrationsdomain.ERP
+		if url := c.Config.String("ERP_URL", ""); url != "" {
+			erp = integrationserp.NewClient(integrationserp.Config{
+				BaseURL:     url,
+				APIKey:      c.Config.String("ERP_API_KEY", ""),
+				Timeout:     c.Config.Duration("ERP_TIMEOUT", 10*time.Second),
+				MaxAttempts: c.Config.Int("ERP_MAX_ATTEMPTS", 4),
+				BaseDelay:   c.Config.Duration("ERP_BASE_DELAY", time.Second),
+				MaxDelay:    c.Config.Duration("ERP_MAX_DELAY", 30*time.Second),
+			})
+		}
+
+		return integrations.New(integrations.Config{
+			SyncRepository:       integrationspersistence.NewSpannerSyncRepository(c.Spanner, c.Logger),
+			TransactionScope:     c.TxScope,
+			Publisher:            c.EventBus,
+			PostCommitPublisher:  c.EventBus,
+			PostCommitSubscriber: c.EventBus,
+			Logger:               c.Logger,
+			ERP:                  erp,
+		}), nil
+	}}
+}
+
+// apikeysModule issues API keys to machine clients and authenticates them;
+// API wires its middleware into the handler chain.
+func apikeysModule() app.Module {
+	return app.Module{Name: "apikeys", New: func(c *app.Context) (any, error) {
+		return apikeys.New(apikeys.Config{
+			Repository:       apikeyspersistence.NewSpannerKeyRepository(c.Spanner, c.Logger),
+			TransactionScope: c.TxScope,
+			Logger:           c.Logger,
+			AdminToken:       c.AdminToken,
+			SecuritySink:     c.SecuritySink,
+			CommandBus:       c.CommandBus(),
+		}), nil
+	}}
+}
+
+// graphqlModule composes users and their orders for the GraphQL gateway,
+// through the users and orders contract queries.
+func graphqlModule() app.Module {
+	return app.Module{Name: "graphql", New: func(c *app.Context) (any, error) {
+		usersModule, err := app.Get[users.Module](c, "users")
+		if err != nil {
+			return nil, err
+		}
+		ordersModule, err := app.Get[orders.Module](c, "orders")
+		if err != nil {
+			return nil, err
+		}
+
+		return graphql.New(graphql.Config{
+			Users: graphqldomain.UserDirectoryFunc(func(ctx context.Context, userID string) (graphqldomain.User, bool, error) {
+				u, ok, err := usersModule.GetUser(ctx, userID)
+				return graphqldomain.User(u), ok, err
+			}),
+			Orders: graphqldomain.OrderDirectoryFunc(func(ctx context.Context, userID string, offset, limit int) (graphqldomain.OrderPage, error) {
+				summaries, total, err := ordersModule.UserOrders(ctx, userID, offset, limit)
+				page := graphqldomain.OrderPage{TotalCount: total, Orders: make([]graphqldomain.Order, len(summaries))}
+				for i, o := range summaries {
+					page.Orders[i] = graphqldomain.Order(o)
+				}
+				return page, err
+			}),
+			Logger: c.Logger,
+		}), nil
+	}}
+}
+
+// adminModule serves the operational endpoints under /admin, through the
+// users, orders, notifications and webhooks module roots.
+func adminModule() app.Module {
+	return app.Module{Name: "admin", New: func(c *app.Context) (any, error) {
+		usersModule, err := app.Get[users.Module](c, "users")
+		if err != nil {
+			return nil, err
+		}
+		ordersModule, err := app.Get[orders.Module](c, "orders")
+		if err != nil {
+			return nil, err
+		}
+		notificationsModule, err := app.Get[notifications.Module](c, "notifications")
+		if err != nil {
+			return nil, err
+		}
+		webhooksModule, err := app.Get[webhooks.Module](c, "webhooks")
+		if err != nil {
+			return nil, err
+		}
+
+		return admin.New(admin.Config{
+			Users: admindomain.UserDirectoryFunc(func(ctx context.Context, offset, limit int) (admindomain.UserPage, error) {
+				deleted, total, err := usersModule.DeletedUsers(ctx, offset, limit)
+				page := admindomain.UserPage{TotalCount: total, Users: make([]admindomain.User, len(deleted))}
+				for i, u := range deleted {
+					page.Users[i] = admindomain.User(u)
+				}
+				return page, err
+			}),
+			Orders: admindomain.OrderCancellerFunc(func(ctx context.Context, orderID, reason, actor string) error {
+				return adminCancelError(ordersModule.CancelOrder(ctx, orderID, reason, actor))
+			}),
+			DeadLetters: map[string]admindomain.DeadLetterQueue{
+				"notifications": admindomain.DeadLetterQueueFunc(func(ctx context.Context, offset, limit int) (admindomain.DeadLetterPage, error) {
+					failed, total, err := notificationsModule.FailedNotifications(ctx, offset, limit)
+					page := admindomain.DeadLetterPage{TotalCount: total, DeadLetters: make([]admindomain.DeadLetter, len(failed))}
+					for i, n := range failed {
+						page.DeadLetters[i] = admindomain.DeadLetter{ID: n.ID, Target: n.Channel, Subject: n.Template, Attempts: n.Attempts, LastError: n.LastError, CreatedAt: n.CreatedAt}
+					}
+					return page, err
+				}),
+				"webhooks": admindomain.DeadLetterQueueFunc(func(ctx context.Context, offset, limit int) (admindomain.DeadLetterPage, error) {
+					failed, total, err := webhooksModule.FailedDeliveries(ctx, offset, limit)
+					page := admindomain.DeadLetterPage{TotalCount: total, DeadLetters: make([]admindomain.DeadLetter, len(failed))}
+					for i, d := range failed {
+						page.DeadLetters[i] = admindomain.DeadLetter{ID: d.ID, Target: d.SubscriptionID, Subject: d.EventType, Attempts: d.Attempts, LastError: d.LastError, CreatedAt: d.CreatedAt}
+					}
+					return page, err
+				}),
+			},
+			AdminToken:   c.AdminToken,
+			SecuritySink: c.SecuritySink,
+		}), nil
+	}}
+}
+
+// adminCancelError translates the errors of orders.Module.CancelOrder for the
+// admin module, which does not know the orders sentinels.
+func adminCancelError(err error) error {
+	switch {
+	case errors.Is(err, ordersdomain.ErrOrderNotFound):
+		return admindomain.ErrOrderNotFound
+	case errors.Is(err, ordersdomain.ErrInvalidOrderID),
+		errors.Is(err, ordersdomain.ErrCancelReasonTooLong):
+		return fault.Wrap(err, fault.Client)
+	case errors.Is(err, ordersdomain.ErrOrderAlreadyCancelled),
+		errors.Is(err, ordersdomain.ErrOrderCompleted):
+		return fault.Wrap(err, fault.Conflict)
+	}
+	return err
+}
+
+// measuredChannel records every delivery through a notification channel
+// provider as the notifications delivery SLI.
+type measuredChannel struct {
+	notificationsdomain.Channel
+	metrics *metrics.Registry
+}
+
+func (c measuredChannel) Send(ctx context.Context, msg notificationsdomain.Message) (string, error) {
+	start := time.Now()
+	messageID, err := c.Channel.Send(ctx, msg)
+	c.metrics.ObserveNotificationDelivery(time.Since(start), err)
+	return messageID, err
+}
+
+// promotionEngine adapts the promotions module to the orders module's
+// PromotionEngine port.
+func promotionEngine(m promotions.Module) ordersdomain.PromotionEngine {
+	return ordersdomain.PromotionEngineFunc(func(ctx context.Context, cart ordersdomain.PromotionCart) ([]ordersdomain.PromotionLine, error) {
+		lines := make([]promotions.CartLine, len(cart.Items))
+		for i, item := range cart.Items {
+			lines[i] = promotions.CartLine{
+				ProductID: item.ProductID,
+				Quantity:  item.Quantity,
+				Amount:    item.Subtotal().Amount(),
+			}
+		}
+
+		applied, err := m.Evaluate(ctx, promotions.Cart{
+			OrderID:  cart.OrderID.String(),
+			UserID:   cart.UserRef.String(),
+			Currency: cart.Subtotal.Currency(),
+			Subtotal: cart.Subtotal.Amount(),
+			Lines:    lines,
+			At:       cart.At,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result := make([]ordersdomain.PromotionLine, len(applied))
+		for i, a := range applied {
+			amount, err := ordersdomain.NewMoney(a.Amount, cart.Subtotal.Currency())
+			if err != nil {
+				return nil, err
+			}
+			result[i] = ordersdomain.PromotionLine{PromotionID: a.PromotionID, Name: a.Name, Amount: amount}
+		}
+		return result, nil
+	})
+}
+
+// newEmailChannel selects the email provider from the notifications config
+// section. NOTIFICATIONS_EMAIL_PROVIDER is one of "noop" (default), "smtp"
+// or "sendgrid".
+func newEmailChannel(cfg *app.Config, logger *slog.Logger) (notificationsdomain.Channel, error) {
+	from := cfg.String("EMAIL_FROM", "no-reply@example.com")
+
+	switch provider := cfg.String("EMAIL_PROVIDER", "noop"); provider {
+	case "noop":
+		return notificationschannels.NewNoopChannel(notificationsdomain.ChannelEmail, logger), nil
+	case "smtp":
+		port, err := strconv.Atoi(Getenv("SMTP_PORT", "587"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid SMTP_PORT: %w", err)
+		}
+		return notificationschannels.NewSMTPChannel(notificationschannels.SMTPConfig{
+			Host:     Getenv("SMTP_HOST", "localhost"),
+			Port:     port,
+			Username: Getenv("SMTP_USERNAME", ""),
+			Password: Getenv("SMTP_PASSWORD", ""),
+			From:     from,
+		}), nil
+	case "sendgrid":
+		apiKey := Getenv("SENDGRID_API_KEY", "")
+		if apiKey == "" {
+			return nil, errors.New("SENDGRID_API_KEY is required for the sendgrid provider")
+		}
+		return notificationschannels.NewSendGridChannel(notificationschannels.SendGridConfig{
+			APIKey: apiKey,
+			From:   from,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown %s %q", cfg.Key("EMAIL_PROVIDER"), provider)
+	}
+}
@@ -0,0 +1,191 @@
+// Package seed loads fixtures — users, and orders with their items — into
+// the modules through their commands, for demos and integration
+// environments. Fixtures name their entities by key; the IDs derived from
+// the keys are deterministic (see UserID and OrderID), so tests can assert
+// on them.
+package seed
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders"
+)
+
+// Fixtures is the content of a fixtures file.
+type Fixtures struct {
+	Users  []User  `json:"users" yaml:"users"`
+	Orders []Order `json:"orders" yaml:"orders"`
+}
+
+// User is a user fixture.
+type User struct {
+	Key       string `json:"key" yaml:"key"`
+	Email     string `json:"email" yaml:"email"`
+	FirstName string `json:"first_name" yaml:"first_name"`
+	LastName  string `json:"last_name" yaml:"last_name"`
+}
+
+// Order is a draft order fixture.
+type Order struct {
+	Key string `json:"key" yaml:"key"`
+	// User is the key of a user in the fixtures, or the ID of an existing user.
+	User     string `json:"user" yaml:"user"`
+	Currency string `json:"currency" yaml:"currency"` // empty means the orders module's default
+	Items    []Item `json:"items" yaml:"items"`
+}
+
+// Item is an order line fixture.
+type Item struct {
+	ProductID   string `json:"product_id" yaml:"product_id"`
+	ProductName string `json:"product_name" yaml:"product_name"`
+	Quantity    int    `json:"quantity" yaml:"quantity"`
+	UnitPrice   int64  `json:"unit_price" yaml:"unit_price"` // in minor units
+}
+
+// namespace seeds the deterministic fixture IDs.
+var namespace = uuid.MustParse("0b9e4c1d-7a53-4e8f-b2d6-5c3f8a1e9d47")
+
+// UserID returns the ID of the user fixture key.
+func UserID(key string) string { return fixtureID("users", key) }
+
+// OrderID returns the ID of the order fixture key.
+func OrderID(key string) string { return fixtureID("orders", key) }
+
+// fixtureID derives an ID from a key, unless the key already is one.
+func fixtureID(kind, key string) string {
+	if _, err := uuid.Parse(key); err == nil {
+		return key
+	}
+	return uuid.NewSHA1(namespace, []byte(kind+"/"+key)).String()
+}
+
+// Load reads a fixtures file, in YAML (.yaml, .yml) or JSON (.json), and
+// validates it.
+func Load(path string) (Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Fixtures{}, fmt.Errorf("reading fixtures: %w", err)
+	}
+
+	var f Fixtures
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &f)
+	case ".json":
+		err = json.Unmarshal(data, &f)
+	default:
+		return Fixtures{}, fmt.Errorf("fixtures %s: unknown format %q, want .yaml, .yml or .json", path, ext)
+	}
+	if err != nil {
+		return Fixtures{}, fmt.Errorf("parsing fixtures %s: %w", path, err)
+	}
+	if err := f.Validate(); err != nil {
+		return Fixtures{}, fmt.Errorf("fixtures %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Validate checks that every fixture has a unique key and that every order
+// refers to a user, so that nothing is written for an inconsistent file.
+// The modules validate the fields themselves when seeding.
+func (f Fixtures) Validate() error {
+	var errs []error
+	users := make(map[string]bool, len(f.Users))
+	for i, u := range f.Users {
+		switch {
+		case u.Key == "":
+			errs = append(errs, fmt.Errorf("users[%d]: key is required", i))
+		case users[u.Key]:
+			errs = append(errs, fmt.Errorf("users[%d]: duplicate key %q", i, u.Key))
+		}
+		users[u.Key] = true
+	}
+
+	orders := make(map[string]bool, len(f.Orders))
+	for i, o := range f.Orders {
+		switch {
+		case o.Key == "":
+			errs = append(errs, fmt.Errorf("orders[%d]: key is required", i))
+		case orders[o.Key]:
+			errs = append(errs, fmt.Errorf("orders[%d]: duplicate key %q", i, o.Key))
+		}
+		orders[o.Key] = true
+
+		if _, err := uuid.Parse(o.User); !users[o.User] && err != nil {
+			errs = append(errs, fmt.Errorf("orders[%d]: user %q is neither a user fixture nor a user ID", i, o.User))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Users is the part of users.Module the seeder uses.
+type Users interface {
+	CreateUser(ctx context.Context, userID, email, firstName, lastName string) (string, error)
+}
+
+// Orders is the part of orders.Module the seeder uses.
+type Orders interface {
+	CreateOrder(ctx context.Context, orderID, userID, currency string) (string, error)
+	AddItem(ctx context.Context, orderID string, item orders.Item) error
+}
+
+// Seeder loads fixtures through the modules' commands, so the events,
+// their handlers and the audit log see the same changes as for API calls.
+type Seeder struct {
+	users  Users
+	orders Orders
+	logger *slog.Logger
+}
+
+// NewSeeder creates a Seeder.
+func NewSeeder(users Users, orders Orders, logger *slog.Logger) *Seeder {
+	return &Seeder{users: users, orders: orders, logger: logger.With("log", "seed")}
+}
+
+// Seed creates the users, then the orders with their items, in file order.
+// It stops at the first failure; what was created until then stays, so
+// seeding is meant for an empty database.
+func (s *Seeder) Seed(ctx context.Context, f Fixtures) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+
+	for _, u := range f.Users {
+		id, err := s.users.CreateUser(ctx, UserID(u.Key), u.Email, u.FirstName, u.LastName)
+		if err != nil {
+			return fmt.Errorf("seeding user %s: %w", u.Key, err)
+		}
+		s.logger.InfoContext(ctx, "user seeded", slog.String("key", u.Key), slog.String("user_id", id))
+	}
+
+	for _, o := range f.Orders {
+		id, err := s.orders.CreateOrder(ctx, OrderID(o.Key), UserID(o.User), o.Currency)
+		if err != nil {
+			return fmt.Errorf("seeding order %s: %w", o.Key, err)
+		}
+		for i, item := range o.Items {
+			err := s.orders.AddItem(ctx, id, orders.Item{
+				ProductID:   item.ProductID,
+				ProductName: item.ProductName,
+				Quantity:    item.Quantity,
+				UnitPrice:   item.UnitPrice,
+				Currency:    o.Currency,
+			})
+			if err != nil {
+				return fmt.Errorf("seeding order %s: item %d: %w", o.Key, i, err)
+			}
+		}
+		s.logger.InfoContext(ctx, "order seeded", slog.String("key", o.Key), slog.String("order_id", id), slog.Int("items", len(o.Items)))
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders"
+)
+
+// fakeModules records the commands the seeder runs.
+type fakeModules struct {
+	log      []string
+	orderErr error
+}
+
+func (m *fakeModules) CreateUser(ctx context.Context, userID, email, firstName, lastName string) (string, error) {
+	m.log = append(m.log, "user "+userID+" "+email)
+	return userID, nil
+}
+
+func (m *fakeModules) CreateOrder(ctx context.Context, orderID, userID, currency string) (string, error) {
+	m.log = append(m.log, "order "+orderID+" for "+userID+" in "+currency)
+	return orderID, m.orderErr
+}
+
+func (m *fakeModules) AddItem(ctx context.Context, orderID string, item orders.Item) error {
+	m.log = append(m.log, "item "+item.ProductID+" in "+item.Currency)
+	return nil
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestFixtureIDs_AreDeterministic(t *testing.T) {
+	if UserID("alice") != UserID("alice") {
+		t.Error("UserID(alice) differs between calls")
+	}
+	if UserID("alice") == OrderID("alice") {
+		t.Error("a user and an order with the same key share an ID")
+	}
+	const id = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	if got := UserID(id); got != id {
+		t.Errorf("UserID(%s) = %s, want the ID itself", id, got)
+	}
+}
+
+func TestLoad_YAMLAndJSON(t *testing.T) {
+	yamlPath := writeFile(t, "demo.yaml", `
+users:
+  - key: alice
+    email: alice@example.com
+    first_name: Alice
+    last_name: Liddell
+orders:
+  - key: alice-first
+    user: alice
+    currency: EUR
+    items:
+      - product_id: book-1
+        product_name: Looking-Glass
+        quantity: 2
+        unit_price: 1250
+`)
+	jsonPath := writeFile(t, "demo.json", `{
+  "users": [{"key": "alice", "email": "alice@example.com", "first_name": "Alice", "last_name": "Liddell"}],
+  "orders": [{"key": "alice-first", "user": "alice", "currency": "EUR",
+    "items": [{"product_id": "book-1", "product_name": "Looking-Glass", "quantity": 2, "unit_price": 1250}]}]
+}`)
+
+	fromYAML, err := Load(yamlPath)
+	if err != nil {
+		t.Fatalf("Load(yaml) error = %v", err)
+	}
+	fromJSON, err := Load(jsonPath)
+	if err != nil {
+		t.Fatalf("Load(json) error = %v", err)
+	}
+
+	item := fromYAML.Orders[0].Items[0]
+	if fromYAML.Users[0].FirstName != "Alice" || item.Quantity != 2 || item.UnitPrice != 1250 {
+		t.Errorf("Load(yaml) = %+v", fromYAML)
+	}
+	if fromJSON.Users[0] != fromYAML.Users[0] || fromJSON.Orders[0].Items[0] != item {
+		t.Errorf("Load(json) = %+v, want the same as from YAML", fromJSON)
+	}
+}
+
+func TestLoad_RejectsUnknownFormat(t *testing.T) {
+	if _, err := Load(writeFile(t, "demo.toml", "")); err == nil || !strings.Contains(err.Error(), "unknown format") {
+		t.Errorf("Load(toml) error = %v", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	f := Fixtures{
+		Users: []User{{Key: "alice"}, {Key: "alice"}, {}},
+		Orders: []Order{
+			{Key: "ok", User: "alice"},
+			{Key: "existing-user", User: "7c9e6679-7425-40de-944b-e07fc1f90ae7"},
+			{Key: "ok", User: "bob"},
+		},
+	}
+
+	err := f.Validate()
+	for _, want := range []string{
+		`users[1]: duplicate key "alice"`,
+		"users[2]: key is required",
+		`orders[2]: duplicate key "ok"`,
+		`orders[2]: user "bob" is neither a user fixture nor a user ID`,
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() error = %v, want it to contain %q", err, want)
+		}
+	}
+	if strings.Contains(err.Error(), "existing-user") || strings.Contains(err.Error(), "orders[1]") {
+		t.Errorf("Validate() rejected an order for an existing user ID: %v", err)
+	}
+}
+
+func TestSeeder_SeedsUsersThenOrdersWithFixtureIDs(t *testing.T) {
+	m := &fakeModules{}
+	s := NewSeeder(m, m, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	err := s.Seed(context.Background(), Fixtures{
+		Orders: []Order{{Key: "first", User: "alice", Currency: "EUR", Items: []Item{{ProductID: "book-1", Quantity: 1}}}},
+		Users:  []User{{Key: "alice", Email: "alice@example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("Seed() error = %v", err)
+	}
+
+	want := []string{
+		"user " + UserID("alice") + " alice@example.com",
+		"order " + OrderID("first") + " for " + UserID("alice") + " in EUR",
+		"item book-1 in EUR",
+	}
+	if got := strings.Join(m.log, "\n"); got != strings.Join(want, "\n") {
+		t.Errorf("commands =\n%s\nwant\n%s", got, strings.Join(want, "\n"))
+	}
+}
+
+func TestSeeder_StopsAtFirstFailure(t *testing.T) {
+	m := &fakeModules{orderErr: errors.New("user not found or deleted")}
+	s := NewSeeder(m, m, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	err := s.Seed(context.Background(), Fixtures{Orders: []Order{
+		{Key: "first", User: "7c9e6679-7425-40de-944b-e07fc1f90ae7", Items: []Item{{ProductID: "book-1"}}},
+		{Key: "second", User: "7c9e6679-7425-40de-944b-e07fc1f90ae7"},
+	}})
+
+	if err == nil || !strings.Contains(err.Error(), "seeding order first") {
+		t.Errorf("Seed() error = %v, want the failing order named", err)
+	}
+	if len(m.log) != 1 {
+		t.Errorf("commands after the failure = %v, want none", m.log[1:])
+	}
+}
@@ -0,0 +1,386 @@
+// Package app assembles the modular monolith. Each module registers itself
+// with a Builder: its name (which is also its config section), its
+// constructor and its health checks. Build constructs the modules in
+// registration order, so a module can only depend on modules registered
+// before it, then mounts their routes and their OpenAPI specification; Run starts their background work,
+// serves HTTP and coordinates the graceful shutdown.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"cloud.google.com/go/spanner"
+
+	"github.com/rai/clean-modularmonolith-go/internal/platform/eventbus"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/httpserver"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/metrics"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/shutdown"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/features"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/lifecycle"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// ShutdownTimeout bounds the graceful shutdown in Run.
+const ShutdownTimeout = 30 * time.Second
+
+// Platform is the shared infrastructure handed to every module constructor.
+type Platform struct {
+	Logger          *slog.Logger
+	Spanner         *spanner.Client
+	TxScope         transaction.Scope // read-write
+	ReadOnlyTxScope transaction.Scope
+	EventBus        *eventbus.EventBus
+	Metrics         *metrics.Registry
+	SecuritySink    security.Sink
+	Features        features.Flags
+	AdminToken      string
+
+	// Databases holds the databases of the modules that keep their tables
+	// apart, by module name: their Context has the database's Spanner
+	// client and transaction scopes instead of the shared ones. Optional.
+	Databases map[string]Database
+}
+
+// Database is a Spanner database of its own for a module, so that its data
+// can be moved out with it. Its transactions are separate from the other
+// modules': its pre-commit event handlers are not atomic with the
+// publisher's transaction, and it cannot join other modules' tables.
+type Database struct {
+	Spanner         *spanner.Client
+	TxScope         transaction.Scope // read-write
+	ReadOnlyTxScope transaction.Scope
+}
+
+// Module registers a module with the Builder.
+type Module struct {
+	// Name identifies the module in logs and errors and names its config
+	// section: Context.Config reads the <NAME>_* environment variables.
+	Name string
+
+	// New constructs the module. The result's RegisterRoutes is mounted on
+	// the router, and its lifecycle.Hooks are run by App.Run, when it has them.
+	New func(c *Context) (any, error)
+
+	// HealthChecks are run by GET /health. Optional.
+	HealthChecks []HealthCheck
+}
+
+// Context is what a module constructor gets: the platform, the module's
+// config section and the modules built before it.
+type Context struct {
+	Platform
+	Config *Config
+
+	name  string
+	built []built
+}
+
+type built struct {
+	name     string
+	instance any
+}
+
+// Get returns the module registered as name, which must have been
+// registered before the calling module.
+func Get[T any](c *Context, name string) (T, error) {
+	var zero T
+	i := slices.IndexFunc(c.built, func(b built) bool { return b.name == name })
+	if i < 0 {
+		return zero, fmt.Errorf("%s depends on %s, which must be registered before it", c.name, name)
+	}
+	m, ok := c.built[i].instance.(T)
+	if !ok {
+		return zero, fmt.Errorf("%s depends on %s as %T, but it is a %T", c.name, name, zero, c.built[i].instance)
+	}
+	return m, nil
+}
+
+// Lookup returns the built module registered as name, for a process that
+// drives modules directly rather than through HTTP (e.g. cmd/admin).
+func Lookup[T any](a *App, name string) (T, error) {
+	var zero T
+	i := slices.IndexFunc(a.modules, func(b built) bool { return b.name == name })
+	if i < 0 {
+		return zero, fmt.Errorf("module %s is not registered", name)
+	}
+	m, ok := a.modules[i].instance.(T)
+	if !ok {
+		return zero, fmt.Errorf("module %s is a %T, not a %T", name, a.modules[i].instance, zero)
+	}
+	return m, nil
+}
+
+// CommandBus returns the bus the module registers its command handlers on.
+// Every command runs in its own span, is logged at debug level, is reported
+// to CommandRecorder and is validated, in that order, before its handler.
+func (c *Context) CommandBus() *command.Bus {
+	return command.NewBus(
+		command.Tracing(),
+		command.Logging(c.Logger),
+		command.Recording(c.CommandRecorder()),
+		command.Validation(),
+	)
+}
+
+// CommandRecorder returns the recorder for the module's commands: every
+// module built so far that is a command.Recorder (e.g. the audit log, which
+// is therefore registered first) and the metrics registry.
+func (c *Context) CommandRecorder() command.Recorder {
+	var recorders []command.Recorder
+	for _, b := range c.built {
+		if r, ok := b.instance.(command.Recorder); ok {
+			recorders = append(recorders, r)
+		}
+	}
+	if c.Metrics != nil {
+		recorders = append(recorders, c.Metrics)
+	}
+	return command.Recorders(recorders...)
+}
+
+// Builder collects the modules and the platform endpoints.
+type Builder struct {
+	platform   Platform
+	modules    []Module
+	handlers   []route
+	checks     []HealthCheck
+	onShutdown []phase
+}
+
+type phase struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+type route struct {
+	pattern string
+	handler http.Handler
+}
+
+// NewBuilder returns a Builder for modules built on p.
+func NewBuilder(p Platform) *Builder {
+	return &Builder{platform: p}
+}
+
+// Register adds a module. Modules are built, started and their routes
+// mounted in registration order, and shut down in reverse.
+func (b *Builder) Register(m Module) *Builder {
+	b.modules = append(b.modules, m)
+	return b
+}
+
+// Handle adds a platform endpoint, e.g. GET /metrics.
+func (b *Builder) Handle(pattern string, h http.Handler) *Builder {
+	b.handlers = append(b.handlers, route{pattern: pattern, handler: h})
+	return b
+}
+
+// HealthCheck adds a platform health check, e.g. the database.
+func (b *Builder) HealthCheck(name string, check func(ctx context.Context) error) *Builder {
+	b.checks = append(b.checks, HealthCheck{Name: name, Check: check})
+	return b
+}
+
+// OnShutdown adds a shutdown phase run after the modules are shut down and
+// the event bus is drained, in order of addition (e.g. waiting for
+// transactions, then closing the Spanner client).
+func (b *Builder) OnShutdown(name string, run func(ctx context.Context) error) *Builder {
+	b.onShutdown = append(b.onShutdown, phase{name: name, run: run})
+	return b
+}
+
+// Build constructs the modules and the router.
+func (b *Builder) Build() (*App, error) {
+	a := &App{
+		logger:     b.platform.Logger,
+		eventBus:   b.platform.EventBus,
+		checks:     slices.Clone(b.checks),
+		onShutdown: b.onShutdown,
+	}
+
+	for name := range b.platform.Databases {
+		if !slices.ContainsFunc(b.modules, func(m Module) bool { return m.Name == name }) {
+			return nil, fmt.Errorf("database configured for unknown module %s", name)
+		}
+	}
+
+	var modules []built
+	for _, m := range b.modules {
+		if slices.ContainsFunc(modules, func(b built) bool { return b.name == m.Name }) {
+			return nil, fmt.Errorf("module %s registered twice", m.Name)
+		}
+		platform := b.platform
+		if db, ok := platform.Databases[m.Name]; ok {
+			platform.Spanner, platform.TxScope, platform.ReadOnlyTxScope = db.Spanner, db.TxScope, db.ReadOnlyTxScope
+		}
+		c := &Context{Platform: platform, Config: NewConfig(m.Name), name: m.Name, built: modules}
+		instance, err := m.New(c)
+		if err == nil {
+			err = c.Config.Err()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("building module %s: %w", m.Name, err)
+		}
+		modules = append(modules, built{name: m.Name, instance: instance})
+		for _, check := range m.HealthChecks {
+			check.Name = m.Name + "." + check.Name
+			a.checks = append(a.checks, check)
+		}
+	}
+	a.modules = modules
+	if a.eventBus != nil {
+		a.eventBus.LogSubscriptions()
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /health", a.HealthHandler())
+	for _, r := range b.handlers {
+		mux.Handle(r.pattern, r.handler)
+	}
+	// Each module registers its own routes (same pattern as event subscriptions)
+	// and documents them in the OpenAPI specification
+	spec := openapi.New("clean-modularmonolith-go", "1.0.0")
+	for _, m := range modules {
+		if r, ok := m.instance.(interface{ RegisterRoutes(*http.ServeMux) }); ok {
+			r.RegisterRoutes(mux)
+		}
+		if d, ok := m.instance.(interface{ Operations() []openapi.Operation }); ok {
+			spec.Add(m.name, d.Operations()...)
+		}
+	}
+	mux.Handle("GET /openapi.json", openapi.Handler(spec))
+	a.handler = mux
+	return a, nil
+}
+
+// App is the built application.
+type App struct {
+	logger     *slog.Logger
+	eventBus   *eventbus.EventBus
+	modules    []built
+	checks     []HealthCheck
+	handler    http.Handler
+	onShutdown []phase
+}
+
+// Handler returns the router with the platform endpoints and every module's
+// routes, without middleware.
+func (a *App) Handler() http.Handler {
+	return a.handler
+}
+
+// HealthHandler returns the GET /health handler on its own, for a process
+// that serves no module routes.
+func (a *App) HealthHandler() http.Handler {
+	return healthHandler(a.checks)
+}
+
+// Start starts the modules' background work in registration order. If a
+// module fails to start, those already started are shut down again.
+func (a *App) Start(ctx context.Context) error {
+	for i, m := range a.modules {
+		hooks, ok := m.instance.(lifecycle.Hooks)
+		if !ok {
+			continue
+		}
+		if err := hooks.Start(ctx); err != nil {
+			a.shutdownModules(ctx, a.modules[:i])
+			return fmt.Errorf("starting module %s: %w", m.name, err)
+		}
+	}
+	return nil
+}
+
+// Shutdown drains the event handlers in progress, shuts the modules down in
+// reverse order (so that a module stops after the modules that depend on
+// it), drains the events their background jobs published and closes the
+// event bus, then runs the OnShutdown phases.
+func (a *App) Shutdown(ctx context.Context) error {
+	return a.coordinator(nil).Shutdown(ctx)
+}
+
+// coordinator returns the shutdown phases, starting with stopping servers.
+func (a *App) coordinator(servers []*httpserver.Server) *shutdown.Coordinator {
+	c := shutdown.NewCoordinator(a.logger)
+	for _, s := range servers {
+		c.Add("http server "+s.Addr(), s.Shutdown)
+	}
+	if a.eventBus != nil {
+		c.Add("event handlers", a.eventBus.Drain)
+	}
+	c.Add("modules", func(ctx context.Context) error {
+		return a.shutdownModules(ctx, a.modules)
+	})
+	if a.eventBus != nil {
+		c.Add("event bus", func(ctx context.Context) error {
+			defer a.eventBus.Close()
+			return a.eventBus.Drain(ctx)
+		})
+	}
+	for _, p := range a.onShutdown {
+		c.Add(p.name, p.run)
+	}
+	return c
+}
+
+func (a *App) shutdownModules(ctx context.Context, modules []built) error {
+	var errs []error
+	for _, m := range slices.Backward(modules) {
+		hooks, ok := m.instance.(lifecycle.Hooks)
+		if !ok {
+			continue
+		}
+		if err := hooks.Shutdown(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("shutting down module %s: %w", m.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run starts the modules and the servers, waits until ctx is done or a
+// server fails, then stops accepting requests, waits for those in progress
+// and shuts down (see Shutdown) within ShutdownTimeout.
+func (a *App) Run(ctx context.Context, servers ...*httpserver.Server) error {
+	if err := a.Start(ctx); err != nil {
+		return err
+	}
+	return a.Serve(ctx, servers...)
+}
+
+// Serve is Run without starting the modules' background work, for a
+// process that leaves it to a worker.
+func (a *App) Serve(ctx context.Context, servers ...*httpserver.Server) error {
+	serveErr := make(chan error, len(servers))
+	for _, s := range servers {
+		go func() {
+			if err := s.Start(); err != nil {
+				serveErr <- fmt.Errorf("server %s: %w", s.Addr(), err)
+			}
+		}()
+	}
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-serveErr:
+	}
+
+	a.logger.Info("shutting down server...")
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ShutdownTimeout)
+	defer cancel()
+
+	if serr := a.coordinator(servers).Shutdown(shutdownCtx); serr != nil {
+		a.logger.Error("shutdown incomplete", slog.Any("error", serr))
+	}
+	a.logger.Info("server stopped")
+	return err
+}
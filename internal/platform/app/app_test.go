@@ -0,0 +1,305 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// fakeModule records its lifecycle in a shared log.
+type fakeModule struct {
+	name     string
+	log      *[]string
+	startErr error
+}
+
+func (m *fakeModule) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /"+m.name, func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, m.name)
+	})
+}
+
+func (m *fakeModule) Operations() []openapi.Operation {
+	return []openapi.Operation{{Pattern: "GET /" + m.name, Summary: "Get " + m.name}}
+}
+
+func (m *fakeModule) Start(ctx context.Context) error {
+	*m.log = append(*m.log, "start "+m.name)
+	return m.startErr
+}
+
+func (m *fakeModule) Shutdown(ctx context.Context) error {
+	*m.log = append(*m.log, "shutdown "+m.name)
+	return nil
+}
+
+// recorderModule is a module that records commands, like the audit log.
+type recorderModule struct {
+	records []command.Record
+}
+
+func (m *recorderModule) RecordCommand(ctx context.Context, rec command.Record) {
+	m.records = append(m.records, rec)
+}
+
+func testPlatform() Platform {
+	return Platform{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+}
+
+func fake(name string, log *[]string) Module {
+	return Module{Name: name, New: func(c *Context) (any, error) {
+		return &fakeModule{name: name, log: log}, nil
+	}}
+}
+
+func TestBuild_ModulesSeeOnlyEarlierModules(t *testing.T) {
+	var log []string
+	b := NewBuilder(testPlatform()).
+		Register(fake("users", &log)).
+		Register(Module{Name: "orders", New: func(c *Context) (any, error) {
+			if _, err := Get[*fakeModule](c, "users"); err != nil {
+				return nil, err
+			}
+			_, err := Get[*fakeModule](c, "payments")
+			return nil, err
+		}}).
+		Register(fake("payments", &log))
+
+	_, err := b.Build()
+	if err == nil || !strings.Contains(err.Error(), "orders depends on payments, which must be registered before it") {
+		t.Errorf("Build() error = %v, want a registration order error", err)
+	}
+}
+
+func TestLookup_ReturnsBuiltModule(t *testing.T) {
+	var log []string
+	a, err := NewBuilder(testPlatform()).Register(fake("users", &log)).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if m, err := Lookup[*fakeModule](a, "users"); err != nil || m.name != "users" {
+		t.Errorf("Lookup(users) = %v, %v", m, err)
+	}
+	if _, err := Lookup[*fakeModule](a, "orders"); err == nil {
+		t.Error("Lookup(orders) succeeded for an unregistered module")
+	}
+	if _, err := Lookup[*recorderModule](a, "users"); err == nil {
+		t.Error("Lookup(users) succeeded with the wrong type")
+	}
+}
+
+func TestBuild_ReportsInvalidConfig(t *testing.T) {
+	t.Setenv("ORDERS_MAX_ITEMS", "lots")
+	b := NewBuilder(testPlatform()).Register(Module{Name: "orders", New: func(c *Context) (any, error) {
+		c.Config.Int("MAX_ITEMS", 100)
+		c.Config.Duration("DRAFT_TTL", 0)
+		return nil, nil
+	}})
+
+	_, err := b.Build()
+	if err == nil || !strings.Contains(err.Error(), `building module orders: invalid ORDERS_MAX_ITEMS: "lots"`) {
+		t.Errorf("Build() error = %v", err)
+	}
+}
+
+// stubScope is a transaction.Scope that runs fn without a transaction.
+type stubScope struct{ name string }
+
+func (stubScope) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestBuild_ModuleDatabaseReplacesSharedScopes(t *testing.T) {
+	shared, own := &stubScope{"shared"}, &stubScope{"orders"}
+	p := testPlatform()
+	p.TxScope, p.ReadOnlyTxScope = shared, shared
+	p.Databases = map[string]Database{"orders": {TxScope: own, ReadOnlyTxScope: own}}
+
+	scopes := map[string]transaction.Scope{}
+	record := func(name string) Module {
+		return Module{Name: name, New: func(c *Context) (any, error) {
+			scopes[name] = c.TxScope
+			return nil, nil
+		}}
+	}
+	if _, err := NewBuilder(p).Register(record("users")).Register(record("orders")).Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if scopes["users"] != shared || scopes["orders"] != own {
+		t.Errorf("TxScope = %v, want the shared scope for users and its own for orders", scopes)
+	}
+}
+
+func TestBuild_RejectsDatabaseOfUnknownModule(t *testing.T) {
+	p := testPlatform()
+	p.Databases = map[string]Database{"payments": {}}
+
+	_, err := NewBuilder(p).Register(Module{Name: "orders", New: func(c *Context) (any, error) { return nil, nil }}).Build()
+	if err == nil || !strings.Contains(err.Error(), "database configured for unknown module payments") {
+		t.Errorf("Build() error = %v", err)
+	}
+}
+
+func TestContext_CommandRecorderIncludesEarlierRecorders(t *testing.T) {
+	audit := &recorderModule{}
+	var rec command.Recorder
+	b := NewBuilder(testPlatform()).
+		Register(Module{Name: "audit", New: func(c *Context) (any, error) { return audit, nil }}).
+		Register(Module{Name: "orders", New: func(c *Context) (any, error) {
+			rec = c.CommandRecorder()
+			return nil, nil
+		}})
+	if _, err := b.Build(); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	rec.RecordCommand(context.Background(), command.Record{Module: "orders", Name: "SubmitOrder"})
+	if len(audit.records) != 1 {
+		t.Errorf("audit recorded %d commands, want 1", len(audit.records))
+	}
+}
+
+func TestApp_LifecycleOrder(t *testing.T) {
+	var log []string
+	a, err := NewBuilder(testPlatform()).
+		Register(fake("users", &log)).
+		Register(fake("orders", &log)).
+		OnShutdown("spanner", func(ctx context.Context) error {
+			log = append(log, "close spanner")
+			return nil
+		}).
+		OnShutdown("tracing", func(ctx context.Context) error {
+			log = append(log, "flush traces")
+			return nil
+		}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err := a.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	if err := a.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+
+	want := "start users,start orders,shutdown orders,shutdown users,close spanner,flush traces"
+	if got := strings.Join(log, ","); got != want {
+		t.Errorf("lifecycle = %s, want %s", got, want)
+	}
+}
+
+func TestApp_ServeDoesNotStartModules(t *testing.T) {
+	var log []string
+	a, err := NewBuilder(testPlatform()).Register(fake("orders", &log)).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := a.Serve(ctx); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	if got := strings.Join(log, ","); got != "shutdown orders" {
+		t.Errorf("lifecycle = %s, want only the shutdown", got)
+	}
+}
+
+func TestApp_StartFailureShutsDownStartedModules(t *testing.T) {
+	var log []string
+	broken := errors.New("port in use")
+	a, err := NewBuilder(testPlatform()).
+		Register(fake("users", &log)).
+		Register(Module{Name: "orders", New: func(c *Context) (any, error) {
+			return &fakeModule{name: "orders", log: &log, startErr: broken}, nil
+		}}).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if err := a.Start(context.Background()); !errors.Is(err, broken) {
+		t.Errorf("Start() error = %v, want %v", err, broken)
+	}
+	want := "start users,start orders,shutdown users"
+	if got := strings.Join(log, ","); got != want {
+		t.Errorf("lifecycle = %s, want %s", got, want)
+	}
+}
+
+func TestApp_HandlerServesRoutesAndHealth(t *testing.T) {
+	var log []string
+	a, err := NewBuilder(testPlatform()).
+		Register(Module{
+			Name: "users",
+			New: func(c *Context) (any, error) {
+				return &fakeModule{name: "users", log: &log}, nil
+			},
+			HealthChecks: []HealthCheck{{Name: "search", Check: func(ctx context.Context) error {
+				return errors.New("connection refused")
+			}}},
+		}).
+		HealthCheck("database", func(ctx context.Context) error { return nil }).
+		Handle("GET /metrics", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	a.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil))
+	if rec.Body.String() != "users" {
+		t.Errorf("GET /users = %q, want the module's route", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	a.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	var health healthResponse
+	if err := json.NewDecoder(rec.Body).Decode(&health); err != nil {
+		t.Fatalf("decoding health: %v", err)
+	}
+	if rec.Code != http.StatusServiceUnavailable || health.Status != "unavailable" ||
+		health.Checks["database"] != "ok" || health.Checks["users.search"] != "connection refused" {
+		t.Errorf("GET /health = %d %+v", rec.Code, health)
+	}
+}
+
+func TestApp_HandlerServesOpenAPI(t *testing.T) {
+	var log []string
+	a, err := NewBuilder(testPlatform()).Register(fake("users", &log)).Register(fake("orders", &log)).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	a.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Tags []string `json:"tags"`
+		} `json:"paths"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&spec); err != nil {
+		t.Fatalf("decoding spec: %v", err)
+	}
+	for _, name := range []string{"users", "orders"} {
+		op, ok := spec.Paths["/"+name]["get"]
+		if !ok || len(op.Tags) != 1 || op.Tags[0] != name {
+			t.Errorf("spec paths = %+v, want GET /%s tagged %s", spec.Paths, name, name)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config reads a module's config section from the environment: key K of
+// module "orders" is ORDERS_K. Unset or empty variables take the default.
+// The first invalid value is kept and reported by Err, so that a
+// constructor can read all its settings before checking once; Build does
+// that check for module constructors.
+type Config struct {
+	prefix string
+	err    error
+}
+
+// NewConfig returns the config section of the named module.
+func NewConfig(section string) *Config {
+	return &Config{prefix: strings.ToUpper(section) + "_"}
+}
+
+// Err returns the first invalid value read, if any.
+func (c *Config) Err() error {
+	return c.err
+}
+
+// Key returns the environment variable holding key.
+func (c *Config) Key(key string) string {
+	return c.prefix + key
+}
+
+// String returns the value of key, or def.
+func (c *Config) String(key, def string) string {
+	if v := os.Getenv(c.Key(key)); v != "" {
+		return v
+	}
+	return def
+}
+
+// Bool returns the value of key, e.g. "true" or "false", or def.
+func (c *Config) Bool(key string, def bool) bool {
+	return parse(c, key, def, strconv.ParseBool)
+}
+
+// Int returns the value of key, or def.
+func (c *Config) Int(key string, def int) int {
+	return parse(c, key, def, strconv.Atoi)
+}
+
+// Int64 returns the value of key, or def.
+func (c *Config) Int64(key string, def int64) int64 {
+	return parse(c, key, def, func(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) })
+}
+
+// Duration returns the value of key, e.g. "30s", or def.
+func (c *Config) Duration(key string, def time.Duration) time.Duration {
+	return parse(c, key, def, time.ParseDuration)
+}
+
+// Check records err as invalid key, for values a constructor validates
+// itself (e.g. an enum).
+func (c *Config) Check(key string, err error) {
+	if err != nil && c.err == nil {
+		c.err = fmt.Errorf("invalid %s: %w", c.Key(key), err)
+	}
+}
+
+func parse[T any](c *Config, key string, def T, parseFn func(string) (T, error)) T {
+	s := os.Getenv(c.Key(key))
+	if s == "" {
+		return def
+	}
+	v, err := parseFn(s)
+	if err != nil {
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) {
+			err = numErr.Err
+		}
+		c.Check(key, fmt.Errorf("%q: %w", s, err))
+		return def
+	}
+	return v
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// healthCheckTimeout bounds each health check.
+const healthCheckTimeout = 2 * time.Second
+
+// HealthCheck checks a dependency, e.g. that the database is reachable.
+type HealthCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"` // name → "ok" or the error
+}
+
+// healthHandler runs the checks concurrently and answers 503 if any fails.
+func healthHandler(checks []HealthCheck) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := healthResponse{Status: "ok"}
+		status := http.StatusOK
+		if len(checks) > 0 {
+			resp.Checks = make(map[string]string, len(checks))
+		}
+
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		for _, hc := range checks {
+			wg.Go(func() {
+				ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+				defer cancel()
+				result := "ok"
+				if err := hc.Check(ctx); err != nil {
+					result = err.Error()
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				resp.Checks[hc.Name] = result
+				if result != "ok" {
+					resp.Status = "unavailable"
+					status = http.StatusServiceUnavailable
+				}
+			})
+		}
+		wg.Wait()
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(resp)
+	})
+}
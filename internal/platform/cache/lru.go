@@ -0,0 +1,106 @@
+// Package cache implements the cache.Store port of modules/shared/cache.
+//
+// LRU keeps the values in process memory, which suits a single instance:
+// invalidations reach only the instance that handled the event. A shared
+// store (e.g. Redis) for several instances implements the same interface
+// and is selected in bootstrap.
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/cache"
+)
+
+// LRU is an in-memory Store holding at most a fixed number of entries; the
+// least recently used one is evicted to make room for a new one.
+type LRU struct {
+	capacity int
+	now      func() time.Time
+
+	mu      sync.Mutex
+	order   *list.List // front is the most recently used
+	entries map[string]*list.Element
+}
+
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+var _ cache.Store = (*LRU)(nil)
+
+// NewLRU returns an LRU holding at most capacity entries.
+func NewLRU(capacity int) *LRU {
+	return &LRU{
+		capacity: max(capacity, 1),
+		now:      time.Now,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Get returns the value stored under key, unless it has expired.
+func (c *LRU) Get(_ context.Context, key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if !c.now().Before(e.expiresAt) {
+		c.remove(el)
+		return nil, false
+	}
+	c.order.MoveToFront(el)
+	return e.value, true
+}
+
+// Set stores value under key for ttl, evicting the least recently used
+// entry if the cache is full.
+func (c *LRU) Set(_ context.Context, key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if el, ok := c.entries[key]; ok {
+		e := el.Value.(*entry)
+		e.value, e.expiresAt = value, expiresAt
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+	if c.order.Len() > c.capacity {
+		c.remove(c.order.Back())
+	}
+}
+
+// Delete removes keys.
+func (c *LRU) Delete(_ context.Context, keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		if el, ok := c.entries[key]; ok {
+			c.remove(el)
+		}
+	}
+}
+
+// Len returns the number of entries, expired ones included.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRU) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*entry).key)
+}
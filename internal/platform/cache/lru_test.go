@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLRU_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(2)
+
+	c.Set(ctx, "a", []byte("1"), time.Minute)
+	c.Set(ctx, "b", []byte("2"), time.Minute)
+	c.Get(ctx, "a") // b is now the least recently used
+	c.Set(ctx, "c", []byte("3"), time.Minute)
+
+	if _, ok := c.Get(ctx, "b"); ok {
+		t.Error("b was not evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(ctx, key); !ok {
+			t.Errorf("%s was evicted", key)
+		}
+	}
+}
+
+func TestLRU_Expiry(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewLRU(10)
+	c.now = func() time.Time { return now }
+
+	c.Set(ctx, "a", []byte("1"), time.Minute)
+	now = now.Add(59 * time.Second)
+	if v, ok := c.Get(ctx, "a"); !ok || string(v) != "1" {
+		t.Errorf("Get() before expiry = %q, %v", v, ok)
+	}
+	now = now.Add(time.Second)
+	if _, ok := c.Get(ctx, "a"); ok {
+		t.Error("Get() after expiry hit")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len() = %d, want the expired entry removed", c.Len())
+	}
+}
+
+func TestLRU_SetReplacesAndDeleteRemoves(t *testing.T) {
+	ctx := context.Background()
+	c := NewLRU(10)
+
+	c.Set(ctx, "a", []byte("1"), time.Minute)
+	c.Set(ctx, "a", []byte("2"), time.Minute)
+	if v, _ := c.Get(ctx, "a"); string(v) != "2" || c.Len() != 1 {
+		t.Errorf("Get() = %q with %d entries, want 2 with 1", v, c.Len())
+	}
+
+	c.Delete(ctx, "a", "missing")
+	if _, ok := c.Get(ctx, "a"); ok {
+		t.Error("Get() after Delete hit")
+	}
+}
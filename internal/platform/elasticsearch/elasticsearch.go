@@ -3,6 +3,7 @@ package elasticsearch
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/elastic/go-elasticsearch/v8"
@@ -45,6 +46,18 @@ func NewElasticsearchClient(cfg Config) (*ElasticsearchClient, error) {
 
 var _ Client = (*ElasticsearchClient)(nil)
 
+// Ping checks that the cluster is reachable.
+func (c *ElasticsearchClient) Ping(ctx context.Context) error {
+	ok, err := c.client.Ping().Do(ctx)
+	if err != nil {
+		return fmt.Errorf("elasticsearch ping: %w", err)
+	}
+	if !ok {
+		return errors.New("elasticsearch ping: cluster unavailable")
+	}
+	return nil
+}
+
 func (c *ElasticsearchClient) Index(ctx context.Context, index string, doc Document) error {
 	_, err := c.client.Index(index).Id(doc.ID).Request(doc.Body).Do(ctx)
 	if err != nil {
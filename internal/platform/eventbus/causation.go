@@ -0,0 +1,80 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// ErrCausationCycle is returned, and post-commit deliveries are dropped, when
+// a handler would handle an event type it is already handling further up
+// the causation chain: the events would keep causing each other forever.
+var ErrCausationCycle = errors.New("event causation cycle")
+
+// ErrCausationTooDeep is returned when a causation chain grows past the
+// bus's limit without repeating, see WithMaxCausationDepth.
+var ErrCausationTooDeep = errors.New("event causation chain too deep")
+
+// cause is one delivery in a causation chain: handler handling an event.
+// The events the handler publishes are caused by it.
+type cause struct {
+	eventType events.EventType
+	eventID   string
+	handler   string
+}
+
+func (c cause) String() string {
+	return fmt.Sprintf("%s(%s)->%s", c.eventType, c.eventID, c.handler)
+}
+
+// causation is the chain of deliveries that led to the current one, oldest
+// first. It travels in the context, into detached post-commit contexts too.
+type causation []cause
+
+type causationKey struct{}
+
+func causationFrom(ctx context.Context) causation {
+	chain, _ := ctx.Value(causationKey{}).(causation)
+	return chain
+}
+
+// with returns a context whose chain is chain followed by c. The chain is
+// copied: sibling deliveries must not share its backing array.
+func (chain causation) with(ctx context.Context, c cause) context.Context {
+	return context.WithValue(ctx, causationKey{}, append(slices.Clip(chain), c))
+}
+
+// repeats reports whether the chain already contains c's handler handling
+// c's event type.
+func (chain causation) repeats(c cause) bool {
+	return slices.ContainsFunc(chain, func(prev cause) bool {
+		return prev.eventType == c.eventType && prev.handler == c.handler
+	})
+}
+
+func (chain causation) String() string {
+	links := make([]string, len(chain))
+	for i, c := range chain {
+		links[i] = c.String()
+	}
+	return strings.Join(links, " => ")
+}
+
+// checkCausation returns the error that forbids delivering d in ctx, if
+// any, and the context to deliver it in otherwise.
+func (b *EventBus) checkCausation(ctx context.Context, d delivery) (context.Context, causation, error) {
+	chain := causationFrom(ctx)
+	c := cause{eventType: d.events[0].EventType(), eventID: d.events[0].EventID(), handler: d.handler.HandlerName()}
+	full := append(slices.Clip(chain), c)
+	switch {
+	case chain.repeats(c):
+		return ctx, full, fmt.Errorf("%w: %s", ErrCausationCycle, full)
+	case b.maxCausationDepth > 0 && len(chain) >= b.maxCausationDepth:
+		return ctx, full, fmt.Errorf("%w (%d): %s", ErrCausationTooDeep, b.maxCausationDepth, full)
+	}
+	return chain.with(ctx, c), full, nil
+}
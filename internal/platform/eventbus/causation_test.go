@@ -0,0 +1,100 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// level names the event type of the i-th level of a chain, e.g. test.LevelAB.
+func level(i int) events.EventType {
+	return events.EventType(fmt.Sprintf("test.Level%c%c", 'A'+i/26, 'A'+i%26))
+}
+
+// chainBus subscribes one handler per level: the handler of level(i)
+// publishes level(i+1), and the last one publishes level(loopTo) if
+// loopTo >= 0.
+func chainBus(t *testing.T, bus *EventBus, levels, loopTo int) {
+	t.Helper()
+	for i := range levels {
+		next := i + 1
+		if next == levels {
+			if loopTo < 0 {
+				next = -1
+			} else {
+				next = loopTo
+			}
+		}
+		h := &testHandler{name: fmt.Sprintf("Level%dHandler", i), subdomain: "test", eventType: level(i), handleFn: func(ctx context.Context, event events.Event) error {
+			if next < 0 {
+				return nil
+			}
+			return bus.Publish(ctx, []events.Event{testEvent{BaseEvent: events.NewBaseEvent(level(next))}})
+		}}
+		if err := bus.Subscribe(level(i), h); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestPublish_AllowsDeepAcyclicChains(t *testing.T) {
+	bus := newTestBus()
+	chainBus(t, bus, 30, -1)
+
+	if err := bus.Publish(context.Background(), []events.Event{testEvent{BaseEvent: events.NewBaseEvent(level(0))}}); err != nil {
+		t.Fatalf("Publish() = %v, want a 30-level chain to pass", err)
+	}
+}
+
+func TestPublish_DetectsCausationCycle(t *testing.T) {
+	var logs strings.Builder
+	bus := NewEventBus(slog.New(slog.NewTextHandler(&logs, nil)))
+	chainBus(t, bus, 3, 1) // AA -> AB -> AC -> AB -> ...
+
+	err := bus.Publish(context.Background(), []events.Event{testEvent{BaseEvent: events.NewBaseEvent(level(0))}})
+
+	if !errors.Is(err, ErrCausationCycle) {
+		t.Fatalf("Publish() = %v, want ErrCausationCycle", err)
+	}
+	out := logs.String()
+	if !strings.Contains(out, "event causation violation") || !strings.Contains(out, "Level0Handler => test.LevelAB") {
+		t.Errorf("log does not show the chain:\n%s", out)
+	}
+}
+
+func TestPublish_WithMaxCausationDepth(t *testing.T) {
+	bus := NewEventBus(slog.Default(), WithMaxCausationDepth(3))
+	chainBus(t, bus, 5, -1)
+
+	err := bus.Publish(context.Background(), []events.Event{testEvent{BaseEvent: events.NewBaseEvent(level(0))}})
+
+	if !errors.Is(err, ErrCausationTooDeep) {
+		t.Fatalf("Publish() = %v, want ErrCausationTooDeep", err)
+	}
+}
+
+func TestPostCommit_DropsCyclicDelivery(t *testing.T) {
+	bus := newTestBus()
+
+	calls := 0
+	h := &testHandler{name: "EchoHandler", subdomain: "test", eventType: testEventType}
+	h.handleFn = func(ctx context.Context, event events.Event) error {
+		calls++
+		bus.processPostCommitEvents(detachContext(ctx), []events.Event{newTestEvent()})
+		return nil
+	}
+	if err := bus.SubscribePostCommit(testEventType, h); err != nil {
+		t.Fatal(err)
+	}
+
+	bus.processPostCommitEvents(context.Background(), []events.Event{newTestEvent()})
+
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+}
@@ -5,22 +5,27 @@ package eventbus
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
+	"runtime/debug"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/rai/clean-modularmonolith-go/internal/platform/logging"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/metrics"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/shutdown"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/watchdog"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/fault"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 )
 
-type depthKey struct{}
-
 // EventBus manages event subscriptions and publishing.
 // It implements events.Subscriber, events.Publisher,
 // events.PostCommitSubscriber, and events.PostCommitPublisher.
@@ -32,8 +37,64 @@ type EventBus struct {
 	handlers           map[events.EventType][]events.Handler // pre-commit handlers
 	postCommitHandlers map[events.EventType][]events.Handler // post-commit handlers
 	logger             *slog.Logger
-	maxDepth           int           // max depth of event processing.
+	maxCausationDepth  int           // max length of a causation chain, 0 for none.
 	postCommitTimeout  time.Duration // per-handler timeout for post-commit handlers.
+	metrics            *metrics.Registry
+	watchdog           *watchdog.Watchdog
+	reporter           fault.Reporter
+	stats              *dispatchStats
+	inflight           shutdown.InFlight // post-commit dispatches in progress
+	decorate           func(events.Handler) events.Handler
+	closed             atomic.Bool
+}
+
+// Option configures an EventBus.
+type Option func(*EventBus)
+
+// WithMetrics records published events and handler outcomes in m.
+func WithMetrics(m *metrics.Registry) Option {
+	return func(b *EventBus) { b.metrics = m }
+}
+
+// WithLogSampling writes only the first of every `every` debug records with
+// the same message, such as the "event handled" record logged for each handler
+// invocation. Errors and subscription logs are never sampled.
+func WithLogSampling(every int) Option {
+	return func(b *EventBus) { b.logger = slog.New(logging.Sample(b.logger.Handler(), every)) }
+}
+
+// WithErrorReporter reports post-commit handler panics, and failures that are
+// not classified as client, conflict or transient errors, to r. Pre-commit
+// handler failures are returned to the publisher instead.
+func WithErrorReporter(r fault.Reporter) Option {
+	return func(b *EventBus) { b.reporter = r }
+}
+
+// WithSlowHandlerThreshold logs a "slow event handler" warning, with the
+// publisher's stack, when a handler is still running after d. Pre-commit
+// handlers run inside the publisher's transaction, so a slow one holds its
+// Spanner locks. Zero disables the warning.
+func WithSlowHandlerThreshold(d time.Duration) Option {
+	return func(b *EventBus) { b.watchdog = watchdog.New(b.logger, d) }
+}
+
+// defaultMaxCausationDepth bounds causation chains that never repeat, e.g. a
+// handler publishing an event of a new type at every level. It is well above
+// what legitimate flows need; cycles are detected regardless of it.
+const defaultMaxCausationDepth = 64
+
+// WithMaxCausationDepth bounds the number of handlers a causation chain may
+// pass through, event A handled by one that publishes B, handled by one that
+// publishes C, and so on. Cycles are detected whatever the bound; zero
+// removes it.
+func WithMaxCausationDepth(n int) Option {
+	return func(b *EventBus) { b.maxCausationDepth = n }
+}
+
+// WithHandlerDecorator wraps every handler subscribed afterwards, pre- and
+// post-commit, with decorate, e.g. chaos.Handler to inject faults.
+func WithHandlerDecorator(decorate func(events.Handler) events.Handler) Option {
+	return func(b *EventBus) { b.decorate = decorate }
 }
 
 var (
@@ -46,14 +107,19 @@ var (
 )
 
 // NewEventBus creates a new event bus.
-func NewEventBus(logger *slog.Logger) *EventBus {
-	return &EventBus{
+func NewEventBus(logger *slog.Logger, opts ...Option) *EventBus {
+	b := &EventBus{
 		handlers:           make(map[events.EventType][]events.Handler),
 		postCommitHandlers: make(map[events.EventType][]events.Handler),
 		logger:             logger,
-		maxDepth:           10,
+		maxCausationDepth:  defaultMaxCausationDepth,
 		postCommitTimeout:  30 * time.Second,
+		stats:              newDispatchStats(),
 	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
 }
 
 // LogSubscriptions logs all registered event subscriptions.
@@ -76,6 +142,8 @@ func (b *EventBus) LogSubscriptions() {
 
 // Subscribe registers a pre-commit handler for an event type.
 // Pre-commit handlers run inside the transaction boundary.
+// Subscribing to events.AnyEventType delivers every event to the handler,
+// after the handlers registered for the concrete type.
 // Returns an error if a handler with the same name is already registered for the event type.
 // Implements events.Subscriber.
 func (b *EventBus) Subscribe(eventType events.EventType, handler events.Handler) error {
@@ -86,6 +154,9 @@ func (b *EventBus) Subscribe(eventType events.EventType, handler events.Handler)
 		return fmt.Errorf("duplicate handler %q for event %s (pre-commit)", handler.HandlerName(), eventType)
 	}
 
+	if b.decorate != nil {
+		handler = b.decorate(handler)
+	}
 	b.handlers[eventType] = append(b.handlers[eventType], handler)
 	b.logger.Debug("subscribed to event", slog.String("event_type", eventType.String()), slog.String("phase", "pre-commit"))
 
@@ -94,6 +165,7 @@ func (b *EventBus) Subscribe(eventType events.EventType, handler events.Handler)
 
 // SubscribePostCommit registers a post-commit handler for an event type.
 // Post-commit handlers run after the transaction commits successfully.
+// events.AnyEventType subscribes the handler to every event.
 // Returns an error if a handler with the same name is already registered for the event type.
 // Implements events.PostCommitSubscriber.
 func (b *EventBus) SubscribePostCommit(eventType events.EventType, handler events.Handler) error {
@@ -104,6 +176,9 @@ func (b *EventBus) SubscribePostCommit(eventType events.EventType, handler event
 		return fmt.Errorf("duplicate handler %q for event %s (post-commit)", handler.HandlerName(), eventType)
 	}
 
+	if b.decorate != nil {
+		handler = b.decorate(handler)
+	}
 	b.postCommitHandlers[eventType] = append(b.postCommitHandlers[eventType], handler)
 	b.logger.Debug("subscribed to event", slog.String("event_type", eventType.String()), slog.String("phase", "post-commit"))
 
@@ -111,92 +186,148 @@ func (b *EventBus) SubscribePostCommit(eventType events.EventType, handler event
 }
 
 // Publish dispatches the given domain events to registered handlers synchronously.
+// The handlers of the whole batch are looked up under a single lock, and a
+// handler implementing events.BatchHandler receives all of its events at once.
 // Implements events.Publisher.
 func (b *EventBus) Publish(ctx context.Context, evts []events.Event) error {
-	for event := range slices.Values(evts) {
-		if err := b.processEvent(ctx, event); err != nil {
-			return err
+	if len(evts) == 0 {
+		return nil
+	}
+	plan := b.plan(b.handlers, evts)
+	for i, event := range evts {
+		b.metrics.ObserveEventPublished(event.EventType().String(), "pre-commit")
+		b.stats.observePublished(event.EventType(), "pre-commit")
+		for d := range slices.Values(plan[i]) {
+			if err := b.deliver(ctx, d); err != nil {
+				return fmt.Errorf("handler failed for event %s: %w", event.EventType().String(), err)
+			}
 		}
 	}
 	return nil
 }
 
-func (b *EventBus) processEvent(ctx context.Context, event events.Event) error {
-	depth := b.depthFromContext(ctx)
-	if depth >= b.maxDepth {
-		return errors.New("event processing depth exceeded")
+// deliver runs a pre-commit delivery, tracing, timing and logging it. It
+// refuses a delivery that would close a causation cycle.
+func (b *EventBus) deliver(ctx context.Context, d delivery) error {
+	event, handler := d.events[0], d.handler
+	ctx, chain, err := b.checkCausation(ctx, d)
+	if err != nil {
+		b.logger.LogAttrs(ctx, slog.LevelError, "event causation violation",
+			append(handlerAttrs(event, handler, "pre-commit"), slog.String("chain", chain.String()), slog.Any("error", err))...)
+		return fault.Wrap(err, fault.Fatal)
 	}
-	ctx = b.contextWithDepth(ctx, depth+1)
-
-	handlers := b.handlersFor(event.EventType())
-	for handler := range slices.Values(handlers) {
-		ctx, span := tracer.Start(ctx,
-			fmt.Sprintf("event.handle %s/%s", handler.Subdomain(), handler.HandlerName()),
-			trace.WithAttributes(attribute.String("event.type", event.EventType().String()), attribute.String("event.id", event.EventID()), attribute.String("event.handler", handler.HandlerName()), attribute.String("event.subdomain", handler.Subdomain())),
-		)
+	ctx, span := tracer.Start(ctx,
+		fmt.Sprintf("event.handle %s/%s", handler.Subdomain(), handler.HandlerName()),
+		trace.WithAttributes(d.spanAttrs()...),
+	)
+	defer span.End()
 
-		if err := handler.Handle(ctx, event); err != nil {
-			span.RecordError(err)
-			span.SetStatus(codes.Error, err.Error())
-			span.End()
-			return fmt.Errorf("handler failed for event %s: %w", event.EventType().String(), err)
-		}
-		span.End()
+	start := time.Now()
+	stopWatchdog := b.watchdog.Start(ctx, "slow event handler", handlerAttrs(event, handler, "pre-commit")...)
+	err = d.handle(ctx)
+	stopWatchdog()
+	b.metrics.ObserveEventHandled(event.EventType().String(), handler.HandlerName(), "pre-commit", time.Since(start), err)
+	b.stats.observeHandled(event.EventType(), handler.HandlerName(), "pre-commit", time.Since(start), err)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return err
 	}
+	b.logger.DebugContext(ctx, "event handled", append(d.logAttrs("pre-commit", time.Since(start)),
+		transaction.RetryInfoFromContext(ctx).LogAttr(),
+	)...)
 	return nil
 }
 
 // PublishPostCommit dispatches events to post-commit handlers asynchronously.
 // Handlers run in a separate goroutine with a detached context so they are not
 // cancelled when the caller's context (e.g. HTTP request) completes.
-// Errors are logged but not propagated.
+// Errors are logged but not propagated. After Close, events are dropped.
+// Like Publish, it hands a BatchHandler all of its events at once.
 // Implements events.PostCommitPublisher.
 func (b *EventBus) PublishPostCommit(ctx context.Context, evts []events.Event) {
+	if b.closed.Load() {
+		for event := range slices.Values(evts) {
+			b.logger.ErrorContext(ctx, "event bus closed, dropping post-commit event",
+				slog.String("event_type", event.EventType().String()),
+				slog.String("event_id", event.EventID()),
+			)
+		}
+		return
+	}
 	detachedCtx := detachContext(ctx)
 
 	copied := make([]events.Event, len(evts))
 	copy(copied, evts)
 
+	b.inflight.Add()
 	go func() {
-		for event := range slices.Values(copied) {
-			b.processPostCommitEvent(detachedCtx, event)
-		}
+		defer b.inflight.Done()
+		b.processPostCommitEvents(detachedCtx, copied)
 	}()
 }
 
-func (b *EventBus) processPostCommitEvent(ctx context.Context, event events.Event) {
-	handlers := b.postCommitHandlersFor(event.EventType())
+// Drain waits until the post-commit handlers in progress, and those of the
+// events they publish, have finished or ctx is done.
+func (b *EventBus) Drain(ctx context.Context) error {
+	return b.inflight.Wait(ctx)
+}
+
+// Close stops dispatching post-commit events: those published afterwards
+// are logged and dropped. Call it after Drain, once nothing that publishes
+// is running anymore.
+func (b *EventBus) Close() {
+	b.closed.Store(true)
+}
+
+// processPostCommitEvents dispatches evts one after the other, running the
+// deliveries of each event concurrently.
+func (b *EventBus) processPostCommitEvents(ctx context.Context, evts []events.Event) {
+	plan := b.plan(b.postCommitHandlers, evts)
+	for i, event := range evts {
+		b.processPostCommitEvent(ctx, event, plan[i])
+	}
+}
+
+func (b *EventBus) processPostCommitEvent(ctx context.Context, event events.Event, deliveries []delivery) {
+	b.metrics.ObserveEventPublished(event.EventType().String(), "post-commit")
+	b.stats.observePublished(event.EventType(), "post-commit")
 
 	var wg sync.WaitGroup
-	for _, handler := range handlers {
+	for _, d := range deliveries {
 		wg.Go(func() {
-			b.invokePostCommitHandler(ctx, event, handler)
+			b.invokePostCommitHandler(ctx, d)
 		})
 	}
 	wg.Wait()
 }
 
-// invokePostCommitHandler executes a single post-commit handler with panic
+// invokePostCommitHandler executes a single post-commit delivery with panic
 // recovery and tracing. Panics and errors are logged but not propagated.
-func (b *EventBus) invokePostCommitHandler(ctx context.Context, event events.Event, handler events.Handler) {
+func (b *EventBus) invokePostCommitHandler(ctx context.Context, d delivery) {
+	event, handler := d.events[0], d.handler
+	ctx, chain, err := b.checkCausation(ctx, d)
+	if err != nil {
+		b.logger.LogAttrs(ctx, slog.LevelError, "event causation violation, dropping post-commit delivery",
+			append(handlerAttrs(event, handler, "post-commit"), slog.String("chain", chain.String()), slog.Any("error", err))...)
+		b.report(ctx, event, handler, fault.Wrap(err, fault.Fatal), nil)
+		return
+	}
 	ctx, cancel := context.WithTimeout(ctx, b.postCommitTimeout)
 	defer cancel()
 
 	spanName := fmt.Sprintf("event.handle.post-commit %s/%s", handler.Subdomain(), handler.HandlerName())
-	options := []trace.SpanStartOption{
-		trace.WithAttributes(
-			attribute.String("event.type", event.EventType().String()),
-			attribute.String("event.id", event.EventID()),
-			attribute.String("event.handler", handler.HandlerName()),
-			attribute.String("event.subdomain", handler.Subdomain()),
-		),
-	}
-	ctx, span := tracer.Start(ctx, spanName, options...)
+	ctx, span := tracer.Start(ctx, spanName, trace.WithAttributes(d.spanAttrs()...))
 	defer span.End()
 
+	start := time.Now()
+	stopWatchdog := b.watchdog.Start(ctx, "slow event handler", handlerAttrs(event, handler, "post-commit")...)
+	defer stopWatchdog()
 	defer func() {
 		if r := recover(); r != nil {
 			err := fmt.Errorf("panic in post-commit handler: %v", r)
+			b.metrics.ObserveEventHandled(event.EventType().String(), handler.HandlerName(), "post-commit", time.Since(start), err)
+			b.stats.observeHandled(event.EventType(), handler.HandlerName(), "post-commit", time.Since(start), err)
 			span.RecordError(err)
 			span.SetStatus(codes.Error, err.Error())
 			b.logger.Error("post-commit handler panicked",
@@ -206,10 +337,13 @@ func (b *EventBus) invokePostCommitHandler(ctx context.Context, event events.Eve
 				slog.String("event_id", event.EventID()),
 				slog.Any("panic", r),
 			)
+			b.report(ctx, event, handler, fault.Wrap(err, fault.Fatal), debug.Stack())
 		}
 	}()
 
-	err := handler.Handle(ctx, event)
+	err = d.handle(ctx)
+	b.metrics.ObserveEventHandled(event.EventType().String(), handler.HandlerName(), "post-commit", time.Since(start), err)
+	b.stats.observeHandled(event.EventType(), handler.HandlerName(), "post-commit", time.Since(start), err)
 	if err != nil {
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
@@ -220,33 +354,134 @@ func (b *EventBus) invokePostCommitHandler(ctx context.Context, event events.Eve
 			slog.String("event_id", event.EventID()),
 			slog.Any("error", err),
 		)
+		b.report(ctx, event, handler, err, nil)
+		return
 	}
+	b.logger.DebugContext(ctx, "event handled", d.logAttrs("post-commit", time.Since(start))...)
 }
 
-func (b *EventBus) postCommitHandlersFor(eventType events.EventType) []events.Handler {
-	b.mu.RLock()
-	defer b.mu.RUnlock()
+// report passes a post-commit handler failure to the error reporter, unless
+// the error is classified as expected.
+func (b *EventBus) report(ctx context.Context, event events.Event, handler events.Handler, err error, stack []byte) {
+	kind := fault.KindOf(err)
+	if b.reporter == nil || !kind.Alerting() {
+		return
+	}
+	b.reporter.ReportError(ctx, fault.Report{
+		Err:    err,
+		Kind:   kind,
+		Source: "eventbus",
+		Tags: map[string]string{
+			"handler":    handler.HandlerName(),
+			"subdomain":  handler.Subdomain(),
+			"event_type": event.EventType().String(),
+			"event_id":   event.EventID(),
+		},
+		Stack: stack,
+	})
+}
 
-	handlers := b.postCommitHandlers[eventType]
-	result := make([]events.Handler, len(handlers))
-	copy(result, handlers)
-	return result
+// handlerAttrs identifies a handler invocation in watchdog warnings.
+func handlerAttrs(event events.Event, handler events.Handler, phase string) []slog.Attr {
+	return []slog.Attr{
+		slog.String("handler", handler.HandlerName()),
+		slog.String("subdomain", handler.Subdomain()),
+		slog.String("event_type", event.EventType().String()),
+		slog.String("event_id", event.EventID()),
+		slog.String("phase", phase),
+	}
 }
 
-func (b *EventBus) handlersFor(eventType events.EventType) []events.Handler {
+// delivery is one handler invocation planned for a published batch: events
+// holds a single event, or, for a BatchHandler, every event of the batch it
+// subscribed to.
+type delivery struct {
+	handler events.Handler
+	events  []events.Event
+}
+
+// plan looks up the handlers subscribed in registry for evts under a single
+// read lock. It returns the deliveries of each event, in order: the handlers
+// registered for the concrete type, then those subscribed to every event
+// type. A BatchHandler gets one delivery, at the first of its events.
+func (b *EventBus) plan(registry map[events.EventType][]events.Handler, evts []events.Event) [][]delivery {
 	b.mu.RLock()
 	defer b.mu.RUnlock()
 
-	handlers := b.handlers[eventType]
-	result := make([]events.Handler, len(handlers))
-	copy(result, handlers)
-	return result
+	type subscription struct {
+		eventType events.EventType
+		handler   string
+	}
+	type position struct{ event, delivery int }
+	batches := make(map[subscription]position)
+
+	plan := make([][]delivery, len(evts))
+	for i, event := range evts {
+		for eventType := range slices.Values([]events.EventType{event.EventType(), events.AnyEventType}) {
+			for handler := range slices.Values(registry[eventType]) {
+				if _, ok := handler.(events.BatchHandler); ok {
+					key := subscription{eventType, handler.HandlerName()}
+					if at, ok := batches[key]; ok {
+						d := &plan[at.event][at.delivery]
+						d.events = append(d.events, event)
+						continue
+					}
+					batches[key] = position{i, len(plan[i])}
+				}
+				plan[i] = append(plan[i], delivery{handler: handler, events: []events.Event{event}})
+			}
+		}
+	}
+	return plan
 }
 
-// detachContext creates a new context that carries trace span from the parent
-// but is not subject to the parent's cancellation or deadline.
+// handle calls HandleBatch for a batch of several events, Handle otherwise,
+// in the tenant the events were raised for (events.WithTenant): a batch
+// comes from one transaction, so its events share their tenant.
+func (d delivery) handle(ctx context.Context) error {
+	ctx = events.WithTenant(ctx, d.events[0])
+	if len(d.events) > 1 {
+		return d.handler.(events.BatchHandler).HandleBatch(ctx, d.events)
+	}
+	return d.handler.Handle(ctx, d.events[0])
+}
+
+func (d delivery) spanAttrs() []attribute.KeyValue {
+	event := d.events[0]
+	attrs := []attribute.KeyValue{
+		attribute.String("event.type", event.EventType().String()),
+		attribute.String("event.id", event.EventID()),
+		attribute.String("event.handler", d.handler.HandlerName()),
+		attribute.String("event.subdomain", d.handler.Subdomain()),
+	}
+	if len(d.events) > 1 {
+		attrs = append(attrs, attribute.Int("event.batch_size", len(d.events)))
+	}
+	return attrs
+}
+
+func (d delivery) logAttrs(phase string, duration time.Duration) []any {
+	event := d.events[0]
+	attrs := []any{
+		slog.String("handler", d.handler.HandlerName()),
+		slog.String("subdomain", d.handler.Subdomain()),
+		slog.String("event_type", event.EventType().String()),
+		slog.String("event_id", event.EventID()),
+		slog.String("phase", phase),
+		slog.Duration("duration", duration),
+	}
+	if len(d.events) > 1 {
+		attrs = append(attrs, slog.Int("batch_size", len(d.events)))
+	}
+	return attrs
+}
+
+// detachContext creates a new context that carries the trace span and the
+// causation chain from the parent but is not subject to the parent's
+// cancellation or deadline.
 func detachContext(ctx context.Context) context.Context {
-	return trace.ContextWithSpan(context.Background(), trace.SpanFromContext(ctx))
+	detached := context.WithValue(context.Background(), causationKey{}, causationFrom(ctx))
+	return trace.ContextWithSpan(detached, trace.SpanFromContext(ctx))
 }
 
 func (b *EventBus) isDuplicate(existing []events.Handler, handler events.Handler) bool {
@@ -257,14 +492,3 @@ func (b *EventBus) isDuplicate(existing []events.Handler, handler events.Handler
 	}
 	return false
 }
-
-func (b *EventBus) depthFromContext(ctx context.Context) int {
-	if depth, ok := ctx.Value(depthKey{}).(int); ok {
-		return depth
-	}
-	return 0
-}
-
-func (b *EventBus) contextWithDepth(ctx context.Context, depth int) context.Context {
-	return context.WithValue(ctx, depthKey{}, depth)
-}
@@ -1,14 +1,22 @@
 package eventbus
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/fault"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
 )
 
 // testHandler is a configurable handler for testing.
@@ -26,6 +34,16 @@ func (h *testHandler) Handle(ctx context.Context, event events.Event) error {
 	return h.handleFn(ctx, event)
 }
 
+// testBatchHandler is a testHandler that also handles batches.
+type testBatchHandler struct {
+	testHandler
+	handleBatchFn func(ctx context.Context, evts []events.Event) error
+}
+
+func (h *testBatchHandler) HandleBatch(ctx context.Context, evts []events.Event) error {
+	return h.handleBatchFn(ctx, evts)
+}
+
 // testEvent is a minimal event for testing.
 type testEvent struct {
 	events.BaseEvent
@@ -81,13 +99,13 @@ func TestPostCommit_PanicRecovery(t *testing.T) {
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
-		bus.processPostCommitEvent(context.Background(), newTestEvent())
+		bus.processPostCommitEvents(context.Background(), []events.Event{newTestEvent()})
 	}()
 
 	select {
 	case <-done:
 	case <-time.After(5 * time.Second):
-		t.Fatal("processPostCommitEvent did not complete in time")
+		t.Fatal("processPostCommitEvents did not complete in time")
 	}
 
 	mu.Lock()
@@ -126,7 +144,7 @@ func TestPostCommit_ErrorLogged_NotPropagated(t *testing.T) {
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
-		bus.processPostCommitEvent(context.Background(), newTestEvent())
+		bus.processPostCommitEvents(context.Background(), []events.Event{newTestEvent()})
 	}()
 
 	select {
@@ -166,13 +184,13 @@ func TestPostCommit_Timeout(t *testing.T) {
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
-		bus.processPostCommitEvent(context.Background(), newTestEvent())
+		bus.processPostCommitEvents(context.Background(), []events.Event{newTestEvent()})
 	}()
 
 	select {
 	case <-done:
 	case <-time.After(5 * time.Second):
-		t.Fatal("processPostCommitEvent did not complete — timeout not working")
+		t.Fatal("processPostCommitEvents did not complete — timeout not working")
 	}
 
 	if ctxErr != context.DeadlineExceeded {
@@ -214,7 +232,7 @@ func TestPostCommit_HandlersRunConcurrently(t *testing.T) {
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
-		bus.processPostCommitEvent(context.Background(), newTestEvent())
+		bus.processPostCommitEvents(context.Background(), []events.Event{newTestEvent()})
 	}()
 
 	// Drain the barrier — both handlers must send before either can finish.
@@ -266,15 +284,433 @@ func TestPostCommit_PanicInParallel_OtherHandlerStillRuns(t *testing.T) {
 	done := make(chan struct{})
 	go func() {
 		defer close(done)
-		bus.processPostCommitEvent(context.Background(), newTestEvent())
+		bus.processPostCommitEvents(context.Background(), []events.Event{newTestEvent()})
 	}()
 
 	select {
 	case <-done:
 	case <-time.After(5 * time.Second):
-		t.Fatal("processPostCommitEvent did not complete")
+		t.Fatal("processPostCommitEvents did not complete")
 	}
 
 	// completed.Done() was called, so Wait returns immediately
 	completed.Wait()
 }
+
+// --- Wildcard subscriptions ---
+
+func TestPublish_WildcardHandlerRunsAfterTypedHandlers(t *testing.T) {
+	bus := newTestBus()
+
+	var called []string
+	record := func(name string) func(ctx context.Context, event events.Event) error {
+		return func(ctx context.Context, event events.Event) error {
+			called = append(called, name)
+			return nil
+		}
+	}
+
+	wildcard := &testHandler{name: "AuditHandler", subdomain: "test", eventType: events.AnyEventType, handleFn: record("wildcard")}
+	typed := &testHandler{name: "TypedHandler", subdomain: "test", eventType: testEventType, handleFn: record("typed")}
+
+	if err := bus.Subscribe(events.AnyEventType, wildcard); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Subscribe(testEventType, typed); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bus.Publish(context.Background(), []events.Event{newTestEvent()}); err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(called) != "[typed wildcard]" {
+		t.Errorf("handlers called = %v, want [typed wildcard]", called)
+	}
+}
+
+func TestPostCommit_WildcardHandlerReceivesEveryEvent(t *testing.T) {
+	bus := newTestBus()
+
+	var mu sync.Mutex
+	var received []events.EventType
+	wildcard := &testHandler{
+		name:      "AuditHandler",
+		subdomain: "test",
+		eventType: events.AnyEventType,
+		handleFn: func(ctx context.Context, event events.Event) error {
+			mu.Lock()
+			defer mu.Unlock()
+			received = append(received, event.EventType())
+			return nil
+		},
+	}
+	if err := bus.SubscribePostCommit(events.AnyEventType, wildcard); err != nil {
+		t.Fatal(err)
+	}
+
+	const otherEventType events.EventType = "test.OtherHappened"
+	bus.processPostCommitEvents(context.Background(), []events.Event{newTestEvent()})
+	bus.processPostCommitEvents(context.Background(), []events.Event{testEvent{BaseEvent: events.NewBaseEvent(otherEventType)}})
+
+	if len(received) != 2 || received[0] != testEventType || received[1] != otherEventType {
+		t.Errorf("received = %v, want [%s %s]", received, testEventType, otherEventType)
+	}
+}
+
+func TestPostCommit_HandlerRunsInTheEventsTenant(t *testing.T) {
+	bus := newTestBus()
+
+	tenants := make(chan string, 1)
+	handler := &testHandler{
+		name:      "TenantHandler",
+		subdomain: "test",
+		eventType: testEventType,
+		handleFn: func(ctx context.Context, event events.Event) error {
+			tenants <- requestcontext.TenantID(ctx)
+			return nil
+		},
+	}
+	if err := bus.SubscribePostCommit(testEventType, handler); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := requestcontext.With(context.Background(), requestcontext.Values{TenantID: "acme"})
+	event := testEvent{BaseEvent: events.NewBaseEventWithContext(ctx, testEventType)}
+	// The detached context of post-commit handlers drops the request's values.
+	bus.PublishPostCommit(ctx, []events.Event{event})
+
+	select {
+	case got := <-tenants:
+		if got != "acme" {
+			t.Errorf("handler tenant = %q, want acme", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("handler not called")
+	}
+}
+
+func TestPublish_BatchHandlerReceivesEventsOfItsTypeAtOnce(t *testing.T) {
+	bus := newTestBus()
+
+	const otherEventType events.EventType = "test.OtherHappened"
+	var called []string
+	batch := &testBatchHandler{
+		testHandler: testHandler{name: "BatchHandler", subdomain: "test", eventType: testEventType, handleFn: func(ctx context.Context, event events.Event) error {
+			called = append(called, "single")
+			return nil
+		}},
+		handleBatchFn: func(ctx context.Context, evts []events.Event) error {
+			called = append(called, fmt.Sprintf("batch of %d", len(evts)))
+			return nil
+		},
+	}
+	other := &testHandler{name: "OtherHandler", subdomain: "test", eventType: otherEventType, handleFn: func(ctx context.Context, event events.Event) error {
+		called = append(called, "other")
+		return nil
+	}}
+	if err := bus.Subscribe(testEventType, batch); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Subscribe(otherEventType, other); err != nil {
+		t.Fatal(err)
+	}
+
+	evts := []events.Event{newTestEvent(), testEvent{BaseEvent: events.NewBaseEvent(otherEventType)}, newTestEvent()}
+	if err := bus.Publish(context.Background(), evts); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(called) != "[batch of 2 other]" {
+		t.Errorf("handlers called = %v, want [batch of 2 other]", called)
+	}
+
+	called = nil
+	if err := bus.Publish(context.Background(), []events.Event{newTestEvent()}); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(called) != "[single]" {
+		t.Errorf("handlers called = %v, want [single]", called)
+	}
+}
+
+func TestPostCommit_WildcardBatchHandlerReceivesWholeBatch(t *testing.T) {
+	bus := newTestBus()
+
+	var batches [][]events.Event
+	wildcard := &testBatchHandler{
+		testHandler: testHandler{name: "AuditHandler", subdomain: "test", eventType: events.AnyEventType},
+		handleBatchFn: func(ctx context.Context, evts []events.Event) error {
+			batches = append(batches, evts)
+			return nil
+		},
+	}
+	if err := bus.SubscribePostCommit(events.AnyEventType, wildcard); err != nil {
+		t.Fatal(err)
+	}
+
+	const otherEventType events.EventType = "test.OtherHappened"
+	evts := []events.Event{newTestEvent(), testEvent{BaseEvent: events.NewBaseEvent(otherEventType)}}
+	bus.processPostCommitEvents(context.Background(), evts)
+
+	if len(batches) != 1 || len(batches[0]) != 2 || batches[0][1].EventType() != otherEventType {
+		t.Errorf("batches = %v, want one batch of both events", batches)
+	}
+}
+
+func TestPublish_WithLogSampling_SamplesHandledRecords(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	bus := NewEventBus(logger, WithLogSampling(2))
+
+	handler := &testHandler{name: "Handler", subdomain: "test", eventType: testEventType, handleFn: func(ctx context.Context, event events.Event) error { return nil }}
+	if err := bus.Subscribe(testEventType, handler); err != nil {
+		t.Fatal(err)
+	}
+	buf.Reset()
+
+	for range 5 {
+		if err := bus.Publish(context.Background(), []events.Event{newTestEvent()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got := strings.Count(buf.String(), `"msg":"event handled"`); got != 3 {
+		t.Errorf("event handled records = %d, want 3 of 5", got)
+	}
+}
+
+func TestPublish_WithSlowHandlerThreshold_WarnsAboutSlowHandler(t *testing.T) {
+	var mu sync.Mutex
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(writerFunc(func(p []byte) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return buf.Write(p)
+	}), nil))
+	bus := NewEventBus(logger, WithSlowHandlerThreshold(10*time.Millisecond))
+
+	handler := &testHandler{name: "SlowHandler", subdomain: "test", eventType: testEventType, handleFn: func(ctx context.Context, event events.Event) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}}
+	if err := bus.Subscribe(testEventType, handler); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := bus.Publish(context.Background(), []events.Event{newTestEvent()}); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if got := strings.Count(buf.String(), `"msg":"slow event handler"`); got != 2 {
+		t.Errorf("slow event handler records = %d, want 2 (running and finished): %s", got, buf.String())
+	}
+	if !strings.Contains(buf.String(), `"handler":"SlowHandler"`) {
+		t.Errorf("warning does not name the handler: %s", buf.String())
+	}
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
+
+type reporterFunc func(ctx context.Context, r fault.Report)
+
+func (f reporterFunc) ReportError(ctx context.Context, r fault.Report) { f(ctx, r) }
+
+func TestPublish_WithHandlerDecorator_WrapsSubscribedHandlers(t *testing.T) {
+	var calls int
+	decorate := func(h events.Handler) events.Handler {
+		return &testHandler{name: h.HandlerName(), subdomain: h.Subdomain(), eventType: h.EventType(), handleFn: func(ctx context.Context, event events.Event) error {
+			// Deliver twice, like chaos.Handler with DuplicateRate 1.
+			if err := h.Handle(ctx, event); err != nil {
+				return err
+			}
+			return h.Handle(ctx, event)
+		}}
+	}
+	bus := NewEventBus(slog.Default(), WithHandlerDecorator(decorate))
+
+	handler := &testHandler{name: "CountingHandler", subdomain: "test", eventType: testEventType, handleFn: func(ctx context.Context, event events.Event) error {
+		calls++
+		return nil
+	}}
+	if err := bus.Subscribe(testEventType, handler); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Subscribe(testEventType, handler); err == nil {
+		t.Error("expected the decorated handler to still be detected as a duplicate")
+	}
+
+	if err := bus.Publish(context.Background(), []events.Event{newTestEvent()}); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("handler called %d times, want 2", calls)
+	}
+}
+
+func TestPostCommit_WithErrorReporter_ReportsOnlyUnexpectedFailures(t *testing.T) {
+	var mu sync.Mutex
+	var reports []fault.Report
+	bus := NewEventBus(slog.Default(), WithErrorReporter(reporterFunc(func(ctx context.Context, r fault.Report) {
+		mu.Lock()
+		defer mu.Unlock()
+		reports = append(reports, r)
+	})))
+
+	handlers := map[string]func() error{
+		"TransientHandler": func() error { return fault.Wrap(errors.New("spanner unavailable"), fault.Transient) },
+		"BrokenHandler":    func() error { return errors.New("unexpected") },
+		"PanicHandler":     func() error { panic("boom") },
+	}
+	for name, fn := range handlers {
+		handler := &testHandler{name: name, subdomain: "test", eventType: testEventType, handleFn: func(ctx context.Context, event events.Event) error { return fn() }}
+		if err := bus.SubscribePostCommit(testEventType, handler); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	bus.processPostCommitEvents(context.Background(), []events.Event{newTestEvent()})
+
+	mu.Lock()
+	defer mu.Unlock()
+	got := map[string]fault.Kind{}
+	for _, r := range reports {
+		got[r.Tags["handler"]] = r.Kind
+	}
+	if len(got) != 2 || got["BrokenHandler"] != fault.Unclassified || got["PanicHandler"] != fault.Fatal {
+		t.Errorf("reported = %v, want BrokenHandler and PanicHandler only", got)
+	}
+}
+
+func TestIntrospect_ListsSubscriptionsAndDispatchStats(t *testing.T) {
+	bus := newTestBus()
+	ok := func(ctx context.Context, event events.Event) error { return nil }
+
+	typed := &testHandler{name: "TypedHandler", subdomain: "orders", eventType: testEventType, handleFn: ok}
+	failing := &testHandler{name: "FailingHandler", subdomain: "notifications", eventType: testEventType, handleFn: func(ctx context.Context, event events.Event) error {
+		return errors.New("smtp down")
+	}}
+	wildcard := &testHandler{name: "AuditHandler", subdomain: "audit", eventType: events.AnyEventType, handleFn: ok}
+	if err := bus.Subscribe(testEventType, typed); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.SubscribePostCommit(testEventType, failing); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.SubscribePostCommit(events.AnyEventType, wildcard); err != nil {
+		t.Fatal(err)
+	}
+
+	for range 2 {
+		if err := bus.Publish(context.Background(), []events.Event{newTestEvent()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bus.processPostCommitEvents(context.Background(), []events.Event{newTestEvent()})
+
+	infos := bus.Introspect()
+	if len(infos) != 2 || infos[0].EventType != "*" || infos[1].EventType != testEventType.String() {
+		t.Fatalf("event types = %+v, want * and %s", infos, testEventType)
+	}
+
+	audit := infos[0].Subscriptions
+	if len(audit) != 1 || audit[0].Module != "audit" || audit[0].Phase != "post-commit" || audit[0].Handled != 1 {
+		t.Errorf("wildcard subscriptions = %+v", audit)
+	}
+
+	info := infos[1]
+	if info.PublishedPreCommit != 2 || info.PublishedPostCommit != 1 || info.LastPublishedAt == nil {
+		t.Errorf("published = %d/%d at %v, want 2/1", info.PublishedPreCommit, info.PublishedPostCommit, info.LastPublishedAt)
+	}
+	if len(info.Subscriptions) != 2 {
+		t.Fatalf("subscriptions = %+v, want 2", info.Subscriptions)
+	}
+	if s := info.Subscriptions[0]; s.Handler != "TypedHandler" || s.Phase != "pre-commit" || s.Handled != 2 || s.Failed != 0 || s.LastHandledAt == nil {
+		t.Errorf("pre-commit subscription = %+v", s)
+	}
+	if s := info.Subscriptions[1]; s.Handler != "FailingHandler" || s.Phase != "post-commit" || s.Failed != 1 || s.LastError != "smtp down" {
+		t.Errorf("post-commit subscription = %+v", s)
+	}
+}
+
+func TestIntrospectionHandler_FiltersByEventType(t *testing.T) {
+	bus := newTestBus()
+	handler := &testHandler{name: "TypedHandler", subdomain: "orders", eventType: testEventType, handleFn: func(ctx context.Context, event events.Event) error { return nil }}
+	if err := bus.Subscribe(testEventType, handler); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.Subscribe("test.OtherHappened", handler); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := httptest.NewRecorder()
+	bus.IntrospectionHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/eventbus?event_type=test.OtherHappened", nil))
+
+	var resp struct {
+		EventTypes []EventTypeInfo `json:"event_types"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if len(resp.EventTypes) != 1 || resp.EventTypes[0].EventType != "test.OtherHappened" || resp.EventTypes[0].Subscriptions[0].Module != "orders" {
+		t.Errorf("response = %s", rec.Body)
+	}
+}
+
+func TestDrain_WaitsForCascadingPostCommitHandlers(t *testing.T) {
+	bus := newTestBus()
+	const followUpType events.EventType = "test.FollowUp"
+
+	var mu sync.Mutex
+	var handled []string
+	record := func(name string) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled = append(handled, name)
+	}
+	bus.SubscribePostCommit(testEventType, &testHandler{
+		name: "Publisher", subdomain: "test", eventType: testEventType,
+		handleFn: func(ctx context.Context, event events.Event) error {
+			time.Sleep(10 * time.Millisecond)
+			bus.PublishPostCommit(ctx, []events.Event{testEvent{BaseEvent: events.NewBaseEvent(followUpType)}})
+			record("Publisher")
+			return nil
+		},
+	})
+	bus.SubscribePostCommit(followUpType, &testHandler{
+		name: "FollowUp", subdomain: "test", eventType: followUpType,
+		handleFn: func(ctx context.Context, event events.Event) error {
+			time.Sleep(10 * time.Millisecond)
+			record("FollowUp")
+			return nil
+		},
+	})
+
+	bus.PublishPostCommit(context.Background(), []events.Event{newTestEvent()})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := bus.Drain(ctx); err != nil {
+		t.Fatalf("Drain() error = %v", err)
+	}
+
+	mu.Lock()
+	got := strings.Join(handled, ",")
+	mu.Unlock()
+	if got != "Publisher,FollowUp" {
+		t.Errorf("handled before Drain returned = %q, want Publisher,FollowUp", got)
+	}
+
+	bus.Close()
+	bus.PublishPostCommit(context.Background(), []events.Event{newTestEvent()})
+	if err := bus.Drain(ctx); err != nil {
+		t.Fatalf("Drain() after Close error = %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(handled) != 2 {
+		t.Errorf("handled %v, want events published after Close dropped", handled)
+	}
+}
@@ -0,0 +1,225 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// EventTypeInfo describes an event type known to the bus: subscribed to,
+// published, or both.
+type EventTypeInfo struct {
+	EventType           string         `json:"event_type"`
+	PublishedPreCommit  uint64         `json:"published_pre_commit"`
+	PublishedPostCommit uint64         `json:"published_post_commit"`
+	LastPublishedAt     *time.Time     `json:"last_published_at,omitempty"`
+	Subscriptions       []Subscription `json:"subscriptions"`
+}
+
+// Subscription is a handler registered for an event type, with its dispatch
+// statistics since startup. Handlers subscribed to events.AnyEventType are
+// listed under "*" with statistics summed over every event they received.
+type Subscription struct {
+	Handler string `json:"handler"`
+	Module  string `json:"module"`
+	Phase   string `json:"phase"`
+	HandlerStats
+}
+
+// HandlerStats are the dispatch statistics of one subscription.
+type HandlerStats struct {
+	Handled           uint64     `json:"handled"`
+	Failed            uint64     `json:"failed"`
+	AverageDurationMS float64    `json:"average_duration_ms"`
+	LastHandledAt     *time.Time `json:"last_handled_at,omitempty"`
+	LastError         string     `json:"last_error,omitempty"`
+	LastErrorAt       *time.Time `json:"last_error_at,omitempty"`
+}
+
+// Introspect returns every event type with its subscriptions and dispatch
+// statistics, sorted by event type. Pre-commit subscriptions come first,
+// each phase in registration order.
+func (b *EventBus) Introspect() []EventTypeInfo {
+	b.mu.RLock()
+	subscribed := map[events.EventType][2][]events.Handler{}
+	for eventType, handlers := range b.handlers {
+		s := subscribed[eventType]
+		s[0] = slices.Clone(handlers)
+		subscribed[eventType] = s
+	}
+	for eventType, handlers := range b.postCommitHandlers {
+		s := subscribed[eventType]
+		s[1] = slices.Clone(handlers)
+		subscribed[eventType] = s
+	}
+	b.mu.RUnlock()
+
+	b.stats.mu.Lock()
+	defer b.stats.mu.Unlock()
+
+	eventTypes := slices.Collect(maps.Keys(subscribed))
+	for eventType := range b.stats.published {
+		if _, ok := subscribed[eventType]; !ok {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+	slices.Sort(eventTypes)
+
+	infos := make([]EventTypeInfo, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		info := EventTypeInfo{EventType: eventType.String(), Subscriptions: []Subscription{}}
+		if p, ok := b.stats.published[eventType]; ok {
+			info.PublishedPreCommit = p.preCommit
+			info.PublishedPostCommit = p.postCommit
+			info.LastPublishedAt = timePtr(p.last)
+		}
+		for i, phase := range []string{"pre-commit", "post-commit"} {
+			for _, h := range subscribed[eventType][i] {
+				info.Subscriptions = append(info.Subscriptions, Subscription{
+					Handler:      h.HandlerName(),
+					Module:       h.Subdomain(),
+					Phase:        phase,
+					HandlerStats: b.stats.handlerStats(eventType, h.HandlerName(), phase),
+				})
+			}
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// IntrospectionHandler serves Introspect as JSON, e.g. to verify that
+// orders really subscribes to users.UserDeleted. The event_type query
+// parameter narrows the response to one event type. It exposes the
+// application's wiring, so callers must guard it as an admin endpoint.
+func (b *EventBus) IntrospectionHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		infos := b.Introspect()
+		if eventType := r.URL.Query().Get("event_type"); eventType != "" {
+			infos = slices.DeleteFunc(infos, func(info EventTypeInfo) bool { return info.EventType != eventType })
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"event_types": infos})
+	})
+}
+
+type handlerKey struct {
+	eventType events.EventType
+	handler   string
+	phase     string
+}
+
+type publishedStats struct {
+	preCommit  uint64
+	postCommit uint64
+	last       time.Time
+}
+
+type handledStats struct {
+	handled     uint64
+	failed      uint64
+	total       time.Duration
+	last        time.Time
+	lastError   string
+	lastErrorAt time.Time
+}
+
+// dispatchStats counts publications and handler invocations since startup.
+type dispatchStats struct {
+	mu        sync.Mutex
+	published map[events.EventType]*publishedStats
+	handled   map[handlerKey]*handledStats
+}
+
+func newDispatchStats() *dispatchStats {
+	return &dispatchStats{
+		published: make(map[events.EventType]*publishedStats),
+		handled:   make(map[handlerKey]*handledStats),
+	}
+}
+
+func (s *dispatchStats) observePublished(eventType events.EventType, phase string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.published[eventType]
+	if !ok {
+		p = &publishedStats{}
+		s.published[eventType] = p
+	}
+	if phase == "pre-commit" {
+		p.preCommit++
+	} else {
+		p.postCommit++
+	}
+	p.last = time.Now()
+}
+
+func (s *dispatchStats) observeHandled(eventType events.EventType, handler, phase string, d time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := handlerKey{eventType: eventType, handler: handler, phase: phase}
+	h, ok := s.handled[key]
+	if !ok {
+		h = &handledStats{}
+		s.handled[key] = h
+	}
+	now := time.Now()
+	h.handled++
+	h.total += d
+	h.last = now
+	if err != nil {
+		h.failed++
+		h.lastError = err.Error()
+		h.lastErrorAt = now
+	}
+}
+
+// handlerStats returns the statistics of a subscription; for
+// events.AnyEventType they are summed over every event type. s.mu must be held.
+func (s *dispatchStats) handlerStats(eventType events.EventType, handler, phase string) HandlerStats {
+	var sum handledStats
+	for key, h := range s.handled {
+		if key.handler != handler || key.phase != phase || (eventType != events.AnyEventType && key.eventType != eventType) {
+			continue
+		}
+		sum.handled += h.handled
+		sum.failed += h.failed
+		sum.total += h.total
+		sum.last = latest(sum.last, h.last)
+		if h.lastErrorAt.After(sum.lastErrorAt) {
+			sum.lastError, sum.lastErrorAt = h.lastError, h.lastErrorAt
+		}
+	}
+
+	stats := HandlerStats{
+		Handled:       sum.handled,
+		Failed:        sum.failed,
+		LastHandledAt: timePtr(sum.last),
+		LastError:     sum.lastError,
+		LastErrorAt:   timePtr(sum.lastErrorAt),
+	}
+	if sum.handled > 0 {
+		stats.AverageDurationMS = float64(sum.total.Microseconds()) / 1000 / float64(sum.handled)
+	}
+	return stats
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	t = t.UTC()
+	return &t
+}
@@ -0,0 +1,67 @@
+// Package featureflags evaluates the feature flags that modules consult
+// through the features.Flags port.
+//
+// Flags asks its providers in order; the first one that knows a flag decides,
+// and the flag's default applies when none does. The built-in providers read
+// a fixed map or the runtime configuration (FEATURE_FLAGS and the "features"
+// of RUNTIME_CONFIG_FILE, reloadable); a LaunchDarkly-style service is added
+// by implementing Provider and putting it first.
+package featureflags
+
+import (
+	"context"
+
+	"github.com/rai/clean-modularmonolith-go/internal/platform/runtimeconfig"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/features"
+)
+
+// Provider evaluates flags by name.
+type Provider interface {
+	// Evaluate returns the flag's value; ok is false if the provider does
+	// not know the flag.
+	Evaluate(ctx context.Context, name string) (enabled, ok bool)
+}
+
+// Flags implements features.Flags over a chain of providers.
+type Flags struct {
+	providers []Provider
+}
+
+var _ features.Flags = (*Flags)(nil)
+
+// New returns Flags that ask providers in order.
+func New(providers ...Provider) *Flags {
+	return &Flags{providers: providers}
+}
+
+// Enabled reports whether flag is on.
+func (f *Flags) Enabled(ctx context.Context, flag features.Flag) bool {
+	for _, p := range f.providers {
+		if enabled, ok := p.Evaluate(ctx, flag.Name); ok {
+			return enabled
+		}
+	}
+	return flag.Default
+}
+
+// Static is a Provider with fixed values.
+type Static map[string]bool
+
+func (s Static) Evaluate(_ context.Context, name string) (bool, bool) {
+	enabled, ok := s[name]
+	return enabled, ok
+}
+
+// RuntimeConfig returns a Provider reading the features of the current
+// runtime configuration, so that reloading it toggles flags.
+func RuntimeConfig(store *runtimeconfig.Store) Provider {
+	return runtimeConfigProvider{store: store}
+}
+
+type runtimeConfigProvider struct {
+	store *runtimeconfig.Store
+}
+
+func (p runtimeConfigProvider) Evaluate(ctx context.Context, name string) (bool, bool) {
+	return Static(p.store.Current().Features).Evaluate(ctx, name)
+}
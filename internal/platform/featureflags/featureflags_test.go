@@ -0,0 +1,61 @@
+package featureflags
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/internal/platform/runtimeconfig"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/features"
+)
+
+func TestFlags_FirstProviderThatKnowsTheFlagDecides(t *testing.T) {
+	ctx := context.Background()
+	flags := New(Static{"orders.returns": false}, Static{"orders.returns": true, "reviews.photos": true})
+
+	tests := []struct {
+		flag features.Flag
+		want bool
+	}{
+		{features.Flag{Name: "orders.returns", Default: true}, false},
+		{features.Flag{Name: "reviews.photos"}, true},
+		{features.Flag{Name: "orders.gift_wrap", Default: true}, true},
+		{features.Flag{Name: "orders.gift_wrap"}, false},
+	}
+	for _, tt := range tests {
+		if got := flags.Enabled(ctx, tt.flag); got != tt.want {
+			t.Errorf("Enabled(%+v) = %v, want %v", tt.flag, got, tt.want)
+		}
+	}
+}
+
+func TestRuntimeConfig_FollowsReloads(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "runtime.json")
+	if err := os.WriteFile(path, []byte(`{}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	base := runtimeconfig.Config{Features: map[string]bool{"orders.returns": true}}
+	store, err := runtimeconfig.NewStore(base, path, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+	flags := New(RuntimeConfig(store))
+	returns := features.Flag{Name: "orders.returns"}
+
+	if !flags.Enabled(ctx, returns) {
+		t.Fatalf("flag from the environment is off")
+	}
+	if err := os.WriteFile(path, []byte(`{"features":{"orders.returns":false}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := store.Reload(ctx); err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if flags.Enabled(ctx, returns) {
+		t.Errorf("flag still on after the reload turned it off")
+	}
+}
@@ -0,0 +1,97 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// DebugConfig returns the configuration for the admin listener serving
+// DebugHandler. The write timeout leaves room for 30 second CPU profiles and
+// traces, which stream their response only when they finish.
+func DebugConfig(host string, port int) Config {
+	return Config{
+		Host:         host,
+		Port:         port,
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 90 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+}
+
+// DebugHandler serves the Go runtime's diagnostics. It must only be exposed on
+// an admin listener that is not reachable from the public network:
+//
+//	/debug/pprof/   - net/http/pprof profiles (CPU, heap, goroutine, block, mutex, trace)
+//	/debug/vars     - expvar, including memstats and cmdline
+//	/debug/runtime  - goroutine count and GC statistics as JSON
+func DebugHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /debug/pprof/", pprof.Index)
+	mux.HandleFunc("GET /debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("GET /debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("GET /debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("POST /debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("GET /debug/pprof/trace", pprof.Trace)
+	mux.Handle("GET /debug/vars", expvar.Handler())
+	mux.HandleFunc("GET /debug/runtime", handleRuntimeStats)
+
+	return mux
+}
+
+type runtimeStats struct {
+	Goroutines int `json:"goroutines"`
+	GOMAXPROCS int `json:"gomaxprocs"`
+	NumCPU     int `json:"num_cpu"`
+
+	HeapAllocBytes uint64 `json:"heap_alloc_bytes"`
+	HeapInuseBytes uint64 `json:"heap_inuse_bytes"`
+	HeapObjects    uint64 `json:"heap_objects"`
+	SysBytes       uint64 `json:"sys_bytes"`
+
+	NumGC         uint32        `json:"num_gc"`
+	LastGC        time.Time     `json:"last_gc"`
+	PauseTotal    time.Duration `json:"pause_total_ns"`
+	RecentPauses  []int64       `json:"recent_pauses_ns"` // most recent first
+	GCCPUFraction float64       `json:"gc_cpu_fraction"`
+}
+
+// handleRuntimeStats reports goroutine and GC figures without the detail of
+// /debug/vars, e.g. for watching a goroutine leak in the event bus.
+func handleRuntimeStats(w http.ResponseWriter, r *http.Request) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	var gc debug.GCStats
+	debug.ReadGCStats(&gc)
+	recent := gc.Pause[:min(len(gc.Pause), 10)]
+	pauses := make([]int64, len(recent))
+	for i, p := range recent {
+		pauses[i] = p.Nanoseconds()
+	}
+
+	writeDebugJSON(w, runtimeStats{
+		Goroutines:     runtime.NumGoroutine(),
+		GOMAXPROCS:     runtime.GOMAXPROCS(0),
+		NumCPU:         runtime.NumCPU(),
+		HeapAllocBytes: mem.HeapAlloc,
+		HeapInuseBytes: mem.HeapInuse,
+		HeapObjects:    mem.HeapObjects,
+		SysBytes:       mem.Sys,
+		NumGC:          mem.NumGC,
+		LastGC:         gc.LastGC,
+		PauseTotal:     gc.PauseTotal,
+		RecentPauses:   pauses,
+		GCCPUFraction:  mem.GCCPUFraction,
+	})
+}
+
+func writeDebugJSON(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(data)
+}
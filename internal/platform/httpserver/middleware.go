@@ -1,9 +1,21 @@
 package httpserver
 
 import (
+	"fmt"
 	"log/slog"
 	"net/http"
+	"runtime/debug"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rai/clean-modularmonolith-go/internal/platform/metrics"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/fault"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
 )
 
 // Middleware chains multiple middleware functions.
@@ -14,7 +26,8 @@ func Middleware(h http.Handler, middlewares ...func(http.Handler) http.Handler)
 	return h
 }
 
-// Logging middleware logs request details.
+// Logging middleware logs request details, with the request ID installed by
+// RequestContext when there is one.
 func Logging(logger *slog.Logger) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -25,26 +38,95 @@ func Logging(logger *slog.Logger) func(http.Handler) http.Handler {
 
 			next.ServeHTTP(wrapped, r)
 
-			logger.Info("http request",
+			attrs := []any{
 				slog.String("method", r.Method),
 				slog.String("path", r.URL.Path),
 				slog.Int("status", wrapped.statusCode),
 				slog.Duration("duration", time.Since(start)),
+			}
+			if id := requestcontext.RequestID(r.Context()); id != "" {
+				attrs = append(attrs, slog.String("request_id", id))
+			}
+			logger.Info("http request", attrs...)
+		})
+	}
+}
+
+// Tracing middleware starts a server span for every request, continuing the
+// caller's trace when the request carries W3C trace context headers. The span
+// is renamed after the matched route once the mux has routed the request.
+// Place it first so that logs and panics are recorded within the span.
+func Tracing() func(http.Handler) http.Handler {
+	tracer := otel.Tracer("httpserver")
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := tracer.Start(ctx, r.Method,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("url.path", r.URL.Path),
+				),
 			)
+			defer span.End()
+
+			wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			r = r.WithContext(ctx)
+			next.ServeHTTP(wrapped, r)
+
+			// ServeMux records the matched pattern on the request it was given.
+			if r.Pattern != "" {
+				span.SetName(r.Pattern)
+				span.SetAttributes(attribute.String("http.route", r.Pattern))
+			}
+			span.SetAttributes(attribute.Int("http.response.status_code", wrapped.statusCode))
+			if wrapped.statusCode >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(wrapped.statusCode))
+			}
+		})
+	}
+}
+
+// Metrics middleware records the count and latency of every request by its
+// matched route. Place it before Recovery so that panics count as 500s.
+func Metrics(m *metrics.Registry) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			wrapped := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+
+			next.ServeHTTP(wrapped, r)
+
+			// ServeMux records the matched pattern on the request it was given.
+			m.ObserveHTTPRequest(r.Method, r.Pattern, wrapped.statusCode, time.Since(start))
 		})
 	}
 }
 
-// Recovery middleware recovers from panics.
-func Recovery(logger *slog.Logger) func(http.Handler) http.Handler {
+// Recovery middleware recovers from panics and reports them to reporter as
+// fatal errors, tagged with the request's route. reporter may be nil.
+// http.ErrAbortHandler is re-panicked for net/http to abort the response.
+func Recovery(logger *slog.Logger, reporter fault.Reporter) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			defer func() {
 				if err := recover(); err != nil {
+					if err == http.ErrAbortHandler {
+						panic(err) // a deliberately aborted response, e.g. a failed export stream
+					}
 					logger.Error("panic recovered",
 						slog.Any("error", err),
 						slog.String("path", r.URL.Path),
 					)
+					if reporter != nil {
+						reporter.ReportError(r.Context(), fault.Report{
+							Err:    fault.Wrap(fmt.Errorf("panic: %v", err), fault.Fatal),
+							Kind:   fault.Fatal,
+							Source: "http",
+							Tags:   map[string]string{"method": r.Method, "route": r.Pattern, "path": r.URL.Path},
+							Stack:  debug.Stack(),
+						})
+					}
 					http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 				}
 			}()
@@ -65,8 +147,9 @@ func CORS(allowedOrigins []string) func(http.Handler) http.Handler {
 				}
 			}
 
-			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-Match, "+RequestIDHeader+", "+TenantIDHeader)
+			w.Header().Set("Access-Control-Expose-Headers", "ETag, "+RequestIDHeader)
 
 			if r.Method == http.MethodOptions {
 				w.WriteHeader(http.StatusNoContent)
@@ -87,3 +170,9 @@ func (w *responseWriter) WriteHeader(code int) {
 	w.statusCode = code
 	w.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to
+// flush gRPC responses and their trailers.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
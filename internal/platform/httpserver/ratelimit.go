@@ -0,0 +1,103 @@
+package httpserver
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// idleBucketTTL is how long a client's bucket is kept after its last request.
+const idleBucketTTL = 10 * time.Minute
+
+// RateLimiter limits the request rate of each client (by remote IP) with a
+// token bucket. The limit can be changed while serving, e.g. when the
+// runtime configuration is reloaded.
+type RateLimiter struct {
+	mu        sync.Mutex
+	rate      float64 // tokens per second; zero disables limiting
+	burst     float64
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a limiter allowing rps requests per second per
+// client, with bursts of up to burst requests. Zero rps disables it.
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	l := &RateLimiter{buckets: make(map[string]*bucket), now: time.Now}
+	l.SetLimit(rps, burst)
+	return l
+}
+
+// SetLimit changes the limit. Clients keep their current tokens, capped at
+// the new burst.
+func (l *RateLimiter) SetLimit(rps float64, burst int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rate = rps
+	l.burst = math.Max(float64(burst), 1)
+	for _, b := range l.buckets {
+		b.tokens = math.Min(b.tokens, l.burst)
+	}
+}
+
+// allow takes a token from client's bucket, or returns how long until one is available.
+func (l *RateLimiter) allow(client string) (ok bool, retryAfter time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.rate <= 0 {
+		return true, 0
+	}
+
+	now := l.now()
+	if now.Sub(l.lastSweep) > idleBucketTTL {
+		for key, b := range l.buckets {
+			if now.Sub(b.last) > idleBucketTTL {
+				delete(l.buckets, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, found := l.buckets[client]
+	if !found {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
+
+// RateLimit middleware answers 429 Too Many Requests, with a Retry-After
+// header, to clients exceeding l's limit.
+func RateLimit(l *RateLimiter) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			client, _, err := net.SplitHostPort(r.RemoteAddr)
+			if err != nil {
+				client = r.RemoteAddr
+			}
+			if ok, retryAfter := l.allow(client); !ok {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusTooManyRequests)
+				w.Write([]byte(`{"error":"rate limit exceeded"}`))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewRateLimiter(1, 2)
+	l.now = func() time.Time { return now }
+	h := RateLimit(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	status := func(remoteAddr string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		r.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		return rec
+	}
+
+	for i := range 2 {
+		if rec := status("10.0.0.1:1234"); rec.Code != http.StatusOK {
+			t.Fatalf("request %d within burst: status = %d", i, rec.Code)
+		}
+	}
+	rec := status("10.0.0.1:5678")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "1" {
+		t.Fatalf("request over burst: status = %d, Retry-After = %q", rec.Code, rec.Header().Get("Retry-After"))
+	}
+	if rec := status("10.0.0.2:1234"); rec.Code != http.StatusOK {
+		t.Errorf("other client: status = %d, want its own bucket", rec.Code)
+	}
+
+	now = now.Add(time.Second)
+	if rec := status("10.0.0.1:1234"); rec.Code != http.StatusOK {
+		t.Errorf("after refill: status = %d", rec.Code)
+	}
+
+	l.SetLimit(0, 0)
+	for range 5 {
+		if rec := status("10.0.0.1:1234"); rec.Code != http.StatusOK {
+			t.Fatalf("disabled limiter: status = %d", rec.Code)
+		}
+	}
+}
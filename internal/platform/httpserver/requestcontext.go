@@ -0,0 +1,100 @@
+package httpserver
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+// Headers read by RequestContext.
+const (
+	RequestIDHeader = "X-Request-ID"
+	TenantIDHeader  = "X-Tenant-ID"
+)
+
+var (
+	// requestIDPattern accepts caller-supplied IDs that are safe to log and echo.
+	requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+	// localeTagPattern accepts a language tag with an optional region, e.g. en or en-US.
+	localeTagPattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z]{2})?$`)
+	// tenantIDPattern accepts the tenant IDs the Users and Orders tables can
+	// store: lowercase letters, digits and dashes.
+	tenantIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+)
+
+// RequestContext middleware installs the request's requestcontext.Values for
+// handlers and the commands they dispatch. actor names who issued the
+// request, or returns "" for an anonymous one; it may be nil. The request ID
+// is taken from X-Request-ID when the caller sent a sane one and generated
+// otherwise, and is echoed in the response. Place it before Logging so that
+// request log lines carry the ID.
+//
+// The tenant is never taken from the caller's word alone, as the
+// repositories scope every read and write to it: X-Tenant-ID is honored only
+// when chooseTenant reports that r carries credentials trusted to act for any
+// tenant, e.g. the admin token, and rejected with 403 Forbidden otherwise.
+// chooseTenant may be nil, in which case it is always rejected. Without it
+// the request is for the default tenant until an authenticating middleware,
+// such as the apikeys one, sets the tenant of the caller's credentials.
+// Malformed tenant IDs are rejected with 400 Bad Request.
+func RequestContext(actor func(*http.Request) string, chooseTenant func(*http.Request) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			v := requestcontext.Values{
+				TenantID:  strings.TrimSpace(r.Header.Get(TenantIDHeader)),
+				Locale:    preferredLocale(r.Header.Get("Accept-Language")),
+				RequestID: r.Header.Get(RequestIDHeader),
+			}
+			if !requestIDPattern.MatchString(v.RequestID) {
+				v.RequestID = newRequestID()
+			}
+			if v.TenantID != "" && !tenantIDPattern.MatchString(v.TenantID) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"invalid tenant ID"}`))
+				return
+			}
+			if v.TenantID != "" && (chooseTenant == nil || !chooseTenant(r)) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"error":"X-Tenant-ID requires the admin token"}`))
+				return
+			}
+			if actor != nil {
+				v.Actor = actor(r)
+			}
+
+			w.Header().Set(RequestIDHeader, v.RequestID)
+			next.ServeHTTP(w, r.WithContext(requestcontext.With(r.Context(), v)))
+		})
+	}
+}
+
+// preferredLocale returns the first language tag of an Accept-Language header
+// that names a language, normalized to e.g. en-US, or "" if there is none.
+// Quality values are not weighed: clients list their preference first.
+func preferredLocale(header string) string {
+	for tag := range strings.SplitSeq(header, ",") {
+		tag, _, _ = strings.Cut(tag, ";")
+		tag = strings.TrimSpace(tag)
+		if !localeTagPattern.MatchString(tag) {
+			continue // "*" or a tag with a script or variant
+		}
+		lang, region, ok := strings.Cut(tag, "-")
+		if !ok {
+			return strings.ToLower(lang)
+		}
+		return strings.ToLower(lang) + "-" + strings.ToUpper(region)
+	}
+	return ""
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,102 @@
+package httpserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+func TestRequestContext(t *testing.T) {
+	var got requestcontext.Values
+	admin := func(r *http.Request) bool { return true }
+	h := RequestContext(func(r *http.Request) string { return "admin" }, admin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = requestcontext.FromContext(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+	r.Header.Set(RequestIDHeader, "req-42")
+	r.Header.Set(TenantIDHeader, "acme")
+	r.Header.Set("Accept-Language", "*, ja-jp;q=0.9, en;q=0.8")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+
+	want := requestcontext.Values{Actor: "admin", TenantID: "acme", Locale: "ja-JP", RequestID: "req-42"}
+	if got != want {
+		t.Errorf("values = %+v, want %+v", got, want)
+	}
+	if id := rec.Header().Get(RequestIDHeader); id != "req-42" {
+		t.Errorf("response %s = %q, want req-42", RequestIDHeader, id)
+	}
+}
+
+func TestRequestContext_GeneratesRequestID(t *testing.T) {
+	var got requestcontext.Values
+	h := RequestContext(nil, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = requestcontext.FromContext(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+	r.Header.Set(RequestIDHeader, "not a\nsane id")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+
+	if got.RequestID == "" || got.RequestID == "not a\nsane id" {
+		t.Fatalf("RequestID = %q, want a generated one", got.RequestID)
+	}
+	if rec.Header().Get(RequestIDHeader) != got.RequestID {
+		t.Errorf("response %s = %q, want %q", RequestIDHeader, rec.Header().Get(RequestIDHeader), got.RequestID)
+	}
+	if got.Actor != "" || got.Locale != "" || got.TenantID != "" {
+		t.Errorf("values = %+v, want only a request ID", got)
+	}
+}
+
+func TestRequestContext_RejectsMalformedTenantID(t *testing.T) {
+	h := RequestContext(nil, func(r *http.Request) bool { return true })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler called with a malformed tenant ID")
+	}))
+
+	for _, tenant := range []string{"Acme", "acme corp", "-acme", strings.Repeat("a", 64)} {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+		r.Header.Set(TenantIDHeader, tenant)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("%s %q: status = %d, want 400", TenantIDHeader, tenant, rec.Code)
+		}
+	}
+}
+
+func TestRequestContext_RejectsTenantWithoutAdminToken(t *testing.T) {
+	h := RequestContext(nil, func(r *http.Request) bool { return false })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("handler called with a tenant chosen by an anonymous caller")
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/users", nil)
+	r.Header.Set(TenantIDHeader, "acme")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want 403", rec.Code)
+	}
+}
+
+func TestPreferredLocale(t *testing.T) {
+	tests := map[string]string{
+		"":                    "",
+		"en":                  "en",
+		"en-us,en;q=0.5":      "en-US",
+		"*":                   "",
+		"zh-Hant-TW, fr;q=.5": "fr",
+	}
+	for header, want := range tests {
+		if got := preferredLocale(header); got != want {
+			t.Errorf("preferredLocale(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
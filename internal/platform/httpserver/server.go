@@ -43,6 +43,12 @@ func New(cfg Config, handler http.Handler, logger *slog.Logger) *Server {
 
 	addr := fmt.Sprintf("%s:%d", cfg.Host, cfg.Port)
 
+	// gRPC clients of the connect-go services speak HTTP/2 without TLS (h2c);
+	// TLS is terminated in front of the server.
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
+
 	return &Server{
 		server: &http.Server{
 			Addr:         addr,
@@ -50,6 +56,7 @@ func New(cfg Config, handler http.Handler, logger *slog.Logger) *Server {
 			ReadTimeout:  cfg.ReadTimeout,
 			WriteTimeout: cfg.WriteTimeout,
 			IdleTimeout:  cfg.IdleTimeout,
+			Protocols:    &protocols,
 		},
 		logger: logger,
 	}
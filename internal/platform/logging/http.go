@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+)
+
+type levelsResponse struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules"`
+}
+
+type setLevelRequest struct {
+	Module string `json:"module"` // empty for the global level
+	Level  string `json:"level"`  // empty to reset the module to the global level
+}
+
+// HTTPHandler serves the levels at runtime. Callers must guard it as an admin
+// endpoint:
+//
+//	GET /admin/loglevel  - the global level and module overrides
+//	PUT /admin/loglevel  - {"module": "orders", "level": "debug"}; omit module
+//	                       for the global level, or level to drop the override
+func (l *Levels) HTTPHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var req setLevelRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+				writeError(w, http.StatusBadRequest, "invalid request body")
+				return
+			}
+			module := strings.TrimSpace(req.Module)
+			switch {
+			case req.Level != "":
+				level, err := ParseLevel(req.Level)
+				if err != nil {
+					writeError(w, http.StatusBadRequest, err.Error())
+					return
+				}
+				l.Set(module, level)
+			case module != "":
+				l.Reset(module)
+			default:
+				writeError(w, http.StatusBadRequest, "level is required")
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		level, modules := l.Snapshot()
+		resp := levelsResponse{Level: level.String(), Modules: make(map[string]string, len(modules))}
+		for module, lvl := range modules {
+			resp.Modules[module] = lvl.String()
+		}
+		writeJSON(w, http.StatusOK, resp)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
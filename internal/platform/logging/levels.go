@@ -0,0 +1,141 @@
+// Package logging provides slog handlers for runtime log level control and
+// sampling of high-volume debug logs.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+	"sync"
+)
+
+// ModuleKey is the attribute that scopes a logger to a module, as set by
+// logger.With("module", name) in each module's New.
+const ModuleKey = "module"
+
+// Levels holds the minimum log level, globally and per module, and can be
+// changed while the application runs. Loggers pick changes up immediately.
+type Levels struct {
+	mu      sync.RWMutex
+	level   slog.Level
+	modules map[string]slog.Level
+}
+
+// NewLevels returns Levels with the given global minimum and no module overrides.
+func NewLevels(level slog.Level) *Levels {
+	return &Levels{level: level, modules: make(map[string]slog.Level)}
+}
+
+// Level returns the minimum level for module, falling back to the global one.
+func (l *Levels) Level(module string) slog.Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if lvl, ok := l.modules[module]; ok {
+		return lvl
+	}
+	return l.level
+}
+
+// Set changes the minimum level of module, or the global one when module is empty.
+func (l *Levels) Set(module string, level slog.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if module == "" {
+		l.level = level
+		return
+	}
+	l.modules[module] = level
+}
+
+// Reset removes the override of module so that it follows the global level again.
+func (l *Levels) Reset(module string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.modules, module)
+}
+
+// Replace sets the global level and replaces every module override, e.g.
+// when the runtime configuration is reloaded.
+func (l *Levels) Replace(level slog.Level, modules map[string]slog.Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = level
+	l.modules = maps.Clone(modules)
+	if l.modules == nil {
+		l.modules = make(map[string]slog.Level)
+	}
+}
+
+// Snapshot returns the global level and a copy of the module overrides.
+func (l *Levels) Snapshot() (slog.Level, map[string]slog.Level) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.level, maps.Clone(l.modules)
+}
+
+// Handler wraps next so that records are dropped below the level of the
+// logger's module. next should accept every level; Levels does the filtering.
+func (l *Levels) Handler(next slog.Handler) slog.Handler {
+	return &levelHandler{next: next, levels: l}
+}
+
+type levelHandler struct {
+	next   slog.Handler
+	levels *Levels
+	module string
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.levels.Level(h.module) && h.next.Enabled(ctx, level)
+}
+
+func (h *levelHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	module := h.module
+	for _, a := range attrs {
+		if a.Key == ModuleKey {
+			module = a.Value.String()
+		}
+	}
+	return &levelHandler{next: h.next.WithAttrs(attrs), levels: h.levels, module: module}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{next: h.next.WithGroup(name), levels: h.levels, module: h.module}
+}
+
+// ParseLevel parses a level name such as "debug", "INFO" or "warn+2".
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return 0, err
+	}
+	return level, nil
+}
+
+// ParseModuleLevels parses comma-separated module=level pairs, e.g.
+// "orders=debug,eventbus=warn", as used for the LOG_LEVELS variable.
+func ParseModuleLevels(s string) (map[string]slog.Level, error) {
+	levels := make(map[string]slog.Level)
+	for pair := range strings.SplitSeq(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		module, name, ok := strings.Cut(pair, "=")
+		module = strings.TrimSpace(module)
+		if !ok || module == "" {
+			return nil, fmt.Errorf("invalid module level %q: want module=level", pair)
+		}
+		level, err := ParseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("module %s: %w", module, err)
+		}
+		levels[module] = level
+	}
+	return levels, nil
+}
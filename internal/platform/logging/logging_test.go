@@ -0,0 +1,149 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newLogger(levels *Levels, buf *bytes.Buffer) *slog.Logger {
+	return slog.New(levels.Handler(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+}
+
+func TestLevels_ModuleOverride(t *testing.T) {
+	var buf bytes.Buffer
+	levels := NewLevels(slog.LevelInfo)
+	logger := newLogger(levels, &buf)
+	orders := logger.With(ModuleKey, "orders")
+
+	orders.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Fatalf("debug record written at global info level: %s", buf.String())
+	}
+
+	levels.Set("orders", slog.LevelDebug)
+	orders.Debug("shown")
+	logger.With(ModuleKey, "users").Debug("hidden")
+	if got := strings.Count(buf.String(), "\n"); got != 1 || !strings.Contains(buf.String(), "shown") {
+		t.Fatalf("records = %s", buf.String())
+	}
+
+	buf.Reset()
+	levels.Reset("orders")
+	orders.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Fatalf("debug record written after reset: %s", buf.String())
+	}
+}
+
+func TestLevels_GlobalLevel(t *testing.T) {
+	var buf bytes.Buffer
+	levels := NewLevels(slog.LevelDebug)
+	logger := newLogger(levels, &buf)
+
+	levels.Set("", slog.LevelWarn)
+	logger.Info("hidden")
+	logger.With(ModuleKey, "orders").Warn("shown")
+	if got := strings.Count(buf.String(), "\n"); got != 1 {
+		t.Fatalf("records = %s", buf.String())
+	}
+}
+
+func TestSample_KeepsFirstOfEveryN(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(Sample(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}), 3))
+	derived := logger.With("component", "eventbus")
+
+	for range 4 {
+		derived.Debug("event dispatched")
+	}
+	logger.Debug("other message")
+	logger.Info("not sampled")
+	logger.Info("not sampled")
+
+	out := buf.String()
+	if got := strings.Count(out, "event dispatched"); got != 2 {
+		t.Errorf("sampled debug records = %d, want 2 (1st and 4th)", got)
+	}
+	if got := strings.Count(out, "other message"); got != 1 {
+		t.Errorf("debug records with another message = %d, want 1", got)
+	}
+	if got := strings.Count(out, "not sampled"); got != 2 {
+		t.Errorf("info records = %d, want 2", got)
+	}
+}
+
+func TestParseModuleLevels(t *testing.T) {
+	got, err := ParseModuleLevels("orders=debug, eventbus=WARN,")
+	if err != nil {
+		t.Fatalf("ParseModuleLevels() error = %v", err)
+	}
+	if len(got) != 2 || got["orders"] != slog.LevelDebug || got["eventbus"] != slog.LevelWarn {
+		t.Errorf("ParseModuleLevels() = %v", got)
+	}
+
+	for _, s := range []string{"orders", "=debug", "orders=loud"} {
+		if _, err := ParseModuleLevels(s); err == nil {
+			t.Errorf("ParseModuleLevels(%q) succeeded", s)
+		}
+	}
+}
+
+func TestHTTPHandler(t *testing.T) {
+	levels := NewLevels(slog.LevelInfo)
+	h := levels.HTTPHandler()
+
+	put := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/admin/loglevel", strings.NewReader(body)))
+		return rec
+	}
+
+	rec := put(`{"module":"orders","level":"debug"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("PUT status = %d, body = %s", rec.Code, rec.Body)
+	}
+	var resp levelsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Level != "INFO" || resp.Modules["orders"] != "DEBUG" {
+		t.Errorf("response = %+v", resp)
+	}
+
+	if rec := put(`{"module":"orders"}`); rec.Code != http.StatusOK || levels.Level("orders") != slog.LevelInfo {
+		t.Errorf("reset: status = %d, level = %v", rec.Code, levels.Level("orders"))
+	}
+	if rec := put(`{"level":"loud"}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid level status = %d, want 400", rec.Code)
+	}
+	if rec := put(`{}`); rec.Code != http.StatusBadRequest {
+		t.Errorf("empty request status = %d, want 400", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/admin/loglevel", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE status = %d, want 405", rec.Code)
+	}
+}
+
+func TestLevels_Replace(t *testing.T) {
+	levels := NewLevels(slog.LevelDebug)
+	levels.Set("orders", slog.LevelWarn)
+
+	levels.Replace(slog.LevelInfo, map[string]slog.Level{"users": slog.LevelDebug})
+
+	if got := levels.Level("orders"); got != slog.LevelInfo {
+		t.Errorf("orders level = %v, want the new global INFO", got)
+	}
+	if got := levels.Level("users"); got != slog.LevelDebug {
+		t.Errorf("users level = %v, want DEBUG", got)
+	}
+	levels.Replace(slog.LevelWarn, nil)
+	levels.Set("users", slog.LevelError)
+}
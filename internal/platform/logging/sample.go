@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+// Sample wraps next so that only the first of every `every` debug records
+// with the same message is written. Records at Info and above are never
+// sampled. It returns next unchanged when every is 1 or less.
+//
+// Use it for loggers that emit a debug record per unit of work, such as the
+// event bus logging every dispatched event.
+func Sample(next slog.Handler, every int) slog.Handler {
+	if every <= 1 {
+		return next
+	}
+	return &sampleHandler{next: next, every: uint64(every), counts: &sync.Map{}}
+}
+
+type sampleHandler struct {
+	next   slog.Handler
+	every  uint64
+	counts *sync.Map // message -> *atomic.Uint64, shared by derived handlers
+}
+
+func (h *sampleHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *sampleHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < slog.LevelInfo {
+		c, _ := h.counts.LoadOrStore(r.Message, new(atomic.Uint64))
+		if (c.(*atomic.Uint64).Add(1)-1)%h.every != 0 {
+			return nil
+		}
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *sampleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &sampleHandler{next: h.next.WithAttrs(attrs), every: h.every, counts: h.counts}
+}
+
+func (h *sampleHandler) WithGroup(name string) slog.Handler {
+	return &sampleHandler{next: h.next.WithGroup(name), every: h.every, counts: h.counts}
+}
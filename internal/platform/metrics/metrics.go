@@ -0,0 +1,169 @@
+// Package metrics exposes the application's Prometheus metrics: HTTP
+// requests, command handlers, event bus dispatch, Spanner transactions and
+// per-module service level indicators (SLIs), plus any collectors modules
+// register for themselves.
+//
+// A nil *Registry is valid and records nothing, so instrumented components
+// work unchanged when metrics are not configured.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+)
+
+// Registry holds the platform collectors and serves every registered metric.
+type Registry struct {
+	registry *prometheus.Registry
+
+	httpRequests        *prometheus.CounterVec
+	httpRequestDuration *prometheus.HistogramVec
+	commandDuration     *prometheus.HistogramVec
+	eventsPublished     *prometheus.CounterVec
+	eventsHandled       *prometheus.CounterVec
+	eventHandleDuration *prometheus.HistogramVec
+	txRetries           *prometheus.CounterVec
+
+	sli           sliCollectors
+	sloAPIModules []string
+	sloBuckets    []float64
+}
+
+var _ command.Recorder = (*Registry)(nil)
+
+// NewRegistry creates a registry with the platform collectors, the SLI
+// collectors and the Go runtime and process collectors registered.
+func NewRegistry(opts ...Option) *Registry {
+	r := &Registry{
+		registry:   prometheus.NewRegistry(),
+		sloBuckets: DefaultSLOBuckets,
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "HTTP requests handled, by method, matched route and status code.",
+		}, []string{"method", "route", "status"}),
+		httpRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency, by method and matched route.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		commandDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "command_duration_seconds",
+			Help:    "Command handler duration, by module, command and outcome.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"module", "command", "outcome"}),
+		eventsPublished: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "eventbus_events_published_total",
+			Help: "Domain events published, by event type and phase.",
+		}, []string{"event_type", "phase"}),
+		eventsHandled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "eventbus_events_handled_total",
+			Help: "Event handler invocations, by event type, handler, phase and outcome.",
+		}, []string{"event_type", "handler", "phase", "outcome"}),
+		eventHandleDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "eventbus_handler_duration_seconds",
+			Help:    "Event handler duration, by handler and phase.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"handler", "phase"}),
+		txRetries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "spanner_transaction_retries_total",
+			Help: "Read-write transaction attempts that Spanner aborted and retried, by calling handler.",
+		}, []string{"caller"}),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.sli = newSLICollectors(r.sloBuckets)
+	r.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		r.httpRequests,
+		r.httpRequestDuration,
+		r.commandDuration,
+		r.eventsPublished,
+		r.eventsHandled,
+		r.eventHandleDuration,
+		r.txRetries,
+		r.sli.requests,
+		r.sli.errors,
+		r.sli.latency,
+	)
+	return r
+}
+
+// Registerer returns the registerer modules use for their own collectors.
+// Metric names should be prefixed with the module name, e.g. webhooks_.
+func (r *Registry) Registerer() prometheus.Registerer {
+	return r.registry
+}
+
+// Handler serves the registered metrics in the Prometheus exposition format.
+func (r *Registry) Handler() http.Handler {
+	return promhttp.HandlerFor(r.registry, promhttp.HandlerOpts{Registry: r.registry})
+}
+
+// ObserveHTTPRequest records a handled request. route is the matched mux
+// pattern, or "unmatched" so that unknown paths do not create new series.
+// Requests to the API of a module selected by WithSLO also count towards its SLI.
+func (r *Registry) ObserveHTTPRequest(method, route string, status int, d time.Duration) {
+	if r == nil {
+		return
+	}
+	if route == "" {
+		route = "unmatched"
+	}
+	r.httpRequests.WithLabelValues(method, route, strconv.Itoa(status)).Inc()
+	r.httpRequestDuration.WithLabelValues(method, route).Observe(d.Seconds())
+	if module := r.apiModule(route); module != "" {
+		r.ObserveSLI(APISLO(module), d, status < http.StatusInternalServerError)
+	}
+}
+
+// RecordCommand records the duration of a command execution.
+// Implements command.Recorder.
+func (r *Registry) RecordCommand(_ context.Context, rec command.Record) {
+	if r == nil {
+		return
+	}
+	r.commandDuration.WithLabelValues(rec.Module, rec.Name, outcome(rec.Err)).Observe(rec.Duration.Seconds())
+}
+
+// ObserveEventPublished records an event handed to the bus in phase
+// ("pre-commit" or "post-commit").
+func (r *Registry) ObserveEventPublished(eventType, phase string) {
+	if r == nil {
+		return
+	}
+	r.eventsPublished.WithLabelValues(eventType, phase).Inc()
+}
+
+// ObserveEventHandled records one handler invocation; err is nil on success.
+func (r *Registry) ObserveEventHandled(eventType, handler, phase string, d time.Duration, err error) {
+	if r == nil {
+		return
+	}
+	r.eventsHandled.WithLabelValues(eventType, handler, phase, outcome(err)).Inc()
+	r.eventHandleDuration.WithLabelValues(handler, phase).Observe(d.Seconds())
+}
+
+// ObserveTransactionRetries records the retries of one read-write transaction.
+func (r *Registry) ObserveTransactionRetries(caller string, retries int) {
+	if r == nil || retries <= 0 {
+		return
+	}
+	r.txRetries.WithLabelValues(caller).Add(float64(retries))
+}
+
+func outcome(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+)
+
+func TestNilRegistryRecordsNothing(t *testing.T) {
+	var r *Registry
+	r.ObserveHTTPRequest(http.MethodGet, "GET /orders/{id}", http.StatusOK, time.Millisecond)
+	r.RecordCommand(context.Background(), command.Record{Module: "orders", Name: "SubmitOrder"})
+	r.ObserveEventPublished("orders.OrderSubmitted", "pre-commit")
+	r.ObserveEventHandled("orders.OrderSubmitted", "StockReservationHandler", "post-commit", time.Millisecond, nil)
+	r.ObserveTransactionRetries("commands/submit_order.go:42", 1)
+	r.ObserveSLI("orders-api", time.Millisecond, true)
+	r.ObserveNotificationDelivery(time.Millisecond, nil)
+}
+
+func TestRegistry_ObserveHTTPRequest_GroupsUnmatchedRoutes(t *testing.T) {
+	r := NewRegistry()
+	r.ObserveHTTPRequest(http.MethodGet, "GET /orders/{id}", http.StatusOK, time.Millisecond)
+	r.ObserveHTTPRequest(http.MethodGet, "", http.StatusNotFound, time.Millisecond)
+	r.ObserveHTTPRequest(http.MethodGet, "", http.StatusNotFound, time.Millisecond)
+
+	if got := testutil.ToFloat64(r.httpRequests.WithLabelValues(http.MethodGet, "GET /orders/{id}", "200")); got != 1 {
+		t.Errorf("matched route count = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(r.httpRequests.WithLabelValues(http.MethodGet, "unmatched", "404")); got != 2 {
+		t.Errorf("unmatched route count = %v, want 2", got)
+	}
+}
+
+func TestRegistry_RecordCommand_LabelsOutcome(t *testing.T) {
+	r := NewRegistry()
+	r.RecordCommand(context.Background(), command.Record{Module: "orders", Name: "SubmitOrder", Duration: time.Second})
+	r.RecordCommand(context.Background(), command.Record{Module: "orders", Name: "SubmitOrder", Err: errors.New("boom")})
+
+	if got := testutil.CollectAndCount(r.commandDuration); got != 2 {
+		t.Errorf("command series = %d, want 2 (success and error)", got)
+	}
+}
+
+func TestRegistry_HandlerServesModuleCollectors(t *testing.T) {
+	r := NewRegistry()
+	custom := prometheus.NewCounter(prometheus.CounterOpts{Name: "webhooks_test_total", Help: "test"})
+	if err := r.Registerer().Register(custom); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	custom.Inc()
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if !strings.Contains(rec.Body.String(), "webhooks_test_total 1") {
+		t.Errorf("module collector missing from /metrics output")
+	}
+}
+
+func TestRegistry_ObserveHTTPRequest_RecordsAPISLI(t *testing.T) {
+	r := NewRegistry(WithSLO(SLOConfig{APIModules: []string{"users", "orders"}, LatencyBuckets: []float64{0.3, 0.1}}))
+	r.ObserveHTTPRequest(http.MethodGet, "GET /orders/{id}", http.StatusOK, 200*time.Millisecond)
+	r.ObserveHTTPRequest(http.MethodPost, "POST /orders", http.StatusConflict, time.Millisecond)
+	r.ObserveHTTPRequest(http.MethodPost, "POST /orders", http.StatusServiceUnavailable, time.Millisecond)
+	r.ObserveHTTPRequest(http.MethodGet, "GET /reviews/{id}", http.StatusInternalServerError, time.Millisecond)
+	r.ObserveHTTPRequest(http.MethodGet, "", http.StatusNotFound, time.Millisecond)
+
+	if got := testutil.ToFloat64(r.sli.requests.WithLabelValues("orders-api")); got != 3 {
+		t.Errorf("orders-api requests = %v, want 3", got)
+	}
+	if got := testutil.ToFloat64(r.sli.errors.WithLabelValues("orders-api")); got != 1 {
+		t.Errorf("orders-api errors = %v, want 1 (only 5xx)", got)
+	}
+	if got := testutil.CollectAndCount(r.sli.requests); got != 1 {
+		t.Errorf("SLI series = %d, want only orders-api", got)
+	}
+
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.Contains(rec.Body.String(), `sli_latency_seconds_bucket{slo="orders-api",le="0.1"} 2`) ||
+		!strings.Contains(rec.Body.String(), `sli_latency_seconds_bucket{slo="orders-api",le="0.3"} 3`) {
+		t.Errorf("SLI latency does not use the configured buckets:\n%s", rec.Body.String())
+	}
+}
+
+func TestRegistry_ObserveNotificationDelivery(t *testing.T) {
+	r := NewRegistry()
+	r.ObserveNotificationDelivery(time.Second, nil)
+	r.ObserveNotificationDelivery(time.Second, errors.New("smtp: connection refused"))
+
+	if got := testutil.ToFloat64(r.sli.requests.WithLabelValues(SLONotificationsDelivery)); got != 2 {
+		t.Errorf("deliveries = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(r.sli.errors.WithLabelValues(SLONotificationsDelivery)); got != 1 {
+		t.Errorf("failed deliveries = %v, want 1", got)
+	}
+}
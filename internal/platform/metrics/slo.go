@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// SLO names of the indicators recorded by the Registry. They are the "slo"
+// label of the sli_* metrics.
+const (
+	// SLONotificationsDelivery is the delivery of notifications to channel providers.
+	SLONotificationsDelivery = "notifications-delivery"
+)
+
+// APISLO returns the SLO name of a module's HTTP API, e.g. "orders-api".
+func APISLO(module string) string {
+	return module + "-api"
+}
+
+// SLOConfig selects what is recorded as service level indicators, for SLO
+// dashboards and burn-rate alerts (see deploy/sloth/slos.yml).
+type SLOConfig struct {
+	// APIModules are the modules whose HTTP API is an SLI. A request belongs
+	// to the module named by the first segment of its route, e.g. "users"
+	// for "GET /users/{id}". 5xx responses count as errors.
+	APIModules []string
+
+	// LatencyBuckets are the upper bounds, in seconds, of the SLI latency
+	// histogram. Include every latency objective's threshold so that the
+	// share of requests within it is exact. Defaults to DefaultSLOBuckets.
+	LatencyBuckets []float64
+}
+
+// DefaultSLOBuckets covers latency objectives from 50ms to 5s.
+var DefaultSLOBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+// Option configures a Registry.
+type Option func(*Registry)
+
+// WithSLO records the SLIs selected by cfg.
+func WithSLO(cfg SLOConfig) Option {
+	return func(r *Registry) {
+		r.sloAPIModules = slices.Clone(cfg.APIModules)
+		if len(cfg.LatencyBuckets) > 0 {
+			r.sloBuckets = slices.Sorted(slices.Values(cfg.LatencyBuckets))
+		}
+	}
+}
+
+// sliCollectors are registered by NewRegistry once the options are applied.
+type sliCollectors struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+func newSLICollectors(buckets []float64) sliCollectors {
+	return sliCollectors{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sli_requests_total",
+			Help: "Events counted by an SLI (requests, deliveries), by SLO.",
+		}, []string{"slo"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "sli_errors_total",
+			Help: "Events that failed an availability SLI, by SLO.",
+		}, []string{"slo"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "sli_latency_seconds",
+			Help:    "Latency of the events counted by an SLI, by SLO.",
+			Buckets: buckets,
+		}, []string{"slo"}),
+	}
+}
+
+// ObserveSLI records one event of slo, which failed when ok is false.
+func (r *Registry) ObserveSLI(slo string, d time.Duration, ok bool) {
+	if r == nil {
+		return
+	}
+	r.sli.requests.WithLabelValues(slo).Inc()
+	if !ok {
+		r.sli.errors.WithLabelValues(slo).Inc()
+	}
+	r.sli.latency.WithLabelValues(slo).Observe(d.Seconds())
+}
+
+// ObserveNotificationDelivery records a delivery attempt to a channel
+// provider; err is nil on success.
+func (r *Registry) ObserveNotificationDelivery(d time.Duration, err error) {
+	r.ObserveSLI(SLONotificationsDelivery, d, err == nil)
+}
+
+// apiModule returns the module an SLI-tracked route belongs to, or "".
+func (r *Registry) apiModule(route string) string {
+	if len(r.sloAPIModules) == 0 {
+		return ""
+	}
+	_, path, ok := strings.Cut(route, " ")
+	if !ok {
+		path = route
+	}
+	segment, _, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if slices.Contains(r.sloAPIModules, segment) {
+		return segment
+	}
+	return ""
+}
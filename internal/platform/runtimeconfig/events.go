@@ -0,0 +1,13 @@
+package runtimeconfig
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const ConfigReloadedEventType events.EventType = "config.RuntimeConfigReloaded"
+
+// ConfigReloadedEvent is published after a reload changed the configuration.
+// Modules subscribe to it post-commit to react to new feature flags.
+type ConfigReloadedEvent struct {
+	events.BaseEvent
+	Changed  []string        // changed settings: "log_levels", "rate_limit" or "features"
+	Features map[string]bool // every feature flag after the reload
+}
@@ -0,0 +1,41 @@
+package runtimeconfig
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type reloadResponse struct {
+	Config  Config   `json:"config"`
+	Changed []string `json:"changed"`
+}
+
+// HTTPHandler serves the runtime configuration. Callers must guard it as an
+// admin endpoint:
+//
+//	GET  /admin/config         - the configuration in effect
+//	POST /admin/config/reload  - re-read the file, like SIGHUP
+func (s *Store) HTTPHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/config", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.Current())
+	})
+	mux.HandleFunc("POST /admin/config/reload", func(w http.ResponseWriter, r *http.Request) {
+		cfg, changed, err := s.Reload(r.Context())
+		if err != nil {
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+			return
+		}
+		if changed == nil {
+			changed = []string{}
+		}
+		writeJSON(w, http.StatusOK, reloadResponse{Config: cfg, Changed: changed})
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
@@ -0,0 +1,200 @@
+// Package runtimeconfig holds the subset of the configuration that can be
+// reloaded without restarting the server: log levels, the HTTP rate limit
+// and feature flags.
+//
+// The values come from the environment at startup, overlaid with an optional
+// JSON file that is re-read on SIGHUP or through the admin endpoint. Platform
+// components subscribe to changes with Store.Subscribe; modules subscribe to
+// ConfigReloadedEvent on the event bus like to any other event.
+package runtimeconfig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/rai/clean-modularmonolith-go/internal/platform/logging"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// Config is the reloadable configuration.
+type Config struct {
+	LogLevel  string            `json:"log_level,omitempty"`
+	LogLevels map[string]string `json:"log_levels,omitempty"` // module → level
+	RateLimit RateLimit         `json:"rate_limit"`
+	Features  map[string]bool   `json:"features,omitempty"`
+}
+
+// RateLimit is the per-client HTTP request rate limit.
+type RateLimit struct {
+	RequestsPerSecond float64 `json:"requests_per_second"` // zero disables the limit
+	Burst             int     `json:"burst"`
+}
+
+// Validate reports the first invalid value.
+func (c Config) Validate() error {
+	if c.LogLevel != "" {
+		if _, err := logging.ParseLevel(c.LogLevel); err != nil {
+			return fmt.Errorf("log_level: %w", err)
+		}
+	}
+	for module, level := range c.LogLevels {
+		if _, err := logging.ParseLevel(level); err != nil {
+			return fmt.Errorf("log_levels.%s: %w", module, err)
+		}
+	}
+	if c.RateLimit.RequestsPerSecond < 0 || c.RateLimit.Burst < 0 {
+		return errors.New("rate_limit: must not be negative")
+	}
+	return nil
+}
+
+// overlay returns c with the values set in file replacing its own.
+func (c Config) overlay(file Config) Config {
+	if file.LogLevel != "" {
+		c.LogLevel = file.LogLevel
+	}
+	if file.LogLevels != nil {
+		c.LogLevels = file.LogLevels
+	}
+	if file.RateLimit != (RateLimit{}) {
+		c.RateLimit = file.RateLimit
+	}
+	if file.Features != nil {
+		c.Features = file.Features
+	}
+	return c
+}
+
+// changed returns the names of the top-level settings that differ.
+func changed(old, cur Config) []string {
+	var names []string
+	if old.LogLevel != cur.LogLevel || !maps.Equal(old.LogLevels, cur.LogLevels) {
+		names = append(names, "log_levels")
+	}
+	if old.RateLimit != cur.RateLimit {
+		names = append(names, "rate_limit")
+	}
+	if !maps.Equal(old.Features, cur.Features) {
+		names = append(names, "features")
+	}
+	return names
+}
+
+// Store holds the current Config and notifies subscribers when it changes.
+type Store struct {
+	base      Config
+	path      string
+	logger    *slog.Logger
+	publisher events.PostCommitPublisher
+
+	mu          sync.Mutex // serializes reloads and guards the fields below
+	current     Config
+	subscribers []func(ctx context.Context, cfg Config)
+}
+
+// NewStore loads the configuration: base (from the environment) overlaid
+// with the JSON file at path, if path is not empty. publisher, if not nil,
+// receives a ConfigReloadedEvent after every change.
+func NewStore(base Config, path string, publisher events.PostCommitPublisher, logger *slog.Logger) (*Store, error) {
+	s := &Store{base: base, path: path, logger: logger, publisher: publisher}
+	cfg, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	s.current = cfg
+	return s, nil
+}
+
+func (s *Store) load() (Config, error) {
+	cfg := s.base
+	if s.path != "" {
+		data, err := os.ReadFile(s.path)
+		if err != nil {
+			return Config{}, fmt.Errorf("reading runtime config: %w", err)
+		}
+		var file Config
+		if err := json.Unmarshal(data, &file); err != nil {
+			return Config{}, fmt.Errorf("parsing runtime config %s: %w", s.path, err)
+		}
+		cfg = cfg.overlay(file)
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("invalid runtime config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Current returns the current configuration.
+func (s *Store) Current() Config {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current
+}
+
+// Subscribe calls fn with the current configuration now and again after
+// every change. fn runs on the reloading goroutine and must not call Reload.
+func (s *Store) Subscribe(fn func(ctx context.Context, cfg Config)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscribers = append(s.subscribers, fn)
+	fn(context.Background(), s.current)
+}
+
+// Reload re-reads the file and applies it. An invalid file leaves the
+// current configuration in place. It returns the configuration in effect
+// and the names of the settings that changed.
+func (s *Store) Reload(ctx context.Context) (Config, []string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cfg, err := s.load()
+	if err != nil {
+		s.logger.ErrorContext(ctx, "runtime config reload failed", slog.Any("error", err))
+		return s.current, nil, err
+	}
+	names := changed(s.current, cfg)
+	if len(names) == 0 {
+		s.logger.InfoContext(ctx, "runtime config reloaded, nothing changed")
+		return s.current, nil, nil
+	}
+
+	s.current = cfg
+	for _, fn := range s.subscribers {
+		fn(ctx, cfg)
+	}
+	if s.publisher != nil {
+		s.publisher.PublishPostCommit(ctx, []events.Event{ConfigReloadedEvent{
+			BaseEvent: events.NewBaseEvent(ConfigReloadedEventType),
+			Changed:   names,
+			Features:  maps.Clone(cfg.Features),
+		}})
+	}
+	s.logger.InfoContext(ctx, "runtime config reloaded", slog.Any("changed", names))
+	return cfg, names, nil
+}
+
+// ReloadOnSignal reloads the configuration on every SIGHUP until ctx is done.
+func (s *Store) ReloadOnSignal(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(hup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				s.logger.InfoContext(ctx, "SIGHUP received, reloading runtime config")
+				s.Reload(ctx)
+			}
+		}
+	}()
+}
@@ -0,0 +1,115 @@
+package runtimeconfig
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+type publisherFunc func(ctx context.Context, evts []events.Event)
+
+func (f publisherFunc) PublishPostCommit(ctx context.Context, evts []events.Event) { f(ctx, evts) }
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestStore_ReloadNotifiesSubscribersAndPublishes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runtime.json")
+	writeFile(t, path, `{"log_levels": {"orders": "debug"}}`)
+
+	var published []events.Event
+	base := Config{LogLevel: "info", RateLimit: RateLimit{RequestsPerSecond: 10, Burst: 20}}
+	s, err := NewStore(base, path, publisherFunc(func(ctx context.Context, evts []events.Event) { published = append(published, evts...) }), slog.Default())
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	var seen []Config
+	s.Subscribe(func(ctx context.Context, cfg Config) { seen = append(seen, cfg) })
+	if len(seen) != 1 || seen[0].LogLevel != "info" || seen[0].LogLevels["orders"] != "debug" {
+		t.Fatalf("initial notification = %+v", seen)
+	}
+
+	writeFile(t, path, `{"log_levels": {"orders": "debug"}, "features": {"new_checkout": true}}`)
+	cfg, changed, err := s.Reload(context.Background())
+	if err != nil {
+		t.Fatalf("Reload() error = %v", err)
+	}
+	if !slices.Equal(changed, []string{"features"}) || !cfg.Features["new_checkout"] || cfg.RateLimit.Burst != 20 {
+		t.Errorf("Reload() = %+v, changed %v", cfg, changed)
+	}
+	if len(seen) != 2 || !seen[1].Features["new_checkout"] {
+		t.Errorf("subscriber saw %+v", seen)
+	}
+	if len(published) != 1 {
+		t.Fatalf("published %d events, want 1", len(published))
+	}
+	if evt := published[0].(ConfigReloadedEvent); evt.EventType() != ConfigReloadedEventType || !evt.Features["new_checkout"] {
+		t.Errorf("event = %+v", evt)
+	}
+
+	// Unchanged file: nobody is notified.
+	if _, changed, err := s.Reload(context.Background()); err != nil || changed != nil || len(seen) != 2 || len(published) != 1 {
+		t.Errorf("no-op reload: changed %v, err %v, %d notifications, %d events", changed, err, len(seen), len(published))
+	}
+}
+
+func TestStore_InvalidReloadKeepsConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runtime.json")
+	writeFile(t, path, `{"log_level": "warn"}`)
+	s, err := NewStore(Config{}, path, nil, slog.Default())
+	if err != nil {
+		t.Fatalf("NewStore() error = %v", err)
+	}
+
+	writeFile(t, path, `{"log_level": "loud"}`)
+	if _, _, err := s.Reload(context.Background()); err == nil {
+		t.Fatal("Reload() accepted an invalid level")
+	}
+	if got := s.Current().LogLevel; got != "warn" {
+		t.Errorf("log level after failed reload = %q, want warn", got)
+	}
+
+	if _, err := NewStore(Config{RateLimit: RateLimit{Burst: -1}}, "", nil, slog.Default()); err == nil {
+		t.Error("NewStore() accepted a negative burst")
+	}
+}
+
+func TestHTTPHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runtime.json")
+	writeFile(t, path, `{}`)
+	s, err := NewStore(Config{LogLevel: "info"}, path, nil, slog.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := s.HTTPHandler()
+
+	writeFile(t, path, `{"rate_limit": {"requests_per_second": 5, "burst": 10}}`)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/admin/config/reload", nil))
+	var resp reloadResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if rec.Code != http.StatusOK || resp.Config.RateLimit.RequestsPerSecond != 5 || !slices.Equal(resp.Changed, []string{"rate_limit"}) {
+		t.Errorf("reload: status %d, body %s", rec.Code, rec.Body)
+	}
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/config", nil))
+	if rec.Code != http.StatusOK || !json.Valid(rec.Body.Bytes()) {
+		t.Errorf("get: status %d, body %s", rec.Code, rec.Body)
+	}
+}
@@ -0,0 +1,109 @@
+// Package shutdown coordinates the graceful shutdown: a Coordinator runs the
+// phases in order (stop accepting requests, drain event handlers, stop
+// background jobs, wait for transactions, close clients) within one
+// deadline, and InFlight lets a component wait for the work it started.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// InFlight counts work in progress. Unlike sync.WaitGroup, work may start
+// while someone waits, and waiting is bounded by a context.
+type InFlight struct {
+	mu   sync.Mutex
+	n    int
+	idle chan struct{} // closed when n drops to zero
+}
+
+// Add records that a unit of work started.
+func (f *InFlight) Add() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.n == 0 {
+		f.idle = make(chan struct{})
+	}
+	f.n++
+}
+
+// Done records that a unit of work finished.
+func (f *InFlight) Done() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.n--
+	if f.n == 0 {
+		close(f.idle)
+	}
+}
+
+// Len returns the number of units in progress.
+func (f *InFlight) Len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.n
+}
+
+// Wait blocks until no work is in progress or ctx is done. Work started
+// by work in progress (e.g. an event handler publishing events) is waited
+// for too.
+func (f *InFlight) Wait(ctx context.Context) error {
+	for {
+		f.mu.Lock()
+		if f.n == 0 {
+			f.mu.Unlock()
+			return nil
+		}
+		idle := f.idle
+		f.mu.Unlock()
+
+		select {
+		case <-idle:
+		case <-ctx.Done():
+			return fmt.Errorf("%d still in progress: %w", f.Len(), ctx.Err())
+		}
+	}
+}
+
+// Coordinator runs shutdown phases in order.
+type Coordinator struct {
+	logger *slog.Logger
+	phases []phase
+}
+
+type phase struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// NewCoordinator returns a Coordinator that logs the progress of each phase.
+func NewCoordinator(logger *slog.Logger) *Coordinator {
+	return &Coordinator{logger: logger}
+}
+
+// Add appends a phase.
+func (c *Coordinator) Add(name string, run func(ctx context.Context) error) {
+	c.phases = append(c.phases, phase{name: name, run: run})
+}
+
+// Shutdown runs the phases in order, all within ctx's deadline. A phase
+// that fails or runs out of time is logged and the next one still runs, so
+// that clients are closed even when draining did not finish.
+func (c *Coordinator) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, p := range c.phases {
+		start := time.Now()
+		err := p.run(ctx)
+		if err != nil {
+			c.logger.Error("shutdown phase failed", slog.String("phase", p.name), slog.Duration("duration", time.Since(start)), slog.Any("error", err))
+			errs = append(errs, fmt.Errorf("%s: %w", p.name, err))
+			continue
+		}
+		c.logger.Info("shutdown phase completed", slog.String("phase", p.name), slog.Duration("duration", time.Since(start)))
+	}
+	return errors.Join(errs...)
+}
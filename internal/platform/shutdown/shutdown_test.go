@@ -0,0 +1,67 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestInFlight_WaitsForWorkStartedWhileWaiting(t *testing.T) {
+	var f InFlight
+	f.Add()
+
+	release := make(chan struct{})
+	go func() {
+		<-release
+		f.Add() // e.g. a handler publishing a follow-up event
+		f.Done()
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			f.Done()
+		}()
+	}()
+	close(release)
+
+	if err := f.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if n := f.Len(); n != 0 {
+		t.Errorf("Len() = %d after Wait, want 0", n)
+	}
+}
+
+func TestInFlight_WaitGivesUpAtDeadline(t *testing.T) {
+	var f InFlight
+	f.Add()
+	defer f.Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := f.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "1 still in progress") {
+		t.Errorf("Wait() error = %v", err)
+	}
+}
+
+func TestCoordinator_RunsEveryPhaseInOrder(t *testing.T) {
+	var ran []string
+	c := NewCoordinator(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	c.Add("http", func(ctx context.Context) error { ran = append(ran, "http"); return nil })
+	c.Add("events", func(ctx context.Context) error {
+		ran = append(ran, "events")
+		return context.DeadlineExceeded
+	})
+	c.Add("spanner", func(ctx context.Context) error { ran = append(ran, "spanner"); return nil })
+
+	err := c.Shutdown(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "events: ") {
+		t.Errorf("Shutdown() error = %v, want the events phase failure", err)
+	}
+	if got := strings.Join(ran, ","); got != "http,events,spanner" {
+		t.Errorf("phases = %s, want http,events,spanner", got)
+	}
+}
@@ -0,0 +1,75 @@
+package spanner
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"google.golang.org/grpc/status"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/chaos"
+)
+
+// ErrBatchWriteInTransaction is returned when BatchWrite is called within a
+// transaction on the same database: its writes would not be part of it.
+var ErrBatchWriteInTransaction = errors.New("spanner.BatchWrite: cannot batch write within a transaction")
+
+// BatchWrite applies groups of mutations with Spanner's BatchWrite, for bulk
+// ingestion that does not need a transaction around it. Each group is
+// applied atomically, but independently of the others and in no particular
+// order, so one failing group, e.g. on a duplicate key, does not stop the
+// rest. Groups are not replay protected and may be applied more than once:
+// use mutations that tolerate it, or expect AlreadyExists for an insert
+// that was in fact applied.
+//
+// It returns one error per group, nil for those applied. If the call itself
+// fails, its error is returned too, and the groups Spanner did not report
+// on carry it.
+func BatchWrite(ctx context.Context, client *spanner.Client, groups [][]*spanner.Mutation) (_ []error, err error) {
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	if txOn(ctx, client.DatabaseName()) != nil {
+		return nil, ErrBatchWriteInTransaction
+	}
+
+	ctx, endSpan := startSpan(ctx, "BatchWrite")
+	defer func() { endSpan(err) }()
+	if err := chaos.Inject(ctx, "spanner.BatchWrite"); err != nil {
+		return nil, err
+	}
+
+	mgs := make([]*spanner.MutationGroup, len(groups))
+	for i, g := range groups {
+		mgs[i] = &spanner.MutationGroup{Mutations: g}
+	}
+	results := make([]error, len(groups))
+	reported := make([]bool, len(groups))
+	err = client.BatchWrite(ctx, mgs).Do(func(r *spannerpb.BatchWriteResponse) error {
+		recordGroupResults(results, reported, r)
+		return nil
+	})
+	if err != nil {
+		err = classify(err)
+		for i := range results {
+			if !reported[i] {
+				results[i] = err
+			}
+		}
+	}
+	return results, err
+}
+
+// recordGroupResults records the outcome of the groups r reports on.
+func recordGroupResults(results []error, reported []bool, r *spannerpb.BatchWriteResponse) {
+	var groupErr error
+	if r.GetStatus().GetCode() != 0 {
+		groupErr = classify(status.ErrorProto(r.GetStatus()))
+	}
+	for _, i := range r.GetIndexes() {
+		if int(i) < len(results) {
+			results[i], reported[i] = groupErr, true
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package spanner
+
+import (
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/fault"
+)
+
+func TestRecordGroupResults(t *testing.T) {
+	results := make([]error, 3)
+	reported := make([]bool, 3)
+
+	recordGroupResults(results, reported, &spannerpb.BatchWriteResponse{Indexes: []int32{0, 2}})
+	recordGroupResults(results, reported, &spannerpb.BatchWriteResponse{
+		Indexes: []int32{1},
+		Status:  status.New(codes.AlreadyExists, "row exists").Proto(),
+	})
+
+	if results[0] != nil || results[2] != nil {
+		t.Errorf("applied groups have errors: %v", results)
+	}
+	if spanner.ErrCode(results[1]) != codes.AlreadyExists || fault.KindOf(results[1]) != fault.Conflict {
+		t.Errorf("results[1] = %v, want a classified AlreadyExists", results[1])
+	}
+	if reported[0] != true || reported[1] != true || reported[2] != true {
+		t.Errorf("reported = %v, want all", reported)
+	}
+}
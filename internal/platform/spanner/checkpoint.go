@@ -0,0 +1,72 @@
+package spanner
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/projection"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+// CheckpointStore stores projection checkpoints in the ProjectionCheckpoints
+// table, one per tenant (that of the request of ctx) and projection, as each
+// tenant's read model is rebuilt on its own. Save joins the caller's
+// read-write transaction, so a checkpoint commits with the batch it records.
+type CheckpointStore struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+var _ projection.CheckpointStore = (*CheckpointStore)(nil)
+
+func NewCheckpointStore(client *spanner.Client, logger *slog.Logger) *CheckpointStore {
+	return &CheckpointStore{client: client, logger: logger}
+}
+
+// Load returns the checkpoint of a projection, or a zero Checkpoint if it
+// was never rebuilt.
+func (s *CheckpointStore) Load(ctx context.Context, name string) (projection.Checkpoint, error) {
+	return SingleRead(ctx, s.client, s.logger, func(ctx context.Context, rtx ReadTransaction) (projection.Checkpoint, error) {
+		row, err := rtx.ReadRow(ctx, "ProjectionCheckpoints", spanner.Key{requestcontext.TenantID(ctx), name},
+			[]string{"Projection", "Rebuilding", "Cursor", "RebuiltAt", "UpdatedAt"})
+		if err != nil {
+			if spanner.ErrCode(err) == codes.NotFound {
+				return projection.Checkpoint{Projection: name}, nil
+			}
+			return projection.Checkpoint{}, fmt.Errorf("failed to read projection checkpoint: %w", err)
+		}
+
+		var cp projection.Checkpoint
+		var rebuiltAt spanner.NullTime
+		if err := row.Columns(&cp.Projection, &cp.Rebuilding, &cp.Cursor, &rebuiltAt, &cp.UpdatedAt); err != nil {
+			return projection.Checkpoint{}, fmt.Errorf("failed to scan projection checkpoint: %w", err)
+		}
+		if rebuiltAt.Valid {
+			cp.RebuiltAt = rebuiltAt.Time
+		}
+		return cp, nil
+	})
+}
+
+func (s *CheckpointStore) Save(ctx context.Context, cp projection.Checkpoint) error {
+	rebuiltAt := spanner.NullTime{Time: cp.RebuiltAt, Valid: !cp.RebuiltAt.IsZero()}
+	if err := Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO ProjectionCheckpoints (TenantID, Projection, Rebuilding, Cursor, RebuiltAt, UpdatedAt)
+		      VALUES (@tenantID, @projection, @rebuilding, @cursor, @rebuiltAt, @updatedAt)`,
+		Params: map[string]interface{}{
+			"tenantID":   requestcontext.TenantID(ctx),
+			"projection": cp.Projection,
+			"rebuilding": cp.Rebuilding,
+			"cursor":     cp.Cursor,
+			"rebuiltAt":  rebuiltAt,
+			"updatedAt":  cp.UpdatedAt,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save projection checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package spanner
+
+import (
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/fault"
+)
+
+// classify attaches a fault.Kind to Spanner errors whose meaning is known
+// regardless of the caller: retryable infrastructure failures are transient
+// and duplicate keys are conflicts. Errors that are already classified, or
+// that come from the caller's own code, are returned unchanged.
+func classify(err error) error {
+	if err == nil || fault.KindOf(err) != fault.Unclassified {
+		return err
+	}
+	switch spanner.ErrCode(err) {
+	case codes.Aborted, codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return fault.Wrap(err, fault.Transient)
+	case codes.AlreadyExists:
+		return fault.Wrap(err, fault.Conflict)
+	}
+	return err
+}
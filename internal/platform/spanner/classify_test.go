@@ -0,0 +1,43 @@
+package spanner
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/fault"
+)
+
+func TestClassify(t *testing.T) {
+	errDomain := errors.New("order not found")
+
+	tests := []struct {
+		name string
+		err  error
+		want fault.Kind
+	}{
+		{"aborted", status.Error(codes.Aborted, "transaction aborted"), fault.Transient},
+		{"unavailable", fmt.Errorf("reading order: %w", status.Error(codes.Unavailable, "unavailable")), fault.Transient},
+		{"duplicate", status.Error(codes.AlreadyExists, "row exists"), fault.Conflict},
+		{"not found", status.Error(codes.NotFound, "row not found"), fault.Unclassified},
+		{"domain", errDomain, fault.Unclassified},
+		{"already classified", fault.Wrap(status.Error(codes.Aborted, "transaction aborted"), fault.Fatal), fault.Fatal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := classify(tt.err)
+			if got := fault.KindOf(err); got != tt.want {
+				t.Errorf("KindOf(classify()) = %v, want %v", got, tt.want)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Errorf("classify() dropped the original error")
+			}
+		})
+	}
+	if classify(nil) != nil {
+		t.Error("classify(nil) != nil")
+	}
+}
@@ -13,6 +13,11 @@ type Config struct {
 	ProjectID  string
 	InstanceID string
 	DatabaseID string
+
+	// EnableEndToEndTracing makes Spanner create spans for its own RPCs and
+	// propagate the trace to the Spanner frontend. It only takes effect once
+	// a tracer provider has been installed (see the telemetry package).
+	EnableEndToEndTracing bool
 }
 
 // dsn returns the Spanner database connection string.
@@ -22,9 +27,22 @@ func (c Config) dsn() string {
 
 // The caller is responsible for closing the client when done.
 func NewClient(ctx context.Context, cfg Config) (*spanner.Client, error) {
-	client, err := spanner.NewClient(ctx, cfg.dsn())
+	client, err := spanner.NewClientWithConfig(ctx, cfg.dsn(), spanner.ClientConfig{
+		SessionPoolConfig:     spanner.DefaultSessionPoolConfig,
+		EnableEndToEndTracing: cfg.EnableEndToEndTracing,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create spanner client: %w", err)
 	}
 	return client, nil
 }
+
+// Ping runs a trivial query to check that the database is reachable.
+func Ping(ctx context.Context, client *spanner.Client) error {
+	iter := client.Single().Query(ctx, spanner.Statement{SQL: "SELECT 1"})
+	defer iter.Stop()
+	if _, err := iter.Next(); err != nil {
+		return fmt.Errorf("spanner ping: %w", err)
+	}
+	return nil
+}
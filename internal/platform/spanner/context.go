@@ -13,51 +13,99 @@ import (
 // create an independent transaction, breaking atomicity guarantees.
 var ErrNestedTransaction = errors.New("nested transaction detected: Cloud Spanner does not support nested transactions")
 
-// readWriteTxKey is the context key for storing Spanner ReadWriteTransaction.
-type readWriteTxKey struct{}
+// txKey is the context key for the transactions in scope.
+type txKey struct{}
 
-// withReadWriteTx embeds a Spanner ReadWriteTransaction in the context.
-// Returns ErrNestedTransaction if a transaction already exists in the context.
+// txEntry is a transaction in the context. Modules can have their own
+// database (see app.Platform.Databases), so a context can hold one
+// transaction per database: a pre-commit handler of a module on another
+// database runs its own transaction inside the publisher's. The innermost
+// entry is the transaction of the scope being executed, which Write uses;
+// reads and scopes look up the transaction of their client's database.
+type txEntry struct {
+	database string
+	rw       *spanner.ReadWriteTransaction
+	ro       *spanner.ReadOnlyTransaction
+	outer    *txEntry
+}
+
+func (e *txEntry) read() ReadTransaction {
+	if e.rw != nil {
+		return e.rw
+	}
+	return e.ro
+}
+
+// currentTx returns the innermost transaction, or nil.
+func currentTx(ctx context.Context) *txEntry {
+	e, _ := ctx.Value(txKey{}).(*txEntry)
+	return e
+}
+
+// txOn returns the transaction on database, or nil.
+func txOn(ctx context.Context, database string) *txEntry {
+	for e := currentTx(ctx); e != nil; e = e.outer {
+		if e.database == database {
+			return e
+		}
+	}
+	return nil
+}
+
+// joinTx makes e, a transaction of ctx, the innermost again, for a scope
+// that joins it inside a transaction on another database.
+func joinTx(ctx context.Context, e *txEntry) context.Context {
+	if currentTx(ctx) == e {
+		return ctx
+	}
+	joined := *e
+	joined.outer = currentTx(ctx)
+	return context.WithValue(ctx, txKey{}, &joined)
+}
+
+// withReadWriteTx embeds a Spanner ReadWriteTransaction on database in the context.
+// Returns ErrNestedTransaction if a transaction on database already exists in the context.
 //
 // Note: The ErrNestedTransaction path is currently unreachable because
 // ReadWriteTransactionScope.Execute performs an early guard before starting
 // the Spanner round-trip. This check is retained as a defense-in-depth measure.
-func withReadWriteTx(ctx context.Context, tx *spanner.ReadWriteTransaction) (context.Context, error) {
-	if _, ok := readTransactionFromContext(ctx); ok {
+func withReadWriteTx(ctx context.Context, database string, tx *spanner.ReadWriteTransaction) (context.Context, error) {
+	if txOn(ctx, database) != nil {
 		return nil, ErrNestedTransaction
 	}
-	return context.WithValue(ctx, readWriteTxKey{}, tx), nil
+	return context.WithValue(ctx, txKey{}, &txEntry{database: database, rw: tx, outer: currentTx(ctx)}), nil
 }
 
-// readWriteTxFromContext extracts a Spanner ReadWriteTransaction from context.
-// Returns (nil, false) if no transaction is present.
+// readWriteTxFromContext extracts the innermost Spanner ReadWriteTransaction from context.
+// Returns (nil, false) if no transaction is present or the innermost one is read-only.
 func readWriteTxFromContext(ctx context.Context) (*spanner.ReadWriteTransaction, bool) {
-	tx, ok := ctx.Value(readWriteTxKey{}).(*spanner.ReadWriteTransaction)
-	return tx, ok
+	if e := currentTx(ctx); e != nil && e.rw != nil {
+		return e.rw, true
+	}
+	return nil, false
 }
 
-// readOnlyTxKey is the context key for storing Spanner ReadOnlyTransaction.
-type readOnlyTxKey struct{}
-
-// withReadOnlyTx embeds a Spanner ReadOnlyTransaction in the context.
-// Returns ErrNestedTransaction if a transaction already exists in the context.
+// withReadOnlyTx embeds a Spanner ReadOnlyTransaction on database in the context.
+// Returns ErrNestedTransaction if a transaction on database already exists in the context.
 //
 // Note: The ErrNestedTransaction path is currently unreachable because
 // ReadOnlyTransactionScope.Execute joins any existing transaction via
 // readTransactionFromContext before reaching this function.
 // This check is retained as a defense-in-depth measure.
-func withReadOnlyTx(ctx context.Context, tx *spanner.ReadOnlyTransaction) (context.Context, error) {
-	if _, ok := readTransactionFromContext(ctx); ok {
+func withReadOnlyTx(ctx context.Context, database string, tx *spanner.ReadOnlyTransaction) (context.Context, error) {
+	if txOn(ctx, database) != nil {
 		return nil, ErrNestedTransaction
 	}
-	return context.WithValue(ctx, readOnlyTxKey{}, tx), nil
+	return context.WithValue(ctx, txKey{}, &txEntry{database: database, ro: tx, outer: currentTx(ctx)}), nil
 }
 
-// readOnlyTxFromContext extracts a Spanner ReadOnlyTransaction from context.
-// Returns (nil, false) if no transaction is present.
+// readOnlyTxFromContext extracts the innermost Spanner ReadOnlyTransaction from context.
+// Returns (nil, false) if no transaction is present or the innermost one is read-write.
 func readOnlyTxFromContext(ctx context.Context) (*spanner.ReadOnlyTransaction, bool) {
-	tx, ok := ctx.Value(readOnlyTxKey{}).(*spanner.ReadOnlyTransaction)
-	return tx, ok
+	if e := currentTx(ctx); e != nil && e.ro != nil {
+		return e.ro, true
+	}
+	return nil, false
 }
 
 // ReadTransaction is the common interface for Spanner read operations.
@@ -71,16 +119,12 @@ type ReadTransaction interface {
 var _ ReadTransaction = (*spanner.ReadWriteTransaction)(nil)
 var _ ReadTransaction = (*spanner.ReadOnlyTransaction)(nil)
 
-// readTransactionFromContext extracts a ReadTransaction from context.
-// It checks ReadWriteTransaction first (for read-your-writes within a write tx),
-// then ReadOnlyTransaction.
-// Returns (nil, false) if no transaction is present.
-func readTransactionFromContext(ctx context.Context) (ReadTransaction, bool) {
-	if rwTx, ok := readWriteTxFromContext(ctx); ok {
-		return rwTx, true
-	}
-	if roTx, ok := readOnlyTxFromContext(ctx); ok {
-		return roTx, true
+// readTransactionFromContext extracts the ReadTransaction on database from
+// context, read-write (for read-your-writes within a write tx) or read-only.
+// Returns (nil, false) if no transaction on database is present.
+func readTransactionFromContext(ctx context.Context, database string) (ReadTransaction, bool) {
+	if e := txOn(ctx, database); e != nil {
+		return e.read(), true
 	}
 	return nil, false
 }
@@ -0,0 +1,45 @@
+package spanner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+)
+
+func TestTransactionsPerDatabase(t *testing.T) {
+	shared, orders := &spanner.ReadWriteTransaction{}, &spanner.ReadWriteTransaction{}
+
+	ctx, err := withReadWriteTx(context.Background(), "shared", shared)
+	if err != nil {
+		t.Fatalf("withReadWriteTx(shared) error = %v", err)
+	}
+	// A pre-commit handler of a module on its own database
+	ctx, err = withReadWriteTx(ctx, "orders", orders)
+	if err != nil {
+		t.Fatalf("withReadWriteTx(orders) error = %v", err)
+	}
+	if tx, _ := readWriteTxFromContext(ctx); tx != orders {
+		t.Error("Write would not use the innermost transaction")
+	}
+	if rtx, _ := readTransactionFromContext(ctx, "shared"); rtx != shared {
+		t.Error("reads on the shared database do not join its transaction")
+	}
+	if _, ok := readTransactionFromContext(ctx, "payments"); ok {
+		t.Error("reads on another database joined a transaction")
+	}
+
+	// A scope on the shared database joins its transaction again
+	joined := joinTx(ctx, txOn(ctx, "shared"))
+	if tx, _ := readWriteTxFromContext(joined); tx != shared {
+		t.Error("Write would not use the joined transaction")
+	}
+	if rtx, _ := readTransactionFromContext(joined, "orders"); rtx != orders {
+		t.Error("joining lost the transaction on the orders database")
+	}
+
+	if _, err := withReadOnlyTx(joined, "orders", &spanner.ReadOnlyTransaction{}); !errors.Is(err, ErrNestedTransaction) {
+		t.Errorf("withReadOnlyTx(orders) error = %v, want ErrNestedTransaction", err)
+	}
+}
@@ -0,0 +1,35 @@
+package spanner
+
+import (
+	"errors"
+	"fmt"
+
+	"cloud.google.com/go/spanner"
+)
+
+// ErrCorruptRow is matched by every CorruptRowError.
+var ErrCorruptRow = errors.New("corrupt row")
+
+// CorruptRowError reports a stored row that does not make a valid
+// aggregate, e.g. one with a status the domain does not know, or one that
+// fails the aggregate's invariants (Column is then empty). Repositories
+// return it instead of reconstituting an invalid aggregate, naming the row
+// so that it can be repaired.
+//
+// It matches ErrCorruptRow, but not Err: the domain errors a column fails
+// to parse with describe invalid input, which the stored data is not.
+type CorruptRowError struct {
+	Table  string
+	Key    spanner.Key
+	Column string
+	Err    error
+}
+
+func (e *CorruptRowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("corrupt row %s%s: %v", e.Table, e.Key, e.Err)
+	}
+	return fmt.Sprintf("corrupt row %s%s: %s: %v", e.Table, e.Key, e.Column, e.Err)
+}
+
+func (e *CorruptRowError) Is(target error) bool { return target == ErrCorruptRow }
@@ -0,0 +1,33 @@
+package spanner
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+)
+
+func TestCorruptRowError(t *testing.T) {
+	errStatus := errors.New("invalid status")
+	err := fmt.Errorf("failed to find user: %w", &CorruptRowError{Table: "Users", Key: spanner.Key{"u-1"}, Column: "Status", Err: errStatus})
+
+	if got, want := err.Error(), `failed to find user: corrupt row Users("u-1"): Status: invalid status`; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if !errors.Is(err, ErrCorruptRow) {
+		t.Error("does not match ErrCorruptRow")
+	}
+	if errors.Is(err, errStatus) {
+		t.Error("matches the error of the column")
+	}
+	row := &CorruptRowError{Table: "Orders", Key: spanner.Key{"o-1"}, Err: errStatus}
+	if got, want := row.Error(), `corrupt row Orders("o-1"): invalid status`; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	var corrupt *CorruptRowError
+	if !errors.As(err, &corrupt) || corrupt.Key[0] != "u-1" {
+		t.Errorf("errors.As() = %+v", corrupt)
+	}
+}
@@ -16,6 +16,16 @@
 // embeds it in the context. Repository methods then call Write/SingleRead/
 // ConsistentRead, which transparently join that transaction.
 //
+// # Module Databases
+//
+// A module can keep its tables in its own database, with its own client and
+// scopes (see app.Platform.Databases). The context then holds at most one
+// transaction per database: a scope joins the transaction on its client's
+// database and starts one otherwise, so a module's pre-commit handler runs
+// in a transaction of its own, committed before the publisher's. Reads join
+// the transaction on their client's database; Write uses the transaction of
+// the scope being executed.
+//
 // # Choosing a Helper
 //
 //   - Write:          DML statements (INSERT, UPDATE, DELETE). Requires a
@@ -25,11 +35,21 @@
 //   - ConsistentRead: Multiple reads that must see the same snapshot
 //     (e.g., COUNT + SELECT). Falls back to a ReadOnlyTransaction
 //     when standalone.
+//   - BatchWrite:     Bulk inserts outside any transaction, in mutation
+//     groups applied independently, with an error per group. Refused
+//     within a transaction.
+//
+// # Indexes
+//
+// Secondary indexes are declared as Index values in the persistence package
+// that reads through them (e.g. the orders' indexes.go). Queries name them
+// with Index.From instead of a FORCE_INDEX literal, and a test checks with
+// MissingIndexes that schema/schema.sql creates each Index.DDL.
 //
 // # Enforcement
 //
 // The spannercheck linter (tools/spannercheck) forbids direct use of
-// client.Apply, client.Single, client.ReadOnlyTransaction, and
-// client.ReadWriteTransaction in persistence packages, ensuring all
+// client.Apply, client.Single, client.ReadOnlyTransaction,
+// client.ReadWriteTransaction, and client.BatchWrite in persistence packages, ensuring all
 // data access flows through these helpers.
 package spanner
@@ -0,0 +1,48 @@
+package spanner
+
+import (
+	"strings"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// maxTagLength is the longest transaction tag Spanner accepts.
+const maxTagLength = 50
+
+// transactionTag returns the tag of a transaction: the one in the hints, or
+// the business caller that started it, e.g. "commands/cancel_order.go:42".
+// Characters Spanner does not accept in tags are replaced by '_'.
+func transactionTag(h transaction.Hints, caller string) string {
+	tag := h.Tag
+	if tag == "" {
+		tag = caller
+	}
+	tag = strings.Map(func(r rune) rune {
+		if r < 0x20 || r > 0x7e {
+			return '_'
+		}
+		return r
+	}, tag)
+	if len(tag) > maxTagLength {
+		tag = tag[len(tag)-maxTagLength:] // keep the file and line
+	}
+	return tag
+}
+
+// commitPriority maps p to Spanner's request priority, falling back to def.
+func commitPriority(p, def transaction.Priority) spannerpb.RequestOptions_Priority {
+	if p == transaction.PriorityDefault {
+		p = def
+	}
+	switch p {
+	case transaction.PriorityLow:
+		return spannerpb.RequestOptions_PRIORITY_LOW
+	case transaction.PriorityMedium:
+		return spannerpb.RequestOptions_PRIORITY_MEDIUM
+	case transaction.PriorityHigh:
+		return spannerpb.RequestOptions_PRIORITY_HIGH
+	}
+	return spannerpb.RequestOptions_PRIORITY_UNSPECIFIED
+}
@@ -0,0 +1,43 @@
+package spanner
+
+import (
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/spanner/apiv1/spannerpb"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+func TestTransactionTag(t *testing.T) {
+	tests := []struct {
+		name   string
+		hints  transaction.Hints
+		caller string
+		want   string
+	}{
+		{"caller", transaction.Hints{}, "commands/cancel_order.go:42", "commands/cancel_order.go:42"},
+		{"hint wins", transaction.Hints{Tag: "orders.CancelOrder"}, "commands/cancel_order.go:42", "orders.CancelOrder"},
+		{"unprintable", transaction.Hints{Tag: "orders\tCancelé"}, "", "orders_Cancel_"},
+		{"too long", transaction.Hints{}, strings.Repeat("x", 60) + ".go:1", strings.Repeat("x", 45) + ".go:1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := transactionTag(tt.hints, tt.caller); got != tt.want {
+				t.Errorf("transactionTag() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitPriority(t *testing.T) {
+	if got := commitPriority(transaction.PriorityDefault, transaction.PriorityDefault); got != spannerpb.RequestOptions_PRIORITY_UNSPECIFIED {
+		t.Errorf("no priority = %v", got)
+	}
+	if got := commitPriority(transaction.PriorityDefault, transaction.PriorityMedium); got != spannerpb.RequestOptions_PRIORITY_MEDIUM {
+		t.Errorf("scope default = %v, want MEDIUM", got)
+	}
+	if got := commitPriority(transaction.PriorityLow, transaction.PriorityMedium); got != spannerpb.RequestOptions_PRIORITY_LOW {
+		t.Errorf("hinted = %v, want LOW", got)
+	}
+}
@@ -0,0 +1,67 @@
+package spanner
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/inbox"
+)
+
+// InboxStore stores the events a module received in its inbox table, e.g.
+// OrdersInbox, keyed by (Handler, EventID). Every module's inbox table has
+// the same columns; see schema/schema.sql.
+type InboxStore struct {
+	client *spanner.Client
+	logger *slog.Logger
+	table  string
+}
+
+var _ inbox.Store = (*InboxStore)(nil)
+
+func NewInboxStore(client *spanner.Client, logger *slog.Logger, table string) *InboxStore {
+	return &InboxStore{client: client, logger: logger, table: table}
+}
+
+// Receive must be called inside a read-write transaction; the read joins it
+// so that concurrent deliveries of the same event conflict.
+func (s *InboxStore) Receive(ctx context.Context, handler string, event events.Event) (bool, error) {
+	seen, err := SingleRead(ctx, s.client, s.logger, func(ctx context.Context, rtx ReadTransaction) (bool, error) {
+		_, err := rtx.ReadRow(ctx, s.table, spanner.Key{handler, event.EventID()}, []string{"EventID"})
+		if spanner.ErrCode(err) == codes.NotFound {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to read %s: %w", s.table, err)
+		}
+		return true, nil
+	})
+	if err != nil || seen {
+		return false, err
+	}
+
+	payload, err := events.Marshal(event)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode inbox event: %w", err)
+	}
+	if err := Write(ctx, spanner.Statement{
+		SQL: `INSERT INTO ` + s.table + ` (Handler, EventID, EventType, Payload, OccurredAt, ReceivedAt)
+		      VALUES (@handler, @eventID, @eventType, @payload, @occurredAt, @receivedAt)`,
+		Params: map[string]interface{}{
+			"handler":    handler,
+			"eventID":    event.EventID(),
+			"eventType":  event.EventType().String(),
+			"payload":    string(payload),
+			"occurredAt": event.OccurredAt(),
+			"receivedAt": time.Now().UTC(),
+		},
+	}); err != nil {
+		return false, fmt.Errorf("failed to record %s entry: %w", s.table, err)
+	}
+	return true, nil
+}
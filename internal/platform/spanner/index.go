@@ -0,0 +1,62 @@
+package spanner
+
+import "strings"
+
+// Index is a secondary index, declared once in the persistence package whose
+// queries read through it, so that their FORCE_INDEX hints and its
+// definition in schema/schema.sql cannot drift apart: queries select FROM
+// idx.From() rather than a literal hint, and the package tests that the
+// schema creates idx.DDL().
+type Index struct {
+	Name    string   // e.g. "OrdersByStatusCreatedAt"
+	Table   string   // e.g. "Orders"
+	Columns []string // the key, e.g. "Status", "CreatedAt DESC"
+	Unique  bool
+}
+
+// DDL returns the statement creating the index, as written in
+// schema/schema.sql.
+func (i Index) DDL() string {
+	create := "CREATE INDEX "
+	if i.Unique {
+		create = "CREATE UNIQUE INDEX "
+	}
+	return create + i.Name + " ON " + i.Table + "(" + strings.Join(i.Columns, ", ") + ")"
+}
+
+// From returns the table of a query reading through the index, e.g.
+// "Orders@{FORCE_INDEX=OrdersByStatusCreatedAt}". Only columns the index
+// stores (its key and the table's primary key) can be read without a join
+// back to the table.
+func (i Index) From() string {
+	return i.Table + "@{FORCE_INDEX=" + i.Name + "}"
+}
+
+// MissingIndexes returns the DDL of the indexes schema does not create, e.g.
+// for a test that schema/schema.sql is up to date with the code. Statements
+// are compared ignoring whitespace.
+func MissingIndexes(schema string, indexes ...Index) []string {
+	statements := map[string]bool{}
+	for stmt := range strings.SplitSeq(schema, ";") {
+		statements[normalizeDDL(stmt)] = true
+	}
+	var missing []string
+	for _, idx := range indexes {
+		if !statements[normalizeDDL(idx.DDL())] {
+			missing = append(missing, idx.DDL())
+		}
+	}
+	return missing
+}
+
+// normalizeDDL drops whitespace and comment lines from a DDL statement.
+func normalizeDDL(stmt string) string {
+	var b strings.Builder
+	for line := range strings.Lines(stmt) {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		b.WriteString(strings.Join(strings.Fields(line), ""))
+	}
+	return b.String()
+}
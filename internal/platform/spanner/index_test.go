@@ -0,0 +1,40 @@
+package spanner
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIndex(t *testing.T) {
+	idx := Index{Name: "OrdersByStatusCreatedAt", Table: "Orders", Columns: []string{"Status", "CreatedAt DESC"}}
+
+	if got, want := idx.DDL(), "CREATE INDEX OrdersByStatusCreatedAt ON Orders(Status, CreatedAt DESC)"; got != want {
+		t.Errorf("DDL() = %q, want %q", got, want)
+	}
+	if got, want := idx.From(), "Orders@{FORCE_INDEX=OrdersByStatusCreatedAt}"; got != want {
+		t.Errorf("From() = %q, want %q", got, want)
+	}
+	idx.Unique = true
+	if got, want := idx.DDL(), "CREATE UNIQUE INDEX OrdersByStatusCreatedAt ON Orders(Status, CreatedAt DESC)"; got != want {
+		t.Errorf("DDL() = %q, want %q", got, want)
+	}
+}
+
+func TestMissingIndexes(t *testing.T) {
+	schema := `CREATE TABLE Orders (
+    OrderID STRING(36) NOT NULL,
+) PRIMARY KEY (OrderID);
+
+-- Listing a user's orders
+CREATE INDEX OrdersByUserID
+    ON Orders(UserID);
+`
+	byUser := Index{Name: "OrdersByUserID", Table: "Orders", Columns: []string{"UserID"}}
+	byStatus := Index{Name: "OrdersByStatus", Table: "Orders", Columns: []string{"Status"}}
+
+	got := MissingIndexes(schema, byUser, byStatus)
+
+	if want := []string{byStatus.DDL()}; !slices.Equal(got, want) {
+		t.Errorf("MissingIndexes() = %q, want %q", got, want)
+	}
+}
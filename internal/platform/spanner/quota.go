@@ -0,0 +1,65 @@
+package spanner
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/quota"
+)
+
+// QuotaStore counts the uses of quotas in the QuotaCounters table, one row
+// per quota, key and window. Old windows are deleted by the table's row
+// deletion policy.
+type QuotaStore struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+var _ quota.Store = (*QuotaStore)(nil)
+
+func NewQuotaStore(client *spanner.Client, logger *slog.Logger) *QuotaStore {
+	return &QuotaStore{client: client, logger: logger}
+}
+
+// Add must be called inside a read-write transaction; the read joins it so
+// that concurrent uses of the same counter conflict.
+func (s *QuotaStore) Add(ctx context.Context, q, key string, window time.Time, n int64) (int64, error) {
+	count, err := SingleRead(ctx, s.client, s.logger, func(ctx context.Context, rtx ReadTransaction) (int64, error) {
+		row, err := rtx.ReadRow(ctx, "QuotaCounters", spanner.Key{q, key, window}, []string{"Count"})
+		if spanner.ErrCode(err) == codes.NotFound {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read quota counter: %w", err)
+		}
+		var count int64
+		if err := row.Columns(&count); err != nil {
+			return 0, fmt.Errorf("failed to scan quota counter: %w", err)
+		}
+		return count, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	count += n
+	if err := Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO QuotaCounters (Quota, Key, WindowStart, Count, UpdatedAt)
+		      VALUES (@quota, @key, @windowStart, @count, @updatedAt)`,
+		Params: map[string]interface{}{
+			"quota":       q,
+			"key":         key,
+			"windowStart": window,
+			"count":       count,
+			"updatedAt":   time.Now().UTC(),
+		},
+	}); err != nil {
+		return 0, fmt.Errorf("failed to write quota counter: %w", err)
+	}
+	return count, nil
+}
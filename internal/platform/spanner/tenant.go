@@ -0,0 +1,34 @@
+package spanner
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	createTable  = regexp.MustCompile(`(?s)^CREATE TABLE (\w+) \((.*)\) PRIMARY KEY`)
+	tenantColumn = regexp.MustCompile(`(?m)^TenantID\s`)
+)
+
+// UnscopedTables returns the tables of schema that have no TenantID column,
+// e.g. for a test that every table of schema/schema.sql is scoped to a
+// tenant. Interleaved tables are scoped by their parent and not reported.
+func UnscopedTables(schema string) []string {
+	var unscoped []string
+	for stmt := range strings.SplitSeq(schema, ";") {
+		var lines []string
+		for line := range strings.Lines(stmt) {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "--") {
+				lines = append(lines, line)
+			}
+		}
+		m := createTable.FindStringSubmatch(strings.Join(lines, "\n"))
+		if m == nil || strings.Contains(stmt, "INTERLEAVE IN PARENT") {
+			continue
+		}
+		if !tenantColumn.MatchString(m[2]) {
+			unscoped = append(unscoped, m[1])
+		}
+	}
+	return unscoped
+}
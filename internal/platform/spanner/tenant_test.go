@@ -0,0 +1,55 @@
+package spanner
+
+import (
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestUnscopedTables(t *testing.T) {
+	schema := `CREATE TABLE Orders (
+    OrderID  STRING(36) NOT NULL,
+    TenantID STRING(63) NOT NULL DEFAULT (''),
+) PRIMARY KEY (OrderID);
+
+CREATE TABLE OrderItems (
+    OrderID   STRING(36) NOT NULL,
+    ItemIndex INT64 NOT NULL,
+) PRIMARY KEY (OrderID, ItemIndex),
+  INTERLEAVE IN PARENT Orders ON DELETE CASCADE;
+
+-- Codes are unique per tenant.
+CREATE TABLE DiscountCodes (
+    Code STRING(32) NOT NULL,
+) PRIMARY KEY (Code);
+
+CREATE INDEX OrdersByTenantID ON Orders(TenantID);
+`
+	if got, want := UnscopedTables(schema), []string{"DiscountCodes"}; !slices.Equal(got, want) {
+		t.Errorf("UnscopedTables() = %q, want %q", got, want)
+	}
+}
+
+// globalTables are the tables of schema/schema.sql that are not scoped to a
+// tenant by a TenantID column, each for a reason.
+var globalTables = []string{
+	"OrderEvents",         // scoped by the Orders row of the stream
+	"OrderEventSnapshots", // scoped by the Orders row of the stream
+	"OrdersInbox",         // keyed by event ID; the envelope carries the tenant
+	"QuotaCounters",       // the key of a per-tenant quota holds the tenant
+	"AnalyticsInbox",      // keyed by event ID
+}
+
+// TestSchemaTablesScopedByTenant checks that every table of
+// schema/schema.sql, but the global ones, carries the tenant of its rows.
+func TestSchemaTablesScopedByTenant(t *testing.T) {
+	schema, err := os.ReadFile("../../../schema/schema.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, table := range UnscopedTables(string(schema)) {
+		if !slices.Contains(globalTables, table) {
+			t.Errorf("schema/schema.sql: table %s has no TenantID column", table)
+		}
+	}
+}
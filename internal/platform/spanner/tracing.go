@@ -0,0 +1,33 @@
+package spanner
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("spanner")
+
+// startSpan starts a span for a transaction scope or repository call, named
+// after op and attributed to the business caller (see businessCaller), so a
+// trace shows which repository method issued each read and write.
+// The returned function ends the span, recording err if non-nil.
+func startSpan(ctx context.Context, op string) (context.Context, func(error)) {
+	ctx, span := tracer.Start(ctx, "spanner."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "spanner"),
+			attribute.String("code.caller", businessCaller().Value.String()),
+		),
+	)
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"log/slog"
 
 	"cloud.google.com/go/spanner"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/chaos"
 )
 
 // ErrNoReadWriteTransaction is returned when Write is called without a
@@ -16,17 +18,22 @@ var ErrNoReadWriteTransaction = errors.New("spanner.Write: no read-write transac
 // read-only transaction scope.
 var ErrWriteInReadOnlyScope = errors.New("spanner.Write: cannot write within a read-only transaction scope")
 
-// Write executes one or more DML statements within an existing read-write
-// transaction from the context. Returns an error if no read-write transaction
+// Write executes one or more DML statements within the innermost read-write
+// transaction from the context, i.e. that of the scope being executed. Returns an error if no read-write transaction
 // is active; all writes must go through a ReadWriteTransactionScope.
 //
 // For a single statement, tx.Update is used directly.
 // For multiple statements, tx.BatchUpdate executes them in a single RPC.
-func Write(ctx context.Context, stmts ...spanner.Statement) error {
+// Faults injected through chaos.Scope apply here, as in SingleRead and
+// ConsistentRead, so every repository is subject to them.
+func Write(ctx context.Context, stmts ...spanner.Statement) (err error) {
 	if len(stmts) == 0 {
 		panic("spanner.Write: called with zero statements")
 	}
 
+	ctx, endSpan := startSpan(ctx, "Write")
+	defer func() { endSpan(err) }()
+
 	txn, ok := readWriteTxFromContext(ctx)
 	if !ok {
 		if _, ro := readOnlyTxFromContext(ctx); ro {
@@ -34,37 +41,60 @@ func Write(ctx context.Context, stmts ...spanner.Statement) error {
 		}
 		return ErrNoReadWriteTransaction
 	}
+	if err := chaos.Inject(ctx, "spanner.Write"); err != nil {
+		return err
+	}
 
 	if len(stmts) == 1 {
-		_, err := txn.Update(ctx, stmts[0])
-		return err
+		_, err = txn.Update(ctx, stmts[0])
+		return classify(err)
 	}
-	_, err := txn.BatchUpdate(ctx, stmts)
-	return err
+	_, err = txn.BatchUpdate(ctx, stmts)
+	return classify(err)
 }
 
-// SingleRead executes fn with a read transaction from the context, or falls back to
-// client.Single() for a one-shot read.
+// SingleRead executes fn with the context's read transaction on client's database,
+// or falls back to client.Single() for a one-shot read.
 // Use this for operations that perform a single read call.
 func SingleRead[T any](ctx context.Context, client *spanner.Client, logger *slog.Logger, fn func(ctx context.Context, rtx ReadTransaction) (T, error)) (T, error) {
-	if rtx, ok := readTransactionFromContext(ctx); ok {
-		return fn(ctx, rtx)
+	ctx, endSpan := startSpan(ctx, "SingleRead")
+	if err := chaos.Inject(ctx, "spanner.SingleRead"); err != nil {
+		endSpan(err)
+		var zero T
+		return zero, err
+	}
+
+	if rtx, ok := readTransactionFromContext(ctx, client.DatabaseName()); ok {
+		result, err := fn(ctx, rtx)
+		endSpan(err)
+		return result, err
 	}
 
 	finishLog := txLog(ctx, logger, TxSingleRead, "SingleRead")
 
 	result, err := fn(ctx, client.Single())
+	err = classify(err)
 	finishLog(err)
+	endSpan(err)
 	return result, err
 }
 
-// ConsistentRead executes fn with a consistent read transaction from the context,
-// or creates a new ReadOnlyTransaction for point-in-time consistent reads.
+// ConsistentRead executes fn with the context's read transaction on client's
+// database, or creates a new ReadOnlyTransaction for point-in-time consistent reads.
 // Use this when performing multiple reads that must see a consistent snapshot
 // (e.g., COUNT + SELECT, or reading from multiple tables).
 func ConsistentRead[T any](ctx context.Context, client *spanner.Client, logger *slog.Logger, fn func(ctx context.Context, rtx ReadTransaction) (T, error)) (T, error) {
-	if rtx, ok := readTransactionFromContext(ctx); ok {
-		return fn(ctx, rtx)
+	ctx, endSpan := startSpan(ctx, "ConsistentRead")
+	if err := chaos.Inject(ctx, "spanner.ConsistentRead"); err != nil {
+		endSpan(err)
+		var zero T
+		return zero, err
+	}
+
+	if rtx, ok := readTransactionFromContext(ctx, client.DatabaseName()); ok {
+		result, err := fn(ctx, rtx)
+		endSpan(err)
+		return result, err
 	}
 
 	finishLog := txLog(ctx, logger, TxReadOnly, "ConsistentRead")
@@ -73,6 +103,8 @@ func ConsistentRead[T any](ctx context.Context, client *spanner.Client, logger *
 	defer roTx.Close()
 
 	result, err := fn(ctx, roTx)
+	err = classify(err)
 	finishLog(err)
+	endSpan(err)
 	return result, err
 }
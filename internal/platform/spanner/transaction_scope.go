@@ -3,26 +3,63 @@ package spanner
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	"cloud.google.com/go/spanner"
+
+	"github.com/rai/clean-modularmonolith-go/internal/platform/metrics"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/shutdown"
+	"github.com/rai/clean-modularmonolith-go/internal/platform/watchdog"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
 )
 
 // ReadWriteTransactionScope manages the lifecycle of a Spanner read-write transaction.
 type ReadWriteTransactionScope struct {
-	client *spanner.Client
-	logger *slog.Logger
+	client   *spanner.Client
+	logger   *slog.Logger
+	metrics  *metrics.Registry
+	watchdog *watchdog.Watchdog
+	priority transaction.Priority
+	inflight shutdown.InFlight
+}
+
+// ScopeOption configures a ReadWriteTransactionScope.
+type ScopeOption func(*ReadWriteTransactionScope)
+
+// WithMetrics records aborted-and-retried transaction attempts in m.
+func WithMetrics(m *metrics.Registry) ScopeOption {
+	return func(s *ReadWriteTransactionScope) { s.metrics = m }
+}
+
+// WithSlowTransactionThreshold logs a "slow transaction" warning, with the
+// caller's stack, when a transaction is still open after d. Long transactions
+// usually mean slow pre-commit event handlers holding Spanner locks.
+// Zero disables the warning.
+func WithSlowTransactionThreshold(d time.Duration) ScopeOption {
+	return func(s *ReadWriteTransactionScope) { s.watchdog = watchdog.New(s.logger, d) }
+}
+
+// WithDefaultPriority sets the commit priority of transactions whose
+// transaction.Hints do not choose one.
+func WithDefaultPriority(p transaction.Priority) ScopeOption {
+	return func(s *ReadWriteTransactionScope) { s.priority = p }
 }
 
 // NewReadWriteTransactionScope creates a new Spanner-backed transaction scope.
 // It should be called once per application startup in main.
-func NewReadWriteTransactionScope(client *spanner.Client, logger *slog.Logger) *ReadWriteTransactionScope {
-	return &ReadWriteTransactionScope{client: client, logger: logger}
+func NewReadWriteTransactionScope(client *spanner.Client, logger *slog.Logger, opts ...ScopeOption) *ReadWriteTransactionScope {
+	s := &ReadWriteTransactionScope{client: client, logger: logger}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // Execute runs fn within a Spanner ReadWriteTransaction.
-// If a ReadWriteTransaction already exists in ctx, fn joins that transaction
-// instead of creating a new one (REQUIRED propagation semantics).
-// Returns ErrNestedTransaction if a ReadOnlyTransaction is active in ctx,
+// If a ReadWriteTransaction on the scope's database already exists in ctx, fn
+// joins that transaction instead of creating a new one (REQUIRED propagation
+// semantics); a transaction on another database does not count.
+// Returns ErrNestedTransaction if a ReadOnlyTransaction on the database is active in ctx,
 // since Cloud Spanner does not support nested transactions.
 // The transaction is committed if fn returns nil, rolled back otherwise.
 // The ctx passed to fn contains the transaction for repositories to use via Write/SingleRead/ConsistentRead.
@@ -30,29 +67,94 @@ func NewReadWriteTransactionScope(client *spanner.Client, logger *slog.Logger) *
 // IMPORTANT: Spanner may retry fn on Aborted errors. Therefore:
 //   - fn must be idempotent
 //   - fn must NOT perform external side effects (email, API calls, etc.)
+//     directly; it defers them with transaction.AfterCommit instead
 //   - Any state (like TransactionalPublisher) should be created inside fn
+//
+// The transaction is tagged and prioritized with the transaction.Hints in
+// ctx; without a tag, the business caller's file and line are used, so that
+// Spanner's lock and transaction statistics point at the code. Every retry
+// is logged with the cause of the abort, and the run in progress is told to
+// fn with transaction.RetryInfo.
+//
+// Side effects deferred by the run that commits are run after the commit,
+// in order, with ctx; those of aborted runs are dropped. Their failures are
+// logged: the transaction has committed, so Execute still returns nil.
 func (s *ReadWriteTransactionScope) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
-	if _, ok := readWriteTxFromContext(ctx); ok {
-		return fn(ctx)
-	}
-	if _, ok := readOnlyTxFromContext(ctx); ok {
-		s.logger.ErrorContext(ctx, "ReadWriteTransactionScope: attempted to nest read-write transaction inside read-only scope")
-		return ErrNestedTransaction
+	database := s.client.DatabaseName()
+	if e := txOn(ctx, database); e != nil {
+		if e.rw == nil {
+			s.logger.ErrorContext(ctx, "ReadWriteTransactionScope: attempted to nest read-write transaction inside read-only scope")
+			return ErrNestedTransaction
+		}
+		return fn(joinTx(ctx, e))
 	}
 
+	s.inflight.Add()
+	defer s.inflight.Done()
+
+	ctx, endSpan := startSpan(ctx, "ReadWriteTransaction")
 	finishLog := txLog(ctx, s.logger, TxReadWrite, "ReadWriteScope")
+	caller := businessCaller()
+	stopWatchdog := s.watchdog.Start(ctx, "slow transaction", slog.String("transaction_type", string(TxReadWrite)), caller)
+
+	hints := transaction.HintsFromContext(ctx)
+	opts := spanner.TransactionOptions{
+		TransactionTag: transactionTag(hints, caller.Value.String()),
+		CommitPriority: commitPriority(hints.Priority, s.priority),
+	}
 
-	_, err := s.client.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
-		txCtx, err := withReadWriteTx(ctx, tx)
+	// Spanner re-runs the function after an abort; every run past the first is a retry.
+	attempts := 0
+	var effects *transaction.DeferredSideEffects
+	var lastErr error
+	_, err := s.client.ReadWriteTransactionWithOptions(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		attempts++
+		if attempts > 1 {
+			s.logAbort(ctx, caller, opts.TransactionTag, attempts, lastErr)
+		}
+		txCtx, err := withReadWriteTx(ctx, database, tx)
 		if err != nil {
 			return err
 		}
-		return fn(txCtx)
-	})
-	finishLog(err)
+		txCtx, effects = transaction.WithDeferredSideEffects(txCtx)
+		lastErr = fn(transaction.WithRetryInfo(txCtx, transaction.RetryInfo{Attempt: attempts}))
+		return lastErr
+	}, opts)
+	stopWatchdog()
+	err = classify(err)
+	s.metrics.ObserveTransactionRetries(caller.Value.String(), attempts-1)
+	finishLog(err, slog.Int("attempts", attempts))
+	endSpan(err)
+	if err == nil && effects != nil {
+		if effectErr := effects.Run(ctx); effectErr != nil {
+			s.logger.ErrorContext(ctx, "deferred side effects failed after commit", slog.Any("error", effectErr))
+		}
+	}
 	return err
 }
 
+// logAbort logs that the previous run of a transaction was aborted and is
+// about to be retried. Spanner aborts a run either at one of its statements,
+// whose error the run returned, or at commit.
+func (s *ReadWriteTransactionScope) logAbort(ctx context.Context, caller slog.Attr, tag string, attempt int, runErr error) {
+	cause := "aborted at commit"
+	if runErr != nil {
+		cause = runErr.Error()
+	}
+	s.logger.WarnContext(ctx, "read-write transaction aborted, retrying",
+		caller,
+		slog.String("transaction_tag", tag),
+		slog.Int("attempt", attempt),
+		slog.String("cause", cause),
+	)
+}
+
+// Drain waits until the read-write transactions in progress have finished
+// or ctx is done, so that the client can be closed afterwards.
+func (s *ReadWriteTransactionScope) Drain(ctx context.Context) error {
+	return s.inflight.Wait(ctx)
+}
+
 // ReadOnlyTransactionScope manages the lifecycle of a Spanner read-only transaction.
 // Use this when you need consistent reads across multiple queries without writes.
 type ReadOnlyTransactionScope struct {
@@ -66,27 +168,31 @@ func NewReadOnlyTransactionScope(client *spanner.Client, logger *slog.Logger) *R
 }
 
 // Execute runs fn within a Spanner ReadOnlyTransaction.
-// If a ReadTransaction (read-write or read-only) already exists in ctx, fn joins
+// If a ReadTransaction (read-write or read-only) on the scope's database already exists in ctx, fn joins
 // that transaction instead of creating a new one (REQUIRED propagation semantics).
 // The ctx passed to fn contains the transaction for repositories to use via SingleRead/ConsistentRead.
 // The transaction is closed automatically when Execute returns.
 func (s *ReadOnlyTransactionScope) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
-	if _, ok := readTransactionFromContext(ctx); ok {
-		return fn(ctx)
+	database := s.client.DatabaseName()
+	if e := txOn(ctx, database); e != nil {
+		return fn(joinTx(ctx, e))
 	}
 
+	ctx, endSpan := startSpan(ctx, "ReadOnlyTransaction")
 	finishLog := txLog(ctx, s.logger, TxReadOnly, "ReadOnlyScope")
 
 	tx := s.client.ReadOnlyTransaction()
 	defer tx.Close()
 
-	txCtx, err := withReadOnlyTx(ctx, tx)
+	txCtx, err := withReadOnlyTx(ctx, database, tx)
 	if err != nil {
 		finishLog(err)
+		endSpan(err)
 		return err
 	}
 
-	err = fn(txCtx)
+	err = classify(fn(txCtx))
 	finishLog(err)
+	endSpan(err)
 	return err
 }
@@ -56,10 +56,11 @@ const (
 	TxSingleRead transactionType = "single-read"
 )
 
-// txLog logs "transaction starting" and returns a function to log the outcome.
+// txLog logs "transaction starting" and returns a function to log the
+// outcome, with attrs known only at the end (e.g. the number of attempts).
 // It uses businessCaller() to find the first frame outside infrastructure packages,
 // so it works correctly from both Scope.Execute and standalone functions (Write/SingleRead/ConsistentRead).
-func txLog(ctx context.Context, logger *slog.Logger, txType transactionType, op string) (finishLog func(error)) {
+func txLog(ctx context.Context, logger *slog.Logger, txType transactionType, op string) (finishLog func(err error, attrs ...slog.Attr)) {
 	var b [4]byte
 	_, _ = rand.Read(b[:])
 	txID := hex.EncodeToString(b[:])
@@ -79,10 +80,13 @@ func txLog(ctx context.Context, logger *slog.Logger, txType transactionType, op
 	logger.InfoContext(ctx, "transaction starting", args...)
 	start := time.Now()
 
-	return func(err error) {
-		doneArgs := make([]any, 0, len(args)+2)
+	return func(err error, attrs ...slog.Attr) {
+		doneArgs := make([]any, 0, len(args)+len(attrs)+2)
 		doneArgs = append(doneArgs, args...)
 		doneArgs = append(doneArgs, slog.Duration("duration", time.Since(start)))
+		for _, attr := range attrs {
+			doneArgs = append(doneArgs, attr)
+		}
 
 		if err != nil {
 			doneArgs = append(doneArgs, slog.Any("error", err))
@@ -0,0 +1,67 @@
+// Package telemetry installs the OpenTelemetry tracer provider that the
+// HTTP middleware, command decorators, Spanner helpers and event bus report to.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+)
+
+// Config holds tracing configuration.
+type Config struct {
+	// Enabled turns on span export. When false, spans are still created
+	// against the no-op provider and trace context is still propagated.
+	Enabled bool
+
+	ServiceName    string
+	ServiceVersion string
+
+	// SampleRatio is the fraction of new traces to sample, between 0 and 1.
+	// Requests that arrive with a sampled parent are always sampled.
+	SampleRatio float64
+}
+
+// Setup installs the global tracer provider and W3C propagator.
+// The OTLP/HTTP exporter reads its endpoint, headers and TLS settings from
+// the standard OTEL_EXPORTER_OTLP_* environment variables.
+// The returned shutdown function flushes buffered spans; call it on exit.
+func Setup(ctx context.Context, cfg Config) (shutdown func(context.Context) error, err error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if !cfg.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return nil, fmt.Errorf("trace sample ratio must be between 0 and 1, got %v", cfg.SampleRatio)
+	}
+
+	exporter, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(cfg.ServiceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
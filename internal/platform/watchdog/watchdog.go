@@ -0,0 +1,89 @@
+// Package watchdog warns about operations that run longer than expected,
+// such as Spanner transactions held open by slow in-transaction event
+// handlers, which show up as lock contention elsewhere.
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxStackFrames bounds the stack recorded with a warning.
+const maxStackFrames = 32
+
+// Watchdog logs a warning when a watched operation exceeds its threshold:
+// once while it is still running, so that hung operations are visible, and
+// again when it finishes, with the total duration.
+//
+// A nil Watchdog watches nothing, so callers need not check whether it is enabled.
+type Watchdog struct {
+	logger    *slog.Logger
+	threshold time.Duration
+}
+
+// New returns a Watchdog for threshold, or nil (disabled) when threshold is not positive.
+func New(logger *slog.Logger, threshold time.Duration) *Watchdog {
+	if threshold <= 0 {
+		return nil
+	}
+	return &Watchdog{logger: logger, threshold: threshold}
+}
+
+// Start watches an operation described by msg (e.g. "slow transaction") and
+// attrs. The stack of the caller is captured so that the warning points at
+// the code that started the operation. stop must be called when it finishes.
+func (w *Watchdog) Start(ctx context.Context, msg string, attrs ...slog.Attr) (stop func()) {
+	if w == nil {
+		return func() {}
+	}
+
+	var pcs [maxStackFrames]uintptr
+	n := runtime.Callers(2, pcs[:])
+	stack := pcs[:n]
+
+	if traceID := trace.SpanContextFromContext(ctx).TraceID(); traceID.IsValid() {
+		attrs = append(attrs, slog.String("trace_id", traceID.String()))
+	}
+	attrs = append(attrs, slog.Duration("threshold", w.threshold))
+
+	start := time.Now()
+	timer := time.AfterFunc(w.threshold, func() {
+		w.logger.LogAttrs(ctx, slog.LevelWarn, msg, slices.Concat(attrs, []slog.Attr{
+			slog.Bool("still_running", true),
+			slog.Duration("elapsed", time.Since(start)),
+			slog.Any("stack", formatStack(stack)),
+		})...)
+	})
+
+	return func() {
+		if timer.Stop() {
+			return // finished within the threshold
+		}
+		w.logger.LogAttrs(ctx, slog.LevelWarn, msg, slices.Concat(attrs, []slog.Attr{
+			slog.Bool("still_running", false),
+			slog.Duration("duration", time.Since(start)),
+		})...)
+	}
+}
+
+// formatStack renders pcs as "function file:line" entries, dropping runtime frames.
+func formatStack(pcs []uintptr) []string {
+	frames := runtime.CallersFrames(pcs)
+	var stack []string
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "runtime.") {
+			stack = append(stack, fmt.Sprintf("%s %s:%d", frame.Function, frame.File, frame.Line))
+		}
+		if !more {
+			return stack
+		}
+	}
+}
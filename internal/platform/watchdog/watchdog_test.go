@@ -0,0 +1,86 @@
+package watchdog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// syncBuffer guards the log output, which the timer goroutine writes to.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) records(t *testing.T) []map[string]any {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var recs []map[string]any
+	for line := range strings.SplitSeq(strings.TrimSpace(b.buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var rec map[string]any
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("unmarshal %q: %v", line, err)
+		}
+		recs = append(recs, rec)
+	}
+	return recs
+}
+
+func TestWatchdog_FastOperationLogsNothing(t *testing.T) {
+	var out syncBuffer
+	w := New(slog.New(slog.NewJSONHandler(&out, nil)), time.Hour)
+
+	w.Start(context.Background(), "slow transaction")()
+
+	if recs := out.records(t); len(recs) != 0 {
+		t.Errorf("records = %v, want none", recs)
+	}
+}
+
+func TestWatchdog_SlowOperationWarnsWhileRunningAndOnFinish(t *testing.T) {
+	var out syncBuffer
+	w := New(slog.New(slog.NewJSONHandler(&out, nil)), 10*time.Millisecond)
+
+	stop := w.Start(context.Background(), "slow transaction", slog.String("caller", "commands/submit_order.go:42"))
+	time.Sleep(50 * time.Millisecond)
+	stop()
+
+	recs := out.records(t)
+	if len(recs) != 2 {
+		t.Fatalf("records = %v, want 2", recs)
+	}
+	running, finished := recs[0], recs[1]
+	if running["level"] != "WARN" || running["msg"] != "slow transaction" || running["still_running"] != true || running["caller"] != "commands/submit_order.go:42" {
+		t.Errorf("running record = %v", running)
+	}
+	stack, _ := running["stack"].([]any)
+	if len(stack) == 0 || !strings.Contains(stack[0].(string), "TestWatchdog_SlowOperationWarnsWhileRunningAndOnFinish") {
+		t.Errorf("stack = %v, want the caller first", running["stack"])
+	}
+	if finished["still_running"] != false || finished["duration"] == nil {
+		t.Errorf("finished record = %v", finished)
+	}
+}
+
+func TestWatchdog_Disabled(t *testing.T) {
+	if New(slog.Default(), 0) != nil {
+		t.Fatal("New() with zero threshold should be nil")
+	}
+	var w *Watchdog
+	w.Start(context.Background(), "slow transaction")()
+}
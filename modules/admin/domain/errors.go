@@ -0,0 +1,8 @@
+package domain
+
+import "errors"
+
+var (
+	ErrOrderNotFound = errors.New("order not found")
+	ErrQueueNotFound = errors.New("dead letter queue not found")
+)
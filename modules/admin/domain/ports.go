@@ -0,0 +1,87 @@
+// Package domain contains the read models the admin module serves and the
+// ports it reaches the other modules through. The module owns no data: it
+// gathers the operational endpoints the other modules expose to operators,
+// through adapters wired in at composition time so that this module never
+// imports their internals and their public routes stay free of them.
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// User is a user's profile.
+type User struct {
+	ID        string
+	Email     string
+	FirstName string
+	LastName  string
+	Status    string
+	CreatedAt time.Time
+}
+
+// UserPage is a page of users and the total number of users.
+type UserPage struct {
+	Users      []User
+	TotalCount int
+}
+
+// UserDirectory is the admin module's port for users.
+type UserDirectory interface {
+	// DeletedUsers returns a page of the soft-deleted users, newest first.
+	DeletedUsers(ctx context.Context, offset, limit int) (UserPage, error)
+}
+
+// UserDirectoryFunc adapts an ordinary function to UserDirectory.
+type UserDirectoryFunc func(ctx context.Context, offset, limit int) (UserPage, error)
+
+func (f UserDirectoryFunc) DeletedUsers(ctx context.Context, offset, limit int) (UserPage, error) {
+	return f(ctx, offset, limit)
+}
+
+// OrderCanceller is the admin module's port for orders.
+type OrderCanceller interface {
+	// CancelOrder cancels an order whatever its owner, on behalf of actor.
+	// It returns ErrOrderNotFound if the order does not exist, and errors
+	// classified as fault.Client or fault.Conflict if the request is
+	// invalid or the order cannot be cancelled.
+	CancelOrder(ctx context.Context, orderID, reason, actor string) error
+}
+
+// OrderCancellerFunc adapts an ordinary function to OrderCanceller.
+type OrderCancellerFunc func(ctx context.Context, orderID, reason, actor string) error
+
+func (f OrderCancellerFunc) CancelOrder(ctx context.Context, orderID, reason, actor string) error {
+	return f(ctx, orderID, reason, actor)
+}
+
+// DeadLetter is a message whose delivery failed for good, retries
+// exhausted, e.g. a webhook delivery or a notification.
+type DeadLetter struct {
+	ID        string
+	Target    string // where it was for, e.g. a subscription ID or a channel
+	Subject   string // what it was about, e.g. an event type or a template
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+}
+
+// DeadLetterPage is a page of dead letters and the total number of them.
+type DeadLetterPage struct {
+	DeadLetters []DeadLetter
+	TotalCount  int
+}
+
+// DeadLetterQueue is the admin module's port for the dead letters of a
+// module.
+type DeadLetterQueue interface {
+	// DeadLetters returns a page of the dead letters, newest first.
+	DeadLetters(ctx context.Context, offset, limit int) (DeadLetterPage, error)
+}
+
+// DeadLetterQueueFunc adapts an ordinary function to DeadLetterQueue.
+type DeadLetterQueueFunc func(ctx context.Context, offset, limit int) (DeadLetterPage, error)
+
+func (f DeadLetterQueueFunc) DeadLetters(ctx context.Context, offset, limit int) (DeadLetterPage, error) {
+	return f(ctx, offset, limit)
+}
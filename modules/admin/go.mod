@@ -0,0 +1,3 @@
+module github.com/rai/clean-modularmonolith-go/modules/admin
+
+go 1.26.0
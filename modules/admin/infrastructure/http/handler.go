@@ -0,0 +1,189 @@
+// Package http provides the operational HTTP endpoints of the admin module.
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/admin/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/fault"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+type Handler struct {
+	users       domain.UserDirectory
+	orders      domain.OrderCanceller
+	deadLetters map[string]domain.DeadLetterQueue
+	queues      []string
+	admin       security.AdminGuard
+}
+
+// RegisterRoutes registers the admin module routes to the given mux.
+// Every route requires the admin token; they are disabled when it is empty.
+// deadLetters are the dead letter queues by name, e.g. "webhooks".
+func RegisterRoutes(
+	mux *http.ServeMux,
+	users domain.UserDirectory,
+	orders domain.OrderCanceller,
+	deadLetters map[string]domain.DeadLetterQueue,
+	admin security.AdminGuard,
+) {
+	h := &Handler{
+		users:       users,
+		orders:      orders,
+		deadLetters: deadLetters,
+		admin:       admin,
+	}
+	for name := range deadLetters {
+		h.queues = append(h.queues, name)
+	}
+	slices.Sort(h.queues)
+
+	mux.HandleFunc("GET /admin/users/deleted", h.admin.HandlerFunc(h.handleListDeletedUsers))
+	mux.HandleFunc("POST /admin/orders/{id}/cancel", h.admin.HandlerFunc(h.handleCancelOrder))
+	mux.HandleFunc("GET /admin/dead-letters", h.admin.HandlerFunc(h.handleListQueues))
+	mux.HandleFunc("GET /admin/dead-letters/{queue}", h.admin.HandlerFunc(h.handleListDeadLetters))
+}
+
+// Request/Response DTOs
+
+type userDTO struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type userListResponse struct {
+	Users      []userDTO `json:"users"`
+	TotalCount int       `json:"total_count"`
+	Offset     int       `json:"offset"`
+	Limit      int       `json:"limit"`
+}
+
+type cancelOrderRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+type queuesResponse struct {
+	Queues []string `json:"queues"`
+}
+
+type deadLetterDTO struct {
+	ID        string    `json:"id"`
+	Target    string    `json:"target"`
+	Subject   string    `json:"subject"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type deadLetterListResponse struct {
+	Queue       string          `json:"queue"`
+	DeadLetters []deadLetterDTO `json:"dead_letters"`
+	TotalCount  int             `json:"total_count"`
+	Offset      int             `json:"offset"`
+	Limit       int             `json:"limit"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handlers
+
+func (h *Handler) handleListDeletedUsers(w http.ResponseWriter, r *http.Request) {
+	q := page.ParseRequest(r.URL.Query())
+	p := page.NewRequest(q.Offset, q.Limit)
+	result, err := h.users.DeletedUsers(r.Context(), p.Offset, p.Limit)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	resp := userListResponse{Users: make([]userDTO, len(result.Users)), TotalCount: result.TotalCount, Offset: p.Offset, Limit: p.Limit}
+	for i, u := range result.Users {
+		resp.Users[i] = userDTO(u)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleCancelOrder serves POST /admin/orders/{id}/cancel, which cancels an
+// order whoever owns it, attributed to the admin.
+func (h *Handler) handleCancelOrder(w http.ResponseWriter, r *http.Request) {
+	// The body is optional: {"reason": "..."}
+	var req cancelOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.orders.CancelOrder(r.Context(), r.PathValue("id"), req.Reason, h.admin.Actor(r)); err != nil {
+		handleError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleListQueues(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, queuesResponse{Queues: h.queues})
+}
+
+func (h *Handler) handleListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("queue")
+	queue, ok := h.deadLetters[name]
+	if !ok {
+		handleError(w, domain.ErrQueueNotFound)
+		return
+	}
+
+	q := page.ParseRequest(r.URL.Query())
+	p := page.NewRequest(q.Offset, q.Limit)
+	result, err := queue.DeadLetters(r.Context(), p.Offset, p.Limit)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	resp := deadLetterListResponse{Queue: name, DeadLetters: make([]deadLetterDTO, len(result.DeadLetters)), TotalCount: result.TotalCount, Offset: p.Offset, Limit: p.Limit}
+	for i, d := range result.DeadLetters {
+		resp.DeadLetters[i] = deadLetterDTO(d)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// handleError maps an error to a response. The errors of the other modules
+// come through the ports classified by the adapters (see package fault), so
+// that this module needs not know their sentinels.
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrOrderNotFound),
+		errors.Is(err, domain.ErrQueueNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case fault.KindOf(err) == fault.Client:
+		writeError(w, http.StatusBadRequest, err.Error())
+	case fault.KindOf(err) == fault.Conflict:
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+// Helper functions
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
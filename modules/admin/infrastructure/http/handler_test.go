@@ -0,0 +1,151 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/admin/domain"
+	adminhttp "github.com/rai/clean-modularmonolith-go/modules/admin/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/fault"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/handlertest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+const (
+	adminToken = "admin-secret"
+	orderID    = "0b5e1d5a-6c1f-4f3e-9c3a-2f7f1d1b2c3d"
+)
+
+var createdAt = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+type deps struct {
+	users       domain.UserDirectoryFunc
+	orders      domain.OrderCancellerFunc
+	deadLetters map[string]domain.DeadLetterQueue
+}
+
+func newMux(d deps) *http.ServeMux {
+	mux := http.NewServeMux()
+	adminhttp.RegisterRoutes(mux, d.users, d.orders, d.deadLetters, security.AdminGuard{Module: "admin", Token: adminToken})
+	return mux
+}
+
+func adminRequest(t *testing.T, method, target string, body any) *http.Request {
+	req := handlertest.NewRequest(t, method, target, body)
+	req.Header.Set(security.AdminTokenHeader, adminToken)
+	return req
+}
+
+func TestRoutes_RequireAdmin(t *testing.T) {
+	routes := []struct{ method, target string }{
+		{http.MethodGet, "/admin/users/deleted"},
+		{http.MethodPost, "/admin/orders/" + orderID + "/cancel"},
+		{http.MethodGet, "/admin/dead-letters"},
+		{http.MethodGet, "/admin/dead-letters/webhooks"},
+	}
+	for _, route := range routes {
+		t.Run(route.method+" "+route.target, func(t *testing.T) {
+			rec := handlertest.Serve(newMux(deps{}), handlertest.NewRequest(t, route.method, route.target, nil))
+			handlertest.AssertStatus(t, rec, http.StatusForbidden)
+		})
+	}
+}
+
+func TestListDeletedUsers(t *testing.T) {
+	var gotOffset, gotLimit int
+	users := func(ctx context.Context, offset, limit int) (domain.UserPage, error) {
+		gotOffset, gotLimit = offset, limit
+		return domain.UserPage{TotalCount: 1, Users: []domain.User{
+			{ID: "7c9e6679-7425-40de-944b-e07fc1f90ae7", Email: "ada@example.com", FirstName: "Ada", LastName: "Lovelace", Status: "deleted", CreatedAt: createdAt},
+		}}, nil
+	}
+
+	rec := handlertest.Serve(newMux(deps{users: users}), adminRequest(t, http.MethodGet, "/admin/users/deleted?offset=5&limit=500", nil))
+
+	handlertest.AssertStatus(t, rec, http.StatusOK)
+	handlertest.AssertGolden(t, rec, "deleted_users")
+	if gotOffset != 5 || gotLimit != 100 {
+		t.Errorf("DeletedUsers(offset %d, limit %d), want 5, 100", gotOffset, gotLimit)
+	}
+}
+
+func TestCancelOrder(t *testing.T) {
+	var gotID, gotReason, gotActor string
+	orders := func(ctx context.Context, id, reason, actor string) error {
+		gotID, gotReason, gotActor = id, reason, actor
+		return nil
+	}
+
+	rec := handlertest.Serve(newMux(deps{orders: orders}), adminRequest(t, http.MethodPost, "/admin/orders/"+orderID+"/cancel", map[string]string{"reason": "fraud"}))
+
+	handlertest.AssertStatus(t, rec, http.StatusNoContent)
+	if gotID != orderID || gotReason != "fraud" || gotActor != security.AdminActor {
+		t.Errorf("CancelOrder(%q, %q, %q), want %q, fraud, %q", gotID, gotReason, gotActor, orderID, security.AdminActor)
+	}
+}
+
+func TestCancelOrder_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		golden string
+	}{
+		{"not found", domain.ErrOrderNotFound, "cancel_not_found"},
+		{"invalid", fault.Wrap(errors.New("invalid order ID"), fault.Client), "cancel_invalid"},
+		{"not cancellable", fault.Wrap(errors.New("order is already completed"), fault.Conflict), "cancel_conflict"},
+		{"unexpected", errors.New("spanner: session pool exhausted"), "cancel_internal_error"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orders := func(ctx context.Context, id, reason, actor string) error { return tt.err }
+
+			rec := handlertest.Serve(newMux(deps{orders: orders}), adminRequest(t, http.MethodPost, "/admin/orders/"+orderID+"/cancel", nil))
+
+			handlertest.AssertGolden(t, rec, tt.golden)
+		})
+	}
+}
+
+func TestDeadLetters(t *testing.T) {
+	webhooks := func(ctx context.Context, offset, limit int) (domain.DeadLetterPage, error) {
+		return domain.DeadLetterPage{TotalCount: 1, DeadLetters: []domain.DeadLetter{
+			{ID: "d-1", Target: "s-1", Subject: "orders.OrderConfirmed", Attempts: 8, LastError: "status 503", CreatedAt: createdAt},
+		}}, nil
+	}
+	mux := newMux(deps{deadLetters: map[string]domain.DeadLetterQueue{
+		"webhooks":      domain.DeadLetterQueueFunc(webhooks),
+		"notifications": domain.DeadLetterQueueFunc(nil),
+	}})
+
+	t.Run("queues", func(t *testing.T) {
+		rec := handlertest.Serve(mux, adminRequest(t, http.MethodGet, "/admin/dead-letters", nil))
+		handlertest.AssertJSON(t, rec, `{"queues":["notifications","webhooks"]}`)
+	})
+	t.Run("queue", func(t *testing.T) {
+		rec := handlertest.Serve(mux, adminRequest(t, http.MethodGet, "/admin/dead-letters/webhooks", nil))
+		handlertest.AssertStatus(t, rec, http.StatusOK)
+		handlertest.AssertGolden(t, rec, "dead_letters")
+	})
+	t.Run("unknown queue", func(t *testing.T) {
+		rec := handlertest.Serve(mux, adminRequest(t, http.MethodGet, "/admin/dead-letters/payments", nil))
+		handlertest.AssertGolden(t, rec, "unknown_queue")
+	})
+}
+
+// TestUnsupportedOperations_NotRegistered checks that there are no routes
+// for the operations without a backing: no event store to replay events from
+// and no outbox to view.
+func TestUnsupportedOperations_NotRegistered(t *testing.T) {
+	for _, req := range []*http.Request{
+		adminRequest(t, http.MethodPost, "/admin/events/replay", nil),
+		adminRequest(t, http.MethodGet, "/admin/outbox", nil),
+	} {
+		t.Run(req.Method+" "+req.URL.Path, func(t *testing.T) {
+			rec := handlertest.Serve(newMux(deps{}), req)
+			handlertest.AssertStatus(t, rec, http.StatusNotFound)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+)
+
+var pageParams = []openapi.Param{
+	{Name: "offset", Type: "integer"},
+	{Name: "limit", Type: "integer"},
+}
+
+// Operations documents the routes registered by RegisterRoutes.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Pattern: "GET /admin/users/deleted", Summary: "List soft-deleted users", Admin: true, Query: pageParams, Response: userListResponse{}},
+		{Pattern: "POST /admin/orders/{id}/cancel", Summary: "Force-cancel an order", Description: "Cancels the order whoever owns it, attributed to the admin.", Admin: true, Request: cancelOrderRequest{}, Status: http.StatusNoContent},
+		{Pattern: "GET /admin/dead-letters", Summary: "List the dead letter queues", Admin: true, Response: queuesResponse{}},
+		{Pattern: "GET /admin/dead-letters/{queue}", Summary: "List the messages whose delivery failed for good", Admin: true, Query: pageParams, Response: deadLetterListResponse{}},
+	}
+}
@@ -0,0 +1,61 @@
+// Package admin serves the operational endpoints under /admin: listing
+// deleted users, force-cancelling orders and inspecting the dead letter
+// queues. It owns no data and reaches the other modules only through its
+// ports, adapted from their module roots at composition time, so that
+// operational endpoints stay out of the public modules' routes.
+package admin
+
+import (
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/admin/domain"
+	httphandler "github.com/rai/clean-modularmonolith-go/modules/admin/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+// Module is the public API for the admin module.
+// External communication: HTTP API (RegisterRoutes)
+type Module interface {
+	// RegisterRoutes registers the /admin routes to the given mux.
+	RegisterRoutes(mux *http.ServeMux)
+	// Operations documents the routes for the OpenAPI specification.
+	Operations() []openapi.Operation
+}
+
+// Config holds the module configuration.
+type Config struct {
+	Users  domain.UserDirectory
+	Orders domain.OrderCanceller
+	// DeadLetters are the dead letter queues by name, e.g. "webhooks".
+	DeadLetters map[string]domain.DeadLetterQueue
+
+	// AdminToken guards every route via the X-Admin-Token header. The
+	// routes are disabled when empty.
+	AdminToken string
+
+	// SecuritySink receives the admin token decisions made by the HTTP
+	// handlers. Optional: nil records nothing.
+	SecuritySink security.Sink
+}
+
+type module struct {
+	cfg   Config
+	admin security.AdminGuard
+}
+
+// New initializes the admin module.
+func New(cfg Config) Module {
+	return &module{
+		cfg:   cfg,
+		admin: security.AdminGuard{Module: "admin", Token: cfg.AdminToken, Sink: cfg.SecuritySink},
+	}
+}
+
+func (m *module) RegisterRoutes(mux *http.ServeMux) {
+	httphandler.RegisterRoutes(mux, m.cfg.Users, m.cfg.Orders, m.cfg.DeadLetters, m.admin)
+}
+
+func (m *module) Operations() []openapi.Operation {
+	return httphandler.Operations()
+}
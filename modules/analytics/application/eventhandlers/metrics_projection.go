@@ -0,0 +1,104 @@
+// Package eventhandlers contains the analytics projections fed by domain events.
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/analytics/domain"
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	paymentevents "github.com/rai/clean-modularmonolith-go/modules/payments/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/projection"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+)
+
+// MetricsEventTypes lists the events that MetricsProjection consumes.
+var MetricsEventTypes = []events.EventType{
+	orderevents.OrderSubmittedEventType,
+	orderevents.OrderCancelledEventType,
+	orderevents.RefundIssuedEventType,
+	paymentevents.PaymentCapturedEventType,
+	userevents.UserCreatedEventType,
+}
+
+// MetricsProjection folds public domain events into the analytics buckets.
+// It runs post-commit so reporting never slows down or fails a business
+// transaction; the inbox makes each event count once even if it is
+// delivered again.
+//
+// It cannot be rebuilt: the buckets count events that no module keeps.
+type MetricsProjection struct {
+	metrics domain.MetricsRepository
+	inbox   domain.InboxRepository
+	txScope transaction.Scope
+}
+
+var _ projection.Projection = (*MetricsProjection)(nil)
+
+func NewMetricsProjection(metrics domain.MetricsRepository, inbox domain.InboxRepository, txScope transaction.Scope) *MetricsProjection {
+	return &MetricsProjection{
+		metrics: metrics,
+		inbox:   inbox,
+		txScope: txScope,
+	}
+}
+
+func (h *MetricsProjection) Name() string                   { return "analytics.Metrics" }
+func (h *MetricsProjection) EventTypes() []events.EventType { return MetricsEventTypes }
+
+func (h *MetricsProjection) Apply(ctx context.Context, event events.Event) error {
+	return h.txScope.Execute(ctx, func(ctx context.Context) error {
+		first, err := h.inbox.MarkProcessed(ctx, event.EventID(), event.OccurredAt())
+		if err != nil {
+			return fmt.Errorf("recording event: %w", err)
+		}
+		if !first {
+			return nil
+		}
+
+		day := domain.DayOf(event.OccurredAt())
+		switch e := event.(type) {
+		case orderevents.OrderSubmittedEvent:
+			return h.updateOrders(ctx, day, func(m *domain.DailyOrders) { m.Submitted++ })
+		case orderevents.OrderCancelledEvent:
+			return h.updateOrders(ctx, day, func(m *domain.DailyOrders) { m.Cancelled++ })
+		case paymentevents.PaymentCapturedEvent:
+			return h.updateRevenue(ctx, day, e.Currency, func(m *domain.DailyRevenue) { m.Captured += e.Amount })
+		case orderevents.RefundIssuedEvent:
+			return h.updateRevenue(ctx, day, e.Currency, func(m *domain.DailyRevenue) { m.Refunded += e.Amount })
+		case userevents.UserCreatedEvent:
+			return h.updateSignups(ctx, domain.WeekOf(event.OccurredAt()))
+		default:
+			return fmt.Errorf("unexpected event type: %T", event)
+		}
+	})
+}
+
+func (h *MetricsProjection) updateOrders(ctx context.Context, day string, apply func(*domain.DailyOrders)) error {
+	m, err := h.metrics.FindDailyOrders(ctx, day)
+	if err != nil {
+		return fmt.Errorf("finding daily orders: %w", err)
+	}
+	apply(&m)
+	return h.metrics.SaveDailyOrders(ctx, m)
+}
+
+func (h *MetricsProjection) updateRevenue(ctx context.Context, day, currency string, apply func(*domain.DailyRevenue)) error {
+	m, err := h.metrics.FindDailyRevenue(ctx, day, currency)
+	if err != nil {
+		return fmt.Errorf("finding daily revenue: %w", err)
+	}
+	apply(&m)
+	return h.metrics.SaveDailyRevenue(ctx, m)
+}
+
+func (h *MetricsProjection) updateSignups(ctx context.Context, week string) error {
+	m, err := h.metrics.FindWeeklySignups(ctx, week)
+	if err != nil {
+		return fmt.Errorf("finding weekly signups: %w", err)
+	}
+	m.Signups++
+	return h.metrics.SaveWeeklySignups(ctx, m)
+}
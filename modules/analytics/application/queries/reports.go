@@ -0,0 +1,173 @@
+// Package queries contains the read-only reports of the analytics module.
+package queries
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/analytics/domain"
+)
+
+// ReportQuery selects the date range of a report. Empty bounds default to
+// the last 30 days.
+type ReportQuery struct {
+	From string
+	To   string
+}
+
+// DailyOrdersDTO is one row of the orders-per-day report.
+type DailyOrdersDTO struct {
+	Day       string `json:"day"`
+	Submitted int    `json:"submitted"`
+	Cancelled int    `json:"cancelled"`
+}
+
+// OrdersPerDayDTO is the orders-per-day report. Days without orders are included with zero counts.
+type OrdersPerDayDTO struct {
+	From string           `json:"from"`
+	To   string           `json:"to"`
+	Days []DailyOrdersDTO `json:"days"`
+}
+
+type OrdersPerDayHandler struct {
+	repo domain.MetricsRepository
+	now  func() time.Time
+}
+
+func NewOrdersPerDayHandler(repo domain.MetricsRepository) *OrdersPerDayHandler {
+	return &OrdersPerDayHandler{repo: repo, now: time.Now}
+}
+
+func (h *OrdersPerDayHandler) Handle(ctx context.Context, query ReportQuery) (*OrdersPerDayDTO, error) {
+	r, err := domain.NewDateRange(query.From, query.To, h.now())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := h.repo.ListDailyOrders(ctx, r.FromDay(), r.ToDay())
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OrdersPerDayDTO{From: r.FromDay(), To: r.ToDay()}
+	for d := r.From; !d.After(r.To); d = d.AddDate(0, 0, 1) {
+		day := domain.DayOf(d)
+		row := DailyOrdersDTO{Day: day}
+		if i := slices.IndexFunc(rows, func(m domain.DailyOrders) bool { return m.Day == day }); i >= 0 {
+			row.Submitted, row.Cancelled = rows[i].Submitted, rows[i].Cancelled
+		}
+		report.Days = append(report.Days, row)
+	}
+	return report, nil
+}
+
+// DailyRevenueDTO is one row of the revenue report.
+type DailyRevenueDTO struct {
+	Day      string `json:"day"`
+	Currency string `json:"currency"`
+	Captured int64  `json:"captured"`
+	Refunded int64  `json:"refunded"`
+	Net      int64  `json:"net"`
+}
+
+// CurrencyTotalDTO sums the revenue report for one currency.
+type CurrencyTotalDTO struct {
+	Currency string `json:"currency"`
+	Captured int64  `json:"captured"`
+	Refunded int64  `json:"refunded"`
+	Net      int64  `json:"net"`
+}
+
+// RevenueDTO is the revenue-by-currency report. Only days with money movements are listed.
+type RevenueDTO struct {
+	From   string             `json:"from"`
+	To     string             `json:"to"`
+	Days   []DailyRevenueDTO  `json:"days"`
+	Totals []CurrencyTotalDTO `json:"totals"`
+}
+
+type RevenueHandler struct {
+	repo domain.MetricsRepository
+	now  func() time.Time
+}
+
+func NewRevenueHandler(repo domain.MetricsRepository) *RevenueHandler {
+	return &RevenueHandler{repo: repo, now: time.Now}
+}
+
+func (h *RevenueHandler) Handle(ctx context.Context, query ReportQuery) (*RevenueDTO, error) {
+	r, err := domain.NewDateRange(query.From, query.To, h.now())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := h.repo.ListDailyRevenue(ctx, r.FromDay(), r.ToDay())
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RevenueDTO{From: r.FromDay(), To: r.ToDay(), Days: []DailyRevenueDTO{}, Totals: []CurrencyTotalDTO{}}
+	for _, m := range rows {
+		report.Days = append(report.Days, DailyRevenueDTO{
+			Day:      m.Day,
+			Currency: m.Currency,
+			Captured: m.Captured,
+			Refunded: m.Refunded,
+			Net:      m.Net(),
+		})
+
+		i := slices.IndexFunc(report.Totals, func(t CurrencyTotalDTO) bool { return t.Currency == m.Currency })
+		if i < 0 {
+			report.Totals = append(report.Totals, CurrencyTotalDTO{Currency: m.Currency})
+			i = len(report.Totals) - 1
+		}
+		report.Totals[i].Captured += m.Captured
+		report.Totals[i].Refunded += m.Refunded
+		report.Totals[i].Net += m.Net()
+	}
+	slices.SortFunc(report.Totals, func(a, b CurrencyTotalDTO) int { return strings.Compare(a.Currency, b.Currency) })
+	return report, nil
+}
+
+// WeeklySignupsDTO is one row of the signups-per-week report.
+type WeeklySignupsDTO struct {
+	Week    string `json:"week"`
+	Signups int    `json:"signups"`
+}
+
+// SignupsPerWeekDTO is the signups-per-week report, covering every ISO week
+// that overlaps the date range. Only weeks with signups are listed.
+type SignupsPerWeekDTO struct {
+	From  string             `json:"from"`
+	To    string             `json:"to"`
+	Weeks []WeeklySignupsDTO `json:"weeks"`
+}
+
+type SignupsPerWeekHandler struct {
+	repo domain.MetricsRepository
+	now  func() time.Time
+}
+
+func NewSignupsPerWeekHandler(repo domain.MetricsRepository) *SignupsPerWeekHandler {
+	return &SignupsPerWeekHandler{repo: repo, now: time.Now}
+}
+
+func (h *SignupsPerWeekHandler) Handle(ctx context.Context, query ReportQuery) (*SignupsPerWeekDTO, error) {
+	r, err := domain.NewDateRange(query.From, query.To, h.now())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := h.repo.ListWeeklySignups(ctx, r.FromWeek(), r.ToWeek())
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SignupsPerWeekDTO{From: r.FromWeek(), To: r.ToWeek(), Weeks: []WeeklySignupsDTO{}}
+	for _, m := range rows {
+		report.Weeks = append(report.Weeks, WeeklySignupsDTO{Week: m.Week, Signups: m.Signups})
+	}
+	return report, nil
+}
@@ -0,0 +1,10 @@
+// Package domain contains the analytics read models.
+package domain
+
+import "errors"
+
+// Domain errors - business rule violations.
+var (
+	ErrInvalidDate      = errors.New("dates must be formatted as YYYY-MM-DD")
+	ErrInvalidDateRange = errors.New("date range must start before it ends and span at most 366 days")
+)
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// DailyOrders counts order lifecycle events per UTC day.
+type DailyOrders struct {
+	Day       string
+	Submitted int
+	Cancelled int
+}
+
+// DailyRevenue sums money movements per UTC day and currency, in the
+// smallest currency unit. Amounts in different currencies are never added.
+type DailyRevenue struct {
+	Day      string
+	Currency string
+	Captured int64
+	Refunded int64
+}
+
+// Net returns the captured amount less refunds.
+func (r DailyRevenue) Net() int64 { return r.Captured - r.Refunded }
+
+// WeeklySignups counts user registrations per ISO week.
+type WeeklySignups struct {
+	Week    string
+	Signups int
+}
+
+// MetricsRepository persists the analytics projections. Find methods return
+// a zero-valued bucket with its key set when nothing has been recorded yet.
+type MetricsRepository interface {
+	FindDailyOrders(ctx context.Context, day string) (DailyOrders, error)
+	SaveDailyOrders(ctx context.Context, m DailyOrders) error
+	// ListDailyOrders returns the recorded days in [fromDay, toDay], oldest first.
+	ListDailyOrders(ctx context.Context, fromDay, toDay string) ([]DailyOrders, error)
+
+	FindDailyRevenue(ctx context.Context, day, currency string) (DailyRevenue, error)
+	SaveDailyRevenue(ctx context.Context, m DailyRevenue) error
+	// ListDailyRevenue returns the recorded days in [fromDay, toDay], oldest first.
+	ListDailyRevenue(ctx context.Context, fromDay, toDay string) ([]DailyRevenue, error)
+
+	FindWeeklySignups(ctx context.Context, week string) (WeeklySignups, error)
+	SaveWeeklySignups(ctx context.Context, m WeeklySignups) error
+	// ListWeeklySignups returns the recorded weeks in [fromWeek, toWeek], oldest first.
+	ListWeeklySignups(ctx context.Context, fromWeek, toWeek string) ([]WeeklySignups, error)
+}
+
+// InboxRepository remembers which events have been projected, so that
+// redelivered events are not counted twice.
+type InboxRepository interface {
+	// MarkProcessed records the event and reports whether it was new.
+	// It must run in the same transaction as the projection update.
+	MarkProcessed(ctx context.Context, eventID string, at time.Time) (bool, error)
+}
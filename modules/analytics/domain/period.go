@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	dayLayout = "2006-01-02"
+
+	defaultRangeDays = 30
+	maxRangeDays     = 366
+)
+
+// DayOf returns the UTC calendar day of t as YYYY-MM-DD, the bucket key of daily metrics.
+func DayOf(t time.Time) string {
+	return t.UTC().Format(dayLayout)
+}
+
+// WeekOf returns the ISO 8601 week of t as YYYY-Www, the bucket key of weekly metrics.
+// Keys sort chronologically as strings.
+func WeekOf(t time.Time) string {
+	year, week := t.UTC().ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// DateRange is an inclusive range of UTC calendar days.
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// NewDateRange parses a YYYY-MM-DD range. Empty bounds default to the
+// 30 days ending today.
+func NewDateRange(from, to string, now time.Time) (DateRange, error) {
+	today := now.UTC().Truncate(24 * time.Hour)
+
+	end := today
+	if to != "" {
+		t, err := time.Parse(dayLayout, to)
+		if err != nil {
+			return DateRange{}, ErrInvalidDate
+		}
+		end = t
+	}
+
+	start := end.AddDate(0, 0, -(defaultRangeDays - 1))
+	if from != "" {
+		t, err := time.Parse(dayLayout, from)
+		if err != nil {
+			return DateRange{}, ErrInvalidDate
+		}
+		start = t
+	}
+
+	if end.Before(start) || end.Sub(start) >= maxRangeDays*24*time.Hour {
+		return DateRange{}, ErrInvalidDateRange
+	}
+	return DateRange{From: start, To: end}, nil
+}
+
+// FromDay and ToDay return the range bounds as daily bucket keys.
+func (r DateRange) FromDay() string { return DayOf(r.From) }
+func (r DateRange) ToDay() string   { return DayOf(r.To) }
+
+// FromWeek and ToWeek return the weeks containing the range bounds as weekly bucket keys.
+func (r DateRange) FromWeek() string { return WeekOf(r.From) }
+func (r DateRange) ToWeek() string   { return WeekOf(r.To) }
@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/analytics/domain"
+)
+
+func TestWeekOf_UsesISOWeeks(t *testing.T) {
+	tests := []struct {
+		date string
+		want string
+	}{
+		{"2026-10-15", "2026-W42"},
+		{"2021-01-03", "2020-W53"}, // Sunday belongs to the last week of the previous ISO year
+		{"2024-12-30", "2025-W01"}, // Monday belongs to the first week of the next ISO year
+	}
+	for _, tt := range tests {
+		d, _ := time.Parse("2006-01-02", tt.date)
+		if got := domain.WeekOf(d); got != tt.want {
+			t.Errorf("WeekOf(%s) = %s, want %s", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestNewDateRange(t *testing.T) {
+	now := time.Date(2026, 10, 15, 13, 30, 0, 0, time.UTC)
+
+	r, err := domain.NewDateRange("", "", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.FromDay() != "2026-09-16" || r.ToDay() != "2026-10-15" {
+		t.Errorf("expected default range 2026-09-16..2026-10-15, got %s..%s", r.FromDay(), r.ToDay())
+	}
+
+	r, err = domain.NewDateRange("2026-01-01", "2026-01-01", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.FromWeek() != "2026-W01" || r.ToWeek() != "2026-W01" {
+		t.Errorf("expected week 2026-W01, got %s..%s", r.FromWeek(), r.ToWeek())
+	}
+
+	if _, err := domain.NewDateRange("01/02/2026", "", now); !errors.Is(err, domain.ErrInvalidDate) {
+		t.Errorf("expected ErrInvalidDate, got %v", err)
+	}
+	if _, err := domain.NewDateRange("2026-02-01", "2026-01-01", now); !errors.Is(err, domain.ErrInvalidDateRange) {
+		t.Errorf("expected ErrInvalidDateRange for reversed range, got %v", err)
+	}
+	if _, err := domain.NewDateRange("2025-01-01", "2026-01-02", now); !errors.Is(err, domain.ErrInvalidDateRange) {
+		t.Errorf("expected ErrInvalidDateRange for range over 366 days, got %v", err)
+	}
+}
@@ -0,0 +1,140 @@
+// Package http provides the read-only reporting endpoints of the analytics module.
+package http
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rai/clean-modularmonolith-go/modules/analytics/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/analytics/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+type Handler struct {
+	ordersPerDay   *queries.OrdersPerDayHandler
+	revenue        *queries.RevenueHandler
+	signupsPerWeek *queries.SignupsPerWeekHandler
+	admin          security.AdminGuard
+}
+
+// RegisterRoutes registers the analytics module routes to the given mux.
+// Every report accepts from and to (YYYY-MM-DD) and format=csv for a CSV export.
+func RegisterRoutes(mux *http.ServeMux, ordersPerDay *queries.OrdersPerDayHandler, revenue *queries.RevenueHandler, signupsPerWeek *queries.SignupsPerWeekHandler, admin security.AdminGuard) {
+	h := &Handler{
+		ordersPerDay:   ordersPerDay,
+		revenue:        revenue,
+		signupsPerWeek: signupsPerWeek,
+		admin:          admin,
+	}
+
+	mux.HandleFunc("GET /analytics/orders-per-day", h.admin.HandlerFunc(h.handleOrdersPerDay))
+	mux.HandleFunc("GET /analytics/revenue", h.admin.HandlerFunc(h.handleRevenue))
+	mux.HandleFunc("GET /analytics/signups-per-week", h.admin.HandlerFunc(h.handleSignupsPerWeek))
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func reportQuery(r *http.Request) queries.ReportQuery {
+	return queries.ReportQuery{
+		From: r.URL.Query().Get("from"),
+		To:   r.URL.Query().Get("to"),
+	}
+}
+
+func wantsCSV(r *http.Request) bool {
+	return r.URL.Query().Get("format") == "csv"
+}
+
+func (h *Handler) handleOrdersPerDay(w http.ResponseWriter, r *http.Request) {
+	report, err := h.ordersPerDay.Handle(r.Context(), reportQuery(r))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	if wantsCSV(r) {
+		rows := [][]string{{"day", "submitted", "cancelled"}}
+		for _, d := range report.Days {
+			rows = append(rows, []string{d.Day, strconv.Itoa(d.Submitted), strconv.Itoa(d.Cancelled)})
+		}
+		writeCSV(w, fmt.Sprintf("orders-per-day_%s_%s.csv", report.From, report.To), rows)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+func (h *Handler) handleRevenue(w http.ResponseWriter, r *http.Request) {
+	report, err := h.revenue.Handle(r.Context(), reportQuery(r))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	if wantsCSV(r) {
+		// Totals are omitted: they are a simple sum per currency over the rows.
+		rows := [][]string{{"day", "currency", "captured", "refunded", "net"}}
+		for _, d := range report.Days {
+			rows = append(rows, []string{
+				d.Day,
+				d.Currency,
+				strconv.FormatInt(d.Captured, 10),
+				strconv.FormatInt(d.Refunded, 10),
+				strconv.FormatInt(d.Net, 10),
+			})
+		}
+		writeCSV(w, fmt.Sprintf("revenue_%s_%s.csv", report.From, report.To), rows)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+func (h *Handler) handleSignupsPerWeek(w http.ResponseWriter, r *http.Request) {
+	report, err := h.signupsPerWeek.Handle(r.Context(), reportQuery(r))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	if wantsCSV(r) {
+		rows := [][]string{{"week", "signups"}}
+		for _, wk := range report.Weeks {
+			rows = append(rows, []string{wk.Week, strconv.Itoa(wk.Signups)})
+		}
+		writeCSV(w, fmt.Sprintf("signups-per-week_%s_%s.csv", report.From, report.To), rows)
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidDate),
+		errors.Is(err, domain.ErrInvalidDateRange):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func writeCSV(w http.ResponseWriter, filename string, rows [][]string) {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.WriteHeader(http.StatusOK)
+	csv.NewWriter(w).WriteAll(rows)
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
@@ -0,0 +1,23 @@
+package http
+
+import (
+	"github.com/rai/clean-modularmonolith-go/modules/analytics/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+)
+
+// reportParams select the date range and format of a report. The CSV format
+// is not described by the response schemas.
+var reportParams = []openapi.Param{
+	{Name: "from", Description: "First day (YYYY-MM-DD); defaults to 30 days ago"},
+	{Name: "to", Description: "Last day (YYYY-MM-DD); defaults to today"},
+	{Name: "format", Description: "csv for a CSV download instead of JSON"},
+}
+
+// Operations documents the routes registered by RegisterRoutes.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Pattern: "GET /analytics/orders-per-day", Summary: "Report submitted and cancelled orders per day", Admin: true, Query: reportParams, Response: queries.OrdersPerDayDTO{}},
+		{Pattern: "GET /analytics/revenue", Summary: "Report captured and refunded revenue per day", Admin: true, Query: reportParams, Response: queries.RevenueDTO{}},
+		{Pattern: "GET /analytics/signups-per-week", Summary: "Report user signups per week", Admin: true, Query: reportParams, Response: queries.SignupsPerWeekDTO{}},
+	}
+}
@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+)
+
+type SpannerInboxRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerInboxRepository(client *spanner.Client, logger *slog.Logger) *SpannerInboxRepository {
+	return &SpannerInboxRepository{client: client, logger: logger}
+}
+
+// MarkProcessed must be called inside a read-write transaction; the read
+// joins it so that concurrent deliveries of the same event conflict.
+func (r *SpannerInboxRepository) MarkProcessed(ctx context.Context, eventID string, at time.Time) (bool, error) {
+	seen, err := platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (bool, error) {
+		_, err := reader.ReadRow(ctx, "AnalyticsInbox", spanner.Key{eventID}, []string{"EventID"})
+		if spanner.ErrCode(err) == codes.NotFound {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to read analytics inbox: %w", err)
+		}
+		return true, nil
+	})
+	if err != nil || seen {
+		return false, err
+	}
+
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT INTO AnalyticsInbox (EventID, OccurredAt, ProcessedAt)
+		      VALUES (@eventID, @occurredAt, @processedAt)`,
+		Params: map[string]interface{}{
+			"eventID":     eventID,
+			"occurredAt":  at,
+			"processedAt": time.Now().UTC(),
+		},
+	}); err != nil {
+		return false, fmt.Errorf("failed to record analytics inbox entry: %w", err)
+	}
+	return true, nil
+}
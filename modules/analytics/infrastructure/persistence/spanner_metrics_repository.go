@@ -0,0 +1,200 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/analytics/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+// SpannerMetricsRepository implements MetricsRepository using Cloud Spanner.
+// Every bucket is keyed by tenant first: the projections count the events of
+// a tenant in its own buckets (the event bus runs them in the event's
+// tenant), and the reports read those of the tenant of the request.
+type SpannerMetricsRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerMetricsRepository(client *spanner.Client, logger *slog.Logger) *SpannerMetricsRepository {
+	return &SpannerMetricsRepository{client: client, logger: logger}
+}
+
+func (r *SpannerMetricsRepository) FindDailyOrders(ctx context.Context, day string) (domain.DailyOrders, error) {
+	m := domain.DailyOrders{Day: day}
+	var submitted, cancelled int64
+	found, err := r.readRow(ctx, "AnalyticsDailyOrders", spanner.Key{day}, []string{"Submitted", "Cancelled"}, &submitted, &cancelled)
+	if err != nil || !found {
+		return m, err
+	}
+	m.Submitted, m.Cancelled = int(submitted), int(cancelled)
+	return m, nil
+}
+
+func (r *SpannerMetricsRepository) SaveDailyOrders(ctx context.Context, m domain.DailyOrders) error {
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO AnalyticsDailyOrders (TenantID, Day, Submitted, Cancelled, UpdatedAt)
+		      VALUES (@tenantID, @day, @submitted, @cancelled, @updatedAt)`,
+		Params: map[string]interface{}{
+			"tenantID":  requestcontext.TenantID(ctx),
+			"day":       m.Day,
+			"submitted": int64(m.Submitted),
+			"cancelled": int64(m.Cancelled),
+			"updatedAt": time.Now().UTC(),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save daily orders: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerMetricsRepository) ListDailyOrders(ctx context.Context, fromDay, toDay string) ([]domain.DailyOrders, error) {
+	return listRows(ctx, r, spanner.Statement{
+		SQL: `SELECT Day, Submitted, Cancelled FROM AnalyticsDailyOrders
+		      WHERE TenantID = @tenantID AND Day BETWEEN @from AND @to
+		      ORDER BY Day`,
+		Params: map[string]interface{}{"tenantID": requestcontext.TenantID(ctx), "from": fromDay, "to": toDay},
+	}, func(row *spanner.Row) (domain.DailyOrders, error) {
+		var m domain.DailyOrders
+		var submitted, cancelled int64
+		if err := row.Columns(&m.Day, &submitted, &cancelled); err != nil {
+			return m, fmt.Errorf("failed to scan daily orders: %w", err)
+		}
+		m.Submitted, m.Cancelled = int(submitted), int(cancelled)
+		return m, nil
+	})
+}
+
+func (r *SpannerMetricsRepository) FindDailyRevenue(ctx context.Context, day, currency string) (domain.DailyRevenue, error) {
+	m := domain.DailyRevenue{Day: day, Currency: currency}
+	_, err := r.readRow(ctx, "AnalyticsDailyRevenue", spanner.Key{day, currency}, []string{"Captured", "Refunded"}, &m.Captured, &m.Refunded)
+	return m, err
+}
+
+func (r *SpannerMetricsRepository) SaveDailyRevenue(ctx context.Context, m domain.DailyRevenue) error {
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO AnalyticsDailyRevenue (TenantID, Day, Currency, Captured, Refunded, UpdatedAt)
+		      VALUES (@tenantID, @day, @currency, @captured, @refunded, @updatedAt)`,
+		Params: map[string]interface{}{
+			"tenantID":  requestcontext.TenantID(ctx),
+			"day":       m.Day,
+			"currency":  m.Currency,
+			"captured":  m.Captured,
+			"refunded":  m.Refunded,
+			"updatedAt": time.Now().UTC(),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save daily revenue: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerMetricsRepository) ListDailyRevenue(ctx context.Context, fromDay, toDay string) ([]domain.DailyRevenue, error) {
+	return listRows(ctx, r, spanner.Statement{
+		SQL: `SELECT Day, Currency, Captured, Refunded FROM AnalyticsDailyRevenue
+		      WHERE TenantID = @tenantID AND Day BETWEEN @from AND @to
+		      ORDER BY Day, Currency`,
+		Params: map[string]interface{}{"tenantID": requestcontext.TenantID(ctx), "from": fromDay, "to": toDay},
+	}, func(row *spanner.Row) (domain.DailyRevenue, error) {
+		var m domain.DailyRevenue
+		if err := row.Columns(&m.Day, &m.Currency, &m.Captured, &m.Refunded); err != nil {
+			return m, fmt.Errorf("failed to scan daily revenue: %w", err)
+		}
+		return m, nil
+	})
+}
+
+func (r *SpannerMetricsRepository) FindWeeklySignups(ctx context.Context, week string) (domain.WeeklySignups, error) {
+	m := domain.WeeklySignups{Week: week}
+	var signups int64
+	found, err := r.readRow(ctx, "AnalyticsWeeklySignups", spanner.Key{week}, []string{"Signups"}, &signups)
+	if err != nil || !found {
+		return m, err
+	}
+	m.Signups = int(signups)
+	return m, nil
+}
+
+func (r *SpannerMetricsRepository) SaveWeeklySignups(ctx context.Context, m domain.WeeklySignups) error {
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO AnalyticsWeeklySignups (TenantID, Week, Signups, UpdatedAt)
+		      VALUES (@tenantID, @week, @signups, @updatedAt)`,
+		Params: map[string]interface{}{
+			"tenantID":  requestcontext.TenantID(ctx),
+			"week":      m.Week,
+			"signups":   int64(m.Signups),
+			"updatedAt": time.Now().UTC(),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save weekly signups: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerMetricsRepository) ListWeeklySignups(ctx context.Context, fromWeek, toWeek string) ([]domain.WeeklySignups, error) {
+	return listRows(ctx, r, spanner.Statement{
+		SQL: `SELECT Week, Signups FROM AnalyticsWeeklySignups
+		      WHERE TenantID = @tenantID AND Week BETWEEN @from AND @to
+		      ORDER BY Week`,
+		Params: map[string]interface{}{"tenantID": requestcontext.TenantID(ctx), "from": fromWeek, "to": toWeek},
+	}, func(row *spanner.Row) (domain.WeeklySignups, error) {
+		var m domain.WeeklySignups
+		var signups int64
+		if err := row.Columns(&m.Week, &signups); err != nil {
+			return m, fmt.Errorf("failed to scan weekly signups: %w", err)
+		}
+		m.Signups = int(signups)
+		return m, nil
+	})
+}
+
+// readRow reads the bucket row of the tenant of ctx with key into dest and
+// reports whether it exists.
+func (r *SpannerMetricsRepository) readRow(ctx context.Context, table string, key spanner.Key, columns []string, dest ...interface{}) (bool, error) {
+	key = append(spanner.Key{requestcontext.TenantID(ctx)}, key...)
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (bool, error) {
+		row, err := reader.ReadRow(ctx, table, key, columns)
+		if spanner.ErrCode(err) == codes.NotFound {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to read %s: %w", table, err)
+		}
+		if err := row.Columns(dest...); err != nil {
+			return false, fmt.Errorf("failed to scan %s: %w", table, err)
+		}
+		return true, nil
+	})
+}
+
+func listRows[T any](ctx context.Context, r *SpannerMetricsRepository, stmt spanner.Statement, scan func(*spanner.Row) (T, error)) ([]T, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]T, error) {
+		iter := reader.Query(ctx, stmt)
+		defer iter.Stop()
+
+		var rows []T
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query metrics: %w", err)
+			}
+			m, err := scan(row)
+			if err != nil {
+				return nil, err
+			}
+			rows = append(rows, m)
+		}
+		return rows, nil
+	})
+}
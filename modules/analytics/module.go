@@ -0,0 +1,78 @@
+// Package analytics builds reporting projections from the domain events of
+// the other modules and serves them read-only. It owns no business rules
+// and never writes to another module.
+package analytics
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/analytics/application/eventhandlers"
+	"github.com/rai/clean-modularmonolith-go/modules/analytics/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/analytics/domain"
+	httphandler "github.com/rai/clean-modularmonolith-go/modules/analytics/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/projection"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// Module is the public API for the analytics bounded context.
+// External communication: HTTP API (RegisterRoutes), read-only
+// Cross-module communication: Domain Events (subscribed internally)
+type Module interface {
+	// RegisterRoutes registers the module's HTTP routes to the given mux.
+	RegisterRoutes(mux *http.ServeMux)
+	// Operations documents the routes for the OpenAPI specification.
+	Operations() []openapi.Operation
+}
+
+type Config struct {
+	MetricsRepository    domain.MetricsRepository
+	InboxRepository      domain.InboxRepository
+	TransactionScope     transaction.Scope
+	PostCommitSubscriber events.PostCommitSubscriber
+	Logger               *slog.Logger
+
+	// AdminToken guards the reports via the X-Admin-Token header.
+	// The reports are disabled when empty.
+	AdminToken string
+
+	// SecuritySink receives the admin token and authorization decisions
+	// made by the HTTP handlers. Optional: nil records nothing.
+	SecuritySink security.Sink
+}
+
+type module struct {
+	ordersPerDay   *queries.OrdersPerDayHandler
+	revenue        *queries.RevenueHandler
+	signupsPerWeek *queries.SignupsPerWeekHandler
+	admin          security.AdminGuard
+}
+
+// New initializes the analytics module and subscribes to events.
+func New(cfg Config) Module {
+	logger := cfg.Logger.With("module", "analytics")
+
+	// Subscribe to events (post-commit: reporting must not affect business transactions)
+	metrics := eventhandlers.NewMetricsProjection(cfg.MetricsRepository, cfg.InboxRepository, cfg.TransactionScope)
+	if err := projection.SubscribePostCommit(cfg.PostCommitSubscriber, metrics); err != nil {
+		logger.Error("failed to subscribe metrics projection", slog.Any("error", err))
+	}
+
+	return &module{
+		ordersPerDay:   queries.NewOrdersPerDayHandler(cfg.MetricsRepository),
+		revenue:        queries.NewRevenueHandler(cfg.MetricsRepository),
+		signupsPerWeek: queries.NewSignupsPerWeekHandler(cfg.MetricsRepository),
+		admin:          security.AdminGuard{Module: "analytics", Token: cfg.AdminToken, Sink: cfg.SecuritySink},
+	}
+}
+
+func (m *module) RegisterRoutes(mux *http.ServeMux) {
+	httphandler.RegisterRoutes(mux, m.ordersPerDay, m.revenue, m.signupsPerWeek, m.admin)
+}
+
+func (m *module) Operations() []openapi.Operation {
+	return httphandler.Operations()
+}
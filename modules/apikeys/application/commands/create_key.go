@@ -0,0 +1,116 @@
+// Package commands contains write use cases for the apikeys module.
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// CreateKeyCommand represents the intent to issue an API key to a machine
+// client, for the tenant of the request.
+type CreateKeyCommand struct {
+	Name   string
+	Scopes []string
+	// ExpiresAt is zero for a key that never expires. It is capped at the
+	// expiry of the key of a caller authenticated by API key.
+	ExpiresAt time.Time
+}
+
+// KeyResult is returned once on creation and rotation; the key cannot be
+// retrieved afterwards.
+type KeyResult struct {
+	ID        string
+	Key       string
+	ExpiresAt time.Time // zero: never expires
+}
+
+type CreateKeyHandler struct {
+	repo    domain.KeyRepository
+	txScope transaction.Scope
+}
+
+func NewCreateKeyHandler(repo domain.KeyRepository, txScope transaction.Scope) *CreateKeyHandler {
+	return &CreateKeyHandler{
+		repo:    repo,
+		txScope: txScope,
+	}
+}
+
+// Handle executes the create key use case. A caller authenticated by API key
+// may only grant the scopes it holds, and not for longer than it holds them:
+// the new key expires at the latest with the caller's.
+func (h *CreateKeyHandler) Handle(ctx context.Context, cmd CreateKeyCommand) (KeyResult, error) {
+	if err := checkGrantable(ctx, cmd.Scopes); err != nil {
+		return KeyResult{}, err
+	}
+
+	var k *domain.APIKey
+	var key string
+	err := h.txScope.Execute(ctx, func(ctx context.Context) error {
+		limit, err := callerExpiry(ctx, h.repo)
+		if err != nil {
+			return err
+		}
+		expiresAt := cmd.ExpiresAt
+		if !limit.IsZero() && (expiresAt.IsZero() || expiresAt.After(limit)) {
+			expiresAt = limit
+		}
+
+		if k, key, err = domain.NewAPIKey(requestcontext.TenantID(ctx), cmd.Name, cmd.Scopes, expiresAt, clock.Now(ctx)); err != nil {
+			return err
+		}
+		if err := h.repo.Save(ctx, k); err != nil {
+			return fmt.Errorf("saving API key: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return KeyResult{}, err
+	}
+
+	return KeyResult{ID: k.ID().String(), Key: key, ExpiresAt: k.ExpiresAt()}, nil
+}
+
+// checkGrantable returns ErrScopeNotHeld if the caller was authenticated by
+// an API key that does not hold every one of scopes. Callers with the admin
+// token may grant any scope.
+func checkGrantable(ctx context.Context, scopes []string) error {
+	if !security.Scoped(ctx) {
+		return nil
+	}
+	for _, s := range scopes {
+		if !security.HasScope(ctx, s) {
+			return fmt.Errorf("%w: %s", domain.ErrScopeNotHeld, s)
+		}
+	}
+	return nil
+}
+
+// callerExpiry returns the expiry of the API key the caller was authenticated
+// by, which is zero for a key that never expires and for the admin token.
+func callerExpiry(ctx context.Context, repo domain.KeyRepository) (time.Time, error) {
+	if !security.Scoped(ctx) {
+		return time.Time{}, nil
+	}
+	actor := requestcontext.Actor(ctx)
+	if !strings.HasPrefix(actor, domain.ActorPrefix) {
+		return time.Time{}, fmt.Errorf("caller %q is not an API key", actor)
+	}
+	id, err := domain.ParseKeyID(strings.TrimPrefix(actor, domain.ActorPrefix))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("caller %q: %w", actor, err)
+	}
+	k, err := findInTenant(ctx, repo, id)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("caller's API key: %w", err)
+	}
+	return k.ExpiresAt(), nil
+}
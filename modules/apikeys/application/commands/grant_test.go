@@ -0,0 +1,166 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+// keyStore is an in-memory domain.KeyRepository.
+type keyStore map[domain.KeyID]*domain.APIKey
+
+func (s keyStore) Save(ctx context.Context, k *domain.APIKey) error {
+	s[k.ID()] = k
+	return nil
+}
+
+func (s keyStore) FindByID(ctx context.Context, id domain.KeyID) (*domain.APIKey, error) {
+	k, ok := s[id]
+	if !ok {
+		return nil, domain.ErrKeyNotFound
+	}
+	return k, nil
+}
+
+func (s keyStore) FindByTenant(ctx context.Context, tenantID string, offset, limit int) ([]*domain.APIKey, int, error) {
+	return nil, 0, errors.New("not implemented")
+}
+
+// direct runs fn without a transaction.
+type direct struct{}
+
+func (direct) Execute(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }
+
+// keyCaller adds a key of tenant "acme" holding scopes and expiring at
+// expiresAt to store, and returns a context authenticated by it.
+func keyCaller(t *testing.T, store keyStore, expiresAt time.Time, scopes ...string) context.Context {
+	t.Helper()
+	k, _, err := domain.NewAPIKey("acme", "caller", scopes, expiresAt, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	store[k.ID()] = k
+	ctx := requestcontext.With(t.Context(), requestcontext.Values{Actor: domain.ActorPrefix + k.ID().String(), TenantID: "acme"})
+	return security.WithScopes(ctx, scopes)
+}
+
+func TestCreateKey_GrantsOnlyHeldScopes(t *testing.T) {
+	store := keyStore{}
+	handler := commands.NewCreateKeyHandler(store, direct{})
+	ctx := keyCaller(t, store, time.Time{}, "apikeys:admin", "orders:read")
+
+	if _, err := handler.Handle(ctx, commands.CreateKeyCommand{Name: "reader", Scopes: []string{"orders:read"}}); err != nil {
+		t.Fatalf("granting a held scope: %v", err)
+	}
+
+	_, err := handler.Handle(ctx, commands.CreateKeyCommand{Name: "escalated", Scopes: []string{"orders:read", "payments:admin"}})
+	if !errors.Is(err, domain.ErrScopeNotHeld) {
+		t.Errorf("granting a scope the key does not hold: err = %v, want ErrScopeNotHeld", err)
+	}
+}
+
+func TestCreateKey_AdminTokenGrantsAnyScope(t *testing.T) {
+	handler := commands.NewCreateKeyHandler(keyStore{}, direct{})
+	ctx := requestcontext.With(t.Context(), requestcontext.Values{Actor: security.AdminActor, TenantID: "acme"})
+
+	if _, err := handler.Handle(ctx, commands.CreateKeyCommand{Name: "ops", Scopes: []string{"payments:admin"}}); err != nil {
+		t.Errorf("admin granting any scope: %v", err)
+	}
+}
+
+func TestRotateKey_RequiresHeldScopes(t *testing.T) {
+	k, _, err := domain.NewAPIKey("acme", "payments", []string{"payments:admin"}, time.Time{}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := keyStore{k.ID(): k}
+	handler := commands.NewRotateKeyHandler(store, direct{})
+
+	_, err = handler.Handle(keyCaller(t, store, time.Time{}, "apikeys:admin"), commands.RotateKeyCommand{KeyID: k.ID().String()})
+	if !errors.Is(err, domain.ErrScopeNotHeld) {
+		t.Errorf("rotating a key with scopes the caller does not hold: err = %v, want ErrScopeNotHeld", err)
+	}
+	if !k.RotatedAt().IsZero() {
+		t.Error("the key was rotated")
+	}
+}
+
+func TestRevokeKey_RequiresHeldScopes(t *testing.T) {
+	k, _, err := domain.NewAPIKey("acme", "payments", []string{"payments:admin"}, time.Time{}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := keyStore{k.ID(): k}
+	handler := commands.NewRevokeKeyHandler(store, direct{})
+
+	err = handler.Handle(keyCaller(t, store, time.Time{}, "apikeys:admin"), commands.RevokeKeyCommand{KeyID: k.ID().String()})
+	if !errors.Is(err, domain.ErrScopeNotHeld) {
+		t.Errorf("revoking a key with scopes the caller does not hold: err = %v, want ErrScopeNotHeld", err)
+	}
+	if k.IsRevoked() {
+		t.Error("the key was revoked")
+	}
+
+	err = handler.Handle(keyCaller(t, store, time.Time{}, "apikeys:admin", "payments:admin"), commands.RevokeKeyCommand{KeyID: k.ID().String()})
+	if err != nil || !k.IsRevoked() {
+		t.Errorf("revoking a key whose scopes the caller holds: err = %v, revoked = %t", err, k.IsRevoked())
+	}
+}
+
+func TestCreateKey_ExpiryCappedAtCaller(t *testing.T) {
+	now := time.Now()
+	callerExpiry := now.Add(24 * time.Hour).Truncate(time.Second)
+	tests := []struct {
+		name      string
+		caller    time.Time
+		expiresAt time.Time
+		want      time.Time
+	}{
+		{"never expiring, from an expiring key", callerExpiry, time.Time{}, callerExpiry},
+		{"after the caller's", callerExpiry, now.Add(48 * time.Hour), callerExpiry},
+		{"before the caller's", callerExpiry, now.Add(time.Hour).Truncate(time.Second), now.Add(time.Hour).Truncate(time.Second)},
+		{"never expiring, from a never expiring key", time.Time{}, time.Time{}, time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := keyStore{}
+			handler := commands.NewCreateKeyHandler(store, direct{})
+
+			result, err := handler.Handle(keyCaller(t, store, tt.caller, "apikeys:admin", "orders:read"), commands.CreateKeyCommand{
+				Name: "reader", Scopes: []string{"orders:read"}, ExpiresAt: tt.expiresAt,
+			})
+			if err != nil {
+				t.Fatalf("Handle: %v", err)
+			}
+			if !result.ExpiresAt.Equal(tt.want) {
+				t.Errorf("result expires at %v, want %v", result.ExpiresAt, tt.want)
+			}
+			id, err := domain.ParseKeyID(result.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := store[id].ExpiresAt(); !got.Equal(tt.want) {
+				t.Errorf("stored key expires at %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateKey_AdminTokenExpiryNotCapped(t *testing.T) {
+	handler := commands.NewCreateKeyHandler(keyStore{}, direct{})
+	ctx := requestcontext.With(t.Context(), requestcontext.Values{Actor: security.AdminActor, TenantID: "acme"})
+
+	result, err := handler.Handle(ctx, commands.CreateKeyCommand{Name: "ops", Scopes: []string{"orders:read"}})
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if !result.ExpiresAt.IsZero() {
+		t.Errorf("expires at %v, want never", result.ExpiresAt)
+	}
+}
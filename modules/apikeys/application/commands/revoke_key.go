@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// RevokeKeyCommand represents the intent to stop a key from authenticating.
+type RevokeKeyCommand struct {
+	KeyID string
+}
+
+type RevokeKeyHandler struct {
+	repo    domain.KeyRepository
+	txScope transaction.Scope
+}
+
+func NewRevokeKeyHandler(repo domain.KeyRepository, txScope transaction.Scope) *RevokeKeyHandler {
+	return &RevokeKeyHandler{
+		repo:    repo,
+		txScope: txScope,
+	}
+}
+
+// Handle executes the revoke key use case. The key is kept, revoked, so
+// that its audit trail still names it. A caller authenticated by API key may
+// only revoke keys whose scopes it holds, as it could otherwise cut off a
+// more privileged client.
+func (h *RevokeKeyHandler) Handle(ctx context.Context, cmd RevokeKeyCommand) error {
+	id, err := domain.ParseKeyID(cmd.KeyID)
+	if err != nil {
+		return err
+	}
+
+	return h.txScope.Execute(ctx, func(ctx context.Context) error {
+		k, err := findInTenant(ctx, h.repo, id)
+		if err != nil {
+			return err
+		}
+		if err := checkGrantable(ctx, k.Scopes()); err != nil {
+			return err
+		}
+		if err := k.Revoke(clock.Now(ctx)); err != nil {
+			return err
+		}
+		if err := h.repo.Save(ctx, k); err != nil {
+			return fmt.Errorf("saving API key: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// RotateKeyCommand represents the intent to replace the secret of a key,
+// e.g. when it may have leaked.
+type RotateKeyCommand struct {
+	KeyID string
+}
+
+type RotateKeyHandler struct {
+	repo    domain.KeyRepository
+	txScope transaction.Scope
+}
+
+func NewRotateKeyHandler(repo domain.KeyRepository, txScope transaction.Scope) *RotateKeyHandler {
+	return &RotateKeyHandler{
+		repo:    repo,
+		txScope: txScope,
+	}
+}
+
+// Handle executes the rotate key use case. The previous key stops
+// authenticating as soon as the new one is returned. A caller authenticated
+// by API key may only rotate keys whose scopes it holds, as it learns the
+// new secret.
+func (h *RotateKeyHandler) Handle(ctx context.Context, cmd RotateKeyCommand) (KeyResult, error) {
+	id, err := domain.ParseKeyID(cmd.KeyID)
+	if err != nil {
+		return KeyResult{}, err
+	}
+
+	var key string
+	var expiresAt time.Time
+	err = h.txScope.Execute(ctx, func(ctx context.Context) error {
+		k, err := findInTenant(ctx, h.repo, id)
+		if err != nil {
+			return err
+		}
+		if err := checkGrantable(ctx, k.Scopes()); err != nil {
+			return err
+		}
+		if key, err = k.Rotate(clock.Now(ctx)); err != nil {
+			return err
+		}
+		expiresAt = k.ExpiresAt()
+		if err := h.repo.Save(ctx, k); err != nil {
+			return fmt.Errorf("saving API key: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return KeyResult{}, err
+	}
+
+	return KeyResult{ID: id.String(), Key: key, ExpiresAt: expiresAt}, nil
+}
+
+// findInTenant loads a key of the tenant of the request: the keys of other
+// tenants are not found.
+func findInTenant(ctx context.Context, repo domain.KeyRepository, id domain.KeyID) (*domain.APIKey, error) {
+	k, err := repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("finding API key: %w", err)
+	}
+	if k.TenantID() != requestcontext.TenantID(ctx) {
+		return nil, domain.ErrKeyNotFound
+	}
+	return k, nil
+}
@@ -0,0 +1,58 @@
+package queries
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+)
+
+// Principal is the machine client an API key authenticates.
+type Principal struct {
+	KeyID    string
+	Name     string
+	TenantID string
+	Scopes   []string
+}
+
+// Actor names the client in audit entries and logs, e.g. "apikey:<id>".
+func (p Principal) Actor() string {
+	return domain.ActorPrefix + p.KeyID
+}
+
+type AuthenticateHandler struct {
+	repo domain.KeyRepository
+}
+
+func NewAuthenticateHandler(repo domain.KeyRepository) *AuthenticateHandler {
+	return &AuthenticateHandler{repo: repo}
+}
+
+// Handle authenticates a plaintext key. It returns domain.ErrKeyInvalid for
+// a key that is malformed, unknown or wrong, and domain.ErrKeyRevoked or
+// domain.ErrKeyExpired for one that can no longer be used.
+func (h *AuthenticateHandler) Handle(ctx context.Context, key string) (Principal, error) {
+	id, secret, err := domain.ParseKey(key)
+	if err != nil {
+		return Principal{}, err
+	}
+
+	k, err := h.repo.FindByID(ctx, id)
+	if errors.Is(err, domain.ErrKeyNotFound) {
+		return Principal{}, domain.ErrKeyInvalid
+	}
+	if err != nil {
+		return Principal{}, err
+	}
+	if err := k.Authenticate(secret, clock.Now(ctx)); err != nil {
+		return Principal{}, err
+	}
+
+	return Principal{
+		KeyID:    k.ID().String(),
+		Name:     k.Name(),
+		TenantID: k.TenantID(),
+		Scopes:   k.Scopes(),
+	}, nil
+}
@@ -0,0 +1,71 @@
+// Package queries contains read use cases for the apikeys module.
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+// KeyDTO is a read model for an API key. Neither the key nor its hash is
+// included; the key is only returned on creation and rotation.
+type KeyDTO struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	RotatedAt *time.Time `json:"rotated_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// GetKeyQuery represents a request to get a key of the request's tenant by ID.
+type GetKeyQuery struct {
+	KeyID string
+}
+
+type GetKeyHandler struct {
+	repo domain.KeyRepository
+}
+
+func NewGetKeyHandler(repo domain.KeyRepository) *GetKeyHandler {
+	return &GetKeyHandler{repo: repo}
+}
+
+func (h *GetKeyHandler) Handle(ctx context.Context, query GetKeyQuery) (*KeyDTO, error) {
+	id, err := domain.ParseKeyID(query.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if k.TenantID() != requestcontext.TenantID(ctx) {
+		return nil, domain.ErrKeyNotFound
+	}
+	dto := toKeyDTO(k)
+	return &dto, nil
+}
+
+func toKeyDTO(k *domain.APIKey) KeyDTO {
+	return KeyDTO{
+		ID:        k.ID().String(),
+		Name:      k.Name(),
+		Scopes:    k.Scopes(),
+		ExpiresAt: optionalTime(k.ExpiresAt()),
+		RevokedAt: optionalTime(k.RevokedAt()),
+		RotatedAt: optionalTime(k.RotatedAt()),
+		CreatedAt: k.CreatedAt(),
+	}
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
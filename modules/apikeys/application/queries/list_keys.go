@@ -0,0 +1,53 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+// KeyListDTO contains a paginated list of API keys.
+type KeyListDTO struct {
+	Keys       []KeyDTO `json:"keys"`
+	TotalCount int      `json:"total_count"`
+	Offset     int      `json:"offset"`
+	Limit      int      `json:"limit"`
+}
+
+// ListKeysQuery retrieves the keys of the request's tenant, revoked ones
+// included.
+type ListKeysQuery struct {
+	Offset int
+	Limit  int
+}
+
+type ListKeysHandler struct {
+	repo domain.KeyRepository
+}
+
+func NewListKeysHandler(repo domain.KeyRepository) *ListKeysHandler {
+	return &ListKeysHandler{repo: repo}
+}
+
+func (h *ListKeysHandler) Handle(ctx context.Context, query ListKeysQuery) (*KeyListDTO, error) {
+	p := page.NewRequest(query.Offset, query.Limit)
+
+	keys, total, err := h.repo.FindByTenant(ctx, requestcontext.TenantID(ctx), p.Offset, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]KeyDTO, len(keys))
+	for i, k := range keys {
+		dtos[i] = toKeyDTO(k)
+	}
+
+	return &KeyListDTO{
+		Keys:       dtos,
+		TotalCount: total,
+		Offset:     p.Offset,
+		Limit:      p.Limit,
+	}, nil
+}
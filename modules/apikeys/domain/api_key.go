@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// KeyPrefix starts every API key, so that leaked keys are recognizable,
+// e.g. by secret scanners.
+const KeyPrefix = "ak_"
+
+// ActorPrefix starts the actor of a request authenticated by an API key,
+// which is followed by the key's ID: "apikey:<id>".
+const ActorPrefix = "apikey:"
+
+const maxNameLength = 100
+
+// scopePattern accepts "<module>:<permission>", e.g. "webhooks:admin".
+var scopePattern = regexp.MustCompile(`^[a-z][a-z0-9-]*:[a-z][a-z0-9-]*$`)
+
+// KeyID identifies an API key. It is the public part of the key.
+type KeyID struct {
+	value string
+}
+
+func NewKeyID() KeyID {
+	return KeyID{value: uuid.New().String()}
+}
+
+func ParseKeyID(s string) (KeyID, error) {
+	if _, err := uuid.Parse(s); err != nil {
+		return KeyID{}, ErrInvalidKeyID
+	}
+	return KeyID{value: s}, nil
+}
+
+func (id KeyID) String() string { return id.value }
+func (id KeyID) IsZero() bool   { return id.value == "" }
+
+// APIKey authenticates a machine client, such as a webhook registrant or
+// an ERP connector, for one tenant. Only a hash of its secret is kept: the
+// key itself is returned once, when it is created or rotated.
+type APIKey struct {
+	id         KeyID
+	tenantID   string
+	name       string
+	scopes     []string
+	secretHash string
+	expiresAt  time.Time // zero: never expires
+	revokedAt  time.Time // zero: not revoked
+	rotatedAt  time.Time // zero: never rotated
+	createdAt  time.Time
+}
+
+// NewAPIKey creates a key for tenantID and returns it with its plaintext
+// value. Duplicate scopes are dropped; a zero expiresAt never expires.
+func NewAPIKey(tenantID, name string, scopes []string, expiresAt, now time.Time) (*APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", ErrNameRequired
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return nil, "", ErrNameTooLong
+	}
+	for _, s := range scopes {
+		if !scopePattern.MatchString(s) {
+			return nil, "", ErrScopeInvalid
+		}
+	}
+	if !expiresAt.IsZero() && !expiresAt.After(now) {
+		return nil, "", ErrExpiryInPast
+	}
+
+	sorted := slices.Clone(scopes)
+	slices.Sort(sorted)
+	k := &APIKey{
+		id:        NewKeyID(),
+		tenantID:  tenantID,
+		name:      name,
+		scopes:    slices.Compact(sorted),
+		expiresAt: expiresAt,
+		createdAt: now,
+	}
+	return k, k.newSecret(), nil
+}
+
+// ReconstituteAPIKey rebuilds an APIKey from persistence without validation.
+func ReconstituteAPIKey(id KeyID, tenantID, name string, scopes []string, secretHash string, expiresAt, revokedAt, rotatedAt, createdAt time.Time) *APIKey {
+	return &APIKey{
+		id:         id,
+		tenantID:   tenantID,
+		name:       name,
+		scopes:     scopes,
+		secretHash: secretHash,
+		expiresAt:  expiresAt,
+		revokedAt:  revokedAt,
+		rotatedAt:  rotatedAt,
+		createdAt:  createdAt,
+	}
+}
+
+func (k *APIKey) ID() KeyID            { return k.id }
+func (k *APIKey) TenantID() string     { return k.tenantID }
+func (k *APIKey) Name() string         { return k.name }
+func (k *APIKey) Scopes() []string     { return k.scopes }
+func (k *APIKey) SecretHash() string   { return k.secretHash }
+func (k *APIKey) ExpiresAt() time.Time { return k.expiresAt }
+func (k *APIKey) RevokedAt() time.Time { return k.revokedAt }
+func (k *APIKey) RotatedAt() time.Time { return k.rotatedAt }
+func (k *APIKey) CreatedAt() time.Time { return k.createdAt }
+
+// IsRevoked reports whether the key was revoked.
+func (k *APIKey) IsRevoked() bool { return !k.revokedAt.IsZero() }
+
+// IsExpired reports whether the key has expired at now.
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return !k.expiresAt.IsZero() && !now.Before(k.expiresAt)
+}
+
+// Rotate replaces the secret of the key and returns the new plaintext key.
+// The previous key stops authenticating at once. Revoked keys cannot be
+// rotated; expired ones can, and keep their expiry.
+func (k *APIKey) Rotate(now time.Time) (string, error) {
+	if k.IsRevoked() {
+		return "", ErrKeyRevoked
+	}
+	k.rotatedAt = now
+	return k.newSecret(), nil
+}
+
+// Revoke stops the key from authenticating for good.
+func (k *APIKey) Revoke(now time.Time) error {
+	if k.IsRevoked() {
+		return ErrKeyAlreadyRevoked
+	}
+	k.revokedAt = now
+	return nil
+}
+
+// Authenticate checks secret, as returned by ParseKey, against the key at
+// now: ErrKeyInvalid if it does not match, ErrKeyRevoked or ErrKeyExpired
+// if the key can no longer be used.
+func (k *APIKey) Authenticate(secret string, now time.Time) error {
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(k.secretHash)) != 1 {
+		return ErrKeyInvalid
+	}
+	if k.IsRevoked() {
+		return ErrKeyRevoked
+	}
+	if k.IsExpired(now) {
+		return ErrKeyExpired
+	}
+	return nil
+}
+
+// newSecret generates a secret of 32 random bytes, keeps its hash and
+// returns the plaintext key: KeyPrefix, the key ID, "_" and the secret.
+func (k *APIKey) newSecret() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	secret := hex.EncodeToString(b)
+	k.secretHash = hashSecret(secret)
+	return KeyPrefix + k.id.String() + "_" + secret
+}
+
+// ParseKey splits a plaintext key into its ID and secret. It returns
+// ErrKeyInvalid if key is not shaped like an API key.
+func ParseKey(key string) (KeyID, string, error) {
+	rest, ok := strings.CutPrefix(key, KeyPrefix)
+	if !ok {
+		return KeyID{}, "", ErrKeyInvalid
+	}
+	id, secret, ok := strings.Cut(rest, "_")
+	if !ok || secret == "" {
+		return KeyID{}, "", ErrKeyInvalid
+	}
+	keyID, err := ParseKeyID(id)
+	if err != nil {
+		return KeyID{}, "", ErrKeyInvalid
+	}
+	return keyID, secret, nil
+}
+
+// hashSecret returns the hex SHA-256 of secret. Secrets are random, so a
+// fast unsalted hash is enough to make a leaked table useless.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var now = time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC)
+
+func TestNewAPIKey(t *testing.T) {
+	k, key, err := NewAPIKey("acme", " ERP sync ", []string{"webhooks:admin", "orders:read", "webhooks:admin"}, now.Add(24*time.Hour), now)
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+
+	if k.Name() != "ERP sync" || k.TenantID() != "acme" {
+		t.Errorf("key = %+v", k)
+	}
+	if got := strings.Join(k.Scopes(), ","); got != "orders:read,webhooks:admin" {
+		t.Errorf("Scopes() = %s, want sorted without duplicates", got)
+	}
+	if strings.Contains(k.SecretHash(), key) || !strings.HasPrefix(key, KeyPrefix+k.ID().String()+"_") {
+		t.Errorf("key = %q, hash = %q", key, k.SecretHash())
+	}
+
+	id, secret, err := ParseKey(key)
+	if err != nil || id != k.ID() {
+		t.Fatalf("ParseKey() = %v, %v", id, err)
+	}
+	if err := k.Authenticate(secret, now); err != nil {
+		t.Errorf("Authenticate() = %v", err)
+	}
+}
+
+func TestNewAPIKey_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		keyName   string
+		scopes    []string
+		expiresAt time.Time
+		want      error
+	}{
+		{"no name", " ", nil, time.Time{}, ErrNameRequired},
+		{"long name", strings.Repeat("x", 101), nil, time.Time{}, ErrNameTooLong},
+		{"scope without permission", "ERP", []string{"webhooks"}, time.Time{}, ErrScopeInvalid},
+		{"expired", "ERP", nil, now, ErrExpiryInPast},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := NewAPIKey("", tt.keyName, tt.scopes, tt.expiresAt, now); !errors.Is(err, tt.want) {
+				t.Errorf("NewAPIKey() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAPIKey_Authenticate(t *testing.T) {
+	k, key, _ := NewAPIKey("", "ERP", nil, now.Add(time.Hour), now)
+	_, secret, _ := ParseKey(key)
+
+	if err := k.Authenticate("wrong", now); !errors.Is(err, ErrKeyInvalid) {
+		t.Errorf("wrong secret: %v, want ErrKeyInvalid", err)
+	}
+	if err := k.Authenticate(secret, now.Add(time.Hour)); !errors.Is(err, ErrKeyExpired) {
+		t.Errorf("at expiry: %v, want ErrKeyExpired", err)
+	}
+
+	if err := k.Revoke(now); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if err := k.Authenticate(secret, now); !errors.Is(err, ErrKeyRevoked) {
+		t.Errorf("revoked: %v, want ErrKeyRevoked", err)
+	}
+	if err := k.Revoke(now); !errors.Is(err, ErrKeyAlreadyRevoked) {
+		t.Errorf("Revoke() twice = %v, want ErrKeyAlreadyRevoked", err)
+	}
+}
+
+func TestAPIKey_Rotate(t *testing.T) {
+	k, oldKey, _ := NewAPIKey("", "ERP", nil, time.Time{}, now)
+
+	newKey, err := k.Rotate(now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+
+	_, oldSecret, _ := ParseKey(oldKey)
+	if err := k.Authenticate(oldSecret, now); !errors.Is(err, ErrKeyInvalid) {
+		t.Errorf("old key: %v, want ErrKeyInvalid", err)
+	}
+	id, newSecret, _ := ParseKey(newKey)
+	if id != k.ID() || k.Authenticate(newSecret, now) != nil {
+		t.Errorf("new key %q does not authenticate", newKey)
+	}
+	if !k.RotatedAt().Equal(now.Add(time.Minute)) {
+		t.Errorf("RotatedAt() = %v", k.RotatedAt())
+	}
+
+	k.Revoke(now)
+	if _, err := k.Rotate(now); !errors.Is(err, ErrKeyRevoked) {
+		t.Errorf("Rotate() revoked = %v, want ErrKeyRevoked", err)
+	}
+}
+
+func TestParseKey_Invalid(t *testing.T) {
+	for _, key := range []string{"", "whsec_abc", "ak_not-a-uuid_secret", "ak_7c9e6679-7425-40de-944b-e07fc1f90ae7", "ak_7c9e6679-7425-40de-944b-e07fc1f90ae7_"} {
+		if _, _, err := ParseKey(key); !errors.Is(err, ErrKeyInvalid) {
+			t.Errorf("ParseKey(%q) error = %v, want ErrKeyInvalid", key, err)
+		}
+	}
+}
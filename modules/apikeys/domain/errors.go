@@ -0,0 +1,26 @@
+// Package domain contains the API key model.
+package domain
+
+import "errors"
+
+// Domain errors - business rule violations.
+var (
+	ErrKeyNotFound       = errors.New("API key not found")
+	ErrInvalidKeyID      = errors.New("invalid API key ID format")
+	ErrNameRequired      = errors.New("API key name is required")
+	ErrNameTooLong       = errors.New("API key name must be at most 100 characters")
+	ErrScopeInvalid      = errors.New("scope must be of the form <module>:<permission>, e.g. webhooks:admin")
+	ErrExpiryInPast      = errors.New("API key expiry must be in the future")
+	ErrKeyAlreadyRevoked = errors.New("API key is already revoked")
+	// ErrScopeNotHeld is returned when a caller authenticated by API key
+	// grants a scope its own key does not hold; only the admin token may
+	// grant any scope.
+	ErrScopeNotHeld = errors.New("cannot grant a scope the calling API key does not hold")
+
+	// Authentication errors. A key that is malformed, unknown or whose
+	// secret does not match is ErrKeyInvalid, so callers learn nothing
+	// about the keys that exist.
+	ErrKeyInvalid = errors.New("invalid API key")
+	ErrKeyExpired = errors.New("API key has expired")
+	ErrKeyRevoked = errors.New("API key has been revoked")
+)
@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// KeyRepository persists API keys.
+type KeyRepository interface {
+	// Save inserts or replaces a key.
+	Save(ctx context.Context, k *APIKey) error
+	// FindByID returns ErrKeyNotFound if the key does not exist, whatever
+	// its tenant: authentication looks keys up before knowing the tenant.
+	FindByID(ctx context.Context, id KeyID) (*APIKey, error)
+	// FindByTenant returns the keys of tenantID, newest first, with the
+	// total count.
+	FindByTenant(ctx context.Context, tenantID string, offset, limit int) ([]*APIKey, int, error)
+}
@@ -0,0 +1,187 @@
+// Package http provides HTTP handlers for the apikeys module.
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+type Handler struct {
+	create   command.Handler[commands.CreateKeyCommand, commands.KeyResult]
+	rotate   command.Handler[commands.RotateKeyCommand, commands.KeyResult]
+	revoke   command.VoidHandler[commands.RevokeKeyCommand]
+	getKey   command.Handler[queries.GetKeyQuery, *queries.KeyDTO]
+	listKeys command.Handler[queries.ListKeysQuery, *queries.KeyListDTO]
+	admin    security.AdminGuard
+}
+
+// RegisterRoutes registers the apikeys module routes to the given mux.
+// Every route requires the admin token (or a key with the apikeys:admin
+// scope) and manages the keys of the request's tenant.
+func RegisterRoutes(
+	mux *http.ServeMux,
+	create command.Handler[commands.CreateKeyCommand, commands.KeyResult],
+	rotate command.Handler[commands.RotateKeyCommand, commands.KeyResult],
+	revoke command.VoidHandler[commands.RevokeKeyCommand],
+	getKey command.Handler[queries.GetKeyQuery, *queries.KeyDTO],
+	listKeys command.Handler[queries.ListKeysQuery, *queries.KeyListDTO],
+	admin security.AdminGuard,
+) {
+	h := &Handler{
+		create:   create,
+		rotate:   rotate,
+		revoke:   revoke,
+		getKey:   getKey,
+		listKeys: listKeys,
+		admin:    admin,
+	}
+
+	mux.HandleFunc("POST /api-keys", h.admin.HandlerFunc(h.handleCreateKey))
+	mux.HandleFunc("GET /api-keys", h.admin.HandlerFunc(h.handleListKeys))
+	mux.HandleFunc("GET /api-keys/{id}", h.admin.HandlerFunc(h.handleGetKey))
+	mux.HandleFunc("POST /api-keys/{id}/rotate", h.admin.HandlerFunc(h.handleRotateKey))
+	mux.HandleFunc("POST /api-keys/{id}/revoke", h.admin.HandlerFunc(h.handleRevokeKey))
+}
+
+// Request/Response DTOs
+
+type createKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// ExpiresAt is optional: keys without it never expire.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type keyResponse struct {
+	ID        string     `json:"id"`
+	Key       string     `json:"key"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+func toKeyResponse(result commands.KeyResult) keyResponse {
+	resp := keyResponse{ID: result.ID, Key: result.Key}
+	if !result.ExpiresAt.IsZero() {
+		resp.ExpiresAt = &result.ExpiresAt
+	}
+	return resp
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handlers
+
+func (h *Handler) handleCreateKey(w http.ResponseWriter, r *http.Request) {
+	var req createKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.create.Handle(r.Context(), commands.CreateKeyCommand{
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		h.denyIfNotHeld(r, err)
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toKeyResponse(result))
+}
+
+func (h *Handler) handleListKeys(w http.ResponseWriter, r *http.Request) {
+	p := page.ParseRequest(r.URL.Query())
+
+	result, err := h.listKeys.Handle(r.Context(), queries.ListKeysQuery{
+		Offset: p.Offset,
+		Limit:  p.Limit,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) handleGetKey(w http.ResponseWriter, r *http.Request) {
+	result, err := h.getKey.Handle(r.Context(), queries.GetKeyQuery{KeyID: r.PathValue("id")})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) handleRotateKey(w http.ResponseWriter, r *http.Request) {
+	result, err := h.rotate.Handle(r.Context(), commands.RotateKeyCommand{KeyID: r.PathValue("id")})
+	if err != nil {
+		h.denyIfNotHeld(r, err)
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toKeyResponse(result))
+}
+
+func (h *Handler) handleRevokeKey(w http.ResponseWriter, r *http.Request) {
+	if err := h.revoke.Handle(r.Context(), commands.RevokeKeyCommand{KeyID: r.PathValue("id")}); err != nil {
+		h.denyIfNotHeld(r, err)
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// denyIfNotHeld records the denial of a key granting, or revoking, scopes it
+// does not hold.
+func (h *Handler) denyIfNotHeld(r *http.Request, err error) {
+	if errors.Is(err, domain.ErrScopeNotHeld) {
+		h.admin.Deny(r, "scope_not_held")
+	}
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrKeyNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidKeyID),
+		errors.Is(err, domain.ErrNameRequired),
+		errors.Is(err, domain.ErrNameTooLong),
+		errors.Is(err, domain.ErrScopeInvalid),
+		errors.Is(err, domain.ErrExpiryInPast):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrScopeNotHeld):
+		writeError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, domain.ErrKeyAlreadyRevoked),
+		errors.Is(err, domain.ErrKeyRevoked):
+		writeError(w, http.StatusConflict, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
@@ -0,0 +1,174 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/domain"
+	apikeyshttp "github.com/rai/clean-modularmonolith-go/modules/apikeys/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/handlertest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+const (
+	adminToken = "admin-secret"
+	keyID      = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	key        = "ak_" + keyID + "_0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0"
+)
+
+var createdAt = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+type deps struct {
+	create   command.HandlerFunc[commands.CreateKeyCommand, commands.KeyResult]
+	rotate   command.HandlerFunc[commands.RotateKeyCommand, commands.KeyResult]
+	revoke   command.VoidHandlerFunc[commands.RevokeKeyCommand]
+	getKey   command.HandlerFunc[queries.GetKeyQuery, *queries.KeyDTO]
+	listKeys command.HandlerFunc[queries.ListKeysQuery, *queries.KeyListDTO]
+}
+
+func newMux(d deps) *http.ServeMux {
+	mux := http.NewServeMux()
+	apikeyshttp.RegisterRoutes(mux, d.create, d.rotate, d.revoke, d.getKey, d.listKeys, security.AdminGuard{Module: "apikeys", Token: adminToken})
+	return mux
+}
+
+func adminRequest(t *testing.T, method, target string, body any) *http.Request {
+	req := handlertest.NewRequest(t, method, target, body)
+	req.Header.Set(security.AdminTokenHeader, adminToken)
+	return req
+}
+
+func TestRoutes_RequireAdmin(t *testing.T) {
+	routes := []struct{ method, target string }{
+		{http.MethodPost, "/api-keys"},
+		{http.MethodGet, "/api-keys"},
+		{http.MethodGet, "/api-keys/" + keyID},
+		{http.MethodPost, "/api-keys/" + keyID + "/rotate"},
+		{http.MethodPost, "/api-keys/" + keyID + "/revoke"},
+	}
+	for _, route := range routes {
+		t.Run(route.method+" "+route.target, func(t *testing.T) {
+			rec := handlertest.Serve(newMux(deps{}), handlertest.NewRequest(t, route.method, route.target, nil))
+			handlertest.AssertStatus(t, rec, http.StatusForbidden)
+		})
+	}
+}
+
+func TestCreateKey(t *testing.T) {
+	var got commands.CreateKeyCommand
+	create := func(ctx context.Context, cmd commands.CreateKeyCommand) (commands.KeyResult, error) {
+		got = cmd
+		return commands.KeyResult{ID: keyID, Key: key, ExpiresAt: cmd.ExpiresAt}, nil
+	}
+
+	rec := handlertest.Serve(newMux(deps{create: create}), adminRequest(t, http.MethodPost, "/api-keys", map[string]any{
+		"name":       "ERP sync",
+		"scopes":     []string{"orders:read"},
+		"expires_at": "2027-01-01T00:00:00Z",
+	}))
+
+	handlertest.AssertStatus(t, rec, http.StatusCreated)
+	handlertest.AssertGolden(t, rec, "create_key")
+	if got.Name != "ERP sync" || len(got.Scopes) != 1 || !got.ExpiresAt.Equal(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("command = %+v", got)
+	}
+}
+
+func TestCreateKey_Invalid(t *testing.T) {
+	create := func(ctx context.Context, cmd commands.CreateKeyCommand) (commands.KeyResult, error) {
+		return commands.KeyResult{}, domain.ErrScopeInvalid
+	}
+
+	rec := handlertest.Serve(newMux(deps{create: create}), adminRequest(t, http.MethodPost, "/api-keys", map[string]any{
+		"name":   "ERP sync",
+		"scopes": []string{"orders"},
+	}))
+
+	handlertest.AssertStatus(t, rec, http.StatusBadRequest)
+	handlertest.AssertGolden(t, rec, "create_key_invalid_scope")
+}
+
+func TestCreateKey_ScopeNotHeld(t *testing.T) {
+	create := func(ctx context.Context, cmd commands.CreateKeyCommand) (commands.KeyResult, error) {
+		return commands.KeyResult{}, domain.ErrScopeNotHeld
+	}
+
+	rec := handlertest.Serve(newMux(deps{create: create}), adminRequest(t, http.MethodPost, "/api-keys", map[string]any{
+		"name":   "ERP sync",
+		"scopes": []string{"payments:admin"},
+	}))
+
+	handlertest.AssertStatus(t, rec, http.StatusForbidden)
+}
+
+func TestListKeys(t *testing.T) {
+	var got queries.ListKeysQuery
+	list := func(ctx context.Context, q queries.ListKeysQuery) (*queries.KeyListDTO, error) {
+		got = q
+		return &queries.KeyListDTO{TotalCount: 1, Offset: q.Offset, Limit: 10, Keys: []queries.KeyDTO{
+			{ID: keyID, Name: "ERP sync", Scopes: []string{"orders:read"}, CreatedAt: createdAt},
+		}}, nil
+	}
+
+	rec := handlertest.Serve(newMux(deps{listKeys: list}), adminRequest(t, http.MethodGet, "/api-keys?offset=0&limit=10", nil))
+
+	handlertest.AssertStatus(t, rec, http.StatusOK)
+	handlertest.AssertGolden(t, rec, "list_keys")
+	if got.Limit != 10 {
+		t.Errorf("query = %+v", got)
+	}
+}
+
+func TestGetKey_NotFound(t *testing.T) {
+	get := func(ctx context.Context, q queries.GetKeyQuery) (*queries.KeyDTO, error) {
+		return nil, domain.ErrKeyNotFound
+	}
+
+	rec := handlertest.Serve(newMux(deps{getKey: get}), adminRequest(t, http.MethodGet, "/api-keys/"+keyID, nil))
+
+	handlertest.AssertStatus(t, rec, http.StatusNotFound)
+	handlertest.AssertGolden(t, rec, "get_key_not_found")
+}
+
+func TestRotateKey(t *testing.T) {
+	var got commands.RotateKeyCommand
+	rotate := func(ctx context.Context, cmd commands.RotateKeyCommand) (commands.KeyResult, error) {
+		got = cmd
+		return commands.KeyResult{ID: keyID, Key: key}, nil
+	}
+
+	rec := handlertest.Serve(newMux(deps{rotate: rotate}), adminRequest(t, http.MethodPost, "/api-keys/"+keyID+"/rotate", nil))
+
+	handlertest.AssertStatus(t, rec, http.StatusOK)
+	handlertest.AssertGolden(t, rec, "rotate_key")
+	if got.KeyID != keyID {
+		t.Errorf("KeyID = %q, want %q", got.KeyID, keyID)
+	}
+}
+
+func TestRevokeKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"revoked", nil, http.StatusNoContent},
+		{"already revoked", domain.ErrKeyAlreadyRevoked, http.StatusConflict},
+		{"invalid id", domain.ErrInvalidKeyID, http.StatusBadRequest},
+		{"scope not held", domain.ErrScopeNotHeld, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revoke := func(ctx context.Context, cmd commands.RevokeKeyCommand) error { return tt.err }
+
+			rec := handlertest.Serve(newMux(deps{revoke: revoke}), adminRequest(t, http.MethodPost, "/api-keys/"+keyID+"/revoke", nil))
+
+			handlertest.AssertStatus(t, rec, tt.status)
+		})
+	}
+}
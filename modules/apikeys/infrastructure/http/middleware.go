@@ -0,0 +1,68 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+// APIKeyHeader carries the API key of machine clients.
+const APIKeyHeader = "X-API-Key"
+
+// Authenticate middleware authenticates requests carrying an X-API-Key with
+// authenticate. Requests without one pass through unchanged. For a valid
+// key, the request continues as the key's client: its actor, its tenant and
+// its scopes (see security.HasScope) replace those of the request context,
+// so it must run after httpserver.RequestContext. Otherwise it answers 401
+// Unauthorized, or 403 Forbidden when the request's tenant, which only the
+// admin token may choose, is another than the key's, and records the denial to sink, which may be nil. Successful
+// authentications are not recorded: they happen on every request.
+func Authenticate(authenticate func(ctx context.Context, key string) (queries.Principal, error), sink security.Sink) func(http.Handler) http.Handler {
+	deny := func(w http.ResponseWriter, r *http.Request, status int, message, reason string) {
+		if sink != nil {
+			sink.RecordDecision(r.Context(), security.NewDecision(r, security.KindAPIKey, security.OutcomeDenied, "apikeys", reason))
+		}
+		writeError(w, status, message)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(APIKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			p, err := authenticate(r.Context(), key)
+			switch {
+			case errors.Is(err, domain.ErrKeyInvalid):
+				deny(w, r, http.StatusUnauthorized, err.Error(), security.ReasonInvalidToken)
+				return
+			case errors.Is(err, domain.ErrKeyExpired):
+				deny(w, r, http.StatusUnauthorized, err.Error(), security.ReasonKeyExpired)
+				return
+			case errors.Is(err, domain.ErrKeyRevoked):
+				deny(w, r, http.StatusUnauthorized, err.Error(), security.ReasonKeyRevoked)
+				return
+			case err != nil:
+				writeError(w, http.StatusInternalServerError, "internal server error")
+				return
+			}
+
+			v := requestcontext.FromContext(r.Context())
+			if v.TenantID != "" && v.TenantID != p.TenantID {
+				deny(w, r, http.StatusForbidden, "API key is not valid for this tenant", security.ReasonWrongTenant)
+				return
+			}
+			v.Actor = p.Actor()
+			v.TenantID = p.TenantID
+			ctx := security.WithScopes(requestcontext.With(r.Context(), v), p.Scopes)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
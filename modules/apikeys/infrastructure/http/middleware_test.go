@@ -0,0 +1,97 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/domain"
+	apikeyshttp "github.com/rai/clean-modularmonolith-go/modules/apikeys/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/handlertest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+type sinkFunc func(ctx context.Context, d security.Decision)
+
+func (f sinkFunc) RecordDecision(ctx context.Context, d security.Decision) { f(ctx, d) }
+
+var principal = queries.Principal{KeyID: keyID, Name: "ERP sync", TenantID: "acme", Scopes: []string{"webhooks:admin"}}
+
+func authenticateAs(p queries.Principal, err error) func(context.Context, string) (queries.Principal, error) {
+	return func(ctx context.Context, key string) (queries.Principal, error) { return p, err }
+}
+
+func TestAuthenticate_ValidKey(t *testing.T) {
+	var got requestcontext.Values
+	var scoped bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = requestcontext.FromContext(r.Context())
+		scoped = security.HasScope(r.Context(), "webhooks:admin")
+	})
+
+	req := handlertest.NewRequest(t, http.MethodGet, "/webhooks", nil)
+	req.Header.Set(apikeyshttp.APIKeyHeader, key)
+	req = req.WithContext(requestcontext.With(req.Context(), requestcontext.Values{Actor: "anonymous", RequestID: "req-1"}))
+	rec := handlertest.Serve(apikeyshttp.Authenticate(authenticateAs(principal, nil), nil)(next), req)
+
+	handlertest.AssertStatus(t, rec, http.StatusOK)
+	if got.Actor != "apikey:"+keyID || got.TenantID != "acme" || got.RequestID != "req-1" {
+		t.Errorf("request context = %+v", got)
+	}
+	if !scoped {
+		t.Error("HasScope(webhooks:admin) = false, want the key's scopes")
+	}
+}
+
+func TestAuthenticate_NoKey(t *testing.T) {
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true })
+	authenticate := func(ctx context.Context, key string) (queries.Principal, error) {
+		t.Error("authenticate called without a key")
+		return queries.Principal{}, nil
+	}
+
+	handlertest.Serve(apikeyshttp.Authenticate(authenticate, nil)(next), handlertest.NewRequest(t, http.MethodGet, "/users", nil))
+
+	if !called {
+		t.Error("request without a key was not passed through")
+	}
+}
+
+func TestAuthenticate_Rejected(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		tenantID string
+		status   int
+		reason   string
+	}{
+		{"invalid", domain.ErrKeyInvalid, "", http.StatusUnauthorized, security.ReasonInvalidToken},
+		{"expired", domain.ErrKeyExpired, "", http.StatusUnauthorized, security.ReasonKeyExpired},
+		{"revoked", domain.ErrKeyRevoked, "", http.StatusUnauthorized, security.ReasonKeyRevoked},
+		{"other tenant", nil, "globex", http.StatusForbidden, security.ReasonWrongTenant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var decisions []security.Decision
+			sink := sinkFunc(func(ctx context.Context, d security.Decision) { decisions = append(decisions, d) })
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { t.Error("rejected request was served") })
+
+			req := handlertest.NewRequest(t, http.MethodGet, "/webhooks", nil)
+			req.Header.Set(apikeyshttp.APIKeyHeader, key)
+			req = req.WithContext(requestcontext.With(req.Context(), requestcontext.Values{TenantID: tt.tenantID}))
+			rec := handlertest.Serve(apikeyshttp.Authenticate(authenticateAs(principal, tt.err), sink)(next), req)
+
+			handlertest.AssertStatus(t, rec, tt.status)
+			want := security.Decision{Kind: security.KindAPIKey, Outcome: security.OutcomeDenied, Module: "apikeys", Reason: tt.reason}
+			if !slices.ContainsFunc(decisions, func(d security.Decision) bool {
+				return d.Kind == want.Kind && d.Outcome == want.Outcome && d.Module == want.Module && d.Reason == want.Reason
+			}) {
+				t.Errorf("decisions = %+v, want %+v", decisions, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+)
+
+var pageParams = []openapi.Param{
+	{Name: "offset", Type: "integer"},
+	{Name: "limit", Type: "integer"},
+}
+
+// Operations documents the routes registered by RegisterRoutes.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Pattern: "POST /api-keys", Summary: "Create an API key", Description: "The key is for the tenant of the request and is only returned here. Scopes are <module>:<permission>; <module>:admin grants the module's admin endpoints. A caller authenticated by API key may only grant the scopes it holds, and expires_at is capped at the expiry of its key.", Admin: true, Request: createKeyRequest{}, Status: http.StatusCreated, Response: keyResponse{}},
+		{Pattern: "GET /api-keys", Summary: "List the API keys of the tenant", Admin: true, Query: pageParams, Response: queries.KeyListDTO{}},
+		{Pattern: "GET /api-keys/{id}", Summary: "Get an API key", Admin: true, Response: queries.KeyDTO{}},
+		{Pattern: "POST /api-keys/{id}/rotate", Summary: "Rotate an API key", Description: "Returns the new key; the previous one stops working at once. A caller authenticated by API key may only rotate keys whose scopes it holds.", Admin: true, Response: keyResponse{}},
+		{Pattern: "POST /api-keys/{id}/revoke", Summary: "Revoke an API key", Description: "A caller authenticated by API key may only revoke keys whose scopes it holds.", Admin: true, Status: http.StatusNoContent},
+	}
+}
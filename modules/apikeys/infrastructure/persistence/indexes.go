@@ -0,0 +1,14 @@
+package persistence
+
+import platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+
+// The indexes of the APIKeys table. Queries read through them with From(),
+// and TestIndexesInSchema checks that schema/schema.sql creates them.
+var (
+	apiKeysByTenantIDCreatedAt = platformspanner.Index{Name: "APIKeysByTenantIDCreatedAt", Table: "APIKeys", Columns: []string{"TenantID", "CreatedAt DESC"}}
+)
+
+// Indexes are the secondary indexes the API key queries rely on.
+var Indexes = []platformspanner.Index{
+	apiKeysByTenantIDCreatedAt,
+}
@@ -0,0 +1,18 @@
+package persistence
+
+import (
+	"os"
+	"testing"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+)
+
+func TestIndexesInSchema(t *testing.T) {
+	schema, err := os.ReadFile("../../../../schema/schema.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ddl := range platformspanner.MissingIndexes(string(schema), Indexes...) {
+		t.Errorf("schema/schema.sql does not create the index: %s", ddl)
+	}
+}
@@ -0,0 +1,148 @@
+// Package persistence implements repository interfaces for API keys.
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/domain"
+)
+
+// SpannerKeyRepository stores API keys in the APIKeys table, with the
+// SHA-256 of their secret rather than the secret.
+type SpannerKeyRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerKeyRepository(client *spanner.Client, logger *slog.Logger) *SpannerKeyRepository {
+	return &SpannerKeyRepository{client: client, logger: logger}
+}
+
+func (r *SpannerKeyRepository) Save(ctx context.Context, k *domain.APIKey) error {
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO APIKeys (KeyID, TenantID, Name, Scopes, SecretHash, ExpiresAt, RevokedAt, RotatedAt, CreatedAt)
+		      VALUES (@keyID, @tenantID, @name, @scopes, @secretHash, @expiresAt, @revokedAt, @rotatedAt, @createdAt)`,
+		Params: map[string]interface{}{
+			"keyID":      k.ID().String(),
+			"tenantID":   k.TenantID(),
+			"name":       k.Name(),
+			"scopes":     k.Scopes(),
+			"secretHash": k.SecretHash(),
+			"expiresAt":  nullTime(k.ExpiresAt()),
+			"revokedAt":  nullTime(k.RevokedAt()),
+			"rotatedAt":  nullTime(k.RotatedAt()),
+			"createdAt":  k.CreatedAt(),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save API key: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerKeyRepository) FindByID(ctx context.Context, id domain.KeyID) (*domain.APIKey, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (*domain.APIKey, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT ` + keyColumns + ` FROM APIKeys WHERE KeyID = @keyID`,
+			Params: map[string]interface{}{"keyID": id.String()},
+		})
+		defer iter.Stop()
+
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return nil, domain.ErrKeyNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query API key: %w", err)
+		}
+		return scanKey(row)
+	})
+}
+
+func (r *SpannerKeyRepository) FindByTenant(ctx context.Context, tenantID string, offset, limit int) ([]*domain.APIKey, int, error) {
+	var total int
+	keys, err := platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.APIKey, error) {
+		params := map[string]interface{}{"tenantID": tenantID}
+		countIter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT COUNT(*) FROM ` + apiKeysByTenantIDCreatedAt.From() + ` WHERE TenantID = @tenantID`,
+			Params: params,
+		})
+		defer countIter.Stop()
+
+		var totalCount int64
+		countRow, err := countIter.Next()
+		if err != nil && err != iterator.Done {
+			return nil, fmt.Errorf("failed to count API keys: %w", err)
+		}
+		if countRow != nil {
+			if err := countRow.Columns(&totalCount); err != nil {
+				return nil, fmt.Errorf("failed to scan count: %w", err)
+			}
+		}
+		total = int(totalCount)
+
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT ` + keyColumns + `
+			      FROM ` + apiKeysByTenantIDCreatedAt.From() + `
+			      WHERE TenantID = @tenantID
+			      ORDER BY CreatedAt DESC
+			      LIMIT @limit OFFSET @offset`,
+			Params: map[string]interface{}{
+				"tenantID": tenantID,
+				"limit":    int64(limit),
+				"offset":   int64(offset),
+			},
+		})
+		defer iter.Stop()
+
+		var keys []*domain.APIKey
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query API keys: %w", err)
+			}
+			k, err := scanKey(row)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, k)
+		}
+		return keys, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return keys, total, nil
+}
+
+// keyColumns lists the columns read by scanKey, in order.
+const keyColumns = `KeyID, TenantID, Name, Scopes, SecretHash, ExpiresAt, RevokedAt, RotatedAt, CreatedAt`
+
+func scanKey(row *spanner.Row) (*domain.APIKey, error) {
+	var id, tenantID, name, secretHash string
+	var scopes []string
+	var expiresAt, revokedAt, rotatedAt spanner.NullTime
+	var createdAt time.Time
+	if err := row.Columns(&id, &tenantID, &name, &scopes, &secretHash, &expiresAt, &revokedAt, &rotatedAt, &createdAt); err != nil {
+		return nil, fmt.Errorf("failed to scan API key: %w", err)
+	}
+	keyID, err := domain.ParseKeyID(id)
+	if err != nil {
+		return nil, &platformspanner.CorruptRowError{Table: "APIKeys", Key: spanner.Key{id}, Column: "KeyID", Err: err}
+	}
+	return domain.ReconstituteAPIKey(keyID, tenantID, name, scopes, secretHash,
+		expiresAt.Time, revokedAt.Time, rotatedAt.Time, createdAt), nil
+}
+
+func nullTime(t time.Time) spanner.NullTime {
+	return spanner.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,88 @@
+// Package apikeys issues API keys to machine clients (integrations, batch
+// jobs) of a tenant and authenticates the requests that carry them. Keys are
+// stored hashed, can expire, be rotated and revoked, and carry scopes such
+// as "webhooks:admin" that other modules check via security.HasScope.
+package apikeys
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/apikeys/domain"
+	httphandler "github.com/rai/clean-modularmonolith-go/modules/apikeys/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// Module is the public API for the apikeys module.
+// External communication: HTTP API (RegisterRoutes) and the authentication
+// middleware (Middleware)
+type Module interface {
+	// RegisterRoutes registers the /api-keys routes to the given mux.
+	RegisterRoutes(mux *http.ServeMux)
+	// Operations documents the routes for the OpenAPI specification.
+	Operations() []openapi.Operation
+	// Middleware authenticates requests carrying an X-API-Key header as the
+	// key's client. It must run after the request context middleware.
+	Middleware() func(http.Handler) http.Handler
+}
+
+// Config holds the module configuration.
+type Config struct {
+	Repository       domain.KeyRepository
+	TransactionScope transaction.Scope
+	Logger           *slog.Logger
+
+	// AdminToken guards the management endpoints via the X-Admin-Token
+	// header. The endpoints are disabled when empty.
+	AdminToken string
+
+	// SecuritySink receives the admin token decisions and the rejected API
+	// keys. Optional: nil records nothing.
+	SecuritySink security.Sink
+
+	// CommandBus, when set, applies the platform's middleware (tracing,
+	// recording for the audit log and metrics, validation) to every command.
+	CommandBus *command.Bus
+}
+
+type module struct {
+	create       command.Handler[commands.CreateKeyCommand, commands.KeyResult]
+	rotate       command.Handler[commands.RotateKeyCommand, commands.KeyResult]
+	revoke       command.VoidHandler[commands.RevokeKeyCommand]
+	getKey       *queries.GetKeyHandler
+	listKeys     *queries.ListKeysHandler
+	authenticate *queries.AuthenticateHandler
+	admin        security.AdminGuard
+	sink         security.Sink
+}
+
+// New initializes the apikeys module.
+func New(cfg Config) Module {
+	return &module{
+		create:       command.Register[commands.CreateKeyCommand, commands.KeyResult](cfg.CommandBus, "apikeys", commands.NewCreateKeyHandler(cfg.Repository, cfg.TransactionScope)),
+		rotate:       command.Register[commands.RotateKeyCommand, commands.KeyResult](cfg.CommandBus, "apikeys", commands.NewRotateKeyHandler(cfg.Repository, cfg.TransactionScope)),
+		revoke:       command.RegisterVoid[commands.RevokeKeyCommand](cfg.CommandBus, "apikeys", commands.NewRevokeKeyHandler(cfg.Repository, cfg.TransactionScope)),
+		getKey:       queries.NewGetKeyHandler(cfg.Repository),
+		listKeys:     queries.NewListKeysHandler(cfg.Repository),
+		authenticate: queries.NewAuthenticateHandler(cfg.Repository),
+		admin:        security.AdminGuard{Module: "apikeys", Token: cfg.AdminToken, Sink: cfg.SecuritySink},
+		sink:         cfg.SecuritySink,
+	}
+}
+
+func (m *module) RegisterRoutes(mux *http.ServeMux) {
+	httphandler.RegisterRoutes(mux, m.create, m.rotate, m.revoke, m.getKey, m.listKeys, m.admin)
+}
+
+func (m *module) Operations() []openapi.Operation {
+	return httphandler.Operations()
+}
+
+func (m *module) Middleware() func(http.Handler) http.Handler {
+	return httphandler.Authenticate(m.authenticate.Handle, m.sink)
+}
@@ -0,0 +1,89 @@
+// Package commands contains the write side of the audit module.
+package commands
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/rai/clean-modularmonolith-go/modules/audit/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// RecordCommandHandler appends an entry for every executed command. It
+// implements command.Recorder and is wired into the other modules through
+// the Recording middleware of their CommandBus.
+type RecordCommandHandler struct {
+	repo    domain.AuditLogRepository
+	txScope transaction.Scope
+	logger  *slog.Logger
+}
+
+var _ command.Recorder = (*RecordCommandHandler)(nil)
+
+func NewRecordCommandHandler(repo domain.AuditLogRepository, txScope transaction.Scope, logger *slog.Logger) *RecordCommandHandler {
+	return &RecordCommandHandler{repo: repo, txScope: txScope, logger: logger}
+}
+
+// RecordCommand stores rec. Failures are logged and never reach the caller:
+// the command has already run and its outcome must not change.
+func (h *RecordCommandHandler) RecordCommand(ctx context.Context, rec command.Record) {
+	if err := h.record(context.WithoutCancel(ctx), rec); err != nil {
+		h.logger.Error("failed to record command in audit log",
+			slog.String("module", rec.Module),
+			slog.String("command", rec.Name),
+			slog.Any("error", err),
+		)
+	}
+}
+
+func (h *RecordCommandHandler) record(ctx context.Context, rec command.Record) error {
+	fields, err := domain.FieldsOf(rec.Command)
+	if err != nil {
+		return err
+	}
+
+	params := domain.EntryParams{
+		ID:           uuid.New().String(),
+		Source:       domain.SourceCommand,
+		Module:       rec.Module,
+		Action:       rec.Name,
+		Fields:       fields,
+		RequestActor: requestcontext.Actor(ctx),
+		OccurredAt:   rec.At,
+	}
+	if rec.Err != nil {
+		params.Failure = rec.Err.Error()
+	} else if id, ok := rec.Result.(string); ok && id != "" {
+		params.AggregateType, params.AggregateID = createdAggregate(rec.Name), id
+	}
+
+	entry, err := domain.NewEntry(params)
+	if err != nil {
+		return err
+	}
+	return h.txScope.Execute(ctx, func(ctx context.Context) error {
+		if err := h.repo.Append(ctx, entry); err != nil {
+			return fmt.Errorf("appending audit entry: %w", err)
+		}
+		return nil
+	})
+}
+
+var (
+	creationVerb = regexp.MustCompile(`^(Create|Register|Submit)`)
+	wordBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+)
+
+// createdAggregate names the aggregate created by a command that returns its
+// new ID, e.g. CreateDiscountCode -> discount_code.
+func createdAggregate(name string) string {
+	noun := creationVerb.ReplaceAllString(name, "")
+	return strings.ToLower(wordBoundary.ReplaceAllString(noun, "${1}_${2}"))
+}
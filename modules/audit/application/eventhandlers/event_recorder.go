@@ -0,0 +1,101 @@
+// Package eventhandlers records domain events in the audit log.
+package eventhandlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/rai/clean-modularmonolith-go/modules/audit/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// EventRecorder appends an entry for every domain event. It subscribes to
+// events.AnyEventType post-commit, so only committed changes are recorded.
+// Entries are keyed by event ID, which makes redelivery harmless. The events
+// of one commit are appended in a single transaction.
+type EventRecorder struct {
+	repo    domain.AuditLogRepository
+	txScope transaction.Scope
+}
+
+func NewEventRecorder(repo domain.AuditLogRepository, txScope transaction.Scope) *EventRecorder {
+	return &EventRecorder{repo: repo, txScope: txScope}
+}
+
+func (h *EventRecorder) HandlerName() string         { return "AuditEventRecorder" }
+func (h *EventRecorder) Subdomain() string           { return "audit" }
+func (h *EventRecorder) EventType() events.EventType { return events.AnyEventType }
+
+var _ events.BatchHandler = (*EventRecorder)(nil)
+
+func (h *EventRecorder) Handle(ctx context.Context, event events.Event) error {
+	entry, err := entryOf(event)
+	if err != nil {
+		return err
+	}
+
+	err = h.txScope.Execute(ctx, func(ctx context.Context) error {
+		return h.repo.Append(ctx, entry)
+	})
+	if errors.Is(err, domain.ErrEntryExists) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("appending audit entry: %w", err)
+	}
+	return nil
+}
+
+// HandleBatch appends the entries of evts in one transaction. If any of them
+// was recorded already, e.g. on redelivery, it falls back to appending them
+// one at a time so that the others are still recorded.
+func (h *EventRecorder) HandleBatch(ctx context.Context, evts []events.Event) error {
+	entries := make([]*domain.Entry, 0, len(evts))
+	for _, event := range evts {
+		entry, err := entryOf(event)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+	}
+
+	err := h.txScope.Execute(ctx, func(ctx context.Context) error {
+		for _, entry := range entries {
+			if err := h.repo.Append(ctx, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, domain.ErrEntryExists) {
+		for _, event := range evts {
+			if err := h.Handle(ctx, event); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("appending audit entries: %w", err)
+	}
+	return nil
+}
+
+func entryOf(event events.Event) (*domain.Entry, error) {
+	fields, err := domain.FieldsOf(event)
+	if err != nil {
+		return nil, err
+	}
+	module, _, _ := strings.Cut(event.EventType().String(), ".")
+	return domain.NewEntry(domain.EntryParams{
+		ID:         event.EventID(),
+		Source:     domain.SourceEvent,
+		Module:     module,
+		Action:     event.EventType().String(),
+		Fields:     fields,
+		OccurredAt: event.OccurredAt(),
+	})
+}
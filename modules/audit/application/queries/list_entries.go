@@ -0,0 +1,98 @@
+// Package queries contains the read side of the audit module.
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/audit/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+)
+
+type ListEntriesQuery struct {
+	Source        string
+	Module        string
+	Action        string
+	Actor         string
+	AggregateType string
+	AggregateID   string
+	From          time.Time
+	To            time.Time
+	Offset        int
+	Limit         int
+}
+
+type EntryDTO struct {
+	ID            string         `json:"id"`
+	Source        string         `json:"source"`
+	Module        string         `json:"module"`
+	Action        string         `json:"action"`
+	Actor         string         `json:"actor"`
+	AggregateType string         `json:"aggregate_type,omitempty"`
+	AggregateID   string         `json:"aggregate_id,omitempty"`
+	Diff          map[string]any `json:"diff"`
+	Succeeded     bool           `json:"succeeded"`
+	Error         string         `json:"error,omitempty"`
+	OccurredAt    time.Time      `json:"occurred_at"`
+}
+
+type ListEntriesResult struct {
+	Entries []EntryDTO `json:"entries"`
+	Total   int        `json:"total"`
+	Offset  int        `json:"offset"`
+	Limit   int        `json:"limit"`
+}
+
+type ListEntriesHandler struct {
+	repo domain.AuditLogRepository
+}
+
+func NewListEntriesHandler(repo domain.AuditLogRepository) *ListEntriesHandler {
+	return &ListEntriesHandler{repo: repo}
+}
+
+func (h *ListEntriesHandler) Handle(ctx context.Context, q ListEntriesQuery) (ListEntriesResult, error) {
+	filter := domain.Filter{
+		Module:        q.Module,
+		Action:        q.Action,
+		Actor:         q.Actor,
+		AggregateType: q.AggregateType,
+		AggregateID:   q.AggregateID,
+		From:          q.From,
+		To:            q.To,
+	}
+	if q.Source != "" {
+		source, err := domain.ParseSource(q.Source)
+		if err != nil {
+			return ListEntriesResult{}, err
+		}
+		filter.Source = source
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && q.From.After(q.To) {
+		return ListEntriesResult{}, domain.ErrInvalidTimeRange
+	}
+
+	p := page.NewRequest(q.Offset, q.Limit)
+	entries, total, err := h.repo.List(ctx, filter, p.Offset, p.Limit)
+	if err != nil {
+		return ListEntriesResult{}, err
+	}
+
+	result := ListEntriesResult{Entries: make([]EntryDTO, len(entries)), Total: total, Offset: p.Offset, Limit: p.Limit}
+	for i, e := range entries {
+		result.Entries[i] = EntryDTO{
+			ID:            e.ID(),
+			Source:        e.Source().String(),
+			Module:        e.Module(),
+			Action:        e.Action(),
+			Actor:         e.Actor(),
+			AggregateType: e.AggregateType(),
+			AggregateID:   e.AggregateID(),
+			Diff:          e.Diff(),
+			Succeeded:     e.Succeeded(),
+			Error:         e.Failure(),
+			OccurredAt:    e.OccurredAt(),
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,235 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Source tells whether an entry was recorded from a command or a domain event.
+type Source string
+
+const (
+	SourceCommand Source = "command"
+	SourceEvent   Source = "event"
+)
+
+// ParseSource validates a source filter.
+func ParseSource(s string) (Source, error) {
+	switch Source(s) {
+	case SourceCommand, SourceEvent:
+		return Source(s), nil
+	}
+	return "", ErrInvalidSource
+}
+
+func (s Source) String() string { return string(s) }
+
+const (
+	// AnonymousActor is recorded when a command does not name who issued it.
+	AnonymousActor = "anonymous"
+	// SystemActor is recorded when an event does not name who caused it.
+	SystemActor = "system"
+
+	redacted = "[redacted]"
+)
+
+// Entry is one immutable line of the audit log. Entries are only ever
+// appended; there is no operation to change or remove one.
+type Entry struct {
+	id            string
+	source        Source
+	module        string
+	action        string
+	actor         string
+	aggregateType string
+	aggregateID   string
+	diff          map[string]any
+	failure       string
+	occurredAt    time.Time
+}
+
+// EntryParams describes an action to record. Fields holds the command input
+// or event payload keyed by field name; the actor and aggregate are derived
+// from it unless given explicitly. RequestActor, who issued the request, is
+// the actor when neither names one.
+type EntryParams struct {
+	ID            string
+	Source        Source
+	Module        string
+	Action        string
+	Actor         string
+	RequestActor  string
+	AggregateType string
+	AggregateID   string
+	Fields        map[string]any
+	Failure       string // error message of a failed command
+	OccurredAt    time.Time
+}
+
+// NewEntry builds an entry, attributing it and redacting secrets from its diff.
+func NewEntry(p EntryParams) (*Entry, error) {
+	if p.ID == "" {
+		return nil, ErrEntryIDRequired
+	}
+	if _, err := ParseSource(string(p.Source)); err != nil {
+		return nil, err
+	}
+	if p.Module == "" {
+		return nil, ErrModuleRequired
+	}
+	if p.Action == "" {
+		return nil, ErrActionRequired
+	}
+	if p.OccurredAt.IsZero() {
+		return nil, ErrOccurredAtRequired
+	}
+
+	actor := p.Actor
+	if actor == "" {
+		actor = actorOf(p.Fields)
+	}
+	if actor == "" {
+		actor = p.RequestActor
+	}
+	if actor == "" {
+		actor = AnonymousActor
+		if p.Source == SourceEvent {
+			actor = SystemActor
+		}
+	}
+	aggregateType, aggregateID := p.AggregateType, p.AggregateID
+	if aggregateID == "" {
+		aggregateType, aggregateID = aggregateOf(p.Fields)
+	}
+
+	return &Entry{
+		id:            p.ID,
+		source:        p.Source,
+		module:        p.Module,
+		action:        p.Action,
+		actor:         actor,
+		aggregateType: aggregateType,
+		aggregateID:   aggregateID,
+		diff:          redact(p.Fields),
+		failure:       p.Failure,
+		occurredAt:    p.OccurredAt.UTC(),
+	}, nil
+}
+
+// FieldsOf flattens a command or event into the field map recorded as its
+// diff. Field names follow the value's JSON encoding.
+func FieldsOf(v any) (map[string]any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("encoding audit fields: %w", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, fmt.Errorf("decoding audit fields: %w", err)
+	}
+	return fields, nil
+}
+
+// ReconstituteEntry rebuilds an Entry from persistence without validation.
+func ReconstituteEntry(id string, source Source, module, action, actor, aggregateType, aggregateID string, diff map[string]any, failure string, occurredAt time.Time) *Entry {
+	return &Entry{
+		id:            id,
+		source:        source,
+		module:        module,
+		action:        action,
+		actor:         actor,
+		aggregateType: aggregateType,
+		aggregateID:   aggregateID,
+		diff:          diff,
+		failure:       failure,
+		occurredAt:    occurredAt,
+	}
+}
+
+func (e *Entry) ID() string            { return e.id }
+func (e *Entry) Source() Source        { return e.source }
+func (e *Entry) Module() string        { return e.module }
+func (e *Entry) Action() string        { return e.action }
+func (e *Entry) Actor() string         { return e.actor }
+func (e *Entry) AggregateType() string { return e.aggregateType }
+func (e *Entry) AggregateID() string   { return e.aggregateID }
+func (e *Entry) Diff() map[string]any  { return e.diff }
+func (e *Entry) Failure() string       { return e.failure }
+func (e *Entry) OccurredAt() time.Time { return e.occurredAt }
+
+// Succeeded reports whether the recorded action took effect. Events always
+// did; commands did unless they returned an error.
+func (e *Entry) Succeeded() bool { return e.failure == "" }
+
+// actorKeys lists, by priority, the fields that name who performed an action.
+// Keys are compared without case or underscores, so both command fields
+// (AdminID) and event payload keys (cancelled_by_id) match.
+var actorKeys = []string{"actorid", "adminid", "actor", "cancelledbyid", "userid"}
+
+// aggregateKeys maps identifier fields to the aggregate they identify. The
+// most specific aggregate comes first: a payment also names its order.
+var aggregateKeys = []struct{ key, aggregate string }{
+	{"reviewid", "review"},
+	{"paymentid", "payment"},
+	{"subscriptionid", "webhook_subscription"},
+	{"notificationid", "notification"},
+	{"orderid", "order"},
+	{"productid", "product"},
+	{"userid", "user"},
+}
+
+func actorOf(fields map[string]any) string {
+	for _, key := range actorKeys {
+		if v := lookup(fields, key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func aggregateOf(fields map[string]any) (string, string) {
+	for _, k := range aggregateKeys {
+		if v := lookup(fields, k.key); v != "" {
+			return k.aggregate, v
+		}
+	}
+	return "", ""
+}
+
+func lookup(fields map[string]any, key string) string {
+	for k, v := range fields {
+		if normalizeKey(k) != key {
+			continue
+		}
+		if s, ok := v.(string); ok {
+			return s
+		}
+		if v != nil {
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
+
+func normalizeKey(k string) string {
+	return strings.ToLower(strings.ReplaceAll(k, "_", ""))
+}
+
+// sensitiveKeys are never written to the audit log.
+var sensitiveKeys = []string{"secret", "token", "password"}
+
+func redact(fields map[string]any) map[string]any {
+	out := make(map[string]any, len(fields))
+	for k, v := range fields {
+		out[k] = v
+		for _, s := range sensitiveKeys {
+			if strings.Contains(normalizeKey(k), s) {
+				out[k] = redacted
+				break
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewEntry_Attribution(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name          string
+		source        Source
+		fields        map[string]any
+		requestActor  string
+		wantActor     string
+		wantAggregate string
+		wantID        string
+	}{
+		{
+			name:          "command names admin and order",
+			source:        SourceCommand,
+			fields:        map[string]any{"OrderID": "o-1", "AdminID": "alice"},
+			wantActor:     "alice",
+			wantAggregate: "order",
+			wantID:        "o-1",
+		},
+		{
+			name:          "event prefers the canceller over the owner",
+			source:        SourceEvent,
+			fields:        map[string]any{"order_id": "o-1", "user_id": "u-1", "cancelled_by_id": "bob"},
+			wantActor:     "bob",
+			wantAggregate: "order",
+			wantID:        "o-1",
+		},
+		{
+			name:          "payment is more specific than its order",
+			source:        SourceEvent,
+			fields:        map[string]any{"payment_id": "p-1", "order_id": "o-1"},
+			wantActor:     SystemActor,
+			wantAggregate: "payment",
+			wantID:        "p-1",
+		},
+		{
+			name:      "anonymous command",
+			source:    SourceCommand,
+			fields:    map[string]any{"Email": "a@example.com"},
+			wantActor: AnonymousActor,
+		},
+		{
+			name:         "request actor attributes an anonymous command",
+			source:       SourceCommand,
+			fields:       map[string]any{"Email": "a@example.com"},
+			requestActor: "admin",
+			wantActor:    "admin",
+		},
+		{
+			name:          "fields win over the request actor",
+			source:        SourceCommand,
+			fields:        map[string]any{"UserID": "u-1"},
+			requestActor:  "admin",
+			wantActor:     "u-1",
+			wantAggregate: "user",
+			wantID:        "u-1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := NewEntry(EntryParams{ID: "id", Source: tt.source, Module: "orders", Action: "Act", Fields: tt.fields, RequestActor: tt.requestActor, OccurredAt: now})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if e.Actor() != tt.wantActor {
+				t.Errorf("Actor() = %q, want %q", e.Actor(), tt.wantActor)
+			}
+			if e.AggregateType() != tt.wantAggregate || e.AggregateID() != tt.wantID {
+				t.Errorf("aggregate = %s/%s, want %s/%s", e.AggregateType(), e.AggregateID(), tt.wantAggregate, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestNewEntry_ExplicitAggregateWins(t *testing.T) {
+	e, err := NewEntry(EntryParams{
+		ID: "id", Source: SourceCommand, Module: "orders", Action: "CreateOrder",
+		AggregateType: "order", AggregateID: "o-new",
+		Fields:     map[string]any{"UserID": "u-1"},
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.AggregateType() != "order" || e.AggregateID() != "o-new" {
+		t.Errorf("aggregate = %s/%s, want order/o-new", e.AggregateType(), e.AggregateID())
+	}
+	if e.Actor() != "u-1" {
+		t.Errorf("Actor() = %q, want u-1", e.Actor())
+	}
+}
+
+func TestNewEntry_RedactsSecrets(t *testing.T) {
+	e, err := NewEntry(EntryParams{
+		ID: "id", Source: SourceCommand, Module: "webhooks", Action: "RegisterSubscription",
+		Fields:     map[string]any{"CallbackURL": "https://example.com", "Secret": "s3cret", "api_token": "t"},
+		OccurredAt: time.Now(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := e.Diff()
+	if diff["Secret"] != redacted || diff["api_token"] != redacted {
+		t.Errorf("secrets not redacted: %v", diff)
+	}
+	if diff["CallbackURL"] != "https://example.com" {
+		t.Errorf("CallbackURL = %v", diff["CallbackURL"])
+	}
+}
+
+func TestNewEntry_Validation(t *testing.T) {
+	valid := EntryParams{ID: "id", Source: SourceEvent, Module: "orders", Action: "orders.OrderSubmitted", OccurredAt: time.Now()}
+
+	bad := valid
+	bad.Source = "job"
+	if _, err := NewEntry(bad); !errors.Is(err, ErrInvalidSource) {
+		t.Errorf("error = %v, want ErrInvalidSource", err)
+	}
+	bad = valid
+	bad.Action = ""
+	if _, err := NewEntry(bad); !errors.Is(err, ErrActionRequired) {
+		t.Errorf("error = %v, want ErrActionRequired", err)
+	}
+	bad = valid
+	bad.OccurredAt = time.Time{}
+	if _, err := NewEntry(bad); !errors.Is(err, ErrOccurredAtRequired) {
+		t.Errorf("error = %v, want ErrOccurredAtRequired", err)
+	}
+}
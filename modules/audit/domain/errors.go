@@ -0,0 +1,15 @@
+// Package domain contains the audit log model.
+package domain
+
+import "errors"
+
+// Domain errors - business rule violations.
+var (
+	ErrEntryExists        = errors.New("audit entry already recorded")
+	ErrInvalidSource      = errors.New("source must be command or event")
+	ErrInvalidTimeRange   = errors.New("from must not be after to")
+	ErrActionRequired     = errors.New("audit action is required")
+	ErrModuleRequired     = errors.New("audit module is required")
+	ErrEntryIDRequired    = errors.New("audit entry ID is required")
+	ErrOccurredAtRequired = errors.New("audit occurrence time is required")
+)
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Filter narrows a listing of the audit log. Empty fields match everything.
+type Filter struct {
+	Source        Source
+	Module        string
+	Action        string
+	Actor         string
+	AggregateType string
+	AggregateID   string
+	From          time.Time // inclusive
+	To            time.Time // exclusive
+}
+
+// AuditLogRepository stores the audit log. It only appends and reads, in the
+// tenant of the request of its context.
+type AuditLogRepository interface {
+	// Append stores a new entry. Returns ErrEntryExists if the ID is taken.
+	Append(ctx context.Context, entry *Entry) error
+	// List returns matching entries, newest first, and the total count.
+	List(ctx context.Context, filter Filter, offset, limit int) ([]*Entry, int, error)
+}
@@ -0,0 +1,93 @@
+// Package http provides the admin endpoint for browsing the audit log.
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/audit/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/audit/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+type Handler struct {
+	listEntries *queries.ListEntriesHandler
+	admin       security.AdminGuard
+}
+
+// RegisterRoutes registers the audit module routes to the given mux.
+func RegisterRoutes(mux *http.ServeMux, listEntries *queries.ListEntriesHandler, admin security.AdminGuard) {
+	h := &Handler{listEntries: listEntries, admin: admin}
+
+	mux.HandleFunc("GET /audit", h.admin.HandlerFunc(h.handleListEntries))
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// handleListEntries filters by source, module, action, actor, aggregate_type,
+// aggregate_id and an RFC 3339 from/to window on the occurrence time.
+func (h *Handler) handleListEntries(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	from, err := parseTimeParam(params.Get("from"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "from must be an RFC 3339 timestamp")
+		return
+	}
+	to, err := parseTimeParam(params.Get("to"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "to must be an RFC 3339 timestamp")
+		return
+	}
+	p := page.ParseRequest(params)
+
+	result, err := h.listEntries.Handle(r.Context(), queries.ListEntriesQuery{
+		Source:        params.Get("source"),
+		Module:        params.Get("module"),
+		Action:        params.Get("action"),
+		Actor:         params.Get("actor"),
+		AggregateType: params.Get("aggregate_type"),
+		AggregateID:   params.Get("aggregate_id"),
+		From:          from,
+		To:            to,
+		Offset:        p.Offset,
+		Limit:         p.Limit,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func parseTimeParam(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSource),
+		errors.Is(err, domain.ErrInvalidTimeRange):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
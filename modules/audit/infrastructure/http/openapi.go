@@ -0,0 +1,24 @@
+package http
+
+import (
+	"github.com/rai/clean-modularmonolith-go/modules/audit/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+)
+
+// Operations documents the routes registered by RegisterRoutes.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Pattern: "GET /audit", Summary: "List audit log entries", Admin: true, Query: []openapi.Param{
+			{Name: "source"},
+			{Name: "module"},
+			{Name: "action"},
+			{Name: "actor"},
+			{Name: "aggregate_type"},
+			{Name: "aggregate_id"},
+			{Name: "from", Description: "RFC 3339 timestamp"},
+			{Name: "to", Description: "RFC 3339 timestamp"},
+			{Name: "offset", Type: "integer"},
+			{Name: "limit", Type: "integer"},
+		}, Response: queries.ListEntriesResult{}},
+	}
+}
@@ -0,0 +1,183 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/audit/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+// SpannerAuditLogRepository implements AuditLogRepository using Cloud
+// Spanner. Entries carry the tenant of the command or event they record, and
+// List only returns those of the tenant of its context.
+type SpannerAuditLogRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerAuditLogRepository(client *spanner.Client, logger *slog.Logger) *SpannerAuditLogRepository {
+	return &SpannerAuditLogRepository{client: client, logger: logger}
+}
+
+// Append inserts an entry; the audit log is never updated in place.
+// Must be called inside a read-write transaction: the existence check joins
+// it so that concurrent deliveries of the same event conflict.
+func (r *SpannerAuditLogRepository) Append(ctx context.Context, e *domain.Entry) error {
+	exists, err := platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (bool, error) {
+		_, err := reader.ReadRow(ctx, "AuditLog", spanner.Key{e.ID()}, []string{"EntryID"})
+		if spanner.ErrCode(err) == codes.NotFound {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to read audit entry: %w", err)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+	if exists {
+		return domain.ErrEntryExists
+	}
+
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT INTO AuditLog (EntryID, TenantID, Source, Module, Action, Actor, AggregateType, AggregateID, Diff, Error, OccurredAt, RecordedAt)
+		      VALUES (@entryID, @tenantID, @source, @module, @action, @actor, @aggregateType, @aggregateID, @diff, @error, @occurredAt, @recordedAt)`,
+		Params: map[string]interface{}{
+			"entryID":       e.ID(),
+			"tenantID":      requestcontext.TenantID(ctx),
+			"source":        e.Source().String(),
+			"module":        e.Module(),
+			"action":        e.Action(),
+			"actor":         e.Actor(),
+			"aggregateType": spanner.NullString{StringVal: e.AggregateType(), Valid: e.AggregateType() != ""},
+			"aggregateID":   spanner.NullString{StringVal: e.AggregateID(), Valid: e.AggregateID() != ""},
+			"diff":          spanner.NullJSON{Value: e.Diff(), Valid: true},
+			"error":         spanner.NullString{StringVal: e.Failure(), Valid: e.Failure() != ""},
+			"occurredAt":    e.OccurredAt(),
+			"recordedAt":    time.Now().UTC(),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	return nil
+}
+
+// List returns matching entries, newest first. Aggregate filters are served
+// by the AuditLogByAggregateOccurredAt index, the others by AuditLogByOccurredAt.
+func (r *SpannerAuditLogRepository) List(ctx context.Context, filter domain.Filter, offset, limit int) ([]*domain.Entry, int, error) {
+	where, params := listFilter(requestcontext.TenantID(ctx), filter)
+
+	var total int
+	entries, err := platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.Entry, error) {
+		countIter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT COUNT(*) FROM AuditLog` + where,
+			Params: params,
+		})
+		defer countIter.Stop()
+
+		var totalCount int64
+		countRow, err := countIter.Next()
+		if err != nil && err != iterator.Done {
+			return nil, fmt.Errorf("failed to count audit entries: %w", err)
+		}
+		if countRow != nil {
+			if err := countRow.Columns(&totalCount); err != nil {
+				return nil, fmt.Errorf("failed to scan count: %w", err)
+			}
+		}
+		total = int(totalCount)
+
+		pageParams := maps.Clone(params)
+		pageParams["limit"] = int64(limit)
+		pageParams["offset"] = int64(offset)
+
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT ` + entryColumns + `
+			      FROM AuditLog` + where + `
+			      ORDER BY OccurredAt DESC
+			      LIMIT @limit OFFSET @offset`,
+			Params: pageParams,
+		})
+		defer iter.Stop()
+
+		var entries []*domain.Entry
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query audit entries: %w", err)
+			}
+			e, err := scanEntry(row)
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, e)
+		}
+		return entries, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, total, nil
+}
+
+// listFilter builds the WHERE clause and parameters for List, always scoped
+// to tenantID. Values are always bound as parameters, never interpolated.
+func listFilter(tenantID string, f domain.Filter) (string, map[string]interface{}) {
+	conds := []string{"TenantID = @tenantID"}
+	params := map[string]interface{}{"tenantID": tenantID}
+
+	equal := func(column, param, value string) {
+		if value != "" {
+			conds = append(conds, column+" = @"+param)
+			params[param] = value
+		}
+	}
+	equal("Source", "source", f.Source.String())
+	equal("Module", "module", f.Module)
+	equal("Action", "action", f.Action)
+	equal("Actor", "actor", f.Actor)
+	equal("AggregateType", "aggregateType", f.AggregateType)
+	equal("AggregateID", "aggregateID", f.AggregateID)
+
+	if !f.From.IsZero() {
+		conds = append(conds, "OccurredAt >= @from")
+		params["from"] = f.From
+	}
+	if !f.To.IsZero() {
+		conds = append(conds, "OccurredAt < @to")
+		params["to"] = f.To
+	}
+
+	return " WHERE " + strings.Join(conds, " AND "), params
+}
+
+const entryColumns = `EntryID, Source, Module, Action, Actor, AggregateType, AggregateID, Diff, Error, OccurredAt`
+
+func scanEntry(row *spanner.Row) (*domain.Entry, error) {
+	var (
+		id, source, module, action, actor   string
+		aggregateType, aggregateID, failure spanner.NullString
+		diff                                spanner.NullJSON
+		occurredAt                          time.Time
+	)
+	if err := row.Columns(&id, &source, &module, &action, &actor, &aggregateType, &aggregateID, &diff, &failure, &occurredAt); err != nil {
+		return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+	}
+
+	fields, _ := diff.Value.(map[string]interface{})
+	return domain.ReconstituteEntry(id, domain.Source(source), module, action, actor, aggregateType.StringVal, aggregateID.StringVal, fields, failure.StringVal, occurredAt), nil
+}
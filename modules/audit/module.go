@@ -0,0 +1,82 @@
+// Package audit keeps an immutable log of every state change: each command
+// executed through the HTTP API and each domain event committed by a module.
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/audit/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/audit/application/eventhandlers"
+	"github.com/rai/clean-modularmonolith-go/modules/audit/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/audit/domain"
+	httphandler "github.com/rai/clean-modularmonolith-go/modules/audit/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// Module is the public API for the audit bounded context.
+// External communication: HTTP API (RegisterRoutes), admin-only
+// Cross-module communication: every Domain Event (wildcard subscription) and
+// command.Recorder, which the other modules call for each command.
+type Module interface {
+	// RegisterRoutes registers the module's HTTP routes to the given mux.
+	RegisterRoutes(mux *http.ServeMux)
+	// Operations documents the routes for the OpenAPI specification.
+	Operations() []openapi.Operation
+
+	command.Recorder
+}
+
+type Config struct {
+	Repository           domain.AuditLogRepository
+	TransactionScope     transaction.Scope
+	PostCommitSubscriber events.PostCommitSubscriber
+	Logger               *slog.Logger
+
+	// AdminToken guards the audit log via the X-Admin-Token header.
+	// The endpoint is disabled when empty.
+	AdminToken string
+
+	// SecuritySink receives the admin token and authorization decisions
+	// made by the HTTP handlers. Optional: nil records nothing.
+	SecuritySink security.Sink
+}
+
+type module struct {
+	recordCommand *commands.RecordCommandHandler
+	listEntries   *queries.ListEntriesHandler
+	admin         security.AdminGuard
+}
+
+// New initializes the audit module and subscribes to all events.
+func New(cfg Config) Module {
+	logger := cfg.Logger.With("module", "audit")
+
+	recorder := eventhandlers.NewEventRecorder(cfg.Repository, cfg.TransactionScope)
+	if err := cfg.PostCommitSubscriber.SubscribePostCommit(recorder.EventType(), recorder); err != nil {
+		logger.Error("failed to subscribe audit event recorder", slog.Any("error", err))
+	}
+
+	return &module{
+		recordCommand: commands.NewRecordCommandHandler(cfg.Repository, cfg.TransactionScope, logger),
+		listEntries:   queries.NewListEntriesHandler(cfg.Repository),
+		admin:         security.AdminGuard{Module: "audit", Token: cfg.AdminToken, Sink: cfg.SecuritySink},
+	}
+}
+
+func (m *module) RegisterRoutes(mux *http.ServeMux) {
+	httphandler.RegisterRoutes(mux, m.listEntries, m.admin)
+}
+
+func (m *module) Operations() []openapi.Operation {
+	return httphandler.Operations()
+}
+
+func (m *module) RecordCommand(ctx context.Context, rec command.Record) {
+	m.recordCommand.RecordCommand(ctx, rec)
+}
@@ -0,0 +1,63 @@
+// Package domain contains the read models the graphql module composes and
+// the ports it reads them through. The module owns no data: users and orders
+// are owned by their modules, whose adapters are wired in at composition
+// time so this module never imports their internals.
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// User is a user's profile.
+type User struct {
+	ID        string
+	Email     string
+	FirstName string
+	LastName  string
+	Status    string
+	CreatedAt time.Time
+}
+
+// Order is an order summary. Total is in minor units of Currency.
+type Order struct {
+	ID        string
+	UserID    string
+	Status    string
+	ItemCount int
+	Total     int64
+	Currency  string
+	CreatedAt time.Time
+}
+
+// OrderPage is a page of orders and the total number of orders.
+type OrderPage struct {
+	Orders     []Order
+	TotalCount int
+}
+
+// UserDirectory is the graphql module's port for users.
+type UserDirectory interface {
+	// FindUser returns a user. ok is false if the user does not exist.
+	FindUser(ctx context.Context, userID string) (user User, ok bool, err error)
+}
+
+// UserDirectoryFunc adapts an ordinary function to UserDirectory.
+type UserDirectoryFunc func(ctx context.Context, userID string) (User, bool, error)
+
+func (f UserDirectoryFunc) FindUser(ctx context.Context, userID string) (User, bool, error) {
+	return f(ctx, userID)
+}
+
+// OrderDirectory is the graphql module's port for orders.
+type OrderDirectory interface {
+	// UserOrders returns a page of a user's orders, newest first.
+	UserOrders(ctx context.Context, userID string, offset, limit int) (OrderPage, error)
+}
+
+// OrderDirectoryFunc adapts an ordinary function to OrderDirectory.
+type OrderDirectoryFunc func(ctx context.Context, userID string, offset, limit int) (OrderPage, error)
+
+func (f OrderDirectoryFunc) UserOrders(ctx context.Context, userID string, offset, limit int) (OrderPage, error) {
+	return f(ctx, userID, offset, limit)
+}
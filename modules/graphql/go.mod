@@ -0,0 +1,5 @@
+module github.com/rai/clean-modularmonolith-go/modules/graphql
+
+go 1.26.0
+
+require github.com/graph-gophers/graphql-go v1.9.0
@@ -0,0 +1,88 @@
+// Package http provides the GraphQL endpoint of the graphql module.
+package http
+
+import (
+	_ "embed"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/rai/clean-modularmonolith-go/modules/graphql/domain"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// maxDepth bounds the nesting of queries; the schema is at most five levels
+// deep (user, orders, nodes, total, amount).
+const maxDepth = 8
+
+// maxBodyBytes bounds the size of a request.
+const maxBodyBytes = 64 << 10
+
+type Handler struct {
+	schema *graphql.Schema
+}
+
+// RegisterRoutes registers the graphql module routes to the given mux.
+func RegisterRoutes(mux *http.ServeMux, users domain.UserDirectory, orders domain.OrderDirectory, logger *slog.Logger) {
+	h := &Handler{schema: NewSchema(users, orders, logger)}
+
+	mux.HandleFunc("POST /graphql", h.handleQuery)
+}
+
+// NewSchema parses the schema with its resolvers.
+func NewSchema(users domain.UserDirectory, orders domain.OrderDirectory, logger *slog.Logger) *graphql.Schema {
+	r := &resolver{users: users, orders: orders, logger: logger}
+	return graphql.MustParseSchema(schemaSDL, r, graphql.MaxDepth(maxDepth))
+}
+
+// Request/Response DTOs
+
+type queryRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// queryResponse documents the response, which is the *graphql.Response of
+// the query: errors in the query or its resolvers are reported in Errors
+// with status 200.
+type queryResponse struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []struct {
+		Message string `json:"message"`
+		Path    []any  `json:"path,omitempty"`
+	} `json:"errors,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handlers
+
+func (h *Handler) handleQuery(w http.ResponseWriter, r *http.Request) {
+	var req queryRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes)).Decode(&req); err != nil || req.Query == "" {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	resp := h.schema.Exec(r.Context(), req.Query, req.OperationName, req.Variables)
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Helper functions
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
@@ -0,0 +1,106 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/graphql/domain"
+	graphqlhttp "github.com/rai/clean-modularmonolith-go/modules/graphql/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/handlertest"
+)
+
+const userID = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+var createdAt = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func newMux(users domain.UserDirectoryFunc, orders domain.OrderDirectoryFunc) *http.ServeMux {
+	mux := http.NewServeMux()
+	graphqlhttp.RegisterRoutes(mux, users, orders, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return mux
+}
+
+func findUser(ctx context.Context, id string) (domain.User, bool, error) {
+	if id != userID {
+		return domain.User{}, false, nil
+	}
+	return domain.User{ID: userID, Email: "ada@example.com", FirstName: "Ada", LastName: "Lovelace", Status: "active", CreatedAt: createdAt}, true, nil
+}
+
+func query(q string, variables map[string]any) map[string]any {
+	return map[string]any{"query": q, "variables": variables}
+}
+
+const userWithOrders = `query($id: ID!) {
+  user(id: $id) {
+    id
+    firstName
+    orders(limit: 2) {
+      totalCount
+      nodes { id status itemCount total { amount currency } createdAt }
+    }
+  }
+}`
+
+func TestQuery_UserWithOrders(t *testing.T) {
+	var gotOffset, gotLimit int
+	orders := func(ctx context.Context, id string, offset, limit int) (domain.OrderPage, error) {
+		gotOffset, gotLimit = offset, limit
+		return domain.OrderPage{TotalCount: 3, Orders: []domain.Order{
+			{ID: "order-2", UserID: id, Status: "pending", ItemCount: 2, Total: 4500, Currency: "USD", CreatedAt: createdAt},
+			{ID: "order-1", UserID: id, Status: "draft", Currency: "USD", CreatedAt: createdAt},
+		}}, nil
+	}
+
+	rec := handlertest.Serve(newMux(findUser, orders), handlertest.NewRequest(t, http.MethodPost, "/graphql", query(userWithOrders, map[string]any{"id": userID})))
+
+	handlertest.AssertStatus(t, rec, http.StatusOK)
+	handlertest.AssertGolden(t, rec, "user_with_orders")
+	if gotOffset != 0 || gotLimit != 2 {
+		t.Errorf("UserOrders(offset %d, limit %d), want 0, 2", gotOffset, gotLimit)
+	}
+}
+
+func TestQuery_UnknownUser(t *testing.T) {
+	orders := func(ctx context.Context, id string, offset, limit int) (domain.OrderPage, error) {
+		t.Error("orders resolved for an unknown user")
+		return domain.OrderPage{}, nil
+	}
+
+	rec := handlertest.Serve(newMux(findUser, orders), handlertest.NewRequest(t, http.MethodPost, "/graphql", query(userWithOrders, map[string]any{"id": "unknown"})))
+
+	handlertest.AssertJSON(t, rec, `{"data":{"user":null}}`)
+}
+
+func TestQuery_DirectoryErrorIsNotLeaked(t *testing.T) {
+	orders := func(ctx context.Context, id string, offset, limit int) (domain.OrderPage, error) {
+		return domain.OrderPage{}, errors.New("spanner: session pool exhausted")
+	}
+
+	rec := handlertest.Serve(newMux(findUser, orders), handlertest.NewRequest(t, http.MethodPost, "/graphql", query(userWithOrders, map[string]any{"id": userID})))
+
+	handlertest.AssertStatus(t, rec, http.StatusOK)
+	handlertest.AssertGolden(t, rec, "directory_error")
+}
+
+func TestQuery_InvalidRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   any
+		golden string
+	}{
+		{"missing query", map[string]any{}, "missing_query"},
+		{"unknown field", query(`{ user(id: "x") { password } }`, nil), "unknown_field"},
+		{"too deep", query(`{ __schema { types { fields { type { ofType { ofType { ofType { ofType { name } } } } } } } } }`, nil), "too_deep"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := handlertest.Serve(newMux(findUser, nil), handlertest.NewRequest(t, http.MethodPost, "/graphql", tt.body))
+			handlertest.AssertGolden(t, rec, tt.golden)
+		})
+	}
+}
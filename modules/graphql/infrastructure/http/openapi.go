@@ -0,0 +1,12 @@
+package http
+
+import (
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+)
+
+// Operations documents the routes registered by RegisterRoutes.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Pattern: "POST /graphql", Summary: "Run a GraphQL query", Description: "Composes users and their orders; see schema.graphql. Query errors are reported in errors with status 200.", Request: queryRequest{}, Response: queryResponse{}},
+	}
+}
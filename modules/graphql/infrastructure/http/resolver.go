@@ -0,0 +1,93 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/rai/clean-modularmonolith-go/modules/graphql/domain"
+)
+
+// errInternal is the error reported in place of the directories' errors,
+// which are logged: the ports classify nothing as the caller's fault.
+var errInternal = errors.New("internal server error")
+
+// resolver resolves schema.graphql: each type of the schema has a resolver
+// with a method per field.
+type resolver struct {
+	users  domain.UserDirectory
+	orders domain.OrderDirectory
+	logger *slog.Logger
+}
+
+func (r *resolver) fail(ctx context.Context, field string, err error) error {
+	r.logger.ErrorContext(ctx, "resolving graphql field", "field", field, "error", err)
+	return errInternal
+}
+
+func (r *resolver) User(ctx context.Context, args struct{ ID graphql.ID }) (*userResolver, error) {
+	user, ok, err := r.users.FindUser(ctx, string(args.ID))
+	if err != nil {
+		return nil, r.fail(ctx, "Query.user", err)
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &userResolver{root: r, user: user}, nil
+}
+
+type userResolver struct {
+	root *resolver
+	user domain.User
+}
+
+func (u *userResolver) ID() graphql.ID          { return graphql.ID(u.user.ID) }
+func (u *userResolver) Email() string           { return u.user.Email }
+func (u *userResolver) FirstName() string       { return u.user.FirstName }
+func (u *userResolver) LastName() string        { return u.user.LastName }
+func (u *userResolver) Status() string          { return u.user.Status }
+func (u *userResolver) CreatedAt() graphql.Time { return graphql.Time{Time: u.user.CreatedAt} }
+
+func (u *userResolver) Orders(ctx context.Context, args struct{ Offset, Limit int32 }) (*orderConnectionResolver, error) {
+	page, err := u.root.orders.UserOrders(ctx, u.user.ID, int(args.Offset), int(args.Limit))
+	if err != nil {
+		return nil, u.root.fail(ctx, "User.orders", err)
+	}
+	return &orderConnectionResolver{page: page}, nil
+}
+
+type orderConnectionResolver struct {
+	page domain.OrderPage
+}
+
+func (c *orderConnectionResolver) TotalCount() int32 { return int32(c.page.TotalCount) }
+
+func (c *orderConnectionResolver) Nodes() []*orderResolver {
+	nodes := make([]*orderResolver, len(c.page.Orders))
+	for i := range c.page.Orders {
+		nodes[i] = &orderResolver{order: c.page.Orders[i]}
+	}
+	return nodes
+}
+
+type orderResolver struct {
+	order domain.Order
+}
+
+func (o *orderResolver) ID() graphql.ID   { return graphql.ID(o.order.ID) }
+func (o *orderResolver) Status() string   { return o.order.Status }
+func (o *orderResolver) ItemCount() int32 { return int32(o.order.ItemCount) }
+func (o *orderResolver) Total() *moneyResolver {
+	return &moneyResolver{amount: o.order.Total, currency: o.order.Currency}
+}
+func (o *orderResolver) CreatedAt() graphql.Time { return graphql.Time{Time: o.order.CreatedAt} }
+
+type moneyResolver struct {
+	amount   int64
+	currency string
+}
+
+func (m *moneyResolver) Amount() float64  { return float64(m.amount) }
+func (m *moneyResolver) Currency() string { return m.currency }
@@ -0,0 +1,47 @@
+// Package graphql is an optional read-only gateway that serves a GraphQL
+// endpoint composing the queries of other modules (a user with their
+// orders). It owns no data and reads only through its ports, which are
+// adapted from the users and orders module roots at composition time.
+package graphql
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/graphql/domain"
+	httphandler "github.com/rai/clean-modularmonolith-go/modules/graphql/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+)
+
+// Module is the public API for the graphql module.
+// External communication: HTTP API (RegisterRoutes)
+type Module interface {
+	// RegisterRoutes registers POST /graphql to the given mux.
+	RegisterRoutes(mux *http.ServeMux)
+	// Operations documents the routes for the OpenAPI specification.
+	Operations() []openapi.Operation
+}
+
+// Config holds the module configuration.
+type Config struct {
+	Users  domain.UserDirectory
+	Orders domain.OrderDirectory
+	Logger *slog.Logger
+}
+
+type module struct {
+	cfg Config
+}
+
+// New initializes the graphql module.
+func New(cfg Config) Module {
+	return &module{cfg: cfg}
+}
+
+func (m *module) RegisterRoutes(mux *http.ServeMux) {
+	httphandler.RegisterRoutes(mux, m.cfg.Users, m.cfg.Orders, m.cfg.Logger)
+}
+
+func (m *module) Operations() []openapi.Operation {
+	return httphandler.Operations()
+}
@@ -0,0 +1,103 @@
+// Package eventhandlers contains the integrations module's reactions to domain events.
+package eventhandlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/rai/clean-modularmonolith-go/modules/integrations/domain"
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// OrderConfirmedHandler books confirmed orders in the ERP and records the
+// outcome; a failed booking publishes ErpSyncFailedEvent. It is subscribed
+// post-commit, since calling the ERP is an external side effect, and skips
+// orders that already have a sync.
+type OrderConfirmedHandler struct {
+	repo    domain.SyncRepository
+	erp     domain.ERP
+	txScope transaction.ScopeWithDomainEvent
+	logger  *slog.Logger
+}
+
+func NewOrderConfirmedHandler(repo domain.SyncRepository, erp domain.ERP, txScope transaction.ScopeWithDomainEvent, logger *slog.Logger) *OrderConfirmedHandler {
+	return &OrderConfirmedHandler{repo: repo, erp: erp, txScope: txScope, logger: logger}
+}
+
+func (h *OrderConfirmedHandler) HandlerName() string { return "ErpSyncHandler" }
+func (h *OrderConfirmedHandler) Subdomain() string   { return "integrations" }
+func (h *OrderConfirmedHandler) EventType() events.EventType {
+	return orderevents.OrderConfirmedEventType
+}
+
+func (h *OrderConfirmedHandler) Handle(ctx context.Context, event events.Event) error {
+	e, ok := event.(orderevents.OrderConfirmedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	_, err := h.repo.FindByOrderID(ctx, e.OrderID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, domain.ErrSyncNotFound) {
+		return fmt.Errorf("finding ERP sync: %w", err)
+	}
+
+	documentNumber, bookErr := h.erp.BookSalesOrder(ctx, salesOrder(e))
+	if bookErr != nil {
+		h.logger.ErrorContext(ctx, "failed to book order in ERP",
+			slog.String("order_id", e.OrderID),
+			slog.Any("error", bookErr),
+		)
+	}
+
+	return h.txScope.ExecuteWithPublish(ctx, func(ctx context.Context) error {
+		sync := domain.NewSucceededSync(ctx, e.OrderID, documentNumber)
+		if bookErr != nil {
+			sync = domain.NewFailedSync(ctx, e.OrderID, bookErr)
+		}
+		if err := h.repo.Save(ctx, sync); err != nil {
+			return fmt.Errorf("saving ERP sync: %w", err)
+		}
+		return nil
+	})
+}
+
+// salesOrder translates the orders module's event into the integrations
+// module's model.
+func salesOrder(e orderevents.OrderConfirmedEvent) domain.SalesOrder {
+	lines := make([]domain.SalesOrderLine, len(e.Lines))
+	for i, line := range e.Lines {
+		lines[i] = domain.SalesOrderLine{
+			SKU:         line.ProductID,
+			Description: line.ProductName,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+		}
+	}
+	addr := e.ShippingAddress
+	return domain.SalesOrder{
+		OrderID:     e.OrderID,
+		CustomerID:  e.UserID,
+		ConfirmedAt: e.OccurredAt(),
+		Currency:    e.Currency,
+		Total:       e.TotalAmount,
+		Discount:    e.DiscountAmount,
+		Tax:         e.TaxAmount,
+		Lines:       lines,
+		ShipTo: domain.Address{
+			Name:       addr.Recipient,
+			Line1:      addr.Line1,
+			Line2:      addr.Line2,
+			City:       addr.City,
+			Region:     addr.Region,
+			PostalCode: addr.PostalCode,
+			Country:    addr.Country,
+		},
+	}
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "context"
+
+// ERP is the port to the external ERP. Adapters translate SalesOrder into
+// the ERP's API, retry its transient failures the way that ERP needs, and
+// return an error wrapping ErrERPRejected or ErrERPUnavailable when the
+// order could not be booked.
+type ERP interface {
+	// BookSalesOrder books order and returns the ERP's document number. It
+	// must be idempotent per OrderID: booking an order again returns the
+	// same document, so that redelivered events are harmless.
+	BookSalesOrder(ctx context.Context, order SalesOrder) (documentNumber string, err error)
+}
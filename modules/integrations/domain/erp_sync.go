@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// SyncStatus is the outcome of booking an order in the ERP.
+type SyncStatus string
+
+const (
+	SyncSucceeded SyncStatus = "succeeded" // booked; DocumentNumber is set
+	SyncFailed    SyncStatus = "failed"    // not booked; FailureReason says why
+)
+
+func (s SyncStatus) String() string { return string(s) }
+
+// ErpSync records how an order was booked in the ERP. There is one per
+// order: a redelivered OrderConfirmed event finds it and is skipped.
+type ErpSync struct {
+	orderID        string
+	status         SyncStatus
+	documentNumber string
+	failureReason  string
+	createdAt      time.Time
+}
+
+// NewSucceededSync records that orderID was booked as documentNumber.
+func NewSucceededSync(ctx context.Context, orderID, documentNumber string) *ErpSync {
+	return &ErpSync{
+		orderID:        orderID,
+		status:         SyncSucceeded,
+		documentNumber: documentNumber,
+		createdAt:      clock.Now(ctx),
+	}
+}
+
+// NewFailedSync records that orderID could not be booked because of err,
+// which the ERP adapter returned.
+// Adds ErpSyncFailedEvent to the context for later dispatch.
+func NewFailedSync(ctx context.Context, orderID string, err error) *ErpSync {
+	s := &ErpSync{
+		orderID:       orderID,
+		status:        SyncFailed,
+		failureReason: err.Error(),
+		createdAt:     clock.Now(ctx),
+	}
+	events.Add(ctx, newErpSyncFailedEvent(ctx, s, errors.Is(err, ErrERPRejected)))
+	return s
+}
+
+// ReconstituteErpSync rebuilds an ErpSync from persistence.
+func ReconstituteErpSync(orderID string, status SyncStatus, documentNumber, failureReason string, createdAt time.Time) *ErpSync {
+	return &ErpSync{
+		orderID:        orderID,
+		status:         status,
+		documentNumber: documentNumber,
+		failureReason:  failureReason,
+		createdAt:      createdAt,
+	}
+}
+
+func (s *ErpSync) OrderID() string        { return s.orderID }
+func (s *ErpSync) Status() SyncStatus     { return s.status }
+func (s *ErpSync) DocumentNumber() string { return s.documentNumber }
+func (s *ErpSync) FailureReason() string  { return s.failureReason }
+func (s *ErpSync) CreatedAt() time.Time   { return s.createdAt }
@@ -0,0 +1,47 @@
+package domain_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/integrations/domain"
+	integrationevents "github.com/rai/clean-modularmonolith-go/modules/integrations/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+func TestNewFailedSync_PublishesErpSyncFailed(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantRejected bool
+	}{
+		{"rejected", fmt.Errorf("%w: 422 ITEM_UNKNOWN", domain.ErrERPRejected), true},
+		{"unavailable", fmt.Errorf("%w after 4 attempts", domain.ErrERPUnavailable), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sync *domain.ErpSync
+			collected, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+				sync = domain.NewFailedSync(ctx, "order-1", tt.err)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("CaptureEvents() error = %v", err)
+			}
+			if sync.Status() != domain.SyncFailed || sync.FailureReason() != tt.err.Error() {
+				t.Errorf("sync = %s %q, want failed %q", sync.Status(), sync.FailureReason(), tt.err)
+			}
+			if len(collected) != 1 {
+				t.Fatalf("events = %d, want 1", len(collected))
+			}
+			e, ok := collected[0].(integrationevents.ErpSyncFailedEvent)
+			if !ok {
+				t.Fatalf("event = %T, want ErpSyncFailedEvent", collected[0])
+			}
+			if e.OrderID != "order-1" || e.Rejected != tt.wantRejected {
+				t.Errorf("event = %+v, want order-1 with rejected %v", e, tt.wantRejected)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+// Package domain contains the ERP synchronization model: the integrations
+// module's own view of a confirmed order, the port to the ERP and the record
+// of each order's booking.
+package domain
+
+import "errors"
+
+// Domain errors - business rule violations.
+var (
+	ErrSyncNotFound = errors.New("ERP sync not found")
+
+	// ErrERPRejected is returned by ERP adapters when the ERP refused the
+	// sales order, e.g. for an unknown product; booking it again will not
+	// help. Other failures wrap ErrERPUnavailable once the adapter has
+	// given up retrying.
+	ErrERPRejected    = errors.New("ERP rejected the sales order")
+	ErrERPUnavailable = errors.New("ERP unavailable")
+)
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"context"
+
+	integrationevents "github.com/rai/clean-modularmonolith-go/modules/integrations/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// Public event types, re-exported for use within the module.
+const (
+	ErpSyncFailedEventType = integrationevents.ErpSyncFailedEventType
+)
+
+func newErpSyncFailedEvent(ctx context.Context, s *ErpSync, rejected bool) integrationevents.ErpSyncFailedEvent {
+	return integrationevents.ErpSyncFailedEvent{
+		BaseEvent: events.NewBaseEventWithContext(ctx, ErpSyncFailedEventType),
+		OrderID:   s.OrderID(),
+		Reason:    s.FailureReason(),
+		Rejected:  rejected,
+	}
+}
@@ -0,0 +1,17 @@
+package events
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const ErpSyncFailedEventType events.EventType = "integrations.ErpSyncFailed"
+
+// ErpSyncFailedEvent is published when a confirmed order could not be
+// booked in the ERP, so that someone can book it by hand.
+// This is a public domain event — it may be imported by event handlers in other modules.
+type ErpSyncFailedEvent struct {
+	events.BaseEvent
+	OrderID string `json:"order_id"`
+	Reason  string `json:"reason"`
+	// Rejected is true when the ERP refused the order, and false when it
+	// stayed unavailable through every retry.
+	Rejected bool `json:"rejected"`
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "context"
+
+// SyncRepository persists the ERP syncs, scoped to the tenant of its
+// context.
+type SyncRepository interface {
+	// Save inserts a sync; there is at most one per order of a tenant.
+	Save(ctx context.Context, s *ErpSync) error
+	// FindByOrderID returns ErrSyncNotFound if the order has not been synced.
+	FindByOrderID(ctx context.Context, orderID string) (*ErpSync, error)
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// SalesOrder is a confirmed order as the integrations module books it in
+// the ERP. It is the module's own model: translated from
+// orders.OrderConfirmed on the way in and into the ERP's API by the adapter
+// on the way out, so that neither model leaks into the other.
+type SalesOrder struct {
+	OrderID     string // also the idempotency key of the booking
+	CustomerID  string
+	ConfirmedAt time.Time
+
+	// Amounts are in minor units of Currency. Total is the sum of the
+	// lines, less Discount, plus Tax.
+	Currency string
+	Total    int64
+	Discount int64
+	Tax      int64
+
+	Lines  []SalesOrderLine
+	ShipTo Address // zero if the order is not shipped
+}
+
+// SalesOrderLine is a product sold in a SalesOrder.
+type SalesOrderLine struct {
+	SKU         string
+	Description string
+	Quantity    int
+	UnitPrice   int64
+}
+
+// Address is where a SalesOrder is shipped.
+type Address struct {
+	Name       string
+	Line1      string
+	Line2      string
+	City       string
+	Region     string
+	PostalCode string
+	Country    string // ISO 3166-1 alpha-2
+}
+
+// IsZero reports whether no address was given.
+func (a Address) IsZero() bool { return a == Address{} }
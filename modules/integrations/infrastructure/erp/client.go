@@ -0,0 +1,133 @@
+// Package erp is the anti-corruption layer to the ERP: it translates the
+// integrations module's SalesOrder into the ERP's sales order API and the
+// ERP's answers into the module's errors.
+package erp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/integrations/domain"
+)
+
+// Config configures Client.
+type Config struct {
+	BaseURL string // e.g. https://erp.example.com/api
+	APIKey  string
+	Timeout time.Duration // per request; default 10s
+
+	// MaxAttempts bounds the requests per booking (default 4). Retries
+	// wait BaseDelay (default 1s) doubled per attempt, capped at MaxDelay
+	// (default 30s), unless the ERP asks for longer with Retry-After.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Client books sales orders through the ERP's HTTP API. The ERP
+// deduplicates bookings by their Idempotency-Key, the order ID, so a
+// booking can be retried whether or not the first request got through.
+// It answers 429 and 503 when overloaded, with Retry-After, and 4xx when
+// it rejects the order.
+type Client struct {
+	cfg    Config
+	client *http.Client
+}
+
+var _ domain.ERP = (*Client)(nil)
+
+func NewClient(cfg Config) *Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 4
+	}
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = time.Second
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = 30 * time.Second
+	}
+	return &Client{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+func (c *Client) BookSalesOrder(ctx context.Context, order domain.SalesOrder) (string, error) {
+	body, err := json.Marshal(toSalesOrderRequest(order))
+	if err != nil {
+		return "", fmt.Errorf("encoding sales order: %w", err)
+	}
+
+	delay := c.cfg.BaseDelay
+	for attempt := 1; ; attempt++ {
+		documentNumber, retryAfter, err := c.post(ctx, order.OrderID, body)
+		if err == nil || errors.Is(err, domain.ErrERPRejected) {
+			return documentNumber, err
+		}
+		if attempt == c.cfg.MaxAttempts {
+			return "", fmt.Errorf("%w after %d attempts: %w", domain.ErrERPUnavailable, attempt, err)
+		}
+
+		wait := max(delay, retryAfter)
+		delay = min(delay*2, c.cfg.MaxDelay)
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return "", fmt.Errorf("%w: %w", domain.ErrERPUnavailable, ctx.Err())
+		}
+	}
+}
+
+// post sends one booking request. A retryable failure is returned with the
+// delay the ERP asked for, if any.
+func (c *Client) post(ctx context.Context, idempotencyKey string, body []byte) (documentNumber string, retryAfter time.Duration, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.BaseURL+"/v1/sales-orders", bytes.NewReader(body))
+	if err != nil {
+		return "", 0, fmt.Errorf("building ERP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+	req.Header.Set("Idempotency-Key", idempotencyKey)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", 0, fmt.Errorf("posting sales order: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return "", 0, fmt.Errorf("reading ERP response: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusCreated:
+		var created salesOrderResponse
+		if err := json.Unmarshal(respBody, &created); err != nil || created.DocumentNumber == "" {
+			return "", 0, fmt.Errorf("unexpected ERP response: %s", respBody)
+		}
+		return created.DocumentNumber, 0, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode >= 500:
+		return "", parseRetryAfter(resp.Header.Get("Retry-After")), fmt.Errorf("ERP answered %d", resp.StatusCode)
+	default:
+		var erpErr errorResponse
+		_ = json.Unmarshal(respBody, &erpErr)
+		return "", 0, fmt.Errorf("%w: %d %s: %s", domain.ErrERPRejected, resp.StatusCode, erpErr.Error.Code, erpErr.Error.Message)
+	}
+}
+
+// parseRetryAfter reads a Retry-After header in seconds; the ERP does not
+// send HTTP dates.
+func parseRetryAfter(s string) time.Duration {
+	seconds, err := strconv.Atoi(s)
+	if err != nil || seconds < 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,78 @@
+package erp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/integrations/domain"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return NewClient(Config{BaseURL: srv.URL, APIKey: "key", MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+}
+
+var order = domain.SalesOrder{OrderID: "order-1", CustomerID: "user-1", Currency: "USD", Total: 100}
+
+func TestClient_RetriesTransientFailures(t *testing.T) {
+	var requests int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if got := r.Header.Get("Idempotency-Key"); got != "order-1" {
+			t.Errorf("Idempotency-Key = %q, want order-1", got)
+		}
+		if requests < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"document_number":"SO000042"}`))
+	})
+
+	doc, err := c.BookSalesOrder(context.Background(), order)
+	if err != nil {
+		t.Fatalf("BookSalesOrder() error = %v", err)
+	}
+	if doc != "SO000042" || requests != 3 {
+		t.Errorf("BookSalesOrder() = %q after %d requests, want SO000042 after 3", doc, requests)
+	}
+}
+
+func TestClient_RejectionIsNotRetried(t *testing.T) {
+	var requests int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"error":{"code":"ITEM_UNKNOWN","message":"item sku-9 does not exist"}}`))
+	})
+
+	_, err := c.BookSalesOrder(context.Background(), order)
+	if !errors.Is(err, domain.ErrERPRejected) {
+		t.Fatalf("BookSalesOrder() error = %v, want ErrERPRejected", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
+
+func TestClient_GivesUpAfterMaxAttempts(t *testing.T) {
+	var requests int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	_, err := c.BookSalesOrder(context.Background(), order)
+	if !errors.Is(err, domain.ErrERPUnavailable) {
+		t.Fatalf("BookSalesOrder() error = %v, want ErrERPUnavailable", err)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+}
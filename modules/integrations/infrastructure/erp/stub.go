@@ -0,0 +1,47 @@
+package erp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rai/clean-modularmonolith-go/modules/integrations/domain"
+)
+
+// RejectedSKU is a test product StubERP does not know, so that it rejects
+// the orders containing it.
+const RejectedSKU = "erp-unknown-item"
+
+// StubERP is an in-memory ERP for development and tests. Like the real
+// ERP, it books each order once and returns the same document number when
+// it is booked again.
+type StubERP struct {
+	mu       sync.Mutex
+	booked   map[string]string // order ID → document number
+	sequence int
+}
+
+var _ domain.ERP = (*StubERP)(nil)
+
+func NewStubERP() *StubERP {
+	return &StubERP{booked: make(map[string]string)}
+}
+
+func (e *StubERP) BookSalesOrder(ctx context.Context, order domain.SalesOrder) (string, error) {
+	for _, line := range order.Lines {
+		if line.SKU == RejectedSKU {
+			return "", fmt.Errorf("%w: unknown item %s", domain.ErrERPRejected, line.SKU)
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if documentNumber, ok := e.booked[order.OrderID]; ok {
+		return documentNumber, nil
+	}
+	e.sequence++
+	documentNumber := fmt.Sprintf("SO%06d", e.sequence)
+	e.booked[order.OrderID] = documentNumber
+	return documentNumber, nil
+}
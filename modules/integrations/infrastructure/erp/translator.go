@@ -0,0 +1,128 @@
+package erp
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/rai/clean-modularmonolith-go/modules/integrations/domain"
+)
+
+// The ERP's sales order API, v1. Amounts are decimal strings in the
+// currency's major unit, and text fields are limited to maxText runes.
+
+type salesOrderRequest struct {
+	ExternalReference string             `json:"external_reference"`
+	CustomerNumber    string             `json:"customer_number"`
+	OrderDate         string             `json:"order_date"` // YYYY-MM-DD, UTC
+	CurrencyCode      string             `json:"currency_code"`
+	NetAmount         string             `json:"net_amount"` // after discounts, before tax
+	DiscountAmount    string             `json:"discount_amount"`
+	TaxAmount         string             `json:"tax_amount"`
+	GrossAmount       string             `json:"gross_amount"`
+	Lines             []salesOrderLine   `json:"lines"`
+	ShipTo            *salesOrderAddress `json:"ship_to,omitempty"`
+}
+
+type salesOrderLine struct {
+	LineNo      int    `json:"line_no"`
+	ItemNo      string `json:"item_no"`
+	Description string `json:"description"`
+	Quantity    int    `json:"quantity"`
+	UnitPrice   string `json:"unit_price"`
+	LineAmount  string `json:"line_amount"`
+}
+
+type salesOrderAddress struct {
+	Name        string `json:"name"`
+	Address1    string `json:"address_1"`
+	Address2    string `json:"address_2,omitempty"`
+	City        string `json:"city"`
+	State       string `json:"state,omitempty"`
+	PostCode    string `json:"post_code"`
+	CountryCode string `json:"country_code"`
+}
+
+type salesOrderResponse struct {
+	DocumentNumber string `json:"document_number"`
+}
+
+type errorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+const maxText = 100
+
+// toSalesOrderRequest translates order into the ERP's API.
+func toSalesOrderRequest(order domain.SalesOrder) salesOrderRequest {
+	amount := func(minor int64) string { return decimal(minor, order.Currency) }
+
+	lines := make([]salesOrderLine, len(order.Lines))
+	for i, line := range order.Lines {
+		lines[i] = salesOrderLine{
+			LineNo:      (i + 1) * 10000, // the ERP's convention, leaving room for inserted lines
+			ItemNo:      line.SKU,
+			Description: truncate(line.Description),
+			Quantity:    line.Quantity,
+			UnitPrice:   amount(line.UnitPrice),
+			LineAmount:  amount(line.UnitPrice * int64(line.Quantity)),
+		}
+	}
+	req := salesOrderRequest{
+		ExternalReference: order.OrderID,
+		CustomerNumber:    order.CustomerID,
+		OrderDate:         order.ConfirmedAt.UTC().Format("2006-01-02"),
+		CurrencyCode:      order.Currency,
+		NetAmount:         amount(order.Total - order.Tax),
+		DiscountAmount:    amount(order.Discount),
+		TaxAmount:         amount(order.Tax),
+		GrossAmount:       amount(order.Total),
+		Lines:             lines,
+	}
+	if !order.ShipTo.IsZero() {
+		a := order.ShipTo
+		req.ShipTo = &salesOrderAddress{
+			Name:        truncate(a.Name),
+			Address1:    truncate(a.Line1),
+			Address2:    truncate(a.Line2),
+			City:        truncate(a.City),
+			State:       a.Region,
+			PostCode:    a.PostalCode,
+			CountryCode: a.Country,
+		}
+	}
+	return req
+}
+
+// decimal formats minor units of currency in its major unit, e.g. 1999 USD
+// as "19.99" and 1999 JPY as "1999".
+func decimal(minor int64, currency string) string {
+	digits := 2
+	switch currency {
+	case "JPY", "KRW", "VND", "CLP", "ISK":
+		digits = 0
+	case "BHD", "KWD", "OMR", "JOD", "TND":
+		digits = 3
+	}
+	sign := ""
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	s := strconv.FormatInt(minor, 10)
+	if digits == 0 {
+		return sign + s
+	}
+	if len(s) <= digits {
+		s = strings.Repeat("0", digits-len(s)+1) + s
+	}
+	return sign + s[:len(s)-digits] + "." + s[len(s)-digits:]
+}
+
+func truncate(s string) string {
+	if r := []rune(s); len(r) > maxText {
+		return string(r[:maxText])
+	}
+	return s
+}
@@ -0,0 +1,57 @@
+package erp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/integrations/domain"
+)
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		minor    int64
+		currency string
+		want     string
+	}{
+		{1999, "USD", "19.99"},
+		{5, "EUR", "0.05"},
+		{0, "USD", "0.00"},
+		{-250, "USD", "-2.50"},
+		{1999, "JPY", "1999"},
+		{1500, "KWD", "1.500"},
+	}
+	for _, tt := range tests {
+		if got := decimal(tt.minor, tt.currency); got != tt.want {
+			t.Errorf("decimal(%d, %s) = %q, want %q", tt.minor, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestToSalesOrderRequest(t *testing.T) {
+	req := toSalesOrderRequest(domain.SalesOrder{
+		OrderID:     "order-1",
+		CustomerID:  "user-1",
+		ConfirmedAt: time.Date(2026, 3, 1, 23, 30, 0, 0, time.FixedZone("JST", 9*60*60)),
+		Currency:    "USD",
+		Total:       990,
+		Discount:    100,
+		Tax:         90,
+		Lines: []domain.SalesOrderLine{
+			{SKU: "sku-1", Description: "Mug", Quantity: 2, UnitPrice: 250},
+			{SKU: "sku-2", Description: "Teapot", Quantity: 1, UnitPrice: 500},
+		},
+	})
+
+	if req.OrderDate != "2026-03-01" || req.NetAmount != "9.00" || req.GrossAmount != "9.90" || req.DiscountAmount != "1.00" {
+		t.Errorf("request = %+v", req)
+	}
+	if len(req.Lines) != 2 || req.Lines[0].LineNo != 10000 || req.Lines[1].LineNo != 20000 {
+		t.Fatalf("lines = %+v, want line numbers 10000 and 20000", req.Lines)
+	}
+	if req.Lines[0].LineAmount != "5.00" {
+		t.Errorf("line amount = %q, want 5.00", req.Lines[0].LineAmount)
+	}
+	if req.ShipTo != nil {
+		t.Errorf("ship_to = %+v for an order without shipping address, want none", req.ShipTo)
+	}
+}
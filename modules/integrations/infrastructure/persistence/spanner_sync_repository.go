@@ -0,0 +1,74 @@
+// Package persistence implements repository interfaces for integrations.
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/integrations/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+type SpannerSyncRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerSyncRepository(client *spanner.Client, logger *slog.Logger) *SpannerSyncRepository {
+	return &SpannerSyncRepository{client: client, logger: logger}
+}
+
+// Save inserts the sync. A concurrent duplicate delivery of the same
+// OrderConfirmed event fails on the primary key rather than overwriting it.
+func (r *SpannerSyncRepository) Save(ctx context.Context, s *domain.ErpSync) error {
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT INTO ErpSyncs (TenantID, OrderID, Status, DocumentNumber, FailureReason, CreatedAt)
+		      VALUES (@tenantID, @orderID, @status, @documentNumber, @failureReason, @createdAt)`,
+		Params: map[string]interface{}{
+			"tenantID":       requestcontext.TenantID(ctx),
+			"orderID":        s.OrderID(),
+			"status":         s.Status().String(),
+			"documentNumber": spanner.NullString{StringVal: s.DocumentNumber(), Valid: s.DocumentNumber() != ""},
+			"failureReason":  spanner.NullString{StringVal: s.FailureReason(), Valid: s.FailureReason() != ""},
+			"createdAt":      s.CreatedAt(),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save ERP sync: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerSyncRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.ErpSync, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (*domain.ErpSync, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT OrderID, Status, DocumentNumber, FailureReason, CreatedAt
+			      FROM ErpSyncs WHERE TenantID = @tenantID AND OrderID = @orderID`,
+			Params: map[string]interface{}{"tenantID": requestcontext.TenantID(ctx), "orderID": orderID},
+		})
+		defer iter.Stop()
+
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return nil, domain.ErrSyncNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query ERP sync: %w", err)
+		}
+
+		var (
+			id, status                    string
+			documentNumber, failureReason spanner.NullString
+			createdAt                     time.Time
+		)
+		if err := row.Columns(&id, &status, &documentNumber, &failureReason, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to scan ERP sync: %w", err)
+		}
+		return domain.ReconstituteErpSync(id, domain.SyncStatus(status), documentNumber.StringVal, failureReason.StringVal, createdAt), nil
+	})
+}
@@ -0,0 +1,57 @@
+// Package integrations connects the system to third-party back-office
+// systems. Each integration is an anti-corruption layer: it translates
+// another module's public events into its own model, and that model into
+// the third party's API in an infrastructure adapter, so that neither
+// side's model leaks into the other. The ERP integration books confirmed
+// orders as sales orders; copy its layout for new integrations.
+package integrations
+
+import (
+	"log/slog"
+
+	"github.com/rai/clean-modularmonolith-go/modules/integrations/application/eventhandlers"
+	"github.com/rai/clean-modularmonolith-go/modules/integrations/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/integrations/infrastructure/erp"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// Module is the public API for the integrations bounded context.
+// External communication: the ERP's API
+// Cross-module communication: Domain Events only — consumes
+// orders.OrderConfirmed and publishes integrations.ErpSyncFailed.
+type Module interface{}
+
+type Config struct {
+	SyncRepository       domain.SyncRepository
+	TransactionScope     transaction.Scope
+	Publisher            events.Publisher
+	PostCommitPublisher  events.PostCommitPublisher
+	PostCommitSubscriber events.PostCommitSubscriber
+	Logger               *slog.Logger
+
+	// ERP books the sales orders. Defaults to the in-memory StubERP.
+	ERP domain.ERP
+}
+
+type module struct{}
+
+// New creates a new integrations module.
+func New(cfg Config) Module {
+	logger := cfg.Logger.With("module", "integrations")
+	txScope := events.NewScopeWithDomainEvent(cfg.TransactionScope, cfg.Publisher, cfg.PostCommitPublisher)
+
+	erpClient := cfg.ERP
+	if erpClient == nil {
+		logger.Warn("no ERP configured, using stub ERP")
+		erpClient = erp.NewStubERP()
+	}
+
+	// Post-commit: calling the ERP is an external side effect
+	orderConfirmed := eventhandlers.NewOrderConfirmedHandler(cfg.SyncRepository, erpClient, txScope, logger)
+	if err := cfg.PostCommitSubscriber.SubscribePostCommit(orderConfirmed.EventType(), orderConfirmed); err != nil {
+		logger.Error("failed to subscribe to order confirmed event", slog.Any("error", err))
+	}
+
+	return &module{}
+}
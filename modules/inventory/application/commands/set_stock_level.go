@@ -0,0 +1,52 @@
+// Package commands contains write use cases for the inventory module.
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// SetStockLevelCommand records the on-hand quantity of a product.
+type SetStockLevelCommand struct {
+	ProductID string
+	OnHand    int
+}
+
+type SetStockLevelHandler struct {
+	repo    domain.StockRepository
+	txScope transaction.Scope
+}
+
+func NewSetStockLevelHandler(repo domain.StockRepository, txScope transaction.Scope) *SetStockLevelHandler {
+	return &SetStockLevelHandler{
+		repo:    repo,
+		txScope: txScope,
+	}
+}
+
+// Handle executes the set stock level use case, creating the stock record
+// on first use.
+func (h *SetStockLevelHandler) Handle(ctx context.Context, cmd SetStockLevelCommand) error {
+	return h.txScope.Execute(ctx, func(ctx context.Context) error {
+		item, err := h.repo.FindByProductID(ctx, cmd.ProductID)
+		if errors.Is(err, domain.ErrStockItemNotFound) {
+			item, err = domain.NewStockItem(cmd.ProductID)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := item.SetOnHand(cmd.OnHand); err != nil {
+			return err
+		}
+
+		if err := h.repo.Save(ctx, item); err != nil {
+			return fmt.Errorf("saving stock: %w", err)
+		}
+		return nil
+	})
+}
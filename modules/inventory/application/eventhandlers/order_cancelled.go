@@ -0,0 +1,78 @@
+package eventhandlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/domain"
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// OrderCancelledHandler compensates a stock reservation when its order is
+// cancelled, returning the reserved units to stock. Orders cancelled because
+// stock was rejected, or before submission, have nothing to release.
+type OrderCancelledHandler struct {
+	stock        domain.StockRepository
+	reservations domain.ReservationRepository
+	txScope      transaction.Scope
+	logger       *slog.Logger
+}
+
+func NewOrderCancelledHandler(stock domain.StockRepository, reservations domain.ReservationRepository, txScope transaction.Scope, logger *slog.Logger) *OrderCancelledHandler {
+	return &OrderCancelledHandler{
+		stock:        stock,
+		reservations: reservations,
+		txScope:      txScope,
+		logger:       logger,
+	}
+}
+
+func (h *OrderCancelledHandler) HandlerName() string { return "StockReleaseHandler" }
+func (h *OrderCancelledHandler) Subdomain() string   { return "inventory" }
+func (h *OrderCancelledHandler) EventType() events.EventType {
+	return orderevents.OrderCancelledEventType
+}
+
+func (h *OrderCancelledHandler) Handle(ctx context.Context, event events.Event) error {
+	e, ok := event.(orderevents.OrderCancelledEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	return h.txScope.Execute(ctx, func(ctx context.Context) error {
+		reservation, err := h.reservations.FindByOrderID(ctx, e.OrderID)
+		if errors.Is(err, domain.ErrReservationNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("finding reservation: %w", err)
+		}
+
+		stock, err := h.stock.FindByProductIDs(ctx, reservation.ProductIDs())
+		if err != nil {
+			return fmt.Errorf("loading stock: %w", err)
+		}
+
+		if err := reservation.Release(stock); errors.Is(err, domain.ErrReservationNotActive) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		for _, item := range stock {
+			if err := h.stock.Save(ctx, item); err != nil {
+				return fmt.Errorf("saving stock: %w", err)
+			}
+		}
+		if err := h.reservations.Save(ctx, reservation); err != nil {
+			return fmt.Errorf("saving reservation: %w", err)
+		}
+
+		h.logger.Info("released stock for cancelled order", slog.String("order_id", e.OrderID))
+		return nil
+	})
+}
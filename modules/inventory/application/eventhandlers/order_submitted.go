@@ -0,0 +1,89 @@
+// Package eventhandlers reacts to events from other modules.
+package eventhandlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/domain"
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// OrderSubmittedHandler starts the order fulfillment saga: it reserves stock
+// for a submitted order in its own transaction and replies with
+// StockReservedEvent or StockRejectedEvent, which the orders module consumes.
+// Subscribed post-commit so the reservation never holds the orders transaction.
+type OrderSubmittedHandler struct {
+	stock        domain.StockRepository
+	reservations domain.ReservationRepository
+	txScope      transaction.ScopeWithDomainEvent
+	logger       *slog.Logger
+}
+
+func NewOrderSubmittedHandler(stock domain.StockRepository, reservations domain.ReservationRepository, txScope transaction.ScopeWithDomainEvent, logger *slog.Logger) *OrderSubmittedHandler {
+	return &OrderSubmittedHandler{
+		stock:        stock,
+		reservations: reservations,
+		txScope:      txScope,
+		logger:       logger,
+	}
+}
+
+func (h *OrderSubmittedHandler) HandlerName() string { return "StockReservationHandler" }
+func (h *OrderSubmittedHandler) Subdomain() string   { return "inventory" }
+func (h *OrderSubmittedHandler) EventType() events.EventType {
+	return orderevents.OrderSubmittedEventType
+}
+
+func (h *OrderSubmittedHandler) Handle(ctx context.Context, event events.Event) error {
+	e, ok := event.(orderevents.OrderSubmittedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	lines := make([]domain.ReservationLine, len(e.Items))
+	productIDs := make([]string, len(e.Items))
+	for i, item := range e.Items {
+		lines[i] = domain.ReservationLine{ProductID: item.ProductID, Quantity: item.Quantity}
+		productIDs[i] = item.ProductID
+	}
+
+	fn := func(ctx context.Context) error {
+		// Redelivered event: the reservation was already decided.
+		if _, err := h.reservations.FindByOrderID(ctx, e.OrderID); err == nil {
+			return nil
+		} else if !errors.Is(err, domain.ErrReservationNotFound) {
+			return fmt.Errorf("finding reservation: %w", err)
+		}
+
+		stock, err := h.stock.FindByProductIDs(ctx, productIDs)
+		if err != nil {
+			return fmt.Errorf("loading stock: %w", err)
+		}
+
+		// Adds StockReservedEvent or StockRejectedEvent to ctx
+		reservation := domain.Reserve(ctx, e.OrderID, lines, stock)
+
+		if reservation.Status() == domain.ReservationActive {
+			for _, item := range stock {
+				if err := h.stock.Save(ctx, item); err != nil {
+					return fmt.Errorf("saving stock: %w", err)
+				}
+			}
+		}
+		if err := h.reservations.Save(ctx, reservation); err != nil {
+			return fmt.Errorf("saving reservation: %w", err)
+		}
+
+		h.logger.Info("stock reservation decided",
+			slog.String("order_id", e.OrderID),
+			slog.String("status", reservation.Status().String()),
+		)
+		return nil
+	}
+	return h.txScope.ExecuteWithPublish(ctx, fn)
+}
@@ -0,0 +1,45 @@
+// Package queries contains read use cases for the inventory module.
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/domain"
+)
+
+// StockItemDTO is a read model for the stock of a product.
+type StockItemDTO struct {
+	ProductID string    `json:"product_id"`
+	OnHand    int       `json:"on_hand"`
+	Reserved  int       `json:"reserved"`
+	Available int       `json:"available"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GetStockItemQuery represents a request for the stock of a product.
+type GetStockItemQuery struct {
+	ProductID string
+}
+
+type GetStockItemHandler struct {
+	repo domain.StockRepository
+}
+
+func NewGetStockItemHandler(repo domain.StockRepository) *GetStockItemHandler {
+	return &GetStockItemHandler{repo: repo}
+}
+
+func (h *GetStockItemHandler) Handle(ctx context.Context, query GetStockItemQuery) (*StockItemDTO, error) {
+	item, err := h.repo.FindByProductID(ctx, query.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	return &StockItemDTO{
+		ProductID: item.ProductID(),
+		OnHand:    item.OnHand(),
+		Reserved:  item.Reserved(),
+		Available: item.Available(),
+		UpdatedAt: item.UpdatedAt(),
+	}, nil
+}
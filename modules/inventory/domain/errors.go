@@ -0,0 +1,17 @@
+// Package domain contains business entities and rules for inventory.
+package domain
+
+import "errors"
+
+// Domain errors - business rule violations.
+var (
+	// Stock errors
+	ErrStockItemNotFound   = errors.New("stock item not found")
+	ErrProductIDRequired   = errors.New("product ID is required")
+	ErrInvalidQuantity     = errors.New("quantity must not be negative")
+	ErrOnHandBelowReserved = errors.New("on-hand quantity cannot be lower than the reserved quantity")
+
+	// Reservation errors
+	ErrReservationNotFound  = errors.New("stock reservation not found")
+	ErrReservationNotActive = errors.New("stock reservation is not active")
+)
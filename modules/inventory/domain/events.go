@@ -0,0 +1,27 @@
+package domain
+
+import (
+	inventoryevents "github.com/rai/clean-modularmonolith-go/modules/inventory/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// Public event types, re-exported for use within the module.
+const (
+	StockReservedEventType = inventoryevents.StockReservedEventType
+	StockRejectedEventType = inventoryevents.StockRejectedEventType
+)
+
+func NewStockReservedEvent(r *Reservation) inventoryevents.StockReservedEvent {
+	return inventoryevents.StockReservedEvent{
+		BaseEvent: events.NewBaseEvent(StockReservedEventType),
+		OrderID:   r.OrderID(),
+	}
+}
+
+func NewStockRejectedEvent(r *Reservation, shortages []inventoryevents.Shortage) inventoryevents.StockRejectedEvent {
+	return inventoryevents.StockRejectedEvent{
+		BaseEvent: events.NewBaseEvent(StockRejectedEventType),
+		OrderID:   r.OrderID(),
+		Shortages: shortages,
+	}
+}
@@ -0,0 +1,21 @@
+package events
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const StockRejectedEventType events.EventType = "inventory.StockRejected"
+
+// StockRejectedEvent is published when a submitted order cannot be fulfilled
+// from available stock. Nothing is reserved; the orders module cancels the order.
+// This is a public domain event — it may be imported by event handlers in other modules.
+type StockRejectedEvent struct {
+	events.BaseEvent
+	OrderID   string     `json:"order_id"`
+	Shortages []Shortage `json:"shortages"`
+}
+
+// Shortage describes a line of StockRejectedEvent that could not be reserved.
+type Shortage struct {
+	ProductID string `json:"product_id"`
+	Requested int    `json:"requested"`
+	Available int    `json:"available"`
+}
@@ -0,0 +1,13 @@
+package events
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const StockReservedEventType events.EventType = "inventory.StockReserved"
+
+// StockReservedEvent is published when stock for every line of a submitted
+// order has been reserved. The order is confirmed once its payment is captured.
+// This is a public domain event — it may be imported by event handlers in other modules.
+type StockReservedEvent struct {
+	events.BaseEvent
+	OrderID string `json:"order_id"`
+}
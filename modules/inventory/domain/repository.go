@@ -0,0 +1,21 @@
+package domain
+
+import "context"
+
+// StockRepository persists stock levels, per tenant of the request of its
+// context.
+type StockRepository interface {
+	Save(ctx context.Context, item *StockItem) error
+	// FindByProductID returns ErrStockItemNotFound if the product has no stock record.
+	FindByProductID(ctx context.Context, productID string) (*StockItem, error)
+	// FindByProductIDs returns the stock records that exist, keyed by product ID.
+	FindByProductIDs(ctx context.Context, productIDs []string) (map[string]*StockItem, error)
+}
+
+// ReservationRepository persists stock reservations, scoped to the tenant of
+// the request of its context.
+type ReservationRepository interface {
+	Save(ctx context.Context, r *Reservation) error
+	// FindByOrderID returns ErrReservationNotFound if no reservation was made for the order.
+	FindByOrderID(ctx context.Context, orderID string) (*Reservation, error)
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	inventoryevents "github.com/rai/clean-modularmonolith-go/modules/inventory/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// ReservationStatus is the outcome of reserving stock for an order.
+type ReservationStatus string
+
+const (
+	ReservationActive   ReservationStatus = "reserved"
+	ReservationRejected ReservationStatus = "rejected" // nothing was reserved
+	ReservationReleased ReservationStatus = "released" // returned to stock after the order was cancelled
+)
+
+func (s ReservationStatus) String() string { return string(s) }
+
+// ReservationLine is a quantity of one product held for an order.
+type ReservationLine struct {
+	ProductID string
+	Quantity  int
+}
+
+// Reservation records the stock held for one order. It is kept after
+// rejection and release too, so that redelivered events are recognized.
+type Reservation struct {
+	orderID   string
+	lines     []ReservationLine
+	status    ReservationStatus
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// Reserve reserves lines for orderID against stock, all or nothing.
+// Products missing from stock count as having no available units.
+// Adds StockReservedEvent, or StockRejectedEvent if any line falls short,
+// to the context for later dispatch.
+func Reserve(ctx context.Context, orderID string, lines []ReservationLine, stock map[string]*StockItem) *Reservation {
+	now := time.Now().UTC()
+	r := &Reservation{
+		orderID:   orderID,
+		lines:     mergeLines(lines),
+		createdAt: now,
+		updatedAt: now,
+	}
+
+	var shortages []inventoryevents.Shortage
+	for _, line := range r.lines {
+		available := 0
+		if item, ok := stock[line.ProductID]; ok {
+			available = item.Available()
+		}
+		if line.Quantity > available {
+			shortages = append(shortages, inventoryevents.Shortage{
+				ProductID: line.ProductID,
+				Requested: line.Quantity,
+				Available: available,
+			})
+		}
+	}
+
+	if len(shortages) > 0 {
+		r.status = ReservationRejected
+		events.Add(ctx, NewStockRejectedEvent(r, shortages))
+		return r
+	}
+
+	for _, line := range r.lines {
+		stock[line.ProductID].reserve(line.Quantity)
+	}
+	r.status = ReservationActive
+	events.Add(ctx, NewStockReservedEvent(r))
+	return r
+}
+
+// ReconstituteReservation rebuilds a Reservation from persistence without validation.
+func ReconstituteReservation(orderID string, lines []ReservationLine, status ReservationStatus, createdAt, updatedAt time.Time) *Reservation {
+	return &Reservation{
+		orderID:   orderID,
+		lines:     lines,
+		status:    status,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+func (r *Reservation) OrderID() string           { return r.orderID }
+func (r *Reservation) Lines() []ReservationLine  { return r.lines }
+func (r *Reservation) Status() ReservationStatus { return r.status }
+func (r *Reservation) CreatedAt() time.Time      { return r.createdAt }
+func (r *Reservation) UpdatedAt() time.Time      { return r.updatedAt }
+
+// Release returns the reserved units to stock, compensating the reservation
+// when its order is cancelled.
+func (r *Reservation) Release(stock map[string]*StockItem) error {
+	if r.status != ReservationActive {
+		return ErrReservationNotActive
+	}
+	for _, line := range r.lines {
+		if item, ok := stock[line.ProductID]; ok {
+			item.release(line.Quantity)
+		}
+	}
+	r.status = ReservationReleased
+	r.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// ProductIDs returns the distinct products of the reservation.
+func (r *Reservation) ProductIDs() []string {
+	ids := make([]string, len(r.lines))
+	for i, line := range r.lines {
+		ids[i] = line.ProductID
+	}
+	return ids
+}
+
+// mergeLines sums quantities per product and orders lines by product ID.
+func mergeLines(lines []ReservationLine) []ReservationLine {
+	var merged []ReservationLine
+	for _, line := range lines {
+		if i := slices.IndexFunc(merged, func(l ReservationLine) bool { return l.ProductID == line.ProductID }); i >= 0 {
+			merged[i].Quantity += line.Quantity
+			continue
+		}
+		merged = append(merged, line)
+	}
+	slices.SortFunc(merged, func(a, b ReservationLine) int { return strings.Compare(a.ProductID, b.ProductID) })
+	return merged
+}
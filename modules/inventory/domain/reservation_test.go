@@ -0,0 +1,151 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/domain"
+	inventoryevents "github.com/rai/clean-modularmonolith-go/modules/inventory/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+func stockOf(t *testing.T, productID string, onHand int) *domain.StockItem {
+	t.Helper()
+	item, err := domain.NewStockItem(productID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := item.SetOnHand(onHand); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return item
+}
+
+func reserve(t *testing.T, orderID string, lines []domain.ReservationLine, stock map[string]*domain.StockItem) *domain.Reservation {
+	t.Helper()
+	var r *domain.Reservation
+	if _, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		r = domain.Reserve(ctx, orderID, lines, stock)
+		return nil
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return r
+}
+
+func TestReserve_HoldsStockAndEmitsStockReserved(t *testing.T) {
+	stock := map[string]*domain.StockItem{
+		"apple":  stockOf(t, "apple", 5),
+		"banana": stockOf(t, "banana", 2),
+	}
+
+	var r *domain.Reservation
+	captured, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		r = domain.Reserve(ctx, "order-1", []domain.ReservationLine{
+			{ProductID: "apple", Quantity: 2},
+			{ProductID: "banana", Quantity: 2},
+			{ProductID: "apple", Quantity: 1},
+		}, stock)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if r.Status() != domain.ReservationActive {
+		t.Fatalf("expected status %q, got %q", domain.ReservationActive, r.Status())
+	}
+	if len(r.Lines()) != 2 || r.Lines()[0].Quantity != 3 {
+		t.Errorf("expected lines merged per product, got %+v", r.Lines())
+	}
+	if got := stock["apple"].Available(); got != 2 {
+		t.Errorf("expected 2 apples available, got %d", got)
+	}
+	if got := stock["banana"].Available(); got != 0 {
+		t.Errorf("expected 0 bananas available, got %d", got)
+	}
+	if len(captured) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(captured))
+	}
+	if e, ok := captured[0].(inventoryevents.StockReservedEvent); !ok || e.OrderID != "order-1" {
+		t.Errorf("expected StockReservedEvent for order-1, got %+v", captured[0])
+	}
+}
+
+func TestReserve_RejectsWholeOrderOnShortage(t *testing.T) {
+	stock := map[string]*domain.StockItem{
+		"apple": stockOf(t, "apple", 5),
+	}
+
+	var r *domain.Reservation
+	captured, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		r = domain.Reserve(ctx, "order-1", []domain.ReservationLine{
+			{ProductID: "apple", Quantity: 1},
+			{ProductID: "cherry", Quantity: 4},
+		}, stock)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if r.Status() != domain.ReservationRejected {
+		t.Fatalf("expected status %q, got %q", domain.ReservationRejected, r.Status())
+	}
+	if got := stock["apple"].Available(); got != 5 {
+		t.Errorf("expected no stock held on rejection, got %d available", got)
+	}
+	if len(captured) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(captured))
+	}
+	rejected, ok := captured[0].(inventoryevents.StockRejectedEvent)
+	if !ok {
+		t.Fatalf("expected StockRejectedEvent, got %T", captured[0])
+	}
+	want := inventoryevents.Shortage{ProductID: "cherry", Requested: 4, Available: 0}
+	if len(rejected.Shortages) != 1 || rejected.Shortages[0] != want {
+		t.Errorf("expected shortage %+v, got %+v", want, rejected.Shortages)
+	}
+}
+
+func TestReservation_Release(t *testing.T) {
+	stock := map[string]*domain.StockItem{
+		"apple": stockOf(t, "apple", 5),
+	}
+	r := reserve(t, "order-1", []domain.ReservationLine{{ProductID: "apple", Quantity: 3}}, stock)
+
+	if err := r.Release(stock); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Status() != domain.ReservationReleased {
+		t.Errorf("expected status %q, got %q", domain.ReservationReleased, r.Status())
+	}
+	if got := stock["apple"].Available(); got != 5 {
+		t.Errorf("expected all 5 apples available again, got %d", got)
+	}
+
+	if err := r.Release(stock); !errors.Is(err, domain.ErrReservationNotActive) {
+		t.Errorf("expected ErrReservationNotActive on second release, got %v", err)
+	}
+}
+
+func TestStockItem_SetOnHand(t *testing.T) {
+	stock := map[string]*domain.StockItem{
+		"apple": stockOf(t, "apple", 5),
+	}
+	reserve(t, "order-1", []domain.ReservationLine{{ProductID: "apple", Quantity: 3}}, stock)
+
+	if err := stock["apple"].SetOnHand(2); !errors.Is(err, domain.ErrOnHandBelowReserved) {
+		t.Errorf("expected ErrOnHandBelowReserved, got %v", err)
+	}
+	if err := stock["apple"].SetOnHand(-1); !errors.Is(err, domain.ErrInvalidQuantity) {
+		t.Errorf("expected ErrInvalidQuantity, got %v", err)
+	}
+	if err := stock["apple"].SetOnHand(10); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := stock["apple"].Available(); got != 7 {
+		t.Errorf("expected 7 available, got %d", got)
+	}
+}
@@ -0,0 +1,61 @@
+package domain
+
+import "time"
+
+// StockItem tracks the stock of one product. Reserved units belong to
+// submitted orders and are no longer available to new ones.
+type StockItem struct {
+	productID string
+	onHand    int
+	reserved  int
+	updatedAt time.Time
+}
+
+// NewStockItem creates an empty stock record for productID.
+func NewStockItem(productID string) (*StockItem, error) {
+	if productID == "" {
+		return nil, ErrProductIDRequired
+	}
+	return &StockItem{productID: productID, updatedAt: time.Now().UTC()}, nil
+}
+
+// ReconstituteStockItem rebuilds a StockItem from persistence without validation.
+func ReconstituteStockItem(productID string, onHand, reserved int, updatedAt time.Time) *StockItem {
+	return &StockItem{
+		productID: productID,
+		onHand:    onHand,
+		reserved:  reserved,
+		updatedAt: updatedAt,
+	}
+}
+
+func (s *StockItem) ProductID() string    { return s.productID }
+func (s *StockItem) OnHand() int          { return s.onHand }
+func (s *StockItem) Reserved() int        { return s.reserved }
+func (s *StockItem) UpdatedAt() time.Time { return s.updatedAt }
+
+// Available returns the units that can still be reserved.
+func (s *StockItem) Available() int { return s.onHand - s.reserved }
+
+// SetOnHand records a stock count, e.g. after a delivery or a stocktake.
+func (s *StockItem) SetOnHand(quantity int) error {
+	if quantity < 0 {
+		return ErrInvalidQuantity
+	}
+	if quantity < s.reserved {
+		return ErrOnHandBelowReserved
+	}
+	s.onHand = quantity
+	s.updatedAt = time.Now().UTC()
+	return nil
+}
+
+func (s *StockItem) reserve(quantity int) {
+	s.reserved += quantity
+	s.updatedAt = time.Now().UTC()
+}
+
+func (s *StockItem) release(quantity int) {
+	s.reserved = max(s.reserved-quantity, 0)
+	s.updatedAt = time.Now().UTC()
+}
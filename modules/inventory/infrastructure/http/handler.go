@@ -0,0 +1,96 @@
+// Package http provides HTTP handlers for the inventory module.
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+type Handler struct {
+	setStock command.VoidHandler[commands.SetStockLevelCommand]
+	getStock *queries.GetStockItemHandler
+	admin    security.AdminGuard
+}
+
+// RegisterRoutes registers the inventory module routes to the given mux.
+func RegisterRoutes(mux *http.ServeMux, setStock command.VoidHandler[commands.SetStockLevelCommand], getStock *queries.GetStockItemHandler, admin security.AdminGuard) {
+	h := &Handler{
+		setStock: setStock,
+		getStock: getStock,
+		admin:    admin,
+	}
+
+	mux.HandleFunc("GET /inventory/{productId}", h.handleGetStock)
+	mux.HandleFunc("PUT /inventory/{productId}", h.admin.HandlerFunc(h.handleSetStock))
+}
+
+type setStockRequest struct {
+	OnHand int `json:"on_hand"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) handleGetStock(w http.ResponseWriter, r *http.Request) {
+	result, err := h.getStock.Handle(r.Context(), queries.GetStockItemQuery{ProductID: r.PathValue("productId")})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) handleSetStock(w http.ResponseWriter, r *http.Request) {
+	var req setStockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	productID := r.PathValue("productId")
+	if err := h.setStock.Handle(r.Context(), commands.SetStockLevelCommand{ProductID: productID, OnHand: req.OnHand}); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	result, err := h.getStock.Handle(r.Context(), queries.GetStockItemQuery{ProductID: productID})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrStockItemNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrOnHandBelowReserved):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrProductIDRequired),
+		errors.Is(err, domain.ErrInvalidQuantity):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
@@ -0,0 +1,14 @@
+package http
+
+import (
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+)
+
+// Operations documents the routes registered by RegisterRoutes.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Pattern: "GET /inventory/{productId}", Summary: "Get the stock level of a product", Response: queries.StockItemDTO{}},
+		{Pattern: "PUT /inventory/{productId}", Summary: "Set the stock on hand of a product", Admin: true, Request: setStockRequest{}, Response: queries.StockItemDTO{}},
+	}
+}
@@ -0,0 +1,102 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+// SpannerReservationRepository implements ReservationRepository using Cloud
+// Spanner, keyed by the tenant of the request of its context and the order.
+type SpannerReservationRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerReservationRepository(client *spanner.Client, logger *slog.Logger) *SpannerReservationRepository {
+	return &SpannerReservationRepository{client: client, logger: logger}
+}
+
+// Save upserts the reservation and its lines. Lines never change after the
+// reservation is made, so they are written with INSERT OR UPDATE as well.
+func (r *SpannerReservationRepository) Save(ctx context.Context, res *domain.Reservation) error {
+	tenantID := requestcontext.TenantID(ctx)
+	stmts := []spanner.Statement{{
+		SQL: `INSERT OR UPDATE INTO StockReservations (TenantID, OrderID, Status, CreatedAt, UpdatedAt)
+		      VALUES (@tenantID, @orderID, @status, @createdAt, @updatedAt)`,
+		Params: map[string]interface{}{
+			"tenantID":  tenantID,
+			"orderID":   res.OrderID(),
+			"status":    res.Status().String(),
+			"createdAt": res.CreatedAt(),
+			"updatedAt": res.UpdatedAt(),
+		},
+	}}
+	for _, line := range res.Lines() {
+		stmts = append(stmts, spanner.Statement{
+			SQL: `INSERT OR UPDATE INTO StockReservationLines (TenantID, OrderID, ProductID, Quantity)
+			      VALUES (@tenantID, @orderID, @productID, @quantity)`,
+			Params: map[string]interface{}{
+				"tenantID":  tenantID,
+				"orderID":   res.OrderID(),
+				"productID": line.ProductID,
+				"quantity":  int64(line.Quantity),
+			},
+		})
+	}
+
+	if err := platformspanner.Write(ctx, stmts...); err != nil {
+		return fmt.Errorf("failed to save stock reservation: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerReservationRepository) FindByOrderID(ctx context.Context, orderID string) (*domain.Reservation, error) {
+	key := spanner.Key{requestcontext.TenantID(ctx), orderID}
+	return platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (*domain.Reservation, error) {
+		row, err := reader.ReadRow(ctx, "StockReservations", key, []string{"Status", "CreatedAt", "UpdatedAt"})
+		if err != nil {
+			if spanner.ErrCode(err) == codes.NotFound {
+				return nil, domain.ErrReservationNotFound
+			}
+			return nil, fmt.Errorf("failed to read stock reservation: %w", err)
+		}
+
+		var status string
+		var createdAt, updatedAt time.Time
+		if err := row.Columns(&status, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan stock reservation: %w", err)
+		}
+
+		iter := reader.Read(ctx, "StockReservationLines", key.AsPrefix(), []string{"ProductID", "Quantity"})
+		defer iter.Stop()
+
+		var lines []domain.ReservationLine
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read stock reservation lines: %w", err)
+			}
+			var productID string
+			var quantity int64
+			if err := row.Columns(&productID, &quantity); err != nil {
+				return nil, fmt.Errorf("failed to scan stock reservation line: %w", err)
+			}
+			lines = append(lines, domain.ReservationLine{ProductID: productID, Quantity: int(quantity)})
+		}
+
+		return domain.ReconstituteReservation(orderID, lines, domain.ReservationStatus(status), createdAt, updatedAt), nil
+	})
+}
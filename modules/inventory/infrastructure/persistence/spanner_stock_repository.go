@@ -0,0 +1,101 @@
+// Package persistence implements repository interfaces for inventory.
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+// SpannerStockRepository implements StockRepository using Cloud Spanner.
+// Stock items are keyed by the tenant of the request of their context and
+// the product, so each tenant keeps its own stock of a product.
+type SpannerStockRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerStockRepository(client *spanner.Client, logger *slog.Logger) *SpannerStockRepository {
+	return &SpannerStockRepository{client: client, logger: logger}
+}
+
+func (r *SpannerStockRepository) Save(ctx context.Context, item *domain.StockItem) error {
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO StockItems (TenantID, ProductID, OnHand, Reserved, UpdatedAt)
+		      VALUES (@tenantID, @productID, @onHand, @reserved, @updatedAt)`,
+		Params: map[string]interface{}{
+			"tenantID":  requestcontext.TenantID(ctx),
+			"productID": item.ProductID(),
+			"onHand":    int64(item.OnHand()),
+			"reserved":  int64(item.Reserved()),
+			"updatedAt": item.UpdatedAt(),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save stock item: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerStockRepository) FindByProductID(ctx context.Context, productID string) (*domain.StockItem, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (*domain.StockItem, error) {
+		row, err := reader.ReadRow(ctx, "StockItems", spanner.Key{requestcontext.TenantID(ctx), productID}, stockColumns)
+		if err != nil {
+			if spanner.ErrCode(err) == codes.NotFound {
+				return nil, domain.ErrStockItemNotFound
+			}
+			return nil, fmt.Errorf("failed to read stock item: %w", err)
+		}
+		return scanStockItem(row)
+	})
+}
+
+func (r *SpannerStockRepository) FindByProductIDs(ctx context.Context, productIDs []string) (map[string]*domain.StockItem, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (map[string]*domain.StockItem, error) {
+		tenantID := requestcontext.TenantID(ctx)
+		keys := make([]spanner.KeySet, len(productIDs))
+		for i, id := range productIDs {
+			keys[i] = spanner.Key{tenantID, id}
+		}
+
+		iter := reader.Read(ctx, "StockItems", spanner.KeySets(keys...), stockColumns)
+		defer iter.Stop()
+
+		items := make(map[string]*domain.StockItem, len(productIDs))
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read stock items: %w", err)
+			}
+			item, err := scanStockItem(row)
+			if err != nil {
+				return nil, err
+			}
+			items[item.ProductID()] = item
+		}
+		return items, nil
+	})
+}
+
+var stockColumns = []string{"ProductID", "OnHand", "Reserved", "UpdatedAt"}
+
+func scanStockItem(row *spanner.Row) (*domain.StockItem, error) {
+	var productID string
+	var onHand, reserved int64
+	var updatedAt time.Time
+	if err := row.Columns(&productID, &onHand, &reserved, &updatedAt); err != nil {
+		return nil, fmt.Errorf("failed to scan stock item: %w", err)
+	}
+	return domain.ReconstituteStockItem(productID, int(onHand), int(reserved), updatedAt), nil
+}
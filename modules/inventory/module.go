@@ -0,0 +1,93 @@
+// Package inventory tracks stock per product and reserves it for submitted
+// orders as the inventory step of the order fulfillment saga.
+package inventory
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/application/eventhandlers"
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/inventory/domain"
+	httphandler "github.com/rai/clean-modularmonolith-go/modules/inventory/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// Module is the public API for the inventory bounded context.
+// External communication: HTTP API (RegisterRoutes)
+// Cross-module communication: Domain Events — consumes orders.OrderSubmitted
+// and orders.OrderCancelled, publishes inventory.StockReserved and
+// inventory.StockRejected.
+type Module interface {
+	// RegisterRoutes registers the module's HTTP routes to the given mux.
+	RegisterRoutes(mux *http.ServeMux)
+	// Operations documents the routes for the OpenAPI specification.
+	Operations() []openapi.Operation
+}
+
+type Config struct {
+	StockRepository       domain.StockRepository
+	ReservationRepository domain.ReservationRepository
+	TransactionScope      transaction.Scope
+	Publisher             events.Publisher
+	PostCommitPublisher   events.PostCommitPublisher
+	PostCommitSubscriber  events.PostCommitSubscriber
+	Logger                *slog.Logger
+
+	// AdminToken guards stock updates via the X-Admin-Token header.
+	// Stock updates are disabled when empty.
+	AdminToken string
+
+	// SecuritySink receives the admin token and authorization decisions
+	// made by the HTTP handlers. Optional: nil records nothing.
+	SecuritySink security.Sink
+
+	// CommandBus, when set, applies the platform's middleware (tracing,
+	// recording for the audit log and metrics, validation) to every command.
+	CommandBus *command.Bus
+}
+
+type module struct {
+	setStock command.VoidHandler[commands.SetStockLevelCommand]
+	getStock *queries.GetStockItemHandler
+	admin    security.AdminGuard
+}
+
+// New initializes the inventory module and subscribes to events.
+func New(cfg Config) Module {
+	logger := cfg.Logger.With("module", "inventory")
+	txScope := events.NewScopeWithDomainEvent(cfg.TransactionScope, cfg.Publisher, cfg.PostCommitPublisher)
+
+	// Subscribe to events (post-commit: each saga step runs in its own transaction)
+	handlers := []events.Handler{
+		eventhandlers.NewOrderSubmittedHandler(cfg.StockRepository, cfg.ReservationRepository, txScope, logger),
+		eventhandlers.NewOrderCancelledHandler(cfg.StockRepository, cfg.ReservationRepository, cfg.TransactionScope, logger),
+	}
+	for _, h := range handlers {
+		if err := cfg.PostCommitSubscriber.SubscribePostCommit(h.EventType(), h); err != nil {
+			logger.Error("failed to subscribe to event",
+				slog.String("event_type", h.EventType().String()),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return &module{
+		setStock: command.RegisterVoid[commands.SetStockLevelCommand](cfg.CommandBus, "inventory", commands.NewSetStockLevelHandler(cfg.StockRepository, cfg.TransactionScope)),
+		getStock: queries.NewGetStockItemHandler(cfg.StockRepository),
+		admin:    security.AdminGuard{Module: "inventory", Token: cfg.AdminToken, Sink: cfg.SecuritySink},
+	}
+}
+
+func (m *module) RegisterRoutes(mux *http.ServeMux) {
+	httphandler.RegisterRoutes(mux, m.setStock, m.getStock, m.admin)
+}
+
+func (m *module) Operations() []openapi.Operation {
+	return httphandler.Operations()
+}
@@ -0,0 +1,101 @@
+// Package commands contains the write use cases of the notifications module.
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// Dispatcher delivers a notification and records the outcome.
+// It is implemented by eventhandlers.Dispatcher.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, n *domain.Notification) error
+}
+
+// RetryDueNotificationsCommand redelivers notifications whose next retry is due at Now.
+type RetryDueNotificationsCommand struct {
+	Now       time.Time
+	BatchSize int
+	// Lease is how long a claimed notification is hidden from other workers
+	// while its delivery is in flight.
+	Lease time.Duration
+}
+
+type RetryDueNotificationsHandler struct {
+	repo       domain.NotificationRepository
+	txScope    transaction.Scope
+	dispatcher Dispatcher
+	logger     *slog.Logger
+}
+
+func NewRetryDueNotificationsHandler(repo domain.NotificationRepository, txScope transaction.Scope, dispatcher Dispatcher, logger *slog.Logger) *RetryDueNotificationsHandler {
+	return &RetryDueNotificationsHandler{
+		repo:       repo,
+		txScope:    txScope,
+		dispatcher: dispatcher,
+		logger:     logger,
+	}
+}
+
+// Handle executes the retry use case and returns the number of notifications redelivered.
+//
+// Each notification is first claimed in its own transaction that re-reads it
+// and moves its next attempt past the lease, then dispatched outside the
+// transaction. When several instances run the worker at once, only one of
+// them claims a given notification; the others observe the new attempt time
+// and skip it. Each notification is retried in its own tenant.
+func (h *RetryDueNotificationsHandler) Handle(ctx context.Context, cmd RetryDueNotificationsCommand) (int, error) {
+	due, err := h.repo.FindDueRetries(ctx, cmd.Now, cmd.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("finding due retries: %w", err)
+	}
+
+	retried := 0
+	for _, d := range due {
+		v := requestcontext.FromContext(ctx)
+		v.TenantID = d.TenantID
+		tenantCtx := requestcontext.With(ctx, v)
+		n, err := transaction.ExecuteWithResult(tenantCtx, h.txScope, func(ctx context.Context) (*domain.Notification, error) {
+			n, err := h.repo.FindByID(ctx, d.ID)
+			if err != nil {
+				return nil, fmt.Errorf("finding notification: %w", err)
+			}
+			if err := n.ClaimRetry(cmd.Now, cmd.Lease); err != nil {
+				return nil, err
+			}
+			if err := h.repo.Save(ctx, n); err != nil {
+				return nil, fmt.Errorf("saving notification: %w", err)
+			}
+			return n, nil
+		})
+		if err == nil {
+			err = h.dispatcher.Dispatch(tenantCtx, n)
+		}
+
+		switch {
+		case err == nil:
+			retried++
+		case errors.Is(err, domain.ErrRetryNotDue),
+			errors.Is(err, domain.ErrNotificationNotFound):
+			// Claimed or removed since the scan, possibly by another instance.
+		default:
+			if ctx.Err() != nil {
+				return retried, ctx.Err()
+			}
+			h.logger.Warn("failed to retry notification",
+				slog.String("notification_id", d.ID.String()),
+				slog.String("tenant_id", d.TenantID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return retried, nil
+}
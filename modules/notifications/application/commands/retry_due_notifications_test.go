@@ -0,0 +1,105 @@
+package commands_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+// tenantLog is a domain.NotificationRepository holding notifications per
+// tenant, which finds a notification only in the tenant of its context.
+type tenantLog struct {
+	due     []domain.DueRetry
+	byID    map[string]*domain.Notification // tenant + "/" + ID
+	savedIn map[domain.NotificationID]string
+}
+
+func (l *tenantLog) key(ctx context.Context, id domain.NotificationID) string {
+	return requestcontext.TenantID(ctx) + "/" + id.String()
+}
+
+func (l *tenantLog) Save(ctx context.Context, n *domain.Notification) error {
+	l.savedIn[n.ID()] = requestcontext.TenantID(ctx)
+	return nil
+}
+
+func (l *tenantLog) FindByRecipient(context.Context, domain.RecipientID, int, int) ([]*domain.Notification, int, error) {
+	return nil, 0, nil
+}
+
+func (l *tenantLog) FindByStatus(context.Context, domain.DeliveryStatus, int, int) ([]*domain.Notification, int, error) {
+	return nil, 0, nil
+}
+
+func (l *tenantLog) FindByID(ctx context.Context, id domain.NotificationID) (*domain.Notification, error) {
+	n, ok := l.byID[l.key(ctx, id)]
+	if !ok {
+		return nil, domain.ErrNotificationNotFound
+	}
+	return n, nil
+}
+
+func (l *tenantLog) FindDueRetries(context.Context, time.Time, int) ([]domain.DueRetry, error) {
+	return l.due, nil
+}
+
+// direct runs fn without a transaction.
+type direct struct{}
+
+func (direct) Execute(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }
+
+// tenantDispatcher records the tenant each notification is dispatched in.
+type tenantDispatcher map[domain.NotificationID]string
+
+func (d tenantDispatcher) Dispatch(ctx context.Context, n *domain.Notification) error {
+	d[n.ID()] = requestcontext.TenantID(ctx)
+	return nil
+}
+
+// TestRetryDueNotificationsHandler_Handle_EveryTenant checks that the due
+// notifications of every tenant are retried, each in its own tenant.
+func TestRetryDueNotificationsHandler_Handle_EveryTenant(t *testing.T) {
+	now := time.Now()
+	recipient, err := domain.ParseRecipientID(uuid.New().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	retrying := func(id domain.NotificationID) *domain.Notification {
+		return domain.ReconstituteNotification(id, recipient, domain.ChannelEmail, "welcome", nil, "",
+			domain.DeliveryRetrying, "timeout", "", "", 1, now.Add(-time.Minute), now.Add(-time.Hour), time.Time{})
+	}
+	defaultID := domain.NotificationIDFor(uuid.New().String(), "welcome")
+	acmeID := domain.NotificationIDFor(uuid.New().String(), "welcome")
+
+	log := &tenantLog{
+		due: []domain.DueRetry{{ID: defaultID, TenantID: ""}, {ID: acmeID, TenantID: "acme"}},
+		byID: map[string]*domain.Notification{
+			"/" + defaultID.String():  retrying(defaultID),
+			"acme/" + acmeID.String(): retrying(acmeID),
+		},
+		savedIn: map[domain.NotificationID]string{},
+	}
+	dispatched := tenantDispatcher{}
+	handler := commands.NewRetryDueNotificationsHandler(log, direct{}, dispatched, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	retried, err := handler.Handle(t.Context(), commands.RetryDueNotificationsCommand{Now: now, BatchSize: 10, Lease: time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if retried != 2 {
+		t.Errorf("retried = %d, want 2", retried)
+	}
+	if log.savedIn[acmeID] != "acme" || dispatched[acmeID] != "acme" {
+		t.Errorf("acme notification claimed in tenant %q and dispatched in %q, want acme", log.savedIn[acmeID], dispatched[acmeID])
+	}
+	if log.savedIn[defaultID] != "" || dispatched[defaultID] != "" {
+		t.Errorf("default notification claimed in tenant %q and dispatched in %q, want the default tenant", log.savedIn[defaultID], dispatched[defaultID])
+	}
+}
@@ -0,0 +1,65 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// DeliveryRecorder writes the outcome of a delivery attempt to the notification log.
+// Post-commit handlers run outside any transaction, so each write opens its own.
+type DeliveryRecorder struct {
+	repo    domain.NotificationRepository
+	txScope transaction.Scope
+	policy  domain.RetryPolicy
+	logger  *slog.Logger
+}
+
+func NewDeliveryRecorder(repo domain.NotificationRepository, txScope transaction.Scope, policy domain.RetryPolicy, logger *slog.Logger) *DeliveryRecorder {
+	return &DeliveryRecorder{repo: repo, txScope: txScope, policy: policy, logger: logger}
+}
+
+// Record marks n as sent with messageID, or as failed if sendErr is non-nil,
+// and persists it.
+func (r *DeliveryRecorder) Record(ctx context.Context, n *domain.Notification, messageID string, sendErr error) error {
+	if sendErr != nil {
+		n.MarkFailed(sendErr)
+	} else {
+		n.MarkSent(messageID)
+	}
+	return r.Save(ctx, n)
+}
+
+// ScheduleRetry records a transient delivery failure and queues n for another
+// attempt, or marks it failed once the retry policy is exhausted.
+func (r *DeliveryRecorder) ScheduleRetry(ctx context.Context, n *domain.Notification, cause error) error {
+	if n.ScheduleRetry(cause, r.policy, time.Now()) {
+		r.logger.WarnContext(ctx, "notification delivery failed, retry scheduled",
+			slog.String("notification_id", n.ID().String()),
+			slog.Int("attempts", n.Attempts()),
+			slog.Time("next_attempt_at", n.NextAttemptAt()),
+			slog.Any("error", cause),
+		)
+	} else {
+		r.logger.ErrorContext(ctx, "notification delivery failed, giving up",
+			slog.String("notification_id", n.ID().String()),
+			slog.Int("attempts", n.Attempts()),
+			slog.Any("error", cause),
+		)
+	}
+	return r.Save(ctx, n)
+}
+
+// Save persists n in its current state.
+func (r *DeliveryRecorder) Save(ctx context.Context, n *domain.Notification) error {
+	return r.txScope.Execute(ctx, func(ctx context.Context) error {
+		if err := r.repo.Save(ctx, n); err != nil {
+			return fmt.Errorf("recording notification: %w", err)
+		}
+		return nil
+	})
+}
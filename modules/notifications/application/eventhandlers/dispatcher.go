@@ -0,0 +1,76 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+)
+
+// Dispatcher delivers a pending notification end to end: it honors recipient
+// preferences, resolves the contact address (unless the notification carries
+// its own), renders the template, sends the
+// message, and records the outcome in the notification log.
+type Dispatcher struct {
+	sender    *NotificationSender
+	recorder  *DeliveryRecorder
+	contacts  domain.ContactDirectory
+	prefs     domain.PreferencesRepository
+	templates domain.TemplateRenderer
+}
+
+func NewDispatcher(
+	sender *NotificationSender,
+	recorder *DeliveryRecorder,
+	contacts domain.ContactDirectory,
+	prefs domain.PreferencesRepository,
+	templates domain.TemplateRenderer,
+) *Dispatcher {
+	return &Dispatcher{
+		sender:    sender,
+		recorder:  recorder,
+		contacts:  contacts,
+		prefs:     prefs,
+		templates: templates,
+	}
+}
+
+// Dispatch delivers n. Suppressed and undeliverable notifications are logged
+// and return nil, since retrying them would not change the outcome.
+// Transient failures, such as a provider outage, queue n for retry and also
+// return nil, so they never fail the triggering event handler.
+// Dispatch returns an error only if the outcome cannot be recorded.
+func (d *Dispatcher) Dispatch(ctx context.Context, n *domain.Notification) error {
+	prefs, err := d.prefs.FindByRecipient(ctx, n.Recipient())
+	if err != nil {
+		return d.recorder.ScheduleRetry(ctx, n, fmt.Errorf("loading recipient preferences: %w", err))
+	}
+	if !prefs.Allows(n.Channel()) {
+		n.MarkSuppressed("recipient opted out of " + n.Channel().String() + " notifications")
+		return d.recorder.Save(ctx, n)
+	}
+
+	contact, err := d.contacts.Contact(ctx, n.Recipient())
+	if err != nil {
+		return d.recorder.ScheduleRetry(ctx, n, fmt.Errorf("looking up recipient contact: %w", err))
+	}
+	to := n.Address()
+	if to == "" {
+		to = contact.Email
+	}
+	if to == "" {
+		return d.recorder.Record(ctx, n, "", domain.ErrNoContactAddress)
+	}
+
+	msg, err := d.templates.Render(n.Template(), contact.Locale, n.Payload())
+	if err != nil {
+		return d.recorder.Record(ctx, n, "", err)
+	}
+	msg.To = to
+
+	messageID, err := d.sender.Send(ctx, n, msg)
+	if err != nil {
+		return d.recorder.ScheduleRetry(ctx, n, err)
+	}
+	return d.recorder.Record(ctx, n, messageID, nil)
+}
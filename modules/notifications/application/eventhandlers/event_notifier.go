@@ -0,0 +1,61 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// EventNotifier turns one event type into a notification as declared by its
+// NotificationMapping and hands it to the Dispatcher.
+// Sending is an external side effect: within a database transaction it is
+// deferred with transaction.AfterCommit until the transaction commits.
+type EventNotifier struct {
+	eventType  events.EventType
+	mapping    NotificationMapping
+	dispatcher *Dispatcher
+}
+
+func NewEventNotifier(eventType events.EventType, mapping NotificationMapping, dispatcher *Dispatcher) *EventNotifier {
+	return &EventNotifier{
+		eventType:  eventType,
+		mapping:    mapping,
+		dispatcher: dispatcher,
+	}
+}
+
+func (h *EventNotifier) HandlerName() string         { return h.mapping.HandlerName }
+func (h *EventNotifier) Subdomain() string           { return "notifications" }
+func (h *EventNotifier) EventType() events.EventType { return h.eventType }
+
+func (h *EventNotifier) Handle(ctx context.Context, event events.Event) error {
+	recipientID, payload, err := h.mapping.Build(event)
+	if err != nil {
+		return err
+	}
+	if recipientID == "" {
+		return nil
+	}
+
+	recipient, err := domain.ParseRecipientID(recipientID)
+	if err != nil {
+		return fmt.Errorf("parsing recipient: %w", err)
+	}
+
+	n := domain.NewNotification(
+		domain.NotificationIDFor(event.EventID(), h.mapping.Template),
+		recipient,
+		h.mapping.Channel,
+		h.mapping.Template,
+		payload,
+	)
+	if h.mapping.Address != nil {
+		n.SendTo(h.mapping.Address(event))
+	}
+	return transaction.AfterCommit(ctx, "dispatch-notification", func(ctx context.Context) error {
+		return h.dispatcher.Dispatch(ctx, n)
+	})
+}
@@ -0,0 +1,124 @@
+package eventhandlers_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/application/eventhandlers"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/infrastructure/templates"
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+)
+
+// recordingChannel is an email channel that keeps the messages it sends.
+type recordingChannel struct{ sent []domain.Message }
+
+func (c *recordingChannel) Kind() domain.ChannelKind { return domain.ChannelEmail }
+
+func (c *recordingChannel) Send(ctx context.Context, msg domain.Message) (string, error) {
+	c.sent = append(c.sent, msg)
+	return "msg-1", nil
+}
+
+// notificationLog is a domain.NotificationRepository that keeps the last
+// saved notification.
+type notificationLog struct{ saved *domain.Notification }
+
+func (l *notificationLog) Save(ctx context.Context, n *domain.Notification) error {
+	l.saved = n
+	return nil
+}
+
+func (l *notificationLog) FindByRecipient(context.Context, domain.RecipientID, int, int) ([]*domain.Notification, int, error) {
+	return nil, 0, nil
+}
+
+func (l *notificationLog) FindByStatus(context.Context, domain.DeliveryStatus, int, int) ([]*domain.Notification, int, error) {
+	return nil, 0, nil
+}
+
+func (l *notificationLog) FindByID(context.Context, domain.NotificationID) (*domain.Notification, error) {
+	return nil, domain.ErrNotificationNotFound
+}
+
+func (l *notificationLog) FindDueRetries(context.Context, time.Time, int) ([]domain.DueRetry, error) {
+	return nil, nil
+}
+
+// allowAll is a domain.PreferencesRepository without recorded preferences.
+type allowAll struct{}
+
+func (allowAll) Save(context.Context, domain.RecipientPreferences) error { return nil }
+
+func (allowAll) FindByRecipient(ctx context.Context, recipient domain.RecipientID) (domain.RecipientPreferences, error) {
+	return domain.RecipientPreferences{}, nil
+}
+
+// direct runs fn without a transaction.
+type direct struct{}
+
+func (direct) Execute(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }
+
+func TestEventNotifier_EmailChangeGoesToNewAddress(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	channel := &recordingChannel{}
+	sender, cleanup := eventhandlers.NewNotificationSender([]domain.Channel{channel}, logger)
+	t.Cleanup(cleanup)
+	log := &notificationLog{}
+	contacts := domain.ContactDirectoryFunc(func(ctx context.Context, recipient domain.RecipientID) (domain.Contact, error) {
+		return domain.Contact{Email: "old@example.com", Locale: "en"}, nil
+	})
+	dispatcher := eventhandlers.NewDispatcher(sender, eventhandlers.NewDeliveryRecorder(log, direct{}, domain.DefaultRetryPolicy, logger), contacts, allowAll{}, templates.Default())
+
+	eventType := userevents.EmailChangeRequestedEventType
+	notifier := eventhandlers.NewEventNotifier(eventType, eventhandlers.NotificationMappings[eventType], dispatcher)
+	err := notifier.Handle(t.Context(), userevents.EmailChangeRequestedEvent{
+		BaseEvent: events.NewBaseEvent(eventType),
+		UserID:    uuid.New().String(),
+		NewEmail:  "new@example.com",
+		Token:     "a.b.c",
+	})
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+
+	if len(channel.sent) != 1 || channel.sent[0].To != "new@example.com" {
+		t.Fatalf("sent = %+v, want one message to new@example.com", channel.sent)
+	}
+	if log.saved == nil || log.saved.Address() != "new@example.com" || log.saved.Status() != domain.DeliverySent {
+		t.Errorf("logged notification = %+v, want it sent to new@example.com", log.saved)
+	}
+}
+
+// TestEventNotifier_GuestOrderNotifiesNoOne cancels a guest order, which has
+// no user to notify.
+func TestEventNotifier_GuestOrderNotifiesNoOne(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	channel := &recordingChannel{}
+	sender, cleanup := eventhandlers.NewNotificationSender([]domain.Channel{channel}, logger)
+	t.Cleanup(cleanup)
+	contacts := domain.ContactDirectoryFunc(func(ctx context.Context, recipient domain.RecipientID) (domain.Contact, error) {
+		t.Errorf("looked up the contact of %s", recipient)
+		return domain.Contact{}, nil
+	})
+	dispatcher := eventhandlers.NewDispatcher(sender, eventhandlers.NewDeliveryRecorder(&notificationLog{}, direct{}, domain.DefaultRetryPolicy, logger), contacts, allowAll{}, templates.Default())
+
+	eventType := orderevents.OrderCancelledEventType
+	notifier := eventhandlers.NewEventNotifier(eventType, eventhandlers.NotificationMappings[eventType], dispatcher)
+	err := notifier.Handle(t.Context(), orderevents.OrderCancelledEvent{
+		BaseEvent: events.NewBaseEvent(eventType),
+		OrderID:   uuid.New().String(),
+	})
+	if err != nil {
+		t.Fatalf("Handle: %v", err)
+	}
+	if len(channel.sent) != 0 {
+		t.Errorf("sent = %+v, want nothing", channel.sent)
+	}
+}
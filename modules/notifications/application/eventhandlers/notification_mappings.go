@@ -0,0 +1,110 @@
+package eventhandlers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+)
+
+// NotificationMapping declares how an event becomes a notification.
+type NotificationMapping struct {
+	HandlerName string
+	Template    string
+	Channel     domain.ChannelKind
+	// Build extracts the recipient user ID and the template data from the
+	// event. An event about no user, such as the cancellation of a guest
+	// order, has an empty recipient ID and notifies no one.
+	Build func(event events.Event) (recipientID string, payload map[string]string, err error)
+	// Address, if set, returns the address to send to in place of the
+	// recipient's contact address.
+	Address func(event events.Event) string
+}
+
+// NotificationMappings lists every event the module notifies users about.
+// The module subscribes one EventNotifier per entry, post-commit.
+var NotificationMappings = map[events.EventType]NotificationMapping{
+	userevents.UserCreatedEventType: {
+		HandlerName: "WelcomeNotifier",
+		Template:    "welcome",
+		Channel:     domain.ChannelEmail,
+		Build: func(event events.Event) (string, map[string]string, error) {
+			e, ok := event.(userevents.UserCreatedEvent)
+			if !ok {
+				return "", nil, fmt.Errorf("unexpected event type: %T", event)
+			}
+			return e.UserID, map[string]string{
+				"first_name": e.FirstName,
+			}, nil
+		},
+	},
+	userevents.EmailChangeRequestedEventType: {
+		HandlerName: "EmailChangeNotifier",
+		Template:    "email_change",
+		Channel:     domain.ChannelEmail,
+		Build: func(event events.Event) (string, map[string]string, error) {
+			e, ok := event.(userevents.EmailChangeRequestedEvent)
+			if !ok {
+				return "", nil, fmt.Errorf("unexpected event type: %T", event)
+			}
+			return e.UserID, map[string]string{
+				"new_email": e.NewEmail,
+				"token":     e.Token,
+			}, nil
+		},
+		// The token proves the new address is the user's: it is sent there,
+		// not to the current one.
+		Address: func(event events.Event) string {
+			e, _ := event.(userevents.EmailChangeRequestedEvent)
+			return e.NewEmail
+		},
+	},
+	userevents.PasswordResetRequestedEventType: {
+		HandlerName: "PasswordResetNotifier",
+		Template:    "password_reset",
+		Channel:     domain.ChannelEmail,
+		Build: func(event events.Event) (string, map[string]string, error) {
+			e, ok := event.(userevents.PasswordResetRequestedEvent)
+			if !ok {
+				return "", nil, fmt.Errorf("unexpected event type: %T", event)
+			}
+			return e.UserID, map[string]string{
+				"token": e.Token,
+			}, nil
+		},
+	},
+	orderevents.OrderSubmittedEventType: {
+		HandlerName: "OrderConfirmationNotifier",
+		Template:    "order_confirmation",
+		Channel:     domain.ChannelEmail,
+		Build: func(event events.Event) (string, map[string]string, error) {
+			e, ok := event.(orderevents.OrderSubmittedEvent)
+			if !ok {
+				return "", nil, fmt.Errorf("unexpected event type: %T", event)
+			}
+			return e.UserID, map[string]string{
+				"order_id":     e.OrderID,
+				"total_amount": strconv.FormatInt(e.TotalAmount, 10),
+				"currency":     e.Currency,
+			}, nil
+		},
+	},
+	orderevents.OrderCancelledEventType: {
+		HandlerName: "OrderCancellationNotifier",
+		Template:    "order_cancelled",
+		Channel:     domain.ChannelEmail,
+		Build: func(event events.Event) (string, map[string]string, error) {
+			e, ok := event.(orderevents.OrderCancelledEvent)
+			if !ok {
+				return "", nil, fmt.Errorf("unexpected event type: %T", event)
+			}
+			return e.UserID, map[string]string{
+				"order_id": e.OrderID,
+				"reason":   e.Reason,
+			}, nil
+		},
+	},
+}
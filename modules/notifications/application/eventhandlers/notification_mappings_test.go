@@ -0,0 +1,72 @@
+package eventhandlers_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/application/eventhandlers"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/infrastructure/templates"
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+)
+
+// TestNotificationMappings_RenderInEveryLocale checks that each mapping builds
+// a payload its template can render in every shipped locale.
+func TestNotificationMappings_RenderInEveryLocale(t *testing.T) {
+	userID := uuid.New().String()
+	samples := map[events.EventType]events.Event{
+		userevents.UserCreatedEventType: userevents.UserCreatedEvent{
+			BaseEvent: events.NewBaseEvent(userevents.UserCreatedEventType),
+			UserID:    userID,
+			FirstName: "Jane",
+		},
+		userevents.EmailChangeRequestedEventType: userevents.EmailChangeRequestedEvent{
+			BaseEvent: events.NewBaseEvent(userevents.EmailChangeRequestedEventType),
+			UserID:    userID,
+			NewEmail:  "jane@example.com",
+			Token:     "a.b.c",
+		},
+		userevents.PasswordResetRequestedEventType: userevents.PasswordResetRequestedEvent{
+			BaseEvent: events.NewBaseEvent(userevents.PasswordResetRequestedEventType),
+			UserID:    userID,
+			Token:     "a.b.c",
+		},
+		orderevents.OrderSubmittedEventType: orderevents.OrderSubmittedEvent{
+			BaseEvent:   events.NewBaseEvent(orderevents.OrderSubmittedEventType),
+			OrderID:     uuid.New().String(),
+			UserID:      userID,
+			TotalAmount: 1200,
+			Currency:    "JPY",
+		},
+		orderevents.OrderCancelledEventType: orderevents.OrderCancelledEvent{
+			BaseEvent: events.NewBaseEvent(orderevents.OrderCancelledEventType),
+			OrderID:   uuid.New().String(),
+			UserID:    userID,
+		},
+	}
+
+	renderer := templates.Default()
+	for eventType, mapping := range eventhandlers.NotificationMappings {
+		sample, ok := samples[eventType]
+		if !ok {
+			t.Errorf("no sample event for %s", eventType)
+			continue
+		}
+
+		recipientID, payload, err := mapping.Build(sample)
+		if err != nil {
+			t.Errorf("%s: Build: %v", eventType, err)
+			continue
+		}
+		if recipientID != userID {
+			t.Errorf("%s: recipient = %q, want %q", eventType, recipientID, userID)
+		}
+
+		for _, locale := range []string{"en", "ja"} {
+			if _, err := renderer.Render(mapping.Template, locale, payload); err != nil {
+				t.Errorf("%s: Render(%s, %s): %v", eventType, mapping.Template, locale, err)
+			}
+		}
+	}
+}
@@ -1,42 +1,55 @@
 package eventhandlers
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/idempotent"
 )
 
-// NotificationSender sends notifications via external services.
+// NotificationSender sends notifications via the configured channel providers.
 // It embeds idempotent.OutboundCache so each outbound call is
 // deduplicated, providing at-most-once delivery in post-commit handlers.
 type NotificationSender struct {
 	*idempotent.OutboundCache
-	logger *slog.Logger
+	channels map[domain.ChannelKind]domain.Channel
+	logger   *slog.Logger
 }
 
-func NewNotificationSender(logger *slog.Logger) (_ *NotificationSender, cleanup func()) {
+func NewNotificationSender(channels []domain.Channel, logger *slog.Logger) (_ *NotificationSender, cleanup func()) {
 	cache, cleanup := idempotent.NewOutboundCache()
+	byKind := make(map[domain.ChannelKind]domain.Channel, len(channels))
+	for _, ch := range channels {
+		byKind[ch.Kind()] = ch
+	}
 	return &NotificationSender{
 		OutboundCache: cache,
+		channels:      byKind,
 		logger:        logger,
 	}, cleanup
 }
 
-func (s *NotificationSender) SendOrderConfirmation(orderID string) error {
-	return s.Once("send-confirmation", orderID, func() error {
-		s.logger.Info("sending email to user", slog.String("order_id", orderID), slog.String("action", "order_confirmation"))
-		return nil
-	})
-}
-
-// SendOrderShipped sends a shipment notification and returns the external
-// message ID. On duplicate invocation the cached message ID is returned
-// without re-sending.
-func (s *NotificationSender) SendOrderShipped(orderID, trackingNumber string) (string, error) {
-	return idempotent.OnceResult(s.OutboundCache, "send-shipped", orderID, func() (string, error) {
-		s.logger.Info("sending shipment notification", slog.String("order_id", orderID), slog.String("tracking_number", trackingNumber))
-		// In production this would call an external email/SMS API and return the provider's message ID.
-		messageID := "msg-" + orderID + "-" + trackingNumber
+// Send delivers msg over the notification's channel and returns the
+// provider's message ID. On duplicate invocation for the same notification
+// the cached message ID is returned without re-sending.
+func (s *NotificationSender) Send(ctx context.Context, n *domain.Notification, msg domain.Message) (string, error) {
+	ch, ok := s.channels[n.Channel()]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", domain.ErrChannelNotConfigured, n.Channel())
+	}
+	return idempotent.OnceResult(s.OutboundCache, "send-notification", n.ID().String(), func() (string, error) {
+		messageID, err := ch.Send(ctx, msg)
+		if err != nil {
+			return "", err
+		}
+		s.logger.InfoContext(ctx, "notification sent",
+			slog.String("notification_id", n.ID().String()),
+			slog.String("channel", n.Channel().String()),
+			slog.String("template", n.Template()),
+			slog.String("message_id", messageID),
+		)
 		return messageID, nil
 	})
 }
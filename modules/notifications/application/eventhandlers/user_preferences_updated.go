@@ -0,0 +1,56 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+)
+
+// UserPreferencesUpdatedHandler keeps the local copy of recipient channel
+// preferences in sync with the users module.
+// It runs pre-commit within the users module's transaction.
+type UserPreferencesUpdatedHandler struct {
+	prefs   domain.PreferencesRepository
+	txScope transaction.Scope
+}
+
+func NewUserPreferencesUpdatedHandler(prefs domain.PreferencesRepository, txScope transaction.Scope) *UserPreferencesUpdatedHandler {
+	return &UserPreferencesUpdatedHandler{prefs: prefs, txScope: txScope}
+}
+
+func (h *UserPreferencesUpdatedHandler) HandlerName() string { return "UserPreferencesUpdatedHandler" }
+func (h *UserPreferencesUpdatedHandler) Subdomain() string   { return "notifications" }
+func (h *UserPreferencesUpdatedHandler) EventType() events.EventType {
+	return userevents.UserPreferencesUpdatedEventType
+}
+
+func (h *UserPreferencesUpdatedHandler) Handle(ctx context.Context, event events.Event) error {
+	e, ok := event.(userevents.UserPreferencesUpdatedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	recipient, err := domain.ParseRecipientID(e.UserID)
+	if err != nil {
+		return fmt.Errorf("parsing recipient: %w", err)
+	}
+
+	prefs := domain.RecipientPreferences{
+		Recipient: recipient,
+		UpdatedAt: e.OccurredAt(),
+	}
+	for _, ch := range e.MutedChannels {
+		prefs.MutedChannels = append(prefs.MutedChannels, domain.ChannelKind(ch))
+	}
+
+	return h.txScope.Execute(ctx, func(ctx context.Context) error {
+		if err := h.prefs.Save(ctx, prefs); err != nil {
+			return fmt.Errorf("saving notification preferences: %w", err)
+		}
+		return nil
+	})
+}
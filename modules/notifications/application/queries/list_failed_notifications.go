@@ -0,0 +1,44 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+)
+
+// ListFailedNotificationsQuery retrieves the notifications whose delivery
+// failed for good, retries exhausted, for operators.
+type ListFailedNotificationsQuery struct {
+	Offset int
+	Limit  int
+}
+
+type ListFailedNotificationsHandler struct {
+	repo domain.NotificationRepository
+}
+
+func NewListFailedNotificationsHandler(repo domain.NotificationRepository) *ListFailedNotificationsHandler {
+	return &ListFailedNotificationsHandler{repo: repo}
+}
+
+func (h *ListFailedNotificationsHandler) Handle(ctx context.Context, query ListFailedNotificationsQuery) (*NotificationListDTO, error) {
+	p := page.NewRequest(query.Offset, query.Limit)
+
+	notifications, total, err := h.repo.FindByStatus(ctx, domain.DeliveryFailed, p.Offset, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]NotificationDTO, len(notifications))
+	for i, n := range notifications {
+		dtos[i] = toNotificationDTO(n)
+	}
+
+	return &NotificationListDTO{
+		Notifications: dtos,
+		TotalCount:    total,
+		Offset:        p.Offset,
+		Limit:         p.Limit,
+	}, nil
+}
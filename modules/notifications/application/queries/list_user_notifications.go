@@ -0,0 +1,98 @@
+// Package queries contains read-side handlers for the notifications module.
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+)
+
+// NotificationDTO is a read model for a notification log entry.
+type NotificationDTO struct {
+	ID                string            `json:"id"`
+	Channel           string            `json:"channel"`
+	Template          string            `json:"template"`
+	Payload           map[string]string `json:"payload"`
+	Status            string            `json:"status"`
+	LastError         string            `json:"last_error,omitempty"`
+	MessageID         string            `json:"message_id,omitempty"`
+	SuppressionReason string            `json:"suppression_reason,omitempty"`
+	Attempts          int               `json:"attempts"`
+	NextAttemptAt     *time.Time        `json:"next_attempt_at,omitempty"`
+	CreatedAt         time.Time         `json:"created_at"`
+	SentAt            *time.Time        `json:"sent_at,omitempty"`
+}
+
+// NotificationListDTO contains a paginated list of notifications.
+type NotificationListDTO struct {
+	Notifications []NotificationDTO `json:"notifications"`
+	TotalCount    int               `json:"total_count"`
+	Offset        int               `json:"offset"`
+	Limit         int               `json:"limit"`
+}
+
+// ListUserNotificationsQuery retrieves the notifications sent to a user.
+type ListUserNotificationsQuery struct {
+	UserID string
+	Offset int
+	Limit  int
+}
+
+type ListUserNotificationsHandler struct {
+	repo domain.NotificationRepository
+}
+
+func NewListUserNotificationsHandler(repo domain.NotificationRepository) *ListUserNotificationsHandler {
+	return &ListUserNotificationsHandler{repo: repo}
+}
+
+func (h *ListUserNotificationsHandler) Handle(ctx context.Context, query ListUserNotificationsQuery) (*NotificationListDTO, error) {
+	recipient, err := domain.ParseRecipientID(query.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	p := page.NewRequest(query.Offset, query.Limit)
+
+	notifications, total, err := h.repo.FindByRecipient(ctx, recipient, p.Offset, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]NotificationDTO, len(notifications))
+	for i, n := range notifications {
+		dtos[i] = toNotificationDTO(n)
+	}
+
+	return &NotificationListDTO{
+		Notifications: dtos,
+		TotalCount:    total,
+		Offset:        p.Offset,
+		Limit:         p.Limit,
+	}, nil
+}
+
+func toNotificationDTO(n *domain.Notification) NotificationDTO {
+	dto := NotificationDTO{
+		ID:                n.ID().String(),
+		Channel:           n.Channel().String(),
+		Template:          n.Template(),
+		Payload:           n.Payload(),
+		Status:            n.Status().String(),
+		LastError:         n.LastError(),
+		MessageID:         n.MessageID(),
+		SuppressionReason: n.SuppressionReason(),
+		Attempts:          n.Attempts(),
+		CreatedAt:         n.CreatedAt(),
+	}
+	if nextAttemptAt := n.NextAttemptAt(); !nextAttemptAt.IsZero() {
+		dto.NextAttemptAt = &nextAttemptAt
+	}
+	if sentAt := n.SentAt(); !sentAt.IsZero() {
+		dto.SentAt = &sentAt
+	}
+	return dto
+}
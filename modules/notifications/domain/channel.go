@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrChannelNotConfigured is returned when no provider is registered for a channel kind.
+	ErrChannelNotConfigured = errors.New("notification channel not configured")
+	// ErrNoContactAddress is returned when the recipient has no address for the channel.
+	ErrNoContactAddress = errors.New("recipient has no contact address")
+)
+
+// Message is a rendered notification ready for delivery.
+type Message struct {
+	To      string // email address, phone number or device token, depending on the channel
+	Subject string // ignored by channels without a subject line
+	Body    string
+}
+
+// Channel is the port for a delivery provider (SMTP, SendGrid, SMS gateway, ...).
+// Implementations live in infrastructure and are selected at composition time.
+type Channel interface {
+	Kind() ChannelKind
+	// Send delivers msg and returns the provider's message ID.
+	Send(ctx context.Context, msg Message) (string, error)
+}
+
+// Contact holds how and in which language to reach a recipient.
+type Contact struct {
+	Email  string // empty if the recipient is unknown or deleted
+	Locale string // empty means the default locale
+}
+
+// ContactDirectory is the notifications module's port for recipient contact
+// details, which are owned by the users module. The module root adapts the
+// users' contracts.UserReader to it, so the domain stays in the
+// notifications' language.
+type ContactDirectory interface {
+	// Contact returns the recipient's contact details, or a zero Contact if unknown.
+	Contact(ctx context.Context, recipient RecipientID) (Contact, error)
+}
+
+// ContactDirectoryFunc adapts an ordinary function to ContactDirectory.
+type ContactDirectoryFunc func(ctx context.Context, recipient RecipientID) (Contact, error)
+
+func (f ContactDirectoryFunc) Contact(ctx context.Context, recipient RecipientID) (Contact, error) {
+	return f(ctx, recipient)
+}
@@ -0,0 +1,201 @@
+// Package domain contains the notification log model.
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidNotificationID indicates the notification ID format is invalid.
+var ErrInvalidNotificationID = errors.New("invalid notification ID format")
+
+// NotificationID identifies a notification log entry.
+type NotificationID struct {
+	value string
+}
+
+// notificationNamespace seeds deterministic notification IDs.
+var notificationNamespace = uuid.MustParse("6f1c4a52-3d0e-4f5b-9a8e-2b7d1c0e9f31")
+
+// NotificationIDFor derives the ID of the notification sent with template in
+// response to the event sourceEventID. Redelivering the same event yields the
+// same ID, so the log entry is overwritten rather than duplicated.
+func NotificationIDFor(sourceEventID, template string) NotificationID {
+	return NotificationID{value: uuid.NewSHA1(notificationNamespace, []byte(sourceEventID+"/"+template)).String()}
+}
+
+func ParseNotificationID(s string) (NotificationID, error) {
+	if _, err := uuid.Parse(s); err != nil {
+		return NotificationID{}, ErrInvalidNotificationID
+	}
+	return NotificationID{value: s}, nil
+}
+
+func (id NotificationID) String() string { return id.value }
+func (id NotificationID) IsZero() bool   { return id.value == "" }
+
+// ChannelKind is the medium a notification is delivered through.
+type ChannelKind string
+
+const (
+	ChannelEmail ChannelKind = "email"
+	ChannelSMS   ChannelKind = "sms"
+	ChannelPush  ChannelKind = "push"
+)
+
+func (c ChannelKind) String() string { return string(c) }
+
+// DeliveryStatus tracks whether a notification reached its provider.
+type DeliveryStatus string
+
+const (
+	DeliveryPending    DeliveryStatus = "pending"
+	DeliverySent       DeliveryStatus = "sent"
+	DeliveryRetrying   DeliveryStatus = "retrying" // queued for another attempt after a provider failure
+	DeliveryFailed     DeliveryStatus = "failed"
+	DeliverySuppressed DeliveryStatus = "suppressed" // not sent because of recipient preferences
+)
+
+func (s DeliveryStatus) String() string { return string(s) }
+
+// Notification is a log entry for a message sent (or attempted) to a user.
+type Notification struct {
+	id        NotificationID
+	recipient RecipientID
+	channel   ChannelKind
+	template  string
+	payload   map[string]string
+	// address overrides the recipient's contact address; empty uses it.
+	address   string
+	status    DeliveryStatus
+	lastError string
+	messageID string // provider message ID; empty until delivered
+	// suppressionReason explains why delivery was skipped; set only when suppressed.
+	suppressionReason string
+	attempts          int       // delivery attempts made so far
+	nextAttemptAt     time.Time // zero unless queued for retry
+	createdAt         time.Time
+	sentAt            time.Time // zero until delivered
+}
+
+// NewNotification creates a pending notification.
+func NewNotification(id NotificationID, recipient RecipientID, channel ChannelKind, template string, payload map[string]string) *Notification {
+	return &Notification{
+		id:        id,
+		recipient: recipient,
+		channel:   channel,
+		template:  template,
+		payload:   payload,
+		status:    DeliveryPending,
+		createdAt: time.Now(),
+	}
+}
+
+// ReconstituteNotification rebuilds a Notification from persistence without validation.
+func ReconstituteNotification(
+	id NotificationID,
+	recipient RecipientID,
+	channel ChannelKind,
+	template string,
+	payload map[string]string,
+	address string,
+	status DeliveryStatus,
+	lastError string,
+	messageID string,
+	suppressionReason string,
+	attempts int,
+	nextAttemptAt time.Time,
+	createdAt, sentAt time.Time,
+) *Notification {
+	return &Notification{
+		id:        id,
+		recipient: recipient,
+		channel:   channel,
+		template:  template,
+		payload:   payload,
+		address:   address,
+		status:    status,
+		lastError: lastError,
+		messageID: messageID,
+		createdAt: createdAt,
+		sentAt:    sentAt,
+
+		suppressionReason: suppressionReason,
+		attempts:          attempts,
+		nextAttemptAt:     nextAttemptAt,
+	}
+}
+
+func (n *Notification) ID() NotificationID         { return n.id }
+func (n *Notification) Recipient() RecipientID     { return n.recipient }
+func (n *Notification) Channel() ChannelKind       { return n.channel }
+func (n *Notification) Template() string           { return n.template }
+func (n *Notification) Payload() map[string]string { return n.payload }
+func (n *Notification) Address() string            { return n.address }
+func (n *Notification) Status() DeliveryStatus     { return n.status }
+func (n *Notification) LastError() string          { return n.lastError }
+func (n *Notification) MessageID() string          { return n.messageID }
+func (n *Notification) SuppressionReason() string  { return n.suppressionReason }
+func (n *Notification) Attempts() int              { return n.attempts }
+func (n *Notification) NextAttemptAt() time.Time   { return n.nextAttemptAt }
+func (n *Notification) CreatedAt() time.Time       { return n.createdAt }
+func (n *Notification) SentAt() time.Time          { return n.sentAt }
+
+// SendTo directs n to address instead of the recipient's contact address,
+// e.g. to the unconfirmed address of an email change.
+func (n *Notification) SendTo(address string) {
+	n.address = address
+}
+
+// MarkSent records successful delivery and the provider's message ID.
+func (n *Notification) MarkSent(messageID string) {
+	n.status = DeliverySent
+	n.lastError = ""
+	n.messageID = messageID
+	n.attempts++
+	n.nextAttemptAt = time.Time{}
+	n.sentAt = time.Now()
+}
+
+// MarkFailed records a delivery attempt that failed permanently and its cause.
+func (n *Notification) MarkFailed(cause error) {
+	n.status = DeliveryFailed
+	n.lastError = cause.Error()
+	n.attempts++
+	n.nextAttemptAt = time.Time{}
+}
+
+// ScheduleRetry records a failed delivery attempt and queues the notification
+// for another one after the policy's backoff. Once policy.MaxAttempts is
+// reached the notification is marked failed instead, and false is returned.
+func (n *Notification) ScheduleRetry(cause error, policy RetryPolicy, now time.Time) bool {
+	if n.attempts+1 >= policy.MaxAttempts {
+		n.MarkFailed(cause)
+		return false
+	}
+	n.status = DeliveryRetrying
+	n.lastError = cause.Error()
+	n.attempts++
+	n.nextAttemptAt = now.Add(policy.Backoff(n.attempts))
+	return true
+}
+
+// ClaimRetry reserves a due retry for the caller by pushing its next attempt
+// lease into the future, so that other workers skip it while it is in flight.
+// If the caller crashes, the notification becomes due again once the lease expires.
+func (n *Notification) ClaimRetry(now time.Time, lease time.Duration) error {
+	if n.status != DeliveryRetrying || n.nextAttemptAt.After(now) {
+		return ErrRetryNotDue
+	}
+	n.nextAttemptAt = now.Add(lease)
+	return nil
+}
+
+// MarkSuppressed records that delivery was skipped and why.
+func (n *Notification) MarkSuppressed(reason string) {
+	n.status = DeliverySuppressed
+	n.suppressionReason = reason
+	n.nextAttemptAt = time.Time{}
+}
@@ -0,0 +1,129 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+)
+
+func TestNotificationIDFor_IsDeterministic(t *testing.T) {
+	eventID := uuid.New().String()
+
+	a := domain.NotificationIDFor(eventID, "order_confirmation")
+	b := domain.NotificationIDFor(eventID, "order_confirmation")
+	if a != b {
+		t.Errorf("expected equal IDs for the same event and template, got %s and %s", a, b)
+	}
+	if c := domain.NotificationIDFor(eventID, "order_shipped"); c == a {
+		t.Error("expected different IDs for different templates")
+	}
+	if _, err := domain.ParseNotificationID(a.String()); err != nil {
+		t.Errorf("derived ID does not parse: %v", err)
+	}
+}
+
+func TestNotification_DeliveryStatus(t *testing.T) {
+	recipient, err := domain.ParseRecipientID(uuid.New().String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	n := domain.NewNotification(domain.NotificationIDFor(uuid.New().String(), "t"), recipient, domain.ChannelEmail, "t", nil)
+	if n.Status() != domain.DeliveryPending {
+		t.Fatalf("expected pending, got %s", n.Status())
+	}
+
+	n.MarkFailed(errors.New("smtp timeout"))
+	if n.Status() != domain.DeliveryFailed || n.LastError() != "smtp timeout" {
+		t.Errorf("unexpected failed state: status=%s error=%q", n.Status(), n.LastError())
+	}
+
+	n.MarkSent("msg-1")
+	if n.Status() != domain.DeliverySent || n.LastError() != "" || n.MessageID() != "msg-1" || n.SentAt().IsZero() {
+		t.Errorf("unexpected sent state: status=%s error=%q sentAt=%v", n.Status(), n.LastError(), n.SentAt())
+	}
+}
+
+func TestRecipientPreferences_Allows(t *testing.T) {
+	var none domain.RecipientPreferences
+	if !none.Allows(domain.ChannelEmail) {
+		t.Error("expected recipients without preferences to allow every channel")
+	}
+
+	prefs := domain.RecipientPreferences{MutedChannels: []domain.ChannelKind{domain.ChannelSMS}}
+	if prefs.Allows(domain.ChannelSMS) {
+		t.Error("expected muted channel to be suppressed")
+	}
+	if !prefs.Allows(domain.ChannelEmail) {
+		t.Error("expected unmuted channel to be allowed")
+	}
+}
+
+func TestNotification_MarkSuppressed(t *testing.T) {
+	recipient, _ := domain.ParseRecipientID(uuid.New().String())
+	n := domain.NewNotification(domain.NotificationIDFor(uuid.New().String(), "t"), recipient, domain.ChannelSMS, "t", nil)
+
+	n.MarkSuppressed("recipient opted out of sms notifications")
+	if n.Status() != domain.DeliverySuppressed || n.SuppressionReason() == "" {
+		t.Errorf("unexpected suppressed state: status=%s reason=%q", n.Status(), n.SuppressionReason())
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := domain.RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{3, 4 * time.Second},
+		{4, 8 * time.Second},
+		{5, 10 * time.Second},
+		{20, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := policy.Backoff(tt.attempts); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestNotification_ScheduleRetry(t *testing.T) {
+	recipient, _ := domain.ParseRecipientID(uuid.New().String())
+	n := domain.NewNotification(domain.NotificationIDFor(uuid.New().String(), "t"), recipient, domain.ChannelEmail, "t", nil)
+	policy := domain.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	now := time.Now()
+	cause := errors.New("provider unavailable")
+
+	if !n.ScheduleRetry(cause, policy, now) {
+		t.Fatal("expected first failure to schedule a retry")
+	}
+	if n.Status() != domain.DeliveryRetrying || n.Attempts() != 1 || !n.NextAttemptAt().Equal(now.Add(time.Minute)) {
+		t.Errorf("unexpected retrying state: status=%s attempts=%d next=%v", n.Status(), n.Attempts(), n.NextAttemptAt())
+	}
+
+	if err := n.ClaimRetry(now, time.Minute); !errors.Is(err, domain.ErrRetryNotDue) {
+		t.Errorf("expected ErrRetryNotDue before the backoff elapses, got %v", err)
+	}
+	due := now.Add(time.Minute)
+	if err := n.ClaimRetry(due, 2*time.Minute); err != nil {
+		t.Fatalf("unexpected error claiming due retry: %v", err)
+	}
+	if err := n.ClaimRetry(due, 2*time.Minute); !errors.Is(err, domain.ErrRetryNotDue) {
+		t.Errorf("expected a claimed retry to be hidden until its lease expires, got %v", err)
+	}
+
+	if !n.ScheduleRetry(cause, policy, due) || !n.NextAttemptAt().Equal(due.Add(2*time.Minute)) {
+		t.Errorf("expected second failure to back off 2m, next=%v", n.NextAttemptAt())
+	}
+	if n.ScheduleRetry(cause, policy, due) {
+		t.Fatal("expected retries to stop at MaxAttempts")
+	}
+	if n.Status() != domain.DeliveryFailed || n.Attempts() != 3 || !n.NextAttemptAt().IsZero() {
+		t.Errorf("unexpected exhausted state: status=%s attempts=%d next=%v", n.Status(), n.Attempts(), n.NextAttemptAt())
+	}
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+)
+
+// RecipientPreferences is the local copy of a user's notification settings,
+// projected from users.UserPreferencesUpdated events so delivery decisions
+// never call into the users module.
+type RecipientPreferences struct {
+	Recipient     RecipientID
+	MutedChannels []ChannelKind
+	UpdatedAt     time.Time // occurrence time of the event the copy was taken from
+}
+
+// Allows reports whether notifications may be delivered over kind.
+// Recipients without recorded preferences allow every channel.
+func (p RecipientPreferences) Allows(kind ChannelKind) bool {
+	return !slices.Contains(p.MutedChannels, kind)
+}
+
+// PreferencesRepository persists the local copy of recipient preferences.
+type PreferencesRepository interface {
+	// Save inserts or replaces the preferences of a recipient.
+	Save(ctx context.Context, prefs RecipientPreferences) error
+	// FindByRecipient returns zero-value preferences (allowing every channel)
+	// if none have been recorded for the recipient.
+	FindByRecipient(ctx context.Context, recipient RecipientID) (RecipientPreferences, error)
+}
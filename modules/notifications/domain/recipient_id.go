@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidRecipientID indicates the recipient user ID format is invalid.
+var ErrInvalidRecipientID = errors.New("invalid recipient ID format")
+
+// RecipientID references the user a notification is addressed to.
+// It is an opaque reference to the users module's identifier.
+type RecipientID struct {
+	value string
+}
+
+func ParseRecipientID(s string) (RecipientID, error) {
+	if _, err := uuid.Parse(s); err != nil {
+		return RecipientID{}, ErrInvalidRecipientID
+	}
+	return RecipientID{value: s}, nil
+}
+
+func (id RecipientID) String() string { return id.value }
+func (id RecipientID) IsZero() bool   { return id.value == "" }
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// NotificationRepository persists the notification log. Every method but
+// FindDueRetries is scoped to the tenant of the request of its context.
+type NotificationRepository interface {
+	// Save inserts or replaces a notification.
+	Save(ctx context.Context, n *Notification) error
+	// FindByRecipient returns a user's notifications, newest first, with the total count.
+	FindByRecipient(ctx context.Context, recipient RecipientID, offset, limit int) ([]*Notification, int, error)
+	// FindByStatus returns the notifications with status, newest first, with
+	// the total count, e.g. the failed ones for operators.
+	FindByStatus(ctx context.Context, status DeliveryStatus, offset, limit int) ([]*Notification, int, error)
+	// FindByID returns ErrNotificationNotFound if the notification does not exist.
+	FindByID(ctx context.Context, id NotificationID) (*Notification, error)
+	// FindDueRetries returns up to limit notifications queued for retry whose
+	// next attempt is due at now, oldest first, of every tenant: the retry
+	// job runs for them all.
+	FindDueRetries(ctx context.Context, now time.Time, limit int) ([]DueRetry, error)
+}
+
+// DueRetry identifies a notification found by FindDueRetries and the tenant
+// it belongs to, which retrying it must be scoped to.
+type DueRetry struct {
+	ID       NotificationID
+	TenantID string
+}
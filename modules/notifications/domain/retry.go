@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+	// ErrRetryNotDue indicates the notification is no longer queued for retry
+	// or its next attempt is not yet due, typically because another worker claimed it.
+	ErrRetryNotDue = errors.New("notification retry is not due")
+)
+
+// RetryPolicy bounds redelivery of notifications whose channel provider failed.
+// The delay before attempt n+1 is BaseDelay * 2^(n-1), capped at MaxDelay.
+type RetryPolicy struct {
+	MaxAttempts int // total delivery attempts, including the first
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy retries five times over roughly half an hour.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 6,
+	BaseDelay:   30 * time.Second,
+	MaxDelay:    15 * time.Minute,
+}
+
+// Backoff returns the delay after the given number of failed attempts.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	return min(delay, p.MaxDelay)
+}
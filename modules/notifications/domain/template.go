@@ -0,0 +1,16 @@
+package domain
+
+import "errors"
+
+// ErrTemplateNotFound is returned when no locale variant of a template exists.
+var ErrTemplateNotFound = errors.New("notification template not found")
+
+// TemplateRenderer renders named notification templates.
+// Each event-to-notification mapping references a template by name and
+// passes the notification payload as data.
+type TemplateRenderer interface {
+	// Render returns the subject and body of template for locale. Implementations
+	// fall back to the base language and then to their default locale when
+	// no exact match exists. The returned Message has no recipient set.
+	Render(template, locale string, data map[string]string) (Message, error)
+}
@@ -0,0 +1,34 @@
+// Package channels provides notification delivery providers.
+package channels
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+)
+
+// NoopChannel logs messages instead of delivering them.
+// It is the default for local development and for channels without a provider.
+type NoopChannel struct {
+	kind   domain.ChannelKind
+	logger *slog.Logger
+}
+
+func NewNoopChannel(kind domain.ChannelKind, logger *slog.Logger) *NoopChannel {
+	return &NoopChannel{kind: kind, logger: logger}
+}
+
+func (c *NoopChannel) Kind() domain.ChannelKind { return c.kind }
+
+func (c *NoopChannel) Send(ctx context.Context, msg domain.Message) (string, error) {
+	messageID := "noop-" + uuid.New().String()
+	c.logger.InfoContext(ctx, "notification not delivered (no-op channel)",
+		slog.String("channel", c.kind.String()),
+		slog.String("subject", msg.Subject),
+		slog.String("message_id", messageID),
+	)
+	return messageID, nil
+}
@@ -0,0 +1,88 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+)
+
+const sendGridEndpoint = "https://api.sendgrid.com/v3/mail/send"
+
+// SendGridConfig configures SendGridChannel.
+type SendGridConfig struct {
+	APIKey string
+	From   string
+}
+
+// SendGridChannel delivers email through the SendGrid v3 Mail Send API.
+type SendGridChannel struct {
+	cfg    SendGridConfig
+	client *http.Client
+}
+
+func NewSendGridChannel(cfg SendGridConfig) *SendGridChannel {
+	return &SendGridChannel{
+		cfg:    cfg,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (c *SendGridChannel) Kind() domain.ChannelKind { return domain.ChannelEmail }
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+// Send delivers msg and returns the X-Message-Id assigned by SendGrid.
+func (c *SendGridChannel) Send(ctx context.Context, msg domain.Message) (string, error) {
+	body, err := json.Marshal(sendGridRequest{
+		Personalizations: []sendGridPersonalization{{To: []sendGridAddress{{Email: msg.To}}}},
+		From:             sendGridAddress{Email: c.cfg.From},
+		Subject:          msg.Subject,
+		Content:          []sendGridContent{{Type: "text/plain", Value: msg.Body}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("sendgrid: encoding request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sendGridEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("sendgrid: building request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.cfg.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("sendgrid: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("sendgrid: unexpected status %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return resp.Header.Get("X-Message-Id"), nil
+}
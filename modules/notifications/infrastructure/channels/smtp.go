@@ -0,0 +1,67 @@
+package channels
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+)
+
+// SMTPConfig configures SMTPChannel. Authentication is skipped when Username is empty.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPChannel delivers email through an SMTP relay.
+type SMTPChannel struct {
+	cfg SMTPConfig
+}
+
+func NewSMTPChannel(cfg SMTPConfig) *SMTPChannel {
+	return &SMTPChannel{cfg: cfg}
+}
+
+func (c *SMTPChannel) Kind() domain.ChannelKind { return domain.ChannelEmail }
+
+// Send delivers msg and returns the generated Message-ID header value.
+// net/smtp does not accept a context, so cancellation is not honored mid-send.
+func (c *SMTPChannel) Send(ctx context.Context, msg domain.Message) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	host := c.cfg.Host
+	messageID := fmt.Sprintf("<%s@%s>", uuid.New().String(), host)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", c.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Message-ID: %s\r\n", messageID)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(msg.Body)
+
+	var auth smtp.Auth
+	if c.cfg.Username != "" {
+		auth = smtp.PlainAuth("", c.cfg.Username, c.cfg.Password, host)
+	}
+
+	addr := net.JoinHostPort(host, strconv.Itoa(c.cfg.Port))
+	if err := smtp.SendMail(addr, auth, c.cfg.From, []string{msg.To}, []byte(b.String())); err != nil {
+		return "", fmt.Errorf("smtp send: %w", err)
+	}
+	return messageID, nil
+}
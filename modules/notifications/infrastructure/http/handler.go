@@ -0,0 +1,64 @@
+// Package http provides HTTP handlers for the notifications module.
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+)
+
+type Handler struct {
+	listNotifications *queries.ListUserNotificationsHandler
+}
+
+// RegisterRoutes registers the notifications module routes to the given mux.
+func RegisterRoutes(mux *http.ServeMux, listNotifications *queries.ListUserNotificationsHandler) {
+	h := &Handler{listNotifications: listNotifications}
+
+	mux.HandleFunc("GET /users/{userId}/notifications", h.handleListUserNotifications)
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) handleListUserNotifications(w http.ResponseWriter, r *http.Request) {
+	p := page.ParseRequest(r.URL.Query())
+
+	query := queries.ListUserNotificationsQuery{
+		UserID: r.PathValue("userId"),
+		Offset: p.Offset,
+		Limit:  p.Limit,
+	}
+
+	result, err := h.listNotifications.Handle(r.Context(), query)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidRecipientID):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
@@ -0,0 +1,16 @@
+package http
+
+import (
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+)
+
+// Operations documents the routes registered by RegisterRoutes.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Pattern: "GET /users/{userId}/notifications", Summary: "List the notifications sent to a user", Query: []openapi.Param{
+			{Name: "offset", Type: "integer"},
+			{Name: "limit", Type: "integer"},
+		}, Response: queries.NotificationListDTO{}},
+	}
+}
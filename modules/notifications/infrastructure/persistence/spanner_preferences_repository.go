@@ -0,0 +1,71 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+// SpannerPreferencesRepository implements PreferencesRepository using Cloud
+// Spanner, keyed by the tenant of the request of its context and the recipient.
+type SpannerPreferencesRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerPreferencesRepository(client *spanner.Client, logger *slog.Logger) *SpannerPreferencesRepository {
+	return &SpannerPreferencesRepository{client: client, logger: logger}
+}
+
+func (r *SpannerPreferencesRepository) Save(ctx context.Context, prefs domain.RecipientPreferences) error {
+	muted := make([]string, len(prefs.MutedChannels))
+	for i, ch := range prefs.MutedChannels {
+		muted[i] = ch.String()
+	}
+
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO NotificationPreferences (TenantID, RecipientID, MutedChannels, UpdatedAt)
+		      VALUES (@tenantID, @recipientID, @mutedChannels, @updatedAt)`,
+		Params: map[string]interface{}{
+			"tenantID":      requestcontext.TenantID(ctx),
+			"recipientID":   prefs.Recipient.String(),
+			"mutedChannels": muted,
+			"updatedAt":     prefs.UpdatedAt,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerPreferencesRepository) FindByRecipient(ctx context.Context, recipient domain.RecipientID) (domain.RecipientPreferences, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (domain.RecipientPreferences, error) {
+		row, err := reader.ReadRow(ctx, "NotificationPreferences", spanner.Key{requestcontext.TenantID(ctx), recipient.String()}, []string{"MutedChannels", "UpdatedAt"})
+		if err != nil {
+			if spanner.ErrCode(err) == codes.NotFound {
+				return domain.RecipientPreferences{Recipient: recipient}, nil
+			}
+			return domain.RecipientPreferences{}, fmt.Errorf("failed to read notification preferences: %w", err)
+		}
+
+		var muted []string
+		var updatedAt time.Time
+		if err := row.Columns(&muted, &updatedAt); err != nil {
+			return domain.RecipientPreferences{}, fmt.Errorf("failed to scan notification preferences: %w", err)
+		}
+
+		prefs := domain.RecipientPreferences{Recipient: recipient, UpdatedAt: updatedAt}
+		for _, ch := range muted {
+			prefs.MutedChannels = append(prefs.MutedChannels, domain.ChannelKind(ch))
+		}
+		return prefs, nil
+	})
+}
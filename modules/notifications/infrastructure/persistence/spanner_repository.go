@@ -0,0 +1,323 @@
+// Package persistence implements repository interfaces for notifications.
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+// SpannerRepository implements NotificationRepository using Cloud Spanner.
+// Notifications carry the tenant of the request that wrote them, and every
+// read but FindDueRetries is scoped to the tenant of its context.
+type SpannerRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerRepository(client *spanner.Client, logger *slog.Logger) *SpannerRepository {
+	return &SpannerRepository{client: client, logger: logger}
+}
+
+// Save upserts a notification. Notification IDs are derived from the source
+// event, so redelivery updates the existing entry.
+func (r *SpannerRepository) Save(ctx context.Context, n *domain.Notification) error {
+	payload := n.Payload()
+	if payload == nil {
+		payload = map[string]string{}
+	}
+
+	var sentAt, nextAttemptAt spanner.NullTime
+	if !n.SentAt().IsZero() {
+		sentAt = spanner.NullTime{Time: n.SentAt(), Valid: true}
+	}
+	if !n.NextAttemptAt().IsZero() {
+		nextAttemptAt = spanner.NullTime{Time: n.NextAttemptAt(), Valid: true}
+	}
+
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO Notifications (NotificationID, TenantID, RecipientID, Channel, Template, Payload, Address, Status, LastError, MessageID, SuppressionReason, Attempts, NextAttemptAt, CreatedAt, SentAt)
+		      VALUES (@notificationID, @tenantID, @recipientID, @channel, @template, @payload, @address, @status, @lastError, @messageID, @suppressionReason, @attempts, @nextAttemptAt, @createdAt, @sentAt)`,
+		Params: map[string]interface{}{
+			"notificationID":    n.ID().String(),
+			"tenantID":          requestcontext.TenantID(ctx),
+			"recipientID":       n.Recipient().String(),
+			"channel":           n.Channel().String(),
+			"template":          n.Template(),
+			"payload":           spanner.NullJSON{Value: payload, Valid: true},
+			"address":           nullString(n.Address()),
+			"status":            n.Status().String(),
+			"lastError":         nullString(n.LastError()),
+			"messageID":         nullString(n.MessageID()),
+			"suppressionReason": nullString(n.SuppressionReason()),
+			"attempts":          int64(n.Attempts()),
+			"nextAttemptAt":     nextAttemptAt,
+			"createdAt":         n.CreatedAt(),
+			"sentAt":            sentAt,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save notification: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerRepository) FindByRecipient(ctx context.Context, recipient domain.RecipientID, offset, limit int) ([]*domain.Notification, int, error) {
+	tenantID := requestcontext.TenantID(ctx)
+	var total int
+	notifications, err := platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.Notification, error) {
+		countIter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT COUNT(*) FROM Notifications WHERE TenantID = @tenantID AND RecipientID = @recipientID`,
+			Params: map[string]interface{}{"tenantID": tenantID, "recipientID": recipient.String()},
+		})
+		defer countIter.Stop()
+
+		var totalCount int64
+		countRow, err := countIter.Next()
+		if err != nil && err != iterator.Done {
+			return nil, fmt.Errorf("failed to count notifications: %w", err)
+		}
+		if countRow != nil {
+			if err := countRow.Columns(&totalCount); err != nil {
+				return nil, fmt.Errorf("failed to scan count: %w", err)
+			}
+		}
+		total = int(totalCount)
+
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT ` + notificationColumns + `
+			      FROM Notifications@{FORCE_INDEX=NotificationsByRecipientCreatedAt}
+			      WHERE TenantID = @tenantID AND RecipientID = @recipientID
+			      ORDER BY CreatedAt DESC
+			      LIMIT @limit OFFSET @offset`,
+			Params: map[string]interface{}{
+				"tenantID":    tenantID,
+				"recipientID": recipient.String(),
+				"limit":       int64(limit),
+				"offset":      int64(offset),
+			},
+		})
+		defer iter.Stop()
+
+		var notifications []*domain.Notification
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query notifications: %w", err)
+			}
+
+			n, err := scanNotification(row)
+			if err != nil {
+				return nil, err
+			}
+			notifications = append(notifications, n)
+		}
+
+		return notifications, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+func (r *SpannerRepository) FindByStatus(ctx context.Context, status domain.DeliveryStatus, offset, limit int) ([]*domain.Notification, int, error) {
+	tenantID := requestcontext.TenantID(ctx)
+	var total int
+	notifications, err := platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.Notification, error) {
+		countIter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT COUNT(*) FROM Notifications@{FORCE_INDEX=NotificationsByTenantStatus} WHERE TenantID = @tenantID AND Status = @status`,
+			Params: map[string]interface{}{"tenantID": tenantID, "status": status.String()},
+		})
+		defer countIter.Stop()
+
+		var totalCount int64
+		countRow, err := countIter.Next()
+		if err != nil && err != iterator.Done {
+			return nil, fmt.Errorf("failed to count notifications: %w", err)
+		}
+		if countRow != nil {
+			if err := countRow.Columns(&totalCount); err != nil {
+				return nil, fmt.Errorf("failed to scan count: %w", err)
+			}
+		}
+		total = int(totalCount)
+
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT ` + notificationColumns + `
+			      FROM Notifications@{FORCE_INDEX=NotificationsByTenantStatus}
+			      WHERE TenantID = @tenantID AND Status = @status
+			      ORDER BY CreatedAt DESC
+			      LIMIT @limit OFFSET @offset`,
+			Params: map[string]interface{}{
+				"tenantID": tenantID,
+				"status":   status.String(),
+				"limit":    int64(limit),
+				"offset":   int64(offset),
+			},
+		})
+		defer iter.Stop()
+
+		var notifications []*domain.Notification
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query notifications: %w", err)
+			}
+
+			n, err := scanNotification(row)
+			if err != nil {
+				return nil, err
+			}
+			notifications = append(notifications, n)
+		}
+
+		return notifications, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, total, nil
+}
+
+func (r *SpannerRepository) FindByID(ctx context.Context, id domain.NotificationID) (*domain.Notification, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (*domain.Notification, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT ` + notificationColumns + ` FROM Notifications WHERE NotificationID = @notificationID AND TenantID = @tenantID`,
+			Params: map[string]interface{}{"notificationID": id.String(), "tenantID": requestcontext.TenantID(ctx)},
+		})
+		defer iter.Stop()
+
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return nil, domain.ErrNotificationNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query notification: %w", err)
+		}
+		return scanNotification(row)
+	})
+}
+
+// FindDueRetries scans the notifications of every tenant.
+func (r *SpannerRepository) FindDueRetries(ctx context.Context, now time.Time, limit int) ([]domain.DueRetry, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]domain.DueRetry, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT NotificationID, TenantID
+			      FROM Notifications@{FORCE_INDEX=NotificationsByStatusNextAttemptAt}
+			      WHERE Status = @status AND NextAttemptAt <= @now
+			      ORDER BY NextAttemptAt
+			      LIMIT @limit`,
+			Params: map[string]interface{}{
+				"status": domain.DeliveryRetrying.String(),
+				"now":    now,
+				"limit":  int64(limit),
+			},
+		})
+		defer iter.Stop()
+
+		var due []domain.DueRetry
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query due retries: %w", err)
+			}
+
+			var id, tenantID string
+			if err := row.Columns(&id, &tenantID); err != nil {
+				return nil, fmt.Errorf("failed to scan notification id: %w", err)
+			}
+			notificationID, err := domain.ParseNotificationID(id)
+			if err != nil {
+				return nil, fmt.Errorf("invalid notification ID in database: %w", err)
+			}
+			due = append(due, domain.DueRetry{ID: notificationID, TenantID: tenantID})
+		}
+		return due, nil
+	})
+}
+
+// notificationColumns lists the columns read by scanNotification, in order.
+const notificationColumns = `NotificationID, RecipientID, Channel, Template, Payload, Address, Status, LastError, MessageID, SuppressionReason, Attempts, NextAttemptAt, CreatedAt, SentAt`
+
+func scanNotification(row *spanner.Row) (*domain.Notification, error) {
+	var id, recipientID, channel, template, status string
+	var payload spanner.NullJSON
+	var address, lastError, messageID, suppressionReason spanner.NullString
+	var attempts int64
+	var createdAt time.Time
+	var nextAttemptAt, sentAt spanner.NullTime
+
+	if err := row.Columns(&id, &recipientID, &channel, &template, &payload, &address, &status, &lastError, &messageID, &suppressionReason, &attempts, &nextAttemptAt, &createdAt, &sentAt); err != nil {
+		return nil, fmt.Errorf("failed to scan notification: %w", err)
+	}
+
+	notificationID, err := domain.ParseNotificationID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notification ID in database: %w", err)
+	}
+	recipient, err := domain.ParseRecipientID(recipientID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient ID in database: %w", err)
+	}
+
+	fields, err := decodePayload(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode notification payload: %w", err)
+	}
+
+	return domain.ReconstituteNotification(
+		notificationID,
+		recipient,
+		domain.ChannelKind(channel),
+		template,
+		fields,
+		address.StringVal,
+		domain.DeliveryStatus(status),
+		lastError.StringVal,
+		messageID.StringVal,
+		suppressionReason.StringVal,
+		int(attempts),
+		nextAttemptAt.Time,
+		createdAt,
+		sentAt.Time,
+	), nil
+}
+
+// nullString maps an empty string to a NULL column value.
+func nullString(s string) spanner.NullString {
+	return spanner.NullString{StringVal: s, Valid: s != ""}
+}
+
+// decodePayload converts a JSON column into the flat string map used by the domain.
+func decodePayload(v spanner.NullJSON) (map[string]string, error) {
+	if !v.Valid {
+		return map[string]string{}, nil
+	}
+	raw, err := json.Marshal(v.Value)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]string{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,81 @@
+// Package scheduler runs periodic background jobs for the notifications module.
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/application/commands"
+)
+
+// RetryJob periodically redelivers notifications queued for retry.
+// It is safe to run on every instance: see RetryDueNotificationsHandler.Handle.
+type RetryJob struct {
+	handler   *commands.RetryDueNotificationsHandler
+	interval  time.Duration
+	batchSize int
+	lease     time.Duration
+	logger    *slog.Logger
+}
+
+func NewRetryJob(handler *commands.RetryDueNotificationsHandler, interval time.Duration, batchSize int, lease time.Duration, logger *slog.Logger) *RetryJob {
+	return &RetryJob{
+		handler:   handler,
+		interval:  interval,
+		batchSize: batchSize,
+		lease:     lease,
+		logger:    logger,
+	}
+}
+
+// Start runs the job in a background goroutine.
+// The first run is delayed by a random fraction of the interval so that
+// instances started together do not scan at the same moment.
+// The returned stop function cancels the job and waits for it to exit.
+func (j *RetryJob) Start() (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		timer := time.NewTimer(rand.N(j.interval))
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+
+			j.runOnce(ctx)
+			timer.Reset(j.interval)
+		}
+	})
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func (j *RetryJob) runOnce(ctx context.Context) {
+	cmd := commands.RetryDueNotificationsCommand{
+		Now:       time.Now().UTC(),
+		BatchSize: j.batchSize,
+		Lease:     j.lease,
+	}
+
+	retried, err := j.handler.Handle(ctx, cmd)
+	if err != nil {
+		if ctx.Err() == nil {
+			j.logger.Error("notification retry run failed", slog.Any("error", err))
+		}
+		return
+	}
+	if retried > 0 {
+		j.logger.Info("retried queued notifications", slog.Int("count", retried))
+	}
+}
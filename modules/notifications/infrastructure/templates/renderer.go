@@ -0,0 +1,106 @@
+// Package templates renders localized notification templates with text/template.
+//
+// Templates are stored as files/<locale>/<name>.tmpl and must define a
+// "subject" and a "body" template. Payload fields are available as {{.field}}.
+package templates
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+)
+
+//go:embed files
+var embedded embed.FS
+
+// DefaultLocale is the locale used when a recipient's locale has no template.
+const DefaultLocale = "en"
+
+// Renderer implements domain.TemplateRenderer.
+type Renderer struct {
+	templates     map[string]map[string]*template.Template // locale -> name -> template
+	defaultLocale string
+}
+
+// Default returns a Renderer over the templates embedded in this package.
+// It panics if the embedded templates fail to parse.
+func Default() *Renderer {
+	sub, err := fs.Sub(embedded, "files")
+	if err != nil {
+		panic(err)
+	}
+	r, err := NewRenderer(sub, DefaultLocale)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// NewRenderer parses every <locale>/<name>.tmpl file in fsys.
+func NewRenderer(fsys fs.FS, defaultLocale string) (*Renderer, error) {
+	r := &Renderer{
+		templates:     map[string]map[string]*template.Template{},
+		defaultLocale: defaultLocale,
+	}
+
+	files, err := fs.Glob(fsys, "*/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range files {
+		locale := path.Dir(file)
+		name := strings.TrimSuffix(path.Base(file), ".tmpl")
+
+		t, err := template.New(name).Option("missingkey=error").ParseFS(fsys, file)
+		if err != nil {
+			return nil, fmt.Errorf("parsing template %s: %w", file, err)
+		}
+		for _, part := range []string{"subject", "body"} {
+			if t.Lookup(part) == nil {
+				return nil, fmt.Errorf("template %s does not define %q", file, part)
+			}
+		}
+
+		if r.templates[locale] == nil {
+			r.templates[locale] = map[string]*template.Template{}
+		}
+		r.templates[locale][name] = t
+	}
+	return r, nil
+}
+
+func (r *Renderer) Render(name, locale string, data map[string]string) (domain.Message, error) {
+	t, ok := r.lookup(name, locale)
+	if !ok {
+		return domain.Message{}, fmt.Errorf("%w: %s", domain.ErrTemplateNotFound, name)
+	}
+
+	var subject, body strings.Builder
+	if err := t.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return domain.Message{}, fmt.Errorf("rendering %s subject: %w", name, err)
+	}
+	if err := t.ExecuteTemplate(&body, "body", data); err != nil {
+		return domain.Message{}, fmt.Errorf("rendering %s body: %w", name, err)
+	}
+	return domain.Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Body:    body.String(),
+	}, nil
+}
+
+// lookup tries the exact locale, then its base language ("en" for "en-US"),
+// then the default locale.
+func (r *Renderer) lookup(name, locale string) (*template.Template, bool) {
+	base, _, _ := strings.Cut(locale, "-")
+	for _, l := range []string{locale, base, r.defaultLocale} {
+		if t, ok := r.templates[l][name]; ok {
+			return t, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,49 @@
+package templates_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/infrastructure/templates"
+)
+
+func TestRenderer_LocaleFallback(t *testing.T) {
+	r := templates.Default()
+	data := map[string]string{"order_id": "o-1", "total_amount": "1200", "currency": "JPY"}
+
+	tests := []struct {
+		locale      string
+		wantSubject string
+	}{
+		{"ja", "ご注文 o-1 を受け付けました"},
+		{"ja-JP", "ご注文 o-1 を受け付けました"},
+		{"en-GB", "Your order o-1 has been submitted"},
+		{"fr", "Your order o-1 has been submitted"},
+		{"", "Your order o-1 has been submitted"},
+	}
+	for _, tt := range tests {
+		msg, err := r.Render("order_confirmation", tt.locale, data)
+		if err != nil {
+			t.Fatalf("Render(%q): unexpected error: %v", tt.locale, err)
+		}
+		if msg.Subject != tt.wantSubject {
+			t.Errorf("Render(%q): subject = %q, want %q", tt.locale, msg.Subject, tt.wantSubject)
+		}
+		if !strings.Contains(msg.Body, "1200 JPY") {
+			t.Errorf("Render(%q): body missing total: %q", tt.locale, msg.Body)
+		}
+	}
+}
+
+func TestRenderer_Errors(t *testing.T) {
+	r := templates.Default()
+
+	if _, err := r.Render("no_such_template", "en", nil); !errors.Is(err, domain.ErrTemplateNotFound) {
+		t.Errorf("expected ErrTemplateNotFound, got %v", err)
+	}
+	if _, err := r.Render("order_confirmation", "en", map[string]string{}); err == nil {
+		t.Error("expected an error for missing payload fields")
+	}
+}
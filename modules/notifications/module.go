@@ -1,34 +1,219 @@
+// Package notifications provides notification delivery and the notification log.
 package notifications
 
 import (
+	"context"
 	"log/slog"
+	"net/http"
+	"slices"
+	"time"
 
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/application/commands"
 	"github.com/rai/clean-modularmonolith-go/modules/notifications/application/eventhandlers"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/infrastructure/channels"
+	httphandler "github.com/rai/clean-modularmonolith-go/modules/notifications/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/infrastructure/scheduler"
+	"github.com/rai/clean-modularmonolith-go/modules/notifications/infrastructure/templates"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/contracts"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/lifecycle"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
 )
 
-// Module represents the notification module entry point.
-type Module struct{}
+// Module is the public API for the notifications bounded context.
+// External communication: HTTP API (RegisterRoutes)
+// Cross-module communication: Domain Events (subscribed internally)
+type Module interface {
+	// RegisterRoutes registers the module's HTTP routes to the given mux.
+	RegisterRoutes(mux *http.ServeMux)
+	// Operations documents the routes for the OpenAPI specification.
+	Operations() []openapi.Operation
+
+	// FailedNotifications returns a page of the notifications whose delivery
+	// failed for good, newest first, and their total number, for operators
+	// (the admin module). limit is capped at 100; zero means 20.
+	FailedNotifications(ctx context.Context, offset, limit int) (notifications []FailedNotification, total int, err error)
+
+	// Start launches the retry job; Shutdown stops it and the sender.
+	lifecycle.Hooks
+}
+
+// FailedNotification is a notification whose delivery failed for good, as
+// returned to other modules.
+type FailedNotification struct {
+	ID        string
+	Channel   string
+	Template  string
+	Attempts  int
+	LastError string
+	CreatedAt time.Time
+}
 
 type Config struct {
+	Repository                domain.NotificationRepository
+	PreferencesRepository     domain.PreferencesRepository
+	Users                     contracts.UserReader
+	TransactionScope          transaction.Scope
+	EventSubscriber           events.Subscriber
 	PostCommitEventSubscriber events.PostCommitSubscriber
 	Logger                    *slog.Logger
+
+	// Channels are the delivery providers, at most one per ChannelKind.
+	// Kinds without a provider fall back to a logging no-op channel.
+	Channels []domain.Channel
+
+	// Templates renders notification content. Defaults to the localized
+	// templates embedded in the module when nil.
+	Templates domain.TemplateRenderer
+
+	Retry RetryConfig
+}
+
+// RetryConfig configures redelivery of notifications whose channel provider failed.
+// Zero fields fall back to domain.DefaultRetryPolicy and the defaults below.
+type RetryConfig struct {
+	MaxAttempts int           // total delivery attempts, including the first
+	BaseDelay   time.Duration // delay before the first retry; doubles after each failure
+	MaxDelay    time.Duration // upper bound on the delay between attempts
+	Interval    time.Duration // how often the worker scans the queue; defaults to 30 seconds
+	BatchSize   int           // maximum notifications retried per run; defaults to 50
+}
+
+// policy returns the retry policy with defaults applied.
+func (c RetryConfig) policy() domain.RetryPolicy {
+	p := domain.DefaultRetryPolicy
+	if c.MaxAttempts > 0 {
+		p.MaxAttempts = c.MaxAttempts
+	}
+	if c.BaseDelay > 0 {
+		p.BaseDelay = c.BaseDelay
+	}
+	if c.MaxDelay > 0 {
+		p.MaxDelay = c.MaxDelay
+	}
+	return p
+}
+
+// retryLease hides a claimed retry from other instances while it is delivered.
+const retryLease = 2 * time.Minute
+
+type module struct {
+	listNotifications *queries.ListUserNotificationsHandler
+	listFailed        *queries.ListFailedNotificationsHandler
+	retries           *scheduler.RetryJob
+	stopRetries       func()
+	stopSender        func()
 }
 
 // New initializes the notification module and subscribes to events.
-// The returned cleanup function releases background resources.
-func New(cfg Config) (_ *Module, cleanup func()) {
+// The retry job runs from Start to Shutdown.
+func New(cfg Config) Module {
 	logger := cfg.Logger.With("module", "notifications")
 
 	// Initialize event handlers
-	sender, cleanup := eventhandlers.NewNotificationSender(logger)
-	orderSubmittedHandler := eventhandlers.NewOrderSubmittedHandler(sender)
+	sender, senderCleanup := eventhandlers.NewNotificationSender(withNoopFallbacks(cfg.Channels, logger), logger)
+	recorder := eventhandlers.NewDeliveryRecorder(cfg.Repository, cfg.TransactionScope, cfg.Retry.policy(), logger)
+	renderer := cfg.Templates
+	if renderer == nil {
+		renderer = templates.Default()
+	}
+	dispatcher := eventhandlers.NewDispatcher(sender, recorder, contactDirectory(cfg.Users), cfg.PreferencesRepository, renderer)
+	preferencesHandler := eventhandlers.NewUserPreferencesUpdatedHandler(cfg.PreferencesRepository, cfg.TransactionScope)
 
-	// Subscribe to events (post-commit: exterSubscribePostCommitnal side effects like email should not be in DB transactions)
-	if err := cfg.PostCommitEventSubscriber.SubscribePostCommit(orderSubmittedHandler.EventType(), orderSubmittedHandler); err != nil {
-		logger.Error("failed to subscribe to order submitted event", slog.Any("error", err))
-		// specific error handling strategy (panic vs log) depends on requirements
+	// Pre-commit: keep the local preference copy consistent with the users module's transaction
+	if err := cfg.EventSubscriber.Subscribe(preferencesHandler.EventType(), preferencesHandler); err != nil {
+		logger.Error("failed to subscribe to user preferences updated event", slog.Any("error", err))
 	}
 
-	return &Module{}, cleanup
+	// Subscribe to events (post-commit: external side effects like email should not be in DB transactions)
+	for eventType, mapping := range eventhandlers.NotificationMappings {
+		notifier := eventhandlers.NewEventNotifier(eventType, mapping, dispatcher)
+		if err := cfg.PostCommitEventSubscriber.SubscribePostCommit(eventType, notifier); err != nil {
+			logger.Error("failed to subscribe notifier", slog.String("event_type", eventType.String()), slog.Any("error", err))
+			// specific error handling strategy (panic vs log) depends on requirements
+		}
+	}
+
+	// Background worker: redeliver notifications queued after provider failures
+	interval := cfg.Retry.Interval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	batchSize := cfg.Retry.BatchSize
+	if batchSize <= 0 {
+		batchSize = 50
+	}
+	retryHandler := commands.NewRetryDueNotificationsHandler(cfg.Repository, cfg.TransactionScope, dispatcher, logger)
+
+	return &module{
+		listNotifications: queries.NewListUserNotificationsHandler(cfg.Repository),
+		listFailed:        queries.NewListFailedNotificationsHandler(cfg.Repository),
+		retries:           scheduler.NewRetryJob(retryHandler, interval, batchSize, retryLease, logger),
+		stopSender:        senderCleanup,
+	}
+}
+
+func (m *module) Start(ctx context.Context) error {
+	m.stopRetries = m.retries.Start()
+	return nil
+}
+
+func (m *module) Shutdown(ctx context.Context) error {
+	return lifecycle.Stop(ctx, m.stopRetries, m.stopSender)
+}
+
+// contactDirectory adapts the users module's contract to the notifications'
+// own port: unknown and deleted users have no contact details.
+func contactDirectory(users contracts.UserReader) domain.ContactDirectory {
+	if users == nil {
+		return nil
+	}
+	return domain.ContactDirectoryFunc(func(ctx context.Context, recipient domain.RecipientID) (domain.Contact, error) {
+		user, ok, err := users.GetUserSummary(ctx, recipient.String())
+		if err != nil || !ok || user.Deleted() {
+			return domain.Contact{}, err
+		}
+		return domain.Contact{Email: user.Email, Locale: user.Locale}, nil
+	})
+}
+
+// withNoopFallbacks adds a no-op channel for every kind without a configured provider.
+func withNoopFallbacks(configured []domain.Channel, logger *slog.Logger) []domain.Channel {
+	result := append([]domain.Channel(nil), configured...)
+	for _, kind := range []domain.ChannelKind{domain.ChannelEmail, domain.ChannelSMS, domain.ChannelPush} {
+		if !slices.ContainsFunc(configured, func(ch domain.Channel) bool { return ch.Kind() == kind }) {
+			result = append(result, channels.NewNoopChannel(kind, logger))
+		}
+	}
+	return result
+}
+
+func (m *module) RegisterRoutes(mux *http.ServeMux) {
+	httphandler.RegisterRoutes(mux, m.listNotifications)
+}
+
+func (m *module) Operations() []openapi.Operation {
+	return httphandler.Operations()
+}
+
+func (m *module) FailedNotifications(ctx context.Context, offset, limit int) ([]FailedNotification, int, error) {
+	list, err := m.listFailed.Handle(ctx, queries.ListFailedNotificationsQuery{Offset: offset, Limit: limit})
+	if err != nil {
+		return nil, 0, err
+	}
+	notifications := make([]FailedNotification, len(list.Notifications))
+	for i, n := range list.Notifications {
+		notifications[i] = FailedNotification{
+			ID:        n.ID,
+			Channel:   n.Channel,
+			Template:  n.Template,
+			Attempts:  n.Attempts,
+			LastError: n.LastError,
+			CreatedAt: n.CreatedAt,
+		}
+	}
+	return notifications, list.TotalCount, nil
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
 )
 
 // AddItemCommand adds an item to an order.
@@ -18,11 +19,17 @@ type AddItemCommand struct {
 }
 
 type AddItemHandler struct {
-	repo domain.OrderRepository
+	repo    domain.OrderRepository
+	txScope transaction.ScopeWithDomainEvent
+	limits  domain.OrderLimits
 }
 
-func NewAddItemHandler(repo domain.OrderRepository) *AddItemHandler {
-	return &AddItemHandler{repo: repo}
+func NewAddItemHandler(repo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent, limits domain.OrderLimits) *AddItemHandler {
+	return &AddItemHandler{
+		repo:    repo,
+		txScope: txScope,
+		limits:  limits,
+	}
 }
 
 func (h *AddItemHandler) Handle(ctx context.Context, cmd AddItemCommand) error {
@@ -31,23 +38,21 @@ func (h *AddItemHandler) Handle(ctx context.Context, cmd AddItemCommand) error {
 		return fmt.Errorf("invalid order ID: %w", err)
 	}
 
-	order, err := h.repo.FindByID(ctx, orderID)
+	currency, err := domain.ParseCurrency(cmd.Currency)
 	if err != nil {
-		return fmt.Errorf("finding order: %w", err)
+		return fmt.Errorf("invalid unit price: %w", err)
 	}
-
-	unitPrice, err := domain.NewMoney(cmd.UnitPrice, cmd.Currency)
+	unitPrice, err := domain.NewMoney(cmd.UnitPrice, currency)
 	if err != nil {
 		return fmt.Errorf("invalid unit price: %w", err)
 	}
 
-	if err := order.AddItem(cmd.ProductID, cmd.ProductName, cmd.Quantity, unitPrice); err != nil {
-		return err
-	}
-
-	if err := h.repo.Save(ctx, order); err != nil {
-		return fmt.Errorf("saving order: %w", err)
-	}
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		order, err := transaction.Load(ctx, "order", orderID, h.repo.FindByID, h.repo.Save)
+		if err != nil {
+			return err
+		}
 
-	return nil
+		return order.AddItem(ctx, h.limits, cmd.ProductID, cmd.ProductName, cmd.Quantity, unitPrice)
+	})
 }
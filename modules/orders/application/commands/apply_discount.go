@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// ApplyDiscountCommand applies a discount code to a draft order.
+type ApplyDiscountCommand struct {
+	OrderID           string
+	Code              string
+	ExpectedUpdatedAt time.Time // see Order.CheckUnchanged; zero skips the check
+}
+
+type ApplyDiscountHandler struct {
+	orderRepo    domain.OrderRepository
+	discountRepo domain.DiscountCodeRepository
+	txScope      transaction.ScopeWithDomainEvent
+}
+
+func NewApplyDiscountHandler(orderRepo domain.OrderRepository, discountRepo domain.DiscountCodeRepository, txScope transaction.ScopeWithDomainEvent) *ApplyDiscountHandler {
+	return &ApplyDiscountHandler{
+		orderRepo:    orderRepo,
+		discountRepo: discountRepo,
+		txScope:      txScope,
+	}
+}
+
+// Handle executes the apply discount use case.
+// Redeeming the code and updating the order happen in the same transaction,
+// so a usage limit can never be exceeded by concurrent requests.
+func (h *ApplyDiscountHandler) Handle(ctx context.Context, cmd ApplyDiscountCommand) error {
+	orderID, err := domain.ParseOrderID(cmd.OrderID)
+	if err != nil {
+		return fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		order, err := transaction.Load(ctx, "order", orderID, h.orderRepo.FindByID, h.orderRepo.Save)
+		if err != nil {
+			return err
+		}
+		if err := order.CheckUnchanged(cmd.ExpectedUpdatedAt); err != nil {
+			return err
+		}
+
+		code, err := transaction.Load(ctx, "discount code", domain.NormalizeDiscountCode(cmd.Code), h.discountRepo.FindByCode, h.discountRepo.Save)
+		if err != nil {
+			return err
+		}
+
+		if err := code.Redeem(ctx); err != nil {
+			return err
+		}
+
+		// Adds OrderDiscountAppliedEvent to ctx
+		return order.ApplyDiscount(ctx, code.Discount())
+	})
+}
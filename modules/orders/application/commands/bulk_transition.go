@@ -0,0 +1,170 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+const (
+	// MaxBulkOrderIDs caps the number of orders in a single bulk request.
+	MaxBulkOrderIDs = 500
+	// bulkChunkSize is the number of orders processed per transaction.
+	bulkChunkSize = 50
+)
+
+// BulkTransitionCommand moves many orders to the same status on behalf of an admin,
+// e.g. mass cancellation after an incident.
+type BulkTransitionCommand struct {
+	OrderIDs []string
+	// To is the target status: cancelled, confirmed or completed.
+	To      string
+	Reason  string // recorded on cancellations
+	AdminID string
+}
+
+// BulkOrderResult reports the outcome for a single order ID. Err is nil on success.
+type BulkOrderResult struct {
+	OrderID string
+	Err     error
+}
+
+type BulkTransitionHandler struct {
+	repo         domain.OrderRepository
+	discountRepo domain.DiscountCodeRepository
+	txScope      transaction.ScopeWithDomainEvent
+}
+
+func NewBulkTransitionHandler(repo domain.OrderRepository, discountRepo domain.DiscountCodeRepository, txScope transaction.ScopeWithDomainEvent) *BulkTransitionHandler {
+	return &BulkTransitionHandler{
+		repo:         repo,
+		discountRepo: discountRepo,
+		txScope:      txScope,
+	}
+}
+
+// Handle applies the transition in chunked transactions and returns one result
+// per distinct order ID, in request order.
+//
+// Business rule violations (unknown order, wrong status, ...) only fail the
+// affected order. Infrastructure errors roll back the whole chunk, and every
+// order in it reports that error; later chunks are still attempted.
+//
+// The discount code uses of the drafts a chunk cancels are released in the
+// chunk's transaction, each code once with the uses of all of them: reads in
+// a transaction do not see its own writes.
+func (h *BulkTransitionHandler) Handle(ctx context.Context, cmd BulkTransitionCommand) ([]BulkOrderResult, error) {
+	to := domain.Status(cmd.To)
+	switch to {
+	case domain.StatusCancelled, domain.StatusConfirmed, domain.StatusCompleted:
+	default:
+		return nil, domain.ErrStatusInvalid
+	}
+
+	ids := dedupeOrderIDs(cmd.OrderIDs)
+	if len(ids) == 0 {
+		return nil, domain.ErrBulkOrderIDsRequired
+	}
+	if len(ids) > MaxBulkOrderIDs {
+		return nil, domain.ErrBulkLimitExceeded
+	}
+
+	actor := domain.AdminActor(cmd.AdminID)
+	reason := strings.TrimSpace(cmd.Reason)
+
+	results := make([]BulkOrderResult, 0, len(ids))
+	for start := 0; start < len(ids); start += bulkChunkSize {
+		chunk := ids[start:min(start+bulkChunkSize, len(ids))]
+		chunkResults := make([]BulkOrderResult, len(chunk))
+
+		err := transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+			released := map[string]int{}
+			for i, rawID := range chunk {
+				chunkResults[i] = BulkOrderResult{OrderID: rawID}
+
+				order, err := h.load(ctx, rawID)
+				if err != nil {
+					if errors.Is(err, domain.ErrOrderNotFound) {
+						chunkResults[i].Err = err
+						continue
+					}
+					return err
+				}
+
+				// Domain methods check their preconditions before raising events,
+				// so a rejected transition leaves nothing to publish.
+				wasDraft := order.Status() == domain.StatusDraft
+				if err := transitionOrder(ctx, order, to, actor, reason); err != nil {
+					chunkResults[i].Err = err
+					continue
+				}
+
+				if err := h.repo.Save(ctx, order); err != nil {
+					return fmt.Errorf("saving order %s: %w", rawID, err)
+				}
+				if code := cancelledDraftDiscount(order, wasDraft); code != "" {
+					released[code]++
+				}
+			}
+
+			for _, code := range slices.Sorted(maps.Keys(released)) {
+				if err := releaseDiscountCode(ctx, h.discountRepo, code, released[code]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			for i := range chunkResults {
+				chunkResults[i] = BulkOrderResult{OrderID: chunk[i], Err: err}
+			}
+		}
+
+		results = append(results, chunkResults...)
+	}
+
+	return results, nil
+}
+
+func (h *BulkTransitionHandler) load(ctx context.Context, rawID string) (*domain.Order, error) {
+	orderID, err := domain.ParseOrderID(rawID)
+	if err != nil {
+		return nil, domain.ErrOrderNotFound
+	}
+	return h.repo.FindByID(ctx, orderID)
+}
+
+func transitionOrder(ctx context.Context, order *domain.Order, to domain.Status, actor domain.Actor, reason string) error {
+	switch to {
+	case domain.StatusCancelled:
+		return order.Cancel(ctx, actor, reason)
+	case domain.StatusConfirmed:
+		return order.Confirm(ctx)
+	default:
+		return order.Complete(ctx)
+	}
+}
+
+// dedupeOrderIDs trims IDs and drops blanks and duplicates, keeping the first occurrence.
+func dedupeOrderIDs(ids []string) []string {
+	seen := make(map[string]struct{}, len(ids))
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
@@ -0,0 +1,196 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/orders/domain/mocks"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	txmocks "github.com/rai/clean-modularmonolith-go/modules/shared/transaction/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// chunkScope expects n transactions, one per chunk, each publishing nothing.
+func chunkScope(ctrl *gomock.Controller, n int) *txmocks.MockScopeWithDomainEvent {
+	scope := txmocks.NewMockScopeWithDomainEvent(ctrl)
+	scope.EXPECT().ExecuteWithPublish(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		_, err := events.CaptureEvents(ctx, fn)
+		return err
+	}).Times(n)
+	return scope
+}
+
+// assertResults checks that got has the order IDs of want, in order, each
+// with an error matching want's, or none.
+func assertResults(t *testing.T, got, want []commands.BulkOrderResult) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d results, want %d: %v", len(got), len(want), got)
+	}
+	for i := range want {
+		if got[i].OrderID != want[i].OrderID || !errors.Is(got[i].Err, want[i].Err) || (got[i].Err == nil) != (want[i].Err == nil) {
+			t.Errorf("result %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBulkTransitionHandler_Handle(t *testing.T) {
+	errSpanner := errors.New("spanner: session expired")
+
+	// drafts returns n new draft orders.
+	drafts := func(t *testing.T, n int) []*domain.Order {
+		orders := make([]*domain.Order, n)
+		for i := range orders {
+			orders[i] = createTestOrder(t)
+		}
+		return orders
+	}
+	ids := func(orders []*domain.Order) []string {
+		out := make([]string, len(orders))
+		for i, o := range orders {
+			out[i] = o.ID().String()
+		}
+		return out
+	}
+
+	tests := []struct {
+		name string
+		// setup returns the command's order IDs and the results it expects,
+		// with the repository expectations set.
+		setup func(t *testing.T, repo *domainmocks.MockOrderRepository) (orderIDs []string, chunks int, want []commands.BulkOrderResult)
+		to    domain.Status
+	}{
+		{
+			name: "duplicate and blank IDs are processed once",
+			to:   domain.StatusCancelled,
+			setup: func(t *testing.T, repo *domainmocks.MockOrderRepository) ([]string, int, []commands.BulkOrderResult) {
+				orders := drafts(t, 2)
+				for _, o := range orders {
+					repo.EXPECT().FindByID(gomock.Any(), o.ID()).Return(o, nil)
+				}
+				repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+				a, b := orders[0].ID().String(), orders[1].ID().String()
+				return []string{a, " " + a, "", b, a, "  "}, 1, []commands.BulkOrderResult{{OrderID: a}, {OrderID: b}}
+			},
+		},
+		{
+			name: "successes and failures are reported per ID in request order",
+			to:   domain.StatusCancelled,
+			setup: func(t *testing.T, repo *domainmocks.MockOrderRepository) ([]string, int, []commands.BulkOrderResult) {
+				orders := drafts(t, 2)
+				cancelled := createTestOrder(t)
+				if _, err := events.CaptureEvents(t.Context(), func(ctx context.Context) error {
+					return cancelled.Cancel(ctx, domain.AdminActor("admin-1"), "")
+				}); err != nil {
+					t.Fatal(err)
+				}
+				missing := domain.NewOrderID(t.Context())
+
+				repo.EXPECT().FindByID(gomock.Any(), orders[0].ID()).Return(orders[0], nil)
+				repo.EXPECT().FindByID(gomock.Any(), missing).Return(nil, domain.ErrOrderNotFound)
+				repo.EXPECT().FindByID(gomock.Any(), cancelled.ID()).Return(cancelled, nil)
+				repo.EXPECT().FindByID(gomock.Any(), orders[1].ID()).Return(orders[1], nil)
+				repo.EXPECT().Save(gomock.Any(), cancelledOrder(orders[0].ID())).Return(nil)
+				repo.EXPECT().Save(gomock.Any(), cancelledOrder(orders[1].ID())).Return(nil)
+
+				return []string{orders[0].ID().String(), missing.String(), "not-an-id", cancelled.ID().String(), orders[1].ID().String()}, 1, []commands.BulkOrderResult{
+					{OrderID: orders[0].ID().String()},
+					{OrderID: missing.String(), Err: domain.ErrOrderNotFound},
+					{OrderID: "not-an-id", Err: domain.ErrOrderNotFound},
+					{OrderID: cancelled.ID().String(), Err: domain.ErrOrderAlreadyCancelled},
+					{OrderID: orders[1].ID().String()},
+				}
+			},
+		},
+		{
+			name: "a chunk boundary: a failed chunk does not fail the one before",
+			to:   domain.StatusCancelled,
+			setup: func(t *testing.T, repo *domainmocks.MockOrderRepository) ([]string, int, []commands.BulkOrderResult) {
+				// One more order than a chunk holds: the last one is a chunk of its own.
+				orders := drafts(t, 51)
+				last := orders[50]
+				for _, o := range orders {
+					repo.EXPECT().FindByID(gomock.Any(), o.ID()).Return(o, nil)
+				}
+				repo.EXPECT().Save(gomock.Any(), gomock.Not(cancelledOrder(last.ID()))).Return(nil).Times(50)
+				repo.EXPECT().Save(gomock.Any(), cancelledOrder(last.ID())).Return(errSpanner)
+
+				want := make([]commands.BulkOrderResult, len(orders))
+				for i, o := range orders {
+					want[i] = commands.BulkOrderResult{OrderID: o.ID().String()}
+				}
+				want[50].Err = errSpanner
+				return ids(orders), 2, want
+			},
+		},
+		{
+			name: "a failed chunk rolls back and fails every order in it",
+			to:   domain.StatusCancelled,
+			setup: func(t *testing.T, repo *domainmocks.MockOrderRepository) ([]string, int, []commands.BulkOrderResult) {
+				orders := drafts(t, 2)
+				repo.EXPECT().FindByID(gomock.Any(), orders[0].ID()).Return(orders[0], nil)
+				repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().FindByID(gomock.Any(), orders[1].ID()).Return(nil, errSpanner)
+
+				return ids(orders), 1, []commands.BulkOrderResult{
+					{OrderID: orders[0].ID().String(), Err: errSpanner},
+					{OrderID: orders[1].ID().String(), Err: errSpanner},
+				}
+			},
+		},
+		{
+			name: "confirm needs a pending order",
+			to:   domain.StatusConfirmed,
+			setup: func(t *testing.T, repo *domainmocks.MockOrderRepository) ([]string, int, []commands.BulkOrderResult) {
+				order := createTestOrder(t)
+				repo.EXPECT().FindByID(gomock.Any(), order.ID()).Return(order, nil)
+				return ids([]*domain.Order{order}), 1, []commands.BulkOrderResult{{OrderID: order.ID().String(), Err: domain.ErrOrderNotPending}}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := domainmocks.NewMockOrderRepository(ctrl)
+			orderIDs, chunks, want := tt.setup(t, repo)
+			handler := commands.NewBulkTransitionHandler(repo, nil, chunkScope(ctrl, chunks))
+
+			results, err := handler.Handle(t.Context(), commands.BulkTransitionCommand{OrderIDs: orderIDs, To: string(tt.to), AdminID: "admin-1"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			assertResults(t, results, want)
+		})
+	}
+}
+
+func TestBulkTransitionHandler_Handle_InvalidCommand(t *testing.T) {
+	tooMany := make([]string, commands.MaxBulkOrderIDs+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprint(i)
+	}
+
+	tests := []struct {
+		name string
+		cmd  commands.BulkTransitionCommand
+		want error
+	}{
+		{"unsupported status", commands.BulkTransitionCommand{OrderIDs: []string{"a"}, To: string(domain.StatusDraft)}, domain.ErrStatusInvalid},
+		{"no IDs", commands.BulkTransitionCommand{To: string(domain.StatusCancelled)}, domain.ErrBulkOrderIDsRequired},
+		{"only blank IDs", commands.BulkTransitionCommand{OrderIDs: []string{"", " "}, To: string(domain.StatusCancelled)}, domain.ErrBulkOrderIDsRequired},
+		{"too many IDs", commands.BulkTransitionCommand{OrderIDs: tooMany, To: string(domain.StatusCancelled)}, domain.ErrBulkLimitExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := commands.NewBulkTransitionHandler(nil, nil, nil)
+
+			if _, err := handler.Handle(t.Context(), tt.cmd); !errors.Is(err, tt.want) {
+				t.Errorf("Handle() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
@@ -11,41 +12,63 @@ import (
 // CancelOrderCommand cancels an order.
 type CancelOrderCommand struct {
 	OrderID string
+	Reason  string
+	// ActorKind is "user" (default), "admin" or "system". A user cancellation
+	// is always attributed to the order owner; ActorID names the admin or process.
+	ActorKind string
+	ActorID   string
 }
 
 type CancelOrderHandler struct {
-	repo    domain.OrderRepository
-	txScope transaction.ScopeWithDomainEvent
+	repo         domain.OrderRepository
+	discountRepo domain.DiscountCodeRepository
+	txScope      transaction.ScopeWithDomainEvent
 }
 
-func NewCancelOrderHandler(repo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent) *CancelOrderHandler {
+func NewCancelOrderHandler(repo domain.OrderRepository, discountRepo domain.DiscountCodeRepository, txScope transaction.ScopeWithDomainEvent) *CancelOrderHandler {
 	return &CancelOrderHandler{
-		repo:    repo,
-		txScope: txScope,
+		repo:         repo,
+		discountRepo: discountRepo,
+		txScope:      txScope,
 	}
 }
 
 // Handle executes the cancel order use case.
+// Cancelling a draft releases the use of its discount code in the same transaction.
 func (h *CancelOrderHandler) Handle(ctx context.Context, cmd CancelOrderCommand) (*domain.Order, error) {
 	orderID, err := domain.ParseOrderID(cmd.OrderID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid order ID: %w", err)
 	}
 
-	return transaction.ExecuteWithPublishResult(ctx, h.txScope, func(ctx context.Context) (*domain.Order, error) {
-		order, err := h.repo.FindByID(ctx, orderID)
+	return transaction.ExecuteUnitOfWorkResult(ctx, h.txScope, func(ctx context.Context) (*domain.Order, error) {
+		order, err := transaction.Load(ctx, "order", orderID, h.repo.FindByID, h.repo.Save)
 		if err != nil {
-			return nil, fmt.Errorf("finding order: %w", err)
+			return nil, err
 		}
 
-		if err := order.Cancel(ctx); err != nil {
+		wasDraft := order.Status() == domain.StatusDraft
+		if err := order.Cancel(ctx, cancelActor(cmd, order), strings.TrimSpace(cmd.Reason)); err != nil {
 			return nil, err
 		}
 
-		if err := h.repo.Save(ctx, order); err != nil {
-			return nil, fmt.Errorf("saving order: %w", err)
+		if code := cancelledDraftDiscount(order, wasDraft); code != "" {
+			if err := releaseDiscountCode(ctx, h.discountRepo, code, 1); err != nil {
+				return nil, err
+			}
 		}
 
 		return order, nil
 	})
 }
+
+func cancelActor(cmd CancelOrderCommand, order *domain.Order) domain.Actor {
+	switch domain.ActorKind(cmd.ActorKind) {
+	case domain.ActorAdmin:
+		return domain.AdminActor(cmd.ActorID)
+	case domain.ActorSystem:
+		return domain.SystemActor(cmd.ActorID)
+	default:
+		return domain.UserActor(order.UserRef())
+	}
+}
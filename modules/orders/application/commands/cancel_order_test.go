@@ -0,0 +1,177 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/orders/domain/mocks"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/chaos"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events/eventstest"
+	eventmocks "github.com/rai/clean-modularmonolith-go/modules/shared/events/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCancelOrderHandler_Handle_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	order := createTestOrder(t)
+
+	repo := domainmocks.NewMockOrderRepository(ctrl)
+	gomock.InOrder(
+		repo.EXPECT().FindByID(gomock.Any(), order.ID()).Return(order, nil),
+		repo.EXPECT().Save(gomock.Any(), cancelledOrder(order.ID())).Return(nil),
+	)
+
+	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewCancelOrderHandler(repo, nil, scope)
+
+	_, err := handler.Handle(t.Context(), commands.CancelOrderCommand{
+		OrderID:   order.ID().String(),
+		Reason:    "  duplicate order ",
+		ActorKind: string(domain.ActorAdmin),
+		ActorID:   "admin-1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var cancelled *orderevents.OrderCancelledEvent
+	for _, evt := range capture.Events {
+		if e, ok := evt.(orderevents.OrderCancelledEvent); ok {
+			cancelled = &e
+		}
+	}
+	if cancelled == nil {
+		t.Fatalf("expected OrderCancelledEvent, got %v", capture.Events)
+	}
+	if cancelled.Reason != "duplicate order" || cancelled.CancelledBy != "admin" || cancelled.CancelledByID != "admin-1" {
+		t.Errorf("unexpected OrderCancelledEvent: %+v", *cancelled)
+	}
+}
+
+// TestCancelOrderHandler_Handle_RetriedAfterAbort runs the command in a
+// transaction that is aborted once, as Spanner may do: the retry must start
+// from the stored order again and the order be cancelled exactly once.
+func TestCancelOrderHandler_Handle_RetriedAfterAbort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	stored := createTestOrder(t)
+	repo := domainmocks.NewMockOrderRepository(ctrl)
+	// Each attempt reads the order as stored: the aborted attempt's changes are rolled back.
+	repo.EXPECT().FindByID(gomock.Any(), stored.ID()).DoAndReturn(func(context.Context, domain.OrderID) (*domain.Order, error) {
+		order := *stored
+		return &order, nil
+	}).Times(2)
+	repo.EXPECT().Save(gomock.Any(), cancelledOrder(stored.ID())).Return(nil).Times(2)
+
+	publisher := eventmocks.NewMockPublisher(ctrl)
+	publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	var committed []events.Event
+	postCommit := eventmocks.NewMockPostCommitPublisher(ctrl)
+	postCommit.EXPECT().PublishPostCommit(gomock.Any(), gomock.Any()).Do(func(_ context.Context, evts []events.Event) { committed = evts })
+
+	txScope := chaos.Scope(directScope{}, chaos.New(chaos.Config{AbortRate: 1}))
+	handler := commands.NewCancelOrderHandler(repo, nil, events.NewScopeWithDomainEvent(txScope, publisher, postCommit))
+
+	if _, err := handler.Handle(t.Context(), commands.CancelOrderCommand{OrderID: stored.ID().String(), ActorKind: string(domain.ActorUser)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var cancelled int
+	for _, evt := range committed {
+		if _, ok := evt.(orderevents.OrderCancelledEvent); ok {
+			cancelled++
+		}
+	}
+	if cancelled != 1 {
+		t.Errorf("expected one OrderCancelledEvent after commit, got %d in %v", cancelled, committed)
+	}
+}
+
+func TestCancelOrderHandler_Handle_InvalidOrderID(t *testing.T) {
+	handler := commands.NewCancelOrderHandler(nil, nil, nil)
+
+	_, err := handler.Handle(t.Context(), commands.CancelOrderCommand{OrderID: "invalid-uuid"})
+
+	if err == nil {
+		t.Fatal("expected error for invalid order ID")
+	}
+}
+
+func TestCancelOrderHandler_Handle_OrderNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	orderID := domain.NewOrderID(t.Context())
+
+	repo := domainmocks.NewMockOrderRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), orderID).Return(nil, domain.ErrOrderNotFound)
+
+	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewCancelOrderHandler(repo, nil, scope)
+
+	_, err := handler.Handle(t.Context(), commands.CancelOrderCommand{OrderID: orderID.String()})
+
+	if !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Errorf("expected ErrOrderNotFound, got %v", err)
+	}
+	if len(capture.Events) != 0 {
+		t.Errorf("expected no events on failure, got %d", len(capture.Events))
+	}
+}
+
+func TestCancelOrderHandler_Handle_TransactionError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	errTx := errors.New("transaction failed")
+	handler := commands.NewCancelOrderHandler(nil, nil, eventstest.NewScopeError(ctrl, errTx))
+
+	_, err := handler.Handle(t.Context(), commands.CancelOrderCommand{OrderID: domain.NewOrderID(t.Context()).String()})
+
+	if !errors.Is(err, errTx) {
+		t.Errorf("expected errTx, got %v", err)
+	}
+}
+
+// --- Matchers ---
+
+// cancelledOrder matches a *domain.Order with the given ID that has been cancelled.
+func cancelledOrder(id domain.OrderID) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		o, ok := x.(*domain.Order)
+		return ok && o.ID() == id && o.Status() == domain.StatusCancelled
+	})
+}
+
+// --- Helper ---
+
+// directScope runs fn without a transaction; a failed fn stands for a rollback.
+type directScope struct{}
+
+func (directScope) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func createTestOrder(t *testing.T) *domain.Order {
+	t.Helper()
+
+	userRef, err := domain.NewUserRef(uuid.NewString())
+	if err != nil {
+		t.Fatalf("failed to create user ref: %v", err)
+	}
+
+	var order *domain.Order
+	_, err = events.CaptureEvents(t.Context(), func(ctx context.Context) error {
+		order, err = domain.NewOrder(ctx, userRef, "USD")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("failed to create order: %v", err)
+	}
+	return order
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// ClaimOrderCommand gives the guest order a token issued by
+// CreateGuestOrderHandler claims to a user.
+type ClaimOrderCommand struct {
+	Token  string
+	UserID string
+}
+
+type ClaimOrderHandler struct {
+	repo    domain.OrderRepository
+	users   domain.UserDirectory
+	txScope transaction.ScopeWithDomainEvent
+	signer  *tokens.Signer
+}
+
+// NewClaimOrderHandler creates a ClaimOrderHandler. users may be nil, in
+// which case the user is not checked.
+func NewClaimOrderHandler(repo domain.OrderRepository, users domain.UserDirectory, txScope transaction.ScopeWithDomainEvent, signer *tokens.Signer) *ClaimOrderHandler {
+	return &ClaimOrderHandler{
+		repo:    repo,
+		users:   users,
+		txScope: txScope,
+		signer:  signer,
+	}
+}
+
+// Handle executes the claim order use case in the tenant the token was
+// issued in. A token that is not valid, or whose order has been claimed
+// since it was issued, fails with an error wrapping tokens.ErrInvalid,
+// tokens.ErrExpired or tokens.ErrWrongPurpose.
+func (h *ClaimOrderHandler) Handle(ctx context.Context, cmd ClaimOrderCommand) error {
+	claims, err := h.signer.Verify(ctx, cmd.Token, ClaimPurpose)
+	if err != nil {
+		return fmt.Errorf("verifying order claim token: %w", err)
+	}
+	ctx = tokens.WithTenant(ctx, claims)
+
+	orderID, err := domain.ParseOrderID(claims.Subject)
+	if err != nil {
+		return fmt.Errorf("order claim token: %w", tokens.ErrInvalid)
+	}
+	userRef, err := domain.NewUserRef(cmd.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		if h.users != nil {
+			exists, err := h.users.UserExists(ctx, userRef)
+			if err != nil {
+				return fmt.Errorf("checking user: %w", err)
+			}
+			if !exists {
+				return domain.ErrUserNotFound
+			}
+		}
+
+		order, err := transaction.Load(ctx, "order", orderID, h.repo.FindByID, h.repo.Save)
+		if err != nil {
+			return err
+		}
+		if !order.IsGuest() {
+			return fmt.Errorf("order claim token already used: %w", tokens.ErrInvalid)
+		}
+
+		// Adds OrderClaimedEvent to ctx
+		return order.Claim(ctx, userRef)
+	})
+}
@@ -0,0 +1,121 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/orders/domain/mocks"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events/eventstest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
+	"go.uber.org/mock/gomock"
+)
+
+func newSigner(t *testing.T) *tokens.Signer {
+	t.Helper()
+	signer, err := tokens.NewSigner([]byte(strings.Repeat("s", tokens.MinSecretLength)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+// createGuestOrder runs CreateGuestOrder in tenant and returns the order it
+// saved and the claim token.
+func createGuestOrder(t *testing.T, signer *tokens.Signer, tenant string) (*domain.Order, string) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	var saved *domain.Order
+	repo := domainmocks.NewMockOrderRepository(ctrl)
+	repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order *domain.Order) error {
+		saved = order
+		return nil
+	})
+	scope, _ := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewCreateGuestOrderHandler(repo, scope, nil, domain.OrderQuotas{}, signer, time.Hour)
+
+	ctx := requestcontext.With(t.Context(), requestcontext.Values{TenantID: tenant})
+	result, err := handler.Handle(ctx, commands.CreateGuestOrderCommand{})
+	if err != nil {
+		t.Fatalf("CreateGuestOrder: unexpected error: %v", err)
+	}
+	if saved == nil || !saved.IsGuest() || result.OrderID != saved.ID().String() || result.ClaimToken == "" {
+		t.Fatalf("CreateGuestOrder = %+v, saved %+v", result, saved)
+	}
+	return saved, result.ClaimToken
+}
+
+// TestClaimOrderHandler_Handle claims a guest order from a request in
+// another tenant: the claim must run in the tenant the token was issued in.
+func TestClaimOrderHandler_Handle(t *testing.T) {
+	signer := newSigner(t)
+	order, token := createGuestOrder(t, signer, "acme")
+
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockOrderRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), order.ID()).DoAndReturn(func(ctx context.Context, _ domain.OrderID) (*domain.Order, error) {
+		if requestcontext.TenantID(ctx) != "acme" {
+			return nil, domain.ErrOrderNotFound
+		}
+		return order, nil
+	}).Times(2)
+	repo.EXPECT().Save(gomock.Any(), order).Return(nil)
+	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewClaimOrderHandler(repo, nil, scope, signer)
+
+	other := requestcontext.With(t.Context(), requestcontext.Values{TenantID: "other"})
+	if err := handler.Handle(other, commands.ClaimOrderCommand{Token: token, UserID: quotaUserID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.IsGuest() || order.UserRef().String() != quotaUserID {
+		t.Errorf("UserRef() = %v, want %s", order.UserRef(), quotaUserID)
+	}
+	if len(capture.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(capture.Events))
+	}
+	if e, ok := capture.Events[0].(domain.OrderClaimedEvent); !ok || e.UserID != quotaUserID {
+		t.Errorf("expected OrderClaimedEvent for %s, got %+v", quotaUserID, capture.Events[0])
+	}
+
+	// The token is refused once the order has been claimed
+	scope, _ = eventstest.NewScopeCaptureEvents(ctrl)
+	handler = commands.NewClaimOrderHandler(repo, nil, scope, signer)
+	if err := handler.Handle(t.Context(), commands.ClaimOrderCommand{Token: token, UserID: quotaUserID}); !errors.Is(err, tokens.ErrInvalid) {
+		t.Errorf("second claim error = %v, want ErrInvalid", err)
+	}
+}
+
+func TestClaimOrderHandler_Handle_WrongPurpose(t *testing.T) {
+	signer := newSigner(t)
+	token, err := signer.Issue(t.Context(), tokens.Claims{Purpose: "users.password_reset", Subject: quotaUserID}, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctrl := gomock.NewController(t)
+	handler := commands.NewClaimOrderHandler(domainmocks.NewMockOrderRepository(ctrl), nil, nil, signer)
+
+	if err := handler.Handle(t.Context(), commands.ClaimOrderCommand{Token: token, UserID: quotaUserID}); !errors.Is(err, tokens.ErrWrongPurpose) {
+		t.Errorf("Handle() error = %v, want ErrWrongPurpose", err)
+	}
+}
+
+func TestGuestOrder_NotConfigured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockOrderRepository(ctrl)
+	scope, _ := eventstest.NewScopeCaptureEvents(ctrl)
+
+	create := commands.NewCreateGuestOrderHandler(repo, scope, nil, domain.OrderQuotas{}, nil, time.Hour)
+	if _, err := create.Handle(t.Context(), commands.CreateGuestOrderCommand{}); !errors.Is(err, tokens.ErrNotConfigured) {
+		t.Errorf("CreateGuestOrder error = %v, want ErrNotConfigured", err)
+	}
+	claim := commands.NewClaimOrderHandler(repo, nil, nil, nil)
+	if err := claim.Handle(t.Context(), commands.ClaimOrderCommand{Token: "x", UserID: quotaUserID}); !errors.Is(err, tokens.ErrNotConfigured) {
+		t.Errorf("ClaimOrder error = %v, want ErrNotConfigured", err)
+	}
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// CreateDiscountCodeCommand creates a new redeemable discount code.
+type CreateDiscountCodeCommand struct {
+	Code      string
+	Kind      string
+	Value     int64     // percentage (1-100) or amount in the smallest currency unit
+	Currency  string    // required for fixed_amount
+	ExpiresAt time.Time // zero means the code never expires
+	MaxUses   int       // zero means unlimited
+}
+
+type CreateDiscountCodeHandler struct {
+	repo    domain.DiscountCodeRepository
+	txScope transaction.ScopeWithDomainEvent
+}
+
+func NewCreateDiscountCodeHandler(repo domain.DiscountCodeRepository, txScope transaction.ScopeWithDomainEvent) *CreateDiscountCodeHandler {
+	return &CreateDiscountCodeHandler{
+		repo:    repo,
+		txScope: txScope,
+	}
+}
+
+// Handle executes the create discount code use case.
+func (h *CreateDiscountCodeHandler) Handle(ctx context.Context, cmd CreateDiscountCodeCommand) (string, error) {
+	code, err := domain.NewDiscountCode(ctx, cmd.Code, domain.DiscountKind(cmd.Kind), cmd.Value, cmd.Currency, cmd.ExpiresAt, cmd.MaxUses)
+	if err != nil {
+		return "", fmt.Errorf("invalid discount code: %w", err)
+	}
+
+	return transaction.ExecuteWithPublishResult(ctx, h.txScope, func(ctx context.Context) (string, error) {
+		if _, err := h.repo.FindByCode(ctx, code.Code()); err == nil {
+			return "", domain.ErrDiscountCodeExists
+		} else if !errors.Is(err, domain.ErrDiscountCodeNotFound) {
+			return "", fmt.Errorf("finding discount code: %w", err)
+		}
+
+		if err := h.repo.Save(ctx, code); err != nil {
+			return "", fmt.Errorf("saving discount code: %w", err)
+		}
+
+		return code.Code(), nil
+	})
+}
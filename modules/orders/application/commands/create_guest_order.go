@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/quota"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// ClaimPurpose is the purpose of the tokens that claim a guest order.
+const ClaimPurpose = "orders.claim"
+
+// CreateGuestOrderCommand creates a draft order without a user, which the
+// guest later claims with the token returned (ClaimOrderCommand).
+type CreateGuestOrderCommand struct {
+	Currency string // ISO 4217 code; defaults to domain.DefaultCurrency
+}
+
+// CreateGuestOrderResult is the new order and the token that claims it.
+type CreateGuestOrderResult struct {
+	OrderID    string
+	ClaimToken string
+}
+
+type CreateGuestOrderHandler struct {
+	repo    domain.OrderRepository
+	txScope transaction.ScopeWithDomainEvent
+	quotas  *quota.Service
+	perDay  quota.Limit
+	signer  *tokens.Signer
+	ttl     time.Duration
+}
+
+// NewCreateGuestOrderHandler creates a handler that issues claim tokens valid
+// for ttl. Guest orders count towards the orders per day, but not the drafts
+// per user. A nil signer fails every request with tokens.ErrNotConfigured.
+func NewCreateGuestOrderHandler(repo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent, quotas *quota.Service, limits domain.OrderQuotas, signer *tokens.Signer, ttl time.Duration) *CreateGuestOrderHandler {
+	return &CreateGuestOrderHandler{
+		repo:    repo,
+		txScope: txScope,
+		quotas:  quotas,
+		perDay:  quota.Limit{Name: "orders.per_tenant_per_day", Max: limits.OrdersPerTenantPerDay, Window: 24 * time.Hour},
+		signer:  signer,
+		ttl:     ttl,
+	}
+}
+
+// Handle executes the create guest order use case.
+func (h *CreateGuestOrderHandler) Handle(ctx context.Context, cmd CreateGuestOrderCommand) (CreateGuestOrderResult, error) {
+	currency := cmd.Currency
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+
+	return transaction.ExecuteUnitOfWorkResult(ctx, h.txScope, func(ctx context.Context) (CreateGuestOrderResult, error) {
+		if err := h.quotas.Consume(ctx, h.perDay, requestcontext.TenantID(ctx)); err != nil {
+			return CreateGuestOrderResult{}, err
+		}
+
+		// Create the order aggregate (adds OrderCreatedEvent to ctx)
+		order, err := domain.NewOrder(ctx, domain.UserRef{}, currency)
+		if err != nil {
+			return CreateGuestOrderResult{}, err
+		}
+
+		// The token is bound to the order being a guest order, so that it
+		// is refused once the order has been claimed
+		token, err := h.signer.Issue(ctx, tokens.Claims{
+			Purpose: ClaimPurpose,
+			Subject: order.ID().String(),
+		}, h.ttl)
+		if err != nil {
+			return CreateGuestOrderResult{}, fmt.Errorf("issuing order claim token: %w", err)
+		}
+		transaction.Add(ctx, "order", order, h.repo.Save)
+
+		return CreateGuestOrderResult{OrderID: order.ID().String(), ClaimToken: token}, nil
+	})
+}
@@ -4,25 +4,44 @@ package commands
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/quota"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
 )
 
 // CreateOrderCommand creates a new order for a user.
 type CreateOrderCommand struct {
-	UserID string
+	// OrderID is optional: a new ID is generated when empty. Fixtures set it
+	// to get deterministic IDs.
+	OrderID  string
+	UserID   string
+	Currency string // ISO 4217 code; defaults to domain.DefaultCurrency
 }
 
 type CreateOrderHandler struct {
 	repo    domain.OrderRepository
+	users   domain.UserDirectory
 	txScope transaction.ScopeWithDomainEvent
+	quotas  *quota.Service
+	perDay  quota.Limit
+	drafts  quota.Limit
 }
 
-func NewCreateOrderHandler(repo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent) *CreateOrderHandler {
+// NewCreateOrderHandler creates a CreateOrderHandler.
+// users may be nil, in which case the user is not checked. The orders per
+// day are counted by quotas; a nil quotas enforces only DraftsPerUser, which
+// is counted from the repository.
+func NewCreateOrderHandler(repo domain.OrderRepository, users domain.UserDirectory, txScope transaction.ScopeWithDomainEvent, quotas *quota.Service, limits domain.OrderQuotas) *CreateOrderHandler {
 	return &CreateOrderHandler{
 		repo:    repo,
+		users:   users,
 		txScope: txScope,
+		quotas:  quotas,
+		perDay:  quota.Limit{Name: "orders.per_tenant_per_day", Max: limits.OrdersPerTenantPerDay, Window: 24 * time.Hour},
+		drafts:  quota.Limit{Name: "orders.drafts_per_user", Max: limits.DraftsPerUser},
 	}
 }
 
@@ -33,14 +52,57 @@ func (h *CreateOrderHandler) Handle(ctx context.Context, cmd CreateOrderCommand)
 		return "", fmt.Errorf("invalid user ID: %w", err)
 	}
 
-	return transaction.ExecuteWithPublishResult(ctx, h.txScope, func(ctx context.Context) (string, error) {
-		// Create the order aggregate (adds OrderCreatedEvent to ctx)
-		order := domain.NewOrder(ctx, userRef)
+	var orderID domain.OrderID // generated in the transaction when not given
+	if cmd.OrderID != "" {
+		if orderID, err = domain.ParseOrderID(cmd.OrderID); err != nil {
+			return "", fmt.Errorf("invalid order ID: %w", err)
+		}
+	}
+
+	currency := cmd.Currency
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+
+	return transaction.ExecuteUnitOfWorkResult(ctx, h.txScope, func(ctx context.Context) (string, error) {
+		// Checked inside the transaction so a concurrent user deletion
+		// either sees this order (and cancels it) or aborts it.
+		if h.users != nil {
+			exists, err := h.users.UserExists(ctx, userRef)
+			if err != nil {
+				return "", fmt.Errorf("checking user: %w", err)
+			}
+			if !exists {
+				return "", domain.ErrUserNotFound
+			}
+		}
 
-		// Persist the order
-		if err := h.repo.Save(ctx, order); err != nil {
-			return "", fmt.Errorf("saving order: %w", err)
+		// Counted in the transaction so that an order that fails to be
+		// created does not use the quotas.
+		if h.drafts.Max > 0 {
+			_, drafts, err := h.repo.FindByUserRefAndStatus(ctx, userRef, domain.StatusDraft, 0, 1)
+			if err != nil {
+				return "", fmt.Errorf("counting drafts: %w", err)
+			}
+			if err := quota.Check(h.drafts, int64(drafts)); err != nil {
+				return "", err
+			}
+		}
+		if err := h.quotas.Consume(ctx, h.perDay, requestcontext.TenantID(ctx)); err != nil {
+			return "", err
+		}
+
+		id := orderID
+		if id.IsZero() {
+			id = domain.NewOrderID(ctx)
+		}
+
+		// Create the order aggregate (adds OrderCreatedEvent to ctx)
+		order, err := domain.NewOrderWithID(ctx, id, userRef, currency)
+		if err != nil {
+			return "", err
 		}
+		transaction.Add(ctx, "order", order, h.repo.Save)
 
 		return order.ID().String(), nil
 	})
@@ -0,0 +1,101 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/orders/domain/mocks"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events/eventstest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/quota"
+	"go.uber.org/mock/gomock"
+)
+
+const quotaUserID = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+func TestCreateOrderHandler_Handle_DraftQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userRef, _ := domain.NewUserRef(quotaUserID)
+
+	repo := domainmocks.NewMockOrderRepository(ctrl)
+	repo.EXPECT().FindByUserRefAndStatus(gomock.Any(), userRef, domain.StatusDraft, 0, 1).Return(nil, 2, nil)
+	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewCreateOrderHandler(repo, nil, scope, nil, domain.OrderQuotas{DraftsPerUser: 2})
+
+	_, err := handler.Handle(t.Context(), commands.CreateOrderCommand{UserID: quotaUserID})
+
+	var exceeded *quota.ExceededError
+	if !errors.As(err, &exceeded) || exceeded.Quota != "orders.drafts_per_user" {
+		t.Fatalf("expected the drafts quota exceeded, got %v", err)
+	}
+	if len(capture.Events) != 0 {
+		t.Errorf("expected no events, got %v", capture.Events)
+	}
+}
+
+func TestCreateOrderHandler_Handle_UnderDraftQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := domainmocks.NewMockOrderRepository(ctrl)
+	repo.EXPECT().FindByUserRefAndStatus(gomock.Any(), gomock.Any(), domain.StatusDraft, 0, 1).Return(nil, 1, nil)
+	repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	scope, _ := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewCreateOrderHandler(repo, nil, scope, nil, domain.OrderQuotas{DraftsPerUser: 2})
+
+	if _, err := handler.Handle(t.Context(), commands.CreateOrderCommand{UserID: quotaUserID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestCreateOrderHandler_Handle_UserDirectory(t *testing.T) {
+	errDirectory := errors.New("users: unavailable")
+
+	tests := []struct {
+		name    string
+		exists  bool
+		err     error
+		wantErr error
+	}{
+		{"existing user", true, nil, nil},
+		{"unknown or deleted user", false, nil, domain.ErrUserNotFound},
+		{"directory error", false, errDirectory, errDirectory},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			userRef, _ := domain.NewUserRef(quotaUserID)
+
+			var checked domain.UserRef
+			users := domain.UserDirectoryFunc(func(ctx context.Context, ref domain.UserRef) (bool, error) {
+				checked = ref
+				return tt.exists, tt.err
+			})
+			repo := domainmocks.NewMockOrderRepository(ctrl)
+			if tt.wantErr == nil {
+				repo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+			}
+			scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+			handler := commands.NewCreateOrderHandler(repo, users, scope, nil, domain.OrderQuotas{})
+
+			_, err := handler.Handle(t.Context(), commands.CreateOrderCommand{UserID: quotaUserID})
+
+			if checked != userRef {
+				t.Errorf("checked user %v, want %v", checked, userRef)
+			}
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+			if len(capture.Events) != 0 {
+				t.Errorf("expected no events, got %v", capture.Events)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/orders/domain/mocks"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events/eventstest"
+	"go.uber.org/mock/gomock"
+)
+
+const discountCode = "SPRING10"
+
+// redeemedCode returns a discount code of 5 uses with uses of them redeemed.
+func redeemedCode(t *testing.T, uses int) *domain.DiscountCode {
+	t.Helper()
+	code, err := domain.NewDiscountCode(t.Context(), discountCode, domain.DiscountPercentage, 10, "", time.Time{}, 5)
+	if err != nil {
+		t.Fatalf("failed to create discount code: %v", err)
+	}
+	for range uses {
+		if err := code.Redeem(t.Context()); err != nil {
+			t.Fatalf("failed to redeem discount code: %v", err)
+		}
+	}
+	return code
+}
+
+// discountedDraft returns a draft order with code applied.
+func discountedDraft(t *testing.T, code *domain.DiscountCode) *domain.Order {
+	t.Helper()
+	order := createTestOrder(t)
+	if _, err := events.CaptureEvents(t.Context(), func(ctx context.Context) error {
+		return order.ApplyDiscount(ctx, code.Discount())
+	}); err != nil {
+		t.Fatalf("failed to apply discount: %v", err)
+	}
+	return order
+}
+
+// usedCount matches a *domain.DiscountCode saved with n uses.
+func usedCount(n int) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		c, ok := x.(*domain.DiscountCode)
+		return ok && c.Code() == discountCode && c.UsedCount() == n
+	})
+}
+
+func TestApplyDiscountHandler_Handle_OrderModified(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	order := createTestOrder(t)
+	orderRepo := domainmocks.NewMockOrderRepository(ctrl)
+	orderRepo.EXPECT().FindByID(gomock.Any(), order.ID()).Return(order, nil)
+	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewApplyDiscountHandler(orderRepo, domainmocks.NewMockDiscountCodeRepository(ctrl), scope)
+
+	err := handler.Handle(t.Context(), commands.ApplyDiscountCommand{
+		OrderID:           order.ID().String(),
+		Code:              discountCode,
+		ExpectedUpdatedAt: order.UpdatedAt().Add(-time.Second),
+	})
+
+	if !errors.Is(err, domain.ErrOrderModified) {
+		t.Errorf("expected ErrOrderModified, got %v", err)
+	}
+	if len(capture.Events) != 0 {
+		t.Errorf("expected no events, got %v", capture.Events)
+	}
+}
+
+func TestCancelOrderHandler_Handle_ReleasesDiscount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	code := redeemedCode(t, 1)
+	order := discountedDraft(t, code)
+
+	orderRepo := domainmocks.NewMockOrderRepository(ctrl)
+	orderRepo.EXPECT().FindByID(gomock.Any(), order.ID()).Return(order, nil)
+	discountRepo := domainmocks.NewMockDiscountCodeRepository(ctrl)
+	discountRepo.EXPECT().FindByCode(gomock.Any(), discountCode).Return(code, nil)
+	gomock.InOrder(
+		orderRepo.EXPECT().Save(gomock.Any(), cancelledOrder(order.ID())).Return(nil),
+		discountRepo.EXPECT().Save(gomock.Any(), usedCount(0)).Return(nil),
+	)
+	scope, _ := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewCancelOrderHandler(orderRepo, discountRepo, scope)
+
+	if _, err := handler.Handle(t.Context(), commands.CancelOrderCommand{OrderID: order.ID().String()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestCancelOrderHandler_Handle_SubmittedKeepsDiscount cancels a submitted
+// order: the discount was part of what it was priced at, and its use stays.
+func TestCancelOrderHandler_Handle_SubmittedKeepsDiscount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	order := discountedDraft(t, redeemedCode(t, 1))
+	if _, err := events.CaptureEvents(t.Context(), func(ctx context.Context) error {
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 1, domain.MustNewMoney(500, "USD")); err != nil {
+			return err
+		}
+		return order.Submit(ctx, nil, domain.FlatRateTaxCalculator{}, domain.OrderLimits{})
+	}); err != nil {
+		t.Fatalf("failed to submit order: %v", err)
+	}
+
+	orderRepo := domainmocks.NewMockOrderRepository(ctrl)
+	orderRepo.EXPECT().FindByID(gomock.Any(), order.ID()).Return(order, nil)
+	orderRepo.EXPECT().Save(gomock.Any(), cancelledOrder(order.ID())).Return(nil)
+	scope, _ := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewCancelOrderHandler(orderRepo, domainmocks.NewMockDiscountCodeRepository(ctrl), scope)
+
+	if _, err := handler.Handle(t.Context(), commands.CancelOrderCommand{OrderID: order.ID().String()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// TestCancelOrderHandler_Handle_ReleaseFails checks that the cancellation
+// and the release succeed or fail together.
+func TestCancelOrderHandler_Handle_ReleaseFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	code := redeemedCode(t, 1)
+	order := discountedDraft(t, code)
+	errSave := errors.New("spanner: aborted")
+
+	orderRepo := domainmocks.NewMockOrderRepository(ctrl)
+	orderRepo.EXPECT().FindByID(gomock.Any(), order.ID()).Return(order, nil)
+	orderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	discountRepo := domainmocks.NewMockDiscountCodeRepository(ctrl)
+	discountRepo.EXPECT().FindByCode(gomock.Any(), discountCode).Return(code, nil)
+	discountRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(errSave)
+	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewCancelOrderHandler(orderRepo, discountRepo, scope)
+
+	if _, err := handler.Handle(t.Context(), commands.CancelOrderCommand{OrderID: order.ID().String()}); !errors.Is(err, errSave) {
+		t.Fatalf("expected errSave, got %v", err)
+	}
+	if len(capture.Events) != 0 {
+		t.Errorf("expected no events on failure, got %v", capture.Events)
+	}
+}
+
+func TestExpireStaleDraftsHandler_Handle_ReleasesDiscount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	code := redeemedCode(t, 1)
+	order := discountedDraft(t, code)
+	cutoff := time.Now().Add(time.Hour)
+
+	orderRepo := domainmocks.NewMockOrderRepository(ctrl)
+	orderRepo.EXPECT().FindStaleDrafts(gomock.Any(), cutoff, 10).Return([]domain.StaleDraft{{ID: order.ID()}}, nil)
+	orderRepo.EXPECT().FindByID(gomock.Any(), order.ID()).Return(order, nil)
+	discountRepo := domainmocks.NewMockDiscountCodeRepository(ctrl)
+	discountRepo.EXPECT().FindByCode(gomock.Any(), discountCode).Return(code, nil)
+	gomock.InOrder(
+		orderRepo.EXPECT().Save(gomock.Any(), cancelledOrder(order.ID())).Return(nil),
+		discountRepo.EXPECT().Save(gomock.Any(), usedCount(0)).Return(nil),
+	)
+	scope, _ := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewExpireStaleDraftsHandler(orderRepo, discountRepo, scope, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	expired, err := handler.Handle(t.Context(), commands.ExpireStaleDraftsCommand{Cutoff: cutoff, BatchSize: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("expired = %d, want 1", expired)
+	}
+}
+
+// TestBulkTransitionHandler_Handle_ReleasesDiscountOnce cancels two drafts
+// with the same code in one chunk: the code is saved once, with both uses
+// released.
+func TestBulkTransitionHandler_Handle_ReleasesDiscountOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	code := redeemedCode(t, 2)
+	first, second := discountedDraft(t, code), discountedDraft(t, code)
+
+	orderRepo := domainmocks.NewMockOrderRepository(ctrl)
+	orderRepo.EXPECT().FindByID(gomock.Any(), first.ID()).Return(first, nil)
+	orderRepo.EXPECT().FindByID(gomock.Any(), second.ID()).Return(second, nil)
+	orderRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	discountRepo := domainmocks.NewMockDiscountCodeRepository(ctrl)
+	discountRepo.EXPECT().FindByCode(gomock.Any(), discountCode).Return(code, nil)
+	discountRepo.EXPECT().Save(gomock.Any(), usedCount(0)).Return(nil)
+	scope, _ := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewBulkTransitionHandler(orderRepo, discountRepo, scope)
+
+	results, err := handler.Handle(t.Context(), commands.BulkTransitionCommand{
+		OrderIDs: []string{first.ID().String(), second.ID().String()},
+		To:       string(domain.StatusCancelled),
+		AdminID:  "admin-1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, r := range results {
+		if r.Err != nil {
+			t.Errorf("order %s: unexpected error: %v", r.OrderID, r.Err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// ExpireStaleDraftsCommand cancels draft orders untouched since Cutoff.
+type ExpireStaleDraftsCommand struct {
+	Cutoff    time.Time
+	BatchSize int
+}
+
+type ExpireStaleDraftsHandler struct {
+	repo         domain.OrderRepository
+	discountRepo domain.DiscountCodeRepository
+	txScope      transaction.ScopeWithDomainEvent
+	logger       *slog.Logger
+}
+
+func NewExpireStaleDraftsHandler(repo domain.OrderRepository, discountRepo domain.DiscountCodeRepository, txScope transaction.ScopeWithDomainEvent, logger *slog.Logger) *ExpireStaleDraftsHandler {
+	return &ExpireStaleDraftsHandler{
+		repo:         repo,
+		discountRepo: discountRepo,
+		txScope:      txScope,
+		logger:       logger,
+	}
+}
+
+// Handle executes the expire stale drafts use case and returns the number of expired orders.
+//
+// The drafts of every tenant are expired, each with the tenant of ctx set to
+// its own, so that it is loaded and saved in its tenant. Each order is expired in its own transaction that re-reads the order and
+// re-checks that it is still a stale draft. Spanner serializes concurrent
+// read-write transactions on the same row, so when several instances run the
+// job at once, only one of them expires a given order; the others observe the
+// new status and skip it. The use of an expired draft's discount code is
+// released in the same transaction.
+func (h *ExpireStaleDraftsHandler) Handle(ctx context.Context, cmd ExpireStaleDraftsCommand) (int, error) {
+	drafts, err := h.repo.FindStaleDrafts(ctx, cmd.Cutoff, cmd.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("finding stale drafts: %w", err)
+	}
+
+	expired := 0
+	for _, draft := range drafts {
+		v := requestcontext.FromContext(ctx)
+		v.TenantID = draft.TenantID
+		err := transaction.ExecuteUnitOfWork(requestcontext.With(ctx, v), h.txScope, func(ctx context.Context) error {
+			order, err := transaction.Load(ctx, "order", draft.ID, h.repo.FindByID, h.repo.Save)
+			if err != nil {
+				return err
+			}
+
+			// Adds OrderStatusChangedEvent and OrderExpiredEvent to ctx
+			if err := order.Expire(ctx, cmd.Cutoff); err != nil {
+				return err
+			}
+
+			if discount := order.Discount(); !discount.IsZero() {
+				return releaseDiscountCode(ctx, h.discountRepo, discount.Code(), 1)
+			}
+			return nil
+		})
+
+		switch {
+		case err == nil:
+			expired++
+		case errors.Is(err, domain.ErrOrderNotDraft),
+			errors.Is(err, domain.ErrOrderNotStale),
+			errors.Is(err, domain.ErrOrderNotFound):
+			// Changed or removed since the scan, possibly by another instance.
+		default:
+			if ctx.Err() != nil {
+				return expired, ctx.Err()
+			}
+			h.logger.Warn("failed to expire draft order",
+				slog.String("order_id", draft.ID.String()),
+				slog.String("tenant_id", draft.TenantID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return expired, nil
+}
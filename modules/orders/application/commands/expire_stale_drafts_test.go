@@ -0,0 +1,69 @@
+package commands_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/orders/domain/mocks"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	txmocks "github.com/rai/clean-modularmonolith-go/modules/shared/transaction/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// TestExpireStaleDraftsHandler_Handle_EveryTenant checks that the drafts of
+// every tenant are expired, each loaded and saved in its own tenant.
+func TestExpireStaleDraftsHandler_Handle_EveryTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	defaultDraft := createTestOrder(t)
+	acmeDraft := createTestOrder(t)
+	cutoff := time.Now().Add(time.Hour)
+
+	repo := domainmocks.NewMockOrderRepository(ctrl)
+	repo.EXPECT().FindStaleDrafts(gomock.Any(), cutoff, 10).Return([]domain.StaleDraft{
+		{ID: defaultDraft.ID(), TenantID: ""},
+		{ID: acmeDraft.ID(), TenantID: "acme"},
+	}, nil)
+
+	tenants := map[domain.OrderID]string{}
+	drafts := map[domain.OrderID]*domain.Order{defaultDraft.ID(): defaultDraft, acmeDraft.ID(): acmeDraft}
+	repo.EXPECT().FindByID(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
+		tenants[id] = requestcontext.TenantID(ctx)
+		return drafts[id], nil
+	}).Times(2)
+	saved := map[domain.OrderID]string{}
+	repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, order *domain.Order) error {
+		saved[order.ID()] = requestcontext.TenantID(ctx)
+		return nil
+	}).Times(2)
+
+	scope := txmocks.NewMockScopeWithDomainEvent(ctrl)
+	scope.EXPECT().ExecuteWithPublish(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		_, err := events.CaptureEvents(ctx, fn)
+		return err
+	}).Times(2)
+	handler := commands.NewExpireStaleDraftsHandler(repo, nil, scope, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	expired, err := handler.Handle(t.Context(), commands.ExpireStaleDraftsCommand{Cutoff: cutoff, BatchSize: 10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expired != 2 {
+		t.Errorf("expired = %d, want 2", expired)
+	}
+	if acmeDraft.Status() != domain.StatusCancelled {
+		t.Errorf("acme draft status = %s, want cancelled", acmeDraft.Status())
+	}
+	if tenants[acmeDraft.ID()] != "acme" || saved[acmeDraft.ID()] != "acme" {
+		t.Errorf("acme draft loaded in tenant %q and saved in %q, want acme", tenants[acmeDraft.ID()], saved[acmeDraft.ID()])
+	}
+	if tenants[defaultDraft.ID()] != "" || saved[defaultDraft.ID()] != "" {
+		t.Errorf("default draft loaded in tenant %q and saved in %q, want the default tenant", tenants[defaultDraft.ID()], saved[defaultDraft.ID()])
+	}
+}
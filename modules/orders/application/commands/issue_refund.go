@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// IssueRefundCommand refunds an order whose return has been requested.
+type IssueRefundCommand struct {
+	OrderID string
+	Actor   string // administrator issuing the refund
+}
+
+type IssueRefundHandler struct {
+	repo    domain.OrderRepository
+	txScope transaction.ScopeWithDomainEvent
+}
+
+func NewIssueRefundHandler(repo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent) *IssueRefundHandler {
+	return &IssueRefundHandler{
+		repo:    repo,
+		txScope: txScope,
+	}
+}
+
+// Handle executes the issue refund use case.
+func (h *IssueRefundHandler) Handle(ctx context.Context, cmd IssueRefundCommand) error {
+	orderID, err := domain.ParseOrderID(cmd.OrderID)
+	if err != nil {
+		return fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		order, err := transaction.Load(ctx, "order", orderID, h.repo.FindByID, h.repo.Save)
+		if err != nil {
+			return err
+		}
+
+		// Adds RefundIssuedEvent to ctx
+		return order.Refund(ctx, domain.AdminActor(cmd.Actor))
+	})
+}
@@ -0,0 +1,54 @@
+package commands_test
+
+import (
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/orders/domain/mocks"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events/eventstest"
+	"go.uber.org/mock/gomock"
+)
+
+// TestItemHandlers_PublishItemsChanged checks that the item commands publish
+// the events their domain methods raise without the handlers doing anything
+// but load the order and call them.
+func TestItemHandlers_PublishItemsChanged(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	order := createTestOrder(t)
+	repo := domainmocks.NewMockOrderRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), order.ID()).Return(order, nil).Times(2)
+	repo.EXPECT().Save(gomock.Any(), order).Return(nil).Times(2)
+
+	addScope, added := eventstest.NewScopeCaptureEvents(ctrl)
+	err := commands.NewAddItemHandler(repo, addScope, domain.OrderLimits{}).Handle(t.Context(), commands.AddItemCommand{
+		OrderID:     order.ID().String(),
+		ProductID:   "p-1",
+		ProductName: "Widget",
+		Quantity:    2,
+		UnitPrice:   500,
+		Currency:    "USD",
+	})
+	if err != nil {
+		t.Fatalf("AddItem: unexpected error: %v", err)
+	}
+	removeScope, removed := eventstest.NewScopeCaptureEvents(ctrl)
+	err = commands.NewRemoveItemHandler(repo, removeScope).Handle(t.Context(), commands.RemoveItemCommand{
+		OrderID:   order.ID().String(),
+		ProductID: "p-1",
+	})
+	if err != nil {
+		t.Fatalf("RemoveItem: unexpected error: %v", err)
+	}
+
+	var counts []int
+	for _, evt := range append(added.Events, removed.Events...) {
+		if e, ok := evt.(domain.OrderItemsChangedEvent); ok {
+			counts = append(counts, e.ItemCount)
+		}
+	}
+	if len(counts) != 2 || counts[0] != 2 || counts[1] != 0 {
+		t.Errorf("OrderItemsChangedEvent item counts = %v, want [2 0]", counts)
+	}
+}
@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// RemoveDiscountCommand removes a discount code from a draft order.
+type RemoveDiscountCommand struct {
+	OrderID           string
+	Code              string
+	ExpectedUpdatedAt time.Time // see Order.CheckUnchanged; zero skips the check
+}
+
+type RemoveDiscountHandler struct {
+	orderRepo    domain.OrderRepository
+	discountRepo domain.DiscountCodeRepository
+	txScope      transaction.ScopeWithDomainEvent
+}
+
+func NewRemoveDiscountHandler(orderRepo domain.OrderRepository, discountRepo domain.DiscountCodeRepository, txScope transaction.ScopeWithDomainEvent) *RemoveDiscountHandler {
+	return &RemoveDiscountHandler{
+		orderRepo:    orderRepo,
+		discountRepo: discountRepo,
+		txScope:      txScope,
+	}
+}
+
+// Handle executes the remove discount use case.
+// The redeemed use is released back to the code.
+func (h *RemoveDiscountHandler) Handle(ctx context.Context, cmd RemoveDiscountCommand) error {
+	orderID, err := domain.ParseOrderID(cmd.OrderID)
+	if err != nil {
+		return fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		order, err := transaction.Load(ctx, "order", orderID, h.orderRepo.FindByID, h.orderRepo.Save)
+		if err != nil {
+			return err
+		}
+		if err := order.CheckUnchanged(cmd.ExpectedUpdatedAt); err != nil {
+			return err
+		}
+
+		// Adds OrderDiscountRemovedEvent to ctx
+		if err := order.RemoveDiscount(ctx, cmd.Code); err != nil {
+			return err
+		}
+
+		return releaseDiscountCode(ctx, h.discountRepo, domain.NormalizeDiscountCode(cmd.Code), 1)
+	})
+}
+
+// releaseDiscountCode returns uses redeemed uses to the discount code in the
+// unit of work of ctx. A code that has since been deleted is ignored.
+func releaseDiscountCode(ctx context.Context, repo domain.DiscountCodeRepository, code string, uses int) error {
+	dc, err := transaction.Load(ctx, "discount code", code, repo.FindByCode, repo.Save)
+	switch {
+	case errors.Is(err, domain.ErrDiscountCodeNotFound):
+		return nil
+	case err != nil:
+		return err
+	}
+	for range uses {
+		dc.Release(ctx)
+	}
+	return nil
+}
+
+// cancelledDraftDiscount returns the discount code a draft order redeemed,
+// "" if it has none. A draft that is cancelled or expires never used its code,
+// so the command that cancels it releases the use; a submitted order keeps it.
+func cancelledDraftDiscount(order *domain.Order, wasDraft bool) string {
+	if !wasDraft || order.Discount().IsZero() {
+		return ""
+	}
+	return order.Discount().Code()
+}
@@ -3,22 +3,29 @@ package commands
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
 )
 
 // RemoveItemCommand removes an item from an order.
 type RemoveItemCommand struct {
-	OrderID   string
-	ProductID string
+	OrderID           string
+	ProductID         string
+	ExpectedUpdatedAt time.Time // see Order.CheckUnchanged; zero skips the check
 }
 
 type RemoveItemHandler struct {
-	repo domain.OrderRepository
+	repo    domain.OrderRepository
+	txScope transaction.ScopeWithDomainEvent
 }
 
-func NewRemoveItemHandler(repo domain.OrderRepository) *RemoveItemHandler {
-	return &RemoveItemHandler{repo: repo}
+func NewRemoveItemHandler(repo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent) *RemoveItemHandler {
+	return &RemoveItemHandler{
+		repo:    repo,
+		txScope: txScope,
+	}
 }
 
 func (h *RemoveItemHandler) Handle(ctx context.Context, cmd RemoveItemCommand) error {
@@ -27,18 +34,15 @@ func (h *RemoveItemHandler) Handle(ctx context.Context, cmd RemoveItemCommand) e
 		return fmt.Errorf("invalid order ID: %w", err)
 	}
 
-	order, err := h.repo.FindByID(ctx, orderID)
-	if err != nil {
-		return fmt.Errorf("finding order: %w", err)
-	}
-
-	if err := order.RemoveItem(cmd.ProductID); err != nil {
-		return err
-	}
-
-	if err := h.repo.Save(ctx, order); err != nil {
-		return fmt.Errorf("saving order: %w", err)
-	}
-
-	return nil
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		order, err := transaction.Load(ctx, "order", orderID, h.repo.FindByID, h.repo.Save)
+		if err != nil {
+			return err
+		}
+		if err := order.CheckUnchanged(cmd.ExpectedUpdatedAt); err != nil {
+			return err
+		}
+
+		return order.RemoveItem(ctx, cmd.ProductID)
+	})
 }
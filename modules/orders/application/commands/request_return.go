@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/features"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// ReturnsFlag gates the order returns flow while it is rolled out.
+var ReturnsFlag = features.Flag{Name: "orders.returns"}
+
+// RequestReturnCommand requests a return of a completed order.
+type RequestReturnCommand struct {
+	OrderID string
+	Reason  string
+}
+
+type RequestReturnHandler struct {
+	repo    domain.OrderRepository
+	txScope transaction.ScopeWithDomainEvent
+	flags   features.Flags
+}
+
+func NewRequestReturnHandler(repo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent, flags features.Flags) *RequestReturnHandler {
+	return &RequestReturnHandler{
+		repo:    repo,
+		txScope: txScope,
+		flags:   flags,
+	}
+}
+
+// Handle executes the request return use case.
+func (h *RequestReturnHandler) Handle(ctx context.Context, cmd RequestReturnCommand) error {
+	if err := features.Require(ctx, h.flags, ReturnsFlag); err != nil {
+		return err
+	}
+
+	orderID, err := domain.ParseOrderID(cmd.OrderID)
+	if err != nil {
+		return fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		order, err := transaction.Load(ctx, "order", orderID, h.repo.FindByID, h.repo.Save)
+		if err != nil {
+			return err
+		}
+
+		return order.RequestReturn(ctx, strings.TrimSpace(cmd.Reason))
+	})
+}
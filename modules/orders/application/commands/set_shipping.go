@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// SetShippingCommand sets the shipping address and delivery instructions of a draft order.
+type SetShippingCommand struct {
+	OrderID              string
+	Recipient            string
+	Line1                string
+	Line2                string
+	City                 string
+	Region               string
+	PostalCode           string
+	Country              string
+	DeliveryInstructions string
+	ExpectedUpdatedAt    time.Time // see Order.CheckUnchanged; zero skips the check
+}
+
+type SetShippingHandler struct {
+	repo    domain.OrderRepository
+	txScope transaction.ScopeWithDomainEvent
+}
+
+func NewSetShippingHandler(repo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent) *SetShippingHandler {
+	return &SetShippingHandler{
+		repo:    repo,
+		txScope: txScope,
+	}
+}
+
+// Handle executes the set shipping use case.
+func (h *SetShippingHandler) Handle(ctx context.Context, cmd SetShippingCommand) error {
+	orderID, err := domain.ParseOrderID(cmd.OrderID)
+	if err != nil {
+		return fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	address, err := domain.NewShippingAddress(cmd.Recipient, cmd.Line1, cmd.Line2, cmd.City, cmd.Region, cmd.PostalCode, cmd.Country)
+	if err != nil {
+		return fmt.Errorf("invalid shipping address: %w", err)
+	}
+	instructions := strings.TrimSpace(cmd.DeliveryInstructions)
+
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		order, err := transaction.Load(ctx, "order", orderID, h.repo.FindByID, h.repo.Save)
+		if err != nil {
+			return err
+		}
+		if err := order.CheckUnchanged(cmd.ExpectedUpdatedAt); err != nil {
+			return err
+		}
+
+		return order.SetShipping(ctx, address, instructions)
+	})
+}
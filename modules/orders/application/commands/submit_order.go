@@ -14,14 +14,22 @@ type SubmitOrderCommand struct {
 }
 
 type SubmitOrderHandler struct {
-	repo    domain.OrderRepository
-	txScope transaction.ScopeWithDomainEvent
+	repo       domain.OrderRepository
+	txScope    transaction.ScopeWithDomainEvent
+	promotions domain.PromotionEngine
+	taxes      domain.TaxCalculator
+	limits     domain.OrderLimits
 }
 
-func NewSubmitOrderHandler(repo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent) *SubmitOrderHandler {
+// NewSubmitOrderHandler creates the handler. promotions may be nil when no
+// promotion engine is configured.
+func NewSubmitOrderHandler(repo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent, promotions domain.PromotionEngine, taxes domain.TaxCalculator, limits domain.OrderLimits) *SubmitOrderHandler {
 	return &SubmitOrderHandler{
-		repo:    repo,
-		txScope: txScope,
+		repo:       repo,
+		txScope:    txScope,
+		promotions: promotions,
+		taxes:      taxes,
+		limits:     limits,
 	}
 }
 
@@ -36,20 +44,12 @@ func (h *SubmitOrderHandler) Handle(ctx context.Context, cmd SubmitOrderCommand)
 		return fmt.Errorf("invalid order ID: %w", err)
 	}
 
-	return h.txScope.ExecuteWithPublish(ctx, func(ctx context.Context) error {
-		order, err := h.repo.FindByID(ctx, orderID)
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		order, err := transaction.Load(ctx, "order", orderID, h.repo.FindByID, h.repo.Save)
 		if err != nil {
-			return fmt.Errorf("finding order: %w", err)
-		}
-
-		if err := order.Submit(ctx); err != nil {
 			return err
 		}
 
-		if err := h.repo.Save(ctx, order); err != nil {
-			return fmt.Errorf("saving order: %w", err)
-		}
-
-		return nil
+		return order.Submit(ctx, h.promotions, h.taxes, h.limits)
 	})
 }
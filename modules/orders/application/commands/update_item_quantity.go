@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// UpdateItemQuantityCommand changes the quantity of an item in an order.
+type UpdateItemQuantityCommand struct {
+	OrderID   string
+	ProductID string
+	Quantity  int
+}
+
+type UpdateItemQuantityHandler struct {
+	repo    domain.OrderRepository
+	txScope transaction.ScopeWithDomainEvent
+	limits  domain.OrderLimits
+}
+
+func NewUpdateItemQuantityHandler(repo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent, limits domain.OrderLimits) *UpdateItemQuantityHandler {
+	return &UpdateItemQuantityHandler{
+		repo:    repo,
+		txScope: txScope,
+		limits:  limits,
+	}
+}
+
+// Handle executes the update item quantity use case.
+func (h *UpdateItemQuantityHandler) Handle(ctx context.Context, cmd UpdateItemQuantityCommand) error {
+	orderID, err := domain.ParseOrderID(cmd.OrderID)
+	if err != nil {
+		return fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		order, err := transaction.Load(ctx, "order", orderID, h.repo.FindByID, h.repo.Save)
+		if err != nil {
+			return err
+		}
+
+		return order.UpdateItemQuantity(ctx, h.limits, cmd.ProductID, cmd.Quantity)
+	})
+}
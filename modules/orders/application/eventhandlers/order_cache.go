@@ -0,0 +1,41 @@
+package eventhandlers
+
+import (
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/cache"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// OrderCacheEventTypes lists the order events that change a cached
+// OrderDTO: every change to an order raises at least one of them.
+var OrderCacheEventTypes = []events.EventType{
+	domain.OrderStatusChangedEventType,
+	domain.OrderItemsChangedEventType,
+	domain.OrderDiscountAppliedEventType,
+	domain.OrderDiscountRemovedEventType,
+	domain.OrderShippingChangedEventType,
+	domain.OrderClaimedEventType,
+}
+
+// NewOrderCacheInvalidator returns the handler that evicts the OrderDTO of
+// the order an event of eventType changed.
+func NewOrderCacheInvalidator(eventType events.EventType, store cache.Store) *cache.Invalidator {
+	return cache.NewInvalidator("orders", eventType, store, func(event events.Event) []string {
+		switch e := event.(type) {
+		case domain.OrderStatusChangedEvent:
+			return []string{queries.OrderCacheKey(events.TenantID(e), e.OrderID)}
+		case domain.OrderItemsChangedEvent:
+			return []string{queries.OrderCacheKey(events.TenantID(e), e.OrderID)}
+		case domain.OrderDiscountAppliedEvent:
+			return []string{queries.OrderCacheKey(events.TenantID(e), e.OrderID)}
+		case domain.OrderDiscountRemovedEvent:
+			return []string{queries.OrderCacheKey(events.TenantID(e), e.OrderID)}
+		case domain.OrderShippingChangedEvent:
+			return []string{queries.OrderCacheKey(events.TenantID(e), e.OrderID)}
+		case domain.OrderClaimedEvent:
+			return []string{queries.OrderCacheKey(events.TenantID(e), e.OrderID)}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,59 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// OrderStatusChangedHandler records every order status transition in the status history.
+// It runs pre-commit, so the history entry is written atomically with the order change.
+type OrderStatusChangedHandler struct {
+	historyRepo domain.StatusHistoryRepository
+	txScope     transaction.Scope
+}
+
+func NewOrderStatusChangedHandler(historyRepo domain.StatusHistoryRepository, txScope transaction.Scope) *OrderStatusChangedHandler {
+	return &OrderStatusChangedHandler{
+		historyRepo: historyRepo,
+		txScope:     txScope,
+	}
+}
+
+func (h *OrderStatusChangedHandler) HandlerName() string { return "OrderStatusChangedHandler" }
+func (h *OrderStatusChangedHandler) Subdomain() string   { return "orders" }
+func (h *OrderStatusChangedHandler) EventType() events.EventType {
+	return domain.OrderStatusChangedEventType
+}
+
+func (h *OrderStatusChangedHandler) Handle(ctx context.Context, event events.Event) error {
+	e, ok := event.(domain.OrderStatusChangedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	orderID, err := domain.ParseOrderID(e.OrderID)
+	if err != nil {
+		return fmt.Errorf("parsing order ID: %w", err)
+	}
+
+	transition := domain.StatusTransition{
+		ID:         e.EventID(),
+		OrderID:    orderID,
+		From:       e.From,
+		To:         e.To,
+		Actor:      e.Actor,
+		Reason:     e.Reason,
+		OccurredAt: e.OccurredAt(),
+	}
+
+	return h.txScope.Execute(ctx, func(ctx context.Context) error {
+		if err := h.historyRepo.Append(ctx, transition); err != nil {
+			return fmt.Errorf("appending status history: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,247 @@
+package eventhandlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/projection"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+)
+
+// OrderSummaryEventTypes lists the order events that OrderSummaryProjection consumes.
+var OrderSummaryEventTypes = []events.EventType{
+	domain.OrderCreatedEventType,
+	domain.OrderStatusChangedEventType,
+	domain.OrderItemsChangedEventType,
+	domain.OrderDiscountAppliedEventType,
+	domain.OrderDiscountRemovedEventType,
+	domain.OrderSubmittedEventType,
+	domain.OrderClaimedEventType,
+}
+
+// OrderSummaryUserEventTypes lists the user events that OrderSummaryProjection
+// consumes to copy user emails into the summaries.
+var OrderSummaryUserEventTypes = []events.EventType{
+	userevents.UserCreatedEventType,
+	userevents.UserUpdatedEventType,
+}
+
+// OrderSummaryProjection keeps the OrderSummaries read model in sync with
+// order events, and copies user emails into it from user events so reports
+// can show the email without querying the users module. It runs pre-commit,
+// so the projection is updated atomically with the order or user change.
+//
+// It is rebuilt from the orders; the user emails recorded so far are kept.
+type OrderSummaryProjection struct {
+	orders    domain.OrderReader
+	summaries domain.OrderSummaryRepository
+	txScope   transaction.Scope
+}
+
+var _ projection.Rebuildable = (*OrderSummaryProjection)(nil)
+
+func NewOrderSummaryProjection(orders domain.OrderReader, summaries domain.OrderSummaryRepository, txScope transaction.Scope) *OrderSummaryProjection {
+	return &OrderSummaryProjection{
+		orders:    orders,
+		summaries: summaries,
+		txScope:   txScope,
+	}
+}
+
+func (h *OrderSummaryProjection) Name() string { return "orders.OrderSummary" }
+
+func (h *OrderSummaryProjection) EventTypes() []events.EventType {
+	return slices.Concat(OrderSummaryEventTypes, OrderSummaryUserEventTypes)
+}
+
+func (h *OrderSummaryProjection) Apply(ctx context.Context, event events.Event) error {
+	return h.txScope.Execute(ctx, func(ctx context.Context) error {
+		switch e := event.(type) {
+		case domain.OrderCreatedEvent:
+			return h.create(ctx, e)
+		case domain.OrderStatusChangedEvent:
+			return h.update(ctx, e.OrderID, e.OccurredAt(), func(s *domain.OrderSummary) error {
+				s.Status = e.To
+				return nil
+			})
+		case domain.OrderItemsChangedEvent:
+			return h.update(ctx, e.OrderID, e.OccurredAt(), func(s *domain.OrderSummary) error {
+				s.ItemCount = e.ItemCount
+				return setTotal(s, e.TotalAmount, e.Currency)
+			})
+		case domain.OrderDiscountAppliedEvent:
+			return h.update(ctx, e.OrderID, e.OccurredAt(), func(s *domain.OrderSummary) error {
+				return setTotal(s, e.TotalAmount, e.Currency)
+			})
+		case domain.OrderDiscountRemovedEvent:
+			return h.update(ctx, e.OrderID, e.OccurredAt(), func(s *domain.OrderSummary) error {
+				return setTotal(s, e.TotalAmount, e.Currency)
+			})
+		case orderevents.OrderSubmittedEvent:
+			return h.update(ctx, e.OrderID, e.OccurredAt(), func(s *domain.OrderSummary) error {
+				return setTotal(s, e.TotalAmount, e.Currency)
+			})
+		case domain.OrderClaimedEvent:
+			return h.update(ctx, e.OrderID, e.OccurredAt(), func(s *domain.OrderSummary) error {
+				return h.setUser(ctx, s, e.UserID)
+			})
+		case userevents.UserCreatedEvent:
+			return h.saveUserEmail(ctx, e.UserID, e.Email)
+		case userevents.UserUpdatedEvent:
+			return h.saveUserEmail(ctx, e.UserID, e.Email)
+		default:
+			return fmt.Errorf("unexpected event type: %T", event)
+		}
+	})
+}
+
+// Reset deletes every summary of the tenant.
+func (h *OrderSummaryProjection) Reset(ctx context.Context) error {
+	if err := h.summaries.DeleteAll(ctx); err != nil {
+		return fmt.Errorf("deleting order summaries: %w", err)
+	}
+	return nil
+}
+
+// Backfill writes the summaries of the orders from offset cursor on, newest
+// first. Orders created during a rebuild shift the offsets, so some orders
+// are summarized twice; none is skipped.
+func (h *OrderSummaryProjection) Backfill(ctx context.Context, cursor string, limit int) (string, error) {
+	var offset int
+	if cursor != "" {
+		var err error
+		if offset, err = strconv.Atoi(cursor); err != nil {
+			return "", fmt.Errorf("invalid cursor %q: %w", cursor, err)
+		}
+	}
+
+	orders, _, err := h.orders.Search(ctx, domain.OrderSearchCriteria{}, offset, limit)
+	if err != nil {
+		return "", fmt.Errorf("reading orders: %w", err)
+	}
+	for _, order := range orders {
+		email, err := h.summaries.FindUserEmail(ctx, order.UserRef())
+		if err != nil {
+			return "", fmt.Errorf("finding user email: %w", err)
+		}
+		if err := h.summaries.Save(ctx, domain.OrderSummary{
+			OrderID:   order.ID(),
+			UserRef:   order.UserRef(),
+			UserEmail: email,
+			ItemCount: order.ItemCount(),
+			Total:     order.Total(),
+			Status:    order.Status(),
+			CreatedAt: order.CreatedAt(),
+			UpdatedAt: order.UpdatedAt(),
+		}); err != nil {
+			return "", fmt.Errorf("saving order summary: %w", err)
+		}
+	}
+
+	if len(orders) < limit {
+		return "", nil
+	}
+	return strconv.Itoa(offset + len(orders)), nil
+}
+
+func (h *OrderSummaryProjection) create(ctx context.Context, e domain.OrderCreatedEvent) error {
+	orderID, err := domain.ParseOrderID(e.OrderID)
+	if err != nil {
+		return fmt.Errorf("parsing order ID: %w", err)
+	}
+	userRef, err := domain.ParseOwnerRef(e.UserID)
+	if err != nil {
+		return fmt.Errorf("parsing user ID: %w", err)
+	}
+	total, err := domain.NewMoney(0, e.Currency)
+	if err != nil {
+		return fmt.Errorf("invalid order currency: %w", err)
+	}
+
+	email, err := h.summaries.FindUserEmail(ctx, userRef)
+	if err != nil {
+		return fmt.Errorf("finding user email: %w", err)
+	}
+
+	if err := h.summaries.Save(ctx, domain.OrderSummary{
+		OrderID:   orderID,
+		UserRef:   userRef,
+		UserEmail: email,
+		Total:     total,
+		Status:    domain.StatusDraft,
+		CreatedAt: e.OccurredAt(),
+		UpdatedAt: e.OccurredAt(),
+	}); err != nil {
+		return fmt.Errorf("saving order summary: %w", err)
+	}
+	return nil
+}
+
+// update applies fn to an existing summary. Orders created before the
+// projection existed have no summary and are skipped.
+func (h *OrderSummaryProjection) update(ctx context.Context, rawID string, at time.Time, fn func(*domain.OrderSummary) error) error {
+	orderID, err := domain.ParseOrderID(rawID)
+	if err != nil {
+		return fmt.Errorf("parsing order ID: %w", err)
+	}
+
+	summary, err := h.summaries.FindByOrderID(ctx, orderID)
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("finding order summary: %w", err)
+	}
+
+	if err := fn(&summary); err != nil {
+		return err
+	}
+	summary.UpdatedAt = at
+
+	if err := h.summaries.Save(ctx, summary); err != nil {
+		return fmt.Errorf("saving order summary: %w", err)
+	}
+	return nil
+}
+
+func (h *OrderSummaryProjection) saveUserEmail(ctx context.Context, userID, email string) error {
+	userRef, err := domain.NewUserRef(userID)
+	if err != nil {
+		return fmt.Errorf("parsing user ID: %w", err)
+	}
+	if err := h.summaries.SaveUserEmail(ctx, userRef, email); err != nil {
+		return fmt.Errorf("saving user email: %w", err)
+	}
+	return nil
+}
+
+// setUser sets the owner of a claimed guest order and their email.
+func (h *OrderSummaryProjection) setUser(ctx context.Context, s *domain.OrderSummary, userID string) error {
+	userRef, err := domain.NewUserRef(userID)
+	if err != nil {
+		return fmt.Errorf("parsing user ID: %w", err)
+	}
+	email, err := h.summaries.FindUserEmail(ctx, userRef)
+	if err != nil {
+		return fmt.Errorf("finding user email: %w", err)
+	}
+	s.UserRef, s.UserEmail = userRef, email
+	return nil
+}
+
+func setTotal(s *domain.OrderSummary, amount int64, currency string) error {
+	total, err := domain.NewMoney(amount, currency)
+	if err != nil {
+		return fmt.Errorf("invalid order total: %w", err)
+	}
+	s.Total = total
+	return nil
+}
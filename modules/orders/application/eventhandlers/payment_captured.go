@@ -0,0 +1,76 @@
+package eventhandlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	paymentevents "github.com/rai/clean-modularmonolith-go/modules/payments/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// PaymentCapturedHandler confirms a pending order once its payment has been captured.
+// Orders that are no longer pending (e.g. cancelled for lack of stock meanwhile)
+// are left untouched and logged, as the payment then needs a refund.
+type PaymentCapturedHandler struct {
+	orderRepo domain.OrderRepository
+	txScope   transaction.ScopeWithDomainEvent
+	logger    *slog.Logger
+}
+
+func NewPaymentCapturedHandler(orderRepo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent, logger *slog.Logger) *PaymentCapturedHandler {
+	return &PaymentCapturedHandler{
+		orderRepo: orderRepo,
+		txScope:   txScope,
+		logger:    logger,
+	}
+}
+
+func (h *PaymentCapturedHandler) HandlerName() string { return "PaymentCapturedHandler" }
+func (h *PaymentCapturedHandler) Subdomain() string   { return "orders" }
+func (h *PaymentCapturedHandler) EventType() events.EventType {
+	return paymentevents.PaymentCapturedEventType
+}
+
+func (h *PaymentCapturedHandler) Handle(ctx context.Context, event events.Event) error {
+	e, ok := event.(paymentevents.PaymentCapturedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	orderID, err := domain.ParseOrderID(e.OrderID)
+	if err != nil {
+		return fmt.Errorf("parsing order ID: %w", err)
+	}
+
+	return h.txScope.ExecuteWithPublish(ctx, func(ctx context.Context) error {
+		order, err := h.orderRepo.FindByID(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("finding order: %w", err)
+		}
+
+		if err := order.Confirm(ctx); errors.Is(err, domain.ErrOrderNotPending) {
+			h.logger.Warn("payment captured for order that is no longer pending",
+				slog.String("order_id", e.OrderID),
+				slog.String("payment_id", e.PaymentID),
+				slog.String("status", order.Status().String()),
+			)
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if err := h.orderRepo.Save(ctx, order); err != nil {
+			return fmt.Errorf("saving confirmed order: %w", err)
+		}
+
+		h.logger.Info("confirmed order after payment capture",
+			slog.String("order_id", e.OrderID),
+			slog.String("payment_id", e.PaymentID),
+		)
+		return nil
+	})
+}
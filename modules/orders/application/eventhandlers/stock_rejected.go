@@ -0,0 +1,70 @@
+package eventhandlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	inventoryevents "github.com/rai/clean-modularmonolith-go/modules/inventory/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// StockRejectedHandler cancels an order whose stock could not be reserved.
+// It is the compensating path of the order fulfillment saga.
+type StockRejectedHandler struct {
+	orderRepo domain.OrderRepository
+	txScope   transaction.ScopeWithDomainEvent
+	logger    *slog.Logger
+}
+
+func NewStockRejectedHandler(orderRepo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent, logger *slog.Logger) *StockRejectedHandler {
+	return &StockRejectedHandler{
+		orderRepo: orderRepo,
+		txScope:   txScope,
+		logger:    logger,
+	}
+}
+
+func (h *StockRejectedHandler) HandlerName() string { return "StockRejectedHandler" }
+func (h *StockRejectedHandler) Subdomain() string   { return "orders" }
+func (h *StockRejectedHandler) EventType() events.EventType {
+	return inventoryevents.StockRejectedEventType
+}
+
+func (h *StockRejectedHandler) Handle(ctx context.Context, event events.Event) error {
+	e, ok := event.(inventoryevents.StockRejectedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	orderID, err := domain.ParseOrderID(e.OrderID)
+	if err != nil {
+		return fmt.Errorf("parsing order ID: %w", err)
+	}
+
+	return h.txScope.ExecuteWithPublish(ctx, func(ctx context.Context) error {
+		order, err := h.orderRepo.FindByID(ctx, orderID)
+		if err != nil {
+			return fmt.Errorf("finding order: %w", err)
+		}
+
+		if err := order.Cancel(ctx, domain.SystemActor(h.HandlerName()), "insufficient stock"); errors.Is(err, domain.ErrOrderAlreadyCancelled) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if err := h.orderRepo.Save(ctx, order); err != nil {
+			return fmt.Errorf("saving cancelled order: %w", err)
+		}
+
+		h.logger.Info("cancelled order after stock rejection",
+			slog.String("order_id", e.OrderID),
+			slog.Int("shortages", len(e.Shortages)),
+		)
+		return nil
+	})
+}
@@ -13,16 +13,21 @@ import (
 )
 
 // UserDeletedHandler handles UserDeleted events by canceling pending orders.
-// It uses TransactionScope to declare its own transactional boundary.
+// It uses ScopeWithDomainEvent to declare its own transactional boundary, so the
+// OrderCancelledEvents raised by the cancellations are published too.
 // If a transaction already exists in the context (e.g., from the originating command),
-// TransactionScope joins it; otherwise, it creates a new one.
+// the scope joins it; otherwise, it creates a new one.
 type UserDeletedHandler struct {
 	orderRepo domain.OrderRepository
-	txScope   transaction.Scope
+	txScope   transaction.ScopeWithDomainEvent
 	logger    *slog.Logger
 }
 
-func NewUserDeletedHandler(orderRepo domain.OrderRepository, txScope transaction.Scope, logger *slog.Logger) *UserDeletedHandler {
+// userDeletionActor cancels the orders of deleted users; UserRestoredHandler
+// reopens the orders it cancelled.
+var userDeletionActor = domain.SystemActor("UserDeletedHandler")
+
+func NewUserDeletedHandler(orderRepo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent, logger *slog.Logger) *UserDeletedHandler {
 	return &UserDeletedHandler{
 		orderRepo: orderRepo,
 		txScope:   txScope,
@@ -58,7 +63,7 @@ func (h *UserDeletedHandler) Handle(ctx context.Context, event events.Event) err
 				continue
 			}
 
-			if err := order.Cancel(ctx); err != nil {
+			if err := order.Cancel(ctx, userDeletionActor, "user deleted"); err != nil {
 				h.logger.Warn("failed to cancel order",
 					slog.String("order_id", order.ID().String()),
 					slog.Any("error", err),
@@ -78,5 +83,5 @@ func (h *UserDeletedHandler) Handle(ctx context.Context, event events.Event) err
 
 		return nil
 	}
-	return h.txScope.Execute(ctx, fn)
+	return h.txScope.ExecuteWithPublish(ctx, fn)
 }
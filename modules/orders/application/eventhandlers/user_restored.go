@@ -0,0 +1,80 @@
+package eventhandlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+)
+
+// UserRestoredHandler handles UserRestored events by reopening the orders
+// UserDeletedHandler cancelled, as drafts. Orders that were submitted stay
+// cancelled (see Order.Reopen), as do orders cancelled for another reason.
+type UserRestoredHandler struct {
+	orderRepo domain.OrderRepository
+	txScope   transaction.ScopeWithDomainEvent
+	logger    *slog.Logger
+}
+
+func NewUserRestoredHandler(orderRepo domain.OrderRepository, txScope transaction.ScopeWithDomainEvent, logger *slog.Logger) *UserRestoredHandler {
+	return &UserRestoredHandler{
+		orderRepo: orderRepo,
+		txScope:   txScope,
+		logger:    logger,
+	}
+}
+
+func (h *UserRestoredHandler) HandlerName() string         { return "UserRestoredHandler" }
+func (h *UserRestoredHandler) Subdomain() string           { return "orders" }
+func (h *UserRestoredHandler) EventType() events.EventType { return userevents.UserRestoredEventType }
+
+func (h *UserRestoredHandler) Handle(ctx context.Context, event events.Event) error {
+	e, ok := event.(userevents.UserRestoredEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	userRef, err := domain.NewUserRef(e.UserID)
+	if err != nil {
+		return fmt.Errorf("parsing user ID: %w", err)
+	}
+
+	fn := func(ctx context.Context) error {
+		orders, _, err := h.orderRepo.FindByUserRef(ctx, userRef, 0, 1000)
+		if err != nil {
+			return fmt.Errorf("finding user orders: %w", err)
+		}
+
+		for order := range slices.Values(orders) {
+			if order.Status() != domain.StatusCancelled || order.CancelledBy() != userDeletionActor {
+				continue
+			}
+
+			err := order.Reopen(ctx, domain.SystemActor(h.HandlerName()), "user restored")
+			if errors.Is(err, domain.ErrOrderNotReopenable) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("reopening order %s: %w", order.ID().String(), err)
+			}
+
+			if err := h.orderRepo.Save(ctx, order); err != nil {
+				return fmt.Errorf("saving reopened order %s: %w", order.ID().String(), err)
+			}
+
+			h.logger.Info("reopened order for restored user",
+				slog.String("order_id", order.ID().String()),
+				slog.String("user_id", e.UserID),
+			)
+		}
+
+		return nil
+	}
+	return h.txScope.ExecuteWithPublish(ctx, fn)
+}
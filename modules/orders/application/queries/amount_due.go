@@ -0,0 +1,55 @@
+package queries
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+)
+
+// AmountDueDTO is the amount other modules may charge for an order.
+type AmountDueDTO struct {
+	Amount   int64
+	Currency string
+	// Payable is false when the order does not exist or is not pending,
+	// i.e. it has not been submitted or has already been confirmed or cancelled.
+	Payable bool
+}
+
+// AmountDueQuery asks how much is owed on an order.
+type AmountDueQuery struct {
+	OrderID string
+}
+
+// AmountDueHandler handles AmountDueQuery for the payments module.
+type AmountDueHandler struct {
+	repo domain.OrderReader
+}
+
+func NewAmountDueHandler(repo domain.OrderReader) *AmountDueHandler {
+	return &AmountDueHandler{repo: repo}
+}
+
+// Handle returns a zero AmountDueDTO for malformed IDs and unknown orders.
+func (h *AmountDueHandler) Handle(ctx context.Context, query AmountDueQuery) (AmountDueDTO, error) {
+	orderID, err := domain.ParseOrderID(query.OrderID)
+	if err != nil {
+		return AmountDueDTO{}, nil
+	}
+
+	order, err := h.repo.FindByID(ctx, orderID)
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		return AmountDueDTO{}, nil
+	}
+	if err != nil {
+		return AmountDueDTO{}, err
+	}
+	if order.Status() != domain.StatusPending {
+		return AmountDueDTO{}, nil
+	}
+	return AmountDueDTO{
+		Amount:   order.Total().Amount(),
+		Currency: order.Total().Currency(),
+		Payable:  true,
+	}, nil
+}
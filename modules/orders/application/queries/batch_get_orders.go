@@ -0,0 +1,75 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// MaxBatchGetOrderIDs caps the number of distinct IDs in a BatchGetOrdersQuery.
+const MaxBatchGetOrderIDs = 100
+
+// BatchGetOrdersQuery gets several orders by ID at once.
+type BatchGetOrdersQuery struct {
+	OrderIDs []string
+}
+
+// BatchGetOrdersDTO holds the orders found, by ID, and the requested IDs
+// without an order, in request order.
+type BatchGetOrdersDTO struct {
+	Orders  map[string]*OrderDTO `json:"orders"`
+	Missing []string             `json:"missing"`
+}
+
+type BatchGetOrdersHandler struct {
+	repo    domain.OrderReader
+	txScope transaction.Scope
+}
+
+func NewBatchGetOrdersHandler(repo domain.OrderReader, txScope transaction.Scope) *BatchGetOrdersHandler {
+	return &BatchGetOrdersHandler{repo: repo, txScope: txScope}
+}
+
+// Handle reads the orders in one repository call. Duplicate IDs are read
+// once; an invalid ID fails the whole query.
+func (h *BatchGetOrdersHandler) Handle(ctx context.Context, query BatchGetOrdersQuery) (*BatchGetOrdersDTO, error) {
+	var ids []domain.OrderID
+	seen := make(map[domain.OrderID]bool)
+	for _, raw := range query.OrderIDs {
+		id, err := domain.ParseOrderID(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", err, raw)
+		}
+		if seen[id] {
+			continue
+		}
+		if len(ids) == MaxBatchGetOrderIDs {
+			return nil, domain.ErrBatchLimitExceeded
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	result := &BatchGetOrdersDTO{Orders: make(map[string]*OrderDTO, len(ids)), Missing: []string{}}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	orders, err := transaction.ExecuteReadOnly(ctx, h.txScope, func(ctx context.Context) ([]*domain.Order, error) {
+		return h.repo.FindByIDs(ctx, ids)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range orders {
+		result.Orders[order.ID().String()] = toOrderDTO(order)
+	}
+	for _, id := range ids {
+		if _, ok := result.Orders[id.String()]; !ok {
+			result.Missing = append(result.Missing, id.String())
+		}
+	}
+	return result, nil
+}
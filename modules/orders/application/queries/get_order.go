@@ -7,17 +7,90 @@ import (
 	"time"
 
 	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
 )
 
 // OrderDTO is a read model for order data.
 type OrderDTO struct {
-	ID        string         `json:"id"`
-	UserID    string         `json:"user_id"`
-	Items     []OrderItemDTO `json:"items"`
-	Status    string         `json:"status"`
-	Total     MoneyDTO       `json:"total"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
+	ID           string           `json:"id"`
+	UserID       string           `json:"user_id"`
+	Items        []OrderItemDTO   `json:"items"`
+	Status       string           `json:"status"`
+	Currency     string           `json:"currency"`
+	Subtotal     MoneyDTO         `json:"subtotal"`
+	Discount     *DiscountDTO     `json:"discount,omitempty"`
+	Promotions   *PromotionsDTO   `json:"promotions,omitempty"`
+	Tax          *TaxDTO          `json:"tax,omitempty"`
+	Total        MoneyDTO         `json:"total"`
+	Shipping     *ShippingDTO     `json:"shipping,omitempty"`
+	ReturnReason string           `json:"return_reason,omitempty"`
+	Cancellation *CancellationDTO `json:"cancellation,omitempty"`
+	Snapshot     *SnapshotDTO     `json:"snapshot,omitempty"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+}
+
+// ShippingDTO holds the delivery address and instructions of an order.
+type ShippingDTO struct {
+	Recipient            string `json:"recipient"`
+	Line1                string `json:"line1"`
+	Line2                string `json:"line2,omitempty"`
+	City                 string `json:"city"`
+	Region               string `json:"region,omitempty"`
+	PostalCode           string `json:"postal_code"`
+	Country              string `json:"country"`
+	DeliveryInstructions string `json:"delivery_instructions,omitempty"`
+}
+
+// DiscountDTO describes the discount line applied to an order.
+type DiscountDTO struct {
+	Code       string    `json:"code"`
+	Kind       string    `json:"kind"`
+	PercentOff int64     `json:"percent_off,omitempty"`
+	AmountOff  *MoneyDTO `json:"amount_off,omitempty"`
+	Amount     MoneyDTO  `json:"amount"`
+}
+
+// PromotionsDTO holds the automatic promotions applied to a submitted order.
+type PromotionsDTO struct {
+	Lines []PromotionLineDTO `json:"lines"`
+	Total MoneyDTO           `json:"total"`
+}
+
+type PromotionLineDTO struct {
+	PromotionID string   `json:"promotion_id"`
+	Name        string   `json:"name"`
+	Amount      MoneyDTO `json:"amount"`
+}
+
+// TaxDTO holds the tax breakdown of a submitted order.
+type TaxDTO struct {
+	Lines []TaxLineDTO `json:"lines"`
+	Total MoneyDTO     `json:"total"`
+}
+
+type TaxLineDTO struct {
+	Name    string   `json:"name"`
+	RateBps int64    `json:"rate_bps"`
+	Amount  MoneyDTO `json:"amount"`
+}
+
+// CancellationDTO describes who cancelled an order and why.
+type CancellationDTO struct {
+	Reason        string `json:"reason,omitempty"`
+	CancelledBy   string `json:"cancelled_by"`
+	CancelledByID string `json:"cancelled_by_id,omitempty"`
+}
+
+// SnapshotDTO holds the line items and amounts frozen when the order was submitted.
+type SnapshotDTO struct {
+	Items    []OrderItemDTO `json:"items"`
+	Subtotal MoneyDTO       `json:"subtotal"`
+	Discount MoneyDTO       `json:"discount"`
+	Tax      MoneyDTO       `json:"tax"`
+	Total    MoneyDTO       `json:"total"`
+	TakenAt  time.Time      `json:"taken_at"`
 }
 
 type OrderItemDTO struct {
@@ -38,12 +111,25 @@ type GetOrderQuery struct {
 	OrderID string
 }
 
+// OrderCacheKey is the key of the OrderDTO of orderID in tenantID in a
+// cache.Store.
+func OrderCacheKey(tenantID, orderID string) string {
+	return "orders:" + tenantID + ":order:" + orderID
+}
+
+// GetOrderCacheKey is the cache.Cached key of query: the OrderCacheKey of
+// the order in the tenant of ctx.
+func GetOrderCacheKey(ctx context.Context, query GetOrderQuery) string {
+	return OrderCacheKey(requestcontext.TenantID(ctx), query.OrderID)
+}
+
 type GetOrderHandler struct {
-	repo domain.OrderRepository
+	repo    domain.OrderReader
+	txScope transaction.Scope
 }
 
-func NewGetOrderHandler(repo domain.OrderRepository) *GetOrderHandler {
-	return &GetOrderHandler{repo: repo}
+func NewGetOrderHandler(repo domain.OrderReader, txScope transaction.Scope) *GetOrderHandler {
+	return &GetOrderHandler{repo: repo, txScope: txScope}
 }
 
 func (h *GetOrderHandler) Handle(ctx context.Context, query GetOrderQuery) (*OrderDTO, error) {
@@ -52,17 +138,46 @@ func (h *GetOrderHandler) Handle(ctx context.Context, query GetOrderQuery) (*Ord
 		return nil, fmt.Errorf("invalid order ID: %w", err)
 	}
 
-	order, err := h.repo.FindByID(ctx, orderID)
-	if err != nil {
-		return nil, err
-	}
-
-	return toOrderDTO(order), nil
+	return transaction.ExecuteReadOnly(ctx, h.txScope, func(ctx context.Context) (*OrderDTO, error) {
+		order, err := h.repo.FindByID(ctx, orderID)
+		if err != nil {
+			return nil, err
+		}
+		return toOrderDTO(order), nil
+	})
 }
 
 func toOrderDTO(order *domain.Order) *OrderDTO {
-	items := make([]OrderItemDTO, len(order.Items()))
-	for i, item := range order.Items() {
+	subtotal := order.Subtotal()
+	return &OrderDTO{
+		ID:       order.ID().String(),
+		UserID:   order.UserRef().String(),
+		Items:    toOrderItemDTOs(order.Items()),
+		Status:   order.Status().String(),
+		Currency: order.Currency(),
+		Subtotal: MoneyDTO{
+			Amount:   subtotal.Amount(),
+			Currency: subtotal.Currency(),
+		},
+		Discount:   toDiscountDTO(order),
+		Promotions: toPromotionsDTO(order),
+		Tax:        toTaxDTO(order),
+		Total: MoneyDTO{
+			Amount:   order.Total().Amount(),
+			Currency: order.Total().Currency(),
+		},
+		Shipping:     toShippingDTO(order),
+		ReturnReason: order.ReturnReason(),
+		Cancellation: toCancellationDTO(order),
+		Snapshot:     toSnapshotDTO(order),
+		CreatedAt:    order.CreatedAt(),
+		UpdatedAt:    order.UpdatedAt(),
+	}
+}
+
+func toOrderItemDTOs(orderItems []domain.OrderItem) []OrderItemDTO {
+	items := make([]OrderItemDTO, len(orderItems))
+	for i, item := range orderItems {
 		subtotal := item.Subtotal()
 		items[i] = OrderItemDTO{
 			ProductID:   item.ProductID,
@@ -78,17 +193,129 @@ func toOrderDTO(order *domain.Order) *OrderDTO {
 			},
 		}
 	}
+	return items
+}
 
-	return &OrderDTO{
-		ID:     order.ID().String(),
-		UserID: order.UserRef().String(),
-		Items:  items,
-		Status: order.Status().String(),
+func toShippingDTO(order *domain.Order) *ShippingDTO {
+	addr := order.ShippingAddress()
+	if addr.IsZero() {
+		return nil
+	}
+	return &ShippingDTO{
+		Recipient:            addr.Recipient(),
+		Line1:                addr.Line1(),
+		Line2:                addr.Line2(),
+		City:                 addr.City(),
+		Region:               addr.Region(),
+		PostalCode:           addr.PostalCode(),
+		Country:              addr.Country(),
+		DeliveryInstructions: order.DeliveryInstructions(),
+	}
+}
+
+func toDiscountDTO(order *domain.Order) *DiscountDTO {
+	discount := order.Discount()
+	if discount.IsZero() {
+		return nil
+	}
+	amount := order.DiscountAmount()
+	dto := &DiscountDTO{
+		Code:       discount.Code(),
+		Kind:       discount.Kind().String(),
+		PercentOff: discount.PercentOff(),
+		Amount: MoneyDTO{
+			Amount:   amount.Amount(),
+			Currency: amount.Currency(),
+		},
+	}
+	if discount.Kind() == domain.DiscountFixedAmount {
+		dto.AmountOff = &MoneyDTO{
+			Amount:   discount.AmountOff().Amount(),
+			Currency: discount.AmountOff().Currency(),
+		}
+	}
+	return dto
+}
+
+func toPromotionsDTO(order *domain.Order) *PromotionsDTO {
+	promotions := order.Promotions()
+	if promotions.IsZero() {
+		return nil
+	}
+	lines := make([]PromotionLineDTO, len(promotions.Lines()))
+	for i, line := range promotions.Lines() {
+		lines[i] = PromotionLineDTO{
+			PromotionID: line.PromotionID,
+			Name:        line.Name,
+			Amount: MoneyDTO{
+				Amount:   line.Amount.Amount(),
+				Currency: line.Amount.Currency(),
+			},
+		}
+	}
+	total := order.PromotionAmount()
+	return &PromotionsDTO{
+		Lines: lines,
 		Total: MoneyDTO{
-			Amount:   order.Total().Amount(),
-			Currency: order.Total().Currency(),
+			Amount:   total.Amount(),
+			Currency: total.Currency(),
 		},
-		CreatedAt: order.CreatedAt(),
-		UpdatedAt: order.UpdatedAt(),
 	}
 }
+
+func toTaxDTO(order *domain.Order) *TaxDTO {
+	tax := order.Tax()
+	if tax.IsZero() {
+		return nil
+	}
+	lines := make([]TaxLineDTO, len(tax.Lines()))
+	for i, line := range tax.Lines() {
+		lines[i] = TaxLineDTO{
+			Name:    line.Name,
+			RateBps: line.RateBps,
+			Amount: MoneyDTO{
+				Amount:   line.Amount.Amount(),
+				Currency: line.Amount.Currency(),
+			},
+		}
+	}
+	total := order.TaxAmount()
+	return &TaxDTO{
+		Lines: lines,
+		Total: MoneyDTO{
+			Amount:   total.Amount(),
+			Currency: total.Currency(),
+		},
+	}
+}
+
+func toCancellationDTO(order *domain.Order) *CancellationDTO {
+	by := order.CancelledBy()
+	if by.IsZero() {
+		return nil
+	}
+	return &CancellationDTO{
+		Reason:        order.CancelReason(),
+		CancelledBy:   by.Kind().String(),
+		CancelledByID: by.ID(),
+	}
+}
+
+func toSnapshotDTO(order *domain.Order) *SnapshotDTO {
+	snapshot := order.Snapshot()
+	if snapshot.IsZero() {
+		return nil
+	}
+	return &SnapshotDTO{
+		Items:    toOrderItemDTOs(snapshot.Items()),
+		Subtotal: toMoneyDTO(snapshot.Subtotal()),
+		Discount: toMoneyDTO(snapshot.Discount()),
+		Tax:      toMoneyDTO(snapshot.Tax()),
+		Total:    toMoneyDTO(snapshot.Total()),
+		TakenAt:  snapshot.TakenAt(),
+	}
+}
+
+func toMoneyDTO(m domain.Money) MoneyDTO {
+	return MoneyDTO{Amount: m.Amount(), Currency: m.Currency()}
+}
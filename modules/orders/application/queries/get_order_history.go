@@ -0,0 +1,78 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// StatusTransitionDTO is a read model for a single entry in an order's status history.
+type StatusTransitionDTO struct {
+	From       string    `json:"from,omitempty"`
+	To         string    `json:"to"`
+	Actor      string    `json:"actor,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// OrderHistoryDTO is the status timeline of an order, oldest first.
+type OrderHistoryDTO struct {
+	OrderID string                `json:"order_id"`
+	History []StatusTransitionDTO `json:"history"`
+}
+
+// GetOrderHistoryQuery retrieves the status history of an order.
+type GetOrderHistoryQuery struct {
+	OrderID string
+}
+
+type GetOrderHistoryHandler struct {
+	orderRepo   domain.OrderReader
+	historyRepo domain.StatusHistoryRepository
+	txScope     transaction.Scope
+}
+
+func NewGetOrderHistoryHandler(orderRepo domain.OrderReader, historyRepo domain.StatusHistoryRepository, txScope transaction.Scope) *GetOrderHistoryHandler {
+	return &GetOrderHistoryHandler{
+		orderRepo:   orderRepo,
+		historyRepo: historyRepo,
+		txScope:     txScope,
+	}
+}
+
+func (h *GetOrderHistoryHandler) Handle(ctx context.Context, query GetOrderHistoryQuery) (*OrderHistoryDTO, error) {
+	orderID, err := domain.ParseOrderID(query.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	transitions, err := transaction.ExecuteReadOnly(ctx, h.txScope, func(ctx context.Context) ([]domain.StatusTransition, error) {
+		// Distinguish an unknown order from one without recorded history.
+		if _, err := h.orderRepo.FindByID(ctx, orderID); err != nil {
+			return nil, err
+		}
+		return h.historyRepo.FindByOrderID(ctx, orderID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]StatusTransitionDTO, len(transitions))
+	for i, t := range transitions {
+		history[i] = StatusTransitionDTO{
+			From:       t.From.String(),
+			To:         t.To.String(),
+			Actor:      t.Actor,
+			Reason:     t.Reason,
+			OccurredAt: t.OccurredAt,
+		}
+	}
+
+	return &OrderHistoryDTO{
+		OrderID: orderID.String(),
+		History: history,
+	}, nil
+}
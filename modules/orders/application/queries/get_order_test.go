@@ -0,0 +1,80 @@
+package queries_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/orders/domain/mocks"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/cache"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"go.uber.org/mock/gomock"
+)
+
+// mapStore is a cache.Store without expiry.
+type mapStore map[string][]byte
+
+func (s mapStore) Get(ctx context.Context, key string) ([]byte, bool) {
+	v, ok := s[key]
+	return v, ok
+}
+
+func (s mapStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	s[key] = value
+}
+
+func (s mapStore) Delete(ctx context.Context, keys ...string) {
+	for _, k := range keys {
+		delete(s, k)
+	}
+}
+
+type directScope struct{}
+
+func (directScope) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// TestGetOrderHandler_Cached_PerTenant reads an order cached in its tenant
+// from another tenant: the cache must not skip the repository's tenant
+// filter.
+func TestGetOrderHandler_Cached_PerTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	acme := requestcontext.With(t.Context(), requestcontext.Values{TenantID: "acme"})
+	other := requestcontext.With(t.Context(), requestcontext.Values{TenantID: "other"})
+
+	userRef, err := domain.NewUserRef(uuid.NewString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order *domain.Order
+	if _, err := events.CaptureEvents(acme, func(ctx context.Context) error {
+		order, err = domain.NewOrder(ctx, userRef, "USD")
+		return err
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := domainmocks.NewMockOrderReader(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), order.ID()).DoAndReturn(func(ctx context.Context, _ domain.OrderID) (*domain.Order, error) {
+		if requestcontext.TenantID(ctx) != "acme" {
+			return nil, domain.ErrOrderNotFound
+		}
+		return order, nil
+	}).Times(2)
+
+	handler := cache.Cached(mapStore{}, time.Minute, queries.GetOrderCacheKey, queries.NewGetOrderHandler(repo, directScope{}))
+
+	if _, err := handler.Handle(acme, queries.GetOrderQuery{OrderID: order.ID().String()}); err != nil {
+		t.Fatalf("Handle in acme: %v", err)
+	}
+	if got, err := handler.Handle(other, queries.GetOrderQuery{OrderID: order.ID().String()}); !errors.Is(err, domain.ErrOrderNotFound) {
+		t.Errorf("Handle in another tenant = %+v, %v; want ErrOrderNotFound", got, err)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
 )
 
 // OrderListDTO contains a paginated list of orders.
@@ -15,49 +17,63 @@ type OrderListDTO struct {
 	Limit      int         `json:"limit"`
 }
 
-// ListUserOrdersQuery retrieves orders for a specific user.
+// ListUserOrdersQuery retrieves orders for a specific user, only those
+// with Status unless it is empty.
 type ListUserOrdersQuery struct {
 	UserID string
+	Status string
 	Offset int
 	Limit  int
 }
 
 type ListUserOrdersHandler struct {
-	repo domain.OrderRepository
+	repo    domain.OrderReader
+	txScope transaction.Scope
 }
 
-func NewListUserOrdersHandler(repo domain.OrderRepository) *ListUserOrdersHandler {
-	return &ListUserOrdersHandler{repo: repo}
+func NewListUserOrdersHandler(repo domain.OrderReader, txScope transaction.Scope) *ListUserOrdersHandler {
+	return &ListUserOrdersHandler{repo: repo, txScope: txScope}
 }
 
+// Handle uses a read-only transaction so that the count and the page are
+// read from the same snapshot.
 func (h *ListUserOrdersHandler) Handle(ctx context.Context, query ListUserOrdersQuery) (*OrderListDTO, error) {
 	userRef, err := domain.NewUserRef(query.UserID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
 
-	limit := query.Limit
-	if limit <= 0 {
-		limit = 20
-	}
-	if limit > 100 {
-		limit = 100
+	var status domain.Status
+	if query.Status != "" {
+		if status, err = domain.ParseStatus(query.Status); err != nil {
+			return nil, err
+		}
 	}
 
-	orders, total, err := h.repo.FindByUserRef(ctx, userRef, query.Offset, limit)
-	if err != nil {
-		return nil, err
-	}
+	p := page.NewRequest(query.Offset, query.Limit)
 
-	dtos := make([]*OrderDTO, len(orders))
-	for i, order := range orders {
-		dtos[i] = toOrderDTO(order)
-	}
+	return transaction.ExecuteReadOnly(ctx, h.txScope, func(ctx context.Context) (*OrderListDTO, error) {
+		var orders []*domain.Order
+		var total int
+		if status == "" {
+			orders, total, err = h.repo.FindByUserRef(ctx, userRef, p.Offset, p.Limit)
+		} else {
+			orders, total, err = h.repo.FindByUserRefAndStatus(ctx, userRef, status, p.Offset, p.Limit)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		dtos := make([]*OrderDTO, len(orders))
+		for i, order := range orders {
+			dtos[i] = toOrderDTO(order)
+		}
 
-	return &OrderListDTO{
-		Orders:     dtos,
-		TotalCount: total,
-		Offset:     query.Offset,
-		Limit:      limit,
-	}, nil
+		return &OrderListDTO{
+			Orders:     dtos,
+			TotalCount: total,
+			Offset:     p.Offset,
+			Limit:      p.Limit,
+		}, nil
+	})
 }
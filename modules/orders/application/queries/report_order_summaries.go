@@ -0,0 +1,95 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+)
+
+// OrderSummaryDTO is a row of the order summaries report.
+type OrderSummaryDTO struct {
+	OrderID   string    `json:"order_id"`
+	UserID    string    `json:"user_id"`
+	UserEmail string    `json:"user_email,omitempty"`
+	ItemCount int       `json:"item_count"`
+	Total     int64     `json:"total"`
+	Currency  string    `json:"currency"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type OrderSummaryListDTO struct {
+	Summaries  []OrderSummaryDTO `json:"summaries"`
+	TotalCount int               `json:"total_count"`
+	Offset     int               `json:"offset"`
+	Limit      int               `json:"limit"`
+}
+
+// ReportOrderSummariesQuery lists rows of the OrderSummaries read model.
+// Zero-valued fields are ignored.
+type ReportOrderSummariesQuery struct {
+	UserID   string
+	Statuses []string
+	Offset   int
+	Limit    int
+}
+
+// ReportOrderSummariesHandler serves reports from the denormalized OrderSummaries
+// projection instead of joining orders with users at read time.
+type ReportOrderSummariesHandler struct {
+	summaries domain.OrderSummaryRepository
+}
+
+func NewReportOrderSummariesHandler(summaries domain.OrderSummaryRepository) *ReportOrderSummariesHandler {
+	return &ReportOrderSummariesHandler{summaries: summaries}
+}
+
+func (h *ReportOrderSummariesHandler) Handle(ctx context.Context, query ReportOrderSummariesQuery) (*OrderSummaryListDTO, error) {
+	var filter domain.OrderSummaryFilter
+	if query.UserID != "" {
+		userRef, err := domain.NewUserRef(query.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user ID: %w", err)
+		}
+		filter.UserRef = userRef
+	}
+	for _, s := range query.Statuses {
+		filter.Statuses = append(filter.Statuses, domain.Status(s))
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := page.NewRequest(query.Offset, query.Limit)
+
+	summaries, total, err := h.summaries.List(ctx, filter, p.Offset, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]OrderSummaryDTO, len(summaries))
+	for i, s := range summaries {
+		dtos[i] = OrderSummaryDTO{
+			OrderID:   s.OrderID.String(),
+			UserID:    s.UserRef.String(),
+			UserEmail: s.UserEmail,
+			ItemCount: s.ItemCount,
+			Total:     s.Total.Amount(),
+			Currency:  s.Total.Currency(),
+			Status:    s.Status.String(),
+			CreatedAt: s.CreatedAt,
+			UpdatedAt: s.UpdatedAt,
+		}
+	}
+
+	return &OrderSummaryListDTO{
+		Summaries:  dtos,
+		TotalCount: total,
+		Offset:     p.Offset,
+		Limit:      p.Limit,
+	}, nil
+}
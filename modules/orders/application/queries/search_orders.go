@@ -0,0 +1,137 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// SearchOrdersQuery searches orders across users by multiple criteria.
+// Zero-valued fields are ignored.
+type SearchOrdersQuery struct {
+	UserID      string
+	Statuses    []string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	MinTotal    *int64
+	MaxTotal    *int64
+	Currency    string
+	Sort        page.Sort
+	Offset      int
+	Limit       int
+}
+
+type SearchOrdersHandler struct {
+	repo    domain.OrderReader
+	txScope transaction.Scope
+}
+
+func NewSearchOrdersHandler(repo domain.OrderReader, txScope transaction.Scope) *SearchOrdersHandler {
+	return &SearchOrdersHandler{repo: repo, txScope: txScope}
+}
+
+func (h *SearchOrdersHandler) Handle(ctx context.Context, query SearchOrdersQuery) (*OrderListDTO, error) {
+	criteria, err := searchCriteria(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := page.NewRequest(query.Offset, query.Limit)
+
+	return transaction.ExecuteReadOnly(ctx, h.txScope, func(ctx context.Context) (*OrderListDTO, error) {
+		orders, total, err := h.repo.Search(ctx, criteria, p.Offset, p.Limit)
+		if err != nil {
+			return nil, err
+		}
+
+		dtos := make([]*OrderDTO, len(orders))
+		for i, order := range orders {
+			dtos[i] = toOrderDTO(order)
+		}
+
+		return &OrderListDTO{
+			Orders:     dtos,
+			TotalCount: total,
+			Offset:     p.Offset,
+			Limit:      p.Limit,
+		}, nil
+	})
+}
+
+// exportPageSize is the number of orders Export reads at a time.
+const exportPageSize = 100
+
+// Export returns every order matching query from query.Offset on, or at
+// most query.Limit orders if it is positive, reading them a page at a time
+// as the caller consumes them, for streamed exports. An invalid query is
+// reported before any order. All pages are read in one read-only
+// transaction, so the export is a consistent snapshot.
+func (h *SearchOrdersHandler) Export(ctx context.Context, query SearchOrdersQuery) iter.Seq2[*OrderDTO, error] {
+	return func(yield func(*OrderDTO, error) bool) {
+		criteria, err := searchCriteria(query)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		remaining := query.Limit
+		err = h.txScope.Execute(ctx, func(ctx context.Context) error {
+			for offset := max(query.Offset, 0); ; offset += exportPageSize {
+				orders, _, err := h.repo.Search(ctx, criteria, offset, exportPageSize)
+				if err != nil {
+					return err
+				}
+				for _, order := range orders {
+					if !yield(toOrderDTO(order), nil) {
+						return nil
+					}
+					if remaining--; remaining == 0 {
+						return nil
+					}
+				}
+				if len(orders) < exportPageSize {
+					return nil
+				}
+			}
+		})
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
+
+func searchCriteria(query SearchOrdersQuery) (domain.OrderSearchCriteria, error) {
+	criteria := domain.OrderSearchCriteria{
+		CreatedFrom: query.CreatedFrom,
+		CreatedTo:   query.CreatedTo,
+		MinTotal:    query.MinTotal,
+		MaxTotal:    query.MaxTotal,
+		Sort:        domain.OrderSort{Field: domain.OrderSortField(query.Sort.Field), Desc: query.Sort.Desc},
+	}
+
+	if query.UserID != "" {
+		userRef, err := domain.NewUserRef(query.UserID)
+		if err != nil {
+			return criteria, fmt.Errorf("invalid user ID: %w", err)
+		}
+		criteria.UserRef = userRef
+	}
+	if query.Currency != "" {
+		currency, err := domain.ParseCurrency(query.Currency)
+		if err != nil {
+			return criteria, err
+		}
+		criteria.Currency = currency
+	}
+	for _, s := range query.Statuses {
+		criteria.Statuses = append(criteria.Statuses, domain.Status(s))
+	}
+	if err := criteria.Validate(); err != nil {
+		return criteria, err
+	}
+	return criteria, nil
+}
@@ -0,0 +1,42 @@
+package domain
+
+// ActorKind classifies who triggered an action on an order.
+type ActorKind string
+
+const (
+	ActorUser   ActorKind = "user"   // the customer who owns the order
+	ActorAdmin  ActorKind = "admin"  // an operator acting through admin endpoints
+	ActorSystem ActorKind = "system" // an automated process (jobs, event handlers)
+)
+
+func (k ActorKind) String() string { return string(k) }
+
+// Actor is a value object identifying who triggered an action.
+type Actor struct {
+	kind ActorKind
+	id   string // user ID, admin name, or process name
+}
+
+// UserActor returns an Actor for the customer referenced by ref.
+func UserActor(ref UserRef) Actor { return Actor{kind: ActorUser, id: ref.String()} }
+
+// AdminActor returns an Actor for an operator; id may be empty if unknown.
+func AdminActor(id string) Actor { return Actor{kind: ActorAdmin, id: id} }
+
+// SystemActor returns an Actor for an automated process named by id.
+func SystemActor(id string) Actor { return Actor{kind: ActorSystem, id: id} }
+
+// ReconstituteActor rebuilds an Actor from persistence without validation.
+func ReconstituteActor(kind ActorKind, id string) Actor { return Actor{kind: kind, id: id} }
+
+func (a Actor) Kind() ActorKind { return a.kind }
+func (a Actor) ID() string      { return a.id }
+func (a Actor) IsZero() bool    { return a.kind == "" }
+
+// String returns "kind:id", or just the kind when the ID is empty.
+func (a Actor) String() string {
+	if a.id == "" {
+		return string(a.kind)
+	}
+	return string(a.kind) + ":" + a.id
+}
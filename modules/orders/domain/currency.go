@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"strings"
+)
+
+// DefaultCurrency is used for orders created without an explicit currency.
+const DefaultCurrency = "USD"
+
+// Currency is an ISO 4217 currency the system accepts.
+type Currency struct {
+	Code string // ISO 4217 alphabetic code, e.g. USD
+
+	// MinorUnits is the number of digits after the decimal point: 2 for
+	// USD (cents), 0 for JPY, 3 for KWD. Amounts are always stored in the
+	// minor unit, so it only matters when converting to the major unit.
+	MinorUnits int
+}
+
+// iso4217 lists the active ISO 4217 currencies by their minor units,
+// without precious metals, testing and "no currency" codes.
+var iso4217 = map[int]string{
+	0: "BIF CLP DJF GNF ISK JPY KMF KRW PYG RWF UGX UYI VND VUV XAF XOF XPF",
+	2: "AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BMD BND BOB BOV BRL BSD BTN BWP BYN BZD " +
+		"CAD CDF CHE CHF CHW CNY COP COU CRC CUP CVE CZK DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD " +
+		"GTQ GYD HKD HNL HTG HUF IDR ILS INR IRR JMD KES KGS KHR KPW KYD KZT LAK LBP LKR LRD LSL MAD MDL MGA MKD " +
+		"MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO NOK NPR NZD PAB PEN PGK PHP PKR PLN QAR RON RSD " +
+		"RUB SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TOP TRY TTD TWD TZS UAH USD " +
+		"USN UYU UZS VED VES WST XCD XCG YER ZAR ZMW ZWG",
+	3: "BHD IQD JOD KWD LYD OMR TND",
+	4: "CLF UYW",
+}
+
+// currencies is the registry of accepted currencies, by code.
+var currencies = func() map[string]Currency {
+	registry := make(map[string]Currency)
+	for minorUnits, codes := range iso4217 {
+		for _, code := range strings.Fields(codes) {
+			registry[code] = Currency{Code: code, MinorUnits: minorUnits}
+		}
+	}
+	return registry
+}()
+
+// LookupCurrency returns the registered currency with the given code.
+func LookupCurrency(code string) (Currency, bool) {
+	c, ok := currencies[code]
+	return c, ok
+}
+
+// ParseCurrency validates and normalizes an ISO 4217 currency code. Codes
+// that are well-formed but not in the registry are rejected.
+func ParseCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if _, ok := currencies[code]; !ok {
+		return "", ErrCurrencyInvalid
+	}
+	return code, nil
+}
+
+// CurrencyExponent returns the minor units of currency, or 2 if it is not
+// registered.
+func CurrencyExponent(currency string) int {
+	if c, ok := currencies[currency]; ok {
+		return c.MinorUnits
+	}
+	return 2
+}
@@ -0,0 +1,52 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+)
+
+func TestParseCurrency(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    string
+		wantErr error
+	}{
+		{"USD", "USD", nil},
+		{" jpy ", "JPY", nil},
+		{"kwd", "KWD", nil},
+		{"", "", domain.ErrCurrencyInvalid},
+		{"US", "", domain.ErrCurrencyInvalid},
+		{"US1", "", domain.ErrCurrencyInvalid},
+		{"ABC", "", domain.ErrCurrencyInvalid}, // well-formed, not ISO 4217
+		{"XAU", "", domain.ErrCurrencyInvalid}, // gold is not a currency orders accept
+	}
+	for _, tt := range tests {
+		got, err := domain.ParseCurrency(tt.code)
+		if !errors.Is(err, tt.wantErr) || got != tt.want {
+			t.Errorf("ParseCurrency(%q) = %q, %v, want %q, %v", tt.code, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestLookupCurrency(t *testing.T) {
+	for code, want := range map[string]int{"USD": 2, "EUR": 2, "JPY": 0, "KRW": 0, "KWD": 3, "BHD": 3, "CLF": 4} {
+		c, ok := domain.LookupCurrency(code)
+		if !ok || c.Code != code || c.MinorUnits != want {
+			t.Errorf("LookupCurrency(%s) = %+v, %v, want %d minor units", code, c, ok, want)
+		}
+	}
+	if _, ok := domain.LookupCurrency("usd"); ok {
+		t.Error("LookupCurrency(usd) found a currency; codes are not normalized")
+	}
+}
+
+func TestNewMoney_RejectsUnknownCurrency(t *testing.T) {
+	if _, err := domain.NewMoney(100, "ABC"); !errors.Is(err, domain.ErrCurrencyInvalid) {
+		t.Errorf("NewMoney(ABC) error = %v, want ErrCurrencyInvalid", err)
+	}
+	if _, err := domain.NewMoney(100, ""); err == nil {
+		t.Error("NewMoney without currency succeeded")
+	}
+}
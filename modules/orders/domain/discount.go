@@ -0,0 +1,171 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+)
+
+// DiscountKind identifies how a discount reduces the order subtotal.
+type DiscountKind string
+
+const (
+	// DiscountPercentage takes a whole-number percentage (1-100) off the subtotal.
+	DiscountPercentage DiscountKind = "percentage"
+	// DiscountFixedAmount takes a fixed amount off the subtotal, capped at the subtotal.
+	DiscountFixedAmount DiscountKind = "fixed_amount"
+)
+
+func (k DiscountKind) String() string { return string(k) }
+
+func (k DiscountKind) IsValid() bool {
+	switch k {
+	case DiscountPercentage, DiscountFixedAmount:
+		return true
+	default:
+		return false
+	}
+}
+
+var discountCodePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// Discount is a value object describing the discount line applied to an order.
+type Discount struct {
+	code       string
+	kind       DiscountKind
+	percentOff int64 // DiscountPercentage only
+	amountOff  Money // DiscountFixedAmount only
+}
+
+// ReconstituteDiscount rebuilds a Discount from persistence without validation.
+func ReconstituteDiscount(code string, kind DiscountKind, percentOff int64, amountOff Money) Discount {
+	return Discount{code: code, kind: kind, percentOff: percentOff, amountOff: amountOff}
+}
+
+func (d Discount) Code() string       { return d.code }
+func (d Discount) Kind() DiscountKind { return d.kind }
+func (d Discount) PercentOff() int64  { return d.percentOff }
+func (d Discount) AmountOff() Money   { return d.amountOff }
+func (d Discount) IsZero() bool       { return d.code == "" }
+
+// AmountFor returns how much this discount takes off the given subtotal.
+// Percentages are rounded half-up to the minor unit; the result never
+// exceeds the subtotal, so discounted totals are never negative.
+func (d Discount) AmountFor(subtotal Money) Money {
+	var off int64
+	switch d.kind {
+	case DiscountPercentage:
+		off = (subtotal.Amount()*d.percentOff + 50) / 100
+	case DiscountFixedAmount:
+		if d.amountOff.Currency() == subtotal.Currency() {
+			off = d.amountOff.Amount()
+		}
+	}
+	off = min(off, subtotal.Amount())
+	return Money{amount: off, currency: subtotal.Currency()}
+}
+
+// DiscountCode is the aggregate root for a redeemable promotion code.
+// Each redemption consumes one use until MaxUses is reached; removing the
+// discount from a draft order releases the use again.
+type DiscountCode struct {
+	discount  Discount
+	expiresAt time.Time // zero means the code never expires
+	maxUses   int       // zero means unlimited
+	usedCount int
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+// NewDiscountCode creates a validated DiscountCode.
+// For DiscountPercentage, value is the whole-number percentage and currency is ignored.
+// For DiscountFixedAmount, value is the amount in the smallest currency unit.
+func NewDiscountCode(ctx context.Context, code string, kind DiscountKind, value int64, currency string, expiresAt time.Time, maxUses int) (*DiscountCode, error) {
+	code = NormalizeDiscountCode(code)
+	if !discountCodePattern.MatchString(code) {
+		return nil, ErrDiscountCodeInvalid
+	}
+	if maxUses < 0 {
+		return nil, ErrDiscountMaxUsesInvalid
+	}
+
+	d := Discount{code: code, kind: kind}
+	switch kind {
+	case DiscountPercentage:
+		if value < 1 || value > 100 {
+			return nil, ErrDiscountValueInvalid
+		}
+		d.percentOff = value
+	case DiscountFixedAmount:
+		if value <= 0 {
+			return nil, ErrDiscountValueInvalid
+		}
+		amount, err := NewMoney(value, currency)
+		if err != nil {
+			return nil, err
+		}
+		d.amountOff = amount
+	default:
+		return nil, ErrDiscountKindInvalid
+	}
+
+	now := clock.Now(ctx)
+	return &DiscountCode{
+		discount:  d,
+		expiresAt: expiresAt.UTC(),
+		maxUses:   maxUses,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// ReconstituteDiscountCode rebuilds a DiscountCode from persistence.
+func ReconstituteDiscountCode(discount Discount, expiresAt time.Time, maxUses, usedCount int, createdAt, updatedAt time.Time) *DiscountCode {
+	return &DiscountCode{
+		discount:  discount,
+		expiresAt: expiresAt,
+		maxUses:   maxUses,
+		usedCount: usedCount,
+		createdAt: createdAt,
+		updatedAt: updatedAt,
+	}
+}
+
+// NormalizeDiscountCode returns the canonical (trimmed, upper-case) form of a code.
+func NormalizeDiscountCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (c *DiscountCode) Code() string         { return c.discount.code }
+func (c *DiscountCode) Discount() Discount   { return c.discount }
+func (c *DiscountCode) ExpiresAt() time.Time { return c.expiresAt }
+func (c *DiscountCode) MaxUses() int         { return c.maxUses }
+func (c *DiscountCode) UsedCount() int       { return c.usedCount }
+func (c *DiscountCode) CreatedAt() time.Time { return c.createdAt }
+func (c *DiscountCode) UpdatedAt() time.Time { return c.updatedAt }
+
+// Redeem consumes one use of the code.
+// Returns ErrDiscountCodeExpired or ErrDiscountCodeExhausted if the code can no longer be used.
+func (c *DiscountCode) Redeem(ctx context.Context) error {
+	now := clock.Now(ctx)
+	if !c.expiresAt.IsZero() && !now.Before(c.expiresAt) {
+		return ErrDiscountCodeExpired
+	}
+	if c.maxUses > 0 && c.usedCount >= c.maxUses {
+		return ErrDiscountCodeExhausted
+	}
+	c.usedCount++
+	c.updatedAt = now
+	return nil
+}
+
+// Release returns a previously redeemed use of the code.
+func (c *DiscountCode) Release(ctx context.Context) {
+	if c.usedCount > 0 {
+		c.usedCount--
+		c.updatedAt = clock.Now(ctx)
+	}
+}
@@ -3,13 +3,71 @@ package domain
 import "errors"
 
 var (
-	ErrOrderNotFound        = errors.New("order not found")
-	ErrOrderNotDraft        = errors.New("order is not in draft status")
-	ErrOrderNotPending      = errors.New("order is not pending")
-	ErrOrderNotConfirmed    = errors.New("order is not confirmed")
-	ErrOrderEmpty           = errors.New("order has no items")
+	ErrOrderNotFound         = errors.New("order not found")
+	ErrOrderModified         = errors.New("order has been modified since it was read")
+	ErrOrderNotDraft         = errors.New("order is not in draft status")
+	ErrOrderNotPending       = errors.New("order is not pending")
+	ErrOrderNotConfirmed     = errors.New("order is not confirmed")
+	ErrOrderEmpty            = errors.New("order has no items")
 	ErrOrderAlreadyCancelled = errors.New("order is already cancelled")
-	ErrOrderCompleted       = errors.New("order is already completed")
-	ErrItemNotFound         = errors.New("item not found in order")
-	ErrInvalidQuantity      = errors.New("quantity must be positive")
+	ErrOrderNotCancelled     = errors.New("order is not cancelled")
+	ErrOrderNotReopenable    = errors.New("order was submitted before it was cancelled and cannot be reopened")
+	ErrOrderCompleted        = errors.New("order is already completed")
+	ErrOrderNotStale         = errors.New("order was updated after the expiry cutoff")
+	ErrOrderNotCompleted     = errors.New("order is not completed")
+	ErrReturnNotRequested    = errors.New("no return has been requested for order")
+	ErrReturnReasonTooLong   = errors.New("return reason must be at most 500 characters")
+	ErrCancelReasonTooLong   = errors.New("cancel reason must be at most 500 characters")
+	ErrItemNotFound          = errors.New("item not found in order")
+	ErrInvalidQuantity       = errors.New("quantity must be positive")
+	ErrUserNotFound          = errors.New("user not found or deleted")
+	ErrCurrencyInvalid       = errors.New("currency must be a supported ISO 4217 code")
+	ErrCurrencyMismatch      = errors.New("item currency does not match order currency")
+	ErrStatusInvalid         = errors.New("invalid order status")
+	ErrSearchRangeInvalid    = errors.New("search range lower bound must not exceed upper bound")
+	ErrOrderInvariant        = errors.New("order violates an invariant")
+
+	// Guest order errors
+	ErrOrderUnclaimed      = errors.New("guest order must be claimed before it is submitted")
+	ErrOrderAlreadyClaimed = errors.New("order is not a guest order")
+
+	// Limit errors
+	ErrTooManyItems         = errors.New("order has too many distinct items")
+	ErrLineQuantityExceeded = errors.New("item quantity exceeds the per-line limit")
+	ErrOrderTotalExceeded   = errors.New("order total exceeds the maximum allowed")
+	ErrMoneyOverflow        = errors.New("amount is too large")
+
+	// Shipping errors
+	ErrShippingRecipientRequired   = errors.New("shipping recipient is required")
+	ErrShippingLine1Required       = errors.New("shipping address line 1 is required")
+	ErrShippingCityRequired        = errors.New("shipping city is required")
+	ErrShippingPostalCodeRequired  = errors.New("shipping postal code is required")
+	ErrShippingCountryInvalid      = errors.New("shipping country must be an ISO 3166-1 alpha-2 code")
+	ErrShippingFieldTooLong        = errors.New("shipping address field must be at most 200 characters")
+	ErrDeliveryInstructionsTooLong = errors.New("delivery instructions must be at most 500 characters")
+
+	// Discount errors
+	ErrDiscountCodeNotFound     = errors.New("discount code not found")
+	ErrDiscountCodeExists       = errors.New("discount code already exists")
+	ErrDiscountCodeInvalid      = errors.New("discount code must be 3-32 characters of A-Z, 0-9, '-' or '_'")
+	ErrDiscountKindInvalid      = errors.New("discount kind must be percentage or fixed_amount")
+	ErrDiscountValueInvalid     = errors.New("discount value is out of range")
+	ErrDiscountMaxUsesInvalid   = errors.New("discount max uses must not be negative")
+	ErrDiscountCodeExpired      = errors.New("discount code has expired")
+	ErrDiscountCodeExhausted    = errors.New("discount code usage limit reached")
+	ErrDiscountCurrencyMismatch = errors.New("discount currency does not match order currency")
+	ErrDiscountAlreadyApplied   = errors.New("order already has a discount applied")
+	ErrDiscountNotApplied       = errors.New("discount is not applied to order")
+
+	// Bulk operation errors
+	ErrBulkOrderIDsRequired = errors.New("at least one order ID is required")
+	ErrBulkLimitExceeded    = errors.New("too many order IDs in bulk request")
+	ErrBatchLimitExceeded   = errors.New("too many order IDs in batch request")
+
+	// Tax errors
+	ErrTaxCurrencyMismatch = errors.New("tax currency does not match order currency")
+
+	// Promotion errors
+	ErrPromotionCurrencyMismatch = errors.New("promotion currency does not match order currency")
+	ErrInvalidPromotionAmount    = errors.New("promotion amount must not be negative")
 )
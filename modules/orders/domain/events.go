@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"context"
+	"time"
+
 	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
 )
@@ -8,46 +11,311 @@ import (
 // Internal event types (not used cross-module)
 const (
 	OrderCreatedEventType   events.EventType = "orders.OrderCreated"
-	OrderCancelledEventType events.EventType = "orders.OrderCancelled"
+	OrderCancelledEventType                  = orderevents.OrderCancelledEventType
+	OrderExpiredEventType   events.EventType = "orders.OrderExpired"
 	OrderSubmittedEventType                  = orderevents.OrderSubmittedEventType
+	OrderConfirmedEventType                  = orderevents.OrderConfirmedEventType
+	OrderCompletedEventType                  = orderevents.OrderCompletedEventType
+	RefundIssuedEventType                    = orderevents.RefundIssuedEventType
+
+	OrderDiscountAppliedEventType events.EventType = "orders.OrderDiscountApplied"
+	OrderDiscountRemovedEventType events.EventType = "orders.OrderDiscountRemoved"
+	OrderStatusChangedEventType   events.EventType = "orders.OrderStatusChanged"
+	OrderItemsChangedEventType    events.EventType = "orders.OrderItemsChanged"
+	OrderShippingChangedEventType events.EventType = "orders.OrderShippingChanged"
+	OrderClaimedEventType         events.EventType = "orders.OrderClaimed"
 )
 
-// OrderCreatedEvent is published when a new order is created.
+// OrderCreatedEvent is published when a new order is created. UserID is
+// empty for a guest order.
 type OrderCreatedEvent struct {
 	events.BaseEvent
-	OrderID string `json:"order_id"`
-	UserID  string `json:"user_id"`
+	OrderID  string `json:"order_id"`
+	UserID   string `json:"user_id"`
+	Currency string `json:"currency"`
 }
 
-func NewOrderCreatedEvent(order *Order) OrderCreatedEvent {
+func NewOrderCreatedEvent(ctx context.Context, order *Order) OrderCreatedEvent {
 	return OrderCreatedEvent{
-		BaseEvent: events.NewBaseEvent(OrderCreatedEventType),
+		BaseEvent: events.NewBaseEventWithContext(ctx, OrderCreatedEventType),
 		OrderID:   order.ID().String(),
 		UserID:    order.UserRef().String(),
+		Currency:  order.Currency(),
 	}
 }
 
-func NewOrderSubmittedEvent(order *Order) orderevents.OrderSubmittedEvent {
+func NewOrderSubmittedEvent(ctx context.Context, order *Order) orderevents.OrderSubmittedEvent {
 	return orderevents.OrderSubmittedEvent{
-		BaseEvent:   events.NewBaseEvent(OrderSubmittedEventType),
+		BaseEvent:            events.NewBaseEventWithContext(ctx, OrderSubmittedEventType),
+		OrderID:              order.ID().String(),
+		UserID:               order.UserRef().String(),
+		TotalAmount:          order.Total().Amount(),
+		TaxAmount:            order.TaxAmount().Amount(),
+		Currency:             order.Total().Currency(),
+		ShippingAddress:      shippingAddress(order),
+		DeliveryInstructions: order.DeliveryInstructions(),
+		Items:                orderLines(order),
+		Promotions:           appliedPromotions(order),
+		Taxes:                taxLines(order),
+	}
+}
+
+func shippingAddress(order *Order) orderevents.ShippingAddress {
+	addr := order.ShippingAddress()
+	return orderevents.ShippingAddress{
+		Recipient:  addr.Recipient(),
+		Line1:      addr.Line1(),
+		Line2:      addr.Line2(),
+		City:       addr.City(),
+		Region:     addr.Region(),
+		PostalCode: addr.PostalCode(),
+		Country:    addr.Country(),
+	}
+}
+
+func appliedPromotions(order *Order) []orderevents.AppliedPromotion {
+	lines := order.Promotions().Lines()
+	if len(lines) == 0 {
+		return nil
+	}
+	applied := make([]orderevents.AppliedPromotion, len(lines))
+	for i, line := range lines {
+		applied[i] = orderevents.AppliedPromotion{PromotionID: line.PromotionID, Name: line.Name, Amount: line.Amount.Amount()}
+	}
+	return applied
+}
+
+func taxLines(order *Order) []orderevents.TaxLine {
+	lines := order.Tax().Lines()
+	if len(lines) == 0 {
+		return nil
+	}
+	taxes := make([]orderevents.TaxLine, len(lines))
+	for i, line := range lines {
+		taxes[i] = orderevents.TaxLine{Name: line.Name, RateBps: line.RateBps, Amount: line.Amount.Amount()}
+	}
+	return taxes
+}
+
+func orderLines(order *Order) []orderevents.OrderLine {
+	lines := make([]orderevents.OrderLine, len(order.Items()))
+	for i, item := range order.Items() {
+		lines[i] = orderevents.OrderLine{ProductID: item.ProductID, Quantity: item.Quantity}
+	}
+	return lines
+}
+
+func NewOrderConfirmedEvent(ctx context.Context, order *Order) orderevents.OrderConfirmedEvent {
+	lines := make([]orderevents.ConfirmedLine, len(order.Items()))
+	for i, item := range order.Items() {
+		lines[i] = orderevents.ConfirmedLine{
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice.Amount(),
+		}
+	}
+	return orderevents.OrderConfirmedEvent{
+		BaseEvent:       events.NewBaseEventWithContext(ctx, OrderConfirmedEventType),
+		OrderID:         order.ID().String(),
+		UserID:          order.UserRef().String(),
+		TotalAmount:     order.Total().Amount(),
+		DiscountAmount:  order.Subtotal().Amount() - (order.Total().Amount() - order.TaxAmount().Amount()),
+		TaxAmount:       order.TaxAmount().Amount(),
+		Currency:        order.Total().Currency(),
+		Lines:           lines,
+		ShippingAddress: shippingAddress(order),
+	}
+}
+
+func NewOrderCompletedEvent(ctx context.Context, order *Order) orderevents.OrderCompletedEvent {
+	return orderevents.OrderCompletedEvent{
+		BaseEvent: events.NewBaseEventWithContext(ctx, OrderCompletedEventType),
+		OrderID:   order.ID().String(),
+		UserID:    order.UserRef().String(),
+		Items:     orderLines(order),
+	}
+}
+
+func NewRefundIssuedEvent(ctx context.Context, order *Order) orderevents.RefundIssuedEvent {
+	return orderevents.RefundIssuedEvent{
+		BaseEvent: events.NewBaseEventWithContext(ctx, RefundIssuedEventType),
+		OrderID:   order.ID().String(),
+		UserID:    order.UserRef().String(),
+		Amount:    order.Total().Amount(),
+		Currency:  order.Total().Currency(),
+		Reason:    order.ReturnReason(),
+	}
+}
+
+func NewOrderCancelledEvent(ctx context.Context, order *Order) orderevents.OrderCancelledEvent {
+	return orderevents.OrderCancelledEvent{
+		BaseEvent:     events.NewBaseEventWithContext(ctx, OrderCancelledEventType),
+		OrderID:       order.ID().String(),
+		UserID:        order.UserRef().String(),
+		Reason:        order.CancelReason(),
+		CancelledBy:   string(order.CancelledBy().Kind()),
+		CancelledByID: order.CancelledBy().ID(),
+	}
+}
+
+// OrderExpiredEvent is published when a stale draft order is cancelled by the expiry job.
+type OrderExpiredEvent struct {
+	events.BaseEvent
+	OrderID       string    `json:"order_id"`
+	UserID        string    `json:"user_id"`
+	LastUpdatedAt time.Time `json:"last_updated_at"`
+}
+
+func NewOrderExpiredEvent(ctx context.Context, order *Order, lastUpdatedAt time.Time) OrderExpiredEvent {
+	return OrderExpiredEvent{
+		BaseEvent:     events.NewBaseEventWithContext(ctx, OrderExpiredEventType),
+		OrderID:       order.ID().String(),
+		UserID:        order.UserRef().String(),
+		LastUpdatedAt: lastUpdatedAt,
+	}
+}
+
+// OrderDiscountAppliedEvent is published when a discount code is applied to an order.
+type OrderDiscountAppliedEvent struct {
+	events.BaseEvent
+	OrderID        string       `json:"order_id"`
+	Code           string       `json:"code"`
+	Kind           DiscountKind `json:"kind"`
+	PercentOff     int64        `json:"percent_off,omitempty"`
+	AmountOff      int64        `json:"amount_off,omitempty"` // for DiscountFixedAmount, in the order currency
+	DiscountAmount int64        `json:"discount_amount"`
+	TotalAmount    int64        `json:"total_amount"`
+	Currency       string       `json:"currency"`
+}
+
+func NewOrderDiscountAppliedEvent(ctx context.Context, order *Order) OrderDiscountAppliedEvent {
+	return OrderDiscountAppliedEvent{
+		BaseEvent:      events.NewBaseEventWithContext(ctx, OrderDiscountAppliedEventType),
+		OrderID:        order.ID().String(),
+		Code:           order.Discount().Code(),
+		Kind:           order.Discount().Kind(),
+		PercentOff:     order.Discount().PercentOff(),
+		AmountOff:      order.Discount().AmountOff().Amount(),
+		DiscountAmount: order.DiscountAmount().Amount(),
+		TotalAmount:    order.Total().Amount(),
+		Currency:       order.Total().Currency(),
+	}
+}
+
+// OrderDiscountRemovedEvent is published when a discount code is removed from an order.
+type OrderDiscountRemovedEvent struct {
+	events.BaseEvent
+	OrderID     string `json:"order_id"`
+	Code        string `json:"code"`
+	TotalAmount int64  `json:"total_amount"`
+	Currency    string `json:"currency"`
+}
+
+func NewOrderDiscountRemovedEvent(ctx context.Context, order *Order, removed Discount) OrderDiscountRemovedEvent {
+	return OrderDiscountRemovedEvent{
+		BaseEvent:   events.NewBaseEventWithContext(ctx, OrderDiscountRemovedEventType),
 		OrderID:     order.ID().String(),
-		UserID:      order.UserRef().String(),
+		Code:        removed.Code(),
 		TotalAmount: order.Total().Amount(),
 		Currency:    order.Total().Currency(),
 	}
 }
 
-// OrderCancelledEvent is published when an order is cancelled.
-type OrderCancelledEvent struct {
+// OrderClaimedEvent is published when a user claims a guest order.
+type OrderClaimedEvent struct {
 	events.BaseEvent
 	OrderID string `json:"order_id"`
 	UserID  string `json:"user_id"`
 }
 
-func NewOrderCancelledEvent(order *Order) OrderCancelledEvent {
-	return OrderCancelledEvent{
-		BaseEvent: events.NewBaseEvent(OrderCancelledEventType),
+func NewOrderClaimedEvent(ctx context.Context, order *Order) OrderClaimedEvent {
+	return OrderClaimedEvent{
+		BaseEvent: events.NewBaseEventWithContext(ctx, OrderClaimedEventType),
 		OrderID:   order.ID().String(),
 		UserID:    order.UserRef().String(),
 	}
 }
+
+// OrderStatusChangedEvent is published on every status transition, including
+// the initial draft status of a new order (From is empty).
+type OrderStatusChangedEvent struct {
+	events.BaseEvent
+	OrderID string `json:"order_id"`
+	From    Status `json:"from"`
+	To      Status `json:"to"`
+	Actor   string `json:"actor,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+func NewOrderStatusChangedEvent(ctx context.Context, order *Order, from Status, actor, reason string) OrderStatusChangedEvent {
+	return OrderStatusChangedEvent{
+		BaseEvent: events.NewBaseEventWithContext(ctx, OrderStatusChangedEventType),
+		OrderID:   order.ID().String(),
+		From:      from,
+		To:        order.Status(),
+		Actor:     actor,
+		Reason:    reason,
+	}
+}
+
+// OrderItemsChangedEvent is published when a line item is added, removed or
+// its quantity changes. Items are all the lines after the change.
+type OrderItemsChangedEvent struct {
+	events.BaseEvent
+	OrderID     string          `json:"order_id"`
+	Items       []OrderItemLine `json:"items"`
+	ItemCount   int             `json:"item_count"`
+	TotalAmount int64           `json:"total_amount"`
+	Currency    string          `json:"currency"`
+}
+
+// OrderItemLine is a line item carried by OrderItemsChangedEvent.
+type OrderItemLine struct {
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Quantity    int    `json:"quantity"`
+	UnitPrice   int64  `json:"unit_price"` // in the order currency
+}
+
+func NewOrderItemsChangedEvent(ctx context.Context, order *Order) OrderItemsChangedEvent {
+	return OrderItemsChangedEvent{
+		BaseEvent:   events.NewBaseEventWithContext(ctx, OrderItemsChangedEventType),
+		OrderID:     order.ID().String(),
+		Items:       itemLines(order),
+		ItemCount:   order.ItemCount(),
+		TotalAmount: order.Total().Amount(),
+		Currency:    order.Total().Currency(),
+	}
+}
+
+func itemLines(order *Order) []OrderItemLine {
+	lines := make([]OrderItemLine, len(order.Items()))
+	for i, item := range order.Items() {
+		lines[i] = OrderItemLine{
+			ProductID:   item.ProductID,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice.Amount(),
+		}
+	}
+	return lines
+}
+
+// OrderShippingChangedEvent is published when the delivery address or
+// instructions of an order are set.
+type OrderShippingChangedEvent struct {
+	events.BaseEvent
+	OrderID              string                      `json:"order_id"`
+	ShippingAddress      orderevents.ShippingAddress `json:"shipping_address"`
+	DeliveryInstructions string                      `json:"delivery_instructions,omitempty"`
+}
+
+func NewOrderShippingChangedEvent(ctx context.Context, order *Order) OrderShippingChangedEvent {
+	return OrderShippingChangedEvent{
+		BaseEvent:            events.NewBaseEventWithContext(ctx, OrderShippingChangedEventType),
+		OrderID:              order.ID().String(),
+		ShippingAddress:      shippingAddress(order),
+		DeliveryInstructions: order.DeliveryInstructions(),
+	}
+}
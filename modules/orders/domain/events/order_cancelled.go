@@ -0,0 +1,16 @@
+package events
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const OrderCancelledEventType events.EventType = "orders.OrderCancelled"
+
+// OrderCancelledEvent is published when an order is cancelled.
+// This is a public domain event — it may be imported by event handlers in other modules.
+type OrderCancelledEvent struct {
+	events.BaseEvent
+	OrderID       string `json:"order_id"`
+	UserID        string `json:"user_id"`
+	Reason        string `json:"reason,omitempty"`
+	CancelledBy   string `json:"cancelled_by"` // "user", "admin" or "system"
+	CancelledByID string `json:"cancelled_by_id,omitempty"`
+}
@@ -0,0 +1,14 @@
+package events
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const OrderCompletedEventType events.EventType = "orders.OrderCompleted"
+
+// OrderCompletedEvent is published when an order has been fulfilled.
+// This is a public domain event — it may be imported by event handlers in other modules.
+type OrderCompletedEvent struct {
+	events.BaseEvent
+	OrderID string      `json:"order_id"`
+	UserID  string      `json:"user_id"`
+	Items   []OrderLine `json:"items"`
+}
@@ -0,0 +1,31 @@
+package events
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const OrderConfirmedEventType events.EventType = "orders.OrderConfirmed"
+
+// OrderConfirmedEvent is published when an order has been paid for and is
+// ready for fulfillment. It carries the order as submitted, e.g. for
+// booking it in an ERP.
+// This is a public domain event — it may be imported by event handlers in other modules.
+type OrderConfirmedEvent struct {
+	events.BaseEvent
+	OrderID        string          `json:"order_id"`
+	UserID         string          `json:"user_id"`
+	TotalAmount    int64           `json:"total_amount"`    // includes TaxAmount
+	DiscountAmount int64           `json:"discount_amount"` // discount code and promotions
+	TaxAmount      int64           `json:"tax_amount"`
+	Currency       string          `json:"currency"`
+	Lines          []ConfirmedLine `json:"lines"`
+
+	// ShippingAddress is the zero value if the order has none.
+	ShippingAddress ShippingAddress `json:"shipping_address"`
+}
+
+// ConfirmedLine is a line item carried by OrderConfirmedEvent.
+type ConfirmedLine struct {
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Quantity    int    `json:"quantity"`
+	UnitPrice   int64  `json:"unit_price"` // minor units of the order's currency
+}
@@ -10,6 +10,52 @@ type OrderSubmittedEvent struct {
 	events.BaseEvent
 	OrderID     string
 	UserID      string
-	TotalAmount int64
+	TotalAmount int64 // includes TaxAmount
+	TaxAmount   int64
 	Currency    string
+
+	// ShippingAddress is the zero value if no address was set before submission.
+	ShippingAddress      ShippingAddress
+	DeliveryInstructions string
+
+	// Items are the submitted lines, e.g. for reserving stock.
+	Items []OrderLine
+
+	// Promotions are the automatic promotions taken off the order.
+	Promotions []AppliedPromotion
+
+	// Taxes are the tax lines added to the order; they sum to TaxAmount.
+	Taxes []TaxLine
+}
+
+// OrderLine is a product and quantity carried by OrderSubmittedEvent.
+type OrderLine struct {
+	ProductID string
+	Quantity  int
+}
+
+// AppliedPromotion is a promotion and the amount it took off, carried by
+// OrderSubmittedEvent so the promotions module can record the redemption.
+type AppliedPromotion struct {
+	PromotionID string
+	Name        string
+	Amount      int64
+}
+
+// TaxLine is a tax carried by OrderSubmittedEvent.
+type TaxLine struct {
+	Name    string
+	RateBps int64 // rate in basis points: 825 = 8.25%
+	Amount  int64
+}
+
+// ShippingAddress is the delivery address carried by OrderSubmittedEvent for fulfillment.
+type ShippingAddress struct {
+	Recipient  string
+	Line1      string
+	Line2      string
+	City       string
+	Region     string
+	PostalCode string
+	Country    string
 }
@@ -0,0 +1,16 @@
+package events
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const RefundIssuedEventType events.EventType = "orders.RefundIssued"
+
+// RefundIssuedEvent is published when a refund is issued for a returned order.
+// This is a public domain event — a payments module reacts to it to return the money.
+type RefundIssuedEvent struct {
+	events.BaseEvent
+	OrderID  string
+	UserID   string
+	Amount   int64 // full order total, in the smallest currency unit
+	Currency string
+	Reason   string
+}
@@ -0,0 +1,39 @@
+package domain
+
+// OrderLimits are business limits that reject abusive or erroneous orders early.
+// They are enforced when items are added or changed and again on submit,
+// where the total includes tax. Zero values mean unlimited.
+type OrderLimits struct {
+	MaxDistinctItems int   // line items per order
+	MaxLineQuantity  int   // quantity of a single line item
+	MaxTotal         int64 // order total in the smallest unit of the order currency
+}
+
+func (l OrderLimits) checkItems(items []OrderItem) error {
+	if l.MaxDistinctItems > 0 && len(items) > l.MaxDistinctItems {
+		return ErrTooManyItems
+	}
+	if l.MaxLineQuantity > 0 {
+		for _, item := range items {
+			if item.Quantity > l.MaxLineQuantity {
+				return ErrLineQuantityExceeded
+			}
+		}
+	}
+	return nil
+}
+
+func (l OrderLimits) checkTotal(total int64) error {
+	if l.MaxTotal > 0 && total > l.MaxTotal {
+		return ErrOrderTotalExceeded
+	}
+	return nil
+}
+
+// OrderQuotas cap how many orders are created, enforced by CreateOrder
+// (with modules/shared/quota) rather than per order. Zero values mean
+// unlimited.
+type OrderQuotas struct {
+	OrdersPerTenantPerDay int64 // orders created per tenant per UTC day
+	DraftsPerUser         int64 // draft orders a user has open at once
+}
@@ -0,0 +1,418 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockOrderRepository is a mock of OrderRepository interface.
+type MockOrderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderRepositoryMockRecorder is the mock recorder for MockOrderRepository.
+type MockOrderRepositoryMockRecorder struct {
+	mock *MockOrderRepository
+}
+
+// NewMockOrderRepository creates a new mock instance.
+func NewMockOrderRepository(ctrl *gomock.Controller) *MockOrderRepository {
+	mock := &MockOrderRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockOrderRepository) Delete(ctx context.Context, id domain.OrderID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockOrderRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderRepository)(nil).Delete), ctx, id)
+}
+
+// FindByID mocks base method.
+func (m *MockOrderRepository) FindByID(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockOrderRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockOrderRepository)(nil).FindByID), ctx, id)
+}
+
+// FindByIDs mocks base method.
+func (m *MockOrderRepository) FindByIDs(ctx context.Context, ids []domain.OrderID) ([]*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockOrderRepositoryMockRecorder) FindByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockOrderRepository)(nil).FindByIDs), ctx, ids)
+}
+
+// FindByStatus mocks base method.
+func (m *MockOrderRepository) FindByStatus(ctx context.Context, status domain.Status, offset, limit int) ([]*domain.Order, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByStatus", ctx, status, offset, limit)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindByStatus indicates an expected call of FindByStatus.
+func (mr *MockOrderRepositoryMockRecorder) FindByStatus(ctx, status, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByStatus", reflect.TypeOf((*MockOrderRepository)(nil).FindByStatus), ctx, status, offset, limit)
+}
+
+// FindByUserRef mocks base method.
+func (m *MockOrderRepository) FindByUserRef(ctx context.Context, userRef domain.UserRef, offset, limit int) ([]*domain.Order, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserRef", ctx, userRef, offset, limit)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindByUserRef indicates an expected call of FindByUserRef.
+func (mr *MockOrderRepositoryMockRecorder) FindByUserRef(ctx, userRef, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserRef", reflect.TypeOf((*MockOrderRepository)(nil).FindByUserRef), ctx, userRef, offset, limit)
+}
+
+// FindByUserRefAndStatus mocks base method.
+func (m *MockOrderRepository) FindByUserRefAndStatus(ctx context.Context, userRef domain.UserRef, status domain.Status, offset, limit int) ([]*domain.Order, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserRefAndStatus", ctx, userRef, status, offset, limit)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindByUserRefAndStatus indicates an expected call of FindByUserRefAndStatus.
+func (mr *MockOrderRepositoryMockRecorder) FindByUserRefAndStatus(ctx, userRef, status, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserRefAndStatus", reflect.TypeOf((*MockOrderRepository)(nil).FindByUserRefAndStatus), ctx, userRef, status, offset, limit)
+}
+
+// FindStaleDrafts mocks base method.
+func (m *MockOrderRepository) FindStaleDrafts(ctx context.Context, cutoff time.Time, limit int) ([]domain.StaleDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStaleDrafts", ctx, cutoff, limit)
+	ret0, _ := ret[0].([]domain.StaleDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStaleDrafts indicates an expected call of FindStaleDrafts.
+func (mr *MockOrderRepositoryMockRecorder) FindStaleDrafts(ctx, cutoff, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStaleDrafts", reflect.TypeOf((*MockOrderRepository)(nil).FindStaleDrafts), ctx, cutoff, limit)
+}
+
+// Save mocks base method.
+func (m *MockOrderRepository) Save(ctx context.Context, order *domain.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockOrderRepositoryMockRecorder) Save(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockOrderRepository)(nil).Save), ctx, order)
+}
+
+// Search mocks base method.
+func (m *MockOrderRepository) Search(ctx context.Context, criteria domain.OrderSearchCriteria, offset, limit int) ([]*domain.Order, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, criteria, offset, limit)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockOrderRepositoryMockRecorder) Search(ctx, criteria, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockOrderRepository)(nil).Search), ctx, criteria, offset, limit)
+}
+
+// MockOrderReader is a mock of OrderReader interface.
+type MockOrderReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderReaderMockRecorder is the mock recorder for MockOrderReader.
+type MockOrderReaderMockRecorder struct {
+	mock *MockOrderReader
+}
+
+// NewMockOrderReader creates a new mock instance.
+func NewMockOrderReader(ctrl *gomock.Controller) *MockOrderReader {
+	mock := &MockOrderReader{ctrl: ctrl}
+	mock.recorder = &MockOrderReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderReader) EXPECT() *MockOrderReaderMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockOrderReader) FindByID(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockOrderReaderMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockOrderReader)(nil).FindByID), ctx, id)
+}
+
+// FindByIDs mocks base method.
+func (m *MockOrderReader) FindByIDs(ctx context.Context, ids []domain.OrderID) ([]*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockOrderReaderMockRecorder) FindByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockOrderReader)(nil).FindByIDs), ctx, ids)
+}
+
+// FindByStatus mocks base method.
+func (m *MockOrderReader) FindByStatus(ctx context.Context, status domain.Status, offset, limit int) ([]*domain.Order, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByStatus", ctx, status, offset, limit)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindByStatus indicates an expected call of FindByStatus.
+func (mr *MockOrderReaderMockRecorder) FindByStatus(ctx, status, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByStatus", reflect.TypeOf((*MockOrderReader)(nil).FindByStatus), ctx, status, offset, limit)
+}
+
+// FindByUserRef mocks base method.
+func (m *MockOrderReader) FindByUserRef(ctx context.Context, userRef domain.UserRef, offset, limit int) ([]*domain.Order, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserRef", ctx, userRef, offset, limit)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindByUserRef indicates an expected call of FindByUserRef.
+func (mr *MockOrderReaderMockRecorder) FindByUserRef(ctx, userRef, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserRef", reflect.TypeOf((*MockOrderReader)(nil).FindByUserRef), ctx, userRef, offset, limit)
+}
+
+// FindByUserRefAndStatus mocks base method.
+func (m *MockOrderReader) FindByUserRefAndStatus(ctx context.Context, userRef domain.UserRef, status domain.Status, offset, limit int) ([]*domain.Order, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserRefAndStatus", ctx, userRef, status, offset, limit)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindByUserRefAndStatus indicates an expected call of FindByUserRefAndStatus.
+func (mr *MockOrderReaderMockRecorder) FindByUserRefAndStatus(ctx, userRef, status, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserRefAndStatus", reflect.TypeOf((*MockOrderReader)(nil).FindByUserRefAndStatus), ctx, userRef, status, offset, limit)
+}
+
+// FindStaleDrafts mocks base method.
+func (m *MockOrderReader) FindStaleDrafts(ctx context.Context, cutoff time.Time, limit int) ([]domain.StaleDraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStaleDrafts", ctx, cutoff, limit)
+	ret0, _ := ret[0].([]domain.StaleDraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStaleDrafts indicates an expected call of FindStaleDrafts.
+func (mr *MockOrderReaderMockRecorder) FindStaleDrafts(ctx, cutoff, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStaleDrafts", reflect.TypeOf((*MockOrderReader)(nil).FindStaleDrafts), ctx, cutoff, limit)
+}
+
+// Search mocks base method.
+func (m *MockOrderReader) Search(ctx context.Context, criteria domain.OrderSearchCriteria, offset, limit int) ([]*domain.Order, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, criteria, offset, limit)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockOrderReaderMockRecorder) Search(ctx, criteria, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockOrderReader)(nil).Search), ctx, criteria, offset, limit)
+}
+
+// MockOrderWriter is a mock of OrderWriter interface.
+type MockOrderWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockOrderWriterMockRecorder is the mock recorder for MockOrderWriter.
+type MockOrderWriterMockRecorder struct {
+	mock *MockOrderWriter
+}
+
+// NewMockOrderWriter creates a new mock instance.
+func NewMockOrderWriter(ctrl *gomock.Controller) *MockOrderWriter {
+	mock := &MockOrderWriter{ctrl: ctrl}
+	mock.recorder = &MockOrderWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderWriter) EXPECT() *MockOrderWriterMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockOrderWriter) Delete(ctx context.Context, id domain.OrderID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockOrderWriterMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockOrderWriter)(nil).Delete), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockOrderWriter) Save(ctx context.Context, order *domain.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockOrderWriterMockRecorder) Save(ctx, order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockOrderWriter)(nil).Save), ctx, order)
+}
+
+// MockDiscountCodeRepository is a mock of DiscountCodeRepository interface.
+type MockDiscountCodeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDiscountCodeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockDiscountCodeRepositoryMockRecorder is the mock recorder for MockDiscountCodeRepository.
+type MockDiscountCodeRepositoryMockRecorder struct {
+	mock *MockDiscountCodeRepository
+}
+
+// NewMockDiscountCodeRepository creates a new mock instance.
+func NewMockDiscountCodeRepository(ctrl *gomock.Controller) *MockDiscountCodeRepository {
+	mock := &MockDiscountCodeRepository{ctrl: ctrl}
+	mock.recorder = &MockDiscountCodeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDiscountCodeRepository) EXPECT() *MockDiscountCodeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByCode mocks base method.
+func (m *MockDiscountCodeRepository) FindByCode(ctx context.Context, code string) (*domain.DiscountCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCode", ctx, code)
+	ret0, _ := ret[0].(*domain.DiscountCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCode indicates an expected call of FindByCode.
+func (mr *MockDiscountCodeRepositoryMockRecorder) FindByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCode", reflect.TypeOf((*MockDiscountCodeRepository)(nil).FindByCode), ctx, code)
+}
+
+// Save mocks base method.
+func (m *MockDiscountCodeRepository) Save(ctx context.Context, code *domain.DiscountCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockDiscountCodeRepositoryMockRecorder) Save(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockDiscountCodeRepository)(nil).Save), ctx, code)
+}
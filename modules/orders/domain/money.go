@@ -1,9 +1,22 @@
 package domain
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
 )
 
+// MoneyConverter converts amounts between currencies.
+// It is the extension point for FX handling; orders currently reject
+// mixed currencies instead of converting them.
+type MoneyConverter interface {
+	Convert(ctx context.Context, amount Money, toCurrency string) (Money, error)
+}
+
 // Money represents a monetary value with currency.
 // Immutable value object - all operations return new instances.
 type Money struct {
@@ -11,12 +24,14 @@ type Money struct {
 	currency string // ISO 4217 currency code
 }
 
+// NewMoney creates an amount in the smallest unit of currency, which must be
+// a registered ISO 4217 code (see ParseCurrency to normalize user input).
 func NewMoney(amount int64, currency string) (Money, error) {
 	if currency == "" {
 		return Money{}, fmt.Errorf("currency is required")
 	}
-	if len(currency) != 3 {
-		return Money{}, fmt.Errorf("currency must be 3-letter ISO code")
+	if _, ok := LookupCurrency(currency); !ok {
+		return Money{}, fmt.Errorf("%w: %q", ErrCurrencyInvalid, currency)
 	}
 	return Money{amount: amount, currency: currency}, nil
 }
@@ -33,24 +48,203 @@ func (m Money) Amount() int64    { return m.amount }
 func (m Money) Currency() string { return m.currency }
 func (m Money) IsZero() bool     { return m.amount == 0 }
 
+// Add returns m + other. It returns ErrMoneyOverflow rather than wrapping
+// around when the sum does not fit in an int64.
 func (m Money) Add(other Money) (Money, error) {
 	if m.currency != other.currency {
 		return Money{}, fmt.Errorf("cannot add different currencies: %s and %s", m.currency, other.currency)
 	}
-	return Money{amount: m.amount + other.amount, currency: m.currency}, nil
+	sum, ok := addInt64(m.amount, other.amount)
+	if !ok {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{amount: sum, currency: m.currency}, nil
 }
 
+// Subtract returns m - other, or ErrMoneyOverflow.
 func (m Money) Subtract(other Money) (Money, error) {
 	if m.currency != other.currency {
 		return Money{}, fmt.Errorf("cannot subtract different currencies: %s and %s", m.currency, other.currency)
 	}
-	return Money{amount: m.amount - other.amount, currency: m.currency}, nil
+	if other.amount == math.MinInt64 {
+		return Money{}, ErrMoneyOverflow
+	}
+	diff, ok := addInt64(m.amount, -other.amount)
+	if !ok {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{amount: diff, currency: m.currency}, nil
 }
 
-func (m Money) Multiply(factor int64) Money {
-	return Money{amount: m.amount * factor, currency: m.currency}
+// Multiply returns m * factor, or ErrMoneyOverflow.
+func (m Money) Multiply(factor int64) (Money, error) {
+	product, ok := mulInt64(m.amount, factor)
+	if !ok {
+		return Money{}, ErrMoneyOverflow
+	}
+	return Money{amount: product, currency: m.currency}, nil
+}
+
+// Allocate splits m into shares proportional to ratios without losing a
+// minor unit: the shares always sum to m. What the division leaves over is
+// handed out one minor unit at a time to the shares with a non-zero ratio,
+// in order, so e.g. 100 allocated 1:1:1 is 34, 33, 33. Use it to spread an
+// order-level amount (a discount, a tax) over the order's lines, with the
+// lines' subtotals as the ratios.
+//
+// Ratios must not be negative and must not all be zero.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	var total int64
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, fmt.Errorf("allocation ratio must not be negative: %d", r)
+		}
+		var ok bool
+		if total, ok = addInt64(total, r); !ok {
+			return nil, ErrMoneyOverflow
+		}
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("allocation needs a positive ratio")
+	}
+
+	shares := make([]Money, len(ratios))
+	remainder := m.amount
+	for i, r := range ratios {
+		// amount*r/total cannot overflow the result, only the product
+		share := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(r))
+		share.Quo(share, big.NewInt(total))
+		shares[i] = Money{amount: share.Int64(), currency: m.currency}
+		remainder -= share.Int64()
+	}
+
+	unit := int64(1)
+	if remainder < 0 {
+		unit = -1
+	}
+	for i := 0; remainder != 0; i++ {
+		if ratios[i] > 0 {
+			shares[i].amount += unit
+			remainder -= unit
+		}
+	}
+	return shares, nil
+}
+
+// Split divides m into n shares as equal as possible, e.g. 100 into 34, 33, 33.
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, fmt.Errorf("split into %d shares: must be positive", n)
+	}
+	ratios := make([]int64, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+	return m.Allocate(ratios...)
+}
+
+// String formats m in the currency's major unit, e.g. "19.99 USD" and
+// "1999 JPY". Use Format for display.
+func (m Money) String() string {
+	return m.decimal("") + " " + m.currency
+}
+
+// Format formats m for display in locale, a language code with an optional
+// region (en, en-US, ja-JP), with digit grouping: "1,234.56 USD" in English,
+// and in Japanese "1,234円" for yen and "1,234.56 USD" otherwise. Other
+// languages are formatted as English.
+func (m Money) Format(locale string) string {
+	language, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	if language == "ja" && m.currency == "JPY" {
+		return m.decimal(",") + "円"
+	}
+	return m.decimal(",") + " " + m.currency
+}
+
+// decimal returns the amount in the major unit, with group between every
+// three integer digits.
+func (m Money) decimal(group string) string {
+	sign := ""
+	if m.amount < 0 {
+		sign = "-"
+	}
+	digits := strconv.FormatUint(absInt64(m.amount), 10)
+	exp := CurrencyExponent(m.currency)
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	integer, fraction := digits[:len(digits)-exp], digits[len(digits)-exp:]
+	if group != "" {
+		var b strings.Builder
+		for i, r := range integer {
+			if i > 0 && (len(integer)-i)%3 == 0 {
+				b.WriteString(group)
+			}
+			b.WriteRune(r)
+		}
+		integer = b.String()
+	}
+	if fraction == "" {
+		return sign + integer
+	}
+	return sign + integer + "." + fraction
 }
 
 func (m Money) Equals(other Money) bool {
 	return m.amount == other.amount && m.currency == other.currency
 }
+
+// moneyJSON is the JSON form of Money, the same as the API's MoneyDTO.
+type moneyJSON struct {
+	Amount   int64  `json:"amount"` // minor units
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON implements json.Marshaler: {"amount": 1234, "currency": "USD"}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.amount, Currency: m.currency})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The currency must be
+// registered (see NewMoney); the zero Money round-trips.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v moneyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v == (moneyJSON{}) {
+		*m = Money{}
+		return nil
+	}
+	parsed, err := NewMoney(v.Amount, v.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// addInt64 returns a + b and whether it did not overflow.
+func addInt64(a, b int64) (int64, bool) {
+	sum := a + b
+	return sum, (sum > a) == (b > 0)
+}
+
+// mulInt64 returns a * b and whether it did not overflow.
+func mulInt64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	product := a * b
+	if (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) || product/b != a {
+		return 0, false
+	}
+	return product, true
+}
+
+func absInt64(n int64) uint64 {
+	if n < 0 {
+		return uint64(-(n + 1)) + 1
+	}
+	return uint64(n)
+}
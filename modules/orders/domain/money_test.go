@@ -0,0 +1,243 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+)
+
+func usd(amount int64) domain.Money { return domain.MustNewMoney(amount, "USD") }
+
+func amounts(ms []domain.Money) []int64 {
+	out := make([]int64, len(ms))
+	for i, m := range ms {
+		out[i] = m.Amount()
+	}
+	return out
+}
+
+func TestMoney_AddOverflow(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    int64
+		want    int64
+		wantErr error
+	}{
+		{"sum", 150, 250, 400, nil},
+		{"negative", -150, 50, -100, nil},
+		{"max", math.MaxInt64 - 1, 1, math.MaxInt64, nil},
+		{"overflow", math.MaxInt64, 1, 0, domain.ErrMoneyOverflow},
+		{"underflow", math.MinInt64, -1, 0, domain.ErrMoneyOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := usd(tt.a).Add(usd(tt.b))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Add() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.Amount() != tt.want {
+				t.Errorf("Add() = %d, want %d", got.Amount(), tt.want)
+			}
+		})
+	}
+
+	if _, err := usd(1).Add(domain.MustNewMoney(1, "EUR")); err == nil {
+		t.Error("Add() of different currencies succeeded")
+	}
+}
+
+func TestMoney_SubtractOverflow(t *testing.T) {
+	if got, err := usd(100).Subtract(usd(250)); err != nil || got.Amount() != -150 {
+		t.Errorf("Subtract() = %d, %v, want -150", got.Amount(), err)
+	}
+	if _, err := usd(0).Subtract(usd(math.MinInt64)); !errors.Is(err, domain.ErrMoneyOverflow) {
+		t.Errorf("Subtract(MinInt64) error = %v, want ErrMoneyOverflow", err)
+	}
+	if _, err := usd(math.MinInt64).Subtract(usd(1)); !errors.Is(err, domain.ErrMoneyOverflow) {
+		t.Errorf("Subtract() below MinInt64 error = %v, want ErrMoneyOverflow", err)
+	}
+}
+
+func TestMoney_MultiplyOverflow(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount, f int64
+		want      int64
+		wantErr   error
+	}{
+		{"product", 250, 3, 750, nil},
+		{"zero", math.MaxInt64, 0, 0, nil},
+		{"negative", 250, -2, -500, nil},
+		{"max", math.MaxInt64, 1, math.MaxInt64, nil},
+		{"overflow", math.MaxInt64/2 + 1, 2, 0, domain.ErrMoneyOverflow},
+		{"negative overflow", math.MinInt64, -1, 0, domain.ErrMoneyOverflow},
+		{"negative overflow reversed", -1, math.MinInt64, 0, domain.ErrMoneyOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := usd(tt.amount).Multiply(tt.f)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Multiply() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.Amount() != tt.want {
+				t.Errorf("Multiply() = %d, want %d", got.Amount(), tt.want)
+			}
+		})
+	}
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount int64
+		ratios []int64
+		want   []int64
+	}{
+		{"even", 100, []int64{1, 1}, []int64{50, 50}},
+		{"remainder to the first shares", 100, []int64{1, 1, 1}, []int64{34, 33, 33}},
+		{"proportional", 1000, []int64{70, 20, 10}, []int64{700, 200, 100}},
+		{"line subtotals", 100, []int64{2500, 500, 1999}, []int64{51, 10, 39}},
+		{"zero ratio gets nothing", 5, []int64{0, 1, 1}, []int64{0, 3, 2}},
+		{"negative amount", -100, []int64{1, 1, 1}, []int64{-34, -33, -33}},
+		{"zero amount", 0, []int64{3, 7}, []int64{0, 0}},
+		{"no overflow of the product", math.MaxInt64, []int64{math.MaxInt64 / 2, math.MaxInt64 / 2}, []int64{math.MaxInt64/2 + 1, math.MaxInt64 / 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares, err := usd(tt.amount).Allocate(tt.ratios...)
+			if err != nil {
+				t.Fatalf("Allocate() error = %v", err)
+			}
+			if got := amounts(shares); !slices.Equal(got, tt.want) {
+				t.Errorf("Allocate() = %v, want %v", got, tt.want)
+			}
+			var sum int64
+			for _, s := range shares {
+				sum += s.Amount()
+				if s.Currency() != "USD" {
+					t.Errorf("share currency = %s, want USD", s.Currency())
+				}
+			}
+			if sum != tt.amount {
+				t.Errorf("shares sum to %d, want %d", sum, tt.amount)
+			}
+		})
+	}
+}
+
+func TestMoney_AllocateInvalidRatios(t *testing.T) {
+	for _, ratios := range [][]int64{nil, {0, 0}, {1, -1}, {math.MaxInt64, 1}} {
+		if _, err := usd(100).Allocate(ratios...); err == nil {
+			t.Errorf("Allocate(%v) succeeded, want an error", ratios)
+		}
+	}
+}
+
+func TestMoney_Split(t *testing.T) {
+	shares, err := usd(1000).Split(3)
+	if err != nil {
+		t.Fatalf("Split() error = %v", err)
+	}
+	if got := amounts(shares); !slices.Equal(got, []int64{334, 333, 333}) {
+		t.Errorf("Split(3) = %v, want [334 333 333]", got)
+	}
+	if _, err := usd(1000).Split(0); err == nil {
+		t.Error("Split(0) succeeded, want an error")
+	}
+}
+
+func TestCurrencyExponent(t *testing.T) {
+	for currency, want := range map[string]int{"USD": 2, "EUR": 2, "JPY": 0, "KRW": 0, "KWD": 3, "BHD": 3} {
+		if got := domain.CurrencyExponent(currency); got != want {
+			t.Errorf("CurrencyExponent(%s) = %d, want %d", currency, got, want)
+		}
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		money domain.Money
+		want  string
+	}{
+		{usd(1999), "19.99 USD"},
+		{usd(5), "0.05 USD"},
+		{usd(-250), "-2.50 USD"},
+		{usd(math.MinInt64), "-92233720368547758.08 USD"},
+		{domain.MustNewMoney(1999, "JPY"), "1999 JPY"},
+		{domain.MustNewMoney(1500, "KWD"), "1.500 KWD"},
+	}
+	for _, tt := range tests {
+		if got := tt.money.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestMoney_Format(t *testing.T) {
+	tests := []struct {
+		money  domain.Money
+		locale string
+		want   string
+	}{
+		{usd(1234), "en-US", "12.34 USD"},
+		{usd(123456789), "en", "1,234,567.89 USD"},
+		{usd(-100000), "en-US", "-1,000.00 USD"},
+		{usd(5), "en-US", "0.05 USD"},
+		{domain.MustNewMoney(1234, "JPY"), "en-US", "1,234 JPY"},
+		{domain.MustNewMoney(1234, "JPY"), "ja-JP", "1,234円"},
+		{domain.MustNewMoney(1234567, "JPY"), "ja", "1,234,567円"},
+		{usd(123456), "ja-JP", "1,234.56 USD"},
+		{domain.MustNewMoney(1234500, "KWD"), "en", "1,234.500 KWD"},
+		{domain.MustNewMoney(999, "EUR"), "fr-FR", "9.99 EUR"}, // unsupported languages fall back to English
+	}
+	for _, tt := range tests {
+		if got := tt.money.Format(tt.locale); got != tt.want {
+			t.Errorf("Format(%s) = %q, want %q", tt.locale, got, tt.want)
+		}
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(usd(1999))
+	if err != nil || string(data) != `{"amount":1999,"currency":"USD"}` {
+		t.Fatalf("Marshal() = %s, %v", data, err)
+	}
+	var got domain.Money
+	if err := json.Unmarshal(data, &got); err != nil || !got.Equals(usd(1999)) {
+		t.Errorf("Unmarshal() = %v, %v, want 19.99 USD", got, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"amount":100,"currency":"ABC"}`), &got); !errors.Is(err, domain.ErrCurrencyInvalid) {
+		t.Errorf("Unmarshal() of unknown currency error = %v, want ErrCurrencyInvalid", err)
+	}
+	var zero domain.Money
+	if err := json.Unmarshal([]byte(`{"amount":0,"currency":""}`), &zero); err != nil || zero != (domain.Money{}) {
+		t.Errorf("Unmarshal() of zero Money = %v, %v", zero, err)
+	}
+}
+
+func TestOrderID_TextAndSpanner(t *testing.T) {
+	id, _ := domain.ParseOrderID("a3bb189e-8bf9-3888-9912-ace4e6543002")
+
+	data, err := json.Marshal(map[string]domain.OrderID{"order_id": id})
+	if err != nil || string(data) != `{"order_id":"a3bb189e-8bf9-3888-9912-ace4e6543002"}` {
+		t.Fatalf("Marshal() = %s, %v", data, err)
+	}
+	var got map[string]domain.OrderID
+	if err := json.Unmarshal(data, &got); err != nil || got["order_id"] != id {
+		t.Errorf("Unmarshal() = %v, %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(`"nope"`), new(domain.OrderID)); !errors.Is(err, domain.ErrInvalidOrderID) {
+		t.Errorf("Unmarshal(malformed) error = %v, want ErrInvalidOrderID", err)
+	}
+
+	encoded, _ := id.EncodeSpanner()
+	var decoded domain.OrderID
+	if err := decoded.DecodeSpanner(encoded); err != nil || decoded != id {
+		t.Errorf("DecodeSpanner() = %v, %v, want %s", decoded, err, id)
+	}
+}
@@ -3,9 +3,14 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"time"
+	"unicode/utf8"
 
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/invariant"
 )
 
 // Order is the aggregate root for the order bounded context.
@@ -17,8 +22,31 @@ type Order struct {
 	total     Money
 	createdAt time.Time
 	updatedAt time.Time
+
+	shippingAddress      ShippingAddress
+	deliveryInstructions string
+	discount             Discount
+	promotions           PromotionBreakdown
+	tax                  TaxBreakdown
+	returnReason         string
+	cancelReason         string
+	cancelledBy          Actor
+	snapshot             OrderSnapshot // set once on submit
+
+	changes []events.Event // raised since the order was loaded or its changes saved
 }
 
+var _ invariant.Validatable = (*Order)(nil)
+
+const (
+	// maxDeliveryInstructionsLength bounds free-form delivery instructions.
+	maxDeliveryInstructionsLength = 500
+	// maxReturnReasonLength bounds the free-form reason given for a return.
+	maxReturnReasonLength = 500
+	// maxCancelReasonLength bounds the free-form reason given for a cancellation.
+	maxCancelReasonLength = 500
+)
+
 // OrderItem represents a line item in an order.
 type OrderItem struct {
 	ProductID   string
@@ -27,24 +55,41 @@ type OrderItem struct {
 	UnitPrice   Money
 }
 
+// Subtotal returns the line's price. Lines are checked not to overflow when
+// they are added or changed (see replaceItems).
 func (i OrderItem) Subtotal() Money {
-	return i.UnitPrice.Multiply(int64(i.Quantity))
+	return Money{amount: i.UnitPrice.amount * int64(i.Quantity), currency: i.UnitPrice.currency}
 }
 
-// NewOrder creates a new order for a user.
+// NewOrder creates a new order for a user in the given currency.
+// All items added to the order must be priced in the same currency.
 // Adds OrderCreatedEvent to the context for later dispatch.
-func NewOrder(ctx context.Context, userRef UserRef) *Order {
+func NewOrder(ctx context.Context, userRef UserRef, currency string) (*Order, error) {
+	return NewOrderWithID(ctx, NewOrderID(ctx), userRef, currency)
+}
+
+// NewOrderWithID is NewOrder with a caller-chosen ID, e.g. a deterministic
+// one for fixtures. A zero userRef creates a guest order, which must be
+// claimed by a user (Claim) before it is submitted.
+func NewOrderWithID(ctx context.Context, id OrderID, userRef UserRef, currency string) (*Order, error) {
+	currency, err := ParseCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	now := clock.Now(ctx)
 	o := &Order{
-		id:        NewOrderID(),
+		id:        id,
 		userRef:   userRef,
 		items:     make([]OrderItem, 0),
 		status:    StatusDraft,
-		total:     MustNewMoney(0, "USD"),
-		createdAt: time.Now().UTC(),
-		updatedAt: time.Now().UTC(),
+		total:     Money{amount: 0, currency: currency},
+		createdAt: now,
+		updatedAt: now,
 	}
-	events.Add(ctx, NewOrderCreatedEvent(o))
-	return o
+	o.raise(ctx, NewOrderCreatedEvent(ctx, o))
+	o.raise(ctx, NewOrderStatusChangedEvent(ctx, o, "", UserActor(userRef).String(), ""))
+	return o, nil
 }
 
 // Reconstitute rebuilds an order from persistence.
@@ -54,16 +99,34 @@ func Reconstitute(
 	items []OrderItem,
 	status Status,
 	total Money,
+	shippingAddress ShippingAddress,
+	deliveryInstructions string,
+	discount Discount,
+	promotions PromotionBreakdown,
+	tax TaxBreakdown,
+	returnReason string,
+	cancelReason string,
+	cancelledBy Actor,
+	snapshot OrderSnapshot,
 	createdAt, updatedAt time.Time,
 ) *Order {
 	return &Order{
-		id:        id,
-		userRef:   userRef,
-		items:     items,
-		status:    status,
-		total:     total,
-		createdAt: createdAt,
-		updatedAt: updatedAt,
+		id:                   id,
+		userRef:              userRef,
+		items:                items,
+		status:               status,
+		total:                total,
+		shippingAddress:      shippingAddress,
+		deliveryInstructions: deliveryInstructions,
+		discount:             discount,
+		promotions:           promotions,
+		tax:                  tax,
+		returnReason:         returnReason,
+		cancelReason:         cancelReason,
+		cancelledBy:          cancelledBy,
+		snapshot:             snapshot,
+		createdAt:            createdAt,
+		updatedAt:            updatedAt,
 	}
 }
 
@@ -71,123 +134,522 @@ func Reconstitute(
 
 func (o *Order) ID() OrderID          { return o.id }
 func (o *Order) UserRef() UserRef     { return o.userRef }
+func (o *Order) IsGuest() bool        { return o.userRef.IsZero() }
 func (o *Order) Items() []OrderItem   { return o.items }
 func (o *Order) Status() Status       { return o.status }
 func (o *Order) Total() Money         { return o.total }
+func (o *Order) Currency() string     { return o.total.currency }
 func (o *Order) CreatedAt() time.Time { return o.createdAt }
 func (o *Order) UpdatedAt() time.Time { return o.updatedAt }
 
+func (o *Order) ShippingAddress() ShippingAddress { return o.shippingAddress }
+func (o *Order) DeliveryInstructions() string     { return o.deliveryInstructions }
+func (o *Order) Discount() Discount               { return o.discount }
+func (o *Order) Promotions() PromotionBreakdown   { return o.promotions }
+func (o *Order) Tax() TaxBreakdown                { return o.tax }
+func (o *Order) ReturnReason() string             { return o.returnReason }
+func (o *Order) CancelReason() string             { return o.cancelReason }
+func (o *Order) CancelledBy() Actor               { return o.cancelledBy }
+func (o *Order) Snapshot() OrderSnapshot          { return o.snapshot }
+
+// CheckUnchanged returns ErrOrderModified if the order has been updated
+// since updatedAt, the version a client last read. The zero time matches
+// any version.
+func (o *Order) CheckUnchanged(updatedAt time.Time) error {
+	if !updatedAt.IsZero() && !o.updatedAt.Equal(updatedAt) {
+		return ErrOrderModified
+	}
+	return nil
+}
+
+// ItemCount returns the total quantity across all line items.
+func (o *Order) ItemCount() int {
+	var count int
+	for _, item := range o.items {
+		count += item.Quantity
+	}
+	return count
+}
+
+// Subtotal returns the sum of all line items before discounts.
+func (o *Order) Subtotal() Money {
+	var amount int64
+	for _, item := range o.items {
+		amount += item.Subtotal().Amount()
+	}
+	return Money{amount: amount, currency: o.Currency()}
+}
+
+// DiscountAmount returns how much the applied discount takes off the subtotal.
+func (o *Order) DiscountAmount() Money {
+	return o.discount.AmountFor(o.Subtotal())
+}
+
+// PromotionAmount returns how much the applied promotions take off the order.
+func (o *Order) PromotionAmount() Money {
+	return o.promotions.Total(o.Currency())
+}
+
+// TaxableAmount returns the subtotal after discounts and promotions, which
+// taxes are applied to.
+func (o *Order) TaxableAmount() Money {
+	return Money{amount: o.discountedSubtotal().Amount() - o.PromotionAmount().Amount(), currency: o.Currency()}
+}
+
+// discountedSubtotal returns the subtotal after the discount code.
+func (o *Order) discountedSubtotal() Money {
+	subtotal := o.Subtotal()
+	return Money{amount: subtotal.Amount() - o.discount.AmountFor(subtotal).Amount(), currency: subtotal.Currency()}
+}
+
+// TaxAmount returns the total tax applied to the order.
+func (o *Order) TaxAmount() Money {
+	return o.tax.Total(o.Currency())
+}
+
 // Business methods
 
-// AddItem adds an item to the order.
-func (o *Order) AddItem(productID, productName string, quantity int, unitPrice Money) error {
-	if o.status != StatusDraft {
-		return ErrOrderNotDraft
+// AddItem adds an item to the order, or increases the quantity of an existing line.
+// The unit price must be in the order currency, and the resulting order must stay within limits.
+func (o *Order) AddItem(ctx context.Context, limits OrderLimits, productID, productName string, quantity int, unitPrice Money) error {
+	if _, err := o.next("edit"); err != nil {
+		return err
 	}
 	if quantity <= 0 {
 		return ErrInvalidQuantity
 	}
+	if unitPrice.Currency() != o.Currency() {
+		return ErrCurrencyMismatch
+	}
 
+	items := slices.Clone(o.items)
 	// Check if product already exists, update quantity
-	for i, item := range o.items {
-		if item.ProductID == productID {
-			o.items[i].Quantity += quantity
-			o.recalculateTotal()
-			o.updatedAt = time.Now().UTC()
-			return nil
-		}
+	if i := o.itemIndex(productID); i >= 0 {
+		items[i].Quantity += quantity
+	} else {
+		items = append(items, OrderItem{
+			ProductID:   productID,
+			ProductName: productName,
+			Quantity:    quantity,
+			UnitPrice:   unitPrice,
+		})
 	}
+	return o.replaceItems(ctx, limits, items)
+}
 
-	// Add new item
-	o.items = append(o.items, OrderItem{
-		ProductID:   productID,
-		ProductName: productName,
-		Quantity:    quantity,
-		UnitPrice:   unitPrice,
-	})
+// RemoveItem removes an item from the order.
+func (o *Order) RemoveItem(ctx context.Context, productID string) error {
+	if _, err := o.next("edit"); err != nil {
+		return err
+	}
+
+	i := o.itemIndex(productID)
+	if i < 0 {
+		return ErrItemNotFound
+	}
+	o.items = slices.Delete(o.items, i, i+1)
+	o.itemsChanged(ctx)
+	return nil
+}
+
+// UpdateItemQuantity sets the quantity of an existing line item.
+// The resulting order must stay within limits.
+func (o *Order) UpdateItemQuantity(ctx context.Context, limits OrderLimits, productID string, quantity int) error {
+	if _, err := o.next("edit"); err != nil {
+		return err
+	}
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+
+	i := o.itemIndex(productID)
+	if i < 0 {
+		return ErrItemNotFound
+	}
+	items := slices.Clone(o.items)
+	items[i].Quantity = quantity
+	return o.replaceItems(ctx, limits, items)
+}
+
+// SetShipping sets the delivery address and optional instructions.
+// Shipping details can only be changed while the order is a draft.
+// Adds OrderShippingChangedEvent to the context for later dispatch.
+func (o *Order) SetShipping(ctx context.Context, address ShippingAddress, instructions string) error {
+	if _, err := o.next("edit"); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(instructions) > maxDeliveryInstructionsLength {
+		return ErrDeliveryInstructionsTooLong
+	}
+
+	o.shippingAddress = address
+	o.deliveryInstructions = instructions
+	o.updatedAt = clock.Now(ctx)
+	o.raise(ctx, NewOrderShippingChangedEvent(ctx, o))
+	return nil
+}
+
+// ApplyDiscount attaches a discount line to the order and recalculates the total.
+// Only one discount can be applied at a time.
+// Adds OrderDiscountAppliedEvent to the context for later dispatch.
+func (o *Order) ApplyDiscount(ctx context.Context, discount Discount) error {
+	if _, err := o.next("edit"); err != nil {
+		return err
+	}
+	if !o.discount.IsZero() {
+		return ErrDiscountAlreadyApplied
+	}
+	if discount.Kind() == DiscountFixedAmount && discount.AmountOff().Currency() != o.Currency() {
+		return ErrDiscountCurrencyMismatch
+	}
+
+	o.discount = discount
 	o.recalculateTotal()
-	o.updatedAt = time.Now().UTC()
+	o.updatedAt = clock.Now(ctx)
+	o.raise(ctx, NewOrderDiscountAppliedEvent(ctx, o))
 	return nil
 }
 
-// RemoveItem removes an item from the order.
-func (o *Order) RemoveItem(productID string) error {
-	if o.status != StatusDraft {
-		return ErrOrderNotDraft
+// RemoveDiscount removes the discount with the given code and recalculates the total.
+// Adds OrderDiscountRemovedEvent to the context for later dispatch.
+func (o *Order) RemoveDiscount(ctx context.Context, code string) error {
+	if _, err := o.next("edit"); err != nil {
+		return err
+	}
+	if o.discount.IsZero() || o.discount.Code() != NormalizeDiscountCode(code) {
+		return ErrDiscountNotApplied
 	}
 
-	for i, item := range o.items {
-		if item.ProductID == productID {
-			o.items = append(o.items[:i], o.items[i+1:]...)
-			o.recalculateTotal()
-			o.updatedAt = time.Now().UTC()
-			return nil
-		}
+	removed := o.discount
+	o.discount = Discount{}
+	o.recalculateTotal()
+	o.updatedAt = clock.Now(ctx)
+	o.raise(ctx, NewOrderDiscountRemovedEvent(ctx, o, removed))
+	return nil
+}
+
+// Claim gives a guest order to the user referenced by userRef, e.g. once the
+// guest has signed up. Only a draft can be claimed, which every guest order
+// is until it is cancelled, as it cannot be submitted.
+// Adds OrderClaimedEvent to the context for later dispatch.
+func (o *Order) Claim(ctx context.Context, userRef UserRef) error {
+	if _, err := o.next("edit"); err != nil {
+		return err
+	}
+	if !o.IsGuest() {
+		return ErrOrderAlreadyClaimed
 	}
-	return ErrItemNotFound
+	if userRef.IsZero() {
+		return ErrInvalidUserRef
+	}
+
+	o.userRef = userRef
+	o.updatedAt = clock.Now(ctx)
+	o.raise(ctx, NewOrderClaimedEvent(ctx, o))
+	return nil
 }
 
-// Submit submits the order for processing.
+// Submit submits the order for processing. A guest order must be claimed
+// first.
+// Promotions found by the engine (nil for none) are taken off the discounted
+// subtotal, then taxes are calculated with the given calculator and added to
+// the total, which must stay within limits. The submitted lines and amounts
+// are frozen into an OrderSnapshot.
 // Adds OrderSubmittedEvent to the context for later dispatch.
-func (o *Order) Submit(ctx context.Context) error {
-	if o.status != StatusDraft {
-		return ErrOrderNotDraft
+func (o *Order) Submit(ctx context.Context, promotions PromotionEngine, taxes TaxCalculator, limits OrderLimits) error {
+	next, err := o.next("submit")
+	if err != nil {
+		return err
+	}
+	if o.IsGuest() {
+		return ErrOrderUnclaimed
 	}
 	if len(o.items) == 0 {
 		return ErrOrderEmpty
 	}
+	// Limits may have been lowered since the items were added.
+	if err := limits.checkItems(o.items); err != nil {
+		return err
+	}
+
+	o.promotions = PromotionBreakdown{}
+	if promotions != nil {
+		lines, err := promotions.Evaluate(ctx, PromotionCart{
+			OrderID:  o.id,
+			UserRef:  o.userRef,
+			Items:    slices.Clone(o.items),
+			Subtotal: o.discountedSubtotal(),
+			At:       clock.Now(ctx),
+		})
+		if err != nil {
+			return fmt.Errorf("evaluating promotions: %w", err)
+		}
+		applied, err := capPromotions(lines, o.discountedSubtotal())
+		if err != nil {
+			return err
+		}
+		o.promotions = applied
+	}
+
+	tax, err := taxes.Calculate(ctx, o)
+	if err != nil {
+		return fmt.Errorf("calculating tax: %w", err)
+	}
+	for _, line := range tax.Lines() {
+		if line.Amount.Currency() != o.Currency() {
+			return ErrTaxCurrencyMismatch
+		}
+	}
+	if err := limits.checkTotal(o.TaxableAmount().Amount() + tax.Total(o.Currency()).Amount()); err != nil {
+		return err
+	}
 
-	o.status = StatusPending
-	o.updatedAt = time.Now().UTC()
-	events.Add(ctx, NewOrderSubmittedEvent(o))
+	o.tax = tax
+	o.recalculateTotal()
+	o.transition(ctx, next, UserActor(o.userRef), "")
+	o.snapshot = newOrderSnapshot(o, o.updatedAt)
+	o.raise(ctx, NewOrderSubmittedEvent(ctx, o))
 	return nil
 }
 
 // Confirm confirms the order.
-func (o *Order) Confirm() error {
-	if o.status != StatusPending {
-		return ErrOrderNotPending
+// Adds OrderStatusChangedEvent and OrderConfirmedEvent to the context for later dispatch.
+func (o *Order) Confirm(ctx context.Context) error {
+	next, err := o.next("confirm")
+	if err != nil {
+		return err
 	}
 
-	o.status = StatusConfirmed
-	o.updatedAt = time.Now().UTC()
+	o.transition(ctx, next, Actor{}, "")
+	o.raise(ctx, NewOrderConfirmedEvent(ctx, o))
 	return nil
 }
 
-// Cancel cancels the order.
+// Cancel cancels the order on behalf of actor, recording the optional reason.
 // Adds OrderCancelledEvent to the context for later dispatch.
-func (o *Order) Cancel(ctx context.Context) error {
-	if o.status == StatusCancelled {
-		return ErrOrderAlreadyCancelled
+func (o *Order) Cancel(ctx context.Context, actor Actor, reason string) error {
+	next, err := o.next("cancel")
+	if err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(reason) > maxCancelReasonLength {
+		return ErrCancelReasonTooLong
+	}
+
+	o.cancelReason = reason
+	o.cancelledBy = actor
+	o.transition(ctx, next, actor, reason)
+	o.raise(ctx, NewOrderCancelledEvent(ctx, o))
+	return nil
+}
+
+// Reopen returns a cancelled order that was never submitted to draft, e.g.
+// when the deletion of its user that cancelled it is undone. A submitted
+// order cannot be reopened: its payment and stock reservation were released
+// when it was cancelled.
+// Adds OrderStatusChangedEvent to the context for later dispatch.
+func (o *Order) Reopen(ctx context.Context, actor Actor, reason string) error {
+	next, err := o.next("reopen")
+	if err != nil {
+		return err
+	}
+	if !o.snapshot.IsZero() {
+		return ErrOrderNotReopenable
+	}
+
+	o.cancelReason = ""
+	o.cancelledBy = Actor{}
+	o.transition(ctx, next, actor, reason)
+	return nil
+}
+
+// Expire cancels a draft order that has not been touched since cutoff.
+// It is invoked by the draft expiry job rather than by a user.
+// Adds OrderStatusChangedEvent and OrderExpiredEvent to the context for later dispatch.
+func (o *Order) Expire(ctx context.Context, cutoff time.Time) error {
+	next, err := o.next("expire")
+	if err != nil {
+		return err
 	}
-	if o.status == StatusCompleted {
-		return ErrOrderCompleted
+	if !o.updatedAt.Before(cutoff) {
+		return ErrOrderNotStale
 	}
 
-	o.status = StatusCancelled
-	o.updatedAt = time.Now().UTC()
-	events.Add(ctx, NewOrderCancelledEvent(o))
+	lastUpdatedAt := o.updatedAt
+	o.cancelReason = "expired"
+	o.cancelledBy = SystemActor("draft-expiry")
+	o.transition(ctx, next, o.cancelledBy, o.cancelReason)
+	o.raise(ctx, NewOrderExpiredEvent(ctx, o, lastUpdatedAt))
 	return nil
 }
 
 // Complete marks the order as completed.
-func (o *Order) Complete() error {
-	if o.status != StatusConfirmed {
-		return ErrOrderNotConfirmed
+// Adds OrderStatusChangedEvent and OrderCompletedEvent to the context for later dispatch.
+func (o *Order) Complete(ctx context.Context) error {
+	next, err := o.next("complete")
+	if err != nil {
+		return err
 	}
 
-	o.status = StatusCompleted
-	o.updatedAt = time.Now().UTC()
+	o.transition(ctx, next, Actor{}, "")
+	o.raise(ctx, NewOrderCompletedEvent(ctx, o))
 	return nil
 }
 
-func (o *Order) recalculateTotal() {
-	var total int64
-	currency := "USD"
+// RequestReturn starts the return sub-flow of a completed order.
+// Adds OrderStatusChangedEvent to the context for later dispatch.
+func (o *Order) RequestReturn(ctx context.Context, reason string) error {
+	next, err := o.next("request_return")
+	if err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(reason) > maxReturnReasonLength {
+		return ErrReturnReasonTooLong
+	}
+
+	o.returnReason = reason
+	o.transition(ctx, next, UserActor(o.userRef), reason)
+	return nil
+}
+
+// Refund issues a refund of the order total for a requested return.
+// Adds OrderStatusChangedEvent and RefundIssuedEvent to the context for later dispatch;
+// the payments side reacts to RefundIssuedEvent to move the money.
+func (o *Order) Refund(ctx context.Context, actor Actor) error {
+	next, err := o.next("refund")
+	if err != nil {
+		return err
+	}
+
+	o.transition(ctx, next, actor, o.returnReason)
+	o.raise(ctx, NewRefundIssuedEvent(ctx, o))
+	return nil
+}
+
+// Validate checks the invariants every order must hold, whatever sequence of
+// business methods produced it: a known status and currency, distinct line
+// items with positive quantities in the order currency, a total that matches
+// its lines, discount, promotions and tax, and items on every order that got
+// past draft. Errors wrap ErrOrderInvariant or ErrStatusInvalid.
+func (o *Order) Validate() error {
+	if !o.status.IsValid() {
+		return fmt.Errorf("%w: %q", ErrStatusInvalid, o.status)
+	}
+	if currency, err := ParseCurrency(o.Currency()); err != nil || currency != o.Currency() {
+		return fmt.Errorf("%w: currency %q", ErrOrderInvariant, o.Currency())
+	}
+
+	seen := make(map[string]bool, len(o.items))
 	for _, item := range o.items {
-		subtotal := item.Subtotal()
-		total += subtotal.Amount()
-		currency = subtotal.Currency()
+		switch {
+		case seen[item.ProductID]:
+			return fmt.Errorf("%w: duplicate line for product %q", ErrOrderInvariant, item.ProductID)
+		case item.Quantity <= 0:
+			return fmt.Errorf("%w: quantity %d for product %q", ErrOrderInvariant, item.Quantity, item.ProductID)
+		case item.UnitPrice.Currency() != o.Currency():
+			return fmt.Errorf("%w: product %q priced in %s", ErrOrderInvariant, item.ProductID, item.UnitPrice.Currency())
+		}
+		seen[item.ProductID] = true
+	}
+
+	want := o.TaxableAmount().Amount() + o.TaxAmount().Amount()
+	if o.total.Amount() != want {
+		return fmt.Errorf("%w: total %d, want %d", ErrOrderInvariant, o.total.Amount(), want)
+	}
+	if len(o.items) == 0 && o.status != StatusDraft && o.status != StatusCancelled {
+		return fmt.Errorf("%w: %s order has no items", ErrOrderInvariant, o.status)
+	}
+	if o.updatedAt.Before(o.createdAt) {
+		return fmt.Errorf("%w: updated before created", ErrOrderInvariant)
+	}
+	return nil
+}
+
+// Changes returns the events the order raised since it was loaded, or since
+// ClearChanges. An event-sourced repository stores them as the order's
+// history (see ReplayOrder).
+func (o *Order) Changes() []events.Event { return o.changes }
+
+// ClearChanges forgets the changes once a repository has stored them.
+func (o *Order) ClearChanges() { o.changes = nil }
+
+// raise records event as a change of the order and adds it to ctx for
+// dispatch.
+func (o *Order) raise(ctx context.Context, event events.Event) {
+	o.changes = append(o.changes, event)
+	events.Add(ctx, event)
+}
+
+// next returns the status the named transition of OrderLifecycle leads to
+// from the order's status, or why the order does not allow it.
+func (o *Order) next(transition string) (Status, error) {
+	return OrderLifecycle.Next(o.status, transition)
+}
+
+// transition moves the order to the given status and records the change
+// as an OrderStatusChangedEvent, which feeds the status history.
+func (o *Order) transition(ctx context.Context, to Status, actor Actor, reason string) {
+	from := o.status
+	o.status = to
+	o.updatedAt = clock.Now(ctx)
+	o.raise(ctx, NewOrderStatusChangedEvent(ctx, o, from, actor.String(), reason))
+}
+
+func (o *Order) itemIndex(productID string) int {
+	return slices.IndexFunc(o.items, func(item OrderItem) bool { return item.ProductID == productID })
+}
+
+// replaceItems swaps in the changed line items if they keep the order within
+// limits; otherwise the order is left untouched.
+func (o *Order) replaceItems(ctx context.Context, limits OrderLimits, items []OrderItem) error {
+	if err := limits.checkItems(items); err != nil {
+		return err
 	}
-	o.total = MustNewMoney(total, currency)
+	if err := checkSubtotal(items, o.Currency()); err != nil {
+		return err
+	}
+
+	prevItems, prevTotal := o.items, o.total
+	o.items = items
+	o.recalculateTotal()
+	if err := limits.checkTotal(o.total.Amount()); err != nil {
+		o.items, o.total = prevItems, prevTotal
+		return err
+	}
+
+	o.itemsChanged(ctx)
+	return nil
+}
+
+// checkSubtotal returns ErrMoneyOverflow if the lines' subtotal does not
+// fit in an int64, so that the unchecked arithmetic of the totals is safe.
+func checkSubtotal(items []OrderItem, currency string) error {
+	subtotal := Money{currency: currency}
+	for _, item := range items {
+		line, err := item.UnitPrice.Multiply(int64(item.Quantity))
+		if err != nil {
+			return err
+		}
+		if subtotal, err = subtotal.Add(line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// itemsChanged recalculates the total after a line item change and records it
+// as an OrderItemsChangedEvent.
+func (o *Order) itemsChanged(ctx context.Context) {
+	o.recalculateTotal()
+	o.updatedAt = clock.Now(ctx)
+	o.raise(ctx, NewOrderItemsChangedEvent(ctx, o))
+}
+
+func (o *Order) recalculateTotal() {
+	subtotal := o.Subtotal()
+	total := subtotal.Amount()
+	total -= o.discount.AmountFor(subtotal).Amount()
+	total -= o.promotions.Total(o.Currency()).Amount()
+	total += o.tax.Total(o.Currency()).Amount()
+	o.total = Money{amount: total, currency: o.Currency()}
 }
@@ -1,9 +1,13 @@
 package domain
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/ids"
 )
 
 // ErrInvalidOrderID indicates the order ID format is invalid.
@@ -14,8 +18,9 @@ type OrderID struct {
 	value string
 }
 
-func NewOrderID() OrderID {
-	return OrderID{value: uuid.New().String()}
+// NewOrderID generates an order ID with the generator in ctx (see ids.New).
+func NewOrderID(ctx context.Context) OrderID {
+	return OrderID{value: ids.New(ctx)}
 }
 
 func ParseOrderID(s string) (OrderID, error) {
@@ -27,3 +32,35 @@ func ParseOrderID(s string) (OrderID, error) {
 
 func (id OrderID) String() string { return id.value }
 func (id OrderID) IsZero() bool   { return id.value == "" }
+
+// MarshalText implements encoding.TextMarshaler, so that a OrderID can be used
+// in JSON request and response structs, where it is a string.
+func (id OrderID) MarshalText() ([]byte, error) { return []byte(id.value), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler. It returns
+// ErrInvalidOrderID for a malformed ID; the empty string is the zero OrderID.
+func (id *OrderID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*id = OrderID{}
+		return nil
+	}
+	parsed, err := ParseOrderID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// EncodeSpanner implements spanner.Encoder: a OrderID is stored as a STRING.
+func (id OrderID) EncodeSpanner() (any, error) { return id.value, nil }
+
+// DecodeSpanner implements spanner.Decoder, for reading a OrderID column
+// directly into a OrderID.
+func (id *OrderID) DecodeSpanner(input any) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("cannot decode %T into an order ID", input)
+	}
+	return id.UnmarshalText([]byte(s))
+}
@@ -0,0 +1,274 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"pgregory.net/rapid"
+)
+
+// legalTransitions is the order lifecycle the property tests check emitted
+// status changes against.
+var legalTransitions = map[domain.Status][]domain.Status{
+	"":                           {domain.StatusDraft},
+	domain.StatusDraft:           {domain.StatusPending, domain.StatusCancelled},
+	domain.StatusPending:         {domain.StatusConfirmed, domain.StatusCancelled},
+	domain.StatusConfirmed:       {domain.StatusCompleted, domain.StatusCancelled},
+	domain.StatusCompleted:       {domain.StatusReturnRequested},
+	domain.StatusReturnRequested: {domain.StatusRefunded},
+}
+
+// products and their unit prices are what the generated commands pick from;
+// a small catalog makes repeated and missing products likely.
+var (
+	products      = []string{"p-1", "p-2", "p-3", "p-4"}
+	productPrices = map[string]int64{"p-1": 500, "p-2": 1250, "p-3": 99, "p-4": 10000}
+)
+
+var propertyLimits = domain.OrderLimits{MaxDistinctItems: 3, MaxLineQuantity: 10}
+
+// orderMachine drives an order through random sequences of commands and
+// keeps a simple model of the expected lines and status.
+type orderMachine struct {
+	ctx    context.Context
+	order  *domain.Order
+	lines  map[string]int // product ID -> quantity
+	status domain.Status
+}
+
+func (m *orderMachine) AddItem(t *rapid.T) {
+	product := rapid.SampledFrom(products).Draw(t, "product")
+	quantity := rapid.IntRange(-1, 6).Draw(t, "quantity")
+
+	err := m.order.AddItem(m.ctx, propertyLimits, product, "Product "+product, quantity, domain.MustNewMoney(productPrices[product], "USD"))
+
+	var want error
+	switch {
+	case m.status != domain.StatusDraft:
+		want = domain.ErrOrderNotDraft
+	case quantity <= 0:
+		want = domain.ErrInvalidQuantity
+	case m.lines[product] == 0 && len(m.lines) == propertyLimits.MaxDistinctItems:
+		want = domain.ErrTooManyItems
+	case m.lines[product]+quantity > propertyLimits.MaxLineQuantity:
+		want = domain.ErrLineQuantityExceeded
+	}
+	if !errors.Is(err, want) {
+		t.Fatalf("AddItem(%s, %d) = %v, want %v", product, quantity, err, want)
+	}
+	if err == nil {
+		m.lines[product] += quantity
+	}
+}
+
+func (m *orderMachine) RemoveItem(t *rapid.T) {
+	product := rapid.SampledFrom(products).Draw(t, "product")
+
+	err := m.order.RemoveItem(m.ctx, product)
+
+	var want error
+	switch {
+	case m.status != domain.StatusDraft:
+		want = domain.ErrOrderNotDraft
+	case m.lines[product] == 0:
+		want = domain.ErrItemNotFound
+	}
+	if !errors.Is(err, want) {
+		t.Fatalf("RemoveItem(%s) = %v, want %v", product, err, want)
+	}
+	if err == nil {
+		delete(m.lines, product)
+	}
+}
+
+func (m *orderMachine) Submit(t *rapid.T) {
+	taxes := domain.FlatRateTaxCalculator{Name: "Sales tax", RateBps: rapid.Int64Range(0, 2500).Draw(t, "rateBps")}
+
+	err := m.order.Submit(m.ctx, nil, taxes, propertyLimits)
+
+	var want error
+	switch {
+	case m.status != domain.StatusDraft:
+		want = domain.ErrOrderNotDraft
+	case len(m.lines) == 0:
+		want = domain.ErrOrderEmpty
+	}
+	if !errors.Is(err, want) {
+		t.Fatalf("Submit() = %v, want %v", err, want)
+	}
+	if err == nil {
+		m.status = domain.StatusPending
+	}
+}
+
+func (m *orderMachine) Confirm(t *rapid.T) {
+	err := m.order.Confirm(m.ctx)
+
+	var want error
+	if m.status != domain.StatusPending {
+		want = domain.ErrOrderNotPending
+	}
+	if !errors.Is(err, want) {
+		t.Fatalf("Confirm() = %v, want %v", err, want)
+	}
+	if err == nil {
+		m.status = domain.StatusConfirmed
+	}
+}
+
+func (m *orderMachine) Complete(t *rapid.T) {
+	err := m.order.Complete(m.ctx)
+
+	var want error
+	if m.status != domain.StatusConfirmed {
+		want = domain.ErrOrderNotConfirmed
+	}
+	if !errors.Is(err, want) {
+		t.Fatalf("Complete() = %v, want %v", err, want)
+	}
+	if err == nil {
+		m.status = domain.StatusCompleted
+	}
+}
+
+func (m *orderMachine) Cancel(t *rapid.T) {
+	err := m.order.Cancel(m.ctx, domain.UserActor(m.order.UserRef()), "changed my mind")
+
+	var want error
+	switch m.status {
+	case domain.StatusCancelled:
+		want = domain.ErrOrderAlreadyCancelled
+	case domain.StatusCompleted, domain.StatusReturnRequested, domain.StatusRefunded:
+		want = domain.ErrOrderCompleted
+	}
+	if !errors.Is(err, want) {
+		t.Fatalf("Cancel() in %s = %v, want %v", m.status, err, want)
+	}
+	if err == nil {
+		m.status = domain.StatusCancelled
+	}
+}
+
+// Check runs after every command.
+func (m *orderMachine) Check(t *rapid.T) {
+	if err := m.order.Validate(); err != nil {
+		t.Fatalf("invariant violated: %v", err)
+	}
+	if m.order.Status() != m.status {
+		t.Fatalf("status = %s, want %s", m.order.Status(), m.status)
+	}
+
+	var subtotal int64
+	for product, quantity := range m.lines {
+		subtotal += productPrices[product] * int64(quantity)
+	}
+	if got := m.order.Subtotal().Amount(); got != subtotal {
+		t.Fatalf("subtotal = %d, want %d", got, subtotal)
+	}
+	if got := len(m.order.Items()); got != len(m.lines) {
+		t.Fatalf("%d line items, want %d", got, len(m.lines))
+	}
+}
+
+func TestOrder_Properties(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		captured, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+			userRef, err := domain.NewUserRef(uuid.NewString())
+			if err != nil {
+				return err
+			}
+			order, err := domain.NewOrder(ctx, userRef, "USD")
+			if err != nil {
+				return err
+			}
+			m := &orderMachine{
+				ctx:    ctx,
+				order:  order,
+				lines:  make(map[string]int),
+				status: domain.StatusDraft,
+			}
+			t.Repeat(map[string]func(*rapid.T){
+				"AddItem":    m.AddItem,
+				"RemoveItem": m.RemoveItem,
+				"Submit":     m.Submit,
+				"Confirm":    m.Confirm,
+				"Complete":   m.Complete,
+				"Cancel":     m.Cancel,
+				"":           m.Check,
+			})
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if err := checkTransitions(captured); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestOrder_Validate(t *testing.T) {
+	id := domain.NewOrderID(context.Background())
+	userRef, err := domain.NewUserRef(uuid.NewString())
+	if err != nil {
+		t.Fatalf("failed to create user ref: %v", err)
+	}
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	usd := func(amount int64) domain.Money { return domain.MustNewMoney(amount, "USD") }
+	item := func(product string, quantity int, price domain.Money) domain.OrderItem {
+		return domain.OrderItem{ProductID: product, ProductName: product, Quantity: quantity, UnitPrice: price}
+	}
+	order := func(status domain.Status, total domain.Money, items ...domain.OrderItem) *domain.Order {
+		return domain.Reconstitute(id, userRef, items, status, total, domain.ShippingAddress{}, "", domain.Discount{},
+			domain.PromotionBreakdown{}, domain.TaxBreakdown{}, "", "", domain.Actor{}, domain.OrderSnapshot{}, now, now)
+	}
+
+	tests := []struct {
+		name  string
+		order *domain.Order
+		want  error
+	}{
+		{"valid", order(domain.StatusPending, usd(1500), item("p-1", 3, usd(500))), nil},
+		{"empty draft", order(domain.StatusDraft, usd(0)), nil},
+		{"unknown status", order("shipped", usd(0)), domain.ErrStatusInvalid},
+		{"total mismatch", order(domain.StatusDraft, usd(1000), item("p-1", 3, usd(500))), domain.ErrOrderInvariant},
+		{"duplicate line", order(domain.StatusDraft, usd(1000), item("p-1", 1, usd(500)), item("p-1", 1, usd(500))), domain.ErrOrderInvariant},
+		{"non-positive quantity", order(domain.StatusDraft, usd(0), item("p-1", 0, usd(500))), domain.ErrOrderInvariant},
+		{"foreign currency line", order(domain.StatusDraft, usd(500), item("p-1", 1, domain.MustNewMoney(500, "EUR"))), domain.ErrOrderInvariant},
+		{"submitted without items", order(domain.StatusConfirmed, usd(0)), domain.ErrOrderInvariant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.order.Validate()
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// checkTransitions reports the first emitted status change that is not in
+// legalTransitions.
+func checkTransitions(captured []events.Event) error {
+	for _, evt := range captured {
+		e, ok := evt.(domain.OrderStatusChangedEvent)
+		if !ok {
+			continue
+		}
+		legal := false
+		for _, to := range legalTransitions[e.From] {
+			legal = legal || to == e.To
+		}
+		if !legal {
+			return fmt.Errorf("illegal transition %q -> %q", e.From, e.To)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// ErrOrderHistoryInvalid is returned when an order's event history cannot
+// be replayed.
+var ErrOrderHistoryInvalid = errors.New("invalid order history")
+
+// ReplayOrder rebuilds an order by applying history, the events it raised
+// in order, to snapshot: the order as of the event before history, or nil
+// when history starts with its OrderCreatedEvent. The events carry every
+// state change: the status transitions also record the cancel and return
+// reasons, and OrderSubmittedEvent the promotions and taxes, from which the
+// OrderSnapshot is taken again. UpdatedAt is the time of the last change.
+func ReplayOrder(snapshot *Order, history []events.Event) (*Order, error) {
+	o := snapshot
+	for _, event := range history {
+		var err error
+		if o, err = o.apply(event); err != nil {
+			return nil, err
+		}
+	}
+	if o == nil {
+		return nil, fmt.Errorf("%w: no events", ErrOrderHistoryInvalid)
+	}
+	return o, nil
+}
+
+// apply returns the order after event. o is nil before OrderCreatedEvent.
+func (o *Order) apply(event events.Event) (*Order, error) {
+	if created, ok := event.(OrderCreatedEvent); ok {
+		if o != nil {
+			return nil, fmt.Errorf("%w: order %s created twice", ErrOrderHistoryInvalid, o.id)
+		}
+		return replayCreated(created)
+	}
+	if o == nil {
+		return nil, fmt.Errorf("%w: %s before OrderCreated", ErrOrderHistoryInvalid, event.EventType())
+	}
+
+	switch e := event.(type) {
+	case OrderStatusChangedEvent:
+		if !e.To.IsValid() {
+			return nil, fmt.Errorf("%w: %q", ErrStatusInvalid, e.To)
+		}
+		o.status = e.To
+		switch e.To {
+		case StatusDraft:
+			o.cancelReason, o.cancelledBy = "", Actor{}
+		case StatusCancelled:
+			o.cancelReason, o.cancelledBy = e.Reason, parseActor(e.Actor)
+		case StatusReturnRequested:
+			o.returnReason = e.Reason
+		}
+	case OrderItemsChangedEvent:
+		o.items = make([]OrderItem, len(e.Items))
+		for i, line := range e.Items {
+			o.items[i] = OrderItem{
+				ProductID:   line.ProductID,
+				ProductName: line.ProductName,
+				Quantity:    line.Quantity,
+				UnitPrice:   Money{amount: line.UnitPrice, currency: o.Currency()},
+			}
+		}
+		o.recalculateTotal()
+	case OrderShippingChangedEvent:
+		a := e.ShippingAddress
+		o.shippingAddress = ReconstituteShippingAddress(a.Recipient, a.Line1, a.Line2, a.City, a.Region, a.PostalCode, a.Country)
+		o.deliveryInstructions = e.DeliveryInstructions
+	case OrderDiscountAppliedEvent:
+		var amountOff Money
+		if e.Kind == DiscountFixedAmount {
+			amountOff = Money{amount: e.AmountOff, currency: o.Currency()}
+		}
+		o.discount = ReconstituteDiscount(e.Code, e.Kind, e.PercentOff, amountOff)
+		o.recalculateTotal()
+	case OrderDiscountRemovedEvent:
+		o.discount = Discount{}
+		o.recalculateTotal()
+	case OrderClaimedEvent:
+		userRef, err := NewUserRef(e.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrOrderHistoryInvalid, err)
+		}
+		o.userRef = userRef
+	case orderevents.OrderSubmittedEvent:
+		o.replaySubmitted(e)
+		// The status transition to pending precedes the event.
+		return o, nil
+	case orderevents.OrderCancelledEvent, OrderExpiredEvent, orderevents.OrderConfirmedEvent, orderevents.OrderCompletedEvent, orderevents.RefundIssuedEvent:
+		// Recorded by the status transition that precedes them.
+		return o, nil
+	default:
+		return nil, fmt.Errorf("%w: unexpected event %T", ErrOrderHistoryInvalid, event)
+	}
+	o.updatedAt = event.OccurredAt()
+	return o, nil
+}
+
+func replayCreated(e OrderCreatedEvent) (*Order, error) {
+	id, err := ParseOrderID(e.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrOrderHistoryInvalid, err)
+	}
+	userRef, err := ParseOwnerRef(e.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrOrderHistoryInvalid, err)
+	}
+	return &Order{
+		id:        id,
+		userRef:   userRef,
+		items:     make([]OrderItem, 0),
+		status:    StatusDraft,
+		total:     Money{amount: 0, currency: e.Currency},
+		createdAt: e.OccurredAt(),
+		updatedAt: e.OccurredAt(),
+	}, nil
+}
+
+func (o *Order) replaySubmitted(e orderevents.OrderSubmittedEvent) {
+	var promotions []PromotionLine
+	for _, p := range e.Promotions {
+		promotions = append(promotions, PromotionLine{PromotionID: p.PromotionID, Name: p.Name, Amount: Money{amount: p.Amount, currency: o.Currency()}})
+	}
+	var taxes []TaxLine
+	for _, t := range e.Taxes {
+		taxes = append(taxes, TaxLine{Name: t.Name, RateBps: t.RateBps, Amount: Money{amount: t.Amount, currency: o.Currency()}})
+	}
+	o.promotions = NewPromotionBreakdown(promotions...)
+	o.tax = NewTaxBreakdown(taxes...)
+	o.recalculateTotal()
+	o.snapshot = newOrderSnapshot(o, o.updatedAt)
+}
+
+// parseActor parses Actor.String.
+func parseActor(s string) Actor {
+	kind, id, _ := strings.Cut(s, ":")
+	return Actor{kind: ActorKind(kind), id: id}
+}
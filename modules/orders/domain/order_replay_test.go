@@ -0,0 +1,170 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock/clocktest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// TestReplayOrder_RebuildsState replays the changes of an order taken through
+// every kind of state change, at once and from a snapshot taken halfway.
+func TestReplayOrder_RebuildsState(t *testing.T) {
+	ctx, clk := clocktest.Context(context.Background(), time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	address, err := domain.NewShippingAddress("Ada Lovelace", "1 Main St", "", "London", "", "N1 9GU", "gb")
+	if err != nil {
+		t.Fatalf("failed to create address: %v", err)
+	}
+
+	var order *domain.Order
+	var halfway int
+	_, err = events.CaptureEvents(ctx, func(ctx context.Context) error {
+		order = createTestOrder(t, ctx)
+		steps := []func() error{
+			func() error {
+				return order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 2, domain.MustNewMoney(500, "USD"))
+			},
+			func() error {
+				return order.AddItem(ctx, domain.OrderLimits{}, "p-2", "Gadget", 1, domain.MustNewMoney(1200, "USD"))
+			},
+			func() error { return order.UpdateItemQuantity(ctx, domain.OrderLimits{}, "p-1", 3) },
+			func() error { return order.SetShipping(ctx, address, "Leave at the door") },
+			func() error {
+				return order.ApplyDiscount(ctx, domain.ReconstituteDiscount("SAVE10", domain.DiscountPercentage, 10, domain.Money{}))
+			},
+			func() error {
+				halfway = len(order.Changes())
+				return order.Submit(ctx, nil, domain.FlatRateTaxCalculator{Name: "VAT", RateBps: 2000}, domain.OrderLimits{})
+			},
+			func() error { return order.Confirm(ctx) },
+			func() error { return order.Complete(ctx) },
+			func() error { return order.RequestReturn(ctx, "too small") },
+		}
+		for _, step := range steps {
+			clk.Advance(time.Minute)
+			if err := step(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	history := order.Changes()
+	order.ClearChanges()
+
+	replayed, err := domain.ReplayOrder(nil, history)
+	if err != nil {
+		t.Fatalf("ReplayOrder() error = %v", err)
+	}
+	if !reflect.DeepEqual(replayed, order) {
+		t.Errorf("ReplayOrder() = %+v\nwant %+v", replayed, order)
+	}
+
+	snapshot, err := domain.ReplayOrder(nil, history[:halfway])
+	if err != nil {
+		t.Fatalf("ReplayOrder() of the first half error = %v", err)
+	}
+	replayed, err = domain.ReplayOrder(snapshot, history[halfway:])
+	if err != nil {
+		t.Fatalf("ReplayOrder() from the snapshot error = %v", err)
+	}
+	if !reflect.DeepEqual(replayed, order) {
+		t.Errorf("ReplayOrder() from the snapshot = %+v\nwant %+v", replayed, order)
+	}
+}
+
+func TestReplayOrder_Cancelled(t *testing.T) {
+	ctx, clk := clocktest.Context(context.Background(), time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	var order *domain.Order
+	_, err := events.CaptureEvents(ctx, func(ctx context.Context) error {
+		order = createTestOrder(t, ctx)
+		clk.Advance(time.Minute)
+		return order.Cancel(ctx, domain.AdminActor("ops@example.com"), "fraud")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	replayed, err := domain.ReplayOrder(nil, order.Changes())
+	if err != nil {
+		t.Fatalf("ReplayOrder() error = %v", err)
+	}
+	if replayed.Status() != domain.StatusCancelled || replayed.CancelReason() != "fraud" || replayed.CancelledBy() != order.CancelledBy() {
+		t.Errorf("ReplayOrder() = %s by %v for %q, want cancelled by %v for fraud",
+			replayed.Status(), replayed.CancelledBy(), replayed.CancelReason(), order.CancelledBy())
+	}
+	if !replayed.UpdatedAt().Equal(order.UpdatedAt()) {
+		t.Errorf("UpdatedAt() = %v, want %v", replayed.UpdatedAt(), order.UpdatedAt())
+	}
+}
+
+func TestReplayOrder_ClaimedGuestOrder(t *testing.T) {
+	ctx, clk := clocktest.Context(context.Background(), time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	userRef, err := domain.NewUserRef(uuid.New().String())
+	if err != nil {
+		t.Fatalf("failed to create user ref: %v", err)
+	}
+
+	var order *domain.Order
+	_, err = events.CaptureEvents(ctx, func(ctx context.Context) error {
+		if order, err = domain.NewOrder(ctx, domain.UserRef{}, "USD"); err != nil {
+			return err
+		}
+		clk.Advance(time.Minute)
+		return order.Claim(ctx, userRef)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	history := order.Changes()
+	order.ClearChanges()
+
+	guest, err := domain.ReplayOrder(nil, history[:2])
+	if err != nil {
+		t.Fatalf("ReplayOrder() of the guest order error = %v", err)
+	}
+	if !guest.IsGuest() {
+		t.Errorf("ReplayOrder() of the guest order has user %s", guest.UserRef())
+	}
+	replayed, err := domain.ReplayOrder(nil, history)
+	if err != nil {
+		t.Fatalf("ReplayOrder() error = %v", err)
+	}
+	if !reflect.DeepEqual(replayed, order) {
+		t.Errorf("ReplayOrder() = %+v\nwant %+v", replayed, order)
+	}
+}
+
+func TestReplayOrder_InvalidHistory(t *testing.T) {
+	var history []events.Event
+	_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		history = order.Changes()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := map[string][]events.Event{
+		"empty":         nil,
+		"not created":   history[1:],
+		"created twice": append(history[:1:1], history[0]),
+	}
+	for name, history := range tests {
+		if _, err := domain.ReplayOrder(nil, history); !errors.Is(err, domain.ErrOrderHistoryInvalid) {
+			t.Errorf("%s: ReplayOrder() error = %v, want ErrOrderHistoryInvalid", name, err)
+		}
+	}
+}
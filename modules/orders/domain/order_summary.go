@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// OrderSummary is a denormalized reporting row for an order.
+// It is a read model projected from order and user events, so reports never
+// need to join across the orders and users modules at read time.
+type OrderSummary struct {
+	OrderID   OrderID
+	UserRef   UserRef
+	UserEmail string // empty until the projection has seen an event for the user
+	ItemCount int    // total quantity across line items
+	Total     Money
+	Status    Status
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OrderSummaryFilter filters summaries for OrderSummaryRepository.List.
+// Zero-valued fields are ignored.
+type OrderSummaryFilter struct {
+	UserRef  UserRef
+	Statuses []Status
+}
+
+// Validate checks that every status in the filter is known.
+func (f OrderSummaryFilter) Validate() error {
+	for _, s := range f.Statuses {
+		if !s.IsValid() {
+			return ErrStatusInvalid
+		}
+	}
+	return nil
+}
+
+// OrderSummaryRepository persists the OrderSummaries projection.
+type OrderSummaryRepository interface {
+	// Save inserts or replaces the summary of an order.
+	Save(ctx context.Context, summary OrderSummary) error
+	// FindByOrderID returns ErrOrderNotFound if no summary exists for the order.
+	FindByOrderID(ctx context.Context, id OrderID) (OrderSummary, error)
+	// List returns summaries matching the filter, newest first, with the total match count.
+	List(ctx context.Context, filter OrderSummaryFilter, offset, limit int) ([]OrderSummary, int, error)
+	// SaveUserEmail records the current email of a user and applies it to
+	// all existing summaries of that user.
+	SaveUserEmail(ctx context.Context, userRef UserRef, email string) error
+	// FindUserEmail returns the last recorded email of a user, or "" if unknown.
+	FindUserEmail(ctx context.Context, userRef UserRef) (string, error)
+	// DeleteAll deletes every summary of the tenant, keeping the recorded
+	// user emails.
+	DeleteAll(ctx context.Context) error
+}
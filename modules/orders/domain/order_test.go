@@ -0,0 +1,913 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock/clocktest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+func TestNewOrder_EmitsOrderCreatedEvent(t *testing.T) {
+	var order *domain.Order
+	captured, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order = createTestOrder(t, ctx)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var created *domain.OrderCreatedEvent
+	for _, evt := range captured {
+		if e, ok := evt.(domain.OrderCreatedEvent); ok {
+			created = &e
+		}
+	}
+	if created == nil {
+		t.Fatal("expected OrderCreatedEvent")
+	}
+	if created.OrderID != order.ID().String() || created.UserID != order.UserRef().String() {
+		t.Errorf("unexpected OrderCreatedEvent: %+v", *created)
+	}
+}
+
+func TestOrder_Cancel_EmitsOrderCancelledEvent(t *testing.T) {
+	captured, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		return order.Cancel(ctx, domain.UserActor(order.UserRef()), "changed my mind")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var cancelled *orderevents.OrderCancelledEvent
+	for _, evt := range captured {
+		if e, ok := evt.(orderevents.OrderCancelledEvent); ok {
+			cancelled = &e
+		}
+	}
+	if cancelled == nil {
+		t.Fatal("expected OrderCancelledEvent")
+	}
+	if cancelled.Reason != "changed my mind" || cancelled.CancelledBy != string(domain.ActorUser) || cancelled.CancelledByID != cancelled.UserID {
+		t.Errorf("unexpected OrderCancelledEvent: %+v", *cancelled)
+	}
+}
+
+func TestOrder_Reopen(t *testing.T) {
+	_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		if err := order.Reopen(ctx, domain.SystemActor("test"), "restored"); !errors.Is(err, domain.ErrOrderNotCancelled) {
+			t.Errorf("Reopen() of a draft error = %v, want ErrOrderNotCancelled", err)
+		}
+
+		if err := order.Cancel(ctx, domain.SystemActor("test"), "user deleted"); err != nil {
+			t.Fatalf("failed to cancel order: %v", err)
+		}
+		if err := order.Reopen(ctx, domain.SystemActor("test"), "restored"); err != nil {
+			t.Fatalf("Reopen() error = %v", err)
+		}
+		if order.Status() != domain.StatusDraft || order.CancelReason() != "" || order.CancelledBy() != (domain.Actor{}) {
+			t.Errorf("Reopen() = %s, cancelled by %v for %q, want a draft not cancelled", order.Status(), order.CancelledBy(), order.CancelReason())
+		}
+
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 1, domain.MustNewMoney(500, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+		if err := order.Submit(ctx, nil, domain.FlatRateTaxCalculator{}, domain.OrderLimits{}); err != nil {
+			t.Fatalf("failed to submit order: %v", err)
+		}
+		if err := order.Cancel(ctx, domain.SystemActor("test"), "user deleted"); err != nil {
+			t.Fatalf("failed to cancel order: %v", err)
+		}
+		if err := order.Reopen(ctx, domain.SystemActor("test"), "restored"); !errors.Is(err, domain.ErrOrderNotReopenable) {
+			t.Errorf("Reopen() of a submitted order error = %v, want ErrOrderNotReopenable", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestOrder_UpdateItemQuantity(t *testing.T) {
+	_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 2, domain.MustNewMoney(500, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+
+		if err := order.UpdateItemQuantity(ctx, domain.OrderLimits{}, "p-1", 5); err != nil {
+			t.Fatalf("failed to update quantity: %v", err)
+		}
+
+		if got := order.Items()[0].Quantity; got != 5 {
+			t.Errorf("expected quantity 5, got %d", got)
+		}
+		if got := order.Total().Amount(); got != 2500 {
+			t.Errorf("expected total 2500, got %d", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestOrder_ItemChangesEmitItemsChangedEvents(t *testing.T) {
+	captured, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 2, domain.MustNewMoney(500, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-2", "Gadget", 1, domain.MustNewMoney(300, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+		if err := order.RemoveItem(ctx, "p-1"); err != nil {
+			t.Fatalf("failed to remove item: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var changes []domain.OrderItemsChangedEvent
+	for _, evt := range captured {
+		if e, ok := evt.(domain.OrderItemsChangedEvent); ok {
+			changes = append(changes, e)
+		}
+	}
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 OrderItemsChangedEvents, got %d", len(changes))
+	}
+	if got := changes[1]; got.ItemCount != 3 || got.TotalAmount != 1300 {
+		t.Errorf("unexpected event after second add: %+v", got)
+	}
+	if got := changes[2]; got.ItemCount != 1 || got.TotalAmount != 300 || got.Currency != "USD" {
+		t.Errorf("unexpected event after remove: %+v", got)
+	}
+}
+
+func TestOrder_UpdateItemQuantity_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		productID string
+		quantity  int
+		submit    bool
+		wantErr   error
+	}{
+		{"zero quantity", "p-1", 0, false, domain.ErrInvalidQuantity},
+		{"negative quantity", "p-1", -1, false, domain.ErrInvalidQuantity},
+		{"unknown product", "p-2", 1, false, domain.ErrItemNotFound},
+		{"not draft", "p-1", 1, true, domain.ErrOrderNotDraft},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+				order := createTestOrder(t, ctx)
+				if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 2, domain.MustNewMoney(500, "USD")); err != nil {
+					t.Fatalf("failed to add item: %v", err)
+				}
+				if tt.submit {
+					if err := order.Submit(ctx, nil, domain.FlatRateTaxCalculator{}, domain.OrderLimits{}); err != nil {
+						t.Fatalf("failed to submit: %v", err)
+					}
+				}
+
+				if err := order.UpdateItemQuantity(ctx, domain.OrderLimits{}, tt.productID, tt.quantity); !errors.Is(err, tt.wantErr) {
+					t.Errorf("UpdateItemQuantity(%q, %d) error = %v, want %v", tt.productID, tt.quantity, err, tt.wantErr)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestOrder_AddItem_CurrencyMismatch(t *testing.T) {
+	_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 1, domain.MustNewMoney(500, "EUR")); !errors.Is(err, domain.ErrCurrencyMismatch) {
+			t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+		}
+		if got := order.Total().Currency(); got != "USD" {
+			t.Errorf("expected order currency USD, got %s", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestOrder_AddItem_Overflow(t *testing.T) {
+	_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 1, domain.MustNewMoney(math.MaxInt64, "USD")); err != nil {
+			t.Fatalf("AddItem() error = %v", err)
+		}
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-2", "Gadget", 1, domain.MustNewMoney(1, "USD")); !errors.Is(err, domain.ErrMoneyOverflow) {
+			t.Errorf("expected ErrMoneyOverflow for the subtotal, got %v", err)
+		}
+		if err := order.UpdateItemQuantity(ctx, domain.OrderLimits{}, "p-1", 2); !errors.Is(err, domain.ErrMoneyOverflow) {
+			t.Errorf("expected ErrMoneyOverflow for the line, got %v", err)
+		}
+		if got := order.Total().Amount(); got != math.MaxInt64 {
+			t.Errorf("total = %d after rejected changes, want %d", got, int64(math.MaxInt64))
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestShippingAddress_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		recipient  string
+		line1      string
+		city       string
+		postalCode string
+		country    string
+		wantErr    error
+	}{
+		{"valid address", "Jane Doe", "1 Main St", "Springfield", "12345", "us", nil},
+		{"missing recipient", "", "1 Main St", "Springfield", "12345", "US", domain.ErrShippingRecipientRequired},
+		{"missing line1", "Jane Doe", "", "Springfield", "12345", "US", domain.ErrShippingLine1Required},
+		{"missing city", "Jane Doe", "1 Main St", "", "12345", "US", domain.ErrShippingCityRequired},
+		{"missing postal code", "Jane Doe", "1 Main St", "Springfield", "", "US", domain.ErrShippingPostalCodeRequired},
+		{"invalid country", "Jane Doe", "1 Main St", "Springfield", "12345", "USA", domain.ErrShippingCountryInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.NewShippingAddress(tt.recipient, tt.line1, "", tt.city, "", tt.postalCode, tt.country)
+			if err != tt.wantErr {
+				t.Errorf("NewShippingAddress() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrder_SetShipping_IncludedInSubmittedEvent(t *testing.T) {
+	collected, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 1, domain.MustNewMoney(500, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+
+		addr, err := domain.NewShippingAddress("Jane Doe", "1 Main St", "", "Springfield", "", "12345", "US")
+		if err != nil {
+			t.Fatalf("failed to create address: %v", err)
+		}
+		if err := order.SetShipping(ctx, addr, "Leave at the door"); err != nil {
+			t.Fatalf("failed to set shipping: %v", err)
+		}
+		if err := order.Submit(ctx, nil, domain.FlatRateTaxCalculator{}, domain.OrderLimits{}); err != nil {
+			t.Fatalf("failed to submit: %v", err)
+		}
+
+		if err := order.SetShipping(ctx, addr, ""); !errors.Is(err, domain.ErrOrderNotDraft) {
+			t.Errorf("expected ErrOrderNotDraft after submit, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	submitted, ok := collected[len(collected)-1].(orderevents.OrderSubmittedEvent)
+	if !ok {
+		t.Fatalf("expected OrderSubmittedEvent, got %T", collected[len(collected)-1])
+	}
+	if submitted.ShippingAddress.City != "Springfield" || submitted.DeliveryInstructions != "Leave at the door" {
+		t.Errorf("expected shipping details in event, got %+v", submitted)
+	}
+}
+
+func TestOrder_ApplyDiscount(t *testing.T) {
+	tests := []struct {
+		name      string
+		kind      domain.DiscountKind
+		value     int64
+		currency  string
+		wantTotal int64
+	}{
+		{"percentage", domain.DiscountPercentage, 10, "", 2700},
+		{"fixed amount", domain.DiscountFixedAmount, 500, "USD", 2500},
+		{"fixed amount capped at subtotal", domain.DiscountFixedAmount, 10000, "USD", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			captured, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+				order := createTestOrder(t, ctx)
+				if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 3, domain.MustNewMoney(1000, "USD")); err != nil {
+					t.Fatalf("failed to add item: %v", err)
+				}
+
+				code, err := domain.NewDiscountCode(ctx, "save10", tt.kind, tt.value, tt.currency, time.Time{}, 0)
+				if err != nil {
+					t.Fatalf("failed to create discount code: %v", err)
+				}
+				if err := order.ApplyDiscount(ctx, code.Discount()); err != nil {
+					t.Fatalf("failed to apply discount: %v", err)
+				}
+
+				if got := order.Total().Amount(); got != tt.wantTotal {
+					t.Errorf("expected total %d, got %d", tt.wantTotal, got)
+				}
+				if got := order.Subtotal().Amount(); got != 3000 {
+					t.Errorf("expected subtotal 3000, got %d", got)
+				}
+
+				if err := order.ApplyDiscount(ctx, code.Discount()); !errors.Is(err, domain.ErrDiscountAlreadyApplied) {
+					t.Errorf("expected ErrDiscountAlreadyApplied, got %v", err)
+				}
+
+				if err := order.RemoveDiscount(ctx, "SAVE10"); err != nil {
+					t.Fatalf("failed to remove discount: %v", err)
+				}
+				if got := order.Total().Amount(); got != 3000 {
+					t.Errorf("expected total 3000 after removal, got %d", got)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var applied, removed bool
+			for _, evt := range captured {
+				switch evt.EventType() {
+				case domain.OrderDiscountAppliedEventType:
+					applied = true
+				case domain.OrderDiscountRemovedEventType:
+					removed = true
+				}
+			}
+			if !applied || !removed {
+				t.Errorf("expected applied and removed events, got applied=%v removed=%v", applied, removed)
+			}
+		})
+	}
+}
+
+func TestDiscountCode_Redeem(t *testing.T) {
+	t.Run("usage limit", func(t *testing.T) {
+		ctx := context.Background()
+		code, err := domain.NewDiscountCode(ctx, "ONCE", domain.DiscountPercentage, 5, "", time.Time{}, 1)
+		if err != nil {
+			t.Fatalf("failed to create discount code: %v", err)
+		}
+		if err := code.Redeem(ctx); err != nil {
+			t.Fatalf("first redeem failed: %v", err)
+		}
+		if err := code.Redeem(ctx); !errors.Is(err, domain.ErrDiscountCodeExhausted) {
+			t.Errorf("expected ErrDiscountCodeExhausted, got %v", err)
+		}
+		code.Release(ctx)
+		if err := code.Redeem(ctx); err != nil {
+			t.Errorf("redeem after release failed: %v", err)
+		}
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		ctx, clk := clocktest.Context(context.Background(), time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+		code, err := domain.NewDiscountCode(ctx, "OLD", domain.DiscountPercentage, 5, "", clk.Now().Add(time.Hour), 0)
+		if err != nil {
+			t.Fatalf("failed to create discount code: %v", err)
+		}
+		clk.Advance(time.Hour - time.Nanosecond)
+		if err := code.Redeem(ctx); err != nil {
+			t.Errorf("redeem before expiry failed: %v", err)
+		}
+		clk.Advance(time.Nanosecond)
+		if err := code.Redeem(ctx); !errors.Is(err, domain.ErrDiscountCodeExpired) {
+			t.Errorf("expected ErrDiscountCodeExpired, got %v", err)
+		}
+	})
+}
+
+func TestOrder_Submit_AppliesTax(t *testing.T) {
+	taxes := domain.RegionalTaxCalculator{
+		Regions: map[string]domain.TaxCalculator{
+			"JP": domain.FlatRateTaxCalculator{Name: "Consumption tax", RateBps: 1000},
+		},
+		Default: domain.FlatRateTaxCalculator{Name: "Sales tax", RateBps: 825},
+	}
+
+	tests := []struct {
+		name      string
+		country   string
+		wantTax   int64
+		wantTotal int64
+	}{
+		{"regional rate", "JP", 270, 2970},
+		{"default rate", "US", 223, 2923},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+				order := createTestOrder(t, ctx)
+				if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 3, domain.MustNewMoney(1000, "USD")); err != nil {
+					t.Fatalf("failed to add item: %v", err)
+				}
+				code, err := domain.NewDiscountCode(ctx, "SAVE10", domain.DiscountPercentage, 10, "", time.Time{}, 0)
+				if err != nil {
+					t.Fatalf("failed to create discount code: %v", err)
+				}
+				if err := order.ApplyDiscount(ctx, code.Discount()); err != nil {
+					t.Fatalf("failed to apply discount: %v", err)
+				}
+				addr, err := domain.NewShippingAddress("Jane", "1 Main St", "", "Town", "", "12345", tt.country)
+				if err != nil {
+					t.Fatalf("failed to create address: %v", err)
+				}
+				if err := order.SetShipping(ctx, addr, ""); err != nil {
+					t.Fatalf("failed to set shipping: %v", err)
+				}
+
+				if err := order.Submit(ctx, nil, taxes, domain.OrderLimits{}); err != nil {
+					t.Fatalf("failed to submit: %v", err)
+				}
+
+				if got := order.TaxAmount().Amount(); got != tt.wantTax {
+					t.Errorf("expected tax %d, got %d", tt.wantTax, got)
+				}
+				if got := order.Total().Amount(); got != tt.wantTotal {
+					t.Errorf("expected total %d, got %d", tt.wantTotal, got)
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestOrder_Submit_AppliesPromotions(t *testing.T) {
+	var cart domain.PromotionCart
+	engine := domain.PromotionEngineFunc(func(ctx context.Context, c domain.PromotionCart) ([]domain.PromotionLine, error) {
+		cart = c
+		return []domain.PromotionLine{
+			{PromotionID: "promo-1", Name: "Spend 20 save 5", Amount: domain.MustNewMoney(500, "USD")},
+			{PromotionID: "promo-2", Name: "Take 30 off", Amount: domain.MustNewMoney(3000, "USD")},
+		}, nil
+	})
+	taxes := domain.FlatRateTaxCalculator{Name: "Sales tax", RateBps: 1000}
+
+	var order *domain.Order
+	captured, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order = createTestOrder(t, ctx)
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 3, domain.MustNewMoney(1000, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+		return order.Submit(ctx, engine, taxes, domain.OrderLimits{})
+	})
+	if err != nil {
+		t.Fatalf("failed to submit: %v", err)
+	}
+
+	if cart.Subtotal.Amount() != 3000 || len(cart.Items) != 1 {
+		t.Errorf("engine saw subtotal %d and %d items, want 3000 and 1", cart.Subtotal.Amount(), len(cart.Items))
+	}
+	// The second promotion is capped at what is left of the subtotal.
+	if got := order.PromotionAmount().Amount(); got != 3000 {
+		t.Errorf("expected promotions of 3000, got %d", got)
+	}
+	if got := order.Promotions().Lines()[1].Amount.Amount(); got != 2500 {
+		t.Errorf("expected capped second promotion of 2500, got %d", got)
+	}
+	if got := order.TaxAmount().Amount(); got != 0 {
+		t.Errorf("expected no tax on a fully promoted order, got %d", got)
+	}
+	if got := order.Total().Amount(); got != 0 {
+		t.Errorf("expected total 0, got %d", got)
+	}
+
+	submitted, ok := captured[len(captured)-1].(orderevents.OrderSubmittedEvent)
+	if !ok {
+		t.Fatalf("expected OrderSubmittedEvent, got %T", captured[len(captured)-1])
+	}
+	if len(submitted.Promotions) != 2 || submitted.Promotions[1].Amount != 2500 {
+		t.Errorf("unexpected promotions in event: %+v", submitted.Promotions)
+	}
+}
+
+func TestOrder_Submit_RejectsForeignCurrencyPromotion(t *testing.T) {
+	engine := domain.PromotionEngineFunc(func(ctx context.Context, c domain.PromotionCart) ([]domain.PromotionLine, error) {
+		return []domain.PromotionLine{{PromotionID: "promo-1", Amount: domain.MustNewMoney(100, "EUR")}}, nil
+	})
+	_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 1, domain.MustNewMoney(1000, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+		return order.Submit(ctx, engine, domain.FlatRateTaxCalculator{}, domain.OrderLimits{})
+	})
+	if !errors.Is(err, domain.ErrPromotionCurrencyMismatch) {
+		t.Errorf("expected ErrPromotionCurrencyMismatch, got %v", err)
+	}
+}
+
+func TestOrder_StatusTransitionsEmitStatusChangedEvents(t *testing.T) {
+	captured, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 1, domain.MustNewMoney(500, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+		if err := order.Submit(ctx, nil, domain.FlatRateTaxCalculator{}, domain.OrderLimits{}); err != nil {
+			t.Fatalf("failed to submit: %v", err)
+		}
+		if err := order.Confirm(ctx); err != nil {
+			t.Fatalf("failed to confirm: %v", err)
+		}
+		return order.Complete(ctx)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var got []domain.Status
+	for _, evt := range captured {
+		if e, ok := evt.(domain.OrderStatusChangedEvent); ok {
+			got = append(got, e.To)
+		}
+	}
+	want := []domain.Status{domain.StatusDraft, domain.StatusPending, domain.StatusConfirmed, domain.StatusCompleted}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected transitions %v, got %v", want, got)
+	}
+}
+
+func TestOrder_Confirm_EmitsOrderConfirmedEvent(t *testing.T) {
+	var order *domain.Order
+	captured, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order = createTestOrder(t, ctx)
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 2, domain.MustNewMoney(500, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+		if err := order.ApplyDiscount(ctx, domain.ReconstituteDiscount("SAVE10", domain.DiscountPercentage, 10, domain.Money{})); err != nil {
+			t.Fatalf("failed to apply discount: %v", err)
+		}
+		taxes := domain.FlatRateTaxCalculator{Name: "Sales tax", RateBps: 1000}
+		if err := order.Submit(ctx, nil, taxes, domain.OrderLimits{}); err != nil {
+			t.Fatalf("failed to submit: %v", err)
+		}
+		return order.Confirm(ctx)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	confirmed, ok := captured[len(captured)-1].(orderevents.OrderConfirmedEvent)
+	if !ok {
+		t.Fatalf("expected OrderConfirmedEvent, got %T", captured[len(captured)-1])
+	}
+	wantLines := []orderevents.ConfirmedLine{{ProductID: "p-1", ProductName: "Widget", Quantity: 2, UnitPrice: 500}}
+	if confirmed.OrderID != order.ID().String() || !slices.Equal(confirmed.Lines, wantLines) {
+		t.Errorf("unexpected OrderConfirmedEvent: %+v", confirmed)
+	}
+	// 1000 - 100 discount + 90 tax
+	if confirmed.TotalAmount != 990 || confirmed.DiscountAmount != 100 || confirmed.TaxAmount != 90 || confirmed.Currency != "USD" {
+		t.Errorf("amounts = total %d, discount %d, tax %d %s; want 990, 100, 90 USD",
+			confirmed.TotalAmount, confirmed.DiscountAmount, confirmed.TaxAmount, confirmed.Currency)
+	}
+}
+
+func TestOrder_Complete_EmitsOrderCompletedEvent(t *testing.T) {
+	var order *domain.Order
+	captured, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order = createTestOrder(t, ctx)
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 2, domain.MustNewMoney(500, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+		if err := order.Submit(ctx, nil, domain.FlatRateTaxCalculator{}, domain.OrderLimits{}); err != nil {
+			t.Fatalf("failed to submit: %v", err)
+		}
+		if err := order.Confirm(ctx); err != nil {
+			t.Fatalf("failed to confirm: %v", err)
+		}
+		return order.Complete(ctx)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var completed *orderevents.OrderCompletedEvent
+	for _, evt := range captured {
+		if e, ok := evt.(orderevents.OrderCompletedEvent); ok {
+			completed = &e
+		}
+	}
+	if completed == nil {
+		t.Fatal("expected OrderCompletedEvent")
+	}
+	want := []orderevents.OrderLine{{ProductID: "p-1", Quantity: 2}}
+	if completed.OrderID != order.ID().String() || completed.UserID != order.UserRef().String() || !slices.Equal(completed.Items, want) {
+		t.Errorf("unexpected OrderCompletedEvent: %+v", *completed)
+	}
+}
+
+func TestOrder_Expire(t *testing.T) {
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	ctx, clk := clocktest.Context(context.Background(), created)
+
+	captured, err := events.CaptureEvents(ctx, func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		if !order.UpdatedAt().Equal(created) {
+			t.Errorf("expected updatedAt %v, got %v", created, order.UpdatedAt())
+		}
+
+		if err := order.Expire(ctx, created); !errors.Is(err, domain.ErrOrderNotStale) {
+			t.Errorf("expected ErrOrderNotStale, got %v", err)
+		}
+		clk.Advance(time.Hour)
+		if err := order.Expire(ctx, clk.Now()); err != nil {
+			t.Fatalf("failed to expire: %v", err)
+		}
+		if order.Status() != domain.StatusCancelled {
+			t.Errorf("expected status cancelled, got %s", order.Status())
+		}
+		if !order.UpdatedAt().Equal(clk.Now()) {
+			t.Errorf("expected updatedAt %v, got %v", clk.Now(), order.UpdatedAt())
+		}
+		if err := order.Expire(ctx, clk.Now()); !errors.Is(err, domain.ErrOrderNotDraft) {
+			t.Errorf("expected ErrOrderNotDraft, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var expired bool
+	for _, evt := range captured {
+		expired = expired || evt.EventType() == domain.OrderExpiredEventType
+	}
+	if !expired {
+		t.Error("expected OrderExpiredEvent")
+	}
+}
+
+func TestStatus_Actions(t *testing.T) {
+	tests := []struct {
+		status               domain.Status
+		canSubmit, canCancel bool
+	}{
+		{domain.StatusDraft, true, true},
+		{domain.StatusPending, false, true},
+		{domain.StatusConfirmed, false, true},
+		{domain.StatusCompleted, false, false},
+		{domain.StatusCancelled, false, false},
+		{domain.StatusReturnRequested, false, false},
+		{domain.StatusRefunded, false, false},
+		{domain.Status("bogus"), false, false},
+	}
+	for _, tt := range tests {
+		if got := tt.status.CanSubmit(); got != tt.canSubmit {
+			t.Errorf("%s.CanSubmit() = %v, want %v", tt.status, got, tt.canSubmit)
+		}
+		if got := tt.status.CanCancel(); got != tt.canCancel {
+			t.Errorf("%s.CanCancel() = %v, want %v", tt.status, got, tt.canCancel)
+		}
+	}
+}
+
+func TestOrder_CheckUnchanged(t *testing.T) {
+	ctx, clk := clocktest.Context(context.Background(), time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	_, err := events.CaptureEvents(ctx, func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		read := order.UpdatedAt()
+		if err := order.CheckUnchanged(read); err != nil {
+			t.Errorf("expected no error for the last update, got %v", err)
+		}
+
+		clk.Advance(time.Minute)
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 1, domain.MustNewMoney(500, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+		if err := order.CheckUnchanged(read); !errors.Is(err, domain.ErrOrderModified) {
+			t.Errorf("expected ErrOrderModified, got %v", err)
+		}
+		if err := order.CheckUnchanged(time.Time{}); err != nil {
+			t.Errorf("expected the zero time to match, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestOrder_ReturnAndRefund(t *testing.T) {
+	captured, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 2, domain.MustNewMoney(500, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+		if err := order.RequestReturn(ctx, "damaged"); !errors.Is(err, domain.ErrOrderNotCompleted) {
+			t.Errorf("expected ErrOrderNotCompleted, got %v", err)
+		}
+		if err := order.Submit(ctx, nil, domain.FlatRateTaxCalculator{}, domain.OrderLimits{}); err != nil {
+			t.Fatalf("failed to submit: %v", err)
+		}
+		if err := order.Confirm(ctx); err != nil {
+			t.Fatalf("failed to confirm: %v", err)
+		}
+		if err := order.Complete(ctx); err != nil {
+			t.Fatalf("failed to complete: %v", err)
+		}
+		if err := order.Refund(ctx, domain.AdminActor("ops")); !errors.Is(err, domain.ErrReturnNotRequested) {
+			t.Errorf("expected ErrReturnNotRequested, got %v", err)
+		}
+		if err := order.RequestReturn(ctx, "damaged"); err != nil {
+			t.Fatalf("failed to request return: %v", err)
+		}
+		if err := order.Cancel(ctx, domain.SystemActor("test"), ""); !errors.Is(err, domain.ErrOrderCompleted) {
+			t.Errorf("expected ErrOrderCompleted, got %v", err)
+		}
+		return order.Refund(ctx, domain.AdminActor("ops"))
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var refund *orderevents.RefundIssuedEvent
+	for _, evt := range captured {
+		if e, ok := evt.(orderevents.RefundIssuedEvent); ok {
+			refund = &e
+		}
+	}
+	if refund == nil {
+		t.Fatal("expected RefundIssuedEvent")
+	}
+	if refund.Amount != 1000 || refund.Currency != "USD" || refund.Reason != "damaged" {
+		t.Errorf("unexpected refund event: %+v", *refund)
+	}
+}
+
+func TestOrder_GuestClaim(t *testing.T) {
+	userRef, err := domain.NewUserRef(uuid.New().String())
+	if err != nil {
+		t.Fatalf("failed to create user ref: %v", err)
+	}
+
+	collected, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order, err := domain.NewOrder(ctx, domain.UserRef{}, "USD")
+		if err != nil {
+			t.Fatalf("failed to create guest order: %v", err)
+		}
+		if !order.IsGuest() {
+			t.Fatal("expected a guest order")
+		}
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 1, domain.MustNewMoney(500, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+		if err := order.Submit(ctx, nil, domain.FlatRateTaxCalculator{}, domain.OrderLimits{}); !errors.Is(err, domain.ErrOrderUnclaimed) {
+			t.Errorf("Submit() of a guest order error = %v, want ErrOrderUnclaimed", err)
+		}
+
+		if err := order.Claim(ctx, domain.UserRef{}); !errors.Is(err, domain.ErrInvalidUserRef) {
+			t.Errorf("Claim() without a user error = %v, want ErrInvalidUserRef", err)
+		}
+		if err := order.Claim(ctx, userRef); err != nil {
+			t.Fatalf("Claim() error = %v", err)
+		}
+		if order.IsGuest() || order.UserRef() != userRef {
+			t.Errorf("UserRef() = %v, want %v", order.UserRef(), userRef)
+		}
+		if err := order.Claim(ctx, userRef); !errors.Is(err, domain.ErrOrderAlreadyClaimed) {
+			t.Errorf("Claim() twice error = %v, want ErrOrderAlreadyClaimed", err)
+		}
+		return order.Submit(ctx, nil, domain.FlatRateTaxCalculator{}, domain.OrderLimits{})
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var claimed []domain.OrderClaimedEvent
+	for _, e := range collected {
+		if e, ok := e.(domain.OrderClaimedEvent); ok {
+			claimed = append(claimed, e)
+		}
+	}
+	if len(claimed) != 1 || claimed[0].UserID != userRef.String() {
+		t.Errorf("OrderClaimedEvents = %+v, want one for %s", claimed, userRef)
+	}
+}
+
+func createTestOrder(t *testing.T, ctx context.Context) *domain.Order {
+	t.Helper()
+
+	userRef, err := domain.NewUserRef(uuid.New().String())
+	if err != nil {
+		t.Fatalf("failed to create user ref: %v", err)
+	}
+
+	order, err := domain.NewOrder(ctx, userRef, "USD")
+	if err != nil {
+		t.Fatalf("failed to create order: %v", err)
+	}
+	return order
+}
+
+func TestOrder_Limits(t *testing.T) {
+	limits := domain.OrderLimits{MaxDistinctItems: 2, MaxLineQuantity: 5, MaxTotal: 3000}
+
+	_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		if err := order.AddItem(ctx, limits, "p-1", "Widget", 5, domain.MustNewMoney(100, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+		if err := order.AddItem(ctx, limits, "p-1", "Widget", 1, domain.MustNewMoney(100, "USD")); !errors.Is(err, domain.ErrLineQuantityExceeded) {
+			t.Errorf("expected ErrLineQuantityExceeded, got %v", err)
+		}
+		if err := order.UpdateItemQuantity(ctx, limits, "p-1", 6); !errors.Is(err, domain.ErrLineQuantityExceeded) {
+			t.Errorf("expected ErrLineQuantityExceeded, got %v", err)
+		}
+		if err := order.AddItem(ctx, limits, "p-2", "Gadget", 1, domain.MustNewMoney(3000, "USD")); !errors.Is(err, domain.ErrOrderTotalExceeded) {
+			t.Errorf("expected ErrOrderTotalExceeded, got %v", err)
+		}
+		if err := order.AddItem(ctx, limits, "p-2", "Gadget", 1, domain.MustNewMoney(2000, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+		if err := order.AddItem(ctx, limits, "p-3", "Gizmo", 1, domain.MustNewMoney(1, "USD")); !errors.Is(err, domain.ErrTooManyItems) {
+			t.Errorf("expected ErrTooManyItems, got %v", err)
+		}
+
+		// Rejected changes leave the order untouched.
+		if got := len(order.Items()); got != 2 {
+			t.Errorf("expected 2 items, got %d", got)
+		}
+		if got := order.Total().Amount(); got != 2500 {
+			t.Errorf("expected total 2500, got %d", got)
+		}
+
+		// Tax pushes the total over the limit at submit time.
+		taxes := domain.FlatRateTaxCalculator{Name: "VAT", RateBps: 2500}
+		if err := order.Submit(ctx, nil, taxes, limits); !errors.Is(err, domain.ErrOrderTotalExceeded) {
+			t.Errorf("expected ErrOrderTotalExceeded, got %v", err)
+		}
+		if order.Status() != domain.StatusDraft {
+			t.Errorf("expected order to remain draft, got %s", order.Status())
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestOrder_Submit_FreezesSnapshot(t *testing.T) {
+	_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		order := createTestOrder(t, ctx)
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 2, domain.MustNewMoney(500, "USD")); err != nil {
+			t.Fatalf("failed to add item: %v", err)
+		}
+		if !order.Snapshot().IsZero() {
+			t.Fatal("expected no snapshot before submit")
+		}
+
+		taxes := domain.FlatRateTaxCalculator{Name: "VAT", RateBps: 1000}
+		if err := order.Submit(ctx, nil, taxes, domain.OrderLimits{}); err != nil {
+			t.Fatalf("failed to submit: %v", err)
+		}
+
+		snapshot := order.Snapshot()
+		if snapshot.IsZero() {
+			t.Fatal("expected snapshot after submit")
+		}
+		if snapshot.Subtotal().Amount() != 1000 || snapshot.Tax().Amount() != 100 || snapshot.Total().Amount() != 1100 {
+			t.Errorf("unexpected snapshot amounts: subtotal=%d tax=%d total=%d",
+				snapshot.Subtotal().Amount(), snapshot.Tax().Amount(), snapshot.Total().Amount())
+		}
+
+		// The snapshot hands out copies, so callers cannot alter the frozen lines.
+		snapshot.Items()[0].Quantity = 99
+		if got := order.Snapshot().Items()[0].Quantity; got != 2 {
+			t.Errorf("expected frozen quantity 2, got %d", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
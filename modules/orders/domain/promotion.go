@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// PromotionEngine finds the automatic promotions an order qualifies for.
+// It is consulted when the order is submitted, before taxes are calculated.
+// The promotions module implements it; this port keeps the orders domain
+// unaware of how promotions are defined.
+type PromotionEngine interface {
+	Evaluate(ctx context.Context, cart PromotionCart) ([]PromotionLine, error)
+}
+
+// PromotionEngineFunc adapts a function to the PromotionEngine port.
+type PromotionEngineFunc func(ctx context.Context, cart PromotionCart) ([]PromotionLine, error)
+
+func (f PromotionEngineFunc) Evaluate(ctx context.Context, cart PromotionCart) ([]PromotionLine, error) {
+	return f(ctx, cart)
+}
+
+// PromotionCart is what the PromotionEngine sees of an order being submitted.
+// Subtotal is after the discount code, if any.
+type PromotionCart struct {
+	OrderID  OrderID
+	UserRef  UserRef
+	Items    []OrderItem
+	Subtotal Money
+	At       time.Time
+}
+
+// PromotionLine is one promotion applied to an order.
+type PromotionLine struct {
+	PromotionID string
+	Name        string
+	Amount      Money
+}
+
+// PromotionBreakdown is a value object holding the promotions applied to an order.
+type PromotionBreakdown struct {
+	lines []PromotionLine
+}
+
+// NewPromotionBreakdown creates a PromotionBreakdown from the given lines.
+func NewPromotionBreakdown(lines ...PromotionLine) PromotionBreakdown {
+	return PromotionBreakdown{lines: lines}
+}
+
+func (b PromotionBreakdown) Lines() []PromotionLine { return b.lines }
+func (b PromotionBreakdown) IsZero() bool           { return len(b.lines) == 0 }
+
+// Total returns the sum of all promotion lines in the given currency.
+func (b PromotionBreakdown) Total(currency string) Money {
+	var amount int64
+	for _, line := range b.lines {
+		amount += line.Amount.Amount()
+	}
+	return Money{amount: amount, currency: currency}
+}
+
+// capPromotions validates the lines returned by a PromotionEngine and trims
+// them so that together they never take more than available off the order.
+// Lines reduced to nothing are dropped.
+func capPromotions(lines []PromotionLine, available Money) (PromotionBreakdown, error) {
+	remaining := available.Amount()
+	capped := make([]PromotionLine, 0, len(lines))
+	for _, line := range lines {
+		if line.Amount.Currency() != available.Currency() {
+			return PromotionBreakdown{}, ErrPromotionCurrencyMismatch
+		}
+		if line.Amount.Amount() < 0 {
+			return PromotionBreakdown{}, ErrInvalidPromotionAmount
+		}
+		amount := min(line.Amount.Amount(), remaining)
+		if amount == 0 {
+			continue
+		}
+		remaining -= amount
+		line.Amount = Money{amount: amount, currency: available.Currency()}
+		capped = append(capped, line)
+	}
+	return NewPromotionBreakdown(capped...), nil
+}
@@ -1,11 +1,105 @@
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
+
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
-// OrderRepository defines persistence operations for orders.
+// OrderRepository defines persistence operations for orders, split into
+// the OrderReader query handlers depend on and the OrderWriter commands add,
+// so a read replica or a projection can serve the queries.
 type OrderRepository interface {
-	Save(ctx context.Context, order *Order) error
+	OrderReader
+	OrderWriter
+}
+
+// OrderReader reads orders.
+type OrderReader interface {
 	FindByID(ctx context.Context, id OrderID) (*Order, error)
+	// FindByIDs returns the orders with the given IDs in one read, in no
+	// particular order; IDs without an order are left out.
+	FindByIDs(ctx context.Context, ids []OrderID) ([]*Order, error)
 	FindByUserRef(ctx context.Context, userRef UserRef, offset, limit int) ([]*Order, int, error)
+	// FindByStatus and FindByUserRefAndStatus return a page of the orders
+	// with status, of all users or of one, newest first, with their total
+	// count.
+	FindByStatus(ctx context.Context, status Status, offset, limit int) ([]*Order, int, error)
+	FindByUserRefAndStatus(ctx context.Context, userRef UserRef, status Status, offset, limit int) ([]*Order, int, error)
+	// Search returns orders matching all criteria, in the order of
+	// criteria.Sort, with the total match count.
+	Search(ctx context.Context, criteria OrderSearchCriteria, offset, limit int) ([]*Order, int, error)
+	// FindStaleDrafts returns up to limit draft orders last updated before
+	// cutoff, of every tenant: unlike the other reads it is not scoped to
+	// the tenant of the request, as the draft expiry job runs for them all.
+	FindStaleDrafts(ctx context.Context, cutoff time.Time, limit int) ([]StaleDraft, error)
+}
+
+// StaleDraft identifies a draft order found by FindStaleDrafts and the tenant
+// it belongs to, which loading and saving it must be scoped to.
+type StaleDraft struct {
+	ID       OrderID
+	TenantID string
+}
+
+// OrderWriter writes orders.
+type OrderWriter interface {
+	Save(ctx context.Context, order *Order) error
 	Delete(ctx context.Context, id OrderID) error
 }
+
+// OrderSearchCriteria filters orders for OrderRepository.Search.
+// Zero-valued fields are ignored.
+type OrderSearchCriteria struct {
+	UserRef     UserRef
+	Statuses    []Status
+	CreatedFrom time.Time // inclusive
+	CreatedTo   time.Time // exclusive
+	MinTotal    *int64    // inclusive, in the smallest currency unit
+	MaxTotal    *int64    // inclusive, in the smallest currency unit
+	Currency    string
+	Sort        OrderSort
+}
+
+// Validate reports whether the criteria are well-formed.
+func (c OrderSearchCriteria) Validate() error {
+	for _, s := range c.Statuses {
+		if !s.IsValid() {
+			return ErrStatusInvalid
+		}
+	}
+	if !c.CreatedFrom.IsZero() && !c.CreatedTo.IsZero() && !c.CreatedFrom.Before(c.CreatedTo) {
+		return ErrSearchRangeInvalid
+	}
+	if c.MinTotal != nil && c.MaxTotal != nil && *c.MinTotal > *c.MaxTotal {
+		return ErrSearchRangeInvalid
+	}
+	return nil
+}
+
+// OrderSortField is a field Search can sort orders by.
+type OrderSortField string
+
+const (
+	OrderSortCreatedAt OrderSortField = "created_at"
+	OrderSortTotal     OrderSortField = "total"
+)
+
+// OrderSortFields are the fields Search can sort orders by.
+var OrderSortFields = []string{string(OrderSortCreatedAt), string(OrderSortTotal)}
+
+// OrderSort orders the results of Search by Field, ascending unless Desc.
+// The zero value lists the newest orders first.
+type OrderSort struct {
+	Field OrderSortField
+	Desc  bool
+}
+
+// DiscountCodeRepository defines persistence operations for discount codes.
+// Codes are unique per tenant and only found in the tenant of the context.
+type DiscountCodeRepository interface {
+	Save(ctx context.Context, code *DiscountCode) error
+	// FindByCode returns ErrDiscountCodeNotFound if no code matches.
+	FindByCode(ctx context.Context, code string) (*DiscountCode, error)
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// maxAddressFieldLength bounds each free-form address field.
+const maxAddressFieldLength = 200
+
+// ShippingAddress is a value object representing where an order is delivered.
+type ShippingAddress struct {
+	recipient  string
+	line1      string
+	line2      string
+	city       string
+	region     string
+	postalCode string
+	country    string // ISO 3166-1 alpha-2 code
+}
+
+// NewShippingAddress creates a validated ShippingAddress value object.
+// line2 and region are optional; country must be an ISO 3166-1 alpha-2 code.
+func NewShippingAddress(recipient, line1, line2, city, region, postalCode, country string) (ShippingAddress, error) {
+	a := ShippingAddress{
+		recipient:  strings.TrimSpace(recipient),
+		line1:      strings.TrimSpace(line1),
+		line2:      strings.TrimSpace(line2),
+		city:       strings.TrimSpace(city),
+		region:     strings.TrimSpace(region),
+		postalCode: strings.TrimSpace(postalCode),
+		country:    strings.ToUpper(strings.TrimSpace(country)),
+	}
+
+	switch {
+	case a.recipient == "":
+		return ShippingAddress{}, ErrShippingRecipientRequired
+	case a.line1 == "":
+		return ShippingAddress{}, ErrShippingLine1Required
+	case a.city == "":
+		return ShippingAddress{}, ErrShippingCityRequired
+	case a.postalCode == "":
+		return ShippingAddress{}, ErrShippingPostalCodeRequired
+	case !isCountryCode(a.country):
+		return ShippingAddress{}, ErrShippingCountryInvalid
+	}
+
+	for _, f := range []string{a.recipient, a.line1, a.line2, a.city, a.region, a.postalCode} {
+		if utf8.RuneCountInString(f) > maxAddressFieldLength {
+			return ShippingAddress{}, ErrShippingFieldTooLong
+		}
+	}
+	return a, nil
+}
+
+// ReconstituteShippingAddress rebuilds a ShippingAddress from persistence without validation.
+func ReconstituteShippingAddress(recipient, line1, line2, city, region, postalCode, country string) ShippingAddress {
+	return ShippingAddress{
+		recipient:  recipient,
+		line1:      line1,
+		line2:      line2,
+		city:       city,
+		region:     region,
+		postalCode: postalCode,
+		country:    country,
+	}
+}
+
+func isCountryCode(s string) bool {
+	if len(s) != 2 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+func (a ShippingAddress) Recipient() string  { return a.recipient }
+func (a ShippingAddress) Line1() string      { return a.line1 }
+func (a ShippingAddress) Line2() string      { return a.line2 }
+func (a ShippingAddress) City() string       { return a.city }
+func (a ShippingAddress) Region() string     { return a.region }
+func (a ShippingAddress) PostalCode() string { return a.postalCode }
+func (a ShippingAddress) Country() string    { return a.country }
+func (a ShippingAddress) IsZero() bool       { return a == ShippingAddress{} }
+
+func (a ShippingAddress) Equals(other ShippingAddress) bool {
+	return a == other
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// OrderSnapshot is an immutable record of an order's line items and amounts,
+// frozen when the order is submitted. Historical totals are read from the
+// snapshot, so later catalog changes (product names, prices) never alter them.
+type OrderSnapshot struct {
+	items    []OrderItem
+	subtotal Money
+	discount Money // discount code and promotions combined
+	tax      Money
+	total    Money
+	takenAt  time.Time
+}
+
+func newOrderSnapshot(o *Order, takenAt time.Time) OrderSnapshot {
+	return OrderSnapshot{
+		items:    slices.Clone(o.items),
+		subtotal: o.Subtotal(),
+		discount: Money{amount: o.DiscountAmount().Amount() + o.PromotionAmount().Amount(), currency: o.Currency()},
+		tax:      o.TaxAmount(),
+		total:    o.total,
+		takenAt:  takenAt,
+	}
+}
+
+// ReconstituteOrderSnapshot rebuilds an OrderSnapshot from persistence.
+func ReconstituteOrderSnapshot(items []OrderItem, subtotal, discount, tax, total Money, takenAt time.Time) OrderSnapshot {
+	return OrderSnapshot{
+		items:    items,
+		subtotal: subtotal,
+		discount: discount,
+		tax:      tax,
+		total:    total,
+		takenAt:  takenAt,
+	}
+}
+
+// Items returns a copy of the frozen line items.
+func (s OrderSnapshot) Items() []OrderItem { return slices.Clone(s.items) }
+
+func (s OrderSnapshot) Subtotal() Money    { return s.subtotal }
+func (s OrderSnapshot) Discount() Money    { return s.discount }
+func (s OrderSnapshot) Tax() Money         { return s.tax }
+func (s OrderSnapshot) Total() Money       { return s.total }
+func (s OrderSnapshot) TakenAt() time.Time { return s.takenAt }
+func (s OrderSnapshot) IsZero() bool       { return s.takenAt.IsZero() }
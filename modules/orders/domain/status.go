@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/statemachine"
+)
+
 // Status represents the order status.
 type Status string
 
@@ -9,15 +15,53 @@ const (
 	StatusConfirmed Status = "confirmed"
 	StatusCompleted Status = "completed"
 	StatusCancelled Status = "cancelled"
+
+	// Return sub-flow of completed orders.
+	StatusReturnRequested Status = "return_requested"
+	StatusRefunded        Status = "refunded"
 )
 
 func (s Status) String() string { return string(s) }
 
 func (s Status) IsValid() bool {
 	switch s {
-	case StatusDraft, StatusPending, StatusConfirmed, StatusCompleted, StatusCancelled:
+	case StatusDraft, StatusPending, StatusConfirmed, StatusCompleted, StatusCancelled,
+		StatusReturnRequested, StatusRefunded:
 		return true
 	default:
 		return false
 	}
 }
+
+// ParseStatus parses a stored status, which must be one of the Status
+// constants.
+func ParseStatus(s string) (Status, error) {
+	if status := Status(s); status.IsValid() {
+		return status, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrStatusInvalid, s)
+}
+
+// OrderLifecycle is the table of the order statuses each Order method
+// accepts, by the method's name; "edit" covers the changes to a draft's
+// lines, shipping and discount, which leave it a draft.
+var OrderLifecycle = statemachine.New("order",
+	statemachine.Transition[Status]{Name: "edit", From: []Status{StatusDraft}, Err: ErrOrderNotDraft},
+	statemachine.Transition[Status]{Name: "submit", From: []Status{StatusDraft}, To: StatusPending, Err: ErrOrderNotDraft},
+	statemachine.Transition[Status]{Name: "confirm", From: []Status{StatusPending}, To: StatusConfirmed, Err: ErrOrderNotPending},
+	statemachine.Transition[Status]{Name: "complete", From: []Status{StatusConfirmed}, To: StatusCompleted, Err: ErrOrderNotConfirmed},
+	statemachine.Transition[Status]{
+		Name: "cancel", From: []Status{StatusDraft, StatusPending, StatusConfirmed}, To: StatusCancelled,
+		Err: ErrOrderCompleted, Rejections: map[Status]error{StatusCancelled: ErrOrderAlreadyCancelled},
+	},
+	statemachine.Transition[Status]{Name: "expire", From: []Status{StatusDraft}, To: StatusCancelled, Err: ErrOrderNotDraft},
+	statemachine.Transition[Status]{Name: "reopen", From: []Status{StatusCancelled}, To: StatusDraft, Err: ErrOrderNotCancelled},
+	statemachine.Transition[Status]{Name: "request_return", From: []Status{StatusCompleted}, To: StatusReturnRequested, Err: ErrOrderNotCompleted},
+	statemachine.Transition[Status]{Name: "refund", From: []Status{StatusReturnRequested}, To: StatusRefunded, Err: ErrReturnNotRequested},
+)
+
+// CanSubmit reports whether Order.Submit accepts an order in status s.
+func (s Status) CanSubmit() bool { return OrderLifecycle.Can(s, "submit") }
+
+// CanCancel reports whether Order.Cancel accepts an order in status s.
+func (s Status) CanCancel() bool { return OrderLifecycle.Can(s, "cancel") }
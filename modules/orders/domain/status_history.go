@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// StatusTransition is an entry in an order's status history.
+type StatusTransition struct {
+	ID         string // ID of the OrderStatusChangedEvent that recorded the transition
+	OrderID    OrderID
+	From       Status // empty for the initial draft status
+	To         Status
+	Actor      string // who triggered the transition; empty for system actions
+	Reason     string
+	OccurredAt time.Time
+}
+
+// StatusHistoryRepository persists the status timeline of orders.
+type StatusHistoryRepository interface {
+	Append(ctx context.Context, transition StatusTransition) error
+	// FindByOrderID returns the transitions of an order, oldest first.
+	FindByOrderID(ctx context.Context, id OrderID) ([]StatusTransition, error)
+}
@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+)
+
+var update = flag.Bool("update", false, "rewrite the generated lifecycle diagram in docs/")
+
+func TestOrderLifecycle_Allowed(t *testing.T) {
+	tests := []struct {
+		status domain.Status
+		want   []string
+	}{
+		{domain.StatusDraft, []string{"edit", "submit", "cancel", "expire"}},
+		{domain.StatusPending, []string{"confirm", "cancel"}},
+		{domain.StatusConfirmed, []string{"complete", "cancel"}},
+		{domain.StatusCompleted, []string{"request_return"}},
+		{domain.StatusCancelled, []string{"reopen"}},
+		{domain.StatusReturnRequested, []string{"refund"}},
+		{domain.StatusRefunded, nil},
+	}
+	for _, tt := range tests {
+		if got := domain.OrderLifecycle.Allowed(tt.status); !slices.Equal(got, tt.want) {
+			t.Errorf("Allowed(%s) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+// TestOrderLifecycle_Diagram keeps docs/architecture/order-lifecycle.md in
+// sync with OrderLifecycle; run it with -update after changing the table.
+func TestOrderLifecycle_Diagram(t *testing.T) {
+	path := filepath.Join("..", "..", "..", "docs", "architecture", "order-lifecycle.md")
+	got := "# Order lifecycle\n\n" +
+		"<!-- Generated from domain.OrderLifecycle by TestOrderLifecycle_Diagram (modules/orders/domain); run it with -update to refresh. -->\n\n" +
+		"The statuses of an order and the `Order` methods that move it between them. `edit` stands for the changes to a draft's lines, shipping and discount.\n\n" +
+		"```mermaid\n" + domain.OrderLifecycle.Mermaid() + "```\n"
+
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s (run with -update to create it): %v", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("%s is out of date (run with -update to regenerate it):\n%s", path, got)
+	}
+}
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"strings"
+)
+
+// TaxCalculator computes the tax owed on an order.
+// It is invoked when the order is submitted. Implementations backed by an
+// external tax service live in the infrastructure layer; the strategies in
+// this file cover rule-based rates that need no I/O.
+type TaxCalculator interface {
+	Calculate(ctx context.Context, order *Order) (TaxBreakdown, error)
+}
+
+// TaxLine is a single tax applied to an order (e.g. state and county sales tax).
+type TaxLine struct {
+	Name    string
+	RateBps int64 // rate in basis points: 825 = 8.25%
+	Amount  Money
+}
+
+// TaxBreakdown is a value object holding the tax lines applied to an order.
+type TaxBreakdown struct {
+	lines []TaxLine
+}
+
+// NewTaxBreakdown creates a TaxBreakdown from the given lines.
+func NewTaxBreakdown(lines ...TaxLine) TaxBreakdown {
+	return TaxBreakdown{lines: lines}
+}
+
+func (b TaxBreakdown) Lines() []TaxLine { return b.lines }
+func (b TaxBreakdown) IsZero() bool     { return len(b.lines) == 0 }
+
+// Total returns the sum of all tax lines in the given currency.
+func (b TaxBreakdown) Total(currency string) Money {
+	var amount int64
+	for _, line := range b.lines {
+		amount += line.Amount.Amount()
+	}
+	return Money{amount: amount, currency: currency}
+}
+
+// TaxFor returns the tax line for applying rateBps to amount, rounded half-up
+// to the minor unit.
+func TaxFor(name string, rateBps int64, amount Money) TaxLine {
+	return TaxLine{
+		Name:    name,
+		RateBps: rateBps,
+		Amount:  Money{amount: (amount.Amount()*rateBps + 5000) / 10000, currency: amount.Currency()},
+	}
+}
+
+// FlatRateTaxCalculator applies a single rate to the taxable amount of every order.
+// A zero rate yields an empty breakdown.
+type FlatRateTaxCalculator struct {
+	Name    string
+	RateBps int64
+}
+
+func (c FlatRateTaxCalculator) Calculate(_ context.Context, order *Order) (TaxBreakdown, error) {
+	if c.RateBps == 0 {
+		return TaxBreakdown{}, nil
+	}
+	return NewTaxBreakdown(TaxFor(c.Name, c.RateBps, order.TaxableAmount())), nil
+}
+
+// RegionalTaxCalculator selects a strategy by the shipping country of the order
+// (ISO 3166-1 alpha-2), falling back to Default when no region matches or no
+// shipping address is set.
+type RegionalTaxCalculator struct {
+	Regions map[string]TaxCalculator
+	Default TaxCalculator
+}
+
+func (c RegionalTaxCalculator) Calculate(ctx context.Context, order *Order) (TaxBreakdown, error) {
+	country := strings.ToUpper(order.ShippingAddress().Country())
+	if calc, ok := c.Regions[country]; ok {
+		return calc.Calculate(ctx, order)
+	}
+	if c.Default == nil {
+		return TaxBreakdown{}, nil
+	}
+	return c.Default.Calculate(ctx, order)
+}
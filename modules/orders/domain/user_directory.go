@@ -0,0 +1,18 @@
+package domain
+
+import "context"
+
+// UserDirectory is the orders module's port for questions about users,
+// which are owned by the users module. The module root adapts the users'
+// contracts.UserReader to it, so the domain stays in the orders' language.
+type UserDirectory interface {
+	// UserExists reports whether the user exists and has not been deleted.
+	UserExists(ctx context.Context, ref UserRef) (bool, error)
+}
+
+// UserDirectoryFunc adapts an ordinary function to UserDirectory.
+type UserDirectoryFunc func(ctx context.Context, ref UserRef) (bool, error)
+
+func (f UserDirectoryFunc) UserExists(ctx context.Context, ref UserRef) (bool, error) {
+	return f(ctx, ref)
+}
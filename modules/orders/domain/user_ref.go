@@ -25,10 +25,19 @@ func NewUserRef(s string) (UserRef, error) {
 	return UserRef{value: s}, nil
 }
 
-// MustNewUserRef creates a UserRef, panicking if invalid.
-// Use only for trusted input (e.g., from database).
+// ParseOwnerRef parses the owner of an order as stored or published: a user
+// reference, or "" for a guest order, which gives the zero UserRef.
+func ParseOwnerRef(s string) (UserRef, error) {
+	if s == "" {
+		return UserRef{}, nil
+	}
+	return NewUserRef(s)
+}
+
+// MustNewUserRef creates a UserRef, panicking if invalid; "" gives the zero
+// UserRef of a guest order. Use only for trusted input (e.g., from database).
 func MustNewUserRef(s string) UserRef {
-	ref, err := NewUserRef(s)
+	ref, err := ParseOwnerRef(s)
 	if err != nil {
 		panic(err)
 	}
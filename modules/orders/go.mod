@@ -4,9 +4,13 @@ go 1.26.0
 
 require (
 	cloud.google.com/go/spanner v1.88.0
+	connectrpc.com/connect v1.21.0
 	github.com/google/uuid v1.6.0
+	go.uber.org/mock v0.6.0
 	google.golang.org/api v0.271.0
 	google.golang.org/grpc v1.79.2
+	google.golang.org/protobuf v1.36.11
+	pgregory.net/rapid v1.3.0
 )
 
 require (
@@ -51,5 +55,4 @@ require (
 	google.golang.org/genproto v0.0.0-20260311181403-84a4fc48630c // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20260311181403-84a4fc48630c // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20260311181403-84a4fc48630c // indirect
-	google.golang.org/protobuf v1.36.11 // indirect
 )
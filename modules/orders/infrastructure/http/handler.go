@@ -4,65 +4,151 @@ package http
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/rai/clean-modularmonolith-go/modules/orders/application/commands"
 	"github.com/rai/clean-modularmonolith-go/modules/orders/application/queries"
 	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/etag"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/export"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/features"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/quota"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
 )
 
 type Handler struct {
-	createOrder *commands.CreateOrderHandler
-	addItem     *commands.AddItemHandler
-	removeItem  *commands.RemoveItemHandler
-	submitOrder *commands.SubmitOrderHandler
-	cancelOrder *commands.CancelOrderHandler
-	getOrder    *queries.GetOrderHandler
+	createOrder command.Handler[commands.CreateOrderCommand, string]
+	createGuest command.Handler[commands.CreateGuestOrderCommand, commands.CreateGuestOrderResult]
+	claimOrder  command.VoidHandler[commands.ClaimOrderCommand]
+	addItem     command.VoidHandler[commands.AddItemCommand]
+	removeItem  command.VoidHandler[commands.RemoveItemCommand]
+	updateItem  command.VoidHandler[commands.UpdateItemQuantityCommand]
+	setShipping command.VoidHandler[commands.SetShippingCommand]
+	applyDisc   command.VoidHandler[commands.ApplyDiscountCommand]
+	removeDisc  command.VoidHandler[commands.RemoveDiscountCommand]
+	createDisc  command.Handler[commands.CreateDiscountCodeCommand, string]
+	submitOrder command.VoidHandler[commands.SubmitOrderCommand]
+	cancelOrder command.Handler[commands.CancelOrderCommand, *domain.Order]
+	bulkOrders  command.Handler[commands.BulkTransitionCommand, []commands.BulkOrderResult]
+	reqReturn   command.VoidHandler[commands.RequestReturnCommand]
+	refund      command.VoidHandler[commands.IssueRefundCommand]
+	getOrder    command.Handler[queries.GetOrderQuery, *queries.OrderDTO]
+	batchGet    *queries.BatchGetOrdersHandler
+	getHistory  *queries.GetOrderHistoryHandler
 	listOrders  *queries.ListUserOrdersHandler
+	search      *queries.SearchOrdersHandler
+	report      *queries.ReportOrderSummariesHandler
+	admin       security.AdminGuard
 }
 
 // RegisterRoutes registers the orders module routes to the given mux.
 func RegisterRoutes(
 	mux *http.ServeMux,
-	createOrder *commands.CreateOrderHandler,
-	addItem *commands.AddItemHandler,
-	removeItem *commands.RemoveItemHandler,
-	submitOrder *commands.SubmitOrderHandler,
-	cancelOrder *commands.CancelOrderHandler,
-	getOrder *queries.GetOrderHandler,
+	createOrder command.Handler[commands.CreateOrderCommand, string],
+	createGuest command.Handler[commands.CreateGuestOrderCommand, commands.CreateGuestOrderResult],
+	claimOrder command.VoidHandler[commands.ClaimOrderCommand],
+	addItem command.VoidHandler[commands.AddItemCommand],
+	removeItem command.VoidHandler[commands.RemoveItemCommand],
+	updateItem command.VoidHandler[commands.UpdateItemQuantityCommand],
+	setShipping command.VoidHandler[commands.SetShippingCommand],
+	applyDisc command.VoidHandler[commands.ApplyDiscountCommand],
+	removeDisc command.VoidHandler[commands.RemoveDiscountCommand],
+	createDisc command.Handler[commands.CreateDiscountCodeCommand, string],
+	submitOrder command.VoidHandler[commands.SubmitOrderCommand],
+	cancelOrder command.Handler[commands.CancelOrderCommand, *domain.Order],
+	bulkOrders command.Handler[commands.BulkTransitionCommand, []commands.BulkOrderResult],
+	reqReturn command.VoidHandler[commands.RequestReturnCommand],
+	refund command.VoidHandler[commands.IssueRefundCommand],
+	getOrder command.Handler[queries.GetOrderQuery, *queries.OrderDTO],
+	batchGet *queries.BatchGetOrdersHandler,
+	getHistory *queries.GetOrderHistoryHandler,
 	listOrders *queries.ListUserOrdersHandler,
+	search *queries.SearchOrdersHandler,
+	report *queries.ReportOrderSummariesHandler,
+	admin security.AdminGuard,
 ) {
 	h := &Handler{
 		createOrder: createOrder,
+		createGuest: createGuest,
+		claimOrder:  claimOrder,
 		addItem:     addItem,
 		removeItem:  removeItem,
+		updateItem:  updateItem,
+		setShipping: setShipping,
+		applyDisc:   applyDisc,
+		removeDisc:  removeDisc,
+		createDisc:  createDisc,
 		submitOrder: submitOrder,
 		cancelOrder: cancelOrder,
+		bulkOrders:  bulkOrders,
+		reqReturn:   reqReturn,
+		refund:      refund,
 		getOrder:    getOrder,
+		batchGet:    batchGet,
+		getHistory:  getHistory,
 		listOrders:  listOrders,
+		search:      search,
+		report:      report,
+		admin:       admin,
 	}
 
 	mux.HandleFunc("POST /orders", h.handleCreateOrder)
+	mux.HandleFunc("POST /orders/guest", h.handleCreateGuestOrder)
+	mux.HandleFunc("POST /orders/claim", h.handleClaimOrder)
+	mux.HandleFunc("GET /orders", h.handleSearchOrders)
+	mux.HandleFunc("POST /orders:batchGet", h.handleBatchGetOrders)
 	mux.HandleFunc("GET /orders/{id}", h.handleGetOrder)
+	mux.HandleFunc("GET /orders/{id}/history", h.handleGetOrderHistory)
 	mux.HandleFunc("POST /orders/{id}/items", h.handleAddItem)
+	mux.HandleFunc("PATCH /orders/{id}/items/{productId}", h.handleUpdateItemQuantity)
 	mux.HandleFunc("DELETE /orders/{id}/items/{productId}", h.handleRemoveItem)
+	mux.HandleFunc("PUT /orders/{id}/shipping", h.handleSetShipping)
+	mux.HandleFunc("POST /orders/{id}/discounts", h.handleApplyDiscount)
+	mux.HandleFunc("DELETE /orders/{id}/discounts/{code}", h.handleRemoveDiscount)
+	mux.HandleFunc("POST /discount-codes", h.admin.HandlerFunc(h.handleCreateDiscountCode))
 	mux.HandleFunc("POST /orders/{id}/submit", h.handleSubmitOrder)
 	mux.HandleFunc("POST /orders/{id}/cancel", h.handleCancelOrder)
+	mux.HandleFunc("POST /orders/bulk-cancel", h.admin.HandlerFunc(h.handleBulkCancel))
+	mux.HandleFunc("POST /orders/bulk-transition", h.admin.HandlerFunc(h.handleBulkTransition))
+	mux.HandleFunc("POST /orders/{id}/returns", h.handleRequestReturn)
+	mux.HandleFunc("POST /orders/{id}/refund", h.admin.HandlerFunc(h.handleIssueRefund))
 	mux.HandleFunc("GET /users/{userId}/orders", h.handleListUserOrders)
+	mux.HandleFunc("GET /reports/orders", h.admin.HandlerFunc(h.handleReportOrders))
 }
 
 // Request/Response DTOs
 
 type createOrderRequest struct {
-	UserID string `json:"user_id"`
+	UserID   string `json:"user_id"`
+	Currency string `json:"currency"`
 }
 
 type createOrderResponse struct {
 	ID string `json:"id"`
 }
 
+type createGuestOrderRequest struct {
+	Currency string `json:"currency"`
+}
+
+type createGuestOrderResponse struct {
+	ID         string `json:"id"`
+	ClaimToken string `json:"claim_token"`
+}
+
+type claimOrderRequest struct {
+	Token  string `json:"token"`
+	UserID string `json:"user_id"`
+}
+
 type addItemRequest struct {
 	ProductID   string `json:"product_id"`
 	ProductName string `json:"product_name"`
@@ -71,6 +157,108 @@ type addItemRequest struct {
 	Currency    string `json:"currency"`
 }
 
+type updateItemQuantityRequest struct {
+	Quantity int `json:"quantity"`
+}
+
+type setShippingRequest struct {
+	Recipient            string `json:"recipient"`
+	Line1                string `json:"line1"`
+	Line2                string `json:"line2"`
+	City                 string `json:"city"`
+	Region               string `json:"region"`
+	PostalCode           string `json:"postal_code"`
+	Country              string `json:"country"`
+	DeliveryInstructions string `json:"delivery_instructions"`
+}
+
+type applyDiscountRequest struct {
+	Code string `json:"code"`
+}
+
+type createDiscountCodeRequest struct {
+	Code      string     `json:"code"`
+	Kind      string     `json:"kind"`
+	Value     int64      `json:"value"`
+	Currency  string     `json:"currency"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	MaxUses   int        `json:"max_uses"`
+}
+
+type createDiscountCodeResponse struct {
+	Code string `json:"code"`
+}
+
+type cancelOrderRequest struct {
+	Reason string `json:"reason"`
+}
+
+type batchGetRequest struct {
+	OrderIDs []string `json:"order_ids"`
+}
+
+type bulkCancelRequest struct {
+	OrderIDs []string `json:"order_ids"`
+	Reason   string   `json:"reason"`
+}
+
+type bulkTransitionRequest struct {
+	OrderIDs []string `json:"order_ids"`
+	Status   string   `json:"status"`
+	Reason   string   `json:"reason"`
+}
+
+type bulkOrderResult struct {
+	OrderID string `json:"order_id"`
+	OK      bool   `json:"ok"`
+	Status  int    `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+type bulkOrdersResponse struct {
+	Results   []bulkOrderResult `json:"results"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+}
+
+type requestReturnRequest struct {
+	Reason string `json:"reason"`
+}
+
+// orderResource is an order in a page, with its links: the actions its
+// status allows besides self.
+type orderResource struct {
+	queries.OrderDTO
+	Links page.Links `json:"_links"`
+}
+
+func newOrderResource(order *queries.OrderDTO) orderResource {
+	self := "/orders/" + order.ID
+	links := page.Links{"self": {Href: self}}
+	status := domain.Status(order.Status)
+	if status.CanSubmit() {
+		links["submit"] = page.Link{Href: self + "/submit", Method: http.MethodPost}
+	}
+	if status.CanCancel() {
+		links["cancel"] = page.Link{Href: self + "/cancel", Method: http.MethodPost}
+	}
+	return orderResource{OrderDTO: *order, Links: links}
+}
+
+// orderColumns are the columns of the CSV export of orders. Amounts are in
+// the smallest currency unit.
+var orderColumns = []export.Column[*queries.OrderDTO]{
+	{Name: "id", Value: func(o *queries.OrderDTO) string { return o.ID }},
+	{Name: "user_id", Value: func(o *queries.OrderDTO) string { return o.UserID }},
+	{Name: "status", Value: func(o *queries.OrderDTO) string { return o.Status }},
+	{Name: "currency", Value: func(o *queries.OrderDTO) string { return o.Currency }},
+	{Name: "item_count", Value: func(o *queries.OrderDTO) string { return strconv.Itoa(len(o.Items)) }},
+	{Name: "subtotal", Value: func(o *queries.OrderDTO) string { return strconv.FormatInt(o.Subtotal.Amount, 10) }},
+	{Name: "total", Value: func(o *queries.OrderDTO) string { return strconv.FormatInt(o.Total.Amount, 10) }},
+	{Name: "created_at", Value: func(o *queries.OrderDTO) string { return o.CreatedAt.Format(time.RFC3339) }},
+	{Name: "updated_at", Value: func(o *queries.OrderDTO) string { return o.UpdatedAt.Format(time.RFC3339) }},
+}
+
 type errorResponse struct {
 	Error string `json:"error"`
 }
@@ -84,7 +272,7 @@ func (h *Handler) handleCreateOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cmd := commands.CreateOrderCommand{UserID: req.UserID}
+	cmd := commands.CreateOrderCommand{UserID: req.UserID, Currency: req.Currency}
 	id, err := h.createOrder.Handle(r.Context(), cmd)
 	if err != nil {
 		handleError(w, err)
@@ -94,6 +282,39 @@ func (h *Handler) handleCreateOrder(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusCreated, createOrderResponse{ID: id})
 }
 
+// handleCreateGuestOrder creates a draft order without a user, and returns
+// the token with which the guest later claims it (handleClaimOrder).
+func (h *Handler) handleCreateGuestOrder(w http.ResponseWriter, r *http.Request) {
+	var req createGuestOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.createGuest.Handle(r.Context(), commands.CreateGuestOrderCommand{Currency: req.Currency})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, createGuestOrderResponse{ID: result.OrderID, ClaimToken: result.ClaimToken})
+}
+
+func (h *Handler) handleClaimOrder(w http.ResponseWriter, r *http.Request) {
+	var req claimOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.claimOrder.Handle(r.Context(), commands.ClaimOrderCommand{Token: req.Token, UserID: req.UserID}); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *Handler) handleGetOrder(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
@@ -108,9 +329,39 @@ func (h *Handler) handleGetOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	etag.Set(w, order.UpdatedAt)
 	writeJSON(w, http.StatusOK, order)
 }
 
+// handleBatchGetOrders serves POST /orders:batchGet, for clients that would
+// otherwise get orders one request at a time.
+func (h *Handler) handleBatchGetOrders(w http.ResponseWriter, r *http.Request) {
+	var req batchGetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.batchGet.Handle(r.Context(), queries.BatchGetOrdersQuery{OrderIDs: req.OrderIDs})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) handleGetOrderHistory(w http.ResponseWriter, r *http.Request) {
+	query := queries.GetOrderHistoryQuery{OrderID: r.PathValue("id")}
+	history, err := h.getHistory.Handle(r.Context(), query)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, history)
+}
+
 func (h *Handler) handleAddItem(w http.ResponseWriter, r *http.Request) {
 	orderID := r.PathValue("id")
 	if orderID == "" {
@@ -145,12 +396,79 @@ func (h *Handler) handleRemoveItem(w http.ResponseWriter, r *http.Request) {
 	orderID := r.PathValue("id")
 	productID := r.PathValue("productId")
 
+	expected, err := etag.IfMatch(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
 	cmd := commands.RemoveItemCommand{
+		OrderID:           orderID,
+		ProductID:         productID,
+		ExpectedUpdatedAt: expected,
+	}
+
+	if err := h.removeItem.Handle(r.Context(), cmd); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleUpdateItemQuantity(w http.ResponseWriter, r *http.Request) {
+	orderID := r.PathValue("id")
+	productID := r.PathValue("productId")
+
+	var req updateItemQuantityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cmd := commands.UpdateItemQuantityCommand{
 		OrderID:   orderID,
 		ProductID: productID,
+		Quantity:  req.Quantity,
 	}
 
-	if err := h.removeItem.Handle(r.Context(), cmd); err != nil {
+	if err := h.updateItem.Handle(r.Context(), cmd); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleSetShipping(w http.ResponseWriter, r *http.Request) {
+	orderID := r.PathValue("id")
+
+	expected, err := etag.IfMatch(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	var req setShippingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cmd := commands.SetShippingCommand{
+		OrderID:              orderID,
+		Recipient:            req.Recipient,
+		Line1:                req.Line1,
+		Line2:                req.Line2,
+		City:                 req.City,
+		Region:               req.Region,
+		PostalCode:           req.PostalCode,
+		Country:              req.Country,
+		DeliveryInstructions: req.DeliveryInstructions,
+		ExpectedUpdatedAt:    expected,
+	}
+
+	if err := h.setShipping.Handle(r.Context(), cmd); err != nil {
 		handleError(w, err)
 		return
 	}
@@ -158,6 +476,82 @@ func (h *Handler) handleRemoveItem(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *Handler) handleApplyDiscount(w http.ResponseWriter, r *http.Request) {
+	orderID := r.PathValue("id")
+	expected, err := etag.IfMatch(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	var req applyDiscountRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cmd := commands.ApplyDiscountCommand{
+		OrderID:           orderID,
+		Code:              req.Code,
+		ExpectedUpdatedAt: expected,
+	}
+
+	if err := h.applyDisc.Handle(r.Context(), cmd); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleRemoveDiscount(w http.ResponseWriter, r *http.Request) {
+	expected, err := etag.IfMatch(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	cmd := commands.RemoveDiscountCommand{
+		OrderID:           r.PathValue("id"),
+		Code:              r.PathValue("code"),
+		ExpectedUpdatedAt: expected,
+	}
+
+	if err := h.removeDisc.Handle(r.Context(), cmd); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleCreateDiscountCode(w http.ResponseWriter, r *http.Request) {
+	var req createDiscountCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cmd := commands.CreateDiscountCodeCommand{
+		Code:     req.Code,
+		Kind:     req.Kind,
+		Value:    req.Value,
+		Currency: req.Currency,
+		MaxUses:  req.MaxUses,
+	}
+	if req.ExpiresAt != nil {
+		cmd.ExpiresAt = *req.ExpiresAt
+	}
+
+	code, err := h.createDisc.Handle(r.Context(), cmd)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, createDiscountCodeResponse{Code: code})
+}
+
 func (h *Handler) handleSubmitOrder(w http.ResponseWriter, r *http.Request) {
 	orderID := r.PathValue("id")
 
@@ -173,26 +567,128 @@ func (h *Handler) handleSubmitOrder(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) handleCancelOrder(w http.ResponseWriter, r *http.Request) {
 	orderID := r.PathValue("id")
 
-	cmd := commands.CancelOrderCommand{OrderID: orderID}
-	order, err := h.cancelOrder.Handle(r.Context(), cmd)
+	// The body is optional: {"reason": "..."}
+	var req cancelOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cmd := commands.CancelOrderCommand{
+		OrderID:   orderID,
+		Reason:    req.Reason,
+		ActorKind: domain.ActorUser.String(),
+	}
+	if h.admin.IsAdmin(r) {
+		cmd.ActorKind = domain.ActorAdmin.String()
+	}
+
+	if _, err := h.cancelOrder.Handle(r.Context(), cmd); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleBulkCancel serves POST /orders/bulk-cancel.
+func (h *Handler) handleBulkCancel(w http.ResponseWriter, r *http.Request) {
+	var req bulkCancelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	h.bulkTransition(w, r, commands.BulkTransitionCommand{
+		OrderIDs: req.OrderIDs,
+		To:       domain.StatusCancelled.String(),
+		Reason:   req.Reason,
+		AdminID:  "admin",
+	})
+}
+
+// handleBulkTransition serves POST /orders/bulk-transition. The target status
+// is one of cancelled, confirmed or completed.
+func (h *Handler) handleBulkTransition(w http.ResponseWriter, r *http.Request) {
+	var req bulkTransitionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	h.bulkTransition(w, r, commands.BulkTransitionCommand{
+		OrderIDs: req.OrderIDs,
+		To:       req.Status,
+		Reason:   req.Reason,
+		AdminID:  "admin",
+	})
+}
+
+// bulkTransition runs the command and writes a per-order report. The response
+// is 200 even if some orders failed; each result carries the status code the
+// single-order endpoint would have returned.
+func (h *Handler) bulkTransition(w http.ResponseWriter, r *http.Request, cmd commands.BulkTransitionCommand) {
+	results, err := h.bulkOrders.Handle(r.Context(), cmd)
 	if err != nil {
 		handleError(w, err)
 		return
 	}
-	fmt.Println(order.ID()) // TODO
+
+	resp := bulkOrdersResponse{Results: make([]bulkOrderResult, len(results))}
+	for i, res := range results {
+		resp.Results[i] = bulkOrderResult{OrderID: res.OrderID, OK: res.Err == nil, Status: http.StatusOK}
+		if res.Err != nil {
+			resp.Results[i].Status, resp.Results[i].Error = errorStatus(res.Err)
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) handleRequestReturn(w http.ResponseWriter, r *http.Request) {
+	var req requestReturnRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cmd := commands.RequestReturnCommand{
+		OrderID: r.PathValue("id"),
+		Reason:  req.Reason,
+	}
+	if err := h.reqReturn.Handle(r.Context(), cmd); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) handleIssueRefund(w http.ResponseWriter, r *http.Request) {
+	cmd := commands.IssueRefundCommand{
+		OrderID: r.PathValue("id"),
+		Actor:   "admin",
+	}
+	if err := h.refund.Handle(r.Context(), cmd); err != nil {
+		handleError(w, err)
+		return
+	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
 func (h *Handler) handleListUserOrders(w http.ResponseWriter, r *http.Request) {
 	userID := r.PathValue("userId")
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	p := page.ParseRequest(r.URL.Query())
 
 	query := queries.ListUserOrdersQuery{
 		UserID: userID,
-		Offset: offset,
-		Limit:  limit,
+		Status: r.URL.Query().Get("status"),
+		Offset: p.Offset,
+		Limit:  p.Limit,
 	}
 
 	result, err := h.listOrders.Handle(r.Context(), query)
@@ -201,25 +697,225 @@ func (h *Handler) handleListUserOrders(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	orders := make([]orderResource, len(result.Orders))
+	for i, order := range result.Orders {
+		orders[i] = newOrderResource(order)
+	}
+	writeJSON(w, http.StatusOK, page.New(r, orders, page.Pagination{
+		Offset:     result.Offset,
+		Limit:      result.Limit,
+		TotalCount: result.TotalCount,
+	}))
+}
+
+// handleSearchOrders serves GET /orders. Supported query parameters:
+// user_id, status (repeatable or comma-separated), created_from and
+// created_to (RFC 3339), min_total and max_total (smallest currency unit),
+// currency, sort (created_at or total, - for descending), offset and limit.
+// It streams every matching order from offset on (at most limit if given) as
+// CSV or NDJSON when the Accept header asks for it.
+func (h *Handler) handleSearchOrders(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	p := page.ParseRequest(q)
+
+	query := queries.SearchOrdersQuery{
+		UserID:   q.Get("user_id"),
+		Currency: q.Get("currency"),
+		Statuses: parseStatusParams(q["status"]),
+		Offset:   p.Offset,
+		Limit:    p.Limit,
+	}
+
+	var err error
+	if query.Sort, err = page.ParseSort(q.Get("sort"), domain.OrderSortFields...); err != nil {
+		handleError(w, err)
+		return
+	}
+	if query.CreatedFrom, err = parseTimeParam(q.Get("created_from")); err != nil {
+		writeError(w, http.StatusBadRequest, "created_from must be an RFC 3339 timestamp")
+		return
+	}
+	if query.CreatedTo, err = parseTimeParam(q.Get("created_to")); err != nil {
+		writeError(w, http.StatusBadRequest, "created_to must be an RFC 3339 timestamp")
+		return
+	}
+	if query.MinTotal, err = parseInt64Param(q.Get("min_total")); err != nil {
+		writeError(w, http.StatusBadRequest, "min_total must be an integer")
+		return
+	}
+	if query.MaxTotal, err = parseInt64Param(q.Get("max_total")); err != nil {
+		writeError(w, http.StatusBadRequest, "max_total must be an integer")
+		return
+	}
+
+	if format := export.Negotiate(r); format != export.JSON {
+		if err := export.Write(w, format, "orders", orderColumns, h.search.Export(r.Context(), query)); err != nil {
+			handleError(w, err)
+		}
+		return
+	}
+
+	result, err := h.search.Handle(r.Context(), query)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleReportOrders serves GET /reports/orders from the order summaries
+// projection. Supported query parameters: user_id, status (repeatable or
+// comma-separated), offset and limit.
+func (h *Handler) handleReportOrders(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	p := page.ParseRequest(q)
+
+	query := queries.ReportOrderSummariesQuery{
+		UserID:   q.Get("user_id"),
+		Statuses: parseStatusParams(q["status"]),
+		Offset:   p.Offset,
+		Limit:    p.Limit,
+	}
+
+	result, err := h.report.Handle(r.Context(), query)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
 	writeJSON(w, http.StatusOK, result)
 }
 
 // Helper functions
 
+// parseStatusParams flattens repeated and comma-separated status parameters.
+func parseStatusParams(values []string) []string {
+	var statuses []string
+	for _, v := range values {
+		for s := range strings.SplitSeq(v, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				statuses = append(statuses, s)
+			}
+		}
+	}
+	return statuses
+}
+
+func parseTimeParam(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, v)
+}
+
+func parseInt64Param(v string) (*int64, error) {
+	if v == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
 func handleError(w http.ResponseWriter, err error) {
+	status, message := errorStatus(err)
+	if status == http.StatusTooManyRequests {
+		var exceeded *quota.ExceededError
+		errors.As(err, &exceeded)
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(exceeded.RetryAfter.Seconds()))))
+	}
+	writeError(w, status, message)
+}
+
+// errorStatus maps an error to its HTTP status code and client-facing message.
+func errorStatus(err error) (int, string) {
 	switch {
 	case errors.Is(err, domain.ErrOrderNotFound):
-		writeError(w, http.StatusNotFound, err.Error())
-	case errors.Is(err, domain.ErrOrderNotDraft):
-		writeError(w, http.StatusConflict, err.Error())
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, quota.ErrExceeded):
+		// Quotas over a window free up with time; the others, such as
+		// the drafts per user, when the caller changes the state.
+		var exceeded *quota.ExceededError
+		if errors.As(err, &exceeded) && exceeded.RetryAfter > 0 {
+			return http.StatusTooManyRequests, err.Error()
+		}
+		return http.StatusUnprocessableEntity, err.Error()
+	case errors.Is(err, domain.ErrOrderModified),
+		errors.Is(err, etag.ErrMismatch):
+		return http.StatusPreconditionFailed, err.Error()
+	case errors.Is(err, etag.ErrRequired):
+		return http.StatusPreconditionRequired, err.Error()
+	case errors.Is(err, features.ErrDisabled):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, domain.ErrOrderNotDraft),
+		errors.Is(err, domain.ErrOrderNotPending),
+		errors.Is(err, domain.ErrOrderNotConfirmed),
+		errors.Is(err, domain.ErrOrderAlreadyCancelled),
+		errors.Is(err, domain.ErrOrderCompleted),
+		errors.Is(err, domain.ErrOrderNotCompleted),
+		errors.Is(err, domain.ErrReturnNotRequested),
+		errors.Is(err, domain.ErrOrderUnclaimed),
+		errors.Is(err, domain.ErrOrderAlreadyClaimed):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, tokens.ErrInvalid),
+		errors.Is(err, tokens.ErrExpired),
+		errors.Is(err, tokens.ErrWrongPurpose):
+		return http.StatusBadRequest, "invalid or expired token"
+	case errors.Is(err, tokens.ErrNotConfigured):
+		return http.StatusNotImplemented, tokens.ErrNotConfigured.Error()
+	case errors.Is(err, domain.ErrReturnReasonTooLong),
+		errors.Is(err, domain.ErrCancelReasonTooLong):
+		return http.StatusBadRequest, err.Error()
 	case errors.Is(err, domain.ErrOrderEmpty):
-		writeError(w, http.StatusBadRequest, err.Error())
+		return http.StatusBadRequest, err.Error()
 	case errors.Is(err, domain.ErrItemNotFound):
-		writeError(w, http.StatusNotFound, err.Error())
-	case errors.Is(err, domain.ErrInvalidQuantity):
-		writeError(w, http.StatusBadRequest, err.Error())
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, domain.ErrInvalidOrderID),
+		errors.Is(err, domain.ErrInvalidQuantity),
+		errors.Is(err, domain.ErrCurrencyInvalid),
+		errors.Is(err, domain.ErrCurrencyMismatch),
+		errors.Is(err, domain.ErrStatusInvalid),
+		errors.Is(err, domain.ErrSearchRangeInvalid),
+		errors.Is(err, page.ErrSortInvalid),
+		errors.Is(err, domain.ErrInvalidUserRef),
+		errors.Is(err, domain.ErrBulkOrderIDsRequired),
+		errors.Is(err, domain.ErrBulkLimitExceeded),
+		errors.Is(err, domain.ErrBatchLimitExceeded):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrShippingRecipientRequired),
+		errors.Is(err, domain.ErrShippingLine1Required),
+		errors.Is(err, domain.ErrShippingCityRequired),
+		errors.Is(err, domain.ErrShippingPostalCodeRequired),
+		errors.Is(err, domain.ErrShippingCountryInvalid),
+		errors.Is(err, domain.ErrShippingFieldTooLong),
+		errors.Is(err, domain.ErrDeliveryInstructionsTooLong):
+		return http.StatusBadRequest, err.Error()
+	case errors.Is(err, domain.ErrDiscountCodeNotFound):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, domain.ErrDiscountCodeExists),
+		errors.Is(err, domain.ErrDiscountAlreadyApplied):
+		return http.StatusConflict, err.Error()
+	case errors.Is(err, domain.ErrDiscountNotApplied):
+		return http.StatusNotFound, err.Error()
+	case errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, domain.ErrTooManyItems),
+		errors.Is(err, domain.ErrLineQuantityExceeded),
+		errors.Is(err, domain.ErrOrderTotalExceeded),
+		errors.Is(err, domain.ErrMoneyOverflow),
+		errors.Is(err, domain.ErrDiscountCodeExpired),
+		errors.Is(err, domain.ErrDiscountCodeExhausted),
+		errors.Is(err, domain.ErrDiscountCurrencyMismatch):
+		return http.StatusUnprocessableEntity, err.Error()
+	case errors.Is(err, domain.ErrDiscountCodeInvalid),
+		errors.Is(err, domain.ErrDiscountKindInvalid),
+		errors.Is(err, domain.ErrDiscountValueInvalid),
+		errors.Is(err, domain.ErrDiscountMaxUsesInvalid):
+		return http.StatusBadRequest, err.Error()
 	default:
-		writeError(w, http.StatusInternalServerError, "internal server error")
+		return http.StatusInternalServerError, "internal server error"
 	}
 }
 
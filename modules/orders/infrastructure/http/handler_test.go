@@ -0,0 +1,572 @@
+package http_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/orders/domain/mocks"
+	ordershttp "github.com/rai/clean-modularmonolith-go/modules/orders/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock/clocktest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/etag"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/handlertest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/quota"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	txmocks "github.com/rai/clean-modularmonolith-go/modules/shared/transaction/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	orderID    = "a3bb189e-8bf9-3888-9912-ace4e6543002"
+	userID     = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	adminToken = "admin-secret"
+)
+
+// deps holds what the routes under test use; the handlers of other routes
+// are left nil.
+type deps struct {
+	createOrder command.Handler[commands.CreateOrderCommand, string]
+	createGuest command.Handler[commands.CreateGuestOrderCommand, commands.CreateGuestOrderResult]
+	claimOrder  command.VoidHandler[commands.ClaimOrderCommand]
+	addItem     command.VoidHandler[commands.AddItemCommand]
+	setShipping command.VoidHandler[commands.SetShippingCommand]
+	applyDisc   command.VoidHandler[commands.ApplyDiscountCommand]
+	createDisc  command.Handler[commands.CreateDiscountCodeCommand, string]
+	cancelOrder command.Handler[commands.CancelOrderCommand, *domain.Order]
+	bulkOrders  command.Handler[commands.BulkTransitionCommand, []commands.BulkOrderResult]
+	refund      command.VoidHandler[commands.IssueRefundCommand]
+	repo        domain.OrderReader
+	txScope     transaction.Scope
+}
+
+func newMux(d deps) *http.ServeMux {
+	mux := http.NewServeMux()
+	ordershttp.RegisterRoutes(mux,
+		d.createOrder, d.createGuest, d.claimOrder, d.addItem, nil, nil, d.setShipping, d.applyDisc, nil, d.createDisc, nil, d.cancelOrder, d.bulkOrders, nil, d.refund,
+		queries.NewGetOrderHandler(d.repo, d.txScope), queries.NewBatchGetOrdersHandler(d.repo, d.txScope), nil, queries.NewListUserOrdersHandler(d.repo, d.txScope), queries.NewSearchOrdersHandler(d.repo, d.txScope), nil,
+		security.AdminGuard{Module: "orders", Token: adminToken})
+	return mux
+}
+
+// readOnlyScope expects the one read-only transaction a query runs in.
+func readOnlyScope(ctrl *gomock.Controller) transaction.Scope {
+	txScope := txmocks.NewMockScope(ctrl)
+	txScope.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+	return txScope
+}
+
+func TestCreateOrder(t *testing.T) {
+	var got commands.CreateOrderCommand
+	mux := newMux(deps{
+		createOrder: command.HandlerFunc[commands.CreateOrderCommand, string](func(ctx context.Context, cmd commands.CreateOrderCommand) (string, error) {
+			got = cmd
+			return orderID, nil
+		}),
+	})
+
+	rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodPost, "/orders", map[string]string{"user_id": userID, "currency": "EUR"}))
+
+	handlertest.AssertStatus(t, rec, http.StatusCreated)
+	handlertest.AssertJSON(t, rec, `{"id": "`+orderID+`"}`)
+	if want := (commands.CreateOrderCommand{UserID: userID, Currency: "EUR"}); got != want {
+		t.Errorf("command = %+v, want %+v", got, want)
+	}
+}
+
+func TestCreateOrder_QuotaExceeded(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		status     int
+		retryAfter string
+	}{
+		{"per day", &quota.ExceededError{Quota: "orders.per_tenant_per_day", Max: 100, RetryAfter: 90 * time.Second}, http.StatusTooManyRequests, "90"},
+		{"drafts", &quota.ExceededError{Quota: "orders.drafts_per_user", Max: 5}, http.StatusUnprocessableEntity, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := newMux(deps{
+				createOrder: command.HandlerFunc[commands.CreateOrderCommand, string](func(context.Context, commands.CreateOrderCommand) (string, error) {
+					return "", tt.err
+				}),
+			})
+
+			rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodPost, "/orders", map[string]string{"user_id": userID}))
+
+			handlertest.AssertStatus(t, rec, tt.status)
+			handlertest.AssertJSON(t, rec, `{"error": "`+tt.err.Error()+`"}`)
+			if got := rec.Header().Get("Retry-After"); got != tt.retryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.retryAfter)
+			}
+		})
+	}
+}
+
+func TestCreateGuestOrder(t *testing.T) {
+	var got commands.CreateGuestOrderCommand
+	mux := newMux(deps{
+		createGuest: command.HandlerFunc[commands.CreateGuestOrderCommand, commands.CreateGuestOrderResult](func(ctx context.Context, cmd commands.CreateGuestOrderCommand) (commands.CreateGuestOrderResult, error) {
+			got = cmd
+			return commands.CreateGuestOrderResult{OrderID: orderID, ClaimToken: "claim-token"}, nil
+		}),
+	})
+
+	rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodPost, "/orders/guest", map[string]string{"currency": "EUR"}))
+
+	handlertest.AssertStatus(t, rec, http.StatusCreated)
+	handlertest.AssertJSON(t, rec, `{"id": "`+orderID+`", "claim_token": "claim-token"}`)
+	if want := (commands.CreateGuestOrderCommand{Currency: "EUR"}); got != want {
+		t.Errorf("command = %+v, want %+v", got, want)
+	}
+}
+
+func TestClaimOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"claimed", nil, http.StatusNoContent},
+		{"token used", fmt.Errorf("order claim token already used: %w", tokens.ErrInvalid), http.StatusBadRequest},
+		{"token expired", fmt.Errorf("verifying order claim token: %w", tokens.ErrExpired), http.StatusBadRequest},
+		{"not configured", tokens.ErrNotConfigured, http.StatusNotImplemented},
+		{"user not found", domain.ErrUserNotFound, http.StatusUnprocessableEntity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got commands.ClaimOrderCommand
+			mux := newMux(deps{
+				claimOrder: command.VoidHandlerFunc[commands.ClaimOrderCommand](func(ctx context.Context, cmd commands.ClaimOrderCommand) error {
+					got = cmd
+					return tt.err
+				}),
+			})
+
+			rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodPost, "/orders/claim", map[string]string{"token": "claim-token", "user_id": userID}))
+
+			handlertest.AssertStatus(t, rec, tt.status)
+			if want := (commands.ClaimOrderCommand{Token: "claim-token", UserID: userID}); got != want {
+				t.Errorf("command = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestCreateOrder_UserNotFound(t *testing.T) {
+	mux := newMux(deps{
+		createOrder: command.HandlerFunc[commands.CreateOrderCommand, string](func(context.Context, commands.CreateOrderCommand) (string, error) {
+			return "", domain.ErrUserNotFound
+		}),
+	})
+
+	rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodPost, "/orders", map[string]string{"user_id": userID}))
+
+	handlertest.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	handlertest.AssertJSON(t, rec, `{"error": "`+domain.ErrUserNotFound.Error()+`"}`)
+}
+
+func TestGetOrder(t *testing.T) {
+	order := testOrder(t)
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockOrderReader(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), order.ID()).Return(order, nil)
+
+	rec := handlertest.Serve(newMux(deps{repo: repo, txScope: readOnlyScope(ctrl)}), handlertest.NewRequest(t, http.MethodGet, "/orders/"+orderID, nil))
+
+	handlertest.AssertGolden(t, rec, "get_order")
+	if got, want := rec.Header().Get("ETag"), etag.Of(order.UpdatedAt()); got != want {
+		t.Errorf("ETag = %s, want %s", got, want)
+	}
+}
+
+func TestBatchGetOrders(t *testing.T) {
+	const missingID = "0b1f5b0e-2f43-4c8e-9d6a-6f1e7d4b2c31"
+	order := testOrder(t)
+	missing, _ := domain.ParseOrderID(missingID)
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockOrderReader(ctrl)
+	repo.EXPECT().FindByIDs(gomock.Any(), []domain.OrderID{order.ID(), missing}).Return([]*domain.Order{order}, nil)
+
+	rec := handlertest.Serve(newMux(deps{repo: repo, txScope: readOnlyScope(ctrl)}), handlertest.NewRequest(t, http.MethodPost, "/orders:batchGet",
+		map[string][]string{"order_ids": {orderID, missingID}}))
+
+	handlertest.AssertGolden(t, rec, "batch_get_orders")
+}
+
+func TestBatchGetOrders_InvalidID(t *testing.T) {
+	rec := handlertest.Serve(newMux(deps{}), handlertest.NewRequest(t, http.MethodPost, "/orders:batchGet",
+		map[string][]string{"order_ids": {orderID, "not-an-id"}}))
+
+	handlertest.AssertGolden(t, rec, "batch_get_orders_invalid_id")
+}
+
+func TestSetShipping_IfMatch(t *testing.T) {
+	order := testOrder(t)
+	var got commands.SetShippingCommand
+	mux := newMux(deps{
+		setShipping: command.VoidHandlerFunc[commands.SetShippingCommand](func(ctx context.Context, cmd commands.SetShippingCommand) error {
+			got = cmd
+			return nil
+		}),
+	})
+
+	req := handlertest.NewRequest(t, http.MethodPut, "/orders/"+orderID+"/shipping",
+		map[string]string{"recipient": "Alice", "line1": "1 Rabbit Hole", "city": "Oxford", "postal_code": "OX1", "country": "GB"})
+	req.Header.Set("If-Match", etag.Of(order.UpdatedAt()))
+	rec := handlertest.Serve(mux, req)
+
+	handlertest.AssertStatus(t, rec, http.StatusNoContent)
+	if !got.ExpectedUpdatedAt.Equal(order.UpdatedAt()) {
+		t.Errorf("ExpectedUpdatedAt = %v, want %v", got.ExpectedUpdatedAt, order.UpdatedAt())
+	}
+}
+
+func TestSetShipping_RequiresIfMatch(t *testing.T) {
+	rec := handlertest.Serve(newMux(deps{}), handlertest.NewRequest(t, http.MethodPut, "/orders/"+orderID+"/shipping",
+		map[string]string{"recipient": "Alice"}))
+
+	handlertest.AssertGolden(t, rec, "set_shipping_without_if_match")
+}
+
+func TestApplyDiscount_IfMatch(t *testing.T) {
+	order := testOrder(t)
+	var got commands.ApplyDiscountCommand
+	mux := newMux(deps{
+		applyDisc: command.VoidHandlerFunc[commands.ApplyDiscountCommand](func(ctx context.Context, cmd commands.ApplyDiscountCommand) error {
+			got = cmd
+			return nil
+		}),
+	})
+
+	req := handlertest.NewRequest(t, http.MethodPost, "/orders/"+orderID+"/discounts", map[string]string{"code": "SPRING10"})
+	req.Header.Set("If-Match", etag.Of(order.UpdatedAt()))
+	rec := handlertest.Serve(mux, req)
+
+	handlertest.AssertStatus(t, rec, http.StatusNoContent)
+	if got.Code != "SPRING10" || !got.ExpectedUpdatedAt.Equal(order.UpdatedAt()) {
+		t.Errorf("command = %+v, want code SPRING10 at %v", got, order.UpdatedAt())
+	}
+}
+
+func TestApplyDiscount_RequiresIfMatch(t *testing.T) {
+	rec := handlertest.Serve(newMux(deps{}), handlertest.NewRequest(t, http.MethodPost, "/orders/"+orderID+"/discounts",
+		map[string]string{"code": "SPRING10"}))
+
+	handlertest.AssertGolden(t, rec, "apply_discount_without_if_match")
+}
+
+func TestGetOrder_InvalidID(t *testing.T) {
+	rec := handlertest.Serve(newMux(deps{}), handlertest.NewRequest(t, http.MethodGet, "/orders/not-an-id", nil))
+
+	handlertest.AssertGolden(t, rec, "get_order_invalid_id")
+}
+
+func TestListUserOrders(t *testing.T) {
+	order := testOrder(t)
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockOrderReader(ctrl)
+	repo.EXPECT().FindByUserRef(gomock.Any(), order.UserRef(), 1, 1).Return([]*domain.Order{order}, 3, nil)
+
+	rec := handlertest.Serve(newMux(deps{repo: repo, txScope: readOnlyScope(ctrl)}), handlertest.NewRequest(t, http.MethodGet, "/users/"+userID+"/orders?offset=1&limit=1", nil))
+
+	handlertest.AssertGolden(t, rec, "list_user_orders")
+}
+
+func TestListUserOrders_Status(t *testing.T) {
+	order := testOrder(t)
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockOrderReader(ctrl)
+	repo.EXPECT().FindByUserRefAndStatus(gomock.Any(), order.UserRef(), domain.StatusPending, 0, 20).Return([]*domain.Order{order}, 1, nil)
+
+	rec := handlertest.Serve(newMux(deps{repo: repo, txScope: readOnlyScope(ctrl)}), handlertest.NewRequest(t, http.MethodGet, "/users/"+userID+"/orders?status=pending", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestListUserOrders_InvalidStatus(t *testing.T) {
+	rec := handlertest.Serve(newMux(deps{}), handlertest.NewRequest(t, http.MethodGet, "/users/"+userID+"/orders?status=lost", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestSearchOrders_Filters(t *testing.T) {
+	userRef, err := domain.NewUserRef(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	minTotal, maxTotal := int64(1000), int64(5000)
+	want := domain.OrderSearchCriteria{
+		UserRef:     userRef,
+		Statuses:    []domain.Status{domain.StatusPending, domain.StatusConfirmed, domain.StatusCancelled},
+		CreatedFrom: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedTo:   time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		MinTotal:    &minTotal,
+		MaxTotal:    &maxTotal,
+		Currency:    "EUR",
+	}
+
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockOrderReader(ctrl)
+	repo.EXPECT().Search(gomock.Any(), want, 40, 20).Return(nil, 0, nil)
+
+	rec := handlertest.Serve(newMux(deps{repo: repo, txScope: readOnlyScope(ctrl)}), handlertest.NewRequest(t, http.MethodGet,
+		"/orders?user_id="+userID+"&status=pending,confirmed&status=cancelled&created_from=2025-01-01T00:00:00Z&created_to=2025-02-01T00:00:00Z"+
+			"&min_total=1000&max_total=5000&currency=EUR&offset=40&limit=20", nil))
+
+	handlertest.AssertStatus(t, rec, http.StatusOK)
+	handlertest.AssertJSON(t, rec, `{"orders": [], "total_count": 0, "offset": 40, "limit": 20}`)
+}
+
+func TestSearchOrders_InvalidFilters(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{"created_from", "created_from=yesterday"},
+		{"created_to", "created_to=2025-02-01"},
+		{"min_total", "min_total=ten"},
+		{"max_total", "max_total=1.5"},
+		{"status", "status=shipped"},
+		{"user_id", "user_id=not-an-id"},
+		{"total range", "min_total=500&max_total=100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := handlertest.Serve(newMux(deps{}), handlertest.NewRequest(t, http.MethodGet, "/orders?"+tt.query, nil))
+
+			handlertest.AssertStatus(t, rec, http.StatusBadRequest)
+		})
+	}
+}
+
+func TestSearchOrders_ExportCSV(t *testing.T) {
+	order := testOrder(t)
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockOrderReader(ctrl)
+	repo.EXPECT().Search(gomock.Any(), gomock.Any(), 0, 100).Return([]*domain.Order{order}, 1, nil)
+
+	req := handlertest.NewRequest(t, http.MethodGet, "/orders?user_id="+userID, nil)
+	req.Header.Set("Accept", "text/csv")
+	rec := handlertest.Serve(newMux(deps{repo: repo, txScope: readOnlyScope(ctrl)}), req)
+
+	handlertest.AssertGolden(t, rec, "search_orders_csv")
+}
+
+func TestSearchOrders_ExportInvalidQuery(t *testing.T) {
+	req := handlertest.NewRequest(t, http.MethodGet, "/orders?user_id=not-an-id", nil)
+	req.Header.Set("Accept", "application/x-ndjson")
+	rec := handlertest.Serve(newMux(deps{}), req)
+
+	handlertest.AssertGolden(t, rec, "search_orders_export_invalid_query")
+}
+
+func TestSearchOrders_Sort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockOrderReader(ctrl)
+	repo.EXPECT().Search(gomock.Any(), domain.OrderSearchCriteria{Sort: domain.OrderSort{Field: domain.OrderSortTotal, Desc: true}}, 0, 20).Return(nil, 0, nil)
+	mux := newMux(deps{repo: repo, txScope: readOnlyScope(ctrl)})
+
+	rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodGet, "/orders?sort=-total", nil))
+	handlertest.AssertStatus(t, rec, http.StatusOK)
+
+	rec = handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodGet, "/orders?sort=user_id", nil))
+	handlertest.AssertStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestAddItem_InvalidBody(t *testing.T) {
+	rec := handlertest.Serve(newMux(deps{}), handlertest.NewRequest(t, http.MethodPost, "/orders/"+orderID+"/items", `{"quantity": "two"}`))
+
+	handlertest.AssertGolden(t, rec, "add_item_invalid_body")
+}
+
+// TestErrorMapping checks the status and message of representative errors
+// of each class the handlers map, through a command that returns it wrapped.
+func TestErrorMapping(t *testing.T) {
+	tests := []struct {
+		golden string
+		err    error
+	}{
+		{"error_order_not_found", domain.ErrOrderNotFound},
+		{"error_order_modified", domain.ErrOrderModified},
+		{"error_item_not_found", domain.ErrItemNotFound},
+		{"error_order_not_draft", domain.ErrOrderNotDraft},
+		{"error_discount_already_applied", domain.ErrDiscountAlreadyApplied},
+		{"error_invalid_quantity", domain.ErrInvalidQuantity},
+		{"error_currency_mismatch", domain.ErrCurrencyMismatch},
+		{"error_shipping_country_invalid", domain.ErrShippingCountryInvalid},
+		{"error_too_many_items", domain.ErrTooManyItems},
+		{"error_discount_code_expired", domain.ErrDiscountCodeExpired},
+		{"error_internal", fmt.Errorf("spanner: session expired")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			mux := newMux(deps{
+				addItem: command.VoidHandlerFunc[commands.AddItemCommand](func(context.Context, commands.AddItemCommand) error {
+					return fmt.Errorf("adding item: %w", tt.err)
+				}),
+			})
+
+			rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodPost, "/orders/"+orderID+"/items",
+				map[string]any{"product_id": "book-1", "product_name": "Looking-Glass", "quantity": 1, "unit_price": 1250, "currency": "EUR"}))
+
+			handlertest.AssertGolden(t, rec, tt.golden)
+		})
+	}
+}
+
+func TestCancelOrder_ActorFromAdminToken(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  string
+	}{
+		{"user", "", domain.ActorUser.String()},
+		{"admin", adminToken, domain.ActorAdmin.String()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got commands.CancelOrderCommand
+			mux := newMux(deps{
+				cancelOrder: command.HandlerFunc[commands.CancelOrderCommand, *domain.Order](func(ctx context.Context, cmd commands.CancelOrderCommand) (*domain.Order, error) {
+					got = cmd
+					return nil, nil
+				}),
+			})
+
+			req := handlertest.NewRequest(t, http.MethodPost, "/orders/"+orderID+"/cancel", nil)
+			if tt.token != "" {
+				req.Header.Set(security.AdminTokenHeader, tt.token)
+			}
+			rec := handlertest.Serve(mux, req)
+
+			handlertest.AssertStatus(t, rec, http.StatusNoContent)
+			if got.OrderID != orderID || got.ActorKind != tt.want {
+				t.Errorf("command = %+v, want order %s by %s", got, orderID, tt.want)
+			}
+		})
+	}
+}
+
+func TestBulkCancel_ReportsPerOrder(t *testing.T) {
+	missing := "b3bb189e-8bf9-3888-9912-ace4e6543002"
+	var got commands.BulkTransitionCommand
+	mux := newMux(deps{
+		bulkOrders: command.HandlerFunc[commands.BulkTransitionCommand, []commands.BulkOrderResult](func(ctx context.Context, cmd commands.BulkTransitionCommand) ([]commands.BulkOrderResult, error) {
+			got = cmd
+			return []commands.BulkOrderResult{
+				{OrderID: orderID},
+				{OrderID: missing, Err: domain.ErrOrderNotFound},
+				{OrderID: "not-an-id", Err: domain.ErrOrderNotFound},
+			}, nil
+		}),
+	})
+
+	req := handlertest.NewRequest(t, http.MethodPost, "/orders/bulk-cancel", map[string]any{"order_ids": []string{orderID, missing, "not-an-id", orderID}, "reason": "incident"})
+	req.Header.Set(security.AdminTokenHeader, adminToken)
+	rec := handlertest.Serve(mux, req)
+
+	handlertest.AssertStatus(t, rec, http.StatusOK)
+	handlertest.AssertJSON(t, rec, `{
+		"results": [
+			{"order_id": "`+orderID+`", "ok": true, "status": 200},
+			{"order_id": "`+missing+`", "ok": false, "status": 404, "error": "order not found"},
+			{"order_id": "not-an-id", "ok": false, "status": 404, "error": "order not found"}
+		],
+		"succeeded": 1,
+		"failed": 2
+	}`)
+	if got.To != domain.StatusCancelled.String() || got.Reason != "incident" || len(got.OrderIDs) != 4 {
+		t.Errorf("command = %+v", got)
+	}
+}
+
+func TestBulkTransition_InvalidStatus(t *testing.T) {
+	mux := newMux(deps{
+		bulkOrders: command.HandlerFunc[commands.BulkTransitionCommand, []commands.BulkOrderResult](func(context.Context, commands.BulkTransitionCommand) ([]commands.BulkOrderResult, error) {
+			return nil, domain.ErrStatusInvalid
+		}),
+	})
+
+	req := handlertest.NewRequest(t, http.MethodPost, "/orders/bulk-transition", map[string]any{"order_ids": []string{orderID}, "status": "draft"})
+	req.Header.Set(security.AdminTokenHeader, adminToken)
+	rec := handlertest.Serve(mux, req)
+
+	handlertest.AssertStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestBulkCancel_RequiresAdmin(t *testing.T) {
+	rec := handlertest.Serve(newMux(deps{}), handlertest.NewRequest(t, http.MethodPost, "/orders/bulk-cancel", map[string]any{"order_ids": []string{orderID}}))
+
+	handlertest.AssertStatus(t, rec, http.StatusForbidden)
+}
+
+func TestIssueRefund_RequiresAdmin(t *testing.T) {
+	rec := handlertest.Serve(newMux(deps{}), handlertest.NewRequest(t, http.MethodPost, "/orders/"+orderID+"/refund", nil))
+
+	handlertest.AssertGolden(t, rec, "refund_forbidden")
+}
+
+func TestCreateDiscountCode_RequiresAdmin(t *testing.T) {
+	var got commands.CreateDiscountCodeCommand
+	mux := newMux(deps{
+		createDisc: command.HandlerFunc[commands.CreateDiscountCodeCommand, string](func(ctx context.Context, cmd commands.CreateDiscountCodeCommand) (string, error) {
+			got = cmd
+			return cmd.Code, nil
+		}),
+	})
+	body := map[string]any{"code": "SPRING10", "kind": "percentage", "value": 10}
+
+	rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodPost, "/discount-codes", body))
+	handlertest.AssertStatus(t, rec, http.StatusForbidden)
+	if got.Code != "" {
+		t.Fatalf("command ran without the admin token: %+v", got)
+	}
+
+	req := handlertest.NewRequest(t, http.MethodPost, "/discount-codes", body)
+	req.Header.Set(security.AdminTokenHeader, adminToken)
+	rec = handlertest.Serve(mux, req)
+
+	handlertest.AssertStatus(t, rec, http.StatusCreated)
+	handlertest.AssertJSON(t, rec, `{"code": "SPRING10"}`)
+}
+
+// testOrder returns a draft order with one item, created at a fixed time.
+func testOrder(t *testing.T) *domain.Order {
+	t.Helper()
+
+	id, err := domain.ParseOrderID(orderID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	userRef, err := domain.NewUserRef(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, _ := clocktest.Context(t.Context(), time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	var order *domain.Order
+	_, err = events.CaptureEvents(ctx, func(ctx context.Context) error {
+		if order, err = domain.NewOrderWithID(ctx, id, userRef, "EUR"); err != nil {
+			return err
+		}
+		return order.AddItem(ctx, domain.OrderLimits{}, "book-1", "Looking-Glass", 2, domain.MustNewMoney(1250, "EUR"))
+	})
+	if err != nil {
+		t.Fatalf("creating order: %v", err)
+	}
+	return order
+}
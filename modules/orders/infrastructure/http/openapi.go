@@ -0,0 +1,55 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/export"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+)
+
+var (
+	pageParams = []openapi.Param{
+		{Name: "offset", Type: "integer"},
+		{Name: "limit", Type: "integer"},
+	}
+	statusParam = openapi.Param{Name: "status", Description: "Order status; repeatable or comma-separated"}
+)
+
+// Operations documents the routes registered by RegisterRoutes.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Pattern: "POST /orders", Summary: "Create a draft order", Description: "Answers 429, with a Retry-After, when the tenant created its orders of the day, and 422 when the user has too many drafts open.", Request: createOrderRequest{}, Status: http.StatusCreated, Response: createOrderResponse{}},
+		{Pattern: "POST /orders/guest", Summary: "Create a guest draft order", Description: "Creates a draft without a user and returns the token that claims it. A guest order must be claimed before it is submitted (409). 501 when no token secret is configured.", Request: createGuestOrderRequest{}, Status: http.StatusCreated, Response: createGuestOrderResponse{}},
+		{Pattern: "POST /orders/claim", Summary: "Claim a guest order", Description: "Takes the token returned by POST /orders/guest and gives the order to the user; the token is refused once expired or used.", Request: claimOrderRequest{}, Status: http.StatusNoContent},
+		{Pattern: "GET /orders", Summary: "Search orders", Description: export.Description, Query: append([]openapi.Param{
+			{Name: "user_id"},
+			statusParam,
+			{Name: "created_from", Description: "RFC 3339 timestamp"},
+			{Name: "created_to", Description: "RFC 3339 timestamp"},
+			{Name: "min_total", Type: "integer", Description: "In the smallest currency unit"},
+			{Name: "max_total", Type: "integer", Description: "In the smallest currency unit"},
+			{Name: "currency"},
+			{Name: "sort", Description: "created_at or total, prefixed with - for descending order; newest first by default"},
+		}, pageParams...), Response: queries.OrderListDTO{}},
+		{Pattern: "POST /orders:batchGet", Summary: "Get several orders", Description: "Takes up to 100 IDs; the response lists the IDs without an order under missing.", Request: batchGetRequest{}, Response: queries.BatchGetOrdersDTO{}},
+		{Pattern: "GET /orders/{id}", Summary: "Get an order", Response: queries.OrderDTO{}},
+		{Pattern: "GET /orders/{id}/history", Summary: "Get the status history of an order", Response: queries.OrderHistoryDTO{}},
+		{Pattern: "POST /orders/{id}/items", Summary: "Add an item to a draft order", Request: addItemRequest{}, Status: http.StatusNoContent},
+		{Pattern: "PATCH /orders/{id}/items/{productId}", Summary: "Change the quantity of an item", Request: updateItemQuantityRequest{}, Status: http.StatusNoContent},
+		{Pattern: "DELETE /orders/{id}/items/{productId}", Summary: "Remove an item from a draft order", Header: []openapi.Param{openapi.IfMatch}, Status: http.StatusNoContent},
+		{Pattern: "PUT /orders/{id}/shipping", Summary: "Set the shipping address", Header: []openapi.Param{openapi.IfMatch}, Request: setShippingRequest{}, Status: http.StatusNoContent},
+		{Pattern: "POST /orders/{id}/discounts", Summary: "Apply a discount code", Header: []openapi.Param{openapi.IfMatch}, Request: applyDiscountRequest{}, Status: http.StatusNoContent},
+		{Pattern: "DELETE /orders/{id}/discounts/{code}", Summary: "Remove a discount code", Header: []openapi.Param{openapi.IfMatch}, Status: http.StatusNoContent},
+		{Pattern: "POST /discount-codes", Summary: "Create a discount code", Admin: true, Request: createDiscountCodeRequest{}, Status: http.StatusCreated, Response: createDiscountCodeResponse{}},
+		{Pattern: "POST /orders/{id}/submit", Summary: "Submit a draft order", Status: http.StatusNoContent},
+		{Pattern: "POST /orders/{id}/cancel", Summary: "Cancel an order", Description: "The body is optional. Cancellations by an admin are recorded as such.", Request: cancelOrderRequest{}, Status: http.StatusNoContent},
+		{Pattern: "POST /orders/bulk-cancel", Summary: "Cancel several orders", Description: "Responds 200 even if some orders failed; each result carries its own status.", Admin: true, Request: bulkCancelRequest{}, Response: bulkOrdersResponse{}},
+		{Pattern: "POST /orders/bulk-transition", Summary: "Move several orders to cancelled, confirmed or completed", Description: "Responds 200 even if some orders failed; each result carries its own status.", Admin: true, Request: bulkTransitionRequest{}, Response: bulkOrdersResponse{}},
+		{Pattern: "POST /orders/{id}/returns", Summary: "Request the return of a completed order", Request: requestReturnRequest{}, Status: http.StatusAccepted},
+		{Pattern: "POST /orders/{id}/refund", Summary: "Refund a returned order", Admin: true, Status: http.StatusNoContent},
+		{Pattern: "GET /users/{userId}/orders", Summary: "List the orders of a user", Query: append([]openapi.Param{{Name: "status", Description: "Only the orders with this status"}}, pageParams...), Response: page.Page[orderResource]{}},
+		{Pattern: "GET /reports/orders", Summary: "Report order summaries", Admin: true, Query: append([]openapi.Param{{Name: "user_id"}, statusParam}, pageParams...), Response: queries.OrderSummaryListDTO{}},
+	}
+}
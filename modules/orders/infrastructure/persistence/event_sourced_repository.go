@@ -0,0 +1,330 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+// DefaultSnapshotEvery is the number of events between two snapshots of an
+// order when NewEventSourcedRepository is given zero.
+const DefaultSnapshotEvery = 20
+
+// EventSourcedRepository stores orders as their event streams: Save appends
+// the events an order raised (domain.Order.Changes) to OrderEvents, and
+// FindByID replays them (domain.ReplayOrder) from the order's latest
+// snapshot in OrderEventSnapshots, taken every snapshotEvery events.
+//
+// It is a reference implementation, selected with ORDERS_PERSISTENCE=events.
+// The streams are the source of truth for loading an order, but the
+// queries over many orders (FindByUserRef, Search, FindStaleDrafts) need
+// indexes that a stream does not have: Save also writes the order to the
+// Orders tables in the same transaction, and those queries read them.
+type EventSourcedRepository struct {
+	client        *spanner.Client
+	logger        *slog.Logger
+	state         *SpannerRepository
+	snapshotEvery int
+}
+
+var _ domain.OrderRepository = (*EventSourcedRepository)(nil)
+
+func NewEventSourcedRepository(client *spanner.Client, logger *slog.Logger, snapshotEvery int) *EventSourcedRepository {
+	if snapshotEvery <= 0 {
+		snapshotEvery = DefaultSnapshotEvery
+	}
+	return &EventSourcedRepository{
+		client:        client,
+		logger:        logger,
+		state:         NewSpannerRepository(client, logger),
+		snapshotEvery: snapshotEvery,
+	}
+}
+
+// Save writes order to the Orders tables, which rejects an invalid order,
+// and appends its changes to its stream, after the last stored
+// version read in the same transaction: concurrent saves of an order
+// conflict, and the aborted one retries from FindByID. A snapshot is stored
+// when the stream crosses a multiple of snapshotEvery.
+func (r *EventSourcedRepository) Save(ctx context.Context, order *domain.Order) error {
+	if err := r.state.Save(ctx, order); err != nil {
+		return err
+	}
+
+	changes := order.Changes()
+	if len(changes) > 0 {
+		version, err := r.lastVersion(ctx, order.ID())
+		if err != nil {
+			return err
+		}
+
+		stmts := make([]spanner.Statement, 0, len(changes)+1)
+		for i, event := range changes {
+			payload, err := events.Marshal(event)
+			if err != nil {
+				return fmt.Errorf("failed to encode order event: %w", err)
+			}
+			stmts = append(stmts, spanner.Statement{
+				SQL: `INSERT INTO OrderEvents (OrderID, Version, EventID, EventType, Payload, OccurredAt)
+				      VALUES (@orderID, @version, @eventID, @eventType, @payload, @occurredAt)`,
+				Params: map[string]interface{}{
+					"orderID":    order.ID().String(),
+					"version":    version + int64(i) + 1,
+					"eventID":    event.EventID(),
+					"eventType":  event.EventType().String(),
+					"payload":    string(payload),
+					"occurredAt": event.OccurredAt(),
+				},
+			})
+		}
+
+		// An order stored before the switch to this repository has no
+		// stream: it starts from a snapshot of the order as saved.
+		_, created := changes[0].(domain.OrderCreatedEvent)
+		next := version + int64(len(changes))
+		if next/int64(r.snapshotEvery) > version/int64(r.snapshotEvery) || (version == 0 && !created) {
+			state, err := json.Marshal(encodeOrderState(order))
+			if err != nil {
+				return fmt.Errorf("failed to encode order snapshot: %w", err)
+			}
+			stmts = append(stmts, spanner.Statement{
+				SQL: `INSERT OR UPDATE INTO OrderEventSnapshots (OrderID, Version, State, CreatedAt)
+				      VALUES (@orderID, @version, @state, @createdAt)`,
+				Params: map[string]interface{}{
+					"orderID":   order.ID().String(),
+					"version":   next,
+					"state":     string(state),
+					"createdAt": time.Now().UTC(),
+				},
+			})
+		}
+
+		if err := platformspanner.Write(ctx, stmts...); err != nil {
+			return fmt.Errorf("failed to append order events: %w", err)
+		}
+	}
+	order.ClearChanges()
+	return nil
+}
+
+func (r *EventSourcedRepository) lastVersion(ctx context.Context, id domain.OrderID) (int64, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (int64, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT COALESCE(MAX(Version), 0) FROM OrderEvents WHERE OrderID = @orderID`,
+			Params: map[string]interface{}{"orderID": id.String()},
+		})
+		defer iter.Stop()
+
+		row, err := iter.Next()
+		if err != nil {
+			return 0, fmt.Errorf("failed to read order version: %w", err)
+		}
+		var version int64
+		if err := row.Columns(&version); err != nil {
+			return 0, fmt.Errorf("failed to scan order version: %w", err)
+		}
+		return version, nil
+	})
+}
+
+// FindByID replays the stream of an order, or reads the Orders tables for
+// an order stored before the switch to this repository.
+func (r *EventSourcedRepository) FindByID(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
+	return platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (*domain.Order, error) {
+		return r.find(ctx, reader, id)
+	})
+}
+
+// FindByIDs finds the orders one after the other in one read transaction.
+func (r *EventSourcedRepository) FindByIDs(ctx context.Context, ids []domain.OrderID) ([]*domain.Order, error) {
+	return platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.Order, error) {
+		var orders []*domain.Order
+		for _, id := range ids {
+			order, err := r.find(ctx, reader, id)
+			if errors.Is(err, domain.ErrOrderNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, order)
+		}
+		return orders, nil
+	})
+}
+
+// find replays an order of the tenant of the request. The streams are not
+// scoped to a tenant, so the Orders row, written with every change, is
+// checked first.
+func (r *EventSourcedRepository) find(ctx context.Context, reader platformspanner.ReadTransaction, id domain.OrderID) (*domain.Order, error) {
+	ok, err := r.state.inTenant(ctx, reader, id)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, domain.ErrOrderNotFound
+	}
+	order, err := r.replay(ctx, reader, id)
+	if errors.Is(err, errNoStream) {
+		return r.state.FindByID(ctx, id)
+	}
+	return order, err
+}
+
+// errNoStream is returned by replay for an order without events.
+var errNoStream = errors.New("order has no event stream")
+
+// replay loads the latest snapshot of an order, if any, and applies the
+// events stored after it.
+func (r *EventSourcedRepository) replay(ctx context.Context, reader platformspanner.ReadTransaction, id domain.OrderID) (*domain.Order, error) {
+	var snapshot *domain.Order
+	var version int64
+	row, err := reader.ReadRow(ctx, "OrderEventSnapshots", spanner.Key{id.String()}, []string{"Version", "State"})
+	switch {
+	case spanner.ErrCode(err) == codes.NotFound:
+	case err != nil:
+		return nil, fmt.Errorf("failed to read order snapshot: %w", err)
+	default:
+		var state string
+		if err := row.Columns(&version, &state); err != nil {
+			return nil, fmt.Errorf("failed to scan order snapshot: %w", err)
+		}
+		var s orderState
+		if err := json.Unmarshal([]byte(state), &s); err != nil {
+			return nil, fmt.Errorf("failed to decode order snapshot: %w", err)
+		}
+		if snapshot, err = s.decode(); err != nil {
+			return nil, &platformspanner.CorruptRowError{Table: "OrderEventSnapshots", Key: spanner.Key{id.String()}, Column: "State", Err: err}
+		}
+	}
+
+	iter := reader.Query(ctx, spanner.Statement{
+		SQL: `SELECT EventType, Payload FROM OrderEvents
+		      WHERE OrderID = @orderID AND Version > @version
+		      ORDER BY Version`,
+		Params: map[string]interface{}{"orderID": id.String(), "version": version},
+	})
+	defer iter.Stop()
+
+	var history []events.Event
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read order events: %w", err)
+		}
+		var eventType, payload string
+		if err := row.Columns(&eventType, &payload); err != nil {
+			return nil, fmt.Errorf("failed to scan order event: %w", err)
+		}
+		event, err := decodeOrderEvent(events.EventType(eventType), []byte(payload))
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, event)
+	}
+
+	if snapshot == nil && len(history) == 0 {
+		return nil, errNoStream
+	}
+	order, err := domain.ReplayOrder(snapshot, history)
+	if err != nil {
+		return nil, err
+	}
+	if err := order.Validate(); err != nil {
+		return nil, &platformspanner.CorruptRowError{Table: "OrderEvents", Key: spanner.Key{id.String()}, Err: err}
+	}
+	return order, nil
+}
+
+func (r *EventSourcedRepository) FindByUserRef(ctx context.Context, userRef domain.UserRef, offset, limit int) ([]*domain.Order, int, error) {
+	return r.state.FindByUserRef(ctx, userRef, offset, limit)
+}
+
+func (r *EventSourcedRepository) FindByStatus(ctx context.Context, status domain.Status, offset, limit int) ([]*domain.Order, int, error) {
+	return r.state.FindByStatus(ctx, status, offset, limit)
+}
+
+func (r *EventSourcedRepository) FindByUserRefAndStatus(ctx context.Context, userRef domain.UserRef, status domain.Status, offset, limit int) ([]*domain.Order, int, error) {
+	return r.state.FindByUserRefAndStatus(ctx, userRef, status, offset, limit)
+}
+
+func (r *EventSourcedRepository) Search(ctx context.Context, criteria domain.OrderSearchCriteria, offset, limit int) ([]*domain.Order, int, error) {
+	return r.state.Search(ctx, criteria, offset, limit)
+}
+
+func (r *EventSourcedRepository) FindStaleDrafts(ctx context.Context, cutoff time.Time, limit int) ([]domain.StaleDraft, error) {
+	return r.state.FindStaleDrafts(ctx, cutoff, limit)
+}
+
+// Delete deletes the stream and the snapshot of an order with its Orders
+// rows, if the order is of the tenant of the request.
+func (r *EventSourcedRepository) Delete(ctx context.Context, id domain.OrderID) error {
+	params := map[string]interface{}{"orderID": id.String(), "tenantID": requestcontext.TenantID(ctx)}
+	if err := platformspanner.Write(ctx,
+		spanner.Statement{
+			SQL: `DELETE FROM OrderEvents WHERE OrderID = @orderID
+			      AND EXISTS (SELECT 1 FROM Orders WHERE OrderID = @orderID AND TenantID = @tenantID)`,
+			Params: params,
+		},
+		spanner.Statement{
+			SQL: `DELETE FROM OrderEventSnapshots WHERE OrderID = @orderID
+			      AND EXISTS (SELECT 1 FROM Orders WHERE OrderID = @orderID AND TenantID = @tenantID)`,
+			Params: params,
+		},
+	); err != nil {
+		return fmt.Errorf("failed to delete order events: %w", err)
+	}
+	return r.state.Delete(ctx, id)
+}
+
+// orderEventDecoders decodes the stored events by type. Every event an
+// Order raises must be listed.
+var orderEventDecoders = map[events.EventType]func([]byte) (events.Event, error){
+	domain.OrderCreatedEventType:         decodeEvent[domain.OrderCreatedEvent],
+	domain.OrderStatusChangedEventType:   decodeEvent[domain.OrderStatusChangedEvent],
+	domain.OrderItemsChangedEventType:    decodeEvent[domain.OrderItemsChangedEvent],
+	domain.OrderShippingChangedEventType: decodeEvent[domain.OrderShippingChangedEvent],
+	domain.OrderDiscountAppliedEventType: decodeEvent[domain.OrderDiscountAppliedEvent],
+	domain.OrderDiscountRemovedEventType: decodeEvent[domain.OrderDiscountRemovedEvent],
+	domain.OrderClaimedEventType:         decodeEvent[domain.OrderClaimedEvent],
+	domain.OrderSubmittedEventType:       decodeEvent[orderevents.OrderSubmittedEvent],
+	domain.OrderCancelledEventType:       decodeEvent[orderevents.OrderCancelledEvent],
+	domain.OrderExpiredEventType:         decodeEvent[domain.OrderExpiredEvent],
+	domain.OrderConfirmedEventType:       decodeEvent[orderevents.OrderConfirmedEvent],
+	domain.OrderCompletedEventType:       decodeEvent[orderevents.OrderCompletedEvent],
+	domain.RefundIssuedEventType:         decodeEvent[orderevents.RefundIssuedEvent],
+}
+
+func decodeOrderEvent(eventType events.EventType, payload []byte) (events.Event, error) {
+	decode, ok := orderEventDecoders[eventType]
+	if !ok {
+		return nil, fmt.Errorf("failed to decode order event: unknown type %s", eventType)
+	}
+	return decode(payload)
+}
+
+// decodeEvent unmarshals an envelope into an E, returned by value as the
+// order raised it.
+func decodeEvent[E events.Event](payload []byte) (events.Event, error) {
+	var event E
+	if err := events.Unmarshal(payload, any(&event).(events.Event)); err != nil {
+		return nil, fmt.Errorf("failed to decode order event: %w", err)
+	}
+	return event, nil
+}
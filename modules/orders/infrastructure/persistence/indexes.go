@@ -0,0 +1,22 @@
+package persistence
+
+import platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+
+// The indexes of the Orders table. Queries read through them with From(),
+// and TestIndexesInSchema checks that schema/schema.sql creates them.
+var (
+	ordersByUserID          = platformspanner.Index{Name: "OrdersByUserID", Table: "Orders", Columns: []string{"UserID"}}
+	ordersByUserIDStatus    = platformspanner.Index{Name: "OrdersByUserIDStatus", Table: "Orders", Columns: []string{"UserID", "Status", "CreatedAt DESC"}}
+	ordersByCreatedAt       = platformspanner.Index{Name: "OrdersByCreatedAt", Table: "Orders", Columns: []string{"CreatedAt DESC"}}
+	ordersByStatusCreatedAt = platformspanner.Index{Name: "OrdersByStatusCreatedAt", Table: "Orders", Columns: []string{"Status", "CreatedAt DESC"}}
+	ordersByStatusUpdatedAt = platformspanner.Index{Name: "OrdersByStatusUpdatedAt", Table: "Orders", Columns: []string{"Status", "UpdatedAt"}}
+)
+
+// Indexes are the secondary indexes the orders queries rely on.
+var Indexes = []platformspanner.Index{
+	ordersByUserID,
+	ordersByUserIDStatus,
+	ordersByCreatedAt,
+	ordersByStatusCreatedAt,
+	ordersByStatusUpdatedAt,
+}
@@ -0,0 +1,203 @@
+package persistence
+
+import (
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+)
+
+// orderState is the JSON encoding of an order in OrderEventSnapshots.
+// Amounts are in minor units of the order currency.
+type orderState struct {
+	ID                   string         `json:"id"`
+	UserID               string         `json:"user_id"`
+	Currency             string         `json:"currency"`
+	Items                []itemState    `json:"items"`
+	Status               string         `json:"status"`
+	Total                int64          `json:"total"`
+	ShippingAddress      addressState   `json:"shipping_address"`
+	DeliveryInstructions string         `json:"delivery_instructions,omitempty"`
+	Discount             *discountState `json:"discount,omitempty"`
+	Promotions           []lineState    `json:"promotions,omitempty"`
+	Taxes                []taxState     `json:"taxes,omitempty"`
+	ReturnReason         string         `json:"return_reason,omitempty"`
+	CancelReason         string         `json:"cancel_reason,omitempty"`
+	CancelledByKind      string         `json:"cancelled_by_kind,omitempty"`
+	CancelledByID        string         `json:"cancelled_by_id,omitempty"`
+	Snapshot             *snapshotState `json:"snapshot,omitempty"`
+	CreatedAt            time.Time      `json:"created_at"`
+	UpdatedAt            time.Time      `json:"updated_at"`
+}
+
+type itemState struct {
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	Quantity    int    `json:"quantity"`
+	UnitPrice   int64  `json:"unit_price"`
+}
+
+type addressState struct {
+	Recipient  string `json:"recipient,omitempty"`
+	Line1      string `json:"line1,omitempty"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city,omitempty"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country,omitempty"`
+}
+
+type discountState struct {
+	Code       string `json:"code"`
+	Kind       string `json:"kind"`
+	PercentOff int64  `json:"percent_off,omitempty"`
+	AmountOff  int64  `json:"amount_off,omitempty"`
+}
+
+type lineState struct {
+	PromotionID string `json:"promotion_id"`
+	Name        string `json:"name"`
+	Amount      int64  `json:"amount"`
+}
+
+type taxState struct {
+	Name    string `json:"name"`
+	RateBps int64  `json:"rate_bps"`
+	Amount  int64  `json:"amount"`
+}
+
+type snapshotState struct {
+	Items    []itemState `json:"items"`
+	Subtotal int64       `json:"subtotal"`
+	Discount int64       `json:"discount"`
+	Tax      int64       `json:"tax"`
+	Total    int64       `json:"total"`
+	TakenAt  time.Time   `json:"taken_at"`
+}
+
+func encodeOrderState(o *domain.Order) orderState {
+	addr := o.ShippingAddress()
+	s := orderState{
+		ID:       o.ID().String(),
+		UserID:   o.UserRef().String(),
+		Currency: o.Currency(),
+		Items:    encodeItems(o.Items()),
+		Status:   o.Status().String(),
+		Total:    o.Total().Amount(),
+		ShippingAddress: addressState{
+			Recipient:  addr.Recipient(),
+			Line1:      addr.Line1(),
+			Line2:      addr.Line2(),
+			City:       addr.City(),
+			Region:     addr.Region(),
+			PostalCode: addr.PostalCode(),
+			Country:    addr.Country(),
+		},
+		DeliveryInstructions: o.DeliveryInstructions(),
+		ReturnReason:         o.ReturnReason(),
+		CancelReason:         o.CancelReason(),
+		CancelledByKind:      string(o.CancelledBy().Kind()),
+		CancelledByID:        o.CancelledBy().ID(),
+		CreatedAt:            o.CreatedAt(),
+		UpdatedAt:            o.UpdatedAt(),
+	}
+	if d := o.Discount(); !d.IsZero() {
+		s.Discount = &discountState{Code: d.Code(), Kind: d.Kind().String(), PercentOff: d.PercentOff(), AmountOff: d.AmountOff().Amount()}
+	}
+	for _, line := range o.Promotions().Lines() {
+		s.Promotions = append(s.Promotions, lineState{PromotionID: line.PromotionID, Name: line.Name, Amount: line.Amount.Amount()})
+	}
+	for _, line := range o.Tax().Lines() {
+		s.Taxes = append(s.Taxes, taxState{Name: line.Name, RateBps: line.RateBps, Amount: line.Amount.Amount()})
+	}
+	if snap := o.Snapshot(); !snap.IsZero() {
+		s.Snapshot = &snapshotState{
+			Items:    encodeItems(snap.Items()),
+			Subtotal: snap.Subtotal().Amount(),
+			Discount: snap.Discount().Amount(),
+			Tax:      snap.Tax().Amount(),
+			Total:    snap.Total().Amount(),
+			TakenAt:  snap.TakenAt(),
+		}
+	}
+	return s
+}
+
+func encodeItems(items []domain.OrderItem) []itemState {
+	states := make([]itemState, len(items))
+	for i, item := range items {
+		states[i] = itemState{ProductID: item.ProductID, ProductName: item.ProductName, Quantity: item.Quantity, UnitPrice: item.UnitPrice.Amount()}
+	}
+	return states
+}
+
+func (s orderState) decode() (*domain.Order, error) {
+	id, err := domain.ParseOrderID(s.ID)
+	if err != nil {
+		return nil, err
+	}
+	userRef, err := domain.ParseOwnerRef(s.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := domain.ParseCurrency(s.Currency); err != nil {
+		return nil, err
+	}
+	status, err := domain.ParseStatus(s.Status)
+	if err != nil {
+		return nil, err
+	}
+	money := func(amount int64) domain.Money { return domain.MustNewMoney(amount, s.Currency) }
+
+	var discount domain.Discount
+	if d := s.Discount; d != nil {
+		var amountOff domain.Money
+		if domain.DiscountKind(d.Kind) == domain.DiscountFixedAmount {
+			amountOff = money(d.AmountOff)
+		}
+		discount = domain.ReconstituteDiscount(d.Code, domain.DiscountKind(d.Kind), d.PercentOff, amountOff)
+	}
+	var promotions []domain.PromotionLine
+	for _, line := range s.Promotions {
+		promotions = append(promotions, domain.PromotionLine{PromotionID: line.PromotionID, Name: line.Name, Amount: money(line.Amount)})
+	}
+	var taxes []domain.TaxLine
+	for _, line := range s.Taxes {
+		taxes = append(taxes, domain.TaxLine{Name: line.Name, RateBps: line.RateBps, Amount: money(line.Amount)})
+	}
+	var snapshot domain.OrderSnapshot
+	if snap := s.Snapshot; snap != nil {
+		snapshot = domain.ReconstituteOrderSnapshot(s.decodeItems(snap.Items), money(snap.Subtotal), money(snap.Discount), money(snap.Tax), money(snap.Total), snap.TakenAt)
+	}
+	var cancelledBy domain.Actor
+	if s.CancelledByKind != "" {
+		cancelledBy = domain.ReconstituteActor(domain.ActorKind(s.CancelledByKind), s.CancelledByID)
+	}
+
+	a := s.ShippingAddress
+	return domain.Reconstitute(
+		id,
+		userRef,
+		s.decodeItems(s.Items),
+		status,
+		money(s.Total),
+		domain.ReconstituteShippingAddress(a.Recipient, a.Line1, a.Line2, a.City, a.Region, a.PostalCode, a.Country),
+		s.DeliveryInstructions,
+		discount,
+		domain.NewPromotionBreakdown(promotions...),
+		domain.NewTaxBreakdown(taxes...),
+		s.ReturnReason,
+		s.CancelReason,
+		cancelledBy,
+		snapshot,
+		s.CreatedAt,
+		s.UpdatedAt,
+	), nil
+}
+
+func (s orderState) decodeItems(states []itemState) []domain.OrderItem {
+	items := make([]domain.OrderItem, len(states))
+	for i, item := range states {
+		items[i] = domain.OrderItem{ProductID: item.ProductID, ProductName: item.ProductName, Quantity: item.Quantity, UnitPrice: domain.MustNewMoney(item.UnitPrice, s.Currency)}
+	}
+	return items
+}
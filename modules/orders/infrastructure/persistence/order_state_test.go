@@ -0,0 +1,99 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock/clocktest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// submittedOrder returns an order that went through most kinds of change.
+func submittedOrder(t *testing.T) *domain.Order {
+	t.Helper()
+	ctx, clk := clocktest.Context(context.Background(), time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	userRef, err := domain.NewUserRef(uuid.New().String())
+	if err != nil {
+		t.Fatalf("failed to create user ref: %v", err)
+	}
+	address, err := domain.NewShippingAddress("Ada Lovelace", "1 Main St", "", "London", "", "N1 9GU", "GB")
+	if err != nil {
+		t.Fatalf("failed to create address: %v", err)
+	}
+
+	var order *domain.Order
+	_, err = events.CaptureEvents(ctx, func(ctx context.Context) error {
+		if order, err = domain.NewOrder(ctx, userRef, "EUR"); err != nil {
+			return err
+		}
+		clk.Advance(time.Minute)
+		if err := order.AddItem(ctx, domain.OrderLimits{}, "p-1", "Widget", 3, domain.MustNewMoney(500, "EUR")); err != nil {
+			return err
+		}
+		if err := order.SetShipping(ctx, address, ""); err != nil {
+			return err
+		}
+		discount := domain.ReconstituteDiscount("TAKE2", domain.DiscountFixedAmount, 0, domain.MustNewMoney(200, "EUR"))
+		if err := order.ApplyDiscount(ctx, discount); err != nil {
+			return err
+		}
+		clk.Advance(time.Minute)
+		return order.Submit(ctx, nil, domain.FlatRateTaxCalculator{Name: "VAT", RateBps: 2000}, domain.OrderLimits{})
+	})
+	if err != nil {
+		t.Fatalf("failed to build order: %v", err)
+	}
+	return order
+}
+
+func TestOrderState_RoundTrip(t *testing.T) {
+	order := submittedOrder(t)
+	order.ClearChanges()
+
+	data, err := json.Marshal(encodeOrderState(order))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var s orderState
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	got, err := s.decode()
+	if err != nil {
+		t.Fatalf("decode() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, order) {
+		t.Errorf("decode() = %+v\nwant %+v", got, order)
+	}
+}
+
+func TestOrderState_DecodeInvalidStatus(t *testing.T) {
+	s := encodeOrderState(submittedOrder(t))
+	s.Status = "shipped"
+
+	if _, err := s.decode(); !errors.Is(err, domain.ErrStatusInvalid) {
+		t.Errorf("decode() error = %v, want ErrStatusInvalid", err)
+	}
+}
+
+func TestDecodeOrderEvent_RoundTrip(t *testing.T) {
+	for _, event := range submittedOrder(t).Changes() {
+		payload, err := events.Marshal(event)
+		if err != nil {
+			t.Fatalf("Marshal(%s) error = %v", event.EventType(), err)
+		}
+		got, err := decodeOrderEvent(event.EventType(), payload)
+		if err != nil {
+			t.Fatalf("decodeOrderEvent(%s) error = %v", event.EventType(), err)
+		}
+		if !reflect.DeepEqual(got, event) {
+			t.Errorf("decodeOrderEvent(%s) = %+v, want %+v", event.EventType(), got, event)
+		}
+	}
+}
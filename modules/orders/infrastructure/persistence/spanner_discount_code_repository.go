@@ -0,0 +1,101 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+var discountCodeColumns = []string{
+	"Code", "Kind", "PercentOff", "AmountOff", "Currency",
+	"ExpiresAt", "MaxUses", "UsedCount", "CreatedAt", "UpdatedAt",
+}
+
+// SpannerDiscountCodeRepository implements DiscountCodeRepository using
+// Cloud Spanner. Codes are keyed by (TenantID, Code): the same code may be
+// issued by several tenants, and is only found in its own.
+type SpannerDiscountCodeRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerDiscountCodeRepository(client *spanner.Client, logger *slog.Logger) *SpannerDiscountCodeRepository {
+	return &SpannerDiscountCodeRepository{client: client, logger: logger}
+}
+
+// Save persists a discount code using DML for read-your-writes consistency.
+func (r *SpannerDiscountCodeRepository) Save(ctx context.Context, code *domain.DiscountCode) error {
+	discount := code.Discount()
+	var expiresAt spanner.NullTime
+	if !code.ExpiresAt().IsZero() {
+		expiresAt = spanner.NullTime{Time: code.ExpiresAt(), Valid: true}
+	}
+
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO DiscountCodes (TenantID, Code, Kind, PercentOff, AmountOff, Currency,
+		          ExpiresAt, MaxUses, UsedCount, CreatedAt, UpdatedAt)
+		      VALUES (@tenantID, @code, @kind, @percentOff, @amountOff, @currency,
+		          @expiresAt, @maxUses, @usedCount, @createdAt, @updatedAt)`,
+		Params: map[string]interface{}{
+			"tenantID":   requestcontext.TenantID(ctx),
+			"code":       code.Code(),
+			"kind":       discount.Kind().String(),
+			"percentOff": nullInt64(discount.PercentOff()),
+			"amountOff":  nullInt64(discount.AmountOff().Amount()),
+			"currency":   nullString(discount.AmountOff().Currency()),
+			"expiresAt":  expiresAt,
+			"maxUses":    int64(code.MaxUses()),
+			"usedCount":  int64(code.UsedCount()),
+			"createdAt":  code.CreatedAt(),
+			"updatedAt":  code.UpdatedAt(),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save discount code: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerDiscountCodeRepository) FindByCode(ctx context.Context, code string) (*domain.DiscountCode, error) {
+	return platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (*domain.DiscountCode, error) {
+		row, err := reader.ReadRow(ctx, "DiscountCodes", spanner.Key{requestcontext.TenantID(ctx), code}, discountCodeColumns)
+		if err != nil {
+			if spanner.ErrCode(err) == codes.NotFound {
+				return nil, domain.ErrDiscountCodeNotFound
+			}
+			return nil, fmt.Errorf("failed to read discount code: %w", err)
+		}
+
+		var codeValue, kind string
+		var percentOff, amountOff spanner.NullInt64
+		var currency spanner.NullString
+		var expiresAt spanner.NullTime
+		var maxUses, usedCount int64
+		var createdAt, updatedAt time.Time
+
+		if err := row.Columns(&codeValue, &kind, &percentOff, &amountOff, &currency,
+			&expiresAt, &maxUses, &usedCount, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan discount code: %w", err)
+		}
+
+		var amount domain.Money
+		if currency.Valid {
+			amount = domain.MustNewMoney(amountOff.Int64, currency.StringVal)
+		}
+		discount := domain.ReconstituteDiscount(codeValue, domain.DiscountKind(kind), percentOff.Int64, amount)
+
+		var expires time.Time
+		if expiresAt.Valid {
+			expires = expiresAt.Time
+		}
+
+		return domain.ReconstituteDiscountCode(discount, expires, int(maxUses), int(usedCount), createdAt, updatedAt), nil
+	})
+}
@@ -0,0 +1,229 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+var orderSummaryColumns = []string{
+	"OrderID", "UserID", "UserEmail", "ItemCount", "TotalAmount", "Currency", "Status", "CreatedAt", "UpdatedAt",
+}
+
+// SpannerOrderSummaryRepository implements OrderSummaryRepository using Cloud
+// Spanner. Summaries and user emails carry the tenant of the event or
+// request that wrote them, and every read, update and delete is scoped to
+// the tenant of its context.
+type SpannerOrderSummaryRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerOrderSummaryRepository(client *spanner.Client, logger *slog.Logger) *SpannerOrderSummaryRepository {
+	return &SpannerOrderSummaryRepository{client: client, logger: logger}
+}
+
+func (r *SpannerOrderSummaryRepository) Save(ctx context.Context, s domain.OrderSummary) error {
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO OrderSummaries (OrderID, TenantID, UserID, UserEmail, ItemCount, TotalAmount, Currency, Status, CreatedAt, UpdatedAt)
+		      VALUES (@orderID, @tenantID, @userID, @userEmail, @itemCount, @totalAmount, @currency, @status, @createdAt, @updatedAt)`,
+		Params: map[string]interface{}{
+			"orderID":     s.OrderID.String(),
+			"tenantID":    requestcontext.TenantID(ctx),
+			"userID":      s.UserRef.String(),
+			"userEmail":   nullString(s.UserEmail),
+			"itemCount":   int64(s.ItemCount),
+			"totalAmount": s.Total.Amount(),
+			"currency":    s.Total.Currency(),
+			"status":      s.Status.String(),
+			"createdAt":   s.CreatedAt,
+			"updatedAt":   s.UpdatedAt,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save order summary: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerOrderSummaryRepository) FindByOrderID(ctx context.Context, id domain.OrderID) (domain.OrderSummary, error) {
+	return platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (domain.OrderSummary, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT ` + strings.Join(orderSummaryColumns, ", ") + ` FROM OrderSummaries WHERE OrderID = @orderID AND TenantID = @tenantID`,
+			Params: map[string]interface{}{"orderID": id.String(), "tenantID": requestcontext.TenantID(ctx)},
+		})
+		defer iter.Stop()
+
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return domain.OrderSummary{}, domain.ErrOrderNotFound
+		}
+		if err != nil {
+			return domain.OrderSummary{}, fmt.Errorf("failed to read order summary: %w", err)
+		}
+		return scanOrderSummary(row)
+	})
+}
+
+// List returns summaries newest first. Filtering by user is served by the
+// OrderSummariesByUserID index.
+func (r *SpannerOrderSummaryRepository) List(ctx context.Context, filter domain.OrderSummaryFilter, offset, limit int) ([]domain.OrderSummary, int, error) {
+	conds := []string{"TenantID = @tenantID"}
+	params := map[string]interface{}{"tenantID": requestcontext.TenantID(ctx)}
+	if !filter.UserRef.IsZero() {
+		conds = append(conds, "UserID = @userID")
+		params["userID"] = filter.UserRef.String()
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, s := range filter.Statuses {
+			statuses[i] = s.String()
+		}
+		conds = append(conds, "Status IN UNNEST(@statuses)")
+		params["statuses"] = statuses
+	}
+	where := " WHERE " + strings.Join(conds, " AND ")
+
+	var total int
+	summaries, err := platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]domain.OrderSummary, error) {
+		countIter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT COUNT(*) FROM OrderSummaries` + where,
+			Params: params,
+		})
+		defer countIter.Stop()
+
+		var totalCount int64
+		countRow, err := countIter.Next()
+		if err != nil && err != iterator.Done {
+			return nil, fmt.Errorf("failed to count order summaries: %w", err)
+		}
+		if countRow != nil {
+			if err := countRow.Columns(&totalCount); err != nil {
+				return nil, fmt.Errorf("failed to scan count: %w", err)
+			}
+		}
+		total = int(totalCount)
+
+		pageParams := map[string]interface{}{"limit": int64(limit), "offset": int64(offset)}
+		for k, v := range params {
+			pageParams[k] = v
+		}
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT ` + strings.Join(orderSummaryColumns, ", ") + `
+			      FROM OrderSummaries` + where + `
+			      ORDER BY CreatedAt DESC
+			      LIMIT @limit OFFSET @offset`,
+			Params: pageParams,
+		})
+		defer iter.Stop()
+
+		var summaries []domain.OrderSummary
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query order summaries: %w", err)
+			}
+
+			s, err := scanOrderSummary(row)
+			if err != nil {
+				return nil, err
+			}
+			summaries = append(summaries, s)
+		}
+		return summaries, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return summaries, total, nil
+}
+
+// SaveUserEmail upserts the user's row in OrderSummaryUsers and rewrites the
+// email on the user's existing summaries in the same transaction.
+func (r *SpannerOrderSummaryRepository) SaveUserEmail(ctx context.Context, userRef domain.UserRef, email string) error {
+	tenantID := requestcontext.TenantID(ctx)
+	if err := platformspanner.Write(ctx,
+		spanner.Statement{
+			SQL: `INSERT OR UPDATE INTO OrderSummaryUsers (TenantID, UserID, Email, UpdatedAt)
+			      VALUES (@tenantID, @userID, @email, @updatedAt)`,
+			Params: map[string]interface{}{"tenantID": tenantID, "userID": userRef.String(), "email": email, "updatedAt": time.Now().UTC()},
+		},
+		spanner.Statement{
+			SQL:    `UPDATE OrderSummaries SET UserEmail = @email WHERE TenantID = @tenantID AND UserID = @userID`,
+			Params: map[string]interface{}{"tenantID": tenantID, "userID": userRef.String(), "email": email},
+		},
+	); err != nil {
+		return fmt.Errorf("failed to save order summary user email: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerOrderSummaryRepository) FindUserEmail(ctx context.Context, userRef domain.UserRef) (string, error) {
+	return platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (string, error) {
+		row, err := reader.ReadRow(ctx, "OrderSummaryUsers", spanner.Key{requestcontext.TenantID(ctx), userRef.String()}, []string{"Email"})
+		if err != nil {
+			if spanner.ErrCode(err) == codes.NotFound {
+				return "", nil
+			}
+			return "", fmt.Errorf("failed to read order summary user: %w", err)
+		}
+
+		var email string
+		if err := row.Columns(&email); err != nil {
+			return "", fmt.Errorf("failed to scan order summary user: %w", err)
+		}
+		return email, nil
+	})
+}
+
+// DeleteAll deletes the summaries of the tenant with a partitioned DML
+// statement, which is not bounded by the mutation limit of a transaction and
+// so runs outside of one.
+func (r *SpannerOrderSummaryRepository) DeleteAll(ctx context.Context) error {
+	if _, err := r.client.PartitionedUpdate(ctx, spanner.Statement{
+		SQL:    `DELETE FROM OrderSummaries WHERE TenantID = @tenantID`,
+		Params: map[string]interface{}{"tenantID": requestcontext.TenantID(ctx)},
+	}); err != nil {
+		return fmt.Errorf("failed to delete order summaries: %w", err)
+	}
+	return nil
+}
+
+func scanOrderSummary(row *spanner.Row) (domain.OrderSummary, error) {
+	var orderID, userID, currency, status string
+	var userEmail spanner.NullString
+	var itemCount, totalAmount int64
+	var createdAt, updatedAt time.Time
+
+	if err := row.Columns(&orderID, &userID, &userEmail, &itemCount, &totalAmount, &currency, &status, &createdAt, &updatedAt); err != nil {
+		return domain.OrderSummary{}, fmt.Errorf("failed to scan order summary: %w", err)
+	}
+
+	parsedOrderID, err := domain.ParseOrderID(orderID)
+	if err != nil {
+		return domain.OrderSummary{}, fmt.Errorf("failed to parse order id: %w", err)
+	}
+
+	return domain.OrderSummary{
+		OrderID:   parsedOrderID,
+		UserRef:   domain.MustNewUserRef(userID),
+		UserEmail: userEmail.StringVal,
+		ItemCount: int(itemCount),
+		Total:     domain.MustNewMoney(totalAmount, currency),
+		Status:    domain.Status(status),
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}, nil
+}
@@ -5,6 +5,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/spanner"
@@ -13,8 +15,23 @@ import (
 
 	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
 	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
 )
 
+// orderColumns lists the Orders columns in the order expected by scanOrder.
+var orderColumns = []string{
+	"OrderID", "UserID", "Status", "TotalAmount", "TotalCurrency",
+	"ShippingRecipient", "ShippingLine1", "ShippingLine2", "ShippingCity",
+	"ShippingRegion", "ShippingPostalCode", "ShippingCountry", "DeliveryInstructions",
+	"DiscountCode", "DiscountKind", "DiscountPercentOff", "DiscountAmountOff", "DiscountCurrency",
+	"ReturnReason", "CancelReason", "CancelledByKind", "CancelledByID",
+	"CreatedAt", "UpdatedAt",
+}
+
+// SpannerRepository implements OrderRepository using Cloud Spanner. Every
+// read and write of the Orders table is scoped to the tenant of the request
+// of its context (requestcontext.TenantID): the orders of other tenants are
+// not found. The child tables are reached through their order.
 type SpannerRepository struct {
 	client *spanner.Client
 	logger *slog.Logger
@@ -27,29 +44,69 @@ func NewSpannerRepository(client *spanner.Client, logger *slog.Logger) *SpannerR
 // Save persists an order using DML for read-your-writes consistency.
 // It uses an existing transaction if available, otherwise creates a new one.
 // All statements are executed in a single BatchUpdate RPC.
+// Orders that fail domain.Order.Validate are rejected before anything is written.
 func (r *SpannerRepository) Save(ctx context.Context, order *domain.Order) error {
+	if err := order.Validate(); err != nil {
+		return fmt.Errorf("refusing to save order: %w", err)
+	}
 	orderID := order.ID().String()
 
-	stmts := make([]spanner.Statement, 0, 2+len(order.Items()))
+	stmts := make([]spanner.Statement, 0, 4+len(order.Items())+len(order.Promotions().Lines())+len(order.Tax().Lines()))
 
-	// Delete existing items first
+	// Delete existing items, promotion and tax lines first
 	stmts = append(stmts, spanner.Statement{
 		SQL:    `DELETE FROM OrderItems WHERE OrderID = @orderID`,
 		Params: map[string]interface{}{"orderID": orderID},
+	}, spanner.Statement{
+		SQL:    `DELETE FROM OrderPromotionLines WHERE OrderID = @orderID`,
+		Params: map[string]interface{}{"orderID": orderID},
+	}, spanner.Statement{
+		SQL:    `DELETE FROM OrderTaxLines WHERE OrderID = @orderID`,
+		Params: map[string]interface{}{"orderID": orderID},
 	})
 
 	// Upsert order
+	addr := order.ShippingAddress()
+	discount := order.Discount()
 	stmts = append(stmts, spanner.Statement{
-		SQL: `INSERT OR UPDATE INTO Orders (OrderID, UserID, Status, TotalAmount, TotalCurrency, CreatedAt, UpdatedAt)
-		      VALUES (@orderID, @userID, @status, @totalAmount, @totalCurrency, @createdAt, @updatedAt)`,
+		SQL: `INSERT OR UPDATE INTO Orders (OrderID, TenantID, UserID, Status, TotalAmount, TotalCurrency,
+		          ShippingRecipient, ShippingLine1, ShippingLine2, ShippingCity,
+		          ShippingRegion, ShippingPostalCode, ShippingCountry, DeliveryInstructions,
+		          DiscountCode, DiscountKind, DiscountPercentOff, DiscountAmountOff, DiscountCurrency,
+		          ReturnReason, CancelReason, CancelledByKind, CancelledByID,
+		          CreatedAt, UpdatedAt)
+		      VALUES (@orderID, @tenantID, @userID, @status, @totalAmount, @totalCurrency,
+		          @shippingRecipient, @shippingLine1, @shippingLine2, @shippingCity,
+		          @shippingRegion, @shippingPostalCode, @shippingCountry, @deliveryInstructions,
+		          @discountCode, @discountKind, @discountPercentOff, @discountAmountOff, @discountCurrency,
+		          @returnReason, @cancelReason, @cancelledByKind, @cancelledByID,
+		          @createdAt, @updatedAt)`,
 		Params: map[string]interface{}{
-			"orderID":       orderID,
-			"userID":        order.UserRef().String(),
-			"status":        order.Status().String(),
-			"totalAmount":   order.Total().Amount(),
-			"totalCurrency": order.Total().Currency(),
-			"createdAt":     order.CreatedAt(),
-			"updatedAt":     order.UpdatedAt(),
+			"orderID":              orderID,
+			"tenantID":             requestcontext.TenantID(ctx),
+			"userID":               order.UserRef().String(),
+			"status":               order.Status().String(),
+			"totalAmount":          order.Total().Amount(),
+			"totalCurrency":        order.Total().Currency(),
+			"shippingRecipient":    nullString(addr.Recipient()),
+			"shippingLine1":        nullString(addr.Line1()),
+			"shippingLine2":        nullString(addr.Line2()),
+			"shippingCity":         nullString(addr.City()),
+			"shippingRegion":       nullString(addr.Region()),
+			"shippingPostalCode":   nullString(addr.PostalCode()),
+			"shippingCountry":      nullString(addr.Country()),
+			"deliveryInstructions": nullString(order.DeliveryInstructions()),
+			"discountCode":         nullString(discount.Code()),
+			"discountKind":         nullString(discount.Kind().String()),
+			"discountPercentOff":   nullInt64(discount.PercentOff()),
+			"discountAmountOff":    nullInt64(discount.AmountOff().Amount()),
+			"discountCurrency":     nullString(discount.AmountOff().Currency()),
+			"returnReason":         nullString(order.ReturnReason()),
+			"cancelReason":         nullString(order.CancelReason()),
+			"cancelledByKind":      nullString(order.CancelledBy().Kind().String()),
+			"cancelledByID":        nullString(order.CancelledBy().ID()),
+			"createdAt":            order.CreatedAt(),
+			"updatedAt":            order.UpdatedAt(),
 		},
 	})
 
@@ -70,6 +127,70 @@ func (r *SpannerRepository) Save(ctx context.Context, order *domain.Order) error
 		})
 	}
 
+	// Insert promotion lines
+	for i, line := range order.Promotions().Lines() {
+		stmts = append(stmts, spanner.Statement{
+			SQL: `INSERT OR UPDATE INTO OrderPromotionLines (OrderID, LineIndex, PromotionID, Name, Amount, Currency)
+			      VALUES (@orderID, @lineIndex, @promotionID, @name, @amount, @currency)`,
+			Params: map[string]interface{}{
+				"orderID":     orderID,
+				"lineIndex":   int64(i),
+				"promotionID": line.PromotionID,
+				"name":        line.Name,
+				"amount":      line.Amount.Amount(),
+				"currency":    line.Amount.Currency(),
+			},
+		})
+	}
+
+	// Insert tax lines
+	for i, line := range order.Tax().Lines() {
+		stmts = append(stmts, spanner.Statement{
+			SQL: `INSERT OR UPDATE INTO OrderTaxLines (OrderID, LineIndex, Name, RateBps, Amount, Currency)
+			      VALUES (@orderID, @lineIndex, @name, @rateBps, @amount, @currency)`,
+			Params: map[string]interface{}{
+				"orderID":   orderID,
+				"lineIndex": int64(i),
+				"name":      line.Name,
+				"rateBps":   line.RateBps,
+				"amount":    line.Amount.Amount(),
+				"currency":  line.Amount.Currency(),
+			},
+		})
+	}
+
+	// Snapshots are immutable: written once on submit and never overwritten.
+	if snapshot := order.Snapshot(); !snapshot.IsZero() {
+		stmts = append(stmts, spanner.Statement{
+			SQL: `INSERT OR IGNORE INTO OrderSnapshots (OrderID, SubtotalAmount, DiscountAmount, TaxAmount, TotalAmount, Currency, TakenAt)
+			      VALUES (@orderID, @subtotalAmount, @discountAmount, @taxAmount, @totalAmount, @currency, @takenAt)`,
+			Params: map[string]interface{}{
+				"orderID":        orderID,
+				"subtotalAmount": snapshot.Subtotal().Amount(),
+				"discountAmount": snapshot.Discount().Amount(),
+				"taxAmount":      snapshot.Tax().Amount(),
+				"totalAmount":    snapshot.Total().Amount(),
+				"currency":       snapshot.Total().Currency(),
+				"takenAt":        snapshot.TakenAt(),
+			},
+		})
+		for i, item := range snapshot.Items() {
+			stmts = append(stmts, spanner.Statement{
+				SQL: `INSERT OR IGNORE INTO OrderSnapshotItems (OrderID, ItemIndex, ProductID, ProductName, Quantity, UnitAmount, Currency)
+				      VALUES (@orderID, @itemIndex, @productID, @productName, @quantity, @unitAmount, @currency)`,
+				Params: map[string]interface{}{
+					"orderID":     orderID,
+					"itemIndex":   int64(i),
+					"productID":   item.ProductID,
+					"productName": item.ProductName,
+					"quantity":    int64(item.Quantity),
+					"unitAmount":  item.UnitPrice.Amount(),
+					"currency":    item.UnitPrice.Currency(),
+				},
+			})
+		}
+	}
+
 	if err := platformspanner.Write(ctx, stmts...); err != nil {
 		return fmt.Errorf("failed to save order: %w", err)
 	}
@@ -78,57 +199,91 @@ func (r *SpannerRepository) Save(ctx context.Context, order *domain.Order) error
 
 func (r *SpannerRepository) FindByID(ctx context.Context, id domain.OrderID) (*domain.Order, error) {
 	return platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (*domain.Order, error) {
-		row, err := reader.ReadRow(ctx, "Orders",
-			spanner.Key{id.String()},
-			[]string{"OrderID", "UserID", "Status", "TotalAmount", "TotalCurrency", "CreatedAt", "UpdatedAt"},
-		)
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT ` + strings.Join(orderColumns, ", ") + ` FROM Orders WHERE OrderID = @orderID AND TenantID = @tenantID`,
+			Params: map[string]interface{}{"orderID": id.String(), "tenantID": requestcontext.TenantID(ctx)},
+		})
+		defer iter.Stop()
+
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return nil, domain.ErrOrderNotFound
+		}
 		if err != nil {
-			if spanner.ErrCode(err) == codes.NotFound {
-				return nil, domain.ErrOrderNotFound
-			}
 			return nil, fmt.Errorf("failed to read order: %w", err)
 		}
 
-		var orderID, userID, status, totalCurrency string
-		var totalAmount int64
-		var createdAt, updatedAt time.Time
+		return r.scanOrder(ctx, reader, row)
+	})
+}
 
-		if err := row.Columns(&orderID, &userID, &status, &totalAmount, &totalCurrency, &createdAt, &updatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan order: %w", err)
-		}
+// FindByIDs reads the Orders rows in one query; as in the other finders, the
+// child rows of each order are read after its row.
+func (r *SpannerRepository) FindByIDs(ctx context.Context, ids []domain.OrderID) ([]*domain.Order, error) {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = id.String()
+	}
+	return platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.Order, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT ` + strings.Join(orderColumns, ", ") + ` FROM Orders WHERE OrderID IN UNNEST(@orderIDs) AND TenantID = @tenantID`,
+			Params: map[string]interface{}{"orderIDs": keys, "tenantID": requestcontext.TenantID(ctx)},
+		})
+		defer iter.Stop()
 
-		items, err := r.readOrderItems(ctx, reader, orderID)
-		if err != nil {
-			return nil, err
-		}
+		var orders []*domain.Order
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read orders: %w", err)
+			}
 
-		parsedOrderID, err := domain.ParseOrderID(orderID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse order id: %w", err)
+			order, err := r.scanOrder(ctx, reader, row)
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, order)
 		}
 
-		userRef := domain.MustNewUserRef(userID)
-		total := domain.MustNewMoney(totalAmount, totalCurrency)
-
-		return domain.Reconstitute(
-			parsedOrderID,
-			userRef,
-			items,
-			domain.Status(status),
-			total,
-			createdAt,
-			updatedAt,
-		), nil
+		return orders, nil
 	})
 }
 
 func (r *SpannerRepository) FindByUserRef(ctx context.Context, userRef domain.UserRef, offset, limit int) ([]*domain.Order, int, error) {
+	return r.findPage(ctx, ordersByUserID, "UserID = @userID", map[string]interface{}{
+		"userID": userRef.String(),
+	}, offset, limit)
+}
+
+// FindByStatus reads through OrdersByStatusCreatedAt.
+func (r *SpannerRepository) FindByStatus(ctx context.Context, status domain.Status, offset, limit int) ([]*domain.Order, int, error) {
+	return r.findPage(ctx, ordersByStatusCreatedAt, "Status = @status", map[string]interface{}{
+		"status": status.String(),
+	}, offset, limit)
+}
+
+// FindByUserRefAndStatus reads through OrdersByUserIDStatus.
+func (r *SpannerRepository) FindByUserRefAndStatus(ctx context.Context, userRef domain.UserRef, status domain.Status, offset, limit int) ([]*domain.Order, int, error) {
+	return r.findPage(ctx, ordersByUserIDStatus, "UserID = @userID AND Status = @status", map[string]interface{}{
+		"userID": userRef.String(),
+		"status": status.String(),
+	}, offset, limit)
+}
+
+// findPage reads a page of the orders of the tenant matching where through
+// idx, newest first, with their total count, from one snapshot.
+func (r *SpannerRepository) findPage(ctx context.Context, idx platformspanner.Index, where string, params map[string]interface{}, offset, limit int) ([]*domain.Order, int, error) {
+	where = "TenantID = @tenantID AND " + where
+	params["tenantID"] = requestcontext.TenantID(ctx)
 	var total int
 	orders, err := platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.Order, error) {
 		// Get total count
 		countStmt := spanner.Statement{
-			SQL:    `SELECT COUNT(*) FROM Orders WHERE UserID = @userID`,
-			Params: map[string]interface{}{"userID": userRef.String()},
+			SQL:    `SELECT COUNT(*) FROM ` + idx.From() + ` WHERE ` + where,
+			Params: params,
 		}
 
 		countIter := reader.Query(ctx, countStmt)
@@ -147,17 +302,16 @@ func (r *SpannerRepository) FindByUserRef(ctx context.Context, userRef domain.Us
 		total = int(totalCount)
 
 		// Query orders with pagination
+		pageParams := maps.Clone(params)
+		pageParams["limit"] = int64(limit)
+		pageParams["offset"] = int64(offset)
 		stmt := spanner.Statement{
-			SQL: `SELECT OrderID, UserID, Status, TotalAmount, TotalCurrency, CreatedAt, UpdatedAt
-			      FROM Orders@{FORCE_INDEX=OrdersByUserID}
-			      WHERE UserID = @userID
+			SQL: `SELECT ` + strings.Join(orderColumns, ", ") + `
+			      FROM ` + idx.From() + `
+			      WHERE ` + where + `
 			      ORDER BY CreatedAt DESC
 			      LIMIT @limit OFFSET @offset`,
-			Params: map[string]interface{}{
-				"userID": userRef.String(),
-				"limit":  int64(limit),
-				"offset": int64(offset),
-			},
+			Params: pageParams,
 		}
 
 		iter := reader.Query(ctx, stmt)
@@ -173,32 +327,78 @@ func (r *SpannerRepository) FindByUserRef(ctx context.Context, userRef domain.Us
 				return nil, fmt.Errorf("failed to query orders: %w", err)
 			}
 
-			var orderID, userIDStr, status, totalCurrency string
-			var totalAmount int64
-			var createdAt, updatedAt time.Time
+			order, err := r.scanOrder(ctx, reader, row)
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, order)
+		}
+
+		return orders, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return orders, total, nil
+}
+
+// Search finds orders matching the criteria using parameterized SQL.
+// Filters are combined with AND; the status and created-at filters are
+// served by the OrdersByStatusCreatedAt and OrdersByCreatedAt indexes.
+func (r *SpannerRepository) Search(ctx context.Context, criteria domain.OrderSearchCriteria, offset, limit int) ([]*domain.Order, int, error) {
+	where, params := searchFilter(requestcontext.TenantID(ctx), criteria)
 
-			if err := row.Columns(&orderID, &userIDStr, &status, &totalAmount, &totalCurrency, &createdAt, &updatedAt); err != nil {
-				return nil, fmt.Errorf("failed to scan order: %w", err)
+	var total int
+	orders, err := platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.Order, error) {
+		countIter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT COUNT(*) FROM Orders` + where,
+			Params: params,
+		})
+		defer countIter.Stop()
+
+		var totalCount int64
+		countRow, err := countIter.Next()
+		if err != nil && err != iterator.Done {
+			return nil, fmt.Errorf("failed to count orders: %w", err)
+		}
+		if countRow != nil {
+			if err := countRow.Columns(&totalCount); err != nil {
+				return nil, fmt.Errorf("failed to scan count: %w", err)
 			}
+		}
+		total = int(totalCount)
+
+		pageParams := make(map[string]interface{}, len(params)+2)
+		for k, v := range params {
+			pageParams[k] = v
+		}
+		pageParams["limit"] = int64(limit)
+		pageParams["offset"] = int64(offset)
 
-			items, err := r.readOrderItems(ctx, reader, orderID)
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT ` + strings.Join(orderColumns, ", ") + `
+			      FROM Orders` + where + `
+			      ORDER BY ` + searchOrder(criteria.Sort) + `
+			      LIMIT @limit OFFSET @offset`,
+			Params: pageParams,
+		})
+		defer iter.Stop()
+
+		var orders []*domain.Order
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("failed to search orders: %w", err)
 			}
 
-			parsedOrderID, _ := domain.ParseOrderID(orderID)
-			userRefFromDB := domain.MustNewUserRef(userIDStr)
-			orderTotal := domain.MustNewMoney(totalAmount, totalCurrency)
-
-			orders = append(orders, domain.Reconstitute(
-				parsedOrderID,
-				userRefFromDB,
-				items,
-				domain.Status(status),
-				orderTotal,
-				createdAt,
-				updatedAt,
-			))
+			order, err := r.scanOrder(ctx, reader, row)
+			if err != nil {
+				return nil, err
+			}
+			orders = append(orders, order)
 		}
 
 		return orders, nil
@@ -209,18 +409,216 @@ func (r *SpannerRepository) FindByUserRef(ctx context.Context, userRef domain.Us
 	return orders, total, nil
 }
 
+// searchFilter builds the WHERE clause and parameters for Search in the
+// tenant tenantID. Values are always bound as parameters, never interpolated.
+func searchFilter(tenantID string, c domain.OrderSearchCriteria) (string, map[string]interface{}) {
+	conds := []string{"TenantID = @tenantID"}
+	params := map[string]interface{}{"tenantID": tenantID}
+
+	if !c.UserRef.IsZero() {
+		conds = append(conds, "UserID = @userID")
+		params["userID"] = c.UserRef.String()
+	}
+	if len(c.Statuses) > 0 {
+		statuses := make([]string, len(c.Statuses))
+		for i, s := range c.Statuses {
+			statuses[i] = s.String()
+		}
+		conds = append(conds, "Status IN UNNEST(@statuses)")
+		params["statuses"] = statuses
+	}
+	if !c.CreatedFrom.IsZero() {
+		conds = append(conds, "CreatedAt >= @createdFrom")
+		params["createdFrom"] = c.CreatedFrom
+	}
+	if !c.CreatedTo.IsZero() {
+		conds = append(conds, "CreatedAt < @createdTo")
+		params["createdTo"] = c.CreatedTo
+	}
+	if c.MinTotal != nil {
+		conds = append(conds, "TotalAmount >= @minTotal")
+		params["minTotal"] = *c.MinTotal
+	}
+	if c.MaxTotal != nil {
+		conds = append(conds, "TotalAmount <= @maxTotal")
+		params["maxTotal"] = *c.MaxTotal
+	}
+	if c.Currency != "" {
+		conds = append(conds, "TotalCurrency = @currency")
+		params["currency"] = c.Currency
+	}
+
+	return " WHERE " + strings.Join(conds, " AND "), params
+}
+
+// searchOrder is the ORDER BY clause of Search for sort. Totals in different
+// currencies are not comparable, so sorting by total groups them by currency.
+func searchOrder(sort domain.OrderSort) string {
+	dir := " ASC"
+	if sort.Desc {
+		dir = " DESC"
+	}
+	switch sort.Field {
+	case domain.OrderSortTotal:
+		return "TotalCurrency, TotalAmount" + dir
+	case domain.OrderSortCreatedAt:
+		return "CreatedAt" + dir
+	}
+	return "CreatedAt DESC"
+}
+
+// FindStaleDrafts reads across tenants: see domain.OrderReader.
+func (r *SpannerRepository) FindStaleDrafts(ctx context.Context, cutoff time.Time, limit int) ([]domain.StaleDraft, error) {
+	return platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]domain.StaleDraft, error) {
+		stmt := spanner.Statement{
+			SQL: `SELECT OrderID, TenantID
+			      FROM ` + ordersByStatusUpdatedAt.From() + `
+			      WHERE Status = @status AND UpdatedAt < @cutoff
+			      ORDER BY UpdatedAt
+			      LIMIT @limit`,
+			Params: map[string]interface{}{
+				"status": domain.StatusDraft.String(),
+				"cutoff": cutoff,
+				"limit":  int64(limit),
+			},
+		}
+
+		iter := reader.Query(ctx, stmt)
+		defer iter.Stop()
+
+		var drafts []domain.StaleDraft
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query stale drafts: %w", err)
+			}
+
+			var d domain.StaleDraft
+			if err := row.Columns(&d.ID, &d.TenantID); err != nil {
+				return nil, fmt.Errorf("failed to scan stale draft: %w", err)
+			}
+			drafts = append(drafts, d)
+		}
+
+		return drafts, nil
+	})
+}
+
 func (r *SpannerRepository) Delete(ctx context.Context, id domain.OrderID) error {
 	if err := platformspanner.Write(ctx, spanner.Statement{
-		SQL:    `DELETE FROM Orders WHERE OrderID = @orderID`,
-		Params: map[string]interface{}{"orderID": id.String()},
+		SQL:    `DELETE FROM Orders WHERE OrderID = @orderID AND TenantID = @tenantID`,
+		Params: map[string]interface{}{"orderID": id.String(), "tenantID": requestcontext.TenantID(ctx)},
 	}); err != nil {
 		return fmt.Errorf("failed to delete order: %w", err)
 	}
 	return nil
 }
 
-func (r *SpannerRepository) readOrderItems(ctx context.Context, reader platformspanner.ReadTransaction, orderID string) ([]domain.OrderItem, error) {
-	iter := reader.Read(ctx, "OrderItems",
+// scanOrder builds an Order from a row selected with orderColumns, loading its items.
+func (r *SpannerRepository) scanOrder(ctx context.Context, reader platformspanner.ReadTransaction, row *spanner.Row) (*domain.Order, error) {
+	var orderID, userID, status, totalCurrency string
+	var totalAmount int64
+	var recipient, line1, line2, city, region, postalCode, country, instructions spanner.NullString
+	var discountCode, discountKind, discountCurrency, returnReason spanner.NullString
+	var cancelReason, cancelledByKind, cancelledByID spanner.NullString
+	var discountPercentOff, discountAmountOff spanner.NullInt64
+	var createdAt, updatedAt time.Time
+
+	if err := row.Columns(&orderID, &userID, &status, &totalAmount, &totalCurrency,
+		&recipient, &line1, &line2, &city, &region, &postalCode, &country, &instructions,
+		&discountCode, &discountKind, &discountPercentOff, &discountAmountOff, &discountCurrency,
+		&returnReason, &cancelReason, &cancelledByKind, &cancelledByID,
+		&createdAt, &updatedAt); err != nil {
+		return nil, fmt.Errorf("failed to scan order: %w", err)
+	}
+	parsedStatus, err := domain.ParseStatus(status)
+	if err != nil {
+		return nil, &platformspanner.CorruptRowError{Table: "Orders", Key: spanner.Key{orderID}, Column: "Status", Err: err}
+	}
+
+	items, err := r.readOrderItems(ctx, reader, "OrderItems", orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	promotions, err := r.readOrderPromotionLines(ctx, reader, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	tax, err := r.readOrderTaxLines(ctx, reader, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := r.readOrderSnapshot(ctx, reader, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsedOrderID, err := domain.ParseOrderID(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse order id: %w", err)
+	}
+
+	shippingAddress := domain.ReconstituteShippingAddress(
+		recipient.StringVal, line1.StringVal, line2.StringVal, city.StringVal,
+		region.StringVal, postalCode.StringVal, country.StringVal,
+	)
+
+	var discount domain.Discount
+	if discountCode.Valid {
+		var amountOff domain.Money
+		if discountCurrency.Valid {
+			amountOff = domain.MustNewMoney(discountAmountOff.Int64, discountCurrency.StringVal)
+		}
+		discount = domain.ReconstituteDiscount(
+			discountCode.StringVal, domain.DiscountKind(discountKind.StringVal),
+			discountPercentOff.Int64, amountOff,
+		)
+	}
+
+	order := domain.Reconstitute(
+		parsedOrderID,
+		domain.MustNewUserRef(userID),
+		items,
+		parsedStatus,
+		domain.MustNewMoney(totalAmount, totalCurrency),
+		shippingAddress,
+		instructions.StringVal,
+		discount,
+		promotions,
+		tax,
+		returnReason.StringVal,
+		cancelReason.StringVal,
+		domain.ReconstituteActor(domain.ActorKind(cancelledByKind.StringVal), cancelledByID.StringVal),
+		snapshot,
+		createdAt,
+		updatedAt,
+	)
+	if err := order.Validate(); err != nil {
+		return nil, &platformspanner.CorruptRowError{Table: "Orders", Key: spanner.Key{orderID}, Err: err}
+	}
+	return order, nil
+}
+
+// nullString maps an empty string to a NULL column value.
+func nullString(s string) spanner.NullString {
+	return spanner.NullString{StringVal: s, Valid: s != ""}
+}
+
+// nullInt64 maps zero to a NULL column value.
+func nullInt64(n int64) spanner.NullInt64 {
+	return spanner.NullInt64{Int64: n, Valid: n != 0}
+}
+
+// readOrderItems reads the line items of an order from table, which is
+// OrderItems or OrderSnapshotItems (both share the same columns).
+func (r *SpannerRepository) readOrderItems(ctx context.Context, reader platformspanner.ReadTransaction, table, orderID string) ([]domain.OrderItem, error) {
+	iter := reader.Read(ctx, table,
 		spanner.KeyRange{
 			Start: spanner.Key{orderID},
 			End:   spanner.Key{orderID},
@@ -257,3 +655,130 @@ func (r *SpannerRepository) readOrderItems(ctx context.Context, reader platforms
 
 	return items, nil
 }
+
+func (r *SpannerRepository) readOrderPromotionLines(ctx context.Context, reader platformspanner.ReadTransaction, orderID string) (domain.PromotionBreakdown, error) {
+	iter := reader.Read(ctx, "OrderPromotionLines",
+		spanner.KeyRange{
+			Start: spanner.Key{orderID},
+			End:   spanner.Key{orderID},
+			Kind:  spanner.ClosedClosed,
+		},
+		[]string{"PromotionID", "Name", "Amount", "Currency"},
+	)
+	defer iter.Stop()
+
+	var lines []domain.PromotionLine
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return domain.PromotionBreakdown{}, fmt.Errorf("failed to read order promotion lines: %w", err)
+		}
+
+		var promotionID, name, currency string
+		var amount int64
+
+		if err := row.Columns(&promotionID, &name, &amount, &currency); err != nil {
+			return domain.PromotionBreakdown{}, fmt.Errorf("failed to scan order promotion line: %w", err)
+		}
+
+		lines = append(lines, domain.PromotionLine{
+			PromotionID: promotionID,
+			Name:        name,
+			Amount:      domain.MustNewMoney(amount, currency),
+		})
+	}
+
+	return domain.NewPromotionBreakdown(lines...), nil
+}
+
+func (r *SpannerRepository) readOrderTaxLines(ctx context.Context, reader platformspanner.ReadTransaction, orderID string) (domain.TaxBreakdown, error) {
+	iter := reader.Read(ctx, "OrderTaxLines",
+		spanner.KeyRange{
+			Start: spanner.Key{orderID},
+			End:   spanner.Key{orderID},
+			Kind:  spanner.ClosedClosed,
+		},
+		[]string{"Name", "RateBps", "Amount", "Currency"},
+	)
+	defer iter.Stop()
+
+	var lines []domain.TaxLine
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return domain.TaxBreakdown{}, fmt.Errorf("failed to read order tax lines: %w", err)
+		}
+
+		var name, currency string
+		var rateBps, amount int64
+
+		if err := row.Columns(&name, &rateBps, &amount, &currency); err != nil {
+			return domain.TaxBreakdown{}, fmt.Errorf("failed to scan order tax line: %w", err)
+		}
+
+		lines = append(lines, domain.TaxLine{
+			Name:    name,
+			RateBps: rateBps,
+			Amount:  domain.MustNewMoney(amount, currency),
+		})
+	}
+
+	return domain.NewTaxBreakdown(lines...), nil
+}
+
+// inTenant reports whether the order exists in the tenant of the request.
+func (r *SpannerRepository) inTenant(ctx context.Context, reader platformspanner.ReadTransaction, id domain.OrderID) (bool, error) {
+	iter := reader.Query(ctx, spanner.Statement{
+		SQL:    `SELECT 1 FROM Orders WHERE OrderID = @orderID AND TenantID = @tenantID`,
+		Params: map[string]interface{}{"orderID": id.String(), "tenantID": requestcontext.TenantID(ctx)},
+	})
+	defer iter.Stop()
+
+	_, err := iter.Next()
+	if err == iterator.Done {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read order: %w", err)
+	}
+	return true, nil
+}
+
+// readOrderSnapshot returns the zero snapshot for orders that were never submitted.
+func (r *SpannerRepository) readOrderSnapshot(ctx context.Context, reader platformspanner.ReadTransaction, orderID string) (domain.OrderSnapshot, error) {
+	row, err := reader.ReadRow(ctx, "OrderSnapshots", spanner.Key{orderID},
+		[]string{"SubtotalAmount", "DiscountAmount", "TaxAmount", "TotalAmount", "Currency", "TakenAt"})
+	if err != nil {
+		if spanner.ErrCode(err) == codes.NotFound {
+			return domain.OrderSnapshot{}, nil
+		}
+		return domain.OrderSnapshot{}, fmt.Errorf("failed to read order snapshot: %w", err)
+	}
+
+	var subtotal, discount, tax, total int64
+	var currency string
+	var takenAt time.Time
+	if err := row.Columns(&subtotal, &discount, &tax, &total, &currency, &takenAt); err != nil {
+		return domain.OrderSnapshot{}, fmt.Errorf("failed to scan order snapshot: %w", err)
+	}
+
+	items, err := r.readOrderItems(ctx, reader, "OrderSnapshotItems", orderID)
+	if err != nil {
+		return domain.OrderSnapshot{}, err
+	}
+
+	return domain.ReconstituteOrderSnapshot(
+		items,
+		domain.MustNewMoney(subtotal, currency),
+		domain.MustNewMoney(discount, currency),
+		domain.MustNewMoney(tax, currency),
+		domain.MustNewMoney(total, currency),
+		takenAt,
+	), nil
+}
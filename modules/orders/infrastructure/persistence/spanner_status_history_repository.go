@@ -0,0 +1,90 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+)
+
+type SpannerStatusHistoryRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerStatusHistoryRepository(client *spanner.Client, logger *slog.Logger) *SpannerStatusHistoryRepository {
+	return &SpannerStatusHistoryRepository{client: client, logger: logger}
+}
+
+// Append records a status transition. The event ID is part of the key, so
+// redelivering the same event overwrites rather than duplicates the entry.
+func (r *SpannerStatusHistoryRepository) Append(ctx context.Context, t domain.StatusTransition) error {
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO OrderStatusHistory (OrderID, TransitionID, FromStatus, ToStatus, Actor, Reason, OccurredAt)
+		      VALUES (@orderID, @transitionID, @fromStatus, @toStatus, @actor, @reason, @occurredAt)`,
+		Params: map[string]interface{}{
+			"orderID":      t.OrderID.String(),
+			"transitionID": t.ID,
+			"fromStatus":   nullString(t.From.String()),
+			"toStatus":     t.To.String(),
+			"actor":        nullString(t.Actor),
+			"reason":       nullString(t.Reason),
+			"occurredAt":   t.OccurredAt,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to append status history: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerStatusHistoryRepository) FindByOrderID(ctx context.Context, id domain.OrderID) ([]domain.StatusTransition, error) {
+	return platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]domain.StatusTransition, error) {
+		stmt := spanner.Statement{
+			SQL: `SELECT TransitionID, FromStatus, ToStatus, Actor, Reason, OccurredAt
+			      FROM OrderStatusHistory
+			      WHERE OrderID = @orderID
+			      ORDER BY OccurredAt`,
+			Params: map[string]interface{}{"orderID": id.String()},
+		}
+
+		iter := reader.Query(ctx, stmt)
+		defer iter.Stop()
+
+		var transitions []domain.StatusTransition
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query status history: %w", err)
+			}
+
+			var transitionID, toStatus string
+			var fromStatus, actor, reason spanner.NullString
+			var occurredAt time.Time
+
+			if err := row.Columns(&transitionID, &fromStatus, &toStatus, &actor, &reason, &occurredAt); err != nil {
+				return nil, fmt.Errorf("failed to scan status history: %w", err)
+			}
+
+			transitions = append(transitions, domain.StatusTransition{
+				ID:         transitionID,
+				OrderID:    id,
+				From:       domain.Status(fromStatus.StringVal),
+				To:         domain.Status(toStatus),
+				Actor:      actor.StringVal,
+				Reason:     reason.StringVal,
+				OccurredAt: occurredAt,
+			})
+		}
+
+		return transitions, nil
+	})
+}
@@ -0,0 +1,312 @@
+// The orders service: the RPC surface of the orders module, served by
+// connect-go over gRPC, gRPC-Web and Connect (JSON over HTTP) from the same
+// command and query handlers as the REST routes. It covers the lifecycle of
+// a user's order; shipping, discount codes, returns and the admin
+// operations are served by the REST routes only. Regenerate the Go code
+// with `make proto` after changing this file.
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: orders/v1/orders.proto
+
+package ordersv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	ordersv1 "github.com/rai/clean-modularmonolith-go/modules/orders/infrastructure/rpc/ordersv1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// OrdersServiceName is the fully-qualified name of the OrdersService service.
+	OrdersServiceName = "orders.v1.OrdersService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// OrdersServiceCreateOrderProcedure is the fully-qualified name of the OrdersService's CreateOrder
+	// RPC.
+	OrdersServiceCreateOrderProcedure = "/orders.v1.OrdersService/CreateOrder"
+	// OrdersServiceGetOrderProcedure is the fully-qualified name of the OrdersService's GetOrder RPC.
+	OrdersServiceGetOrderProcedure = "/orders.v1.OrdersService/GetOrder"
+	// OrdersServiceListUserOrdersProcedure is the fully-qualified name of the OrdersService's
+	// ListUserOrders RPC.
+	OrdersServiceListUserOrdersProcedure = "/orders.v1.OrdersService/ListUserOrders"
+	// OrdersServiceAddItemProcedure is the fully-qualified name of the OrdersService's AddItem RPC.
+	OrdersServiceAddItemProcedure = "/orders.v1.OrdersService/AddItem"
+	// OrdersServiceRemoveItemProcedure is the fully-qualified name of the OrdersService's RemoveItem
+	// RPC.
+	OrdersServiceRemoveItemProcedure = "/orders.v1.OrdersService/RemoveItem"
+	// OrdersServiceSubmitOrderProcedure is the fully-qualified name of the OrdersService's SubmitOrder
+	// RPC.
+	OrdersServiceSubmitOrderProcedure = "/orders.v1.OrdersService/SubmitOrder"
+	// OrdersServiceCancelOrderProcedure is the fully-qualified name of the OrdersService's CancelOrder
+	// RPC.
+	OrdersServiceCancelOrderProcedure = "/orders.v1.OrdersService/CancelOrder"
+)
+
+// OrdersServiceClient is a client for the orders.v1.OrdersService service.
+type OrdersServiceClient interface {
+	// CreateOrder creates a draft order for a user, as POST /orders does.
+	CreateOrder(context.Context, *connect.Request[ordersv1.CreateOrderRequest]) (*connect.Response[ordersv1.CreateOrderResponse], error)
+	// GetOrder returns an order, as GET /orders/{id} does.
+	GetOrder(context.Context, *connect.Request[ordersv1.GetOrderRequest]) (*connect.Response[ordersv1.GetOrderResponse], error)
+	// ListUserOrders returns a page of the orders of a user, newest first, as
+	// GET /users/{userId}/orders does.
+	ListUserOrders(context.Context, *connect.Request[ordersv1.ListUserOrdersRequest]) (*connect.Response[ordersv1.ListUserOrdersResponse], error)
+	// AddItem adds an item to a draft order, as POST /orders/{id}/items does.
+	AddItem(context.Context, *connect.Request[ordersv1.AddItemRequest]) (*connect.Response[ordersv1.AddItemResponse], error)
+	// RemoveItem removes an item from a draft order, as
+	// DELETE /orders/{id}/items/{productId} does.
+	RemoveItem(context.Context, *connect.Request[ordersv1.RemoveItemRequest]) (*connect.Response[ordersv1.RemoveItemResponse], error)
+	// SubmitOrder submits a draft order, as POST /orders/{id}/submit does.
+	SubmitOrder(context.Context, *connect.Request[ordersv1.SubmitOrderRequest]) (*connect.Response[ordersv1.SubmitOrderResponse], error)
+	// CancelOrder cancels an order on behalf of its user, as
+	// POST /orders/{id}/cancel does without the admin token.
+	CancelOrder(context.Context, *connect.Request[ordersv1.CancelOrderRequest]) (*connect.Response[ordersv1.CancelOrderResponse], error)
+}
+
+// NewOrdersServiceClient constructs a client for the orders.v1.OrdersService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewOrdersServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) OrdersServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	ordersServiceMethods := ordersv1.File_orders_v1_orders_proto.Services().ByName("OrdersService").Methods()
+	return &ordersServiceClient{
+		createOrder: connect.NewClient[ordersv1.CreateOrderRequest, ordersv1.CreateOrderResponse](
+			httpClient,
+			baseURL+OrdersServiceCreateOrderProcedure,
+			connect.WithSchema(ordersServiceMethods.ByName("CreateOrder")),
+			connect.WithClientOptions(opts...),
+		),
+		getOrder: connect.NewClient[ordersv1.GetOrderRequest, ordersv1.GetOrderResponse](
+			httpClient,
+			baseURL+OrdersServiceGetOrderProcedure,
+			connect.WithSchema(ordersServiceMethods.ByName("GetOrder")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		listUserOrders: connect.NewClient[ordersv1.ListUserOrdersRequest, ordersv1.ListUserOrdersResponse](
+			httpClient,
+			baseURL+OrdersServiceListUserOrdersProcedure,
+			connect.WithSchema(ordersServiceMethods.ByName("ListUserOrders")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		addItem: connect.NewClient[ordersv1.AddItemRequest, ordersv1.AddItemResponse](
+			httpClient,
+			baseURL+OrdersServiceAddItemProcedure,
+			connect.WithSchema(ordersServiceMethods.ByName("AddItem")),
+			connect.WithClientOptions(opts...),
+		),
+		removeItem: connect.NewClient[ordersv1.RemoveItemRequest, ordersv1.RemoveItemResponse](
+			httpClient,
+			baseURL+OrdersServiceRemoveItemProcedure,
+			connect.WithSchema(ordersServiceMethods.ByName("RemoveItem")),
+			connect.WithClientOptions(opts...),
+		),
+		submitOrder: connect.NewClient[ordersv1.SubmitOrderRequest, ordersv1.SubmitOrderResponse](
+			httpClient,
+			baseURL+OrdersServiceSubmitOrderProcedure,
+			connect.WithSchema(ordersServiceMethods.ByName("SubmitOrder")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelOrder: connect.NewClient[ordersv1.CancelOrderRequest, ordersv1.CancelOrderResponse](
+			httpClient,
+			baseURL+OrdersServiceCancelOrderProcedure,
+			connect.WithSchema(ordersServiceMethods.ByName("CancelOrder")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// ordersServiceClient implements OrdersServiceClient.
+type ordersServiceClient struct {
+	createOrder    *connect.Client[ordersv1.CreateOrderRequest, ordersv1.CreateOrderResponse]
+	getOrder       *connect.Client[ordersv1.GetOrderRequest, ordersv1.GetOrderResponse]
+	listUserOrders *connect.Client[ordersv1.ListUserOrdersRequest, ordersv1.ListUserOrdersResponse]
+	addItem        *connect.Client[ordersv1.AddItemRequest, ordersv1.AddItemResponse]
+	removeItem     *connect.Client[ordersv1.RemoveItemRequest, ordersv1.RemoveItemResponse]
+	submitOrder    *connect.Client[ordersv1.SubmitOrderRequest, ordersv1.SubmitOrderResponse]
+	cancelOrder    *connect.Client[ordersv1.CancelOrderRequest, ordersv1.CancelOrderResponse]
+}
+
+// CreateOrder calls orders.v1.OrdersService.CreateOrder.
+func (c *ordersServiceClient) CreateOrder(ctx context.Context, req *connect.Request[ordersv1.CreateOrderRequest]) (*connect.Response[ordersv1.CreateOrderResponse], error) {
+	return c.createOrder.CallUnary(ctx, req)
+}
+
+// GetOrder calls orders.v1.OrdersService.GetOrder.
+func (c *ordersServiceClient) GetOrder(ctx context.Context, req *connect.Request[ordersv1.GetOrderRequest]) (*connect.Response[ordersv1.GetOrderResponse], error) {
+	return c.getOrder.CallUnary(ctx, req)
+}
+
+// ListUserOrders calls orders.v1.OrdersService.ListUserOrders.
+func (c *ordersServiceClient) ListUserOrders(ctx context.Context, req *connect.Request[ordersv1.ListUserOrdersRequest]) (*connect.Response[ordersv1.ListUserOrdersResponse], error) {
+	return c.listUserOrders.CallUnary(ctx, req)
+}
+
+// AddItem calls orders.v1.OrdersService.AddItem.
+func (c *ordersServiceClient) AddItem(ctx context.Context, req *connect.Request[ordersv1.AddItemRequest]) (*connect.Response[ordersv1.AddItemResponse], error) {
+	return c.addItem.CallUnary(ctx, req)
+}
+
+// RemoveItem calls orders.v1.OrdersService.RemoveItem.
+func (c *ordersServiceClient) RemoveItem(ctx context.Context, req *connect.Request[ordersv1.RemoveItemRequest]) (*connect.Response[ordersv1.RemoveItemResponse], error) {
+	return c.removeItem.CallUnary(ctx, req)
+}
+
+// SubmitOrder calls orders.v1.OrdersService.SubmitOrder.
+func (c *ordersServiceClient) SubmitOrder(ctx context.Context, req *connect.Request[ordersv1.SubmitOrderRequest]) (*connect.Response[ordersv1.SubmitOrderResponse], error) {
+	return c.submitOrder.CallUnary(ctx, req)
+}
+
+// CancelOrder calls orders.v1.OrdersService.CancelOrder.
+func (c *ordersServiceClient) CancelOrder(ctx context.Context, req *connect.Request[ordersv1.CancelOrderRequest]) (*connect.Response[ordersv1.CancelOrderResponse], error) {
+	return c.cancelOrder.CallUnary(ctx, req)
+}
+
+// OrdersServiceHandler is an implementation of the orders.v1.OrdersService service.
+type OrdersServiceHandler interface {
+	// CreateOrder creates a draft order for a user, as POST /orders does.
+	CreateOrder(context.Context, *connect.Request[ordersv1.CreateOrderRequest]) (*connect.Response[ordersv1.CreateOrderResponse], error)
+	// GetOrder returns an order, as GET /orders/{id} does.
+	GetOrder(context.Context, *connect.Request[ordersv1.GetOrderRequest]) (*connect.Response[ordersv1.GetOrderResponse], error)
+	// ListUserOrders returns a page of the orders of a user, newest first, as
+	// GET /users/{userId}/orders does.
+	ListUserOrders(context.Context, *connect.Request[ordersv1.ListUserOrdersRequest]) (*connect.Response[ordersv1.ListUserOrdersResponse], error)
+	// AddItem adds an item to a draft order, as POST /orders/{id}/items does.
+	AddItem(context.Context, *connect.Request[ordersv1.AddItemRequest]) (*connect.Response[ordersv1.AddItemResponse], error)
+	// RemoveItem removes an item from a draft order, as
+	// DELETE /orders/{id}/items/{productId} does.
+	RemoveItem(context.Context, *connect.Request[ordersv1.RemoveItemRequest]) (*connect.Response[ordersv1.RemoveItemResponse], error)
+	// SubmitOrder submits a draft order, as POST /orders/{id}/submit does.
+	SubmitOrder(context.Context, *connect.Request[ordersv1.SubmitOrderRequest]) (*connect.Response[ordersv1.SubmitOrderResponse], error)
+	// CancelOrder cancels an order on behalf of its user, as
+	// POST /orders/{id}/cancel does without the admin token.
+	CancelOrder(context.Context, *connect.Request[ordersv1.CancelOrderRequest]) (*connect.Response[ordersv1.CancelOrderResponse], error)
+}
+
+// NewOrdersServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewOrdersServiceHandler(svc OrdersServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	ordersServiceMethods := ordersv1.File_orders_v1_orders_proto.Services().ByName("OrdersService").Methods()
+	ordersServiceCreateOrderHandler := connect.NewUnaryHandler(
+		OrdersServiceCreateOrderProcedure,
+		svc.CreateOrder,
+		connect.WithSchema(ordersServiceMethods.ByName("CreateOrder")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ordersServiceGetOrderHandler := connect.NewUnaryHandler(
+		OrdersServiceGetOrderProcedure,
+		svc.GetOrder,
+		connect.WithSchema(ordersServiceMethods.ByName("GetOrder")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	ordersServiceListUserOrdersHandler := connect.NewUnaryHandler(
+		OrdersServiceListUserOrdersProcedure,
+		svc.ListUserOrders,
+		connect.WithSchema(ordersServiceMethods.ByName("ListUserOrders")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	ordersServiceAddItemHandler := connect.NewUnaryHandler(
+		OrdersServiceAddItemProcedure,
+		svc.AddItem,
+		connect.WithSchema(ordersServiceMethods.ByName("AddItem")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ordersServiceRemoveItemHandler := connect.NewUnaryHandler(
+		OrdersServiceRemoveItemProcedure,
+		svc.RemoveItem,
+		connect.WithSchema(ordersServiceMethods.ByName("RemoveItem")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ordersServiceSubmitOrderHandler := connect.NewUnaryHandler(
+		OrdersServiceSubmitOrderProcedure,
+		svc.SubmitOrder,
+		connect.WithSchema(ordersServiceMethods.ByName("SubmitOrder")),
+		connect.WithHandlerOptions(opts...),
+	)
+	ordersServiceCancelOrderHandler := connect.NewUnaryHandler(
+		OrdersServiceCancelOrderProcedure,
+		svc.CancelOrder,
+		connect.WithSchema(ordersServiceMethods.ByName("CancelOrder")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/orders.v1.OrdersService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case OrdersServiceCreateOrderProcedure:
+			ordersServiceCreateOrderHandler.ServeHTTP(w, r)
+		case OrdersServiceGetOrderProcedure:
+			ordersServiceGetOrderHandler.ServeHTTP(w, r)
+		case OrdersServiceListUserOrdersProcedure:
+			ordersServiceListUserOrdersHandler.ServeHTTP(w, r)
+		case OrdersServiceAddItemProcedure:
+			ordersServiceAddItemHandler.ServeHTTP(w, r)
+		case OrdersServiceRemoveItemProcedure:
+			ordersServiceRemoveItemHandler.ServeHTTP(w, r)
+		case OrdersServiceSubmitOrderProcedure:
+			ordersServiceSubmitOrderHandler.ServeHTTP(w, r)
+		case OrdersServiceCancelOrderProcedure:
+			ordersServiceCancelOrderHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedOrdersServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedOrdersServiceHandler struct{}
+
+func (UnimplementedOrdersServiceHandler) CreateOrder(context.Context, *connect.Request[ordersv1.CreateOrderRequest]) (*connect.Response[ordersv1.CreateOrderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("orders.v1.OrdersService.CreateOrder is not implemented"))
+}
+
+func (UnimplementedOrdersServiceHandler) GetOrder(context.Context, *connect.Request[ordersv1.GetOrderRequest]) (*connect.Response[ordersv1.GetOrderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("orders.v1.OrdersService.GetOrder is not implemented"))
+}
+
+func (UnimplementedOrdersServiceHandler) ListUserOrders(context.Context, *connect.Request[ordersv1.ListUserOrdersRequest]) (*connect.Response[ordersv1.ListUserOrdersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("orders.v1.OrdersService.ListUserOrders is not implemented"))
+}
+
+func (UnimplementedOrdersServiceHandler) AddItem(context.Context, *connect.Request[ordersv1.AddItemRequest]) (*connect.Response[ordersv1.AddItemResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("orders.v1.OrdersService.AddItem is not implemented"))
+}
+
+func (UnimplementedOrdersServiceHandler) RemoveItem(context.Context, *connect.Request[ordersv1.RemoveItemRequest]) (*connect.Response[ordersv1.RemoveItemResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("orders.v1.OrdersService.RemoveItem is not implemented"))
+}
+
+func (UnimplementedOrdersServiceHandler) SubmitOrder(context.Context, *connect.Request[ordersv1.SubmitOrderRequest]) (*connect.Response[ordersv1.SubmitOrderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("orders.v1.OrdersService.SubmitOrder is not implemented"))
+}
+
+func (UnimplementedOrdersServiceHandler) CancelOrder(context.Context, *connect.Request[ordersv1.CancelOrderRequest]) (*connect.Response[ordersv1.CancelOrderResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("orders.v1.OrdersService.CancelOrder is not implemented"))
+}
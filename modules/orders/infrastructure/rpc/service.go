@@ -0,0 +1,219 @@
+// Package rpc serves the orders module over connect-go: gRPC, gRPC-Web and
+// Connect clients call the OrdersService of proto/orders/v1/orders.proto,
+// which runs the same commands and queries as the HTTP handlers. The
+// ordersv1 packages are generated by `make proto`; do not edit them.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/infrastructure/rpc/ordersv1"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/infrastructure/rpc/ordersv1/ordersv1connect"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/quota"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// errExpectedUpdateTimeRequired is the RPC counterpart of a missing
+// If-Match: removals must say which version they apply to.
+var errExpectedUpdateTimeRequired = errors.New("expected_update_time is required")
+
+// Service implements ordersv1connect.OrdersServiceHandler.
+type Service struct {
+	createOrder command.Handler[commands.CreateOrderCommand, string]
+	addItem     command.VoidHandler[commands.AddItemCommand]
+	removeItem  command.VoidHandler[commands.RemoveItemCommand]
+	submitOrder command.VoidHandler[commands.SubmitOrderCommand]
+	cancelOrder command.Handler[commands.CancelOrderCommand, *domain.Order]
+	getOrder    command.Handler[queries.GetOrderQuery, *queries.OrderDTO]
+	listOrders  *queries.ListUserOrdersHandler
+}
+
+var _ ordersv1connect.OrdersServiceHandler = (*Service)(nil)
+
+// RegisterRoutes mounts the OrdersService on mux, under
+// /orders.v1.OrdersService/ as the gRPC protocol requires.
+func RegisterRoutes(
+	mux *http.ServeMux,
+	createOrder command.Handler[commands.CreateOrderCommand, string],
+	addItem command.VoidHandler[commands.AddItemCommand],
+	removeItem command.VoidHandler[commands.RemoveItemCommand],
+	submitOrder command.VoidHandler[commands.SubmitOrderCommand],
+	cancelOrder command.Handler[commands.CancelOrderCommand, *domain.Order],
+	getOrder command.Handler[queries.GetOrderQuery, *queries.OrderDTO],
+	listOrders *queries.ListUserOrdersHandler,
+) {
+	s := &Service{
+		createOrder: createOrder,
+		addItem:     addItem,
+		removeItem:  removeItem,
+		submitOrder: submitOrder,
+		cancelOrder: cancelOrder,
+		getOrder:    getOrder,
+		listOrders:  listOrders,
+	}
+	mux.Handle(ordersv1connect.NewOrdersServiceHandler(s))
+}
+
+func (s *Service) CreateOrder(ctx context.Context, req *connect.Request[ordersv1.CreateOrderRequest]) (*connect.Response[ordersv1.CreateOrderResponse], error) {
+	id, err := s.createOrder.Handle(ctx, commands.CreateOrderCommand{
+		UserID:   req.Msg.GetUserId(),
+		Currency: req.Msg.GetCurrency(),
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(&ordersv1.CreateOrderResponse{Id: id}), nil
+}
+
+func (s *Service) GetOrder(ctx context.Context, req *connect.Request[ordersv1.GetOrderRequest]) (*connect.Response[ordersv1.GetOrderResponse], error) {
+	order, err := s.getOrder.Handle(ctx, queries.GetOrderQuery{OrderID: req.Msg.GetId()})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(&ordersv1.GetOrderResponse{Order: toProto(order)}), nil
+}
+
+func (s *Service) ListUserOrders(ctx context.Context, req *connect.Request[ordersv1.ListUserOrdersRequest]) (*connect.Response[ordersv1.ListUserOrdersResponse], error) {
+	result, err := s.listOrders.Handle(ctx, queries.ListUserOrdersQuery{
+		UserID: req.Msg.GetUserId(),
+		Status: req.Msg.GetStatus(),
+		Offset: int(req.Msg.GetOffset()),
+		Limit:  int(req.Msg.GetLimit()),
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	resp := &ordersv1.ListUserOrdersResponse{
+		Orders:     make([]*ordersv1.Order, 0, len(result.Orders)),
+		TotalCount: int32(result.TotalCount),
+		Offset:     int32(result.Offset),
+		Limit:      int32(result.Limit),
+	}
+	for _, order := range result.Orders {
+		resp.Orders = append(resp.Orders, toProto(order))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *Service) AddItem(ctx context.Context, req *connect.Request[ordersv1.AddItemRequest]) (*connect.Response[ordersv1.AddItemResponse], error) {
+	err := s.addItem.Handle(ctx, commands.AddItemCommand{
+		OrderID:     req.Msg.GetOrderId(),
+		ProductID:   req.Msg.GetProductId(),
+		ProductName: req.Msg.GetProductName(),
+		Quantity:    int(req.Msg.GetQuantity()),
+		UnitPrice:   req.Msg.GetUnitPrice().GetAmount(),
+		Currency:    req.Msg.GetUnitPrice().GetCurrency(),
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(&ordersv1.AddItemResponse{}), nil
+}
+
+func (s *Service) RemoveItem(ctx context.Context, req *connect.Request[ordersv1.RemoveItemRequest]) (*connect.Response[ordersv1.RemoveItemResponse], error) {
+	if req.Msg.GetExpectedUpdateTime() == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errExpectedUpdateTimeRequired)
+	}
+
+	err := s.removeItem.Handle(ctx, commands.RemoveItemCommand{
+		OrderID:           req.Msg.GetOrderId(),
+		ProductID:         req.Msg.GetProductId(),
+		ExpectedUpdatedAt: req.Msg.GetExpectedUpdateTime().AsTime(),
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(&ordersv1.RemoveItemResponse{}), nil
+}
+
+func (s *Service) SubmitOrder(ctx context.Context, req *connect.Request[ordersv1.SubmitOrderRequest]) (*connect.Response[ordersv1.SubmitOrderResponse], error) {
+	if err := s.submitOrder.Handle(ctx, commands.SubmitOrderCommand{OrderID: req.Msg.GetOrderId()}); err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(&ordersv1.SubmitOrderResponse{}), nil
+}
+
+func (s *Service) CancelOrder(ctx context.Context, req *connect.Request[ordersv1.CancelOrderRequest]) (*connect.Response[ordersv1.CancelOrderResponse], error) {
+	_, err := s.cancelOrder.Handle(ctx, commands.CancelOrderCommand{
+		OrderID:   req.Msg.GetOrderId(),
+		Reason:    req.Msg.GetReason(),
+		ActorKind: domain.ActorUser.String(),
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(&ordersv1.CancelOrderResponse{}), nil
+}
+
+func toProto(order *queries.OrderDTO) *ordersv1.Order {
+	items := make([]*ordersv1.OrderItem, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = &ordersv1.OrderItem{
+			ProductId:   item.ProductID,
+			ProductName: item.ProductName,
+			Quantity:    int32(item.Quantity),
+			UnitPrice:   toProtoMoney(item.UnitPrice),
+			Subtotal:    toProtoMoney(item.Subtotal),
+		}
+	}
+	return &ordersv1.Order{
+		Id:         order.ID,
+		UserId:     order.UserID,
+		Items:      items,
+		Status:     order.Status,
+		Currency:   order.Currency,
+		Subtotal:   toProtoMoney(order.Subtotal),
+		Total:      toProtoMoney(order.Total),
+		CreateTime: timestamppb.New(order.CreatedAt),
+		UpdateTime: timestamppb.New(order.UpdatedAt),
+	}
+}
+
+func toProtoMoney(m queries.MoneyDTO) *ordersv1.Money {
+	return &ordersv1.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+// toConnectError maps errors to connect codes the way the HTTP handler maps
+// them to status codes; unexpected errors are not leaked to the client.
+func toConnectError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrOrderNotFound),
+		errors.Is(err, domain.ErrItemNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, quota.ErrExceeded):
+		return connect.NewError(connect.CodeResourceExhausted, err)
+	case errors.Is(err, domain.ErrOrderModified):
+		return connect.NewError(connect.CodeAborted, err)
+	case errors.Is(err, domain.ErrOrderNotDraft),
+		errors.Is(err, domain.ErrOrderNotPending),
+		errors.Is(err, domain.ErrOrderNotConfirmed),
+		errors.Is(err, domain.ErrOrderAlreadyCancelled),
+		errors.Is(err, domain.ErrOrderCompleted),
+		errors.Is(err, domain.ErrOrderUnclaimed),
+		errors.Is(err, domain.ErrOrderEmpty),
+		errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, domain.ErrTooManyItems),
+		errors.Is(err, domain.ErrLineQuantityExceeded),
+		errors.Is(err, domain.ErrOrderTotalExceeded),
+		errors.Is(err, domain.ErrMoneyOverflow):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, domain.ErrInvalidOrderID),
+		errors.Is(err, domain.ErrInvalidUserRef),
+		errors.Is(err, domain.ErrInvalidQuantity),
+		errors.Is(err, domain.ErrCurrencyInvalid),
+		errors.Is(err, domain.ErrCurrencyMismatch),
+		errors.Is(err, domain.ErrStatusInvalid),
+		errors.Is(err, domain.ErrCancelReasonTooLong):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	default:
+		return connect.NewError(connect.CodeInternal, errors.New("internal server error"))
+	}
+}
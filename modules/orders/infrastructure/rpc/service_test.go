@@ -0,0 +1,237 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/infrastructure/rpc"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/infrastructure/rpc/ordersv1"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/infrastructure/rpc/ordersv1/ordersv1connect"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/quota"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	orderID = "0f8fad5b-d9cb-469f-a165-70867728950e"
+	userID  = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+)
+
+// handlers holds the command and query handlers behind the service; unset
+// ones fail the test.
+type handlers struct {
+	createOrder command.HandlerFunc[commands.CreateOrderCommand, string]
+	addItem     command.VoidHandlerFunc[commands.AddItemCommand]
+	removeItem  command.VoidHandlerFunc[commands.RemoveItemCommand]
+	submitOrder command.VoidHandlerFunc[commands.SubmitOrderCommand]
+	cancelOrder command.HandlerFunc[commands.CancelOrderCommand, *domain.Order]
+	getOrder    command.HandlerFunc[queries.GetOrderQuery, *queries.OrderDTO]
+}
+
+// newClients serves the service over HTTP/2 and returns a client for each
+// protocol it speaks.
+func newClients(t *testing.T, h handlers) map[string]ordersv1connect.OrdersServiceClient {
+	t.Helper()
+	unexpected := func(name string) error {
+		t.Errorf("unexpected %s command", name)
+		return errors.New("unexpected command")
+	}
+	if h.createOrder == nil {
+		h.createOrder = func(context.Context, commands.CreateOrderCommand) (string, error) {
+			return "", unexpected("CreateOrder")
+		}
+	}
+	if h.addItem == nil {
+		h.addItem = func(context.Context, commands.AddItemCommand) error { return unexpected("AddItem") }
+	}
+	if h.removeItem == nil {
+		h.removeItem = func(context.Context, commands.RemoveItemCommand) error { return unexpected("RemoveItem") }
+	}
+	if h.submitOrder == nil {
+		h.submitOrder = func(context.Context, commands.SubmitOrderCommand) error { return unexpected("SubmitOrder") }
+	}
+	if h.cancelOrder == nil {
+		h.cancelOrder = func(context.Context, commands.CancelOrderCommand) (*domain.Order, error) {
+			return nil, unexpected("CancelOrder")
+		}
+	}
+	if h.getOrder == nil {
+		h.getOrder = func(context.Context, queries.GetOrderQuery) (*queries.OrderDTO, error) {
+			return nil, unexpected("GetOrder")
+		}
+	}
+
+	mux := http.NewServeMux()
+	rpc.RegisterRoutes(mux, h.createOrder, h.addItem, h.removeItem, h.submitOrder, h.cancelOrder, h.getOrder, queries.NewListUserOrdersHandler(nil, nil))
+	srv := httptest.NewUnstartedServer(mux)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	return map[string]ordersv1connect.OrdersServiceClient{
+		"connect": ordersv1connect.NewOrdersServiceClient(srv.Client(), srv.URL),
+		"grpc":    ordersv1connect.NewOrdersServiceClient(srv.Client(), srv.URL, connect.WithGRPC()),
+	}
+}
+
+func TestCreateOrder(t *testing.T) {
+	for protocol, client := range newClients(t, handlers{
+		createOrder: func(ctx context.Context, cmd commands.CreateOrderCommand) (string, error) {
+			if cmd.UserID != userID || cmd.Currency != "EUR" {
+				t.Errorf("command = %+v", cmd)
+			}
+			return orderID, nil
+		},
+	}) {
+		t.Run(protocol, func(t *testing.T) {
+			resp, err := client.CreateOrder(t.Context(), connect.NewRequest(&ordersv1.CreateOrderRequest{
+				UserId: userID, Currency: "EUR",
+			}))
+			if err != nil {
+				t.Fatalf("CreateOrder: %v", err)
+			}
+			if resp.Msg.GetId() != orderID {
+				t.Errorf("id = %q, want %q", resp.Msg.GetId(), orderID)
+			}
+		})
+	}
+}
+
+func TestGetOrder(t *testing.T) {
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	order := &queries.OrderDTO{
+		ID:     orderID,
+		UserID: userID,
+		Items: []queries.OrderItemDTO{{
+			ProductID:   "prod-1",
+			ProductName: "Widget",
+			Quantity:    2,
+			UnitPrice:   queries.MoneyDTO{Amount: 1500, Currency: "EUR"},
+			Subtotal:    queries.MoneyDTO{Amount: 3000, Currency: "EUR"},
+		}},
+		Status:    "draft",
+		Currency:  "EUR",
+		Subtotal:  queries.MoneyDTO{Amount: 3000, Currency: "EUR"},
+		Total:     queries.MoneyDTO{Amount: 3000, Currency: "EUR"},
+		CreatedAt: created,
+		UpdatedAt: created.Add(time.Hour),
+	}
+	for protocol, client := range newClients(t, handlers{
+		getOrder: func(ctx context.Context, query queries.GetOrderQuery) (*queries.OrderDTO, error) {
+			if query.OrderID != orderID {
+				return nil, domain.ErrOrderNotFound
+			}
+			return order, nil
+		},
+	}) {
+		t.Run(protocol, func(t *testing.T) {
+			resp, err := client.GetOrder(t.Context(), connect.NewRequest(&ordersv1.GetOrderRequest{Id: orderID}))
+			if err != nil {
+				t.Fatalf("GetOrder: %v", err)
+			}
+			got := resp.Msg.GetOrder()
+			if got.GetUserId() != userID || got.GetStatus() != "draft" || got.GetTotal().GetAmount() != 3000 || !got.GetUpdateTime().AsTime().Equal(order.UpdatedAt) {
+				t.Errorf("order = %v", got)
+			}
+			if items := got.GetItems(); len(items) != 1 || items[0].GetQuantity() != 2 || items[0].GetUnitPrice().GetAmount() != 1500 {
+				t.Errorf("items = %v", items)
+			}
+
+			_, err = client.GetOrder(t.Context(), connect.NewRequest(&ordersv1.GetOrderRequest{Id: userID}))
+			if connect.CodeOf(err) != connect.CodeNotFound {
+				t.Errorf("GetOrder of an unknown order = %v, want not_found", err)
+			}
+		})
+	}
+}
+
+func TestRemoveItem_ExpectedUpdateTime(t *testing.T) {
+	updated := time.Date(2025, 1, 1, 13, 0, 0, 0, time.UTC)
+	for protocol, client := range newClients(t, handlers{
+		removeItem: func(ctx context.Context, cmd commands.RemoveItemCommand) error {
+			if !cmd.ExpectedUpdatedAt.Equal(updated) {
+				return domain.ErrOrderModified
+			}
+			return nil
+		},
+	}) {
+		t.Run(protocol, func(t *testing.T) {
+			remove := func(expected *timestamppb.Timestamp) error {
+				_, err := client.RemoveItem(t.Context(), connect.NewRequest(&ordersv1.RemoveItemRequest{
+					OrderId: orderID, ProductId: "prod-1", ExpectedUpdateTime: expected,
+				}))
+				return err
+			}
+
+			if err := remove(timestamppb.New(updated)); err != nil {
+				t.Errorf("RemoveItem: %v", err)
+			}
+			if err := remove(timestamppb.New(updated.Add(-time.Minute))); connect.CodeOf(err) != connect.CodeAborted {
+				t.Errorf("stale RemoveItem = %v, want aborted", err)
+			}
+			if err := remove(nil); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+				t.Errorf("RemoveItem without expected_update_time = %v, want failed_precondition", err)
+			}
+		})
+	}
+}
+
+// TestCancelOrder checks that an RPC cancellation is the customer's own.
+func TestCancelOrder(t *testing.T) {
+	for protocol, client := range newClients(t, handlers{
+		cancelOrder: func(ctx context.Context, cmd commands.CancelOrderCommand) (*domain.Order, error) {
+			if cmd.OrderID != orderID || cmd.Reason != "changed my mind" || cmd.ActorKind != string(domain.ActorUser) || cmd.ActorID != "" {
+				t.Errorf("command = %+v", cmd)
+			}
+			return nil, nil
+		},
+	}) {
+		t.Run(protocol, func(t *testing.T) {
+			_, err := client.CancelOrder(t.Context(), connect.NewRequest(&ordersv1.CancelOrderRequest{
+				OrderId: orderID, Reason: "changed my mind",
+			}))
+			if err != nil {
+				t.Errorf("CancelOrder: %v", err)
+			}
+		})
+	}
+}
+
+func TestErrorMapping(t *testing.T) {
+	tests := []struct {
+		err  error
+		code connect.Code
+	}{
+		{domain.ErrOrderNotFound, connect.CodeNotFound},
+		{quota.ErrExceeded, connect.CodeResourceExhausted},
+		{domain.ErrOrderNotDraft, connect.CodeFailedPrecondition},
+		{domain.ErrOrderEmpty, connect.CodeFailedPrecondition},
+		{domain.ErrInvalidOrderID, connect.CodeInvalidArgument},
+		{errors.New("spanner: session expired"), connect.CodeInternal},
+	}
+	for _, tt := range tests {
+		clients := newClients(t, handlers{
+			submitOrder: func(context.Context, commands.SubmitOrderCommand) error { return tt.err },
+		})
+		for protocol, client := range clients {
+			t.Run(protocol+"/"+tt.err.Error(), func(t *testing.T) {
+				_, err := client.SubmitOrder(t.Context(), connect.NewRequest(&ordersv1.SubmitOrderRequest{OrderId: orderID}))
+				if connect.CodeOf(err) != tt.code {
+					t.Errorf("SubmitOrder = %v, want %s", err, tt.code)
+				}
+				var connectErr *connect.Error
+				if tt.code == connect.CodeInternal && errors.As(err, &connectErr) && connectErr.Message() != "internal server error" {
+					t.Errorf("message = %q, want the internal error hidden", connectErr.Message())
+				}
+			})
+		}
+	}
+}
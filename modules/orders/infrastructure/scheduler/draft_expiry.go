@@ -0,0 +1,83 @@
+// Package scheduler runs periodic background jobs for the orders module.
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/orders/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+)
+
+// DraftExpiryJob periodically cancels draft orders untouched for longer than ttl.
+// It is safe to run on every instance: see ExpireStaleDraftsHandler.Handle.
+type DraftExpiryJob struct {
+	handler   *commands.ExpireStaleDraftsHandler
+	clock     clock.Clock
+	ttl       time.Duration
+	interval  time.Duration
+	batchSize int
+	logger    *slog.Logger
+}
+
+func NewDraftExpiryJob(handler *commands.ExpireStaleDraftsHandler, clock clock.Clock, ttl, interval time.Duration, batchSize int, logger *slog.Logger) *DraftExpiryJob {
+	return &DraftExpiryJob{
+		handler:   handler,
+		clock:     clock,
+		ttl:       ttl,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    logger,
+	}
+}
+
+// Start runs the job in a background goroutine.
+// The first run is delayed by a random fraction of the interval so that
+// instances started together do not scan at the same moment.
+// The returned stop function cancels the job and waits for it to exit.
+func (j *DraftExpiryJob) Start() (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		timer := time.NewTimer(rand.N(j.interval))
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+
+			j.runOnce(ctx)
+			timer.Reset(j.interval)
+		}
+	})
+
+	return func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func (j *DraftExpiryJob) runOnce(ctx context.Context) {
+	cmd := commands.ExpireStaleDraftsCommand{
+		Cutoff:    j.clock.Now().Add(-j.ttl),
+		BatchSize: j.batchSize,
+	}
+
+	expired, err := j.handler.Handle(ctx, cmd)
+	if err != nil {
+		if ctx.Err() == nil {
+			j.logger.Error("draft expiry run failed", slog.Any("error", err))
+		}
+		return
+	}
+	if expired > 0 {
+		j.logger.Info("expired stale draft orders", slog.Int("count", expired), slog.Time("cutoff", cmd.Cutoff))
+	}
+}
@@ -3,47 +3,217 @@
 package orders
 
 import (
+	"cmp"
+	"context"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/rai/clean-modularmonolith-go/modules/orders/application/commands"
 	"github.com/rai/clean-modularmonolith-go/modules/orders/application/eventhandlers"
 	"github.com/rai/clean-modularmonolith-go/modules/orders/application/queries"
 	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
 	httphandler "github.com/rai/clean-modularmonolith-go/modules/orders/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/infrastructure/rpc"
+	"github.com/rai/clean-modularmonolith-go/modules/orders/infrastructure/scheduler"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/cache"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/contracts"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/features"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/ids"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/inbox"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/lifecycle"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/projection"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/quota"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
 )
 
 // Module is the public API for the orders bounded context.
-// External communication: HTTP API (RegisterRoutes)
-// Cross-module communication: Domain Events (subscribed internally)
+// External communication: HTTP API and the orders.v1.OrdersService RPC
+// service (RegisterRoutes)
+// Cross-module communication: Domain Events (subscribed internally) and
+// contract queries (methods below) for synchronous checks.
 type Module interface {
-	// RegisterRoutes registers the module's HTTP routes to the given mux.
+	// RegisterRoutes registers the module's HTTP routes and its RPC service
+	// to the given mux.
 	RegisterRoutes(mux *http.ServeMux)
+	// Operations documents the routes for the OpenAPI specification.
+	Operations() []openapi.Operation
+
+	// Start launches the draft expiry job, if configured; Shutdown stops it.
+	lifecycle.Hooks
+
+	// AmountDue returns the total of an order awaiting payment.
+	// ok is false if the order does not exist or is not pending.
+	AmountDue(ctx context.Context, orderID string) (amount int64, currency string, ok bool, err error)
+
+	// UserOrders returns a page of a user's orders, newest first, and the
+	// user's total number of orders. limit is capped at 100; zero means 20.
+	UserOrders(ctx context.Context, userID string, offset, limit int) (orders []OrderSummary, total int, err error)
+
+	// CreateOrder and AddItem run the same commands as the HTTP API, for
+	// fixtures (cmd/admin seed). CreateOrder generates an ID when orderID is
+	// empty, and uses the default currency when currency is.
+	CreateOrder(ctx context.Context, orderID, userID, currency string) (string, error)
+	AddItem(ctx context.Context, orderID string, item Item) error
+
+	// CancelOrder cancels an order on behalf of the admin adminID, for
+	// operators (cmd/admin).
+	CancelOrder(ctx context.Context, orderID, reason, adminID string) error
+
+	// RebuildProjection rebuilds the "orders.OrderSummary" read model, for
+	// operators (cmd/admin).
+	projection.Rebuilder
+}
+
+// Item is an order line added through Module.AddItem.
+type Item struct {
+	ProductID   string
+	ProductName string
+	Quantity    int
+	UnitPrice   int64  // in minor units
+	Currency    string // the order's currency; empty means the default currency
+}
+
+// OrderSummary is an order, as returned to other modules. Amounts are in
+// minor units of the order's currency.
+type OrderSummary struct {
+	ID        string
+	UserID    string
+	Status    string
+	ItemCount int
+	Total     int64
+	Currency  string
+	CreatedAt time.Time
 }
 
 // Config holds the module configuration.
 type Config struct {
-	Repository          domain.OrderRepository
-	TransactionScope    transaction.Scope
-	Publisher           events.Publisher
-	PostCommitPublisher events.PostCommitPublisher
-	Subscriber          events.Subscriber
-	Logger              *slog.Logger
+	Repository               domain.OrderRepository
+	DiscountRepository       domain.DiscountCodeRepository
+	HistoryRepository        domain.StatusHistoryRepository
+	SummaryRepository        domain.OrderSummaryRepository
+	Checkpoints              projection.CheckpointStore
+	Users                    contracts.UserReader
+	TransactionScope         transaction.Scope
+	ReadOnlyTransactionScope transaction.Scope
+	Publisher                events.Publisher
+	PostCommitPublisher      events.PostCommitPublisher
+	Subscriber               events.Subscriber
+	// PostCommitSubscriber receives the replies that settle submitted orders:
+	// payments.PaymentCaptured confirms them, inventory.StockRejected cancels them.
+	PostCommitSubscriber events.PostCommitSubscriber
+	Logger               *slog.Logger
+
+	// Reader, when set, serves the order queries of the HTTP API and the
+	// module's public API instead of Repository, e.g. from a read replica
+	// or a projection. Commands, event handlers, the summary projection and
+	// AmountDue, which payments charge, always read Repository.
+	Reader domain.OrderReader
+
+	// Inbox, when set, records the events of other modules before their
+	// handlers run, so that a redelivered event (e.g. from an external
+	// broker) is processed once. Optional: nil processes every delivery.
+	Inbox inbox.Store
+
+	// TaxCalculator computes taxes when an order is submitted.
+	// Defaults to a zero-rate FlatRateTaxCalculator when nil.
+	TaxCalculator domain.TaxCalculator
+
+	// Promotions finds the automatic promotions applied when an order is
+	// submitted. No promotions are applied when nil.
+	Promotions domain.PromotionEngine
+
+	// Limits caps the size of orders. Zero fields mean unlimited.
+	Limits domain.OrderLimits
+
+	// Quotas caps how many orders are created. Zero fields mean unlimited.
+	// The orders per day are counted by QuotaService and only enforced with
+	// one.
+	Quotas       domain.OrderQuotas
+	QuotaService *quota.Service
+
+	DraftExpiry DraftExpiryConfig
+
+	// Tokens signs the tokens that claim guest orders, valid for ClaimTTL
+	// (default 7 days). Guest orders answer 501 when nil.
+	Tokens   *tokens.Signer
+	ClaimTTL time.Duration
+
+	// AdminToken guards admin-only endpoints (e.g. issuing refunds) via the
+	// X-Admin-Token header. Admin endpoints are disabled when empty.
+	AdminToken string
+
+	// SecuritySink receives the admin token and authorization decisions
+	// made by the HTTP handlers. Optional: nil records nothing.
+	SecuritySink security.Sink
+
+	// Features evaluates the module's feature flags (e.g. "orders.returns").
+	// Optional: every flag has its default when nil.
+	Features features.Flags
+
+	// CommandBus, when set, applies the platform's middleware (tracing,
+	// recording for the audit log and metrics, validation) to every command.
+	CommandBus *command.Bus
+
+	// Cache, when set, caches the OrderDTOs of GET /orders/{id} for
+	// CacheTTL (default one minute), evicting them on the events that change
+	// them.
+	Cache    cache.Store
+	CacheTTL time.Duration
+
+	// Clock is the time the orders and the draft expiry job read.
+	// Defaults to clock.System when nil.
+	Clock clock.Clock
+
+	// IDGenerator generates the order and event IDs. Defaults to ids.UUIDv7
+	// when nil.
+	IDGenerator ids.Generator
+}
+
+// DraftExpiryConfig configures the background job that cancels stale draft orders.
+// The job is disabled when TTL is zero.
+type DraftExpiryConfig struct {
+	TTL       time.Duration // drafts untouched for longer than this are expired
+	Interval  time.Duration // how often the job runs; defaults to 5 minutes
+	BatchSize int           // maximum orders expired per run; defaults to 100
 }
 
 type module struct {
-	createOrderHandler *commands.CreateOrderHandler
-	addItemHandler     *commands.AddItemHandler
-	removeItemHandler  *commands.RemoveItemHandler
-	submitOrderHandler *commands.SubmitOrderHandler
-	cancelOrderHandler *commands.CancelOrderHandler
-	getOrderHandler    *queries.GetOrderHandler
+	createOrderHandler command.Handler[commands.CreateOrderCommand, string]
+	createGuestHandler command.Handler[commands.CreateGuestOrderCommand, commands.CreateGuestOrderResult]
+	claimOrderHandler  command.VoidHandler[commands.ClaimOrderCommand]
+	addItemHandler     command.VoidHandler[commands.AddItemCommand]
+	removeItemHandler  command.VoidHandler[commands.RemoveItemCommand]
+	updateItemHandler  command.VoidHandler[commands.UpdateItemQuantityCommand]
+	setShippingHandler command.VoidHandler[commands.SetShippingCommand]
+	applyDiscHandler   command.VoidHandler[commands.ApplyDiscountCommand]
+	removeDiscHandler  command.VoidHandler[commands.RemoveDiscountCommand]
+	createDiscHandler  command.Handler[commands.CreateDiscountCodeCommand, string]
+	submitOrderHandler command.VoidHandler[commands.SubmitOrderCommand]
+	cancelOrderHandler command.Handler[commands.CancelOrderCommand, *domain.Order]
+	bulkOrdersHandler  command.Handler[commands.BulkTransitionCommand, []commands.BulkOrderResult]
+	reqReturnHandler   command.VoidHandler[commands.RequestReturnCommand]
+	refundHandler      command.VoidHandler[commands.IssueRefundCommand]
+	getOrderHandler    command.Handler[queries.GetOrderQuery, *queries.OrderDTO]
+	batchGetHandler    *queries.BatchGetOrdersHandler
+	getHistoryHandler  *queries.GetOrderHistoryHandler
 	listUserOrders     *queries.ListUserOrdersHandler
+	searchOrders       *queries.SearchOrdersHandler
+	reportOrders       *queries.ReportOrderSummariesHandler
+	rebuildHandler     command.Handler[projection.RebuildCommand, projection.RebuildResult]
+	amountDue          *queries.AmountDueHandler
+	admin              security.AdminGuard
+	draftExpiry        *scheduler.DraftExpiryJob // nil when disabled
+	stopDraftExpiry    func()
 }
 
-// New creates a new orders module.
+// New creates a new orders module. Background jobs run from Start to Shutdown.
 func New(cfg Config) Module {
 	logger := cfg.Logger
 	if logger == nil {
@@ -53,35 +223,230 @@ func New(cfg Config) Module {
 
 	// Wrap the transaction scope with ScopeWithDomainEvent that automatically
 	// collects domain events from context and publishes them after success.
+	// The clock and ID generator wrappers make the configured ones those the
+	// orders read.
+	clk := cfg.Clock
+	if clk == nil {
+		clk = clock.System
+	}
 	txScope := events.NewScopeWithDomainEvent(cfg.TransactionScope, cfg.Publisher, cfg.PostCommitPublisher)
+	txScope = ids.Scope(clock.Scope(txScope, clk), cfg.IDGenerator)
 
-	createOrderHandler := commands.NewCreateOrderHandler(cfg.Repository, txScope)
-	addItemHandler := commands.NewAddItemHandler(cfg.Repository)
-	removeItemHandler := commands.NewRemoveItemHandler(cfg.Repository)
-	submitOrderHandler := commands.NewSubmitOrderHandler(cfg.Repository, txScope)
-	cancelOrderHandler := commands.NewCancelOrderHandler(cfg.Repository, txScope)
+	taxes := cfg.TaxCalculator
+	if taxes == nil {
+		taxes = domain.FlatRateTaxCalculator{}
+	}
 
-	getOrderHandler := queries.NewGetOrderHandler(cfg.Repository)
-	listUserOrdersHandler := queries.NewListUserOrdersHandler(cfg.Repository)
+	createOrderHandler := commands.NewCreateOrderHandler(cfg.Repository, userDirectory(cfg.Users), txScope, cfg.QuotaService, cfg.Quotas)
+	createGuestHandler := commands.NewCreateGuestOrderHandler(cfg.Repository, txScope, cfg.QuotaService, cfg.Quotas, cfg.Tokens, cmp.Or(cfg.ClaimTTL, 7*24*time.Hour))
+	claimOrderHandler := commands.NewClaimOrderHandler(cfg.Repository, userDirectory(cfg.Users), txScope, cfg.Tokens)
+	addItemHandler := commands.NewAddItemHandler(cfg.Repository, txScope, cfg.Limits)
+	removeItemHandler := commands.NewRemoveItemHandler(cfg.Repository, txScope)
+	updateItemHandler := commands.NewUpdateItemQuantityHandler(cfg.Repository, txScope, cfg.Limits)
+	setShippingHandler := commands.NewSetShippingHandler(cfg.Repository, txScope)
+	applyDiscHandler := commands.NewApplyDiscountHandler(cfg.Repository, cfg.DiscountRepository, txScope)
+	removeDiscHandler := commands.NewRemoveDiscountHandler(cfg.Repository, cfg.DiscountRepository, txScope)
+	createDiscHandler := commands.NewCreateDiscountCodeHandler(cfg.DiscountRepository, txScope)
+	submitOrderHandler := commands.NewSubmitOrderHandler(cfg.Repository, txScope, cfg.Promotions, taxes, cfg.Limits)
+	cancelOrderHandler := commands.NewCancelOrderHandler(cfg.Repository, cfg.DiscountRepository, txScope)
+	bulkOrdersHandler := commands.NewBulkTransitionHandler(cfg.Repository, cfg.DiscountRepository, txScope)
+	reqReturnHandler := commands.NewRequestReturnHandler(cfg.Repository, txScope, cfg.Features)
+	refundHandler := commands.NewIssueRefundHandler(cfg.Repository, txScope)
+
+	var reader domain.OrderReader = cfg.Repository
+	if cfg.Reader != nil {
+		reader = cfg.Reader
+	}
+	var getOrderHandler command.Handler[queries.GetOrderQuery, *queries.OrderDTO] = queries.NewGetOrderHandler(reader, cfg.ReadOnlyTransactionScope)
+	batchGetHandler := queries.NewBatchGetOrdersHandler(reader, cfg.ReadOnlyTransactionScope)
+	getHistoryHandler := queries.NewGetOrderHistoryHandler(reader, cfg.HistoryRepository, cfg.ReadOnlyTransactionScope)
+	listUserOrdersHandler := queries.NewListUserOrdersHandler(reader, cfg.ReadOnlyTransactionScope)
+	searchOrdersHandler := queries.NewSearchOrdersHandler(reader, cfg.ReadOnlyTransactionScope)
+	reportOrdersHandler := queries.NewReportOrderSummariesHandler(cfg.SummaryRepository)
+
+	summaries := eventhandlers.NewOrderSummaryProjection(cfg.Repository, cfg.SummaryRepository, cfg.TransactionScope)
+	rebuildHandler := projection.NewRebuildHandler(cfg.Checkpoints, cfg.TransactionScope, summaries)
 
 	if cfg.Subscriber != nil {
-		userDeletedHandler := eventhandlers.NewUserDeletedHandler(cfg.Repository, cfg.TransactionScope, logger)
+		userDeletedHandler := inbox.Wrap(cfg.Inbox, cfg.TransactionScope, eventhandlers.NewUserDeletedHandler(cfg.Repository, txScope, logger))
 		if err := cfg.Subscriber.Subscribe(userDeletedHandler.EventType(), userDeletedHandler); err != nil {
 			logger.Error("failed to subscribe to user deleted event", slog.Any("error", err))
 		}
+
+		userRestoredHandler := inbox.Wrap(cfg.Inbox, cfg.TransactionScope, eventhandlers.NewUserRestoredHandler(cfg.Repository, txScope, logger))
+		if err := cfg.Subscriber.Subscribe(userRestoredHandler.EventType(), userRestoredHandler); err != nil {
+			logger.Error("failed to subscribe to user restored event", slog.Any("error", err))
+		}
+
+		statusChangedHandler := eventhandlers.NewOrderStatusChangedHandler(cfg.HistoryRepository, cfg.TransactionScope)
+		if err := cfg.Subscriber.Subscribe(statusChangedHandler.EventType(), statusChangedHandler); err != nil {
+			logger.Error("failed to subscribe to order status changed event", slog.Any("error", err))
+		}
+
+		if err := projection.Subscribe(cfg.Subscriber, summaries); err != nil {
+			logger.Error("failed to subscribe order summary projection", slog.Any("error", err))
+		}
+	}
+
+	if cfg.PostCommitSubscriber != nil {
+		sagaHandlers := []events.Handler{
+			inbox.Wrap(cfg.Inbox, cfg.TransactionScope, eventhandlers.NewPaymentCapturedHandler(cfg.Repository, txScope, logger)),
+			inbox.Wrap(cfg.Inbox, cfg.TransactionScope, eventhandlers.NewStockRejectedHandler(cfg.Repository, txScope, logger)),
+		}
+		for _, h := range sagaHandlers {
+			if err := cfg.PostCommitSubscriber.SubscribePostCommit(h.EventType(), h); err != nil {
+				logger.Error("failed to subscribe to event", slog.String("event_type", h.EventType().String()), slog.Any("error", err))
+			}
+		}
+	}
+
+	// Cache order read models, evicting them pre-commit and again post-commit
+	// (see package cache).
+	if cfg.Cache != nil {
+		for _, eventType := range eventhandlers.OrderCacheEventTypes {
+			invalidator := eventhandlers.NewOrderCacheInvalidator(eventType, cfg.Cache)
+			if cfg.Subscriber != nil {
+				if err := cfg.Subscriber.Subscribe(eventType, invalidator); err != nil {
+					logger.Error("failed to subscribe order cache invalidator", slog.String("event_type", eventType.String()), slog.Any("error", err))
+				}
+			}
+			if cfg.PostCommitSubscriber != nil {
+				if err := cfg.PostCommitSubscriber.SubscribePostCommit(eventType, invalidator); err != nil {
+					logger.Error("failed to subscribe order cache invalidator", slog.String("event_type", eventType.String()), slog.Any("error", err))
+				}
+			}
+		}
+		getOrderHandler = cache.Cached(cfg.Cache, cmp.Or(cfg.CacheTTL, time.Minute), queries.GetOrderCacheKey, getOrderHandler)
+	}
+
+	var draftExpiry *scheduler.DraftExpiryJob
+	if cfg.DraftExpiry.TTL > 0 {
+		interval := cfg.DraftExpiry.Interval
+		if interval <= 0 {
+			interval = 5 * time.Minute
+		}
+		batchSize := cfg.DraftExpiry.BatchSize
+		if batchSize <= 0 {
+			batchSize = 100
+		}
+		expireHandler := commands.NewExpireStaleDraftsHandler(cfg.Repository, cfg.DiscountRepository, txScope, logger)
+		draftExpiry = scheduler.NewDraftExpiryJob(expireHandler, clk, cfg.DraftExpiry.TTL, interval, batchSize, logger)
 	}
 
 	return &module{
-		createOrderHandler: createOrderHandler,
-		addItemHandler:     addItemHandler,
-		removeItemHandler:  removeItemHandler,
-		submitOrderHandler: submitOrderHandler,
-		cancelOrderHandler: cancelOrderHandler,
+		createOrderHandler: command.Register[commands.CreateOrderCommand, string](cfg.CommandBus, "orders", createOrderHandler),
+		createGuestHandler: command.Register[commands.CreateGuestOrderCommand, commands.CreateGuestOrderResult](cfg.CommandBus, "orders", createGuestHandler),
+		claimOrderHandler:  command.RegisterVoid[commands.ClaimOrderCommand](cfg.CommandBus, "orders", claimOrderHandler),
+		addItemHandler:     command.RegisterVoid[commands.AddItemCommand](cfg.CommandBus, "orders", addItemHandler),
+		removeItemHandler:  command.RegisterVoid[commands.RemoveItemCommand](cfg.CommandBus, "orders", removeItemHandler),
+		updateItemHandler:  command.RegisterVoid[commands.UpdateItemQuantityCommand](cfg.CommandBus, "orders", updateItemHandler),
+		setShippingHandler: command.RegisterVoid[commands.SetShippingCommand](cfg.CommandBus, "orders", setShippingHandler),
+		applyDiscHandler:   command.RegisterVoid[commands.ApplyDiscountCommand](cfg.CommandBus, "orders", applyDiscHandler),
+		removeDiscHandler:  command.RegisterVoid[commands.RemoveDiscountCommand](cfg.CommandBus, "orders", removeDiscHandler),
+		createDiscHandler:  command.Register[commands.CreateDiscountCodeCommand, string](cfg.CommandBus, "orders", createDiscHandler),
+		submitOrderHandler: command.RegisterVoid[commands.SubmitOrderCommand](cfg.CommandBus, "orders", submitOrderHandler),
+		cancelOrderHandler: command.Register[commands.CancelOrderCommand, *domain.Order](cfg.CommandBus, "orders", cancelOrderHandler),
+		bulkOrdersHandler:  command.Register[commands.BulkTransitionCommand, []commands.BulkOrderResult](cfg.CommandBus, "orders", bulkOrdersHandler),
+		reqReturnHandler:   command.RegisterVoid[commands.RequestReturnCommand](cfg.CommandBus, "orders", reqReturnHandler),
+		refundHandler:      command.RegisterVoid[commands.IssueRefundCommand](cfg.CommandBus, "orders", refundHandler),
+		rebuildHandler:     command.Register[projection.RebuildCommand, projection.RebuildResult](cfg.CommandBus, "orders", rebuildHandler),
 		getOrderHandler:    getOrderHandler,
+		batchGetHandler:    batchGetHandler,
+		getHistoryHandler:  getHistoryHandler,
 		listUserOrders:     listUserOrdersHandler,
+		searchOrders:       searchOrdersHandler,
+		reportOrders:       reportOrdersHandler,
+		amountDue:          queries.NewAmountDueHandler(cfg.Repository),
+		admin:              security.AdminGuard{Module: "orders", Token: cfg.AdminToken, Sink: cfg.SecuritySink},
+		draftExpiry:        draftExpiry,
 	}
 }
 
+// userDirectory adapts the users module's contract to the orders' own port:
+// a deleted user counts as unknown.
+func userDirectory(users contracts.UserReader) domain.UserDirectory {
+	if users == nil {
+		return nil
+	}
+	return domain.UserDirectoryFunc(func(ctx context.Context, ref domain.UserRef) (bool, error) {
+		user, ok, err := users.GetUserSummary(ctx, ref.String())
+		return ok && !user.Deleted(), err
+	})
+}
+
+func (m *module) Start(ctx context.Context) error {
+	if m.draftExpiry != nil {
+		m.stopDraftExpiry = m.draftExpiry.Start()
+	}
+	return nil
+}
+
+func (m *module) Shutdown(ctx context.Context) error {
+	return lifecycle.Stop(ctx, m.stopDraftExpiry)
+}
+
 func (m *module) RegisterRoutes(mux *http.ServeMux) {
-	httphandler.RegisterRoutes(mux, m.createOrderHandler, m.addItemHandler, m.removeItemHandler, m.submitOrderHandler, m.cancelOrderHandler, m.getOrderHandler, m.listUserOrders)
+	httphandler.RegisterRoutes(mux, m.createOrderHandler, m.createGuestHandler, m.claimOrderHandler, m.addItemHandler, m.removeItemHandler, m.updateItemHandler, m.setShippingHandler, m.applyDiscHandler, m.removeDiscHandler, m.createDiscHandler, m.submitOrderHandler, m.cancelOrderHandler, m.bulkOrdersHandler, m.reqReturnHandler, m.refundHandler, m.getOrderHandler, m.batchGetHandler, m.getHistoryHandler, m.listUserOrders, m.searchOrders, m.reportOrders, m.admin)
+	rpc.RegisterRoutes(mux, m.createOrderHandler, m.addItemHandler, m.removeItemHandler, m.submitOrderHandler, m.cancelOrderHandler, m.getOrderHandler, m.listUserOrders)
+}
+
+func (m *module) Operations() []openapi.Operation {
+	return httphandler.Operations()
+}
+
+func (m *module) AmountDue(ctx context.Context, orderID string) (amount int64, currency string, ok bool, err error) {
+	due, err := m.amountDue.Handle(ctx, queries.AmountDueQuery{OrderID: orderID})
+	return due.Amount, due.Currency, due.Payable, err
+}
+
+func (m *module) UserOrders(ctx context.Context, userID string, offset, limit int) ([]OrderSummary, int, error) {
+	list, err := m.listUserOrders.Handle(ctx, queries.ListUserOrdersQuery{UserID: userID, Offset: offset, Limit: limit})
+	if err != nil {
+		return nil, 0, err
+	}
+	orders := make([]OrderSummary, len(list.Orders))
+	for i, o := range list.Orders {
+		orders[i] = OrderSummary{
+			ID:        o.ID,
+			UserID:    o.UserID,
+			Status:    o.Status,
+			ItemCount: len(o.Items),
+			Total:     o.Total.Amount,
+			Currency:  o.Currency,
+			CreatedAt: o.CreatedAt,
+		}
+	}
+	return orders, list.TotalCount, nil
+}
+
+func (m *module) CreateOrder(ctx context.Context, orderID, userID, currency string) (string, error) {
+	return m.createOrderHandler.Handle(ctx, commands.CreateOrderCommand{OrderID: orderID, UserID: userID, Currency: currency})
+}
+
+func (m *module) AddItem(ctx context.Context, orderID string, item Item) error {
+	if item.Currency == "" {
+		item.Currency = domain.DefaultCurrency
+	}
+	return m.addItemHandler.Handle(ctx, commands.AddItemCommand{
+		OrderID:     orderID,
+		ProductID:   item.ProductID,
+		ProductName: item.ProductName,
+		Quantity:    item.Quantity,
+		UnitPrice:   item.UnitPrice,
+		Currency:    item.Currency,
+	})
+}
+
+func (m *module) CancelOrder(ctx context.Context, orderID, reason, adminID string) error {
+	_, err := m.cancelOrderHandler.Handle(ctx, commands.CancelOrderCommand{
+		OrderID:   orderID,
+		Reason:    reason,
+		ActorKind: domain.ActorAdmin.String(),
+		ActorID:   adminID,
+	})
+	return err
+}
+
+func (m *module) RebuildProjection(ctx context.Context, cmd projection.RebuildCommand) (projection.RebuildResult, error) {
+	return m.rebuildHandler.Handle(ctx, cmd)
 }
@@ -0,0 +1,135 @@
+// Package commands contains write use cases for the payments module.
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/rai/clean-modularmonolith-go/modules/payments/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// CreatePaymentCommand represents the intent to pay for an order.
+type CreatePaymentCommand struct {
+	OrderID       string
+	PaymentMethod string
+}
+
+// CreatePaymentHandler authorizes and captures the amount due on an order.
+// Provider calls are made between transactions so that no database
+// transaction is held open across a network round trip; every state change
+// is persisted before the next call, leaving a record of how far it got.
+type CreatePaymentHandler struct {
+	repo     domain.PaymentRepository
+	orders   domain.OrderDirectory
+	provider domain.PaymentProvider
+	txScope  transaction.ScopeWithDomainEvent
+	logger   *slog.Logger
+}
+
+func NewCreatePaymentHandler(repo domain.PaymentRepository, orders domain.OrderDirectory, provider domain.PaymentProvider, txScope transaction.ScopeWithDomainEvent, logger *slog.Logger) *CreatePaymentHandler {
+	return &CreatePaymentHandler{
+		repo:     repo,
+		orders:   orders,
+		provider: provider,
+		txScope:  txScope,
+		logger:   logger,
+	}
+}
+
+// Handle executes the create payment use case and returns the payment ID.
+// A declined payment is recorded as failed and reported as ErrPaymentDeclined;
+// the ID is returned alongside so the caller can inspect it.
+func (h *CreatePaymentHandler) Handle(ctx context.Context, cmd CreatePaymentCommand) (string, error) {
+	amount, currency, ok, err := h.orders.AmountDue(ctx, cmd.OrderID)
+	if err != nil {
+		return "", fmt.Errorf("looking up order: %w", err)
+	}
+	if !ok {
+		return "", domain.ErrOrderNotPayable
+	}
+
+	intent, err := domain.NewPaymentIntent(cmd.OrderID, amount, currency, cmd.PaymentMethod)
+	if err != nil {
+		return "", err
+	}
+
+	err = h.txScope.ExecuteWithPublish(ctx, func(ctx context.Context) error {
+		existing, err := h.repo.FindByOrderID(ctx, cmd.OrderID)
+		if err != nil {
+			return fmt.Errorf("finding order payments: %w", err)
+		}
+		if slices.ContainsFunc(existing, func(p *domain.PaymentIntent) bool { return p.Status() != domain.PaymentFailed }) {
+			return domain.ErrPaymentInProgress
+		}
+		return h.repo.Save(ctx, intent)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	providerRef, err := h.provider.Authorize(ctx, domain.AuthorizeRequest{
+		IdempotencyKey: intent.ID().String(),
+		Amount:         intent.Amount(),
+		Currency:       intent.Currency(),
+		PaymentMethod:  intent.PaymentMethod(),
+	})
+	if err != nil {
+		return intent.ID().String(), h.fail(ctx, intent, err)
+	}
+	if err := h.update(ctx, intent.ID(), func(ctx context.Context, p *domain.PaymentIntent) error { return p.MarkAuthorized(providerRef) }); err != nil {
+		return intent.ID().String(), err
+	}
+
+	if err := h.provider.Capture(ctx, providerRef); err != nil {
+		return intent.ID().String(), h.fail(ctx, intent, err)
+	}
+	if err := h.update(ctx, intent.ID(), func(ctx context.Context, p *domain.PaymentIntent) error { return p.MarkCaptured(ctx) }); err != nil {
+		return intent.ID().String(), err
+	}
+
+	h.logger.Info("payment captured",
+		slog.String("payment_id", intent.ID().String()),
+		slog.String("order_id", intent.OrderID()),
+	)
+	return intent.ID().String(), nil
+}
+
+// fail records a provider error on the intent and returns the error to report:
+// ErrPaymentDeclined for declines, ErrProviderUnavailable otherwise.
+func (h *CreatePaymentHandler) fail(ctx context.Context, intent *domain.PaymentIntent, cause error) error {
+	reported := domain.ErrPaymentDeclined
+	if !errors.Is(cause, domain.ErrPaymentDeclined) {
+		reported = domain.ErrProviderUnavailable
+		h.logger.Error("payment provider call failed",
+			slog.String("payment_id", intent.ID().String()),
+			slog.Any("error", cause),
+		)
+	}
+
+	if err := h.update(ctx, intent.ID(), func(_ context.Context, p *domain.PaymentIntent) error { return p.MarkFailed(cause.Error()) }); err != nil {
+		return err
+	}
+	return reported
+}
+
+// update reloads the intent, applies mutate and saves it in its own transaction.
+// Reloading keeps mutate safe to re-run when the transaction is retried.
+func (h *CreatePaymentHandler) update(ctx context.Context, id domain.PaymentID, mutate func(ctx context.Context, p *domain.PaymentIntent) error) error {
+	return h.txScope.ExecuteWithPublish(ctx, func(ctx context.Context) error {
+		p, err := h.repo.FindByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("finding payment: %w", err)
+		}
+		if err := mutate(ctx, p); err != nil {
+			return err
+		}
+		if err := h.repo.Save(ctx, p); err != nil {
+			return fmt.Errorf("saving payment: %w", err)
+		}
+		return nil
+	})
+}
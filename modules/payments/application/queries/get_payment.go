@@ -0,0 +1,64 @@
+// Package queries contains read-side handlers for the payments module.
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/payments/domain"
+)
+
+// PaymentDTO is a read model for a payment intent.
+type PaymentDTO struct {
+	ID            string    `json:"id"`
+	OrderID       string    `json:"order_id"`
+	Amount        int64     `json:"amount"`
+	Currency      string    `json:"currency"`
+	Status        string    `json:"status"`
+	ProviderRef   string    `json:"provider_ref,omitempty"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// GetPaymentQuery retrieves a payment intent by ID.
+type GetPaymentQuery struct {
+	PaymentID string
+}
+
+type GetPaymentHandler struct {
+	repo domain.PaymentRepository
+}
+
+func NewGetPaymentHandler(repo domain.PaymentRepository) *GetPaymentHandler {
+	return &GetPaymentHandler{repo: repo}
+}
+
+func (h *GetPaymentHandler) Handle(ctx context.Context, query GetPaymentQuery) (*PaymentDTO, error) {
+	id, err := domain.ParsePaymentID(query.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := toPaymentDTO(p)
+	return &dto, nil
+}
+
+func toPaymentDTO(p *domain.PaymentIntent) PaymentDTO {
+	return PaymentDTO{
+		ID:            p.ID().String(),
+		OrderID:       p.OrderID(),
+		Amount:        p.Amount(),
+		Currency:      p.Currency(),
+		Status:        p.Status().String(),
+		ProviderRef:   p.ProviderRef(),
+		FailureReason: p.FailureReason(),
+		CreatedAt:     p.CreatedAt(),
+		UpdatedAt:     p.UpdatedAt(),
+	}
+}
@@ -0,0 +1,33 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/rai/clean-modularmonolith-go/modules/payments/domain"
+)
+
+// ListOrderPaymentsQuery retrieves every payment attempt for an order.
+type ListOrderPaymentsQuery struct {
+	OrderID string
+}
+
+type ListOrderPaymentsHandler struct {
+	repo domain.PaymentRepository
+}
+
+func NewListOrderPaymentsHandler(repo domain.PaymentRepository) *ListOrderPaymentsHandler {
+	return &ListOrderPaymentsHandler{repo: repo}
+}
+
+func (h *ListOrderPaymentsHandler) Handle(ctx context.Context, query ListOrderPaymentsQuery) ([]PaymentDTO, error) {
+	payments, err := h.repo.FindByOrderID(ctx, query.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]PaymentDTO, len(payments))
+	for i, p := range payments {
+		dtos[i] = toPaymentDTO(p)
+	}
+	return dtos, nil
+}
@@ -0,0 +1,21 @@
+// Package domain contains the payment intent model.
+package domain
+
+import "errors"
+
+// Domain errors - business rule violations.
+var (
+	ErrPaymentNotFound       = errors.New("payment not found")
+	ErrInvalidPaymentID      = errors.New("invalid payment ID format")
+	ErrOrderIDRequired       = errors.New("order ID is required")
+	ErrPaymentMethodRequired = errors.New("payment method is required")
+	ErrInvalidAmount         = errors.New("payment amount must be positive")
+	ErrOrderNotPayable       = errors.New("order does not exist or is not awaiting payment")
+	ErrPaymentInProgress     = errors.New("order already has a payment in progress or captured")
+	ErrInvalidTransition     = errors.New("invalid payment status transition")
+
+	// ErrPaymentDeclined is returned by providers when the payment method was
+	// refused. Other provider errors are treated as unavailability.
+	ErrPaymentDeclined     = errors.New("payment declined")
+	ErrProviderUnavailable = errors.New("payment provider unavailable")
+)
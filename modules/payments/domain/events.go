@@ -0,0 +1,21 @@
+package domain
+
+import (
+	paymentevents "github.com/rai/clean-modularmonolith-go/modules/payments/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// Public event types, re-exported for use within the module.
+const (
+	PaymentCapturedEventType = paymentevents.PaymentCapturedEventType
+)
+
+func NewPaymentCapturedEvent(p *PaymentIntent) paymentevents.PaymentCapturedEvent {
+	return paymentevents.PaymentCapturedEvent{
+		BaseEvent: events.NewBaseEvent(PaymentCapturedEventType),
+		PaymentID: p.ID().String(),
+		OrderID:   p.OrderID(),
+		Amount:    p.Amount(),
+		Currency:  p.Currency(),
+	}
+}
@@ -0,0 +1,16 @@
+package events
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const PaymentCapturedEventType events.EventType = "payments.PaymentCaptured"
+
+// PaymentCapturedEvent is published when the funds for an order have been
+// captured. The orders module confirms the order in response.
+// This is a public domain event — it may be imported by event handlers in other modules.
+type PaymentCapturedEvent struct {
+	events.BaseEvent
+	PaymentID string `json:"payment_id"`
+	OrderID   string `json:"order_id"`
+	Amount    int64  `json:"amount"`
+	Currency  string `json:"currency"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "context"
+
+// OrderDirectory is the payments module's port for questions about orders,
+// which are owned by the orders module. The adapter is wired in at
+// composition time so the payments module never imports orders internals.
+type OrderDirectory interface {
+	// AmountDue returns the total of an order awaiting payment.
+	// ok is false if the order does not exist or is not awaiting payment.
+	AmountDue(ctx context.Context, orderID string) (amount int64, currency string, ok bool, err error)
+}
+
+// OrderDirectoryFunc adapts an ordinary function to OrderDirectory.
+type OrderDirectoryFunc func(ctx context.Context, orderID string) (int64, string, bool, error)
+
+func (f OrderDirectoryFunc) AmountDue(ctx context.Context, orderID string) (int64, string, bool, error) {
+	return f(ctx, orderID)
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// PaymentID identifies a payment intent.
+type PaymentID struct {
+	value string
+}
+
+func NewPaymentID() PaymentID {
+	return PaymentID{value: uuid.New().String()}
+}
+
+func ParsePaymentID(s string) (PaymentID, error) {
+	if _, err := uuid.Parse(s); err != nil {
+		return PaymentID{}, ErrInvalidPaymentID
+	}
+	return PaymentID{value: s}, nil
+}
+
+func (id PaymentID) String() string { return id.value }
+func (id PaymentID) IsZero() bool   { return id.value == "" }
+
+// PaymentStatus is the lifecycle state of a payment intent.
+type PaymentStatus string
+
+const (
+	PaymentPending    PaymentStatus = "pending"    // created, not yet sent to the provider
+	PaymentAuthorized PaymentStatus = "authorized" // funds held by the provider
+	PaymentCaptured   PaymentStatus = "captured"   // funds collected; terminal
+	PaymentFailed     PaymentStatus = "failed"     // declined or abandoned; terminal
+)
+
+func (s PaymentStatus) String() string { return string(s) }
+
+// IsTerminal reports whether the intent can no longer change.
+func (s PaymentStatus) IsTerminal() bool {
+	return s == PaymentCaptured || s == PaymentFailed
+}
+
+// PaymentIntent tracks one attempt to pay for an order, from creation through
+// authorization to capture. An order may have several failed intents but at
+// most one that is not failed.
+type PaymentIntent struct {
+	id            PaymentID
+	orderID       string
+	amount        int64
+	currency      string
+	paymentMethod string
+	status        PaymentStatus
+	providerRef   string
+	failureReason string
+	createdAt     time.Time
+	updatedAt     time.Time
+}
+
+// NewPaymentIntent creates a pending intent to charge amount to paymentMethod for orderID.
+func NewPaymentIntent(orderID string, amount int64, currency, paymentMethod string) (*PaymentIntent, error) {
+	if orderID == "" {
+		return nil, ErrOrderIDRequired
+	}
+	if paymentMethod == "" {
+		return nil, ErrPaymentMethodRequired
+	}
+	if amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	now := time.Now().UTC()
+	return &PaymentIntent{
+		id:            NewPaymentID(),
+		orderID:       orderID,
+		amount:        amount,
+		currency:      currency,
+		paymentMethod: paymentMethod,
+		status:        PaymentPending,
+		createdAt:     now,
+		updatedAt:     now,
+	}, nil
+}
+
+// ReconstitutePaymentIntent rebuilds a PaymentIntent from persistence without validation.
+func ReconstitutePaymentIntent(
+	id PaymentID,
+	orderID string,
+	amount int64,
+	currency string,
+	paymentMethod string,
+	status PaymentStatus,
+	providerRef string,
+	failureReason string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *PaymentIntent {
+	return &PaymentIntent{
+		id:            id,
+		orderID:       orderID,
+		amount:        amount,
+		currency:      currency,
+		paymentMethod: paymentMethod,
+		status:        status,
+		providerRef:   providerRef,
+		failureReason: failureReason,
+		createdAt:     createdAt,
+		updatedAt:     updatedAt,
+	}
+}
+
+func (p *PaymentIntent) ID() PaymentID         { return p.id }
+func (p *PaymentIntent) OrderID() string       { return p.orderID }
+func (p *PaymentIntent) Amount() int64         { return p.amount }
+func (p *PaymentIntent) Currency() string      { return p.currency }
+func (p *PaymentIntent) PaymentMethod() string { return p.paymentMethod }
+func (p *PaymentIntent) Status() PaymentStatus { return p.status }
+func (p *PaymentIntent) ProviderRef() string   { return p.providerRef }
+func (p *PaymentIntent) FailureReason() string { return p.failureReason }
+func (p *PaymentIntent) CreatedAt() time.Time  { return p.createdAt }
+func (p *PaymentIntent) UpdatedAt() time.Time  { return p.updatedAt }
+
+// MarkAuthorized records that the provider is holding the funds under providerRef.
+func (p *PaymentIntent) MarkAuthorized(providerRef string) error {
+	if p.status != PaymentPending {
+		return ErrInvalidTransition
+	}
+	p.status = PaymentAuthorized
+	p.providerRef = providerRef
+	p.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// MarkCaptured records that the authorized funds have been collected.
+// Adds PaymentCapturedEvent to the context for later dispatch.
+func (p *PaymentIntent) MarkCaptured(ctx context.Context) error {
+	if p.status != PaymentAuthorized {
+		return ErrInvalidTransition
+	}
+	p.status = PaymentCaptured
+	p.updatedAt = time.Now().UTC()
+	events.Add(ctx, NewPaymentCapturedEvent(p))
+	return nil
+}
+
+// MarkFailed records that the intent will not complete, e.g. because the
+// provider declined it. A new intent may then be created for the order.
+func (p *PaymentIntent) MarkFailed(reason string) error {
+	if p.status.IsTerminal() {
+		return ErrInvalidTransition
+	}
+	p.status = PaymentFailed
+	p.failureReason = reason
+	p.updatedAt = time.Now().UTC()
+	return nil
+}
@@ -0,0 +1,95 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/payments/domain"
+	paymentevents "github.com/rai/clean-modularmonolith-go/modules/payments/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+func newIntent(t *testing.T) *domain.PaymentIntent {
+	t.Helper()
+	p, err := domain.NewPaymentIntent("order-1", 1500, "USD", "pm_card_visa")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return p
+}
+
+func TestNewPaymentIntent_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		orderID string
+		amount  int64
+		method  string
+		wantErr error
+	}{
+		{"missing order", "", 100, "pm_card_visa", domain.ErrOrderIDRequired},
+		{"missing method", "order-1", 100, "", domain.ErrPaymentMethodRequired},
+		{"zero amount", "order-1", 0, "pm_card_visa", domain.ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.NewPaymentIntent(tt.orderID, tt.amount, "USD", tt.method)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPaymentIntent_CaptureEmitsPaymentCaptured(t *testing.T) {
+	p := newIntent(t)
+	if p.Status() != domain.PaymentPending {
+		t.Fatalf("expected pending, got %q", p.Status())
+	}
+	if err := p.MarkAuthorized("pi_123"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	captured, err := events.CaptureEvents(context.Background(), p.MarkCaptured)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if p.Status() != domain.PaymentCaptured {
+		t.Errorf("expected captured, got %q", p.Status())
+	}
+	if len(captured) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(captured))
+	}
+	e, ok := captured[0].(paymentevents.PaymentCapturedEvent)
+	if !ok {
+		t.Fatalf("expected PaymentCapturedEvent, got %T", captured[0])
+	}
+	if e.OrderID != "order-1" || e.PaymentID != p.ID().String() || e.Amount != 1500 || e.Currency != "USD" {
+		t.Errorf("unexpected PaymentCapturedEvent: %+v", e)
+	}
+}
+
+func TestPaymentIntent_CaptureRequiresAuthorization(t *testing.T) {
+	p := newIntent(t)
+	_, err := events.CaptureEvents(context.Background(), p.MarkCaptured)
+	if !errors.Is(err, domain.ErrInvalidTransition) {
+		t.Errorf("expected ErrInvalidTransition, got %v", err)
+	}
+}
+
+func TestPaymentIntent_MarkFailed(t *testing.T) {
+	p := newIntent(t)
+	if err := p.MarkFailed("card declined"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Status() != domain.PaymentFailed || p.FailureReason() != "card declined" {
+		t.Errorf("unexpected state: %q %q", p.Status(), p.FailureReason())
+	}
+	if err := p.MarkAuthorized("pi_123"); !errors.Is(err, domain.ErrInvalidTransition) {
+		t.Errorf("expected ErrInvalidTransition after failure, got %v", err)
+	}
+	if err := p.MarkFailed("again"); !errors.Is(err, domain.ErrInvalidTransition) {
+		t.Errorf("expected ErrInvalidTransition when failing twice, got %v", err)
+	}
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "context"
+
+// AuthorizeRequest asks a provider to hold funds for a payment intent.
+type AuthorizeRequest struct {
+	// IdempotencyKey lets the provider recognize a retried request;
+	// the payment intent ID is used.
+	IdempotencyKey string
+	Amount         int64
+	Currency       string
+	PaymentMethod  string
+}
+
+// PaymentProvider is the port to the external payment processor.
+// Implementations return an error wrapping ErrPaymentDeclined when the
+// payment method is refused.
+type PaymentProvider interface {
+	// Authorize holds the funds and returns the provider's reference for them.
+	Authorize(ctx context.Context, req AuthorizeRequest) (providerRef string, err error)
+	// Capture collects funds previously authorized under providerRef.
+	Capture(ctx context.Context, providerRef string) error
+}
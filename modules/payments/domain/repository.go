@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+// PaymentRepository persists payment intents, scoped to the tenant of the
+// request of its context.
+type PaymentRepository interface {
+	Save(ctx context.Context, p *PaymentIntent) error
+	// FindByID returns ErrPaymentNotFound if no intent exists with the ID.
+	FindByID(ctx context.Context, id PaymentID) (*PaymentIntent, error)
+	// FindByOrderID returns all intents for an order, newest first.
+	FindByOrderID(ctx context.Context, orderID string) ([]*PaymentIntent, error)
+}
@@ -0,0 +1,126 @@
+// Package http provides HTTP handlers for the payments module.
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/payments/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/payments/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/payments/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+)
+
+type Handler struct {
+	createPayment command.Handler[commands.CreatePaymentCommand, string]
+	getPayment    *queries.GetPaymentHandler
+	listPayments  *queries.ListOrderPaymentsHandler
+}
+
+// RegisterRoutes registers the payments module routes to the given mux.
+func RegisterRoutes(mux *http.ServeMux, createPayment command.Handler[commands.CreatePaymentCommand, string], getPayment *queries.GetPaymentHandler, listPayments *queries.ListOrderPaymentsHandler) {
+	h := &Handler{
+		createPayment: createPayment,
+		getPayment:    getPayment,
+		listPayments:  listPayments,
+	}
+
+	mux.HandleFunc("POST /orders/{id}/payments", h.handleCreatePayment)
+	mux.HandleFunc("GET /orders/{id}/payments", h.handleListOrderPayments)
+	mux.HandleFunc("GET /payments/{id}", h.handleGetPayment)
+}
+
+type createPaymentRequest struct {
+	PaymentMethod string `json:"payment_method"`
+}
+
+type declinedResponse struct {
+	Error   string              `json:"error"`
+	Payment *queries.PaymentDTO `json:"payment,omitempty"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) handleCreatePayment(w http.ResponseWriter, r *http.Request) {
+	var req createPaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	paymentID, err := h.createPayment.Handle(r.Context(), commands.CreatePaymentCommand{
+		OrderID:       r.PathValue("id"),
+		PaymentMethod: req.PaymentMethod,
+	})
+	if errors.Is(err, domain.ErrPaymentDeclined) {
+		// The declined attempt is recorded; return it so the client can show why.
+		payment, _ := h.getPayment.Handle(r.Context(), queries.GetPaymentQuery{PaymentID: paymentID})
+		writeJSON(w, http.StatusPaymentRequired, declinedResponse{Error: err.Error(), Payment: payment})
+		return
+	}
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	payment, err := h.getPayment.Handle(r.Context(), queries.GetPaymentQuery{PaymentID: paymentID})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, payment)
+}
+
+func (h *Handler) handleListOrderPayments(w http.ResponseWriter, r *http.Request) {
+	payments, err := h.listPayments.Handle(r.Context(), queries.ListOrderPaymentsQuery{OrderID: r.PathValue("id")})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"payments": payments})
+}
+
+func (h *Handler) handleGetPayment(w http.ResponseWriter, r *http.Request) {
+	payment, err := h.getPayment.Handle(r.Context(), queries.GetPaymentQuery{PaymentID: r.PathValue("id")})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, payment)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrPaymentNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrOrderNotPayable),
+		errors.Is(err, domain.ErrPaymentInProgress),
+		errors.Is(err, domain.ErrInvalidTransition):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrInvalidPaymentID),
+		errors.Is(err, domain.ErrOrderIDRequired),
+		errors.Is(err, domain.ErrPaymentMethodRequired),
+		errors.Is(err, domain.ErrInvalidAmount):
+		writeError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, domain.ErrProviderUnavailable):
+		writeError(w, http.StatusServiceUnavailable, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
@@ -0,0 +1,19 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/payments/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+)
+
+// Operations documents the routes registered by RegisterRoutes.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Pattern: "POST /orders/{id}/payments", Summary: "Pay for a pending order", Description: "A declined payment is recorded and returned with status 402.", Request: createPaymentRequest{}, Status: http.StatusCreated, Response: queries.PaymentDTO{}},
+		{Pattern: "GET /orders/{id}/payments", Summary: "List the payments of an order", Response: struct {
+			Payments []queries.PaymentDTO `json:"payments"`
+		}{}},
+		{Pattern: "GET /payments/{id}", Summary: "Get a payment", Response: queries.PaymentDTO{}},
+	}
+}
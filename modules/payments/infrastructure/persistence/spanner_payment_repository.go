@@ -0,0 +1,130 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/payments/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+const paymentColumns = `PaymentID, OrderID, Amount, Currency, PaymentMethod, Status, ProviderRef, FailureReason, CreatedAt, UpdatedAt`
+
+// SpannerPaymentRepository implements PaymentRepository using Cloud Spanner.
+// Payment intents carry the tenant of the request that created them, and
+// every read is scoped to the tenant of its context.
+type SpannerPaymentRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerPaymentRepository(client *spanner.Client, logger *slog.Logger) *SpannerPaymentRepository {
+	return &SpannerPaymentRepository{client: client, logger: logger}
+}
+
+func (r *SpannerPaymentRepository) Save(ctx context.Context, p *domain.PaymentIntent) error {
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO PaymentIntents (TenantID, ` + paymentColumns + `)
+		      VALUES (@tenantID, @paymentID, @orderID, @amount, @currency, @paymentMethod, @status, @providerRef, @failureReason, @createdAt, @updatedAt)`,
+		Params: map[string]interface{}{
+			"tenantID":      requestcontext.TenantID(ctx),
+			"paymentID":     p.ID().String(),
+			"orderID":       p.OrderID(),
+			"amount":        p.Amount(),
+			"currency":      p.Currency(),
+			"paymentMethod": p.PaymentMethod(),
+			"status":        p.Status().String(),
+			"providerRef":   spanner.NullString{StringVal: p.ProviderRef(), Valid: p.ProviderRef() != ""},
+			"failureReason": spanner.NullString{StringVal: p.FailureReason(), Valid: p.FailureReason() != ""},
+			"createdAt":     p.CreatedAt(),
+			"updatedAt":     p.UpdatedAt(),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save payment intent: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerPaymentRepository) FindByID(ctx context.Context, id domain.PaymentID) (*domain.PaymentIntent, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (*domain.PaymentIntent, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT ` + paymentColumns + ` FROM PaymentIntents WHERE PaymentID = @paymentID AND TenantID = @tenantID`,
+			Params: map[string]interface{}{"paymentID": id.String(), "tenantID": requestcontext.TenantID(ctx)},
+		})
+		defer iter.Stop()
+
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return nil, domain.ErrPaymentNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query payment intent: %w", err)
+		}
+		return scanPayment(row)
+	})
+}
+
+func (r *SpannerPaymentRepository) FindByOrderID(ctx context.Context, orderID string) ([]*domain.PaymentIntent, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.PaymentIntent, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT ` + paymentColumns + `
+			      FROM PaymentIntents@{FORCE_INDEX=PaymentIntentsByTenantOrderIDCreatedAt}
+			      WHERE TenantID = @tenantID AND OrderID = @orderID
+			      ORDER BY CreatedAt DESC`,
+			Params: map[string]interface{}{"tenantID": requestcontext.TenantID(ctx), "orderID": orderID},
+		})
+		defer iter.Stop()
+
+		var payments []*domain.PaymentIntent
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query payment intents: %w", err)
+			}
+			p, err := scanPayment(row)
+			if err != nil {
+				return nil, err
+			}
+			payments = append(payments, p)
+		}
+		return payments, nil
+	})
+}
+
+func scanPayment(row *spanner.Row) (*domain.PaymentIntent, error) {
+	var paymentID, orderID, currency, paymentMethod, status string
+	var amount int64
+	var providerRef, failureReason spanner.NullString
+	var createdAt, updatedAt time.Time
+
+	if err := row.Columns(&paymentID, &orderID, &amount, &currency, &paymentMethod, &status, &providerRef, &failureReason, &createdAt, &updatedAt); err != nil {
+		return nil, fmt.Errorf("failed to scan payment intent: %w", err)
+	}
+
+	id, err := domain.ParsePaymentID(paymentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment ID in database: %w", err)
+	}
+
+	return domain.ReconstitutePaymentIntent(
+		id,
+		orderID,
+		amount,
+		currency,
+		paymentMethod,
+		domain.PaymentStatus(status),
+		providerRef.StringVal,
+		failureReason.StringVal,
+		createdAt,
+		updatedAt,
+	), nil
+}
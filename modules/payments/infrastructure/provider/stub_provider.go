@@ -0,0 +1,73 @@
+// Package provider contains payment provider adapters.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/payments/domain"
+)
+
+// Test payment methods understood by StubProvider, modelled on the test
+// tokens of card processors such as Stripe.
+const (
+	MethodDeclined     = "pm_card_declined"       // authorization is declined
+	MethodCaptureFails = "pm_card_capture_failed" // authorization succeeds, capture is declined
+	methodPrefix       = "pm_"
+	providerRefPrefix  = "pi_"
+)
+
+// StubProvider is an in-memory PaymentProvider for development and tests.
+// Any payment method starting with "pm_" is accepted except the test methods above.
+// Authorizations are idempotent per idempotency key, as with a real processor.
+type StubProvider struct {
+	mu      sync.Mutex
+	byKey   map[string]string // idempotency key → provider ref
+	methods map[string]string // provider ref → payment method
+}
+
+var _ domain.PaymentProvider = (*StubProvider)(nil)
+
+func NewStubProvider() *StubProvider {
+	return &StubProvider{
+		byKey:   make(map[string]string),
+		methods: make(map[string]string),
+	}
+}
+
+func (p *StubProvider) Authorize(ctx context.Context, req domain.AuthorizeRequest) (string, error) {
+	if !strings.HasPrefix(req.PaymentMethod, methodPrefix) {
+		return "", fmt.Errorf("%w: unknown payment method", domain.ErrPaymentDeclined)
+	}
+	if req.PaymentMethod == MethodDeclined {
+		return "", fmt.Errorf("%w: card declined", domain.ErrPaymentDeclined)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if ref, ok := p.byKey[req.IdempotencyKey]; ok {
+		return ref, nil
+	}
+	ref := providerRefPrefix + strings.ReplaceAll(uuid.New().String(), "-", "")
+	p.byKey[req.IdempotencyKey] = ref
+	p.methods[ref] = req.PaymentMethod
+	return ref, nil
+}
+
+func (p *StubProvider) Capture(ctx context.Context, providerRef string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	method, ok := p.methods[providerRef]
+	if !ok {
+		return fmt.Errorf("unknown authorization %q", providerRef)
+	}
+	if method == MethodCaptureFails {
+		return fmt.Errorf("%w: capture refused", domain.ErrPaymentDeclined)
+	}
+	return nil
+}
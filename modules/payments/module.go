@@ -0,0 +1,77 @@
+// Package payments takes payment for submitted orders through an external
+// payment provider and announces captured payments to the rest of the system.
+package payments
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/payments/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/payments/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/payments/domain"
+	httphandler "github.com/rai/clean-modularmonolith-go/modules/payments/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/payments/infrastructure/provider"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// Module is the public API for the payments bounded context.
+// External communication: HTTP API (RegisterRoutes) and the payment provider
+// Cross-module communication: publishes payments.PaymentCaptured; reads
+// amounts due through the OrderDirectory port.
+type Module interface {
+	// RegisterRoutes registers the module's HTTP routes to the given mux.
+	RegisterRoutes(mux *http.ServeMux)
+	// Operations documents the routes for the OpenAPI specification.
+	Operations() []openapi.Operation
+}
+
+type Config struct {
+	Repository          domain.PaymentRepository
+	Orders              domain.OrderDirectory
+	TransactionScope    transaction.Scope
+	Publisher           events.Publisher
+	PostCommitPublisher events.PostCommitPublisher
+	Logger              *slog.Logger
+
+	// Provider processes payments. Defaults to the in-memory StubProvider.
+	Provider domain.PaymentProvider
+
+	// CommandBus, when set, applies the platform's middleware (tracing,
+	// recording for the audit log and metrics, validation) to every command.
+	CommandBus *command.Bus
+}
+
+type module struct {
+	createPayment command.Handler[commands.CreatePaymentCommand, string]
+	getPayment    *queries.GetPaymentHandler
+	listPayments  *queries.ListOrderPaymentsHandler
+}
+
+// New creates a new payments module.
+func New(cfg Config) Module {
+	logger := cfg.Logger.With("module", "payments")
+	txScope := events.NewScopeWithDomainEvent(cfg.TransactionScope, cfg.Publisher, cfg.PostCommitPublisher)
+
+	p := cfg.Provider
+	if p == nil {
+		logger.Warn("no payment provider configured, using stub provider")
+		p = provider.NewStubProvider()
+	}
+
+	return &module{
+		createPayment: command.Register[commands.CreatePaymentCommand, string](cfg.CommandBus, "payments", commands.NewCreatePaymentHandler(cfg.Repository, cfg.Orders, p, txScope, logger)),
+		getPayment:    queries.NewGetPaymentHandler(cfg.Repository),
+		listPayments:  queries.NewListOrderPaymentsHandler(cfg.Repository),
+	}
+}
+
+func (m *module) RegisterRoutes(mux *http.ServeMux) {
+	httphandler.RegisterRoutes(mux, m.createPayment, m.getPayment, m.listPayments)
+}
+
+func (m *module) Operations() []openapi.Operation {
+	return httphandler.Operations()
+}
@@ -0,0 +1,54 @@
+// Package commands contains write use cases for the promotions module.
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// CreatePromotionCommand represents the intent to start an automatic promotion.
+type CreatePromotionCommand struct {
+	Name         string
+	Currency     string
+	MinimumTotal int64
+	ProductIDs   []string
+	StartsAt     time.Time
+	EndsAt       time.Time
+	RewardKind   string
+	RewardValue  int64
+}
+
+type CreatePromotionHandler struct {
+	repo    domain.PromotionRepository
+	txScope transaction.Scope
+}
+
+func NewCreatePromotionHandler(repo domain.PromotionRepository, txScope transaction.Scope) *CreatePromotionHandler {
+	return &CreatePromotionHandler{repo: repo, txScope: txScope}
+}
+
+// Handle creates the promotion and returns its ID.
+func (h *CreatePromotionHandler) Handle(ctx context.Context, cmd CreatePromotionCommand) (string, error) {
+	p, err := domain.NewPromotion(cmd.Name, cmd.Currency, domain.Rules{
+		MinimumTotal: cmd.MinimumTotal,
+		ProductIDs:   cmd.ProductIDs,
+		StartsAt:     cmd.StartsAt,
+		EndsAt:       cmd.EndsAt,
+	}, domain.Reward{
+		Kind:  domain.RewardKind(cmd.RewardKind),
+		Value: cmd.RewardValue,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err := h.txScope.Execute(ctx, func(ctx context.Context) error {
+		return h.repo.Save(ctx, p)
+	}); err != nil {
+		return "", err
+	}
+	return p.ID().String(), nil
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// DeactivatePromotionCommand stops a promotion from applying to new orders.
+type DeactivatePromotionCommand struct {
+	PromotionID string
+}
+
+type DeactivatePromotionHandler struct {
+	repo    domain.PromotionRepository
+	txScope transaction.Scope
+}
+
+func NewDeactivatePromotionHandler(repo domain.PromotionRepository, txScope transaction.Scope) *DeactivatePromotionHandler {
+	return &DeactivatePromotionHandler{repo: repo, txScope: txScope}
+}
+
+func (h *DeactivatePromotionHandler) Handle(ctx context.Context, cmd DeactivatePromotionCommand) error {
+	id, err := domain.ParsePromotionID(cmd.PromotionID)
+	if err != nil {
+		return err
+	}
+
+	return h.txScope.Execute(ctx, func(ctx context.Context) error {
+		p, err := h.repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		p.Deactivate()
+		return h.repo.Save(ctx, p)
+	})
+}
@@ -0,0 +1,63 @@
+// Package eventhandlers reacts to events from other modules.
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// OrderSubmittedHandler records a redemption for every promotion taken off a
+// submitted order and publishes PromotionAppliedEvent for each.
+// Subscribed pre-commit so the redemption counts stay consistent with the
+// orders they were applied to.
+type OrderSubmittedHandler struct {
+	repo    domain.PromotionRepository
+	txScope transaction.ScopeWithDomainEvent
+}
+
+func NewOrderSubmittedHandler(repo domain.PromotionRepository, txScope transaction.ScopeWithDomainEvent) *OrderSubmittedHandler {
+	return &OrderSubmittedHandler{repo: repo, txScope: txScope}
+}
+
+func (h *OrderSubmittedHandler) HandlerName() string { return "PromotionRedemptionHandler" }
+func (h *OrderSubmittedHandler) Subdomain() string   { return "promotions" }
+func (h *OrderSubmittedHandler) EventType() events.EventType {
+	return orderevents.OrderSubmittedEventType
+}
+
+func (h *OrderSubmittedHandler) Handle(ctx context.Context, event events.Event) error {
+	e, ok := event.(orderevents.OrderSubmittedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+	if len(e.Promotions) == 0 {
+		return nil
+	}
+
+	// Joins the submitting transaction; its own scope publishes the
+	// PromotionAppliedEvents raised here.
+	return h.txScope.ExecuteWithPublish(ctx, func(ctx context.Context) error {
+		for _, applied := range e.Promotions {
+			id, err := domain.ParsePromotionID(applied.PromotionID)
+			if err != nil {
+				return err
+			}
+			p, err := h.repo.FindByID(ctx, id)
+			if err != nil {
+				return fmt.Errorf("finding promotion %s: %w", applied.PromotionID, err)
+			}
+			if err := p.RecordRedemption(ctx, e.OrderID, e.UserID, applied.Amount); err != nil {
+				return err
+			}
+			if err := h.repo.Save(ctx, p); err != nil {
+				return fmt.Errorf("saving promotion: %w", err)
+			}
+		}
+		return nil
+	})
+}
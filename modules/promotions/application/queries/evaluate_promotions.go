@@ -0,0 +1,44 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/domain"
+)
+
+// AppliedPromotionDTO is a promotion a cart qualifies for and what it takes off.
+type AppliedPromotionDTO struct {
+	PromotionID string
+	Name        string
+	Amount      int64
+}
+
+// EvaluatePromotionsHandler runs the active promotions for the cart's
+// currency against it. It backs the orders module's promotion engine port
+// and runs inside the order submission transaction.
+type EvaluatePromotionsHandler struct {
+	repo domain.PromotionRepository
+}
+
+func NewEvaluatePromotionsHandler(repo domain.PromotionRepository) *EvaluatePromotionsHandler {
+	return &EvaluatePromotionsHandler{repo: repo}
+}
+
+func (h *EvaluatePromotionsHandler) Handle(ctx context.Context, cart domain.Cart) ([]AppliedPromotionDTO, error) {
+	promotions, err := h.repo.FindActive(ctx, cart.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("loading active promotions: %w", err)
+	}
+
+	matches := domain.Evaluate(promotions, cart)
+	applied := make([]AppliedPromotionDTO, len(matches))
+	for i, m := range matches {
+		applied[i] = AppliedPromotionDTO{
+			PromotionID: m.Promotion.ID().String(),
+			Name:        m.Promotion.Name(),
+			Amount:      m.Amount,
+		}
+	}
+	return applied, nil
+}
@@ -0,0 +1,78 @@
+// Package queries contains read-side handlers for the promotions module.
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/domain"
+)
+
+// PromotionDTO is a read model for a promotion.
+type PromotionDTO struct {
+	ID           string     `json:"id"`
+	Name         string     `json:"name"`
+	Currency     string     `json:"currency"`
+	MinimumTotal int64      `json:"minimum_total,omitempty"`
+	ProductIDs   []string   `json:"product_ids,omitempty"`
+	StartsAt     *time.Time `json:"starts_at,omitempty"`
+	EndsAt       *time.Time `json:"ends_at,omitempty"`
+	RewardKind   string     `json:"reward_kind"`
+	RewardValue  int64      `json:"reward_value"`
+	Active       bool       `json:"active"`
+	Redemptions  int        `json:"redemptions"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// GetPromotionQuery retrieves a promotion by ID.
+type GetPromotionQuery struct {
+	PromotionID string
+}
+
+type GetPromotionHandler struct {
+	repo domain.PromotionRepository
+}
+
+func NewGetPromotionHandler(repo domain.PromotionRepository) *GetPromotionHandler {
+	return &GetPromotionHandler{repo: repo}
+}
+
+func (h *GetPromotionHandler) Handle(ctx context.Context, query GetPromotionQuery) (*PromotionDTO, error) {
+	id, err := domain.ParsePromotionID(query.PromotionID)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := toPromotionDTO(p)
+	return &dto, nil
+}
+
+func toPromotionDTO(p *domain.Promotion) PromotionDTO {
+	rules := p.Rules()
+	dto := PromotionDTO{
+		ID:           p.ID().String(),
+		Name:         p.Name(),
+		Currency:     p.Currency(),
+		MinimumTotal: rules.MinimumTotal,
+		ProductIDs:   rules.ProductIDs,
+		RewardKind:   p.Reward().Kind.String(),
+		RewardValue:  p.Reward().Value,
+		Active:       p.Active(),
+		Redemptions:  p.Redemptions(),
+		CreatedAt:    p.CreatedAt(),
+		UpdatedAt:    p.UpdatedAt(),
+	}
+	if !rules.StartsAt.IsZero() {
+		dto.StartsAt = &rules.StartsAt
+	}
+	if !rules.EndsAt.IsZero() {
+		dto.EndsAt = &rules.EndsAt
+	}
+	return dto
+}
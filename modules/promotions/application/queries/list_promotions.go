@@ -0,0 +1,42 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+)
+
+type ListPromotionsQuery struct {
+	Offset int
+	Limit  int
+}
+
+type ListPromotionsResult struct {
+	Promotions []PromotionDTO `json:"promotions"`
+	Total      int            `json:"total"`
+	Offset     int            `json:"offset"`
+	Limit      int            `json:"limit"`
+}
+
+type ListPromotionsHandler struct {
+	repo domain.PromotionRepository
+}
+
+func NewListPromotionsHandler(repo domain.PromotionRepository) *ListPromotionsHandler {
+	return &ListPromotionsHandler{repo: repo}
+}
+
+func (h *ListPromotionsHandler) Handle(ctx context.Context, q ListPromotionsQuery) (ListPromotionsResult, error) {
+	pageReq := page.NewRequest(q.Offset, q.Limit)
+	promotions, total, err := h.repo.List(ctx, pageReq.Offset, pageReq.Limit)
+	if err != nil {
+		return ListPromotionsResult{}, err
+	}
+
+	result := ListPromotionsResult{Promotions: make([]PromotionDTO, len(promotions)), Total: total, Offset: pageReq.Offset, Limit: pageReq.Limit}
+	for i, p := range promotions {
+		result.Promotions[i] = toPromotionDTO(p)
+	}
+	return result, nil
+}
@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// Match is a promotion that applies to a cart and the amount it takes off.
+type Match struct {
+	Promotion *Promotion
+	Amount    int64
+}
+
+// Evaluate runs every promotion against cart and returns those that apply,
+// oldest promotion first. Promotions stack; the caller caps the combined
+// amount at what the order is worth.
+func Evaluate(promotions []*Promotion, cart Cart) []Match {
+	ordered := slices.Clone(promotions)
+	slices.SortStableFunc(ordered, func(a, b *Promotion) int {
+		if c := a.CreatedAt().Compare(b.CreatedAt()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID().String(), b.ID().String())
+	})
+
+	var matches []Match
+	for _, p := range ordered {
+		if amount := p.Evaluate(cart); amount > 0 {
+			matches = append(matches, Match{Promotion: p, Amount: amount})
+		}
+	}
+	return matches
+}
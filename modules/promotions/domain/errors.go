@@ -0,0 +1,20 @@
+// Package domain contains the promotion rules model.
+package domain
+
+import "errors"
+
+// Domain errors - business rule violations.
+var (
+	ErrPromotionNotFound     = errors.New("promotion not found")
+	ErrInvalidPromotionID    = errors.New("invalid promotion ID format")
+	ErrNameRequired          = errors.New("promotion name is required")
+	ErrNameTooLong           = errors.New("promotion name is too long")
+	ErrInvalidCurrency       = errors.New("currency must be a 3-letter ISO 4217 code")
+	ErrInvalidMinimumTotal   = errors.New("minimum total must not be negative")
+	ErrInvalidWindow         = errors.New("promotion must end after it starts")
+	ErrInvalidRewardKind     = errors.New("reward kind must be percentage or fixed_amount")
+	ErrInvalidPercentage     = errors.New("percentage must be between 1 and 100")
+	ErrInvalidAmountOff      = errors.New("amount off must be positive")
+	ErrTooManyProducts       = errors.New("too many products in promotion")
+	ErrRedemptionNotPositive = errors.New("redeemed amount must be positive")
+)
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	promotionevents "github.com/rai/clean-modularmonolith-go/modules/promotions/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// Public event types, re-exported for use within the module.
+const (
+	PromotionAppliedEventType = promotionevents.PromotionAppliedEventType
+)
+
+func NewPromotionAppliedEvent(p *Promotion, orderID, userID string, amount int64) promotionevents.PromotionAppliedEvent {
+	return promotionevents.PromotionAppliedEvent{
+		BaseEvent:   events.NewBaseEvent(PromotionAppliedEventType),
+		PromotionID: p.ID().String(),
+		OrderID:     orderID,
+		UserID:      userID,
+		Amount:      amount,
+		Currency:    p.Currency(),
+	}
+}
@@ -0,0 +1,17 @@
+package events
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const PromotionAppliedEventType events.EventType = "promotions.PromotionApplied"
+
+// PromotionAppliedEvent is published when a promotion was taken off a
+// submitted order.
+// This is a public domain event — it may be imported by event handlers in other modules.
+type PromotionAppliedEvent struct {
+	events.BaseEvent
+	PromotionID string `json:"promotion_id"`
+	OrderID     string `json:"order_id"`
+	UserID      string `json:"user_id"`
+	Amount      int64  `json:"amount"`
+	Currency    string `json:"currency"`
+}
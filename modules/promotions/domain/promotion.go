@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// PromotionID identifies a promotion.
+type PromotionID struct {
+	value string
+}
+
+func NewPromotionID() PromotionID {
+	return PromotionID{value: uuid.New().String()}
+}
+
+func ParsePromotionID(s string) (PromotionID, error) {
+	if _, err := uuid.Parse(s); err != nil {
+		return PromotionID{}, ErrInvalidPromotionID
+	}
+	return PromotionID{value: s}, nil
+}
+
+func (id PromotionID) String() string { return id.value }
+
+const maxNameLength = 100
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Promotion is the aggregate root for an automatic promotion: a reward
+// applied to every submitted order that satisfies its rules, without a code.
+type Promotion struct {
+	id          PromotionID
+	name        string
+	currency    string
+	rules       Rules
+	reward      Reward
+	active      bool
+	redemptions int
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// NewPromotion creates an active promotion for orders in currency.
+func NewPromotion(name, currency string, rules Rules, reward Reward) (*Promotion, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrNameRequired
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return nil, ErrNameTooLong
+	}
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if !currencyPattern.MatchString(currency) {
+		return nil, ErrInvalidCurrency
+	}
+	if err := rules.validate(); err != nil {
+		return nil, err
+	}
+	if err := reward.validate(); err != nil {
+		return nil, err
+	}
+	rules.ProductIDs = slices.Compact(slices.Sorted(slices.Values(rules.ProductIDs)))
+
+	now := time.Now().UTC()
+	return &Promotion{
+		id:        NewPromotionID(),
+		name:      name,
+		currency:  currency,
+		rules:     rules,
+		reward:    reward,
+		active:    true,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// ReconstitutePromotion rebuilds a Promotion from persistence without validation.
+func ReconstitutePromotion(id PromotionID, name, currency string, rules Rules, reward Reward, active bool, redemptions int, createdAt, updatedAt time.Time) *Promotion {
+	return &Promotion{
+		id:          id,
+		name:        name,
+		currency:    currency,
+		rules:       rules,
+		reward:      reward,
+		active:      active,
+		redemptions: redemptions,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
+func (p *Promotion) ID() PromotionID      { return p.id }
+func (p *Promotion) Name() string         { return p.name }
+func (p *Promotion) Currency() string     { return p.currency }
+func (p *Promotion) Rules() Rules         { return p.rules }
+func (p *Promotion) Reward() Reward       { return p.reward }
+func (p *Promotion) Active() bool         { return p.active }
+func (p *Promotion) Redemptions() int     { return p.redemptions }
+func (p *Promotion) CreatedAt() time.Time { return p.createdAt }
+func (p *Promotion) UpdatedAt() time.Time { return p.updatedAt }
+
+// Evaluate returns how much the promotion takes off cart, or zero if the
+// cart does not satisfy every rule.
+func (p *Promotion) Evaluate(cart Cart) int64 {
+	if !p.active || cart.Currency != p.currency {
+		return 0
+	}
+	for _, c := range p.rules.Conditions() {
+		if !c.Matches(cart) {
+			return 0
+		}
+	}
+	return p.reward.amountFor(p.qualifyingAmount(cart))
+}
+
+// qualifyingAmount is the part of the cart the reward applies to.
+func (p *Promotion) qualifyingAmount(cart Cart) int64 {
+	if len(p.rules.ProductIDs) == 0 {
+		return cart.Subtotal
+	}
+	var amount int64
+	for _, line := range cart.Lines {
+		if slices.Contains(p.rules.ProductIDs, line.ProductID) {
+			amount += line.Amount
+		}
+	}
+	return min(amount, cart.Subtotal)
+}
+
+// Deactivate stops the promotion from applying to new orders.
+func (p *Promotion) Deactivate() {
+	if !p.active {
+		return
+	}
+	p.active = false
+	p.updatedAt = time.Now().UTC()
+}
+
+// RecordRedemption counts a submitted order the promotion was applied to.
+// Orders evaluated while the promotion was active are honoured even if it
+// was deactivated since.
+// Adds PromotionAppliedEvent to the context for later dispatch.
+func (p *Promotion) RecordRedemption(ctx context.Context, orderID, userID string, amount int64) error {
+	if amount <= 0 {
+		return ErrRedemptionNotPositive
+	}
+	p.redemptions++
+	p.updatedAt = time.Now().UTC()
+	events.Add(ctx, NewPromotionAppliedEvent(p, orderID, userID, amount))
+	return nil
+}
@@ -0,0 +1,158 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/domain"
+	promotionevents "github.com/rai/clean-modularmonolith-go/modules/promotions/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+var submittedAt = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func newPromotion(t *testing.T, rules domain.Rules, reward domain.Reward) *domain.Promotion {
+	t.Helper()
+	p, err := domain.NewPromotion("Spring sale", "usd", rules, reward)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return p
+}
+
+func cart() domain.Cart {
+	return domain.Cart{
+		OrderID:  "order-1",
+		UserID:   "user-1",
+		Currency: "USD",
+		Subtotal: 5000,
+		Lines: []domain.CartLine{
+			{ProductID: "shirt", Quantity: 2, Amount: 3000},
+			{ProductID: "socks", Quantity: 1, Amount: 2000},
+		},
+		At: submittedAt,
+	}
+}
+
+func TestNewPromotion_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		rules    domain.Rules
+		reward   domain.Reward
+		wantErr  error
+	}{
+		{"bad currency", "dollars", domain.Rules{}, domain.Reward{Kind: domain.RewardPercentage, Value: 10}, domain.ErrInvalidCurrency},
+		{"negative minimum", "USD", domain.Rules{MinimumTotal: -1}, domain.Reward{Kind: domain.RewardPercentage, Value: 10}, domain.ErrInvalidMinimumTotal},
+		{"empty window", "USD", domain.Rules{StartsAt: submittedAt, EndsAt: submittedAt}, domain.Reward{Kind: domain.RewardPercentage, Value: 10}, domain.ErrInvalidWindow},
+		{"unknown reward", "USD", domain.Rules{}, domain.Reward{Kind: "bogo", Value: 1}, domain.ErrInvalidRewardKind},
+		{"percentage over 100", "USD", domain.Rules{}, domain.Reward{Kind: domain.RewardPercentage, Value: 101}, domain.ErrInvalidPercentage},
+		{"zero amount off", "USD", domain.Rules{}, domain.Reward{Kind: domain.RewardFixedAmount}, domain.ErrInvalidAmountOff},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.NewPromotion("Spring sale", tt.currency, tt.rules, tt.reward)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPromotion_Evaluate(t *testing.T) {
+	tests := []struct {
+		name   string
+		rules  domain.Rules
+		reward domain.Reward
+		want   int64
+	}{
+		{"percentage of subtotal", domain.Rules{}, domain.Reward{Kind: domain.RewardPercentage, Value: 10}, 500},
+		{"minimum total met", domain.Rules{MinimumTotal: 5000}, domain.Reward{Kind: domain.RewardFixedAmount, Value: 700}, 700},
+		{"minimum total not met", domain.Rules{MinimumTotal: 5001}, domain.Reward{Kind: domain.RewardFixedAmount, Value: 700}, 0},
+		{"product set discounts matching lines only", domain.Rules{ProductIDs: []string{"socks", "hat"}}, domain.Reward{Kind: domain.RewardPercentage, Value: 25}, 500},
+		{"product set not in cart", domain.Rules{ProductIDs: []string{"hat"}}, domain.Reward{Kind: domain.RewardPercentage, Value: 25}, 0},
+		{"fixed amount capped at qualifying lines", domain.Rules{ProductIDs: []string{"socks"}}, domain.Reward{Kind: domain.RewardFixedAmount, Value: 9000}, 2000},
+		{"inside window", domain.Rules{StartsAt: submittedAt.Add(-time.Hour), EndsAt: submittedAt.Add(time.Hour)}, domain.Reward{Kind: domain.RewardFixedAmount, Value: 100}, 100},
+		{"not started", domain.Rules{StartsAt: submittedAt.Add(time.Hour)}, domain.Reward{Kind: domain.RewardFixedAmount, Value: 100}, 0},
+		{"ended", domain.Rules{EndsAt: submittedAt}, domain.Reward{Kind: domain.RewardFixedAmount, Value: 100}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newPromotion(t, tt.rules, tt.reward)
+			if got := p.Evaluate(cart()); got != tt.want {
+				t.Errorf("expected %d off, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPromotion_Evaluate_IgnoresOtherCurrenciesAndInactive(t *testing.T) {
+	p := newPromotion(t, domain.Rules{}, domain.Reward{Kind: domain.RewardPercentage, Value: 10})
+
+	eur := cart()
+	eur.Currency = "EUR"
+	if got := p.Evaluate(eur); got != 0 {
+		t.Errorf("expected no reduction for EUR cart, got %d", got)
+	}
+
+	p.Deactivate()
+	if got := p.Evaluate(cart()); got != 0 {
+		t.Errorf("expected no reduction once deactivated, got %d", got)
+	}
+}
+
+func TestEvaluate_ReturnsMatchesOldestFirst(t *testing.T) {
+	older := domain.ReconstitutePromotion(domain.NewPromotionID(), "Older", "USD", domain.Rules{}, domain.Reward{Kind: domain.RewardFixedAmount, Value: 100}, true, 0, submittedAt.Add(-2*time.Hour), submittedAt)
+	newer := domain.ReconstitutePromotion(domain.NewPromotionID(), "Newer", "USD", domain.Rules{}, domain.Reward{Kind: domain.RewardFixedAmount, Value: 200}, true, 0, submittedAt.Add(-time.Hour), submittedAt)
+	unmatched := domain.ReconstitutePromotion(domain.NewPromotionID(), "Big spenders", "USD", domain.Rules{MinimumTotal: 10000}, domain.Reward{Kind: domain.RewardFixedAmount, Value: 500}, true, 0, submittedAt.Add(-3*time.Hour), submittedAt)
+
+	matches := domain.Evaluate([]*domain.Promotion{newer, unmatched, older}, cart())
+
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %d", len(matches))
+	}
+	if matches[0].Promotion != older || matches[0].Amount != 100 {
+		t.Errorf("expected older promotion first with 100 off, got %s with %d", matches[0].Promotion.Name(), matches[0].Amount)
+	}
+	if matches[1].Promotion != newer || matches[1].Amount != 200 {
+		t.Errorf("expected newer promotion second with 200 off, got %s with %d", matches[1].Promotion.Name(), matches[1].Amount)
+	}
+}
+
+func TestPromotion_RecordRedemption(t *testing.T) {
+	p := newPromotion(t, domain.Rules{}, domain.Reward{Kind: domain.RewardPercentage, Value: 10})
+
+	captured, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		return p.RecordRedemption(ctx, "order-1", "user-1", 500)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if p.Redemptions() != 1 {
+		t.Errorf("expected 1 redemption, got %d", p.Redemptions())
+	}
+	if len(captured) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(captured))
+	}
+	e, ok := captured[0].(promotionevents.PromotionAppliedEvent)
+	if !ok {
+		t.Fatalf("expected PromotionAppliedEvent, got %T", captured[0])
+	}
+	if e.PromotionID != p.ID().String() || e.OrderID != "order-1" || e.Amount != 500 || e.Currency != "USD" {
+		t.Errorf("unexpected event: %+v", e)
+	}
+}
+
+func TestPromotion_RecordRedemption_RejectsNonPositiveAmount(t *testing.T) {
+	p := newPromotion(t, domain.Rules{}, domain.Reward{Kind: domain.RewardPercentage, Value: 10})
+
+	_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		return p.RecordRedemption(ctx, "order-1", "user-1", 0)
+	})
+	if !errors.Is(err, domain.ErrRedemptionNotPositive) {
+		t.Errorf("expected ErrRedemptionNotPositive, got %v", err)
+	}
+}
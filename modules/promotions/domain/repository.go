@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// PromotionRepository persists promotions, scoped to the tenant of its
+// context.
+type PromotionRepository interface {
+	Save(ctx context.Context, p *Promotion) error
+	// FindByID returns ErrPromotionNotFound if no promotion exists with the ID.
+	FindByID(ctx context.Context, id PromotionID) (*Promotion, error)
+	// FindActive returns the active promotions for a currency.
+	FindActive(ctx context.Context, currency string) ([]*Promotion, error)
+	// List returns all promotions, newest first, and the total count.
+	List(ctx context.Context, offset, limit int) ([]*Promotion, int, error)
+}
@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Cart is what a promotion is evaluated against: an order being submitted.
+// Subtotal is after any discount code; line amounts are before it.
+type Cart struct {
+	OrderID  string
+	UserID   string
+	Currency string
+	Subtotal int64
+	Lines    []CartLine
+	At       time.Time
+}
+
+// CartLine is a product in a Cart and what it costs in total.
+type CartLine struct {
+	ProductID string
+	Quantity  int
+	Amount    int64
+}
+
+// Condition is one rule a cart must satisfy for a promotion to apply.
+type Condition interface {
+	Matches(cart Cart) bool
+}
+
+// MinimumTotal requires the cart subtotal to reach an amount.
+type MinimumTotal int64
+
+func (m MinimumTotal) Matches(cart Cart) bool { return cart.Subtotal >= int64(m) }
+
+// ProductSet requires the cart to contain at least one of the products.
+type ProductSet []string
+
+func (p ProductSet) Matches(cart Cart) bool {
+	for _, line := range cart.Lines {
+		if slices.Contains(p, line.ProductID) {
+			return true
+		}
+	}
+	return false
+}
+
+// DateWindow requires the cart to be submitted within [Start, End).
+// A zero bound leaves that side of the window open.
+type DateWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+func (w DateWindow) Matches(cart Cart) bool {
+	if !w.Start.IsZero() && cart.At.Before(w.Start) {
+		return false
+	}
+	if !w.End.IsZero() && !cart.At.Before(w.End) {
+		return false
+	}
+	return true
+}
+
+// Rules are the conditions configured on a promotion. All of them must hold.
+type Rules struct {
+	MinimumTotal int64     // zero means no minimum
+	ProductIDs   []string  // empty means any product
+	StartsAt     time.Time // zero means already started
+	EndsAt       time.Time // zero means never ends
+}
+
+// maxProductsPerPromotion bounds the product set of a single promotion.
+const maxProductsPerPromotion = 100
+
+func (r Rules) validate() error {
+	if r.MinimumTotal < 0 {
+		return ErrInvalidMinimumTotal
+	}
+	if len(r.ProductIDs) > maxProductsPerPromotion {
+		return ErrTooManyProducts
+	}
+	if !r.StartsAt.IsZero() && !r.EndsAt.IsZero() && !r.EndsAt.After(r.StartsAt) {
+		return ErrInvalidWindow
+	}
+	return nil
+}
+
+// Conditions returns the rules as the conditions the engine checks.
+func (r Rules) Conditions() []Condition {
+	conditions := []Condition{DateWindow{Start: r.StartsAt, End: r.EndsAt}}
+	if r.MinimumTotal > 0 {
+		conditions = append(conditions, MinimumTotal(r.MinimumTotal))
+	}
+	if len(r.ProductIDs) > 0 {
+		conditions = append(conditions, ProductSet(r.ProductIDs))
+	}
+	return conditions
+}
+
+// RewardKind identifies how a promotion reduces the order.
+type RewardKind string
+
+const (
+	// RewardPercentage takes a whole-number percentage (1-100) off the qualifying amount.
+	RewardPercentage RewardKind = "percentage"
+	// RewardFixedAmount takes a fixed amount off, capped at the qualifying amount.
+	RewardFixedAmount RewardKind = "fixed_amount"
+)
+
+func (k RewardKind) String() string { return string(k) }
+
+// Reward is what a promotion takes off a qualifying cart. The qualifying
+// amount is the subtotal, or only the matching lines for a product set.
+type Reward struct {
+	Kind  RewardKind
+	Value int64 // percentage (1-100) or amount in the smallest currency unit
+}
+
+func (r Reward) validate() error {
+	switch r.Kind {
+	case RewardPercentage:
+		if r.Value < 1 || r.Value > 100 {
+			return ErrInvalidPercentage
+		}
+	case RewardFixedAmount:
+		if r.Value <= 0 {
+			return ErrInvalidAmountOff
+		}
+	default:
+		return ErrInvalidRewardKind
+	}
+	return nil
+}
+
+// amountFor returns the reduction for a qualifying amount, rounded half-up.
+func (r Reward) amountFor(qualifying int64) int64 {
+	var off int64
+	switch r.Kind {
+	case RewardPercentage:
+		off = (qualifying*r.Value + 50) / 100
+	case RewardFixedAmount:
+		off = r.Value
+	}
+	return min(off, qualifying)
+}
@@ -0,0 +1,154 @@
+// Package http provides the admin endpoints for managing promotions.
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+type Handler struct {
+	create     command.Handler[commands.CreatePromotionCommand, string]
+	deactivate command.VoidHandler[commands.DeactivatePromotionCommand]
+	get        *queries.GetPromotionHandler
+	list       *queries.ListPromotionsHandler
+	admin      security.AdminGuard
+}
+
+// RegisterRoutes registers the promotions module routes to the given mux.
+// Every route is admin-only.
+func RegisterRoutes(mux *http.ServeMux, create command.Handler[commands.CreatePromotionCommand, string], deactivate command.VoidHandler[commands.DeactivatePromotionCommand], get *queries.GetPromotionHandler, list *queries.ListPromotionsHandler, admin security.AdminGuard) {
+	h := &Handler{
+		create:     create,
+		deactivate: deactivate,
+		get:        get,
+		list:       list,
+		admin:      admin,
+	}
+
+	mux.HandleFunc("POST /promotions", h.admin.HandlerFunc(h.handleCreatePromotion))
+	mux.HandleFunc("GET /promotions", h.admin.HandlerFunc(h.handleListPromotions))
+	mux.HandleFunc("GET /promotions/{id}", h.admin.HandlerFunc(h.handleGetPromotion))
+	mux.HandleFunc("POST /promotions/{id}/deactivate", h.admin.HandlerFunc(h.handleDeactivatePromotion))
+}
+
+type createPromotionRequest struct {
+	Name         string     `json:"name"`
+	Currency     string     `json:"currency"`
+	MinimumTotal int64      `json:"minimum_total"`
+	ProductIDs   []string   `json:"product_ids"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+	RewardKind   string     `json:"reward_kind"`
+	RewardValue  int64      `json:"reward_value"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) handleCreatePromotion(w http.ResponseWriter, r *http.Request) {
+	var req createPromotionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cmd := commands.CreatePromotionCommand{
+		Name:         req.Name,
+		Currency:     req.Currency,
+		MinimumTotal: req.MinimumTotal,
+		ProductIDs:   req.ProductIDs,
+		RewardKind:   req.RewardKind,
+		RewardValue:  req.RewardValue,
+	}
+	if req.StartsAt != nil {
+		cmd.StartsAt = *req.StartsAt
+	}
+	if req.EndsAt != nil {
+		cmd.EndsAt = *req.EndsAt
+	}
+
+	id, err := h.create.Handle(r.Context(), cmd)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	promotion, err := h.get.Handle(r.Context(), queries.GetPromotionQuery{PromotionID: id})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, promotion)
+}
+
+func (h *Handler) handleListPromotions(w http.ResponseWriter, r *http.Request) {
+	p := page.ParseRequest(r.URL.Query())
+
+	result, err := h.list.Handle(r.Context(), queries.ListPromotionsQuery{Offset: p.Offset, Limit: p.Limit})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) handleGetPromotion(w http.ResponseWriter, r *http.Request) {
+	promotion, err := h.get.Handle(r.Context(), queries.GetPromotionQuery{PromotionID: r.PathValue("id")})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, promotion)
+}
+
+func (h *Handler) handleDeactivatePromotion(w http.ResponseWriter, r *http.Request) {
+	if err := h.deactivate.Handle(r.Context(), commands.DeactivatePromotionCommand{PromotionID: r.PathValue("id")}); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrPromotionNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidPromotionID),
+		errors.Is(err, domain.ErrNameRequired),
+		errors.Is(err, domain.ErrNameTooLong),
+		errors.Is(err, domain.ErrInvalidCurrency),
+		errors.Is(err, domain.ErrInvalidMinimumTotal),
+		errors.Is(err, domain.ErrInvalidWindow),
+		errors.Is(err, domain.ErrInvalidRewardKind),
+		errors.Is(err, domain.ErrInvalidPercentage),
+		errors.Is(err, domain.ErrInvalidAmountOff),
+		errors.Is(err, domain.ErrTooManyProducts):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
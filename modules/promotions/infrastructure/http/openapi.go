@@ -0,0 +1,21 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+)
+
+// Operations documents the routes registered by RegisterRoutes.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Pattern: "POST /promotions", Summary: "Create a promotion", Admin: true, Request: createPromotionRequest{}, Status: http.StatusCreated, Response: queries.PromotionDTO{}},
+		{Pattern: "GET /promotions", Summary: "List promotions", Admin: true, Query: []openapi.Param{
+			{Name: "offset", Type: "integer"},
+			{Name: "limit", Type: "integer"},
+		}, Response: queries.ListPromotionsResult{}},
+		{Pattern: "GET /promotions/{id}", Summary: "Get a promotion", Admin: true, Response: queries.PromotionDTO{}},
+		{Pattern: "POST /promotions/{id}/deactivate", Summary: "Deactivate a promotion", Admin: true, Status: http.StatusNoContent},
+	}
+}
@@ -0,0 +1,187 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+const promotionColumns = `PromotionID, Name, Currency, MinimumTotal, ProductIDs, StartsAt, EndsAt, RewardKind, RewardValue, Active, Redemptions, CreatedAt, UpdatedAt`
+
+// SpannerPromotionRepository implements PromotionRepository using Cloud
+// Spanner. Promotions carry the tenant that created them and are only found
+// in it, so a promotion never applies to another tenant's orders.
+type SpannerPromotionRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerPromotionRepository(client *spanner.Client, logger *slog.Logger) *SpannerPromotionRepository {
+	return &SpannerPromotionRepository{client: client, logger: logger}
+}
+
+func (r *SpannerPromotionRepository) Save(ctx context.Context, p *domain.Promotion) error {
+	rules := p.Rules()
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO Promotions (TenantID, ` + promotionColumns + `)
+		      VALUES (@tenantID, @promotionID, @name, @currency, @minimumTotal, @productIDs, @startsAt, @endsAt, @rewardKind, @rewardValue, @active, @redemptions, @createdAt, @updatedAt)`,
+		Params: map[string]interface{}{
+			"tenantID":     requestcontext.TenantID(ctx),
+			"promotionID":  p.ID().String(),
+			"name":         p.Name(),
+			"currency":     p.Currency(),
+			"minimumTotal": rules.MinimumTotal,
+			"productIDs":   rules.ProductIDs,
+			"startsAt":     spanner.NullTime{Time: rules.StartsAt, Valid: !rules.StartsAt.IsZero()},
+			"endsAt":       spanner.NullTime{Time: rules.EndsAt, Valid: !rules.EndsAt.IsZero()},
+			"rewardKind":   p.Reward().Kind.String(),
+			"rewardValue":  p.Reward().Value,
+			"active":       p.Active(),
+			"redemptions":  int64(p.Redemptions()),
+			"createdAt":    p.CreatedAt(),
+			"updatedAt":    p.UpdatedAt(),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save promotion: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerPromotionRepository) FindByID(ctx context.Context, id domain.PromotionID) (*domain.Promotion, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (*domain.Promotion, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT ` + promotionColumns + ` FROM Promotions WHERE PromotionID = @promotionID AND TenantID = @tenantID`,
+			Params: map[string]interface{}{"promotionID": id.String(), "tenantID": requestcontext.TenantID(ctx)},
+		})
+		defer iter.Stop()
+
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return nil, domain.ErrPromotionNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query promotion: %w", err)
+		}
+		return scanPromotion(row)
+	})
+}
+
+// FindActive is served by the PromotionsByActiveCurrency index. The date
+// window is left to the domain so that it is evaluated at submission time.
+func (r *SpannerPromotionRepository) FindActive(ctx context.Context, currency string) ([]*domain.Promotion, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.Promotion, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT ` + promotionColumns + `
+			      FROM Promotions@{FORCE_INDEX=PromotionsByActiveCurrency}
+			      WHERE TenantID = @tenantID AND Active = TRUE AND Currency = @currency`,
+			Params: map[string]interface{}{"tenantID": requestcontext.TenantID(ctx), "currency": currency},
+		})
+		return collectPromotions(iter)
+	})
+}
+
+func (r *SpannerPromotionRepository) List(ctx context.Context, offset, limit int) ([]*domain.Promotion, int, error) {
+	var total int
+	tenantID := requestcontext.TenantID(ctx)
+	promotions, err := platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.Promotion, error) {
+		countIter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT COUNT(*) FROM Promotions WHERE TenantID = @tenantID`,
+			Params: map[string]interface{}{"tenantID": tenantID},
+		})
+		defer countIter.Stop()
+
+		var totalCount int64
+		countRow, err := countIter.Next()
+		if err != nil && err != iterator.Done {
+			return nil, fmt.Errorf("failed to count promotions: %w", err)
+		}
+		if countRow != nil {
+			if err := countRow.Columns(&totalCount); err != nil {
+				return nil, fmt.Errorf("failed to scan count: %w", err)
+			}
+		}
+		total = int(totalCount)
+
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT ` + promotionColumns + `
+			      FROM Promotions
+			      WHERE TenantID = @tenantID
+			      ORDER BY CreatedAt DESC
+			      LIMIT @limit OFFSET @offset`,
+			Params: map[string]interface{}{
+				"tenantID": tenantID,
+				"limit":    int64(limit),
+				"offset":   int64(offset),
+			},
+		})
+		return collectPromotions(iter)
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return promotions, total, nil
+}
+
+func collectPromotions(iter *spanner.RowIterator) ([]*domain.Promotion, error) {
+	defer iter.Stop()
+
+	var promotions []*domain.Promotion
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return promotions, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query promotions: %w", err)
+		}
+		p, err := scanPromotion(row)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, p)
+	}
+}
+
+func scanPromotion(row *spanner.Row) (*domain.Promotion, error) {
+	var (
+		promotionID, name, currency, rewardKind string
+		minimumTotal, rewardValue, redemptions  int64
+		productIDs                              []string
+		startsAt, endsAt                        spanner.NullTime
+		active                                  bool
+		createdAt, updatedAt                    time.Time
+	)
+	if err := row.Columns(&promotionID, &name, &currency, &minimumTotal, &productIDs, &startsAt, &endsAt, &rewardKind, &rewardValue, &active, &redemptions, &createdAt, &updatedAt); err != nil {
+		return nil, fmt.Errorf("failed to scan promotion: %w", err)
+	}
+
+	id, err := domain.ParsePromotionID(promotionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid promotion ID in database: %w", err)
+	}
+
+	return domain.ReconstitutePromotion(
+		id,
+		name,
+		currency,
+		domain.Rules{
+			MinimumTotal: minimumTotal,
+			ProductIDs:   productIDs,
+			StartsAt:     startsAt.Time,
+			EndsAt:       endsAt.Time,
+		},
+		domain.Reward{Kind: domain.RewardKind(rewardKind), Value: rewardValue},
+		active,
+		int(redemptions),
+		createdAt,
+		updatedAt,
+	), nil
+}
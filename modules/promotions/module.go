@@ -0,0 +1,146 @@
+// Package promotions runs automatic promotions: admin-defined rules that
+// take an amount off every submitted order they match, without a code.
+package promotions
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/application/eventhandlers"
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/promotions/domain"
+	httphandler "github.com/rai/clean-modularmonolith-go/modules/promotions/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// Module is the public API for the promotions bounded context.
+// External communication: HTTP API (RegisterRoutes)
+// Cross-module communication: Evaluate backs the orders module's promotion
+// engine port; consumes orders.OrderSubmitted and publishes
+// promotions.PromotionApplied for every promotion redeemed.
+type Module interface {
+	// RegisterRoutes registers the module's HTTP routes to the given mux.
+	RegisterRoutes(mux *http.ServeMux)
+	// Operations documents the routes for the OpenAPI specification.
+	Operations() []openapi.Operation
+
+	// Evaluate returns the promotions a cart qualifies for, oldest first.
+	// The amounts are not capped; the caller limits them to the order total.
+	Evaluate(ctx context.Context, cart Cart) ([]AppliedPromotion, error)
+}
+
+// Cart is an order being submitted, as seen by Evaluate.
+// Subtotal is after any discount code; line amounts are before it.
+type Cart struct {
+	OrderID  string
+	UserID   string
+	Currency string
+	Subtotal int64
+	Lines    []CartLine
+	At       time.Time
+}
+
+// CartLine is a product in a Cart and what it costs in total.
+type CartLine struct {
+	ProductID string
+	Quantity  int
+	Amount    int64
+}
+
+// AppliedPromotion is a promotion a Cart qualifies for and what it takes off.
+type AppliedPromotion struct {
+	PromotionID string
+	Name        string
+	Amount      int64
+}
+
+type Config struct {
+	Repository          domain.PromotionRepository
+	TransactionScope    transaction.Scope
+	Publisher           events.Publisher
+	PostCommitPublisher events.PostCommitPublisher
+	Subscriber          events.Subscriber
+	Logger              *slog.Logger
+
+	// AdminToken guards every endpoint via the X-Admin-Token header.
+	// The endpoints are disabled when empty.
+	AdminToken string
+
+	// SecuritySink receives the admin token and authorization decisions
+	// made by the HTTP handlers. Optional: nil records nothing.
+	SecuritySink security.Sink
+
+	// CommandBus, when set, applies the platform's middleware (tracing,
+	// recording for the audit log and metrics, validation) to every command.
+	CommandBus *command.Bus
+}
+
+type module struct {
+	create     command.Handler[commands.CreatePromotionCommand, string]
+	deactivate command.VoidHandler[commands.DeactivatePromotionCommand]
+	get        *queries.GetPromotionHandler
+	list       *queries.ListPromotionsHandler
+	evaluate   *queries.EvaluatePromotionsHandler
+	admin      security.AdminGuard
+}
+
+// New initializes the promotions module and subscribes to events.
+func New(cfg Config) Module {
+	logger := cfg.Logger.With("module", "promotions")
+	txScope := events.NewScopeWithDomainEvent(cfg.TransactionScope, cfg.Publisher, cfg.PostCommitPublisher)
+
+	// Pre-commit: count redemptions atomically with the submitted order
+	orderSubmitted := eventhandlers.NewOrderSubmittedHandler(cfg.Repository, txScope)
+	if err := cfg.Subscriber.Subscribe(orderSubmitted.EventType(), orderSubmitted); err != nil {
+		logger.Error("failed to subscribe to order submitted event", slog.Any("error", err))
+	}
+
+	return &module{
+		create:     command.Register[commands.CreatePromotionCommand, string](cfg.CommandBus, "promotions", commands.NewCreatePromotionHandler(cfg.Repository, cfg.TransactionScope)),
+		deactivate: command.RegisterVoid[commands.DeactivatePromotionCommand](cfg.CommandBus, "promotions", commands.NewDeactivatePromotionHandler(cfg.Repository, cfg.TransactionScope)),
+		get:        queries.NewGetPromotionHandler(cfg.Repository),
+		list:       queries.NewListPromotionsHandler(cfg.Repository),
+		evaluate:   queries.NewEvaluatePromotionsHandler(cfg.Repository),
+		admin:      security.AdminGuard{Module: "promotions", Token: cfg.AdminToken, Sink: cfg.SecuritySink},
+	}
+}
+
+func (m *module) RegisterRoutes(mux *http.ServeMux) {
+	httphandler.RegisterRoutes(mux, m.create, m.deactivate, m.get, m.list, m.admin)
+}
+
+func (m *module) Operations() []openapi.Operation {
+	return httphandler.Operations()
+}
+
+func (m *module) Evaluate(ctx context.Context, cart Cart) ([]AppliedPromotion, error) {
+	lines := make([]domain.CartLine, len(cart.Lines))
+	for i, l := range cart.Lines {
+		lines[i] = domain.CartLine(l)
+	}
+
+	applied, err := m.evaluate.Handle(ctx, domain.Cart{
+		OrderID:  cart.OrderID,
+		UserID:   cart.UserID,
+		Currency: cart.Currency,
+		Subtotal: cart.Subtotal,
+		Lines:    lines,
+		At:       cart.At,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]AppliedPromotion, len(applied))
+	for i, a := range applied {
+		result[i] = AppliedPromotion(a)
+	}
+	return result, nil
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// ModerateReviewCommand approves or rejects a review.
+type ModerateReviewCommand struct {
+	ReviewID string
+	Status   string
+	Note     string
+}
+
+type ModerateReviewHandler struct {
+	repo    domain.ReviewRepository
+	txScope transaction.ScopeWithDomainEvent
+}
+
+func NewModerateReviewHandler(repo domain.ReviewRepository, txScope transaction.ScopeWithDomainEvent) *ModerateReviewHandler {
+	return &ModerateReviewHandler{repo: repo, txScope: txScope}
+}
+
+// Handle executes the moderate review use case. The product rating
+// projection is updated in the same transaction by a pre-commit handler.
+func (h *ModerateReviewHandler) Handle(ctx context.Context, cmd ModerateReviewCommand) error {
+	id, err := domain.ParseReviewID(cmd.ReviewID)
+	if err != nil {
+		return err
+	}
+	status, err := domain.ParseModerationStatus(cmd.Status)
+	if err != nil {
+		return err
+	}
+
+	return h.txScope.ExecuteWithPublish(ctx, func(ctx context.Context) error {
+		review, err := h.repo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := review.Moderate(ctx, status, cmd.Note); err != nil {
+			return err
+		}
+
+		if err := h.repo.Save(ctx, review); err != nil {
+			return fmt.Errorf("saving review: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,70 @@
+// Package commands contains write use cases for the reviews module.
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// SubmitReviewCommand represents a user's rating of a product they bought.
+type SubmitReviewCommand struct {
+	ProductID string
+	UserID    string
+	Rating    int
+	Title     string
+	Body      string
+}
+
+type SubmitReviewHandler struct {
+	reviews   domain.ReviewRepository
+	purchases domain.PurchaseRepository
+	txScope   transaction.Scope
+}
+
+func NewSubmitReviewHandler(reviews domain.ReviewRepository, purchases domain.PurchaseRepository, txScope transaction.Scope) *SubmitReviewHandler {
+	return &SubmitReviewHandler{
+		reviews:   reviews,
+		purchases: purchases,
+		txScope:   txScope,
+	}
+}
+
+// Handle executes the submit review use case and returns the review ID.
+// The review is pending until a moderator approves it.
+func (h *SubmitReviewHandler) Handle(ctx context.Context, cmd SubmitReviewCommand) (string, error) {
+	review, err := domain.NewReview(cmd.ProductID, cmd.UserID, cmd.Rating, cmd.Title, cmd.Body)
+	if err != nil {
+		return "", err
+	}
+
+	err = h.txScope.Execute(ctx, func(ctx context.Context) error {
+		purchased, err := h.purchases.Exists(ctx, cmd.UserID, cmd.ProductID)
+		if err != nil {
+			return fmt.Errorf("checking purchase: %w", err)
+		}
+		if !purchased {
+			return domain.ErrProductNotPurchased
+		}
+
+		reviewed, err := h.reviews.ExistsForUser(ctx, cmd.ProductID, cmd.UserID)
+		if err != nil {
+			return fmt.Errorf("checking existing review: %w", err)
+		}
+		if reviewed {
+			return domain.ErrAlreadyReviewed
+		}
+
+		if err := h.reviews.Save(ctx, review); err != nil {
+			return fmt.Errorf("saving review: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return review.ID().String(), nil
+}
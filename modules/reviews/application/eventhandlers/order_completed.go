@@ -0,0 +1,51 @@
+// Package eventhandlers contains the reviews module's reactions to domain events.
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// OrderCompletedHandler records the products of completed orders as purchases,
+// making their buyers eligible to review them. Saving is an upsert, so
+// redelivered events are harmless.
+type OrderCompletedHandler struct {
+	purchases domain.PurchaseRepository
+	txScope   transaction.Scope
+}
+
+func NewOrderCompletedHandler(purchases domain.PurchaseRepository, txScope transaction.Scope) *OrderCompletedHandler {
+	return &OrderCompletedHandler{purchases: purchases, txScope: txScope}
+}
+
+func (h *OrderCompletedHandler) HandlerName() string { return "ReviewEligibilityHandler" }
+func (h *OrderCompletedHandler) Subdomain() string   { return "reviews" }
+func (h *OrderCompletedHandler) EventType() events.EventType {
+	return orderevents.OrderCompletedEventType
+}
+
+func (h *OrderCompletedHandler) Handle(ctx context.Context, event events.Event) error {
+	e, ok := event.(orderevents.OrderCompletedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	return h.txScope.Execute(ctx, func(ctx context.Context) error {
+		for _, item := range e.Items {
+			if err := h.purchases.Save(ctx, domain.Purchase{
+				UserID:      e.UserID,
+				ProductID:   item.ProductID,
+				OrderID:     e.OrderID,
+				CompletedAt: e.OccurredAt(),
+			}); err != nil {
+				return fmt.Errorf("saving purchase: %w", err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,49 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// ProductRatingProjector keeps the ProductRatings read model in sync with
+// moderation decisions. It runs pre-commit, so the aggregate is updated
+// atomically with the review.
+type ProductRatingProjector struct {
+	ratings domain.ProductRatingRepository
+	txScope transaction.Scope
+}
+
+func NewProductRatingProjector(ratings domain.ProductRatingRepository, txScope transaction.Scope) *ProductRatingProjector {
+	return &ProductRatingProjector{ratings: ratings, txScope: txScope}
+}
+
+func (h *ProductRatingProjector) HandlerName() string { return "ProductRatingProjector" }
+func (h *ProductRatingProjector) Subdomain() string   { return "reviews" }
+func (h *ProductRatingProjector) EventType() events.EventType {
+	return domain.ReviewModeratedEventType
+}
+
+func (h *ProductRatingProjector) Handle(ctx context.Context, event events.Event) error {
+	e, ok := event.(domain.ReviewModeratedEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+
+	return h.txScope.Execute(ctx, func(ctx context.Context) error {
+		rating, err := h.ratings.FindByProductID(ctx, e.ProductID)
+		if err != nil {
+			return fmt.Errorf("finding product rating: %w", err)
+		}
+
+		rating.Apply(e)
+
+		if err := h.ratings.Save(ctx, rating); err != nil {
+			return fmt.Errorf("saving product rating: %w", err)
+		}
+		return nil
+	})
+}
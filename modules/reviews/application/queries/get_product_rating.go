@@ -0,0 +1,40 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/domain"
+)
+
+// ProductRatingDTO summarizes the approved reviews of a product.
+type ProductRatingDTO struct {
+	ProductID     string  `json:"product_id"`
+	ReviewCount   int     `json:"review_count"`
+	AverageRating float64 `json:"average_rating"`
+}
+
+// GetProductRatingQuery retrieves the aggregate rating of a product.
+type GetProductRatingQuery struct {
+	ProductID string
+}
+
+type GetProductRatingHandler struct {
+	repo domain.ProductRatingRepository
+}
+
+func NewGetProductRatingHandler(repo domain.ProductRatingRepository) *GetProductRatingHandler {
+	return &GetProductRatingHandler{repo: repo}
+}
+
+func (h *GetProductRatingHandler) Handle(ctx context.Context, query GetProductRatingQuery) (*ProductRatingDTO, error) {
+	rating, err := h.repo.FindByProductID(ctx, query.ProductID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProductRatingDTO{
+		ProductID:     query.ProductID,
+		ReviewCount:   rating.ReviewCount,
+		AverageRating: rating.Average(),
+	}, nil
+}
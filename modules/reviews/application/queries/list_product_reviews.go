@@ -0,0 +1,86 @@
+// Package queries contains read-side handlers for the reviews module.
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+)
+
+// ReviewDTO is a read model for a review.
+type ReviewDTO struct {
+	ID             string    `json:"id"`
+	ProductID      string    `json:"product_id"`
+	UserID         string    `json:"user_id"`
+	Rating         int       `json:"rating"`
+	Title          string    `json:"title,omitempty"`
+	Body           string    `json:"body,omitempty"`
+	Status         string    `json:"status"`
+	ModerationNote string    `json:"moderation_note,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// ReviewListDTO contains a paginated list of reviews.
+type ReviewListDTO struct {
+	Reviews    []ReviewDTO `json:"reviews"`
+	TotalCount int         `json:"total_count"`
+	Offset     int         `json:"offset"`
+	Limit      int         `json:"limit"`
+}
+
+// ListProductReviewsQuery retrieves the approved reviews of a product.
+type ListProductReviewsQuery struct {
+	ProductID string
+	Offset    int
+	Limit     int
+}
+
+type ListProductReviewsHandler struct {
+	repo domain.ReviewRepository
+}
+
+func NewListProductReviewsHandler(repo domain.ReviewRepository) *ListProductReviewsHandler {
+	return &ListProductReviewsHandler{repo: repo}
+}
+
+func (h *ListProductReviewsHandler) Handle(ctx context.Context, query ListProductReviewsQuery) (*ReviewListDTO, error) {
+	p := page.NewRequest(query.Offset, query.Limit)
+
+	reviews, total, err := h.repo.FindByProduct(ctx, query.ProductID, domain.ModerationApproved, p.Offset, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toReviewListDTO(reviews, total, p.Offset, p.Limit), nil
+}
+
+func toReviewListDTO(reviews []*domain.Review, total, offset, limit int) *ReviewListDTO {
+	dtos := make([]ReviewDTO, len(reviews))
+	for i, r := range reviews {
+		dtos[i] = toReviewDTO(r)
+	}
+	return &ReviewListDTO{
+		Reviews:    dtos,
+		TotalCount: total,
+		Offset:     offset,
+		Limit:      limit,
+	}
+}
+
+func toReviewDTO(r *domain.Review) ReviewDTO {
+	return ReviewDTO{
+		ID:             r.ID().String(),
+		ProductID:      r.ProductID(),
+		UserID:         r.UserID(),
+		Rating:         r.Rating(),
+		Title:          r.Title(),
+		Body:           r.Body(),
+		Status:         r.Status().String(),
+		ModerationNote: r.ModerationNote(),
+		CreatedAt:      r.CreatedAt(),
+		UpdatedAt:      r.UpdatedAt(),
+	}
+}
@@ -0,0 +1,43 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+)
+
+// ListReviewsForModerationQuery retrieves reviews by moderation status,
+// defaulting to the pending queue.
+type ListReviewsForModerationQuery struct {
+	Status string
+	Offset int
+	Limit  int
+}
+
+type ListReviewsForModerationHandler struct {
+	repo domain.ReviewRepository
+}
+
+func NewListReviewsForModerationHandler(repo domain.ReviewRepository) *ListReviewsForModerationHandler {
+	return &ListReviewsForModerationHandler{repo: repo}
+}
+
+func (h *ListReviewsForModerationHandler) Handle(ctx context.Context, query ListReviewsForModerationQuery) (*ReviewListDTO, error) {
+	status := domain.ModerationStatus(query.Status)
+	switch status {
+	case "":
+		status = domain.ModerationPending
+	case domain.ModerationPending, domain.ModerationApproved, domain.ModerationRejected:
+	default:
+		return nil, domain.ErrInvalidStatusFilter
+	}
+	p := page.NewRequest(query.Offset, query.Limit)
+
+	reviews, total, err := h.repo.FindByStatus(ctx, status, p.Offset, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return toReviewListDTO(reviews, total, p.Offset, p.Limit), nil
+}
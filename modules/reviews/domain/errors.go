@@ -0,0 +1,21 @@
+// Package domain contains the product review model.
+package domain
+
+import "errors"
+
+// Domain errors - business rule violations.
+var (
+	// Review errors
+	ErrReviewNotFound        = errors.New("review not found")
+	ErrInvalidReviewID       = errors.New("invalid review ID format")
+	ErrInvalidUserID         = errors.New("invalid user ID format")
+	ErrProductIDRequired     = errors.New("product ID is required")
+	ErrInvalidRating         = errors.New("rating must be between 1 and 5")
+	ErrTitleTooLong          = errors.New("review title is too long")
+	ErrBodyTooLong           = errors.New("review body is too long")
+	ErrProductNotPurchased   = errors.New("product has not been purchased in a completed order")
+	ErrAlreadyReviewed       = errors.New("product has already been reviewed by this user")
+	ErrInvalidModeration     = errors.New("moderation status must be approved or rejected")
+	ErrModerationNoteTooLong = errors.New("moderation note is too long")
+	ErrInvalidStatusFilter   = errors.New("status must be pending, approved or rejected")
+)
@@ -0,0 +1,29 @@
+package domain
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+// Internal event types (not used cross-module)
+const (
+	ReviewModeratedEventType events.EventType = "reviews.ReviewModerated"
+)
+
+// ReviewModeratedEvent is published when a review's moderation status changes.
+type ReviewModeratedEvent struct {
+	events.BaseEvent
+	ReviewID  string           `json:"review_id"`
+	ProductID string           `json:"product_id"`
+	Rating    int              `json:"rating"`
+	From      ModerationStatus `json:"from"`
+	To        ModerationStatus `json:"to"`
+}
+
+func NewReviewModeratedEvent(r *Review, from ModerationStatus) ReviewModeratedEvent {
+	return ReviewModeratedEvent{
+		BaseEvent: events.NewBaseEvent(ReviewModeratedEventType),
+		ReviewID:  r.ID().String(),
+		ProductID: r.ProductID(),
+		Rating:    r.Rating(),
+		From:      from,
+		To:        r.Status(),
+	}
+}
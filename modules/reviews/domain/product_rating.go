@@ -0,0 +1,32 @@
+package domain
+
+import "time"
+
+// ProductRating is the read model aggregating the approved reviews of a product.
+type ProductRating struct {
+	ProductID   string
+	ReviewCount int
+	RatingSum   int
+	UpdatedAt   time.Time
+}
+
+// Average returns the mean approved rating, or 0 if there are no approved reviews.
+func (p ProductRating) Average() float64 {
+	if p.ReviewCount == 0 {
+		return 0
+	}
+	return float64(p.RatingSum) / float64(p.ReviewCount)
+}
+
+// Apply updates the aggregate for a review moving between moderation statuses.
+func (p *ProductRating) Apply(e ReviewModeratedEvent) {
+	if e.From == ModerationApproved {
+		p.ReviewCount--
+		p.RatingSum -= e.Rating
+	}
+	if e.To == ModerationApproved {
+		p.ReviewCount++
+		p.RatingSum += e.Rating
+	}
+	p.UpdatedAt = e.OccurredAt()
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// Purchase records that a user received a product in a completed order,
+// which makes the user eligible to review it. It is a local copy of
+// orders.OrderCompleted so that eligibility checks need no cross-module call.
+type Purchase struct {
+	UserID      string
+	ProductID   string
+	OrderID     string
+	CompletedAt time.Time
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "context"
+
+type ReviewRepository interface {
+	Save(ctx context.Context, r *Review) error
+	// FindByID returns ErrReviewNotFound if no review exists with the ID.
+	FindByID(ctx context.Context, id ReviewID) (*Review, error)
+	// ExistsForUser reports whether the user has already reviewed the product.
+	ExistsForUser(ctx context.Context, productID, userID string) (bool, error)
+	// FindByProduct returns a product's reviews with the given status, newest first,
+	// and the total number of matches.
+	FindByProduct(ctx context.Context, productID string, status ModerationStatus, offset, limit int) ([]*Review, int, error)
+	// FindByStatus returns reviews with the given status, oldest first, for moderation queues.
+	FindByStatus(ctx context.Context, status ModerationStatus, offset, limit int) ([]*Review, int, error)
+}
+
+type PurchaseRepository interface {
+	// Save records a purchase; saving the same user and product again keeps the latest order.
+	Save(ctx context.Context, p Purchase) error
+	Exists(ctx context.Context, userID, productID string) (bool, error)
+}
+
+type ProductRatingRepository interface {
+	Save(ctx context.Context, r ProductRating) error
+	// FindByProductID returns a zero rating for products without approved reviews.
+	FindByProductID(ctx context.Context, productID string) (ProductRating, error)
+}
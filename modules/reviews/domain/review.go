@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+
+	maxTitleLength          = 120
+	maxBodyLength           = 5000
+	maxModerationNoteLength = 500
+)
+
+// ReviewID identifies a review.
+type ReviewID struct {
+	value string
+}
+
+func NewReviewID() ReviewID {
+	return ReviewID{value: uuid.New().String()}
+}
+
+func ParseReviewID(s string) (ReviewID, error) {
+	if _, err := uuid.Parse(s); err != nil {
+		return ReviewID{}, ErrInvalidReviewID
+	}
+	return ReviewID{value: s}, nil
+}
+
+func (id ReviewID) String() string { return id.value }
+func (id ReviewID) IsZero() bool   { return id.value == "" }
+
+// ModerationStatus controls whether a review is shown publicly.
+type ModerationStatus string
+
+const (
+	ModerationPending  ModerationStatus = "pending"
+	ModerationApproved ModerationStatus = "approved"
+	ModerationRejected ModerationStatus = "rejected"
+)
+
+func (s ModerationStatus) String() string { return string(s) }
+
+// ParseModerationStatus accepts the statuses a moderator may set.
+func ParseModerationStatus(s string) (ModerationStatus, error) {
+	switch status := ModerationStatus(s); status {
+	case ModerationApproved, ModerationRejected:
+		return status, nil
+	default:
+		return "", ErrInvalidModeration
+	}
+}
+
+// Review is a user's rating of a product they bought. Reviews start pending
+// and only approved reviews are shown and counted in the product rating.
+type Review struct {
+	id             ReviewID
+	productID      string
+	userID         string
+	rating         int
+	title          string
+	body           string
+	status         ModerationStatus
+	moderationNote string
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+// NewReview creates a pending review. Eligibility (a completed purchase)
+// is checked by the caller.
+func NewReview(productID, userID string, rating int, title, body string) (*Review, error) {
+	if productID == "" {
+		return nil, ErrProductIDRequired
+	}
+	if _, err := uuid.Parse(userID); err != nil {
+		return nil, ErrInvalidUserID
+	}
+	if rating < MinRating || rating > MaxRating {
+		return nil, ErrInvalidRating
+	}
+	if utf8.RuneCountInString(title) > maxTitleLength {
+		return nil, ErrTitleTooLong
+	}
+	if utf8.RuneCountInString(body) > maxBodyLength {
+		return nil, ErrBodyTooLong
+	}
+
+	now := time.Now().UTC()
+	return &Review{
+		id:        NewReviewID(),
+		productID: productID,
+		userID:    userID,
+		rating:    rating,
+		title:     title,
+		body:      body,
+		status:    ModerationPending,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// ReconstituteReview rebuilds a Review from persistence without validation.
+func ReconstituteReview(
+	id ReviewID,
+	productID string,
+	userID string,
+	rating int,
+	title string,
+	body string,
+	status ModerationStatus,
+	moderationNote string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Review {
+	return &Review{
+		id:             id,
+		productID:      productID,
+		userID:         userID,
+		rating:         rating,
+		title:          title,
+		body:           body,
+		status:         status,
+		moderationNote: moderationNote,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+	}
+}
+
+func (r *Review) ID() ReviewID             { return r.id }
+func (r *Review) ProductID() string        { return r.productID }
+func (r *Review) UserID() string           { return r.userID }
+func (r *Review) Rating() int              { return r.rating }
+func (r *Review) Title() string            { return r.title }
+func (r *Review) Body() string             { return r.body }
+func (r *Review) Status() ModerationStatus { return r.status }
+func (r *Review) ModerationNote() string   { return r.moderationNote }
+func (r *Review) CreatedAt() time.Time     { return r.createdAt }
+func (r *Review) UpdatedAt() time.Time     { return r.updatedAt }
+
+// Moderate approves or rejects the review, recording an optional note.
+// Re-applying the current status only updates the note.
+// Adds ReviewModeratedEvent to the context for later dispatch when the status changes.
+func (r *Review) Moderate(ctx context.Context, status ModerationStatus, note string) error {
+	if status != ModerationApproved && status != ModerationRejected {
+		return ErrInvalidModeration
+	}
+	if utf8.RuneCountInString(note) > maxModerationNoteLength {
+		return ErrModerationNoteTooLong
+	}
+
+	from := r.status
+	r.status = status
+	r.moderationNote = note
+	r.updatedAt = time.Now().UTC()
+	if from != status {
+		events.Add(ctx, NewReviewModeratedEvent(r, from))
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package domain_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+func newReview(t *testing.T, rating int) *domain.Review {
+	t.Helper()
+	r, err := domain.NewReview("p-1", uuid.New().String(), rating, "Great", "Works well")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return r
+}
+
+func moderate(t *testing.T, r *domain.Review, status domain.ModerationStatus) []events.Event {
+	t.Helper()
+	captured, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		return r.Moderate(ctx, status, "")
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return captured
+}
+
+func TestNewReview_Validation(t *testing.T) {
+	userID := uuid.New().String()
+	tests := []struct {
+		name    string
+		product string
+		user    string
+		rating  int
+		title   string
+		wantErr error
+	}{
+		{"missing product", "", userID, 5, "", domain.ErrProductIDRequired},
+		{"invalid user", "p-1", "nope", 5, "", domain.ErrInvalidUserID},
+		{"rating too low", "p-1", userID, 0, "", domain.ErrInvalidRating},
+		{"rating too high", "p-1", userID, 6, "", domain.ErrInvalidRating},
+		{"title too long", "p-1", userID, 3, strings.Repeat("a", 121), domain.ErrTitleTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.NewReview(tt.product, tt.user, tt.rating, tt.title, "")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewReview_StartsPending(t *testing.T) {
+	if r := newReview(t, 4); r.Status() != domain.ModerationPending {
+		t.Errorf("expected pending, got %q", r.Status())
+	}
+}
+
+func TestReview_Moderate_RejectsPendingTarget(t *testing.T) {
+	r := newReview(t, 4)
+	_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		return r.Moderate(ctx, domain.ModerationPending, "")
+	})
+	if !errors.Is(err, domain.ErrInvalidModeration) {
+		t.Errorf("expected ErrInvalidModeration, got %v", err)
+	}
+}
+
+func TestProductRating_TracksApprovedReviews(t *testing.T) {
+	a, b := newReview(t, 5), newReview(t, 2)
+	rating := domain.ProductRating{ProductID: "p-1"}
+	apply := func(evts []events.Event) {
+		for _, evt := range evts {
+			rating.Apply(evt.(domain.ReviewModeratedEvent))
+		}
+	}
+
+	apply(moderate(t, a, domain.ModerationApproved))
+	apply(moderate(t, b, domain.ModerationApproved))
+	if rating.ReviewCount != 2 || rating.Average() != 3.5 {
+		t.Fatalf("expected 2 reviews averaging 3.5, got %d averaging %v", rating.ReviewCount, rating.Average())
+	}
+
+	// Re-approving is a no-op; rejecting an approved review removes it.
+	apply(moderate(t, a, domain.ModerationApproved))
+	apply(moderate(t, a, domain.ModerationRejected))
+	if rating.ReviewCount != 1 || rating.Average() != 2 {
+		t.Errorf("expected 1 review averaging 2, got %d averaging %v", rating.ReviewCount, rating.Average())
+	}
+
+	apply(moderate(t, b, domain.ModerationRejected))
+	if rating.ReviewCount != 0 || rating.Average() != 0 {
+		t.Errorf("expected no reviews, got %d averaging %v", rating.ReviewCount, rating.Average())
+	}
+}
@@ -0,0 +1,191 @@
+// Package http provides HTTP handlers for the reviews module.
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+type Handler struct {
+	submit         command.Handler[commands.SubmitReviewCommand, string]
+	moderate       command.VoidHandler[commands.ModerateReviewCommand]
+	listProduct    *queries.ListProductReviewsHandler
+	listModeration *queries.ListReviewsForModerationHandler
+	getRating      *queries.GetProductRatingHandler
+	admin          security.AdminGuard
+}
+
+// RegisterRoutes registers the reviews module routes to the given mux.
+func RegisterRoutes(
+	mux *http.ServeMux,
+	submit command.Handler[commands.SubmitReviewCommand, string],
+	moderate command.VoidHandler[commands.ModerateReviewCommand],
+	listProduct *queries.ListProductReviewsHandler,
+	listModeration *queries.ListReviewsForModerationHandler,
+	getRating *queries.GetProductRatingHandler,
+	admin security.AdminGuard,
+) {
+	h := &Handler{
+		submit:         submit,
+		moderate:       moderate,
+		listProduct:    listProduct,
+		listModeration: listModeration,
+		getRating:      getRating,
+		admin:          admin,
+	}
+
+	mux.HandleFunc("POST /products/{id}/reviews", h.handleSubmitReview)
+	mux.HandleFunc("GET /products/{id}/reviews", h.handleListProductReviews)
+	mux.HandleFunc("GET /products/{id}/rating", h.handleGetProductRating)
+	mux.HandleFunc("GET /reviews", h.admin.HandlerFunc(h.handleListReviewsForModeration))
+	mux.HandleFunc("PUT /reviews/{id}/moderation", h.admin.HandlerFunc(h.handleModerateReview))
+}
+
+type submitReviewRequest struct {
+	UserID string `json:"user_id"`
+	Rating int    `json:"rating"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+}
+
+type submitReviewResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type moderateReviewRequest struct {
+	Status string `json:"status"`
+	Note   string `json:"note"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) handleSubmitReview(w http.ResponseWriter, r *http.Request) {
+	var req submitReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	id, err := h.submit.Handle(r.Context(), commands.SubmitReviewCommand{
+		ProductID: r.PathValue("id"),
+		UserID:    req.UserID,
+		Rating:    req.Rating,
+		Title:     req.Title,
+		Body:      req.Body,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrProductNotPurchased) {
+			h.admin.Deny(r, "product_not_purchased")
+		}
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, submitReviewResponse{ID: id, Status: domain.ModerationPending.String()})
+}
+
+func (h *Handler) handleListProductReviews(w http.ResponseWriter, r *http.Request) {
+	p := page.ParseRequest(r.URL.Query())
+
+	result, err := h.listProduct.Handle(r.Context(), queries.ListProductReviewsQuery{
+		ProductID: r.PathValue("id"),
+		Offset:    p.Offset,
+		Limit:     p.Limit,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) handleGetProductRating(w http.ResponseWriter, r *http.Request) {
+	result, err := h.getRating.Handle(r.Context(), queries.GetProductRatingQuery{ProductID: r.PathValue("id")})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) handleListReviewsForModeration(w http.ResponseWriter, r *http.Request) {
+	p := page.ParseRequest(r.URL.Query())
+
+	result, err := h.listModeration.Handle(r.Context(), queries.ListReviewsForModerationQuery{
+		Status: r.URL.Query().Get("status"),
+		Offset: p.Offset,
+		Limit:  p.Limit,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) handleModerateReview(w http.ResponseWriter, r *http.Request) {
+	var req moderateReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	err := h.moderate.Handle(r.Context(), commands.ModerateReviewCommand{
+		ReviewID: r.PathValue("id"),
+		Status:   req.Status,
+		Note:     req.Note,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrReviewNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrProductNotPurchased):
+		writeError(w, http.StatusForbidden, err.Error())
+	case errors.Is(err, domain.ErrAlreadyReviewed):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrInvalidReviewID),
+		errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrProductIDRequired),
+		errors.Is(err, domain.ErrInvalidRating),
+		errors.Is(err, domain.ErrTitleTooLong),
+		errors.Is(err, domain.ErrBodyTooLong),
+		errors.Is(err, domain.ErrInvalidModeration),
+		errors.Is(err, domain.ErrModerationNoteTooLong),
+		errors.Is(err, domain.ErrInvalidStatusFilter):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
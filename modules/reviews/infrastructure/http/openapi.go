@@ -0,0 +1,24 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+)
+
+var pageParams = []openapi.Param{
+	{Name: "offset", Type: "integer"},
+	{Name: "limit", Type: "integer"},
+}
+
+// Operations documents the routes registered by RegisterRoutes.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Pattern: "POST /products/{id}/reviews", Summary: "Review a product", Description: "The review is published once approved by a moderator.", Request: submitReviewRequest{}, Status: http.StatusCreated, Response: submitReviewResponse{}},
+		{Pattern: "GET /products/{id}/reviews", Summary: "List the approved reviews of a product", Query: pageParams, Response: queries.ReviewListDTO{}},
+		{Pattern: "GET /products/{id}/rating", Summary: "Get the average rating of a product", Response: queries.ProductRatingDTO{}},
+		{Pattern: "GET /reviews", Summary: "List reviews for moderation", Admin: true, Query: append([]openapi.Param{{Name: "status", Description: "Moderation status"}}, pageParams...), Response: queries.ReviewListDTO{}},
+		{Pattern: "PUT /reviews/{id}/moderation", Summary: "Approve or reject a review", Admin: true, Request: moderateReviewRequest{}, Status: http.StatusNoContent},
+	}
+}
@@ -0,0 +1,62 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+type SpannerProductRatingRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerProductRatingRepository(client *spanner.Client, logger *slog.Logger) *SpannerProductRatingRepository {
+	return &SpannerProductRatingRepository{client: client, logger: logger}
+}
+
+func (r *SpannerProductRatingRepository) Save(ctx context.Context, rating domain.ProductRating) error {
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO ProductRatings (TenantID, ProductID, ReviewCount, RatingSum, UpdatedAt)
+		      VALUES (@tenantID, @productID, @reviewCount, @ratingSum, @updatedAt)`,
+		Params: map[string]interface{}{
+			"tenantID":    requestcontext.TenantID(ctx),
+			"productID":   rating.ProductID,
+			"reviewCount": int64(rating.ReviewCount),
+			"ratingSum":   int64(rating.RatingSum),
+			"updatedAt":   rating.UpdatedAt,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save product rating: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerProductRatingRepository) FindByProductID(ctx context.Context, productID string) (domain.ProductRating, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (domain.ProductRating, error) {
+		rating := domain.ProductRating{ProductID: productID}
+
+		row, err := reader.ReadRow(ctx, "ProductRatings", spanner.Key{requestcontext.TenantID(ctx), productID}, []string{"ReviewCount", "RatingSum", "UpdatedAt"})
+		if spanner.ErrCode(err) == codes.NotFound {
+			return rating, nil
+		}
+		if err != nil {
+			return rating, fmt.Errorf("failed to read product rating: %w", err)
+		}
+
+		var count, sum int64
+		if err := row.Columns(&count, &sum, &rating.UpdatedAt); err != nil {
+			return rating, fmt.Errorf("failed to scan product rating: %w", err)
+		}
+		rating.ReviewCount = int(count)
+		rating.RatingSum = int(sum)
+		return rating, nil
+	})
+}
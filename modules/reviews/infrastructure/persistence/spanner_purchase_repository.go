@@ -0,0 +1,53 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/grpc/codes"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+type SpannerPurchaseRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerPurchaseRepository(client *spanner.Client, logger *slog.Logger) *SpannerPurchaseRepository {
+	return &SpannerPurchaseRepository{client: client, logger: logger}
+}
+
+func (r *SpannerPurchaseRepository) Save(ctx context.Context, p domain.Purchase) error {
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO ReviewablePurchases (TenantID, UserID, ProductID, OrderID, CompletedAt)
+		      VALUES (@tenantID, @userID, @productID, @orderID, @completedAt)`,
+		Params: map[string]interface{}{
+			"tenantID":    requestcontext.TenantID(ctx),
+			"userID":      p.UserID,
+			"productID":   p.ProductID,
+			"orderID":     p.OrderID,
+			"completedAt": p.CompletedAt,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save purchase: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerPurchaseRepository) Exists(ctx context.Context, userID, productID string) (bool, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (bool, error) {
+		_, err := reader.ReadRow(ctx, "ReviewablePurchases", spanner.Key{requestcontext.TenantID(ctx), userID, productID}, []string{"OrderID"})
+		if spanner.ErrCode(err) == codes.NotFound {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to read purchase: %w", err)
+		}
+		return true, nil
+	})
+}
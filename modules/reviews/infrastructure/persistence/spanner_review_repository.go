@@ -0,0 +1,200 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+const reviewColumns = `ReviewID, ProductID, UserID, Rating, Title, Body, Status, ModerationNote, CreatedAt, UpdatedAt`
+
+// SpannerReviewRepository implements ReviewRepository using Cloud Spanner.
+// Reviews carry the tenant they were written in and every query is scoped to
+// the tenant of its context.
+type SpannerReviewRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerReviewRepository(client *spanner.Client, logger *slog.Logger) *SpannerReviewRepository {
+	return &SpannerReviewRepository{client: client, logger: logger}
+}
+
+func (r *SpannerReviewRepository) Save(ctx context.Context, review *domain.Review) error {
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO Reviews (TenantID, ` + reviewColumns + `)
+		      VALUES (@tenantID, @reviewID, @productID, @userID, @rating, @title, @body, @status, @moderationNote, @createdAt, @updatedAt)`,
+		Params: map[string]interface{}{
+			"tenantID":       requestcontext.TenantID(ctx),
+			"reviewID":       review.ID().String(),
+			"productID":      review.ProductID(),
+			"userID":         review.UserID(),
+			"rating":         int64(review.Rating()),
+			"title":          review.Title(),
+			"body":           review.Body(),
+			"status":         review.Status().String(),
+			"moderationNote": spanner.NullString{StringVal: review.ModerationNote(), Valid: review.ModerationNote() != ""},
+			"createdAt":      review.CreatedAt(),
+			"updatedAt":      review.UpdatedAt(),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save review: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerReviewRepository) FindByID(ctx context.Context, id domain.ReviewID) (*domain.Review, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (*domain.Review, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT ` + reviewColumns + ` FROM Reviews WHERE ReviewID = @reviewID AND TenantID = @tenantID`,
+			Params: map[string]interface{}{"reviewID": id.String(), "tenantID": requestcontext.TenantID(ctx)},
+		})
+		defer iter.Stop()
+
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return nil, domain.ErrReviewNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query review: %w", err)
+		}
+		return scanReview(row)
+	})
+}
+
+func (r *SpannerReviewRepository) ExistsForUser(ctx context.Context, productID, userID string) (bool, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (bool, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT 1 FROM Reviews@{FORCE_INDEX=ReviewsByProductUser}
+			      WHERE TenantID = @tenantID AND ProductID = @productID AND UserID = @userID
+			      LIMIT 1`,
+			Params: map[string]interface{}{
+				"tenantID":  requestcontext.TenantID(ctx),
+				"productID": productID,
+				"userID":    userID,
+			},
+		})
+		defer iter.Stop()
+
+		_, err := iter.Next()
+		if err == iterator.Done {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to query review: %w", err)
+		}
+		return true, nil
+	})
+}
+
+func (r *SpannerReviewRepository) FindByProduct(ctx context.Context, productID string, status domain.ModerationStatus, offset, limit int) ([]*domain.Review, int, error) {
+	return r.findPage(ctx,
+		`FROM Reviews@{FORCE_INDEX=ReviewsByProductStatusCreatedAt} WHERE TenantID = @tenantID AND ProductID = @productID AND Status = @status`,
+		`ORDER BY CreatedAt DESC`,
+		map[string]interface{}{"productID": productID, "status": status.String()},
+		offset, limit,
+	)
+}
+
+func (r *SpannerReviewRepository) FindByStatus(ctx context.Context, status domain.ModerationStatus, offset, limit int) ([]*domain.Review, int, error) {
+	return r.findPage(ctx,
+		`FROM Reviews@{FORCE_INDEX=ReviewsByStatusCreatedAt} WHERE TenantID = @tenantID AND Status = @status`,
+		`ORDER BY CreatedAt`,
+		map[string]interface{}{"status": status.String()},
+		offset, limit,
+	)
+}
+
+// findPage counts and reads one page of reviews matching from (a FROM ... WHERE clause,
+// which filters on @tenantID) in a single consistent snapshot.
+func (r *SpannerReviewRepository) findPage(ctx context.Context, from, orderBy string, params map[string]interface{}, offset, limit int) ([]*domain.Review, int, error) {
+	params["tenantID"] = requestcontext.TenantID(ctx)
+	var total int
+	reviews, err := platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.Review, error) {
+		countIter := reader.Query(ctx, spanner.Statement{SQL: `SELECT COUNT(*) ` + from, Params: params})
+		defer countIter.Stop()
+
+		var totalCount int64
+		countRow, err := countIter.Next()
+		if err != nil && err != iterator.Done {
+			return nil, fmt.Errorf("failed to count reviews: %w", err)
+		}
+		if countRow != nil {
+			if err := countRow.Columns(&totalCount); err != nil {
+				return nil, fmt.Errorf("failed to scan count: %w", err)
+			}
+		}
+		total = int(totalCount)
+
+		pageParams := map[string]interface{}{
+			"limit":  int64(limit),
+			"offset": int64(offset),
+		}
+		for k, v := range params {
+			pageParams[k] = v
+		}
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT ` + reviewColumns + ` ` + from + ` ` + orderBy + ` LIMIT @limit OFFSET @offset`,
+			Params: pageParams,
+		})
+		defer iter.Stop()
+
+		var reviews []*domain.Review
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query reviews: %w", err)
+			}
+			review, err := scanReview(row)
+			if err != nil {
+				return nil, err
+			}
+			reviews = append(reviews, review)
+		}
+		return reviews, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return reviews, total, nil
+}
+
+func scanReview(row *spanner.Row) (*domain.Review, error) {
+	var reviewID, productID, userID, title, body, status string
+	var rating int64
+	var moderationNote spanner.NullString
+	var createdAt, updatedAt time.Time
+
+	if err := row.Columns(&reviewID, &productID, &userID, &rating, &title, &body, &status, &moderationNote, &createdAt, &updatedAt); err != nil {
+		return nil, fmt.Errorf("failed to scan review: %w", err)
+	}
+
+	id, err := domain.ParseReviewID(reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid review ID in database: %w", err)
+	}
+
+	return domain.ReconstituteReview(
+		id,
+		productID,
+		userID,
+		int(rating),
+		title,
+		body,
+		domain.ModerationStatus(status),
+		moderationNote.StringVal,
+		createdAt,
+		updatedAt,
+	), nil
+}
@@ -0,0 +1,98 @@
+// Package reviews lets users rate the products of their completed orders,
+// moderates the reviews and aggregates approved ratings per product.
+package reviews
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/application/eventhandlers"
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/reviews/domain"
+	httphandler "github.com/rai/clean-modularmonolith-go/modules/reviews/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// Module is the public API for the reviews bounded context.
+// External communication: HTTP API (RegisterRoutes)
+// Cross-module communication: Domain Events — consumes orders.OrderCompleted
+// to learn which users may review which products.
+type Module interface {
+	// RegisterRoutes registers the module's HTTP routes to the given mux.
+	RegisterRoutes(mux *http.ServeMux)
+	// Operations documents the routes for the OpenAPI specification.
+	Operations() []openapi.Operation
+}
+
+type Config struct {
+	ReviewRepository        domain.ReviewRepository
+	PurchaseRepository      domain.PurchaseRepository
+	ProductRatingRepository domain.ProductRatingRepository
+	TransactionScope        transaction.Scope
+	Publisher               events.Publisher
+	PostCommitPublisher     events.PostCommitPublisher
+	Subscriber              events.Subscriber
+	PostCommitSubscriber    events.PostCommitSubscriber
+	Logger                  *slog.Logger
+
+	// AdminToken guards the moderation endpoints via the X-Admin-Token
+	// header. The endpoints are disabled when empty.
+	AdminToken string
+
+	// SecuritySink receives the admin token and authorization decisions
+	// made by the HTTP handlers. Optional: nil records nothing.
+	SecuritySink security.Sink
+
+	// CommandBus, when set, applies the platform's middleware (tracing,
+	// recording for the audit log and metrics, validation) to every command.
+	CommandBus *command.Bus
+}
+
+type module struct {
+	submit         command.Handler[commands.SubmitReviewCommand, string]
+	moderate       command.VoidHandler[commands.ModerateReviewCommand]
+	listProduct    *queries.ListProductReviewsHandler
+	listModeration *queries.ListReviewsForModerationHandler
+	getRating      *queries.GetProductRatingHandler
+	admin          security.AdminGuard
+}
+
+// New initializes the reviews module and subscribes to events.
+func New(cfg Config) Module {
+	logger := cfg.Logger.With("module", "reviews")
+	txScope := events.NewScopeWithDomainEvent(cfg.TransactionScope, cfg.Publisher, cfg.PostCommitPublisher)
+
+	// Pre-commit: keep the rating aggregate consistent with moderation decisions
+	projector := eventhandlers.NewProductRatingProjector(cfg.ProductRatingRepository, cfg.TransactionScope)
+	if err := cfg.Subscriber.Subscribe(projector.EventType(), projector); err != nil {
+		logger.Error("failed to subscribe product rating projector", slog.Any("error", err))
+	}
+
+	// Post-commit: record purchases from completed orders in our own transaction
+	orderCompleted := eventhandlers.NewOrderCompletedHandler(cfg.PurchaseRepository, cfg.TransactionScope)
+	if err := cfg.PostCommitSubscriber.SubscribePostCommit(orderCompleted.EventType(), orderCompleted); err != nil {
+		logger.Error("failed to subscribe to order completed event", slog.Any("error", err))
+	}
+
+	return &module{
+		submit:         command.Register[commands.SubmitReviewCommand, string](cfg.CommandBus, "reviews", commands.NewSubmitReviewHandler(cfg.ReviewRepository, cfg.PurchaseRepository, cfg.TransactionScope)),
+		moderate:       command.RegisterVoid[commands.ModerateReviewCommand](cfg.CommandBus, "reviews", commands.NewModerateReviewHandler(cfg.ReviewRepository, txScope)),
+		listProduct:    queries.NewListProductReviewsHandler(cfg.ReviewRepository),
+		listModeration: queries.NewListReviewsForModerationHandler(cfg.ReviewRepository),
+		getRating:      queries.NewGetProductRatingHandler(cfg.ProductRatingRepository),
+		admin:          security.AdminGuard{Module: "reviews", Token: cfg.AdminToken, Sink: cfg.SecuritySink},
+	}
+}
+
+func (m *module) RegisterRoutes(mux *http.ServeMux) {
+	httphandler.RegisterRoutes(mux, m.submit, m.moderate, m.listProduct, m.listModeration, m.getRating, m.admin)
+}
+
+func (m *module) Operations() []openapi.Operation {
+	return httphandler.Operations()
+}
@@ -0,0 +1,85 @@
+// Package cache is the port through which query handlers cache the read
+// models they return. Stores are implemented in internal/platform/cache.
+//
+// A module decorates a query handler with Cached in module.go and
+// subscribes an Invalidator to every event that changes what it returns,
+// both pre-commit and post-commit: pre-commit so that the writer's next read
+// misses the cache, post-commit to evict a read model that a concurrent
+// reader cached from the old state before the commit. The TTL bounds how
+// long a missed invalidation can serve stale data.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// Store stores values by key. Implementations must be safe for concurrent
+// use and treat their own failures as misses: a cache never fails a query.
+type Store interface {
+	// Get returns the value stored under key; ok is false on a miss.
+	Get(ctx context.Context, key string) (value []byte, ok bool)
+	// Set stores value under key for ttl.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration)
+	// Delete removes keys.
+	Delete(ctx context.Context, keys ...string)
+}
+
+// Cached decorates the query handler h: its results are stored as JSON
+// under key(ctx, query) for ttl, and served from store until they expire or are
+// invalidated. Errors are not cached. It returns h unchanged when store is
+// nil. A key must hold everything the result depends on besides the query,
+// such as the tenant of ctx, or one tenant is served another's read model.
+func Cached[Q, R any](store Store, ttl time.Duration, key func(ctx context.Context, query Q) string, h command.Handler[Q, R]) command.Handler[Q, R] {
+	if store == nil {
+		return h
+	}
+	return command.HandlerFunc[Q, R](func(ctx context.Context, query Q) (R, error) {
+		k := key(ctx, query)
+		if data, ok := store.Get(ctx, k); ok {
+			var cached R
+			if err := json.Unmarshal(data, &cached); err == nil {
+				return cached, nil
+			}
+		}
+
+		result, err := h.Handle(ctx, query)
+		if err != nil {
+			return result, err
+		}
+		if data, err := json.Marshal(result); err == nil {
+			store.Set(ctx, k, data, ttl)
+		}
+		return result, nil
+	})
+}
+
+// Invalidator is an event handler that deletes the cached read models an
+// event changes.
+type Invalidator struct {
+	module    string
+	eventType events.EventType
+	store     Store
+	keys      func(events.Event) []string
+}
+
+// NewInvalidator returns an Invalidator of module for eventType that
+// deletes keys(event) from store.
+func NewInvalidator(module string, eventType events.EventType, store Store, keys func(events.Event) []string) *Invalidator {
+	return &Invalidator{module: module, eventType: eventType, store: store, keys: keys}
+}
+
+func (h *Invalidator) HandlerName() string         { return "CacheInvalidator" }
+func (h *Invalidator) Subdomain() string           { return h.module }
+func (h *Invalidator) EventType() events.EventType { return h.eventType }
+
+func (h *Invalidator) Handle(ctx context.Context, event events.Event) error {
+	if keys := h.keys(event); len(keys) > 0 {
+		h.store.Delete(ctx, keys...)
+	}
+	return nil
+}
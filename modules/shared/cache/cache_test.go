@@ -0,0 +1,106 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// mapStore is a Store without expiry.
+type mapStore map[string][]byte
+
+func (s mapStore) Get(ctx context.Context, key string) ([]byte, bool) {
+	v, ok := s[key]
+	return v, ok
+}
+
+func (s mapStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	s[key] = value
+}
+
+func (s mapStore) Delete(ctx context.Context, keys ...string) {
+	for _, k := range keys {
+		delete(s, k)
+	}
+}
+
+type userDTO struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type getUser struct {
+	calls int
+	name  string
+	err   error
+}
+
+func (h *getUser) Handle(ctx context.Context, id string) (*userDTO, error) {
+	h.calls++
+	if h.err != nil {
+		return nil, h.err
+	}
+	return &userDTO{ID: id, Name: h.name}, nil
+}
+
+func userKey(ctx context.Context, id string) string { return "users:" + id }
+
+type userUpdated struct {
+	events.BaseEvent
+	UserID string
+}
+
+func TestCached_ServesFromStoreUntilInvalidated(t *testing.T) {
+	store := mapStore{}
+	inner := &getUser{name: "alice"}
+	h := Cached(store, time.Minute, userKey, command.Handler[string, *userDTO](inner))
+
+	for range 2 {
+		got, err := h.Handle(t.Context(), "u-1")
+		if err != nil || got.Name != "alice" {
+			t.Fatalf("Handle() = %+v, %v", got, err)
+		}
+	}
+	if inner.calls != 1 {
+		t.Errorf("inner handler called %d times, want 1", inner.calls)
+	}
+
+	inner.name = "bob"
+	invalidator := NewInvalidator("users", "users.UserUpdated", store, func(e events.Event) []string {
+		return []string{userKey(t.Context(), e.(userUpdated).UserID)}
+	})
+	if err := invalidator.Handle(t.Context(), userUpdated{UserID: "u-1"}); err != nil {
+		t.Fatalf("Invalidator.Handle() error = %v", err)
+	}
+
+	got, err := h.Handle(t.Context(), "u-1")
+	if err != nil || got.Name != "bob" || inner.calls != 2 {
+		t.Errorf("after invalidation Handle() = %+v, %v after %d calls; want bob after 2", got, err, inner.calls)
+	}
+}
+
+func TestCached_DoesNotCacheErrors(t *testing.T) {
+	store := mapStore{}
+	inner := &getUser{err: errors.New("not found")}
+	h := Cached(store, time.Minute, userKey, command.Handler[string, *userDTO](inner))
+
+	for range 2 {
+		if _, err := h.Handle(t.Context(), "u-1"); err == nil {
+			t.Fatal("Handle() error = nil, want the inner error")
+		}
+	}
+	if inner.calls != 2 || len(store) != 0 {
+		t.Errorf("inner handler called %d times with %d cached entries, want 2 and 0", inner.calls, len(store))
+	}
+}
+
+func TestCached_NilStoreReturnsHandler(t *testing.T) {
+	var h command.Handler[string, *userDTO] = &getUser{}
+	if Cached(nil, time.Minute, userKey, h) != h {
+		t.Error("Cached with a nil store should return the handler unchanged")
+	}
+}
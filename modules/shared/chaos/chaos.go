@@ -0,0 +1,193 @@
+// Package chaos injects faults — latency, errors, aborted transactions and
+// duplicate event deliveries — through decorators of the transaction scope
+// and of event handlers, to check that code copes with what production
+// infrastructure does to it. In particular, transaction bodies and the
+// pre-commit handlers they publish to must be idempotent because Spanner
+// re-runs them after an abort (see transaction.ScopeWithDomainEvent), and
+// post-commit handlers should tolerate being delivered an event twice.
+//
+// Tests set rates of 0 or 1 for deterministic behavior:
+//
+//	inj := chaos.New(chaos.Config{AbortRate: 1})
+//	scope := events.NewScopeWithDomainEvent(chaos.Scope(txScope, inj), bus, bus)
+//
+// In development the server enables it from the CHAOS_* environment
+// variables. Scope also puts the Injector in the context, where the Spanner
+// helpers used by every repository pick it up (Inject), so repositories get
+// faults without a decorator each.
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/fault"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// ErrInjected is the error injected by an Injector. It is classified as
+// transient, like the infrastructure failures it stands in for.
+var ErrInjected = errors.New("chaos: injected failure")
+
+// errAborted makes the inner scope roll back the first attempt of a
+// transaction that Scope aborts.
+var errAborted = errors.New("chaos: transaction aborted")
+
+// Config sets the faults to inject. Rates are probabilities in [0, 1];
+// zero values inject nothing.
+type Config struct {
+	Latency       time.Duration // upper bound of the random delay before each operation
+	ErrorRate     float64       // operations failing with ErrInjected
+	AbortRate     float64       // transactions run twice, the first attempt rolled back
+	DuplicateRate float64       // events delivered twice to a handler
+	Seed          uint64        // seeds the random source; zero picks a random seed
+}
+
+// Enabled reports whether c injects anything.
+func (c Config) Enabled() bool {
+	return c.Latency > 0 || c.ErrorRate > 0 || c.AbortRate > 0 || c.DuplicateRate > 0
+}
+
+// Injector decides which faults to inject. It is safe for concurrent use.
+// A nil *Injector injects nothing.
+type Injector struct {
+	cfg Config
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+// New returns an Injector for cfg.
+func New(cfg Config) *Injector {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+	return &Injector{cfg: cfg, rand: rand.New(rand.NewPCG(seed, seed))}
+}
+
+// Inject delays the operation op and may fail it with ErrInjected. It
+// returns ctx's error if ctx is done during the delay.
+func (in *Injector) Inject(ctx context.Context, op string) error {
+	if in == nil {
+		return nil
+	}
+	if delay := in.delay(); delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	if in.roll(in.cfg.ErrorRate) {
+		return fault.Wrap(fmt.Errorf("%s: %w", op, ErrInjected), fault.Transient)
+	}
+	return nil
+}
+
+func (in *Injector) delay() time.Duration {
+	if in.cfg.Latency <= 0 {
+		return 0
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return time.Duration(in.rand.Int64N(int64(in.cfg.Latency)))
+}
+
+// roll reports whether an event of probability p happens.
+func (in *Injector) roll(p float64) bool {
+	switch {
+	case in == nil || p <= 0:
+		return false
+	case p >= 1:
+		return true
+	}
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.rand.Float64() < p
+}
+
+type ctxKey struct{}
+
+// WithInjector returns a context carrying in.
+func WithInjector(ctx context.Context, in *Injector) context.Context {
+	return context.WithValue(ctx, ctxKey{}, in)
+}
+
+// Inject is Injector.Inject with the Injector carried by ctx, if any.
+func Inject(ctx context.Context, op string) error {
+	in, _ := ctx.Value(ctxKey{}).(*Injector)
+	return in.Inject(ctx, op)
+}
+
+// Scope decorates a transaction scope: each transaction may be delayed or
+// fail before it starts, or be aborted — fn runs once in a transaction that
+// is rolled back, then again in a new one, as Spanner does on an Aborted
+// error, with transaction.RetryInfo telling fn it is the second attempt.
+// Transactions joined by nested Execute calls are left alone, since
+// only the outermost one can be retried. The Injector is carried by the
+// context passed to fn.
+func Scope(inner transaction.Scope, in *Injector) transaction.Scope {
+	return &scope{inner: inner, in: in}
+}
+
+type scope struct {
+	inner transaction.Scope
+	in    *Injector
+}
+
+func (s *scope) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, nested := ctx.Value(ctxKey{}).(*Injector); nested {
+		return s.inner.Execute(ctx, fn)
+	}
+	if err := s.in.Inject(ctx, "transaction"); err != nil {
+		return err
+	}
+
+	ctx = WithInjector(ctx, s.in)
+	if s.in.roll(s.in.cfg.AbortRate) {
+		err := s.inner.Execute(ctx, func(ctx context.Context) error {
+			if err := fn(ctx); err != nil {
+				return err
+			}
+			return errAborted
+		})
+		if !errors.Is(err, errAborted) {
+			return err
+		}
+		return s.inner.Execute(ctx, func(ctx context.Context) error {
+			return fn(transaction.WithRetryInfo(ctx, transaction.RetryInfo{Attempt: 2}))
+		})
+	}
+	return s.inner.Execute(ctx, fn)
+}
+
+// Handler decorates an event handler: each delivery may be delayed or fail
+// before reaching h, or be made twice.
+func Handler(h events.Handler, in *Injector) events.Handler {
+	return &handler{Handler: h, in: in}
+}
+
+type handler struct {
+	events.Handler
+	in *Injector
+}
+
+func (h *handler) Handle(ctx context.Context, event events.Event) error {
+	if err := h.in.Inject(ctx, h.HandlerName()); err != nil {
+		return err
+	}
+	if h.in.roll(h.in.cfg.DuplicateRate) {
+		if err := h.Handler.Handle(ctx, event); err != nil {
+			return err
+		}
+	}
+	return h.Handler.Handle(ctx, event)
+}
@@ -0,0 +1,147 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	eventmocks "github.com/rai/clean-modularmonolith-go/modules/shared/events/mocks"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/fault"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"go.uber.org/mock/gomock"
+)
+
+// txScope is a transaction scope that counts the transactions rolled back.
+type txScope struct {
+	rolledBack int
+}
+
+func (s *txScope) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	if err != nil {
+		s.rolledBack++
+	}
+	return err
+}
+
+func TestInject(t *testing.T) {
+	t.Run("error", func(t *testing.T) {
+		err := New(Config{ErrorRate: 1}).Inject(t.Context(), "save order")
+		if !errors.Is(err, ErrInjected) || fault.KindOf(err) != fault.Transient {
+			t.Errorf("Inject() = %v (%v), want transient ErrInjected", err, fault.KindOf(err))
+		}
+	})
+	t.Run("none", func(t *testing.T) {
+		if err := New(Config{}).Inject(t.Context(), "save order"); err != nil {
+			t.Errorf("Inject() = %v, want nil", err)
+		}
+	})
+	t.Run("nil injector", func(t *testing.T) {
+		if err := Inject(t.Context(), "save order"); err != nil {
+			t.Errorf("Inject() = %v, want nil", err)
+		}
+	})
+	t.Run("latency honors context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(t.Context(), time.Millisecond)
+		defer cancel()
+		err := New(Config{Latency: time.Hour, Seed: 1}).Inject(ctx, "save order")
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Inject() = %v, want context.DeadlineExceeded", err)
+		}
+	})
+}
+
+func TestScope_Abort(t *testing.T) {
+	inner := &txScope{}
+	s := Scope(inner, New(Config{AbortRate: 1}))
+
+	runs, nestedRuns := 0, 0
+	var attempts []int
+	err := s.Execute(t.Context(), func(ctx context.Context) error {
+		runs++
+		attempts = append(attempts, transaction.RetryInfoFromContext(ctx).Attempt)
+		// Nested transactions join the outer one and are never aborted.
+		return s.Execute(ctx, func(ctx context.Context) error {
+			nestedRuns++
+			return nil
+		})
+	})
+
+	if err != nil {
+		t.Fatalf("Execute() = %v", err)
+	}
+	if runs != 2 || nestedRuns != 2 || inner.rolledBack != 1 {
+		t.Errorf("runs = %d, nested runs = %d, rolled back %d; want 2, 2, 1", runs, nestedRuns, inner.rolledBack)
+	}
+	if !slices.Equal(attempts, []int{1, 2}) {
+		t.Errorf("attempts = %v, want [1 2]", attempts)
+	}
+}
+
+func TestScope_AbortKeepsFnError(t *testing.T) {
+	errFn := errors.New("order not found")
+	s := Scope(&txScope{}, New(Config{AbortRate: 1}))
+
+	runs := 0
+	err := s.Execute(t.Context(), func(ctx context.Context) error {
+		runs++
+		return errFn
+	})
+
+	if !errors.Is(err, errFn) || runs != 1 {
+		t.Errorf("Execute() = %v after %d runs, want errFn after 1", err, runs)
+	}
+}
+
+// TestScope_AbortPublishesOnce checks that domain events collected by an
+// aborted attempt are dropped: post-commit handlers see one attempt's events.
+func TestScope_AbortPublishesOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	publisher := eventmocks.NewMockPublisher(ctrl)
+	publisher.EXPECT().Publish(gomock.Any(), gomock.Len(1)).Return(nil).Times(2)
+	postCommit := eventmocks.NewMockPostCommitPublisher(ctrl)
+	postCommit.EXPECT().PublishPostCommit(gomock.Any(), gomock.Len(1))
+
+	scope := events.NewScopeWithDomainEvent(Scope(&txScope{}, New(Config{AbortRate: 1})), publisher, postCommit)
+	err := scope.ExecuteWithPublish(t.Context(), func(ctx context.Context) error {
+		events.Add(ctx, eventmocks.NewMockEvent(ctrl))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExecuteWithPublish() = %v", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		cfg       Config
+		wantCalls int
+		wantErr   error
+	}{
+		{"passthrough", Config{}, 1, nil},
+		{"duplicate", Config{DuplicateRate: 1}, 2, nil},
+		{"error", Config{ErrorRate: 1}, 0, ErrInjected},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			inner := eventmocks.NewMockHandler(ctrl)
+			inner.EXPECT().HandlerName().Return("ReserveStockHandler").AnyTimes()
+			inner.EXPECT().Handle(gomock.Any(), gomock.Any()).Return(nil).Times(tt.wantCalls)
+
+			h := Handler(inner, New(tt.cfg))
+			err := h.Handle(t.Context(), eventmocks.NewMockEvent(ctrl))
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Handle() = %v, want %v", err, tt.wantErr)
+			}
+			if h.HandlerName() != "ReserveStockHandler" {
+				t.Errorf("HandlerName() = %q, want the decorated handler's", h.HandlerName())
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Package clock is the port through which modules read the current time.
+// Like the domain event collector, the clock travels in the context:
+// aggregates call Now(ctx), and each module installs the clock from its
+// config with WithClock or, for commands, Scope. Without one, Now falls back
+// to the system clock, so code that never configures a clock is unaffected.
+// clocktest provides a deterministic implementation for tests.
+package clock
+
+import (
+	"context"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// Clock tells the time.
+type Clock interface {
+	// Now returns the current time, in UTC.
+	Now() time.Time
+}
+
+// System is the wall clock.
+var System Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now().UTC() }
+
+type clockKey struct{}
+
+// WithClock returns a context whose Now reads c. A nil c leaves ctx as is.
+func WithClock(ctx context.Context, c Clock) context.Context {
+	if c == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, clockKey{}, c)
+}
+
+// FromContext returns the clock in ctx, or System if there is none.
+func FromContext(ctx context.Context) Clock {
+	if c, ok := ctx.Value(clockKey{}).(Clock); ok {
+		return c
+	}
+	return System
+}
+
+// Now returns the current time of the clock in ctx.
+func Now(ctx context.Context) time.Time {
+	return FromContext(ctx).Now()
+}
+
+// Scope wraps a ScopeWithDomainEvent so that the business logic it runs
+// reads c. It returns scope itself when c is nil.
+func Scope(scope transaction.ScopeWithDomainEvent, c Clock) transaction.ScopeWithDomainEvent {
+	if c == nil {
+		return scope
+	}
+	return clockScope{scope: scope, clock: c}
+}
+
+type clockScope struct {
+	scope transaction.ScopeWithDomainEvent
+	clock Clock
+}
+
+func (s clockScope) ExecuteWithPublish(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.scope.ExecuteWithPublish(WithClock(ctx, s.clock), fn)
+}
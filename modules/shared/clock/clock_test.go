@@ -0,0 +1,58 @@
+package clock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock/clocktest"
+)
+
+var epoch = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type runScope struct{}
+
+func (runScope) ExecuteWithPublish(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestNow_DefaultsToSystemClock(t *testing.T) {
+	before := time.Now()
+	got := clock.Now(context.Background())
+	if got.Before(before) || got.Location() != time.UTC {
+		t.Errorf("Now() = %v, want the current time in UTC", got)
+	}
+}
+
+func TestNow_ReadsClockInContext(t *testing.T) {
+	ctx, clk := clocktest.Context(context.Background(), epoch)
+
+	if got := clock.Now(ctx); !got.Equal(epoch) {
+		t.Errorf("Now() = %v, want %v", got, epoch)
+	}
+	clk.Advance(time.Hour)
+	if got := clock.Now(ctx); !got.Equal(epoch.Add(time.Hour)) {
+		t.Errorf("Now() after Advance = %v, want %v", got, epoch.Add(time.Hour))
+	}
+}
+
+func TestScope_InstallsClock(t *testing.T) {
+	scope := clock.Scope(runScope{}, clocktest.New(epoch))
+
+	var got time.Time
+	err := scope.ExecuteWithPublish(context.Background(), func(ctx context.Context) error {
+		got = clock.Now(ctx)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExecuteWithPublish() error = %v", err)
+	}
+	if !got.Equal(epoch) {
+		t.Errorf("Now() in scope = %v, want %v", got, epoch)
+	}
+
+	if s := clock.Scope(runScope{}, nil); s != (runScope{}) {
+		t.Errorf("Scope(nil clock) = %T, want the scope itself", s)
+	}
+}
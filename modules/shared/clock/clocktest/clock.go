@@ -0,0 +1,51 @@
+// Package clocktest provides a deterministic clock.Clock for tests.
+package clocktest
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+)
+
+// Clock is a clock that only moves when told to. It is safe for concurrent use.
+type Clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// New creates a Clock stopped at now.
+func New(now time.Time) *Clock {
+	return &Clock{now: now.UTC()}
+}
+
+// Context returns ctx carrying a Clock stopped at now, with the Clock.
+//
+//	ctx, clk := clocktest.Context(t.Context(), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+//	order, _ := domain.NewOrder(ctx, ...)
+//	clk.Advance(time.Hour)
+func Context(ctx context.Context, now time.Time) (context.Context, *Clock) {
+	c := New(now)
+	return clock.WithClock(ctx, c), c
+}
+
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Set moves the clock to now.
+func (c *Clock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now.UTC()
+}
+
+// Advance moves the clock forward by d.
+func (c *Clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
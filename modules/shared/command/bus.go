@@ -0,0 +1,82 @@
+package command
+
+import (
+	"context"
+	"slices"
+)
+
+// Info identifies a command registered on a Bus.
+type Info struct {
+	Module string // owning module, e.g. "orders"
+	Name   string // command name without the "Command" suffix, e.g. "SubmitOrder"
+}
+
+// Next executes a command through the rest of a Bus's middleware and its
+// handler. The result is nil for VoidHandler commands.
+type Next func(ctx context.Context, cmd any) (any, error)
+
+// Middleware applies a cross-cutting concern to the execution of every
+// command registered on a Bus. It is called once per registration, so it
+// can precompute per-command state from info.
+type Middleware func(info Info, next Next) Next
+
+// Bus applies the same middleware to every command handler registered on
+// it. The first middleware is the outermost: it sees the command first and
+// the result last.
+//
+// A nil *Bus applies no middleware, which is what unit tests of a module's
+// wiring usually want.
+type Bus struct {
+	middleware []Middleware
+}
+
+// NewBus returns a Bus that applies middleware in order.
+func NewBus(middleware ...Middleware) *Bus {
+	return &Bus{middleware: slices.Clone(middleware)}
+}
+
+// With returns a Bus that applies b's middleware, then middleware.
+func (b *Bus) With(middleware ...Middleware) *Bus {
+	if b == nil {
+		return NewBus(middleware...)
+	}
+	return NewBus(slices.Concat(b.middleware, middleware)...)
+}
+
+// Register returns h with the bus's middleware applied, for the command C
+// of module.
+func Register[C, R any](b *Bus, module string, h Handler[C, R]) Handler[C, R] {
+	if b == nil || len(b.middleware) == 0 {
+		return h
+	}
+	next := b.chain(Info{Module: module, Name: nameOf[C]()}, func(ctx context.Context, cmd any) (any, error) {
+		return h.Handle(ctx, cmd.(C))
+	})
+	return HandlerFunc[C, R](func(ctx context.Context, cmd C) (R, error) {
+		result, err := next(ctx, cmd)
+		r, _ := result.(R)
+		return r, err
+	})
+}
+
+// RegisterVoid is Register for commands without a result.
+func RegisterVoid[C any](b *Bus, module string, h VoidHandler[C]) VoidHandler[C] {
+	if b == nil || len(b.middleware) == 0 {
+		return h
+	}
+	next := b.chain(Info{Module: module, Name: nameOf[C]()}, func(ctx context.Context, cmd any) (any, error) {
+		return nil, h.Handle(ctx, cmd.(C))
+	})
+	return VoidHandlerFunc[C](func(ctx context.Context, cmd C) error {
+		_, err := next(ctx, cmd)
+		return err
+	})
+}
+
+func (b *Bus) chain(info Info, handler Next) Next {
+	next := handler
+	for _, mw := range slices.Backward(b.middleware) {
+		next = mw(info, next)
+	}
+	return next
+}
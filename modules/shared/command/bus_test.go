@@ -0,0 +1,54 @@
+package command
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+// tag appends "<name>:<command>" to log on the way in.
+func tag(log *[]string, name string) Middleware {
+	return func(info Info, next Next) Next {
+		return func(ctx context.Context, cmd any) (any, error) {
+			*log = append(*log, name+":"+info.Module+"."+info.Name)
+			return next(ctx, cmd)
+		}
+	}
+}
+
+func TestBus_AppliesMiddlewareInOrder(t *testing.T) {
+	var log []string
+	bus := NewBus(tag(&log, "outer")).With(tag(&log, "inner"))
+	h := Register[SubmitOrderCommand, string](bus, "orders", createOrder{})
+
+	id, err := h.Handle(context.Background(), SubmitOrderCommand{})
+
+	if err != nil || id != "order-1" {
+		t.Fatalf("Handle() = %q, %v", id, err)
+	}
+	want := []string{"outer:orders.SubmitOrder", "inner:orders.SubmitOrder"}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("middleware ran as %v, want %v", log, want)
+	}
+}
+
+func TestBus_WithDoesNotChangeTheParent(t *testing.T) {
+	var log []string
+	parent := NewBus(tag(&log, "parent"))
+	parent.With(tag(&log, "child"))
+
+	h := RegisterVoid[SubmitOrderCommand](parent, "orders", submitOrder{})
+	if err := h.Handle(context.Background(), SubmitOrderCommand{}); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if want := []string{"parent:orders.SubmitOrder"}; !reflect.DeepEqual(log, want) {
+		t.Errorf("middleware ran as %v, want %v", log, want)
+	}
+}
+
+func TestRegister_NilBusReturnsHandler(t *testing.T) {
+	var h VoidHandler[SubmitOrderCommand] = submitOrder{}
+	if RegisterVoid[SubmitOrderCommand](nil, "orders", h) != h {
+		t.Error("RegisterVoid on a nil bus should return the handler unchanged")
+	}
+}
@@ -0,0 +1,84 @@
+// Package command defines the common shape of application command handlers
+// and the Bus that applies cross-cutting concerns to them.
+//
+// Modules construct their concrete handlers as before and register them on
+// the Bus they are given in module.go; inbound adapters (HTTP) depend on the
+// Handler and VoidHandler interfaces, so they are unaware of the middleware.
+package command
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Handler executes a command and returns its result (e.g. a created ID).
+type Handler[C, R any] interface {
+	Handle(ctx context.Context, cmd C) (R, error)
+}
+
+// VoidHandler executes a command that returns no result.
+type VoidHandler[C any] interface {
+	Handle(ctx context.Context, cmd C) error
+}
+
+// HandlerFunc adapts a function to a Handler, e.g. for tests of inbound adapters.
+type HandlerFunc[C, R any] func(ctx context.Context, cmd C) (R, error)
+
+func (f HandlerFunc[C, R]) Handle(ctx context.Context, cmd C) (R, error) { return f(ctx, cmd) }
+
+// VoidHandlerFunc adapts a function to a VoidHandler.
+type VoidHandlerFunc[C any] func(ctx context.Context, cmd C) error
+
+func (f VoidHandlerFunc[C]) Handle(ctx context.Context, cmd C) error { return f(ctx, cmd) }
+
+// Record describes one command execution.
+type Record struct {
+	Module   string // owning module, e.g. "orders"
+	Name     string // command name without the "Command" suffix, e.g. "SubmitOrder"
+	Command  any
+	Result   any   // nil for VoidHandler commands
+	Err      error // nil when the command succeeded
+	At       time.Time
+	Duration time.Duration
+}
+
+// Recorder receives a Record after every command execution on a Bus with
+// Recording middleware, whether it succeeded or not. Implementations must
+// not block for long: they run on the caller's goroutine after the command
+// has returned.
+type Recorder interface {
+	RecordCommand(ctx context.Context, rec Record)
+}
+
+// Recorders fans a Record out to every non-nil recorder, in order.
+// It returns nil when none is given, which disables recording.
+func Recorders(recs ...Recorder) Recorder {
+	var fanout multiRecorder
+	for _, rec := range recs {
+		if rec != nil {
+			fanout = append(fanout, rec)
+		}
+	}
+	switch len(fanout) {
+	case 0:
+		return nil
+	case 1:
+		return fanout[0]
+	}
+	return fanout
+}
+
+type multiRecorder []Recorder
+
+func (m multiRecorder) RecordCommand(ctx context.Context, rec Record) {
+	for _, r := range m {
+		r.RecordCommand(ctx, rec)
+	}
+}
+
+// nameOf derives the command name from its type: SubmitOrderCommand -> SubmitOrder.
+func nameOf[C any]() string {
+	return strings.TrimSuffix(reflect.TypeFor[C]().Name(), "Command")
+}
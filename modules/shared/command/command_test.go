@@ -0,0 +1,47 @@
+package command
+
+import (
+	"context"
+	"testing"
+)
+
+type SubmitOrderCommand struct {
+	OrderID string
+}
+
+type submitOrder struct{ err error }
+
+func (h submitOrder) Handle(ctx context.Context, cmd SubmitOrderCommand) error { return h.err }
+
+type createOrder struct{}
+
+func (createOrder) Handle(ctx context.Context, cmd SubmitOrderCommand) (string, error) {
+	return "order-1", nil
+}
+
+type recorderFunc func(ctx context.Context, rec Record)
+
+func (f recorderFunc) RecordCommand(ctx context.Context, rec Record) { f(ctx, rec) }
+
+func TestRecorders_FansOutToEveryRecorder(t *testing.T) {
+	var first, second []string
+	rec := Recorders(
+		recorderFunc(func(ctx context.Context, rec Record) { first = append(first, rec.Name) }),
+		nil,
+		recorderFunc(func(ctx context.Context, rec Record) { second = append(second, rec.Name) }),
+	)
+
+	h := RegisterVoid[SubmitOrderCommand](NewBus(Recording(rec)), "orders", submitOrder{})
+	if err := h.Handle(context.Background(), SubmitOrderCommand{}); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if len(first) != 1 || len(second) != 1 {
+		t.Errorf("recorded %v and %v, want one record each", first, second)
+	}
+}
+
+func TestRecorders_NoneDisablesRecording(t *testing.T) {
+	if rec := Recorders(nil, nil); rec != nil {
+		t.Errorf("Recorders(nil, nil) = %v, want nil", rec)
+	}
+}
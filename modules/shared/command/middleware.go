@@ -0,0 +1,109 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Recording reports every execution to rec. It applies nothing when rec is
+// nil.
+func Recording(rec Recorder) Middleware {
+	return func(info Info, next Next) Next {
+		if rec == nil {
+			return next
+		}
+		return func(ctx context.Context, cmd any) (any, error) {
+			start := time.Now()
+			result, err := next(ctx, cmd)
+			rec.RecordCommand(ctx, Record{
+				Module:   info.Module,
+				Name:     info.Name,
+				Command:  cmd,
+				Result:   result,
+				Err:      err,
+				At:       time.Now().UTC(),
+				Duration: time.Since(start),
+			})
+			return result, err
+		}
+	}
+}
+
+// Validator is implemented by commands that can reject their own input
+// before the handler opens a transaction, e.g. a missing ID or an empty
+// batch. Rules that depend on stored state belong in the domain.
+type Validator interface {
+	Validate() error
+}
+
+// Validation rejects a command that implements Validator and whose Validate
+// fails, without running its handler. The error is returned as is, so
+// commands return the domain errors their HTTP adapter already maps.
+func Validation() Middleware {
+	return func(info Info, next Next) Next {
+		return func(ctx context.Context, cmd any) (any, error) {
+			if v, ok := cmd.(Validator); ok {
+				if err := v.Validate(); err != nil {
+					return nil, err
+				}
+			}
+			return next(ctx, cmd)
+		}
+	}
+}
+
+// Authorizer decides whether the caller in ctx may execute cmd. It returns
+// nil to allow it and an error, returned to the caller as is, to deny it.
+type Authorizer interface {
+	Authorize(ctx context.Context, info Info, cmd any) error
+}
+
+// AuthorizerFunc adapts a function to an Authorizer.
+type AuthorizerFunc func(ctx context.Context, info Info, cmd any) error
+
+func (f AuthorizerFunc) Authorize(ctx context.Context, info Info, cmd any) error {
+	return f(ctx, info, cmd)
+}
+
+// Authorization asks a whether to execute every command. It applies nothing
+// when a is nil.
+func Authorization(a Authorizer) Middleware {
+	return func(info Info, next Next) Next {
+		if a == nil {
+			return next
+		}
+		return func(ctx context.Context, cmd any) (any, error) {
+			if err := a.Authorize(ctx, info, cmd); err != nil {
+				return nil, err
+			}
+			return next(ctx, cmd)
+		}
+	}
+}
+
+// Logging logs every execution at debug level, with its error if it failed:
+// failures are usually business rules the HTTP request log already shows,
+// so this is for tracing the commands behind a request during development.
+// It applies nothing when logger is nil.
+func Logging(logger *slog.Logger) Middleware {
+	return func(info Info, next Next) Next {
+		if logger == nil {
+			return next
+		}
+		return func(ctx context.Context, cmd any) (any, error) {
+			start := time.Now()
+			result, err := next(ctx, cmd)
+			attrs := []slog.Attr{
+				slog.String("module", info.Module),
+				slog.String("command", info.Name),
+				slog.Duration("duration", time.Since(start)),
+			}
+			if err != nil {
+				attrs = append(attrs, slog.Any("error", err))
+			}
+			logger.LogAttrs(ctx, slog.LevelDebug, "command executed", attrs...)
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRecording_ReportsResult(t *testing.T) {
+	var got Record
+	bus := NewBus(Recording(recorderFunc(func(ctx context.Context, rec Record) { got = rec })))
+	h := Register[SubmitOrderCommand, string](bus, "orders", createOrder{})
+
+	id, err := h.Handle(context.Background(), SubmitOrderCommand{OrderID: "o"})
+	if err != nil || id != "order-1" {
+		t.Fatalf("Handle() = %q, %v", id, err)
+	}
+	if got.Module != "orders" || got.Name != "SubmitOrder" || got.Result != "order-1" || got.Err != nil {
+		t.Errorf("record = %+v", got)
+	}
+	if got.At.IsZero() {
+		t.Error("record has no timestamp")
+	}
+}
+
+func TestRecording_ReportsFailure(t *testing.T) {
+	wantErr := errors.New("boom")
+	var got Record
+	bus := NewBus(Recording(recorderFunc(func(ctx context.Context, rec Record) { got = rec })))
+	h := RegisterVoid[SubmitOrderCommand](bus, "orders", submitOrder{err: wantErr})
+
+	if err := h.Handle(context.Background(), SubmitOrderCommand{OrderID: "o"}); !errors.Is(err, wantErr) {
+		t.Fatalf("Handle() error = %v, want %v", err, wantErr)
+	}
+	if !errors.Is(got.Err, wantErr) || got.Result != nil {
+		t.Errorf("record = %+v", got)
+	}
+	if cmd, ok := got.Command.(SubmitOrderCommand); !ok || cmd.OrderID != "o" {
+		t.Errorf("record command = %#v", got.Command)
+	}
+}
+
+var errEmptyOrderID = errors.New("order ID is required")
+
+type validatedCommand struct{ OrderID string }
+
+func (c validatedCommand) Validate() error {
+	if c.OrderID == "" {
+		return errEmptyOrderID
+	}
+	return nil
+}
+
+func TestValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		cmd     validatedCommand
+		wantErr error
+		wantRun bool
+	}{
+		{"valid", validatedCommand{OrderID: "o"}, nil, true},
+		{"invalid", validatedCommand{}, errEmptyOrderID, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ran := false
+			h := RegisterVoid(NewBus(Validation()), "orders", VoidHandlerFunc[validatedCommand](func(ctx context.Context, cmd validatedCommand) error {
+				ran = true
+				return nil
+			}))
+
+			err := h.Handle(context.Background(), tt.cmd)
+
+			if !errors.Is(err, tt.wantErr) || ran != tt.wantRun {
+				t.Errorf("Handle() error = %v, ran = %v; want %v, %v", err, ran, tt.wantErr, tt.wantRun)
+			}
+		})
+	}
+}
+
+func TestAuthorization_DeniesBeforeHandler(t *testing.T) {
+	errForbidden := errors.New("forbidden")
+	var asked Info
+	authz := AuthorizerFunc(func(ctx context.Context, info Info, cmd any) error {
+		asked = info
+		return errForbidden
+	})
+	ran := false
+	h := RegisterVoid(NewBus(Authorization(authz)), "orders", VoidHandlerFunc[SubmitOrderCommand](func(ctx context.Context, cmd SubmitOrderCommand) error {
+		ran = true
+		return nil
+	}))
+
+	err := h.Handle(context.Background(), SubmitOrderCommand{})
+
+	if !errors.Is(err, errForbidden) || ran {
+		t.Errorf("Handle() error = %v, ran = %v; want %v, false", err, ran, errForbidden)
+	}
+	if asked != (Info{Module: "orders", Name: "SubmitOrder"}) {
+		t.Errorf("authorizer asked about %+v", asked)
+	}
+}
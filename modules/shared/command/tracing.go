@@ -0,0 +1,33 @@
+package command
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("command")
+
+// Tracing runs every execution in its own span, named
+// "command <module>.<Name>". Repository calls and pre-commit event handlers
+// made by the command become children of that span.
+func Tracing() Middleware {
+	return func(info Info, next Next) Next {
+		spanName := "command " + info.Module + "." + info.Name
+		attrs := trace.WithAttributes(attribute.String("command.module", info.Module), attribute.String("command.name", info.Name))
+		return func(ctx context.Context, cmd any) (any, error) {
+			ctx, span := tracer.Start(ctx, spanName, attrs)
+			defer span.End()
+
+			result, err := next(ctx, cmd)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return result, err
+		}
+	}
+}
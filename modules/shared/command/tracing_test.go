@@ -0,0 +1,35 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracing_RecordsSpan(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+
+	wantErr := errors.New("boom")
+	h := RegisterVoid[SubmitOrderCommand](NewBus(Tracing()), "orders", submitOrder{err: wantErr})
+
+	if err := h.Handle(context.Background(), SubmitOrderCommand{}); !errors.Is(err, wantErr) {
+		t.Fatalf("Handle() error = %v, want %v", err, wantErr)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 1 {
+		t.Fatalf("got %d spans, want 1", len(ended))
+	}
+	if ended[0].Name() != "command orders.SubmitOrder" {
+		t.Errorf("span name = %q", ended[0].Name())
+	}
+	if ended[0].Status().Code != codes.Error {
+		t.Errorf("span status = %v, want error", ended[0].Status())
+	}
+}
@@ -0,0 +1,50 @@
+// Package contracts defines the synchronous query ports modules use to read
+// each other's data. Events stay the way modules react to each other; a
+// contract is for the reads a command cannot wait for, e.g. checking that
+// the user placing an order exists.
+//
+// The owning module implements its contract on its Module (users.Module is
+// a UserReader) and the composition root injects it into the consumers'
+// Configs, so a consumer depends on this package rather than on the owner.
+// Consumers keep translating contract types into their own domain ports at
+// the edge of their module.
+package contracts
+
+import (
+	"context"
+	"time"
+)
+
+// UserStatusDeleted is the Status of a soft-deleted user.
+const UserStatusDeleted = "deleted"
+
+// UserSummary is what other modules may know about a user.
+type UserSummary struct {
+	ID        string
+	Email     string
+	FirstName string
+	LastName  string
+	Status    string // "active", "inactive" or UserStatusDeleted
+	Locale    string // preferred locale; empty means the default
+	CreatedAt time.Time
+}
+
+// Deleted reports whether the user has been soft-deleted.
+func (u UserSummary) Deleted() bool {
+	return u.Status == UserStatusDeleted
+}
+
+// UserReader is implemented by the users module.
+type UserReader interface {
+	// GetUserSummary returns a user, deleted users included. ok is false
+	// for malformed IDs and unknown users. When called inside another
+	// module's transaction it reads that transaction's snapshot.
+	GetUserSummary(ctx context.Context, userID string) (user UserSummary, ok bool, err error)
+}
+
+// UserReaderFunc adapts an ordinary function to UserReader.
+type UserReaderFunc func(ctx context.Context, userID string) (UserSummary, bool, error)
+
+func (f UserReaderFunc) GetUserSummary(ctx context.Context, userID string) (UserSummary, bool, error) {
+	return f(ctx, userID)
+}
@@ -0,0 +1,63 @@
+// Package etag implements conditional requests on aggregates for optimistic
+// concurrency over HTTP. An aggregate's entity tag is derived from its
+// UpdatedAt, which every state change advances: GET responses carry it in
+// the ETag header, and writes send it back in If-Match. The handler passes
+// the parsed time to the command, which compares it with the aggregate it
+// loads in its transaction and fails with the module's "modified" error,
+// mapped to 412 Precondition Failed, if someone else updated it meanwhile.
+package etag
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrRequired is returned by IfMatch for a request without If-Match;
+	// handlers respond 428 Precondition Required.
+	ErrRequired = errors.New("an If-Match header with the ETag of the resource is required")
+	// ErrMismatch is returned by IfMatch for an entity tag that this package
+	// did not issue, which can never match; handlers respond 412
+	// Precondition Failed.
+	ErrMismatch = errors.New("the resource has been modified: If-Match does not match its ETag")
+)
+
+// Of returns the entity tag of an aggregate last updated at updatedAt.
+func Of(updatedAt time.Time) string {
+	return `"` + strconv.FormatInt(updatedAt.UnixNano(), 36) + `"`
+}
+
+// Set sets the ETag header of a response for an aggregate last updated at
+// updatedAt.
+func Set(w http.ResponseWriter, updatedAt time.Time) {
+	w.Header().Set("ETag", Of(updatedAt))
+}
+
+// IfMatch returns the UpdatedAt the request's If-Match header expects, or
+// the zero time for "*", which matches any current version. It accepts a
+// single entity tag, compared strongly: a weak tag never matches.
+func IfMatch(r *http.Request) (time.Time, error) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	switch header {
+	case "":
+		return time.Time{}, ErrRequired
+	case "*":
+		return time.Time{}, nil
+	}
+	tag, ok := strings.CutPrefix(header, `"`)
+	if !ok {
+		return time.Time{}, ErrMismatch
+	}
+	tag, ok = strings.CutSuffix(tag, `"`)
+	if !ok {
+		return time.Time{}, ErrMismatch
+	}
+	nanos, err := strconv.ParseInt(tag, 36, 64)
+	if err != nil {
+		return time.Time{}, ErrMismatch
+	}
+	return time.Unix(0, nanos).UTC(), nil
+}
@@ -0,0 +1,42 @@
+package etag
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestIfMatch(t *testing.T) {
+	updatedAt := time.Date(2025, 1, 1, 12, 0, 0, 123456000, time.UTC)
+
+	tests := []struct {
+		name    string
+		header  string
+		want    time.Time
+		wantErr error
+	}{
+		{"round trip", Of(updatedAt), updatedAt, nil},
+		{"any", "*", time.Time{}, nil},
+		{"missing", "", time.Time{}, ErrRequired},
+		{"weak", "W/" + Of(updatedAt), time.Time{}, ErrMismatch},
+		{"list", Of(updatedAt) + `, "x"`, time.Time{}, ErrMismatch},
+		{"unquoted", "abc", time.Time{}, ErrMismatch},
+		{"foreign", `"not-base36!"`, time.Time{}, ErrMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/users/1", nil)
+			if tt.header != "" {
+				r.Header.Set("If-Match", tt.header)
+			}
+
+			got, err := IfMatch(r)
+
+			if !errors.Is(err, tt.wantErr) || !got.Equal(tt.want) {
+				t.Errorf("IfMatch(%q) = %v, %v; want %v, %v", tt.header, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CloudEvents 1.0 attribute values set by MarshalCloudEvent.
+const (
+	CloudEventsSpecVersion = "1.0"
+	CloudEventsContentType = "application/cloudevents+json"
+)
+
+// CloudEvent is an event in the CloudEvents 1.0 JSON format
+// (https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/formats/json-format.md),
+// for brokers shared with systems outside the monolith. The BaseEvent fields
+// map to the id, type and time attributes, and the module that published
+// the event to the source, e.g. "/modules/orders" for "orders.OrderSubmitted".
+// The tenant travels in the tenantid extension attribute, left out for the
+// default tenant. Broker adapters send it as the message body with
+// CloudEventsContentType.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            EventType       `json:"type"`
+	Time            time.Time       `json:"time"`
+	TenantID        string          `json:"tenantid,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+// CloudEventSource returns the source attribute of events of type t.
+func CloudEventSource(t EventType) string {
+	module, _, _ := strings.Cut(string(t), ".")
+	return "/modules/" + module
+}
+
+// MarshalCloudEvent serializes an event as a structured-mode CloudEvent.
+func MarshalCloudEvent(event Event) ([]byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling %s: %w", event.EventType(), err)
+	}
+	return json.Marshal(CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              event.EventID(),
+		Source:          CloudEventSource(event.EventType()),
+		Type:            event.EventType(),
+		Time:            event.OccurredAt(),
+		TenantID:        TenantID(event),
+		DataContentType: "application/json",
+		Data:            data,
+	})
+}
+
+// UnmarshalCloudEvent deserializes a structured-mode CloudEvent into event, a
+// pointer to the concrete event type, which must embed BaseEvent. Extension
+// attributes other than tenantid are ignored; binary data (data_base64) and content types other
+// than JSON are rejected.
+func UnmarshalCloudEvent(data []byte, event Event) error {
+	target, ok := event.(restorer)
+	if !ok {
+		return fmt.Errorf("unmarshaling into %T: not a pointer to an event embedding BaseEvent", event)
+	}
+
+	var ce struct {
+		CloudEvent
+		DataBase64 *string `json:"data_base64"`
+	}
+	if err := json.Unmarshal(data, &ce); err != nil {
+		return fmt.Errorf("unmarshaling cloudevent: %w", err)
+	}
+	switch {
+	case ce.SpecVersion != CloudEventsSpecVersion:
+		return fmt.Errorf("unmarshaling cloudevent: unsupported specversion %q", ce.SpecVersion)
+	case ce.ID == "":
+		return errors.New("unmarshaling cloudevent: missing id")
+	case ce.Source == "":
+		return errors.New("unmarshaling cloudevent: missing source")
+	case ce.DataBase64 != nil:
+		return errors.New("unmarshaling cloudevent: binary data is not supported")
+	case ce.DataContentType != "" && ce.DataContentType != "application/json":
+		return fmt.Errorf("unmarshaling cloudevent: unsupported datacontenttype %q", ce.DataContentType)
+	}
+	if err := ce.Type.Validate(); err != nil {
+		return err
+	}
+	if len(ce.Data) == 0 {
+		ce.Data = json.RawMessage("{}")
+	}
+
+	return restore(Envelope{ID: ce.ID, Type: ce.Type, OccurredAt: ce.Time, TenantID: ce.TenantID, Data: ce.Data}, target)
+}
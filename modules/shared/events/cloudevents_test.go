@@ -0,0 +1,82 @@
+package events
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestMarshalCloudEvent_RoundTrip(t *testing.T) {
+	sent := envelopeTestEvent{BaseEvent: NewBaseEvent("test.TestHappened"), Name: "alice"}
+
+	data, err := MarshalCloudEvent(sent)
+	if err != nil {
+		t.Fatalf("MarshalCloudEvent() error = %v", err)
+	}
+
+	var attrs map[string]json.RawMessage
+	if err := json.Unmarshal(data, &attrs); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"specversion":     `"1.0"`,
+		"id":              `"` + sent.EventID() + `"`,
+		"source":          `"/modules/test"`,
+		"type":            `"test.TestHappened"`,
+		"datacontenttype": `"application/json"`,
+		"data":            `{"name":"alice"}`,
+	} {
+		if got := string(attrs[name]); got != want {
+			t.Errorf("%s = %s, want %s", name, got, want)
+		}
+	}
+
+	var received envelopeTestEvent
+	if err := UnmarshalCloudEvent(data, &received); err != nil {
+		t.Fatalf("UnmarshalCloudEvent() error = %v", err)
+	}
+	if received.EventID() != sent.EventID() || received.EventType() != sent.EventType() ||
+		!received.OccurredAt().Equal(sent.OccurredAt()) || received.Name != sent.Name {
+		t.Errorf("UnmarshalCloudEvent() = %+v, want %+v", received, sent)
+	}
+}
+
+func TestUnmarshalCloudEvent_External(t *testing.T) {
+	data := `{"specversion":"1.0","id":"A234-1234","source":"https://erp.example.com/stock","type":"test.TestHappened",` +
+		`"time":"2026-04-05T17:31:00Z","subject":"sku-1","traceparent":"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",` +
+		`"data":{"name":"bob"}}`
+
+	var received envelopeTestEvent
+	if err := UnmarshalCloudEvent([]byte(data), &received); err != nil {
+		t.Fatalf("UnmarshalCloudEvent() error = %v", err)
+	}
+	if received.EventID() != "A234-1234" || received.Name != "bob" || received.OccurredAt().IsZero() {
+		t.Errorf("UnmarshalCloudEvent() = %+v", received)
+	}
+}
+
+func TestUnmarshalCloudEvent_Rejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		event Event
+		want  string
+	}{
+		{"value target", `{"specversion":"1.0","id":"1","source":"/s","type":"test.TestHappened"}`, envelopeTestEvent{}, "not a pointer"},
+		{"spec version", `{"specversion":"0.3","id":"1","source":"/s","type":"test.TestHappened"}`, &envelopeTestEvent{}, "unsupported specversion"},
+		{"missing id", `{"specversion":"1.0","source":"/s","type":"test.TestHappened"}`, &envelopeTestEvent{}, "missing id"},
+		{"missing source", `{"specversion":"1.0","id":"1","type":"test.TestHappened"}`, &envelopeTestEvent{}, "missing source"},
+		{"invalid type", `{"specversion":"1.0","id":"1","source":"/s","type":"com.example.happened"}`, &envelopeTestEvent{}, "invalid event type"},
+		{"binary data", `{"specversion":"1.0","id":"1","source":"/s","type":"test.TestHappened","data_base64":"e30="}`, &envelopeTestEvent{}, "binary data"},
+		{"content type", `{"specversion":"1.0","id":"1","source":"/s","type":"test.TestHappened","datacontenttype":"text/xml"}`, &envelopeTestEvent{}, "unsupported datacontenttype"},
+		{"mistyped data", `{"specversion":"1.0","id":"1","source":"/s","type":"test.TestHappened","data":{"name":1}}`, &envelopeTestEvent{}, "unmarshaling test.TestHappened"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := UnmarshalCloudEvent([]byte(tt.data), tt.event)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("UnmarshalCloudEvent() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
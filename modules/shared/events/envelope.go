@@ -0,0 +1,72 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Envelope is the serialized form of an event: the BaseEvent fields, and the
+// event's own fields as data, encoded with their JSON tags.
+type Envelope struct {
+	ID         string          `json:"id"`
+	Type       EventType       `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	TenantID   string          `json:"tenant_id,omitempty"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Marshal serializes an event into an Envelope.
+func Marshal(event Event) ([]byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling %s: %w", event.EventType(), err)
+	}
+	return json.Marshal(Envelope{
+		ID:         event.EventID(),
+		Type:       event.EventType(),
+		OccurredAt: event.OccurredAt(),
+		TenantID:   TenantID(event),
+		Data:       data,
+	})
+}
+
+// restorer is implemented by pointers to events embedding BaseEvent.
+type restorer interface {
+	restore(base BaseEvent)
+}
+
+func (e *BaseEvent) restore(base BaseEvent) { *e = base }
+
+// Unmarshal deserializes an Envelope into event, a pointer to the concrete
+// event type, which must embed BaseEvent.
+func Unmarshal(data []byte, event Event) error {
+	target, ok := event.(restorer)
+	if !ok {
+		return fmt.Errorf("unmarshaling into %T: not a pointer to an event embedding BaseEvent", event)
+	}
+
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return fmt.Errorf("unmarshaling envelope: %w", err)
+	}
+	if err := env.Type.Validate(); err != nil {
+		return err
+	}
+	if env.ID == "" {
+		return errors.New("unmarshaling envelope: missing id")
+	}
+
+	return restore(env, target)
+}
+
+// restore decodes the data of a validated envelope into target and sets its
+// BaseEvent fields.
+func restore(env Envelope, target restorer) error {
+	if err := json.Unmarshal(env.Data, target); err != nil {
+		return fmt.Errorf("unmarshaling %s: %w", env.Type, err)
+	}
+	target.restore(BaseEvent{id: env.ID, eventType: env.Type, timestamp: env.OccurredAt, tenantID: env.TenantID})
+	return nil
+}
@@ -0,0 +1,60 @@
+package events
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type envelopeTestEvent struct {
+	BaseEvent
+	Name string `json:"name"`
+}
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	sent := envelopeTestEvent{BaseEvent: NewBaseEvent("test.TestHappened"), Name: "alice"}
+
+	data, err := Marshal(sent)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var env map[string]json.RawMessage
+	if err := json.Unmarshal(data, &env); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(env["data"]); got != `{"name":"alice"}` {
+		t.Errorf("data = %s, want only the event's own fields", got)
+	}
+
+	var received envelopeTestEvent
+	if err := Unmarshal(data, &received); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if received.EventID() != sent.EventID() || received.EventType() != sent.EventType() ||
+		!received.OccurredAt().Equal(sent.OccurredAt()) || received.Name != sent.Name {
+		t.Errorf("Unmarshal() = %+v, want %+v", received, sent)
+	}
+}
+
+func TestUnmarshal_Rejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		event Event
+		want  string
+	}{
+		{"value target", `{"id":"1","type":"test.TestHappened","data":{}}`, envelopeTestEvent{}, "not a pointer"},
+		{"invalid type", `{"id":"1","type":"TestHappened","data":{}}`, &envelopeTestEvent{}, "invalid event type"},
+		{"missing id", `{"type":"test.TestHappened","data":{}}`, &envelopeTestEvent{}, "missing id"},
+		{"mistyped data", `{"id":"1","type":"test.TestHappened","data":{"name":1}}`, &envelopeTestEvent{}, "unmarshaling test.TestHappened"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Unmarshal([]byte(tt.data), tt.event)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Unmarshal() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -1,3 +1,5 @@
+//go:generate mockgen -source=event.go -destination=mocks/mock_event.go -package=mocks
+
 package events
 
 import (
@@ -7,6 +9,10 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/ids"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
 )
 
 // Event represents a domain event.
@@ -23,6 +29,10 @@ type Event interface {
 // Examples: "users.UserCreated", "orders.OrderSubmitted"
 type EventType string
 
+// AnyEventType subscribes a handler to every event type. It is only valid as a
+// subscription key; events themselves always carry a concrete type.
+const AnyEventType EventType = "*"
+
 // eventTypePattern validates the format: lowercase_module.PascalCaseEvent
 var eventTypePattern = regexp.MustCompile(`^[a-z]+\.[A-Z][a-zA-Z]+$`)
 
@@ -43,6 +53,7 @@ type BaseEvent struct {
 	id        string
 	eventType EventType
 	timestamp time.Time
+	tenantID  string
 }
 
 // NewBaseEvent creates a new BaseEvent. Panics if eventType format is invalid.
@@ -57,10 +68,30 @@ func NewBaseEvent(eventType EventType) BaseEvent {
 	}
 }
 
+// NewBaseEventWithContext is NewBaseEvent with the ID and the timestamp taken
+// from the generator and the clock in ctx (see ids.New and clock.Now), so
+// that tests can pin them, and the tenant of the request of ctx (see
+// requestcontext.TenantID). Panics if eventType format is invalid.
+func NewBaseEventWithContext(ctx context.Context, eventType EventType) BaseEvent {
+	if err := eventType.Validate(); err != nil {
+		panic(err)
+	}
+	return BaseEvent{
+		id:        ids.New(ctx),
+		eventType: eventType,
+		timestamp: clock.Now(ctx),
+		tenantID:  requestcontext.TenantID(ctx),
+	}
+}
+
 func (e BaseEvent) EventID() string       { return e.id }
 func (e BaseEvent) EventType() EventType  { return e.eventType }
 func (e BaseEvent) OccurredAt() time.Time { return e.timestamp }
 
+// TenantID returns the tenant the event was raised for, "" for the default
+// tenant.
+func (e BaseEvent) TenantID() string { return e.tenantID }
+
 // Publisher dispatches domain events to registered handlers.
 type Publisher interface {
 	Publish(ctx context.Context, events []Event) error
@@ -77,6 +108,17 @@ type Handler interface {
 	EventType() EventType
 }
 
+// BatchHandler is a Handler that can also handle several events at once,
+// e.g. to store them in a single transaction. When one Publish carries more
+// than one event the handler subscribed to, the bus calls HandleBatch once
+// with all of them, in order, at the position of the first; a single event
+// still goes to Handle. Handlers wrapped by a decorator are batched only if
+// the wrapper implements BatchHandler too.
+type BatchHandler interface {
+	Handler
+	HandleBatch(ctx context.Context, events []Event) error
+}
+
 // Subscriber subscribes handlers that run INSIDE the transaction boundary (pre-commit).
 type Subscriber interface {
 	Subscribe(eventType EventType, handler Handler) error
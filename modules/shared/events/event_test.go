@@ -0,0 +1,22 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock/clocktest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/ids/idstest"
+)
+
+func TestNewBaseEventWithContext_UsesClockAndGenerator(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	ctx, _ := clocktest.Context(context.Background(), now)
+	ctx, _ = idstest.Context(ctx)
+
+	e := NewBaseEventWithContext(ctx, "test.TestHappened")
+
+	if e.EventID() != idstest.ID(1) || !e.OccurredAt().Equal(now) || e.EventType() != "test.TestHappened" {
+		t.Errorf("NewBaseEventWithContext() = %+v, want ID %s at %v", e, idstest.ID(1), now)
+	}
+}
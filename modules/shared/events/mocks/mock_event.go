@@ -0,0 +1,315 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: event.go
+//
+// Generated by this command:
+//
+//	mockgen -source=event.go -destination=mocks/mock_event.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	events "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEvent is a mock of Event interface.
+type MockEvent struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventMockRecorder
+	isgomock struct{}
+}
+
+// MockEventMockRecorder is the mock recorder for MockEvent.
+type MockEventMockRecorder struct {
+	mock *MockEvent
+}
+
+// NewMockEvent creates a new mock instance.
+func NewMockEvent(ctrl *gomock.Controller) *MockEvent {
+	mock := &MockEvent{ctrl: ctrl}
+	mock.recorder = &MockEventMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEvent) EXPECT() *MockEventMockRecorder {
+	return m.recorder
+}
+
+// EventID mocks base method.
+func (m *MockEvent) EventID() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventID")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// EventID indicates an expected call of EventID.
+func (mr *MockEventMockRecorder) EventID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventID", reflect.TypeOf((*MockEvent)(nil).EventID))
+}
+
+// EventType mocks base method.
+func (m *MockEvent) EventType() events.EventType {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventType")
+	ret0, _ := ret[0].(events.EventType)
+	return ret0
+}
+
+// EventType indicates an expected call of EventType.
+func (mr *MockEventMockRecorder) EventType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventType", reflect.TypeOf((*MockEvent)(nil).EventType))
+}
+
+// OccurredAt mocks base method.
+func (m *MockEvent) OccurredAt() time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OccurredAt")
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// OccurredAt indicates an expected call of OccurredAt.
+func (mr *MockEventMockRecorder) OccurredAt() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OccurredAt", reflect.TypeOf((*MockEvent)(nil).OccurredAt))
+}
+
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockPublisher) Publish(ctx context.Context, arg1 []events.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPublisherMockRecorder) Publish(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPublisher)(nil).Publish), ctx, arg1)
+}
+
+// MockHandler is a mock of Handler interface.
+type MockHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockHandlerMockRecorder is the mock recorder for MockHandler.
+type MockHandlerMockRecorder struct {
+	mock *MockHandler
+}
+
+// NewMockHandler creates a new mock instance.
+func NewMockHandler(ctrl *gomock.Controller) *MockHandler {
+	mock := &MockHandler{ctrl: ctrl}
+	mock.recorder = &MockHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHandler) EXPECT() *MockHandlerMockRecorder {
+	return m.recorder
+}
+
+// EventType mocks base method.
+func (m *MockHandler) EventType() events.EventType {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EventType")
+	ret0, _ := ret[0].(events.EventType)
+	return ret0
+}
+
+// EventType indicates an expected call of EventType.
+func (mr *MockHandlerMockRecorder) EventType() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EventType", reflect.TypeOf((*MockHandler)(nil).EventType))
+}
+
+// Handle mocks base method.
+func (m *MockHandler) Handle(ctx context.Context, event events.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Handle", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Handle indicates an expected call of Handle.
+func (mr *MockHandlerMockRecorder) Handle(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Handle", reflect.TypeOf((*MockHandler)(nil).Handle), ctx, event)
+}
+
+// HandlerName mocks base method.
+func (m *MockHandler) HandlerName() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandlerName")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// HandlerName indicates an expected call of HandlerName.
+func (mr *MockHandlerMockRecorder) HandlerName() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlerName", reflect.TypeOf((*MockHandler)(nil).HandlerName))
+}
+
+// Subdomain mocks base method.
+func (m *MockHandler) Subdomain() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subdomain")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Subdomain indicates an expected call of Subdomain.
+func (mr *MockHandlerMockRecorder) Subdomain() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subdomain", reflect.TypeOf((*MockHandler)(nil).Subdomain))
+}
+
+// MockSubscriber is a mock of Subscriber interface.
+type MockSubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockSubscriberMockRecorder
+	isgomock struct{}
+}
+
+// MockSubscriberMockRecorder is the mock recorder for MockSubscriber.
+type MockSubscriberMockRecorder struct {
+	mock *MockSubscriber
+}
+
+// NewMockSubscriber creates a new mock instance.
+func NewMockSubscriber(ctrl *gomock.Controller) *MockSubscriber {
+	mock := &MockSubscriber{ctrl: ctrl}
+	mock.recorder = &MockSubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSubscriber) EXPECT() *MockSubscriberMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockSubscriber) Subscribe(eventType events.EventType, handler events.Handler) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", eventType, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockSubscriberMockRecorder) Subscribe(eventType, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockSubscriber)(nil).Subscribe), eventType, handler)
+}
+
+// MockPostCommitSubscriber is a mock of PostCommitSubscriber interface.
+type MockPostCommitSubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostCommitSubscriberMockRecorder
+	isgomock struct{}
+}
+
+// MockPostCommitSubscriberMockRecorder is the mock recorder for MockPostCommitSubscriber.
+type MockPostCommitSubscriberMockRecorder struct {
+	mock *MockPostCommitSubscriber
+}
+
+// NewMockPostCommitSubscriber creates a new mock instance.
+func NewMockPostCommitSubscriber(ctrl *gomock.Controller) *MockPostCommitSubscriber {
+	mock := &MockPostCommitSubscriber{ctrl: ctrl}
+	mock.recorder = &MockPostCommitSubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostCommitSubscriber) EXPECT() *MockPostCommitSubscriberMockRecorder {
+	return m.recorder
+}
+
+// SubscribePostCommit mocks base method.
+func (m *MockPostCommitSubscriber) SubscribePostCommit(eventType events.EventType, handler events.Handler) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubscribePostCommit", eventType, handler)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubscribePostCommit indicates an expected call of SubscribePostCommit.
+func (mr *MockPostCommitSubscriberMockRecorder) SubscribePostCommit(eventType, handler any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubscribePostCommit", reflect.TypeOf((*MockPostCommitSubscriber)(nil).SubscribePostCommit), eventType, handler)
+}
+
+// MockPostCommitPublisher is a mock of PostCommitPublisher interface.
+type MockPostCommitPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostCommitPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockPostCommitPublisherMockRecorder is the mock recorder for MockPostCommitPublisher.
+type MockPostCommitPublisherMockRecorder struct {
+	mock *MockPostCommitPublisher
+}
+
+// NewMockPostCommitPublisher creates a new mock instance.
+func NewMockPostCommitPublisher(ctrl *gomock.Controller) *MockPostCommitPublisher {
+	mock := &MockPostCommitPublisher{ctrl: ctrl}
+	mock.recorder = &MockPostCommitPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostCommitPublisher) EXPECT() *MockPostCommitPublisherMockRecorder {
+	return m.recorder
+}
+
+// PublishPostCommit mocks base method.
+func (m *MockPostCommitPublisher) PublishPostCommit(ctx context.Context, arg1 []events.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PublishPostCommit", ctx, arg1)
+}
+
+// PublishPostCommit indicates an expected call of PublishPostCommit.
+func (mr *MockPostCommitPublisherMockRecorder) PublishPostCommit(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishPostCommit", reflect.TypeOf((*MockPostCommitPublisher)(nil).PublishPostCommit), ctx, arg1)
+}
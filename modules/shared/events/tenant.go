@@ -0,0 +1,26 @@
+package events
+
+import (
+	"context"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+// TenantID returns the tenant event was raised for: "" for the default
+// tenant, and for events that do not embed BaseEvent.
+func TenantID(event Event) string {
+	if e, ok := event.(interface{ TenantID() string }); ok {
+		return e.TenantID()
+	}
+	return ""
+}
+
+// WithTenant returns ctx with the tenant of event as the tenant of its
+// request (see requestcontext.TenantID), so that a handler reads and writes
+// the data of the tenant the event was raised for, even once the request
+// that raised it is gone. The other request values are kept.
+func WithTenant(ctx context.Context, event Event) context.Context {
+	v := requestcontext.FromContext(ctx)
+	v.TenantID = TenantID(event)
+	return requestcontext.With(ctx, v)
+}
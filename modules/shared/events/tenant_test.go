@@ -0,0 +1,49 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+func TestTenant_RoundTrip(t *testing.T) {
+	ctx := requestcontext.With(context.Background(), requestcontext.Values{TenantID: "acme", Actor: "admin"})
+	sent := envelopeTestEvent{BaseEvent: NewBaseEventWithContext(ctx, "test.TestHappened"), Name: "alice"}
+	if got := TenantID(sent); got != "acme" {
+		t.Fatalf("TenantID() = %q, want acme", got)
+	}
+
+	for name, codec := range map[string]struct {
+		marshal   func(Event) ([]byte, error)
+		unmarshal func([]byte, Event) error
+	}{
+		"envelope":   {Marshal, Unmarshal},
+		"cloudevent": {MarshalCloudEvent, UnmarshalCloudEvent},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := codec.marshal(sent)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var received envelopeTestEvent
+			if err := codec.unmarshal(data, &received); err != nil {
+				t.Fatal(err)
+			}
+			if got := TenantID(received); got != "acme" {
+				t.Errorf("TenantID() after a round trip = %q, want acme", got)
+			}
+		})
+	}
+}
+
+func TestWithTenant(t *testing.T) {
+	ctx := requestcontext.With(context.Background(), requestcontext.Values{TenantID: "other", Actor: "admin"})
+	event := envelopeTestEvent{BaseEvent: BaseEvent{eventType: "test.TestHappened", tenantID: "acme"}}
+
+	got := requestcontext.FromContext(WithTenant(ctx, event))
+
+	if want := (requestcontext.Values{TenantID: "acme", Actor: "admin"}); got != want {
+		t.Errorf("values = %+v, want %+v", got, want)
+	}
+}
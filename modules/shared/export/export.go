@@ -0,0 +1,161 @@
+// Package export streams list endpoints as CSV or NDJSON for back-office
+// exports. The handler negotiates the format from the Accept header and,
+// for anything but JSON, hands the query's rows to Write, which writes and
+// flushes them as they come instead of buffering the whole result:
+//
+//	if format := export.Negotiate(r); format != export.JSON {
+//		if err := export.Write(w, format, "users", userColumns, h.listUsers.Export(ctx, query)); err != nil {
+//			handleError(w, err)
+//		}
+//		return
+//	}
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Format is the media type of a response.
+type Format string
+
+const (
+	JSON   Format = "application/json"
+	CSV    Format = "text/csv"
+	NDJSON Format = "application/x-ndjson"
+)
+
+// Description documents the export formats in the OpenAPI description of an
+// endpoint that uses Write.
+const Description = "Send Accept: text/csv or application/x-ndjson to stream every matching row from offset on, at most limit if given, instead of a JSON page."
+
+// Negotiate returns the format preferred by the request's Accept header,
+// JSON if it accepts none of CSV and NDJSON.
+func Negotiate(r *http.Request) Format {
+	type candidate struct {
+		format Format
+		q      float64
+	}
+	var candidates []candidate
+	for part := range strings.SplitSeq(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil || q <= 0 {
+				continue
+			}
+		}
+		switch format := Format(mediaType); format {
+		case CSV, NDJSON, JSON:
+			candidates = append(candidates, candidate{format, q})
+		case "*/*", "application/*":
+			candidates = append(candidates, candidate{JSON, q})
+		}
+	}
+	if len(candidates) == 0 {
+		return JSON
+	}
+	// Stable, so that the first of equally preferred types wins.
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		}
+		return 0
+	})
+	return candidates[0].format
+}
+
+// Column is a CSV column: its header and how to render a row's cell.
+type Column[T any] struct {
+	Name  string
+	Value func(row T) string
+}
+
+// flushEvery is the number of rows written between flushes.
+const flushEvery = 100
+
+// Write streams rows as CSV, with a header line of the columns' names, or as
+// NDJSON, one JSON value per row. CSV is served as an attachment named after
+// name.
+//
+// An error before the first row is returned with nothing written, for the
+// caller to respond with. An error afterwards aborts the response (panic
+// with http.ErrAbortHandler) so that the client sees a truncated transfer
+// rather than a complete-looking export.
+func Write[T any](w http.ResponseWriter, format Format, name string, columns []Column[T], rows iter.Seq2[T, error]) error {
+	next, stop := iter.Pull2(rows)
+	defer stop()
+
+	row, err, ok := next()
+	if err != nil {
+		return err
+	}
+
+	var encode func(T) error
+	switch format {
+	case CSV:
+		cw := csv.NewWriter(w)
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".csv"))
+		header := make([]string, len(columns))
+		for i, c := range columns {
+			header[i] = c.Name
+		}
+		cw.Write(header)
+		cw.Flush()
+		record := make([]string, len(columns))
+		encode = func(row T) error {
+			for i, c := range columns {
+				record[i] = escapeFormula(c.Value(row))
+			}
+			cw.Write(record)
+			cw.Flush()
+			return cw.Error()
+		}
+	case NDJSON:
+		enc := json.NewEncoder(w)
+		w.Header().Set("Content-Type", string(NDJSON))
+		encode = func(row T) error { return enc.Encode(row) }
+	default:
+		return fmt.Errorf("export: unsupported format %q", format)
+	}
+
+	rc := http.NewResponseController(w)
+	for n := 1; ok; n++ {
+		if err := encode(row); err != nil {
+			panic(http.ErrAbortHandler) // the client went away
+		}
+		if n%flushEvery == 0 {
+			if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+				panic(http.ErrAbortHandler)
+			}
+		}
+		if row, err, ok = next(); err != nil {
+			panic(http.ErrAbortHandler)
+		}
+	}
+	return nil
+}
+
+// escapeFormula keeps spreadsheets from evaluating a cell as a formula
+// (CSV injection) by prefixing it with a quote.
+func escapeFormula(cell string) string {
+	if cell != "" && strings.ContainsRune("=+-@\t\r", rune(cell[0])) {
+		return "'" + cell
+	}
+	return cell
+}
@@ -0,0 +1,115 @@
+package export
+
+import (
+	"errors"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   Format
+	}{
+		{"", JSON},
+		{"*/*", JSON},
+		{"text/csv", CSV},
+		{"application/x-ndjson", NDJSON},
+		{"text/html, text/csv", CSV},
+		{"application/json, text/csv", JSON},
+		{"text/csv;q=0.5, application/x-ndjson", NDJSON},
+		{"text/csv;q=0.5, */*", JSON},
+		{"text/csv;q=0", JSON},
+		{"TEXT/CSV; charset=utf-8", CSV},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/users", nil)
+			r.Header.Set("Accept", tt.accept)
+			if got := Negotiate(r); got != tt.want {
+				t.Errorf("Negotiate(%q) = %s, want %s", tt.accept, got, tt.want)
+			}
+		})
+	}
+}
+
+type row struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+var columns = []Column[row]{
+	{Name: "id", Value: func(r row) string { return strconv.Itoa(r.ID) }},
+	{Name: "name", Value: func(r row) string { return r.Name }},
+}
+
+// rowsOf yields rows, then err if it is not nil.
+func rowsOf(err error, rows ...row) iter.Seq2[row, error] {
+	return func(yield func(row, error) bool) {
+		for _, r := range rows {
+			if !yield(r, nil) {
+				return
+			}
+		}
+		if err != nil {
+			yield(row{}, err)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	rows := []row{{1, "alice"}, {2, "=HYPERLINK(\"x\")"}, {3, "bob, jr"}}
+	tests := []struct {
+		format      Format
+		rows        []row
+		contentType string
+		body        string
+	}{
+		{CSV, rows, "text/csv; charset=utf-8", "id,name\n1,alice\n2,\"'=HYPERLINK(\"\"x\"\")\"\n3,\"bob, jr\"\n"},
+		{CSV, nil, "text/csv; charset=utf-8", "id,name\n"},
+		{NDJSON, rows, "application/x-ndjson", `{"id":1,"name":"alice"}` + "\n" + `{"id":2,"name":"=HYPERLINK(\"x\")"}` + "\n" + `{"id":3,"name":"bob, jr"}` + "\n"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			if err := Write(rec, tt.format, "users", columns, rowsOf(nil, tt.rows...)); err != nil {
+				t.Fatalf("Write() error = %v", err)
+			}
+			if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != tt.contentType {
+				t.Errorf("status = %d, Content-Type = %q", rec.Code, rec.Header().Get("Content-Type"))
+			}
+			if got := rec.Body.String(); got != tt.body {
+				t.Errorf("body =\n%s\nwant\n%s", got, tt.body)
+			}
+		})
+	}
+}
+
+func TestWrite_ErrorBeforeFirstRow(t *testing.T) {
+	wantErr := errors.New("unavailable")
+	rec := httptest.NewRecorder()
+
+	err := Write(rec, CSV, "users", columns, rowsOf(wantErr))
+
+	if !errors.Is(err, wantErr) {
+		t.Errorf("Write() error = %v, want %v", err, wantErr)
+	}
+	if rec.Body.Len() != 0 || rec.Header().Get("Content-Type") != "" {
+		t.Errorf("Write() wrote %q, want nothing", rec.Body.String())
+	}
+}
+
+func TestWrite_ErrorMidStreamAborts(t *testing.T) {
+	rec := httptest.NewRecorder()
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("recover() = %v, want http.ErrAbortHandler", r)
+		}
+	}()
+
+	Write(rec, NDJSON, "users", columns, rowsOf(errors.New("unavailable"), row{1, "alice"}))
+	t.Error("Write() returned, want it to abort the response")
+}
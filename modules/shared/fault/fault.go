@@ -0,0 +1,114 @@
+// Package fault classifies errors into a small taxonomy that is attached by
+// wrapping, so that adapters can decide how to answer or whether to alert
+// without knowing every module's sentinel errors, and defines the Reporter
+// port through which unexpected failures are reported (e.g. to Sentry).
+package fault
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// Kind classifies an error.
+type Kind int
+
+const (
+	// Unclassified errors are unexpected: nothing on the way up knew what they mean.
+	Unclassified Kind = iota
+	// Client errors are the caller's fault, e.g. invalid input or a missing resource.
+	Client
+	// Conflict errors clash with the current state, e.g. a duplicate or a concurrent update.
+	Conflict
+	// Transient errors are temporary infrastructure failures; retrying may succeed.
+	Transient
+	// Fatal errors cannot be recovered from, e.g. a panic or an invariant violated.
+	Fatal
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Client:
+		return "client"
+	case Conflict:
+		return "conflict"
+	case Transient:
+		return "transient"
+	case Fatal:
+		return "fatal"
+	}
+	return "unclassified"
+}
+
+// Alerting reports whether errors of kind k warrant alerting someone:
+// fatal errors and those nobody classified.
+func (k Kind) Alerting() bool {
+	return k == Unclassified || k == Fatal
+}
+
+// Wrap classifies err as kind. errors.Is and errors.As still see err.
+// It returns nil when err is nil.
+func Wrap(err error, kind Kind) error {
+	if err == nil {
+		return nil
+	}
+	return &classified{err: err, kind: kind}
+}
+
+// KindOf returns the outermost classification in err's chain, or
+// Unclassified when there is none.
+func KindOf(err error) Kind {
+	var c *classified
+	if errors.As(err, &c) {
+		return c.kind
+	}
+	return Unclassified
+}
+
+type classified struct {
+	err  error
+	kind Kind
+}
+
+func (c *classified) Error() string { return c.err.Error() }
+func (c *classified) Unwrap() error { return c.err }
+
+// Report describes an unexpected failure.
+type Report struct {
+	Err    error
+	Kind   Kind
+	Source string            // where it was caught, e.g. "http" or "eventbus"
+	Tags   map[string]string // e.g. the route or the event handler
+	Stack  []byte            // set for panics
+}
+
+// Reporter receives unexpected failures. Implementations must not block for
+// long and must not panic: they run on the failing goroutine.
+type Reporter interface {
+	ReportError(ctx context.Context, r Report)
+}
+
+// LogReporter reports failures as "error reported" records at Error level,
+// for deployments without an error tracking service.
+func LogReporter(logger *slog.Logger) Reporter {
+	return &logReporter{logger: logger}
+}
+
+type logReporter struct {
+	logger *slog.Logger
+}
+
+func (l *logReporter) ReportError(ctx context.Context, r Report) {
+	attrs := []slog.Attr{
+		slog.Any("error", r.Err),
+		slog.String("kind", r.Kind.String()),
+		slog.String("source", r.Source),
+	}
+	for k, v := range r.Tags {
+		attrs = append(attrs, slog.String(k, v))
+	}
+	if len(r.Stack) > 0 {
+		attrs = append(attrs, slog.String("stack", string(r.Stack)))
+	}
+	l.logger.LogAttrs(ctx, slog.LevelError, "error reported", attrs...)
+}
@@ -0,0 +1,69 @@
+package fault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+)
+
+var errTimeout = errors.New("spanner: deadline exceeded")
+
+func TestKindOf(t *testing.T) {
+	transient := Wrap(errTimeout, Transient)
+
+	tests := []struct {
+		name string
+		err  error
+		want Kind
+	}{
+		{"unwrapped", errTimeout, Unclassified},
+		{"wrapped", transient, Transient},
+		{"wrapped further", fmt.Errorf("saving order: %w", transient), Transient},
+		{"outermost wins", Wrap(transient, Fatal), Fatal},
+		{"nil", nil, Unclassified},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KindOf(tt.err); got != tt.want {
+				t.Errorf("KindOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !errors.Is(transient, errTimeout) || transient.Error() != errTimeout.Error() {
+		t.Errorf("Wrap() hides the wrapped error: %v", transient)
+	}
+	if Wrap(nil, Fatal) != nil {
+		t.Error("Wrap(nil) != nil")
+	}
+}
+
+func TestKind_Alerting(t *testing.T) {
+	for kind, want := range map[Kind]bool{Unclassified: true, Client: false, Conflict: false, Transient: false, Fatal: true} {
+		if got := kind.Alerting(); got != want {
+			t.Errorf("%v.Alerting() = %v, want %v", kind, got, want)
+		}
+	}
+}
+
+func TestLogReporter(t *testing.T) {
+	var buf bytes.Buffer
+	LogReporter(slog.New(slog.NewJSONHandler(&buf, nil))).ReportError(context.Background(), Report{
+		Err:    errTimeout,
+		Kind:   Fatal,
+		Source: "http",
+		Tags:   map[string]string{"route": "POST /orders"},
+	})
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("unmarshal log record: %v", err)
+	}
+	if rec["level"] != "ERROR" || rec["kind"] != "fatal" || rec["source"] != "http" || rec["route"] != "POST /orders" {
+		t.Errorf("log record = %v", rec)
+	}
+}
@@ -0,0 +1,46 @@
+// Package features is the port through which modules consult feature flags.
+// A flag is declared by the module that owns it, named "<module>.<name>"
+// (e.g. "orders.returns"), together with the value used when no provider
+// knows it. Providers are implemented in internal/platform/featureflags.
+package features
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/fault"
+)
+
+// ErrDisabled is returned by Require when a flag is off.
+var ErrDisabled = errors.New("feature disabled")
+
+// Flag is a feature flag.
+type Flag struct {
+	Name    string
+	Default bool // value when no provider knows the flag
+}
+
+// Flags evaluates feature flags. Implementations may target by anything
+// carried in ctx.
+type Flags interface {
+	Enabled(ctx context.Context, flag Flag) bool
+}
+
+// Enabled reports whether flag is on. It returns the flag's default when
+// flags is nil.
+func Enabled(ctx context.Context, flags Flags, flag Flag) bool {
+	if flags == nil {
+		return flag.Default
+	}
+	return flags.Enabled(ctx, flag)
+}
+
+// Require returns an error wrapping ErrDisabled, classified as a client
+// error, when flag is off.
+func Require(ctx context.Context, flags Flags, flag Flag) error {
+	if Enabled(ctx, flags, flag) {
+		return nil
+	}
+	return fault.Wrap(fmt.Errorf("%w: %s", ErrDisabled, flag.Name), fault.Client)
+}
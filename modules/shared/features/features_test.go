@@ -0,0 +1,33 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/fault"
+)
+
+type fixedFlags bool
+
+func (f fixedFlags) Enabled(context.Context, Flag) bool { return bool(f) }
+
+func TestRequire(t *testing.T) {
+	ctx := context.Background()
+	off := Flag{Name: "orders.returns"}
+	on := Flag{Name: "orders.returns", Default: true}
+
+	if err := Require(ctx, nil, on); err != nil {
+		t.Errorf("Require(nil flags, default on) error = %v", err)
+	}
+	err := Require(ctx, nil, off)
+	if !errors.Is(err, ErrDisabled) || fault.KindOf(err) != fault.Client {
+		t.Errorf("Require(nil flags, default off) error = %v, want client ErrDisabled", err)
+	}
+	if err := Require(ctx, fixedFlags(true), off); err != nil {
+		t.Errorf("Require(enabled) error = %v", err)
+	}
+	if err := Require(ctx, fixedFlags(false), on); !errors.Is(err, ErrDisabled) {
+		t.Errorf("Require(disabled) error = %v, want ErrDisabled", err)
+	}
+}
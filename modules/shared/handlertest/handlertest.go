@@ -0,0 +1,139 @@
+// Package handlertest helps test the modules' HTTP handlers: it builds
+// requests, serves them through a mux, and compares responses with expected
+// JSON or with golden files.
+//
+// Golden files live in the testdata directory of the package under test and
+// hold the status line and the indented JSON body of a response. Run the
+// tests with -update to rewrite them after an intended change, and review
+// the diff:
+//
+//	go test ./modules/orders/infrastructure/http/... -update
+package handlertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of handler tests")
+
+// NewRequest builds a request to target. body is sent as is when it is a
+// string, encoded as JSON otherwise, and omitted when nil.
+func NewRequest(t testing.TB, method, target string, body any) *http.Request {
+	t.Helper()
+
+	var r io.Reader
+	switch b := body.(type) {
+	case nil:
+	case string:
+		r = strings.NewReader(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Fatalf("encoding request body: %v", err)
+		}
+		r = bytes.NewReader(data)
+	}
+
+	req := httptest.NewRequest(method, target, r)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req
+}
+
+// Serve serves req with h and returns the recorded response.
+func Serve(h http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// AssertStatus fails the test if the response status is not want.
+func AssertStatus(t testing.TB, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rec.Code != want {
+		t.Errorf("status = %d, want %d; body = %s", rec.Code, want, rec.Body)
+	}
+}
+
+// AssertJSON fails the test if the response is not JSON equal to want,
+// ignoring formatting and key order.
+func AssertJSON(t testing.TB, rec *httptest.ResponseRecorder, want string) {
+	t.Helper()
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+
+	var got, expected any
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("response is not JSON: %v; body = %s", err, rec.Body)
+	}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatalf("expected value is not JSON: %v", err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("body = %s, want %s", strings.TrimSpace(rec.Body.String()), want)
+	}
+}
+
+// AssertGolden fails the test if the response differs from the golden file
+// testdata/<name>.golden, or rewrites the file when run with -update.
+func AssertGolden(t testing.TB, rec *httptest.ResponseRecorder, name string) {
+	t.Helper()
+
+	got, err := format(rec)
+	if err != nil {
+		t.Fatalf("formatting response: %v", err)
+	}
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file (run with -update to create it): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("response differs from %s (run with -update to accept it):\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+// format renders a response as its status line followed by its body,
+// indented when it is JSON.
+func format(rec *httptest.ResponseRecorder) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%d %s\n", rec.Code, http.StatusText(rec.Code))
+
+	body := bytes.TrimSpace(rec.Body.Bytes())
+	if len(body) == 0 {
+		return buf.Bytes(), nil
+	}
+	if json.Valid(body) {
+		if err := json.Indent(&buf, body, "", "  "); err != nil {
+			return nil, err
+		}
+	} else {
+		buf.Write(body)
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
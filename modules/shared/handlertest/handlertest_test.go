@@ -0,0 +1,52 @@
+package handlertest
+
+import (
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestNewRequest_EncodesBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body any
+		want string
+	}{
+		{"none", nil, ""},
+		{"raw", "{not json", "{not json"},
+		{"value", map[string]int{"quantity": 2}, `{"quantity":2}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := NewRequest(t, http.MethodPost, "/orders", tt.body)
+			got, err := io.ReadAll(req.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("body = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"order not found"}` + "\n"))
+	})
+	rec := Serve(h, NewRequest(t, http.MethodGet, "/orders/1", nil))
+
+	AssertStatus(t, rec, http.StatusNotFound)
+	AssertJSON(t, rec, `{ "error": "order not found" }`)
+
+	got, err := format(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "404 Not Found\n{\n  \"error\": \"order not found\"\n}\n"
+	if string(got) != want {
+		t.Errorf("format() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+// Package ids is the port through which modules generate identifiers for
+// aggregates and events. Like the clock, the generator travels in the
+// context: domain code calls New(ctx), and each module installs the
+// generator from its config with WithGenerator or, for commands, Scope.
+// Without one, New falls back to UUIDv7, whose IDs sort by creation time and
+// so make time-ordered storage keys. idstest provides a deterministic
+// implementation for tests.
+package ids
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// Generator generates unique identifiers.
+type Generator interface {
+	// NewID returns a new identifier in the canonical UUID text form.
+	NewID() string
+}
+
+// UUIDv7 generates time-ordered version 7 UUIDs.
+var UUIDv7 Generator = uuidV7{}
+
+type uuidV7 struct{}
+
+func (uuidV7) NewID() string { return uuid.Must(uuid.NewV7()).String() }
+
+type generatorKey struct{}
+
+// WithGenerator returns a context whose New reads g. A nil g leaves ctx as is.
+func WithGenerator(ctx context.Context, g Generator) context.Context {
+	if g == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, generatorKey{}, g)
+}
+
+// FromContext returns the generator in ctx, or UUIDv7 if there is none.
+func FromContext(ctx context.Context) Generator {
+	if g, ok := ctx.Value(generatorKey{}).(Generator); ok {
+		return g
+	}
+	return UUIDv7
+}
+
+// New returns a new identifier from the generator in ctx.
+func New(ctx context.Context) string {
+	return FromContext(ctx).NewID()
+}
+
+// Scope wraps a ScopeWithDomainEvent so that the business logic it runs
+// reads g. It returns scope itself when g is nil.
+func Scope(scope transaction.ScopeWithDomainEvent, g Generator) transaction.ScopeWithDomainEvent {
+	if g == nil {
+		return scope
+	}
+	return generatorScope{scope: scope, generator: g}
+}
+
+type generatorScope struct {
+	scope     transaction.ScopeWithDomainEvent
+	generator Generator
+}
+
+func (s generatorScope) ExecuteWithPublish(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.scope.ExecuteWithPublish(WithGenerator(ctx, s.generator), fn)
+}
@@ -0,0 +1,63 @@
+package ids_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/ids"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/ids/idstest"
+)
+
+type runScope struct{}
+
+func (runScope) ExecuteWithPublish(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func TestNew_DefaultsToTimeOrderedUUIDv7(t *testing.T) {
+	ctx := context.Background()
+	first, second := ids.New(ctx), ids.New(ctx)
+
+	parsed, err := uuid.Parse(first)
+	if err != nil || parsed.Version() != 7 {
+		t.Errorf("New() = %s, want a version 7 UUID", first)
+	}
+	if first >= second {
+		t.Errorf("New() = %s then %s, want increasing IDs", first, second)
+	}
+}
+
+func TestNew_ReadsGeneratorInContext(t *testing.T) {
+	ctx, _ := idstest.Context(context.Background())
+
+	for n := 1; n <= 2; n++ {
+		got := ids.New(ctx)
+		if got != idstest.ID(n) {
+			t.Errorf("New() #%d = %s, want %s", n, got, idstest.ID(n))
+		}
+		if _, err := uuid.Parse(got); err != nil {
+			t.Errorf("New() #%d = %s, not a UUID: %v", n, got, err)
+		}
+	}
+}
+
+func TestScope_InstallsGenerator(t *testing.T) {
+	scope := ids.Scope(runScope{}, idstest.NewSequence())
+
+	var got string
+	err := scope.ExecuteWithPublish(context.Background(), func(ctx context.Context) error {
+		got = ids.New(ctx)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ExecuteWithPublish() error = %v", err)
+	}
+	if got != idstest.ID(1) {
+		t.Errorf("New() in scope = %s, want %s", got, idstest.ID(1))
+	}
+
+	if s := ids.Scope(runScope{}, nil); s != (runScope{}) {
+		t.Errorf("Scope(nil generator) = %T, want the scope itself", s)
+	}
+}
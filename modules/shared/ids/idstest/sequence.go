@@ -0,0 +1,44 @@
+// Package idstest provides a deterministic ids.Generator for tests.
+package idstest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/ids"
+)
+
+// Sequence generates the UUIDs ID(1), ID(2), ... in order. It is safe for
+// concurrent use.
+type Sequence struct {
+	mu   sync.Mutex
+	next int
+}
+
+// NewSequence creates a Sequence starting at ID(1).
+func NewSequence() *Sequence {
+	return &Sequence{next: 1}
+}
+
+// Context returns ctx carrying a new Sequence, with the Sequence.
+//
+//	ctx, _ := idstest.Context(t.Context())
+//	user := domain.NewUser(ctx, email, name) // user.ID().String() == idstest.ID(1)
+func Context(ctx context.Context) (context.Context, *Sequence) {
+	s := NewSequence()
+	return ids.WithGenerator(ctx, s), s
+}
+
+func (s *Sequence) NewID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := ID(s.next)
+	s.next++
+	return id
+}
+
+// ID returns the n-th ID of a Sequence, e.g. for assertions.
+func ID(n int) string {
+	return fmt.Sprintf("00000000-0000-7000-8000-%012d", n)
+}
@@ -0,0 +1,53 @@
+// Package inbox gives event handlers exactly-once processing when their
+// events can be delivered more than once, e.g. when they arrive from an
+// external broker that redelivers on timeouts.
+//
+// Wrap persists every incoming event in the module's inbox before its
+// handler runs, in the handler's transaction: a redelivered event finds
+// its entry and is skipped, and a handler that fails rolls back the entry
+// with its own writes, so the event is processed again on redelivery. The
+// handler must therefore only write through the transaction of ctx; side
+// effects outside of it are not deduplicated (see package idempotent).
+package inbox
+
+import (
+	"context"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// Store persists the events a module received. Implementations are in
+// internal/platform, one table per module.
+type Store interface {
+	// Receive records event for handler and reports whether it is the
+	// first delivery. It must join the read-write transaction of ctx, so
+	// that concurrent deliveries of the same event conflict and the entry
+	// commits or rolls back with the handler's writes.
+	Receive(ctx context.Context, handler string, event events.Event) (first bool, err error)
+}
+
+// Wrap returns h processing each event once per handler name. Duplicates
+// return nil without calling h. A nil store returns h unchanged.
+func Wrap(store Store, txScope transaction.Scope, h events.Handler) events.Handler {
+	if store == nil {
+		return h
+	}
+	return &handler{Handler: h, store: store, txScope: txScope}
+}
+
+type handler struct {
+	events.Handler
+	store   Store
+	txScope transaction.Scope
+}
+
+func (h *handler) Handle(ctx context.Context, event events.Event) error {
+	return h.txScope.Execute(ctx, func(ctx context.Context) error {
+		first, err := h.store.Receive(ctx, h.HandlerName(), event)
+		if err != nil || !first {
+			return err
+		}
+		return h.Handler.Handle(ctx, event)
+	})
+}
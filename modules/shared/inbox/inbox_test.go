@@ -0,0 +1,99 @@
+package inbox_test
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/inbox"
+)
+
+// memoryInbox is a Store whose entries txScope discards when the
+// transaction fails.
+type memoryInbox map[string]bool
+
+func (s memoryInbox) Receive(ctx context.Context, handler string, event events.Event) (bool, error) {
+	key := handler + "/" + event.EventID()
+	if s[key] {
+		return false, nil
+	}
+	s[key] = true
+	return true, nil
+}
+
+type rollbackScope struct{ store memoryInbox }
+
+func (s rollbackScope) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	saved := maps.Clone(s.store)
+	if err := fn(ctx); err != nil {
+		maps.DeleteFunc(s.store, func(key string, _ bool) bool { return !saved[key] })
+		return err
+	}
+	return nil
+}
+
+type countingHandler struct {
+	name  string
+	calls int
+	err   error
+}
+
+func (h *countingHandler) Handle(ctx context.Context, event events.Event) error {
+	h.calls++
+	return h.err
+}
+func (h *countingHandler) HandlerName() string         { return h.name }
+func (h *countingHandler) Subdomain() string           { return "test" }
+func (h *countingHandler) EventType() events.EventType { return "test.ThingHappened" }
+
+func TestWrap_SkipsRedeliveries(t *testing.T) {
+	store := memoryInbox{}
+	first := &countingHandler{name: "FirstHandler"}
+	second := &countingHandler{name: "SecondHandler"}
+	handlers := []events.Handler{
+		inbox.Wrap(store, rollbackScope{store}, first),
+		inbox.Wrap(store, rollbackScope{store}, second),
+	}
+
+	event := events.NewBaseEvent("test.ThingHappened")
+	for range 2 {
+		for _, h := range handlers {
+			if err := h.Handle(context.Background(), event); err != nil {
+				t.Fatalf("Handle() error = %v", err)
+			}
+		}
+	}
+	if first.calls != 1 || second.calls != 1 {
+		t.Errorf("calls = %d, %d, want each handler called once", first.calls, second.calls)
+	}
+	if name := handlers[0].HandlerName(); name != "FirstHandler" {
+		t.Errorf("HandlerName() = %q, want FirstHandler", name)
+	}
+}
+
+func TestWrap_FailureProcessesAgain(t *testing.T) {
+	store := memoryInbox{}
+	h := &countingHandler{name: "FlakyHandler", err: errors.New("unavailable")}
+	wrapped := inbox.Wrap(store, rollbackScope{store}, h)
+
+	event := events.NewBaseEvent("test.ThingHappened")
+	if err := wrapped.Handle(context.Background(), event); !errors.Is(err, h.err) {
+		t.Fatalf("Handle() error = %v, want %v", err, h.err)
+	}
+	h.err = nil
+	if err := wrapped.Handle(context.Background(), event); err != nil {
+		t.Fatalf("Handle() on redelivery error = %v", err)
+	}
+	if h.calls != 2 {
+		t.Errorf("calls = %d, want 2", h.calls)
+	}
+}
+
+func TestWrap_NilStore(t *testing.T) {
+	h := &countingHandler{name: "PlainHandler"}
+	if got := inbox.Wrap(nil, rollbackScope{}, h); got != events.Handler(h) {
+		t.Errorf("Wrap(nil) = %v, want the handler unchanged", got)
+	}
+}
@@ -0,0 +1,16 @@
+// Package invariant is the contract by which aggregates check the
+// invariants that hold across their fields, whatever sequence of business
+// methods produced them.
+//
+// Business methods keep an aggregate valid, so a violation is a bug in them
+// or corrupt stored data. Repositories catch both at the persistence
+// boundary: they refuse to save an aggregate that fails Validate, and report
+// one that fails it after reconstitution as a corrupt row rather than hand it
+// to the application.
+package invariant
+
+// Validatable is an aggregate that can check its invariants. Validate
+// returns the first violation found, or nil.
+type Validatable interface {
+	Validate() error
+}
@@ -0,0 +1,37 @@
+// Package lifecycle defines the hooks through which the composition root
+// starts a module's background work (retry jobs, expiry jobs, indexers) once
+// everything is wired, and stops it during graceful shutdown.
+package lifecycle
+
+import "context"
+
+// Hooks is implemented by modules with background work.
+type Hooks interface {
+	// Start launches the module's background workers. They run until
+	// Shutdown, not until ctx is done.
+	Start(ctx context.Context) error
+
+	// Shutdown stops the workers and flushes pending work. It returns
+	// ctx.Err() if ctx is done first; the workers then finish on their own.
+	Shutdown(ctx context.Context) error
+}
+
+// Stop calls each non-nil stop function in order and waits for them to
+// return or for ctx to be done, whichever comes first.
+func Stop(ctx context.Context, stops ...func()) error {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, stop := range stops {
+			if stop != nil {
+				stop()
+			}
+		}
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,35 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStop_CallsStopFunctionsInOrder(t *testing.T) {
+	var calls []string
+	err := Stop(context.Background(),
+		func() { calls = append(calls, "jobs") },
+		nil,
+		func() { calls = append(calls, "flush") },
+	)
+	if err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "jobs" || calls[1] != "flush" {
+		t.Errorf("calls = %v, want [jobs flush]", calls)
+	}
+}
+
+func TestStop_GivesUpWhenContextIsDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	release := make(chan struct{})
+	defer close(release)
+
+	err := Stop(ctx, func() { <-release })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Stop() error = %v, want context.DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,348 @@
+// Package openapi builds the OpenAPI 3 document of the HTTP API, code first:
+// each module describes its routes as Operations next to RegisterRoutes
+// (infrastructure/http/openapi.go), with the same patterns and the DTOs the
+// handlers decode and encode, and the application assembles and serves them
+// at GET /openapi.json.
+//
+// Request and response schemas are derived from the DTO types by reflection,
+// following encoding/json: exported fields named by their json tags, fields
+// without omitempty required. `make validate-openapi` checks that every
+// route registered by a module is documented, and the other way round.
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AdminTokenHeader is documented as the security scheme of admin operations.
+// It matches security.AdminTokenHeader.
+const AdminTokenHeader = "X-Admin-Token"
+
+// Operation describes one route.
+type Operation struct {
+	Pattern     string // the route's http.ServeMux pattern, e.g. "GET /orders/{id}"
+	Summary     string
+	Description string
+	Admin       bool    // requires the admin token
+	Query       []Param // query string parameters; path parameters come from Pattern
+	Header      []Param // request header parameters
+	Request     any     // a value of the request body type, nil for none
+	Status      int     // the success status; zero means 200
+	Response    any     // a value of the success response body type, nil for none
+}
+
+// Param is a query string or header parameter.
+type Param struct {
+	Name        string
+	Description string
+	Type        string // JSON schema type; empty means string
+	Required    bool
+}
+
+// IfMatch documents the If-Match header of the writes guarded by
+// etag.IfMatch.
+var IfMatch = Param{
+	Name:        "If-Match",
+	Description: "The ETag of the resource as last read; 412 if it has changed since, 428 if missing. * skips the check.",
+	Required:    true,
+}
+
+// Document is an OpenAPI 3 document under construction.
+type Document struct {
+	title, version string
+	tags           []string
+	ops            map[string]map[string]operation // path -> method -> operation
+}
+
+type operation struct {
+	tag string
+	Operation
+}
+
+// New returns an empty document for the API title and version.
+func New(title, version string) *Document {
+	return &Document{title: title, version: version, ops: make(map[string]map[string]operation)}
+}
+
+// Add documents the operations of a module, grouped under tag.
+func (d *Document) Add(tag string, ops ...Operation) {
+	if len(ops) > 0 && !slices.Contains(d.tags, tag) {
+		d.tags = append(d.tags, tag)
+	}
+	for _, op := range ops {
+		method, path, _ := strings.Cut(op.Pattern, " ")
+		if d.ops[path] == nil {
+			d.ops[path] = make(map[string]operation)
+		}
+		d.ops[path][strings.ToLower(method)] = operation{tag: tag, Operation: op}
+	}
+}
+
+// Patterns returns the patterns of the documented operations, sorted.
+func (d *Document) Patterns() []string {
+	var patterns []string
+	for _, methods := range d.ops {
+		for _, op := range methods {
+			patterns = append(patterns, op.Pattern)
+		}
+	}
+	slices.Sort(patterns)
+	return patterns
+}
+
+// MarshalJSON renders the document as OpenAPI 3.0 JSON.
+func (d *Document) MarshalJSON() ([]byte, error) {
+	tags := make([]map[string]string, 0, len(d.tags))
+	for _, tag := range d.tags {
+		tags = append(tags, map[string]string{"name": tag})
+	}
+
+	paths := make(map[string]map[string]any, len(d.ops))
+	for path, methods := range d.ops {
+		item := make(map[string]any, len(methods))
+		for method, op := range methods {
+			item[method] = op.render(path)
+		}
+		paths[path] = item
+	}
+
+	return json.Marshal(map[string]any{
+		"openapi": "3.0.3",
+		"info":    map[string]string{"title": d.title, "version": d.version},
+		"tags":    tags,
+		"paths":   paths,
+		"components": map[string]any{
+			"securitySchemes": map[string]any{
+				"adminToken": map[string]string{"type": "apiKey", "in": "header", "name": AdminTokenHeader},
+			},
+			"schemas": map[string]any{
+				"Error": Schema(struct {
+					Error string `json:"error"`
+				}{}),
+			},
+		},
+	})
+}
+
+func (op operation) render(path string) map[string]any {
+	out := map[string]any{
+		"tags":        []string{op.tag},
+		"summary":     op.Summary,
+		"operationId": operationID(op.Pattern),
+	}
+	if op.Description != "" {
+		out["description"] = op.Description
+	}
+
+	var params []map[string]any
+	for _, name := range pathParams(path) {
+		params = append(params, map[string]any{"name": name, "in": "path", "required": true, "schema": map[string]string{"type": "string"}})
+	}
+	for _, p := range op.Query {
+		params = append(params, p.render("query"))
+	}
+	for _, p := range op.Header {
+		params = append(params, p.render("header"))
+	}
+	if len(params) > 0 {
+		out["parameters"] = params
+	}
+
+	if op.Request != nil {
+		out["requestBody"] = map[string]any{"required": true, "content": jsonContent(Schema(op.Request))}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	success := map[string]any{"description": http.StatusText(status)}
+	if op.Response != nil {
+		success["content"] = jsonContent(Schema(op.Response))
+	}
+	errorResponse := map[string]any{"description": "Error", "content": jsonContent(map[string]any{"$ref": "#/components/schemas/Error"})}
+	responses := map[string]any{strconv.Itoa(status): success, "default": errorResponse}
+	if op.Admin {
+		responses["403"] = map[string]any{"description": "Admin access required", "content": jsonContent(map[string]any{"$ref": "#/components/schemas/Error"})}
+		out["security"] = []map[string][]string{{"adminToken": {}}}
+	}
+	out["responses"] = responses
+	return out
+}
+
+func (p Param) render(in string) map[string]any {
+	typ := p.Type
+	if typ == "" {
+		typ = "string"
+	}
+	param := map[string]any{"name": p.Name, "in": in, "required": p.Required, "schema": map[string]string{"type": typ}}
+	if p.Description != "" {
+		param["description"] = p.Description
+	}
+	return param
+}
+
+func jsonContent(schema any) map[string]any {
+	return map[string]any{"application/json": map[string]any{"schema": schema}}
+}
+
+// operationID derives an operation ID from a pattern, e.g.
+// "GET /orders/{id}/items" becomes "get_orders_id_items".
+func operationID(pattern string) string {
+	words := strings.FieldsFunc(strings.ToLower(pattern), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	return strings.Join(words, "_")
+}
+
+func pathParams(path string) []string {
+	var names []string
+	for segment := range strings.SplitSeq(path, "/") {
+		if name, ok := strings.CutPrefix(segment, "{"); ok {
+			names = append(names, strings.TrimSuffix(strings.TrimSuffix(name, "}"), "..."))
+		}
+	}
+	return names
+}
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+	marshalerType  = reflect.TypeFor[json.Marshaler]()
+)
+
+// Schema returns the JSON schema of v's type as encoding/json renders it.
+// Types with their own MarshalJSON are described as free-form values.
+func Schema(v any) map[string]any {
+	return schemaOf(reflect.TypeOf(v), nil)
+}
+
+func schemaOf(t reflect.Type, seen []reflect.Type) map[string]any {
+	if t == nil {
+		return map[string]any{}
+	}
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case rawMessageType:
+		return map[string]any{}
+	}
+	if t.Kind() != reflect.Pointer && t.Implements(marshalerType) {
+		return map[string]any{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := schemaOf(t.Elem(), seen)
+		s["nullable"] = true
+		return s
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]any{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": schemaOf(t.Elem(), seen)}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaOf(t.Elem(), seen)}
+	case reflect.Struct:
+		if slices.Contains(seen, t) {
+			return map[string]any{"type": "object"} // recursive type
+		}
+		return structSchema(t, append(seen, t))
+	}
+	return map[string]any{}
+}
+
+func structSchema(t reflect.Type, seen []reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	for f := range t.Fields() {
+		if !f.IsExported() && !f.Anonymous {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			// Embedded struct fields are promoted.
+			embedded := structSchema(f.Type, seen)
+			for k, v := range embedded["properties"].(map[string]any) {
+				properties[k] = v
+			}
+			if r, ok := embedded["required"].([]string); ok {
+				required = append(required, r...)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		properties[name] = schemaOf(f.Type, seen)
+		if !strings.Contains(opts, "omitempty") && !strings.Contains(opts, "omitzero") && f.Type.Kind() != reflect.Pointer {
+			required = append(required, name)
+		}
+	}
+	s := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		slices.Sort(required)
+		s["required"] = required
+	}
+	return s
+}
+
+// Handler serves the document as JSON.
+func Handler(d *Document) http.Handler {
+	data, err := json.Marshal(d)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+}
+
+// UIHandler serves a Swagger UI page for the document at specURL. The UI is
+// loaded from a CDN, so it is meant for development only.
+func UIHandler(specURL string) http.Handler {
+	page := strings.ReplaceAll(uiPage, "{{SPEC_URL}}", specURL)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(page))
+	})
+}
+
+const uiPage = `<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <title>API documentation</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>SwaggerUIBundle({url: "{{SPEC_URL}}", dom_id: "#swagger-ui"});</script>
+</body>
+</html>
+`
@@ -0,0 +1,118 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type itemDTO struct {
+	ProductID string `json:"product_id"`
+	Quantity  int    `json:"quantity"`
+}
+
+type orderDTO struct {
+	ID        string            `json:"id"`
+	Note      string            `json:"note,omitempty"`
+	Items     []itemDTO         `json:"items"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Total     int64             `json:"total"`
+	ExpiresAt *time.Time        `json:"expires_at"`
+	CreatedAt time.Time         `json:"created_at"`
+	internal  string
+}
+
+func TestSchema(t *testing.T) {
+	got := Schema(orderDTO{})
+
+	want := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"id":   map[string]any{"type": "string"},
+			"note": map[string]any{"type": "string"},
+			"items": map[string]any{"type": "array", "items": map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"product_id": map[string]any{"type": "string"},
+					"quantity":   map[string]any{"type": "integer", "format": "int32"},
+				},
+				"required": []string{"product_id", "quantity"},
+			}},
+			"labels":     map[string]any{"type": "object", "additionalProperties": map[string]any{"type": "string"}},
+			"total":      map[string]any{"type": "integer", "format": "int64"},
+			"expires_at": map[string]any{"type": "string", "format": "date-time", "nullable": true},
+			"created_at": map[string]any{"type": "string", "format": "date-time"},
+		},
+		"required": []string{"created_at", "id", "items", "total"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		gotJSON, _ := json.MarshalIndent(got, "", "  ")
+		t.Errorf("Schema() =\n%s", gotJSON)
+	}
+}
+
+func TestDocument(t *testing.T) {
+	doc := New("shop", "1.0.0")
+	doc.Add("orders",
+		Operation{Pattern: "GET /orders/{id}/items/{productId}", Summary: "Get an item", Response: itemDTO{}},
+		Operation{Pattern: "POST /orders/{id}/refund", Summary: "Refund an order", Admin: true, Status: http.StatusNoContent,
+			Header: []Param{{Name: "If-Match", Required: true}}},
+	)
+	doc.Add("users")
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var spec struct {
+		OpenAPI string           `json:"openapi"`
+		Tags    []map[string]any `json:"tags"`
+		Paths   map[string]map[string]struct {
+			OperationID string                     `json:"operationId"`
+			Parameters  []map[string]any           `json:"parameters"`
+			Security    []map[string][]string      `json:"security"`
+			Responses   map[string]json.RawMessage `json:"responses"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if spec.OpenAPI != "3.0.3" || len(spec.Tags) != 1 {
+		t.Errorf("openapi = %q, tags = %v; want 3.0.3 and only the orders tag", spec.OpenAPI, spec.Tags)
+	}
+	get := spec.Paths["/orders/{id}/items/{productId}"]["get"]
+	if get.OperationID != "get_orders_id_items_productid" || len(get.Parameters) != 2 || get.Parameters[1]["name"] != "productId" {
+		t.Errorf("GET operation = %+v, want both path parameters", get)
+	}
+	if _, ok := get.Responses["200"]; !ok || get.Security != nil {
+		t.Errorf("GET responses = %v, security = %v; want 200 and no security", get.Responses, get.Security)
+	}
+	refund := spec.Paths["/orders/{id}/refund"]["post"]
+	if _, ok := refund.Responses["204"]; !ok {
+		t.Errorf("POST responses = %v, want 204", refund.Responses)
+	}
+	if len(refund.Parameters) != 2 || refund.Parameters[1]["in"] != "header" || refund.Parameters[1]["required"] != true {
+		t.Errorf("POST parameters = %v, want the path parameter and a required If-Match header", refund.Parameters)
+	}
+	if _, ok := refund.Responses["403"]; !ok || len(refund.Security) != 1 {
+		t.Errorf("admin operation responses = %v, security = %v; want 403 and the admin token", refund.Responses, refund.Security)
+	}
+
+	if got := doc.Patterns(); !reflect.DeepEqual(got, []string{"GET /orders/{id}/items/{productId}", "POST /orders/{id}/refund"}) {
+		t.Errorf("Patterns() = %v", got)
+	}
+}
+
+func TestUIHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	UIHandler("/openapi.json").ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+	if !strings.Contains(rec.Body.String(), `url: "/openapi.json"`) {
+		t.Errorf("UI page does not load the spec:\n%s", rec.Body.String())
+	}
+}
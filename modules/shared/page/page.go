@@ -0,0 +1,68 @@
+// Package page is the response envelope of paginated list endpoints: the
+// items, their position in the whole list, and HAL-style links to this page
+// and its neighbors, so that clients follow links instead of computing
+// offsets. Items carry their own links too, e.g. the actions their state
+// allows:
+//
+//	{
+//	  "items": [{"id": "...", "_links": {"self": {"href": "/orders/..."}}}],
+//	  "pagination": {"offset": 20, "limit": 20, "total_count": 45},
+//	  "_links": {
+//	    "self": {"href": "/users/.../orders?limit=20&offset=20"},
+//	    "next": {"href": "/users/.../orders?limit=20&offset=40"},
+//	    "prev": {"href": "/users/.../orders?limit=20&offset=0"}
+//	  }
+//	}
+package page
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Link is a link to a resource, or to an action on it when Method is set.
+type Link struct {
+	Href   string `json:"href"`
+	Method string `json:"method,omitempty"` // omitted for GET
+}
+
+// Links are links by relation, e.g. "self", "next", "cancel".
+type Links map[string]Link
+
+// Pagination locates a page in the whole list.
+type Pagination struct {
+	Offset     int `json:"offset"`
+	Limit      int `json:"limit"`
+	TotalCount int `json:"total_count"`
+}
+
+// Page is a page of items.
+type Page[T any] struct {
+	Items      []T        `json:"items"`
+	Pagination Pagination `json:"pagination"`
+	Links      Links      `json:"_links"`
+}
+
+// New returns the page of items at p of the list requested by r. Its links
+// keep r's other query parameters.
+func New[T any](r *http.Request, items []T, p Pagination) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	links := Links{"self": {Href: href(r, p.Offset, p.Limit)}}
+	if p.Limit > 0 && p.Offset+p.Limit < p.TotalCount {
+		links["next"] = Link{Href: href(r, p.Offset+p.Limit, p.Limit)}
+	}
+	if p.Offset > 0 {
+		links["prev"] = Link{Href: href(r, max(p.Offset-p.Limit, 0), p.Limit)}
+	}
+	return Page[T]{Items: items, Pagination: p, Links: links}
+}
+
+func href(r *http.Request, offset, limit int) string {
+	q := r.URL.Query()
+	q.Set("offset", strconv.Itoa(offset))
+	q.Set("limit", strconv.Itoa(limit))
+	return (&url.URL{Path: r.URL.Path, RawQuery: q.Encode()}).String()
+}
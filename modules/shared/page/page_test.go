@@ -0,0 +1,60 @@
+package page
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNew_Links(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Pagination
+		want Links
+	}{
+		{"first of several", Pagination{Offset: 0, Limit: 20, TotalCount: 45}, Links{
+			"self": {Href: "/orders?limit=20&offset=0&status=draft"},
+			"next": {Href: "/orders?limit=20&offset=20&status=draft"},
+		}},
+		{"middle", Pagination{Offset: 20, Limit: 20, TotalCount: 45}, Links{
+			"self": {Href: "/orders?limit=20&offset=20&status=draft"},
+			"next": {Href: "/orders?limit=20&offset=40&status=draft"},
+			"prev": {Href: "/orders?limit=20&offset=0&status=draft"},
+		}},
+		{"last", Pagination{Offset: 40, Limit: 20, TotalCount: 45}, Links{
+			"self": {Href: "/orders?limit=20&offset=40&status=draft"},
+			"prev": {Href: "/orders?limit=20&offset=20&status=draft"},
+		}},
+		{"unaligned offset", Pagination{Offset: 5, Limit: 20, TotalCount: 10}, Links{
+			"self": {Href: "/orders?limit=20&offset=5&status=draft"},
+			"prev": {Href: "/orders?limit=20&offset=0&status=draft"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/orders?status=draft&offset=999", nil)
+
+			got := New(r, []string{"a"}, tt.p)
+
+			if !reflect.DeepEqual(got.Links, tt.want) {
+				t.Errorf("Links = %v, want %v", got.Links, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew_EmptyItems(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/users", nil)
+
+	data, err := json.Marshal(New[string](r, nil, Pagination{Limit: 20}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"items":[],"pagination":{"offset":0,"limit":20,"total_count":0},"_links":{"self":{"href":"/users?limit=20\u0026offset=0"}}}`
+	if string(data) != want {
+		t.Errorf("JSON = %s, want %s", data, want)
+	}
+}
@@ -0,0 +1,74 @@
+package page
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Page size bounds of every list query.
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Request is the page a list query asks for.
+type Request struct {
+	Offset int
+	Limit  int
+}
+
+// NewRequest returns the page at offset of at most limit items, within the
+// bounds of list queries: a negative offset starts at the first item, a
+// limit of zero or less is DefaultLimit, and one above MaxLimit is MaxLimit.
+func NewRequest(offset, limit int) Request {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	return Request{Offset: max(offset, 0), Limit: min(limit, MaxLimit)}
+}
+
+// ParseRequest reads the offset and limit query parameters as given, to be
+// bounded by NewRequest in the query handler (exports use the unbounded
+// limit). Missing or malformed values are zero.
+func ParseRequest(q url.Values) Request {
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	return Request{Offset: offset, Limit: limit}
+}
+
+// ErrSortInvalid is returned by ParseSort for a field the list cannot be
+// sorted by.
+var ErrSortInvalid = errors.New("invalid sort field")
+
+// Sort is the order of a list: by Field, ascending unless Desc. The zero
+// Sort is the list's default order.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// String returns s in the syntax of ParseSort.
+func (s Sort) String() string {
+	if s.Desc {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// ParseSort parses the value of a sort query parameter: a field name,
+// prefixed with "-" for descending order, e.g. "-created_at". The field must
+// be one of fields; the empty value is the zero Sort.
+func ParseSort(value string, fields ...string) (Sort, error) {
+	if value == "" {
+		return Sort{}, nil
+	}
+	field, desc := strings.CutPrefix(value, "-")
+	if !slices.Contains(fields, field) {
+		return Sort{}, fmt.Errorf("%w %q: must be one of %s, optionally prefixed with '-'", ErrSortInvalid, field, strings.Join(fields, ", "))
+	}
+	return Sort{Field: field, Desc: desc}, nil
+}
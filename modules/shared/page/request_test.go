@@ -0,0 +1,76 @@
+package page
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestNewRequest(t *testing.T) {
+	tests := []struct {
+		name          string
+		offset, limit int
+		want          Request
+	}{
+		{"given", 40, 10, Request{Offset: 40, Limit: 10}},
+		{"default limit", 0, 0, Request{Offset: 0, Limit: DefaultLimit}},
+		{"negative limit", 0, -1, Request{Offset: 0, Limit: DefaultLimit}},
+		{"limit above max", 0, 1000, Request{Offset: 0, Limit: MaxLimit}},
+		{"negative offset", -5, 10, Request{Offset: 0, Limit: 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewRequest(tt.offset, tt.limit); got != tt.want {
+				t.Errorf("NewRequest(%d, %d) = %+v, want %+v", tt.offset, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRequest(t *testing.T) {
+	tests := []struct {
+		query string
+		want  Request
+	}{
+		{"offset=20&limit=500", Request{Offset: 20, Limit: 500}},
+		{"", Request{}},
+		{"offset=abc&limit=", Request{}},
+	}
+	for _, tt := range tests {
+		q, _ := url.ParseQuery(tt.query)
+		if got := ParseRequest(q); got != tt.want {
+			t.Errorf("ParseRequest(%q) = %+v, want %+v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	fields := []string{"created_at", "email"}
+	tests := []struct {
+		value   string
+		want    Sort
+		wantErr bool
+	}{
+		{"", Sort{}, false},
+		{"email", Sort{Field: "email"}, false},
+		{"-created_at", Sort{Field: "created_at", Desc: true}, false},
+		{"password", Sort{}, true},
+		{"-", Sort{}, true},
+		{"--email", Sort{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseSort(tt.value, fields...)
+		if tt.wantErr {
+			if !errors.Is(err, ErrSortInvalid) {
+				t.Errorf("ParseSort(%q) error = %v, want ErrSortInvalid", tt.value, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseSort(%q) = %+v, %v, want %+v", tt.value, got, err, tt.want)
+		}
+		if got.String() != tt.value {
+			t.Errorf("ParseSort(%q).String() = %q", tt.value, got.String())
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package projection
+
+import (
+	"context"
+	"time"
+)
+
+// Checkpoint records the progress of a projection's rebuild.
+type Checkpoint struct {
+	Projection string
+	// Rebuilding is set from the reset of a rebuild until its last batch.
+	Rebuilding bool
+	// Cursor is where the rebuild in progress resumes; "" before its
+	// first batch.
+	Cursor string
+	// RebuiltAt is when the last rebuild completed; zero if none did.
+	RebuiltAt time.Time
+	UpdatedAt time.Time
+}
+
+// CheckpointStore persists checkpoints. Implementations are in
+// internal/platform.
+type CheckpointStore interface {
+	// Load returns the checkpoint of projection, or a zero Checkpoint for
+	// it if none was saved.
+	Load(ctx context.Context, projection string) (Checkpoint, error)
+	// Save inserts or replaces a checkpoint, in the transaction of ctx if
+	// there is one.
+	Save(ctx context.Context, checkpoint Checkpoint) error
+}
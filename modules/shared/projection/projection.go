@@ -0,0 +1,99 @@
+// Package projection is the framework for read models built from domain
+// events. A module implements Projection for each read model, subscribes
+// it with Subscribe or SubscribePostCommit, and, when the read model can be
+// derived from the module's own state, implements Rebuildable so that
+// RebuildHandler can rebuild it, recording its progress in a
+// CheckpointStore.
+//
+// There is no event store to replay, so a rebuild does not re-apply past
+// events: it resets the read model and backfills it from the source of
+// truth a batch at a time, while live events keep being applied. Apply must
+// therefore tolerate rows the backfill has not written yet, and Backfill
+// must overwrite rows rather than add to them.
+package projection
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// Projection keeps a read model in sync with domain events.
+type Projection interface {
+	// Name identifies the projection as "<module>.<ReadModel>", e.g.
+	// "orders.OrderSummary".
+	Name() string
+	// EventTypes lists the events the projection consumes.
+	EventTypes() []events.EventType
+	// Apply updates the read model with event, in its own transaction.
+	Apply(ctx context.Context, event events.Event) error
+}
+
+// Rebuildable is a Projection that can be rebuilt from the state it
+// projects.
+type Rebuildable interface {
+	Projection
+	// Reset deletes the read model of the tenant of ctx. It runs outside of
+	// a transaction.
+	Reset(ctx context.Context) error
+	// Backfill writes the read model of the tenant of ctx for up to limit of
+	// the tenant's source entities after cursor ("" for the first batch) and
+	// returns the cursor of the next batch, or "" after the last one. It runs
+	// in the rebuild's transaction.
+	Backfill(ctx context.Context, cursor string, limit int) (next string, err error)
+}
+
+// Module returns the module of a projection name: "orders" for
+// "orders.OrderSummary".
+func Module(name string) string {
+	module, _, _ := strings.Cut(name, ".")
+	return module
+}
+
+// Handlers returns the event handlers of p, one per event type. They are
+// named after the read model, e.g. "OrderSummaryProjector".
+func Handlers(p Projection) []events.Handler {
+	module, readModel, _ := strings.Cut(p.Name(), ".")
+	handlers := make([]events.Handler, len(p.EventTypes()))
+	for i, eventType := range p.EventTypes() {
+		handlers[i] = &handler{projection: p, module: module, name: readModel + "Projector", eventType: eventType}
+	}
+	return handlers
+}
+
+// Subscribe subscribes p to run pre-commit, so that the read model is
+// updated atomically with the change that raised the event.
+func Subscribe(sub events.Subscriber, p Projection) error {
+	var errs []error
+	for _, h := range Handlers(p) {
+		errs = append(errs, sub.Subscribe(h.EventType(), h))
+	}
+	return errors.Join(errs...)
+}
+
+// SubscribePostCommit subscribes p to run post-commit, so that it never
+// slows down or fails the change that raised the event.
+func SubscribePostCommit(sub events.PostCommitSubscriber, p Projection) error {
+	var errs []error
+	for _, h := range Handlers(p) {
+		errs = append(errs, sub.SubscribePostCommit(h.EventType(), h))
+	}
+	return errors.Join(errs...)
+}
+
+type handler struct {
+	projection Projection
+	module     string
+	name       string
+	eventType  events.EventType
+}
+
+func (h *handler) HandlerName() string         { return h.name }
+func (h *handler) Subdomain() string           { return h.module }
+func (h *handler) EventType() events.EventType { return h.eventType }
+
+func (h *handler) Handle(ctx context.Context, event events.Event) error {
+	return h.projection.Apply(ctx, event)
+}
@@ -0,0 +1,35 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+type subscriptions map[events.EventType]events.Handler
+
+func (s subscriptions) Subscribe(eventType events.EventType, h events.Handler) error {
+	s[eventType] = h
+	return nil
+}
+
+func TestSubscribe_OneHandlerPerEventType(t *testing.T) {
+	sub := subscriptions{}
+	if err := Subscribe(sub, &counter{}); err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	h, ok := sub["test.Counted"]
+	if len(sub) != 1 || !ok {
+		t.Fatalf("subscriptions = %v, want test.Counted", sub)
+	}
+	if h.HandlerName() != "CounterProjector" || h.Subdomain() != "test" || h.EventType() != "test.Counted" {
+		t.Errorf("handler = %s/%s for %s, want CounterProjector/test for test.Counted", h.HandlerName(), h.Subdomain(), h.EventType())
+	}
+}
+
+func TestModule(t *testing.T) {
+	if got := Module("orders.OrderSummary"); got != "orders" {
+		t.Errorf("Module() = %q, want orders", got)
+	}
+}
@@ -0,0 +1,122 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+)
+
+// BatchSize is the number of source entities a rebuild backfills per
+// transaction.
+const BatchSize = 100
+
+// ErrUnknownProjection is returned for a rebuild of a projection that is
+// not registered with the RebuildHandler.
+var ErrUnknownProjection = errors.New("unknown projection")
+
+// RebuildCommand rebuilds a projection. A rebuild that was interrupted
+// resumes from its checkpoint unless Restart is set.
+type RebuildCommand struct {
+	Projection string
+	Restart    bool
+}
+
+// RebuildResult reports a completed rebuild.
+type RebuildResult struct {
+	// Resumed is true if the rebuild continued an interrupted one.
+	Resumed bool
+	Batches int
+}
+
+// Rebuilder is implemented by the modules that own rebuildable projections,
+// for the admin CLI.
+type Rebuilder interface {
+	RebuildProjection(ctx context.Context, cmd RebuildCommand) (RebuildResult, error)
+}
+
+// RebuildHandler rebuilds the projections of a module.
+type RebuildHandler struct {
+	projections map[string]Rebuildable
+	checkpoints CheckpointStore
+	txScope     transaction.Scope
+}
+
+func NewRebuildHandler(checkpoints CheckpointStore, txScope transaction.Scope, projections ...Rebuildable) *RebuildHandler {
+	h := &RebuildHandler{
+		projections: make(map[string]Rebuildable, len(projections)),
+		checkpoints: checkpoints,
+		txScope:     txScope,
+	}
+	for _, p := range projections {
+		h.projections[p.Name()] = p
+	}
+	return h
+}
+
+// Names returns the names of the projections h can rebuild, sorted.
+func (h *RebuildHandler) Names() []string {
+	return slices.Sorted(maps.Keys(h.projections))
+}
+
+// Handle rebuilds the read model of the tenant of ctx. It resets the
+// projection, unless it resumes an interrupted rebuild, outside of a
+// transaction since a reset may exceed a transaction's mutation limit, then
+// backfills it a batch per transaction, saving the cursor of the next batch
+// in the same transaction as each batch. The transactions are tagged
+// with the projection and run at low priority, yielding to live traffic.
+func (h *RebuildHandler) Handle(ctx context.Context, cmd RebuildCommand) (RebuildResult, error) {
+	p, ok := h.projections[cmd.Projection]
+	if !ok {
+		return RebuildResult{}, fmt.Errorf("%w: %q", ErrUnknownProjection, cmd.Projection)
+	}
+	ctx = transaction.WithHints(ctx, transaction.Hints{Tag: "rebuild " + cmd.Projection, Priority: transaction.PriorityLow})
+
+	cp, err := h.checkpoints.Load(ctx, cmd.Projection)
+	if err != nil {
+		return RebuildResult{}, fmt.Errorf("loading checkpoint: %w", err)
+	}
+	cp.Projection = cmd.Projection
+
+	var result RebuildResult
+	if cp.Rebuilding && !cmd.Restart {
+		result.Resumed = true
+	} else {
+		if err := p.Reset(ctx); err != nil {
+			return result, fmt.Errorf("resetting %s: %w", cmd.Projection, err)
+		}
+		cp.Rebuilding, cp.Cursor, cp.UpdatedAt = true, "", clock.Now(ctx)
+		if err := h.txScope.Execute(ctx, func(ctx context.Context) error {
+			return h.checkpoints.Save(ctx, cp)
+		}); err != nil {
+			return result, fmt.Errorf("saving checkpoint: %w", err)
+		}
+	}
+
+	for cp.Rebuilding {
+		cp, err = transaction.ExecuteWithResult(ctx, h.txScope, func(ctx context.Context) (Checkpoint, error) {
+			next, err := p.Backfill(ctx, cp.Cursor, BatchSize)
+			if err != nil {
+				return Checkpoint{}, fmt.Errorf("backfilling %s: %w", cmd.Projection, err)
+			}
+			batch := cp
+			batch.Cursor, batch.UpdatedAt = next, clock.Now(ctx)
+			if next == "" {
+				batch.Rebuilding, batch.RebuiltAt = false, batch.UpdatedAt
+			}
+			if err := h.checkpoints.Save(ctx, batch); err != nil {
+				return Checkpoint{}, fmt.Errorf("saving checkpoint: %w", err)
+			}
+			return batch, nil
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Batches++
+	}
+	return result, nil
+}
@@ -0,0 +1,122 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+type runScope struct{}
+
+func (runScope) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type memoryCheckpoints map[string]Checkpoint
+
+func (s memoryCheckpoints) Load(ctx context.Context, projection string) (Checkpoint, error) {
+	return s[projection], nil
+}
+
+func (s memoryCheckpoints) Save(ctx context.Context, cp Checkpoint) error {
+	s[cp.Projection] = cp
+	return nil
+}
+
+// counter projects the numbers 0..source-1, BatchSize per batch; failAt
+// makes the batch starting there fail.
+type counter struct {
+	source int
+	failAt int
+	resets int
+	rows   map[int]bool
+}
+
+func (p *counter) Name() string                                        { return "test.Counter" }
+func (p *counter) EventTypes() []events.EventType                      { return []events.EventType{"test.Counted"} }
+func (p *counter) Apply(ctx context.Context, event events.Event) error { return nil }
+
+func (p *counter) Reset(ctx context.Context) error {
+	p.resets++
+	p.rows = map[int]bool{}
+	return nil
+}
+
+func (p *counter) Backfill(ctx context.Context, cursor string, limit int) (string, error) {
+	start, _ := strconv.Atoi(cursor)
+	if p.failAt > 0 && start == p.failAt {
+		return "", errors.New("source unavailable")
+	}
+	end := min(start+limit, p.source)
+	for i := start; i < end; i++ {
+		p.rows[i] = true
+	}
+	if end == p.source {
+		return "", nil
+	}
+	return strconv.Itoa(end), nil
+}
+
+func TestRebuildHandler_RebuildsInBatches(t *testing.T) {
+	p := &counter{source: 2*BatchSize + 1}
+	checkpoints := memoryCheckpoints{}
+	h := NewRebuildHandler(checkpoints, runScope{}, p)
+
+	got, err := h.Handle(t.Context(), RebuildCommand{Projection: "test.Counter"})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if got != (RebuildResult{Batches: 3}) || len(p.rows) != p.source {
+		t.Errorf("Handle() = %+v with %d rows, want 3 batches and %d rows", got, len(p.rows), p.source)
+	}
+	if cp := checkpoints["test.Counter"]; cp.Rebuilding || cp.Cursor != "" || cp.RebuiltAt.IsZero() {
+		t.Errorf("checkpoint = %+v, want a completed rebuild", cp)
+	}
+}
+
+func TestRebuildHandler_ResumesFromCheckpoint(t *testing.T) {
+	p := &counter{source: 3 * BatchSize, failAt: BatchSize}
+	checkpoints := memoryCheckpoints{}
+	h := NewRebuildHandler(checkpoints, runScope{}, p)
+
+	if _, err := h.Handle(t.Context(), RebuildCommand{Projection: "test.Counter"}); err == nil {
+		t.Fatal("Handle() error = nil, want the backfill error")
+	}
+	if cp := checkpoints["test.Counter"]; !cp.Rebuilding || cp.Cursor != strconv.Itoa(BatchSize) {
+		t.Fatalf("checkpoint after failure = %+v, want the cursor of the failed batch", cp)
+	}
+
+	p.failAt = 0
+	got, err := h.Handle(t.Context(), RebuildCommand{Projection: "test.Counter"})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if got != (RebuildResult{Resumed: true, Batches: 2}) || p.resets != 1 || len(p.rows) != p.source {
+		t.Errorf("Handle() = %+v after %d resets with %d rows, want 2 resumed batches, 1 reset and %d rows", got, p.resets, len(p.rows), p.source)
+	}
+}
+
+func TestRebuildHandler_RestartResets(t *testing.T) {
+	p := &counter{source: 1}
+	checkpoints := memoryCheckpoints{"test.Counter": {Projection: "test.Counter", Rebuilding: true, Cursor: "1"}}
+	h := NewRebuildHandler(checkpoints, runScope{}, p)
+
+	got, err := h.Handle(t.Context(), RebuildCommand{Projection: "test.Counter", Restart: true})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if got.Resumed || p.resets != 1 || !p.rows[0] {
+		t.Errorf("Handle() = %+v after %d resets, want a fresh rebuild", got, p.resets)
+	}
+}
+
+func TestRebuildHandler_UnknownProjection(t *testing.T) {
+	h := NewRebuildHandler(memoryCheckpoints{}, runScope{}, &counter{})
+
+	if _, err := h.Handle(t.Context(), RebuildCommand{Projection: "test.Other"}); !errors.Is(err, ErrUnknownProjection) {
+		t.Errorf("Handle() error = %v, want ErrUnknownProjection", err)
+	}
+}
@@ -0,0 +1,95 @@
+// Package quota enforces usage quotas in command handlers, such as the
+// number of orders a tenant may create per day.
+//
+// Quotas over uses (Service.Consume) are counted in a Store, in the
+// command's transaction: a command that fails rolls back its use with its
+// other writes, and concurrent uses of the same counter conflict rather than
+// both slipping under the limit. Quotas over the current state (e.g. open
+// drafts) are counted by the command itself from its repository and checked
+// with Check.
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+)
+
+// ErrExceeded is matched by every *ExceededError.
+var ErrExceeded = errors.New("quota exceeded")
+
+// Limit is a quota: at most Max uses per Window for each key. A zero Max is
+// unlimited. Windows are fixed and aligned to the Unix epoch, so a Window of
+// 24 hours is a UTC day.
+type Limit struct {
+	Name   string // e.g. "orders.per_tenant_per_day"
+	Max    int64
+	Window time.Duration // unused by Check
+}
+
+// ExceededError reports that a use would exceed a quota.
+type ExceededError struct {
+	Quota string
+	Max   int64
+	// RetryAfter is how long until the window of the quota ends; zero for
+	// quotas over the current state, which free up when the state changes.
+	RetryAfter time.Duration
+}
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("quota %s exceeded: at most %d", e.Quota, e.Max)
+}
+
+func (e *ExceededError) Unwrap() error { return ErrExceeded }
+
+// Store counts the uses of quotas. Implementations are in internal/platform.
+type Store interface {
+	// Add adds n to the counter of quota and key in the window starting at
+	// window and returns its new value. It must join the read-write
+	// transaction of ctx, so that the use commits or rolls back with the
+	// command and concurrent uses conflict.
+	Add(ctx context.Context, quota, key string, window time.Time, n int64) (int64, error)
+}
+
+// Service consumes quotas from a Store. A nil *Service enforces none, so
+// modules can take one as an optional dependency.
+type Service struct {
+	store Store
+}
+
+// NewService creates a Service counting in store.
+func NewService(store Store) *Service {
+	return &Service{store: store}
+}
+
+// Consume counts one use of l by key in the current window, read from
+// clock.Now(ctx), and returns an *ExceededError when it exceeds l.Max. It
+// must be called in the command's read-write transaction, which the error
+// is meant to roll back.
+func (s *Service) Consume(ctx context.Context, l Limit, key string) error {
+	if s == nil || l.Max <= 0 {
+		return nil
+	}
+	now := clock.Now(ctx)
+	window := now.Truncate(l.Window)
+	count, err := s.store.Add(ctx, l.Name, key, window, 1)
+	if err != nil {
+		return fmt.Errorf("counting quota %s: %w", l.Name, err)
+	}
+	if count > l.Max {
+		return &ExceededError{Quota: l.Name, Max: l.Max, RetryAfter: window.Add(l.Window).Sub(now)}
+	}
+	return nil
+}
+
+// Check returns an *ExceededError when one more use would exceed l.Max,
+// given the used ones counted by the caller.
+func Check(l Limit, used int64) error {
+	if l.Max > 0 && used >= l.Max {
+		return &ExceededError{Quota: l.Name, Max: l.Max}
+	}
+	return nil
+}
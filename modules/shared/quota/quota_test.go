@@ -0,0 +1,78 @@
+package quota_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock/clocktest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/quota"
+)
+
+// memoryStore counts in a map, by quota, key and window.
+type memoryStore map[string]int64
+
+func (s memoryStore) Add(_ context.Context, q, key string, window time.Time, n int64) (int64, error) {
+	k := q + "/" + key + "/" + window.Format(time.RFC3339)
+	s[k] += n
+	return s[k], nil
+}
+
+func TestService_Consume_PerWindow(t *testing.T) {
+	ctx, clk := clocktest.Context(t.Context(), time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC))
+	svc := quota.NewService(memoryStore{})
+	perDay := quota.Limit{Name: "orders.per_tenant_per_day", Max: 2, Window: 24 * time.Hour}
+
+	for range 2 {
+		if err := svc.Consume(ctx, perDay, "acme"); err != nil {
+			t.Fatalf("Consume() under the limit: %v", err)
+		}
+	}
+	if err := svc.Consume(ctx, perDay, "globex"); err != nil {
+		t.Fatalf("Consume() for another key: %v", err)
+	}
+
+	err := svc.Consume(ctx, perDay, "acme")
+	var exceeded *quota.ExceededError
+	if !errors.As(err, &exceeded) || !errors.Is(err, quota.ErrExceeded) {
+		t.Fatalf("Consume() over the limit = %v, want an ExceededError", err)
+	}
+	if exceeded.Max != 2 || exceeded.RetryAfter != 6*time.Hour {
+		t.Errorf("ExceededError = %+v, want Max 2, RetryAfter until midnight", exceeded)
+	}
+
+	clk.Advance(6 * time.Hour)
+	if err := svc.Consume(ctx, perDay, "acme"); err != nil {
+		t.Errorf("Consume() the next day: %v", err)
+	}
+}
+
+func TestService_Consume_Unlimited(t *testing.T) {
+	var nilService *quota.Service
+	if err := nilService.Consume(t.Context(), quota.Limit{Name: "q", Max: 1, Window: time.Hour}, "k"); err != nil {
+		t.Errorf("nil Service: %v", err)
+	}
+
+	svc := quota.NewService(memoryStore{})
+	for range 3 {
+		if err := svc.Consume(t.Context(), quota.Limit{Name: "q", Window: time.Hour}, "k"); err != nil {
+			t.Errorf("zero Max: %v", err)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	drafts := quota.Limit{Name: "orders.drafts_per_user", Max: 3}
+
+	if err := quota.Check(drafts, 2); err != nil {
+		t.Errorf("Check(2) = %v, want nil", err)
+	}
+	var exceeded *quota.ExceededError
+	if err := quota.Check(drafts, 3); !errors.As(err, &exceeded) || exceeded.RetryAfter != 0 {
+		t.Errorf("Check(3) = %v, want an ExceededError without RetryAfter", err)
+	}
+	if err := quota.Check(quota.Limit{Name: "unlimited"}, 100); err != nil {
+		t.Errorf("Check() with zero Max = %v, want nil", err)
+	}
+}
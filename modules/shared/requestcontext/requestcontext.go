@@ -0,0 +1,47 @@
+// Package requestcontext carries what the inbound adapter learned about a
+// request past the handler: who issued it, for which tenant, in which
+// locale, and under which request ID. Like the clock, the values travel in
+// the context: the HTTP middleware installs them with With, and command
+// handlers read them through the typed accessors. Each accessor returns ""
+// when the value is unknown, e.g. for commands run by a worker or the admin
+// CLI, so consumers keep their own defaults.
+package requestcontext
+
+import "context"
+
+// Values describes the request a context belongs to.
+type Values struct {
+	// Actor identifies who issued the request, e.g. "admin".
+	Actor string
+	// TenantID is the tenant the request was made for.
+	TenantID string
+	// Locale is the caller's preferred language tag, e.g. "en-US".
+	Locale string
+	// RequestID correlates logs and audit entries of one request.
+	RequestID string
+}
+
+type valuesKey struct{}
+
+// With returns a context carrying v.
+func With(ctx context.Context, v Values) context.Context {
+	return context.WithValue(ctx, valuesKey{}, v)
+}
+
+// FromContext returns the values in ctx, or the zero Values if there are none.
+func FromContext(ctx context.Context) Values {
+	v, _ := ctx.Value(valuesKey{}).(Values)
+	return v
+}
+
+// Actor returns who issued the request of ctx.
+func Actor(ctx context.Context) string { return FromContext(ctx).Actor }
+
+// TenantID returns the tenant of the request of ctx.
+func TenantID(ctx context.Context) string { return FromContext(ctx).TenantID }
+
+// Locale returns the preferred locale of the request of ctx.
+func Locale(ctx context.Context) string { return FromContext(ctx).Locale }
+
+// RequestID returns the ID of the request of ctx.
+func RequestID(ctx context.Context) string { return FromContext(ctx).RequestID }
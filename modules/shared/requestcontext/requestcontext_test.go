@@ -0,0 +1,35 @@
+package requestcontext_test
+
+import (
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+func TestAccessors(t *testing.T) {
+	ctx := requestcontext.With(t.Context(), requestcontext.Values{
+		Actor:     "admin",
+		TenantID:  "acme",
+		Locale:    "ja-JP",
+		RequestID: "req-1",
+	})
+
+	if got := requestcontext.Actor(ctx); got != "admin" {
+		t.Errorf("Actor = %q, want admin", got)
+	}
+	if got := requestcontext.TenantID(ctx); got != "acme" {
+		t.Errorf("TenantID = %q, want acme", got)
+	}
+	if got := requestcontext.Locale(ctx); got != "ja-JP" {
+		t.Errorf("Locale = %q, want ja-JP", got)
+	}
+	if got := requestcontext.RequestID(ctx); got != "req-1" {
+		t.Errorf("RequestID = %q, want req-1", got)
+	}
+}
+
+func TestAccessors_Empty(t *testing.T) {
+	if got := requestcontext.FromContext(t.Context()); got != (requestcontext.Values{}) {
+		t.Errorf("FromContext = %+v, want zero Values", got)
+	}
+}
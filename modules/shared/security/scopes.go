@@ -0,0 +1,34 @@
+package security
+
+import (
+	"context"
+	"slices"
+)
+
+// AdminScope is the scope granting a caller the admin endpoints of module,
+// e.g. "webhooks:admin", in place of the admin token.
+func AdminScope(module string) string {
+	return module + ":admin"
+}
+
+type scopesKey struct{}
+
+// WithScopes returns a context carrying the scopes granted to the caller by
+// its credentials, e.g. an API key.
+func WithScopes(ctx context.Context, scopes []string) context.Context {
+	return context.WithValue(ctx, scopesKey{}, slices.Clone(scopes))
+}
+
+// Scoped reports whether the caller of ctx was authenticated with scoped
+// credentials, e.g. an API key, rather than with the admin token: such a
+// caller holds only the scopes of its credentials.
+func Scoped(ctx context.Context) bool {
+	_, ok := ctx.Value(scopesKey{}).([]string)
+	return ok
+}
+
+// HasScope reports whether the caller of ctx was granted scope.
+func HasScope(ctx context.Context, scope string) bool {
+	scopes, _ := ctx.Value(scopesKey{}).([]string)
+	return slices.Contains(scopes, scope)
+}
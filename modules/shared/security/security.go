@@ -0,0 +1,228 @@
+// Package security reports authentication and authorization decisions made
+// by inbound adapters to a pluggable Sink, so that security teams can ship
+// them to a SIEM independently of the request log.
+//
+// Modules guard their admin endpoints with an AdminGuard built in module.go;
+// the sink is wired once in main.go and shared by every module. Machine
+// clients reach them with credentials granting the module's AdminScope
+// instead of the admin token.
+package security
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+// AdminTokenHeader carries the shared admin token on admin-only requests.
+const AdminTokenHeader = "X-Admin-Token"
+
+// AdminActor attributes requests carrying the admin token.
+const AdminActor = "admin"
+
+// Kind classifies what was being decided.
+type Kind string
+
+const (
+	// KindAdminToken is the validation of the X-Admin-Token header.
+	KindAdminToken Kind = "admin_token"
+	// KindAuthorization is a business rule refusing an authenticated caller access (HTTP 403).
+	KindAuthorization Kind = "authorization"
+	// KindAPIKey is the authentication of a machine client by its API key,
+	// or its admin access through the key's scopes.
+	KindAPIKey Kind = "api_key"
+)
+
+// Outcome is the result of a decision.
+type Outcome string
+
+const (
+	OutcomeAllowed Outcome = "allowed"
+	OutcomeDenied  Outcome = "denied"
+)
+
+// Reasons recorded with denied decisions.
+const (
+	ReasonMissingToken  = "missing_token"
+	ReasonInvalidToken  = "invalid_token"
+	ReasonAdminDisabled = "admin_disabled" // no admin token is configured
+	ReasonKeyExpired    = "key_expired"
+	ReasonKeyRevoked    = "key_revoked"
+	ReasonWrongTenant   = "wrong_tenant" // the credentials are for another tenant
+)
+
+// Decision describes one authentication or authorization decision.
+type Decision struct {
+	Kind       Kind
+	Outcome    Outcome
+	Module     string // module owning the endpoint, e.g. "orders"
+	Method     string
+	Route      string // matched ServeMux pattern, e.g. "POST /orders/{id}/refund"
+	Path       string
+	RemoteAddr string
+	UserAgent  string
+	Reason     string // empty when allowed
+	At         time.Time
+}
+
+// Sink receives every recorded Decision. Implementations must not block for
+// long: they run on the request goroutine before the response is written.
+type Sink interface {
+	RecordDecision(ctx context.Context, d Decision)
+}
+
+// Sinks fans a Decision out to every non-nil sink, in order.
+// It returns nil when none is given, which disables recording.
+func Sinks(sinks ...Sink) Sink {
+	var fanout multiSink
+	for _, s := range sinks {
+		if s != nil {
+			fanout = append(fanout, s)
+		}
+	}
+	switch len(fanout) {
+	case 0:
+		return nil
+	case 1:
+		return fanout[0]
+	}
+	return fanout
+}
+
+type multiSink []Sink
+
+func (m multiSink) RecordDecision(ctx context.Context, d Decision) {
+	for _, s := range m {
+		s.RecordDecision(ctx, d)
+	}
+}
+
+// AdminGuard validates the admin token of a module's requests and records the
+// decisions to Sink. Admin access by token is disabled when Token is empty;
+// nothing is recorded when Sink is nil. A request without the token is also
+// an admin request when its credentials grant the module's AdminScope.
+type AdminGuard struct {
+	Module string
+	Token  string
+	Sink   Sink
+}
+
+// RequireAdmin reports whether r carries the admin token, recording the
+// decision either way. Use it for endpoints that are admin-only.
+func (g AdminGuard) RequireAdmin(r *http.Request) bool {
+	if g.scoped(r) {
+		g.record(r, KindAPIKey, OutcomeAllowed, "")
+		return true
+	}
+	reason := g.check(r)
+	if reason == "" {
+		g.record(r, KindAdminToken, OutcomeAllowed, "")
+		return true
+	}
+	g.record(r, KindAdminToken, OutcomeDenied, reason)
+	return false
+}
+
+// IsAdmin reports whether r carries the admin token, for endpoints that also
+// serve non-admin callers. Only tokens that are presented and rejected are
+// recorded: a request without a token is an ordinary request.
+func (g AdminGuard) IsAdmin(r *http.Request) bool {
+	if g.scoped(r) {
+		return true
+	}
+	reason := g.check(r)
+	if reason != "" && reason != ReasonMissingToken {
+		g.record(r, KindAdminToken, OutcomeDenied, reason)
+	}
+	return reason == ""
+}
+
+// Deny records an authorization denial of r for reason, e.g. when a domain
+// rule is about to be answered with 403 Forbidden.
+func (g AdminGuard) Deny(r *http.Request, reason string) {
+	g.record(r, KindAuthorization, OutcomeDenied, reason)
+}
+
+// Actor names who issued r for attribution: AdminActor when r carries the admin
+// token, the actor of the request context when its credentials grant the
+// admin scope, "" otherwise. Nothing is recorded; the endpoint records its
+// own decision.
+func (g AdminGuard) Actor(r *http.Request) string {
+	if g.scoped(r) {
+		return requestcontext.Actor(r.Context())
+	}
+	if g.check(r) != "" {
+		return ""
+	}
+	return AdminActor
+}
+
+// Handler guards next, an admin-only endpoint: requests that are not admin
+// requests (see RequireAdmin) are answered 403 Forbidden.
+func (g AdminGuard) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !g.RequireAdmin(r) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(struct {
+				Error string `json:"error"`
+			}{"admin access required"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// HandlerFunc is Handler for the handler functions modules register with
+// (*http.ServeMux).HandleFunc.
+func (g AdminGuard) HandlerFunc(next http.HandlerFunc) http.HandlerFunc {
+	return g.Handler(next).ServeHTTP
+}
+
+// scoped reports whether r, without the admin token, was authenticated with
+// credentials granting the module's admin scope.
+func (g AdminGuard) scoped(r *http.Request) bool {
+	return r.Header.Get(AdminTokenHeader) == "" && HasScope(r.Context(), AdminScope(g.Module))
+}
+
+// check returns the reason r is not an admin request, or "" if it is.
+func (g AdminGuard) check(r *http.Request) string {
+	token := r.Header.Get(AdminTokenHeader)
+	switch {
+	case token == "":
+		return ReasonMissingToken
+	case g.Token == "":
+		return ReasonAdminDisabled
+	case subtle.ConstantTimeCompare([]byte(token), []byte(g.Token)) != 1:
+		return ReasonInvalidToken
+	}
+	return ""
+}
+
+func (g AdminGuard) record(r *http.Request, kind Kind, outcome Outcome, reason string) {
+	if g.Sink == nil {
+		return
+	}
+	g.Sink.RecordDecision(r.Context(), NewDecision(r, kind, outcome, g.Module, reason))
+}
+
+// NewDecision describes a decision about r made now, for adapters that
+// authenticate requests outside an AdminGuard.
+func NewDecision(r *http.Request, kind Kind, outcome Outcome, module, reason string) Decision {
+	return Decision{
+		Kind:       kind,
+		Outcome:    outcome,
+		Module:     module,
+		Method:     r.Method,
+		Route:      r.Pattern,
+		Path:       r.URL.Path,
+		RemoteAddr: r.RemoteAddr,
+		UserAgent:  r.UserAgent(),
+		Reason:     reason,
+		At:         time.Now().UTC(),
+	}
+}
@@ -0,0 +1,219 @@
+package security
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+type sinkFunc func(ctx context.Context, d Decision)
+
+func (f sinkFunc) RecordDecision(ctx context.Context, d Decision) { f(ctx, d) }
+
+func collect(got *[]Decision) Sink {
+	return sinkFunc(func(ctx context.Context, d Decision) { *got = append(*got, d) })
+}
+
+func TestAdminGuard_RequireAdmin(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		header     string
+		want       bool
+		wantReason string
+	}{
+		{"valid token", "secret", "secret", true, ""},
+		{"missing token", "secret", "", false, ReasonMissingToken},
+		{"invalid token", "secret", "guess", false, ReasonInvalidToken},
+		{"admin disabled", "", "secret", false, ReasonAdminDisabled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []Decision
+			g := AdminGuard{Module: "orders", Token: tt.configured, Sink: collect(&got)}
+
+			r := httptest.NewRequest("POST", "/orders/o1/refund", nil)
+			r.Pattern = "POST /orders/{id}/refund"
+			if tt.header != "" {
+				r.Header.Set(AdminTokenHeader, tt.header)
+			}
+
+			if ok := g.RequireAdmin(r); ok != tt.want {
+				t.Fatalf("RequireAdmin() = %v, want %v", ok, tt.want)
+			}
+			if len(got) != 1 {
+				t.Fatalf("recorded %d decisions, want 1", len(got))
+			}
+			d := got[0]
+			wantOutcome := OutcomeDenied
+			if tt.want {
+				wantOutcome = OutcomeAllowed
+			}
+			if d.Kind != KindAdminToken || d.Outcome != wantOutcome || d.Reason != tt.wantReason {
+				t.Errorf("decision = %+v", d)
+			}
+			if d.Module != "orders" || d.Route != "POST /orders/{id}/refund" || d.Path != "/orders/o1/refund" || d.At.IsZero() {
+				t.Errorf("decision = %+v", d)
+			}
+		})
+	}
+}
+
+func TestAdminGuard_IsAdminIgnoresMissingToken(t *testing.T) {
+	var got []Decision
+	g := AdminGuard{Module: "orders", Token: "secret", Sink: collect(&got)}
+
+	if g.IsAdmin(httptest.NewRequest("POST", "/orders/o1/cancel", nil)) {
+		t.Fatal("IsAdmin() = true without a token")
+	}
+	if len(got) != 0 {
+		t.Fatalf("recorded %+v for an ordinary request", got)
+	}
+
+	r := httptest.NewRequest("POST", "/orders/o1/cancel", nil)
+	r.Header.Set(AdminTokenHeader, "guess")
+	if g.IsAdmin(r) {
+		t.Fatal("IsAdmin() = true with an invalid token")
+	}
+	if len(got) != 1 || got[0].Reason != ReasonInvalidToken {
+		t.Fatalf("decisions = %+v", got)
+	}
+}
+
+func TestAdminGuard_AdminScope(t *testing.T) {
+	var got []Decision
+	g := AdminGuard{Module: "webhooks", Sink: collect(&got)}
+
+	r := httptest.NewRequest("POST", "/webhooks/subscriptions", nil)
+	ctx := requestcontext.With(r.Context(), requestcontext.Values{Actor: "apikey:k1"})
+	r = r.WithContext(WithScopes(ctx, []string{"orders:admin", AdminScope("webhooks")}))
+
+	if !g.RequireAdmin(r) || !g.IsAdmin(r) {
+		t.Fatal("the webhooks:admin scope does not grant admin access")
+	}
+	if len(got) != 1 || got[0].Kind != KindAPIKey || got[0].Outcome != OutcomeAllowed {
+		t.Errorf("decisions = %+v", got)
+	}
+	if actor := g.Actor(r); actor != "apikey:k1" {
+		t.Errorf("Actor() = %q, want the request's actor", actor)
+	}
+
+	other := AdminGuard{Module: "payments"}
+	if other.IsAdmin(r) {
+		t.Error("the webhooks:admin scope grants admin access to payments")
+	}
+}
+
+func TestAdminGuard_Actor(t *testing.T) {
+	var got []Decision
+	g := AdminGuard{Module: "platform", Token: "secret", Sink: collect(&got)}
+
+	r := httptest.NewRequest("GET", "/api/v1/users", nil)
+	if actor := g.Actor(r); actor != "" {
+		t.Errorf("Actor() = %q without a token", actor)
+	}
+	r.Header.Set(AdminTokenHeader, "guess")
+	if actor := g.Actor(r); actor != "" {
+		t.Errorf("Actor() = %q with an invalid token", actor)
+	}
+	r.Header.Set(AdminTokenHeader, "secret")
+	if actor := g.Actor(r); actor != AdminActor {
+		t.Errorf("Actor() = %q, want %q", actor, AdminActor)
+	}
+	if len(got) != 0 {
+		t.Fatalf("recorded %+v", got)
+	}
+}
+
+func TestAdminGuard_Handler(t *testing.T) {
+	var got []Decision
+	g := AdminGuard{Module: "platform", Token: "secret", Sink: collect(&got)}
+	h := g.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+
+	rec := httptest.NewRecorder()
+	h(rec, httptest.NewRequest("GET", "/admin/eventbus", nil))
+	if rec.Code != http.StatusForbidden || rec.Body.String() != "{\"error\":\"admin access required\"}\n" {
+		t.Errorf("without token: %d %q", rec.Code, rec.Body.String())
+	}
+
+	r := httptest.NewRequest("GET", "/admin/eventbus", nil)
+	r.Header.Set(AdminTokenHeader, "secret")
+	rec = httptest.NewRecorder()
+	h(rec, r)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("with token: %d, want 204", rec.Code)
+	}
+	if len(got) != 2 || got[0].Outcome != OutcomeDenied || got[1].Outcome != OutcomeAllowed {
+		t.Errorf("decisions = %+v", got)
+	}
+}
+
+func TestAdminGuard_NilSink(t *testing.T) {
+	g := AdminGuard{Module: "orders", Token: "secret"}
+	r := httptest.NewRequest("GET", "/reports/orders", nil)
+	r.Header.Set(AdminTokenHeader, "secret")
+	if !g.RequireAdmin(r) {
+		t.Fatal("RequireAdmin() = false with a valid token")
+	}
+	g.Deny(r, "product_not_purchased")
+}
+
+func TestSinks(t *testing.T) {
+	if Sinks() != nil || Sinks(nil, nil) != nil {
+		t.Fatal("Sinks() without sinks should be nil")
+	}
+
+	var first, second []Decision
+	s := Sinks(collect(&first), nil, collect(&second))
+	s.RecordDecision(context.Background(), Decision{Module: "reviews"})
+	if len(first) != 1 || len(second) != 1 {
+		t.Fatalf("fan-out reached %d and %d sinks", len(first), len(second))
+	}
+}
+
+func TestLogSink(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	LogSink(logger).RecordDecision(context.Background(), Decision{
+		Kind:    KindAdminToken,
+		Outcome: OutcomeDenied,
+		Module:  "audit",
+		Route:   "GET /audit",
+		Reason:  ReasonInvalidToken,
+	})
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("unmarshal log record: %v", err)
+	}
+	if rec["level"] != "WARN" || rec["msg"] != "security decision" || rec["reason"] != ReasonInvalidToken || rec["route"] != "GET /audit" {
+		t.Errorf("log record = %v", rec)
+	}
+}
+
+type publisherFunc func(ctx context.Context, evts []events.Event)
+
+func (f publisherFunc) PublishPostCommit(ctx context.Context, evts []events.Event) { f(ctx, evts) }
+
+func TestEventSink(t *testing.T) {
+	var got []events.Event
+	sink := EventSink(publisherFunc(func(ctx context.Context, evts []events.Event) { got = append(got, evts...) }))
+
+	sink.RecordDecision(context.Background(), Decision{Kind: KindAuthorization, Outcome: OutcomeDenied, Module: "reviews"})
+
+	if len(got) != 1 {
+		t.Fatalf("published %d events, want 1", len(got))
+	}
+	evt, ok := got[0].(AuthDecisionEvent)
+	if !ok || evt.EventType() != AuthDecisionEventType || evt.Module != "reviews" || evt.Kind != KindAuthorization {
+		t.Errorf("event = %#v", got[0])
+	}
+}
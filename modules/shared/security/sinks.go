@@ -0,0 +1,68 @@
+package security
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// LogSink writes every Decision as a structured "security decision" record.
+// Denials are logged at Warn and allowed decisions at Info, so a dedicated
+// handler (e.g. one shipping to a SIEM) can filter on level and attributes.
+func LogSink(logger *slog.Logger) Sink {
+	return &logSink{logger: logger}
+}
+
+type logSink struct {
+	logger *slog.Logger
+}
+
+func (s *logSink) RecordDecision(ctx context.Context, d Decision) {
+	level := slog.LevelInfo
+	if d.Outcome == OutcomeDenied {
+		level = slog.LevelWarn
+	}
+	attrs := []slog.Attr{
+		slog.String("kind", string(d.Kind)),
+		slog.String("outcome", string(d.Outcome)),
+		slog.String("module", d.Module),
+		slog.String("method", d.Method),
+		slog.String("route", d.Route),
+		slog.String("path", d.Path),
+		slog.String("remote_addr", d.RemoteAddr),
+		slog.String("user_agent", d.UserAgent),
+		slog.Time("at", d.At),
+	}
+	if d.Reason != "" {
+		attrs = append(attrs, slog.String("reason", d.Reason))
+	}
+	s.logger.LogAttrs(ctx, level, "security decision", attrs...)
+}
+
+// AuthDecisionEventType is the type of events published by EventSink.
+const AuthDecisionEventType events.EventType = "security.AuthDecision"
+
+// AuthDecisionEvent carries a Decision to post-commit subscribers, such as
+// the audit log's wildcard recorder.
+type AuthDecisionEvent struct {
+	events.BaseEvent
+	Decision
+}
+
+// EventSink publishes every Decision as an AuthDecisionEvent through pub.
+// Publication is asynchronous, so the request is not held up by subscribers.
+func EventSink(pub events.PostCommitPublisher) Sink {
+	return &eventSink{pub: pub}
+}
+
+type eventSink struct {
+	pub events.PostCommitPublisher
+}
+
+func (s *eventSink) RecordDecision(ctx context.Context, d Decision) {
+	s.pub.PublishPostCommit(ctx, []events.Event{AuthDecisionEvent{
+		BaseEvent: events.NewBaseEvent(AuthDecisionEventType),
+		Decision:  d,
+	}})
+}
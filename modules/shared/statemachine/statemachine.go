@@ -0,0 +1,142 @@
+// Package statemachine declares the status lifecycle of an aggregate as a
+// table of named transitions, instead of status checks spread over its
+// business methods. Each business method asks the table for its
+// transition, and gets the next status or an error that names the current
+// one:
+//
+//	var lifecycle = statemachine.New("order",
+//		statemachine.Transition[Status]{Name: "submit", From: []Status{StatusDraft}, To: StatusPending, Err: ErrOrderNotDraft},
+//		...
+//	)
+//
+//	func (o *Order) Submit(ctx context.Context) error {
+//		next, err := lifecycle.Next(o.status, "submit")
+//		if err != nil {
+//			return err // wraps ErrOrderNotDraft
+//		}
+//		...
+//		o.status = next
+//	}
+//
+// The same table answers which actions a status allows (e.g. for the links
+// of an API resource) and draws the lifecycle for the docs (Mermaid).
+package statemachine
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Transition is an action allowed in the From statuses, which moves the
+// aggregate to To. An action that does not change the status (e.g. editing
+// a draft) leaves To empty.
+type Transition[S ~string] struct {
+	Name string
+	From []S
+	To   S
+
+	// Err is the error of attempting the transition from any other status.
+	// Rejections overrides it for specific statuses, e.g. "already
+	// cancelled" rather than "completed".
+	Err        error
+	Rejections map[S]error
+}
+
+// Machine is the table of transitions of one kind of aggregate.
+type Machine[S ~string] struct {
+	name        string
+	transitions []Transition[S]
+}
+
+// New creates the machine of the aggregate called name (used in errors and
+// diagrams) from its transitions. It panics on a duplicate or unnamed
+// transition, or one without Err: tables are package-level declarations.
+func New[S ~string](name string, transitions ...Transition[S]) *Machine[S] {
+	for i, t := range transitions {
+		if t.Name == "" || t.Err == nil {
+			panic(fmt.Sprintf("statemachine: %s transition %d needs a name and an error", name, i))
+		}
+		if slices.ContainsFunc(transitions[:i], func(other Transition[S]) bool { return other.Name == t.Name }) {
+			panic(fmt.Sprintf("statemachine: duplicate %s transition %q", name, t.Name))
+		}
+	}
+	return &Machine[S]{name: name, transitions: transitions}
+}
+
+// TransitionError is returned for a transition that the current status does
+// not allow. It wraps the transition's error, so callers keep matching it
+// with errors.Is.
+type TransitionError[S ~string] struct {
+	Machine    string
+	Transition string
+	From       S
+	Err        error
+}
+
+func (e *TransitionError[S]) Error() string {
+	return fmt.Sprintf("cannot %s %s in status %s: %v", e.Transition, e.Machine, e.From, e.Err)
+}
+
+func (e *TransitionError[S]) Unwrap() error { return e.Err }
+
+// Next returns the status that transition name leads to from status from,
+// or a *TransitionError if from does not allow it. It panics on an unknown
+// transition.
+func (m *Machine[S]) Next(from S, name string) (S, error) {
+	t := m.transition(name)
+	if !slices.Contains(t.From, from) {
+		err := t.Err
+		if rejection, ok := t.Rejections[from]; ok {
+			err = rejection
+		}
+		return from, &TransitionError[S]{Machine: m.name, Transition: name, From: from, Err: err}
+	}
+	if t.To == "" {
+		return from, nil
+	}
+	return t.To, nil
+}
+
+// Can reports whether status from allows transition name.
+func (m *Machine[S]) Can(from S, name string) bool {
+	return slices.Contains(m.transition(name).From, from)
+}
+
+// Allowed returns the names of the transitions status from allows, in
+// declaration order.
+func (m *Machine[S]) Allowed(from S) []string {
+	var names []string
+	for _, t := range m.transitions {
+		if slices.Contains(t.From, from) {
+			names = append(names, t.Name)
+		}
+	}
+	return names
+}
+
+// Mermaid draws the machine as a Mermaid state diagram. Transitions that
+// do not change the status are drawn as loops.
+func (m *Machine[S]) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("stateDiagram-v2\n")
+	for _, t := range m.transitions {
+		for _, from := range t.From {
+			to := t.To
+			if to == "" {
+				to = from
+			}
+			fmt.Fprintf(&b, "    %s --> %s: %s\n", from, to, t.Name)
+		}
+	}
+	return b.String()
+}
+
+func (m *Machine[S]) transition(name string) Transition[S] {
+	for _, t := range m.transitions {
+		if t.Name == name {
+			return t
+		}
+	}
+	panic(fmt.Sprintf("statemachine: unknown %s transition %q", m.name, name))
+}
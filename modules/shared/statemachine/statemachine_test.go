@@ -0,0 +1,111 @@
+package statemachine_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/statemachine"
+)
+
+type status string
+
+var (
+	errNotOpen       = errors.New("ticket is not open")
+	errAlreadyClosed = errors.New("ticket is already closed")
+)
+
+var tickets = statemachine.New("ticket",
+	statemachine.Transition[status]{Name: "comment", From: []status{"open", "waiting"}, Err: errNotOpen},
+	statemachine.Transition[status]{Name: "wait", From: []status{"open"}, To: "waiting", Err: errNotOpen},
+	statemachine.Transition[status]{
+		Name: "close", From: []status{"open", "waiting"}, To: "closed",
+		Err: errNotOpen, Rejections: map[status]error{"closed": errAlreadyClosed},
+	},
+)
+
+func TestMachine_Next(t *testing.T) {
+	tests := []struct {
+		from       status
+		transition string
+		want       status
+		wantErr    error
+	}{
+		{"open", "wait", "waiting", nil},
+		{"waiting", "close", "closed", nil},
+		{"waiting", "comment", "waiting", nil}, // no status change
+		{"waiting", "wait", "waiting", errNotOpen},
+		{"closed", "close", "closed", errAlreadyClosed},
+		{"closed", "comment", "closed", errNotOpen},
+	}
+	for _, tt := range tests {
+		got, err := tickets.Next(tt.from, tt.transition)
+		if !errors.Is(err, tt.wantErr) || got != tt.want {
+			t.Errorf("Next(%s, %s) = %s, %v, want %s, %v", tt.from, tt.transition, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestMachine_NextErrorNamesTheStatus(t *testing.T) {
+	_, err := tickets.Next("closed", "close")
+
+	var transitionErr *statemachine.TransitionError[status]
+	if !errors.As(err, &transitionErr) || transitionErr.From != "closed" || transitionErr.Transition != "close" {
+		t.Fatalf("Next() error = %#v, want a TransitionError from closed", err)
+	}
+	if want := "cannot close ticket in status closed: ticket is already closed"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err, want)
+	}
+}
+
+func TestMachine_CanAndAllowed(t *testing.T) {
+	if !tickets.Can("open", "close") || tickets.Can("closed", "close") {
+		t.Error("Can() disagrees with the table")
+	}
+	if got := tickets.Allowed("waiting"); !slices.Equal(got, []string{"comment", "close"}) {
+		t.Errorf("Allowed(waiting) = %v, want [comment close]", got)
+	}
+	if got := tickets.Allowed("closed"); got != nil {
+		t.Errorf("Allowed(closed) = %v, want none", got)
+	}
+}
+
+func TestMachine_Mermaid(t *testing.T) {
+	want := `stateDiagram-v2
+    open --> open: comment
+    waiting --> waiting: comment
+    open --> waiting: wait
+    open --> closed: close
+    waiting --> closed: close
+`
+	if got := tickets.Mermaid(); got != want {
+		t.Errorf("Mermaid() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestNew_PanicsOnInvalidTable(t *testing.T) {
+	tables := map[string][]statemachine.Transition[status]{
+		"duplicate": {{Name: "close", Err: errNotOpen}, {Name: "close", Err: errNotOpen}},
+		"no error":  {{Name: "close"}},
+		"no name":   {{Err: errNotOpen}},
+	}
+	for name, transitions := range tables {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("New() did not panic")
+				}
+			}()
+			statemachine.New("ticket", transitions...)
+		})
+	}
+}
+
+func TestMachine_PanicsOnUnknownTransition(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Next() of an unknown transition did not panic")
+		}
+	}()
+	_, _ = tickets.Next("open", "reopen")
+}
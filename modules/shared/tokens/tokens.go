@@ -0,0 +1,175 @@
+// Package tokens issues and verifies signed, expiring action tokens: the
+// values behind the links of emails that let their recipient confirm an
+// action, such as an email change, without being signed in.
+//
+// Tokens are JWTs signed with HMAC-SHA256 (HS256), so any JWT library can
+// read them, but nothing here depends on one. Each token names its Purpose,
+// which Verify checks, so that a token issued for one flow is refused by
+// another even when both share a secret. Tokens are stateless: a flow that
+// must accept a token once binds it to the state it changes (e.g. the
+// current email in Data) and refuses it once that state has changed.
+//
+// A token is for the tenant of the request that issued it. Its link is
+// followed without credentials, so in the default tenant: a flow runs the
+// action in the token's tenant (WithTenant) instead.
+package tokens
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+)
+
+var (
+	// ErrInvalid is returned for a token that is malformed, badly signed, or
+	// refused by the flow it was issued for.
+	ErrInvalid = errors.New("invalid token")
+	// ErrExpired is returned for a valid token past its expiry.
+	ErrExpired = errors.New("token has expired")
+	// ErrWrongPurpose is returned for a valid token issued for another purpose.
+	ErrWrongPurpose = errors.New("token was issued for another purpose")
+	// ErrNotConfigured is returned by a nil *Signer.
+	ErrNotConfigured = errors.New("action tokens are not configured")
+)
+
+// MinSecretLength is the length, in bytes, of the shortest secret NewSigner
+// accepts: the size of the HMAC-SHA256 key that gives its full strength.
+const MinSecretLength = 32
+
+// Claims are the contents of a token.
+type Claims struct {
+	Purpose   string            // e.g. "users.email_change"
+	Subject   string            // who or what the action is for, e.g. a user ID
+	Data      map[string]string // flow-specific values, e.g. the new email
+	TenantID  string            // the tenant the token was issued in, "" for the default one
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// payload is the JSON encoding of Claims, with the registered JWT claim
+// names where there is one.
+type payload struct {
+	Purpose   string            `json:"pur"`
+	Subject   string            `json:"sub"`
+	Data      map[string]string `json:"dat,omitempty"`
+	TenantID  string            `json:"tid,omitempty"`
+	IssuedAt  int64             `json:"iat"`
+	ExpiresAt int64             `json:"exp"`
+}
+
+// header is the only JOSE header tokens are signed with; Verify refuses any
+// other, "alg": "none" included.
+var header = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Signer issues and verifies tokens with a secret. A nil *Signer returns
+// ErrNotConfigured, so modules can take one as an optional dependency and
+// disable their token flows without it.
+type Signer struct {
+	secret []byte
+}
+
+// NewSigner returns a Signer for secret, which must be at least
+// MinSecretLength bytes long.
+func NewSigner(secret []byte) (*Signer, error) {
+	if len(secret) < MinSecretLength {
+		return nil, fmt.Errorf("token secret must be at least %d bytes, got %d", MinSecretLength, len(secret))
+	}
+	return &Signer{secret: secret}, nil
+}
+
+// Issue returns a token for the purpose, subject and data of c, in the
+// tenant of ctx, that expires ttl from now (clock.Now). The times and tenant
+// of c are ignored.
+func (s *Signer) Issue(ctx context.Context, c Claims, ttl time.Duration) (string, error) {
+	if s == nil {
+		return "", ErrNotConfigured
+	}
+	if c.Purpose == "" {
+		return "", errors.New("token purpose is required")
+	}
+	if ttl <= 0 {
+		return "", fmt.Errorf("token TTL must be positive, got %s", ttl)
+	}
+
+	now := clock.Now(ctx)
+	body, err := json.Marshal(payload{
+		Purpose:   c.Purpose,
+		Subject:   c.Subject,
+		Data:      c.Data,
+		TenantID:  requestcontext.TenantID(ctx),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("encoding token claims: %w", err)
+	}
+
+	signed := header + "." + base64.RawURLEncoding.EncodeToString(body)
+	return signed + "." + s.sign(signed), nil
+}
+
+// Verify returns the claims of token if it is signed with the secret, is
+// for purpose and has not expired at clock.Now. It returns ErrInvalid,
+// ErrWrongPurpose or ErrExpired otherwise.
+func (s *Signer) Verify(ctx context.Context, token, purpose string) (Claims, error) {
+	if s == nil {
+		return Claims{}, ErrNotConfigured
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != header {
+		return Claims{}, ErrInvalid
+	}
+	if !hmac.Equal([]byte(s.sign(parts[0]+"."+parts[1])), []byte(parts[2])) {
+		return Claims{}, ErrInvalid
+	}
+
+	body, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Claims{}, ErrInvalid
+	}
+	var p payload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return Claims{}, ErrInvalid
+	}
+
+	c := Claims{
+		Purpose:   p.Purpose,
+		Subject:   p.Subject,
+		Data:      p.Data,
+		TenantID:  p.TenantID,
+		IssuedAt:  time.Unix(p.IssuedAt, 0).UTC(),
+		ExpiresAt: time.Unix(p.ExpiresAt, 0).UTC(),
+	}
+	if c.Purpose != purpose {
+		return Claims{}, ErrWrongPurpose
+	}
+	if !clock.Now(ctx).Before(c.ExpiresAt) {
+		return Claims{}, ErrExpired
+	}
+	return c, nil
+}
+
+// sign returns the base64url HMAC-SHA256 of the header and payload.
+func (s *Signer) sign(signed string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(signed))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// WithTenant returns ctx in the tenant c was issued in, so that the action
+// the token confirms reads and writes that tenant's rows.
+func WithTenant(ctx context.Context, c Claims) context.Context {
+	v := requestcontext.FromContext(ctx)
+	v.TenantID = c.TenantID
+	return requestcontext.With(ctx, v)
+}
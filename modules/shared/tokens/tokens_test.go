@@ -0,0 +1,112 @@
+package tokens_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock/clocktest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
+)
+
+const purpose = "users.email_change"
+
+func newSigner(t *testing.T, secret string) *tokens.Signer {
+	t.Helper()
+	s, err := tokens.NewSigner([]byte(secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestSigner_IssueVerify(t *testing.T) {
+	ctx, clk := clocktest.Context(t.Context(), time.Date(2026, 10, 15, 9, 0, 0, 0, time.UTC))
+	s := newSigner(t, strings.Repeat("k", 32))
+
+	token, err := s.Issue(ctx, tokens.Claims{Purpose: purpose, Subject: "user-1", Data: map[string]string{"email": "ada@example.com"}}, time.Hour)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	clk.Advance(59 * time.Minute)
+	c, err := s.Verify(ctx, token, purpose)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if c.Subject != "user-1" || c.Data["email"] != "ada@example.com" || !c.ExpiresAt.Equal(time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("claims = %+v", c)
+	}
+
+	clk.Advance(time.Minute)
+	if _, err := s.Verify(ctx, token, purpose); !errors.Is(err, tokens.ErrExpired) {
+		t.Errorf("Verify() at expiry = %v, want ErrExpired", err)
+	}
+}
+
+// TestSigner_Tenant verifies, without credentials and so in the default
+// tenant, a token issued in another tenant.
+func TestSigner_Tenant(t *testing.T) {
+	s := newSigner(t, strings.Repeat("k", 32))
+	acme := requestcontext.With(t.Context(), requestcontext.Values{TenantID: "acme"})
+	token, err := s.Issue(acme, tokens.Claims{Purpose: purpose, Subject: "user-1", TenantID: "forged"}, time.Hour)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	c, err := s.Verify(t.Context(), token, purpose)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if c.TenantID != "acme" {
+		t.Errorf("TenantID = %q, want acme", c.TenantID)
+	}
+	if got := requestcontext.TenantID(tokens.WithTenant(t.Context(), c)); got != "acme" {
+		t.Errorf("TenantID(WithTenant()) = %q, want acme", got)
+	}
+}
+
+func TestSigner_Verify_Refused(t *testing.T) {
+	ctx := t.Context()
+	s := newSigner(t, strings.Repeat("k", 32))
+	token, _ := s.Issue(ctx, tokens.Claims{Purpose: purpose, Subject: "user-1"}, time.Hour)
+	parts := strings.Split(token, ".")
+
+	tests := []struct {
+		name    string
+		signer  *tokens.Signer
+		token   string
+		purpose string
+		want    error
+	}{
+		{"other purpose", s, token, "orders.claim", tokens.ErrWrongPurpose},
+		{"other secret", newSigner(t, strings.Repeat("x", 32)), token, purpose, tokens.ErrInvalid},
+		{"tampered payload", s, parts[0] + "." + parts[1] + "x." + parts[2], purpose, tokens.ErrInvalid},
+		{"unsigned", s, "eyJhbGciOiJub25lIn0." + parts[1] + ".", purpose, tokens.ErrInvalid},
+		{"malformed", s, "not-a-token", purpose, tokens.ErrInvalid},
+		{"not configured", nil, token, purpose, tokens.ErrNotConfigured},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.signer.Verify(ctx, tt.token, tt.purpose); !errors.Is(err, tt.want) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSigner_ShortSecret(t *testing.T) {
+	if _, err := tokens.NewSigner([]byte("short")); err == nil {
+		t.Error("NewSigner() accepted a 5 byte secret")
+	}
+}
+
+func TestSigner_Issue_NotConfigured(t *testing.T) {
+	var s *tokens.Signer
+	if _, err := s.Issue(context.Background(), tokens.Claims{Purpose: purpose}, time.Hour); !errors.Is(err, tokens.ErrNotConfigured) {
+		t.Errorf("Issue() error = %v, want ErrNotConfigured", err)
+	}
+}
@@ -0,0 +1,59 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+)
+
+// Priority is the priority a transaction asks the database for. Lower
+// priority work, e.g. a backfill, yields to interactive requests under
+// contention.
+type Priority int
+
+const (
+	PriorityDefault Priority = iota // the scope's default
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+)
+
+var priorityNames = map[Priority]string{
+	PriorityDefault: "",
+	PriorityLow:     "low",
+	PriorityMedium:  "medium",
+	PriorityHigh:    "high",
+}
+
+func (p Priority) String() string { return priorityNames[p] }
+
+// ParsePriority parses "low", "medium" or "high"; "" is PriorityDefault.
+func ParsePriority(s string) (Priority, error) {
+	for p, name := range priorityNames {
+		if name == s {
+			return p, nil
+		}
+	}
+	return PriorityDefault, fmt.Errorf("invalid transaction priority %q: must be low, medium or high", s)
+}
+
+// Hints describe a transaction to the database, so that contention can be
+// debugged on its side: Tag shows up in its lock and transaction statistics.
+// They apply to the transaction a Scope starts in the context carrying
+// them; transactions joined by nested calls keep the outermost's.
+type Hints struct {
+	Tag      string // e.g. "orders.CancelOrder"; scopes derive one when empty
+	Priority Priority
+}
+
+type hintsKey struct{}
+
+// WithHints returns ctx whose transactions are started with h.
+func WithHints(ctx context.Context, h Hints) context.Context {
+	return context.WithValue(ctx, hintsKey{}, h)
+}
+
+// HintsFromContext returns the hints in ctx, or zero Hints.
+func HintsFromContext(ctx context.Context) Hints {
+	h, _ := ctx.Value(hintsKey{}).(Hints)
+	return h
+}
@@ -0,0 +1,25 @@
+package transaction
+
+import "testing"
+
+func TestParsePriority(t *testing.T) {
+	for _, p := range []Priority{PriorityDefault, PriorityLow, PriorityMedium, PriorityHigh} {
+		got, err := ParsePriority(p.String())
+		if err != nil || got != p {
+			t.Errorf("ParsePriority(%q) = %v, %v, want %v", p.String(), got, err, p)
+		}
+	}
+	if _, err := ParsePriority("urgent"); err == nil {
+		t.Error("ParsePriority(urgent) succeeded")
+	}
+}
+
+func TestHintsFromContext(t *testing.T) {
+	if got := HintsFromContext(t.Context()); got != (Hints{}) {
+		t.Errorf("HintsFromContext() = %+v without hints", got)
+	}
+	want := Hints{Tag: "orders.CancelOrder", Priority: PriorityLow}
+	if got := HintsFromContext(WithHints(t.Context(), want)); got != want {
+		t.Errorf("HintsFromContext() = %+v, want %+v", got, want)
+	}
+}
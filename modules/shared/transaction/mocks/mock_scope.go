@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: scope.go
+//
+// Generated by this command:
+//
+//	mockgen -source=scope.go -destination=mocks/mock_scope.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockScope is a mock of Scope interface.
+type MockScope struct {
+	ctrl     *gomock.Controller
+	recorder *MockScopeMockRecorder
+	isgomock struct{}
+}
+
+// MockScopeMockRecorder is the mock recorder for MockScope.
+type MockScopeMockRecorder struct {
+	mock *MockScope
+}
+
+// NewMockScope creates a new mock instance.
+func NewMockScope(ctrl *gomock.Controller) *MockScope {
+	mock := &MockScope{ctrl: ctrl}
+	mock.recorder = &MockScopeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScope) EXPECT() *MockScopeMockRecorder {
+	return m.recorder
+}
+
+// Execute mocks base method.
+func (m *MockScope) Execute(ctx context.Context, fn func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Execute", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Execute indicates an expected call of Execute.
+func (mr *MockScopeMockRecorder) Execute(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Execute", reflect.TypeOf((*MockScope)(nil).Execute), ctx, fn)
+}
@@ -0,0 +1,37 @@
+package transaction
+
+import (
+	"context"
+	"log/slog"
+)
+
+// RetryInfo describes the run of a transaction body in progress. A Scope
+// may run its body more than once, e.g. Spanner after an Aborted error;
+// bodies and the pre-commit handlers they trigger can read it to tell a
+// retry from the first run in their logs.
+type RetryInfo struct {
+	Attempt int // 1 on the first run
+}
+
+// Retried reports whether this run is a retry.
+func (i RetryInfo) Retried() bool { return i.Attempt > 1 }
+
+// LogAttr returns the attempt as a log attribute.
+func (i RetryInfo) LogAttr() slog.Attr { return slog.Int("tx_attempt", i.Attempt) }
+
+type retryInfoKey struct{}
+
+// WithRetryInfo returns ctx carrying info, for Scope implementations to
+// pass to each run of their body.
+func WithRetryInfo(ctx context.Context, info RetryInfo) context.Context {
+	return context.WithValue(ctx, retryInfoKey{}, info)
+}
+
+// RetryInfoFromContext returns the run in progress, or the first attempt
+// when ctx comes from a Scope that does not retry.
+func RetryInfoFromContext(ctx context.Context) RetryInfo {
+	if info, ok := ctx.Value(retryInfoKey{}).(RetryInfo); ok {
+		return info
+	}
+	return RetryInfo{Attempt: 1}
+}
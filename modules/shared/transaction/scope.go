@@ -1,3 +1,5 @@
+//go:generate mockgen -source=scope.go -destination=mocks/mock_scope.go -package=mocks
+
 package transaction
 
 import "context"
@@ -27,3 +29,10 @@ func ExecuteWithResult[T any](ctx context.Context, scope Scope, fn func(ctx cont
 	})
 	return result, err
 }
+
+// ExecuteReadOnly is ExecuteWithResult for query handlers, which are given
+// the read-only scope: every read in fn sees the same snapshot, fn runs once
+// and it must not write.
+func ExecuteReadOnly[T any](ctx context.Context, scope Scope, fn func(ctx context.Context) (T, error)) (T, error) {
+	return ExecuteWithResult(ctx, scope, fn)
+}
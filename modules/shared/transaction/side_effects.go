@@ -0,0 +1,65 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DeferredSideEffects collects the external side effects (an email, a
+// webhook call) registered with AfterCommit during one run of a transaction
+// body, to be run once the transaction has committed. A Scope that may
+// retry its body creates a new collection for every run, so that the side
+// effects of an aborted run are dropped rather than repeated.
+type DeferredSideEffects struct {
+	mu      sync.Mutex
+	effects []sideEffect
+}
+
+type sideEffect struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+type sideEffectsKey struct{}
+
+// WithDeferredSideEffects returns ctx carrying a new, empty collection, for
+// Scope implementations to pass to each run of their body.
+func WithDeferredSideEffects(ctx context.Context) (context.Context, *DeferredSideEffects) {
+	s := &DeferredSideEffects{}
+	return context.WithValue(ctx, sideEffectsKey{}, s), s
+}
+
+// AfterCommit defers fn, named for error messages, until the transaction of
+// ctx commits; it never runs if the transaction rolls back. Outside a
+// transaction that defers side effects, e.g. in a post-commit handler,
+// there is nothing to wait for and fn runs at once, returning its error.
+func AfterCommit(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	s, ok := ctx.Value(sideEffectsKey{}).(*DeferredSideEffects)
+	if !ok {
+		return fn(ctx)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.effects = append(s.effects, sideEffect{name: name, fn: fn})
+	return nil
+}
+
+// Run runs the collected side effects in the order they were registered,
+// with ctx, which must not carry the committed transaction. A failing side
+// effect does not stop the others; their errors are joined.
+func (s *DeferredSideEffects) Run(ctx context.Context) error {
+	s.mu.Lock()
+	effects := s.effects
+	s.effects = nil
+	s.mu.Unlock()
+
+	var errs []error
+	for _, e := range effects {
+		if err := e.fn(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("side effect %s: %w", e.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,79 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestAfterCommit_DefersUntilRun(t *testing.T) {
+	var ran []string
+	effect := func(name string, err error) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			ran = append(ran, name)
+			return err
+		}
+	}
+
+	ctx, effects := WithDeferredSideEffects(t.Context())
+	boom := errors.New("smtp down")
+	for _, name := range []string{"email", "webhook"} {
+		var err error
+		if name == "email" {
+			err = boom
+		}
+		if err := AfterCommit(ctx, name, effect(name, err)); err != nil {
+			t.Fatalf("AfterCommit(%s) = %v", name, err)
+		}
+	}
+	if len(ran) != 0 {
+		t.Fatalf("ran %v before commit", ran)
+	}
+
+	err := effects.Run(t.Context())
+
+	if fmt.Sprint(ran) != "[email webhook]" {
+		t.Errorf("ran %v, want [email webhook]: a failure must not stop the others", ran)
+	}
+	if !errors.Is(err, boom) {
+		t.Errorf("Run() = %v, want the email failure", err)
+	}
+	if err := effects.Run(t.Context()); err != nil || len(ran) != 2 {
+		t.Errorf("second Run() ran side effects again")
+	}
+}
+
+func TestAfterCommit_RetriedRunDropsEarlierSideEffects(t *testing.T) {
+	sent := 0
+	body := func(ctx context.Context) error {
+		return AfterCommit(ctx, "email", func(ctx context.Context) error { sent++; return nil })
+	}
+
+	// A scope runs the body twice, as after an abort, with a new collection each time.
+	var effects *DeferredSideEffects
+	for range 2 {
+		var ctx context.Context
+		ctx, effects = WithDeferredSideEffects(t.Context())
+		if err := body(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := effects.Run(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	if sent != 1 {
+		t.Errorf("sent %d emails, want 1", sent)
+	}
+}
+
+func TestAfterCommit_RunsAtOnceOutsideTransaction(t *testing.T) {
+	boom := errors.New("smtp down")
+
+	err := AfterCommit(t.Context(), "email", func(ctx context.Context) error { return boom })
+
+	if !errors.Is(err, boom) {
+		t.Errorf("AfterCommit() = %v, want the side effect's error", err)
+	}
+}
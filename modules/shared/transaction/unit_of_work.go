@@ -0,0 +1,128 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// UnitOfWork saves the aggregates a command loads or creates in its
+// transaction, so that the command only calls domain methods on them:
+//
+//	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+//		order, err := transaction.Load(ctx, "order", orderID, h.repo.FindByID, h.repo.Save)
+//		if err != nil {
+//			return err
+//		}
+//		return order.Submit(ctx, ...) // raises OrderSubmittedEvent
+//	})
+//
+// When the body succeeds, the unit of work saves every aggregate it changed,
+// in the order they were tracked; ScopeWithDomainEvent then publishes the
+// events they raised, before the commit. A fresh unit of work is used for
+// every attempt of the transaction.
+type UnitOfWork struct {
+	mu      sync.Mutex
+	tracked []tracked
+}
+
+type tracked struct {
+	name    string
+	changed func() bool
+	save    func(ctx context.Context) error
+}
+
+// versioned is implemented by aggregates whose UpdatedAt advances on every
+// state change.
+type versioned interface {
+	UpdatedAt() time.Time
+}
+
+type unitOfWorkKey struct{}
+
+// ExecuteUnitOfWork runs fn in scope with a UnitOfWork, then saves the
+// aggregates fn tracked with Load, Track or Add.
+func ExecuteUnitOfWork(ctx context.Context, scope ScopeWithDomainEvent, fn func(ctx context.Context) error) error {
+	_, err := ExecuteUnitOfWorkResult(ctx, scope, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, fn(ctx)
+	})
+	return err
+}
+
+// ExecuteUnitOfWorkResult is ExecuteUnitOfWork for a body that returns a
+// value, e.g. the ID of the aggregate it created.
+func ExecuteUnitOfWorkResult[T any](ctx context.Context, scope ScopeWithDomainEvent, fn func(ctx context.Context) (T, error)) (T, error) {
+	return ExecuteWithPublishResult(ctx, scope, func(ctx context.Context) (T, error) {
+		uow := &UnitOfWork{}
+		result, err := fn(context.WithValue(ctx, unitOfWorkKey{}, uow))
+		if err != nil {
+			var zero T
+			return zero, err
+		}
+		if err := uow.commit(ctx); err != nil {
+			var zero T
+			return zero, err
+		}
+		return result, nil
+	})
+}
+
+// Load finds the aggregate id with find and tracks it, to be saved with
+// save if it changed. name describes it in errors, e.g. "order".
+func Load[ID, A any](ctx context.Context, name string, id ID, find func(context.Context, ID) (A, error), save func(context.Context, A) error) (A, error) {
+	a, err := find(ctx, id)
+	if err != nil {
+		var zero A
+		return zero, fmt.Errorf("finding %s: %w", name, err)
+	}
+	Track(ctx, name, a, save)
+	return a, nil
+}
+
+// Track tracks an aggregate the body loaded, to be saved with save if it
+// changed: if its UpdatedAt advanced since it was tracked or, for an
+// aggregate without UpdatedAt, always.
+func Track[A any](ctx context.Context, name string, a A, save func(context.Context, A) error) {
+	changed := func() bool { return true }
+	if v, ok := any(a).(versioned); ok {
+		loaded := v.UpdatedAt()
+		changed = func() bool { return !v.UpdatedAt().Equal(loaded) }
+	}
+	unitOfWorkFrom(ctx).track(name, changed, func(ctx context.Context) error { return save(ctx, a) })
+}
+
+// Add tracks an aggregate the body created, to be saved with save.
+func Add[A any](ctx context.Context, name string, a A, save func(context.Context, A) error) {
+	unitOfWorkFrom(ctx).track(name, func() bool { return true }, func(ctx context.Context) error { return save(ctx, a) })
+}
+
+// unitOfWorkFrom panics if there is no UnitOfWork in ctx — a programming
+// error, like calling events.Add outside ScopeWithDomainEvent.
+func unitOfWorkFrom(ctx context.Context) *UnitOfWork {
+	uow, ok := ctx.Value(unitOfWorkKey{}).(*UnitOfWork)
+	if !ok {
+		panic("transaction: no unit of work in context; ensure ExecuteUnitOfWork is used")
+	}
+	return uow
+}
+
+func (u *UnitOfWork) track(name string, changed func() bool, save func(ctx context.Context) error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.tracked = append(u.tracked, tracked{name: name, changed: changed, save: save})
+}
+
+func (u *UnitOfWork) commit(ctx context.Context) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	for _, t := range u.tracked {
+		if !t.changed() {
+			continue
+		}
+		if err := t.save(ctx); err != nil {
+			return fmt.Errorf("saving %s: %w", t.name, err)
+		}
+	}
+	return nil
+}
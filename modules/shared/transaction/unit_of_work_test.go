@@ -0,0 +1,132 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// scope runs the body once, as a transaction without retries.
+type scope struct{}
+
+func (scope) ExecuteWithPublish(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type order struct {
+	id        string
+	updatedAt time.Time
+}
+
+func (o *order) UpdatedAt() time.Time { return o.updatedAt }
+
+func (o *order) touch() { o.updatedAt = o.updatedAt.Add(time.Second) }
+
+// repo records the IDs of the orders it saves.
+type repo struct {
+	orders map[string]*order
+	saved  []string
+	err    error
+}
+
+func (r *repo) FindByID(ctx context.Context, id string) (*order, error) {
+	o, ok := r.orders[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return o, nil
+}
+
+func (r *repo) Save(ctx context.Context, o *order) error {
+	r.saved = append(r.saved, o.id)
+	return r.err
+}
+
+func newRepo(ids ...string) *repo {
+	r := &repo{orders: map[string]*order{}}
+	for _, id := range ids {
+		r.orders[id] = &order{id: id, updatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	}
+	return r
+}
+
+func TestExecuteUnitOfWork_SavesChangedAggregatesInOrder(t *testing.T) {
+	r := newRepo("a", "b", "c")
+
+	err := ExecuteUnitOfWork(t.Context(), scope{}, func(ctx context.Context) error {
+		for _, id := range []string{"c", "b", "a"} {
+			o, err := Load(ctx, "order", id, r.FindByID, r.Save)
+			if err != nil {
+				return err
+			}
+			if id != "b" {
+				o.touch()
+			}
+		}
+		Add(ctx, "order", &order{id: "new"}, r.Save)
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("ExecuteUnitOfWork() error = %v", err)
+	}
+	if want := []string{"c", "a", "new"}; !slices.Equal(r.saved, want) {
+		t.Errorf("saved %v, want %v", r.saved, want)
+	}
+}
+
+func TestExecuteUnitOfWork_BodyErrorSavesNothing(t *testing.T) {
+	r := newRepo("a")
+	errRule := errors.New("order already submitted")
+
+	err := ExecuteUnitOfWork(t.Context(), scope{}, func(ctx context.Context) error {
+		o, err := Load(ctx, "order", "a", r.FindByID, r.Save)
+		if err != nil {
+			return err
+		}
+		o.touch()
+		return errRule
+	})
+
+	if !errors.Is(err, errRule) || len(r.saved) != 0 {
+		t.Errorf("ExecuteUnitOfWork() error = %v, saved %v; want %v, none", err, r.saved, errRule)
+	}
+}
+
+func TestExecuteUnitOfWork_WrapsErrors(t *testing.T) {
+	errSave := errors.New("unavailable")
+	r := newRepo("a")
+	r.err = errSave
+
+	err := ExecuteUnitOfWork(t.Context(), scope{}, func(ctx context.Context) error {
+		o, err := Load(ctx, "order", "a", r.FindByID, r.Save)
+		if err != nil {
+			return err
+		}
+		o.touch()
+		return nil
+	})
+	if !errors.Is(err, errSave) || err.Error() != "saving order: unavailable" {
+		t.Errorf("save error = %v", err)
+	}
+
+	err = ExecuteUnitOfWork(t.Context(), scope{}, func(ctx context.Context) error {
+		_, err := Load(ctx, "order", "missing", r.FindByID, r.Save)
+		return err
+	})
+	if err == nil || err.Error() != "finding order: not found" {
+		t.Errorf("find error = %v", err)
+	}
+}
+
+func TestTrack_OutsideUnitOfWorkPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Track() did not panic without a unit of work")
+		}
+	}()
+	r := newRepo()
+	Track(t.Context(), "order", &order{id: "a"}, r.Save)
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+)
+
+// ConfirmEmailChangeCommand represents the intent to apply the email change
+// a token issued by RequestEmailChangeHandler confirms.
+type ConfirmEmailChangeCommand struct {
+	Token string
+}
+
+// ConfirmEmailChangeHandler handles the ConfirmEmailChangeCommand.
+type ConfirmEmailChangeHandler struct {
+	repo        domain.UserRepository
+	txScope     transaction.ScopeWithDomainEvent
+	emailPolicy domain.EmailPolicy
+	signer      *tokens.Signer
+}
+
+func NewConfirmEmailChangeHandler(repo domain.UserRepository, txScope transaction.ScopeWithDomainEvent, emailPolicy domain.EmailPolicy, signer *tokens.Signer) *ConfirmEmailChangeHandler {
+	return &ConfirmEmailChangeHandler{
+		repo:        repo,
+		txScope:     txScope,
+		emailPolicy: emailPolicy,
+		signer:      signer,
+	}
+}
+
+// Handle executes the confirm email change use case in the tenant the token
+// was issued in: the link is followed without credentials. A token that is not
+// valid, or whose user's email has changed since it was issued (it was
+// already used, or superseded), fails with an error wrapping
+// tokens.ErrInvalid, tokens.ErrExpired or tokens.ErrWrongPurpose.
+func (h *ConfirmEmailChangeHandler) Handle(ctx context.Context, cmd ConfirmEmailChangeCommand) error {
+	claims, err := h.signer.Verify(ctx, cmd.Token, EmailChangePurpose)
+	if err != nil {
+		return fmt.Errorf("verifying email change token: %w", err)
+	}
+	ctx = tokens.WithTenant(ctx, claims)
+
+	userID, err := domain.ParseUserID(claims.Subject)
+	if err != nil {
+		return fmt.Errorf("email change token: %w", tokens.ErrInvalid)
+	}
+	email, err := h.emailPolicy.NewEmail(claims.Data["email"])
+	if err != nil {
+		return fmt.Errorf("invalid email: %w", err)
+	}
+
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		user, err := transaction.Load(ctx, "user", userID, findUser(h.repo), h.repo.Save)
+		if err != nil {
+			return err
+		}
+		if user.Email().String() != claims.Data["from"] {
+			return fmt.Errorf("email change token already used: %w", tokens.ErrInvalid)
+		}
+
+		exists, err := h.repo.Exists(ctx, email)
+		if err != nil {
+			return fmt.Errorf("checking email existence: %w", err)
+		}
+		if exists {
+			return domain.ErrEmailExists
+		}
+
+		if err := user.ChangeEmail(ctx, email); err != nil {
+			return fmt.Errorf("changing email: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -6,12 +6,16 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
 	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
 )
 
 // CreateUserCommand represents the intent to create a new user.
 type CreateUserCommand struct {
+	// UserID is optional: a new ID is generated when empty. Fixtures set it
+	// to get deterministic IDs.
+	UserID    string
 	Email     string
 	FirstName string
 	LastName  string
@@ -19,21 +23,23 @@ type CreateUserCommand struct {
 
 // CreateUserHandler handles the CreateUserCommand.
 type CreateUserHandler struct {
-	repo    domain.UserRepository
-	txScope transaction.ScopeWithDomainEvent
+	repo        domain.UserRepository
+	txScope     transaction.ScopeWithDomainEvent
+	emailPolicy domain.EmailPolicy
 }
 
-func NewCreateUserHandler(repo domain.UserRepository, txScope transaction.ScopeWithDomainEvent) *CreateUserHandler {
+func NewCreateUserHandler(repo domain.UserRepository, txScope transaction.ScopeWithDomainEvent, emailPolicy domain.EmailPolicy) *CreateUserHandler {
 	return &CreateUserHandler{
-		repo:    repo,
-		txScope: txScope,
+		repo:        repo,
+		txScope:     txScope,
+		emailPolicy: emailPolicy,
 	}
 }
 
 // Handle executes the create user use case.
 func (h *CreateUserHandler) Handle(ctx context.Context, cmd CreateUserCommand) (string, error) {
 	// Validate and create value objects (before transaction)
-	email, err := domain.NewEmail(cmd.Email)
+	email, err := h.emailPolicy.NewEmail(cmd.Email)
 	if err != nil {
 		return "", fmt.Errorf("invalid email: %w", err)
 	}
@@ -43,7 +49,14 @@ func (h *CreateUserHandler) Handle(ctx context.Context, cmd CreateUserCommand) (
 		return "", fmt.Errorf("invalid name: %w", err)
 	}
 
-	return transaction.ExecuteWithPublishResult(ctx, h.txScope, func(ctx context.Context) (string, error) {
+	var id domain.UserID // generated in the transaction when not given
+	if cmd.UserID != "" {
+		if id, err = domain.ParseUserID(cmd.UserID); err != nil {
+			return "", fmt.Errorf("invalid user ID: %w", err)
+		}
+	}
+
+	return transaction.ExecuteUnitOfWorkResult(ctx, h.txScope, func(ctx context.Context) (string, error) {
 		exists, err := h.repo.Exists(ctx, email)
 		if err != nil {
 			return "", fmt.Errorf("checking email existence: %w", err)
@@ -52,14 +65,17 @@ func (h *CreateUserHandler) Handle(ctx context.Context, cmd CreateUserCommand) (
 			return "", domain.ErrEmailExists
 		}
 
-		// Create the user aggregate (adds UserCreatedEvent to ctx)
-		user := domain.NewUser(ctx, email, name)
-
-		// Persist the user
-		if err := h.repo.Save(ctx, user); err != nil {
-			return "", fmt.Errorf("saving user: %w", err)
+		userID := id
+		if userID.IsZero() {
+			userID = domain.NewUserID(ctx)
 		}
 
+		// Create the user aggregate (adds UserCreatedEvent to ctx), in the
+		// locale of the request that signed them up
+		prefs := domain.PreferencesForLocale(requestcontext.Locale(ctx))
+		user := domain.NewUserWithPreferences(ctx, userID, email, name, prefs)
+		transaction.Add(ctx, "user", user, h.repo.Save)
+
 		return user.ID().String(), nil
 	})
 }
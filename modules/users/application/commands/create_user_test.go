@@ -0,0 +1,36 @@
+package commands_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events/eventstest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/users/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/users/domain/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateUserHandler_Handle_RequestLocale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	var saved *domain.User
+	repo := domainmocks.NewMockUserRepository(ctrl)
+	repo.EXPECT().Exists(gomock.Any(), gomock.Any()).Return(false, nil)
+	repo.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u *domain.User) error {
+		saved = u
+		return nil
+	})
+	scope, _ := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewCreateUserHandler(repo, scope, domain.EmailPolicy{})
+
+	ctx := requestcontext.With(t.Context(), requestcontext.Values{Locale: "ja-JP"})
+	_, err := handler.Handle(ctx, commands.CreateUserCommand{Email: "alice@example.com", FirstName: "Alice", LastName: "Smith"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if saved == nil || saved.Preferences().Locale() != "ja-JP" {
+		t.Fatalf("expected the user to be saved in the request's locale, got %+v", saved)
+	}
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
 	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
@@ -10,7 +11,8 @@ import (
 
 // DeleteUserCommand represents the intent to delete a user.
 type DeleteUserCommand struct {
-	UserID string
+	UserID            string
+	ExpectedUpdatedAt time.Time // see User.CheckUnchanged; zero skips the check
 }
 
 // DeleteUserHandler handles the DeleteUserCommand.
@@ -33,21 +35,19 @@ func (h *DeleteUserHandler) Handle(ctx context.Context, cmd DeleteUserCommand) e
 		return fmt.Errorf("invalid user ID: %w", err)
 	}
 
-	fn := func(ctx context.Context) error {
-		user, err := h.repo.FindByID(ctx, userID)
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		user, err := transaction.Load(ctx, "user", userID, findUser(h.repo), h.repo.Save)
 		if err != nil {
-			return fmt.Errorf("finding user: %w", err)
+			return err
+		}
+		if err := user.CheckUnchanged(cmd.ExpectedUpdatedAt); err != nil {
+			return err
 		}
 
 		if err := user.Delete(ctx); err != nil {
 			return fmt.Errorf("deleting user: %w", err)
 		}
 
-		if err := h.repo.Save(ctx, user); err != nil {
-			return fmt.Errorf("saving user: %w", err)
-		}
-
 		return nil
-	}
-	return h.txScope.ExecuteWithPublish(ctx, fn)
+	})
 }
@@ -16,12 +16,12 @@ import (
 func TestDeleteUserHandler_Handle_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
-	userID := domain.NewUserID()
+	userID := domain.NewUserID(t.Context())
 	user := createTestUser(t, userID)
 
 	repo := domainmocks.NewMockUserRepository(ctrl)
 	gomock.InOrder(
-		repo.EXPECT().FindByID(gomock.Any(), userID).Return(user, nil),
+		repo.EXPECT().FindByID(gomock.Any(), userID, domain.IncludeDeleted).Return(user, nil),
 		repo.EXPECT().Save(gomock.Any(), deletedUser(userID)).Return(nil),
 	)
 
@@ -61,11 +61,11 @@ func TestDeleteUserHandler_Handle_InvalidUserID(t *testing.T) {
 func TestDeleteUserHandler_Handle_UserNotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
-	userID := domain.NewUserID()
+	userID := domain.NewUserID(t.Context())
 	errNotFound := errors.New("user not found")
 
 	repo := domainmocks.NewMockUserRepository(ctrl)
-	repo.EXPECT().FindByID(gomock.Any(), userID).Return(nil, errNotFound)
+	repo.EXPECT().FindByID(gomock.Any(), userID, domain.IncludeDeleted).Return(nil, errNotFound)
 
 	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
 	handler := commands.NewDeleteUserHandler(repo, scope)
@@ -86,13 +86,13 @@ func TestDeleteUserHandler_Handle_UserNotFound(t *testing.T) {
 func TestDeleteUserHandler_Handle_SaveError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
-	userID := domain.NewUserID()
+	userID := domain.NewUserID(t.Context())
 	user := createTestUser(t, userID)
 	errSave := errors.New("save failed")
 
 	repo := domainmocks.NewMockUserRepository(ctrl)
 	gomock.InOrder(
-		repo.EXPECT().FindByID(gomock.Any(), userID).Return(user, nil),
+		repo.EXPECT().FindByID(gomock.Any(), userID, domain.IncludeDeleted).Return(user, nil),
 		repo.EXPECT().Save(gomock.Any(), deletedUser(userID)).Return(errSave),
 	)
 
@@ -115,7 +115,7 @@ func TestDeleteUserHandler_Handle_SaveError(t *testing.T) {
 func TestDeleteUserHandler_Handle_TransactionError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
-	userID := domain.NewUserID()
+	userID := domain.NewUserID(t.Context())
 	errTx := errors.New("transaction failed")
 
 	handler := commands.NewDeleteUserHandler(nil, eventstest.NewScopeError(ctrl, errTx))
@@ -155,5 +155,5 @@ func createTestUser(t *testing.T, id domain.UserID) *domain.User {
 		t.Fatalf("failed to create name: %v", err)
 	}
 
-	return domain.Reconstitute(id, email, name, domain.StatusActive, time.Now(), time.Now())
+	return domain.Reconstitute(id, email, name, domain.StatusActive, domain.DefaultPreferences(), domain.PasswordHash{}, time.Now(), time.Now())
 }
@@ -0,0 +1,148 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events/eventstest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
+	"github.com/rai/clean-modularmonolith-go/modules/users/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/users/domain/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func newSigner(t *testing.T) *tokens.Signer {
+	t.Helper()
+	signer, err := tokens.NewSigner([]byte(strings.Repeat("s", tokens.MinSecretLength)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+// requestEmailChange runs RequestEmailChange for user and returns the token
+// of the event it raised.
+func requestEmailChange(t *testing.T, signer *tokens.Signer, user *domain.User, email string) string {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	repo := domainmocks.NewMockUserRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), user.ID(), domain.IncludeDeleted).Return(user, nil)
+	repo.EXPECT().Exists(gomock.Any(), gomock.Any()).Return(false, nil)
+	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewRequestEmailChangeHandler(repo, scope, domain.EmailPolicy{}, signer, time.Hour)
+
+	if err := handler.Handle(t.Context(), commands.RequestEmailChangeCommand{UserID: user.ID().String(), Email: email}); err != nil {
+		t.Fatalf("RequestEmailChange: unexpected error: %v", err)
+	}
+	if len(capture.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(capture.Events))
+	}
+	event, ok := capture.Events[0].(userevents.EmailChangeRequestedEvent)
+	if !ok || event.NewEmail != email || event.Token == "" {
+		t.Fatalf("expected EmailChangeRequestedEvent for %s, got %+v", email, capture.Events[0])
+	}
+	return event.Token
+}
+
+func TestEmailChange_RequestAndConfirm(t *testing.T) {
+	signer := newSigner(t)
+	user := createTestUser(t, domain.NewUserID(t.Context()))
+	token := requestEmailChange(t, signer, user, "new@example.com")
+	if user.Email().String() != "test@example.com" {
+		t.Fatalf("the request changed the email to %s", user.Email())
+	}
+
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockUserRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), user.ID(), domain.IncludeDeleted).Return(user, nil).Times(2)
+	repo.EXPECT().Exists(gomock.Any(), gomock.Any()).Return(false, nil)
+	repo.EXPECT().Save(gomock.Any(), user).Return(nil)
+	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewConfirmEmailChangeHandler(repo, scope, domain.EmailPolicy{}, signer)
+
+	if err := handler.Handle(t.Context(), commands.ConfirmEmailChangeCommand{Token: token}); err != nil {
+		t.Fatalf("ConfirmEmailChange: unexpected error: %v", err)
+	}
+	if user.Email().String() != "new@example.com" {
+		t.Errorf("expected the email to be changed, got %s", user.Email())
+	}
+	if len(capture.Events) != 1 || capture.Events[0].EventType() != userevents.UserUpdatedEventType {
+		t.Errorf("expected a UserUpdated event, got %v", capture.Events)
+	}
+
+	// The email the token was bound to has changed: it cannot be used again
+	scope, _ = eventstest.NewScopeCaptureEvents(ctrl)
+	handler = commands.NewConfirmEmailChangeHandler(repo, scope, domain.EmailPolicy{}, signer)
+	err := handler.Handle(t.Context(), commands.ConfirmEmailChangeCommand{Token: token})
+	if !errors.Is(err, tokens.ErrInvalid) {
+		t.Errorf("expected ErrInvalid confirming twice, got %v", err)
+	}
+}
+
+// TestEmailChange_OtherTenant confirms, without credentials and so in the
+// default tenant, the email change of a user of another tenant.
+func TestEmailChange_OtherTenant(t *testing.T) {
+	signer := newSigner(t)
+	acme := requestcontext.With(t.Context(), requestcontext.Values{TenantID: "acme"})
+	user := createTestUser(t, domain.NewUserID(t.Context()))
+
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockUserRepository(ctrl)
+	findInAcme := func(ctx context.Context, _ domain.UserID, _ domain.DeletedFilter) (*domain.User, error) {
+		if requestcontext.TenantID(ctx) != "acme" {
+			return nil, domain.ErrUserNotFound
+		}
+		return user, nil
+	}
+	repo.EXPECT().FindByID(gomock.Any(), user.ID(), domain.IncludeDeleted).DoAndReturn(findInAcme).Times(2)
+	repo.EXPECT().Exists(gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+	repo.EXPECT().Save(gomock.Any(), user).Return(nil)
+
+	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+	request := commands.NewRequestEmailChangeHandler(repo, scope, domain.EmailPolicy{}, signer, time.Hour)
+	if err := request.Handle(acme, commands.RequestEmailChangeCommand{UserID: user.ID().String(), Email: "new@example.com"}); err != nil {
+		t.Fatalf("RequestEmailChange: unexpected error: %v", err)
+	}
+	token := capture.Events[0].(userevents.EmailChangeRequestedEvent).Token
+
+	scope, _ = eventstest.NewScopeCaptureEvents(ctrl)
+	confirm := commands.NewConfirmEmailChangeHandler(repo, scope, domain.EmailPolicy{}, signer)
+	if err := confirm.Handle(t.Context(), commands.ConfirmEmailChangeCommand{Token: token}); err != nil {
+		t.Fatalf("ConfirmEmailChange: unexpected error: %v", err)
+	}
+	if user.Email().String() != "new@example.com" {
+		t.Errorf("expected the email to be changed, got %s", user.Email())
+	}
+}
+
+func TestConfirmEmailChange_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	handler := commands.NewConfirmEmailChangeHandler(domainmocks.NewMockUserRepository(ctrl), nil, domain.EmailPolicy{}, newSigner(t))
+
+	err := handler.Handle(t.Context(), commands.ConfirmEmailChangeCommand{Token: "not-a-token"})
+	if !errors.Is(err, tokens.ErrInvalid) {
+		t.Errorf("expected ErrInvalid, got %v", err)
+	}
+}
+
+func TestRequestEmailChange_NotConfigured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	user := createTestUser(t, domain.NewUserID(t.Context()))
+	repo := domainmocks.NewMockUserRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), user.ID(), domain.IncludeDeleted).Return(user, nil)
+	repo.EXPECT().Exists(gomock.Any(), gomock.Any()).Return(false, nil)
+	scope, _ := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewRequestEmailChangeHandler(repo, scope, domain.EmailPolicy{}, nil, time.Hour)
+
+	err := handler.Handle(t.Context(), commands.RequestEmailChangeCommand{UserID: user.ID().String(), Email: "new@example.com"})
+	if !errors.Is(err, tokens.ErrNotConfigured) {
+		t.Errorf("expected ErrNotConfigured, got %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+)
+
+// findUser returns the FindByID of repo for transaction.Load, deleted users
+// included: the commands load them to reject changes with ErrUserDeleted
+// rather than ErrUserNotFound.
+func findUser(repo domain.UserRepository) func(context.Context, domain.UserID) (*domain.User, error) {
+	return func(ctx context.Context, id domain.UserID) (*domain.User, error) {
+		return repo.FindByID(ctx, id, domain.IncludeDeleted)
+	}
+}
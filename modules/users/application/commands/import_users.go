@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/ids"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+)
+
+// ImportUsersCommand represents the intent to create users in bulk, e.g.
+// when migrating them from another system, for operators.
+type ImportUsersCommand struct {
+	Users []ImportUser
+}
+
+// ImportUser is a user to import.
+type ImportUser struct {
+	Email     string
+	FirstName string
+	LastName  string
+}
+
+// ImportUsersResult reports the outcome of an import, user by user.
+type ImportUsersResult struct {
+	// UserIDs are the IDs of the users imported.
+	UserIDs []string
+	// Failures are the users not imported, in the order of the command.
+	Failures []ImportFailure
+}
+
+// ImportFailure is a user of an ImportUsersCommand that was not imported.
+type ImportFailure struct {
+	Index int // in ImportUsersCommand.Users
+	Email string
+	Err   error
+}
+
+// ImportUsersHandler handles the ImportUsersCommand.
+type ImportUsersHandler struct {
+	repo        domain.UserRepository
+	txScope     transaction.ScopeWithDomainEvent
+	emailPolicy domain.EmailPolicy
+	clock       clock.Clock
+	idGenerator ids.Generator
+}
+
+// NewImportUsersHandler creates the handler. The users are written outside
+// txScope, so it takes the clock and ID generator txScope installs for the
+// other commands; nil ones fall back to the defaults.
+func NewImportUsersHandler(repo domain.UserRepository, txScope transaction.ScopeWithDomainEvent, emailPolicy domain.EmailPolicy, clk clock.Clock, idGenerator ids.Generator) *ImportUsersHandler {
+	return &ImportUsersHandler{
+		repo:        repo,
+		txScope:     txScope,
+		emailPolicy: emailPolicy,
+		clock:       clk,
+		idGenerator: idGenerator,
+	}
+}
+
+// Handle validates the users, inserts the valid ones with the repository's
+// bulk Import, which does not stop at the first failure, then publishes the
+// UserCreatedEvents of those inserted in one transaction, so the users are
+// announced only once they exist. Invalid and duplicate users are reported
+// in the result rather than failing the command; an error means the import
+// or the publication failed as a whole, and the result tells which users
+// were imported nonetheless.
+func (h *ImportUsersHandler) Handle(ctx context.Context, cmd ImportUsersCommand) (ImportUsersResult, error) {
+	var result ImportUsersResult
+	ctx = ids.WithGenerator(clock.WithClock(ctx, h.clock), h.idGenerator)
+
+	var users []*domain.User
+	var created [][]events.Event // of each user
+	var indexes []int            // of each user in cmd.Users
+	for i, u := range cmd.Users {
+		email, err := h.emailPolicy.NewEmail(u.Email)
+		if err != nil {
+			result.Failures = append(result.Failures, ImportFailure{Index: i, Email: u.Email, Err: fmt.Errorf("invalid email: %w", err)})
+			continue
+		}
+		name, err := domain.NewName(u.FirstName, u.LastName)
+		if err != nil {
+			result.Failures = append(result.Failures, ImportFailure{Index: i, Email: u.Email, Err: fmt.Errorf("invalid name: %w", err)})
+			continue
+		}
+
+		var user *domain.User
+		evts, _ := events.CaptureEvents(ctx, func(ctx context.Context) error {
+			user = domain.NewUser(ctx, email, name)
+			return nil
+		})
+		users = append(users, user)
+		created = append(created, evts)
+		indexes = append(indexes, i)
+	}
+	if len(users) == 0 {
+		return result, nil
+	}
+
+	errs, importErr := h.repo.Import(ctx, users)
+	var imported []events.Event
+	for j, user := range users {
+		err := importErr // for users the repository did not report on
+		if j < len(errs) {
+			err = errs[j]
+		}
+		if err != nil {
+			i := indexes[j]
+			result.Failures = append(result.Failures, ImportFailure{Index: i, Email: cmd.Users[i].Email, Err: err})
+			continue
+		}
+		result.UserIDs = append(result.UserIDs, user.ID().String())
+		imported = append(imported, created[j]...)
+	}
+	// Validation failures were found first.
+	slices.SortFunc(result.Failures, func(a, b ImportFailure) int { return a.Index - b.Index })
+
+	if len(imported) > 0 {
+		err := h.txScope.ExecuteWithPublish(ctx, func(ctx context.Context) error {
+			events.Add(ctx, imported...)
+			return nil
+		})
+		if err != nil {
+			return result, fmt.Errorf("publishing imported users: %w", err)
+		}
+	}
+	return result, importErr
+}
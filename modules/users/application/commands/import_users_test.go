@@ -0,0 +1,71 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events/eventstest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/ids/idstest"
+	"github.com/rai/clean-modularmonolith-go/modules/users/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/users/domain/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImportUsersHandler_Handle_ReportsFailuresPerUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	repo := domainmocks.NewMockUserRepository(ctrl)
+	repo.EXPECT().Import(gomock.Any(), gomock.Len(2)).DoAndReturn(func(_ context.Context, users []*domain.User) ([]error, error) {
+		return []error{nil, domain.ErrEmailExists}, nil
+	})
+	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewImportUsersHandler(repo, scope, domain.EmailPolicy{}, nil, idstest.NewSequence())
+
+	result, err := handler.Handle(t.Context(), commands.ImportUsersCommand{Users: []commands.ImportUser{
+		{Email: "alice@example.com", FirstName: "Alice", LastName: "Smith"},
+		{Email: "not-an-email", FirstName: "Bob", LastName: "Jones"},
+		{Email: "carol@example.com", FirstName: "Carol", LastName: "White"},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.UserIDs) != 1 || result.UserIDs[0] != idstest.ID(1) {
+		t.Errorf("UserIDs = %v, want [%s]", result.UserIDs, idstest.ID(1))
+	}
+	if len(result.Failures) != 2 || result.Failures[0].Index != 1 || result.Failures[1].Index != 2 {
+		t.Fatalf("Failures = %+v, want users 1 and 2", result.Failures)
+	}
+	if !errors.Is(result.Failures[1].Err, domain.ErrEmailExists) {
+		t.Errorf("Failures[1].Err = %v, want ErrEmailExists", result.Failures[1].Err)
+	}
+	if len(capture.Events) != 1 {
+		t.Fatalf("expected 1 event for the imported user, got %d", len(capture.Events))
+	}
+	if created, ok := capture.Events[0].(userevents.UserCreatedEvent); !ok || created.Email != "alice@example.com" {
+		t.Errorf("expected alice's UserCreatedEvent, got %+v", capture.Events[0])
+	}
+}
+
+func TestImportUsersHandler_Handle_ImportFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	unavailable := errors.New("spanner unavailable")
+	repo := domainmocks.NewMockUserRepository(ctrl)
+	repo.EXPECT().Import(gomock.Any(), gomock.Any()).Return(nil, unavailable)
+	handler := commands.NewImportUsersHandler(repo, nil, domain.EmailPolicy{}, nil, nil)
+
+	result, err := handler.Handle(t.Context(), commands.ImportUsersCommand{Users: []commands.ImportUser{
+		{Email: "alice@example.com", FirstName: "Alice", LastName: "Smith"},
+	}})
+
+	if !errors.Is(err, unavailable) {
+		t.Errorf("expected the import error, got %v", err)
+	}
+	if len(result.UserIDs) != 0 || len(result.Failures) != 1 {
+		t.Errorf("expected alice reported as failed, got %+v", result)
+	}
+}
@@ -0,0 +1,157 @@
+package commands_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events/eventstest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
+	"github.com/rai/clean-modularmonolith-go/modules/users/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/users/domain/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+const newPassword = "correct horse battery staple"
+
+// requestPasswordReset runs RequestPasswordReset for user in ctx and returns
+// the token of the event it raised.
+func requestPasswordReset(t *testing.T, ctx context.Context, signer *tokens.Signer, user *domain.User) string {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+
+	repo := domainmocks.NewMockUserRepository(ctrl)
+	repo.EXPECT().FindByEmail(gomock.Any(), user.Email()).Return(user, nil)
+	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewRequestPasswordResetHandler(repo, scope, domain.EmailPolicy{}, signer, time.Hour)
+
+	if err := handler.Handle(ctx, commands.RequestPasswordResetCommand{Email: user.Email().String()}); err != nil {
+		t.Fatalf("RequestPasswordReset: unexpected error: %v", err)
+	}
+	if len(capture.Events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(capture.Events))
+	}
+	event, ok := capture.Events[0].(userevents.PasswordResetRequestedEvent)
+	if !ok || event.UserID != user.ID().String() || event.Token == "" {
+		t.Fatalf("expected PasswordResetRequestedEvent for %s, got %+v", user.ID(), capture.Events[0])
+	}
+	return event.Token
+}
+
+func TestPasswordReset_RequestAndReset(t *testing.T) {
+	signer := newSigner(t)
+	user := createTestUser(t, domain.NewUserID(t.Context()))
+	token := requestPasswordReset(t, t.Context(), signer, user)
+	if !user.Password().IsZero() {
+		t.Fatal("the request set a password")
+	}
+
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockUserRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), user.ID(), domain.IncludeDeleted).Return(user, nil).Times(2)
+	repo.EXPECT().Save(gomock.Any(), user).Return(nil)
+	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewResetPasswordHandler(repo, scope, signer)
+
+	if err := handler.Handle(t.Context(), commands.ResetPasswordCommand{Token: token, Password: newPassword}); err != nil {
+		t.Fatalf("ResetPassword: unexpected error: %v", err)
+	}
+	if !user.Password().Matches(newPassword) {
+		t.Error("expected the password to be set")
+	}
+	if len(capture.Events) != 1 || capture.Events[0].EventType() != userevents.PasswordChangedEventType {
+		t.Errorf("expected a PasswordChanged event, got %v", capture.Events)
+	}
+
+	// The password the token was bound to has changed: it cannot be used again
+	scope, _ = eventstest.NewScopeCaptureEvents(ctrl)
+	handler = commands.NewResetPasswordHandler(repo, scope, signer)
+	err := handler.Handle(t.Context(), commands.ResetPasswordCommand{Token: token, Password: newPassword})
+	if !errors.Is(err, tokens.ErrInvalid) {
+		t.Errorf("expected ErrInvalid resetting twice, got %v", err)
+	}
+}
+
+// TestPasswordReset_OtherTenant resets, without credentials and so in the
+// default tenant, the password of a user of another tenant.
+func TestPasswordReset_OtherTenant(t *testing.T) {
+	signer := newSigner(t)
+	acme := requestcontext.With(t.Context(), requestcontext.Values{TenantID: "acme"})
+	user := createTestUser(t, domain.NewUserID(t.Context()))
+	token := requestPasswordReset(t, acme, signer, user)
+
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockUserRepository(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), user.ID(), domain.IncludeDeleted).DoAndReturn(func(ctx context.Context, _ domain.UserID, _ domain.DeletedFilter) (*domain.User, error) {
+		if requestcontext.TenantID(ctx) != "acme" {
+			return nil, domain.ErrUserNotFound
+		}
+		return user, nil
+	})
+	repo.EXPECT().Save(gomock.Any(), user).Return(nil)
+	scope, _ := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewResetPasswordHandler(repo, scope, signer)
+
+	if err := handler.Handle(t.Context(), commands.ResetPasswordCommand{Token: token, Password: newPassword}); err != nil {
+		t.Fatalf("ResetPassword: unexpected error: %v", err)
+	}
+	if !user.Password().Matches(newPassword) {
+		t.Error("expected the password to be set")
+	}
+}
+
+func TestRequestPasswordReset_UnknownEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockUserRepository(ctrl)
+	repo.EXPECT().FindByEmail(gomock.Any(), gomock.Any()).Return(nil, domain.ErrUserNotFound)
+	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewRequestPasswordResetHandler(repo, scope, domain.EmailPolicy{}, newSigner(t), time.Hour)
+
+	if err := handler.Handle(t.Context(), commands.RequestPasswordResetCommand{Email: "nobody@example.com"}); err != nil {
+		t.Fatalf("expected no error for an unknown email, got %v", err)
+	}
+	if len(capture.Events) != 0 {
+		t.Errorf("expected no event, got %v", capture.Events)
+	}
+}
+
+func TestResetPassword_Invalid(t *testing.T) {
+	signer := newSigner(t)
+	user := createTestUser(t, domain.NewUserID(t.Context()))
+	token := requestPasswordReset(t, t.Context(), signer, user)
+
+	tests := []struct {
+		name     string
+		token    string
+		password string
+		wantErr  error
+	}{
+		{"invalid token", "not-a-token", newPassword, tokens.ErrInvalid},
+		{"password too short", token, "short", domain.ErrPasswordTooShort},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			handler := commands.NewResetPasswordHandler(domainmocks.NewMockUserRepository(ctrl), nil, signer)
+
+			err := handler.Handle(t.Context(), commands.ResetPasswordCommand{Token: tt.token, Password: tt.password})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRequestPasswordReset_NotConfigured(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	handler := commands.NewRequestPasswordResetHandler(domainmocks.NewMockUserRepository(ctrl), nil, domain.EmailPolicy{}, nil, time.Hour)
+
+	err := handler.Handle(t.Context(), commands.RequestPasswordResetCommand{Email: "test@example.com"})
+	if !errors.Is(err, tokens.ErrNotConfigured) {
+		t.Errorf("expected ErrNotConfigured, got %v", err)
+	}
+}
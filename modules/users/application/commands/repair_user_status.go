@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+)
+
+// RepairUserStatusCommand represents the intent to set the status of a user
+// whose stored status is corrupt, for operators.
+type RepairUserStatusCommand struct {
+	UserID string
+	Status string
+}
+
+// RepairUserStatusHandler handles the RepairUserStatusCommand.
+type RepairUserStatusHandler struct {
+	repo    domain.UserRepository
+	txScope transaction.ScopeWithDomainEvent
+}
+
+func NewRepairUserStatusHandler(repo domain.UserRepository, txScope transaction.ScopeWithDomainEvent) *RepairUserStatusHandler {
+	return &RepairUserStatusHandler{
+		repo:    repo,
+		txScope: txScope,
+	}
+}
+
+// Handle writes the status to the stored user without loading it, which a
+// corrupt user cannot be, so the user lifecycle is not enforced and no event
+// is raised; users with a valid status are left alone (ErrUserStatusValid).
+func (h *RepairUserStatusHandler) Handle(ctx context.Context, cmd RepairUserStatusCommand) error {
+	userID, err := domain.ParseUserID(cmd.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+	status, err := domain.ParseStatus(cmd.Status)
+	if err != nil {
+		return err
+	}
+
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		return h.repo.RepairStatus(ctx, userID, status, clock.Now(ctx))
+	})
+}
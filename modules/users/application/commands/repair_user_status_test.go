@@ -0,0 +1,39 @@
+package commands_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events/eventstest"
+	"github.com/rai/clean-modularmonolith-go/modules/users/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/users/domain/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRepairUserStatusHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	userID := domain.NewUserID(t.Context())
+
+	repo := domainmocks.NewMockUserRepository(ctrl)
+	repo.EXPECT().RepairStatus(gomock.Any(), userID, domain.StatusInactive, gomock.Any()).Return(nil)
+	scope, capture := eventstest.NewScopeCaptureEvents(ctrl)
+	handler := commands.NewRepairUserStatusHandler(repo, scope)
+
+	if err := handler.Handle(t.Context(), commands.RepairUserStatusCommand{UserID: userID.String(), Status: "inactive"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(capture.Events) != 0 {
+		t.Errorf("expected no events, got %d", len(capture.Events))
+	}
+}
+
+func TestRepairUserStatusHandler_Handle_InvalidStatus(t *testing.T) {
+	handler := commands.NewRepairUserStatusHandler(nil, nil)
+
+	err := handler.Handle(t.Context(), commands.RepairUserStatusCommand{UserID: domain.NewUserID(t.Context()).String(), Status: "banned"})
+
+	if !errors.Is(err, domain.ErrStatusInvalid) {
+		t.Errorf("expected ErrStatusInvalid, got %v", err)
+	}
+}
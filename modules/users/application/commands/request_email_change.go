@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+)
+
+// EmailChangePurpose is the purpose of the tokens that confirm an email
+// change.
+const EmailChangePurpose = "users.email_change"
+
+// RequestEmailChangeCommand represents the intent to change a user's email,
+// once confirmed with the token mailed to the user (ConfirmEmailChangeCommand).
+type RequestEmailChangeCommand struct {
+	UserID string
+	Email  string
+}
+
+// RequestEmailChangeHandler handles the RequestEmailChangeCommand.
+type RequestEmailChangeHandler struct {
+	repo        domain.UserRepository
+	txScope     transaction.ScopeWithDomainEvent
+	emailPolicy domain.EmailPolicy
+	signer      *tokens.Signer
+	ttl         time.Duration
+}
+
+// NewRequestEmailChangeHandler creates a handler that issues confirmation
+// tokens valid for ttl. A nil signer fails every request with
+// tokens.ErrNotConfigured.
+func NewRequestEmailChangeHandler(repo domain.UserRepository, txScope transaction.ScopeWithDomainEvent, emailPolicy domain.EmailPolicy, signer *tokens.Signer, ttl time.Duration) *RequestEmailChangeHandler {
+	return &RequestEmailChangeHandler{
+		repo:        repo,
+		txScope:     txScope,
+		emailPolicy: emailPolicy,
+		signer:      signer,
+		ttl:         ttl,
+	}
+}
+
+// Handle executes the request email change use case.
+func (h *RequestEmailChangeHandler) Handle(ctx context.Context, cmd RequestEmailChangeCommand) error {
+	userID, err := domain.ParseUserID(cmd.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	email, err := h.emailPolicy.NewEmail(cmd.Email)
+	if err != nil {
+		return fmt.Errorf("invalid email: %w", err)
+	}
+
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		user, err := transaction.Load(ctx, "user", userID, findUser(h.repo), h.repo.Save)
+		if err != nil {
+			return err
+		}
+
+		exists, err := h.repo.Exists(ctx, email)
+		if err != nil {
+			return fmt.Errorf("checking email existence: %w", err)
+		}
+		if exists {
+			return domain.ErrEmailExists
+		}
+
+		// The token is bound to the current email, so that it is refused
+		// once the email has changed, by it or otherwise
+		token, err := h.signer.Issue(ctx, tokens.Claims{
+			Purpose: EmailChangePurpose,
+			Subject: userID.String(),
+			Data:    map[string]string{"email": email.String(), "from": user.Email().String()},
+		}, h.ttl)
+		if err != nil {
+			return fmt.Errorf("issuing email change token: %w", err)
+		}
+
+		if err := user.RequestEmailChange(ctx, email, token); err != nil {
+			return fmt.Errorf("requesting email change: %w", err)
+		}
+
+		return nil
+	})
+}
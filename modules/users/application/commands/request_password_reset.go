@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+)
+
+// PasswordResetPurpose is the purpose of the tokens that confirm a password
+// reset.
+const PasswordResetPurpose = "users.password_reset"
+
+// RequestPasswordResetCommand represents the intent to reset the password of
+// the user with an email, once confirmed with the token mailed to the user
+// (ResetPasswordCommand).
+type RequestPasswordResetCommand struct {
+	Email string
+}
+
+// RequestPasswordResetHandler handles the RequestPasswordResetCommand.
+type RequestPasswordResetHandler struct {
+	repo        domain.UserRepository
+	txScope     transaction.ScopeWithDomainEvent
+	emailPolicy domain.EmailPolicy
+	signer      *tokens.Signer
+	ttl         time.Duration
+}
+
+// NewRequestPasswordResetHandler creates a handler that issues reset tokens
+// valid for ttl. A nil signer fails every request with
+// tokens.ErrNotConfigured.
+func NewRequestPasswordResetHandler(repo domain.UserRepository, txScope transaction.ScopeWithDomainEvent, emailPolicy domain.EmailPolicy, signer *tokens.Signer, ttl time.Duration) *RequestPasswordResetHandler {
+	return &RequestPasswordResetHandler{
+		repo:        repo,
+		txScope:     txScope,
+		emailPolicy: emailPolicy,
+		signer:      signer,
+		ttl:         ttl,
+	}
+}
+
+// Handle executes the request password reset use case. It succeeds without
+// doing anything for an email no active user has, so that it does not tell
+// who has an account.
+func (h *RequestPasswordResetHandler) Handle(ctx context.Context, cmd RequestPasswordResetCommand) error {
+	if h.signer == nil {
+		return tokens.ErrNotConfigured
+	}
+
+	email, err := h.emailPolicy.NewEmail(cmd.Email)
+	if err != nil {
+		return fmt.Errorf("invalid email: %w", err)
+	}
+
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		user, err := h.repo.FindByEmail(ctx, email)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// The token is bound to the current password, so that it is refused
+		// once the password has changed, by it or otherwise
+		token, err := h.signer.Issue(ctx, tokens.Claims{
+			Purpose: PasswordResetPurpose,
+			Subject: user.ID().String(),
+			Data:    map[string]string{"password": user.Password().Fingerprint()},
+		}, h.ttl)
+		if err != nil {
+			return fmt.Errorf("issuing password reset token: %w", err)
+		}
+
+		if err := user.RequestPasswordReset(ctx, token); err != nil {
+			if errors.Is(err, domain.ErrUserDeleted) {
+				return nil
+			}
+			return fmt.Errorf("requesting password reset: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+)
+
+// ResetPasswordCommand represents the intent to set the password of the user
+// a token issued by RequestPasswordResetHandler was issued for.
+type ResetPasswordCommand struct {
+	Token    string
+	Password string
+}
+
+// ResetPasswordHandler handles the ResetPasswordCommand.
+type ResetPasswordHandler struct {
+	repo    domain.UserRepository
+	txScope transaction.ScopeWithDomainEvent
+	signer  *tokens.Signer
+}
+
+func NewResetPasswordHandler(repo domain.UserRepository, txScope transaction.ScopeWithDomainEvent, signer *tokens.Signer) *ResetPasswordHandler {
+	return &ResetPasswordHandler{
+		repo:    repo,
+		txScope: txScope,
+		signer:  signer,
+	}
+}
+
+// Handle executes the reset password use case in the tenant the token was
+// issued in: the link is followed without credentials. A token that is not
+// valid, or whose user's password has changed since it was issued (it was
+// already used, or superseded), fails with an error wrapping
+// tokens.ErrInvalid, tokens.ErrExpired or tokens.ErrWrongPurpose.
+func (h *ResetPasswordHandler) Handle(ctx context.Context, cmd ResetPasswordCommand) error {
+	claims, err := h.signer.Verify(ctx, cmd.Token, PasswordResetPurpose)
+	if err != nil {
+		return fmt.Errorf("verifying password reset token: %w", err)
+	}
+	ctx = tokens.WithTenant(ctx, claims)
+
+	userID, err := domain.ParseUserID(claims.Subject)
+	if err != nil {
+		return fmt.Errorf("password reset token: %w", tokens.ErrInvalid)
+	}
+
+	// Hashed outside the unit of work, which may run more than once
+	password, err := domain.HashPassword(cmd.Password)
+	if err != nil {
+		return fmt.Errorf("invalid password: %w", err)
+	}
+
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		user, err := transaction.Load(ctx, "user", userID, findUser(h.repo), h.repo.Save)
+		if err != nil {
+			return err
+		}
+		if user.Password().Fingerprint() != claims.Data["password"] {
+			return fmt.Errorf("password reset token already used: %w", tokens.ErrInvalid)
+		}
+
+		if err := user.ResetPassword(ctx, password); err != nil {
+			return fmt.Errorf("resetting password: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+)
+
+// RestoreUserCommand represents the intent to restore a deleted user.
+type RestoreUserCommand struct {
+	UserID string
+	// AnyTime restores the user however long ago it was deleted, for
+	// admins; otherwise only within the handler's grace period.
+	AnyTime bool
+}
+
+// RestoreUserHandler handles the RestoreUserCommand.
+type RestoreUserHandler struct {
+	repo        domain.UserRepository
+	txScope     transaction.ScopeWithDomainEvent
+	gracePeriod time.Duration
+}
+
+// NewRestoreUserHandler creates a handler that restores users deleted at
+// most gracePeriod ago; zero means any time.
+func NewRestoreUserHandler(repo domain.UserRepository, txScope transaction.ScopeWithDomainEvent, gracePeriod time.Duration) *RestoreUserHandler {
+	return &RestoreUserHandler{
+		repo:        repo,
+		txScope:     txScope,
+		gracePeriod: gracePeriod,
+	}
+}
+
+// Handle executes the restore user use case.
+func (h *RestoreUserHandler) Handle(ctx context.Context, cmd RestoreUserCommand) error {
+	userID, err := domain.ParseUserID(cmd.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		user, err := transaction.Load(ctx, "user", userID, findUser(h.repo), h.repo.Save)
+		if err != nil {
+			return err
+		}
+
+		gracePeriod := h.gracePeriod
+		if cmd.AnyTime {
+			gracePeriod = 0
+		}
+		if err := user.Restore(ctx, gracePeriod); err != nil {
+			return fmt.Errorf("restoring user: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+)
+
+// UpdatePreferencesCommand represents the intent to change a user's preferences.
+type UpdatePreferencesCommand struct {
+	UserID            string
+	Locale            string
+	MutedChannels     []string
+	ExpectedUpdatedAt time.Time // see User.CheckUnchanged; zero skips the check
+}
+
+// UpdatePreferencesHandler handles the UpdatePreferencesCommand.
+type UpdatePreferencesHandler struct {
+	repo    domain.UserRepository
+	txScope transaction.ScopeWithDomainEvent
+}
+
+func NewUpdatePreferencesHandler(repo domain.UserRepository, txScope transaction.ScopeWithDomainEvent) *UpdatePreferencesHandler {
+	return &UpdatePreferencesHandler{
+		repo:    repo,
+		txScope: txScope,
+	}
+}
+
+// Handle executes the update preferences use case.
+func (h *UpdatePreferencesHandler) Handle(ctx context.Context, cmd UpdatePreferencesCommand) error {
+	userID, err := domain.ParseUserID(cmd.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	prefs, err := domain.NewPreferences(cmd.Locale, cmd.MutedChannels)
+	if err != nil {
+		return fmt.Errorf("invalid preferences: %w", err)
+	}
+
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		user, err := transaction.Load(ctx, "user", userID, findUser(h.repo), h.repo.Save)
+		if err != nil {
+			return err
+		}
+		if err := user.CheckUnchanged(cmd.ExpectedUpdatedAt); err != nil {
+			return err
+		}
+
+		if err := user.UpdatePreferences(ctx, prefs); err != nil {
+			return fmt.Errorf("updating preferences: %w", err)
+		}
+
+		return nil
+	})
+}
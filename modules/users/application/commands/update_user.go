@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
 	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
@@ -10,9 +11,10 @@ import (
 
 // UpdateUserCommand represents the intent to update a user's profile.
 type UpdateUserCommand struct {
-	UserID    string
-	FirstName string
-	LastName  string
+	UserID            string
+	FirstName         string
+	LastName          string
+	ExpectedUpdatedAt time.Time // see User.CheckUnchanged; zero skips the check
 }
 
 // UpdateUserHandler handles the UpdateUserCommand.
@@ -40,21 +42,19 @@ func (h *UpdateUserHandler) Handle(ctx context.Context, cmd UpdateUserCommand) e
 		return fmt.Errorf("invalid name: %w", err)
 	}
 
-	fn := func(ctx context.Context) error {
-		user, err := h.repo.FindByID(ctx, userID)
+	return transaction.ExecuteUnitOfWork(ctx, h.txScope, func(ctx context.Context) error {
+		user, err := transaction.Load(ctx, "user", userID, findUser(h.repo), h.repo.Save)
 		if err != nil {
-			return fmt.Errorf("finding user: %w", err)
+			return err
+		}
+		if err := user.CheckUnchanged(cmd.ExpectedUpdatedAt); err != nil {
+			return err
 		}
 
 		if err := user.UpdateProfile(ctx, name); err != nil {
 			return fmt.Errorf("updating profile: %w", err)
 		}
 
-		if err := h.repo.Save(ctx, user); err != nil {
-			return fmt.Errorf("saving user: %w", err)
-		}
-
 		return nil
-	}
-	return h.txScope.ExecuteWithPublish(ctx, fn)
+	})
 }
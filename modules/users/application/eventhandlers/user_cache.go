@@ -0,0 +1,37 @@
+package eventhandlers
+
+import (
+	"github.com/rai/clean-modularmonolith-go/modules/shared/cache"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/users/application/queries"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+)
+
+// UserCacheEventTypes lists the user events that change a cached UserDTO.
+var UserCacheEventTypes = []events.EventType{
+	userevents.UserUpdatedEventType,
+	userevents.UserPreferencesUpdatedEventType,
+	userevents.UserDeletedEventType,
+	userevents.UserRestoredEventType,
+	userevents.PasswordChangedEventType,
+}
+
+// NewUserCacheInvalidator returns the handler that evicts the UserDTO of the
+// user an event of eventType changed.
+func NewUserCacheInvalidator(eventType events.EventType, store cache.Store) *cache.Invalidator {
+	return cache.NewInvalidator("users", eventType, store, func(event events.Event) []string {
+		switch e := event.(type) {
+		case userevents.UserUpdatedEvent:
+			return []string{queries.UserCacheKey(events.TenantID(e), e.UserID)}
+		case userevents.UserPreferencesUpdatedEvent:
+			return []string{queries.UserCacheKey(events.TenantID(e), e.UserID)}
+		case userevents.UserDeletedEvent:
+			return []string{queries.UserCacheKey(events.TenantID(e), e.UserID)}
+		case userevents.UserRestoredEvent:
+			return []string{queries.UserCacheKey(events.TenantID(e), e.UserID)}
+		case userevents.PasswordChangedEvent:
+			return []string{queries.UserCacheKey(events.TenantID(e), e.UserID)}
+		}
+		return nil
+	})
+}
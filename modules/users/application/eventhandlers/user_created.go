@@ -5,7 +5,7 @@ import (
 	"fmt"
 
 	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
-	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
 )
 
 // UserCreatedHandler handles UserCreated events by indexing the user in Elasticsearch.
@@ -20,10 +20,10 @@ func NewUserCreatedHandler(indexer *UserIndexer) *UserCreatedHandler {
 
 func (h *UserCreatedHandler) HandlerName() string         { return "UserCreatedHandler" }
 func (h *UserCreatedHandler) Subdomain() string           { return "users" }
-func (h *UserCreatedHandler) EventType() events.EventType { return domain.UserCreatedEventType }
+func (h *UserCreatedHandler) EventType() events.EventType { return userevents.UserCreatedEventType }
 
 func (h *UserCreatedHandler) Handle(ctx context.Context, event events.Event) error {
-	e, ok := event.(domain.UserCreatedEvent)
+	e, ok := event.(userevents.UserCreatedEvent)
 	if !ok {
 		return fmt.Errorf("unexpected event type: %T", event)
 	}
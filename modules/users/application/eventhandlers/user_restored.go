@@ -0,0 +1,32 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+)
+
+// UserRestoredHandler handles UserRestored events by indexing the user in
+// Elasticsearch again.
+// Performs external side effects; must not run within a database transaction.
+type UserRestoredHandler struct {
+	indexer *UserIndexer
+}
+
+func NewUserRestoredHandler(indexer *UserIndexer) *UserRestoredHandler {
+	return &UserRestoredHandler{indexer: indexer}
+}
+
+func (h *UserRestoredHandler) HandlerName() string         { return "UserRestoredHandler" }
+func (h *UserRestoredHandler) Subdomain() string           { return "users" }
+func (h *UserRestoredHandler) EventType() events.EventType { return userevents.UserRestoredEventType }
+
+func (h *UserRestoredHandler) Handle(ctx context.Context, event events.Event) error {
+	e, ok := event.(userevents.UserRestoredEvent)
+	if !ok {
+		return fmt.Errorf("unexpected event type: %T", event)
+	}
+	return h.indexer.IndexUser(ctx, e.UserID, e.Email, e.FirstName, e.LastName)
+}
@@ -5,7 +5,7 @@ import (
 	"fmt"
 
 	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
-	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
 )
 
 // UserUpdatedHandler handles UserUpdated events by re-indexing the user in Elasticsearch.
@@ -20,10 +20,10 @@ func NewUserUpdatedHandler(indexer *UserIndexer) *UserUpdatedHandler {
 
 func (h *UserUpdatedHandler) HandlerName() string         { return "UserUpdatedHandler" }
 func (h *UserUpdatedHandler) Subdomain() string           { return "users" }
-func (h *UserUpdatedHandler) EventType() events.EventType { return domain.UserUpdatedEventType }
+func (h *UserUpdatedHandler) EventType() events.EventType { return userevents.UserUpdatedEventType }
 
 func (h *UserUpdatedHandler) Handle(ctx context.Context, event events.Event) error {
-	e, ok := event.(domain.UserUpdatedEvent)
+	e, ok := event.(userevents.UserUpdatedEvent)
 	if !ok {
 		return fmt.Errorf("unexpected event type: %T", event)
 	}
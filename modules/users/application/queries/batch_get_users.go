@@ -0,0 +1,72 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+)
+
+// MaxBatchGetUserIDs caps the number of distinct IDs in a BatchGetUsersQuery.
+const MaxBatchGetUserIDs = 100
+
+// BatchGetUsersQuery gets several users by ID at once.
+type BatchGetUsersQuery struct {
+	UserIDs []string
+}
+
+// BatchGetUsersDTO holds the users found, by ID, and the requested IDs
+// without a user, in request order.
+type BatchGetUsersDTO struct {
+	Users   map[string]*UserDTO `json:"users"`
+	Missing []string            `json:"missing"`
+}
+
+// BatchGetUsersHandler handles BatchGetUsersQuery.
+type BatchGetUsersHandler struct {
+	repo domain.UserReader
+}
+
+func NewBatchGetUsersHandler(repo domain.UserReader) *BatchGetUsersHandler {
+	return &BatchGetUsersHandler{repo: repo}
+}
+
+// Handle reads the users in one repository call. Duplicate IDs are read
+// once; an invalid ID fails the whole query.
+func (h *BatchGetUsersHandler) Handle(ctx context.Context, query BatchGetUsersQuery) (*BatchGetUsersDTO, error) {
+	var ids []domain.UserID
+	seen := make(map[domain.UserID]bool)
+	for _, raw := range query.UserIDs {
+		id, err := domain.ParseUserID(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", err, raw)
+		}
+		if seen[id] {
+			continue
+		}
+		if len(ids) == MaxBatchGetUserIDs {
+			return nil, domain.ErrBatchLimitExceeded
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+
+	result := &BatchGetUsersDTO{Users: make(map[string]*UserDTO, len(ids)), Missing: []string{}}
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	users, err := h.repo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, user := range users {
+		result.Users[user.ID().String()] = toUserDTO(user)
+	}
+	for _, id := range ids {
+		if _, ok := result.Users[id.String()]; !ok {
+			result.Missing = append(result.Missing, id.String())
+		}
+	}
+	return result, nil
+}
@@ -7,20 +7,23 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
 	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
 )
 
 // UserDTO is a read model for user data.
 // DTOs are optimized for reading and decoupled from domain entities.
 type UserDTO struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	FullName  string    `json:"full_name"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	FullName      string    `json:"full_name"`
+	Status        string    `json:"status"`
+	Locale        string    `json:"locale"`
+	MutedChannels []string  `json:"muted_channels"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
 }
 
 // GetUserQuery represents a request to get a user by ID.
@@ -28,12 +31,22 @@ type GetUserQuery struct {
 	UserID string
 }
 
+// UserCacheKey is the key of the UserDTO of userID in tenantID in a
+// cache.Store.
+func UserCacheKey(tenantID, userID string) string { return "users:" + tenantID + ":user:" + userID }
+
+// GetUserCacheKey is the cache.Cached key of query: the UserCacheKey of the
+// user in the tenant of ctx.
+func GetUserCacheKey(ctx context.Context, query GetUserQuery) string {
+	return UserCacheKey(requestcontext.TenantID(ctx), query.UserID)
+}
+
 // GetUserHandler handles GetUserQuery.
 type GetUserHandler struct {
-	repo domain.UserRepository
+	repo domain.UserReader
 }
 
-func NewGetUserHandler(repo domain.UserRepository) *GetUserHandler {
+func NewGetUserHandler(repo domain.UserReader) *GetUserHandler {
 	return &GetUserHandler{repo: repo}
 }
 
@@ -44,7 +57,7 @@ func (h *GetUserHandler) Handle(ctx context.Context, query GetUserQuery) (*UserD
 		return nil, fmt.Errorf("invalid user ID: %w", err)
 	}
 
-	user, err := h.repo.FindByID(ctx, userID)
+	user, err := h.repo.FindByID(ctx, userID, domain.IncludeDeleted)
 	if err != nil {
 		return nil, err
 	}
@@ -53,13 +66,15 @@ func (h *GetUserHandler) Handle(ctx context.Context, query GetUserQuery) (*UserD
 
 func toUserDTO(user *domain.User) *UserDTO {
 	return &UserDTO{
-		ID:        user.ID().String(),
-		Email:     user.Email().String(),
-		FirstName: user.Name().FirstName(),
-		LastName:  user.Name().LastName(),
-		FullName:  user.Name().FullName(),
-		Status:    user.Status().String(),
-		CreatedAt: user.CreatedAt(),
-		UpdatedAt: user.UpdatedAt(),
+		ID:            user.ID().String(),
+		Email:         user.Email().String(),
+		FirstName:     user.Name().FirstName(),
+		LastName:      user.Name().LastName(),
+		FullName:      user.Name().FullName(),
+		Status:        user.Status().String(),
+		Locale:        user.Preferences().Locale(),
+		MutedChannels: user.Preferences().MutedChannels(),
+		CreatedAt:     user.CreatedAt(),
+		UpdatedAt:     user.UpdatedAt(),
 	}
 }
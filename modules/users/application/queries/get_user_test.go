@@ -0,0 +1,73 @@
+package queries_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/cache"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/users/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/users/domain/mocks"
+	"go.uber.org/mock/gomock"
+)
+
+// mapStore is a cache.Store without expiry.
+type mapStore map[string][]byte
+
+func (s mapStore) Get(ctx context.Context, key string) ([]byte, bool) {
+	v, ok := s[key]
+	return v, ok
+}
+
+func (s mapStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	s[key] = value
+}
+
+func (s mapStore) Delete(ctx context.Context, keys ...string) {
+	for _, k := range keys {
+		delete(s, k)
+	}
+}
+
+// TestGetUserHandler_Cached_PerTenant reads a user cached in its tenant from
+// another tenant: the cache must not skip the repository's tenant filter.
+func TestGetUserHandler_Cached_PerTenant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	id, err := domain.ParseUserID(uuid.NewString())
+	if err != nil {
+		t.Fatal(err)
+	}
+	email, err := domain.NewEmail("alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := domain.NewName("Alice", "Liddell")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := domain.Reconstitute(id, email, name, domain.StatusActive, domain.DefaultPreferences(), domain.PasswordHash{}, time.Now(), time.Now())
+
+	repo := domainmocks.NewMockUserReader(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), id, domain.IncludeDeleted).DoAndReturn(func(ctx context.Context, _ domain.UserID, _ domain.DeletedFilter) (*domain.User, error) {
+		if requestcontext.TenantID(ctx) != "acme" {
+			return nil, domain.ErrUserNotFound
+		}
+		return user, nil
+	}).Times(2)
+
+	handler := cache.Cached(mapStore{}, time.Minute, queries.GetUserCacheKey, queries.NewGetUserHandler(repo))
+	acme := requestcontext.With(t.Context(), requestcontext.Values{TenantID: "acme"})
+	other := requestcontext.With(t.Context(), requestcontext.Values{TenantID: "other"})
+
+	if _, err := handler.Handle(acme, queries.GetUserQuery{UserID: id.String()}); err != nil {
+		t.Fatalf("Handle in acme: %v", err)
+	}
+	if got, err := handler.Handle(other, queries.GetUserQuery{UserID: id.String()}); !errors.Is(err, domain.ErrUserNotFound) {
+		t.Errorf("Handle in another tenant = %+v, %v; want ErrUserNotFound", got, err)
+	}
+}
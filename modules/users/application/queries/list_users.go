@@ -2,7 +2,9 @@ package queries
 
 import (
 	"context"
+	"iter"
 
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
 	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
 )
@@ -19,15 +21,19 @@ type UserListDTO struct {
 type ListUsersQuery struct {
 	Offset int
 	Limit  int
+	// Deleted selects soft-deleted users; they are left out by default.
+	Deleted domain.DeletedFilter
+	// Sort orders the users; newest first by default.
+	Sort domain.UserSort
 }
 
 // ListUsersHandler handles ListUsersQuery.
 type ListUsersHandler struct {
-	repo    domain.UserRepository
+	repo    domain.UserReader
 	txScope transaction.Scope
 }
 
-func NewListUsersHandler(repo domain.UserRepository, txScope transaction.Scope) *ListUsersHandler {
+func NewListUsersHandler(repo domain.UserReader, txScope transaction.Scope) *ListUsersHandler {
 	return &ListUsersHandler{repo: repo, txScope: txScope}
 }
 
@@ -35,18 +41,10 @@ func NewListUsersHandler(repo domain.UserRepository, txScope transaction.Scope)
 // Uses a read-only transaction to ensure COUNT and SELECT see
 // the same point-in-time snapshot.
 func (h *ListUsersHandler) Handle(ctx context.Context, query ListUsersQuery) (*UserListDTO, error) {
-	// Apply defaults
-	offset := query.Offset
-	limit := query.Limit
-	if limit <= 0 {
-		limit = 20
-	}
-	if limit > 100 {
-		limit = 100
-	}
+	p := page.NewRequest(query.Offset, query.Limit)
 
-	return transaction.ExecuteWithResult(ctx, h.txScope, func(ctx context.Context) (*UserListDTO, error) {
-		users, total, err := h.repo.FindAll(ctx, offset, limit)
+	return transaction.ExecuteReadOnly(ctx, h.txScope, func(ctx context.Context) (*UserListDTO, error) {
+		users, total, err := h.repo.FindAll(ctx, query.Deleted, query.Sort, p.Offset, p.Limit)
 		if err != nil {
 			return nil, err
 		}
@@ -59,8 +57,43 @@ func (h *ListUsersHandler) Handle(ctx context.Context, query ListUsersQuery) (*U
 		return &UserListDTO{
 			Users:      dtos,
 			TotalCount: total,
-			Offset:     offset,
-			Limit:      limit,
+			Offset:     p.Offset,
+			Limit:      p.Limit,
 		}, nil
 	})
 }
+
+// exportPageSize is the number of users Export reads at a time.
+const exportPageSize = 100
+
+// Export returns every user from query.Offset on, or at most query.Limit
+// users if it is positive, reading them a page at a time as the caller
+// consumes them, for streamed exports. All pages are read in one read-only
+// transaction, so the export is a consistent snapshot.
+func (h *ListUsersHandler) Export(ctx context.Context, query ListUsersQuery) iter.Seq2[*UserDTO, error] {
+	return func(yield func(*UserDTO, error) bool) {
+		remaining := query.Limit
+		err := h.txScope.Execute(ctx, func(ctx context.Context) error {
+			for offset := max(query.Offset, 0); ; offset += exportPageSize {
+				users, _, err := h.repo.FindAll(ctx, query.Deleted, query.Sort, offset, exportPageSize)
+				if err != nil {
+					return err
+				}
+				for _, user := range users {
+					if !yield(toUserDTO(user), nil) {
+						return nil
+					}
+					if remaining--; remaining == 0 {
+						return nil
+					}
+				}
+				if len(users) < exportPageSize {
+					return nil
+				}
+			}
+		})
+		if err != nil {
+			yield(nil, err)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/rai/clean-modularmonolith-go/internal/platform/elasticsearch"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
 )
 
 const usersIndex = "users"
@@ -43,16 +44,9 @@ func NewSearchUsersHandler(esClient elasticsearch.Client) *SearchUsersHandler {
 
 // Handle executes the search users query against Elasticsearch.
 func (h *SearchUsersHandler) Handle(ctx context.Context, query SearchUsersQuery) (*UserSearchResponseDTO, error) {
-	offset := query.Offset
-	limit := query.Limit
-	if limit <= 0 {
-		limit = 20
-	}
-	if limit > 100 {
-		limit = 100
-	}
+	p := page.NewRequest(query.Offset, query.Limit)
 
-	resp, err := h.esClient.Search(ctx, usersIndex, query.Query, offset, limit)
+	resp, err := h.esClient.Search(ctx, usersIndex, query.Query, p.Offset, p.Limit)
 	if err != nil {
 		return nil, err
 	}
@@ -71,8 +65,8 @@ func (h *SearchUsersHandler) Handle(ctx context.Context, query SearchUsersQuery)
 	return &UserSearchResponseDTO{
 		Users:  users,
 		Total:  resp.Total,
-		Offset: offset,
-		Limit:  limit,
+		Offset: p.Offset,
+		Limit:  p.Limit,
 	}, nil
 }
 
@@ -0,0 +1,52 @@
+package queries
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/contracts"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+)
+
+// UserSummaryQuery asks for the contracts.UserSummary of a user.
+type UserSummaryQuery struct {
+	UserID string
+}
+
+// UserSummaryHandler handles UserSummaryQuery for other modules, through
+// contracts.UserReader. It reads through the repository rather than the
+// cache, so when called inside another module's transaction it observes
+// that transaction's snapshot.
+type UserSummaryHandler struct {
+	repo domain.UserReader
+}
+
+func NewUserSummaryHandler(repo domain.UserReader) *UserSummaryHandler {
+	return &UserSummaryHandler{repo: repo}
+}
+
+// Handle returns deleted users too; ok is false for malformed IDs and
+// unknown users.
+func (h *UserSummaryHandler) Handle(ctx context.Context, query UserSummaryQuery) (summary contracts.UserSummary, ok bool, err error) {
+	userID, err := domain.ParseUserID(query.UserID)
+	if err != nil {
+		return contracts.UserSummary{}, false, nil
+	}
+
+	user, err := h.repo.FindByID(ctx, userID, domain.IncludeDeleted)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return contracts.UserSummary{}, false, nil
+	}
+	if err != nil {
+		return contracts.UserSummary{}, false, err
+	}
+	return contracts.UserSummary{
+		ID:        user.ID().String(),
+		Email:     user.Email().String(),
+		FirstName: user.Name().FirstName(),
+		LastName:  user.Name().LastName(),
+		Status:    user.Status().String(),
+		Locale:    user.Preferences().Locale(),
+		CreatedAt: user.CreatedAt(),
+	}, true, nil
+}
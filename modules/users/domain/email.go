@@ -0,0 +1,224 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+// EmailStrictness controls how permissive email validation is.
+type EmailStrictness int
+
+const (
+	// EmailStandard accepts RFC 5322 dot-atom local parts and
+	// internationalized (IDN) domains. This is the default.
+	EmailStandard EmailStrictness = iota
+	// EmailStrict accepts only plain ASCII addresses matching a conservative
+	// pattern. IDN domains and uncommon local-part characters are rejected.
+	EmailStrict
+	// EmailRelaxed additionally accepts UTF-8 local parts (RFC 6531).
+	EmailRelaxed
+)
+
+// ParseEmailStrictness converts a configuration string ("strict", "standard",
+// "relaxed") into an EmailStrictness. An empty string yields EmailStandard.
+func ParseEmailStrictness(s string) (EmailStrictness, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "standard":
+		return EmailStandard, nil
+	case "strict":
+		return EmailStrict, nil
+	case "relaxed":
+		return EmailRelaxed, nil
+	default:
+		return 0, fmt.Errorf("unknown email strictness %q: must be strict, standard, or relaxed", s)
+	}
+}
+
+const (
+	maxEmailLength     = 254 // RFC 5321 path limit minus angle brackets
+	maxEmailLocalBytes = 64
+)
+
+// strictEmailRegex is the conservative ASCII-only pattern used by EmailStrict.
+var strictEmailRegex = regexp.MustCompile(`^[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}$`)
+
+// atextSpecials are the non-alphanumeric characters permitted in an
+// RFC 5322 dot-atom local part.
+const atextSpecials = "!#$%&'*+-/=?^_`{|}~"
+
+// EmailPolicy configures how email addresses are validated and normalized.
+// The zero value is the default policy used by NewEmail.
+type EmailPolicy struct {
+	Strictness EmailStrictness
+
+	// NormalizePlusTags strips "+tag" suffixes from the local part when
+	// computing the canonical form used for uniqueness checks, so that
+	// "john+shop@example.com" and "john@example.com" are the same account.
+	// The address itself is stored as entered.
+	NormalizePlusTags bool
+}
+
+// Email is a value object representing a validated email address.
+// Value objects are immutable and compared by value.
+type Email struct {
+	value     string // lowercased address, domain in Unicode form
+	canonical string // ASCII (punycode) form used for uniqueness checks
+}
+
+// NewEmail creates a validated Email value object using the default policy.
+func NewEmail(value string) (Email, error) {
+	return EmailPolicy{}.NewEmail(value)
+}
+
+// ReconstituteEmail recreates an Email from persistence without re-validating.
+// Used by repositories so that tightening the policy never makes stored
+// addresses unreadable.
+func ReconstituteEmail(value, canonical string) Email {
+	if canonical == "" {
+		canonical = value
+	}
+	return Email{value: value, canonical: canonical}
+}
+
+// NewEmail creates a validated Email value object according to the policy.
+func (p EmailPolicy) NewEmail(value string) (Email, error) {
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" {
+		return Email{}, ErrEmailRequired
+	}
+
+	at := strings.LastIndexByte(value, '@')
+	if at <= 0 || at == len(value)-1 {
+		return Email{}, ErrEmailInvalid
+	}
+	local, host := value[:at], value[at+1:]
+
+	if p.Strictness == EmailStrict && !strictEmailRegex.MatchString(value) {
+		return Email{}, ErrEmailInvalid
+	}
+	if !p.validLocalPart(local) {
+		return Email{}, ErrEmailInvalid
+	}
+
+	asciiHost, unicodeHost, err := normalizeEmailDomain(host)
+	if err != nil {
+		return Email{}, ErrEmailInvalid
+	}
+	if len(local)+1+len(asciiHost) > maxEmailLength {
+		return Email{}, ErrEmailInvalid
+	}
+
+	canonicalLocal := local
+	if p.NormalizePlusTags {
+		if i := strings.IndexByte(local, '+'); i > 0 {
+			canonicalLocal = local[:i]
+		}
+	}
+
+	return Email{
+		value:     local + "@" + unicodeHost,
+		canonical: canonicalLocal + "@" + asciiHost,
+	}, nil
+}
+
+// validLocalPart reports whether local is an acceptable dot-atom for the policy.
+func (p EmailPolicy) validLocalPart(local string) bool {
+	if len(local) > maxEmailLocalBytes || !utf8.ValidString(local) {
+		return false
+	}
+	if strings.HasPrefix(local, ".") || strings.HasSuffix(local, ".") || strings.Contains(local, "..") {
+		return false
+	}
+	for _, r := range local {
+		switch {
+		case r < utf8.RuneSelf && (isASCIIAlnum(r) || r == '.' || strings.ContainsRune(atextSpecials, r)):
+		case r >= utf8.RuneSelf && p.Strictness == EmailRelaxed && !unicode.IsSpace(r) && !unicode.IsControl(r):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeEmailDomain validates host as a (possibly internationalized)
+// domain name and returns both its ASCII (punycode) and Unicode forms.
+// A domain must have at least two labels and an alphabetic top-level domain.
+func normalizeEmailDomain(host string) (ascii, unicodeForm string, err error) {
+	ascii, err = idna.Lookup.ToASCII(host)
+	if err != nil {
+		return "", "", err
+	}
+
+	labels := strings.Split(ascii, ".")
+	if len(labels) < 2 {
+		return "", "", ErrEmailInvalid
+	}
+	if tld := labels[len(labels)-1]; !validTopLevelDomain(tld) {
+		return "", "", ErrEmailInvalid
+	}
+
+	unicodeForm, err = idna.Lookup.ToUnicode(ascii)
+	if err != nil {
+		return "", "", err
+	}
+	return ascii, unicodeForm, nil
+}
+
+func validTopLevelDomain(tld string) bool {
+	if strings.HasPrefix(tld, "xn--") {
+		return len(tld) > len("xn--")
+	}
+	if len(tld) < 2 {
+		return false
+	}
+	for _, r := range tld {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCIIAlnum(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+// String returns the address as entered (lowercased, Unicode domain).
+func (e Email) String() string { return e.value }
+
+// Canonical returns the normalized form used for uniqueness checks:
+// the domain in ASCII (punycode) form and, if the policy enables it,
+// the local part without its plus tag.
+func (e Email) Canonical() string { return e.canonical }
+
+func (e Email) IsZero() bool { return e.value == "" }
+
+func (e Email) Equals(other Email) bool {
+	return e.value == other.value
+}
+
+// MarshalText implements encoding.TextMarshaler: an Email is its address in
+// JSON.
+func (e Email) MarshalText() ([]byte, error) { return []byte(e.value), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler. It validates the
+// address with the default policy (see NewEmail); the empty string is the
+// zero Email. Commands validating with a configured EmailPolicy should take
+// the address as a string instead.
+func (e *Email) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*e = Email{}
+		return nil
+	}
+	parsed, err := NewEmail(string(text))
+	if err != nil {
+		return err
+	}
+	*e = parsed
+	return nil
+}
@@ -6,17 +6,40 @@ import "errors"
 // These errors are part of the domain language.
 var (
 	// User errors
-	ErrUserNotFound = errors.New("user not found")
-	ErrUserDeleted  = errors.New("user has been deleted")
+	ErrUserNotFound   = errors.New("user not found")
+	ErrUserDeleted    = errors.New("user has been deleted")
+	ErrUserModified   = errors.New("user has been modified since it was read")
+	ErrUserNotDeleted = errors.New("user has not been deleted")
+	ErrUserInvariant  = errors.New("user violates an invariant")
+
+	ErrRestorePeriodExpired = errors.New("user was deleted too long ago to be restored")
+
+	// Status errors
+	ErrStatusInvalid   = errors.New("invalid user status")
+	ErrUserStatusValid = errors.New("user status is valid and only changes through the user lifecycle")
 
 	// Email errors
 	ErrEmailRequired = errors.New("email is required")
 	ErrEmailInvalid  = errors.New("email format is invalid")
 	ErrEmailExists   = errors.New("email already exists")
 
+	// Password errors
+	ErrPasswordTooShort = errors.New("password must be at least 12 characters")
+	ErrPasswordTooLong  = errors.New("password must be at most 128 characters")
+
 	// Name errors
 	ErrFirstNameRequired = errors.New("first name is required")
 	ErrFirstNameLength   = errors.New("first name must be 2-50 characters")
 	ErrLastNameRequired  = errors.New("last name is required")
 	ErrLastNameLength    = errors.New("last name must be 2-50 characters")
+
+	// Preference errors
+	ErrLocaleInvalid              = errors.New("locale must be a language code with an optional region, e.g. en or en-US")
+	ErrNotificationChannelInvalid = errors.New("notification channel must be one of email, sms, push")
+
+	// Query errors
+	ErrDeletedFilterInvalid = errors.New("deleted must be one of exclude, include, only")
+
+	// Batch errors
+	ErrBatchLimitExceeded = errors.New("too many user IDs in batch request")
 )
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"context"
+
 	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
 	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
 )
@@ -8,27 +10,35 @@ import (
 // Domain events for the users bounded context.
 // Events represent facts about what happened in the domain.
 //
-// Internal events (UserCreated, UserUpdated) stay within the module.
-// Cross-module events (UserDeleted) are defined in domain/events sub-package.
+// All user events are currently cross-module and defined in the domain/events
+// sub-package: other modules keep read models (e.g. order summaries) in sync
+// with user emails.
 
 const (
-	UserCreatedEventType events.EventType = "users.UserCreated"
-	UserUpdatedEventType events.EventType = "users.UserUpdated"
-	UserDeletedEventType                  = userevents.UserDeletedEventType
+	UserCreatedEventType  = userevents.UserCreatedEventType
+	UserUpdatedEventType  = userevents.UserUpdatedEventType
+	UserDeletedEventType  = userevents.UserDeletedEventType
+	UserRestoredEventType = userevents.UserRestoredEventType
+
+	UserPreferencesUpdatedEventType = userevents.UserPreferencesUpdatedEventType
+	EmailChangeRequestedEventType   = userevents.EmailChangeRequestedEventType
+	PasswordResetRequestedEventType = userevents.PasswordResetRequestedEventType
+	PasswordChangedEventType        = userevents.PasswordChangedEventType
 )
 
-// UserCreatedEvent is published when a new user is created.
-type UserCreatedEvent struct {
-	events.BaseEvent
-	UserID    string `json:"user_id"`
-	Email     string `json:"email"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
+func newUserCreatedEvent(ctx context.Context, user *User) userevents.UserCreatedEvent {
+	return userevents.UserCreatedEvent{
+		BaseEvent: events.NewBaseEventWithContext(ctx, UserCreatedEventType),
+		UserID:    user.ID().String(),
+		Email:     user.Email().String(),
+		FirstName: user.Name().FirstName(),
+		LastName:  user.Name().LastName(),
+	}
 }
 
-func newUserCreatedEvent(user *User) UserCreatedEvent {
-	return UserCreatedEvent{
-		BaseEvent: events.NewBaseEvent(UserCreatedEventType),
+func newUserUpdatedEvent(ctx context.Context, user *User) userevents.UserUpdatedEvent {
+	return userevents.UserUpdatedEvent{
+		BaseEvent: events.NewBaseEventWithContext(ctx, UserUpdatedEventType),
 		UserID:    user.ID().String(),
 		Email:     user.Email().String(),
 		FirstName: user.Name().FirstName(),
@@ -36,18 +46,16 @@ func newUserCreatedEvent(user *User) UserCreatedEvent {
 	}
 }
 
-// UserUpdatedEvent is published when a user is updated.
-type UserUpdatedEvent struct {
-	events.BaseEvent
-	UserID    string `json:"user_id"`
-	Email     string `json:"email"`
-	FirstName string `json:"first_name"`
-	LastName  string `json:"last_name"`
+func newUserDeletedEvent(ctx context.Context, userID UserID) userevents.UserDeletedEvent {
+	return userevents.UserDeletedEvent{
+		BaseEvent: events.NewBaseEventWithContext(ctx, UserDeletedEventType),
+		UserID:    userID.String(),
+	}
 }
 
-func newUserUpdatedEvent(user *User) UserUpdatedEvent {
-	return UserUpdatedEvent{
-		BaseEvent: events.NewBaseEvent(UserUpdatedEventType),
+func newUserRestoredEvent(ctx context.Context, user *User) userevents.UserRestoredEvent {
+	return userevents.UserRestoredEvent{
+		BaseEvent: events.NewBaseEventWithContext(ctx, UserRestoredEventType),
 		UserID:    user.ID().String(),
 		Email:     user.Email().String(),
 		FirstName: user.Name().FirstName(),
@@ -55,9 +63,35 @@ func newUserUpdatedEvent(user *User) UserUpdatedEvent {
 	}
 }
 
-func newUserDeletedEvent(userID UserID) userevents.UserDeletedEvent {
-	return userevents.UserDeletedEvent{
-		BaseEvent: events.NewBaseEvent(UserDeletedEventType),
-		UserID:    userID.String(),
+func newUserPreferencesUpdatedEvent(ctx context.Context, user *User) userevents.UserPreferencesUpdatedEvent {
+	return userevents.UserPreferencesUpdatedEvent{
+		BaseEvent:     events.NewBaseEventWithContext(ctx, UserPreferencesUpdatedEventType),
+		UserID:        user.ID().String(),
+		Locale:        user.Preferences().Locale(),
+		MutedChannels: user.Preferences().MutedChannels(),
+	}
+}
+
+func newEmailChangeRequestedEvent(ctx context.Context, user *User, email Email, token string) userevents.EmailChangeRequestedEvent {
+	return userevents.EmailChangeRequestedEvent{
+		BaseEvent: events.NewBaseEventWithContext(ctx, EmailChangeRequestedEventType),
+		UserID:    user.ID().String(),
+		NewEmail:  email.String(),
+		Token:     token,
+	}
+}
+
+func newPasswordResetRequestedEvent(ctx context.Context, user *User, token string) userevents.PasswordResetRequestedEvent {
+	return userevents.PasswordResetRequestedEvent{
+		BaseEvent: events.NewBaseEventWithContext(ctx, PasswordResetRequestedEventType),
+		UserID:    user.ID().String(),
+		Token:     token,
+	}
+}
+
+func newPasswordChangedEvent(ctx context.Context, user *User) userevents.PasswordChangedEvent {
+	return userevents.PasswordChangedEvent{
+		BaseEvent: events.NewBaseEventWithContext(ctx, PasswordChangedEventType),
+		UserID:    user.ID().String(),
 	}
 }
@@ -0,0 +1,16 @@
+package events
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const EmailChangeRequestedEventType events.EventType = "users.EmailChangeRequested"
+
+// EmailChangeRequestedEvent is published when a user asks to change their
+// email. Token confirms the change; the notifications module mails it to
+// the user. The audit log redacts it.
+// This is a public domain event — it may be imported by event handlers in other modules.
+type EmailChangeRequestedEvent struct {
+	events.BaseEvent
+	UserID   string `json:"user_id"`
+	NewEmail string `json:"new_email"`
+	Token    string `json:"token"`
+}
@@ -0,0 +1,13 @@
+package events
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const PasswordChangedEventType events.EventType = "users.PasswordChanged"
+
+// PasswordChangedEvent is published when a user's password is set or reset.
+// It carries no part of the password.
+// This is a public domain event — it may be imported by event handlers in other modules.
+type PasswordChangedEvent struct {
+	events.BaseEvent
+	UserID string `json:"user_id"`
+}
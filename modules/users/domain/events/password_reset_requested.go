@@ -0,0 +1,15 @@
+package events
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const PasswordResetRequestedEventType events.EventType = "users.PasswordResetRequested"
+
+// PasswordResetRequestedEvent is published when a user asks to reset their
+// password. Token confirms the reset; the notifications module mails it to
+// the user. The audit log redacts it.
+// This is a public domain event — it may be imported by event handlers in other modules.
+type PasswordResetRequestedEvent struct {
+	events.BaseEvent
+	UserID string `json:"user_id"`
+	Token  string `json:"token"`
+}
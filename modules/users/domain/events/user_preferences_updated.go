@@ -0,0 +1,14 @@
+package events
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const UserPreferencesUpdatedEventType events.EventType = "users.UserPreferencesUpdated"
+
+// UserPreferencesUpdatedEvent is published when a user changes their preferences.
+// This is a public domain event — it may be imported by event handlers in other modules.
+type UserPreferencesUpdatedEvent struct {
+	events.BaseEvent
+	UserID        string   `json:"user_id"`
+	Locale        string   `json:"locale"`
+	MutedChannels []string `json:"muted_channels"` // notification channels the user opted out of
+}
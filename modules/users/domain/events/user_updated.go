@@ -0,0 +1,15 @@
+package events
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+
+const UserUpdatedEventType events.EventType = "users.UserUpdated"
+
+// UserUpdatedEvent is published when a user's profile or email changes.
+// This is a public domain event — it may be imported by event handlers in other modules.
+type UserUpdatedEvent struct {
+	events.BaseEvent
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
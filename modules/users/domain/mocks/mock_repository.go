@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: domain/repository.go
+// Source: repository.go
 //
 // Generated by this command:
 //
-//	mockgen -source=domain/repository.go -destination=domain/mocks/mock_repository.go -package=mocks
+//	mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks
 //
 
 // Package mocks is a generated GoMock package.
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/rai/clean-modularmonolith-go/modules/users/domain"
 	gomock "go.uber.org/mock/gomock"
@@ -57,9 +58,9 @@ func (mr *MockUserRepositoryMockRecorder) Exists(ctx, email any) *gomock.Call {
 }
 
 // FindAll mocks base method.
-func (m *MockUserRepository) FindAll(ctx context.Context, offset, limit int) ([]*domain.User, int, error) {
+func (m *MockUserRepository) FindAll(ctx context.Context, deleted domain.DeletedFilter, sort domain.UserSort, offset, limit int) ([]*domain.User, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx, offset, limit)
+	ret := m.ctrl.Call(m, "FindAll", ctx, deleted, sort, offset, limit)
 	ret0, _ := ret[0].([]*domain.User)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
@@ -67,9 +68,9 @@ func (m *MockUserRepository) FindAll(ctx context.Context, offset, limit int) ([]
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockUserRepositoryMockRecorder) FindAll(ctx, offset, limit any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) FindAll(ctx, deleted, sort, offset, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockUserRepository)(nil).FindAll), ctx, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockUserRepository)(nil).FindAll), ctx, deleted, sort, offset, limit)
 }
 
 // FindByEmail mocks base method.
@@ -88,18 +89,62 @@ func (mr *MockUserRepositoryMockRecorder) FindByEmail(ctx, email any) *gomock.Ca
 }
 
 // FindByID mocks base method.
-func (m *MockUserRepository) FindByID(ctx context.Context, id domain.UserID) (*domain.User, error) {
+func (m *MockUserRepository) FindByID(ctx context.Context, id domain.UserID, deleted domain.DeletedFilter) (*domain.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret := m.ctrl.Call(m, "FindByID", ctx, id, deleted)
 	ret0, _ := ret[0].(*domain.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByID indicates an expected call of FindByID.
-func (mr *MockUserRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) FindByID(ctx, id, deleted any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepository)(nil).FindByID), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserRepository)(nil).FindByID), ctx, id, deleted)
+}
+
+// FindByIDs mocks base method.
+func (m *MockUserRepository) FindByIDs(ctx context.Context, ids []domain.UserID) ([]*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockUserRepositoryMockRecorder) FindByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockUserRepository)(nil).FindByIDs), ctx, ids)
+}
+
+// Import mocks base method.
+func (m *MockUserRepository) Import(ctx context.Context, users []*domain.User) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, users)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockUserRepositoryMockRecorder) Import(ctx, users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockUserRepository)(nil).Import), ctx, users)
+}
+
+// RepairStatus mocks base method.
+func (m *MockUserRepository) RepairStatus(ctx context.Context, id domain.UserID, status domain.Status, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairStatus", ctx, id, status, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RepairStatus indicates an expected call of RepairStatus.
+func (mr *MockUserRepositoryMockRecorder) RepairStatus(ctx, id, status, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairStatus", reflect.TypeOf((*MockUserRepository)(nil).RepairStatus), ctx, id, status, at)
 }
 
 // Save mocks base method.
@@ -115,3 +160,170 @@ func (mr *MockUserRepositoryMockRecorder) Save(ctx, user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserRepository)(nil).Save), ctx, user)
 }
+
+// MockUserReader is a mock of UserReader interface.
+type MockUserReader struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserReaderMockRecorder
+	isgomock struct{}
+}
+
+// MockUserReaderMockRecorder is the mock recorder for MockUserReader.
+type MockUserReaderMockRecorder struct {
+	mock *MockUserReader
+}
+
+// NewMockUserReader creates a new mock instance.
+func NewMockUserReader(ctrl *gomock.Controller) *MockUserReader {
+	mock := &MockUserReader{ctrl: ctrl}
+	mock.recorder = &MockUserReaderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserReader) EXPECT() *MockUserReaderMockRecorder {
+	return m.recorder
+}
+
+// Exists mocks base method.
+func (m *MockUserReader) Exists(ctx context.Context, email domain.Email) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, email)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockUserReaderMockRecorder) Exists(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockUserReader)(nil).Exists), ctx, email)
+}
+
+// FindAll mocks base method.
+func (m *MockUserReader) FindAll(ctx context.Context, deleted domain.DeletedFilter, sort domain.UserSort, offset, limit int) ([]*domain.User, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx, deleted, sort, offset, limit)
+	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockUserReaderMockRecorder) FindAll(ctx, deleted, sort, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockUserReader)(nil).FindAll), ctx, deleted, sort, offset, limit)
+}
+
+// FindByEmail mocks base method.
+func (m *MockUserReader) FindByEmail(ctx context.Context, email domain.Email) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByEmail", ctx, email)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByEmail indicates an expected call of FindByEmail.
+func (mr *MockUserReaderMockRecorder) FindByEmail(ctx, email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByEmail", reflect.TypeOf((*MockUserReader)(nil).FindByEmail), ctx, email)
+}
+
+// FindByID mocks base method.
+func (m *MockUserReader) FindByID(ctx context.Context, id domain.UserID, deleted domain.DeletedFilter) (*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id, deleted)
+	ret0, _ := ret[0].(*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockUserReaderMockRecorder) FindByID(ctx, id, deleted any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUserReader)(nil).FindByID), ctx, id, deleted)
+}
+
+// FindByIDs mocks base method.
+func (m *MockUserReader) FindByIDs(ctx context.Context, ids []domain.UserID) ([]*domain.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids)
+	ret0, _ := ret[0].([]*domain.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockUserReaderMockRecorder) FindByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockUserReader)(nil).FindByIDs), ctx, ids)
+}
+
+// MockUserWriter is a mock of UserWriter interface.
+type MockUserWriter struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserWriterMockRecorder
+	isgomock struct{}
+}
+
+// MockUserWriterMockRecorder is the mock recorder for MockUserWriter.
+type MockUserWriterMockRecorder struct {
+	mock *MockUserWriter
+}
+
+// NewMockUserWriter creates a new mock instance.
+func NewMockUserWriter(ctrl *gomock.Controller) *MockUserWriter {
+	mock := &MockUserWriter{ctrl: ctrl}
+	mock.recorder = &MockUserWriterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserWriter) EXPECT() *MockUserWriterMockRecorder {
+	return m.recorder
+}
+
+// Import mocks base method.
+func (m *MockUserWriter) Import(ctx context.Context, users []*domain.User) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, users)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockUserWriterMockRecorder) Import(ctx, users any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockUserWriter)(nil).Import), ctx, users)
+}
+
+// RepairStatus mocks base method.
+func (m *MockUserWriter) RepairStatus(ctx context.Context, id domain.UserID, status domain.Status, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RepairStatus", ctx, id, status, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RepairStatus indicates an expected call of RepairStatus.
+func (mr *MockUserWriterMockRecorder) RepairStatus(ctx, id, status, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RepairStatus", reflect.TypeOf((*MockUserWriter)(nil).RepairStatus), ctx, id, status, at)
+}
+
+// Save mocks base method.
+func (m *MockUserWriter) Save(ctx context.Context, user *domain.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockUserWriterMockRecorder) Save(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserWriter)(nil).Save), ctx, user)
+}
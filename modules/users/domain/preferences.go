@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"regexp"
+	"slices"
+)
+
+// DefaultLocale is used for users who have not chosen a locale.
+const DefaultLocale = "en"
+
+// localePattern accepts a language code with an optional region, e.g. "en", "ja", "en-US".
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+
+// NotificationChannels lists the channels a user can opt out of.
+var NotificationChannels = []string{"email", "sms", "push"}
+
+// Preferences is a value object holding a user's personal settings.
+type Preferences struct {
+	locale        string
+	mutedChannels []string // notification channels the user opted out of, sorted
+}
+
+// NewPreferences creates validated Preferences.
+// Duplicate muted channels are collapsed.
+func NewPreferences(locale string, mutedChannels []string) (Preferences, error) {
+	if !localePattern.MatchString(locale) {
+		return Preferences{}, ErrLocaleInvalid
+	}
+	for _, ch := range mutedChannels {
+		if !slices.Contains(NotificationChannels, ch) {
+			return Preferences{}, ErrNotificationChannelInvalid
+		}
+	}
+	muted := slices.Clone(mutedChannels)
+	slices.Sort(muted)
+	return Preferences{locale: locale, mutedChannels: slices.Compact(muted)}, nil
+}
+
+// DefaultPreferences returns the preferences of a newly created user.
+func DefaultPreferences() Preferences {
+	return Preferences{locale: DefaultLocale}
+}
+
+// PreferencesForLocale returns the default preferences in locale, or
+// DefaultPreferences if locale is not a valid user locale.
+func PreferencesForLocale(locale string) Preferences {
+	if !localePattern.MatchString(locale) {
+		return DefaultPreferences()
+	}
+	return Preferences{locale: locale}
+}
+
+// ReconstitutePreferences rebuilds Preferences from persistence without validation.
+func ReconstitutePreferences(locale string, mutedChannels []string) Preferences {
+	return Preferences{locale: locale, mutedChannels: mutedChannels}
+}
+
+func (p Preferences) Locale() string { return p.locale }
+
+// MutedChannels returns the notification channels the user opted out of.
+func (p Preferences) MutedChannels() []string { return slices.Clone(p.mutedChannels) }
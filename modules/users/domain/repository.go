@@ -4,26 +4,111 @@ package domain
 
 import (
 	"context"
+	"fmt"
+	"time"
 )
 
 // UserRepository defines the persistence interface for users.
 // This is a port - defined in domain, implemented in infrastructure.
-// Following the Interface Segregation Principle, we keep the interface minimal.
+// Following the Interface Segregation Principle, it is split into the
+// UserReader query handlers depend on and the UserWriter commands add, so a
+// read replica or a projection can serve the queries.
 type UserRepository interface {
-	// Save persists a user (create or update).
-	Save(ctx context.Context, user *User) error
+	UserReader
+	UserWriter
+}
 
-	// FindByID retrieves a user by ID.
+// UserReader reads users.
+type UserReader interface {
+	// FindByID retrieves a user by ID, if deleted selects it.
 	// Returns ErrUserNotFound if user doesn't exist.
-	FindByID(ctx context.Context, id UserID) (*User, error)
+	FindByID(ctx context.Context, id UserID, deleted DeletedFilter) (*User, error)
+
+	// FindByIDs retrieves the users with the given IDs in one read, in no
+	// particular order. IDs without a user are left out.
+	FindByIDs(ctx context.Context, ids []UserID) ([]*User, error)
 
 	// FindByEmail retrieves a user by email.
 	// Returns ErrUserNotFound if user doesn't exist.
 	FindByEmail(ctx context.Context, email Email) (*User, error)
 
-	// Exists checks if a user with the given email exists.
+	// Exists checks if a user with the same canonical email exists.
+	// See Email.Canonical for how addresses are normalized.
 	Exists(ctx context.Context, email Email) (bool, error)
 
-	// FindAll retrieves users with pagination.
-	FindAll(ctx context.Context, offset, limit int) ([]*User, int, error)
+	// FindAll retrieves the users deleted selects with pagination, in the
+	// order of sort, and their total count.
+	FindAll(ctx context.Context, deleted DeletedFilter, sort UserSort, offset, limit int) ([]*User, int, error)
+}
+
+// UserWriter writes users.
+type UserWriter interface {
+	// Save persists a user (create or update).
+	Save(ctx context.Context, user *User) error
+
+	// RepairStatus sets the status of a user whose stored status is not a
+	// valid Status, which reads report as a corrupt row, and its update time
+	// to at. Returns ErrUserNotFound if the user doesn't exist and
+	// ErrUserStatusValid if its status is valid.
+	RepairStatus(ctx context.Context, id UserID, status Status, at time.Time) error
+
+	// Import inserts new users in bulk, outside any transaction and each
+	// independently of the others, so one failing does not stop the rest.
+	// It returns one error per user, nil for those inserted and
+	// ErrEmailExists for those whose email is taken, and an error for the
+	// call as a whole when it failed part way.
+	Import(ctx context.Context, users []*User) ([]error, error)
+}
+
+// DeletedFilter selects soft-deleted users (StatusDeleted) in repository
+// queries. The zero value leaves them out.
+type DeletedFilter int
+
+const (
+	ExcludeDeleted DeletedFilter = iota
+	IncludeDeleted
+	OnlyDeleted
+)
+
+// ParseDeletedFilter parses "exclude", "include" or "only"; "" is
+// ExcludeDeleted.
+func ParseDeletedFilter(s string) (DeletedFilter, error) {
+	switch s {
+	case "", "exclude":
+		return ExcludeDeleted, nil
+	case "include":
+		return IncludeDeleted, nil
+	case "only":
+		return OnlyDeleted, nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrDeletedFilterInvalid, s)
+}
+
+// Matches reports whether a user with status s is selected.
+func (f DeletedFilter) Matches(s Status) bool {
+	switch f {
+	case IncludeDeleted:
+		return true
+	case OnlyDeleted:
+		return s == StatusDeleted
+	}
+	return s != StatusDeleted
+}
+
+// UserSortField is a field FindAll can sort users by.
+type UserSortField string
+
+const (
+	UserSortCreatedAt UserSortField = "created_at"
+	UserSortEmail     UserSortField = "email"
+)
+
+// UserSortFields are the fields FindAll can sort users by.
+var UserSortFields = []string{string(UserSortCreatedAt), string(UserSortEmail)}
+
+// UserSort orders the users of FindAll by Field, ascending unless Desc.
+// The zero value lists the newest users first.
+type UserSort struct {
+	Field UserSortField
+	Desc  bool
 }
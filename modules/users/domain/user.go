@@ -4,9 +4,12 @@ package domain
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/invariant"
 )
 
 // User is the aggregate root for the user bounded context.
@@ -16,35 +19,55 @@ type User struct {
 	email     Email
 	name      Name
 	status    Status
+	prefs     Preferences
+	password  PasswordHash
 	createdAt time.Time
 	updatedAt time.Time
 }
 
+var _ invariant.Validatable = (*User)(nil)
+
 // NewUser creates a new User with validated inputs.
 // Factory function enforces all invariants at creation time.
 // Adds UserCreatedEvent to the context for later dispatch.
 func NewUser(ctx context.Context, email Email, name Name) *User {
+	return NewUserWithID(ctx, NewUserID(ctx), email, name)
+}
+
+// NewUserWithID is NewUser with a caller-chosen ID, e.g. a deterministic
+// one for fixtures.
+func NewUserWithID(ctx context.Context, id UserID, email Email, name Name) *User {
+	return NewUserWithPreferences(ctx, id, email, name, DefaultPreferences())
+}
+
+// NewUserWithPreferences is NewUserWithID with initial preferences, e.g. in
+// the locale the user signed up in.
+func NewUserWithPreferences(ctx context.Context, id UserID, email Email, name Name, prefs Preferences) *User {
+	now := clock.Now(ctx)
 	u := &User{
-		id:        NewUserID(),
+		id:        id,
 		email:     email,
 		name:      name,
 		status:    StatusActive,
-		createdAt: time.Now().UTC(),
-		updatedAt: time.Now().UTC(),
+		prefs:     prefs,
+		createdAt: now,
+		updatedAt: now,
 	}
-	events.Add(ctx, newUserCreatedEvent(u))
+	events.Add(ctx, newUserCreatedEvent(ctx, u))
 	return u
 }
 
 // Reconstitute recreates a User from persistence.
 // Used by repositories to rebuild aggregates from stored data.
-func Reconstitute(id UserID, email Email, name Name, status Status, createdAt, updatedAt time.Time,
+func Reconstitute(id UserID, email Email, name Name, status Status, prefs Preferences, password PasswordHash, createdAt, updatedAt time.Time,
 ) *User {
 	return &User{
 		id:        id,
 		email:     email,
 		name:      name,
 		status:    status,
+		prefs:     prefs,
+		password:  password,
 		createdAt: createdAt,
 		updatedAt: updatedAt,
 	}
@@ -52,69 +75,178 @@ func Reconstitute(id UserID, email Email, name Name, status Status, createdAt, u
 
 // Getters - expose state without allowing direct mutation
 
-func (u *User) ID() UserID           { return u.id }
-func (u *User) Email() Email         { return u.email }
-func (u *User) Name() Name           { return u.name }
-func (u *User) Status() Status       { return u.status }
-func (u *User) CreatedAt() time.Time { return u.createdAt }
-func (u *User) UpdatedAt() time.Time { return u.updatedAt }
+func (u *User) ID() UserID               { return u.id }
+func (u *User) Email() Email             { return u.email }
+func (u *User) Name() Name               { return u.name }
+func (u *User) Status() Status           { return u.status }
+func (u *User) Preferences() Preferences { return u.prefs }
+func (u *User) Password() PasswordHash   { return u.password }
+func (u *User) CreatedAt() time.Time     { return u.createdAt }
+func (u *User) UpdatedAt() time.Time     { return u.updatedAt }
+
+// CheckUnchanged returns ErrUserModified if the user has been updated since
+// updatedAt, the version a client last read. The zero time matches any
+// version.
+func (u *User) CheckUnchanged(updatedAt time.Time) error {
+	if !updatedAt.IsZero() && !u.updatedAt.Equal(updatedAt) {
+		return ErrUserModified
+	}
+	return nil
+}
 
 // Business methods - encapsulate business rules
 
 // UpdateProfile updates the user's profile information.
 // Adds UserUpdatedEvent to the context for later dispatch.
 func (u *User) UpdateProfile(ctx context.Context, name Name) error {
-	if u.status == StatusDeleted {
-		return ErrUserDeleted
+	if _, err := u.next("update"); err != nil {
+		return err
 	}
 	u.name = name
-	u.updatedAt = time.Now().UTC()
-	events.Add(ctx, newUserUpdatedEvent(u))
+	u.updatedAt = clock.Now(ctx)
+	events.Add(ctx, newUserUpdatedEvent(ctx, u))
 	return nil
 }
 
 // ChangeEmail changes the user's email address.
 // Adds UserUpdatedEvent to the context for later dispatch.
 func (u *User) ChangeEmail(ctx context.Context, email Email) error {
-	if u.status == StatusDeleted {
-		return ErrUserDeleted
+	if _, err := u.next("update"); err != nil {
+		return err
 	}
 	u.email = email
-	u.updatedAt = time.Now().UTC()
-	events.Add(ctx, newUserUpdatedEvent(u))
+	u.updatedAt = clock.Now(ctx)
+	events.Add(ctx, newUserUpdatedEvent(ctx, u))
+	return nil
+}
+
+// RequestEmailChange asks the user to confirm the change of their email to
+// email with token, which ChangeEmail then applies. It does not change the
+// user. Adds EmailChangeRequestedEvent to the context for later dispatch.
+func (u *User) RequestEmailChange(ctx context.Context, email Email, token string) error {
+	if _, err := u.next("update"); err != nil {
+		return err
+	}
+	events.Add(ctx, newEmailChangeRequestedEvent(ctx, u, email, token))
+	return nil
+}
+
+// RequestPasswordReset asks the user to confirm the reset of their password
+// with token, which ResetPassword then applies. It does not change the user.
+// Adds PasswordResetRequestedEvent to the context for later dispatch.
+func (u *User) RequestPasswordReset(ctx context.Context, token string) error {
+	if _, err := u.next("update"); err != nil {
+		return err
+	}
+	events.Add(ctx, newPasswordResetRequestedEvent(ctx, u, token))
+	return nil
+}
+
+// ResetPassword replaces the user's password, or sets the first one.
+// Adds PasswordChangedEvent to the context for later dispatch.
+func (u *User) ResetPassword(ctx context.Context, password PasswordHash) error {
+	if _, err := u.next("update"); err != nil {
+		return err
+	}
+	u.password = password
+	u.updatedAt = clock.Now(ctx)
+	events.Add(ctx, newPasswordChangedEvent(ctx, u))
+	return nil
+}
+
+// UpdatePreferences replaces the user's preferences.
+// Adds UserPreferencesUpdatedEvent to the context for later dispatch.
+func (u *User) UpdatePreferences(ctx context.Context, prefs Preferences) error {
+	if _, err := u.next("update"); err != nil {
+		return err
+	}
+	u.prefs = prefs
+	u.updatedAt = clock.Now(ctx)
+	events.Add(ctx, newUserPreferencesUpdatedEvent(ctx, u))
 	return nil
 }
 
 // Deactivate deactivates the user account.
-func (u *User) Deactivate() error {
-	if u.status == StatusDeleted {
-		return ErrUserDeleted
+func (u *User) Deactivate(ctx context.Context) error {
+	next, err := u.next("deactivate")
+	if err != nil {
+		return err
 	}
-	u.status = StatusInactive
-	u.updatedAt = time.Now().UTC()
+	u.status = next
+	u.updatedAt = clock.Now(ctx)
 	return nil
 }
 
 // Activate activates the user account.
-func (u *User) Activate() error {
-	if u.status == StatusDeleted {
-		return ErrUserDeleted
+func (u *User) Activate(ctx context.Context) error {
+	next, err := u.next("activate")
+	if err != nil {
+		return err
 	}
-	u.status = StatusActive
-	u.updatedAt = time.Now().UTC()
+	u.status = next
+	u.updatedAt = clock.Now(ctx)
 	return nil
 }
 
-// Delete marks the user as deleted (soft delete).
+// Delete marks the user as deleted (soft delete). A deleted user cannot be
+// deleted again, which would move the start of its restore grace period.
 // Adds UserDeletedEvent to the context for later dispatch.
 func (u *User) Delete(ctx context.Context) error {
-	u.status = StatusDeleted
-	u.updatedAt = time.Now().UTC()
+	next, err := u.next("delete")
+	if err != nil {
+		return err
+	}
+	u.status = next
+	u.updatedAt = clock.Now(ctx)
 
-	events.Add(ctx, newUserDeletedEvent(u.id))
+	events.Add(ctx, newUserDeletedEvent(ctx, u.id))
 	return nil
 }
 
+// Restore reactivates a user deleted at most gracePeriod ago; zero
+// gracePeriod restores a user deleted at any time. A deleted user cannot be
+// changed, so its UpdatedAt is when it was deleted.
+// Adds UserRestoredEvent to the context for later dispatch.
+func (u *User) Restore(ctx context.Context, gracePeriod time.Duration) error {
+	next, err := u.next("restore")
+	if err != nil {
+		return err
+	}
+	now := clock.Now(ctx)
+	if gracePeriod > 0 && now.Sub(u.updatedAt) > gracePeriod {
+		return ErrRestorePeriodExpired
+	}
+	u.status = next
+	u.updatedAt = now
+	events.Add(ctx, newUserRestoredEvent(ctx, u))
+	return nil
+}
+
+// Validate checks the invariants every user must hold, whatever sequence of
+// business methods produced it: a known status, an email and a name, and an
+// update time no earlier than the creation time. Errors wrap
+// ErrUserInvariant or ErrStatusInvalid.
+func (u *User) Validate() error {
+	if !u.status.IsValid() {
+		return fmt.Errorf("%w: %q", ErrStatusInvalid, u.status)
+	}
+	switch {
+	case u.email.IsZero():
+		return fmt.Errorf("%w: no email", ErrUserInvariant)
+	case u.name.IsZero():
+		return fmt.Errorf("%w: no name", ErrUserInvariant)
+	case u.updatedAt.Before(u.createdAt):
+		return fmt.Errorf("%w: updated before created", ErrUserInvariant)
+	}
+	return nil
+}
+
+// next returns the status the named transition of UserLifecycle leads to
+// from the user's status, or why the user does not allow it.
+func (u *User) next(transition string) (Status, error) {
+	return UserLifecycle.Next(u.status, transition)
+}
+
 // IsActive returns true if the user account is active.
 func (u *User) IsActive() bool {
 	return u.status == StatusActive
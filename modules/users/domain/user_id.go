@@ -1,9 +1,13 @@
 package domain
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/ids"
 )
 
 // ErrInvalidUserID indicates the user ID format is invalid.
@@ -15,8 +19,9 @@ type UserID struct {
 	value string
 }
 
-func NewUserID() UserID {
-	return UserID{value: uuid.New().String()}
+// NewUserID generates a user ID with the generator in ctx (see ids.New).
+func NewUserID(ctx context.Context) UserID {
+	return UserID{value: ids.New(ctx)}
 }
 
 func ParseUserID(s string) (UserID, error) {
@@ -28,3 +33,35 @@ func ParseUserID(s string) (UserID, error) {
 
 func (id UserID) String() string { return id.value }
 func (id UserID) IsZero() bool   { return id.value == "" }
+
+// MarshalText implements encoding.TextMarshaler, so that a UserID can be used
+// in JSON request and response structs, where it is a string.
+func (id UserID) MarshalText() ([]byte, error) { return []byte(id.value), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler. It returns
+// ErrInvalidUserID for a malformed ID; the empty string is the zero UserID.
+func (id *UserID) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*id = UserID{}
+		return nil
+	}
+	parsed, err := ParseUserID(string(text))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// EncodeSpanner implements spanner.Encoder: a UserID is stored as a STRING.
+func (id UserID) EncodeSpanner() (any, error) { return id.value, nil }
+
+// DecodeSpanner implements spanner.Decoder, for reading a UserID column
+// directly into a UserID.
+func (id *UserID) DecodeSpanner(input any) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("cannot decode %T into a user ID", input)
+	}
+	return id.UnmarshalText([]byte(s))
+}
@@ -2,9 +2,19 @@ package domain_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock/clocktest"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/ids/idstest"
 	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
 )
 
@@ -39,9 +49,33 @@ func TestNewUser(t *testing.T) {
 	}
 }
 
+func TestNewUser_IDsFromGenerator(t *testing.T) {
+	ctx, _ := idstest.Context(context.Background())
+
+	var user *domain.User
+	collected, err := events.CaptureEvents(ctx, func(ctx context.Context) error {
+		user = createTestUser(t, ctx)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if user.ID().String() != idstest.ID(1) {
+		t.Errorf("expected user ID %s, got %s", idstest.ID(1), user.ID())
+	}
+	if len(collected) != 1 || collected[0].EventID() != idstest.ID(2) {
+		t.Errorf("expected one event with ID %s, got %v", idstest.ID(2), collected)
+	}
+}
+
 func TestUser_UpdateProfile(t *testing.T) {
-	_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	ctx, clk := clocktest.Context(context.Background(), created)
+
+	_, err := events.CaptureEvents(ctx, func(ctx context.Context) error {
 		user := createTestUser(t, ctx)
+		clk.Advance(time.Minute)
 
 		newName, err := domain.NewName("Jane", "Smith")
 		if err != nil {
@@ -56,6 +90,9 @@ func TestUser_UpdateProfile(t *testing.T) {
 		if user.Name().FullName() != "Jane Smith" {
 			t.Errorf("expected name 'Jane Smith', got '%s'", user.Name().FullName())
 		}
+		if !user.CreatedAt().Equal(created) || !user.UpdatedAt().Equal(created.Add(time.Minute)) {
+			t.Errorf("expected created %v and updated a minute later, got %v and %v", created, user.CreatedAt(), user.UpdatedAt())
+		}
 		return nil
 	})
 	if err != nil {
@@ -90,7 +127,7 @@ func TestUser_UpdateProfile_Deleted(t *testing.T) {
 		newName, _ := domain.NewName("Jane", "Smith")
 		err := user.UpdateProfile(ctx, newName)
 
-		if err != domain.ErrUserDeleted {
+		if !errors.Is(err, domain.ErrUserDeleted) {
 			t.Errorf("expected ErrUserDeleted, got %v", err)
 		}
 		return nil
@@ -100,6 +137,133 @@ func TestUser_UpdateProfile_Deleted(t *testing.T) {
 	}
 }
 
+func TestUser_Restore(t *testing.T) {
+	deleted := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		after       time.Duration
+		gracePeriod time.Duration
+		wantErr     error
+	}{
+		{"within grace period", 24 * time.Hour, 30 * 24 * time.Hour, nil},
+		{"after grace period", 31 * 24 * time.Hour, 30 * 24 * time.Hour, domain.ErrRestorePeriodExpired},
+		{"no grace period", 365 * 24 * time.Hour, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, clk := clocktest.Context(context.Background(), deleted)
+			collected, err := events.CaptureEvents(ctx, func(ctx context.Context) error {
+				user := createTestUser(t, ctx)
+				if err := user.Delete(ctx); err != nil {
+					t.Fatalf("failed to delete user: %v", err)
+				}
+				clk.Advance(tt.after)
+
+				if err := user.Restore(ctx, tt.gracePeriod); err != tt.wantErr {
+					t.Fatalf("Restore() error = %v, want %v", err, tt.wantErr)
+				}
+				if want := domain.StatusActive; tt.wantErr == nil && user.Status() != want {
+					t.Errorf("expected status %q, got %q", want, user.Status())
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			restored := slices.ContainsFunc(collected, func(e events.Event) bool { return e.EventType() == domain.UserRestoredEventType })
+			if restored != (tt.wantErr == nil) {
+				t.Errorf("UserRestoredEvent raised = %v, want %v", restored, tt.wantErr == nil)
+			}
+		})
+	}
+}
+
+func TestUser_Restore_NotDeleted(t *testing.T) {
+	_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		user := createTestUser(t, ctx)
+		if err := user.Restore(ctx, 0); !errors.Is(err, domain.ErrUserNotDeleted) {
+			t.Errorf("expected ErrUserNotDeleted, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDeletedFilter_Matches(t *testing.T) {
+	tests := []struct {
+		filter          domain.DeletedFilter
+		active, deleted bool
+	}{
+		{domain.ExcludeDeleted, true, false},
+		{domain.IncludeDeleted, true, true},
+		{domain.OnlyDeleted, false, true},
+	}
+	for _, tt := range tests {
+		if got := tt.filter.Matches(domain.StatusActive); got != tt.active {
+			t.Errorf("%d.Matches(active) = %v, want %v", tt.filter, got, tt.active)
+		}
+		if got := tt.filter.Matches(domain.StatusDeleted); got != tt.deleted {
+			t.Errorf("%d.Matches(deleted) = %v, want %v", tt.filter, got, tt.deleted)
+		}
+	}
+}
+
+func TestUser_CheckUnchanged(t *testing.T) {
+	_, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		ctx, clk := clocktest.Context(ctx, time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+		user := createTestUser(t, ctx)
+		read := user.UpdatedAt()
+
+		if err := user.CheckUnchanged(read); err != nil {
+			t.Errorf("CheckUnchanged(last update) = %v, want nil", err)
+		}
+		clk.Advance(time.Second)
+		newName, _ := domain.NewName("Jane", "Smith")
+		user.UpdateProfile(ctx, newName)
+		if err := user.CheckUnchanged(read); err != domain.ErrUserModified {
+			t.Errorf("CheckUnchanged(earlier update) = %v, want ErrUserModified", err)
+		}
+		if err := user.CheckUnchanged(time.Time{}); err != nil {
+			t.Errorf("CheckUnchanged(zero) = %v, want nil", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestUser_Validate(t *testing.T) {
+	id := domain.NewUserID(context.Background())
+	email, _ := domain.NewEmail("john@example.com")
+	name, _ := domain.NewName("John", "Doe")
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	user := func(email domain.Email, name domain.Name, status domain.Status, updatedAt time.Time) *domain.User {
+		return domain.Reconstitute(id, email, name, status, domain.DefaultPreferences(), domain.PasswordHash{}, now, updatedAt)
+	}
+
+	tests := []struct {
+		name string
+		user *domain.User
+		want error
+	}{
+		{"valid", user(email, name, domain.StatusActive, now), nil},
+		{"unknown status", user(email, name, "banned", now), domain.ErrStatusInvalid},
+		{"no email", user(domain.Email{}, name, domain.StatusActive, now), domain.ErrUserInvariant},
+		{"no name", user(email, domain.Name{}, domain.StatusDeleted, now), domain.ErrUserInvariant},
+		{"updated before created", user(email, name, domain.StatusInactive, now.Add(-time.Second)), domain.ErrUserInvariant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.user.Validate(); !errors.Is(err, tt.want) || (err == nil) != (tt.want == nil) {
+				t.Errorf("Validate() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
 func TestEmail_Validation(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -112,6 +276,15 @@ func TestEmail_Validation(t *testing.T) {
 		{"invalid format", "not-an-email", domain.ErrEmailInvalid},
 		{"missing @", "testexample.com", domain.ErrEmailInvalid},
 		{"missing domain", "test@", domain.ErrEmailInvalid},
+		{"plus tag", "john+shop@example.com", nil},
+		{"rfc 5322 specials", "o'brien!{x}@example.com", nil},
+		{"idn domain", "user@bücher.example", nil},
+		{"punycode domain", "user@xn--bcher-kva.example", nil},
+		{"leading dot", ".john@example.com", domain.ErrEmailInvalid},
+		{"consecutive dots", "john..doe@example.com", domain.ErrEmailInvalid},
+		{"single label domain", "john@localhost", domain.ErrEmailInvalid},
+		{"numeric tld", "john@example.123", domain.ErrEmailInvalid},
+		{"utf-8 local part", "jürgen@example.com", domain.ErrEmailInvalid},
 	}
 
 	for _, tt := range tests {
@@ -124,6 +297,60 @@ func TestEmail_Validation(t *testing.T) {
 	}
 }
 
+func TestEmailPolicy_Strictness(t *testing.T) {
+	tests := []struct {
+		name       string
+		strictness domain.EmailStrictness
+		email      string
+		wantErr    error
+	}{
+		{"strict rejects idn", domain.EmailStrict, "user@bücher.example", domain.ErrEmailInvalid},
+		{"strict rejects specials", domain.EmailStrict, "o'brien@example.com", domain.ErrEmailInvalid},
+		{"strict accepts plain", domain.EmailStrict, "john.doe@example.com", nil},
+		{"relaxed accepts utf-8 local part", domain.EmailRelaxed, "jürgen@bücher.example", nil},
+		{"relaxed rejects spaces", domain.EmailRelaxed, "jür gen@example.com", domain.ErrEmailInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.EmailPolicy{Strictness: tt.strictness}.NewEmail(tt.email)
+			if err != tt.wantErr {
+				t.Errorf("NewEmail(%q) error = %v, want %v", tt.email, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEmail_Canonical(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        domain.EmailPolicy
+		email         string
+		wantValue     string
+		wantCanonical string
+	}{
+		{"plus tag kept by default", domain.EmailPolicy{}, "John+Shop@Example.com", "john+shop@example.com", "john+shop@example.com"},
+		{"plus tag stripped", domain.EmailPolicy{NormalizePlusTags: true}, "john+shop@example.com", "john+shop@example.com", "john@example.com"},
+		{"idn canonicalized to punycode", domain.EmailPolicy{}, "user@BÜCHER.example", "user@bücher.example", "user@xn--bcher-kva.example"},
+		{"punycode displayed as unicode", domain.EmailPolicy{}, "user@xn--bcher-kva.example", "user@bücher.example", "user@xn--bcher-kva.example"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email, err := tt.policy.NewEmail(tt.email)
+			if err != nil {
+				t.Fatalf("NewEmail(%q) unexpected error: %v", tt.email, err)
+			}
+			if email.String() != tt.wantValue {
+				t.Errorf("String() = %q, want %q", email.String(), tt.wantValue)
+			}
+			if email.Canonical() != tt.wantCanonical {
+				t.Errorf("Canonical() = %q, want %q", email.Canonical(), tt.wantCanonical)
+			}
+		})
+	}
+}
+
 func TestName_Validation(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -163,3 +390,217 @@ func createTestUser(t *testing.T, ctx context.Context) *domain.User {
 
 	return domain.NewUser(ctx, email, name)
 }
+
+func TestUser_UpdatePreferences(t *testing.T) {
+	collected, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		user := createTestUser(t, ctx)
+		if user.Preferences().Locale() != domain.DefaultLocale {
+			t.Errorf("expected default locale %q, got %q", domain.DefaultLocale, user.Preferences().Locale())
+		}
+
+		prefs, err := domain.NewPreferences("ja-JP", []string{"sms", "email", "sms"})
+		if err != nil {
+			t.Fatalf("failed to create preferences: %v", err)
+		}
+		if err := user.UpdatePreferences(ctx, prefs); err != nil {
+			t.Fatalf("failed to update preferences: %v", err)
+		}
+		if user.Preferences().Locale() != "ja-JP" {
+			t.Errorf("expected locale 'ja-JP', got %q", user.Preferences().Locale())
+		}
+		if got := user.Preferences().MutedChannels(); !slices.Equal(got, []string{"email", "sms"}) {
+			t.Errorf("expected muted channels [email sms], got %v", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(collected) != 2 || collected[1].EventType() != domain.UserPreferencesUpdatedEventType {
+		t.Errorf("expected UserCreatedEvent followed by UserPreferencesUpdatedEvent, got %d events", len(collected))
+	}
+}
+
+func TestHashPassword(t *testing.T) {
+	hash, err := domain.HashPassword("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	if !hash.Matches("correct horse battery staple") {
+		t.Error("expected the hash to match its password")
+	}
+	if hash.Matches("correct horse battery stable") {
+		t.Error("expected the hash not to match another password")
+	}
+	if got := domain.ReconstitutePasswordHash(hash.Encoded()); got.Fingerprint() != hash.Fingerprint() {
+		t.Errorf("expected a reconstituted hash to keep its fingerprint")
+	}
+	if (domain.PasswordHash{}).Matches("") || (domain.PasswordHash{}).Fingerprint() != "" {
+		t.Error("expected no password to match nothing and have no fingerprint")
+	}
+
+	if _, err := domain.HashPassword("short"); !errors.Is(err, domain.ErrPasswordTooShort) {
+		t.Errorf("expected ErrPasswordTooShort, got %v", err)
+	}
+	if _, err := domain.HashPassword(strings.Repeat("p", 129)); !errors.Is(err, domain.ErrPasswordTooLong) {
+		t.Errorf("expected ErrPasswordTooLong, got %v", err)
+	}
+}
+
+func TestUser_ResetPassword(t *testing.T) {
+	hash, err := domain.HashPassword("correct horse battery staple")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	collected, err := events.CaptureEvents(context.Background(), func(ctx context.Context) error {
+		user := createTestUser(t, ctx)
+		if err := user.RequestPasswordReset(ctx, "a.b.c"); err != nil {
+			t.Fatalf("failed to request password reset: %v", err)
+		}
+		if !user.Password().IsZero() {
+			t.Error("expected the request not to set a password")
+		}
+		if err := user.ResetPassword(ctx, hash); err != nil {
+			t.Fatalf("failed to reset password: %v", err)
+		}
+		if user.Password() != hash {
+			t.Error("expected the password to be set")
+		}
+
+		user.Delete(ctx)
+		if err := user.RequestPasswordReset(ctx, "a.b.c"); !errors.Is(err, domain.ErrUserDeleted) {
+			t.Errorf("expected ErrUserDeleted, got %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var types []events.EventType
+	for _, e := range collected {
+		types = append(types, e.EventType())
+	}
+	want := []events.EventType{domain.UserCreatedEventType, domain.PasswordResetRequestedEventType, domain.PasswordChangedEventType, domain.UserDeletedEventType}
+	if !slices.Equal(types, want) {
+		t.Errorf("expected events %v, got %v", want, types)
+	}
+}
+
+func TestNewPreferences_RejectsInvalidLocale(t *testing.T) {
+	for _, locale := range []string{"", "english", "EN", "en_US", "en-us"} {
+		if _, err := domain.NewPreferences(locale, nil); err != domain.ErrLocaleInvalid {
+			t.Errorf("NewPreferences(%q): expected ErrLocaleInvalid, got %v", locale, err)
+		}
+	}
+	if _, err := domain.NewPreferences("en", []string{"fax"}); err != domain.ErrNotificationChannelInvalid {
+		t.Errorf("expected ErrNotificationChannelInvalid, got %v", err)
+	}
+}
+
+func TestPreferencesForLocale(t *testing.T) {
+	tests := map[string]string{"ja-JP": "ja-JP", "fr": "fr", "": domain.DefaultLocale, "en_US": domain.DefaultLocale}
+	for locale, want := range tests {
+		if got := domain.PreferencesForLocale(locale).Locale(); got != want {
+			t.Errorf("PreferencesForLocale(%q).Locale() = %q, want %q", locale, got, want)
+		}
+	}
+}
+
+func TestValueObjects_JSON(t *testing.T) {
+	type request struct {
+		ID    domain.UserID `json:"id"`
+		Email domain.Email  `json:"email"`
+		Name  domain.Name   `json:"name"`
+	}
+	id, _ := domain.ParseUserID("7c9e6679-7425-40de-944b-e07fc1f90ae7")
+	email, _ := domain.NewEmail("Alice@Example.com")
+	name, _ := domain.NewName("Alice", "Smith")
+
+	data, err := json.Marshal(request{ID: id, Email: email, Name: name})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `{"id":"7c9e6679-7425-40de-944b-e07fc1f90ae7","email":"alice@example.com","name":{"first_name":"Alice","last_name":"Smith"}}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+
+	var got request
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.ID != id || !got.Email.Equals(email) || got.Email.Canonical() != email.Canonical() || !got.Name.Equals(name) {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, request{ID: id, Email: email, Name: name})
+	}
+
+	var zero request
+	if err := json.Unmarshal([]byte(`{"id":"","email":"","name":{}}`), &zero); err != nil || zero != (request{}) {
+		t.Errorf("Unmarshal() of zero values = %+v, %v", zero, err)
+	}
+}
+
+func TestValueObjects_UnmarshalJSONValidates(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		target  any
+		wantErr error
+	}{
+		{"user ID", `"not-a-uuid"`, new(domain.UserID), domain.ErrInvalidUserID},
+		{"email", `"not-an-email"`, new(domain.Email), domain.ErrEmailInvalid},
+		{"name", `{"first_name":"A","last_name":"Smith"}`, new(domain.Name), domain.ErrFirstNameLength},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(tt.data), tt.target); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Unmarshal(%s) error = %v, want %v", tt.data, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserID_Spanner(t *testing.T) {
+	id, _ := domain.ParseUserID("7c9e6679-7425-40de-944b-e07fc1f90ae7")
+	encoded, err := id.EncodeSpanner()
+	if err != nil || encoded != id.String() {
+		t.Fatalf("EncodeSpanner() = %v, %v, want %s", encoded, err, id)
+	}
+
+	var decoded domain.UserID
+	if err := decoded.DecodeSpanner(encoded); err != nil || decoded != id {
+		t.Errorf("DecodeSpanner() = %v, %v, want %s", decoded, err, id)
+	}
+	if err := decoded.DecodeSpanner("not-a-uuid"); !errors.Is(err, domain.ErrInvalidUserID) {
+		t.Errorf("DecodeSpanner(malformed) error = %v, want ErrInvalidUserID", err)
+	}
+	if err := decoded.DecodeSpanner(int64(1)); err == nil {
+		t.Error("DecodeSpanner(INT64) succeeded")
+	}
+}
+
+var update = flag.Bool("update", false, "rewrite the generated lifecycle diagram in docs/")
+
+// TestUserLifecycle_Diagram keeps docs/architecture/user-lifecycle.md in
+// sync with UserLifecycle; run it with -update after changing the table.
+func TestUserLifecycle_Diagram(t *testing.T) {
+	path := filepath.Join("..", "..", "..", "docs", "architecture", "user-lifecycle.md")
+	got := "# User lifecycle\n\n" +
+		"<!-- Generated from domain.UserLifecycle by TestUserLifecycle_Diagram (modules/users/domain); run it with -update to refresh. -->\n\n" +
+		"The statuses of a user and the `User` methods that move it between them. `update` stands for the profile, email and preference changes.\n\n" +
+		"```mermaid\n" + domain.UserLifecycle.Mermaid() + "```\n"
+
+	if *update {
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s (run with -update to create it): %v", path, err)
+	}
+	if got != string(want) {
+		t.Errorf("%s is out of date (run with -update to regenerate it):\n%s", path, got)
+	}
+}
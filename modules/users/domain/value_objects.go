@@ -1,36 +1,12 @@
 package domain
 
 import (
-	"regexp"
+	"encoding/json"
+	"fmt"
 	"strings"
-)
-
-// Email is a value object representing a validated email address.
-// Value objects are immutable and compared by value.
-type Email struct {
-	value string
-}
-
-var emailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 
-// NewEmail creates a validated Email value object.
-func NewEmail(value string) (Email, error) {
-	value = strings.TrimSpace(strings.ToLower(value))
-	if value == "" {
-		return Email{}, ErrEmailRequired
-	}
-	if !emailRegex.MatchString(value) {
-		return Email{}, ErrEmailInvalid
-	}
-	return Email{value: value}, nil
-}
-
-func (e Email) String() string { return e.value }
-func (e Email) IsZero() bool   { return e.value == "" }
-
-func (e Email) Equals(other Email) bool {
-	return e.value == other.value
-}
+	"github.com/rai/clean-modularmonolith-go/modules/shared/statemachine"
+)
 
 // Name is a value object representing a user's name.
 type Name struct {
@@ -67,6 +43,36 @@ func (n Name) Equals(other Name) bool {
 	return n.firstName == other.firstName && n.lastName == other.lastName
 }
 
+// nameJSON is the JSON form of Name.
+type nameJSON struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// MarshalJSON implements json.Marshaler: {"first_name": ..., "last_name": ...}.
+func (n Name) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nameJSON{FirstName: n.firstName, LastName: n.lastName})
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It validates the name like
+// NewName; an object with neither name is the zero Name.
+func (n *Name) UnmarshalJSON(data []byte) error {
+	var v nameJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.FirstName == "" && v.LastName == "" {
+		*n = Name{}
+		return nil
+	}
+	parsed, err := NewName(v.FirstName, v.LastName)
+	if err != nil {
+		return err
+	}
+	*n = parsed
+	return nil
+}
+
 // Status represents the user account status.
 type Status string
 
@@ -86,3 +92,23 @@ func (s Status) IsValid() bool {
 		return false
 	}
 }
+
+// ParseStatus parses a stored status, which must be one of the Status
+// constants.
+func ParseStatus(s string) (Status, error) {
+	if status := Status(s); status.IsValid() {
+		return status, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrStatusInvalid, s)
+}
+
+// UserLifecycle is the table of the user statuses each User method
+// accepts, by the method's name; "update" covers the profile, email and
+// preference changes, which leave the status as it is.
+var UserLifecycle = statemachine.New("user",
+	statemachine.Transition[Status]{Name: "update", From: []Status{StatusActive, StatusInactive}, Err: ErrUserDeleted},
+	statemachine.Transition[Status]{Name: "deactivate", From: []Status{StatusActive, StatusInactive}, To: StatusInactive, Err: ErrUserDeleted},
+	statemachine.Transition[Status]{Name: "activate", From: []Status{StatusActive, StatusInactive}, To: StatusActive, Err: ErrUserDeleted},
+	statemachine.Transition[Status]{Name: "delete", From: []Status{StatusActive, StatusInactive}, To: StatusDeleted, Err: ErrUserDeleted},
+	statemachine.Transition[Status]{Name: "restore", From: []Status{StatusDeleted}, To: StatusActive, Err: ErrUserNotDeleted},
+)
@@ -6,8 +6,14 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
-	"strconv"
-
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/etag"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/export"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
 	"github.com/rai/clean-modularmonolith-go/modules/users/application/commands"
 	"github.com/rai/clean-modularmonolith-go/modules/users/application/queries"
 	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
@@ -15,39 +21,70 @@ import (
 
 // Handler handles HTTP requests for the users module.
 type Handler struct {
-	createUser  *commands.CreateUserHandler
-	updateUser  *commands.UpdateUserHandler
-	deleteUser  *commands.DeleteUserHandler
-	getUser     *queries.GetUserHandler
-	listUsers   *queries.ListUsersHandler
-	searchUsers *queries.SearchUsersHandler
+	createUser         command.Handler[commands.CreateUserCommand, string]
+	updateUser         command.VoidHandler[commands.UpdateUserCommand]
+	deleteUser         command.VoidHandler[commands.DeleteUserCommand]
+	restoreUser        command.VoidHandler[commands.RestoreUserCommand]
+	updatePrefs        command.VoidHandler[commands.UpdatePreferencesCommand]
+	requestEmailChange command.VoidHandler[commands.RequestEmailChangeCommand]
+	confirmEmailChange command.VoidHandler[commands.ConfirmEmailChangeCommand]
+	requestPassword    command.VoidHandler[commands.RequestPasswordResetCommand]
+	resetPassword      command.VoidHandler[commands.ResetPasswordCommand]
+	getUser            command.Handler[queries.GetUserQuery, *queries.UserDTO]
+	batchGet           *queries.BatchGetUsersHandler
+	listUsers          *queries.ListUsersHandler
+	searchUsers        *queries.SearchUsersHandler
+	admin              security.AdminGuard
 }
 
 // RegisterRoutes registers the users module routes to the given mux.
 func RegisterRoutes(
 	mux *http.ServeMux,
-	createUser *commands.CreateUserHandler,
-	updateUser *commands.UpdateUserHandler,
-	deleteUser *commands.DeleteUserHandler,
-	getUser *queries.GetUserHandler,
+	createUser command.Handler[commands.CreateUserCommand, string],
+	updateUser command.VoidHandler[commands.UpdateUserCommand],
+	deleteUser command.VoidHandler[commands.DeleteUserCommand],
+	restoreUser command.VoidHandler[commands.RestoreUserCommand],
+	updatePrefs command.VoidHandler[commands.UpdatePreferencesCommand],
+	requestEmailChange command.VoidHandler[commands.RequestEmailChangeCommand],
+	confirmEmailChange command.VoidHandler[commands.ConfirmEmailChangeCommand],
+	requestPassword command.VoidHandler[commands.RequestPasswordResetCommand],
+	resetPassword command.VoidHandler[commands.ResetPasswordCommand],
+	getUser command.Handler[queries.GetUserQuery, *queries.UserDTO],
+	batchGet *queries.BatchGetUsersHandler,
 	listUsers *queries.ListUsersHandler,
 	searchUsers *queries.SearchUsersHandler,
+	admin security.AdminGuard,
 ) {
 	h := &Handler{
-		createUser:  createUser,
-		updateUser:  updateUser,
-		deleteUser:  deleteUser,
-		getUser:     getUser,
-		listUsers:   listUsers,
-		searchUsers: searchUsers,
+		createUser:         createUser,
+		updateUser:         updateUser,
+		deleteUser:         deleteUser,
+		restoreUser:        restoreUser,
+		updatePrefs:        updatePrefs,
+		requestEmailChange: requestEmailChange,
+		confirmEmailChange: confirmEmailChange,
+		requestPassword:    requestPassword,
+		resetPassword:      resetPassword,
+		getUser:            getUser,
+		batchGet:           batchGet,
+		listUsers:          listUsers,
+		searchUsers:        searchUsers,
+		admin:              admin,
 	}
 
 	mux.HandleFunc("GET /users", h.handleListUsers)
 	mux.HandleFunc("POST /users", h.handleCreateUser)
+	mux.HandleFunc("POST /users:batchGet", h.handleBatchGetUsers)
 	mux.HandleFunc("GET /users/search", h.handleSearchUsers)
 	mux.HandleFunc("GET /users/{id}", h.handleGetUser)
 	mux.HandleFunc("PUT /users/{id}", h.handleUpdateUser)
 	mux.HandleFunc("DELETE /users/{id}", h.handleDeleteUser)
+	mux.HandleFunc("PUT /users/{id}/preferences", h.handleUpdatePreferences)
+	mux.HandleFunc("POST /users/{id}/restore", h.handleRestoreUser)
+	mux.HandleFunc("POST /users/{id}/email-change", h.handleRequestEmailChange)
+	mux.HandleFunc("POST /users/email-change/confirm", h.handleConfirmEmailChange)
+	mux.HandleFunc("POST /users/password-reset", h.handleRequestPasswordReset)
+	mux.HandleFunc("POST /users/password-reset/confirm", h.handleResetPassword)
 }
 
 // Request/Response DTOs
@@ -62,11 +99,62 @@ type createUserResponse struct {
 	ID string `json:"id"`
 }
 
+type batchGetRequest struct {
+	UserIDs []string `json:"user_ids"`
+}
+
 type updateUserRequest struct {
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
 }
 
+type emailChangeRequest struct {
+	Email string `json:"email"`
+}
+
+type confirmEmailChangeRequest struct {
+	Token string `json:"token"`
+}
+
+type passwordResetRequest struct {
+	Email string `json:"email"`
+}
+
+type resetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+type updatePreferencesRequest struct {
+	Locale        string   `json:"locale"`
+	MutedChannels []string `json:"muted_channels"`
+}
+
+// userResource is a user in a page, with its links.
+type userResource struct {
+	queries.UserDTO
+	Links page.Links `json:"_links"`
+}
+
+func newUserResource(user *queries.UserDTO) userResource {
+	return userResource{
+		UserDTO: *user,
+		Links:   page.Links{"self": {Href: "/users/" + user.ID}},
+	}
+}
+
+// userColumns are the columns of the CSV export of users.
+var userColumns = []export.Column[*queries.UserDTO]{
+	{Name: "id", Value: func(u *queries.UserDTO) string { return u.ID }},
+	{Name: "email", Value: func(u *queries.UserDTO) string { return u.Email }},
+	{Name: "first_name", Value: func(u *queries.UserDTO) string { return u.FirstName }},
+	{Name: "last_name", Value: func(u *queries.UserDTO) string { return u.LastName }},
+	{Name: "status", Value: func(u *queries.UserDTO) string { return u.Status }},
+	{Name: "locale", Value: func(u *queries.UserDTO) string { return u.Locale }},
+	{Name: "created_at", Value: func(u *queries.UserDTO) string { return u.CreatedAt.Format(time.RFC3339) }},
+	{Name: "updated_at", Value: func(u *queries.UserDTO) string { return u.UpdatedAt.Format(time.RFC3339) }},
+}
+
 type errorResponse struct {
 	Error string `json:"error"`
 }
@@ -109,9 +197,28 @@ func (h *Handler) handleGetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	etag.Set(w, user.UpdatedAt)
 	writeJSON(w, http.StatusOK, user)
 }
 
+// handleBatchGetUsers serves POST /users:batchGet, for clients that would
+// otherwise get users one request at a time.
+func (h *Handler) handleBatchGetUsers(w http.ResponseWriter, r *http.Request) {
+	var req batchGetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.batchGet.Handle(r.Context(), queries.BatchGetUsersQuery{UserIDs: req.UserIDs})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
 func (h *Handler) handleUpdateUser(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
@@ -119,6 +226,12 @@ func (h *Handler) handleUpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	expected, err := etag.IfMatch(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
 	var req updateUserRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "invalid request body")
@@ -126,9 +239,10 @@ func (h *Handler) handleUpdateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	cmd := commands.UpdateUserCommand{
-		UserID:    id,
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
+		UserID:            id,
+		FirstName:         req.FirstName,
+		LastName:          req.LastName,
+		ExpectedUpdatedAt: expected,
 	}
 
 	if err := h.updateUser.Handle(r.Context(), cmd); err != nil {
@@ -139,6 +253,34 @@ func (h *Handler) handleUpdateUser(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *Handler) handleUpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	expected, err := etag.IfMatch(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	var req updatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cmd := commands.UpdatePreferencesCommand{
+		UserID:            r.PathValue("id"),
+		Locale:            req.Locale,
+		MutedChannels:     req.MutedChannels,
+		ExpectedUpdatedAt: expected,
+	}
+
+	if err := h.updatePrefs.Handle(r.Context(), cmd); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *Handler) handleDeleteUser(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	if id == "" {
@@ -146,7 +288,13 @@ func (h *Handler) handleDeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	cmd := commands.DeleteUserCommand{UserID: id}
+	expected, err := etag.IfMatch(r)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	cmd := commands.DeleteUserCommand{UserID: id, ExpectedUpdatedAt: expected}
 	if err := h.deleteUser.Handle(r.Context(), cmd); err != nil {
 		handleError(w, err)
 		return
@@ -155,6 +303,85 @@ func (h *Handler) handleDeleteUser(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handleRestoreUser restores a deleted user within the grace period, or at
+// any time for admins.
+func (h *Handler) handleRestoreUser(w http.ResponseWriter, r *http.Request) {
+	cmd := commands.RestoreUserCommand{UserID: r.PathValue("id"), AnyTime: h.admin.IsAdmin(r)}
+	if err := h.restoreUser.Handle(r.Context(), cmd); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleRequestEmailChange mails the user a token that confirms the change
+// of their email; the email is unchanged until then.
+func (h *Handler) handleRequestEmailChange(w http.ResponseWriter, r *http.Request) {
+	var req emailChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cmd := commands.RequestEmailChangeCommand{UserID: r.PathValue("id"), Email: req.Email}
+	if err := h.requestEmailChange.Handle(r.Context(), cmd); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) handleConfirmEmailChange(w http.ResponseWriter, r *http.Request) {
+	var req confirmEmailChangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.confirmEmailChange.Handle(r.Context(), commands.ConfirmEmailChangeCommand{Token: req.Token}); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleRequestPasswordReset mails the user with the email a token that
+// confirms the reset of their password. It answers 202 whether or not there
+// is such a user.
+func (h *Handler) handleRequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req passwordResetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.requestPassword.Handle(r.Context(), commands.RequestPasswordResetCommand{Email: req.Email}); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (h *Handler) handleResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req resetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	cmd := commands.ResetPasswordCommand{Token: req.Token, Password: req.Password}
+	if err := h.resetPassword.Handle(r.Context(), cmd); err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *Handler) handleSearchUsers(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query().Get("q")
 	if q == "" {
@@ -162,13 +389,12 @@ func (h *Handler) handleSearchUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	p := page.ParseRequest(r.URL.Query())
 
 	query := queries.SearchUsersQuery{
 		Query:  q,
-		Offset: offset,
-		Limit:  limit,
+		Offset: p.Offset,
+		Limit:  p.Limit,
 	}
 
 	result, err := h.searchUsers.Handle(r.Context(), query)
@@ -180,13 +406,39 @@ func (h *Handler) handleSearchUsers(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, result)
 }
 
+// handleListUsers serves GET /users as pages of users with links, or streams every user
+// from offset on (at most limit if given) as CSV or NDJSON when the Accept
+// header asks for it. Listing deleted users (deleted=include or only) is
+// admin-only.
 func (h *Handler) handleListUsers(w http.ResponseWriter, r *http.Request) {
-	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
-	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	p := page.ParseRequest(r.URL.Query())
+	deleted, err := domain.ParseDeletedFilter(r.URL.Query().Get("deleted"))
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	sort, err := page.ParseSort(r.URL.Query().Get("sort"), domain.UserSortFields...)
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+	if deleted != domain.ExcludeDeleted && !h.admin.RequireAdmin(r) {
+		writeError(w, http.StatusForbidden, "admin access required")
+		return
+	}
 
 	query := queries.ListUsersQuery{
-		Offset: offset,
-		Limit:  limit,
+		Offset:  p.Offset,
+		Limit:   p.Limit,
+		Deleted: deleted,
+		Sort:    domain.UserSort{Field: domain.UserSortField(sort.Field), Desc: sort.Desc},
+	}
+
+	if format := export.Negotiate(r); format != export.JSON {
+		if err := export.Write(w, format, "users", userColumns, h.listUsers.Export(r.Context(), query)); err != nil {
+			handleError(w, err)
+		}
+		return
 	}
 
 	result, err := h.listUsers.Handle(r.Context(), query)
@@ -195,7 +447,15 @@ func (h *Handler) handleListUsers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, result)
+	users := make([]userResource, len(result.Users))
+	for i, user := range result.Users {
+		users[i] = newUserResource(user)
+	}
+	writeJSON(w, http.StatusOK, page.New(r, users, page.Pagination{
+		Offset:     result.Offset,
+		Limit:      result.Limit,
+		TotalCount: result.TotalCount,
+	}))
 }
 
 // Helper functions
@@ -208,10 +468,32 @@ func handleError(w http.ResponseWriter, err error) {
 		writeError(w, http.StatusConflict, err.Error())
 	case errors.Is(err, domain.ErrUserDeleted):
 		writeError(w, http.StatusGone, err.Error())
-	case errors.Is(err, domain.ErrEmailInvalid),
+	case errors.Is(err, domain.ErrUserNotDeleted),
+		errors.Is(err, domain.ErrRestorePeriodExpired):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, domain.ErrUserModified),
+		errors.Is(err, etag.ErrMismatch):
+		writeError(w, http.StatusPreconditionFailed, err.Error())
+	case errors.Is(err, tokens.ErrInvalid),
+		errors.Is(err, tokens.ErrExpired),
+		errors.Is(err, tokens.ErrWrongPurpose):
+		writeError(w, http.StatusBadRequest, "invalid or expired token")
+	case errors.Is(err, tokens.ErrNotConfigured):
+		writeError(w, http.StatusNotImplemented, tokens.ErrNotConfigured.Error())
+	case errors.Is(err, etag.ErrRequired):
+		writeError(w, http.StatusPreconditionRequired, err.Error())
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrEmailInvalid),
 		errors.Is(err, domain.ErrEmailRequired),
 		errors.Is(err, domain.ErrFirstNameRequired),
-		errors.Is(err, domain.ErrLastNameRequired):
+		errors.Is(err, domain.ErrLastNameRequired),
+		errors.Is(err, domain.ErrPasswordTooShort),
+		errors.Is(err, domain.ErrPasswordTooLong),
+		errors.Is(err, domain.ErrLocaleInvalid),
+		errors.Is(err, domain.ErrNotificationChannelInvalid),
+		errors.Is(err, domain.ErrDeletedFilterInvalid),
+		errors.Is(err, page.ErrSortInvalid),
+		errors.Is(err, domain.ErrBatchLimitExceeded):
 		writeError(w, http.StatusBadRequest, err.Error())
 	default:
 		writeError(w, http.StatusInternalServerError, "internal server error")
@@ -0,0 +1,459 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/etag"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/handlertest"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	txmocks "github.com/rai/clean-modularmonolith-go/modules/shared/transaction/mocks"
+	"github.com/rai/clean-modularmonolith-go/modules/users/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/users/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/users/domain/mocks"
+	usershttp "github.com/rai/clean-modularmonolith-go/modules/users/infrastructure/http"
+	"go.uber.org/mock/gomock"
+)
+
+const (
+	userID     = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+	adminToken = "admin-secret"
+)
+
+// handlers holds the command handlers behind the routes; unset ones fail the test.
+type handlers struct {
+	createUser         command.HandlerFunc[commands.CreateUserCommand, string]
+	updateUser         command.VoidHandlerFunc[commands.UpdateUserCommand]
+	deleteUser         command.VoidHandlerFunc[commands.DeleteUserCommand]
+	restoreUser        command.VoidHandlerFunc[commands.RestoreUserCommand]
+	updatePrefs        command.VoidHandlerFunc[commands.UpdatePreferencesCommand]
+	requestEmailChange command.VoidHandlerFunc[commands.RequestEmailChangeCommand]
+	confirmEmailChange command.VoidHandlerFunc[commands.ConfirmEmailChangeCommand]
+	requestPassword    command.VoidHandlerFunc[commands.RequestPasswordResetCommand]
+	resetPassword      command.VoidHandlerFunc[commands.ResetPasswordCommand]
+	repo               domain.UserReader
+	txScope            transaction.Scope
+}
+
+func newMux(t *testing.T, h handlers) *http.ServeMux {
+	t.Helper()
+	unexpected := func(name string) error {
+		t.Errorf("unexpected %s command", name)
+		return errors.New("unexpected command")
+	}
+	if h.createUser == nil {
+		h.createUser = func(context.Context, commands.CreateUserCommand) (string, error) { return "", unexpected("CreateUser") }
+	}
+	if h.updateUser == nil {
+		h.updateUser = func(context.Context, commands.UpdateUserCommand) error { return unexpected("UpdateUser") }
+	}
+	if h.deleteUser == nil {
+		h.deleteUser = func(context.Context, commands.DeleteUserCommand) error { return unexpected("DeleteUser") }
+	}
+	if h.restoreUser == nil {
+		h.restoreUser = func(context.Context, commands.RestoreUserCommand) error { return unexpected("RestoreUser") }
+	}
+	if h.updatePrefs == nil {
+		h.updatePrefs = func(context.Context, commands.UpdatePreferencesCommand) error { return unexpected("UpdatePreferences") }
+	}
+	if h.requestEmailChange == nil {
+		h.requestEmailChange = func(context.Context, commands.RequestEmailChangeCommand) error {
+			return unexpected("RequestEmailChange")
+		}
+	}
+	if h.confirmEmailChange == nil {
+		h.confirmEmailChange = func(context.Context, commands.ConfirmEmailChangeCommand) error {
+			return unexpected("ConfirmEmailChange")
+		}
+	}
+	if h.requestPassword == nil {
+		h.requestPassword = func(context.Context, commands.RequestPasswordResetCommand) error {
+			return unexpected("RequestPasswordReset")
+		}
+	}
+	if h.resetPassword == nil {
+		h.resetPassword = func(context.Context, commands.ResetPasswordCommand) error {
+			return unexpected("ResetPassword")
+		}
+	}
+
+	mux := http.NewServeMux()
+	usershttp.RegisterRoutes(mux, h.createUser, h.updateUser, h.deleteUser, h.restoreUser, h.updatePrefs, h.requestEmailChange, h.confirmEmailChange, h.requestPassword, h.resetPassword,
+		queries.NewGetUserHandler(h.repo), queries.NewBatchGetUsersHandler(h.repo), queries.NewListUsersHandler(h.repo, h.txScope), nil,
+		security.AdminGuard{Module: "users", Token: adminToken})
+	return mux
+}
+
+func TestCreateUser(t *testing.T) {
+	var got commands.CreateUserCommand
+	mux := newMux(t, handlers{
+		createUser: func(ctx context.Context, cmd commands.CreateUserCommand) (string, error) {
+			got = cmd
+			return userID, nil
+		},
+	})
+
+	rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodPost, "/users",
+		map[string]string{"email": "alice@example.com", "first_name": "Alice", "last_name": "Liddell"}))
+
+	handlertest.AssertStatus(t, rec, http.StatusCreated)
+	handlertest.AssertJSON(t, rec, `{"id": "`+userID+`"}`)
+	want := commands.CreateUserCommand{Email: "alice@example.com", FirstName: "Alice", LastName: "Liddell"}
+	if got != want {
+		t.Errorf("command = %+v, want %+v", got, want)
+	}
+}
+
+func TestCreateUser_InvalidBody(t *testing.T) {
+	rec := handlertest.Serve(newMux(t, handlers{}), handlertest.NewRequest(t, http.MethodPost, "/users", "{"))
+
+	handlertest.AssertGolden(t, rec, "create_user_invalid_body")
+}
+
+func TestGetUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockUserReader(ctrl)
+	repo.EXPECT().FindByID(gomock.Any(), testUser(t).ID(), domain.IncludeDeleted).Return(testUser(t), nil)
+
+	rec := handlertest.Serve(newMux(t, handlers{repo: repo}), handlertest.NewRequest(t, http.MethodGet, "/users/"+userID, nil))
+
+	handlertest.AssertGolden(t, rec, "get_user")
+	if got, want := rec.Header().Get("ETag"), etag.Of(testUser(t).UpdatedAt()); got != want {
+		t.Errorf("ETag = %s, want %s", got, want)
+	}
+}
+
+func TestBatchGetUsers(t *testing.T) {
+	const missingID = "9b2d2c39-7c0b-4f3e-8f3a-3c1f4c4f2a10"
+	user := testUser(t)
+	missing, _ := domain.ParseUserID(missingID)
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockUserReader(ctrl)
+	repo.EXPECT().FindByIDs(gomock.Any(), []domain.UserID{user.ID(), missing}).Return([]*domain.User{user}, nil)
+
+	rec := handlertest.Serve(newMux(t, handlers{repo: repo}), handlertest.NewRequest(t, http.MethodPost, "/users:batchGet",
+		map[string][]string{"user_ids": {userID, missingID, userID}}))
+
+	handlertest.AssertGolden(t, rec, "batch_get_users")
+}
+
+func TestBatchGetUsers_TooMany(t *testing.T) {
+	ids := make([]string, queries.MaxBatchGetUserIDs+1)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("00000000-0000-4000-8000-%012d", i)
+	}
+
+	rec := handlertest.Serve(newMux(t, handlers{}), handlertest.NewRequest(t, http.MethodPost, "/users:batchGet", map[string][]string{"user_ids": ids}))
+
+	handlertest.AssertGolden(t, rec, "batch_get_users_too_many")
+}
+
+func TestListUsers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockUserReader(ctrl)
+	repo.EXPECT().FindAll(gomock.Any(), domain.ExcludeDeleted, domain.UserSort{}, 0, 1).Return([]*domain.User{testUser(t)}, 2, nil)
+	txScope := txmocks.NewMockScope(ctrl)
+	txScope.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
+	rec := handlertest.Serve(newMux(t, handlers{repo: repo, txScope: txScope}), handlertest.NewRequest(t, http.MethodGet, "/users?limit=1", nil))
+
+	handlertest.AssertGolden(t, rec, "list_users")
+}
+
+func TestListUsers_Deleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockUserReader(ctrl)
+	repo.EXPECT().FindAll(gomock.Any(), domain.OnlyDeleted, domain.UserSort{}, 0, 20).Return(nil, 0, nil)
+	txScope := txmocks.NewMockScope(ctrl)
+	txScope.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+	mux := newMux(t, handlers{repo: repo, txScope: txScope})
+
+	rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodGet, "/users?deleted=only", nil))
+	handlertest.AssertStatus(t, rec, http.StatusForbidden)
+
+	req := handlertest.NewRequest(t, http.MethodGet, "/users?deleted=only", nil)
+	req.Header.Set(security.AdminTokenHeader, adminToken)
+	rec = handlertest.Serve(mux, req)
+	handlertest.AssertStatus(t, rec, http.StatusOK)
+}
+
+func TestListUsers_Sort(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockUserReader(ctrl)
+	repo.EXPECT().FindAll(gomock.Any(), domain.ExcludeDeleted, domain.UserSort{Field: domain.UserSortEmail, Desc: true}, 0, 20).Return(nil, 0, nil)
+	txScope := txmocks.NewMockScope(ctrl)
+	txScope.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+	mux := newMux(t, handlers{repo: repo, txScope: txScope})
+
+	rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodGet, "/users?sort=-email", nil))
+	handlertest.AssertStatus(t, rec, http.StatusOK)
+
+	rec = handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodGet, "/users?sort=password", nil))
+	handlertest.AssertStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestRestoreUser(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		want  commands.RestoreUserCommand
+	}{
+		{"user", "", commands.RestoreUserCommand{UserID: userID}},
+		{"admin", adminToken, commands.RestoreUserCommand{UserID: userID, AnyTime: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got commands.RestoreUserCommand
+			mux := newMux(t, handlers{
+				restoreUser: func(ctx context.Context, cmd commands.RestoreUserCommand) error {
+					got = cmd
+					return nil
+				},
+			})
+
+			req := handlertest.NewRequest(t, http.MethodPost, "/users/"+userID+"/restore", nil)
+			if tt.token != "" {
+				req.Header.Set(security.AdminTokenHeader, tt.token)
+			}
+			rec := handlertest.Serve(mux, req)
+
+			handlertest.AssertStatus(t, rec, http.StatusNoContent)
+			if got != tt.want {
+				t.Errorf("command = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListUsers_Export(t *testing.T) {
+	tests := []struct {
+		accept string
+		golden string
+	}{
+		{"text/csv", "list_users_csv"},
+		{"application/x-ndjson", "list_users_ndjson"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := domainmocks.NewMockUserReader(ctrl)
+			repo.EXPECT().FindAll(gomock.Any(), domain.ExcludeDeleted, domain.UserSort{}, 0, 100).Return([]*domain.User{testUser(t)}, 1, nil)
+			txScope := txmocks.NewMockScope(ctrl)
+			txScope.EXPECT().Execute(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, fn func(context.Context) error) error { return fn(ctx) })
+
+			req := handlertest.NewRequest(t, http.MethodGet, "/users", nil)
+			req.Header.Set("Accept", tt.accept)
+			rec := handlertest.Serve(newMux(t, handlers{repo: repo, txScope: txScope}), req)
+
+			handlertest.AssertGolden(t, rec, tt.golden)
+		})
+	}
+}
+
+// TestErrorMapping checks the status and message of every error the
+// handlers map, through a command that returns it wrapped.
+func TestErrorMapping(t *testing.T) {
+	tests := []struct {
+		golden string
+		err    error
+	}{
+		{"error_user_not_found", domain.ErrUserNotFound},
+		{"error_email_exists", domain.ErrEmailExists},
+		{"error_user_deleted", domain.ErrUserDeleted},
+		{"error_user_modified", domain.ErrUserModified},
+		{"error_invalid_user_id", domain.ErrInvalidUserID},
+		{"error_email_invalid", domain.ErrEmailInvalid},
+		{"error_locale_invalid", domain.ErrLocaleInvalid},
+		{"error_internal", errors.New("spanner: session expired")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			mux := newMux(t, handlers{
+				updatePrefs: func(context.Context, commands.UpdatePreferencesCommand) error {
+					return fmt.Errorf("updating preferences: %w", tt.err)
+				},
+			})
+
+			req := handlertest.NewRequest(t, http.MethodPut, "/users/"+userID+"/preferences",
+				map[string]any{"locale": "en", "muted_channels": []string{}})
+			req.Header.Set("If-Match", "*")
+			rec := handlertest.Serve(mux, req)
+
+			handlertest.AssertGolden(t, rec, tt.golden)
+		})
+	}
+}
+
+func TestDeleteUser(t *testing.T) {
+	var got commands.DeleteUserCommand
+	mux := newMux(t, handlers{
+		deleteUser: func(ctx context.Context, cmd commands.DeleteUserCommand) error {
+			got = cmd
+			return nil
+		},
+	})
+
+	req := handlertest.NewRequest(t, http.MethodDelete, "/users/"+userID, nil)
+	req.Header.Set("If-Match", etag.Of(testUser(t).UpdatedAt()))
+	rec := handlertest.Serve(mux, req)
+
+	handlertest.AssertStatus(t, rec, http.StatusNoContent)
+	if got.UserID != userID || !got.ExpectedUpdatedAt.Equal(testUser(t).UpdatedAt()) {
+		t.Errorf("command = %+v, want user %s at its last update", got, userID)
+	}
+}
+
+func TestDeleteUser_RequiresIfMatch(t *testing.T) {
+	rec := handlertest.Serve(newMux(t, handlers{}), handlertest.NewRequest(t, http.MethodDelete, "/users/"+userID, nil))
+
+	handlertest.AssertGolden(t, rec, "delete_user_without_if_match")
+}
+
+func TestUpdateUser_ForeignETag(t *testing.T) {
+	req := handlertest.NewRequest(t, http.MethodPut, "/users/"+userID, map[string]string{"first_name": "Alice", "last_name": "Liddell"})
+	req.Header.Set("If-Match", `W/"abc"`)
+	rec := handlertest.Serve(newMux(t, handlers{}), req)
+
+	handlertest.AssertGolden(t, rec, "update_user_foreign_etag")
+}
+
+func testUser(t *testing.T) *domain.User {
+	t.Helper()
+
+	id, err := domain.ParseUserID(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	email, err := domain.NewEmail("alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := domain.NewName("Alice", "Liddell")
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	return domain.Reconstitute(id, email, name, domain.StatusActive, domain.DefaultPreferences(), domain.PasswordHash{}, created, created.Add(time.Hour))
+}
+
+func TestRequestEmailChange(t *testing.T) {
+	var got commands.RequestEmailChangeCommand
+	mux := newMux(t, handlers{
+		requestEmailChange: func(ctx context.Context, cmd commands.RequestEmailChangeCommand) error {
+			got = cmd
+			return nil
+		},
+	})
+
+	rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodPost, "/users/"+userID+"/email-change", map[string]string{"email": "new@example.com"}))
+
+	handlertest.AssertStatus(t, rec, http.StatusAccepted)
+	if want := (commands.RequestEmailChangeCommand{UserID: userID, Email: "new@example.com"}); got != want {
+		t.Errorf("command = %+v, want %+v", got, want)
+	}
+}
+
+func TestRequestEmailChange_NotConfigured(t *testing.T) {
+	mux := newMux(t, handlers{
+		requestEmailChange: func(ctx context.Context, cmd commands.RequestEmailChangeCommand) error {
+			return fmt.Errorf("issuing email change token: %w", tokens.ErrNotConfigured)
+		},
+	})
+
+	rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodPost, "/users/"+userID+"/email-change", map[string]string{"email": "new@example.com"}))
+
+	handlertest.AssertGolden(t, rec, "email_change_not_configured")
+}
+
+func TestConfirmEmailChange(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"confirmed", nil, http.StatusNoContent},
+		{"expired", fmt.Errorf("verifying email change token: %w", tokens.ErrExpired), http.StatusBadRequest},
+		{"used", fmt.Errorf("email change token already used: %w", tokens.ErrInvalid), http.StatusBadRequest},
+		{"email taken", domain.ErrEmailExists, http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got commands.ConfirmEmailChangeCommand
+			mux := newMux(t, handlers{
+				confirmEmailChange: func(ctx context.Context, cmd commands.ConfirmEmailChangeCommand) error {
+					got = cmd
+					return tt.err
+				},
+			})
+
+			rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodPost, "/users/email-change/confirm", map[string]string{"token": "a.b.c"}))
+
+			handlertest.AssertStatus(t, rec, tt.status)
+			if got.Token != "a.b.c" {
+				t.Errorf("Token = %q, want a.b.c", got.Token)
+			}
+		})
+	}
+}
+
+func TestRequestPasswordReset(t *testing.T) {
+	var got commands.RequestPasswordResetCommand
+	mux := newMux(t, handlers{
+		requestPassword: func(ctx context.Context, cmd commands.RequestPasswordResetCommand) error {
+			got = cmd
+			return nil
+		},
+	})
+
+	rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodPost, "/users/password-reset", map[string]string{"email": "alice@example.com"}))
+
+	handlertest.AssertStatus(t, rec, http.StatusAccepted)
+	if want := (commands.RequestPasswordResetCommand{Email: "alice@example.com"}); got != want {
+		t.Errorf("command = %+v, want %+v", got, want)
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+	}{
+		{"reset", nil, http.StatusNoContent},
+		{"expired", fmt.Errorf("verifying password reset token: %w", tokens.ErrExpired), http.StatusBadRequest},
+		{"used", fmt.Errorf("password reset token already used: %w", tokens.ErrInvalid), http.StatusBadRequest},
+		{"password too short", fmt.Errorf("invalid password: %w", domain.ErrPasswordTooShort), http.StatusBadRequest},
+		{"not configured", fmt.Errorf("verifying password reset token: %w", tokens.ErrNotConfigured), http.StatusNotImplemented},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got commands.ResetPasswordCommand
+			mux := newMux(t, handlers{
+				resetPassword: func(ctx context.Context, cmd commands.ResetPasswordCommand) error {
+					got = cmd
+					return tt.err
+				},
+			})
+
+			rec := handlertest.Serve(mux, handlertest.NewRequest(t, http.MethodPost, "/users/password-reset/confirm",
+				map[string]string{"token": "a.b.c", "password": "correct horse battery staple"}))
+
+			handlertest.AssertStatus(t, rec, tt.status)
+			if want := (commands.ResetPasswordCommand{Token: "a.b.c", Password: "correct horse battery staple"}); got != want {
+				t.Errorf("command = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
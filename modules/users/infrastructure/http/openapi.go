@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/export"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/users/application/queries"
+)
+
+var pageParams = []openapi.Param{
+	{Name: "offset", Type: "integer"},
+	{Name: "limit", Type: "integer"},
+}
+
+var deletedParam = openapi.Param{Name: "deleted", Description: "Deleted users: exclude (default), include or only"}
+
+var sortParam = openapi.Param{Name: "sort", Description: "created_at or email, prefixed with - for descending order; newest first by default"}
+
+// Operations documents the routes registered by RegisterRoutes.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Pattern: "GET /users", Summary: "List users", Description: export.Description + " Listing deleted users requires the admin token.", Query: append([]openapi.Param{deletedParam, sortParam}, pageParams...), Response: page.Page[userResource]{}},
+		{Pattern: "POST /users", Summary: "Register a user", Request: createUserRequest{}, Status: http.StatusCreated, Response: createUserResponse{}},
+		{Pattern: "POST /users:batchGet", Summary: "Get several users", Description: "Takes up to 100 IDs; the response lists the IDs without a user under missing.", Request: batchGetRequest{}, Response: queries.BatchGetUsersDTO{}},
+		{Pattern: "GET /users/search", Summary: "Search users by name or email", Query: append([]openapi.Param{{Name: "q", Required: true}}, pageParams...), Response: queries.UserSearchResponseDTO{}},
+		{Pattern: "GET /users/{id}", Summary: "Get a user", Response: queries.UserDTO{}},
+		{Pattern: "PUT /users/{id}", Summary: "Update a user's name", Header: []openapi.Param{openapi.IfMatch}, Request: updateUserRequest{}, Status: http.StatusNoContent},
+		{Pattern: "DELETE /users/{id}", Summary: "Delete a user", Header: []openapi.Param{openapi.IfMatch}, Status: http.StatusNoContent},
+		{Pattern: "POST /users/{id}/restore", Summary: "Restore a deleted user", Description: "Within the restore grace period of the deletion (409 after it), or at any time with the admin token.", Status: http.StatusNoContent},
+		{Pattern: "POST /users/{id}/email-change", Summary: "Request a change of a user's email", Description: "Mails the user a token that confirms the change; the email is unchanged until then. 501 when no token secret is configured.", Request: emailChangeRequest{}, Status: http.StatusAccepted},
+		{Pattern: "POST /users/email-change/confirm", Summary: "Confirm a change of email", Description: "Takes the token mailed by POST /users/{id}/email-change; it is refused once expired or used.", Request: confirmEmailChangeRequest{}, Status: http.StatusNoContent},
+		{Pattern: "POST /users/password-reset", Summary: "Request a reset of a user's password", Description: "Mails the user with the email a token that confirms the reset. 202 whether or not there is such a user; 501 when no token secret is configured.", Request: passwordResetRequest{}, Status: http.StatusAccepted},
+		{Pattern: "POST /users/password-reset/confirm", Summary: "Reset a user's password", Description: "Takes the token mailed by POST /users/password-reset and the new password, 12 to 128 characters; the token is refused once expired or used.", Request: resetPasswordRequest{}, Status: http.StatusNoContent},
+		{Pattern: "PUT /users/{id}/preferences", Summary: "Update a user's notification preferences", Header: []openapi.Param{openapi.IfMatch}, Request: updatePreferencesRequest{}, Status: http.StatusNoContent},
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/spanner"
@@ -12,10 +13,14 @@ import (
 	"google.golang.org/grpc/codes"
 
 	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
 	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
 )
 
-// SpannerRepository implements UserRepository using Cloud Spanner.
+// SpannerRepository implements UserRepository using Cloud Spanner. Every
+// read and write is scoped to the tenant of the request of its context
+// (requestcontext.TenantID): the users of other tenants are not found, and
+// emails are unique per tenant.
 type SpannerRepository struct {
 	client *spanner.Client
 	logger *slog.Logger
@@ -29,18 +34,28 @@ func NewSpannerRepository(client *spanner.Client, logger *slog.Logger) *SpannerR
 // Compile-time interface check.
 var _ domain.UserRepository = (*SpannerRepository)(nil)
 
+// Save persists a user. Users that fail domain.User.Validate are rejected
+// before anything is written.
 func (r *SpannerRepository) Save(ctx context.Context, user *domain.User) error {
+	if err := user.Validate(); err != nil {
+		return fmt.Errorf("refusing to save user: %w", err)
+	}
 	stmt := spanner.Statement{
-		SQL: `INSERT OR UPDATE INTO Users (UserID, Email, FirstName, LastName, Status, CreatedAt, UpdatedAt)
-		      VALUES (@userID, @email, @firstName, @lastName, @status, @createdAt, @updatedAt)`,
+		SQL: `INSERT OR UPDATE INTO Users (UserID, TenantID, Email, CanonicalEmail, FirstName, LastName, Status, Locale, MutedChannels, PasswordHash, CreatedAt, UpdatedAt)
+		      VALUES (@userID, @tenantID, @email, @canonicalEmail, @firstName, @lastName, @status, @locale, @mutedChannels, @passwordHash, @createdAt, @updatedAt)`,
 		Params: map[string]interface{}{
-			"userID":    user.ID().String(),
-			"email":     user.Email().String(),
-			"firstName": user.Name().FirstName(),
-			"lastName":  user.Name().LastName(),
-			"status":    user.Status().String(),
-			"createdAt": user.CreatedAt(),
-			"updatedAt": user.UpdatedAt(),
+			"userID":         user.ID().String(),
+			"tenantID":       requestcontext.TenantID(ctx),
+			"email":          user.Email().String(),
+			"canonicalEmail": user.Email().Canonical(),
+			"firstName":      user.Name().FirstName(),
+			"lastName":       user.Name().LastName(),
+			"status":         user.Status().String(),
+			"locale":         user.Preferences().Locale(),
+			"mutedChannels":  user.Preferences().MutedChannels(),
+			"passwordHash":   passwordHash(user.Password()),
+			"createdAt":      user.CreatedAt(),
+			"updatedAt":      user.UpdatedAt(),
 		},
 	}
 
@@ -50,31 +65,74 @@ func (r *SpannerRepository) Save(ctx context.Context, user *domain.User) error {
 	return nil
 }
 
-func (r *SpannerRepository) FindByID(ctx context.Context, id domain.UserID) (*domain.User, error) {
+func (r *SpannerRepository) FindByID(ctx context.Context, id domain.UserID, deleted domain.DeletedFilter) (*domain.User, error) {
 	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, rtx platformspanner.ReadTransaction) (*domain.User, error) {
-		row, err := rtx.ReadRow(ctx, "Users",
-			spanner.Key{id.String()},
-			[]string{"UserID", "Email", "FirstName", "LastName", "Status", "CreatedAt", "UpdatedAt"},
-		)
+		iter := rtx.Query(ctx, spanner.Statement{
+			SQL:    `SELECT ` + strings.Join(userColumns, ", ") + ` FROM Users WHERE UserID = @userID AND TenantID = @tenantID`,
+			Params: map[string]interface{}{"userID": id.String(), "tenantID": requestcontext.TenantID(ctx)},
+		})
+		defer iter.Stop()
+
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return nil, domain.ErrUserNotFound
+		}
 		if err != nil {
-			if spanner.ErrCode(err) == codes.NotFound {
-				return nil, domain.ErrUserNotFound
-			}
 			return nil, fmt.Errorf("failed to read user: %w", err)
 		}
 
-		return r.scanUser(row)
+		user, err := r.scanUser(row)
+		if err != nil {
+			return nil, err
+		}
+		if !deleted.Matches(user.Status()) {
+			return nil, domain.ErrUserNotFound
+		}
+		return user, nil
+	})
+}
+
+func (r *SpannerRepository) FindByIDs(ctx context.Context, ids []domain.UserID) ([]*domain.User, error) {
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = id.String()
+	}
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, rtx platformspanner.ReadTransaction) ([]*domain.User, error) {
+		iter := rtx.Query(ctx, spanner.Statement{
+			SQL:    `SELECT ` + strings.Join(userColumns, ", ") + ` FROM Users WHERE UserID IN UNNEST(@userIDs) AND TenantID = @tenantID`,
+			Params: map[string]interface{}{"userIDs": keys, "tenantID": requestcontext.TenantID(ctx)},
+		})
+		defer iter.Stop()
+
+		var users []*domain.User
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read users: %w", err)
+			}
+
+			user, err := r.scanUser(row)
+			if err != nil {
+				return nil, err
+			}
+			users = append(users, user)
+		}
+
+		return users, nil
 	})
 }
 
 func (r *SpannerRepository) FindByEmail(ctx context.Context, email domain.Email) (*domain.User, error) {
 	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, rtx platformspanner.ReadTransaction) (*domain.User, error) {
 		stmt := spanner.Statement{
-			SQL: `SELECT UserID, Email, FirstName, LastName, Status, CreatedAt, UpdatedAt
+			SQL: `SELECT ` + strings.Join(userColumns, ", ") + `
 			      FROM Users@{FORCE_INDEX=UsersByEmail}
-			      WHERE Email = @email
+			      WHERE TenantID = @tenantID AND Email = @email
 			      LIMIT 1`,
-			Params: map[string]interface{}{"email": email.String()},
+			Params: map[string]interface{}{"tenantID": requestcontext.TenantID(ctx), "email": email.String()},
 		}
 
 		iter := rtx.Query(ctx, stmt)
@@ -95,8 +153,8 @@ func (r *SpannerRepository) FindByEmail(ctx context.Context, email domain.Email)
 func (r *SpannerRepository) Exists(ctx context.Context, email domain.Email) (bool, error) {
 	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, rtx platformspanner.ReadTransaction) (bool, error) {
 		stmt := spanner.Statement{
-			SQL:    `SELECT 1 FROM Users@{FORCE_INDEX=UsersByEmail} WHERE Email = @email LIMIT 1`,
-			Params: map[string]interface{}{"email": email.String()},
+			SQL:    `SELECT 1 FROM Users@{FORCE_INDEX=UsersByCanonicalEmail} WHERE TenantID = @tenantID AND CanonicalEmail = @canonicalEmail LIMIT 1`,
+			Params: map[string]interface{}{"tenantID": requestcontext.TenantID(ctx), "canonicalEmail": email.Canonical()},
 		}
 
 		iter := rtx.Query(ctx, stmt)
@@ -113,12 +171,15 @@ func (r *SpannerRepository) Exists(ctx context.Context, email domain.Email) (boo
 	})
 }
 
-func (r *SpannerRepository) FindAll(ctx context.Context, offset, limit int) ([]*domain.User, int, error) {
+func (r *SpannerRepository) FindAll(ctx context.Context, deleted domain.DeletedFilter, sort domain.UserSort, offset, limit int) ([]*domain.User, int, error) {
+	where := "WHERE TenantID = @tenantID" + deletedCondition(deleted)
+	tenantID := requestcontext.TenantID(ctx)
 	var total int
 	users, err := platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, rtx platformspanner.ReadTransaction) ([]*domain.User, error) {
 		// Get total count
 		countStmt := spanner.Statement{
-			SQL: `SELECT COUNT(*) FROM Users WHERE Status != 'deleted'`,
+			SQL:    `SELECT COUNT(*) FROM Users ` + where,
+			Params: map[string]interface{}{"tenantID": tenantID},
 		}
 		countIter := rtx.Query(ctx, countStmt)
 		defer countIter.Stop()
@@ -137,14 +198,14 @@ func (r *SpannerRepository) FindAll(ctx context.Context, offset, limit int) ([]*
 
 		// Query with pagination
 		stmt := spanner.Statement{
-			SQL: `SELECT UserID, Email, FirstName, LastName, Status, CreatedAt, UpdatedAt
-			      FROM Users
-			      WHERE Status != 'deleted'
-			      ORDER BY CreatedAt DESC
+			SQL: `SELECT ` + strings.Join(userColumns, ", ") + `
+			      FROM Users ` + where + `
+			      ORDER BY ` + userOrder(sort) + `
 			      LIMIT @limit OFFSET @offset`,
 			Params: map[string]interface{}{
-				"limit":  int64(limit),
-				"offset": int64(offset),
+				"tenantID": tenantID,
+				"limit":    int64(limit),
+				"offset":   int64(offset),
 			},
 		}
 
@@ -176,28 +237,167 @@ func (r *SpannerRepository) FindAll(ctx context.Context, offset, limit int) ([]*
 	return users, total, nil
 }
 
-func (r *SpannerRepository) scanUser(row *spanner.Row) (*domain.User, error) {
-	var userID, emailStr, firstName, lastName, status string
-	var createdAt, updatedAt time.Time
+func (r *SpannerRepository) RepairStatus(ctx context.Context, id domain.UserID, status domain.Status, at time.Time) error {
+	current, err := platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, rtx platformspanner.ReadTransaction) (string, error) {
+		iter := rtx.Query(ctx, spanner.Statement{
+			SQL:    `SELECT Status FROM Users WHERE UserID = @userID AND TenantID = @tenantID`,
+			Params: map[string]interface{}{"userID": id.String(), "tenantID": requestcontext.TenantID(ctx)},
+		})
+		defer iter.Stop()
 
-	if err := row.Columns(&userID, &emailStr, &firstName, &lastName, &status, &createdAt, &updatedAt); err != nil {
-		return nil, fmt.Errorf("failed to scan user: %w", err)
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return "", domain.ErrUserNotFound
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read user: %w", err)
+		}
+		var status string
+		if err := row.Columns(&status); err != nil {
+			return "", fmt.Errorf("failed to scan user status: %w", err)
+		}
+		return status, nil
+	})
+	if err != nil {
+		return err
+	}
+	if domain.Status(current).IsValid() {
+		return domain.ErrUserStatusValid
 	}
 
-	id, err := domain.ParseUserID(userID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse user id: %w", err)
+	stmt := spanner.Statement{
+		SQL: `UPDATE Users SET Status = @status, UpdatedAt = @updatedAt WHERE UserID = @userID AND TenantID = @tenantID`,
+		Params: map[string]interface{}{
+			"userID":    id.String(),
+			"tenantID":  requestcontext.TenantID(ctx),
+			"status":    status.String(),
+			"updatedAt": at,
+		},
+	}
+	if err := platformspanner.Write(ctx, stmt); err != nil {
+		return fmt.Errorf("failed to repair user status: %w", err)
+	}
+	return nil
+}
+
+// userColumns are the columns scanUser reads and, with TenantID, Import
+// inserts, in the order of userValues.
+var userColumns = []string{"UserID", "Email", "CanonicalEmail", "FirstName", "LastName", "Status", "Locale", "MutedChannels", "PasswordHash", "CreatedAt", "UpdatedAt"}
+
+func userValues(user *domain.User) []interface{} {
+	return []interface{}{
+		user.ID().String(),
+		user.Email().String(),
+		user.Email().Canonical(),
+		user.Name().FirstName(),
+		user.Name().LastName(),
+		user.Status().String(),
+		user.Preferences().Locale(),
+		user.Preferences().MutedChannels(),
+		passwordHash(user.Password()),
+		user.CreatedAt(),
+		user.UpdatedAt(),
 	}
+}
 
-	email, err := domain.NewEmail(emailStr)
+// passwordHash is the PasswordHash column of password, NULL for no password.
+func passwordHash(password domain.PasswordHash) spanner.NullString {
+	return spanner.NullString{StringVal: password.Encoded(), Valid: !password.IsZero()}
+}
+
+// Import inserts each user in a mutation group of its own with
+// platformspanner.BatchWrite. A taken email violates the
+// UsersByCanonicalEmail index, which Spanner reports as AlreadyExists;
+// so does a group it applied twice, which BatchWrite does not rule out.
+// Users that fail domain.User.Validate are rejected without being written.
+func (r *SpannerRepository) Import(ctx context.Context, users []*domain.User) ([]error, error) {
+	results := make([]error, len(users))
+	tenantID := requestcontext.TenantID(ctx)
+	var groups [][]*spanner.Mutation
+	var indexes []int // of the user each group inserts
+	for i, user := range users {
+		if err := user.Validate(); err != nil {
+			results[i] = fmt.Errorf("refusing to import user: %w", err)
+			continue
+		}
+		groups = append(groups, []*spanner.Mutation{spanner.Insert("Users", append(userColumns, "TenantID"), append(userValues(user), tenantID))})
+		indexes = append(indexes, i)
+	}
+
+	groupErrs, err := platformspanner.BatchWrite(ctx, r.client, groups)
+	for g, groupErr := range groupErrs {
+		switch {
+		case groupErr == nil:
+		case spanner.ErrCode(groupErr) == codes.AlreadyExists:
+			results[indexes[g]] = domain.ErrEmailExists
+		default:
+			results[indexes[g]] = fmt.Errorf("failed to import user: %w", groupErr)
+		}
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse email: %w", err)
+		if groupErrs == nil { // refused before writing anything
+			for _, i := range indexes {
+				results[i] = err
+			}
+		}
+		return results, fmt.Errorf("failed to import users: %w", err)
+	}
+	return results, nil
+}
+
+// userOrder is the ORDER BY clause of sort.
+func userOrder(sort domain.UserSort) string {
+	dir := " ASC"
+	if sort.Desc {
+		dir = " DESC"
+	}
+	switch sort.Field {
+	case domain.UserSortEmail:
+		return "Email" + dir
+	case domain.UserSortCreatedAt:
+		return "CreatedAt" + dir
+	}
+	return "CreatedAt DESC"
+}
+
+// deletedCondition is the condition, ANDed to the WHERE clause, of the users
+// deleted selects.
+func deletedCondition(deleted domain.DeletedFilter) string {
+	switch deleted {
+	case domain.IncludeDeleted:
+		return ""
+	case domain.OnlyDeleted:
+		return " AND Status = 'deleted'"
+	}
+	return " AND Status != 'deleted'"
+}
+
+func (r *SpannerRepository) scanUser(row *spanner.Row) (*domain.User, error) {
+	var id domain.UserID
+	var emailStr, canonicalEmail, firstName, lastName, status, locale string
+	var mutedChannels []string
+	var password spanner.NullString
+	var createdAt, updatedAt time.Time
+
+	if err := row.Columns(&id, &emailStr, &canonicalEmail, &firstName, &lastName, &status, &locale, &mutedChannels, &password, &createdAt, &updatedAt); err != nil {
+		return nil, fmt.Errorf("failed to scan user: %w", err)
 	}
 
+	email := domain.ReconstituteEmail(emailStr, canonicalEmail)
+
 	name, err := domain.NewName(firstName, lastName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse name: %w", err)
 	}
 
-	return domain.Reconstitute(id, email, name, domain.Status(status), createdAt, updatedAt), nil
+	parsedStatus, err := domain.ParseStatus(status)
+	if err != nil {
+		return nil, &platformspanner.CorruptRowError{Table: "Users", Key: spanner.Key{id.String()}, Column: "Status", Err: err}
+	}
+
+	user := domain.Reconstitute(id, email, name, parsedStatus, domain.ReconstitutePreferences(locale, mutedChannels), domain.ReconstitutePasswordHash(password.StringVal), createdAt, updatedAt)
+	if err := user.Validate(); err != nil {
+		return nil, &platformspanner.CorruptRowError{Table: "Users", Key: spanner.Key{id.String()}, Err: err}
+	}
+	return user, nil
 }
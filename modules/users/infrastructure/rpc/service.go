@@ -0,0 +1,168 @@
+// Package rpc serves the users module over connect-go: gRPC, gRPC-Web and
+// Connect clients call the UsersService of proto/users/v1/users.proto, which
+// runs the same commands and queries as the HTTP handlers. The usersv1
+// packages are generated by `make proto`; do not edit them.
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/users/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/users/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+	"github.com/rai/clean-modularmonolith-go/modules/users/infrastructure/rpc/usersv1"
+	"github.com/rai/clean-modularmonolith-go/modules/users/infrastructure/rpc/usersv1/usersv1connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// errExpectedUpdateTimeRequired is the RPC counterpart of a missing
+// If-Match: updates and deletes must say which version they apply to.
+var errExpectedUpdateTimeRequired = errors.New("expected_update_time is required")
+
+// Service implements usersv1connect.UsersServiceHandler.
+type Service struct {
+	createUser command.Handler[commands.CreateUserCommand, string]
+	updateUser command.VoidHandler[commands.UpdateUserCommand]
+	deleteUser command.VoidHandler[commands.DeleteUserCommand]
+	getUser    command.Handler[queries.GetUserQuery, *queries.UserDTO]
+	listUsers  *queries.ListUsersHandler
+}
+
+var _ usersv1connect.UsersServiceHandler = (*Service)(nil)
+
+// RegisterRoutes mounts the UsersService on mux, under
+// /users.v1.UsersService/ as the gRPC protocol requires.
+func RegisterRoutes(
+	mux *http.ServeMux,
+	createUser command.Handler[commands.CreateUserCommand, string],
+	updateUser command.VoidHandler[commands.UpdateUserCommand],
+	deleteUser command.VoidHandler[commands.DeleteUserCommand],
+	getUser command.Handler[queries.GetUserQuery, *queries.UserDTO],
+	listUsers *queries.ListUsersHandler,
+) {
+	s := &Service{
+		createUser: createUser,
+		updateUser: updateUser,
+		deleteUser: deleteUser,
+		getUser:    getUser,
+		listUsers:  listUsers,
+	}
+	mux.Handle(usersv1connect.NewUsersServiceHandler(s))
+}
+
+func (s *Service) CreateUser(ctx context.Context, req *connect.Request[usersv1.CreateUserRequest]) (*connect.Response[usersv1.CreateUserResponse], error) {
+	id, err := s.createUser.Handle(ctx, commands.CreateUserCommand{
+		Email:     req.Msg.GetEmail(),
+		FirstName: req.Msg.GetFirstName(),
+		LastName:  req.Msg.GetLastName(),
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(&usersv1.CreateUserResponse{Id: id}), nil
+}
+
+func (s *Service) GetUser(ctx context.Context, req *connect.Request[usersv1.GetUserRequest]) (*connect.Response[usersv1.GetUserResponse], error) {
+	user, err := s.getUser.Handle(ctx, queries.GetUserQuery{UserID: req.Msg.GetId()})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(&usersv1.GetUserResponse{User: toProto(user)}), nil
+}
+
+func (s *Service) ListUsers(ctx context.Context, req *connect.Request[usersv1.ListUsersRequest]) (*connect.Response[usersv1.ListUsersResponse], error) {
+	result, err := s.listUsers.Handle(ctx, queries.ListUsersQuery{
+		Offset: int(req.Msg.GetOffset()),
+		Limit:  int(req.Msg.GetLimit()),
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+
+	resp := &usersv1.ListUsersResponse{
+		Users:      make([]*usersv1.User, 0, len(result.Users)),
+		TotalCount: int32(result.TotalCount),
+		Offset:     int32(result.Offset),
+		Limit:      int32(result.Limit),
+	}
+	for _, user := range result.Users {
+		resp.Users = append(resp.Users, toProto(user))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func (s *Service) UpdateUser(ctx context.Context, req *connect.Request[usersv1.UpdateUserRequest]) (*connect.Response[usersv1.UpdateUserResponse], error) {
+	if req.Msg.GetExpectedUpdateTime() == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errExpectedUpdateTimeRequired)
+	}
+
+	err := s.updateUser.Handle(ctx, commands.UpdateUserCommand{
+		UserID:            req.Msg.GetId(),
+		FirstName:         req.Msg.GetFirstName(),
+		LastName:          req.Msg.GetLastName(),
+		ExpectedUpdatedAt: req.Msg.GetExpectedUpdateTime().AsTime(),
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(&usersv1.UpdateUserResponse{}), nil
+}
+
+func (s *Service) DeleteUser(ctx context.Context, req *connect.Request[usersv1.DeleteUserRequest]) (*connect.Response[usersv1.DeleteUserResponse], error) {
+	if req.Msg.GetExpectedUpdateTime() == nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errExpectedUpdateTimeRequired)
+	}
+
+	err := s.deleteUser.Handle(ctx, commands.DeleteUserCommand{
+		UserID:            req.Msg.GetId(),
+		ExpectedUpdatedAt: req.Msg.GetExpectedUpdateTime().AsTime(),
+	})
+	if err != nil {
+		return nil, toConnectError(err)
+	}
+	return connect.NewResponse(&usersv1.DeleteUserResponse{}), nil
+}
+
+func toProto(user *queries.UserDTO) *usersv1.User {
+	return &usersv1.User{
+		Id:            user.ID,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		FullName:      user.FullName,
+		Status:        user.Status,
+		Locale:        user.Locale,
+		MutedChannels: user.MutedChannels,
+		CreateTime:    timestamppb.New(user.CreatedAt),
+		UpdateTime:    timestamppb.New(user.UpdatedAt),
+	}
+}
+
+// toConnectError maps errors to connect codes the way the HTTP handler maps
+// them to status codes; unexpected errors are not leaked to the client.
+func toConnectError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrUserNotFound):
+		return connect.NewError(connect.CodeNotFound, err)
+	case errors.Is(err, domain.ErrEmailExists):
+		return connect.NewError(connect.CodeAlreadyExists, err)
+	case errors.Is(err, domain.ErrUserDeleted),
+		errors.Is(err, domain.ErrUserNotDeleted),
+		errors.Is(err, domain.ErrRestorePeriodExpired):
+		return connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, domain.ErrUserModified):
+		return connect.NewError(connect.CodeAborted, err)
+	case errors.Is(err, domain.ErrInvalidUserID),
+		errors.Is(err, domain.ErrEmailInvalid),
+		errors.Is(err, domain.ErrEmailRequired),
+		errors.Is(err, domain.ErrFirstNameRequired),
+		errors.Is(err, domain.ErrLastNameRequired):
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	default:
+		return connect.NewError(connect.CodeInternal, errors.New("internal server error"))
+	}
+}
@@ -0,0 +1,187 @@
+package rpc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/users/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/users/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
+	domainmocks "github.com/rai/clean-modularmonolith-go/modules/users/domain/mocks"
+	"github.com/rai/clean-modularmonolith-go/modules/users/infrastructure/rpc"
+	"github.com/rai/clean-modularmonolith-go/modules/users/infrastructure/rpc/usersv1"
+	"github.com/rai/clean-modularmonolith-go/modules/users/infrastructure/rpc/usersv1/usersv1connect"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const userID = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+
+// handlers holds the command handlers behind the service; unset ones fail the test.
+type handlers struct {
+	createUser command.HandlerFunc[commands.CreateUserCommand, string]
+	updateUser command.VoidHandlerFunc[commands.UpdateUserCommand]
+	deleteUser command.VoidHandlerFunc[commands.DeleteUserCommand]
+	repo       domain.UserReader
+}
+
+// newClients serves the service over HTTP/2 and returns a client for each
+// protocol it speaks.
+func newClients(t *testing.T, h handlers) map[string]usersv1connect.UsersServiceClient {
+	t.Helper()
+	unexpected := func(name string) error {
+		t.Errorf("unexpected %s command", name)
+		return errors.New("unexpected command")
+	}
+	if h.createUser == nil {
+		h.createUser = func(context.Context, commands.CreateUserCommand) (string, error) { return "", unexpected("CreateUser") }
+	}
+	if h.updateUser == nil {
+		h.updateUser = func(context.Context, commands.UpdateUserCommand) error { return unexpected("UpdateUser") }
+	}
+	if h.deleteUser == nil {
+		h.deleteUser = func(context.Context, commands.DeleteUserCommand) error { return unexpected("DeleteUser") }
+	}
+
+	mux := http.NewServeMux()
+	rpc.RegisterRoutes(mux, h.createUser, h.updateUser, h.deleteUser, queries.NewGetUserHandler(h.repo), queries.NewListUsersHandler(h.repo, nil))
+	srv := httptest.NewUnstartedServer(mux)
+	srv.EnableHTTP2 = true
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	return map[string]usersv1connect.UsersServiceClient{
+		"connect": usersv1connect.NewUsersServiceClient(srv.Client(), srv.URL),
+		"grpc":    usersv1connect.NewUsersServiceClient(srv.Client(), srv.URL, connect.WithGRPC()),
+	}
+}
+
+func TestCreateUser(t *testing.T) {
+	for protocol, client := range newClients(t, handlers{
+		createUser: func(ctx context.Context, cmd commands.CreateUserCommand) (string, error) {
+			if cmd.Email != "alice@example.com" || cmd.FirstName != "Alice" || cmd.LastName != "Liddell" {
+				t.Errorf("command = %+v", cmd)
+			}
+			return userID, nil
+		},
+	}) {
+		t.Run(protocol, func(t *testing.T) {
+			resp, err := client.CreateUser(t.Context(), connect.NewRequest(&usersv1.CreateUserRequest{
+				Email: "alice@example.com", FirstName: "Alice", LastName: "Liddell",
+			}))
+			if err != nil {
+				t.Fatalf("CreateUser: %v", err)
+			}
+			if resp.Msg.GetId() != userID {
+				t.Errorf("id = %q, want %q", resp.Msg.GetId(), userID)
+			}
+		})
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := domainmocks.NewMockUserReader(ctrl)
+	user := testUser(t)
+	repo.EXPECT().FindByID(gomock.Any(), user.ID(), domain.IncludeDeleted).Return(user, nil).Times(2)
+
+	for protocol, client := range newClients(t, handlers{repo: repo}) {
+		t.Run(protocol, func(t *testing.T) {
+			resp, err := client.GetUser(t.Context(), connect.NewRequest(&usersv1.GetUserRequest{Id: userID}))
+			if err != nil {
+				t.Fatalf("GetUser: %v", err)
+			}
+			got := resp.Msg.GetUser()
+			if got.GetEmail() != "alice@example.com" || got.GetFullName() != "Alice Liddell" || !got.GetUpdateTime().AsTime().Equal(user.UpdatedAt()) {
+				t.Errorf("user = %v", got)
+			}
+		})
+	}
+}
+
+func TestUpdateUser_ExpectedUpdateTime(t *testing.T) {
+	updated := testUser(t).UpdatedAt()
+	for protocol, client := range newClients(t, handlers{
+		updateUser: func(ctx context.Context, cmd commands.UpdateUserCommand) error {
+			if !cmd.ExpectedUpdatedAt.Equal(updated) {
+				return domain.ErrUserModified
+			}
+			return nil
+		},
+	}) {
+		t.Run(protocol, func(t *testing.T) {
+			update := func(expected *timestamppb.Timestamp) error {
+				_, err := client.UpdateUser(t.Context(), connect.NewRequest(&usersv1.UpdateUserRequest{
+					Id: userID, FirstName: "Alice", LastName: "Liddell", ExpectedUpdateTime: expected,
+				}))
+				return err
+			}
+
+			if err := update(timestamppb.New(updated)); err != nil {
+				t.Errorf("UpdateUser: %v", err)
+			}
+			if err := update(timestamppb.New(updated.Add(-time.Minute))); connect.CodeOf(err) != connect.CodeAborted {
+				t.Errorf("stale UpdateUser = %v, want aborted", err)
+			}
+			if err := update(nil); connect.CodeOf(err) != connect.CodeFailedPrecondition {
+				t.Errorf("UpdateUser without expected_update_time = %v, want failed_precondition", err)
+			}
+		})
+	}
+}
+
+func TestErrorMapping(t *testing.T) {
+	tests := []struct {
+		err  error
+		code connect.Code
+	}{
+		{domain.ErrUserNotFound, connect.CodeNotFound},
+		{domain.ErrUserDeleted, connect.CodeFailedPrecondition},
+		{domain.ErrInvalidUserID, connect.CodeInvalidArgument},
+		{errors.New("spanner: session expired"), connect.CodeInternal},
+	}
+	for _, tt := range tests {
+		clients := newClients(t, handlers{
+			deleteUser: func(context.Context, commands.DeleteUserCommand) error { return tt.err },
+		})
+		for protocol, client := range clients {
+			t.Run(protocol+"/"+tt.code.String(), func(t *testing.T) {
+				_, err := client.DeleteUser(t.Context(), connect.NewRequest(&usersv1.DeleteUserRequest{
+					Id: userID, ExpectedUpdateTime: timestamppb.Now(),
+				}))
+				if connect.CodeOf(err) != tt.code {
+					t.Errorf("DeleteUser = %v, want %s", err, tt.code)
+				}
+				var connectErr *connect.Error
+				if tt.code == connect.CodeInternal && errors.As(err, &connectErr) && connectErr.Message() != "internal server error" {
+					t.Errorf("message = %q, want the internal error hidden", connectErr.Message())
+				}
+			})
+		}
+	}
+}
+
+func testUser(t *testing.T) *domain.User {
+	t.Helper()
+
+	id, err := domain.ParseUserID(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	email, err := domain.NewEmail("alice@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := domain.NewName("Alice", "Liddell")
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	return domain.Reconstitute(id, email, name, domain.StatusActive, domain.DefaultPreferences(), domain.PasswordHash{}, created, created.Add(time.Hour))
+}
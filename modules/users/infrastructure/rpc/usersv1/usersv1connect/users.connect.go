@@ -0,0 +1,241 @@
+// The users service: the RPC surface of the users module, served by
+// connect-go over gRPC, gRPC-Web and Connect (JSON over HTTP) from the same
+// command and query handlers as the REST routes. Regenerate the Go code with
+// `make proto` after changing this file.
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: users/v1/users.proto
+
+package usersv1connect
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	usersv1 "github.com/rai/clean-modularmonolith-go/modules/users/infrastructure/rpc/usersv1"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// UsersServiceName is the fully-qualified name of the UsersService service.
+	UsersServiceName = "users.v1.UsersService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// UsersServiceCreateUserProcedure is the fully-qualified name of the UsersService's CreateUser RPC.
+	UsersServiceCreateUserProcedure = "/users.v1.UsersService/CreateUser"
+	// UsersServiceGetUserProcedure is the fully-qualified name of the UsersService's GetUser RPC.
+	UsersServiceGetUserProcedure = "/users.v1.UsersService/GetUser"
+	// UsersServiceListUsersProcedure is the fully-qualified name of the UsersService's ListUsers RPC.
+	UsersServiceListUsersProcedure = "/users.v1.UsersService/ListUsers"
+	// UsersServiceUpdateUserProcedure is the fully-qualified name of the UsersService's UpdateUser RPC.
+	UsersServiceUpdateUserProcedure = "/users.v1.UsersService/UpdateUser"
+	// UsersServiceDeleteUserProcedure is the fully-qualified name of the UsersService's DeleteUser RPC.
+	UsersServiceDeleteUserProcedure = "/users.v1.UsersService/DeleteUser"
+)
+
+// UsersServiceClient is a client for the users.v1.UsersService service.
+type UsersServiceClient interface {
+	// CreateUser creates a user, as POST /users does.
+	CreateUser(context.Context, *connect.Request[usersv1.CreateUserRequest]) (*connect.Response[usersv1.CreateUserResponse], error)
+	// GetUser returns a user, deleted users included, as GET /users/{id} does.
+	GetUser(context.Context, *connect.Request[usersv1.GetUserRequest]) (*connect.Response[usersv1.GetUserResponse], error)
+	// ListUsers returns a page of the users that are not deleted, newest
+	// first, as GET /users does.
+	ListUsers(context.Context, *connect.Request[usersv1.ListUsersRequest]) (*connect.Response[usersv1.ListUsersResponse], error)
+	// UpdateUser changes the name of a user, as PUT /users/{id} does.
+	UpdateUser(context.Context, *connect.Request[usersv1.UpdateUserRequest]) (*connect.Response[usersv1.UpdateUserResponse], error)
+	// DeleteUser soft-deletes a user, as DELETE /users/{id} does.
+	DeleteUser(context.Context, *connect.Request[usersv1.DeleteUserRequest]) (*connect.Response[usersv1.DeleteUserResponse], error)
+}
+
+// NewUsersServiceClient constructs a client for the users.v1.UsersService service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewUsersServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) UsersServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	usersServiceMethods := usersv1.File_users_v1_users_proto.Services().ByName("UsersService").Methods()
+	return &usersServiceClient{
+		createUser: connect.NewClient[usersv1.CreateUserRequest, usersv1.CreateUserResponse](
+			httpClient,
+			baseURL+UsersServiceCreateUserProcedure,
+			connect.WithSchema(usersServiceMethods.ByName("CreateUser")),
+			connect.WithClientOptions(opts...),
+		),
+		getUser: connect.NewClient[usersv1.GetUserRequest, usersv1.GetUserResponse](
+			httpClient,
+			baseURL+UsersServiceGetUserProcedure,
+			connect.WithSchema(usersServiceMethods.ByName("GetUser")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		listUsers: connect.NewClient[usersv1.ListUsersRequest, usersv1.ListUsersResponse](
+			httpClient,
+			baseURL+UsersServiceListUsersProcedure,
+			connect.WithSchema(usersServiceMethods.ByName("ListUsers")),
+			connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+			connect.WithClientOptions(opts...),
+		),
+		updateUser: connect.NewClient[usersv1.UpdateUserRequest, usersv1.UpdateUserResponse](
+			httpClient,
+			baseURL+UsersServiceUpdateUserProcedure,
+			connect.WithSchema(usersServiceMethods.ByName("UpdateUser")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteUser: connect.NewClient[usersv1.DeleteUserRequest, usersv1.DeleteUserResponse](
+			httpClient,
+			baseURL+UsersServiceDeleteUserProcedure,
+			connect.WithSchema(usersServiceMethods.ByName("DeleteUser")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// usersServiceClient implements UsersServiceClient.
+type usersServiceClient struct {
+	createUser *connect.Client[usersv1.CreateUserRequest, usersv1.CreateUserResponse]
+	getUser    *connect.Client[usersv1.GetUserRequest, usersv1.GetUserResponse]
+	listUsers  *connect.Client[usersv1.ListUsersRequest, usersv1.ListUsersResponse]
+	updateUser *connect.Client[usersv1.UpdateUserRequest, usersv1.UpdateUserResponse]
+	deleteUser *connect.Client[usersv1.DeleteUserRequest, usersv1.DeleteUserResponse]
+}
+
+// CreateUser calls users.v1.UsersService.CreateUser.
+func (c *usersServiceClient) CreateUser(ctx context.Context, req *connect.Request[usersv1.CreateUserRequest]) (*connect.Response[usersv1.CreateUserResponse], error) {
+	return c.createUser.CallUnary(ctx, req)
+}
+
+// GetUser calls users.v1.UsersService.GetUser.
+func (c *usersServiceClient) GetUser(ctx context.Context, req *connect.Request[usersv1.GetUserRequest]) (*connect.Response[usersv1.GetUserResponse], error) {
+	return c.getUser.CallUnary(ctx, req)
+}
+
+// ListUsers calls users.v1.UsersService.ListUsers.
+func (c *usersServiceClient) ListUsers(ctx context.Context, req *connect.Request[usersv1.ListUsersRequest]) (*connect.Response[usersv1.ListUsersResponse], error) {
+	return c.listUsers.CallUnary(ctx, req)
+}
+
+// UpdateUser calls users.v1.UsersService.UpdateUser.
+func (c *usersServiceClient) UpdateUser(ctx context.Context, req *connect.Request[usersv1.UpdateUserRequest]) (*connect.Response[usersv1.UpdateUserResponse], error) {
+	return c.updateUser.CallUnary(ctx, req)
+}
+
+// DeleteUser calls users.v1.UsersService.DeleteUser.
+func (c *usersServiceClient) DeleteUser(ctx context.Context, req *connect.Request[usersv1.DeleteUserRequest]) (*connect.Response[usersv1.DeleteUserResponse], error) {
+	return c.deleteUser.CallUnary(ctx, req)
+}
+
+// UsersServiceHandler is an implementation of the users.v1.UsersService service.
+type UsersServiceHandler interface {
+	// CreateUser creates a user, as POST /users does.
+	CreateUser(context.Context, *connect.Request[usersv1.CreateUserRequest]) (*connect.Response[usersv1.CreateUserResponse], error)
+	// GetUser returns a user, deleted users included, as GET /users/{id} does.
+	GetUser(context.Context, *connect.Request[usersv1.GetUserRequest]) (*connect.Response[usersv1.GetUserResponse], error)
+	// ListUsers returns a page of the users that are not deleted, newest
+	// first, as GET /users does.
+	ListUsers(context.Context, *connect.Request[usersv1.ListUsersRequest]) (*connect.Response[usersv1.ListUsersResponse], error)
+	// UpdateUser changes the name of a user, as PUT /users/{id} does.
+	UpdateUser(context.Context, *connect.Request[usersv1.UpdateUserRequest]) (*connect.Response[usersv1.UpdateUserResponse], error)
+	// DeleteUser soft-deletes a user, as DELETE /users/{id} does.
+	DeleteUser(context.Context, *connect.Request[usersv1.DeleteUserRequest]) (*connect.Response[usersv1.DeleteUserResponse], error)
+}
+
+// NewUsersServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewUsersServiceHandler(svc UsersServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	usersServiceMethods := usersv1.File_users_v1_users_proto.Services().ByName("UsersService").Methods()
+	usersServiceCreateUserHandler := connect.NewUnaryHandler(
+		UsersServiceCreateUserProcedure,
+		svc.CreateUser,
+		connect.WithSchema(usersServiceMethods.ByName("CreateUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	usersServiceGetUserHandler := connect.NewUnaryHandler(
+		UsersServiceGetUserProcedure,
+		svc.GetUser,
+		connect.WithSchema(usersServiceMethods.ByName("GetUser")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	usersServiceListUsersHandler := connect.NewUnaryHandler(
+		UsersServiceListUsersProcedure,
+		svc.ListUsers,
+		connect.WithSchema(usersServiceMethods.ByName("ListUsers")),
+		connect.WithIdempotency(connect.IdempotencyNoSideEffects),
+		connect.WithHandlerOptions(opts...),
+	)
+	usersServiceUpdateUserHandler := connect.NewUnaryHandler(
+		UsersServiceUpdateUserProcedure,
+		svc.UpdateUser,
+		connect.WithSchema(usersServiceMethods.ByName("UpdateUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	usersServiceDeleteUserHandler := connect.NewUnaryHandler(
+		UsersServiceDeleteUserProcedure,
+		svc.DeleteUser,
+		connect.WithSchema(usersServiceMethods.ByName("DeleteUser")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/users.v1.UsersService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case UsersServiceCreateUserProcedure:
+			usersServiceCreateUserHandler.ServeHTTP(w, r)
+		case UsersServiceGetUserProcedure:
+			usersServiceGetUserHandler.ServeHTTP(w, r)
+		case UsersServiceListUsersProcedure:
+			usersServiceListUsersHandler.ServeHTTP(w, r)
+		case UsersServiceUpdateUserProcedure:
+			usersServiceUpdateUserHandler.ServeHTTP(w, r)
+		case UsersServiceDeleteUserProcedure:
+			usersServiceDeleteUserHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedUsersServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedUsersServiceHandler struct{}
+
+func (UnimplementedUsersServiceHandler) CreateUser(context.Context, *connect.Request[usersv1.CreateUserRequest]) (*connect.Response[usersv1.CreateUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("users.v1.UsersService.CreateUser is not implemented"))
+}
+
+func (UnimplementedUsersServiceHandler) GetUser(context.Context, *connect.Request[usersv1.GetUserRequest]) (*connect.Response[usersv1.GetUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("users.v1.UsersService.GetUser is not implemented"))
+}
+
+func (UnimplementedUsersServiceHandler) ListUsers(context.Context, *connect.Request[usersv1.ListUsersRequest]) (*connect.Response[usersv1.ListUsersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("users.v1.UsersService.ListUsers is not implemented"))
+}
+
+func (UnimplementedUsersServiceHandler) UpdateUser(context.Context, *connect.Request[usersv1.UpdateUserRequest]) (*connect.Response[usersv1.UpdateUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("users.v1.UsersService.UpdateUser is not implemented"))
+}
+
+func (UnimplementedUsersServiceHandler) DeleteUser(context.Context, *connect.Request[usersv1.DeleteUserRequest]) (*connect.Response[usersv1.DeleteUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("users.v1.UsersService.DeleteUser is not implemented"))
+}
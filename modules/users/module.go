@@ -4,27 +4,95 @@
 package users
 
 import (
+	"cmp"
+	"context"
+	"errors"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/rai/clean-modularmonolith-go/internal/platform/elasticsearch"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/cache"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/clock"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/contracts"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/ids"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/lifecycle"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/tokens"
 	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
 	"github.com/rai/clean-modularmonolith-go/modules/users/application/commands"
 	"github.com/rai/clean-modularmonolith-go/modules/users/application/eventhandlers"
 	"github.com/rai/clean-modularmonolith-go/modules/users/application/queries"
 	"github.com/rai/clean-modularmonolith-go/modules/users/domain"
 	httphandler "github.com/rai/clean-modularmonolith-go/modules/users/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/users/infrastructure/rpc"
 )
 
 // Module is the public API for the users bounded context.
-// External communication: HTTP API (RegisterRoutes)
-// Cross-module communication: Domain Events (subscribed internally)
+// External communication: HTTP API and the users.v1.UsersService RPC
+// service (RegisterRoutes)
+// Cross-module communication: Domain Events (subscribed internally) and
+// contracts.UserReader for synchronous reads.
 type Module interface {
-	// RegisterRoutes registers the module's HTTP routes to the given mux.
+	// RegisterRoutes registers the module's HTTP routes and its RPC service
+	// to the given mux.
 	RegisterRoutes(mux *http.ServeMux)
+	// Operations documents the routes for the OpenAPI specification.
+	Operations() []openapi.Operation
+
+	// UserReader gives the orders and notifications modules read access to
+	// users (see package contracts).
+	contracts.UserReader
+
+	// GetUser returns a user's profile, deleted users included. ok is false
+	// for malformed IDs and unknown users.
+	GetUser(ctx context.Context, userID string) (user User, ok bool, err error)
+	// DeletedUsers returns a page of the soft-deleted users, newest first,
+	// and their total number, for operators (the admin module). limit is
+	// capped at 100; zero means 20.
+	DeletedUsers(ctx context.Context, offset, limit int) (users []User, total int, err error)
+
+	// CreateUser and DeleteUser run the same commands as the HTTP API, for
+	// operators and fixtures (cmd/admin). CreateUser generates an ID when
+	// userID is empty.
+	CreateUser(ctx context.Context, userID, email, firstName, lastName string) (string, error)
+	DeleteUser(ctx context.Context, userID string) error
+	// RestoreUser reactivates a deleted user however long ago it was
+	// deleted, for operators (cmd/admin).
+	RestoreUser(ctx context.Context, userID string) error
+	// RepairUserStatus sets the status of a user whose stored status is
+	// corrupt, as reported by reads failing with a corrupt row error, for
+	// operators (cmd/admin).
+	RepairUserStatus(ctx context.Context, userID, status string) error
+	// ImportUsers creates users in bulk, for operators (cmd/admin). Users
+	// that are invalid or whose email is taken are reported in failures
+	// without stopping the others; err means the import failed part way,
+	// and userIDs are those created nonetheless.
+	ImportUsers(ctx context.Context, users []ImportUser) (userIDs []string, failures []ImportFailure, err error)
+
+	// Shutdown flushes the Elasticsearch indexer; Start has nothing to launch.
+	lifecycle.Hooks
 }
 
+// User is a user's profile, as returned to other modules.
+type User struct {
+	ID        string
+	Email     string
+	FirstName string
+	LastName  string
+	Status    string
+	CreatedAt time.Time
+}
+
+// ImportUser is a user for ImportUsers to create.
+type ImportUser = commands.ImportUser
+
+// ImportFailure is a user ImportUsers did not create, and why.
+type ImportFailure = commands.ImportFailure
+
 // Config holds the module configuration.
 type Config struct {
 	Repository                domain.UserRepository
@@ -36,21 +104,77 @@ type Config struct {
 	PostCommitSubscriber      events.PostCommitSubscriber
 	ESClient                  elasticsearch.Client
 	Logger                    *slog.Logger
+
+	// Reader, when set, serves the user queries instead of Repository, e.g.
+	// from a read replica or a projection. Commands always read Repository.
+	Reader domain.UserReader
+
+	// EmailPolicy controls validation strictness and canonicalization of
+	// email addresses for new users. The zero value is the standard policy.
+	EmailPolicy domain.EmailPolicy
+
+	// RestoreGracePeriod is how long after deleting a user
+	// POST /users/{id}/restore can restore it; admins can restore it any
+	// time. Zero means any time.
+	RestoreGracePeriod time.Duration
+
+	// Tokens signs the tokens that confirm email changes, valid for
+	// EmailChangeTTL (default 24 hours), and password resets, valid for
+	// PasswordResetTTL (default 1 hour). Both answer 501 when nil.
+	Tokens           *tokens.Signer
+	EmailChangeTTL   time.Duration
+	PasswordResetTTL time.Duration
+
+	// AdminToken guards admin-only endpoints (listing deleted users,
+	// restoring them after the grace period) via the X-Admin-Token header.
+	// Admin endpoints are disabled when empty.
+	AdminToken string
+
+	// SecuritySink receives the admin token decisions made by the HTTP
+	// handlers. Optional: nil records nothing.
+	SecuritySink security.Sink
+
+	// CommandBus, when set, applies the platform's middleware (tracing,
+	// recording for the audit log and metrics, validation) to every command.
+	CommandBus *command.Bus
+
+	// Cache, when set, caches the UserDTOs GetUser returns for CacheTTL
+	// (default one minute), evicting them on the events that change them.
+	Cache    cache.Store
+	CacheTTL time.Duration
+
+	// Clock is the time the users read. Defaults to clock.System when nil.
+	Clock clock.Clock
+
+	// IDGenerator generates the user and event IDs. Defaults to ids.UUIDv7
+	// when nil.
+	IDGenerator ids.Generator
 }
 
 // module implements the Module interface.
 type module struct {
-	createUserHandler  *commands.CreateUserHandler
-	updateUserHandler  *commands.UpdateUserHandler
-	deleteUserHandler  *commands.DeleteUserHandler
-	getUserHandler     *queries.GetUserHandler
+	createUserHandler  command.Handler[commands.CreateUserCommand, string]
+	updateUserHandler  command.VoidHandler[commands.UpdateUserCommand]
+	deleteUserHandler  command.VoidHandler[commands.DeleteUserCommand]
+	restoreUserHandler command.VoidHandler[commands.RestoreUserCommand]
+	repairStatus       command.VoidHandler[commands.RepairUserStatusCommand]
+	importUsers        command.Handler[commands.ImportUsersCommand, commands.ImportUsersResult]
+	updatePrefsHandler command.VoidHandler[commands.UpdatePreferencesCommand]
+	requestEmailChange command.VoidHandler[commands.RequestEmailChangeCommand]
+	confirmEmailChange command.VoidHandler[commands.ConfirmEmailChangeCommand]
+	requestPassword    command.VoidHandler[commands.RequestPasswordResetCommand]
+	resetPassword      command.VoidHandler[commands.ResetPasswordCommand]
+	getUserHandler     command.Handler[queries.GetUserQuery, *queries.UserDTO]
+	batchGetHandler    *queries.BatchGetUsersHandler
 	listUsersHandler   *queries.ListUsersHandler
 	searchUsersHandler *queries.SearchUsersHandler
+	userSummaryHandler *queries.UserSummaryHandler
+	admin              security.AdminGuard
+	stopIndexer        func()
 }
 
 // New creates a new users module with all dependencies wired.
-// The returned cleanup function releases background resources.
-func New(cfg Config) (_ Module, cleanup func()) {
+func New(cfg Config) Module {
 	logger := cfg.Logger
 	if logger == nil {
 		logger = slog.Default()
@@ -58,27 +182,45 @@ func New(cfg Config) (_ Module, cleanup func()) {
 	logger = logger.With("module", "users")
 
 	// Wrap the transaction scope with ScopeWithDomainEvent that automatically
-	// collects domain events from context and publishes them after success.
+	// collects domain events from context and publishes them after success,
+	// with the configured clock and ID generator installed for the users to read.
 	txScope := events.NewScopeWithDomainEvent(cfg.ReadWriteTransactionScope, cfg.Publisher, cfg.PostCommitPublisher)
+	txScope = ids.Scope(clock.Scope(txScope, cfg.Clock), cfg.IDGenerator)
 
 	// Wire up command handlers (no publisher needed — ScopeWithDomainEvent handles it)
-	createUserHandler := commands.NewCreateUserHandler(cfg.Repository, txScope)
+	createUserHandler := commands.NewCreateUserHandler(cfg.Repository, txScope, cfg.EmailPolicy)
 	updateUserHandler := commands.NewUpdateUserHandler(cfg.Repository, txScope)
 	deleteUserHandler := commands.NewDeleteUserHandler(cfg.Repository, txScope)
+	restoreUserHandler := commands.NewRestoreUserHandler(cfg.Repository, txScope, cfg.RestoreGracePeriod)
+	updatePrefsHandler := commands.NewUpdatePreferencesHandler(cfg.Repository, txScope)
+	repairStatusHandler := commands.NewRepairUserStatusHandler(cfg.Repository, txScope)
+	requestEmailChange := commands.NewRequestEmailChangeHandler(cfg.Repository, txScope, cfg.EmailPolicy, cfg.Tokens, cmp.Or(cfg.EmailChangeTTL, 24*time.Hour))
+	confirmEmailChange := commands.NewConfirmEmailChangeHandler(cfg.Repository, txScope, cfg.EmailPolicy, cfg.Tokens)
+	requestPassword := commands.NewRequestPasswordResetHandler(cfg.Repository, txScope, cfg.EmailPolicy, cfg.Tokens, cmp.Or(cfg.PasswordResetTTL, time.Hour))
+	resetPassword := commands.NewResetPasswordHandler(cfg.Repository, txScope, cfg.Tokens)
+	importUsersHandler := commands.NewImportUsersHandler(cfg.Repository, txScope, cfg.EmailPolicy, cfg.Clock, cfg.IDGenerator)
 
 	// Wire up query handlers
-	getUserHandler := queries.NewGetUserHandler(cfg.Repository)
-	listUsersHandler := queries.NewListUsersHandler(cfg.Repository, cfg.ReadOnlyTransactionScope)
+	var reader domain.UserReader = cfg.Repository
+	if cfg.Reader != nil {
+		reader = cfg.Reader
+	}
+	var getUserHandler command.Handler[queries.GetUserQuery, *queries.UserDTO] = queries.NewGetUserHandler(reader)
+	batchGetHandler := queries.NewBatchGetUsersHandler(reader)
+	listUsersHandler := queries.NewListUsersHandler(reader, cfg.ReadOnlyTransactionScope)
 	searchUsersHandler := queries.NewSearchUsersHandler(cfg.ESClient)
+	userSummaryHandler := queries.NewUserSummaryHandler(reader)
 
 	// Subscribe to domain events for Elasticsearch sync (post-commit: external side effects)
+	var stopIndexer func()
 	if cfg.PostCommitSubscriber != nil && cfg.ESClient != nil {
 		var indexer *eventhandlers.UserIndexer
-		indexer, cleanup = eventhandlers.NewUserIndexer(cfg.ESClient, logger)
+		indexer, stopIndexer = eventhandlers.NewUserIndexer(cfg.ESClient, logger)
 		handlers := []events.Handler{
 			eventhandlers.NewUserCreatedHandler(indexer),
 			eventhandlers.NewUserUpdatedHandler(indexer),
 			eventhandlers.NewUserDeletedHandler(indexer),
+			eventhandlers.NewUserRestoredHandler(indexer),
 		}
 		for _, h := range handlers {
 			if err := cfg.PostCommitSubscriber.SubscribePostCommit(h.EventType(), h); err != nil {
@@ -90,16 +232,122 @@ func New(cfg Config) (_ Module, cleanup func()) {
 		}
 	}
 
+	// Cache user read models, evicting them pre-commit and again post-commit
+	// (see package cache).
+	if cfg.Cache != nil {
+		for _, eventType := range eventhandlers.UserCacheEventTypes {
+			invalidator := eventhandlers.NewUserCacheInvalidator(eventType, cfg.Cache)
+			if cfg.Subscriber != nil {
+				if err := cfg.Subscriber.Subscribe(eventType, invalidator); err != nil {
+					logger.Error("failed to subscribe user cache invalidator", slog.String("event_type", eventType.String()), slog.Any("error", err))
+				}
+			}
+			if cfg.PostCommitSubscriber != nil {
+				if err := cfg.PostCommitSubscriber.SubscribePostCommit(eventType, invalidator); err != nil {
+					logger.Error("failed to subscribe user cache invalidator", slog.String("event_type", eventType.String()), slog.Any("error", err))
+				}
+			}
+		}
+		getUserHandler = cache.Cached(cfg.Cache, cmp.Or(cfg.CacheTTL, time.Minute), queries.GetUserCacheKey, getUserHandler)
+	}
+
 	return &module{
-		createUserHandler:  createUserHandler,
-		updateUserHandler:  updateUserHandler,
-		deleteUserHandler:  deleteUserHandler,
+		createUserHandler:  command.Register[commands.CreateUserCommand, string](cfg.CommandBus, "users", createUserHandler),
+		updateUserHandler:  command.RegisterVoid[commands.UpdateUserCommand](cfg.CommandBus, "users", updateUserHandler),
+		deleteUserHandler:  command.RegisterVoid[commands.DeleteUserCommand](cfg.CommandBus, "users", deleteUserHandler),
+		restoreUserHandler: command.RegisterVoid[commands.RestoreUserCommand](cfg.CommandBus, "users", restoreUserHandler),
+		updatePrefsHandler: command.RegisterVoid[commands.UpdatePreferencesCommand](cfg.CommandBus, "users", updatePrefsHandler),
+		requestEmailChange: command.RegisterVoid[commands.RequestEmailChangeCommand](cfg.CommandBus, "users", requestEmailChange),
+		confirmEmailChange: command.RegisterVoid[commands.ConfirmEmailChangeCommand](cfg.CommandBus, "users", confirmEmailChange),
+		requestPassword:    command.RegisterVoid[commands.RequestPasswordResetCommand](cfg.CommandBus, "users", requestPassword),
+		resetPassword:      command.RegisterVoid[commands.ResetPasswordCommand](cfg.CommandBus, "users", resetPassword),
+		repairStatus:       command.RegisterVoid[commands.RepairUserStatusCommand](cfg.CommandBus, "users", repairStatusHandler),
+		importUsers:        command.Register[commands.ImportUsersCommand, commands.ImportUsersResult](cfg.CommandBus, "users", importUsersHandler),
 		getUserHandler:     getUserHandler,
+		batchGetHandler:    batchGetHandler,
 		listUsersHandler:   listUsersHandler,
 		searchUsersHandler: searchUsersHandler,
-	}, cleanup
+		userSummaryHandler: userSummaryHandler,
+		admin:              security.AdminGuard{Module: "users", Token: cfg.AdminToken, Sink: cfg.SecuritySink},
+		stopIndexer:        stopIndexer,
+	}
+}
+
+func (m *module) Start(ctx context.Context) error {
+	return nil
+}
+
+func (m *module) Shutdown(ctx context.Context) error {
+	return lifecycle.Stop(ctx, m.stopIndexer)
 }
 
 func (m *module) RegisterRoutes(mux *http.ServeMux) {
-	httphandler.RegisterRoutes(mux, m.createUserHandler, m.updateUserHandler, m.deleteUserHandler, m.getUserHandler, m.listUsersHandler, m.searchUsersHandler)
+	httphandler.RegisterRoutes(mux, m.createUserHandler, m.updateUserHandler, m.deleteUserHandler, m.restoreUserHandler, m.updatePrefsHandler, m.requestEmailChange, m.confirmEmailChange, m.requestPassword, m.resetPassword, m.getUserHandler, m.batchGetHandler, m.listUsersHandler, m.searchUsersHandler, m.admin)
+	rpc.RegisterRoutes(mux, m.createUserHandler, m.updateUserHandler, m.deleteUserHandler, m.getUserHandler, m.listUsersHandler)
+}
+
+func (m *module) Operations() []openapi.Operation {
+	return httphandler.Operations()
+}
+
+func (m *module) GetUserSummary(ctx context.Context, userID string) (contracts.UserSummary, bool, error) {
+	return m.userSummaryHandler.Handle(ctx, queries.UserSummaryQuery{UserID: userID})
+}
+
+func (m *module) GetUser(ctx context.Context, userID string) (User, bool, error) {
+	user, err := m.getUserHandler.Handle(ctx, queries.GetUserQuery{UserID: userID})
+	if errors.Is(err, domain.ErrInvalidUserID) || errors.Is(err, domain.ErrUserNotFound) {
+		return User{}, false, nil
+	}
+	if err != nil {
+		return User{}, false, err
+	}
+	return User{
+		ID:        user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		Status:    user.Status,
+		CreatedAt: user.CreatedAt,
+	}, true, nil
+}
+
+func (m *module) DeletedUsers(ctx context.Context, offset, limit int) ([]User, int, error) {
+	list, err := m.listUsersHandler.Handle(ctx, queries.ListUsersQuery{Offset: offset, Limit: limit, Deleted: domain.OnlyDeleted})
+	if err != nil {
+		return nil, 0, err
+	}
+	users := make([]User, len(list.Users))
+	for i, u := range list.Users {
+		users[i] = User{
+			ID:        u.ID,
+			Email:     u.Email,
+			FirstName: u.FirstName,
+			LastName:  u.LastName,
+			Status:    u.Status,
+			CreatedAt: u.CreatedAt,
+		}
+	}
+	return users, list.TotalCount, nil
+}
+
+func (m *module) CreateUser(ctx context.Context, userID, email, firstName, lastName string) (string, error) {
+	return m.createUserHandler.Handle(ctx, commands.CreateUserCommand{UserID: userID, Email: email, FirstName: firstName, LastName: lastName})
+}
+
+func (m *module) DeleteUser(ctx context.Context, userID string) error {
+	return m.deleteUserHandler.Handle(ctx, commands.DeleteUserCommand{UserID: userID})
+}
+
+func (m *module) RestoreUser(ctx context.Context, userID string) error {
+	return m.restoreUserHandler.Handle(ctx, commands.RestoreUserCommand{UserID: userID, AnyTime: true})
+}
+
+func (m *module) RepairUserStatus(ctx context.Context, userID, status string) error {
+	return m.repairStatus.Handle(ctx, commands.RepairUserStatusCommand{UserID: userID, Status: status})
+}
+
+func (m *module) ImportUsers(ctx context.Context, users []ImportUser) ([]string, []ImportFailure, error) {
+	result, err := m.importUsers.Handle(ctx, commands.ImportUsersCommand{Users: users})
+	return result.UserIDs, result.Failures, err
 }
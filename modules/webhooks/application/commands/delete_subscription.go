@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+// DeleteSubscriptionCommand represents the intent to stop receiving events.
+type DeleteSubscriptionCommand struct {
+	SubscriptionID string
+}
+
+type DeleteSubscriptionHandler struct {
+	repo    domain.SubscriptionRepository
+	txScope transaction.Scope
+}
+
+func NewDeleteSubscriptionHandler(repo domain.SubscriptionRepository, txScope transaction.Scope) *DeleteSubscriptionHandler {
+	return &DeleteSubscriptionHandler{
+		repo:    repo,
+		txScope: txScope,
+	}
+}
+
+// Handle executes the delete subscription use case. Pending retries are
+// dropped together with the delivery log.
+func (h *DeleteSubscriptionHandler) Handle(ctx context.Context, cmd DeleteSubscriptionCommand) error {
+	id, err := domain.ParseSubscriptionID(cmd.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	return h.txScope.Execute(ctx, func(ctx context.Context) error {
+		if _, err := h.repo.FindByID(ctx, id); err != nil {
+			return fmt.Errorf("finding subscription: %w", err)
+		}
+		if err := h.repo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("deleting subscription: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,67 @@
+// Package commands contains write use cases for the webhooks module.
+package commands
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+// RegisterSubscriptionCommand represents the intent to receive events at a callback URL.
+type RegisterSubscriptionCommand struct {
+	CallbackURL string
+	EventTypes  []string
+}
+
+// RegisterSubscriptionResult is returned once on registration; the secret
+// cannot be retrieved afterwards.
+type RegisterSubscriptionResult struct {
+	ID     string
+	Secret string
+}
+
+type RegisterSubscriptionHandler struct {
+	repo      domain.SubscriptionRepository
+	txScope   transaction.Scope
+	supported []events.EventType
+}
+
+func NewRegisterSubscriptionHandler(repo domain.SubscriptionRepository, txScope transaction.Scope, supported []events.EventType) *RegisterSubscriptionHandler {
+	return &RegisterSubscriptionHandler{
+		repo:      repo,
+		txScope:   txScope,
+		supported: supported,
+	}
+}
+
+// Handle executes the register subscription use case.
+func (h *RegisterSubscriptionHandler) Handle(ctx context.Context, cmd RegisterSubscriptionCommand) (RegisterSubscriptionResult, error) {
+	eventTypes := make([]events.EventType, len(cmd.EventTypes))
+	for i, t := range cmd.EventTypes {
+		eventTypes[i] = events.EventType(t)
+		if !slices.Contains(h.supported, eventTypes[i]) {
+			return RegisterSubscriptionResult{}, fmt.Errorf("%w: %q", domain.ErrEventTypeNotSupported, t)
+		}
+	}
+
+	sub, err := domain.NewSubscription(cmd.CallbackURL, eventTypes)
+	if err != nil {
+		return RegisterSubscriptionResult{}, err
+	}
+
+	err = h.txScope.Execute(ctx, func(ctx context.Context) error {
+		if err := h.repo.Save(ctx, sub); err != nil {
+			return fmt.Errorf("saving subscription: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return RegisterSubscriptionResult{}, err
+	}
+
+	return RegisterSubscriptionResult{ID: sub.ID().String(), Secret: sub.Secret()}, nil
+}
@@ -0,0 +1,119 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+// Deliverer posts a delivery and records the outcome.
+// It is implemented by eventhandlers.Deliverer.
+type Deliverer interface {
+	Deliver(ctx context.Context, sub *domain.Subscription, d *domain.Delivery) error
+}
+
+// RetryDueDeliveriesCommand redelivers webhooks whose next retry is due at Now.
+type RetryDueDeliveriesCommand struct {
+	Now       time.Time
+	BatchSize int
+	// Lease is how long a claimed delivery is hidden from other workers
+	// while it is in flight.
+	Lease time.Duration
+}
+
+type RetryDueDeliveriesHandler struct {
+	subscriptions domain.SubscriptionRepository
+	deliveries    domain.DeliveryRepository
+	txScope       transaction.Scope
+	deliverer     Deliverer
+	logger        *slog.Logger
+}
+
+func NewRetryDueDeliveriesHandler(
+	subscriptions domain.SubscriptionRepository,
+	deliveries domain.DeliveryRepository,
+	txScope transaction.Scope,
+	deliverer Deliverer,
+	logger *slog.Logger,
+) *RetryDueDeliveriesHandler {
+	return &RetryDueDeliveriesHandler{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		txScope:       txScope,
+		deliverer:     deliverer,
+		logger:        logger,
+	}
+}
+
+// Handle executes the retry use case and returns the number of deliveries attempted.
+//
+// Each delivery is first claimed in its own transaction that re-reads it and
+// moves its next attempt past the lease, then posted outside the transaction.
+// When several instances run the worker at once, only one of them claims a
+// given delivery; the others observe the new attempt time and skip it. Each
+// delivery is retried in its own tenant.
+func (h *RetryDueDeliveriesHandler) Handle(ctx context.Context, cmd RetryDueDeliveriesCommand) (int, error) {
+	due, err := h.deliveries.FindDueRetries(ctx, cmd.Now, cmd.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("finding due retries: %w", err)
+	}
+
+	retried := 0
+	for _, r := range due {
+		v := requestcontext.FromContext(ctx)
+		v.TenantID = r.TenantID
+		tenantCtx := requestcontext.With(ctx, v)
+		c, err := transaction.ExecuteWithResult(tenantCtx, h.txScope, func(ctx context.Context) (claim, error) {
+			sub, err := h.subscriptions.FindByID(ctx, r.SubscriptionID)
+			if err != nil {
+				return claim{}, fmt.Errorf("finding subscription: %w", err)
+			}
+			d, err := h.deliveries.FindByID(ctx, r.SubscriptionID, r.DeliveryID)
+			if err != nil {
+				return claim{}, fmt.Errorf("finding delivery: %w", err)
+			}
+			if err := d.ClaimRetry(cmd.Now, cmd.Lease); err != nil {
+				return claim{}, err
+			}
+			if err := h.deliveries.Save(ctx, d); err != nil {
+				return claim{}, fmt.Errorf("saving delivery: %w", err)
+			}
+			return claim{subscription: sub, delivery: d}, nil
+		})
+		if err == nil {
+			err = h.deliverer.Deliver(tenantCtx, c.subscription, c.delivery)
+		}
+
+		switch {
+		case err == nil:
+			retried++
+		case errors.Is(err, domain.ErrRetryNotDue),
+			errors.Is(err, domain.ErrDeliveryNotFound),
+			errors.Is(err, domain.ErrSubscriptionNotFound):
+			// Claimed or removed since the scan, possibly by another instance.
+		default:
+			if ctx.Err() != nil {
+				return retried, ctx.Err()
+			}
+			h.logger.Warn("failed to retry webhook delivery",
+				slog.String("delivery_id", r.DeliveryID.String()),
+				slog.String("tenant_id", r.TenantID),
+				slog.Any("error", err),
+			)
+		}
+	}
+
+	return retried, nil
+}
+
+// claim is a delivery claimed for a retry, with its subscription.
+type claim struct {
+	subscription *domain.Subscription
+	delivery     *domain.Delivery
+}
@@ -0,0 +1,115 @@
+package commands_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+// tenantSubscriptions is a domain.SubscriptionRepository that finds a
+// subscription only in the tenant it belongs to.
+type tenantSubscriptions map[domain.SubscriptionID]string
+
+func (s tenantSubscriptions) Save(context.Context, *domain.Subscription) error { return nil }
+
+func (s tenantSubscriptions) Delete(context.Context, domain.SubscriptionID) error { return nil }
+
+func (s tenantSubscriptions) FindByID(ctx context.Context, id domain.SubscriptionID) (*domain.Subscription, error) {
+	tenant, ok := s[id]
+	if !ok || tenant != requestcontext.TenantID(ctx) {
+		return nil, domain.ErrSubscriptionNotFound
+	}
+	return domain.ReconstituteSubscription(id, "https://example.com/hook", []events.EventType{"order.submitted"}, "secret", time.Now()), nil
+}
+
+func (s tenantSubscriptions) FindAll(context.Context, int, int) ([]*domain.Subscription, int, error) {
+	return nil, 0, nil
+}
+
+func (s tenantSubscriptions) FindByEventType(context.Context, events.EventType) ([]*domain.Subscription, error) {
+	return nil, nil
+}
+
+// tenantDeliveries is a domain.DeliveryRepository of retrying deliveries
+// that records the tenant each one is saved in.
+type tenantDeliveries struct {
+	due     []domain.DueRetry
+	now     time.Time
+	savedIn map[domain.DeliveryID]string
+}
+
+func (d *tenantDeliveries) Save(ctx context.Context, delivery *domain.Delivery) error {
+	d.savedIn[delivery.ID()] = requestcontext.TenantID(ctx)
+	return nil
+}
+
+func (d *tenantDeliveries) FindByID(ctx context.Context, subscription domain.SubscriptionID, id domain.DeliveryID) (*domain.Delivery, error) {
+	return domain.ReconstituteDelivery(id, subscription, "event-1", "order.submitted", []byte(`{}`),
+		domain.DeliveryRetrying, 1, 503, "unavailable", d.now.Add(-time.Minute), d.now.Add(-time.Hour), time.Time{}), nil
+}
+
+func (d *tenantDeliveries) FindBySubscription(context.Context, domain.SubscriptionID, int, int) ([]*domain.Delivery, int, error) {
+	return nil, 0, nil
+}
+
+func (d *tenantDeliveries) FindByStatus(context.Context, domain.DeliveryStatus, int, int) ([]*domain.Delivery, int, error) {
+	return nil, 0, nil
+}
+
+func (d *tenantDeliveries) FindDueRetries(context.Context, time.Time, int) ([]domain.DueRetry, error) {
+	return d.due, nil
+}
+
+// direct runs fn without a transaction.
+type direct struct{}
+
+func (direct) Execute(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }
+
+// tenantDeliverer records the tenant each delivery is posted in.
+type tenantDeliverer map[domain.DeliveryID]string
+
+func (p tenantDeliverer) Deliver(ctx context.Context, sub *domain.Subscription, d *domain.Delivery) error {
+	p[d.ID()] = requestcontext.TenantID(ctx)
+	return nil
+}
+
+// TestRetryDueDeliveriesHandler_Handle_EveryTenant checks that the due
+// deliveries of every tenant are retried, each in its own tenant.
+func TestRetryDueDeliveriesHandler_Handle_EveryTenant(t *testing.T) {
+	now := time.Now()
+	defaultSub, acmeSub := domain.NewSubscriptionID(), domain.NewSubscriptionID()
+	defaultID, acmeID := domain.DeliveryIDFor(defaultSub, "event-1"), domain.DeliveryIDFor(acmeSub, "event-1")
+
+	deliveries := &tenantDeliveries{
+		due: []domain.DueRetry{
+			{SubscriptionID: defaultSub, DeliveryID: defaultID, TenantID: ""},
+			{SubscriptionID: acmeSub, DeliveryID: acmeID, TenantID: "acme"},
+		},
+		now:     now,
+		savedIn: map[domain.DeliveryID]string{},
+	}
+	posted := tenantDeliverer{}
+	handler := commands.NewRetryDueDeliveriesHandler(tenantSubscriptions{defaultSub: "", acmeSub: "acme"}, deliveries, direct{}, posted,
+		slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	retried, err := handler.Handle(t.Context(), commands.RetryDueDeliveriesCommand{Now: now, BatchSize: 10, Lease: time.Minute})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if retried != 2 {
+		t.Errorf("retried = %d, want 2", retried)
+	}
+	if deliveries.savedIn[acmeID] != "acme" || posted[acmeID] != "acme" {
+		t.Errorf("acme delivery claimed in tenant %q and posted in %q, want acme", deliveries.savedIn[acmeID], posted[acmeID])
+	}
+	if deliveries.savedIn[defaultID] != "" || posted[defaultID] != "" {
+		t.Errorf("default delivery claimed in tenant %q and posted in %q, want the default tenant", deliveries.savedIn[defaultID], posted[defaultID])
+	}
+}
@@ -0,0 +1,110 @@
+package eventhandlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/idempotent"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+// Deliverer signs and posts deliveries and records the outcome in the delivery log.
+// It embeds idempotent.OutboundCache so a delivery the callback already
+// accepted is not posted again when the same event is handled twice.
+type Deliverer struct {
+	*idempotent.OutboundCache
+	repo      domain.DeliveryRepository
+	transport domain.Transport
+	txScope   transaction.Scope
+	policy    domain.RetryPolicy
+	logger    *slog.Logger
+}
+
+func NewDeliverer(repo domain.DeliveryRepository, transport domain.Transport, txScope transaction.Scope, policy domain.RetryPolicy, logger *slog.Logger) (_ *Deliverer, cleanup func()) {
+	cache, cleanup := idempotent.NewOutboundCache()
+	return &Deliverer{
+		OutboundCache: cache,
+		repo:          repo,
+		transport:     transport,
+		txScope:       txScope,
+		policy:        policy,
+		logger:        logger,
+	}, cleanup
+}
+
+// Deliver posts d to the subscription's callback URL. Failures queue d for
+// retry and return nil; Deliver returns an error only if the outcome cannot
+// be recorded.
+func (r *Deliverer) Deliver(ctx context.Context, sub *domain.Subscription, d *domain.Delivery) error {
+	var rejectedStatus int // OnceResult drops the result on error
+	statusCode, err := idempotent.OnceResult(r.OutboundCache, "deliver-webhook", d.ID().String(), func() (int, error) {
+		code, err := r.post(ctx, sub, d)
+		if err != nil {
+			rejectedStatus = code
+		}
+		return code, err
+	})
+	if err != nil {
+		statusCode = rejectedStatus
+	}
+
+	if err == nil {
+		d.MarkSucceeded(statusCode)
+		r.logger.InfoContext(ctx, "webhook delivered",
+			slog.String("delivery_id", d.ID().String()),
+			slog.String("subscription_id", sub.ID().String()),
+			slog.String("event_type", d.EventType().String()),
+			slog.Int("status", statusCode),
+		)
+	} else if d.ScheduleRetry(err, statusCode, r.policy, time.Now()) {
+		r.logger.WarnContext(ctx, "webhook delivery failed, retry scheduled",
+			slog.String("delivery_id", d.ID().String()),
+			slog.Int("attempts", d.Attempts()),
+			slog.Time("next_attempt_at", d.NextAttemptAt()),
+			slog.Any("error", err),
+		)
+	} else {
+		r.logger.ErrorContext(ctx, "webhook delivery failed, giving up",
+			slog.String("delivery_id", d.ID().String()),
+			slog.Int("attempts", d.Attempts()),
+			slog.Any("error", err),
+		)
+	}
+
+	return r.txScope.Execute(ctx, func(ctx context.Context) error {
+		if err := r.repo.Save(ctx, d); err != nil {
+			return fmt.Errorf("recording webhook delivery: %w", err)
+		}
+		return nil
+	})
+}
+
+// post signs and sends one attempt. Non-2xx responses are returned as
+// ErrCallbackRejected together with the status code.
+func (r *Deliverer) post(ctx context.Context, sub *domain.Subscription, d *domain.Delivery) (int, error) {
+	now := time.Now()
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/json")
+	headers.Set(domain.HeaderDeliveryID, d.ID().String())
+	headers.Set(domain.HeaderEventType, d.EventType().String())
+	headers.Set(domain.HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	headers.Set(domain.HeaderSignature, domain.Sign(sub.Secret(), now, d.Payload()))
+
+	statusCode, err := r.transport.Post(ctx, domain.Request{
+		URL:     sub.CallbackURL(),
+		Headers: headers,
+		Body:    d.Payload(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if statusCode < 200 || statusCode > 299 {
+		return statusCode, fmt.Errorf("%w: %d", domain.ErrCallbackRejected, statusCode)
+	}
+	return statusCode, nil
+}
@@ -0,0 +1,76 @@
+package eventhandlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+// envelope is the JSON body of every webhook delivery.
+type envelope struct {
+	ID         string           `json:"id"`
+	Type       events.EventType `json:"type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Data       map[string]any   `json:"data"`
+}
+
+// EventForwarder delivers one event type to every subscription that wants it.
+// The callbacks are external side effects: within a database transaction
+// they are deferred with transaction.AfterCommit until the transaction commits.
+type EventForwarder struct {
+	eventType     events.EventType
+	build         PayloadBuilder
+	subscriptions domain.SubscriptionRepository
+	deliverer     *Deliverer
+}
+
+func NewEventForwarder(eventType events.EventType, build PayloadBuilder, subscriptions domain.SubscriptionRepository, deliverer *Deliverer) *EventForwarder {
+	return &EventForwarder{
+		eventType:     eventType,
+		build:         build,
+		subscriptions: subscriptions,
+		deliverer:     deliverer,
+	}
+}
+
+func (h *EventForwarder) HandlerName() string         { return "WebhookForwarder" }
+func (h *EventForwarder) Subdomain() string           { return "webhooks" }
+func (h *EventForwarder) EventType() events.EventType { return h.eventType }
+
+// Handle fans the event out to matching subscriptions. A failing callback
+// only queues its own delivery for retry and never affects the others.
+func (h *EventForwarder) Handle(ctx context.Context, event events.Event) error {
+	data, err := h.build(event)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(envelope{
+		ID:         event.EventID(),
+		Type:       event.EventType(),
+		OccurredAt: event.OccurredAt(),
+		Data:       data,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding webhook payload: %w", err)
+	}
+
+	subs, err := h.subscriptions.FindByEventType(ctx, h.eventType)
+	if err != nil {
+		return fmt.Errorf("finding webhook subscriptions: %w", err)
+	}
+
+	return transaction.AfterCommit(ctx, "deliver-webhooks", func(ctx context.Context) error {
+		var errs []error
+		for _, sub := range subs {
+			d := domain.NewDelivery(sub.ID(), event.EventID(), event.EventType(), body)
+			errs = append(errs, h.deliverer.Deliver(ctx, sub, d))
+		}
+		return errors.Join(errs...)
+	})
+}
@@ -0,0 +1,64 @@
+package eventhandlers
+
+import (
+	"fmt"
+
+	orderevents "github.com/rai/clean-modularmonolith-go/modules/orders/domain/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+)
+
+// PayloadBuilder extracts the public "data" object of a webhook from an event.
+// Payloads are an external contract: add fields freely, but never rename or remove them.
+type PayloadBuilder func(event events.Event) (map[string]any, error)
+
+// WebhookPayloads lists every event type API clients may subscribe to.
+// The module subscribes one EventForwarder per entry, post-commit.
+var WebhookPayloads = map[events.EventType]PayloadBuilder{
+	userevents.UserCreatedEventType: func(event events.Event) (map[string]any, error) {
+		e, ok := event.(userevents.UserCreatedEvent)
+		if !ok {
+			return nil, fmt.Errorf("unexpected event type: %T", event)
+		}
+		return map[string]any{
+			"user_id":    e.UserID,
+			"email":      e.Email,
+			"first_name": e.FirstName,
+			"last_name":  e.LastName,
+		}, nil
+	},
+	orderevents.OrderSubmittedEventType: func(event events.Event) (map[string]any, error) {
+		e, ok := event.(orderevents.OrderSubmittedEvent)
+		if !ok {
+			return nil, fmt.Errorf("unexpected event type: %T", event)
+		}
+		return map[string]any{
+			"order_id":     e.OrderID,
+			"user_id":      e.UserID,
+			"total_amount": e.TotalAmount,
+			"tax_amount":   e.TaxAmount,
+			"currency":     e.Currency,
+		}, nil
+	},
+	orderevents.OrderCancelledEventType: func(event events.Event) (map[string]any, error) {
+		e, ok := event.(orderevents.OrderCancelledEvent)
+		if !ok {
+			return nil, fmt.Errorf("unexpected event type: %T", event)
+		}
+		return map[string]any{
+			"order_id":     e.OrderID,
+			"user_id":      e.UserID,
+			"reason":       e.Reason,
+			"cancelled_by": e.CancelledBy,
+		}, nil
+	},
+}
+
+// WebhookEventTypes returns the keys of WebhookPayloads.
+func WebhookEventTypes() []events.EventType {
+	types := make([]events.EventType, 0, len(WebhookPayloads))
+	for t := range WebhookPayloads {
+		types = append(types, t)
+	}
+	return types
+}
@@ -0,0 +1,58 @@
+// Package queries contains read use cases for the webhooks module.
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+// SubscriptionDTO is a read model for a webhook subscription.
+// The signing secret is deliberately omitted; it is only returned on registration.
+type SubscriptionDTO struct {
+	ID          string    `json:"id"`
+	CallbackURL string    `json:"callback_url"`
+	EventTypes  []string  `json:"event_types"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// GetSubscriptionQuery represents a request to get a subscription by ID.
+type GetSubscriptionQuery struct {
+	SubscriptionID string
+}
+
+type GetSubscriptionHandler struct {
+	repo domain.SubscriptionRepository
+}
+
+func NewGetSubscriptionHandler(repo domain.SubscriptionRepository) *GetSubscriptionHandler {
+	return &GetSubscriptionHandler{repo: repo}
+}
+
+func (h *GetSubscriptionHandler) Handle(ctx context.Context, query GetSubscriptionQuery) (*SubscriptionDTO, error) {
+	id, err := domain.ParseSubscriptionID(query.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	sub, err := h.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	dto := toSubscriptionDTO(sub)
+	return &dto, nil
+}
+
+func toSubscriptionDTO(s *domain.Subscription) SubscriptionDTO {
+	eventTypes := make([]string, len(s.EventTypes()))
+	for i, t := range s.EventTypes() {
+		eventTypes[i] = t.String()
+	}
+	return SubscriptionDTO{
+		ID:          s.ID().String(),
+		CallbackURL: s.CallbackURL(),
+		EventTypes:  eventTypes,
+		CreatedAt:   s.CreatedAt(),
+	}
+}
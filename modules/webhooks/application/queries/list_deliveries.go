@@ -0,0 +1,95 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+// DeliveryDTO is a read model for a webhook delivery log entry.
+type DeliveryDTO struct {
+	ID             string     `json:"id"`
+	EventID        string     `json:"event_id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus int        `json:"response_status,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
+
+// DeliveryListDTO contains a paginated list of deliveries.
+type DeliveryListDTO struct {
+	Deliveries []DeliveryDTO `json:"deliveries"`
+	TotalCount int           `json:"total_count"`
+	Offset     int           `json:"offset"`
+	Limit      int           `json:"limit"`
+}
+
+// ListDeliveriesQuery retrieves the delivery log of a subscription.
+type ListDeliveriesQuery struct {
+	SubscriptionID string
+	Offset         int
+	Limit          int
+}
+
+type ListDeliveriesHandler struct {
+	subscriptions domain.SubscriptionRepository
+	deliveries    domain.DeliveryRepository
+}
+
+func NewListDeliveriesHandler(subscriptions domain.SubscriptionRepository, deliveries domain.DeliveryRepository) *ListDeliveriesHandler {
+	return &ListDeliveriesHandler{subscriptions: subscriptions, deliveries: deliveries}
+}
+
+func (h *ListDeliveriesHandler) Handle(ctx context.Context, query ListDeliveriesQuery) (*DeliveryListDTO, error) {
+	id, err := domain.ParseSubscriptionID(query.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := h.subscriptions.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	p := page.NewRequest(query.Offset, query.Limit)
+	deliveries, total, err := h.deliveries.FindBySubscription(ctx, id, p.Offset, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]DeliveryDTO, len(deliveries))
+	for i, d := range deliveries {
+		dtos[i] = toDeliveryDTO(d)
+	}
+
+	return &DeliveryListDTO{
+		Deliveries: dtos,
+		TotalCount: total,
+		Offset:     p.Offset,
+		Limit:      p.Limit,
+	}, nil
+}
+
+func toDeliveryDTO(d *domain.Delivery) DeliveryDTO {
+	dto := DeliveryDTO{
+		ID:             d.ID().String(),
+		EventID:        d.EventID(),
+		EventType:      d.EventType().String(),
+		Status:         d.Status().String(),
+		Attempts:       d.Attempts(),
+		ResponseStatus: d.ResponseStatus(),
+		LastError:      d.LastError(),
+		CreatedAt:      d.CreatedAt(),
+	}
+	if next := d.NextAttemptAt(); !next.IsZero() {
+		dto.NextAttemptAt = &next
+	}
+	if delivered := d.DeliveredAt(); !delivered.IsZero() {
+		dto.DeliveredAt = &delivered
+	}
+	return dto
+}
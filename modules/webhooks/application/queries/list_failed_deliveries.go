@@ -0,0 +1,58 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+// FailedDeliveryDTO is a delivery whose retries are exhausted, with the
+// subscription it was for.
+type FailedDeliveryDTO struct {
+	SubscriptionID string `json:"subscription_id"`
+	DeliveryDTO
+}
+
+// FailedDeliveryListDTO contains a paginated list of failed deliveries.
+type FailedDeliveryListDTO struct {
+	Deliveries []FailedDeliveryDTO `json:"deliveries"`
+	TotalCount int                 `json:"total_count"`
+	Offset     int                 `json:"offset"`
+	Limit      int                 `json:"limit"`
+}
+
+// ListFailedDeliveriesQuery retrieves the deliveries of every subscription
+// whose retries are exhausted, for operators.
+type ListFailedDeliveriesQuery struct {
+	Offset int
+	Limit  int
+}
+
+type ListFailedDeliveriesHandler struct {
+	deliveries domain.DeliveryRepository
+}
+
+func NewListFailedDeliveriesHandler(deliveries domain.DeliveryRepository) *ListFailedDeliveriesHandler {
+	return &ListFailedDeliveriesHandler{deliveries: deliveries}
+}
+
+func (h *ListFailedDeliveriesHandler) Handle(ctx context.Context, query ListFailedDeliveriesQuery) (*FailedDeliveryListDTO, error) {
+	p := page.NewRequest(query.Offset, query.Limit)
+	deliveries, total, err := h.deliveries.FindByStatus(ctx, domain.DeliveryFailed, p.Offset, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]FailedDeliveryDTO, len(deliveries))
+	for i, d := range deliveries {
+		dtos[i] = FailedDeliveryDTO{SubscriptionID: d.SubscriptionID().String(), DeliveryDTO: toDeliveryDTO(d)}
+	}
+
+	return &FailedDeliveryListDTO{
+		Deliveries: dtos,
+		TotalCount: total,
+		Offset:     p.Offset,
+		Limit:      p.Limit,
+	}, nil
+}
@@ -0,0 +1,51 @@
+package queries
+
+import (
+	"context"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+// SubscriptionListDTO contains a paginated list of subscriptions.
+type SubscriptionListDTO struct {
+	Subscriptions []SubscriptionDTO `json:"subscriptions"`
+	TotalCount    int               `json:"total_count"`
+	Offset        int               `json:"offset"`
+	Limit         int               `json:"limit"`
+}
+
+// ListSubscriptionsQuery retrieves registered subscriptions.
+type ListSubscriptionsQuery struct {
+	Offset int
+	Limit  int
+}
+
+type ListSubscriptionsHandler struct {
+	repo domain.SubscriptionRepository
+}
+
+func NewListSubscriptionsHandler(repo domain.SubscriptionRepository) *ListSubscriptionsHandler {
+	return &ListSubscriptionsHandler{repo: repo}
+}
+
+func (h *ListSubscriptionsHandler) Handle(ctx context.Context, query ListSubscriptionsQuery) (*SubscriptionListDTO, error) {
+	p := page.NewRequest(query.Offset, query.Limit)
+
+	subs, total, err := h.repo.FindAll(ctx, p.Offset, p.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]SubscriptionDTO, len(subs))
+	for i, s := range subs {
+		dtos[i] = toSubscriptionDTO(s)
+	}
+
+	return &SubscriptionListDTO{
+		Subscriptions: dtos,
+		TotalCount:    total,
+		Offset:        p.Offset,
+		Limit:         p.Limit,
+	}, nil
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// DeliveryID identifies one event delivered to one subscription.
+type DeliveryID struct {
+	value string
+}
+
+// deliveryNamespace seeds deterministic delivery IDs.
+var deliveryNamespace = uuid.MustParse("0b8e6a3c-5f2d-4c71-9e4a-7d3b1f6c2a90")
+
+// DeliveryIDFor derives the ID of the delivery of event eventID to subscription.
+// Redelivering the same event yields the same ID, which is also sent to the
+// client so that it can discard duplicates.
+func DeliveryIDFor(subscription SubscriptionID, eventID string) DeliveryID {
+	return DeliveryID{value: uuid.NewSHA1(deliveryNamespace, []byte(subscription.String()+"/"+eventID)).String()}
+}
+
+func ParseDeliveryID(s string) (DeliveryID, error) {
+	if _, err := uuid.Parse(s); err != nil {
+		return DeliveryID{}, ErrInvalidDeliveryID
+	}
+	return DeliveryID{value: s}, nil
+}
+
+func (id DeliveryID) String() string { return id.value }
+func (id DeliveryID) IsZero() bool   { return id.value == "" }
+
+// DeliveryStatus tracks whether the callback accepted a delivery.
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryRetrying  DeliveryStatus = "retrying" // queued for another attempt after a failure
+	DeliveryFailed    DeliveryStatus = "failed"   // retries exhausted
+)
+
+func (s DeliveryStatus) String() string { return string(s) }
+
+// Delivery is the log entry for posting one event to one subscription.
+type Delivery struct {
+	id             DeliveryID
+	subscriptionID SubscriptionID
+	eventID        string
+	eventType      events.EventType
+	payload        []byte // request body, signed as is
+	status         DeliveryStatus
+	attempts       int
+	responseStatus int // HTTP status of the last attempt; zero if no response
+	lastError      string
+	nextAttemptAt  time.Time // zero unless queued for retry
+	createdAt      time.Time
+	deliveredAt    time.Time // zero until the callback accepts the delivery
+}
+
+// NewDelivery creates a pending delivery of payload for the given event.
+func NewDelivery(subscription SubscriptionID, eventID string, eventType events.EventType, payload []byte) *Delivery {
+	return &Delivery{
+		id:             DeliveryIDFor(subscription, eventID),
+		subscriptionID: subscription,
+		eventID:        eventID,
+		eventType:      eventType,
+		payload:        payload,
+		status:         DeliveryPending,
+		createdAt:      time.Now(),
+	}
+}
+
+// ReconstituteDelivery rebuilds a Delivery from persistence without validation.
+func ReconstituteDelivery(
+	id DeliveryID,
+	subscriptionID SubscriptionID,
+	eventID string,
+	eventType events.EventType,
+	payload []byte,
+	status DeliveryStatus,
+	attempts int,
+	responseStatus int,
+	lastError string,
+	nextAttemptAt, createdAt, deliveredAt time.Time,
+) *Delivery {
+	return &Delivery{
+		id:             id,
+		subscriptionID: subscriptionID,
+		eventID:        eventID,
+		eventType:      eventType,
+		payload:        payload,
+		status:         status,
+		attempts:       attempts,
+		responseStatus: responseStatus,
+		lastError:      lastError,
+		nextAttemptAt:  nextAttemptAt,
+		createdAt:      createdAt,
+		deliveredAt:    deliveredAt,
+	}
+}
+
+func (d *Delivery) ID() DeliveryID                 { return d.id }
+func (d *Delivery) SubscriptionID() SubscriptionID { return d.subscriptionID }
+func (d *Delivery) EventID() string                { return d.eventID }
+func (d *Delivery) EventType() events.EventType    { return d.eventType }
+func (d *Delivery) Payload() []byte                { return d.payload }
+func (d *Delivery) Status() DeliveryStatus         { return d.status }
+func (d *Delivery) Attempts() int                  { return d.attempts }
+func (d *Delivery) ResponseStatus() int            { return d.responseStatus }
+func (d *Delivery) LastError() string              { return d.lastError }
+func (d *Delivery) NextAttemptAt() time.Time       { return d.nextAttemptAt }
+func (d *Delivery) CreatedAt() time.Time           { return d.createdAt }
+func (d *Delivery) DeliveredAt() time.Time         { return d.deliveredAt }
+
+// MarkSucceeded records that the callback accepted the delivery.
+func (d *Delivery) MarkSucceeded(responseStatus int) {
+	d.status = DeliverySucceeded
+	d.attempts++
+	d.responseStatus = responseStatus
+	d.lastError = ""
+	d.nextAttemptAt = time.Time{}
+	d.deliveredAt = time.Now()
+}
+
+// ScheduleRetry records a failed attempt and queues the delivery for another
+// one after the policy's backoff. Once policy.MaxAttempts is reached the
+// delivery is marked failed instead, and false is returned.
+// responseStatus is zero if the callback could not be reached.
+func (d *Delivery) ScheduleRetry(cause error, responseStatus int, policy RetryPolicy, now time.Time) bool {
+	d.attempts++
+	d.responseStatus = responseStatus
+	d.lastError = cause.Error()
+	if d.attempts >= policy.MaxAttempts {
+		d.status = DeliveryFailed
+		d.nextAttemptAt = time.Time{}
+		return false
+	}
+	d.status = DeliveryRetrying
+	d.nextAttemptAt = now.Add(policy.Backoff(d.attempts))
+	return true
+}
+
+// ClaimRetry reserves a due retry for the caller by pushing its next attempt
+// lease into the future, so that other workers skip it while it is in flight.
+// If the caller crashes, the delivery becomes due again once the lease expires.
+func (d *Delivery) ClaimRetry(now time.Time, lease time.Duration) error {
+	if d.status != DeliveryRetrying || d.nextAttemptAt.After(now) {
+		return ErrRetryNotDue
+	}
+	d.nextAttemptAt = now.Add(lease)
+	return nil
+}
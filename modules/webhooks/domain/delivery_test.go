@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+func TestDeliveryIDFor_IsDeterministic(t *testing.T) {
+	sub := domain.NewSubscriptionID()
+
+	if domain.DeliveryIDFor(sub, "evt-1") != domain.DeliveryIDFor(sub, "evt-1") {
+		t.Error("expected equal IDs for the same subscription and event")
+	}
+	if domain.DeliveryIDFor(sub, "evt-1") == domain.DeliveryIDFor(domain.NewSubscriptionID(), "evt-1") {
+		t.Error("expected different IDs for different subscriptions")
+	}
+}
+
+func TestDelivery_RetryLifecycle(t *testing.T) {
+	d := domain.NewDelivery(domain.NewSubscriptionID(), "evt-1", "orders.OrderSubmitted", []byte(`{}`))
+	policy := domain.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	now := time.Now()
+
+	if !d.ScheduleRetry(domain.ErrCallbackRejected, 503, policy, now) {
+		t.Fatal("expected first failure to schedule a retry")
+	}
+	if d.Status() != domain.DeliveryRetrying || d.Attempts() != 1 || d.ResponseStatus() != 503 || !d.NextAttemptAt().Equal(now.Add(time.Minute)) {
+		t.Errorf("unexpected retrying state: status=%s attempts=%d response=%d next=%v", d.Status(), d.Attempts(), d.ResponseStatus(), d.NextAttemptAt())
+	}
+
+	if err := d.ClaimRetry(now, time.Minute); !errors.Is(err, domain.ErrRetryNotDue) {
+		t.Errorf("expected ErrRetryNotDue before the backoff elapses, got %v", err)
+	}
+	due := now.Add(time.Minute)
+	if err := d.ClaimRetry(due, time.Minute); err != nil {
+		t.Fatalf("unexpected error claiming due retry: %v", err)
+	}
+
+	d.MarkSucceeded(204)
+	if d.Status() != domain.DeliverySucceeded || d.Attempts() != 2 || d.LastError() != "" || d.DeliveredAt().IsZero() || !d.NextAttemptAt().IsZero() {
+		t.Errorf("unexpected succeeded state: status=%s attempts=%d error=%q", d.Status(), d.Attempts(), d.LastError())
+	}
+}
+
+func TestDelivery_ScheduleRetry_GivesUpAtMaxAttempts(t *testing.T) {
+	d := domain.NewDelivery(domain.NewSubscriptionID(), "evt-1", "orders.OrderSubmitted", []byte(`{}`))
+	policy := domain.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	cause := errors.New("connection refused")
+
+	d.ScheduleRetry(cause, 0, policy, time.Now())
+	if d.ScheduleRetry(cause, 0, policy, time.Now()) {
+		t.Fatal("expected retries to stop at MaxAttempts")
+	}
+	if d.Status() != domain.DeliveryFailed || d.Attempts() != 2 || !d.NextAttemptAt().IsZero() {
+		t.Errorf("unexpected failed state: status=%s attempts=%d next=%v", d.Status(), d.Attempts(), d.NextAttemptAt())
+	}
+}
@@ -0,0 +1,20 @@
+// Package domain contains the webhook subscription and delivery model.
+package domain
+
+import "errors"
+
+// Domain errors - business rule violations.
+var (
+	// Subscription errors
+	ErrSubscriptionNotFound  = errors.New("webhook subscription not found")
+	ErrInvalidSubscriptionID = errors.New("invalid webhook subscription ID format")
+	ErrCallbackURLInvalid    = errors.New("callback URL must be an absolute http or https URL")
+	ErrEventTypesRequired    = errors.New("at least one event type is required")
+	ErrEventTypeNotSupported = errors.New("event type is not available for webhooks")
+
+	// Delivery errors
+	ErrDeliveryNotFound  = errors.New("webhook delivery not found")
+	ErrInvalidDeliveryID = errors.New("invalid webhook delivery ID format")
+	ErrRetryNotDue       = errors.New("webhook delivery retry is not due")
+	ErrCallbackRejected  = errors.New("callback responded with a non-2xx status")
+)
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// SubscriptionRepository persists webhook subscriptions, scoped to the tenant
+// of the request of its context.
+type SubscriptionRepository interface {
+	Save(ctx context.Context, s *Subscription) error
+	// Delete removes a subscription and its delivery log.
+	Delete(ctx context.Context, id SubscriptionID) error
+	// FindByID returns ErrSubscriptionNotFound if the subscription does not exist.
+	FindByID(ctx context.Context, id SubscriptionID) (*Subscription, error)
+	// FindAll returns subscriptions, oldest first, with the total count.
+	FindAll(ctx context.Context, offset, limit int) ([]*Subscription, int, error)
+	// FindByEventType returns every subscription that wants events of eventType.
+	FindByEventType(ctx context.Context, eventType events.EventType) ([]*Subscription, error)
+}
+
+// DeliveryRepository persists the webhook delivery log. Every method but
+// FindDueRetries is scoped to the tenant of the request of its context.
+type DeliveryRepository interface {
+	// Save inserts or replaces a delivery.
+	Save(ctx context.Context, d *Delivery) error
+	// FindByID returns ErrDeliveryNotFound if the delivery does not exist.
+	FindByID(ctx context.Context, subscription SubscriptionID, id DeliveryID) (*Delivery, error)
+	// FindBySubscription returns a subscription's deliveries, newest first, with the total count.
+	FindBySubscription(ctx context.Context, subscription SubscriptionID, offset, limit int) ([]*Delivery, int, error)
+	// FindByStatus returns the deliveries with status, of every subscription,
+	// newest first, with the total count, e.g. the failed ones for operators.
+	FindByStatus(ctx context.Context, status DeliveryStatus, offset, limit int) ([]*Delivery, int, error)
+	// FindDueRetries returns up to limit deliveries queued for retry whose
+	// next attempt is due at now, oldest first, of every tenant: the retry
+	// job runs for them all.
+	FindDueRetries(ctx context.Context, now time.Time, limit int) ([]DueRetry, error)
+}
+
+// DueRetry identifies a delivery awaiting another attempt and the tenant it
+// belongs to, which retrying it must be scoped to.
+type DueRetry struct {
+	SubscriptionID SubscriptionID
+	DeliveryID     DeliveryID
+	TenantID       string
+}
@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+// RetryPolicy bounds redelivery of webhooks the callback did not accept.
+// The delay before attempt n+1 is BaseDelay * 2^(n-1), capped at MaxDelay.
+type RetryPolicy struct {
+	MaxAttempts int // total delivery attempts, including the first
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy keeps retrying for about a day, giving clients time to
+// recover from an outage before deliveries are abandoned.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 12,
+	BaseDelay:   time.Minute,
+	MaxDelay:    6 * time.Hour,
+}
+
+// Backoff returns the delay after the given number of failed attempts.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	return min(delay, p.MaxDelay)
+}
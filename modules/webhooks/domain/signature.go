@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// Headers sent with every delivery.
+const (
+	HeaderDeliveryID = "X-Webhook-Id"
+	HeaderEventType  = "X-Webhook-Event"
+	HeaderTimestamp  = "X-Webhook-Timestamp"
+	HeaderSignature  = "X-Webhook-Signature"
+)
+
+// Sign computes the value of the signature header for body sent at timestamp.
+// The signature is the hex HMAC-SHA256, keyed by the subscription secret, of
+// the Unix timestamp, a dot, and the raw body. Including the timestamp lets
+// clients reject replayed requests.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether signature is valid for body sent at timestamp.
+func VerifySignature(secret string, timestamp time.Time, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+)
+
+// SubscriptionID identifies a webhook subscription.
+type SubscriptionID struct {
+	value string
+}
+
+func NewSubscriptionID() SubscriptionID {
+	return SubscriptionID{value: uuid.New().String()}
+}
+
+func ParseSubscriptionID(s string) (SubscriptionID, error) {
+	if _, err := uuid.Parse(s); err != nil {
+		return SubscriptionID{}, ErrInvalidSubscriptionID
+	}
+	return SubscriptionID{value: s}, nil
+}
+
+func (id SubscriptionID) String() string { return id.value }
+func (id SubscriptionID) IsZero() bool   { return id.value == "" }
+
+// Subscription is an API client's request to receive events of the given
+// types as signed POST requests to a callback URL.
+type Subscription struct {
+	id          SubscriptionID
+	callbackURL string
+	eventTypes  []events.EventType
+	// secret is the HMAC key shared with the client for verifying deliveries.
+	secret    string
+	createdAt time.Time
+}
+
+// NewSubscription creates a subscription with a freshly generated signing secret.
+// Duplicate event types are dropped.
+func NewSubscription(callbackURL string, eventTypes []events.EventType) (*Subscription, error) {
+	u, err := url.Parse(callbackURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, ErrCallbackURLInvalid
+	}
+	if len(eventTypes) == 0 {
+		return nil, ErrEventTypesRequired
+	}
+
+	types := slices.Clone(eventTypes)
+	slices.Sort(types)
+	types = slices.Compact(types)
+
+	return &Subscription{
+		id:          NewSubscriptionID(),
+		callbackURL: u.String(),
+		eventTypes:  types,
+		secret:      newSecret(),
+		createdAt:   time.Now(),
+	}, nil
+}
+
+// ReconstituteSubscription rebuilds a Subscription from persistence without validation.
+func ReconstituteSubscription(id SubscriptionID, callbackURL string, eventTypes []events.EventType, secret string, createdAt time.Time) *Subscription {
+	return &Subscription{
+		id:          id,
+		callbackURL: callbackURL,
+		eventTypes:  eventTypes,
+		secret:      secret,
+		createdAt:   createdAt,
+	}
+}
+
+func (s *Subscription) ID() SubscriptionID             { return s.id }
+func (s *Subscription) CallbackURL() string            { return s.callbackURL }
+func (s *Subscription) EventTypes() []events.EventType { return s.eventTypes }
+func (s *Subscription) Secret() string                 { return s.secret }
+func (s *Subscription) CreatedAt() time.Time           { return s.createdAt }
+
+// Matches reports whether the subscription wants events of the given type.
+func (s *Subscription) Matches(eventType events.EventType) bool {
+	return slices.Contains(s.eventTypes, eventType)
+}
+
+// newSecret returns 32 random bytes, hex encoded and prefixed for recognizability.
+func newSecret() string {
+	b := make([]byte, 32)
+	rand.Read(b)
+	return "whsec_" + hex.EncodeToString(b)
+}
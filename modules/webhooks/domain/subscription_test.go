@@ -0,0 +1,80 @@
+package domain_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+func TestNewSubscription_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		eventTypes []events.EventType
+		wantErr    error
+	}{
+		{"valid https", "https://example.com/hooks", []events.EventType{"orders.OrderSubmitted"}, nil},
+		{"valid http with port", "http://localhost:9000/hooks", []events.EventType{"users.UserCreated"}, nil},
+		{"relative URL", "/hooks", []events.EventType{"users.UserCreated"}, domain.ErrCallbackURLInvalid},
+		{"unsupported scheme", "ftp://example.com/hooks", []events.EventType{"users.UserCreated"}, domain.ErrCallbackURLInvalid},
+		{"no event types", "https://example.com/hooks", nil, domain.ErrEventTypesRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.NewSubscription(tt.url, tt.eventTypes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNewSubscription_GeneratesSecretAndDedupesEventTypes(t *testing.T) {
+	sub, err := domain.NewSubscription("https://example.com/hooks", []events.EventType{"users.UserCreated", "orders.OrderSubmitted", "users.UserCreated"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(sub.Secret(), "whsec_") || len(sub.Secret()) != len("whsec_")+64 {
+		t.Errorf("unexpected secret format: %q", sub.Secret())
+	}
+	other, _ := domain.NewSubscription("https://example.com/hooks", []events.EventType{"users.UserCreated"})
+	if other.Secret() == sub.Secret() {
+		t.Error("expected every subscription to get its own secret")
+	}
+
+	want := []events.EventType{"orders.OrderSubmitted", "users.UserCreated"}
+	if !slices.Equal(sub.EventTypes(), want) {
+		t.Errorf("event types = %v, want %v", sub.EventTypes(), want)
+	}
+	if !sub.Matches("users.UserCreated") || sub.Matches("orders.OrderCancelled") {
+		t.Error("unexpected Matches result")
+	}
+}
+
+func TestSign_VerifiesOnlyUnmodifiedRequests(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	body := []byte(`{"id":"evt-1"}`)
+	sig := domain.Sign("whsec_test", ts, body)
+
+	if !strings.HasPrefix(sig, "sha256=") {
+		t.Errorf("unexpected signature format: %q", sig)
+	}
+	if !domain.VerifySignature("whsec_test", ts, body, sig) {
+		t.Error("expected signature to verify")
+	}
+	if domain.VerifySignature("whsec_other", ts, body, sig) {
+		t.Error("expected signature with another secret to fail")
+	}
+	if domain.VerifySignature("whsec_test", ts.Add(time.Second), body, sig) {
+		t.Error("expected signature with another timestamp to fail")
+	}
+	if domain.VerifySignature("whsec_test", ts, []byte(`{"id":"evt-2"}`), sig) {
+		t.Error("expected signature over a modified body to fail")
+	}
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+	"net/http"
+)
+
+// Request is a signed delivery ready to be posted to a callback URL.
+type Request struct {
+	URL     string
+	Headers http.Header
+	Body    []byte
+}
+
+// Transport posts deliveries to callback URLs.
+type Transport interface {
+	// Post sends req and returns the response status code. A non-nil error
+	// means no response was received; non-2xx responses are not errors.
+	Post(ctx context.Context, req Request) (statusCode int, err error)
+}
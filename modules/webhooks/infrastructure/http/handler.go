@@ -0,0 +1,184 @@
+// Package http provides HTTP handlers for the webhooks module.
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/page"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+type Handler struct {
+	register          command.Handler[commands.RegisterSubscriptionCommand, commands.RegisterSubscriptionResult]
+	deleteSub         command.VoidHandler[commands.DeleteSubscriptionCommand]
+	getSubscription   *queries.GetSubscriptionHandler
+	listSubscriptions *queries.ListSubscriptionsHandler
+	listDeliveries    *queries.ListDeliveriesHandler
+	eventTypes        []string
+	admin             security.AdminGuard
+}
+
+// RegisterRoutes registers the webhooks module routes to the given mux.
+// Every route requires the admin token; they are disabled when it is empty.
+func RegisterRoutes(
+	mux *http.ServeMux,
+	register command.Handler[commands.RegisterSubscriptionCommand, commands.RegisterSubscriptionResult],
+	deleteSub command.VoidHandler[commands.DeleteSubscriptionCommand],
+	getSubscription *queries.GetSubscriptionHandler,
+	listSubscriptions *queries.ListSubscriptionsHandler,
+	listDeliveries *queries.ListDeliveriesHandler,
+	eventTypes []events.EventType,
+	admin security.AdminGuard,
+) {
+	h := &Handler{
+		register:          register,
+		deleteSub:         deleteSub,
+		getSubscription:   getSubscription,
+		listSubscriptions: listSubscriptions,
+		listDeliveries:    listDeliveries,
+		admin:             admin,
+	}
+	for _, t := range eventTypes {
+		h.eventTypes = append(h.eventTypes, t.String())
+	}
+	slices.Sort(h.eventTypes)
+
+	mux.HandleFunc("GET /webhooks/event-types", h.admin.HandlerFunc(h.handleListEventTypes))
+	mux.HandleFunc("POST /webhooks/subscriptions", h.admin.HandlerFunc(h.handleRegisterSubscription))
+	mux.HandleFunc("GET /webhooks/subscriptions", h.admin.HandlerFunc(h.handleListSubscriptions))
+	mux.HandleFunc("GET /webhooks/subscriptions/{id}", h.admin.HandlerFunc(h.handleGetSubscription))
+	mux.HandleFunc("DELETE /webhooks/subscriptions/{id}", h.admin.HandlerFunc(h.handleDeleteSubscription))
+	mux.HandleFunc("GET /webhooks/subscriptions/{id}/deliveries", h.admin.HandlerFunc(h.handleListDeliveries))
+}
+
+// Request/Response DTOs
+
+type registerSubscriptionRequest struct {
+	CallbackURL string   `json:"callback_url"`
+	EventTypes  []string `json:"event_types"`
+}
+
+type registerSubscriptionResponse struct {
+	ID     string `json:"id"`
+	Secret string `json:"secret"`
+}
+
+type eventTypesResponse struct {
+	EventTypes []string `json:"event_types"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handlers
+
+func (h *Handler) handleListEventTypes(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, eventTypesResponse{EventTypes: h.eventTypes})
+}
+
+func (h *Handler) handleRegisterSubscription(w http.ResponseWriter, r *http.Request) {
+	var req registerSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.register.Handle(r.Context(), commands.RegisterSubscriptionCommand{
+		CallbackURL: req.CallbackURL,
+		EventTypes:  req.EventTypes,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, registerSubscriptionResponse{ID: result.ID, Secret: result.Secret})
+}
+
+func (h *Handler) handleListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	p := page.ParseRequest(r.URL.Query())
+
+	result, err := h.listSubscriptions.Handle(r.Context(), queries.ListSubscriptionsQuery{
+		Offset: p.Offset,
+		Limit:  p.Limit,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) handleGetSubscription(w http.ResponseWriter, r *http.Request) {
+	result, err := h.getSubscription.Handle(r.Context(), queries.GetSubscriptionQuery{
+		SubscriptionID: r.PathValue("id"),
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *Handler) handleDeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	err := h.deleteSub.Handle(r.Context(), commands.DeleteSubscriptionCommand{
+		SubscriptionID: r.PathValue("id"),
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleListDeliveries(w http.ResponseWriter, r *http.Request) {
+	p := page.ParseRequest(r.URL.Query())
+
+	result, err := h.listDeliveries.Handle(r.Context(), queries.ListDeliveriesQuery{
+		SubscriptionID: r.PathValue("id"),
+		Offset:         p.Offset,
+		Limit:          p.Limit,
+	})
+	if err != nil {
+		handleError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func handleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSubscriptionNotFound):
+		writeError(w, http.StatusNotFound, err.Error())
+	case errors.Is(err, domain.ErrInvalidSubscriptionID),
+		errors.Is(err, domain.ErrCallbackURLInvalid),
+		errors.Is(err, domain.ErrEventTypesRequired),
+		errors.Is(err, domain.ErrEventTypeNotSupported):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, "internal server error")
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, errorResponse{Error: message})
+}
@@ -0,0 +1,25 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/application/queries"
+)
+
+var pageParams = []openapi.Param{
+	{Name: "offset", Type: "integer"},
+	{Name: "limit", Type: "integer"},
+}
+
+// Operations documents the routes registered by RegisterRoutes.
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Pattern: "GET /webhooks/event-types", Summary: "List the event types subscriptions can select", Admin: true, Response: eventTypesResponse{}},
+		{Pattern: "POST /webhooks/subscriptions", Summary: "Register a webhook subscription", Description: "The signing secret is only returned here.", Admin: true, Request: registerSubscriptionRequest{}, Status: http.StatusCreated, Response: registerSubscriptionResponse{}},
+		{Pattern: "GET /webhooks/subscriptions", Summary: "List webhook subscriptions", Admin: true, Query: pageParams, Response: queries.SubscriptionListDTO{}},
+		{Pattern: "GET /webhooks/subscriptions/{id}", Summary: "Get a webhook subscription", Admin: true, Response: queries.SubscriptionDTO{}},
+		{Pattern: "DELETE /webhooks/subscriptions/{id}", Summary: "Delete a webhook subscription", Admin: true, Status: http.StatusNoContent},
+		{Pattern: "GET /webhooks/subscriptions/{id}/deliveries", Summary: "List the deliveries of a subscription", Admin: true, Query: pageParams, Response: queries.DeliveryListDTO{}},
+	}
+}
@@ -0,0 +1,290 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+// SpannerDeliveryRepository implements DeliveryRepository using Cloud
+// Spanner. Deliveries carry the tenant of their subscription, and every read
+// but FindDueRetries is scoped to the tenant of its context.
+type SpannerDeliveryRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerDeliveryRepository(client *spanner.Client, logger *slog.Logger) *SpannerDeliveryRepository {
+	return &SpannerDeliveryRepository{client: client, logger: logger}
+}
+
+// Save upserts a delivery. Delivery IDs are derived from the source event,
+// so redelivery updates the existing entry.
+func (r *SpannerDeliveryRepository) Save(ctx context.Context, d *domain.Delivery) error {
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO WebhookDeliveries (SubscriptionID, DeliveryID, TenantID, EventID, EventType, Payload, Status, Attempts, ResponseStatus, LastError, NextAttemptAt, CreatedAt, DeliveredAt)
+		      VALUES (@subscriptionID, @deliveryID, @tenantID, @eventID, @eventType, @payload, @status, @attempts, @responseStatus, @lastError, @nextAttemptAt, @createdAt, @deliveredAt)`,
+		Params: map[string]interface{}{
+			"subscriptionID": d.SubscriptionID().String(),
+			"deliveryID":     d.ID().String(),
+			"tenantID":       requestcontext.TenantID(ctx),
+			"eventID":        d.EventID(),
+			"eventType":      d.EventType().String(),
+			"payload":        string(d.Payload()),
+			"status":         d.Status().String(),
+			"attempts":       int64(d.Attempts()),
+			"responseStatus": spanner.NullInt64{Int64: int64(d.ResponseStatus()), Valid: d.ResponseStatus() != 0},
+			"lastError":      spanner.NullString{StringVal: d.LastError(), Valid: d.LastError() != ""},
+			"nextAttemptAt":  nullTime(d.NextAttemptAt()),
+			"createdAt":      d.CreatedAt(),
+			"deliveredAt":    nullTime(d.DeliveredAt()),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerDeliveryRepository) FindByID(ctx context.Context, subscription domain.SubscriptionID, id domain.DeliveryID) (*domain.Delivery, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (*domain.Delivery, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT ` + deliveryColumns + `
+			      FROM WebhookDeliveries
+			      WHERE SubscriptionID = @subscriptionID AND DeliveryID = @deliveryID AND TenantID = @tenantID`,
+			Params: map[string]interface{}{
+				"subscriptionID": subscription.String(),
+				"deliveryID":     id.String(),
+				"tenantID":       requestcontext.TenantID(ctx),
+			},
+		})
+		defer iter.Stop()
+
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return nil, domain.ErrDeliveryNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query webhook delivery: %w", err)
+		}
+		return scanDelivery(row)
+	})
+}
+
+func (r *SpannerDeliveryRepository) FindBySubscription(ctx context.Context, subscription domain.SubscriptionID, offset, limit int) ([]*domain.Delivery, int, error) {
+	tenantID := requestcontext.TenantID(ctx)
+	var total int
+	deliveries, err := platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.Delivery, error) {
+		countIter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT COUNT(*) FROM WebhookDeliveries WHERE SubscriptionID = @subscriptionID AND TenantID = @tenantID`,
+			Params: map[string]interface{}{"subscriptionID": subscription.String(), "tenantID": tenantID},
+		})
+		defer countIter.Stop()
+
+		var totalCount int64
+		countRow, err := countIter.Next()
+		if err != nil && err != iterator.Done {
+			return nil, fmt.Errorf("failed to count webhook deliveries: %w", err)
+		}
+		if countRow != nil {
+			if err := countRow.Columns(&totalCount); err != nil {
+				return nil, fmt.Errorf("failed to scan count: %w", err)
+			}
+		}
+		total = int(totalCount)
+
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT ` + deliveryColumns + `
+			      FROM WebhookDeliveries@{FORCE_INDEX=WebhookDeliveriesBySubscriptionCreatedAt}
+			      WHERE SubscriptionID = @subscriptionID AND TenantID = @tenantID
+			      ORDER BY CreatedAt DESC
+			      LIMIT @limit OFFSET @offset`,
+			Params: map[string]interface{}{
+				"subscriptionID": subscription.String(),
+				"tenantID":       tenantID,
+				"limit":          int64(limit),
+				"offset":         int64(offset),
+			},
+		})
+		defer iter.Stop()
+
+		var deliveries []*domain.Delivery
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+			}
+			d, err := scanDelivery(row)
+			if err != nil {
+				return nil, err
+			}
+			deliveries = append(deliveries, d)
+		}
+		return deliveries, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+func (r *SpannerDeliveryRepository) FindByStatus(ctx context.Context, status domain.DeliveryStatus, offset, limit int) ([]*domain.Delivery, int, error) {
+	tenantID := requestcontext.TenantID(ctx)
+	var total int
+	deliveries, err := platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.Delivery, error) {
+		countIter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT COUNT(*) FROM WebhookDeliveries@{FORCE_INDEX=WebhookDeliveriesByTenantStatus} WHERE TenantID = @tenantID AND Status = @status`,
+			Params: map[string]interface{}{"tenantID": tenantID, "status": status.String()},
+		})
+		defer countIter.Stop()
+
+		var totalCount int64
+		countRow, err := countIter.Next()
+		if err != nil && err != iterator.Done {
+			return nil, fmt.Errorf("failed to count webhook deliveries: %w", err)
+		}
+		if countRow != nil {
+			if err := countRow.Columns(&totalCount); err != nil {
+				return nil, fmt.Errorf("failed to scan count: %w", err)
+			}
+		}
+		total = int(totalCount)
+
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT ` + deliveryColumns + `
+			      FROM WebhookDeliveries@{FORCE_INDEX=WebhookDeliveriesByTenantStatus}
+			      WHERE TenantID = @tenantID AND Status = @status
+			      ORDER BY CreatedAt DESC
+			      LIMIT @limit OFFSET @offset`,
+			Params: map[string]interface{}{
+				"tenantID": tenantID,
+				"status":   status.String(),
+				"limit":    int64(limit),
+				"offset":   int64(offset),
+			},
+		})
+		defer iter.Stop()
+
+		var deliveries []*domain.Delivery
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+			}
+			d, err := scanDelivery(row)
+			if err != nil {
+				return nil, err
+			}
+			deliveries = append(deliveries, d)
+		}
+		return deliveries, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, total, nil
+}
+
+// FindDueRetries scans the deliveries of every tenant.
+func (r *SpannerDeliveryRepository) FindDueRetries(ctx context.Context, now time.Time, limit int) ([]domain.DueRetry, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]domain.DueRetry, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL: `SELECT SubscriptionID, DeliveryID, TenantID
+			      FROM WebhookDeliveries@{FORCE_INDEX=WebhookDeliveriesByStatusNextAttemptAt}
+			      WHERE Status = @status AND NextAttemptAt <= @now
+			      ORDER BY NextAttemptAt
+			      LIMIT @limit`,
+			Params: map[string]interface{}{
+				"status": domain.DeliveryRetrying.String(),
+				"now":    now,
+				"limit":  int64(limit),
+			},
+		})
+		defer iter.Stop()
+
+		var due []domain.DueRetry
+		for {
+			row, err := iter.Next()
+			if err == iterator.Done {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to query due webhook retries: %w", err)
+			}
+
+			var subscriptionID, deliveryID, tenantID string
+			if err := row.Columns(&subscriptionID, &deliveryID, &tenantID); err != nil {
+				return nil, fmt.Errorf("failed to scan webhook delivery id: %w", err)
+			}
+			sid, err := domain.ParseSubscriptionID(subscriptionID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid subscription ID in database: %w", err)
+			}
+			did, err := domain.ParseDeliveryID(deliveryID)
+			if err != nil {
+				return nil, fmt.Errorf("invalid delivery ID in database: %w", err)
+			}
+			due = append(due, domain.DueRetry{SubscriptionID: sid, DeliveryID: did, TenantID: tenantID})
+		}
+		return due, nil
+	})
+}
+
+// deliveryColumns lists the columns read by scanDelivery, in order.
+const deliveryColumns = `SubscriptionID, DeliveryID, EventID, EventType, Payload, Status, Attempts, ResponseStatus, LastError, NextAttemptAt, CreatedAt, DeliveredAt`
+
+func scanDelivery(row *spanner.Row) (*domain.Delivery, error) {
+	var subscriptionID, deliveryID, eventID, eventType, payload, status string
+	var attempts int64
+	var responseStatus spanner.NullInt64
+	var lastError spanner.NullString
+	var nextAttemptAt, deliveredAt spanner.NullTime
+	var createdAt time.Time
+
+	if err := row.Columns(&subscriptionID, &deliveryID, &eventID, &eventType, &payload, &status, &attempts, &responseStatus, &lastError, &nextAttemptAt, &createdAt, &deliveredAt); err != nil {
+		return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+	}
+
+	sid, err := domain.ParseSubscriptionID(subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription ID in database: %w", err)
+	}
+	did, err := domain.ParseDeliveryID(deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid delivery ID in database: %w", err)
+	}
+
+	return domain.ReconstituteDelivery(
+		did,
+		sid,
+		eventID,
+		events.EventType(eventType),
+		[]byte(payload),
+		domain.DeliveryStatus(status),
+		int(attempts),
+		int(responseStatus.Int64),
+		lastError.StringVal,
+		nextAttemptAt.Time,
+		createdAt,
+		deliveredAt.Time,
+	), nil
+}
+
+// nullTime maps a zero time to a NULL column value.
+func nullTime(t time.Time) spanner.NullTime {
+	return spanner.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,185 @@
+// Package persistence implements repository interfaces for webhooks.
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"google.golang.org/api/iterator"
+
+	platformspanner "github.com/rai/clean-modularmonolith-go/internal/platform/spanner"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/requestcontext"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+// SpannerSubscriptionRepository implements SubscriptionRepository using Cloud
+// Spanner. Subscriptions carry the tenant of the request that registered
+// them, and every read and delete is scoped to the tenant of its context, so
+// a tenant's events only reach its own subscriptions.
+type SpannerSubscriptionRepository struct {
+	client *spanner.Client
+	logger *slog.Logger
+}
+
+func NewSpannerSubscriptionRepository(client *spanner.Client, logger *slog.Logger) *SpannerSubscriptionRepository {
+	return &SpannerSubscriptionRepository{client: client, logger: logger}
+}
+
+func (r *SpannerSubscriptionRepository) Save(ctx context.Context, s *domain.Subscription) error {
+	eventTypes := make([]string, len(s.EventTypes()))
+	for i, t := range s.EventTypes() {
+		eventTypes[i] = t.String()
+	}
+
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL: `INSERT OR UPDATE INTO WebhookSubscriptions (SubscriptionID, TenantID, CallbackURL, EventTypes, Secret, CreatedAt)
+		      VALUES (@subscriptionID, @tenantID, @callbackURL, @eventTypes, @secret, @createdAt)`,
+		Params: map[string]interface{}{
+			"subscriptionID": s.ID().String(),
+			"tenantID":       requestcontext.TenantID(ctx),
+			"callbackURL":    s.CallbackURL(),
+			"eventTypes":     eventTypes,
+			"secret":         s.Secret(),
+			"createdAt":      s.CreatedAt(),
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to save webhook subscription: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the subscription; its deliveries are removed by ON DELETE CASCADE.
+func (r *SpannerSubscriptionRepository) Delete(ctx context.Context, id domain.SubscriptionID) error {
+	if err := platformspanner.Write(ctx, spanner.Statement{
+		SQL:    `DELETE FROM WebhookSubscriptions WHERE SubscriptionID = @subscriptionID AND TenantID = @tenantID`,
+		Params: map[string]interface{}{"subscriptionID": id.String(), "tenantID": requestcontext.TenantID(ctx)},
+	}); err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *SpannerSubscriptionRepository) FindByID(ctx context.Context, id domain.SubscriptionID) (*domain.Subscription, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) (*domain.Subscription, error) {
+		iter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT ` + subscriptionColumns + ` FROM WebhookSubscriptions WHERE SubscriptionID = @subscriptionID AND TenantID = @tenantID`,
+			Params: map[string]interface{}{"subscriptionID": id.String(), "tenantID": requestcontext.TenantID(ctx)},
+		})
+		defer iter.Stop()
+
+		row, err := iter.Next()
+		if err == iterator.Done {
+			return nil, domain.ErrSubscriptionNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query webhook subscription: %w", err)
+		}
+		return scanSubscription(row)
+	})
+}
+
+func (r *SpannerSubscriptionRepository) FindAll(ctx context.Context, offset, limit int) ([]*domain.Subscription, int, error) {
+	tenantID := requestcontext.TenantID(ctx)
+	var total int
+	subs, err := platformspanner.ConsistentRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.Subscription, error) {
+		countIter := reader.Query(ctx, spanner.Statement{
+			SQL:    `SELECT COUNT(*) FROM WebhookSubscriptions WHERE TenantID = @tenantID`,
+			Params: map[string]interface{}{"tenantID": tenantID},
+		})
+		defer countIter.Stop()
+
+		var totalCount int64
+		countRow, err := countIter.Next()
+		if err != nil && err != iterator.Done {
+			return nil, fmt.Errorf("failed to count webhook subscriptions: %w", err)
+		}
+		if countRow != nil {
+			if err := countRow.Columns(&totalCount); err != nil {
+				return nil, fmt.Errorf("failed to scan count: %w", err)
+			}
+		}
+		total = int(totalCount)
+
+		return querySubscriptions(ctx, reader, spanner.Statement{
+			SQL: `SELECT ` + subscriptionColumns + `
+			      FROM WebhookSubscriptions@{FORCE_INDEX=WebhookSubscriptionsByTenantCreatedAt}
+			      WHERE TenantID = @tenantID
+			      ORDER BY CreatedAt
+			      LIMIT @limit OFFSET @offset`,
+			Params: map[string]interface{}{
+				"tenantID": tenantID,
+				"limit":    int64(limit),
+				"offset":   int64(offset),
+			},
+		})
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return subs, total, nil
+}
+
+// FindByEventType scans every subscription of the tenant. The table holds one
+// row per registered client endpoint, so it stays small enough not to need an
+// index.
+func (r *SpannerSubscriptionRepository) FindByEventType(ctx context.Context, eventType events.EventType) ([]*domain.Subscription, error) {
+	return platformspanner.SingleRead(ctx, r.client, r.logger, func(ctx context.Context, reader platformspanner.ReadTransaction) ([]*domain.Subscription, error) {
+		return querySubscriptions(ctx, reader, spanner.Statement{
+			SQL: `SELECT ` + subscriptionColumns + `
+			      FROM WebhookSubscriptions
+			      WHERE TenantID = @tenantID AND @eventType IN UNNEST(EventTypes)`,
+			Params: map[string]interface{}{"tenantID": requestcontext.TenantID(ctx), "eventType": eventType.String()},
+		})
+	})
+}
+
+// subscriptionColumns lists the columns read by scanSubscription, in order.
+const subscriptionColumns = `SubscriptionID, CallbackURL, EventTypes, Secret, CreatedAt`
+
+func querySubscriptions(ctx context.Context, reader platformspanner.ReadTransaction, stmt spanner.Statement) ([]*domain.Subscription, error) {
+	iter := reader.Query(ctx, stmt)
+	defer iter.Stop()
+
+	var subs []*domain.Subscription
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to query webhook subscriptions: %w", err)
+		}
+		s, err := scanSubscription(row)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, s)
+	}
+	return subs, nil
+}
+
+func scanSubscription(row *spanner.Row) (*domain.Subscription, error) {
+	var id, callbackURL, secret string
+	var eventTypes []string
+	var createdAt time.Time
+
+	if err := row.Columns(&id, &callbackURL, &eventTypes, &secret, &createdAt); err != nil {
+		return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+	}
+
+	subscriptionID, err := domain.ParseSubscriptionID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subscription ID in database: %w", err)
+	}
+
+	types := make([]events.EventType, len(eventTypes))
+	for i, t := range eventTypes {
+		types[i] = events.EventType(t)
+	}
+
+	return domain.ReconstituteSubscription(subscriptionID, callbackURL, types, secret, createdAt), nil
+}
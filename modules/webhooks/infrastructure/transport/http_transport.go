@@ -0,0 +1,47 @@
+// Package transport posts webhook deliveries over HTTP.
+package transport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+// HTTPTransport posts deliveries with a bounded timeout and without following
+// redirects, so a callback cannot bounce signed payloads to another host.
+type HTTPTransport struct {
+	client *http.Client
+}
+
+func NewHTTPTransport(timeout time.Duration) *HTTPTransport {
+	return &HTTPTransport{client: &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}}
+}
+
+func (t *HTTPTransport) Post(ctx context.Context, req domain.Request) (int, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return 0, fmt.Errorf("building webhook request: %w", err)
+	}
+	httpReq.Header = req.Headers.Clone()
+	httpReq.Header.Set("User-Agent", "clean-modularmonolith-webhooks/1.0")
+
+	resp, err := t.client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("posting webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	// Drain a bounded amount so the connection can be reused.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,54 @@
+package transport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+)
+
+// InstrumentedTransport records the outcome and latency of every delivery
+// attempt made through another Transport.
+type InstrumentedTransport struct {
+	next     domain.Transport
+	attempts *prometheus.CounterVec
+	duration prometheus.Histogram
+}
+
+// NewInstrumentedTransport wraps next and registers its collectors with reg.
+func NewInstrumentedTransport(next domain.Transport, reg prometheus.Registerer) (*InstrumentedTransport, error) {
+	t := &InstrumentedTransport{
+		next: next,
+		attempts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "webhooks_delivery_attempts_total",
+			Help: "Webhook delivery attempts, by response status class (2xx-5xx) or error.",
+		}, []string{"outcome"}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "webhooks_delivery_duration_seconds",
+			Help:    "Time until the callback responded or the attempt failed.",
+			Buckets: prometheus.DefBuckets,
+		}),
+	}
+	for _, c := range []prometheus.Collector{t.attempts, t.duration} {
+		if err := reg.Register(c); err != nil {
+			return nil, fmt.Errorf("registering webhook metrics: %w", err)
+		}
+	}
+	return t, nil
+}
+
+func (t *InstrumentedTransport) Post(ctx context.Context, req domain.Request) (int, error) {
+	start := time.Now()
+	status, err := t.next.Post(ctx, req)
+	t.duration.Observe(time.Since(start).Seconds())
+
+	outcome := "error"
+	if err == nil {
+		outcome = fmt.Sprintf("%dxx", status/100)
+	}
+	t.attempts.WithLabelValues(outcome).Inc()
+	return status, err
+}
@@ -0,0 +1,218 @@
+// Package webhooks delivers domain events to external consumers that
+// register callback URLs, with signed requests, retries and a delivery log.
+package webhooks
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/command"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/lifecycle"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+	"github.com/rai/clean-modularmonolith-go/modules/shared/transaction"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/application/commands"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/application/eventhandlers"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/application/queries"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/domain"
+	httphandler "github.com/rai/clean-modularmonolith-go/modules/webhooks/infrastructure/http"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/infrastructure/scheduler"
+	"github.com/rai/clean-modularmonolith-go/modules/webhooks/infrastructure/transport"
+)
+
+// Module is the public API for the webhooks bounded context.
+// External communication: HTTP API (RegisterRoutes) and outbound webhooks
+// Cross-module communication: Domain Events (subscribed internally)
+type Module interface {
+	// RegisterRoutes registers the module's HTTP routes to the given mux.
+	RegisterRoutes(mux *http.ServeMux)
+	// Operations documents the routes for the OpenAPI specification.
+	Operations() []openapi.Operation
+
+	// FailedDeliveries returns a page of the deliveries whose retries are
+	// exhausted, newest first, and their total number, for operators (the
+	// admin module). limit is capped at 100; zero means 20.
+	FailedDeliveries(ctx context.Context, offset, limit int) (deliveries []FailedDelivery, total int, err error)
+
+	// Start launches the retry job; Shutdown stops it and the deliverer.
+	lifecycle.Hooks
+}
+
+// FailedDelivery is a webhook delivery whose retries are exhausted, as
+// returned to other modules.
+type FailedDelivery struct {
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Attempts       int
+	ResponseStatus int
+	LastError      string
+	CreatedAt      time.Time
+}
+
+type Config struct {
+	SubscriptionRepository    domain.SubscriptionRepository
+	DeliveryRepository        domain.DeliveryRepository
+	TransactionScope          transaction.Scope
+	PostCommitEventSubscriber events.PostCommitSubscriber
+	Logger                    *slog.Logger
+
+	// Transport posts deliveries. Defaults to an HTTP client with a 10 second timeout.
+	Transport domain.Transport
+
+	// AdminToken guards the management endpoints via the X-Admin-Token
+	// header. The endpoints are disabled when empty.
+	AdminToken string
+
+	// SecuritySink receives the admin token and authorization decisions
+	// made by the HTTP handlers. Optional: nil records nothing.
+	SecuritySink security.Sink
+
+	Retry RetryConfig
+
+	// CommandBus, when set, applies the platform's middleware (tracing,
+	// recording for the audit log and metrics, validation) to every command.
+	CommandBus *command.Bus
+
+	// MetricsRegisterer, when set, receives the module's delivery metrics.
+	MetricsRegisterer prometheus.Registerer
+}
+
+// RetryConfig configures redelivery of webhooks the callback did not accept.
+// Zero fields fall back to domain.DefaultRetryPolicy and the defaults below.
+type RetryConfig struct {
+	MaxAttempts int           // total delivery attempts, including the first
+	BaseDelay   time.Duration // delay before the first retry; doubles after each failure
+	MaxDelay    time.Duration // upper bound on the delay between attempts
+	Interval    time.Duration // how often the worker scans the queue; defaults to 30 seconds
+	BatchSize   int           // maximum deliveries retried per run; defaults to 50
+}
+
+// policy returns the retry policy with defaults applied.
+func (c RetryConfig) policy() domain.RetryPolicy {
+	p := domain.DefaultRetryPolicy
+	if c.MaxAttempts > 0 {
+		p.MaxAttempts = c.MaxAttempts
+	}
+	if c.BaseDelay > 0 {
+		p.BaseDelay = c.BaseDelay
+	}
+	if c.MaxDelay > 0 {
+		p.MaxDelay = c.MaxDelay
+	}
+	return p
+}
+
+// retryLease hides a claimed retry from other instances while it is delivered.
+// It must exceed the transport timeout.
+const retryLease = 2 * time.Minute
+
+type module struct {
+	register          command.Handler[commands.RegisterSubscriptionCommand, commands.RegisterSubscriptionResult]
+	deleteSub         command.VoidHandler[commands.DeleteSubscriptionCommand]
+	getSubscription   *queries.GetSubscriptionHandler
+	listSubscriptions *queries.ListSubscriptionsHandler
+	listDeliveries    *queries.ListDeliveriesHandler
+	listFailed        *queries.ListFailedDeliveriesHandler
+	eventTypes        []events.EventType
+	admin             security.AdminGuard
+	retries           *scheduler.RetryJob
+	stopRetries       func()
+	stopDeliverer     func()
+}
+
+// New initializes the webhooks module and subscribes to events.
+// The retry job runs from Start to Shutdown.
+func New(cfg Config) Module {
+	logger := cfg.Logger.With("module", "webhooks")
+
+	tr := cfg.Transport
+	if tr == nil {
+		tr = transport.NewHTTPTransport(10 * time.Second)
+	}
+	if cfg.MetricsRegisterer != nil {
+		if instrumented, err := transport.NewInstrumentedTransport(tr, cfg.MetricsRegisterer); err != nil {
+			logger.Error("failed to register webhook metrics", slog.Any("error", err))
+		} else {
+			tr = instrumented
+		}
+	}
+	eventTypes := eventhandlers.WebhookEventTypes()
+
+	deliverer, delivererCleanup := eventhandlers.NewDeliverer(cfg.DeliveryRepository, tr, cfg.TransactionScope, cfg.Retry.policy(), logger)
+
+	// Subscribe to events (post-commit: outbound HTTP calls must not hold DB transactions)
+	for eventType, build := range eventhandlers.WebhookPayloads {
+		forwarder := eventhandlers.NewEventForwarder(eventType, build, cfg.SubscriptionRepository, deliverer)
+		if err := cfg.PostCommitEventSubscriber.SubscribePostCommit(eventType, forwarder); err != nil {
+			logger.Error("failed to subscribe webhook forwarder", slog.String("event_type", eventType.String()), slog.Any("error", err))
+		}
+	}
+
+	// Background worker: redeliver webhooks queued after failures
+	interval := cfg.Retry.Interval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	batchSize := cfg.Retry.BatchSize
+	if batchSize <= 0 {
+		batchSize = 50
+	}
+	retryHandler := commands.NewRetryDueDeliveriesHandler(cfg.SubscriptionRepository, cfg.DeliveryRepository, cfg.TransactionScope, deliverer, logger)
+	return &module{
+		register:          command.Register[commands.RegisterSubscriptionCommand, commands.RegisterSubscriptionResult](cfg.CommandBus, "webhooks", commands.NewRegisterSubscriptionHandler(cfg.SubscriptionRepository, cfg.TransactionScope, eventTypes)),
+		deleteSub:         command.RegisterVoid[commands.DeleteSubscriptionCommand](cfg.CommandBus, "webhooks", commands.NewDeleteSubscriptionHandler(cfg.SubscriptionRepository, cfg.TransactionScope)),
+		getSubscription:   queries.NewGetSubscriptionHandler(cfg.SubscriptionRepository),
+		listSubscriptions: queries.NewListSubscriptionsHandler(cfg.SubscriptionRepository),
+		listDeliveries:    queries.NewListDeliveriesHandler(cfg.SubscriptionRepository, cfg.DeliveryRepository),
+		listFailed:        queries.NewListFailedDeliveriesHandler(cfg.DeliveryRepository),
+		eventTypes:        eventTypes,
+		admin:             security.AdminGuard{Module: "webhooks", Token: cfg.AdminToken, Sink: cfg.SecuritySink},
+		retries:           scheduler.NewRetryJob(retryHandler, interval, batchSize, retryLease, logger),
+		stopDeliverer:     delivererCleanup,
+	}
+}
+
+func (m *module) Start(ctx context.Context) error {
+	m.stopRetries = m.retries.Start()
+	return nil
+}
+
+func (m *module) Shutdown(ctx context.Context) error {
+	return lifecycle.Stop(ctx, m.stopRetries, m.stopDeliverer)
+}
+
+func (m *module) RegisterRoutes(mux *http.ServeMux) {
+	httphandler.RegisterRoutes(mux, m.register, m.deleteSub, m.getSubscription, m.listSubscriptions, m.listDeliveries, m.eventTypes, m.admin)
+}
+
+func (m *module) Operations() []openapi.Operation {
+	return httphandler.Operations()
+}
+
+func (m *module) FailedDeliveries(ctx context.Context, offset, limit int) ([]FailedDelivery, int, error) {
+	list, err := m.listFailed.Handle(ctx, queries.ListFailedDeliveriesQuery{Offset: offset, Limit: limit})
+	if err != nil {
+		return nil, 0, err
+	}
+	deliveries := make([]FailedDelivery, len(list.Deliveries))
+	for i, d := range list.Deliveries {
+		deliveries[i] = FailedDelivery{
+			ID:             d.ID,
+			SubscriptionID: d.SubscriptionID,
+			EventID:        d.EventID,
+			EventType:      d.EventType,
+			Attempts:       d.Attempts,
+			ResponseStatus: d.ResponseStatus,
+			LastError:      d.LastError,
+			CreatedAt:      d.CreatedAt,
+		}
+	}
+	return deliveries, list.TotalCount, nil
+}
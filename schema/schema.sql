@@ -1,27 +1,60 @@
 CREATE TABLE Users (
-    UserID     STRING(36) NOT NULL,
-    Email      STRING(320) NOT NULL,
-    FirstName  STRING(100) NOT NULL,
-    LastName   STRING(100) NOT NULL,
-    Status     STRING(20) NOT NULL,
-    CreatedAt  TIMESTAMP NOT NULL,
-    UpdatedAt  TIMESTAMP NOT NULL,
+    UserID         STRING(36) NOT NULL,
+    TenantID       STRING(63) NOT NULL DEFAULT (''),
+    Email          STRING(320) NOT NULL,
+    CanonicalEmail STRING(320) NOT NULL,
+    FirstName      STRING(100) NOT NULL,
+    LastName       STRING(100) NOT NULL,
+    Status         STRING(20) NOT NULL,
+    Locale         STRING(10) NOT NULL DEFAULT ('en'),
+    MutedChannels  ARRAY<STRING(20)>,
+    PasswordHash   STRING(200),
+    CreatedAt      TIMESTAMP NOT NULL,
+    UpdatedAt      TIMESTAMP NOT NULL,
 ) PRIMARY KEY (UserID);
 
-CREATE UNIQUE INDEX UsersByEmail ON Users(Email);
+CREATE UNIQUE INDEX UsersByEmail ON Users(TenantID, Email);
+
+CREATE UNIQUE INDEX UsersByCanonicalEmail ON Users(TenantID, CanonicalEmail);
 
 CREATE TABLE Orders (
-    OrderID       STRING(36) NOT NULL,
-    UserID        STRING(36) NOT NULL,
-    Status        STRING(20) NOT NULL,
-    TotalAmount   INT64 NOT NULL,
-    TotalCurrency STRING(3) NOT NULL,
-    CreatedAt     TIMESTAMP NOT NULL,
-    UpdatedAt     TIMESTAMP NOT NULL,
+    OrderID              STRING(36) NOT NULL,
+    TenantID             STRING(63) NOT NULL DEFAULT (''),
+    UserID               STRING(36) NOT NULL,
+    Status               STRING(20) NOT NULL,
+    TotalAmount          INT64 NOT NULL,
+    TotalCurrency        STRING(3) NOT NULL,
+    ShippingRecipient    STRING(200),
+    ShippingLine1        STRING(200),
+    ShippingLine2        STRING(200),
+    ShippingCity         STRING(200),
+    ShippingRegion       STRING(200),
+    ShippingPostalCode   STRING(200),
+    ShippingCountry      STRING(2),
+    DeliveryInstructions STRING(500),
+    DiscountCode         STRING(32),
+    DiscountKind         STRING(20),
+    DiscountPercentOff   INT64,
+    DiscountAmountOff    INT64,
+    DiscountCurrency     STRING(3),
+    ReturnReason         STRING(500),
+    CancelReason         STRING(500),
+    CancelledByKind      STRING(20),
+    CancelledByID        STRING(100),
+    CreatedAt            TIMESTAMP NOT NULL,
+    UpdatedAt            TIMESTAMP NOT NULL,
 ) PRIMARY KEY (OrderID);
 
 CREATE INDEX OrdersByUserID ON Orders(UserID);
 
+CREATE INDEX OrdersByUserIDStatus ON Orders(UserID, Status, CreatedAt DESC);
+
+CREATE INDEX OrdersByCreatedAt ON Orders(CreatedAt DESC);
+
+CREATE INDEX OrdersByStatusCreatedAt ON Orders(Status, CreatedAt DESC);
+
+CREATE INDEX OrdersByStatusUpdatedAt ON Orders(Status, UpdatedAt);
+
 CREATE TABLE OrderItems (
     OrderID     STRING(36) NOT NULL,
     ItemIndex   INT64 NOT NULL,
@@ -32,3 +65,375 @@ CREATE TABLE OrderItems (
     Currency    STRING(3) NOT NULL,
 ) PRIMARY KEY (OrderID, ItemIndex),
   INTERLEAVE IN PARENT Orders ON DELETE CASCADE;
+
+CREATE TABLE OrderPromotionLines (
+    OrderID     STRING(36) NOT NULL,
+    LineIndex   INT64 NOT NULL,
+    PromotionID STRING(36) NOT NULL,
+    Name        STRING(100) NOT NULL,
+    Amount      INT64 NOT NULL,
+    Currency    STRING(3) NOT NULL,
+) PRIMARY KEY (OrderID, LineIndex),
+  INTERLEAVE IN PARENT Orders ON DELETE CASCADE;
+
+CREATE TABLE OrderTaxLines (
+    OrderID   STRING(36) NOT NULL,
+    LineIndex INT64 NOT NULL,
+    Name      STRING(100) NOT NULL,
+    RateBps   INT64 NOT NULL,
+    Amount    INT64 NOT NULL,
+    Currency  STRING(3) NOT NULL,
+) PRIMARY KEY (OrderID, LineIndex),
+  INTERLEAVE IN PARENT Orders ON DELETE CASCADE;
+
+CREATE TABLE OrderSnapshots (
+    OrderID        STRING(36) NOT NULL,
+    SubtotalAmount INT64 NOT NULL,
+    DiscountAmount INT64 NOT NULL,
+    TaxAmount      INT64 NOT NULL,
+    TotalAmount    INT64 NOT NULL,
+    Currency       STRING(3) NOT NULL,
+    TakenAt        TIMESTAMP NOT NULL,
+) PRIMARY KEY (OrderID),
+  INTERLEAVE IN PARENT Orders ON DELETE CASCADE;
+
+CREATE TABLE OrderSnapshotItems (
+    OrderID     STRING(36) NOT NULL,
+    ItemIndex   INT64 NOT NULL,
+    ProductID   STRING(36) NOT NULL,
+    ProductName STRING(200) NOT NULL,
+    Quantity    INT64 NOT NULL,
+    UnitAmount  INT64 NOT NULL,
+    Currency    STRING(3) NOT NULL,
+) PRIMARY KEY (OrderID, ItemIndex),
+  INTERLEAVE IN PARENT OrderSnapshots ON DELETE CASCADE;
+
+CREATE TABLE OrderEvents (
+    OrderID    STRING(36) NOT NULL,
+    Version    INT64 NOT NULL,
+    EventID    STRING(36) NOT NULL,
+    EventType  STRING(100) NOT NULL,
+    Payload    STRING(MAX) NOT NULL,
+    OccurredAt TIMESTAMP NOT NULL,
+) PRIMARY KEY (OrderID, Version);
+
+CREATE TABLE OrderEventSnapshots (
+    OrderID   STRING(36) NOT NULL,
+    Version   INT64 NOT NULL,
+    State     STRING(MAX) NOT NULL,
+    CreatedAt TIMESTAMP NOT NULL,
+) PRIMARY KEY (OrderID);
+
+CREATE TABLE OrdersInbox (
+    Handler    STRING(100) NOT NULL,
+    EventID    STRING(36) NOT NULL,
+    EventType  STRING(100) NOT NULL,
+    Payload    STRING(MAX) NOT NULL,
+    OccurredAt TIMESTAMP NOT NULL,
+    ReceivedAt TIMESTAMP NOT NULL,
+) PRIMARY KEY (Handler, EventID),
+  ROW DELETION POLICY (OLDER_THAN(ReceivedAt, INTERVAL 30 DAY));
+
+CREATE TABLE OrderStatusHistory (
+    OrderID      STRING(36) NOT NULL,
+    TransitionID STRING(36) NOT NULL,
+    FromStatus   STRING(20),
+    ToStatus     STRING(20) NOT NULL,
+    Actor        STRING(100),
+    Reason       STRING(500),
+    OccurredAt   TIMESTAMP NOT NULL,
+) PRIMARY KEY (OrderID, TransitionID),
+  INTERLEAVE IN PARENT Orders ON DELETE CASCADE;
+
+CREATE TABLE DiscountCodes (
+    TenantID   STRING(63) NOT NULL DEFAULT (''),
+    Code       STRING(32) NOT NULL,
+    Kind       STRING(20) NOT NULL,
+    PercentOff INT64,
+    AmountOff  INT64,
+    Currency   STRING(3),
+    ExpiresAt  TIMESTAMP,
+    MaxUses    INT64 NOT NULL,
+    UsedCount  INT64 NOT NULL,
+    CreatedAt  TIMESTAMP NOT NULL,
+    UpdatedAt  TIMESTAMP NOT NULL,
+) PRIMARY KEY (TenantID, Code);
+
+CREATE TABLE OrderSummaries (
+    OrderID     STRING(36) NOT NULL,
+    TenantID    STRING(63) NOT NULL DEFAULT (''),
+    UserID      STRING(36) NOT NULL,
+    UserEmail   STRING(320),
+    ItemCount   INT64 NOT NULL,
+    TotalAmount INT64 NOT NULL,
+    Currency    STRING(3) NOT NULL,
+    Status      STRING(20) NOT NULL,
+    CreatedAt   TIMESTAMP NOT NULL,
+    UpdatedAt   TIMESTAMP NOT NULL,
+) PRIMARY KEY (OrderID);
+
+CREATE INDEX OrderSummariesByUserID ON OrderSummaries(TenantID, UserID);
+
+CREATE INDEX OrderSummariesByCreatedAt ON OrderSummaries(TenantID, CreatedAt DESC);
+
+CREATE TABLE OrderSummaryUsers (
+    TenantID  STRING(63) NOT NULL DEFAULT (''),
+    UserID    STRING(36) NOT NULL,
+    Email     STRING(320) NOT NULL,
+    UpdatedAt TIMESTAMP NOT NULL,
+) PRIMARY KEY (TenantID, UserID);
+
+-- Uses of the quotas of modules/shared/quota, per fixed window; e.g.
+-- ("orders.per_tenant_per_day", <tenant>, <UTC day>).
+CREATE TABLE QuotaCounters (
+    Quota       STRING(100) NOT NULL,
+    Key         STRING(100) NOT NULL,
+    WindowStart TIMESTAMP NOT NULL,
+    Count       INT64 NOT NULL,
+    UpdatedAt   TIMESTAMP NOT NULL,
+) PRIMARY KEY (Quota, Key, WindowStart),
+  ROW DELETION POLICY (OLDER_THAN(WindowStart, INTERVAL 7 DAY));
+
+-- Checkpoints of projection rebuilds, which run per tenant.
+CREATE TABLE ProjectionCheckpoints (
+    TenantID   STRING(63) NOT NULL DEFAULT (''),
+    Projection STRING(100) NOT NULL,
+    Rebuilding BOOL NOT NULL,
+    Cursor     STRING(MAX) NOT NULL,
+    RebuiltAt  TIMESTAMP,
+    UpdatedAt  TIMESTAMP NOT NULL,
+) PRIMARY KEY (TenantID, Projection);
+
+CREATE TABLE Notifications (
+    NotificationID    STRING(36) NOT NULL,
+    TenantID          STRING(63) NOT NULL DEFAULT (''),
+    RecipientID       STRING(36) NOT NULL,
+    Channel           STRING(20) NOT NULL,
+    Template          STRING(100) NOT NULL,
+    Payload           JSON NOT NULL,
+    Address           STRING(320),
+    Status            STRING(20) NOT NULL,
+    LastError         STRING(MAX),
+    MessageID         STRING(200),
+    SuppressionReason STRING(200),
+    Attempts          INT64 NOT NULL DEFAULT (0),
+    NextAttemptAt     TIMESTAMP,
+    CreatedAt         TIMESTAMP NOT NULL,
+    SentAt            TIMESTAMP,
+) PRIMARY KEY (NotificationID);
+
+CREATE INDEX NotificationsByRecipientCreatedAt ON Notifications(TenantID, RecipientID, CreatedAt DESC);
+
+CREATE INDEX NotificationsByTenantStatus ON Notifications(TenantID, Status);
+
+CREATE INDEX NotificationsByStatusNextAttemptAt ON Notifications(Status, NextAttemptAt);
+
+CREATE TABLE NotificationPreferences (
+    TenantID      STRING(63) NOT NULL DEFAULT (''),
+    RecipientID   STRING(36) NOT NULL,
+    MutedChannels ARRAY<STRING(20)>,
+    UpdatedAt     TIMESTAMP NOT NULL,
+) PRIMARY KEY (TenantID, RecipientID);
+
+CREATE TABLE WebhookSubscriptions (
+    SubscriptionID STRING(36) NOT NULL,
+    TenantID       STRING(63) NOT NULL DEFAULT (''),
+    CallbackURL    STRING(2048) NOT NULL,
+    EventTypes     ARRAY<STRING(100)> NOT NULL,
+    Secret         STRING(100) NOT NULL,
+    CreatedAt      TIMESTAMP NOT NULL,
+) PRIMARY KEY (SubscriptionID);
+
+CREATE INDEX WebhookSubscriptionsByTenantCreatedAt ON WebhookSubscriptions(TenantID, CreatedAt);
+
+CREATE TABLE WebhookDeliveries (
+    SubscriptionID STRING(36) NOT NULL,
+    DeliveryID     STRING(36) NOT NULL,
+    TenantID       STRING(63) NOT NULL DEFAULT (''),
+    EventID        STRING(36) NOT NULL,
+    EventType      STRING(100) NOT NULL,
+    Payload        STRING(MAX) NOT NULL,
+    Status         STRING(20) NOT NULL,
+    Attempts       INT64 NOT NULL,
+    ResponseStatus INT64,
+    LastError      STRING(MAX),
+    NextAttemptAt  TIMESTAMP,
+    CreatedAt      TIMESTAMP NOT NULL,
+    DeliveredAt    TIMESTAMP,
+) PRIMARY KEY (SubscriptionID, DeliveryID),
+  INTERLEAVE IN PARENT WebhookSubscriptions ON DELETE CASCADE;
+
+CREATE INDEX WebhookDeliveriesBySubscriptionCreatedAt ON WebhookDeliveries(SubscriptionID, CreatedAt DESC);
+
+CREATE INDEX WebhookDeliveriesByTenantStatus ON WebhookDeliveries(TenantID, Status);
+
+CREATE INDEX WebhookDeliveriesByStatusNextAttemptAt ON WebhookDeliveries(Status, NextAttemptAt);
+
+-- API keys of machine clients; SecretHash is the hex SHA-256 of the secret.
+CREATE TABLE APIKeys (
+    KeyID      STRING(36) NOT NULL,
+    TenantID   STRING(63) NOT NULL,
+    Name       STRING(100) NOT NULL,
+    Scopes     ARRAY<STRING(100)> NOT NULL,
+    SecretHash STRING(64) NOT NULL,
+    ExpiresAt  TIMESTAMP,
+    RevokedAt  TIMESTAMP,
+    RotatedAt  TIMESTAMP,
+    CreatedAt  TIMESTAMP NOT NULL,
+) PRIMARY KEY (KeyID);
+
+CREATE INDEX APIKeysByTenantIDCreatedAt ON APIKeys(TenantID, CreatedAt DESC);
+
+CREATE TABLE ErpSyncs (
+    TenantID       STRING(63) NOT NULL DEFAULT (''),
+    OrderID        STRING(36) NOT NULL,
+    Status         STRING(20) NOT NULL,
+    DocumentNumber STRING(50),
+    FailureReason  STRING(MAX),
+    CreatedAt      TIMESTAMP NOT NULL,
+) PRIMARY KEY (TenantID, OrderID);
+
+CREATE TABLE StockItems (
+    TenantID  STRING(63) NOT NULL DEFAULT (''),
+    ProductID STRING(36) NOT NULL,
+    OnHand    INT64 NOT NULL,
+    Reserved  INT64 NOT NULL,
+    UpdatedAt TIMESTAMP NOT NULL,
+) PRIMARY KEY (TenantID, ProductID);
+
+CREATE TABLE StockReservations (
+    TenantID  STRING(63) NOT NULL DEFAULT (''),
+    OrderID   STRING(36) NOT NULL,
+    Status    STRING(20) NOT NULL,
+    CreatedAt TIMESTAMP NOT NULL,
+    UpdatedAt TIMESTAMP NOT NULL,
+) PRIMARY KEY (TenantID, OrderID);
+
+CREATE TABLE StockReservationLines (
+    TenantID  STRING(63) NOT NULL DEFAULT (''),
+    OrderID   STRING(36) NOT NULL,
+    ProductID STRING(36) NOT NULL,
+    Quantity  INT64 NOT NULL,
+) PRIMARY KEY (TenantID, OrderID, ProductID),
+  INTERLEAVE IN PARENT StockReservations ON DELETE CASCADE;
+
+CREATE TABLE PaymentIntents (
+    PaymentID     STRING(36) NOT NULL,
+    TenantID      STRING(63) NOT NULL DEFAULT (''),
+    OrderID       STRING(36) NOT NULL,
+    Amount        INT64 NOT NULL,
+    Currency      STRING(3) NOT NULL,
+    PaymentMethod STRING(100) NOT NULL,
+    Status        STRING(20) NOT NULL,
+    ProviderRef   STRING(100),
+    FailureReason STRING(MAX),
+    CreatedAt     TIMESTAMP NOT NULL,
+    UpdatedAt     TIMESTAMP NOT NULL,
+) PRIMARY KEY (PaymentID);
+
+CREATE INDEX PaymentIntentsByTenantOrderIDCreatedAt ON PaymentIntents(TenantID, OrderID, CreatedAt DESC);
+
+CREATE TABLE Promotions (
+    PromotionID  STRING(36) NOT NULL,
+    TenantID     STRING(63) NOT NULL DEFAULT (''),
+    Name         STRING(100) NOT NULL,
+    Currency     STRING(3) NOT NULL,
+    MinimumTotal INT64 NOT NULL,
+    ProductIDs   ARRAY<STRING(36)>,
+    StartsAt     TIMESTAMP,
+    EndsAt       TIMESTAMP,
+    RewardKind   STRING(20) NOT NULL,
+    RewardValue  INT64 NOT NULL,
+    Active       BOOL NOT NULL,
+    Redemptions  INT64 NOT NULL,
+    CreatedAt    TIMESTAMP NOT NULL,
+    UpdatedAt    TIMESTAMP NOT NULL,
+) PRIMARY KEY (PromotionID);
+
+CREATE INDEX PromotionsByActiveCurrency ON Promotions(TenantID, Active, Currency);
+
+CREATE TABLE Reviews (
+    ReviewID       STRING(36) NOT NULL,
+    TenantID       STRING(63) NOT NULL DEFAULT (''),
+    ProductID      STRING(36) NOT NULL,
+    UserID         STRING(36) NOT NULL,
+    Rating         INT64 NOT NULL,
+    Title          STRING(120) NOT NULL,
+    Body           STRING(MAX) NOT NULL,
+    Status         STRING(20) NOT NULL,
+    ModerationNote STRING(500),
+    CreatedAt      TIMESTAMP NOT NULL,
+    UpdatedAt      TIMESTAMP NOT NULL,
+) PRIMARY KEY (ReviewID);
+
+CREATE UNIQUE INDEX ReviewsByProductUser ON Reviews(TenantID, ProductID, UserID);
+
+CREATE INDEX ReviewsByProductStatusCreatedAt ON Reviews(TenantID, ProductID, Status, CreatedAt DESC);
+
+CREATE INDEX ReviewsByStatusCreatedAt ON Reviews(TenantID, Status, CreatedAt);
+
+CREATE TABLE ReviewablePurchases (
+    TenantID    STRING(63) NOT NULL DEFAULT (''),
+    UserID      STRING(36) NOT NULL,
+    ProductID   STRING(36) NOT NULL,
+    OrderID     STRING(36) NOT NULL,
+    CompletedAt TIMESTAMP NOT NULL,
+) PRIMARY KEY (TenantID, UserID, ProductID);
+
+CREATE TABLE ProductRatings (
+    TenantID    STRING(63) NOT NULL DEFAULT (''),
+    ProductID   STRING(36) NOT NULL,
+    ReviewCount INT64 NOT NULL,
+    RatingSum   INT64 NOT NULL,
+    UpdatedAt   TIMESTAMP NOT NULL,
+) PRIMARY KEY (TenantID, ProductID);
+
+CREATE TABLE AnalyticsDailyOrders (
+    TenantID  STRING(63) NOT NULL DEFAULT (''),
+    Day       STRING(10) NOT NULL,
+    Submitted INT64 NOT NULL,
+    Cancelled INT64 NOT NULL,
+    UpdatedAt TIMESTAMP NOT NULL,
+) PRIMARY KEY (TenantID, Day);
+
+CREATE TABLE AnalyticsDailyRevenue (
+    TenantID  STRING(63) NOT NULL DEFAULT (''),
+    Day       STRING(10) NOT NULL,
+    Currency  STRING(3) NOT NULL,
+    Captured  INT64 NOT NULL,
+    Refunded  INT64 NOT NULL,
+    UpdatedAt TIMESTAMP NOT NULL,
+) PRIMARY KEY (TenantID, Day, Currency);
+
+CREATE TABLE AnalyticsWeeklySignups (
+    TenantID  STRING(63) NOT NULL DEFAULT (''),
+    Week      STRING(8) NOT NULL,
+    Signups   INT64 NOT NULL,
+    UpdatedAt TIMESTAMP NOT NULL,
+) PRIMARY KEY (TenantID, Week);
+
+CREATE TABLE AnalyticsInbox (
+    EventID     STRING(36) NOT NULL,
+    OccurredAt  TIMESTAMP NOT NULL,
+    ProcessedAt TIMESTAMP NOT NULL,
+) PRIMARY KEY (EventID),
+  ROW DELETION POLICY (OLDER_THAN(ProcessedAt, INTERVAL 30 DAY));
+
+CREATE TABLE AuditLog (
+    EntryID       STRING(36) NOT NULL,
+    TenantID      STRING(63) NOT NULL DEFAULT (''),
+    Source        STRING(10) NOT NULL,
+    Module        STRING(50) NOT NULL,
+    Action        STRING(100) NOT NULL,
+    Actor         STRING(200) NOT NULL,
+    AggregateType STRING(50),
+    AggregateID   STRING(200),
+    Diff          JSON NOT NULL,
+    Error         STRING(MAX),
+    OccurredAt    TIMESTAMP NOT NULL,
+    RecordedAt    TIMESTAMP NOT NULL,
+) PRIMARY KEY (EntryID);
+
+CREATE INDEX AuditLogByOccurredAt ON AuditLog(TenantID, OccurredAt DESC);
+
+CREATE INDEX AuditLogByAggregateOccurredAt ON AuditLog(TenantID, AggregateType, AggregateID, OccurredAt DESC);
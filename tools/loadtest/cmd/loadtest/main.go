@@ -0,0 +1,92 @@
+// Command loadtest runs load scenarios against a running server and prints
+// a latency and throughput report for each.
+//
+//	loadtest -target http://localhost:8080 -scenario create-order -rate 100 -duration 30s -report reports/
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	vegeta "github.com/tsenart/vegeta/v12/lib"
+
+	"github.com/rai/clean-modularmonolith-go/tools/loadtest"
+)
+
+func main() {
+	target := flag.String("target", "http://localhost:8080", "base URL of the server under test")
+	scenarios := flag.String("scenario", "all", "comma-separated scenarios to run, or all")
+	rate := flag.Int("rate", 50, "requests per second")
+	duration := flag.Duration("duration", 30*time.Second, "duration of each scenario")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of each request")
+	users := flag.Int("users", 20, "users (each with one order) to seed before the scenarios")
+	reportDir := flag.String("report", "", "directory to write text, JSON and histogram reports to")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if err := run(ctx, *target, *scenarios, *rate, *duration, *timeout, *users, *reportDir); err != nil {
+		fmt.Fprintln(os.Stderr, "loadtest:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, target, names string, rate int, duration, timeout time.Duration, users int, reportDir string) error {
+	selected, err := selectScenarios(names)
+	if err != nil {
+		return err
+	}
+	target = strings.TrimSuffix(target, "/")
+
+	fixture, err := loadtest.Seed(ctx, &http.Client{Timeout: timeout}, target, users)
+	if err != nil {
+		return err
+	}
+
+	opts := loadtest.Options{Rate: vegeta.Rate{Freq: rate, Per: time.Second}, Duration: duration, Timeout: timeout}
+	for _, s := range selected {
+		fmt.Printf("== %s: %d req/s for %s\n", s.Name, rate, duration)
+		metrics, hist := loadtest.Run(ctx, s, target, fixture, opts)
+		if err := vegeta.NewTextReporter(metrics).Report(os.Stdout); err != nil {
+			return err
+		}
+		if reportDir != "" {
+			if err := loadtest.WriteReports(reportDir, s.Name, metrics, hist); err != nil {
+				return err
+			}
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func selectScenarios(names string) ([]loadtest.Scenario, error) {
+	if names == "all" {
+		keys := make([]string, 0, len(loadtest.Scenarios))
+		for name := range loadtest.Scenarios {
+			keys = append(keys, name)
+		}
+		slices.Sort(keys)
+		names = strings.Join(keys, ",")
+	}
+
+	var selected []loadtest.Scenario
+	for name := range strings.SplitSeq(names, ",") {
+		s, ok := loadtest.Scenarios[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown scenario %q", name)
+		}
+		selected = append(selected, s)
+	}
+	return selected, nil
+}
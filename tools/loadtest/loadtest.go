@@ -0,0 +1,212 @@
+// Package loadtest drives load scenarios against a running server with
+// vegeta and reports their latency and throughput. It is meant for
+// validating performance-motivated changes against the Spanner emulator:
+// run the same scenario before and after a change and compare the reports.
+//
+// A run seeds its own users (and one order per user) through the public API,
+// so it needs no fixtures and can be repeated against the same database.
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	vegeta "github.com/tsenart/vegeta/v12/lib"
+)
+
+// Scenario is a named load pattern against one endpoint.
+type Scenario struct {
+	Name string
+	// Targeter returns the requests of the scenario, spread over the
+	// fixture's users.
+	Targeter func(baseURL string, f Fixture) vegeta.Targeter
+}
+
+// Scenarios lists every scenario by name.
+var Scenarios = map[string]Scenario{
+	CreateOrder.Name: CreateOrder,
+	ListOrders.Name:  ListOrders,
+}
+
+// CreateOrder creates draft orders: POST /orders.
+var CreateOrder = Scenario{
+	Name: "create-order",
+	Targeter: func(baseURL string, f Fixture) vegeta.Targeter {
+		return roundRobin(f.UserIDs, func(userID string, t *vegeta.Target) {
+			t.Method = http.MethodPost
+			t.URL = baseURL + "/orders"
+			t.Body = []byte(`{"user_id":"` + userID + `","currency":"USD"}`)
+			t.Header = http.Header{"Content-Type": {"application/json"}}
+		})
+	},
+}
+
+// ListOrders lists the orders of a user: GET /users/{userId}/orders.
+var ListOrders = Scenario{
+	Name: "list-orders",
+	Targeter: func(baseURL string, f Fixture) vegeta.Targeter {
+		return roundRobin(f.UserIDs, func(userID string, t *vegeta.Target) {
+			t.Method = http.MethodGet
+			t.URL = baseURL + "/users/" + userID + "/orders?limit=20"
+		})
+	},
+}
+
+// roundRobin returns a targeter that fills each target for the next user.
+func roundRobin(userIDs []string, fill func(userID string, t *vegeta.Target)) vegeta.Targeter {
+	var next atomic.Uint64
+	return func(t *vegeta.Target) error {
+		if t == nil {
+			return vegeta.ErrNilTarget
+		}
+		if len(userIDs) == 0 {
+			return vegeta.ErrNoTargets
+		}
+		*t = vegeta.Target{}
+		fill(userIDs[(next.Add(1)-1)%uint64(len(userIDs))], t)
+		return nil
+	}
+}
+
+// Fixture is the data the scenarios run against.
+type Fixture struct {
+	UserIDs []string
+}
+
+// Seed creates users, each with one draft order, through the API at baseURL.
+// Emails are unique per run, so seeding can be repeated.
+func Seed(ctx context.Context, client *http.Client, baseURL string, users int) (Fixture, error) {
+	run := make([]byte, 4)
+	rand.Read(run)
+
+	var f Fixture
+	for i := range users {
+		var user struct {
+			ID string `json:"id"`
+		}
+		err := post(ctx, client, baseURL+"/users", map[string]string{
+			"email":      fmt.Sprintf("loadtest-%s-%d@example.com", hex.EncodeToString(run), i),
+			"first_name": "Load",
+			"last_name":  fmt.Sprintf("Test %d", i),
+		}, &user)
+		if err != nil {
+			return Fixture{}, fmt.Errorf("seeding user %d: %w", i, err)
+		}
+		if err := post(ctx, client, baseURL+"/orders", map[string]string{"user_id": user.ID, "currency": "USD"}, nil); err != nil {
+			return Fixture{}, fmt.Errorf("seeding order for user %s: %w", user.ID, err)
+		}
+		f.UserIDs = append(f.UserIDs, user.ID)
+	}
+	return f, nil
+}
+
+func post(ctx context.Context, client *http.Client, url string, body, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("POST %s = %d %s", url, resp.StatusCode, bytes.TrimSpace(respBody))
+	}
+	if out != nil {
+		return json.Unmarshal(respBody, out)
+	}
+	return nil
+}
+
+// Options control how hard a scenario is run.
+type Options struct {
+	Rate     vegeta.Rate
+	Duration time.Duration
+	Timeout  time.Duration // per request; zero uses vegeta's default
+}
+
+// Run attacks baseURL with the scenario until the duration elapses or ctx is
+// done, and returns the collected metrics and latency histogram.
+func Run(ctx context.Context, s Scenario, baseURL string, f Fixture, opts Options) (*vegeta.Metrics, *vegeta.Histogram) {
+	attackOpts := []func(*vegeta.Attacker){}
+	if opts.Timeout > 0 {
+		attackOpts = append(attackOpts, vegeta.Timeout(opts.Timeout))
+	}
+	attacker := vegeta.NewAttacker(attackOpts...)
+	stop := context.AfterFunc(ctx, func() { attacker.Stop() })
+	defer stop()
+
+	metrics := &vegeta.Metrics{}
+	hist := &vegeta.Histogram{Buckets: LatencyBuckets}
+	for res := range attacker.Attack(s.Targeter(baseURL, f), opts.Rate, opts.Duration, s.Name) {
+		metrics.Add(res)
+		hist.Add(res)
+	}
+	metrics.Close()
+	return metrics, hist
+}
+
+// LatencyBuckets are the histogram buckets of the reports.
+var LatencyBuckets = vegeta.Buckets{
+	0,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+}
+
+// WriteReports writes the text, JSON and histogram reports of a scenario run
+// to dir as <name>.txt, <name>.json and <name>.hist.txt.
+func WriteReports(dir, name string, metrics *vegeta.Metrics, hist *vegeta.Histogram) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	reports := map[string]vegeta.Reporter{
+		name + ".txt":      vegeta.NewTextReporter(metrics),
+		name + ".json":     vegeta.NewJSONReporter(metrics),
+		name + ".hist.txt": vegeta.NewHistogramReporter(hist),
+	}
+	for file, report := range reports {
+		if err := writeReport(filepath.Join(dir, file), report); err != nil {
+			return fmt.Errorf("writing %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+func writeReport(path string, report vegeta.Reporter) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := report.Report(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
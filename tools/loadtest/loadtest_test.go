@@ -0,0 +1,126 @@
+package loadtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	vegeta "github.com/tsenart/vegeta/v12/lib"
+)
+
+// fakeServer serves the endpoints the scenarios use and counts orders per user.
+func fakeServer(t *testing.T) (*httptest.Server, map[string]int) {
+	var mu sync.Mutex
+	orders := map[string]int{}
+	users := 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /users", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		users++
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"id": "user-" + strconv.Itoa(users)})
+	})
+	mux.HandleFunc("POST /orders", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			UserID string `json:"user_id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.UserID == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		orders[req.UserID]++
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"order"}`))
+	})
+	mux.HandleFunc("GET /users/{userId}/orders", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"orders":[],"total":0}`))
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, orders
+}
+
+func TestSeed(t *testing.T) {
+	srv, orders := fakeServer(t)
+
+	f, err := Seed(t.Context(), srv.Client(), srv.URL, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(f.UserIDs) != 3 {
+		t.Fatalf("seeded %d users, want 3", len(f.UserIDs))
+	}
+	for _, id := range f.UserIDs {
+		if orders[id] != 1 {
+			t.Errorf("user %s has %d orders, want 1", id, orders[id])
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	srv, _ := fakeServer(t)
+	f := Fixture{UserIDs: []string{"user-1", "user-2"}}
+	opts := Options{Rate: vegeta.Rate{Freq: 100, Per: time.Second}, Duration: 200 * time.Millisecond}
+
+	for name, s := range Scenarios {
+		t.Run(name, func(t *testing.T) {
+			metrics, hist := Run(t.Context(), s, srv.URL, f, opts)
+
+			if metrics.Requests == 0 {
+				t.Fatal("no requests sent")
+			}
+			if metrics.Success != 1 {
+				t.Errorf("success ratio = %v, errors = %v", metrics.Success, metrics.Errors)
+			}
+			var counted uint64
+			for _, n := range hist.Counts {
+				counted += n
+			}
+			if counted != metrics.Requests {
+				t.Errorf("histogram counted %d requests, want %d", counted, metrics.Requests)
+			}
+
+			dir := t.TempDir()
+			if err := WriteReports(dir, name, metrics, hist); err != nil {
+				t.Fatal(err)
+			}
+			for _, file := range []string{name + ".txt", name + ".json", name + ".hist.txt"} {
+				if info, err := os.Stat(filepath.Join(dir, file)); err != nil || info.Size() == 0 {
+					t.Errorf("report %s missing or empty: %v", file, err)
+				}
+			}
+		})
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	tr := roundRobin([]string{"a", "b"}, func(userID string, t *vegeta.Target) { t.URL = userID })
+
+	var got []string
+	for range 3 {
+		var target vegeta.Target
+		if err := tr(&target); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, target.URL)
+	}
+	if want := []string{"a", "b", "a"}; !slices.Equal(got, want) {
+		t.Errorf("targets = %v, want %v", got, want)
+	}
+	if err := roundRobin(nil, nil)(&vegeta.Target{}); err != vegeta.ErrNoTargets {
+		t.Errorf("empty fixture: err = %v, want ErrNoTargets", err)
+	}
+}
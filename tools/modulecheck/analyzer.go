@@ -0,0 +1,79 @@
+// Package modulecheck defines an analyzer that enforces the module
+// boundaries: code in one module may import another module only through its
+// public API — the module root (module.go) and its cross-module event
+// contracts (domain/events) — plus the shared kernel, which imports no
+// module. Layering within a module is left to depguard.
+package modulecheck
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "modulecheck",
+	Doc:  "checks that modules import other modules only through their root package or domain/events",
+	Run:  run,
+}
+
+const modulesPath = "github.com/rai/clean-modularmonolith-go/modules/"
+
+// sharedModule is the shared kernel, importable by every module.
+const sharedModule = "shared"
+
+// publicPackages are the packages of a module, relative to its root, that
+// other modules may import besides the root itself.
+var publicPackages = []string{"domain/events"}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	module, _, ok := splitModule(pass.Pkg.Path())
+	if !ok {
+		return nil, nil
+	}
+
+	for _, file := range pass.Files {
+		for _, spec := range file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if msg := check(module, path); msg != "" {
+				pass.Reportf(spec.Pos(), "%s", msg)
+			}
+		}
+	}
+	return nil, nil
+}
+
+// check returns why module may not import path, or "" if it may.
+func check(module, path string) string {
+	if imported, rest, ok := splitModule(path); ok {
+		if module == sharedModule && imported != sharedModule {
+			return "the shared kernel imports module " + imported + "; it must not depend on any module"
+		}
+		if imported == module || imported == sharedModule || rest == "" {
+			return ""
+		}
+		for _, public := range publicPackages {
+			if rest == public || strings.HasPrefix(rest, public+"/") {
+				return ""
+			}
+		}
+		return "module " + module + " imports " + imported + "/" + rest +
+			", an internal package of module " + imported + "; use its root package or domain/events"
+	}
+	return ""
+}
+
+// splitModule splits a package path under modules/ into the module name and
+// the package path relative to the module root.
+func splitModule(path string) (module, rest string, ok bool) {
+	rel, ok := strings.CutPrefix(path, modulesPath)
+	if !ok {
+		return "", "", false
+	}
+	module, rest, _ = strings.Cut(rel, "/")
+	return module, rest, true
+}
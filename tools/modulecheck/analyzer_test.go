@@ -0,0 +1,18 @@
+package modulecheck_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/rai/clean-modularmonolith-go/tools/modulecheck"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, modulecheck.Analyzer,
+		"github.com/rai/clean-modularmonolith-go/modules/orders/application",
+		"github.com/rai/clean-modularmonolith-go/modules/shared/kernel",
+		"github.com/rai/clean-modularmonolith-go/modules/users/domain",
+	)
+}
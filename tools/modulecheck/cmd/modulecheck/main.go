@@ -0,0 +1,11 @@
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/rai/clean-modularmonolith-go/tools/modulecheck"
+)
+
+func main() {
+	singlechecker.Main(modulecheck.Analyzer)
+}
@@ -0,0 +1,17 @@
+package application
+
+import (
+	"github.com/rai/clean-modularmonolith-go/modules/orders/domain"
+	sharedevents "github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/users"
+	usersdomain "github.com/rai/clean-modularmonolith-go/modules/users/domain" // want `module orders imports users/domain, an internal package of module users; use its root package or domain/events`
+	userevents "github.com/rai/clean-modularmonolith-go/modules/users/domain/events"
+)
+
+var (
+	_ domain.Order
+	_ sharedevents.Event
+	_ users.Module
+	_ usersdomain.User
+	_ userevents.UserDeletedEvent
+)
@@ -0,0 +1,11 @@
+package kernel
+
+import (
+	"github.com/rai/clean-modularmonolith-go/modules/shared/events"
+	"github.com/rai/clean-modularmonolith-go/modules/users" // want `the shared kernel imports module users; it must not depend on any module`
+)
+
+var (
+	_ events.Event
+	_ users.Module
+)
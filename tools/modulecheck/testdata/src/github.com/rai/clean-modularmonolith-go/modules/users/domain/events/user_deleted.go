@@ -0,0 +1,3 @@
+package events
+
+type UserDeletedEvent struct{}
@@ -0,0 +1,3 @@
+package users
+
+type Module interface{}
@@ -0,0 +1,155 @@
+// Package openapicheck defines an analyzer that keeps the OpenAPI
+// specification in step with the routes: in a module's HTTP package, every
+// route registered on the ServeMux must be documented by an
+// openapi.Operation with the same pattern, every Operation must match a
+// registered route, and an Operation is marked Admin exactly when its route
+// is guarded by a security.AdminGuard.
+package openapicheck
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var Analyzer = &analysis.Analyzer{
+	Name: "openapicheck",
+	Doc:  "checks that the routes of a module's HTTP package and its openapi.Operations agree",
+	Run:  run,
+}
+
+const (
+	modulesPath  = "github.com/rai/clean-modularmonolith-go/modules/"
+	openapiPath  = modulesPath + "shared/openapi"
+	securityPath = modulesPath + "shared/security"
+)
+
+type route struct {
+	pos   token.Pos
+	admin bool
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	if !strings.HasPrefix(pass.Pkg.Path(), modulesPath) || !strings.HasSuffix(pass.Pkg.Path(), "/infrastructure/http") {
+		return nil, nil
+	}
+
+	routes := map[string]route{}
+	documented := map[string]route{}
+	for _, file := range pass.Files {
+		if strings.HasSuffix(pass.Fset.File(file.Pos()).Name(), "_test.go") {
+			continue
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.CallExpr:
+				if pattern, ok := registeredPattern(pass, n); ok {
+					routes[pattern] = route{pos: n.Pos(), admin: isAdminGuarded(pass, n.Args[1])}
+				}
+			case *ast.CompositeLit:
+				if pattern, admin, pos, ok := operation(pass, n); ok {
+					documented[pattern] = route{pos: pos, admin: admin}
+				}
+			}
+			return true
+		})
+	}
+
+	for pattern, r := range routes {
+		op, ok := documented[pattern]
+		switch {
+		case !ok:
+			pass.Reportf(r.pos, "route %q is not documented by an openapi.Operation", pattern)
+		case r.admin && !op.admin:
+			pass.Reportf(op.pos, "operation %q must be marked Admin: its route requires the admin token", pattern)
+		case !r.admin && op.admin:
+			pass.Reportf(op.pos, "operation %q is marked Admin but its route does not require the admin token", pattern)
+		}
+	}
+	for pattern, op := range documented {
+		if _, ok := routes[pattern]; !ok {
+			pass.Reportf(op.pos, "operation %q documents no registered route", pattern)
+		}
+	}
+	return nil, nil
+}
+
+// registeredPattern returns the pattern of a (*http.ServeMux).HandleFunc or
+// Handle call.
+func registeredPattern(pass *analysis.Pass, call *ast.CallExpr) (string, bool) {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || (sel.Sel.Name != "HandleFunc" && sel.Sel.Name != "Handle") || len(call.Args) != 2 {
+		return "", false
+	}
+	if !isNamed(pass.TypesInfo.TypeOf(sel.X), "net/http", "ServeMux") {
+		return "", false
+	}
+	return stringConstant(pass, call.Args[0])
+}
+
+// isAdminGuarded reports whether a handler is wrapped in the Handler or
+// HandlerFunc method of a security.AdminGuard.
+func isAdminGuarded(pass *analysis.Pass, handler ast.Expr) bool {
+	call, ok := handler.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fun, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || (fun.Sel.Name != "Handler" && fun.Sel.Name != "HandlerFunc") {
+		return false
+	}
+	sel := pass.TypesInfo.Selections[fun]
+	return sel != nil && sel.Kind() == types.MethodVal && isNamed(sel.Recv(), securityPath, "AdminGuard")
+}
+
+// operation returns the Pattern and Admin fields of an openapi.Operation
+// literal.
+func operation(pass *analysis.Pass, lit *ast.CompositeLit) (pattern string, admin bool, pos token.Pos, ok bool) {
+	if !isNamed(pass.TypesInfo.TypeOf(lit), openapiPath, "Operation") {
+		return "", false, 0, false
+	}
+	for _, elt := range lit.Elts {
+		kv, isKV := elt.(*ast.KeyValueExpr)
+		if !isKV {
+			continue
+		}
+		key, isIdent := kv.Key.(*ast.Ident)
+		if !isIdent {
+			continue
+		}
+		switch key.Name {
+		case "Pattern":
+			pattern, ok = stringConstant(pass, kv.Value)
+			pos = kv.Value.Pos()
+		case "Admin":
+			if v := pass.TypesInfo.Types[kv.Value].Value; v != nil && v.Kind() == constant.Bool {
+				admin = constant.BoolVal(v)
+			}
+		}
+	}
+	return pattern, admin, pos, ok
+}
+
+func stringConstant(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	v := pass.TypesInfo.Types[expr].Value
+	if v == nil || v.Kind() != constant.String {
+		return "", false
+	}
+	return constant.StringVal(v), true
+}
+
+func isNamed(t types.Type, pkgPath, name string) bool {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Name() == name && obj.Pkg() != nil && obj.Pkg().Path() == pkgPath
+}
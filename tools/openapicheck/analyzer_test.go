@@ -0,0 +1,16 @@
+package openapicheck_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/rai/clean-modularmonolith-go/tools/openapicheck"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, openapicheck.Analyzer,
+		"github.com/rai/clean-modularmonolith-go/modules/orders/infrastructure/http",
+	)
+}
@@ -0,0 +1,35 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/rai/clean-modularmonolith-go/modules/shared/security"
+)
+
+type Handler struct {
+	admin security.AdminGuard
+}
+
+func RegisterRoutes(mux *http.ServeMux, admin security.AdminGuard) {
+	h := &Handler{admin: admin}
+	mux.HandleFunc("POST /orders", h.handleCreateOrder)
+	mux.HandleFunc("GET /orders/{id}", h.handleGetOrder)
+	mux.HandleFunc("GET /orders/{id}/history", h.handleGetOrder) // want `route "GET /orders/{id}/history" is not documented by an openapi.Operation`
+	mux.HandleFunc("POST /orders/{id}/refund", h.admin.HandlerFunc(h.handleGetOrder))
+	mux.HandleFunc("POST /orders/bulk-cancel", admin.HandlerFunc(h.handleGetOrder))
+	mux.Handle("GET /reports/orders", h.admin.Handler(http.HandlerFunc(h.handleGetOrder)))
+	mux.HandleFunc("POST /orders/{id}/submit", h.guarded(h.handleGetOrder))
+}
+
+func (h *Handler) handleCreateOrder(w http.ResponseWriter, r *http.Request) {}
+
+func (h *Handler) handleGetOrder(w http.ResponseWriter, r *http.Request) {}
+
+// guarded is not an AdminGuard, whatever it does.
+func (h *Handler) guarded(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if h.admin.RequireAdmin(r) {
+			next(w, r)
+		}
+	}
+}
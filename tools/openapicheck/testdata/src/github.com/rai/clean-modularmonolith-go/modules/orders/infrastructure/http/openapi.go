@@ -0,0 +1,15 @@
+package http
+
+import "github.com/rai/clean-modularmonolith-go/modules/shared/openapi"
+
+func Operations() []openapi.Operation {
+	return []openapi.Operation{
+		{Pattern: "POST /orders", Summary: "Create a draft order"},
+		{Pattern: "GET /orders/{id}", Summary: "Get an order", Admin: true}, // want `operation "GET /orders/{id}" is marked Admin but its route does not require the admin token`
+		{Pattern: "POST /orders/{id}/refund", Summary: "Refund an order"},   // want `operation "POST /orders/{id}/refund" must be marked Admin: its route requires the admin token`
+		{Pattern: "POST /orders/bulk-cancel", Summary: "Cancel several orders", Admin: true},
+		{Pattern: "GET /reports/orders", Summary: "Report order summaries", Admin: true},
+		{Pattern: "POST /orders/{id}/submit", Summary: "Submit an order", Admin: true}, // want `operation "POST /orders/{id}/submit" is marked Admin but its route does not require the admin token`
+		{Pattern: "DELETE /orders/{id}", Summary: "Delete an order"},                   // want `operation "DELETE /orders/{id}" documents no registered route`
+	}
+}
@@ -0,0 +1,7 @@
+package openapi
+
+type Operation struct {
+	Pattern string
+	Summary string
+	Admin   bool
+}
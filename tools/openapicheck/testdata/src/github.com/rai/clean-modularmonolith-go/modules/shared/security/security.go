@@ -0,0 +1,11 @@
+package security
+
+import "net/http"
+
+type AdminGuard struct{}
+
+func (g AdminGuard) Handler(next http.Handler) http.Handler { return next }
+
+func (g AdminGuard) HandlerFunc(next http.HandlerFunc) http.HandlerFunc { return next }
+
+func (g AdminGuard) RequireAdmin(r *http.Request) bool { return true }
@@ -0,0 +1,57 @@
+// Command protogen generates code from .proto files with protoc plugins,
+// without protoc:
+//
+//	protogen -I proto -out . -plugin bin/protoc-gen-go=module=example.com/m users/v1/users.proto
+//
+// Each -plugin is a plugin executable, optionally followed by "=" and its
+// parameter; the files are given relative to the -I directory.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rai/clean-modularmonolith-go/tools/protogen"
+)
+
+type plugins []protogen.Plugin
+
+func (p *plugins) String() string { return fmt.Sprint(*p) }
+
+func (p *plugins) Set(s string) error {
+	path, parameter, _ := strings.Cut(s, "=")
+	*p = append(*p, protogen.Plugin{Path: path, Parameter: parameter})
+	return nil
+}
+
+func main() {
+	importPath := flag.String("I", ".", "directory the .proto files and their imports are relative to")
+	outDir := flag.String("out", ".", "directory the generated files are written under")
+	var run plugins
+	flag.Var(&run, "plugin", "plugin executable, optionally followed by =parameter (repeatable)")
+	flag.Parse()
+
+	if err := generate(context.Background(), *importPath, *outDir, run, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, "protogen:", err)
+		os.Exit(1)
+	}
+}
+
+func generate(ctx context.Context, importPath, outDir string, run plugins, files []string) error {
+	if len(files) == 0 || len(run) == 0 {
+		return fmt.Errorf("usage: protogen -I dir -out dir -plugin path[=parameter]... file.proto...")
+	}
+	req, err := protogen.Request(ctx, []string{importPath}, files)
+	if err != nil {
+		return err
+	}
+	for _, plugin := range run {
+		if err := protogen.Run(ctx, plugin, req, outDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+module github.com/rai/clean-modularmonolith-go/tools/protogen
+
+go 1.26.1
+
+tool (
+	connectrpc.com/connect/cmd/protoc-gen-connect-go
+	google.golang.org/protobuf/cmd/protoc-gen-go
+)
+
+require (
+	github.com/bufbuild/protocompile v0.14.1
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	connectrpc.com/connect v1.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
+)
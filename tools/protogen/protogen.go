@@ -0,0 +1,110 @@
+// Package protogen runs protoc plugins without protoc: it compiles .proto
+// files with protocompile, hands them to each plugin as a
+// CodeGeneratorRequest, and writes the files the plugins return. It keeps
+// the RPC code generation (make proto) free of a protoc or buf install, with
+// the plugins pinned in this module's go.mod like the other tools.
+package protogen
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/bufbuild/protocompile"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// Plugin is a protoc plugin executable and the parameter passed to it, e.g.
+// "module=github.com/rai/clean-modularmonolith-go".
+type Plugin struct {
+	Path      string
+	Parameter string
+}
+
+// Request compiles files, given relative to one of importPaths, and returns
+// the CodeGeneratorRequest asking to generate them. The well-known types
+// (google/protobuf/*.proto) are always importable.
+func Request(ctx context.Context, importPaths, files []string) (*pluginpb.CodeGeneratorRequest, error) {
+	compiler := protocompile.Compiler{
+		Resolver:       protocompile.WithStandardImports(&protocompile.SourceResolver{ImportPaths: importPaths}),
+		SourceInfoMode: protocompile.SourceInfoStandard,
+	}
+	compiled, err := compiler.Compile(ctx, files...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Plugins expect every file after its dependencies
+	var protoFiles []*descriptorpb.FileDescriptorProto
+	seen := map[string]bool{}
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		imports := fd.Imports()
+		for i := range imports.Len() {
+			add(imports.Get(i).FileDescriptor)
+		}
+		protoFiles = append(protoFiles, protodesc.ToFileDescriptorProto(fd))
+	}
+	for _, fd := range compiled {
+		add(fd)
+	}
+
+	return &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: files,
+		ProtoFile:      protoFiles,
+	}, nil
+}
+
+// Run runs plugin on req and writes the files it generates under outDir.
+func Run(ctx context.Context, plugin Plugin, req *pluginpb.CodeGeneratorRequest, outDir string) error {
+	req = proto.CloneOf(req)
+	if plugin.Parameter != "" {
+		req.Parameter = proto.String(plugin.Parameter)
+	}
+	in, err := proto.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("encoding the request for %s: %w", plugin.Path, err)
+	}
+
+	var out, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, plugin.Path)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("running %s: %w: %s", plugin.Path, err, stderr.String())
+	}
+
+	var resp pluginpb.CodeGeneratorResponse
+	if err := proto.Unmarshal(out.Bytes(), &resp); err != nil {
+		return fmt.Errorf("decoding the response of %s: %w", plugin.Path, err)
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("%s: %s", plugin.Path, resp.GetError())
+	}
+
+	for _, f := range resp.File {
+		if f.GetInsertionPoint() != "" {
+			return fmt.Errorf("%s: insertion points are not supported (%s)", plugin.Path, f.GetName())
+		}
+		path := filepath.Join(outDir, filepath.FromSlash(f.GetName()))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(f.GetContent()), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
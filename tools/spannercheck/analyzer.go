@@ -36,6 +36,7 @@ var forbiddenMethods = map[methodKey]string{
 	{"Client", "ReadOnlyTransaction"}:       "use platformspanner.ConsistentRead",
 	{"Client", "ReadWriteTransaction"}:      "use platformspanner.Write",
 	{"ReadWriteTransaction", "BufferWrite"}: "use DML via platformspanner.Write",
+	{"Client", "BatchWrite"}:                "use platformspanner.BatchWrite",
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
@@ -11,10 +11,14 @@ type Key []interface{}
 type KeySet interface{}
 type RowIterator struct{}
 type Row struct{}
+type MutationGroup struct{ Mutations []*Mutation }
 
 func (c *Client) Apply(ctx context.Context, ms []*Mutation, opts ...interface{}) (interface{}, error) {
 	return nil, nil
 }
+func (c *Client) BatchWrite(ctx context.Context, mgs []*MutationGroup, opts ...interface{}) interface{} {
+	return nil
+}
 func (c *Client) Single() *ReadOnlyTransaction                    { return nil }
 func (c *Client) ReadOnlyTransaction() *ReadOnlyTransaction       { return nil }
 func (c *Client) ReadWriteTransaction(ctx context.Context, f func(context.Context, *ReadWriteTransaction) error) (interface{}, error) {
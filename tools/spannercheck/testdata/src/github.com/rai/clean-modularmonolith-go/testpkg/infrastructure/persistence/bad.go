@@ -16,6 +16,10 @@ func (r *Repo) badApply(ctx context.Context) {
 	r.client.Apply(ctx, nil) // want `direct call to \(\*spanner\.Client\)\.Apply in persistence package`
 }
 
+func (r *Repo) badBatchWrite(ctx context.Context) {
+	r.client.BatchWrite(ctx, nil) // want `direct call to \(\*spanner\.Client\)\.BatchWrite in persistence package`
+}
+
 func (r *Repo) badSingle(ctx context.Context) {
 	r.client.Single() // want `direct call to \(\*spanner\.Client\)\.Single in persistence package`
 }